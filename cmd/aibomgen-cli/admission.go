@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/internal/admission"
+	"github.com/idlab-discover/aibomgen-cli/internal/apperr"
+	"github.com/idlab-discover/aibomgen-cli/internal/oci"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/bomio"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/validator"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// runAdmission answers the AdmissionReview on stdin with the validation.
+// policy (validate --admission). Denials are answers, not command errors.
+func runAdmission(cmd *cobra.Command) error {
+	profile, err := loadCompletenessProfile(viper.GetString("validate.profile"))
+	if err != nil {
+		return err
+	}
+	opts := validator.ValidationOptions{
+		StrictMode:           viper.GetBool("validate.strict"),
+		MinCompletenessScore: viper.GetFloat64("validate.min-score"),
+		CheckModelCard:       viper.GetBool("validate.check-model-card"),
+		CheckDataConsent:     viper.GetBool("validate.check-consent"),
+		CheckAttribution:     viper.GetBool("validate.check-attribution"),
+		CheckSecurityContact: viper.GetBool("validate.check-security-contact"),
+		Profile:              profile,
+	}
+	timeout := viper.GetInt("validate.admission-timeout")
+	if timeout <= 0 {
+		timeout = 8
+	}
+
+	review, err := admission.Decode(cmd.InOrStdin())
+	if err != nil {
+		return apperr.User(err.Error())
+	}
+	var resp admission.Response
+	workload, err := admission.ParseWorkload(review.Request.Object)
+	if err != nil {
+		resp = admission.Response{Status: &admission.Status{Code: http.StatusBadRequest, Message: err.Error()}}
+	} else {
+		ctx, cancel := context.WithTimeout(cmd.Context(), time.Duration(timeout)*time.Second)
+		defer cancel()
+		resp = admission.Evaluate(ctx, workload, admission.Options{
+			LoadAIBOM:     admissionBOMLoader(viper.GetString("validate.admission-dir")),
+			FetchAttached: fetchAttachedBOM,
+			Validate: func(bom *cdx.BOM) validator.ValidationResult {
+				return validator.Validate(bom, opts)
+			},
+		})
+	}
+	if !resp.Allowed && resp.Status != nil {
+		runSummary.PolicyViolations = []string{resp.Status.Message}
+	}
+
+	enc := json.NewEncoder(cmd.OutOrStdout())
+	return enc.Encode(review.Reply(resp))
+}
+
+// admissionBOMLoader reads the AIBOMs named by aibomgen.io/aibom annotations.
+// from dir. Names must stay inside dir.
+func admissionBOMLoader(dir string) func(string) (*cdx.BOM, error) {
+	return func(name string) (*cdx.BOM, error) {
+		if strings.TrimSpace(dir) == "" {
+			return nil, errors.New("no AIBOM directory configured (validate.admission-dir)")
+		}
+		if !filepath.IsLocal(name) {
+			return nil, fmt.Errorf("%q is not a file name inside the AIBOM directory", name)
+		}
+		return bomio.ReadBOM(filepath.Join(dir, name), "auto")
+	}
+}
+
+// fetchAttachedBOM returns the AIBOM attached to image, or nil when none is.
+// Registry credentials come from the docker config file.
+func fetchAttachedBOM(ctx context.Context, image string) (*cdx.BOM, error) {
+	ref, err := oci.ParseReference(image)
+	if err != nil {
+		return nil, err
+	}
+	client := &oci.Client{Credentials: oci.DockerCredentials}
+	data, err := client.FetchAIBOM(ctx, ref)
+	if errors.Is(err, oci.ErrNoAIBOM) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	bom := new(cdx.BOM)
+	if err := cdx.NewBOMDecoder(bytes.NewReader(data), cdx.BOMFileFormatJSON).Decode(bom); err != nil {
+		return nil, fmt.Errorf("decode attached AIBOM: %w", err)
+	}
+	return bom, nil
+}
@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/internal/apperr"
+	"github.com/idlab-discover/aibomgen-cli/internal/oci"
+	"github.com/idlab-discover/aibomgen-cli/internal/ui"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/bomio"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// attachCmd represents the attach command.
+var attachCmd = &cobra.Command{
+	Use:   "attach [input] --image <ref>",
+	Short: "Attach an AIBOM to a container image in an OCI registry",
+	Long: `Attach an AIBOM to a container image, so runtime scanners can discover the
+AI BOM alongside the image SBOM.
+
+The default referrer mode pushes the AIBOM as an OCI artifact
+(artifactType application/vnd.cyclonedx+json) whose subject is the image
+manifest; the image itself is not changed. Tools such as 'oras discover' and
+'docker buildx imagetools' list it. On registries without the OCI referrers
+API the artifact is listed under the fallback tag sha256-<image digest hex>.
+
+The label mode stores the AIBOM in the io.aibomgen.aibom label of the image
+configuration (base64 of the gzip-compressed JSON, with its sha256 digest in
+io.aibomgen.aibom.digest) and moves the tag to the rewritten manifest. The
+image digest changes, so signatures of the old digest no longer apply. Only
+single-platform images can be relabelled.
+
+The AIBOM is attached as CycloneDX JSON, whatever the input format.
+Credentials come from --username/--password, else from the docker config file
+($DOCKER_CONFIG/config.json or ~/.docker/config.json).
+
+Example:
+  aibomgen-cli attach dist/aibom.json --image ghcr.io/org/app:1.0
+  aibomgen-cli attach dist/aibom.json --image ghcr.io/org/app:1.0 --mode label
+  aibomgen-cli attach dist/aibom.json --image localhost:5000/app:dev --plain-http`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runAttach,
+}
+
+func runAttach(cmd *cobra.Command, args []string) error {
+	logLevel := strings.ToLower(strings.TrimSpace(viper.GetString("attach.log-level")))
+	if logLevel == "" {
+		logLevel = "standard"
+	}
+	switch logLevel {
+	case "quiet", "standard", "debug":
+	default:
+		return apperr.Userf("invalid --log-level %q (expected quiet|standard|debug)", logLevel)
+	}
+
+	inputPath := viper.GetString("attach.input")
+	if len(args) == 1 {
+		inputPath = args[0]
+	}
+	if inputPath == "" {
+		return apperr.User("an input AIBOM is required (argument or --input)")
+	}
+	inputFormat := viper.GetString("attach.format")
+	if inputFormat == "" {
+		inputFormat = "auto"
+	}
+
+	image := strings.TrimSpace(viper.GetString("attach.image"))
+	if image == "" {
+		return apperr.User("an image reference is required (--image)")
+	}
+	ref, err := oci.ParseReference(image)
+	if err != nil {
+		return apperr.Userf("invalid --image: %v", err)
+	}
+	mode := strings.ToLower(strings.TrimSpace(viper.GetString("attach.mode")))
+	if mode == "" {
+		mode = "referrer"
+	}
+	if mode != "referrer" && mode != "label" {
+		return apperr.Userf("invalid --mode %q (expected referrer|label)", mode)
+	}
+	if mode == "label" && ref.Digest != "" {
+		return apperr.User("label mode moves a tag; reference the image by tag, not by digest")
+	}
+	timeout := viper.GetInt("attach.timeout")
+	if timeout <= 0 {
+		timeout = 60
+	}
+
+	bom, err := bomio.ReadBOM(inputPath, inputFormat)
+	if err != nil {
+		return fmt.Errorf("failed to read input BOM: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := bomio.EncodeBOM(&buf, bom, cdx.BOMFileFormatJSON, ""); err != nil {
+		return fmt.Errorf("failed to encode AIBOM: %w", err)
+	}
+
+	username := viper.GetString("attach.username")
+	password := viper.GetString("attach.password")
+	client := &oci.Client{
+		HTTP:      &http.Client{Timeout: time.Duration(timeout) * time.Second},
+		PlainHTTP: viper.GetBool("attach.plain-http"),
+		Credentials: func(registry string) oci.Credentials {
+			if username != "" {
+				return oci.Credentials{Username: username, Password: password}
+			}
+			return oci.DockerCredentials(registry)
+		},
+	}
+
+	w := cmd.OutOrStdout()
+	var workflow *ui.Workflow
+	if logLevel != "quiet" {
+		workflow = ui.NewWorkflow(w, "Attach AIBOM")
+		workflow.AddTask("Attaching to " + ref.String())
+		workflow.Start()
+		workflow.StartTask(0, mode)
+	}
+
+	var res oci.Result
+	if mode == "label" {
+		res, err = client.AttachLabel(cmd.Context(), ref, buf.Bytes())
+	} else {
+		name := strings.TrimSuffix(filepath.Base(inputPath), filepath.Ext(inputPath))
+		title := strings.TrimSuffix(name, ".spdx") + ".json"
+		res, err = client.AttachReferrer(cmd.Context(), ref, buf.Bytes(), title)
+	}
+	if err != nil {
+		if workflow != nil {
+			workflow.FailTask(0, err.Error())
+			workflow.Stop()
+		}
+		return fmt.Errorf("failed to attach AIBOM: %w", err)
+	}
+	if workflow != nil {
+		workflow.CompleteTask(0, res.Manifest.Digest)
+		workflow.Stop()
+	}
+
+	if logLevel == "quiet" {
+		return nil
+	}
+	image = ref.Registry + "/" + ref.Repository
+	var msg string
+	if mode == "label" {
+		msg = fmt.Sprintf("%s Labelled %s:%s → %s@%s (was %s)", ui.GetCheckMark(), image, ref.Tag, image, res.Manifest.Digest, res.Subject.Digest)
+	} else {
+		msg = fmt.Sprintf("%s Attached AIBOM %s@%s to %s@%s", ui.GetCheckMark(), image, res.Manifest.Digest, image, res.Subject.Digest)
+		if res.FallbackTag != "" {
+			msg += fmt.Sprintf("\nThe registry has no referrers API; listed under tag %s", res.FallbackTag)
+		}
+	}
+	fmt.Fprintf(w, "\n%s\n", ui.SuccessBox.Render(msg))
+	return nil
+}
+
+// ── Flag vars ─────────────────────────────────────────────────────────────────.
+
+var (
+	attachInput       string
+	attachInputFormat string
+	attachImage       string
+	attachMode        string
+	attachUsername    string
+	attachPassword    string
+	attachPlainHTTP   bool
+	attachTimeout     int
+	attachLogLevel    string
+)
+
+func init() {
+	attachCmd.Flags().StringVarP(&attachInput, "input", "i", "", "Path to the AIBOM to attach (or pass it as argument)")
+	attachCmd.Flags().StringVarP(&attachInputFormat, "format", "f", "", "Input BOM format: json|xml|spdx-json|auto")
+	attachCmd.Flags().StringVar(&attachImage, "image", "", "Image reference to attach the AIBOM to (required)")
+	attachCmd.Flags().StringVar(&attachMode, "mode", "referrer", "How to attach: referrer (OCI artifact) or label (image config label)")
+	attachCmd.Flags().StringVar(&attachUsername, "username", "", "Registry username (default: from the docker config file)")
+	attachCmd.Flags().StringVar(&attachPassword, "password", "", "Registry password or token")
+	attachCmd.Flags().BoolVar(&attachPlainHTTP, "plain-http", false, "Talk to the registry over plain HTTP")
+	attachCmd.Flags().IntVar(&attachTimeout, "timeout", 60, "Registry request timeout in seconds")
+	attachCmd.Flags().StringVar(&attachLogLevel, "log-level", "", "Log level: quiet|standard|debug")
+
+	// Bind to viper.
+	viper.BindPFlag("attach.input", attachCmd.Flags().Lookup("input"))
+	viper.BindPFlag("attach.format", attachCmd.Flags().Lookup("format"))
+	viper.BindPFlag("attach.image", attachCmd.Flags().Lookup("image"))
+	viper.BindPFlag("attach.mode", attachCmd.Flags().Lookup("mode"))
+	viper.BindPFlag("attach.username", attachCmd.Flags().Lookup("username"))
+	viper.BindPFlag("attach.password", attachCmd.Flags().Lookup("password"))
+	viper.BindPFlag("attach.plain-http", attachCmd.Flags().Lookup("plain-http"))
+	viper.BindPFlag("attach.timeout", attachCmd.Flags().Lookup("timeout"))
+	viper.BindPFlag("attach.log-level", attachCmd.Flags().Lookup("log-level"))
+}
@@ -0,0 +1,293 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/internal/advisories"
+	"github.com/idlab-discover/aibomgen-cli/internal/apperr"
+	"github.com/idlab-discover/aibomgen-cli/internal/ui"
+	"github.com/idlab-discover/aibomgen-cli/internal/vulnaudit"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/bomio"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// auditCmd represents the audit command.
+var auditCmd = &cobra.Command{
+	Use:   "audit <bom>",
+	Short: "Look up known vulnerabilities of the models and libraries in an AIBOM",
+	Long: `Query vulnerability sources for every component of an existing AIBOM and
+report what was found:
+
+  hf    the Hugging Face security scanners, for model and dataset components
+  osv   the OSV database (osv.dev), for library components with a versioned
+        package URL (pkg:pypi, pkg:npm, pkg:golang, ...)
+  feed  a signed advisories feed of model CVEs, takedowns and weight-poisoning
+        reports (see check-advisories); queried when --feed-url is set
+
+With --output the AIBOM is written with the findings attached as CycloneDX
+vulnerabilities. Vulnerabilities already in the BOM with the same bom-ref are
+replaced, so re-auditing a BOM does not duplicate them.
+
+--fail-on sets the exit-code policy: the command fails when a finding has the
+given severity or higher (low, medium, high, critical), or any finding at all
+(any). Findings without a rating only fail "any". When a policy is set, failed
+lookups fail the command too, as the BOM could not be fully audited.
+
+Example:
+  aibomgen-cli audit dist/aibom.json
+  aibomgen-cli audit dist/aibom.json --fail-on high
+  aibomgen-cli audit dist/aibom.json -o dist/aibom.audited.json
+  aibomgen-cli audit dist/aibom.json --feed-url ./advisories.json --public-key <base64>`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAudit,
+}
+
+func runAudit(cmd *cobra.Command, args []string) error {
+	inputPath := args[0]
+	inputFormat := viper.GetString("audit.format")
+	if inputFormat == "" {
+		inputFormat = "auto"
+	}
+
+	logLevel := strings.ToLower(strings.TrimSpace(viper.GetString("audit.log-level")))
+	if logLevel == "" {
+		logLevel = "standard"
+	}
+	switch logLevel {
+	case "quiet", "standard", "debug":
+	default:
+		return apperr.Userf("invalid --log-level %q (expected quiet|standard|debug)", logLevel)
+	}
+
+	failOn, err := vulnaudit.ParseFailOn(viper.GetString("audit.fail-on"))
+	if err != nil {
+		return apperr.User(err.Error())
+	}
+
+	var sources []string
+	for _, s := range viper.GetStringSlice("audit.sources") {
+		s = strings.ToLower(strings.TrimSpace(s))
+		if s == "" {
+			continue
+		}
+		if !slices.Contains(vulnaudit.Sources, s) {
+			return apperr.Userf("invalid source %q (expected %s)", s, strings.Join(vulnaudit.Sources, "|"))
+		}
+		sources = append(sources, s)
+	}
+
+	feedURL := strings.TrimSpace(viper.GetString("audit.feed-url"))
+	if slices.Contains(sources, vulnaudit.SourceFeed) && feedURL == "" {
+		return apperr.User("the feed source needs --feed-url")
+	}
+	publicKey := viper.GetString("audit.public-key")
+	skipVerify := viper.GetBool("audit.skip-verify")
+	if feedURL != "" && publicKey == "" && !skipVerify {
+		return apperr.User("a public key is required to verify the advisories feed (--public-key, or --skip-verify for unsigned local feeds)")
+	}
+	timeout := viper.GetInt("audit.timeout")
+	if timeout <= 0 {
+		timeout = 15
+	}
+
+	bom, err := bomio.ReadBOM(inputPath, inputFormat)
+	if err != nil {
+		return fmt.Errorf("failed to read input BOM: %w", err)
+	}
+
+	w := cmd.OutOrStdout()
+
+	var workflow *ui.Workflow
+	if logLevel != "quiet" {
+		workflow = ui.NewWorkflow(w, "Vulnerability Audit")
+		workflow.AddTask("Loading advisories feed")
+		workflow.AddTask("Querying vulnerability sources")
+		workflow.Start()
+		workflow.StartTask(0, "")
+	}
+
+	var feed *advisories.Feed
+	if feedURL != "" && (len(sources) == 0 || slices.Contains(sources, vulnaudit.SourceFeed)) {
+		feed, err = advisories.Load(advisories.Options{
+			Source:     feedURL,
+			PublicKey:  publicKey,
+			SkipVerify: skipVerify,
+			Timeout:    time.Duration(timeout) * time.Second,
+		})
+		if err != nil {
+			if workflow != nil {
+				workflow.FailTask(0, err.Error())
+				workflow.Stop()
+			}
+			return fmt.Errorf("failed to load advisories feed: %w", err)
+		}
+	}
+
+	if workflow != nil {
+		if feed != nil {
+			workflow.CompleteTask(0, fmt.Sprintf("%d advisories", len(feed.Advisories)))
+		} else {
+			workflow.SkipTask(0, "no feed")
+		}
+		workflow.StartTask(1, "")
+	}
+
+	report := vulnaudit.Run(bom, vulnaudit.Options{
+		Sources:   sources,
+		Feed:      feed,
+		FeedURL:   feedURL,
+		HFToken:   viper.GetString("audit.hf-token"),
+		HFBaseURL: viper.GetString("audit.hf-base-url"),
+		OSVURL:    viper.GetString("audit.osv-url"),
+		Timeout:   time.Duration(timeout) * time.Second,
+		Context:   cmd.Context(),
+	})
+
+	if workflow != nil {
+		workflow.CompleteTask(1, fmt.Sprintf("%d finding(s)", len(report.Findings)))
+		workflow.Stop()
+	}
+
+	if logLevel != "quiet" {
+		printAuditReport(w, report, logLevel == "debug")
+	}
+
+	if outPath := strings.TrimSpace(viper.GetString("audit.output")); outPath != "" {
+		n := vulnaudit.Apply(bom, report)
+		outputFormat := viper.GetString("audit.output-format")
+		if outputFormat == "" {
+			outputFormat = "auto"
+		}
+		if err := bomio.WriteBOM(bom, outPath, outputFormat, strings.TrimSpace(viper.GetString("audit.spec"))); err != nil {
+			return fmt.Errorf("failed to write audited BOM: %w", err)
+		}
+		if logLevel != "quiet" {
+			fmt.Fprintf(w, "\n%s\n", ui.SuccessBox.Render(fmt.Sprintf("%s Wrote %d vulnerabilities → %s", ui.GetCheckMark(), n, outPath)))
+		}
+	}
+
+	if failOn == vulnaudit.FailOnNone {
+		return nil
+	}
+	if v := report.Violations(failOn); len(v) > 0 {
+		return apperr.Userf("%d finding(s) at or above the %q policy", len(v), failOn)
+	}
+	if len(report.Errors) > 0 {
+		return apperr.Userf("%d vulnerability lookup(s) failed; the AIBOM was not fully audited", len(report.Errors))
+	}
+	return nil
+}
+
+// printAuditReport writes the findings of an audit, most severe first, and.
+// the failed lookups to w.
+func printAuditReport(w io.Writer, report vulnaudit.Report, debug bool) {
+	fmt.Fprintln(w)
+
+	for _, err := range report.Errors {
+		fmt.Fprintf(w, "%s  %s\n", ui.Warning.Render("⚠"), ui.Muted.Render(err.Error()))
+	}
+	if len(report.Errors) > 0 {
+		fmt.Fprintln(w)
+	}
+
+	if len(report.Findings) == 0 {
+		fmt.Fprintf(w, "%s\n", ui.SuccessBox.Render(ui.GetCheckMark()+" No known vulnerabilities found."))
+		return
+	}
+
+	findings := slices.Clone(report.Findings)
+	vulnaudit.SortFindings(findings)
+	for _, f := range findings {
+		v := f.Vulnerability
+		sev := string(vulnaudit.Severity(v))
+		id := v.ID
+		if id == "" {
+			id = v.BOMRef
+		}
+		fmt.Fprintf(w, "%s  %s  %s  %s\n",
+			renderVulnSeverity(sev, fmt.Sprintf("[%s]", strings.ToUpper(sev))),
+			ui.Primary.Render(id),
+			ui.Bold.Render(f.ComponentID),
+			ui.Muted.Render("("+f.Source+")"))
+		if v.Description != "" {
+			fmt.Fprintf(w, "    %s\n", ui.Dim.Render(v.Description))
+		}
+		if debug {
+			if v.Source != nil && v.Source.URL != "" {
+				fmt.Fprintf(w, "    %s\n", ui.Muted.Render(v.Source.URL))
+			}
+			if v.References != nil {
+				for _, r := range *v.References {
+					fmt.Fprintf(w, "      • %s\n", ui.Muted.Render(r.ID))
+				}
+			}
+		}
+	}
+
+	counts := report.Counts()
+	var parts []string
+	for _, sev := range []cdx.Severity{cdx.SeverityCritical, cdx.SeverityHigh, cdx.SeverityMedium, cdx.SeverityLow, cdx.SeverityInfo, cdx.SeverityUnknown} {
+		if counts[sev] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[sev], sev))
+		}
+	}
+	fmt.Fprintf(w, "\n%s\n", ui.ErrorBox.Render(fmt.Sprintf("%s %d finding(s): %s", ui.GetCrossMark(), len(findings), strings.Join(parts, ", "))))
+}
+
+// ── Flag vars ─────────────────────────────────────────────────────────────────.
+
+var (
+	auditInputFormat  string
+	auditOutput       string
+	auditOutputFormat string
+	auditSpecVersion  string
+	auditSources      []string
+	auditFailOn       string
+	auditFeedURL      string
+	auditPublicKey    string
+	auditSkipVerify   bool
+	auditOSVURL       string
+	auditHFToken      string
+	auditHFBaseURL    string
+	auditTimeout      int
+	auditLogLevel     string
+)
+
+func init() {
+	auditCmd.Flags().StringVarP(&auditInputFormat, "format", "f", "", "Input BOM format: json|xml|spdx-json|auto")
+	auditCmd.Flags().StringVarP(&auditOutput, "output", "o", "", "Write the AIBOM with the findings attached as vulnerabilities to this path")
+	auditCmd.Flags().StringVar(&auditOutputFormat, "output-format", "", "Output BOM format: json|xml|spdx-json|auto")
+	auditCmd.Flags().StringVar(&auditSpecVersion, "spec", "", "CycloneDX spec version for output")
+	auditCmd.Flags().StringSliceVar(&auditSources, "sources", nil, "Vulnerability sources to query: hf,osv,feed (default: hf,osv, plus feed with --feed-url)")
+	auditCmd.Flags().StringVar(&auditFailOn, "fail-on", "none", "Exit with an error on findings of this severity or higher: none|any|low|medium|high|critical")
+	auditCmd.Flags().StringVar(&auditFeedURL, "feed-url", "", "Advisories feed URL or local path")
+	auditCmd.Flags().StringVar(&auditPublicKey, "public-key", "", "Base64 Ed25519 public key used to verify the feed signature")
+	auditCmd.Flags().BoolVar(&auditSkipVerify, "skip-verify", false, "Skip feed signature verification (testing only)")
+	auditCmd.Flags().StringVar(&auditOSVURL, "osv-url", "", "OSV API base URL override")
+	auditCmd.Flags().StringVar(&auditHFToken, "hf-token", "", "Hugging Face API token")
+	auditCmd.Flags().StringVar(&auditHFBaseURL, "hf-base-url", "", "Hugging Face base URL override")
+	auditCmd.Flags().IntVar(&auditTimeout, "timeout", 15, "Timeout per lookup in seconds")
+	auditCmd.Flags().StringVar(&auditLogLevel, "log-level", "", "Log level: quiet|standard|debug")
+
+	// Bind to viper.
+	viper.BindPFlag("audit.format", auditCmd.Flags().Lookup("format"))
+	viper.BindPFlag("audit.output", auditCmd.Flags().Lookup("output"))
+	viper.BindPFlag("audit.output-format", auditCmd.Flags().Lookup("output-format"))
+	viper.BindPFlag("audit.spec", auditCmd.Flags().Lookup("spec"))
+	viper.BindPFlag("audit.sources", auditCmd.Flags().Lookup("sources"))
+	viper.BindPFlag("audit.fail-on", auditCmd.Flags().Lookup("fail-on"))
+	viper.BindPFlag("audit.feed-url", auditCmd.Flags().Lookup("feed-url"))
+	viper.BindPFlag("audit.public-key", auditCmd.Flags().Lookup("public-key"))
+	viper.BindPFlag("audit.skip-verify", auditCmd.Flags().Lookup("skip-verify"))
+	viper.BindPFlag("audit.osv-url", auditCmd.Flags().Lookup("osv-url"))
+	viper.BindPFlag("audit.hf-token", auditCmd.Flags().Lookup("hf-token"))
+	viper.BindPFlag("audit.hf-base-url", auditCmd.Flags().Lookup("hf-base-url"))
+	viper.BindPFlag("audit.timeout", auditCmd.Flags().Lookup("timeout"))
+	viper.BindPFlag("audit.log-level", auditCmd.Flags().Lookup("log-level"))
+}
@@ -0,0 +1,280 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/idlab-discover/aibomgen-cli/internal/apperr"
+	"github.com/idlab-discover/aibomgen-cli/internal/hfcache"
+	"github.com/idlab-discover/aibomgen-cli/internal/ui"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/bomio"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/generator"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// auditCacheCmd represents the audit-cache command.
+var auditCacheCmd = &cobra.Command{
+	Use:   "audit-cache",
+	Short: "List the models and datasets in the local Hugging Face cache",
+	Long: `Walk the Hugging Face hub cache and list every cached model, dataset and
+space revision with its size, the refs pointing to it and when it was last
+read: what models have ever run on this machine?
+
+The cache directory is found like huggingface_hub does: $HF_HUB_CACHE, else
+$HF_HOME/hub, else ~/.cache/huggingface/hub. Last-read times are file access
+times, which file systems mounted with noatime do not update.
+
+With --bom-dir an AIBOM is generated offline for every cached model, from the
+revision its "main" ref points to (see generate --local-path).
+
+With --cleanup-plan the command prints what could be deleted instead: detached
+revisions (no ref points to them any more) and, with --unused-for, revisions
+not read for that long. Blobs shared with a kept revision stay. Nothing is
+deleted; remove the listed paths, or use 'huggingface-cli delete-cache'.
+
+Example:
+  aibomgen-cli audit-cache
+  aibomgen-cli audit-cache --output json
+  aibomgen-cli audit-cache --bom-dir dist/cache
+  aibomgen-cli audit-cache --cleanup-plan --unused-for 90d`,
+	Args: cobra.NoArgs,
+	RunE: runAuditCache,
+}
+
+func runAuditCache(cmd *cobra.Command, _ []string) error {
+	output := strings.ToLower(strings.TrimSpace(viper.GetString("audit-cache.output")))
+	if output == "" {
+		output = "text"
+	}
+	if output != "text" && output != "json" {
+		return apperr.Userf("invalid --output %q (expected text|json)", output)
+	}
+	unusedFor, err := parseAge(viper.GetString("audit-cache.unused-for"))
+	if err != nil {
+		return apperr.Userf("invalid --unused-for: %v", err)
+	}
+	cleanup := viper.GetBool("audit-cache.cleanup-plan")
+	if unusedFor > 0 && !cleanup {
+		return apperr.User("--unused-for requires --cleanup-plan")
+	}
+	bomDir := strings.TrimSpace(viper.GetString("audit-cache.bom-dir"))
+	if bomDir != "" && cleanup {
+		return apperr.User("--bom-dir cannot be used with --cleanup-plan")
+	}
+
+	dir := strings.TrimSpace(viper.GetString("audit-cache.cache-dir"))
+	if dir == "" {
+		if dir, err = hfcache.DefaultDir(); err != nil {
+			return apperr.Userf("cannot determine the Hugging Face cache directory: %v (use --cache-dir)", err)
+		}
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return apperr.Userf("Hugging Face cache directory %s not found (use --cache-dir)", dir)
+	}
+
+	repos, err := hfcache.Scan(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read the Hugging Face cache: %w", err)
+	}
+
+	w := cmd.OutOrStdout()
+	if cleanup {
+		plan := hfcache.CleanupPlan(repos, hfcache.PlanOptions{UnusedFor: unusedFor})
+		if output == "json" {
+			enc := json.NewEncoder(w)
+			enc.SetIndent("", "  ")
+			return enc.Encode(plan)
+		}
+		printCleanupPlan(w, plan)
+		return nil
+	}
+
+	if bomDir != "" {
+		if err := writeCacheBOMs(w, repos, bomDir, output == "text"); err != nil {
+			return err
+		}
+	}
+	if output == "json" {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(struct {
+			Dir   string         `json:"dir"`
+			Repos []hfcache.Repo `json:"repos"`
+		}{dir, repos})
+	}
+	printCacheRepos(w, dir, repos)
+	return nil
+}
+
+// writeCacheBOMs generates an AIBOM for the latest revision of every cached.
+// model and writes them to dir.
+func writeCacheBOMs(w io.Writer, repos []hfcache.Repo, dir string, verbose bool) error {
+	format := strings.ToLower(strings.TrimSpace(viper.GetString("audit-cache.format")))
+	fileExt := ".json"
+	switch format {
+	case "", "json":
+		format = "json"
+	case "xml":
+		fileExt = ".xml"
+	case bomio.FormatSPDXJSON:
+		fileExt = ".spdx.json"
+	default:
+		return apperr.Userf("invalid --format %q (expected json|xml|spdx-json)", format)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	var boms []generator.DiscoveredBOM
+	for _, repo := range repos {
+		if repo.Type != hfcache.TypeModel {
+			continue
+		}
+		rev, ok := repo.Latest()
+		if !ok {
+			continue
+		}
+		built, err := generator.BuildFromLocalPath(rev.Path, repo.ID, generator.GenerateOptions{})
+		if err != nil {
+			fmt.Fprintln(os.Stderr, ui.GetWarnMark()+" "+ui.Warning.Render(fmt.Sprintf("%s: %v", repo.ID, err)))
+			continue
+		}
+		boms = append(boms, built...)
+	}
+
+	written, err := bomio.WriteOutputFiles(boms, filepath.Clean(dir), fileExt, format, viper.GetString("audit-cache.spec"), bomio.ChainOptions{})
+	if err != nil {
+		return err
+	}
+	if verbose {
+		fmt.Fprintln(w, ui.SuccessBox.Render(fmt.Sprintf("%s Wrote %d AIBOM(s) to %s", ui.GetCheckMark(), len(written), dir)))
+	}
+	return nil
+}
+
+// printCacheRepos writes the cache listing to w.
+func printCacheRepos(w io.Writer, dir string, repos []hfcache.Repo) {
+	var total int64
+	for _, r := range repos {
+		total += r.Size
+	}
+	fmt.Fprintf(w, "%s %s\n\n", ui.Primary.Render("Hugging Face cache"), ui.Muted.Render("("+dir+")"))
+	if len(repos) == 0 {
+		fmt.Fprintln(w, ui.Muted.Render("The cache is empty."))
+		return
+	}
+	for _, r := range repos {
+		fmt.Fprintf(w, "%s %s  %s\n", ui.Bold.Render(r.ID), ui.Muted.Render("("+r.Type+")"),
+			ui.Muted.Render(fmt.Sprintf("%s · last used %s", formatBytes(r.Size), formatCacheTime(r.LastAccessed))))
+		for _, rev := range r.Revisions {
+			refs := "detached"
+			if !rev.Detached() {
+				refs = strings.Join(rev.Refs, ", ")
+			}
+			fmt.Fprintf(w, "    %s  %-12s %d file(s), %s · last used %s\n",
+				shortCommit(rev.Commit), refs, rev.Files, formatBytes(rev.Size), formatCacheTime(rev.LastAccessed))
+		}
+	}
+	fmt.Fprintf(w, "\n%s %d repo(s), %s\n", ui.Bold.Render("Total:"), len(repos), formatBytes(total))
+}
+
+// printCleanupPlan writes the cleanup plan to w.
+func printCleanupPlan(w io.Writer, plan hfcache.Plan) {
+	if len(plan.Items) == 0 {
+		fmt.Fprintln(w, ui.SuccessBox.Render(ui.GetCheckMark()+" Nothing to clean up"))
+		return
+	}
+	fmt.Fprintln(w, ui.SectionHeader.Render("Cleanup plan"))
+	for _, item := range plan.Items {
+		what := item.ID + " (" + item.Type + ")"
+		if item.Commit != "" {
+			what += " revision " + shortCommit(item.Commit)
+		}
+		fmt.Fprintf(w, "  %s  %s %s\n", ui.Bold.Render(what), formatBytes(item.Size), ui.Muted.Render("· "+item.Reason))
+		for _, p := range item.Paths {
+			fmt.Fprintf(w, "      %s\n", ui.Muted.Render(p))
+		}
+	}
+	fmt.Fprintf(w, "\n%s %s in %d item(s). Nothing was deleted.\n", ui.Bold.Render("Reclaimable:"), formatBytes(plan.Size), len(plan.Items))
+}
+
+// shortCommit returns the first 8 characters of a commit hash.
+func shortCommit(c string) string {
+	if len(c) > 8 {
+		return c[:8]
+	}
+	return c
+}
+
+func formatCacheTime(t time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+	return ui.Locale().DateTime(t.Local())
+}
+
+// formatBytes renders n with a binary unit (KiB, MiB, ...) for the report.
+// locale.
+func formatBytes(n int64) string {
+	return ui.Locale().Bytes(n)
+}
+
+// parseAge parses a duration that may also be given in days (90d) or weeks.
+// (2w). An empty string is zero.
+func parseAge(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if n, ok := strings.CutSuffix(s, suffix); ok {
+			v, err := strconv.Atoi(n)
+			if err != nil || v < 0 {
+				return 0, fmt.Errorf("%q is not a duration", s)
+			}
+			return time.Duration(v) * unit, nil
+		}
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("%q is not a duration", s)
+	}
+	return d, nil
+}
+
+// ── Flag vars ─────────────────────────────────────────────────────────────────.
+
+var (
+	auditCacheDir       string
+	auditCacheOutput    string
+	auditCacheBOMDir    string
+	auditCacheFormat    string
+	auditCacheSpec      string
+	auditCacheCleanup   bool
+	auditCacheUnusedFor string
+)
+
+func init() {
+	auditCacheCmd.Flags().StringVar(&auditCacheDir, "cache-dir", "", "Hugging Face hub cache directory (default: $HF_HUB_CACHE, $HF_HOME/hub or ~/.cache/huggingface/hub)")
+	auditCacheCmd.Flags().StringVar(&auditCacheOutput, "output", "text", "Report format: text|json")
+	auditCacheCmd.Flags().StringVar(&auditCacheBOMDir, "bom-dir", "", "Also write an AIBOM for every cached model to this directory")
+	auditCacheCmd.Flags().StringVarP(&auditCacheFormat, "format", "f", "", "Output BOM format for --bom-dir: json|xml|spdx-json")
+	auditCacheCmd.Flags().StringVar(&auditCacheSpec, "spec", "", "CycloneDX spec version for --bom-dir (e.g., 1.4, 1.5, 1.6)")
+	auditCacheCmd.Flags().BoolVar(&auditCacheCleanup, "cleanup-plan", false, "Print the revisions that can be deleted instead of the listing")
+	auditCacheCmd.Flags().StringVar(&auditCacheUnusedFor, "unused-for", "", "With --cleanup-plan, also plan revisions not read for this long (e.g. 90d, 2w, 720h)")
+
+	viper.BindPFlag("audit-cache.cache-dir", auditCacheCmd.Flags().Lookup("cache-dir"))
+	viper.BindPFlag("audit-cache.output", auditCacheCmd.Flags().Lookup("output"))
+	viper.BindPFlag("audit-cache.bom-dir", auditCacheCmd.Flags().Lookup("bom-dir"))
+	viper.BindPFlag("audit-cache.format", auditCacheCmd.Flags().Lookup("format"))
+	viper.BindPFlag("audit-cache.spec", auditCacheCmd.Flags().Lookup("spec"))
+	viper.BindPFlag("audit-cache.cleanup-plan", auditCacheCmd.Flags().Lookup("cleanup-plan"))
+	viper.BindPFlag("audit-cache.unused-for", auditCacheCmd.Flags().Lookup("unused-for"))
+}
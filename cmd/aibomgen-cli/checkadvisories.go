@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/idlab-discover/aibomgen-cli/internal/advisories"
+	"github.com/idlab-discover/aibomgen-cli/internal/apperr"
+	"github.com/idlab-discover/aibomgen-cli/internal/ui"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/bomio"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// checkAdvisoriesCmd represents the check-advisories command.
+var checkAdvisoriesCmd = &cobra.Command{
+	Use:   "check-advisories",
+	Short: "Match AIBOM models and datasets against a signed advisories feed",
+	Long: `Load a curated, signed advisories feed (takedowns, license changes and
+weight-poisoning reports) and report every model or dataset component in an
+existing AIBOM that is affected.
+
+The feed is verified with an Ed25519 public key before it is used. Use
+--fail-on-match to return a non-zero exit code when affected components are
+found, e.g. in CI pipelines.`,
+	RunE: runCheckAdvisories,
+}
+
+func runCheckAdvisories(cmd *cobra.Command, _ []string) error {
+	inputPath := viper.GetString("check-advisories.input")
+	if inputPath == "" {
+		return apperr.User("--input is required")
+	}
+
+	inputFormat := viper.GetString("check-advisories.format")
+	if inputFormat == "" {
+		inputFormat = "auto"
+	}
+
+	logLevel := strings.ToLower(strings.TrimSpace(viper.GetString("check-advisories.log-level")))
+	if logLevel == "" {
+		logLevel = "standard"
+	}
+	switch logLevel {
+	case "quiet", "standard", "debug":
+	default:
+		return apperr.Userf("invalid --log-level %q (expected quiet|standard|debug)", logLevel)
+	}
+
+	feedURL := strings.TrimSpace(viper.GetString("check-advisories.feed-url"))
+	if feedURL == "" {
+		return apperr.User("--feed-url is required (or set check-advisories.feed-url in the config file)")
+	}
+	publicKey := viper.GetString("check-advisories.public-key")
+	skipVerify := viper.GetBool("check-advisories.skip-verify")
+	if publicKey == "" && !skipVerify {
+		return apperr.User("--public-key is required to verify the advisories feed (use --skip-verify for unsigned local feeds)")
+	}
+	timeout := viper.GetInt("check-advisories.timeout")
+	if timeout <= 0 {
+		timeout = 15
+	}
+
+	w := cmd.OutOrStdout()
+
+	// ── Read AIBOM ──────────────────────────────────────────────────────────.
+	if viper.GetBool("check-advisories.require-signature") {
+		if err := verifyBOMSignatures(cmd.Context(), w, []string{inputPath}, logLevel == "quiet"); err != nil {
+			return err
+		}
+	}
+	bom, err := bomio.ReadBOM(inputPath, inputFormat)
+	if err != nil {
+		return fmt.Errorf("failed to read input BOM: %w", err)
+	}
+
+	// ── Workflow / progress ──────────────────────────────────────────────────.
+	var workflow *ui.Workflow
+	if logLevel != "quiet" {
+		workflow = ui.NewWorkflow(w, "Advisory Check")
+		workflow.AddTask("Loading advisories feed")
+		workflow.AddTask("Matching components")
+		workflow.Start()
+		workflow.StartTask(0, "")
+	}
+
+	feed, err := advisories.Load(advisories.Options{
+		Source:     feedURL,
+		PublicKey:  publicKey,
+		SkipVerify: skipVerify,
+		Timeout:    time.Duration(timeout) * time.Second,
+	})
+	if err != nil {
+		if workflow != nil {
+			workflow.FailTask(0, err.Error())
+			workflow.Stop()
+		}
+		return fmt.Errorf("failed to load advisories feed: %w", err)
+	}
+
+	if workflow != nil {
+		msg := fmt.Sprintf("%d advisories", len(feed.Advisories))
+		if skipVerify {
+			msg += " (signature not verified)"
+		}
+		workflow.CompleteTask(0, msg)
+		workflow.StartTask(1, "")
+	}
+
+	matches := advisories.CheckBOM(bom, feed)
+
+	if workflow != nil {
+		workflow.CompleteTask(1, fmt.Sprintf("%d affected", len(matches)))
+		workflow.Stop()
+	}
+
+	if logLevel != "quiet" {
+		printAdvisoryReport(w, matches, logLevel == "debug")
+	}
+
+	if len(matches) > 0 && viper.GetBool("check-advisories.fail-on-match") {
+		return apperr.Userf("%d component advisory match(es) found", len(matches))
+	}
+	return nil
+}
+
+// printAdvisoryReport writes a human-readable list of affected components to w.
+func printAdvisoryReport(w io.Writer, matches []advisories.Match, debug bool) {
+	fmt.Fprintln(w)
+
+	if len(matches) == 0 {
+		fmt.Fprintf(w, "%s\n", ui.SuccessBox.Render(ui.GetCheckMark()+" No components affected by published advisories."))
+		return
+	}
+
+	for _, m := range matches {
+		label := ui.Bold.Render(m.ComponentID)
+		if m.Revision != "" && debug {
+			label += ui.Muted.Render("@" + m.Revision)
+		}
+		fmt.Fprintf(w, "%s  %s  %s\n",
+			renderVulnSeverity(m.Advisory.Severity, "✗"),
+			label,
+			ui.Muted.Render("("+m.Kind+")"))
+		fmt.Fprintf(w, "    %s  %s  %s\n",
+			renderVulnSeverity(m.Advisory.Severity, fmt.Sprintf("[%s]", strings.ToUpper(advisoryTypeLabel(m.Advisory.Type)))),
+			ui.Primary.Render(m.Advisory.ID),
+			ui.Dim.Render(m.Advisory.Summary))
+		if m.Advisory.Published != "" {
+			fmt.Fprintf(w, "    Published: %s\n", ui.Muted.Render(m.Advisory.Published))
+		}
+		for _, ref := range m.Advisory.References {
+			fmt.Fprintf(w, "      • %s\n", ui.Muted.Render(ref))
+		}
+		fmt.Fprintln(w)
+	}
+
+	fmt.Fprintf(w, "%s\n", ui.ErrorBox.Render(fmt.Sprintf("%s %d component advisory match(es) found", ui.GetCrossMark(), len(matches))))
+}
+
+func advisoryTypeLabel(t string) string {
+	switch t {
+	case advisories.TypeTakedown:
+		return "takedown"
+	case advisories.TypeLicenseChange:
+		return "license change"
+	case advisories.TypeWeightPoisoning:
+		return "weight poisoning"
+	case "":
+		return "advisory"
+	default:
+		return t
+	}
+}
+
+// ── Flag vars ─────────────────────────────────────────────────────────────────.
+
+var (
+	checkAdvInput       string
+	checkAdvInputFormat string
+	checkAdvFeedURL     string
+	checkAdvPublicKey   string
+	checkAdvSkipVerify  bool
+	checkAdvTimeout     int
+	checkAdvFailOnMatch bool
+	checkAdvRequireSig  bool
+	checkAdvLogLevel    string
+)
+
+func init() {
+	checkAdvisoriesCmd.Flags().StringVarP(&checkAdvInput, "input", "i", "", "Path to existing AIBOM (required)")
+	checkAdvisoriesCmd.Flags().StringVarP(&checkAdvInputFormat, "format", "f", "", "Input BOM format: json|xml|spdx-json|auto")
+	checkAdvisoriesCmd.Flags().StringVar(&checkAdvFeedURL, "feed-url", "", "Advisories feed URL or local path (required)")
+	checkAdvisoriesCmd.Flags().StringVar(&checkAdvPublicKey, "public-key", "", "Base64 Ed25519 public key used to verify the feed signature")
+	checkAdvisoriesCmd.Flags().BoolVar(&checkAdvSkipVerify, "skip-verify", false, "Skip feed signature verification (testing only)")
+	checkAdvisoriesCmd.Flags().IntVar(&checkAdvTimeout, "timeout", 15, "Feed download timeout in seconds")
+	checkAdvisoriesCmd.Flags().BoolVar(&checkAdvFailOnMatch, "fail-on-match", false, "Exit with an error when affected components are found")
+	checkAdvisoriesCmd.Flags().BoolVar(&checkAdvRequireSig, "require-signature", false, "Verify the cosign signature of the AIBOM (verify-signature settings) before reading it")
+	checkAdvisoriesCmd.Flags().StringVar(&checkAdvLogLevel, "log-level", "", "Log level: quiet|standard|debug")
+
+	// Bind to viper.
+	viper.BindPFlag("check-advisories.input", checkAdvisoriesCmd.Flags().Lookup("input"))
+	viper.BindPFlag("check-advisories.format", checkAdvisoriesCmd.Flags().Lookup("format"))
+	viper.BindPFlag("check-advisories.feed-url", checkAdvisoriesCmd.Flags().Lookup("feed-url"))
+	viper.BindPFlag("check-advisories.public-key", checkAdvisoriesCmd.Flags().Lookup("public-key"))
+	viper.BindPFlag("check-advisories.skip-verify", checkAdvisoriesCmd.Flags().Lookup("skip-verify"))
+	viper.BindPFlag("check-advisories.timeout", checkAdvisoriesCmd.Flags().Lookup("timeout"))
+	viper.BindPFlag("check-advisories.fail-on-match", checkAdvisoriesCmd.Flags().Lookup("fail-on-match"))
+	viper.BindPFlag("check-advisories.require-signature", checkAdvisoriesCmd.Flags().Lookup("require-signature"))
+	viper.BindPFlag("check-advisories.log-level", checkAdvisoriesCmd.Flags().Lookup("log-level"))
+}
@@ -0,0 +1,247 @@
+package cmd
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+
+	"github.com/idlab-discover/aibomgen-cli/internal/apperr"
+	"github.com/idlab-discover/aibomgen-cli/internal/attest"
+	"github.com/idlab-discover/aibomgen-cli/internal/audit"
+	"github.com/idlab-discover/aibomgen-cli/internal/enricher"
+	"github.com/idlab-discover/aibomgen-cli/internal/ui"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/bomio"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// verifyClaimsCmd represents the verify-claims command.
+var verifyClaimsCmd = &cobra.Command{
+	Use:   "verify-claims",
+	Short: "Verify the signed field claims of an enriched AIBOM",
+	Long: `Verify the field claims written by enrich --sign-key: every claim must be
+signed by one of the trusted public keys and name the serial number of the
+AIBOM, and the AIBOM must still hold the claimed value (or, for a removal, no
+value). Only the latest claim of a field is compared with the AIBOM. The
+report lists each claimed field with its value, the approver and the key that
+signed it. The command fails when there are no claims to verify, and when a
+field named with --require-claim has no verified claim (a dataset field needs
+one for every dataset component).
+
+Example:
+  aibomgen-cli verify-claims -i bom.json --public-key approver.pub.pem --require-claim licenses`,
+	RunE: runVerifyClaims,
+}
+
+func runVerifyClaims(cmd *cobra.Command, _ []string) error {
+	inputPath := viper.GetString("verify-claims.input")
+	if inputPath == "" {
+		return apperr.User("--input is required")
+	}
+	inputFormat := viper.GetString("verify-claims.format")
+	if inputFormat == "" {
+		inputFormat = "auto"
+	}
+	claimsPath := strings.TrimSpace(viper.GetString("verify-claims.claims-file"))
+	if claimsPath == "" {
+		claimsPath = inputPath + ".claims.jsonl"
+	}
+
+	rawKeys := viper.GetStringSlice("verify-claims.public-key")
+	if len(rawKeys) == 0 {
+		return apperr.User("--public-key is required (repeat it for every trusted approver key)")
+	}
+	var keys []ed25519.PublicKey
+	for _, raw := range rawKeys {
+		k, err := attest.ParsePublicKey(raw)
+		if err != nil {
+			return apperr.Userf("invalid --public-key: %v", err)
+		}
+		keys = append(keys, k)
+	}
+	required, err := requiredClaims(viper.GetStringSlice("verify-claims.require-claim"))
+	if err != nil {
+		return err
+	}
+
+	bom, err := bomio.ReadBOM(inputPath, inputFormat)
+	if err != nil {
+		return fmt.Errorf("failed to read input BOM: %w", err)
+	}
+	claims, err := attest.Read(claimsPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return apperr.Userf("no claims to verify: %s does not exist", claimsPath)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read claims: %w", err)
+	}
+	if len(claims) == 0 {
+		return apperr.Userf("no claims to verify in %s", claimsPath)
+	}
+
+	// Claims are appended as fields change, so only the latest claim of a.
+	// field says what the BOM holds now.
+	verified := make([]*attest.Verified, len(claims))
+	errs := make([]error, len(claims))
+	latest := make(map[string]int)
+	for i, jws := range claims {
+		v, err := attest.Verify(jws, keys)
+		if err == nil && v.Statement.Predicate.SerialNumber != bom.SerialNumber {
+			err = fmt.Errorf("claim is for BOM %q", v.Statement.Predicate.SerialNumber)
+		}
+		verified[i], errs[i] = v, err
+		if err == nil {
+			latest[v.Statement.Predicate.SubjectName()] = i
+		}
+	}
+
+	w := cmd.OutOrStdout()
+	invalid := 0
+	claimed := make(map[string]bool)
+	for i, v := range verified {
+		err := errs[i]
+		if err == nil && latest[v.Statement.Predicate.SubjectName()] == i {
+			c := v.Statement.Predicate
+			if verr := enricher.CheckClaimedValue(bom, c.Dataset, c.Component, c.BOMRef, c.Field, c.Value, c.Removed); verr != nil {
+				err = fmt.Errorf("%s: %w", c.Field, verr)
+			}
+		}
+		if err != nil {
+			invalid++
+			fmt.Fprintf(w, "%s  claim %d: %s\n", ui.GetCrossMark(), i+1, ui.Error.Render(err.Error()))
+			continue
+		}
+		printClaim(w, v)
+		claimed[v.Statement.Predicate.SubjectName()] = true
+	}
+
+	missing := unclaimedFields(bom, required, claimed)
+	for _, m := range missing {
+		fmt.Fprintf(w, "%s  %s: %s\n", ui.GetCrossMark(), ui.Bold.Render(m), ui.Error.Render("required field has no verified claim"))
+	}
+
+	fmt.Fprintln(w)
+	if invalid > 0 || len(missing) > 0 {
+		var parts []string
+		if invalid > 0 {
+			parts = append(parts, fmt.Sprintf("%d of %d claim(s) failed verification", invalid, len(claims)))
+		}
+		if len(missing) > 0 {
+			parts = append(parts, fmt.Sprintf("%d required field(s) not claimed", len(missing)))
+		}
+		msg := strings.Join(parts, ", ")
+		fmt.Fprintf(w, "%s\n", ui.ErrorBox.Render(ui.GetCrossMark()+" "+msg))
+		return apperr.User(msg)
+	}
+	fmt.Fprintf(w, "%s\n", ui.SuccessBox.Render(fmt.Sprintf("%s %d claim(s) verified", ui.GetCheckMark(), len(claims))))
+	return nil
+}
+
+// requiredClaim is a field that --require-claim asks a verified claim for.
+type requiredClaim struct {
+	key     string
+	dataset bool
+}
+
+// requiredClaims resolves the fields named with --require-claim.
+func requiredClaims(fields []string) ([]requiredClaim, error) {
+	var out []requiredClaim
+	for _, raw := range fields {
+		key, dataset, ok := enricher.FieldKey(raw)
+		if !ok {
+			return nil, apperr.Userf("unknown field %q in --require-claim", raw)
+		}
+		out = append(out, requiredClaim{key, dataset})
+	}
+	return out, nil
+}
+
+// unclaimedFields lists the required fields of bom without a verified claim,.
+// by subject name: a model field on the model component, a dataset field on.
+// every dataset component.
+func unclaimedFields(bom *cdx.BOM, required []requiredClaim, claimed map[string]bool) []string {
+	var out []string
+	for _, r := range required {
+		if !r.dataset {
+			var c attest.Claim
+			if bom.Metadata != nil && bom.Metadata.Component != nil {
+				c = attest.Claim{SerialNumber: bom.SerialNumber, Component: bom.Metadata.Component.Name, BOMRef: bom.Metadata.Component.BOMRef, Field: r.key}
+			}
+			if !claimed[c.SubjectName()] {
+				out = append(out, r.key)
+			}
+			continue
+		}
+		if bom.Components == nil {
+			continue
+		}
+		for _, comp := range *bom.Components {
+			if comp.Type != cdx.ComponentTypeData {
+				continue
+			}
+			c := attest.Claim{SerialNumber: bom.SerialNumber, Component: comp.Name, BOMRef: comp.BOMRef, Field: r.key}
+			if !claimed[c.SubjectName()] {
+				out = append(out, fmt.Sprintf("%s (dataset %s)", r.key, comp.Name))
+			}
+		}
+	}
+	return out
+}
+
+// printClaim writes one verified claim to w.
+func printClaim(w io.Writer, v *attest.Verified) {
+	c := v.Statement.Predicate
+	target := c.Field
+	if c.Dataset {
+		target = fmt.Sprintf("%s (dataset %s)", c.Field, c.Component)
+	}
+	value := fmt.Sprintf("%q", c.Value)
+	if c.Removed {
+		value = "removed"
+	}
+	fmt.Fprintf(w, "%s  %s = %s\n", ui.GetCheckMark(), ui.Bold.Render(target), value)
+	fmt.Fprintf(w, "    %s\n", ui.Muted.Render(fmt.Sprintf("by %s at %s via %s, key %s", c.Actor, c.Time.UTC().Format("2006-01-02 15:04:05Z"), c.Source, v.KeyID)))
+}
+
+// writeClaims signs every entry with key and appends the claims to path.
+func writeClaims(path string, entries []audit.Entry, key ed25519.PrivateKey) error {
+	claims := make([]string, 0, len(entries))
+	for _, e := range entries {
+		jws, err := attest.Sign(attest.FromEntry(e), key)
+		if err != nil {
+			return err
+		}
+		claims = append(claims, jws)
+	}
+	return attest.Write(path, claims)
+}
+
+// ── Flag vars ─────────────────────────────────────────────────────────────────.
+
+var (
+	verifyClaimsInput      string
+	verifyClaimsFormat     string
+	verifyClaimsFile       string
+	verifyClaimsPublicKeys []string
+	verifyClaimsRequire    []string
+)
+
+func init() {
+	verifyClaimsCmd.Flags().StringVarP(&verifyClaimsInput, "input", "i", "", "Path to the enriched AIBOM (required)")
+	verifyClaimsCmd.Flags().StringVarP(&verifyClaimsFormat, "format", "f", "", "Input BOM format: json|xml|spdx-json|auto")
+	verifyClaimsCmd.Flags().StringVar(&verifyClaimsFile, "claims-file", "", "Signed field claims (default: <input>.claims.jsonl)")
+	verifyClaimsCmd.Flags().StringArrayVar(&verifyClaimsPublicKeys, "public-key", nil, "Trusted Ed25519 public key (PEM, base64, or a file holding either; repeatable)")
+
+	verifyClaimsCmd.Flags().StringArrayVar(&verifyClaimsRequire, "require-claim", nil, "Field that must have a verified claim, in any form --answer accepts (repeatable; e.g. licenses, dataset.licenses)")
+
+	viper.BindPFlag("verify-claims.input", verifyClaimsCmd.Flags().Lookup("input"))
+	viper.BindPFlag("verify-claims.format", verifyClaimsCmd.Flags().Lookup("format"))
+	viper.BindPFlag("verify-claims.claims-file", verifyClaimsCmd.Flags().Lookup("claims-file"))
+	viper.BindPFlag("verify-claims.public-key", verifyClaimsCmd.Flags().Lookup("public-key"))
+	viper.BindPFlag("verify-claims.require-claim", verifyClaimsCmd.Flags().Lookup("require-claim"))
+}
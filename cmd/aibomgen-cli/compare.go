@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/internal/apperr"
+	"github.com/idlab-discover/aibomgen-cli/internal/compare"
+	"github.com/idlab-discover/aibomgen-cli/internal/ui"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/bomio"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/generator"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// compareValueWidth is the length at which values are cut in text output.
+const compareValueWidth = 40
+
+// compareCmd represents the compare command.
+var compareCmd = &cobra.Command{
+	Use:   "compare [model-id...]",
+	Short: "Compare candidate models side by side",
+	Long: `Generate the AIBOM metadata of two or more candidate models and render a
+side-by-side matrix of their license, size, task, datasets, benchmark results,
+completeness and risk, to support model selection decisions.
+
+Each model ID is fetched from Hugging Face like generate does (the BOM cache is
+used). Existing AIBOMs can be added to the comparison with --input. Nothing is
+written to disk; the matrix is printed as text, Markdown or JSON.
+
+Risk is a coarse low/medium/high level with the factors behind it: unsafe or
+flagged files in the Hugging Face security scan, vulnerabilities, missing,
+non-commercial or custom license terms, missing required fields and
+undocumented training data.
+
+Example:
+  aibomgen-cli compare distilbert-base-uncased google-bert/bert-base-uncased FacebookAI/roberta-base
+  aibomgen-cli compare org/model-a -i dist/org_model-b_aibom.json --output markdown > comparison.md`,
+	RunE: runCompare,
+}
+
+func runCompare(cmd *cobra.Command, args []string) error {
+	output := strings.ToLower(strings.TrimSpace(viper.GetString("compare.output")))
+	if output == "" {
+		output = "text"
+	}
+	if output != "text" && output != "markdown" && output != "json" {
+		return apperr.Userf("invalid --output %q (expected text|markdown|json)", output)
+	}
+
+	modelIDs := make([]string, 0, len(args))
+	for _, id := range args {
+		if id = strings.TrimSpace(id); id != "" {
+			modelIDs = append(modelIDs, id)
+		}
+	}
+	inputs := viper.GetStringSlice("compare.input")
+	if len(modelIDs)+len(inputs) < 2 {
+		return apperr.User("compare needs at least two models (model IDs and/or --input files)")
+	}
+	profile, err := loadCompletenessProfile(viper.GetString("compare.profile"))
+	if err != nil {
+		return err
+	}
+
+	boms := make([]*cdx.BOM, 0, len(modelIDs)+len(inputs))
+	if len(modelIDs) > 0 {
+		generated, err := compareGenerate(cmd, modelIDs)
+		if err != nil {
+			return err
+		}
+		boms = append(boms, generated...)
+	}
+	for _, path := range inputs {
+		bom, err := bomio.ReadBOM(path, "auto")
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		boms = append(boms, bom)
+	}
+
+	m := compare.Build(boms, compare.Options{Profile: profile, Locale: ui.Locale()})
+	if len(m.Models) < 2 {
+		return fmt.Errorf("only %d model(s) could be described, nothing to compare", len(m.Models))
+	}
+	switch output {
+	case "json":
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(m)
+	case "markdown":
+		return compare.WriteMarkdown(cmd.OutOrStdout(), m)
+	}
+	return compare.WriteText(cmd.OutOrStdout(), m, compareValueWidth)
+}
+
+// compareGenerate builds the BOMs of modelIDs, in the order of the IDs. Models.
+// that could not be built are reported on stderr and left out.
+func compareGenerate(cmd *cobra.Command, modelIDs []string) ([]*cdx.BOM, error) {
+	timeout := viper.GetInt("compare.hf-timeout")
+	if timeout <= 0 {
+		timeout = 10
+	}
+	bomCache := openBOMCache(viper.GetBool("compare.no-bom-cache"))
+	cmd.SetContext(withBOMCache(cmd.Context(), bomCache))
+	opts := generator.GenerateOptions{
+		HFToken:          viper.GetString("compare.hf-token"),
+		Timeout:          time.Duration(timeout) * time.Second,
+		SkipSecurityScan: viper.GetBool("compare.no-security-scan"),
+		Cache:            bomCache,
+		MaxRetries:       viper.GetInt("compare.hf-retries"),
+		MaxBackoff:       time.Duration(viper.GetInt("compare.hf-max-backoff")) * time.Second,
+		APITimeout:       time.Duration(viper.GetInt("compare.hf-api-timeout")) * time.Second,
+		ReadmeTimeout:    time.Duration(viper.GetInt("compare.hf-readme-timeout")) * time.Second,
+		DatasetTimeout:   time.Duration(viper.GetInt("compare.hf-dataset-timeout")) * time.Second,
+		ModelDeadline:    time.Duration(viper.GetInt("compare.model-deadline")) * time.Second,
+		Context:          traceCtx,
+	}
+	results, err := generator.BuildFromModelIDs(modelIDs, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]*cdx.BOM, len(results))
+	for _, r := range results {
+		byID[r.Discovery.ID] = r.BOM
+	}
+	boms := make([]*cdx.BOM, 0, len(modelIDs))
+	for _, id := range modelIDs {
+		bom, ok := byID[id]
+		if !ok || bom == nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "%s %s %s\n", ui.GetWarnMark(), id,
+				ui.Warning.Render("→ no AIBOM could be generated (not found, private or unreachable); left out"))
+			continue
+		}
+		boms = append(boms, bom)
+	}
+	return boms, nil
+}
+
+var (
+	compareInput            []string
+	compareOutput           string
+	compareProfile          string
+	compareHfToken          string
+	compareHfTimeout        int
+	compareHfRetries        int
+	compareHfMaxBackoff     int
+	compareHfAPITimeout     int
+	compareHfReadmeTimeout  int
+	compareHfDatasetTimeout int
+	compareModelDeadline    int
+	compareNoSecurityScan   bool
+	compareNoBOMCache       bool
+)
+
+func init() {
+	compareCmd.Flags().StringSliceVarP(&compareInput, "input", "i", []string{}, "Existing AIBOM file(s) to add to the comparison - can be used multiple times or comma-separated")
+	compareCmd.Flags().StringVar(&compareOutput, "output", "text", "Output format: text|markdown|json")
+	compareCmd.Flags().StringVar(&compareProfile, "profile", "", "Weights profile (YAML/JSON) for the completeness scores")
+	compareCmd.Flags().StringVar(&compareHfToken, "hf-token", "", "Hugging Face access token")
+	compareCmd.Flags().IntVar(&compareHfTimeout, "hf-timeout", 0, "Timeout in seconds per Hugging Face API request (default 10)")
+	compareCmd.Flags().IntVar(&compareHfAPITimeout, "hf-api-timeout", 0, "Timeout in seconds per model API and repository file request (default: --hf-timeout)")
+	compareCmd.Flags().IntVar(&compareHfReadmeTimeout, "hf-readme-timeout", 0, "Timeout in seconds per model card request (default: --hf-timeout)")
+	compareCmd.Flags().IntVar(&compareHfDatasetTimeout, "hf-dataset-timeout", 0, "Timeout in seconds per dataset API and dataset card request (default: --hf-timeout)")
+	compareCmd.Flags().IntVar(&compareModelDeadline, "model-deadline", 0, "Deadline in seconds for all requests of one model, retries included (0: none)")
+	compareCmd.Flags().IntVar(&compareHfRetries, "hf-retries", 3, "Retries of rate-limited (429) or transiently failing Hugging Face requests (0 disables retries)")
+	compareCmd.Flags().IntVar(&compareHfMaxBackoff, "hf-max-backoff", 30, "Longest wait in seconds before retrying a Hugging Face request")
+	compareCmd.Flags().BoolVar(&compareNoSecurityScan, "no-security-scan", false, "Skip fetching the HuggingFace security scan tree (the risk then ignores unsafe files)")
+	compareCmd.Flags().BoolVar(&compareNoBOMCache, "no-bom-cache", false, "Bypass the local cache of generated BOMs (cached BOMs keep their dataset and base model components until the model itself changes)")
+
+	viper.BindPFlag("compare.input", compareCmd.Flags().Lookup("input"))
+	viper.BindPFlag("compare.output", compareCmd.Flags().Lookup("output"))
+	viper.BindPFlag("compare.profile", compareCmd.Flags().Lookup("profile"))
+	viper.BindPFlag("compare.hf-token", compareCmd.Flags().Lookup("hf-token"))
+	viper.BindPFlag("compare.hf-timeout", compareCmd.Flags().Lookup("hf-timeout"))
+	viper.BindPFlag("compare.hf-api-timeout", compareCmd.Flags().Lookup("hf-api-timeout"))
+	viper.BindPFlag("compare.hf-readme-timeout", compareCmd.Flags().Lookup("hf-readme-timeout"))
+	viper.BindPFlag("compare.hf-dataset-timeout", compareCmd.Flags().Lookup("hf-dataset-timeout"))
+	viper.BindPFlag("compare.model-deadline", compareCmd.Flags().Lookup("model-deadline"))
+	viper.BindPFlag("compare.hf-retries", compareCmd.Flags().Lookup("hf-retries"))
+	viper.BindPFlag("compare.hf-max-backoff", compareCmd.Flags().Lookup("hf-max-backoff"))
+	viper.BindPFlag("compare.no-security-scan", compareCmd.Flags().Lookup("no-security-scan"))
+	viper.BindPFlag("compare.no-bom-cache", compareCmd.Flags().Lookup("no-bom-cache"))
+}
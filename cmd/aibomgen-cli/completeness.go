@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -14,9 +15,23 @@ import (
 )
 
 var completenessCmd = &cobra.Command{
-	Use:   "completeness",
-	Short: "Compute completeness score for an AIBOM",
-	Long:  "Reads an existing CycloneDX AIBOM (json/xml) and scores it against the configured field registry.",
+	Use:     "completeness [input]",
+	Aliases: []string{"check"},
+	Short:   "Compute completeness score for an AIBOM",
+	Long: `Reads an existing CycloneDX AIBOM (json/xml) and scores it against the configured field registry.
+
+With --min-score the command exits non-zero when the model score is below the
+threshold, for use as a CI gate. --profile loads a weights profile (YAML or
+JSON) that overrides the weight and required flag of individual fields, e.g.
+to score against a regulatory scheme.
+
+Fields of a category, such as the responsible AI fields (ethical
+considerations, fairness metrics and criteria), are also scored per category.
+
+Example:
+  aibomgen-cli check dist/google-bert_bert-base-uncased_aibom.json --min-score 0.8
+  aibomgen-cli check dist/google-bert_bert-base-uncased_aibom.json --profile config/profiles/eu-ai-act.yaml`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 
 		// Get log level from viper (respects config file and CLI flag).
@@ -33,56 +48,122 @@ var completenessCmd = &cobra.Command{
 
 		// Get input path and format from viper.
 		inputPath := viper.GetString("completeness.input")
+		if len(args) == 1 {
+			inputPath = args[0]
+		}
 		if inputPath == "" {
-			return apperr.User("--input is required")
+			return apperr.User("an input AIBOM is required (argument or --input)")
+		}
+		minScore := viper.GetFloat64("completeness.min-score")
+		if minScore < 0 || minScore > 1 {
+			return apperr.Userf("invalid --min-score %v (expected 0.0-1.0)", minScore)
 		}
 		inputFormat := viper.GetString("completeness.format")
 		if inputFormat == "" {
 			inputFormat = "auto"
 		}
 
+		profile, err := loadCompletenessProfile(viper.GetString("completeness.profile"))
+		if err != nil {
+			return err
+		}
+
 		bom, err := bomio.ReadBOM(inputPath, inputFormat)
 		if err != nil {
 			return err
 		}
 
-		res := completeness.Check(bom)
+		res := completeness.CheckWithProfile(bom, profile)
+		verified := viper.GetBool("completeness.verified")
 
 		// If plain-summary requested, print a machine-readable plain summary (no styling).
 		if completenessPlainSummary {
 			// Model summary line.
-			fmt.Printf("Model: %s | Score: %.1f%% | Fields: %d/%d\n", res.ModelID, res.Score*100, res.Passed, res.Total)
+			fmt.Printf("Model: %s | Score: %.1f%% | Fields: %d/%d%s\n", res.ModelID, res.Score*100, res.Passed, res.Total, verifiedSummary(verified, res.VerifiedScore, res.Verified))
+			// Category summary lines, in name order.
+			categories := make([]string, 0, len(res.Categories))
+			for name := range res.Categories {
+				categories = append(categories, name)
+			}
+			sort.Strings(categories)
+			for _, name := range categories {
+				cat := res.Categories[name]
+				fmt.Printf("Category: %s | Score: %.1f%% | Fields: %d/%d\n", name, cat.Score*100, cat.Passed, cat.Total)
+			}
 			// Dataset summary lines (if any).
 			for dsName, ds := range res.DatasetResults {
-				fmt.Printf("Dataset: %s | Score: %.1f%% | Fields: %d/%d\n", dsName, ds.Score*100, ds.Passed, ds.Total)
+				fmt.Printf("Dataset: %s | Score: %.1f%% | Fields: %d/%d%s\n", dsName, ds.Score*100, ds.Passed, ds.Total, verifiedSummary(verified, ds.VerifiedScore, ds.Verified))
+			}
+		} else {
+			// Use the new UI for rendering if not in quiet mode.
+			ui := ui.NewCompletenessUI(cmd.OutOrStdout(), level == "quiet")
+			ui.PrintReport(res)
+			if verified && level != "quiet" {
+				fmt.Fprintf(cmd.OutOrStdout(), "Verified score (approved fields only): %.1f%% (%d/%d fields)\n", res.VerifiedScore*100, res.Verified, res.Total)
 			}
-			return nil
 		}
 
-		// Use the new UI for rendering if not in quiet mode.
-		ui := ui.NewCompletenessUI(cmd.OutOrStdout(), level == "quiet")
-		ui.PrintReport(res)
-
-		return nil
+		if verified {
+			// Gate on the verified score: unreviewed fields do not count.
+			return checkMinScore("verified score", res.VerifiedScore, minScore)
+		}
+		return checkMinScore("completeness score", res.Score, minScore)
 	},
 }
 
+// verifiedSummary is the plain-summary suffix for the verified score.
+func verifiedSummary(enabled bool, score float64, n int) string {
+	if !enabled {
+		return ""
+	}
+	return fmt.Sprintf(" | Verified: %.1f%% (%d fields)", score*100, n)
+}
+
+// loadCompletenessProfile loads the weights profile at path (nil when empty).
+func loadCompletenessProfile(path string) (*completeness.Profile, error) {
+	if strings.TrimSpace(path) == "" {
+		return nil, nil
+	}
+	p, err := completeness.LoadProfile(path)
+	if err != nil {
+		return nil, apperr.User(err.Error())
+	}
+	return p, nil
+}
+
+// checkMinScore fails when score is below minScore (0: no gate).
+func checkMinScore(label string, score, minScore float64) error {
+	if minScore > 0 && score < minScore {
+		return fmt.Errorf("%s %.1f%% is below the minimum of %.1f%%", label, score*100, minScore*100)
+	}
+	return nil
+}
+
 var (
 	inPath                   string
 	inFormat                 string
 	completenessLogLevel     string
 	completenessPlainSummary bool
+	completenessMinScore     float64
+	completenessVerified     bool
+	completenessProfile      string
 )
 
 func init() {
 	completenessCmd.Flags().StringVarP(&inPath, "input", "i", "", "Path to existing AIBOM file (required)")
-	completenessCmd.Flags().StringVarP(&inFormat, "format", "f", "", "Input BOM format: json|xml|auto")
+	completenessCmd.Flags().StringVarP(&inFormat, "format", "f", "", "Input BOM format: json|xml|spdx-json|auto")
 	completenessCmd.Flags().StringVar(&completenessLogLevel, "log-level", "", "Log level: quiet|standard|debug")
 	completenessCmd.Flags().BoolVar(&completenessPlainSummary, "plain-summary", false, "Print a single-line plain summary (no styling)")
+	completenessCmd.Flags().Float64Var(&completenessMinScore, "min-score", 0.0, "Exit non-zero when the model completeness score is below this value (0.0-1.0; 0: no gate)")
+	completenessCmd.Flags().StringVar(&completenessProfile, "profile", "", "Weights profile (YAML/JSON) overriding the weights and required flags of fields")
+	completenessCmd.Flags().BoolVar(&completenessVerified, "verified", false, "Also report the verified score, which only counts fields approved with review approve, and apply --min-score to it")
 
 	// Bind all flags to viper for config file support.
 	viper.BindPFlag("completeness.input", completenessCmd.Flags().Lookup("input"))
 	viper.BindPFlag("completeness.format", completenessCmd.Flags().Lookup("format"))
 	viper.BindPFlag("completeness.log-level", completenessCmd.Flags().Lookup("log-level"))
 	viper.BindPFlag("completeness.plain-summary", completenessCmd.Flags().Lookup("plain-summary"))
+	viper.BindPFlag("completeness.min-score", completenessCmd.Flags().Lookup("min-score"))
+	viper.BindPFlag("completeness.verified", completenessCmd.Flags().Lookup("verified"))
+	viper.BindPFlag("completeness.profile", completenessCmd.Flags().Lookup("profile"))
 }
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/idlab-discover/aibomgen-cli/internal/apperr"
+	"github.com/idlab-discover/aibomgen-cli/internal/ui"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/bomio"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/converter"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// convertCmd represents the convert command.
+var convertCmd = &cobra.Command{
+	Use:   "convert [input] [-- plugin args...]",
+	Short: "Convert an AIBOM to another format through a converter plugin",
+	Long: `Convert an AIBOM to another format through a converter plugin.
+
+A converter plugin is an executable named aibomgen-convert-<name> on the PATH.
+It receives the BOM as CycloneDX JSON on stdin and writes the converted
+document to stdout. Arguments after -- are passed to the plugin. Use --list to
+show the plugins that are installed.
+
+Example:
+  aibomgen-cli convert dist/model_aibom.json --to registry -o model.registry.json -- --team ml`,
+	RunE: runConvert,
+}
+
+func runConvert(cmd *cobra.Command, args []string) error {
+	out := cmd.OutOrStdout()
+	if viper.GetBool("convert.list") {
+		plugins := converter.Discover()
+		if len(plugins) == 0 {
+			fmt.Fprintf(out, "No converter plugins (%s<name>) found on PATH.\n", converter.Prefix)
+			return nil
+		}
+		for _, p := range plugins {
+			fmt.Fprintf(out, "%s\t%s\n", p.Name, p.Path)
+		}
+		return nil
+	}
+
+	// Arguments after "--" belong to the plugin.
+	var pluginArgs []string
+	if dash := cmd.ArgsLenAtDash(); dash >= 0 {
+		args, pluginArgs = args[:dash], args[dash:]
+	}
+	if len(args) > 1 {
+		return apperr.Userf("expected at most one input, got %d", len(args))
+	}
+
+	name := strings.TrimSpace(viper.GetString("convert.to"))
+	if name == "" {
+		return apperr.User("a target format is required (--to <name>); see --list")
+	}
+	plugin, err := converter.Lookup(name)
+	if err != nil {
+		return apperr.User(err.Error())
+	}
+
+	inputPath := viper.GetString("convert.input")
+	if len(args) == 1 {
+		inputPath = args[0]
+	}
+	if inputPath == "" {
+		return apperr.User("an input AIBOM is required (argument or --input)")
+	}
+	inputFormat := viper.GetString("convert.format")
+	if inputFormat == "" {
+		inputFormat = "auto"
+	}
+	bom, err := bomio.ReadBOM(inputPath, inputFormat)
+	if err != nil {
+		return fmt.Errorf("failed to read input BOM: %w", err)
+	}
+	specVersion := strings.TrimSpace(viper.GetString("convert.spec"))
+
+	outputPath := strings.TrimSpace(viper.GetString("convert.output"))
+	if outputPath == "" || outputPath == "-" {
+		return converter.Convert(cmd.Context(), plugin, bom, specVersion, pluginArgs, out)
+	}
+
+	// Buffer the output so that a failing plugin does not leave a partial file.
+	var buf bytes.Buffer
+	if err := converter.Convert(cmd.Context(), plugin, bom, specVersion, pluginArgs, &buf); err != nil {
+		return err
+	}
+	if err := os.WriteFile(outputPath, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	fmt.Fprintln(out, ui.SuccessBox.Render(fmt.Sprintf("%s Converted %s to %s with %s", ui.GetCheckMark(), inputPath, outputPath, plugin.Name)))
+	return nil
+}
+
+// ── Flag vars ─────────────────────────────────────────────────────────────────.
+
+var (
+	convertInput  string
+	convertFormat string
+	convertTo     string
+	convertOutput string
+	convertSpec   string
+	convertList   bool
+)
+
+func init() {
+	convertCmd.Flags().StringVarP(&convertInput, "input", "i", "", "Path to the AIBOM to convert (or pass it as argument)")
+	convertCmd.Flags().StringVarP(&convertFormat, "format", "f", "", "Input BOM format: json|xml|spdx-json|auto")
+	convertCmd.Flags().StringVar(&convertTo, "to", "", "Converter plugin to use (runs aibomgen-convert-<name>)")
+	convertCmd.Flags().StringVarP(&convertOutput, "output", "o", "", "Output file (default: stdout)")
+	convertCmd.Flags().StringVar(&convertSpec, "spec", "", "CycloneDX spec version passed to the plugin (default: same as input)")
+	convertCmd.Flags().BoolVar(&convertList, "list", false, "List the converter plugins found on PATH")
+
+	// Bind to viper.
+	viper.BindPFlag("convert.input", convertCmd.Flags().Lookup("input"))
+	viper.BindPFlag("convert.format", convertCmd.Flags().Lookup("format"))
+	viper.BindPFlag("convert.to", convertCmd.Flags().Lookup("to"))
+	viper.BindPFlag("convert.output", convertCmd.Flags().Lookup("output"))
+	viper.BindPFlag("convert.spec", convertCmd.Flags().Lookup("spec"))
+	viper.BindPFlag("convert.list", convertCmd.Flags().Lookup("list"))
+}
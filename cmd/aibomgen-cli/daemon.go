@@ -0,0 +1,382 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/idlab-discover/aibomgen-cli/internal/apperr"
+	"github.com/idlab-discover/aibomgen-cli/internal/hooks"
+	"github.com/idlab-discover/aibomgen-cli/internal/metrics"
+	"github.com/idlab-discover/aibomgen-cli/internal/schedule"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// daemonCmd represents the daemon command.
+var daemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "Run the configured generation jobs on a cron schedule",
+	Long: `Run the jobs configured under daemon.jobs in the config file on a cron
+schedule, in a single long-running process.
+
+Each job is a list of steps. An "aibomgen" step runs an aibomgen-cli command
+(scan, generate, merge, convert, ...) with the same config file; a "run" step
+runs an external command, e.g. to publish the BOMs. A failing step skips the
+rest of its job until the next scheduled run. Jobs without a schedule of their
+own use --schedule.
+
+With --metrics-addr the daemon serves Prometheus metrics at /metrics: runs,
+durations, BOMs generated, Hugging Face API requests and errors, BOM cache
+hits and misses and completeness scores of the aibomgen steps, and the outcome
+and duration of every job run.
+
+Example config (jobs.yaml):
+  daemon:
+    jobs:
+      - name: nightly
+        schedule: "0 3 * * *"
+        steps:
+          - aibomgen: "scan -i . --application -o dist/aibom.json"
+          - aibomgen: "merge --aibom dist/myapp_aibom.json --sbom sbom.json -o dist/merged.json"
+          - run: "./publish.sh dist/merged.json"
+
+Example:
+  aibomgen-cli daemon --config jobs.yaml
+  aibomgen-cli daemon --config jobs.yaml --schedule "*/30 * * * *" --once
+  aibomgen-cli daemon --config jobs.yaml --metrics-addr :9464`,
+	RunE: runDaemon,
+}
+
+// daemonJob is one entry of daemon.jobs.
+type daemonJob struct {
+	Name     string       `mapstructure:"name"`
+	Schedule string       `mapstructure:"schedule"`
+	Steps    []daemonStep `mapstructure:"steps"`
+
+	sched *schedule.Schedule
+	steps [][]string
+}
+
+// daemonStep runs either an aibomgen-cli command or an external command.
+type daemonStep struct {
+	Aibomgen string `mapstructure:"aibomgen"`
+	Run      string `mapstructure:"run"`
+}
+
+func runDaemon(cmd *cobra.Command, _ []string) error {
+	jobs, err := loadDaemonJobs()
+	if err != nil {
+		return err
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("locate aibomgen-cli executable: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	log := &daemonLog{w: cmd.OutOrStdout()}
+
+	var collector *metrics.Collector
+	if addr := strings.TrimSpace(viper.GetString("daemon.metrics-addr")); addr != "" {
+		ln, err := net.Listen("tcp", addr)
+		if err != nil {
+			return apperr.Userf("cannot serve metrics on %s: %v", addr, err)
+		}
+		collector = metrics.NewCollector()
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", collector)
+		srv := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+		go func() { _ = srv.Serve(ln) }()
+		defer srv.Close()
+		log.printf("daemon", "serving metrics at http://%s/metrics", ln.Addr())
+	}
+
+	if viper.GetBool("daemon.once") {
+		failed := 0
+		for _, j := range jobs {
+			if !runDaemonJob(ctx, log, exe, j, collector) {
+				failed++
+			}
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d job(s) failed", failed)
+		}
+		return nil
+	}
+
+	var wg sync.WaitGroup
+	for _, j := range jobs {
+		wg.Add(1)
+		go func(j *daemonJob) {
+			defer wg.Done()
+			for {
+				next := j.sched.Next(time.Now())
+				if next.IsZero() {
+					log.printf(j.Name, "schedule %q never runs; job disabled", j.sched)
+					return
+				}
+				log.printf(j.Name, "next run at %s", next.Format(time.RFC3339))
+				timer := time.NewTimer(time.Until(next))
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return
+				case <-timer.C:
+				}
+				runDaemonJob(ctx, log, exe, j, collector)
+			}
+		}(j)
+	}
+	wg.Wait()
+	log.printf("daemon", "stopped")
+	return nil
+}
+
+// loadDaemonJobs reads and checks daemon.jobs.
+func loadDaemonJobs() ([]*daemonJob, error) {
+	var jobs []*daemonJob
+	if err := viper.UnmarshalKey("daemon.jobs", &jobs); err != nil {
+		return nil, apperr.Userf("invalid daemon.jobs: %v", err)
+	}
+	if len(jobs) == 0 {
+		return nil, apperr.User("no jobs configured: add daemon.jobs to the config file (see aibomgen-cli daemon --help)")
+	}
+	defaultSchedule := strings.TrimSpace(viper.GetString("daemon.schedule"))
+
+	names := make(map[string]bool)
+	for i, j := range jobs {
+		if j.Name == "" {
+			j.Name = fmt.Sprintf("job-%d", i+1)
+		}
+		if names[j.Name] {
+			return nil, apperr.Userf("duplicate job name %q", j.Name)
+		}
+		names[j.Name] = true
+
+		expr := strings.TrimSpace(j.Schedule)
+		if expr == "" {
+			expr = defaultSchedule
+		}
+		if expr == "" {
+			return nil, apperr.Userf("job %q has no schedule (set schedule or --schedule)", j.Name)
+		}
+		sched, err := schedule.Parse(expr)
+		if err != nil {
+			return nil, apperr.Userf("job %q: %v", j.Name, err)
+		}
+		j.sched = sched
+
+		if len(j.Steps) == 0 {
+			return nil, apperr.Userf("job %q has no steps", j.Name)
+		}
+		for k, s := range j.Steps {
+			args, err := daemonStepArgs(s)
+			if err != nil {
+				return nil, apperr.Userf("job %q step %d: %v", j.Name, k+1, err)
+			}
+			j.steps = append(j.steps, args)
+		}
+	}
+	return jobs, nil
+}
+
+// daemonStepArgs returns the command line of a step. aibomgen-cli steps are.
+// marked by an empty first element.
+func daemonStepArgs(s daemonStep) ([]string, error) {
+	switch {
+	case s.Aibomgen != "" && s.Run != "":
+		return nil, fmt.Errorf("set either aibomgen or run, not both")
+	case s.Aibomgen != "":
+		args, err := hooks.Split(s.Aibomgen)
+		if err != nil {
+			return nil, err
+		}
+		if len(args) == 0 {
+			return nil, fmt.Errorf("empty aibomgen command")
+		}
+		if args[0] == "daemon" {
+			return nil, fmt.Errorf("a job cannot start another daemon")
+		}
+		return append([]string{""}, args...), nil
+	case s.Run != "":
+		args, err := hooks.Split(s.Run)
+		if err != nil {
+			return nil, err
+		}
+		if len(args) == 0 {
+			return nil, fmt.Errorf("empty run command")
+		}
+		return args, nil
+	}
+	return nil, fmt.Errorf("set aibomgen or run")
+}
+
+// runDaemonJob runs the steps of j in order and reports whether all of them.
+// succeeded. aibomgen-cli steps run in a child process of the same executable.
+// with the same config file, so that every run starts from a clean state.
+// When collector is set, the job and the metrics of its aibomgen-cli steps.
+// are added to it.
+func runDaemonJob(ctx context.Context, log *daemonLog, exe string, j *daemonJob, collector *metrics.Collector) (ok bool) {
+	start := time.Now()
+	log.printf(j.Name, "started")
+	if collector != nil {
+		defer func() {
+			collector.ObserveJob(j.Name, ok, time.Since(start), time.Now())
+		}()
+	}
+	for i, step := range j.steps {
+		name, args := step[0], step[1:]
+		var metricsFile string
+		if name == "" {
+			name = exe
+			global := []string{"--no-ui"}
+			if cfg := viper.ConfigFileUsed(); cfg != "" {
+				global = append(global, "--config="+cfg)
+			}
+			args = append(global, args...)
+			if collector != nil {
+				metricsFile = daemonMetricsFile(log, j.Name)
+			}
+		}
+		c := exec.CommandContext(ctx, name, args...)
+		out := log.prefixed(j.Name)
+		c.Stdout, c.Stderr = out, out
+		if metricsFile != "" {
+			c.Env = append(os.Environ(), "AIBOMGEN_METRICS_ENABLED=true", "AIBOMGEN_METRICS_FILE="+metricsFile)
+		}
+		stepStart := time.Now()
+		err := c.Run()
+		out.flush()
+		if metricsFile != "" {
+			collectDaemonMetrics(collector, metricsFile)
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				log.printf(j.Name, "interrupted in step %d", i+1)
+				return false
+			}
+			log.printf(j.Name, "step %d/%d failed after %s: %v; skipping the remaining steps", i+1, len(j.steps), time.Since(stepStart).Round(time.Millisecond), err)
+			return false
+		}
+	}
+	log.printf(j.Name, "completed in %s", time.Since(start).Round(time.Millisecond))
+	return true
+}
+
+// daemonMetricsFile creates the temporary file an aibomgen-cli step records.
+// its metrics to, or returns "" when it cannot be created.
+func daemonMetricsFile(log *daemonLog, job string) string {
+	f, err := os.CreateTemp("", "aibomgen-metrics-*.jsonl")
+	if err != nil {
+		log.printf(job, "metrics disabled for this step: %v", err)
+		return ""
+	}
+	_ = f.Close()
+	return f.Name()
+}
+
+// collectDaemonMetrics adds the runs recorded in path to collector and.
+// removes the file. The runs are also appended to the local metrics file when.
+// metrics.enabled is set, so that the stats command keeps working.
+func collectDaemonMetrics(collector *metrics.Collector, path string) {
+	defer os.Remove(path)
+	runs, err := metrics.Load(path)
+	if err != nil {
+		return
+	}
+	local := ""
+	if viper.GetBool("metrics.enabled") {
+		local, _ = metricsPath("")
+	}
+	for _, r := range runs {
+		collector.Observe(r)
+		if local != "" {
+			_ = metrics.Append(local, r)
+		}
+	}
+}
+
+// daemonLog writes timestamped, job-prefixed lines from concurrent jobs.
+type daemonLog struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (l *daemonLog) printf(job, format string, args ...any) {
+	l.line(job, fmt.Sprintf(format, args...))
+}
+
+func (l *daemonLog) line(job, msg string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	fmt.Fprintf(l.w, "%s [%s] %s\n", time.Now().Format(time.RFC3339), job, msg)
+}
+
+func (l *daemonLog) prefixed(job string) *daemonLineWriter {
+	return &daemonLineWriter{log: l, job: job}
+}
+
+// daemonLineWriter forwards the output of a step line by line.
+type daemonLineWriter struct {
+	mu  sync.Mutex
+	log *daemonLog
+	job string
+	buf bytes.Buffer
+}
+
+func (w *daemonLineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.buf.Write(p)
+	for {
+		i := bytes.IndexByte(w.buf.Bytes(), '\n')
+		if i < 0 {
+			break
+		}
+		line := strings.TrimRight(string(w.buf.Next(i+1)), "\r\n")
+		if strings.TrimSpace(line) != "" {
+			w.log.line(w.job, line)
+		}
+	}
+	return len(p), nil
+}
+
+func (w *daemonLineWriter) flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if line := strings.TrimSpace(w.buf.String()); line != "" {
+		w.log.line(w.job, line)
+	}
+	w.buf.Reset()
+}
+
+// ── Flag vars ─────────────────────────────────────────────────────────────────.
+
+var (
+	daemonSchedule    string
+	daemonOnce        bool
+	daemonMetricsAddr string
+)
+
+func init() {
+	daemonCmd.Flags().StringVar(&daemonSchedule, "schedule", "", "Cron expression for jobs without a schedule of their own (e.g. \"0 3 * * *\")")
+	daemonCmd.Flags().BoolVar(&daemonOnce, "once", false, "Run every job once now and exit (e.g. to test the configuration)")
+	daemonCmd.Flags().StringVar(&daemonMetricsAddr, "metrics-addr", "", "Serve Prometheus metrics at /metrics on this address (e.g. \":9464\"; default: disabled)")
+
+	// Bind to viper.
+	viper.BindPFlag("daemon.schedule", daemonCmd.Flags().Lookup("schedule"))
+	viper.BindPFlag("daemon.once", daemonCmd.Flags().Lookup("once"))
+	viper.BindPFlag("daemon.metrics-addr", daemonCmd.Flags().Lookup("metrics-addr"))
+}
@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/idlab-discover/aibomgen-cli/internal/apperr"
+	"github.com/idlab-discover/aibomgen-cli/internal/diff"
+	"github.com/idlab-discover/aibomgen-cli/internal/ui"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/bomio"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// diffValueWidth is the length at which values are cut in text output (the.
+// raw metadata properties are kilobytes of base64).
+const diffValueWidth = 100
+
+// diffCmd represents the diff command.
+var diffCmd = &cobra.Command{
+	Use:   "diff <old> <new>",
+	Short: "Show what changed between two AIBOMs",
+	Long: `Compare two AIBOMs and report what changed between them: model card fields,
+datasets that were added or removed, changed hashes and licenses, properties,
+vulnerabilities, and the change of the completeness score.
+
+The model card is compared by meaning rather than as raw JSON: components are
+matched by bom-ref (or by type and name when the bom-ref is a random UUID),
+list fields such as licenses, datasets, use cases or limitations are compared
+as sets, and metrics, hashes and properties by their type, algorithm or name.
+
+Example:
+  aibomgen-cli diff dist/v1/org_model_aibom.json dist/v2/org_model_aibom.json
+  aibomgen-cli diff old.json new.json --output json`,
+	Args: cobra.ExactArgs(2),
+	RunE: runDiff,
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	output := strings.ToLower(strings.TrimSpace(viper.GetString("diff.output")))
+	if output == "" {
+		output = "text"
+	}
+	if output != "text" && output != "json" {
+		return apperr.Userf("invalid --output %q (expected text|json)", output)
+	}
+	inputFormat := viper.GetString("diff.format")
+	if inputFormat == "" {
+		inputFormat = "auto"
+	}
+
+	oldBOM, err := bomio.ReadBOM(args[0], inputFormat)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", args[0], err)
+	}
+	newBOM, err := bomio.ReadBOM(args[1], inputFormat)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", args[1], err)
+	}
+
+	rep := diff.Compare(oldBOM, newBOM)
+	if output == "json" {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(rep)
+	}
+	printDiffReport(cmd.OutOrStdout(), rep)
+	return nil
+}
+
+// printDiffReport writes the diff report to w.
+func printDiffReport(w io.Writer, rep diff.Report) {
+	fmt.Fprintln(w)
+	if rep.Empty() {
+		fmt.Fprintf(w, "%s\n", ui.SuccessBox.Render(fmt.Sprintf("%s No differences", ui.GetCheckMark())))
+		return
+	}
+
+	if rep.Model != nil {
+		fmt.Fprintln(w, ui.SectionHeader.Render("Model"))
+		printComponentDiff(w, *rep.Model)
+		fmt.Fprintln(w)
+	}
+	if len(rep.Components) > 0 {
+		fmt.Fprintln(w, ui.SectionHeader.Render("Components"))
+		for _, c := range rep.Components {
+			printComponentDiff(w, c)
+		}
+		fmt.Fprintln(w)
+	}
+	if len(rep.Vulnerabilities.Added) > 0 || len(rep.Vulnerabilities.Removed) > 0 {
+		fmt.Fprintln(w, ui.SectionHeader.Render("Vulnerabilities"))
+		for _, id := range rep.Vulnerabilities.Added {
+			fmt.Fprintf(w, "  + %s\n", id)
+		}
+		for _, id := range rep.Vulnerabilities.Removed {
+			fmt.Fprintf(w, "  - %s\n", id)
+		}
+		fmt.Fprintln(w)
+	}
+
+	c := rep.Completeness
+	fmt.Fprintf(w, "%s %.1f%% → %.1f%% %s\n", ui.Bold.Render("Completeness:"), c.Old*100, c.New*100,
+		ui.Muted.Render(fmt.Sprintf("(%+.1f)", c.Delta*100)))
+}
+
+func printComponentDiff(w io.Writer, d diff.ComponentDiff) {
+	mark := "~"
+	switch d.Kind {
+	case diff.Added:
+		mark = "+"
+	case diff.Removed:
+		mark = "-"
+	}
+	fmt.Fprintf(w, "  %s %s %s\n", mark, ui.Bold.Render(d.Name), ui.Muted.Render("("+d.Type+", "+string(d.Kind)+")"))
+	for _, ch := range d.Changes {
+		switch ch.Kind {
+		case diff.Added:
+			fmt.Fprintf(w, "      %s %s\n", ui.Muted.Render(ch.Field+":"), "+ "+diffValue(ch.New))
+		case diff.Removed:
+			fmt.Fprintf(w, "      %s %s\n", ui.Muted.Render(ch.Field+":"), "- "+diffValue(ch.Old))
+		default:
+			fmt.Fprintf(w, "      %s %s → %s\n", ui.Muted.Render(ch.Field+":"), diffValue(ch.Old), diffValue(ch.New))
+		}
+	}
+}
+
+// diffValue shortens v to one line of at most diffValueWidth characters.
+func diffValue(v string) string {
+	v = strings.Join(strings.Fields(v), " ")
+	if r := []rune(v); len(r) > diffValueWidth {
+		return string(r[:diffValueWidth-1]) + "…"
+	}
+	return v
+}
+
+// ── Flag vars ─────────────────────────────────────────────────────────────────.
+
+var (
+	diffFormat string
+	diffOutput string
+)
+
+func init() {
+	diffCmd.Flags().StringVarP(&diffFormat, "format", "f", "", "Input BOM format: json|xml|spdx-json|auto")
+	diffCmd.Flags().StringVar(&diffOutput, "output", "text", "Report format: text|json")
+
+	viper.BindPFlag("diff.format", diffCmd.Flags().Lookup("format"))
+	viper.BindPFlag("diff.output", diffCmd.Flags().Lookup("output"))
+}
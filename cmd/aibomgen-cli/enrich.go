@@ -1,13 +1,19 @@
 package cmd
 
 import (
+	"crypto/ed25519"
 	"fmt"
+	"os"
 	"strings"
 
+	cdx "github.com/CycloneDX/cyclonedx-go"
 	"github.com/idlab-discover/aibomgen-cli/internal/apperr"
+	"github.com/idlab-discover/aibomgen-cli/internal/attest"
+	"github.com/idlab-discover/aibomgen-cli/internal/audit"
 	"github.com/idlab-discover/aibomgen-cli/internal/enricher"
 	"github.com/idlab-discover/aibomgen-cli/internal/ui"
 	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/bomio"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/completeness"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -18,111 +24,311 @@ var enrichCmd = &cobra.Command{
 	Short: "Enrich an existing AIBOM with additional metadata",
 	Long: `Enrich an existing AIBOM with additional metadata through interactive prompts
 or by loading values from a configuration file. Optionally refetch model metadata
-from Hugging Face API and README before enrichment.`,
+from Hugging Face API and README before enrichment.
+
+With --answers, the values of a declarative answers file are applied instead,
+without prompts, and every applied or rejected value is reported. Nothing is
+written when a value is rejected, or at all with --dry-run.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		// Get strategy from viper (respects config file).
-		strategy := strings.ToLower(strings.TrimSpace(viper.GetString("enrich.strategy")))
-		if strategy == "" {
-			strategy = "interactive"
-		}
-		switch strategy {
-		case "interactive", "file":
-			// ok.
-		default:
-			return apperr.Userf("invalid strategy %q (expected interactive|file)", strategy)
+		return runEnrich(cmd, viper.GetString("enrich.input"), enrichScope{})
+	},
+}
+
+// enrichScope narrows an enrich run to one component of the BOM, as the.
+// review dashboard does. The zero value enriches the whole BOM as configured.
+type enrichScope struct {
+	strategy  string // overrides enrich.strategy when set
+	modelOnly bool   // only the model component
+	dataset   string // only this dataset component
+}
+
+// narrowed reports whether the run is limited to one component.
+func (s enrichScope) narrowed() bool {
+	return s.modelOnly || s.dataset != ""
+}
+
+// runEnrich enriches the BOM at inputPath with the enrich.* settings.
+func runEnrich(cmd *cobra.Command, inputPath string, scope enrichScope) error {
+	// Get strategy from viper (respects config file).
+	strategy := scope.strategy
+	if strategy == "" {
+		strategy = strings.ToLower(strings.TrimSpace(viper.GetString("enrich.strategy")))
+	}
+	if strategy == "" {
+		strategy = "interactive"
+	}
+	switch strategy {
+	case "interactive", "file":
+		// ok.
+	default:
+		return apperr.Userf("invalid strategy %q (expected interactive|file)", strategy)
+	}
+
+	// Get log level from viper.
+	level := strings.ToLower(strings.TrimSpace(viper.GetString("enrich.log-level")))
+	if level == "" {
+		level = "standard"
+	}
+	switch level {
+	case "quiet", "standard", "debug":
+		// ok.
+	default:
+		return apperr.Userf("invalid --log-level %q (expected quiet|standard|debug)", level)
+	}
+
+	// Read existing BOM.
+	if inputPath == "" {
+		return apperr.User("--input is required")
+	}
+	inputFormat := viper.GetString("enrich.format")
+	if inputFormat == "" {
+		inputFormat = "auto"
+	}
+	bom, err := bomio.ReadBOM(inputPath, inputFormat)
+	if err != nil {
+		return fmt.Errorf("failed to read input BOM: %w", err)
+	}
+
+	// Determine output path. A run narrowed to one component writes back to.
+	// the BOM it was started on.
+	outPath := viper.GetString("enrich.output")
+	if outPath == "" || scope.narrowed() {
+		outPath = inputPath // overwrite by default
+	}
+
+	// Get settings from viper (respects config file).
+	specVersion := strings.TrimSpace(viper.GetString("enrich.spec"))
+	outputFormat := viper.GetString("enrich.output-format")
+	if outputFormat == "" {
+		outputFormat = "auto"
+	}
+
+	// An answers file replaces the prompts and the file strategy. Runs.
+	// narrowed by the review dashboard stay interactive.
+	answersPath := strings.TrimSpace(viper.GetString("enrich.answers"))
+	if scope.narrowed() {
+		answersPath = ""
+	}
+	dryRun := viper.GetBool("enrich.dry-run")
+	if dryRun && answersPath == "" {
+		return apperr.User("--dry-run requires --answers")
+	}
+
+	// Pre-supplied prompt answers from --answer and AIBOMGEN_ANSWER_*.
+	answers, err := enricher.ParseAnswers(viper.GetStringSlice("enrich.answer"), os.Environ())
+	if err != nil {
+		return err
+	}
+
+	// Interactive sessions keep a draft of entered values so quitting.
+	// midway does not lose them.
+	var draftPath string
+	if strategy == "interactive" && answersPath == "" && !viper.GetBool("enrich.no-draft") {
+		if p, err := enricher.DraftPath(inputPath); err == nil {
+			draftPath = p
 		}
+	}
 
-		// Get log level from viper.
-		level := strings.ToLower(strings.TrimSpace(viper.GetString("enrich.log-level")))
-		if level == "" {
-			level = "standard"
+	// Previously used answers are offered as suggestions in interactive.
+	// sessions. A shared history file gives organization-wide suggestions.
+	historyPath := strings.TrimSpace(viper.GetString("enrich.history-file"))
+	if historyPath == "" {
+		if p, err := enricher.DefaultHistoryPath(); err == nil {
+			historyPath = p
 		}
-		switch level {
-		case "quiet", "standard", "debug":
-			// ok.
-		default:
-			return apperr.Userf("invalid --log-level %q (expected quiet|standard|debug)", level)
+	}
+	if viper.GetBool("enrich.no-history") {
+		historyPath = ""
+	}
+
+	// Manually supplied values are recorded in an append-only audit log and.
+	// as annotations on the BOM, attributed to the actor.
+	actor := strings.TrimSpace(viper.GetString("enrich.actor"))
+	if actor == "" {
+		actor = audit.DefaultActor()
+	}
+	auditPath := strings.TrimSpace(viper.GetString("enrich.audit-log"))
+	if auditPath == "" {
+		if p, err := audit.DefaultPath(); err == nil {
+			auditPath = p
 		}
+	}
+	noAudit := viper.GetBool("enrich.no-audit")
 
-		// Read existing BOM.
-		inputPath := viper.GetString("enrich.input")
-		if inputPath == "" {
-			return apperr.User("--input is required")
+	// With a signing key, every manually supplied field is also signed as.
+	// a claim of its own, written next to the output BOM.
+	var signKey ed25519.PrivateKey
+	if raw := viper.GetString("enrich.sign-key"); strings.TrimSpace(raw) != "" {
+		signKey, err = attest.ParsePrivateKey(raw)
+		if err != nil {
+			return apperr.Userf("invalid --sign-key: %v", err)
 		}
-		inputFormat := viper.GetString("enrich.format")
-		if inputFormat == "" {
-			inputFormat = "auto"
+	}
+
+	// Canonical option lists per field key, from the config file only.
+	fieldOptions, err := enricher.ParseFieldOptions(viper.Get("enrich.options"))
+	if err != nil {
+		return err
+	}
+
+	// The live score preview marks the policy threshold, which defaults to.
+	// the validate command's minimum score.
+	targetScore := viper.GetFloat64("enrich.target-score")
+	if targetScore <= 0 {
+		targetScore = viper.GetFloat64("validate.min-score")
+	}
+
+	// Build enricher configuration.
+	cfg := enricher.Config{
+		Strategy:      strategy,
+		ConfigFile:    viper.GetString("enrich.file"),
+		RequiredOnly:  viper.GetBool("enrich.required-only"),
+		MinWeight:     viper.GetFloat64("enrich.min-weight"),
+		Refetch:       viper.GetBool("enrich.refetch"),
+		NoPreview:     viper.GetBool("enrich.no-preview"),
+		SpecVersion:   specVersion,
+		HFToken:       viper.GetString("enrich.hf-token"),
+		HFBaseURL:     viper.GetString("enrich.hf-base-url"),
+		HFTimeout:     viper.GetInt("enrich.hf-timeout"),
+		Answers:       answers,
+		DraftPath:     draftPath,
+		HistoryPath:   historyPath,
+		Options:       fieldOptions,
+		StrictOptions: viper.GetBool("enrich.strict-options"),
+		TargetScore:   targetScore,
+		Datasets:      viper.GetStringSlice("enrich.datasets"),
+		Unset:         viper.GetStringSlice("enrich.unset"),
+		Actor:         actor,
+	}
+	if scope.modelOnly {
+		cfg.SkipDatasets = true
+	}
+	if scope.dataset != "" {
+		cfg.SkipModel = true
+		cfg.Datasets = []string{scope.dataset}
+	}
+
+	// Load config file values if using file strategy.
+	var configViper *viper.Viper
+	if strategy == "file" && answersPath == "" {
+		configFile := cfg.ConfigFile
+		if configFile == "" {
+			configFile = "./config/enrichment.yaml"
 		}
-		bom, err := bomio.ReadBOM(inputPath, inputFormat)
+		configViper, err = loadEnrichmentConfig(configFile)
 		if err != nil {
-			return fmt.Errorf("failed to read input BOM: %w", err)
-		}
-
-		// Determine output path.
-		outPath := viper.GetString("enrich.output")
-		if outPath == "" {
-			outPath = inputPath // overwrite by default
-		}
-
-		// Get settings from viper (respects config file).
-		specVersion := strings.TrimSpace(viper.GetString("enrich.spec"))
-		outputFormat := viper.GetString("enrich.output-format")
-		if outputFormat == "" {
-			outputFormat = "auto"
-		}
-
-		// Build enricher configuration.
-		cfg := enricher.Config{
-			Strategy:     strategy,
-			ConfigFile:   viper.GetString("enrich.file"),
-			RequiredOnly: viper.GetBool("enrich.required-only"),
-			MinWeight:    viper.GetFloat64("enrich.min-weight"),
-			Refetch:      viper.GetBool("enrich.refetch"),
-			NoPreview:    viper.GetBool("enrich.no-preview"),
-			SpecVersion:  specVersion,
-			HFToken:      viper.GetString("enrich.hf-token"),
-			HFBaseURL:    viper.GetString("enrich.hf-base-url"),
-			HFTimeout:    viper.GetInt("enrich.hf-timeout"),
-		}
-
-		// Load config file values if using file strategy.
-		var configViper *viper.Viper
-		if strategy == "file" {
-			configFile := cfg.ConfigFile
-			if configFile == "" {
-				configFile = "./config/enrichment.yaml"
-			}
-			configViper, err = loadEnrichmentConfig(configFile)
-			if err != nil {
-				return fmt.Errorf("failed to load config file: %w", err)
-			}
-		}
-
-		// Create enricher.
-		e := enricher.New(enricher.Options{
-			Reader: cmd.InOrStdin(),
-			Writer: cmd.OutOrStdout(),
-			Config: cfg,
-		})
-
-		// Run enrichment.
-		enriched, err := e.Enrich(bom, configViper)
+			return fmt.Errorf("failed to load config file: %w", err)
+		}
+	}
+
+	// Create enricher.
+	e := enricher.New(enricher.Options{
+		Reader: cmd.InOrStdin(),
+		Writer: cmd.OutOrStdout(),
+		Config: cfg,
+	})
+
+	// Run enrichment.
+	var enriched *cdx.BOM
+	if answersPath != "" {
+		enriched, err = applyAnswersFile(cmd, e, bom, answersPath, dryRun, level)
+		if err != nil || enriched == nil {
+			return err
+		}
+	} else {
+		enriched, err = e.Enrich(bom, configViper)
 		if err != nil {
 			return fmt.Errorf("enrichment failed: %w", err)
 		}
+	}
 
-		// Write output.
-		if err := bomio.WriteBOM(enriched, outPath, outputFormat, specVersion); err != nil {
-			return fmt.Errorf("failed to write output: %w", err)
-		}
+	entries := e.AuditEntries()
+	if !noAudit {
+		audit.Annotate(enriched, entries)
+	}
 
+	// Write output.
+	if err := bomio.WriteBOM(enriched, outPath, outputFormat, specVersion); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	e.DiscardDraft()
+	e.RecordHistory()
+
+	if !noAudit && auditPath != "" {
+		for i := range entries {
+			entries[i].File = outPath
+		}
+		if err := audit.Append(auditPath, entries); err != nil {
+			fmt.Fprintln(os.Stderr, ui.GetWarnMark()+" "+ui.Warning.Render(fmt.Sprintf("audit log not written: %v", err)))
+		}
+	}
+	if signKey != nil && len(entries) > 0 {
+		claimsPath := strings.TrimSpace(viper.GetString("enrich.claims-file"))
+		if claimsPath == "" {
+			claimsPath = outPath + ".claims.jsonl"
+		}
+		if err := writeClaims(claimsPath, entries, signKey); err != nil {
+			return fmt.Errorf("failed to write signed claims: %w", err)
+		}
 		if level != "quiet" {
-			msg := fmt.Sprintf("Enriched BOM saved to %s", outPath)
-			fmt.Fprintf(cmd.OutOrStdout(), "\n%s\n", ui.SuccessBox.Render(ui.GetCheckMark()+" "+msg))
+			fmt.Fprintf(cmd.OutOrStdout(), "%d signed field claim(s) appended to %s\n", len(entries), claimsPath)
 		}
+	}
 
-		return nil
-	},
+	if level != "quiet" {
+		msg := fmt.Sprintf("Enriched BOM saved to %s", outPath)
+		fmt.Fprintf(cmd.OutOrStdout(), "\n%s\n", ui.SuccessBox.Render(ui.GetCheckMark()+" "+msg))
+	}
+
+	return nil
+}
+
+// applyAnswersFile applies the answers file at path to bom and reports the.
+// applied and rejected values. It returns a nil BOM, so that nothing is.
+// written, for a dry run, and an error when a value was rejected.
+func applyAnswersFile(cmd *cobra.Command, e *enricher.Enricher, bom *cdx.BOM, path string, dryRun bool, level string) (*cdx.BOM, error) {
+	file, err := enricher.LoadAnswersFile(path)
+	if err != nil {
+		return nil, err
+	}
+	before := completeness.Check(bom).Score
+	report, err := e.ApplyAnswersFile(bom, file)
+	if err != nil {
+		return nil, err
+	}
+	after := completeness.Check(bom).Score
+
+	out := cmd.OutOrStdout()
+	if level != "quiet" {
+		fmt.Fprintln(out, ui.SectionHeader.Render(fmt.Sprintf("Answers for %s", report.ModelID)))
+	}
+	for _, res := range report.Results {
+		target := res.Field
+		if res.Dataset {
+			target = fmt.Sprintf("%s (dataset %q)", res.Field, res.Component)
+		}
+		switch {
+		case !res.Applied:
+			fmt.Fprintf(out, "  %s %s %s\n", ui.GetCrossMark(), target, ui.Error.Render(fmt.Sprintf("line %d: %s", res.Line, res.Reason)))
+		case level != "quiet":
+			fmt.Fprintf(out, "  %s %s = %s\n", ui.GetCheckMark(), target, ui.Dim.Render(res.Value))
+		}
+	}
+	if level != "quiet" {
+		fmt.Fprintln(out, ui.FormatKeyValue("Completeness", fmt.Sprintf("%.1f%% → %.1f%%", before*100, after*100)))
+		fmt.Fprintln(out, ui.FormatKeyValue("Answers", fmt.Sprintf("%d applied, %d rejected", report.Applied(), report.Rejected())))
+	}
+
+	if n := report.Rejected(); n > 0 {
+		return nil, apperr.Userf("%d answer(s) in %s rejected; nothing written", n, path)
+	}
+	if dryRun {
+		if level != "quiet" {
+			fmt.Fprintln(out, ui.Muted.Render("Dry run: nothing written."))
+		}
+		return nil, nil
+	}
+	return bom, nil
 }
 
 var (
@@ -137,6 +343,21 @@ var (
 	enrichMinWeight    float64
 	enrichRefetch      bool
 	enrichNoPreview    bool
+	enrichAnswers      []string
+	enrichUnset        []string
+	enrichAnswersFile  string
+	enrichDryRun       bool
+	enrichNoDraft      bool
+	enrichDatasets     []string
+	enrichHistoryFile  string
+	enrichNoHistory    bool
+	enrichActor        string
+	enrichAuditLog     string
+	enrichNoAudit      bool
+	enrichSignKey      string
+	enrichClaimsFile   string
+	enrichStrictOpts   bool
+	enrichTargetScore  float64
 	enrichLogLevel     string
 	enrichHFToken      string
 	enrichHFBaseURL    string
@@ -146,8 +367,8 @@ var (
 func init() {
 	enrichCmd.Flags().StringVarP(&enrichInput, "input", "i", "", "Path to existing AIBOM (required)")
 	enrichCmd.Flags().StringVarP(&enrichOutput, "output", "o", "", "Output file path (default: overwrite input)")
-	enrichCmd.Flags().StringVarP(&enrichInputFormat, "format", "f", "", "Input BOM format: json|xml|auto")
-	enrichCmd.Flags().StringVar(&enrichOutputFormat, "output-format", "", "Output BOM format: json|xml|auto")
+	enrichCmd.Flags().StringVarP(&enrichInputFormat, "format", "f", "", "Input BOM format: json|xml|spdx-json|auto")
+	enrichCmd.Flags().StringVar(&enrichOutputFormat, "output-format", "", "Output BOM format: json|xml|spdx-json|auto")
 	enrichCmd.Flags().StringVar(&enrichSpecVersion, "spec", "", "CycloneDX spec version for output (default: same as input)")
 
 	enrichCmd.Flags().StringVar(&enrichStrategy, "strategy", "", "Enrichment strategy: interactive|file")
@@ -156,6 +377,21 @@ func init() {
 	enrichCmd.Flags().Float64Var(&enrichMinWeight, "min-weight", 0.0, "Only prompt for fields with weight >= this value")
 	enrichCmd.Flags().BoolVar(&enrichRefetch, "refetch", false, "Refetch model metadata from Hugging Face before enrichment")
 	enrichCmd.Flags().BoolVar(&enrichNoPreview, "no-preview", false, "Skip preview before saving")
+	enrichCmd.Flags().StringSliceVar(&enrichDatasets, "datasets", nil, "Only enrich these dataset components (comma-separated names; default: all, or choose interactively)")
+	enrichCmd.Flags().StringVar(&enrichHistoryFile, "history-file", "", "Answers history used for suggestions (default: user config directory; point at a shared file for organization-wide suggestions)")
+	enrichCmd.Flags().BoolVar(&enrichNoHistory, "no-history", false, "Do not suggest or record previously used answers")
+	enrichCmd.Flags().StringVar(&enrichActor, "actor", "", "Person recorded as the author of manually supplied values (default: current OS user)")
+	enrichCmd.Flags().StringVar(&enrichAuditLog, "audit-log", "", "Append-only log of manually set and removed fields (default: user config directory)")
+	enrichCmd.Flags().BoolVar(&enrichNoAudit, "no-audit", false, "Do not record manual changes in the audit log or as BOM annotations")
+	enrichCmd.Flags().StringVar(&enrichSignKey, "sign-key", "", "Ed25519 private key (PEM, base64, or a file holding either) used to sign every manually supplied field as a claim")
+	enrichCmd.Flags().StringVar(&enrichClaimsFile, "claims-file", "", "File the signed field claims are appended to (default: <output>.claims.jsonl)")
+	enrichCmd.Flags().BoolVar(&enrichStrictOpts, "strict-options", false, "Only accept the option lists configured under enrich.options as field values")
+	enrichCmd.Flags().Float64Var(&enrichTargetScore, "target-score", 0.0, "Completeness target (0.0-1.0) shown in the live score preview (default: validate.min-score)")
+	enrichCmd.Flags().BoolVar(&enrichNoDraft, "no-draft", false, "Do not save or resume drafts of unfinished interactive sessions")
+	enrichCmd.Flags().StringArrayVar(&enrichAnswers, "answer", nil, "Pre-answer an interactive field as key=value (repeatable; e.g. licenses=mit, dataset.description=...)")
+	enrichCmd.Flags().StringArrayVar(&enrichUnset, "unset", nil, "Remove a field from the BOM before enrichment (repeatable; same key forms as --answer)")
+	enrichCmd.Flags().StringVar(&enrichAnswersFile, "answers", "", "Apply the field values of a YAML answers file (per model ID) instead of prompting")
+	enrichCmd.Flags().BoolVar(&enrichDryRun, "dry-run", false, "With --answers, report the applied and rejected values without writing the BOM")
 
 	enrichCmd.Flags().StringVar(&enrichLogLevel, "log-level", "", "Log level: quiet|standard|debug")
 	enrichCmd.Flags().StringVar(&enrichHFToken, "hf-token", "", "Hugging Face API token (for refetch)")
@@ -174,6 +410,21 @@ func init() {
 	viper.BindPFlag("enrich.min-weight", enrichCmd.Flags().Lookup("min-weight"))
 	viper.BindPFlag("enrich.refetch", enrichCmd.Flags().Lookup("refetch"))
 	viper.BindPFlag("enrich.no-preview", enrichCmd.Flags().Lookup("no-preview"))
+	viper.BindPFlag("enrich.answer", enrichCmd.Flags().Lookup("answer"))
+	viper.BindPFlag("enrich.unset", enrichCmd.Flags().Lookup("unset"))
+	viper.BindPFlag("enrich.answers", enrichCmd.Flags().Lookup("answers"))
+	viper.BindPFlag("enrich.dry-run", enrichCmd.Flags().Lookup("dry-run"))
+	viper.BindPFlag("enrich.no-draft", enrichCmd.Flags().Lookup("no-draft"))
+	viper.BindPFlag("enrich.datasets", enrichCmd.Flags().Lookup("datasets"))
+	viper.BindPFlag("enrich.history-file", enrichCmd.Flags().Lookup("history-file"))
+	viper.BindPFlag("enrich.no-history", enrichCmd.Flags().Lookup("no-history"))
+	viper.BindPFlag("enrich.actor", enrichCmd.Flags().Lookup("actor"))
+	viper.BindPFlag("enrich.audit-log", enrichCmd.Flags().Lookup("audit-log"))
+	viper.BindPFlag("enrich.no-audit", enrichCmd.Flags().Lookup("no-audit"))
+	viper.BindPFlag("enrich.sign-key", enrichCmd.Flags().Lookup("sign-key"))
+	viper.BindPFlag("enrich.claims-file", enrichCmd.Flags().Lookup("claims-file"))
+	viper.BindPFlag("enrich.strict-options", enrichCmd.Flags().Lookup("strict-options"))
+	viper.BindPFlag("enrich.target-score", enrichCmd.Flags().Lookup("target-score"))
 	viper.BindPFlag("enrich.log-level", enrichCmd.Flags().Lookup("log-level"))
 	viper.BindPFlag("enrich.hf-token", enrichCmd.Flags().Lookup("hf-token"))
 	viper.BindPFlag("enrich.hf-base-url", enrichCmd.Flags().Lookup("hf-base-url"))
@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/internal/apperr"
+	"github.com/idlab-discover/aibomgen-cli/internal/notices"
+	"github.com/idlab-discover/aibomgen-cli/internal/ui"
+	"github.com/idlab-discover/aibomgen-cli/internal/viewer"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/bomio"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// exportCmd represents the export command.
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export documents derived from one or more AIBOMs (third-party notices, HTML viewer)",
+	Long: `Export documents derived from the model and dataset components of one or
+more existing AIBOMs.
+
+With --notices, a NOTICE / third-party attributions document is generated from
+all model and dataset licenses, attached license texts, copyright holders and
+required attribution statements.
+Entries are deduplicated across the input BOMs and sorted: models first, then
+datasets, each by name. Use --template to render the entries with your own
+Go text/template.
+
+With --viewer, a self-contained HTML page is generated that embeds the BOMs and
+shows their components in a searchable table, the model cards and the
+completeness scores. The page works offline: send it to anyone who needs to
+inspect a BOM without the CLI.
+
+Examples:
+  aibomgen-cli export --notices -i dist/model1_aibom.json -i dist/model2_aibom.json -o THIRD_PARTY_NOTICES.txt
+  aibomgen-cli export --viewer -i dist/model1_aibom.json -o model1.html`,
+	RunE: runExport,
+}
+
+func runExport(cmd *cobra.Command, _ []string) error {
+	noticesMode := viper.GetBool("export.notices")
+	viewerMode := viper.GetBool("export.viewer")
+	switch {
+	case noticesMode && viewerMode:
+		return apperr.User("--notices and --viewer cannot be used together")
+	case !noticesMode && !viewerMode:
+		return apperr.User("nothing to export: pass --notices or --viewer")
+	}
+
+	inputPaths := viper.GetStringSlice("export.input")
+	if len(inputPaths) == 0 {
+		return apperr.User("at least one --input is required")
+	}
+
+	inputFormat := viper.GetString("export.format")
+	if inputFormat == "" {
+		inputFormat = "auto"
+	}
+
+	var tmpl string
+	if path := strings.TrimSpace(viper.GetString("export.template")); path != "" {
+		if viewerMode {
+			return apperr.User("--template can only be used with --notices")
+		}
+		t, err := notices.LoadTemplate(path)
+		if err != nil {
+			return apperr.User(err.Error())
+		}
+		tmpl = t
+	}
+
+	boms := make([]*cdx.BOM, 0, len(inputPaths))
+	for _, p := range inputPaths {
+		bom, err := bomio.ReadBOM(p, inputFormat)
+		if err != nil {
+			return fmt.Errorf("failed to read AIBOM %s: %w", p, err)
+		}
+		boms = append(boms, bom)
+	}
+
+	if viewerMode {
+		return writeViewer(cmd, inputPaths, boms)
+	}
+
+	entries := notices.Collect(boms...)
+
+	outputPath := strings.TrimSpace(viper.GetString("export.output"))
+	if outputPath == "" || outputPath == "-" {
+		return notices.Render(cmd.OutOrStdout(), entries, tmpl)
+	}
+
+	f, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to create notices file: %w", err)
+	}
+	if err := notices.Render(f, entries, tmpl); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write notices file: %w", err)
+	}
+
+	fmt.Fprintln(cmd.OutOrStdout(), ui.SuccessBox.Render(fmt.Sprintf("%s Wrote notices for %d component(s) to %s", ui.GetCheckMark(), len(entries), outputPath)))
+	return nil
+}
+
+// writeViewer writes the HTML viewer of boms, read from paths.
+func writeViewer(cmd *cobra.Command, paths []string, boms []*cdx.BOM) error {
+	profile, err := loadCompletenessProfile(viper.GetString("export.profile"))
+	if err != nil {
+		return err
+	}
+	inputs := make([]viewer.Input, len(boms))
+	for i, bom := range boms {
+		inputs[i] = viewer.Input{Name: filepath.Base(paths[i]), BOM: bom}
+	}
+	opts := viewer.Options{Title: strings.TrimSpace(viper.GetString("export.title")), Profile: profile, Locale: ui.Locale()}
+
+	outputPath := strings.TrimSpace(viper.GetString("export.output"))
+	if outputPath == "" || outputPath == "-" {
+		return viewer.Render(cmd.OutOrStdout(), inputs, opts)
+	}
+
+	f, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to create viewer file: %w", err)
+	}
+	if err := viewer.Render(f, inputs, opts); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write viewer file: %w", err)
+	}
+
+	fmt.Fprintln(cmd.OutOrStdout(), ui.SuccessBox.Render(fmt.Sprintf("%s Wrote the viewer for %d AIBOM(s) to %s", ui.GetCheckMark(), len(inputs), outputPath)))
+	return nil
+}
+
+// ── Flag vars ─────────────────────────────────────────────────────────────────.
+
+var (
+	exportInputs   []string
+	exportFormat   string
+	exportOutput   string
+	exportNotices  bool
+	exportTemplate string
+	exportViewer   bool
+	exportTitle    string
+	exportProfile  string
+)
+
+func init() {
+	exportCmd.Flags().StringSliceVarP(&exportInputs, "input", "i", []string{}, "Path to AIBOM file (can be specified multiple times, required)")
+	exportCmd.Flags().StringVarP(&exportFormat, "format", "f", "", "Input BOM format: json|xml|spdx-json|auto")
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "Output file (default: stdout)")
+	exportCmd.Flags().BoolVar(&exportNotices, "notices", false, "Generate a NOTICE / third-party attributions document")
+	exportCmd.Flags().StringVar(&exportTemplate, "template", "", "Go text/template file used to render the notices")
+	exportCmd.Flags().BoolVar(&exportViewer, "viewer", false, "Generate a self-contained HTML page to inspect the AIBOMs in a browser")
+	exportCmd.Flags().StringVar(&exportTitle, "title", "", "Title of the viewer page (default: AIBOM viewer)")
+	exportCmd.Flags().StringVar(&exportProfile, "profile", "", "Weights profile (YAML/JSON) for the completeness scores of the viewer")
+
+	// Bind to viper.
+	viper.BindPFlag("export.input", exportCmd.Flags().Lookup("input"))
+	viper.BindPFlag("export.format", exportCmd.Flags().Lookup("format"))
+	viper.BindPFlag("export.output", exportCmd.Flags().Lookup("output"))
+	viper.BindPFlag("export.notices", exportCmd.Flags().Lookup("notices"))
+	viper.BindPFlag("export.template", exportCmd.Flags().Lookup("template"))
+	viper.BindPFlag("export.viewer", exportCmd.Flags().Lookup("viewer"))
+	viper.BindPFlag("export.title", exportCmd.Flags().Lookup("title"))
+	viper.BindPFlag("export.profile", exportCmd.Flags().Lookup("profile"))
+}
@@ -1,9 +1,11 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -11,7 +13,9 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/idlab-discover/aibomgen-cli/internal/apperr"
+	"github.com/idlab-discover/aibomgen-cli/internal/bomcache"
 	"github.com/idlab-discover/aibomgen-cli/internal/fetcher"
+	"github.com/idlab-discover/aibomgen-cli/internal/signing"
 	"github.com/idlab-discover/aibomgen-cli/internal/ui"
 	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/bomio"
 	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/generator"
@@ -25,25 +29,62 @@ var (
 
 	// hfMode controls whether metadata is fetched from Hugging Face.
 	// Supported values: online|dummy.
-	hfMode    string
-	hfTimeout int
-	hfToken   string
+	hfMode       string
+	hfTimeout    int
+	hfToken      string
+	hfRetries    int
+	hfMaxBackoff int
+
+	// Per-stage timeouts and the per-model deadline, in seconds.
+	hfAPITimeout     int
+	hfReadmeTimeout  int
+	hfDatasetTimeout int
+	modelDeadline    int
 
 	// Logging is controlled via generateLogLevel.
 	generateLogLevel string
 
+	// generateProgress selects the progress output: ui|jsonl.
+	generateProgress string
+
 	// interactive enables the interactive model selector.
 	interactive bool
 
 	// noSecurityScan disables the HF tree security scan fetch.
 	noSecurityScan bool
+
+	// generateNoBOMCache bypasses the local cache of generated BOMs.
+	generateNoBOMCache bool
+	// generateIncludeRawMetadata stores the raw HF metadata on model components.
+	generateIncludeRawMetadata bool
+	// generateBaseModelDepth is the number of base_model levels followed.
+	generateBaseModelDepth int
+
+	// generateConcurrency is the number of models generated at the same time.
+	generateConcurrency int
+
+	// Revision history of regenerated BOMs.
+	generatePrevious       string
+	generateNoVersionChain bool
+
+	// generateLifecycle overrides the CycloneDX lifecycle phase of the BOMs.
+	generateLifecycle string
+
+	// generateNoHooks skips the hooks.post-generate commands.
+	generateNoHooks bool
+
+	// generateLocalPath builds the BOM from a local model repo (offline).
+	generateLocalPath string
+
+	// generateSign signs each written BOM with the sign settings.
+	generateSign bool
 )
 
 // generateCmd represents the generate command.
 var generateCmd = &cobra.Command{
 	Use:   "generate",
 	Short: "Generate an AI-aware BOM (AIBOM) from Hugging Face model IDs",
-	Long:  "Generate BOM from Hugging Face model ID(s). Use --model-id to specify models directly or --interactive for a model selector. Use 'scan' command to scan directories for AI imports.",
+	Long:  "Generate BOM from Hugging Face model ID(s). Use --model-id to specify models directly or --interactive for a model selector. Use --local-path to build the BOM from a downloaded or cloned model repo without any Hugging Face API calls. Use 'scan' command to scan directories for AI imports.",
 	RunE:  runGenerate,
 }
 
@@ -59,6 +100,9 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 	default:
 		return apperr.Userf("invalid --log-level %q (expected quiet|standard|debug)", level)
 	}
+	if err := setProgressStream(viper.GetString("generate.progress")); err != nil {
+		return err
+	}
 
 	quiet := level == "quiet"
 
@@ -73,6 +117,21 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 	default:
 		return apperr.Userf("invalid --hf-mode %q (expected online|dummy)", mode)
 	}
+	if n := viper.GetInt("generate.concurrency"); n < 1 {
+		return apperr.Userf("invalid --concurrency %d (expected at least 1)", n)
+	}
+	if n := viper.GetInt("generate.base-model-depth"); n < 0 {
+		return apperr.Userf("invalid --base-model-depth %d (expected 0 or more)", n)
+	}
+
+	var signOpts signing.Options
+	if viper.GetBool("generate.sign") {
+		opts, err := signOptions()
+		if err != nil {
+			return err
+		}
+		signOpts = opts
+	}
 
 	// Check if --interactive was explicitly provided.
 	interactiveMode := viper.GetBool("generate.interactive")
@@ -108,6 +167,30 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// A local model repo is read offline; --model-id only names the model.
+	localPath := strings.TrimSpace(viper.GetString("generate.local-path"))
+	if localPath != "" {
+		if interactiveMode {
+			return apperr.User("--interactive cannot be used with --local-path")
+		}
+		if mode == "dummy" {
+			return apperr.User("--local-path cannot be used with --hf-mode=dummy")
+		}
+		if len(cleanModelIDs) > 1 {
+			return apperr.User("--local-path accepts at most one --model-id")
+		}
+		info, err := os.Stat(localPath)
+		if err != nil {
+			return apperr.Userf("cannot read --local-path %s: %v", localPath, err)
+		}
+		if !info.IsDir() {
+			return apperr.Userf("--local-path %s is not a directory", localPath)
+		}
+		if len(cleanModelIDs) == 0 {
+			cleanModelIDs = []string{fetcher.LocalModelID(localPath)}
+		}
+	}
+
 	// Validate that we have either model IDs or interactive mode for non-dummy modes.
 	if !interactiveMode && len(cleanModelIDs) == 0 && mode != "dummy" {
 		return apperr.User("either --model-id or --interactive is required. Use 'scan' command to scan directories")
@@ -120,6 +203,10 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 	}
 
 	specVersion := viper.GetString("generate.spec")
+	lifecycle, err := generator.ParseLifecyclePhase(viper.GetString("generate.lifecycle"))
+	if err != nil {
+		return apperr.User(err.Error())
+	}
 	outputPath := viper.GetString("generate.output")
 
 	// Fail fast on format/extension mismatch.
@@ -128,7 +215,7 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 		if outputFormat == "xml" && ext == ".json" {
 			return apperr.Userf("output path extension %q does not match format %q", ext, outputFormat)
 		}
-		if outputFormat == "json" && ext == ".xml" {
+		if (outputFormat == "json" || outputFormat == bomio.FormatSPDXJSON) && ext == ".xml" {
 			return apperr.Userf("output path extension %q does not match format %q", ext, outputFormat)
 		}
 	}
@@ -145,7 +232,6 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 	genUI := ui.NewGenerateUI(cmd.OutOrStdout(), quiet)
 
 	var discoveredBOMs []generator.DiscoveredBOM
-	var err error
 
 	if interactiveMode {
 		// Interactive mode: show model selector.
@@ -163,7 +249,9 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 	}
 
 	// Generate BOMs from model IDs.
-	err = runModelIDMode(genUI, cleanModelIDs, mode, hfToken, timeout, quiet, &discoveredBOMs)
+	bomCache := openBOMCache(viper.GetBool("generate.no-bom-cache"))
+	cmd.SetContext(withBOMCache(cmd.Context(), bomCache))
+	err = runModelIDMode(genUI, cleanModelIDs, localPath, mode, hfToken, timeout, quiet, bomCache, &discoveredBOMs)
 	if err != nil {
 		return err
 	}
@@ -181,9 +269,12 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 	fmtChosen := outputFormat
 	if fmtChosen == "auto" || fmtChosen == "" {
 		ext := filepath.Ext(output)
-		if ext == ".xml" {
+		switch {
+		case ext == ".xml":
 			fmtChosen = "xml"
-		} else {
+		case strings.HasSuffix(strings.ToLower(output), ".spdx.json"):
+			fmtChosen = bomio.FormatSPDXJSON
+		default:
 			fmtChosen = "json"
 		}
 	}
@@ -198,12 +289,25 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 	}
 
 	fileExt := ".json"
-	if fmtChosen == "xml" {
+	switch fmtChosen {
+	case "xml":
 		fileExt = ".xml"
+	case bomio.FormatSPDXJSON:
+		fileExt = ".spdx.json"
 	}
 
 	// Write output files.
-	written, err := bomio.WriteOutputFiles(discoveredBOMs, outputDir, fileExt, fmtChosen, specVersion)
+	generator.ApplyLifecycle(discoveredBOMs, lifecycle)
+
+	chain := bomio.ChainOptions{
+		Disabled:    viper.GetBool("generate.no-version-chain"),
+		PreviousDir: viper.GetString("generate.previous"),
+		Compare:     compareScores,
+	}
+	written, err := writeTraced(discoveredBOMs, func() ([]string, error) {
+		return bomio.WriteOutputFiles(discoveredBOMs, outputDir, fileExt, fmtChosen, specVersion, chain)
+	})
+	noteWritten(discoveredBOMs, written)
 	if err != nil {
 		return err
 	}
@@ -215,10 +319,19 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 	}
 
 	genUI.PrintSummary(len(written), outputDir, fmtChosen)
-	return nil
+	if viper.GetBool("generate.sign") {
+		bundles, err := signBOMs(cmd.Context(), written, signOpts)
+		for _, b := range bundles {
+			genUI.LogStep("success", "Signed "+b)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return runPostGenerateHooks(cmd, genUI, discoveredBOMs, written, outputDir, fmtChosen, viper.GetBool("generate.no-hooks"))
 }
 
-func runModelIDMode(genUI *ui.GenerateUI, modelIDs []string, mode, hfToken string, timeout time.Duration, quiet bool, results *[]generator.DiscoveredBOM) error {
+func runModelIDMode(genUI *ui.GenerateUI, modelIDs []string, localPath, mode, hfToken string, timeout time.Duration, quiet bool, cache *bomcache.Cache, results *[]generator.DiscoveredBOM) error {
 	hasToken := strings.TrimSpace(hfToken) != ""
 	if mode == "dummy" {
 		if !quiet {
@@ -231,6 +344,9 @@ func runModelIDMode(genUI *ui.GenerateUI, modelIDs []string, mode, hfToken strin
 		*results = boms
 		return nil
 	}
+	if localPath != "" && !quiet {
+		genUI.LogStep("info", "Reading local model repo "+localPath+" (no API calls)")
+	}
 
 	// Track per-model outcome for the final summary.
 	// fetch warnings (non-fatal) are accumulated and shown on the single success line.
@@ -238,6 +354,7 @@ func runModelIDMode(genUI *ui.GenerateUI, modelIDs []string, mode, hfToken strin
 	// produced no AIBOM and is shown as a failure.
 	pendingModels := make(map[string]*modelTracker)
 	var modelOrder []string // insertion-order IDs for deterministic display
+	var hubErr error // set when the HF Hub was found unreachable
 
 	// Create workflow with combined processing step.
 	var workflow *ui.Workflow
@@ -260,6 +377,8 @@ func runModelIDMode(genUI *ui.GenerateUI, modelIDs []string, mode, hfToken strin
 
 	// Progress callback to update UI.
 	onProgress := func(evt generator.ProgressEvent) {
+		noteProgress(evt)
+		streamProgress(evt)
 		if quiet || workflow == nil {
 			return
 		}
@@ -275,6 +394,13 @@ func runModelIDMode(genUI *ui.GenerateUI, modelIDs []string, mode, hfToken strin
 			workflow.UpdateMessage(processTaskIdx, ui.Dim.Render(fmt.Sprintf("%d/%d: %s (fetching)", modelsCompleted, totalModels, evt.ModelID)))
 		case generator.EventFetchAPIComplete:
 			pendingModels[evt.ModelID].apiOK = true
+		case generator.EventCacheHit:
+			pendingModels[evt.ModelID].cached = true
+		case generator.EventRetry:
+			workflow.UpdateMessage(processTaskIdx, ui.Dim.Render(fmt.Sprintf("%d/%d: %s (%s)", modelsCompleted, totalModels, evt.ModelID, evt.Message)))
+		case generator.EventHubUnreachable:
+			hubErr = evt.Error
+			workflow.UpdateMessage(processTaskIdx, ui.Warning.Render(fmt.Sprintf("%d/%d: %s", modelsCompleted, totalModels, evt.Message)))
 		case generator.EventBuildStart:
 			workflow.UpdateMessage(processTaskIdx, ui.Dim.Render(fmt.Sprintf("%d/%d: %s (building)", modelsCompleted, totalModels, evt.ModelID)))
 		case generator.EventDatasetStart:
@@ -295,7 +421,9 @@ func runModelIDMode(genUI *ui.GenerateUI, modelIDs []string, mode, hfToken strin
 			// Fetch failures are non-fatal; classify them for the summary line.
 			if evt.Message != "BOM build failed" {
 				t := pendingModels[evt.ModelID]
-				if fetcher.IsNotFound(evt.Error) {
+				if errors.Is(evt.Error, fetcher.ErrHubUnreachable) {
+					t.hubDown = true
+				} else if fetcher.IsNotFound(evt.Error) {
 					t.notFound = true
 				} else if fetcher.IsUnauthorized(evt.Error) && !t.apiOK {
 					// 401/403 before the model API succeeded = model is private or non-existent.
@@ -312,13 +440,30 @@ func runModelIDMode(genUI *ui.GenerateUI, modelIDs []string, mode, hfToken strin
 	}
 
 	opts := generator.GenerateOptions{
-		HFToken:          hfToken,
-		Timeout:          timeout,
-		OnProgress:       onProgress,
-		SkipSecurityScan: noSecurityScan,
+		HFToken:            hfToken,
+		Timeout:            timeout,
+		OnProgress:         onProgress,
+		SkipSecurityScan:   noSecurityScan,
+		Cache:              cache,
+		MaxRetries:         viper.GetInt("generate.hf-retries"),
+		MaxBackoff:         time.Duration(viper.GetInt("generate.hf-max-backoff")) * time.Second,
+		APITimeout:         time.Duration(viper.GetInt("generate.hf-api-timeout")) * time.Second,
+		ReadmeTimeout:      time.Duration(viper.GetInt("generate.hf-readme-timeout")) * time.Second,
+		DatasetTimeout:     time.Duration(viper.GetInt("generate.hf-dataset-timeout")) * time.Second,
+		ModelDeadline:      time.Duration(viper.GetInt("generate.model-deadline")) * time.Second,
+		IncludeRawMetadata: viper.GetBool("generate.include-raw-metadata"),
+		Concurrency:        viper.GetInt("generate.concurrency"),
+		BaseModelDepth:     viper.GetInt("generate.base-model-depth"),
+		Context:            traceCtx,
+	}
+
+	var boms []generator.DiscoveredBOM
+	var err error
+	if localPath != "" {
+		boms, err = generator.BuildFromLocalPath(localPath, modelIDs[0], opts)
+	} else {
+		boms, err = generator.BuildFromModelIDs(modelIDs, opts)
 	}
-
-	boms, err := generator.BuildFromModelIDs(modelIDs, opts)
 	if err != nil {
 		if !quiet && workflow != nil {
 			workflow.Stop()
@@ -332,11 +477,17 @@ func runModelIDMode(genUI *ui.GenerateUI, modelIDs []string, mode, hfToken strin
 		workflow.CompleteTask(writeTaskIdx, fmt.Sprintf("%d file(s)", len(boms)))
 		workflow.Stop()
 
-		// Print individual model results after workflow completes.
-		fmt.Println()
-		for _, id := range modelOrder {
-			printModelResult(id, pendingModels[id], hasToken)
+		// Print individual model results after workflow completes, in the.
+		// order of the model IDs even when they were generated concurrently.
+		position := make(map[string]int, len(modelIDs))
+		for i, id := range modelIDs {
+			if _, ok := position[strings.TrimSpace(id)]; !ok {
+				position[strings.TrimSpace(id)] = i
+			}
 		}
+		sort.SliceStable(modelOrder, func(i, j int) bool { return position[modelOrder[i]] < position[modelOrder[j]] })
+		fmt.Println()
+		printModelResults(modelOrder, pendingModels, hubErr, hasToken)
 	}
 
 	*results = boms
@@ -346,14 +497,31 @@ func runModelIDMode(genUI *ui.GenerateUI, modelIDs []string, mode, hfToken strin
 func init() {
 	generateCmd.Flags().StringSliceVarP(&generateModelIDs, "model-id", "m", []string{}, "Hugging Face model ID(s) (e.g., gpt2 or org/model-name) - can be used multiple times or comma-separated")
 	generateCmd.Flags().StringVarP(&generateOutput, "output", "o", "", "Output file path (directory is used)")
-	generateCmd.Flags().StringVarP(&generateOutputFormat, "format", "f", "", "Output BOM format: json|xml|auto")
+	generateCmd.Flags().StringVarP(&generateOutputFormat, "format", "f", "", "Output BOM format: json|xml|spdx-json|auto")
 	generateCmd.Flags().StringVar(&generateSpecVersion, "spec", "", "CycloneDX spec version for output (e.g., 1.4, 1.5, 1.6)")
 	generateCmd.Flags().StringVar(&hfMode, "hf-mode", "", "Hugging Face metadata mode: online|dummy")
 	generateCmd.Flags().IntVar(&hfTimeout, "hf-timeout", 0, "Timeout in seconds per Hugging Face API request (default 10)")
+	generateCmd.Flags().IntVar(&hfAPITimeout, "hf-api-timeout", 0, "Timeout in seconds per model API and repository file request (default: --hf-timeout)")
+	generateCmd.Flags().IntVar(&hfReadmeTimeout, "hf-readme-timeout", 0, "Timeout in seconds per model card request (default: --hf-timeout)")
+	generateCmd.Flags().IntVar(&hfDatasetTimeout, "hf-dataset-timeout", 0, "Timeout in seconds per dataset API and dataset card request (default: --hf-timeout)")
+	generateCmd.Flags().IntVar(&modelDeadline, "model-deadline", 0, "Deadline in seconds for all requests of one model, retries included (0: none)")
+	generateCmd.Flags().IntVar(&hfRetries, "hf-retries", 3, "Retries of rate-limited (429) or transiently failing Hugging Face requests (0 disables retries)")
+	generateCmd.Flags().IntVar(&hfMaxBackoff, "hf-max-backoff", 30, "Longest wait in seconds before retrying a Hugging Face request")
 	generateCmd.Flags().StringVar(&hfToken, "hf-token", "", "Hugging Face access token")
 	generateCmd.Flags().StringVar(&generateLogLevel, "log-level", "", "Log level: quiet|standard|debug")
+	generateCmd.Flags().StringVar(&generateProgress, "progress", "", "Progress output: ui|jsonl (jsonl writes one JSON object per progress event to stderr)")
 	generateCmd.Flags().BoolVar(&interactive, "interactive", false, "Interactive model selector (cannot be used with --model-id)")
 	generateCmd.Flags().BoolVar(&noSecurityScan, "no-security-scan", false, "Skip fetching the HuggingFace security scan tree")
+	generateCmd.Flags().BoolVar(&generateNoBOMCache, "no-bom-cache", false, "Bypass the local cache of generated BOMs (cached BOMs keep their dataset and base model components until the model itself changes)")
+	generateCmd.Flags().BoolVar(&generateIncludeRawMetadata, "include-raw-metadata", false, "Store the compressed raw Hugging Face API response and model card front matter on each model component")
+	generateCmd.Flags().IntVar(&generateBaseModelDepth, "base-model-depth", 0, "Follow the base_model chain of each model card up to this many levels, adding every ancestor as a component")
+	generateCmd.Flags().IntVar(&generateConcurrency, "concurrency", 4, "Number of models fetched and built at the same time")
+	generateCmd.Flags().StringVar(&generatePrevious, "previous", "", "Directory with the previous revisions of the BOMs (default: the output directory)")
+	generateCmd.Flags().BoolVar(&generateNoVersionChain, "no-version-chain", false, "Write new BOMs (fresh serial number, version 1) instead of continuing previous revisions")
+	generateCmd.Flags().BoolVar(&generateNoHooks, "no-hooks", false, "Do not run the configured post-generate hooks")
+	generateCmd.Flags().StringVar(&generateLocalPath, "local-path", "", "Build the BOM from a downloaded or cloned model repo (config.json, README.md, file listing) without Hugging Face API calls")
+	generateCmd.Flags().BoolVar(&generateSign, "sign", false, "Sign each written BOM with cosign, using the sign settings (key, mode, identity-token)")
+	generateCmd.Flags().StringVar(&generateLifecycle, "lifecycle", "", "CycloneDX lifecycle phase of the BOMs: design|pre-build|build|post-build|operations|discovery|decommission (default: from the command context)")
 
 	// Bind all flags to viper for config file support.
 	viper.BindPFlag("generate.model-ids", generateCmd.Flags().Lookup("model-id"))
@@ -362,9 +530,26 @@ func init() {
 	viper.BindPFlag("generate.spec", generateCmd.Flags().Lookup("spec"))
 	viper.BindPFlag("generate.hf-mode", generateCmd.Flags().Lookup("hf-mode"))
 	viper.BindPFlag("generate.hf-timeout", generateCmd.Flags().Lookup("hf-timeout"))
+	viper.BindPFlag("generate.hf-api-timeout", generateCmd.Flags().Lookup("hf-api-timeout"))
+	viper.BindPFlag("generate.hf-readme-timeout", generateCmd.Flags().Lookup("hf-readme-timeout"))
+	viper.BindPFlag("generate.hf-dataset-timeout", generateCmd.Flags().Lookup("hf-dataset-timeout"))
+	viper.BindPFlag("generate.model-deadline", generateCmd.Flags().Lookup("model-deadline"))
+	viper.BindPFlag("generate.hf-retries", generateCmd.Flags().Lookup("hf-retries"))
+	viper.BindPFlag("generate.hf-max-backoff", generateCmd.Flags().Lookup("hf-max-backoff"))
 	viper.BindPFlag("generate.hf-token", generateCmd.Flags().Lookup("hf-token"))
 	viper.BindPFlag("generate.log-level", generateCmd.Flags().Lookup("log-level"))
+	viper.BindPFlag("generate.progress", generateCmd.Flags().Lookup("progress"))
 	viper.BindPFlag("generate.interactive", generateCmd.Flags().Lookup("interactive"))
+	viper.BindPFlag("generate.no-bom-cache", generateCmd.Flags().Lookup("no-bom-cache"))
+	viper.BindPFlag("generate.include-raw-metadata", generateCmd.Flags().Lookup("include-raw-metadata"))
+	viper.BindPFlag("generate.base-model-depth", generateCmd.Flags().Lookup("base-model-depth"))
+	viper.BindPFlag("generate.concurrency", generateCmd.Flags().Lookup("concurrency"))
+	viper.BindPFlag("generate.previous", generateCmd.Flags().Lookup("previous"))
+	viper.BindPFlag("generate.no-version-chain", generateCmd.Flags().Lookup("no-version-chain"))
+	viper.BindPFlag("generate.no-hooks", generateCmd.Flags().Lookup("no-hooks"))
+	viper.BindPFlag("generate.lifecycle", generateCmd.Flags().Lookup("lifecycle"))
+	viper.BindPFlag("generate.local-path", generateCmd.Flags().Lookup("local-path"))
+	viper.BindPFlag("generate.sign", generateCmd.Flags().Lookup("sign"))
 }
 
 // datasetResult holds the outcome of fetching a single dataset referenced by a model.
@@ -382,6 +567,8 @@ type modelTracker struct {
 	fetchErr       bool            // at least one non-404, post-apiOK fetch failure
 	fetchErrVal    error           // the first such error, kept for classification
 	complete       bool            // true when EventModelComplete was received
+	cached         bool            // BOM was reused from the local BOM cache
+	hubDown        bool            // a fetch failed because the HF Hub was unreachable
 	datasetResults []datasetResult // one entry per dataset referenced by the model
 }
 
@@ -414,11 +601,27 @@ func modelOutcome(t *modelTracker, hasToken bool) (mark, detail string) {
 		// fetchErr is false here; model exists but has no README.
 		return ui.GetWarnMark(), ui.Warning.Render("→ no README")
 
+	case t.cached:
+		return ui.GetCheckMark(), ui.Dim.Render("(cached)")
+
 	default:
 		return ui.GetCheckMark(), ""
 	}
 }
 
+// openBOMCache returns the local BOM cache, or nil when it is disabled or no.
+// cache directory is available (caching is an optimisation, never an error).
+func openBOMCache(disabled bool) *bomcache.Cache {
+	if disabled {
+		return nil
+	}
+	c, err := bomcache.New("")
+	if err != nil {
+		return nil
+	}
+	return c
+}
+
 // datasetOutcome derives the mark and detail string for one dataset sub-line.
 func datasetOutcome(r datasetResult, hasToken bool) (mark, detail string) {
 	if r.err == nil {
@@ -433,6 +636,27 @@ func datasetOutcome(r datasetResult, hasToken bool) (mark, detail string) {
 	return ui.GetWarnMark(), ui.Warning.Render("→ fetch failed")
 }
 
+// printModelResults prints the result of every model in order. The models.
+// built without Hub metadata because the Hub was unreachable (hubErr) share a.
+// single diagnostic instead of a warning each.
+func printModelResults(order []string, models map[string]*modelTracker, hubErr error, hasToken bool) {
+	offline := 0
+	for _, id := range order {
+		if t := models[id]; t != nil && t.hubDown && t.complete {
+			offline++
+			continue
+		}
+		printModelResult(id, models[id], hasToken)
+	}
+	if offline == 0 || hubErr == nil {
+		return
+	}
+	fmt.Printf("  %s %s\n", ui.GetWarnMark(), ui.Warning.Render(fmt.Sprintf("Hugging Face Hub unreachable: %d model(s) built without Hub metadata", offline)))
+	fmt.Printf("      %s\n", ui.Dim.Render(hubErr.Error()))
+	fmt.Printf("      %s\n", ui.Dim.Render("Check the network connection and proxy settings (HTTPS_PROXY) and run again;"))
+	fmt.Printf("      %s\n", ui.Dim.Render("raise --hf-timeout and --hf-retries on a slow connection, or use --hf-mode dummy to work offline."))
+}
+
 // printModelResult prints the model summary line followed by one sub-line per dataset.
 func printModelResult(id string, t *modelTracker, hasToken bool) {
 	mark, detail := modelOutcome(t, hasToken)
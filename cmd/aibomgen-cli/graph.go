@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/idlab-discover/aibomgen-cli/internal/apperr"
+	"github.com/idlab-discover/aibomgen-cli/internal/graph"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/bomio"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// graphCmd represents the graph command.
+var graphCmd = &cobra.Command{
+	Use:   "graph <bom>",
+	Short: "Draw the model supply-chain graph of an AIBOM as DOT or Mermaid",
+	Long: `Draw the supply-chain graph of an AIBOM (application → models → datasets →
+base models) as a Mermaid flowchart or a Graphviz DOT digraph, to embed the
+diagram in documentation and reviews.
+
+The graph follows the dependency graph of the BOM, nested components (such as
+the parts of a diffusers pipeline) and the pedigree of models, so base models
+are drawn even when they are only named as ancestors. Run it on the output of
+merge or scan --application to see the whole application. Only application,
+model and dataset components are drawn unless --all is set.
+
+Example:
+  aibomgen-cli graph dist/aibom.json > docs/aibom.mmd
+  aibomgen-cli graph dist/merged.json --output dot | dot -Tsvg -o aibom.svg`,
+	Args: cobra.ExactArgs(1),
+	RunE: runGraph,
+}
+
+func runGraph(cmd *cobra.Command, args []string) error {
+	output := strings.ToLower(strings.TrimSpace(viper.GetString("graph.output")))
+	if output == "" {
+		output = "mermaid"
+	}
+	if output != "mermaid" && output != "dot" {
+		return apperr.Userf("invalid --output %q (expected mermaid|dot)", output)
+	}
+	inputFormat := viper.GetString("graph.format")
+	if inputFormat == "" {
+		inputFormat = "auto"
+	}
+
+	bom, err := bomio.ReadBOM(args[0], inputFormat)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", args[0], err)
+	}
+
+	g := graph.Build(bom, graph.Options{All: viper.GetBool("graph.all")})
+	if output == "dot" {
+		return graph.WriteDOT(cmd.OutOrStdout(), g)
+	}
+	return graph.WriteMermaid(cmd.OutOrStdout(), g)
+}
+
+var (
+	graphFormat string
+	graphOutput string
+	graphAll    bool
+)
+
+func init() {
+	graphCmd.Flags().StringVarP(&graphFormat, "format", "f", "", "Input BOM format: json|xml|spdx-json|auto")
+	graphCmd.Flags().StringVar(&graphOutput, "output", "mermaid", "Graph format: mermaid|dot")
+	graphCmd.Flags().BoolVar(&graphAll, "all", false, "Draw every component, not only applications, models and datasets")
+
+	viper.BindPFlag("graph.format", graphCmd.Flags().Lookup("format"))
+	viper.BindPFlag("graph.output", graphCmd.Flags().Lookup("output"))
+	viper.BindPFlag("graph.all", graphCmd.Flags().Lookup("all"))
+}
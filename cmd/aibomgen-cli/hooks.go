@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/idlab-discover/aibomgen-cli/internal/hooks"
+	"github.com/idlab-discover/aibomgen-cli/internal/ui"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/generator"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// hooksManifestName is the default file name of the hook manifest, written.
+// to the output directory.
+const hooksManifestName = "aibomgen-hooks.json"
+
+// runPostGenerateHooks runs the hooks.post-generate commands for every BOM in.
+// written (the paths returned by bomio.WriteOutputFiles for boms) and records.
+// the runs in the hook manifest. It fails when a hook fails.
+func runPostGenerateHooks(cmd *cobra.Command, genUI *ui.GenerateUI, boms []generator.DiscoveredBOM, written []string, outputDir, format string, disabled bool) error {
+	commands := viper.GetStringSlice("hooks.post-generate")
+	if disabled || len(commands) == 0 || len(written) == 0 {
+		return nil
+	}
+
+	files := make([]hooks.Data, 0, len(written))
+	for i, path := range written {
+		d := hooks.Data{File: path, Format: format, OutputDir: outputDir}
+		if i < len(boms) && boms[i].BOM != nil {
+			bom := boms[i].BOM
+			d.Version = bom.Version
+			d.SerialNumber = bom.SerialNumber
+			if bom.Metadata != nil && bom.Metadata.Component != nil {
+				d.Name = bom.Metadata.Component.Name
+			}
+		}
+		files = append(files, d)
+	}
+
+	timeout := time.Duration(viper.GetInt("hooks.timeout")) * time.Second
+	genUI.LogStep("info", fmt.Sprintf("Running %d post-generate hook(s) for %d BOM(s)", len(commands), len(files)))
+	manifest, err := hooks.Run(cmd.Context(), "post-generate", commands, files, timeout)
+	if err != nil {
+		return fmt.Errorf("invalid hook: %w", err)
+	}
+
+	manifestPath := strings.TrimSpace(viper.GetString("hooks.manifest"))
+	if manifestPath == "" {
+		manifestPath = filepath.Join(outputDir, hooksManifestName)
+	}
+	if err := manifest.Write(manifestPath); err != nil {
+		return fmt.Errorf("failed to write hook manifest: %w", err)
+	}
+
+	for _, f := range manifest.Files {
+		for _, r := range f.Hooks {
+			if r.ExitCode == 0 && r.Error == "" {
+				continue
+			}
+			reason := r.Error
+			if reason == "" {
+				reason = fmt.Sprintf("exit status %d", r.ExitCode)
+			}
+			genUI.LogStep("warning", fmt.Sprintf("hook %q failed for %s: %s", strings.Join(r.Command, " "), f.File, reason))
+		}
+	}
+	if n := manifest.Failed(); n > 0 {
+		return fmt.Errorf("%d post-generate hook run(s) failed; output recorded in %s", n, manifestPath)
+	}
+	genUI.LogStep("success", fmt.Sprintf("Hooks completed; output recorded in %s", manifestPath))
+	return nil
+}
@@ -3,10 +3,12 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	cdx "github.com/CycloneDX/cyclonedx-go"
 	"github.com/idlab-discover/aibomgen-cli/internal/apperr"
+	"github.com/idlab-discover/aibomgen-cli/internal/signing"
 	"github.com/idlab-discover/aibomgen-cli/internal/ui"
 	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/bomio"
 	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/merger"
@@ -19,8 +21,10 @@ var (
 	mergeSBOM        string
 	mergeOutput      string
 	mergeFormat      string
+	mergeSpec        string
 	mergeDeduplicate bool
 	mergeLogLevel    string
+	mergeRequireSig  bool
 )
 
 var mergeCmd = &cobra.Command{
@@ -43,10 +47,16 @@ Example:
   ./aibomgen-cli merge --aibom aibom.json --sbom sbom.json -o merged.json
 
   # Merge multiple AIBOMs with one SBOM
-  ./aibomgen-cli merge --aibom model1_aibom.json --aibom model2_aibom.json --sbom sbom.json -o merged.json`,
+  ./aibomgen-cli merge --aibom model1_aibom.json --aibom model2_aibom.json --sbom sbom.json -o merged.json
+
+  # Merge every AIBOM of a directory (the pattern also works in config files)
+  ./aibomgen-cli merge --aibom 'dist/*.json' --sbom sbom.json -o merged.json`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Get inputs from viper (respects config file and CLI flag).
-		aibomPaths := viper.GetStringSlice("merge.aiboms")
+		aibomPaths, err := expandBOMPaths(viper.GetStringSlice("merge.aiboms"))
+		if err != nil {
+			return err
+		}
 		if len(aibomPaths) == 0 {
 			return apperr.User("at least one --aibom is required")
 		}
@@ -81,6 +91,11 @@ Example:
 
 		// Initialize UI.
 		quiet := level == "quiet"
+		if viper.GetBool("merge.require-signature") {
+			if err := verifyBOMSignatures(cmd.Context(), os.Stdout, aibomPaths, quiet); err != nil {
+				return err
+			}
+		}
 		mergerUI := ui.NewMergerUI(os.Stdout, quiet)
 		mergerUI.StartWorkflow(len(aibomPaths))
 
@@ -128,7 +143,7 @@ Example:
 
 		// Write merged BOM.
 		mergerUI.StartWriting(outputPath)
-		if err := bomio.WriteBOM(result.MergedBOM, outputPath, format, ""); err != nil {
+		if err := bomio.WriteBOM(result.MergedBOM, outputPath, format, viper.GetString("merge.spec")); err != nil {
 			mergerUI.PrintError(fmt.Errorf("failed to write merged BOM: %w", err))
 			return err
 		}
@@ -141,12 +156,44 @@ Example:
 	},
 }
 
+// expandBOMPaths expands the glob patterns among paths (e.g. dist/*.json),.
+// for shells that do not and for config files. A pattern without matches is.
+// an error, so that a typo does not silently merge fewer BOMs.
+func expandBOMPaths(paths []string) ([]string, error) {
+	var out []string
+	for _, p := range paths {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		if !strings.ContainsAny(p, "*?[") {
+			out = append(out, p)
+			continue
+		}
+		matches, err := filepath.Glob(p)
+		if err != nil {
+			return nil, apperr.Userf("invalid --aibom pattern %q: %v", p, err)
+		}
+		if len(matches) == 0 {
+			return nil, apperr.Userf("no files match the --aibom pattern %q", p)
+		}
+		for _, m := range matches {
+			if !signing.IsBundle(m) {
+				out = append(out, m)
+			}
+		}
+	}
+	return out, nil
+}
+
 func init() {
 	mergeCmd.Flags().StringSliceVar(&mergeAIBOMs, "aibom", []string{}, "Path to AIBOM file (can be specified multiple times, required)")
 	mergeCmd.Flags().StringVar(&mergeSBOM, "sbom", "", "Path to SBOM file (required)")
 	mergeCmd.Flags().StringVarP(&mergeOutput, "output", "o", "", "Output path for merged BOM (required)")
-	mergeCmd.Flags().StringVarP(&mergeFormat, "format", "f", "", "Output format: json|xml|auto (default: auto)")
+	mergeCmd.Flags().StringVarP(&mergeFormat, "format", "f", "", "Output format: json|xml|spdx-json|auto (default: auto)")
+	mergeCmd.Flags().StringVar(&mergeSpec, "spec", "", "CycloneDX spec version for output (e.g., 1.4, 1.5, 1.6; default: that of the SBOM)")
 	mergeCmd.Flags().BoolVar(&mergeDeduplicate, "deduplicate", true, "Remove duplicate components based on BOM-ref")
+	mergeCmd.Flags().BoolVar(&mergeRequireSig, "require-signature", false, "Verify the cosign signature of every AIBOM (verify-signature settings) before merging")
 	mergeCmd.Flags().StringVar(&mergeLogLevel, "log-level", "", "Log level: quiet|standard|debug")
 
 	// Bind all flags to viper for config file support.
@@ -154,6 +201,8 @@ func init() {
 	viper.BindPFlag("merge.sbom", mergeCmd.Flags().Lookup("sbom"))
 	viper.BindPFlag("merge.output", mergeCmd.Flags().Lookup("output"))
 	viper.BindPFlag("merge.format", mergeCmd.Flags().Lookup("format"))
+	viper.BindPFlag("merge.spec", mergeCmd.Flags().Lookup("spec"))
 	viper.BindPFlag("merge.deduplicate", mergeCmd.Flags().Lookup("deduplicate"))
+	viper.BindPFlag("merge.require-signature", mergeCmd.Flags().Lookup("require-signature"))
 	viper.BindPFlag("merge.log-level", mergeCmd.Flags().Lookup("log-level"))
 }
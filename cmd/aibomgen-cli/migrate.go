@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/idlab-discover/aibomgen-cli/internal/apperr"
+	"github.com/idlab-discover/aibomgen-cli/internal/ui"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/bomio"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/migrator"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// migrateCmd represents the migrate command.
+var migrateCmd = &cobra.Command{
+	Use:   "migrate [input]",
+	Short: "Upgrade an AIBOM produced by an earlier version to the current conventions",
+	Long: `Upgrade an AIBOM produced by an earlier version of AIBoMGen to the current
+conventions, so that validate and completeness scores are comparable over time.
+
+The migration renames legacy property names to the current aibomgen: property
+taxonomy (see docs/property-taxonomy.md), turns "lastModified:" dataset tags
+into the lastModified property, adds missing bom-refs and records the taxonomy
+version. Unknown properties and all other fields are kept as they are.
+
+Example:
+  aibomgen-cli migrate old.json -o new.json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runMigrate,
+}
+
+func runMigrate(cmd *cobra.Command, args []string) error {
+	level := strings.ToLower(strings.TrimSpace(viper.GetString("migrate.log-level")))
+	if level == "" {
+		level = "standard"
+	}
+	switch level {
+	case "quiet", "standard", "debug":
+		// ok.
+	default:
+		return apperr.Userf("invalid --log-level %q (expected quiet|standard|debug)", level)
+	}
+
+	inputPath := viper.GetString("migrate.input")
+	if len(args) == 1 {
+		inputPath = args[0]
+	}
+	if inputPath == "" {
+		return apperr.User("an input AIBOM is required (argument or --input)")
+	}
+	inputFormat := viper.GetString("migrate.format")
+	if inputFormat == "" {
+		inputFormat = "auto"
+	}
+	bom, err := bomio.ReadBOM(inputPath, inputFormat)
+	if err != nil {
+		return fmt.Errorf("failed to read input BOM: %w", err)
+	}
+
+	outPath := viper.GetString("migrate.output")
+	if outPath == "" {
+		outPath = inputPath // overwrite by default
+	}
+	outputFormat := viper.GetString("migrate.output-format")
+	if outputFormat == "" {
+		outputFormat = "auto"
+	}
+	specVersion := strings.TrimSpace(viper.GetString("migrate.spec"))
+
+	res := migrator.Migrate(bom)
+
+	if err := bomio.WriteBOM(bom, outPath, outputFormat, specVersion); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+
+	if level == "quiet" {
+		return nil
+	}
+	from := res.FromVersion
+	if from == "" {
+		from = "none"
+	}
+	out := cmd.OutOrStdout()
+	if level == "debug" {
+		fmt.Fprintf(out, "Taxonomy version: %s -> %s\n", from, res.ToVersion)
+		fmt.Fprintf(out, "Renamed properties: %d\n", res.RenamedProperties)
+		fmt.Fprintf(out, "Duplicate properties removed: %d\n", res.DuplicatesRemoved)
+		fmt.Fprintf(out, "lastModified tags moved: %d\n", res.MovedTags)
+		fmt.Fprintf(out, "bom-refs added: %d\n", res.AddedBOMRefs)
+	}
+	msg := fmt.Sprintf("Migrated BOM saved to %s", outPath)
+	if !res.Changed() {
+		msg = fmt.Sprintf("BOM already follows taxonomy version %s; saved to %s", res.ToVersion, outPath)
+	}
+	fmt.Fprintf(out, "\n%s\n", ui.SuccessBox.Render(ui.GetCheckMark()+" "+msg))
+	return nil
+}
+
+// ── Flag vars ─────────────────────────────────────────────────────────────────.
+
+var (
+	migrateInput        string
+	migrateOutput       string
+	migrateInputFormat  string
+	migrateOutputFormat string
+	migrateSpecVersion  string
+	migrateLogLevel     string
+)
+
+func init() {
+	migrateCmd.Flags().StringVarP(&migrateInput, "input", "i", "", "Path to the AIBOM to migrate (or pass it as argument)")
+	migrateCmd.Flags().StringVarP(&migrateOutput, "output", "o", "", "Output file path (default: overwrite input)")
+	migrateCmd.Flags().StringVarP(&migrateInputFormat, "format", "f", "", "Input BOM format: json|xml|spdx-json|auto")
+	migrateCmd.Flags().StringVar(&migrateOutputFormat, "output-format", "", "Output BOM format: json|xml|spdx-json|auto")
+	migrateCmd.Flags().StringVar(&migrateSpecVersion, "spec", "", "CycloneDX spec version for output (default: same as input)")
+	migrateCmd.Flags().StringVar(&migrateLogLevel, "log-level", "", "Log level: quiet|standard|debug")
+
+	// Bind to viper.
+	viper.BindPFlag("migrate.input", migrateCmd.Flags().Lookup("input"))
+	viper.BindPFlag("migrate.output", migrateCmd.Flags().Lookup("output"))
+	viper.BindPFlag("migrate.format", migrateCmd.Flags().Lookup("format"))
+	viper.BindPFlag("migrate.output-format", migrateCmd.Flags().Lookup("output-format"))
+	viper.BindPFlag("migrate.spec", migrateCmd.Flags().Lookup("spec"))
+	viper.BindPFlag("migrate.log-level", migrateCmd.Flags().Lookup("log-level"))
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/internal/notify"
+	"github.com/idlab-discover/aibomgen-cli/internal/ui"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/completeness"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/generator"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	notifyTargets []string
+	notifyOn      string
+)
+
+// runSummary collects the end-of-run summary posted to the --notify targets.
+// Commands wrapped by withNotify fill it in while they run.
+var runSummary notify.Summary
+
+// withNotify wraps the RunE of cmds so that their summary is posted to the.
+// --notify targets when they finish, whether they succeed or fail.
+func withNotify(cmds ...*cobra.Command) {
+	for _, c := range cmds {
+		run := c.RunE
+		c.RunE = func(cmd *cobra.Command, args []string) error {
+			start := time.Now()
+			runSummary = notify.Summary{Command: cmd.Name()}
+			err := run(cmd, args)
+			sendNotifications(cmd, err, time.Since(start))
+			return err
+		}
+	}
+}
+
+// noteProgress records models whose BOM could not be built.
+func noteProgress(evt generator.ProgressEvent) {
+	if evt.Type == generator.EventError && evt.Message == "BOM build failed" {
+		runSummary.Failures = append(runSummary.Failures, evt.ModelID)
+	}
+}
+
+// noteWritten records the BOMs written by scan or generate for the.
+// notifications and the usage metrics. written[i] is the file of boms[i].
+func noteWritten(boms []generator.DiscoveredBOM, written []string) {
+	runSummary.ModelsGenerated = len(written)
+	runSummary.Files = written
+	if !viper.GetBool("metrics.enabled") {
+		return
+	}
+	runScores = runScores[:0]
+	for _, d := range boms[:len(written)] {
+		runScores = append(runScores, completeness.Check(d.BOM).Score)
+	}
+}
+
+// compareScores records a completeness regression of bom against its.
+// previous revision. It is used as bomio.ChainOptions.Compare.
+func compareScores(bom, prev *cdx.BOM) {
+	current, previous := completeness.Check(bom), completeness.Check(prev)
+	if current.Score >= previous.Score {
+		return
+	}
+	name := current.ModelID
+	if bom.Metadata != nil && bom.Metadata.Component != nil && bom.Metadata.Component.Name != "" {
+		name = bom.Metadata.Component.Name
+	}
+	runSummary.ScoreRegressions = append(runSummary.ScoreRegressions, notify.Regression{
+		Name:     name,
+		Previous: previous.Score,
+		Current:  current.Score,
+	})
+}
+
+// sendNotifications posts the run summary to every --notify target. A.
+// notification that cannot be delivered is reported but does not change the.
+// outcome of the command.
+func sendNotifications(cmd *cobra.Command, runErr error, elapsed time.Duration) {
+	targets := viper.GetStringSlice("notify")
+	if len(targets) == 0 {
+		return
+	}
+	s := runSummary
+	s.Success = runErr == nil
+	if runErr != nil {
+		s.Error = runErr.Error()
+	}
+	s.Duration = elapsed.Round(time.Millisecond).String()
+
+	problems := !s.Success || len(s.Failures) > 0 || len(s.PolicyViolations) > 0 || len(s.ScoreRegressions) > 0
+	if strings.EqualFold(strings.TrimSpace(viper.GetString("notify-on")), "failure") && !problems {
+		return
+	}
+	for _, target := range targets {
+		if err := notify.Send(cmd.Context(), nil, target, s); err != nil {
+			fmt.Fprintln(os.Stderr, ui.GetWarnMark()+" "+ui.Warning.Render(fmt.Sprintf("notification not sent: %v", err)))
+		}
+	}
+}
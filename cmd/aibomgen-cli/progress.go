@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+
+	"github.com/idlab-discover/aibomgen-cli/internal/apperr"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/generator"
+)
+
+// progressStream receives every progress event of scan or generate when.
+// --progress jsonl is set (nil: only the interactive UI shows progress).
+var progressStream generator.ProgressCallback
+
+// setProgressStream selects the --progress mode: "jsonl" writes every.
+// progress event to stderr as a JSON object per line, "" or "ui" leaves.
+// progress to the interactive UI.
+func setProgressStream(mode string) error {
+	switch strings.ToLower(strings.TrimSpace(mode)) {
+	case "", "ui":
+		progressStream = nil
+	case "jsonl":
+		progressStream = generator.JSONLProgress(os.Stderr)
+	default:
+		return apperr.Userf("invalid --progress %q (expected ui|jsonl)", mode)
+	}
+	return nil
+}
+
+// streamProgress forwards evt to the --progress stream, if any.
+func streamProgress(evt generator.ProgressEvent) {
+	if progressStream != nil {
+		progressStream(evt)
+	}
+}
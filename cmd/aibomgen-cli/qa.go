@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/idlab-discover/aibomgen-cli/internal/apperr"
+	"github.com/idlab-discover/aibomgen-cli/internal/qa"
+	"github.com/idlab-discover/aibomgen-cli/internal/ui"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/bomio"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// qaCmd groups the quality assurance commands.
+var qaCmd = &cobra.Command{
+	Use:   "qa",
+	Short: "Support manual quality audits of AIBOMs",
+	Long: `Support manual audits of large AIBOM collections.
+
+qa sample draws a random sample of AIBOMs and writes a review packet with a
+checklist per AIBOM, so that auditors check a manageable number of AIBOMs
+instead of all of them.
+
+Example:
+  aibomgen-cli qa sample -i dist/ --n 20 -o review-packet.md`,
+}
+
+var qaSampleCmd = &cobra.Command{
+	Use:   "sample",
+	Short: "Draw a weighted random sample of AIBOMs into a review packet",
+	Long: `Draw a random sample of AIBOMs, without replacement, and write a review packet
+for manual audit.
+
+Every AIBOM can be drawn, but by default AIBOMs with a low completeness score
+or a recent change are drawn more often (--weight both). The change time is the
+BOM timestamp, else the file modification time; --half-life sets how fast a
+change stops counting. --weight uniform gives every AIBOM the same chance.
+
+The seed of the draw is printed in the packet: pass it with --seed to draw the
+same sample again.
+
+Example:
+  aibomgen-cli qa sample -i dist/ --n 20 -o review-packet.md
+  aibomgen-cli qa sample -i dist/ -i team-b/ --weight score --packet json`,
+	RunE: runQASample,
+}
+
+func runQASample(cmd *cobra.Command, _ []string) error {
+	inputs := viper.GetStringSlice("qa.sample.input")
+	if len(inputs) == 0 {
+		return apperr.User("--input is required")
+	}
+	weighting := strings.ToLower(strings.TrimSpace(viper.GetString("qa.sample.weight")))
+	if weighting == "" {
+		weighting = qa.WeightBoth
+	}
+	if !qa.ValidWeighting(weighting) {
+		return apperr.Userf("invalid --weight %q (expected score|recent|both|uniform)", weighting)
+	}
+	packetFormat := strings.ToLower(strings.TrimSpace(viper.GetString("qa.sample.packet")))
+	if packetFormat == "" {
+		packetFormat = "markdown"
+	}
+	if packetFormat != "markdown" && packetFormat != "json" {
+		return apperr.Userf("invalid --packet %q (expected markdown|json)", packetFormat)
+	}
+	n := viper.GetInt("qa.sample.n")
+	if n <= 0 {
+		return apperr.Userf("invalid --n %d (must be at least 1)", n)
+	}
+	halfLife, err := parseAge(viper.GetString("qa.sample.half-life"))
+	if err != nil {
+		return apperr.Userf("invalid --half-life: %v", err)
+	}
+	profile, err := loadCompletenessProfile(viper.GetString("qa.sample.profile"))
+	if err != nil {
+		return err
+	}
+	format := viper.GetString("qa.sample.format")
+	if strings.TrimSpace(format) == "" {
+		format = "auto"
+	}
+
+	paths, err := bomFiles(inputs)
+	if err != nil {
+		return err
+	}
+	cands := make([]qa.Candidate, 0, len(paths))
+	for _, path := range paths {
+		bom, err := bomio.ReadBOM(path, format)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, ui.GetWarnMark()+" "+ui.Warning.Render(fmt.Sprintf("skipping %s: %v", path, err)))
+			continue
+		}
+		var modTime time.Time
+		if info, err := os.Stat(path); err == nil {
+			modTime = info.ModTime()
+		}
+		cands = append(cands, qa.FromBOM(path, bom, profile, modTime))
+	}
+	if len(cands) == 0 {
+		return apperr.User("no readable AIBOM files found")
+	}
+
+	seed := viper.GetUint64("qa.sample.seed")
+	if seed == 0 {
+		seed = rand.Uint64()
+	}
+	now := time.Now()
+	packet := qa.Packet{
+		Generated:  now,
+		Seed:       seed,
+		Weighting:  weighting,
+		Population: len(cands),
+		Samples: qa.Sample(cands, qa.Options{
+			N:         n,
+			Weighting: weighting,
+			HalfLife:  halfLife,
+			Seed:      seed,
+			Now:       now,
+		}),
+	}
+
+	var w io.Writer = cmd.OutOrStdout()
+	outPath := strings.TrimSpace(viper.GetString("qa.sample.output"))
+	if outPath != "" {
+		f, err := os.Create(outPath)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", outPath, err)
+		}
+		defer f.Close()
+		w = f
+	}
+	if packetFormat == "json" {
+		err = qa.WriteJSON(w, packet)
+	} else {
+		err = qa.WriteMarkdown(w, packet, qaReproduceCommand(inputs, weighting, n, halfLife, seed))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write review packet: %w", err)
+	}
+	if outPath != "" {
+		fmt.Fprintln(cmd.ErrOrStderr(), ui.GetCheckMark()+" "+fmt.Sprintf("Sampled %d of %d AIBOMs into %s (seed %d)",
+			len(packet.Samples), packet.Population, outPath, seed))
+	}
+	return nil
+}
+
+// qaReproduceCommand is the command line that draws the same sample. The.
+// recency weights also depend on the time of the draw, so a sample weighted.
+// by recency is only reproduced exactly on the same files soon after.
+func qaReproduceCommand(inputs []string, weighting string, n int, halfLife time.Duration, seed uint64) string {
+	args := []string{"aibomgen-cli", "qa", "sample"}
+	for _, in := range inputs {
+		args = append(args, "-i", strconv.Quote(in))
+	}
+	args = append(args, "--n", strconv.Itoa(n), "--weight", weighting)
+	if halfLife > 0 && halfLife != qa.DefaultHalfLife {
+		args = append(args, "--half-life", strings.TrimSpace(viper.GetString("qa.sample.half-life")))
+	}
+	if p := strings.TrimSpace(viper.GetString("qa.sample.profile")); p != "" {
+		args = append(args, "--profile", strconv.Quote(p))
+	}
+	return strings.Join(append(args, "--seed", strconv.FormatUint(seed, 10)), " ")
+}
+
+var (
+	qaSampleInput    []string
+	qaSampleFormat   string
+	qaSampleN        int
+	qaSampleWeight   string
+	qaSampleHalfLife string
+	qaSampleSeed     uint64
+	qaSampleProfile  string
+	qaSampleOutput   string
+	qaSamplePacket   string
+)
+
+func init() {
+	qaSampleCmd.Flags().StringSliceVarP(&qaSampleInput, "input", "i", nil, "AIBOM file(s) or directories of AIBOMs to sample from (required) - can be used multiple times or comma-separated")
+	qaSampleCmd.Flags().StringVarP(&qaSampleFormat, "format", "f", "", "Input BOM format: json|xml|spdx-json|auto")
+	qaSampleCmd.Flags().IntVar(&qaSampleN, "n", 20, "Number of AIBOMs to sample")
+	qaSampleCmd.Flags().StringVar(&qaSampleWeight, "weight", qa.WeightBoth, "What makes an AIBOM more likely to be sampled: score|recent|both|uniform")
+	qaSampleCmd.Flags().StringVar(&qaSampleHalfLife, "half-life", "30d", "Age at which a change counts half as much as a change made now (e.g. 30d, 2w, 72h)")
+	qaSampleCmd.Flags().Uint64Var(&qaSampleSeed, "seed", 0, "Seed of the random draw, to reproduce a sample (default: random)")
+	qaSampleCmd.Flags().StringVar(&qaSampleProfile, "profile", "", "Weights profile (YAML/JSON) for the completeness scores")
+	qaSampleCmd.Flags().StringVarP(&qaSampleOutput, "output", "o", "", "Review packet file (default: stdout)")
+	qaSampleCmd.Flags().StringVar(&qaSamplePacket, "packet", "markdown", "Review packet format: markdown|json")
+
+	viper.BindPFlag("qa.sample.input", qaSampleCmd.Flags().Lookup("input"))
+	viper.BindPFlag("qa.sample.format", qaSampleCmd.Flags().Lookup("format"))
+	viper.BindPFlag("qa.sample.n", qaSampleCmd.Flags().Lookup("n"))
+	viper.BindPFlag("qa.sample.weight", qaSampleCmd.Flags().Lookup("weight"))
+	viper.BindPFlag("qa.sample.half-life", qaSampleCmd.Flags().Lookup("half-life"))
+	viper.BindPFlag("qa.sample.seed", qaSampleCmd.Flags().Lookup("seed"))
+	viper.BindPFlag("qa.sample.profile", qaSampleCmd.Flags().Lookup("profile"))
+	viper.BindPFlag("qa.sample.output", qaSampleCmd.Flags().Lookup("output"))
+	viper.BindPFlag("qa.sample.packet", qaSampleCmd.Flags().Lookup("packet"))
+
+	qaCmd.AddCommand(qaSampleCmd)
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/spf13/viper"
+
+	"github.com/idlab-discover/aibomgen-cli/internal/apperr"
+	"github.com/idlab-discover/aibomgen-cli/internal/gitrepo"
+	"github.com/idlab-discover/aibomgen-cli/internal/ui"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/generator"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/scanner"
+)
+
+// cloneScanRepo checks out the --repo repository of scan at --ref into a.
+// temporary directory. The checkout is shallow unless --git-history needs.
+// the history, which is then fetched up to --git-history-max-commits.
+func cloneScanRepo(ctx context.Context, repo string, quiet bool) (*gitrepo.Checkout, error) {
+	opts := gitrepo.Options{
+		URL:   repo,
+		Ref:   strings.TrimSpace(viper.GetString("scan.ref")),
+		Token: viper.GetString("scan.repo-token"),
+		Depth: 1,
+	}
+	if viper.GetBool("scan.git-history") {
+		opts.Depth = viper.GetInt("scan.git-history-max-commits")
+	}
+	what := gitrepo.Redact(repo)
+	if opts.Ref != "" {
+		what += " at " + opts.Ref
+	}
+	genUI := ui.NewGenerateUI(os.Stdout, quiet)
+	genUI.LogStep("info", "Cloning "+what)
+	checkout, err := gitrepo.Clone(ctx, opts)
+	if err != nil {
+		return nil, apperr.Userf("cannot clone %s: %v", what, err)
+	}
+	genUI.LogStep("success", "Cloned "+what+" ("+shortCommit(checkout.Commit)+")")
+	return checkout, nil
+}
+
+// trimCheckoutPaths makes the paths of a scan of the checkout in dir.
+// relative to the repository, once the BOMs are built: the checkout is.
+// removed after the run, so its temporary paths mean nothing to readers.
+func trimCheckoutPaths(dir string, discoveries []scanner.Discovery, boms []generator.DiscoveredBOM) {
+	prefix := filepath.Clean(dir) + string(filepath.Separator)
+	trim := func(p string) string {
+		if rel, ok := strings.CutPrefix(p, prefix); ok {
+			return filepath.ToSlash(rel)
+		}
+		return p
+	}
+	for i := range discoveries {
+		discoveries[i].Path = trim(discoveries[i].Path)
+	}
+	for i := range boms {
+		b := &boms[i]
+		b.Discovery.Path = trim(b.Discovery.Path)
+		if b.BOM == nil {
+			continue
+		}
+		if b.BOM.Metadata != nil && b.BOM.Metadata.Component != nil {
+			trimComponentPaths(b.BOM.Metadata.Component, trim)
+		}
+		if b.BOM.Components != nil {
+			for i := range *b.BOM.Components {
+				trimComponentPaths(&(*b.BOM.Components)[i], trim)
+			}
+		}
+		if b.BOM.Services != nil {
+			for i := range *b.BOM.Services {
+				trimServicePaths(&(*b.BOM.Services)[i], trim)
+			}
+		}
+	}
+}
+
+func trimComponentPaths(c *cdx.Component, trim func(string) string) {
+	trimProperties(c.Properties, trim)
+	if c.Components != nil {
+		for i := range *c.Components {
+			trimComponentPaths(&(*c.Components)[i], trim)
+		}
+	}
+}
+
+func trimServicePaths(s *cdx.Service, trim func(string) string) {
+	trimProperties(s.Properties, trim)
+	if s.Services != nil {
+		for i := range *s.Services {
+			trimServicePaths(&(*s.Services)[i], trim)
+		}
+	}
+}
+
+func trimProperties(props *[]cdx.Property, trim func(string) string) {
+	if props == nil {
+		return
+	}
+	for i := range *props {
+		(*props)[i].Value = trim((*props)[i].Value)
+	}
+}
@@ -0,0 +1,403 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/internal/apperr"
+	"github.com/idlab-discover/aibomgen-cli/internal/audit"
+	"github.com/idlab-discover/aibomgen-cli/internal/enricher"
+	"github.com/idlab-discover/aibomgen-cli/internal/metadata"
+	"github.com/idlab-discover/aibomgen-cli/internal/review"
+	"github.com/idlab-discover/aibomgen-cli/internal/ui"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/bomio"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/completeness"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// reviewCmd opens the review dashboard and groups the review workflow commands.
+var reviewCmd = &cobra.Command{
+	Use:   "review [dir|bom...]",
+	Short: "Review generated AIBOMs and track per-field review status",
+	Long: `Without a subcommand, review opens a dashboard of generated AIBOMs: the
+AIBOMs of the given directories and files (default: the generate output
+directory) with their completeness scores, the model and dataset components of
+each, and the fields each component is missing. Pressing e on a component runs
+the interactive enricher for it and returns to the dashboard with the updated
+scores, without re-running generate.
+
+The subcommands request and record reviews of individual AIBOM fields. The
+review status of each field is kept in the AIBOM as aibomgen:review:*
+properties of the model or dataset component, and every action is added as an
+annotation naming the reviewer. completeness --verified only counts approved
+fields.
+
+Field keys use the same forms as enrich --answer (e.g. licenses,
+modelCard.considerations.useCases, dataset.description).
+
+Example:
+  aibomgen-cli review
+  aibomgen-cli review dist/ other/bom.json
+  aibomgen-cli review request -i bom.json --field licenses --field dataset.licenses
+  aibomgen-cli review approve -i bom.json --field licenses --reviewer alice
+  aibomgen-cli review status -i bom.json`,
+	Args: cobra.ArbitraryArgs,
+	RunE: runReviewDashboard,
+}
+
+var reviewRequestCmd = &cobra.Command{
+	Use:   "request",
+	Short: "Mark fields as awaiting review",
+	Long:  "Mark the given fields (default: every present field) as awaiting review.",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return runReviewAction(cmd, "request")
+	},
+}
+
+var reviewApproveCmd = &cobra.Command{
+	Use:   "approve",
+	Short: "Approve reviewed fields",
+	Long:  "Approve the given fields (default: every field awaiting review).",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return runReviewAction(cmd, "approve")
+	},
+}
+
+var reviewStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the review status of every present field",
+	RunE:  runReviewStatus,
+}
+
+// runReviewDashboard shows the dashboard of the AIBOMs named by args and.
+// enriches the components picked in it until the user quits.
+func runReviewDashboard(cmd *cobra.Command, args []string) error {
+	if ui.Plain() {
+		return apperr.User("the review dashboard needs an interactive terminal (use review status for plain output)")
+	}
+	if len(args) == 0 {
+		args = []string{filepath.Dir(viper.GetString("generate.output"))}
+	}
+	paths, err := dashboardPaths(args)
+	if err != nil {
+		return err
+	}
+	if len(paths) == 0 {
+		return apperr.Userf("no AIBOMs found in %s", strings.Join(args, ", "))
+	}
+
+	var last ui.DashboardSelection
+	for {
+		boms := loadDashboardBOMs(paths)
+		sel, err := ui.RunDashboard(boms, last)
+		if errors.Is(err, apperr.ErrCancelled) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		last = sel
+
+		scope := enrichScope{strategy: "interactive", modelOnly: sel.Dataset == "", dataset: sel.Dataset}
+		if err := runEnrich(cmd, sel.Path, scope); err != nil && !errors.Is(err, apperr.ErrCancelled) {
+			fmt.Fprintln(os.Stderr, ui.GetWarnMark()+" "+ui.Warning.Render(fmt.Sprintf("enrichment of %s failed: %v", sel.Path, err)))
+		}
+	}
+}
+
+// dashboardPaths lists the BOM files among args: files as given, and the.
+// JSON and XML files of directories (not recursive), sorted by name.
+func dashboardPaths(args []string) ([]string, error) {
+	var paths []string
+	for _, arg := range args {
+		info, err := os.Stat(arg)
+		if err != nil {
+			return nil, apperr.Userf("cannot read %s: %v", arg, err)
+		}
+		if !info.IsDir() {
+			paths = append(paths, arg)
+			continue
+		}
+		entries, err := os.ReadDir(arg)
+		if err != nil {
+			return nil, apperr.Userf("cannot read %s: %v", arg, err)
+		}
+		for _, e := range entries {
+			switch strings.ToLower(filepath.Ext(e.Name())) {
+			case ".json", ".xml":
+				if !e.IsDir() {
+					paths = append(paths, filepath.Join(arg, e.Name()))
+				}
+			}
+		}
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// loadDashboardBOMs reads and scores the BOMs at paths. Files that cannot be.
+// read are listed with their error; documents without a metadata component.
+// (reports and other JSON files next to the AIBOMs) are left out.
+func loadDashboardBOMs(paths []string) []ui.DashboardBOM {
+	format := viper.GetString("review.format")
+	if format == "" {
+		format = "auto"
+	}
+	boms := make([]ui.DashboardBOM, 0, len(paths))
+	for _, path := range paths {
+		bom, err := bomio.ReadBOM(path, format)
+		if err == nil && (bom.Metadata == nil || bom.Metadata.Component == nil) {
+			continue
+		}
+		entry := ui.DashboardBOM{Path: path, Err: err}
+		if err == nil {
+			entry.Result = completeness.Check(bom)
+		}
+		boms = append(boms, entry)
+	}
+	return boms
+}
+
+// reviewTarget is one field of one component.
+type reviewTarget struct {
+	comp    *cdx.Component
+	key     string
+	present bool
+}
+
+func runReviewAction(cmd *cobra.Command, action string) error {
+	inputPath, bom, err := readReviewBOM()
+	if err != nil {
+		return err
+	}
+	reviewer := strings.TrimSpace(viper.GetString("review.reviewer"))
+	if reviewer == "" {
+		reviewer = audit.DefaultActor()
+	}
+	fields := viper.GetStringSlice("review." + action + ".field")
+
+	targets, err := reviewTargets(bom, fields)
+	if err != nil {
+		return err
+	}
+
+	// Without --field, request every present field and approve every field.
+	// awaiting review.
+	changed := make(map[*cdx.Component][]string)
+	var order []*cdx.Component
+	for _, t := range targets {
+		if !t.present {
+			if len(fields) > 0 {
+				fmt.Fprintln(os.Stderr, ui.GetWarnMark()+" "+ui.Warning.Render(fmt.Sprintf("%s is not set on %s; skipped", t.key, t.comp.Name)))
+			}
+			continue
+		}
+		var ok bool
+		switch action {
+		case "request":
+			ok = review.Request(t.comp, t.key)
+		case "approve":
+			if len(fields) == 0 && review.Status(t.comp, t.key) != review.StatusRequested {
+				continue
+			}
+			ok = review.Approve(t.comp, t.key)
+		}
+		if !ok {
+			continue
+		}
+		if _, seen := changed[t.comp]; !seen {
+			order = append(order, t.comp)
+		}
+		changed[t.comp] = append(changed[t.comp], t.key)
+	}
+
+	now := time.Now()
+	n := 0
+	for _, comp := range order {
+		verb := "requested review of"
+		if action == "approve" {
+			verb = "approved"
+		}
+		review.Annotate(bom, comp, reviewer, verb, changed[comp], now)
+		n += len(changed[comp])
+	}
+
+	outPath := viper.GetString("review.output")
+	if outPath == "" {
+		outPath = inputPath
+	}
+	if n > 0 {
+		if err := bomio.WriteBOM(bom, outPath, "auto", ""); err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
+		}
+	}
+
+	msg := fmt.Sprintf("%d field(s) marked for review in %s", n, outPath)
+	if action == "approve" {
+		msg = fmt.Sprintf("%d field(s) approved by %s in %s", n, reviewer, outPath)
+	}
+	if n == 0 {
+		msg = "No review status changed"
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", ui.SuccessBox.Render(ui.GetCheckMark()+" "+msg))
+	return nil
+}
+
+func runReviewStatus(cmd *cobra.Command, _ []string) error {
+	_, bom, err := readReviewBOM()
+	if err != nil {
+		return err
+	}
+	targets, err := reviewTargets(bom, nil)
+	if err != nil {
+		return err
+	}
+
+	w := cmd.OutOrStdout()
+	var last *cdx.Component
+	pending := 0
+	for _, t := range targets {
+		if !t.present {
+			continue
+		}
+		if t.comp != last {
+			fmt.Fprintf(w, "\n%s\n", ui.SectionHeader.Render(t.comp.Name))
+			last = t.comp
+		}
+		status := review.Status(t.comp, t.key)
+		if status == review.StatusRequested {
+			pending++
+		}
+		printReviewStatus(w, t.key, status)
+	}
+
+	res := completeness.Check(bom)
+	fmt.Fprintf(w, "\nVerified score: %.1f%% (%d approved) of completeness %.1f%% (%d/%d fields)\n",
+		res.VerifiedScore*100, res.Verified, res.Score*100, res.Passed, res.Total)
+	if pending > 0 {
+		fmt.Fprintf(w, "%d field(s) awaiting review\n", pending)
+	}
+	return nil
+}
+
+func printReviewStatus(w io.Writer, key, status string) {
+	switch status {
+	case review.StatusApproved:
+		fmt.Fprintf(w, "  %s %s\n", ui.GetCheckMark(), key)
+	case review.StatusRequested:
+		fmt.Fprintf(w, "  %s %s %s\n", ui.GetWarnMark(), key, ui.Muted.Render("(awaiting review)"))
+	default:
+		fmt.Fprintf(w, "  %s %s\n", ui.Muted.Render("·"), ui.Muted.Render(key+" (not reviewed)"))
+	}
+}
+
+func readReviewBOM() (string, *cdx.BOM, error) {
+	inputPath := viper.GetString("review.input")
+	if inputPath == "" {
+		return "", nil, apperr.User("--input is required")
+	}
+	format := viper.GetString("review.format")
+	if format == "" {
+		format = "auto"
+	}
+	bom, err := bomio.ReadBOM(inputPath, format)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read input BOM: %w", err)
+	}
+	return inputPath, bom, nil
+}
+
+// reviewTargets resolves fields (default: every scored field) to the model.
+// component or the dataset components selected with --datasets.
+func reviewTargets(bom *cdx.BOM, fields []string) ([]reviewTarget, error) {
+	modelKeys := make(map[string]bool)
+	datasetKeys := make(map[string]bool)
+	for _, raw := range fields {
+		key, dataset, ok := enricher.FieldKey(raw)
+		if !ok {
+			return nil, apperr.Userf("unknown field %q", raw)
+		}
+		if dataset {
+			datasetKeys[key] = true
+		} else {
+			modelKeys[key] = true
+		}
+	}
+	all := len(fields) == 0
+
+	var out []reviewTarget
+	if bom.Metadata != nil && bom.Metadata.Component != nil {
+		for _, spec := range metadata.Registry() {
+			if spec.Weight <= 0 || (!all && !modelKeys[string(spec.Key)]) {
+				continue
+			}
+			out = append(out, reviewTarget{bom.Metadata.Component, string(spec.Key), spec.Present != nil && spec.Present(bom)})
+		}
+	}
+	if bom.Components == nil || (!all && len(datasetKeys) == 0) {
+		return out, nil
+	}
+	names := viper.GetStringSlice("review.datasets")
+	for i := range *bom.Components {
+		comp := &(*bom.Components)[i]
+		if comp.Type != cdx.ComponentTypeData || (len(names) > 0 && !containsName(names, comp.Name)) {
+			continue
+		}
+		for _, spec := range metadata.DatasetRegistry() {
+			if spec.Weight <= 0 || (!all && !datasetKeys[string(spec.Key)]) {
+				continue
+			}
+			out = append(out, reviewTarget{comp, string(spec.Key), spec.Present != nil && spec.Present(comp)})
+		}
+	}
+	return out, nil
+}
+
+func containsName(names []string, name string) bool {
+	for _, n := range names {
+		if strings.EqualFold(strings.TrimSpace(n), name) {
+			return true
+		}
+	}
+	return false
+}
+
+// ── Flag vars ─────────────────────────────────────────────────────────────────.
+
+var (
+	reviewInput         string
+	reviewFormat        string
+	reviewOutput        string
+	reviewReviewer      string
+	reviewDatasets      []string
+	reviewRequestFields []string
+	reviewApproveFields []string
+)
+
+func init() {
+	reviewCmd.PersistentFlags().StringVarP(&reviewInput, "input", "i", "", "Path to the AIBOM (required)")
+	reviewCmd.PersistentFlags().StringVarP(&reviewFormat, "format", "f", "", "Input BOM format: json|xml|spdx-json|auto")
+	reviewCmd.PersistentFlags().StringVarP(&reviewOutput, "output", "o", "", "Output file path (default: overwrite input)")
+	reviewCmd.PersistentFlags().StringVar(&reviewReviewer, "reviewer", "", "Person recorded as requester or approver (default: current OS user)")
+	reviewCmd.PersistentFlags().StringSliceVar(&reviewDatasets, "datasets", nil, "Only review these dataset components (comma-separated names; default: all)")
+	reviewRequestCmd.Flags().StringArrayVar(&reviewRequestFields, "field", nil, "Field to request a review of (repeatable; default: every present field)")
+	reviewApproveCmd.Flags().StringArrayVar(&reviewApproveFields, "field", nil, "Field to approve (repeatable; default: every field awaiting review)")
+
+	viper.BindPFlag("review.input", reviewCmd.PersistentFlags().Lookup("input"))
+	viper.BindPFlag("review.format", reviewCmd.PersistentFlags().Lookup("format"))
+	viper.BindPFlag("review.output", reviewCmd.PersistentFlags().Lookup("output"))
+	viper.BindPFlag("review.reviewer", reviewCmd.PersistentFlags().Lookup("reviewer"))
+	viper.BindPFlag("review.datasets", reviewCmd.PersistentFlags().Lookup("datasets"))
+	viper.BindPFlag("review.request.field", reviewRequestCmd.Flags().Lookup("field"))
+	viper.BindPFlag("review.approve.field", reviewApproveCmd.Flags().Lookup("field"))
+
+	reviewCmd.AddCommand(reviewRequestCmd, reviewApproveCmd, reviewStatusCmd)
+}
@@ -1,11 +1,18 @@
 package cmd
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
+	"github.com/idlab-discover/aibomgen-cli/internal/configref"
+	"github.com/idlab-discover/aibomgen-cli/internal/fetcher"
+	"github.com/idlab-discover/aibomgen-cli/internal/locale"
+	"github.com/idlab-discover/aibomgen-cli/internal/projectconfig"
 	"github.com/idlab-discover/aibomgen-cli/internal/ui"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -19,6 +26,15 @@ var rootCmd = &cobra.Command{
 
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
 		initUIAndBanner(cmd)
+		loadProjectConfig(cmd)
+		configureLocale()
+		configureHTTPCache()
+		startRunMetrics()
+	},
+
+	// Record opt-in local usage metrics for successful runs.
+	PersistentPostRun: func(cmd *cobra.Command, args []string) {
+		recordRunMetrics(cmd, runBOMCache(cmd.Context()))
 	},
 
 	// When invoked without a subcommand, show help (with banner) instead of.
@@ -30,6 +46,9 @@ var rootCmd = &cobra.Command{
 }
 
 var cfgFile string
+var noUI bool
+var noProjectConfig bool
+var noHTTPCache bool
 var renderedBanner string
 
 // SetVersion sets the version for the CLI.
@@ -50,6 +69,22 @@ func init() {
 	cobra.OnInitialize(initConfig)
 
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.aibomgen-cli.yaml or ./config/defaults.yaml)")
+	rootCmd.PersistentFlags().BoolVar(&noUI, "no-ui", false, "Plain sequential log output without colors or animations (automatic when NO_COLOR is set or stdout is not a terminal)")
+	viper.BindPFlag("no-ui", rootCmd.PersistentFlags().Lookup("no-ui"))
+	rootCmd.PersistentFlags().Bool("explain-fields", false, "Explain the CycloneDX field paths of reports in plain language and end them with a glossary of abbreviations")
+	viper.BindPFlag("explain-fields", rootCmd.PersistentFlags().Lookup("explain-fields"))
+	rootCmd.PersistentFlags().String("locale", "", "Format the numbers, sizes and dates of reports for a locale (e.g. de-DE, fr, auto; default: raw values)")
+	viper.BindPFlag("locale", rootCmd.PersistentFlags().Lookup("locale"))
+	rootCmd.PersistentFlags().BoolVar(&noProjectConfig, "no-project-config", false, "Ignore project-local .aibomgen.yaml files")
+	viper.BindPFlag("no-project-config", rootCmd.PersistentFlags().Lookup("no-project-config"))
+	rootCmd.PersistentFlags().BoolVar(&noHTTPCache, "no-cache", false, "Do not use the on-disk cache of Hugging Face API responses")
+	viper.BindPFlag("no-cache", rootCmd.PersistentFlags().Lookup("no-cache"))
+	rootCmd.PersistentFlags().StringSliceVar(&notifyTargets, "notify", nil, "Post the run summary of scan, generate and validate to webhook://, slack:// or teams:// URLs (can be repeated)")
+	viper.BindPFlag("notify", rootCmd.PersistentFlags().Lookup("notify"))
+	rootCmd.PersistentFlags().StringVar(&notifyOn, "notify-on", "", "When to notify: always|failure (failure also covers failed models, policy violations and score regressions)")
+	viper.BindPFlag("notify-on", rootCmd.PersistentFlags().Lookup("notify-on"))
+	rootCmd.PersistentFlags().StringVar(&tracingEndpoint, "otlp-endpoint", "", "Export OpenTelemetry traces of scan and generate to this OTLP/HTTP endpoint (e.g. http://localhost:4318)")
+	viper.BindPFlag("tracing.endpoint", rootCmd.PersistentFlags().Lookup("otlp-endpoint"))
 
 	// Ensure `--help` (and help subcommands) show a green banner consistently.
 	defaultHelp := rootCmd.HelpFunc()
@@ -63,7 +98,9 @@ func init() {
 	rootCmd.SilenceUsage = true
 
 	// Add subcommands.
-	rootCmd.AddCommand(generateCmd, scanCmd, enrichCmd, validateCmd, completenessCmd, mergeCmd, vulnScanCmd)
+	withNotify(generateCmd, scanCmd, validateCmd)
+	withTracing(generateCmd, scanCmd)
+	rootCmd.AddCommand(generateCmd, scanCmd, enrichCmd, validateCmd, completenessCmd, diffCmd, mergeCmd, migrateCmd, exportCmd, convertCmd, daemonCmd, vulnScanCmd, checkAdvisoriesCmd, verifyCmd, verifyClaimsCmd, verifyRuntimeCmd, reviewCmd, statsCmd, auditCacheCmd, auditCmd, attachCmd, signCmd, verifySignatureCmd, graphCmd, compareCmd, qaCmd)
 }
 
 func initConfig() {
@@ -77,6 +114,11 @@ func initConfig() {
 	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_", "-", "_"))
 	viper.AutomaticEnv()
 
+	// Pick the rendering mode before anything is printed, and again once the.
+	// config file (which may set no-ui) has been read.
+	configureUI()
+	defer configureUI()
+
 	if cfgFile != "" {
 		// Use config file from the flag.
 		viper.SetConfigFile(cfgFile)
@@ -107,6 +149,7 @@ func initConfig() {
 		if err == nil {
 			configMsg := ui.Dim.Render("Using config file: ") + ui.Secondary.Render(viper.ConfigFileUsed())
 			fmt.Fprintln(os.Stderr, configMsg)
+			cobra.CheckErr(resolveConfigRefs())
 		}
 
 		return
@@ -124,11 +167,108 @@ func initConfig() {
 	default:
 		configMsg := ui.Dim.Render("Using config file: ") + ui.Secondary.Render(viper.ConfigFileUsed())
 		fmt.Fprintln(os.Stderr, configMsg)
+		cobra.CheckErr(resolveConfigRefs())
+	}
+}
+
+// resolveConfigRefs expands the ${ENV_VAR} and secretRef references in the.
+// config file that was read, so that tokens do not have to be committed in.
+// plain text. A reference that cannot be resolved stops the run.
+func resolveConfigRefs() error {
+	path := viper.ConfigFileUsed()
+	file := viper.New()
+	file.SetConfigFile(path)
+	if filepath.Ext(path) == "" {
+		file.SetConfigType("yaml")
+	}
+	if err := file.ReadInConfig(); err != nil {
+		return err
+	}
+	settings := file.AllSettings()
+	if len(configref.Keys(settings)) == 0 {
+		return nil
+	}
+	if err := configref.Resolve(settings); err != nil {
+		return fmt.Errorf("config file %s: %w", path, err)
+	}
+
+	// Replace the file layer with the resolved values. A secretRef mapping.
+	// becomes a string, which viper cannot merge over the original map.
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return err
+	}
+	viper.SetConfigType("json")
+	return viper.ReadConfig(bytes.NewReader(data))
+}
+
+// loadProjectConfig merges the project-local .aibomgen.yaml files found from.
+// the scan root (or the working directory for other commands) upwards over.
+// the global config. Command-line flags keep precedence.
+func loadProjectConfig(cmd *cobra.Command) {
+	if viper.GetBool("no-project-config") {
+		return
+	}
+	start := "."
+	if cmd == scanCmd {
+		if in := strings.TrimSpace(viper.GetString("scan.input")); in != "" {
+			start = in
+		}
+	}
+	paths, err := projectconfig.Find(start)
+	if err != nil {
+		// An unreadable scan root is reported by the command itself.
+		return
+	}
+	cobra.CheckErr(projectconfig.Apply(viper.GetViper(), paths))
+	for _, p := range paths {
+		fmt.Fprintln(os.Stderr, ui.Dim.Render("Using project config: ")+ui.Secondary.Render(p))
 	}
+	configureUI()
 }
 
 const longDescription = "BOM Generator for Software Projects using AI. Helps PDE manufacturers create accurate Bills of Materials for their AI-based software projects."
 
+// configureUI switches internal/ui to plain output when --no-ui is set or the.
+// terminal cannot show the styled, animated UI, and turns on the field.
+// explanations of --explain-fields.
+func configureUI() {
+	ui.Configure(viper.GetBool("no-ui"), os.Stdout)
+	ui.SetExplainFields(viper.GetBool("explain-fields"))
+}
+
+// configureLocale sets the locale of the reports from --locale. An unknown.
+// locale stops the run.
+func configureLocale() {
+	l, err := locale.Parse(viper.GetString("locale"))
+	if err != nil {
+		cobra.CheckErr(fmt.Errorf("invalid --locale: %w", err))
+	}
+	ui.SetLocale(l)
+}
+
+// configureHTTPCache points the Hugging Face clients at the on-disk response.
+// cache (http-cache.dir, http-cache.ttl) unless --no-cache is set. A cache.
+// that cannot be set up is skipped with a warning.
+func configureHTTPCache() {
+	if viper.GetBool("no-cache") {
+		fetcher.SetHTTPCache(nil)
+		return
+	}
+	ttl, err := parseAge(viper.GetString("http-cache.ttl"))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, ui.GetWarnMark()+" "+ui.Warning.Render(fmt.Sprintf("HTTP cache disabled: invalid http-cache.ttl: %v", err)))
+		fetcher.SetHTTPCache(nil)
+		return
+	}
+	c, err := fetcher.NewHTTPCache(viper.GetString("http-cache.dir"), ttl)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, ui.GetWarnMark()+" "+ui.Warning.Render(fmt.Sprintf("HTTP cache disabled: %v", err)))
+		c = nil
+	}
+	fetcher.SetHTTPCache(c)
+}
+
 func initUIAndBanner(cmd *cobra.Command) {
 	if cmd == nil {
 		return
@@ -1,7 +1,9 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -11,11 +13,15 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/idlab-discover/aibomgen-cli/internal/apperr"
+	"github.com/idlab-discover/aibomgen-cli/internal/bomcache"
 	"github.com/idlab-discover/aibomgen-cli/internal/fetcher"
+	"github.com/idlab-discover/aibomgen-cli/internal/gitrepo"
 	"github.com/idlab-discover/aibomgen-cli/internal/ui"
 	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/bomio"
 	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/generator"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/sarif"
 	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/scanner"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/scanreport"
 )
 
 var (
@@ -29,12 +35,84 @@ var (
 	scanHfMode       string
 	scanHfTimeoutSec int
 	scanHfToken      string
+	scanHfRetries    int
+	scanHfMaxBackoff int
+
+	// Per-stage timeouts and the per-model deadline, in seconds.
+	scanHfAPITimeout     int
+	scanHfReadmeTimeout  int
+	scanHfDatasetTimeout int
+	scanModelDeadline    int
 
 	// Logging is controlled via scanLogLevel.
 	scanLogLevel string
 
+	// scanProgress selects the progress output: ui|jsonl.
+	scanProgress string
+
 	// scanNoSecurityScan disables the HF tree security scan fetch.
 	scanNoSecurityScan bool
+
+	// scanNoBOMCache bypasses the local cache of generated BOMs.
+	scanNoBOMCache bool
+
+	// scanApplication writes one BOM for the scanned application instead of.
+	// one BOM per model.
+	scanApplication bool
+
+	// scanPerProject writes one application BOM per project found in a.
+	// monorepo.
+	scanPerProject bool
+
+	// Overrides for the inferred application identity (with --application).
+	scanAppName    string
+	scanAppVersion string
+	scanAppVCSURL  string
+
+	// scanIncludeComments also reports model IDs in Python comments and.
+	// docstrings (with low confidence).
+	scanIncludeComments bool
+	// scanIgnore lists glob patterns of files and directories to skip.
+	scanIgnore []string
+
+	// scanProbeURLs sends HEAD requests to external weight URLs.
+	scanProbeURLs bool
+	// scanIncludeRawMetadata stores the raw HF metadata on model components.
+	scanIncludeRawMetadata bool
+	// scanReport is the file of the machine-readable scan report ("-": stdout).
+	scanReport string
+	// scanBaseModelDepth is the number of base_model levels followed.
+	scanBaseModelDepth int
+
+	// Revision history of regenerated BOMs.
+	scanPrevious       string
+	scanNoVersionChain bool
+
+	// scanLifecycle overrides the CycloneDX lifecycle phase of the BOMs.
+	scanLifecycle string
+
+	// scanNoHooks skips the hooks.post-generate commands.
+	scanNoHooks bool
+
+	// scanPythonEnvs are Python environments whose installed model weights.
+	// are reported in addition to the scanned directory.
+	scanPythonEnvs []string
+
+	// Remote repository to clone and scan instead of --input, the ref to.
+	// check out and the token of HTTPS clones.
+	scanRepo      string
+	scanRef       string
+	scanRepoToken string
+
+	// scanGitHistory also reports model references found in earlier commits.
+	scanGitHistory           bool
+	scanGitHistoryMaxCommits int
+
+	// Hashing of detected model weight files.
+	scanHashWorkers    int
+	scanHashChunkMiB   int
+	scanHashUseSidecar bool
+	scanHashBLAKE3     bool
 )
 
 // scanCmd represents the scan command.
@@ -57,8 +135,13 @@ func runScan(cmd *cobra.Command, args []string) error {
 	default:
 		return apperr.Userf("invalid --log-level %q (expected quiet|standard|debug)", level)
 	}
+	if err := setProgressStream(viper.GetString("scan.progress")); err != nil {
+		return err
+	}
 
-	quiet := level == "quiet"
+	// A report on stdout replaces the human output.
+	reportPath := strings.TrimSpace(viper.GetString("scan.report"))
+	quiet := level == "quiet" || reportPath == "-"
 
 	// Resolve effective HF mode (from config, env, or flag).
 	mode := strings.ToLower(strings.TrimSpace(viper.GetString("scan.hf-mode")))
@@ -71,6 +154,12 @@ func runScan(cmd *cobra.Command, args []string) error {
 	default:
 		return apperr.Userf("invalid --hf-mode %q (expected online|dummy)", mode)
 	}
+	if n := viper.GetInt("scan.base-model-depth"); n < 0 {
+		return apperr.Userf("invalid --base-model-depth %d (expected 0 or more)", n)
+	}
+	if n := viper.GetInt("scan.git-history-max-commits"); n < 0 {
+		return apperr.Userf("invalid --git-history-max-commits %d (expected 0 or more)", n)
+	}
 
 	inputPath := viper.GetString("scan.input")
 	// Detect whether the user explicitly provided --input on the CLI (vs. using default).
@@ -85,13 +174,66 @@ func runScan(cmd *cobra.Command, args []string) error {
 		return apperr.User("--input cannot be used with --hf-mode=dummy")
 	}
 
+	pythonEnvs := viper.GetStringSlice("scan.python-env")
+	if len(pythonEnvs) > 0 {
+		if mode == "dummy" {
+			return apperr.User("--python-env cannot be used with --hf-mode=dummy")
+		}
+		if viper.GetBool("scan.per-project") {
+			return apperr.User("--python-env cannot be used with --per-project")
+		}
+		for _, env := range pythonEnvs {
+			if info, err := os.Stat(env); err != nil {
+				return apperr.Userf("cannot read --python-env %s: %v", env, err)
+			} else if !info.IsDir() {
+				return apperr.Userf("--python-env %s is not a directory", env)
+			}
+		}
+	}
+
+	// A remote repository is scanned in a temporary checkout.
+	repo := strings.TrimSpace(viper.GetString("scan.repo"))
+	switch {
+	case repo == "" && strings.TrimSpace(viper.GetString("scan.ref")) != "":
+		return apperr.User("--ref requires --repo")
+	case repo != "" && inputPathProvided:
+		return apperr.User("--repo and --input cannot be used together")
+	case repo != "" && mode == "dummy":
+		return apperr.User("--repo cannot be used with --hf-mode=dummy")
+	}
+	var checkout *gitrepo.Checkout
+	if repo != "" {
+		var err error
+		if checkout, err = cloneScanRepo(cmd.Context(), repo, quiet); err != nil {
+			return err
+		}
+		defer checkout.Remove()
+		inputPath = checkout.Dir
+	}
+
+	if viper.GetBool("scan.git-history") {
+		if mode == "dummy" {
+			return apperr.User("--git-history cannot be used with --hf-mode=dummy")
+		}
+		if viper.GetBool("scan.per-project") {
+			return apperr.User("--git-history cannot be used with --per-project")
+		}
+	}
+
 	// Get format from viper.
 	outputFormat := viper.GetString("scan.format")
 	if outputFormat == "" {
 		outputFormat = "auto"
 	}
+	if outputFormat == formatSARIF {
+		return runScanSARIF(cmd, inputPath, mode, quiet)
+	}
 
 	specVersion := viper.GetString("scan.spec")
+	lifecycle, err := generator.ParseLifecyclePhase(viper.GetString("scan.lifecycle"))
+	if err != nil {
+		return apperr.User(err.Error())
+	}
 	outputPath := viper.GetString("scan.output")
 
 	// Fail fast on format/extension mismatch.
@@ -100,7 +242,7 @@ func runScan(cmd *cobra.Command, args []string) error {
 		if outputFormat == "xml" && ext == ".json" {
 			return apperr.Userf("output path extension %q does not match format %q", ext, outputFormat)
 		}
-		if outputFormat == "json" && ext == ".xml" {
+		if (outputFormat == "json" || outputFormat == bomio.FormatSPDXJSON) && ext == ".xml" {
 			return apperr.Userf("output path extension %q does not match format %q", ext, outputFormat)
 		}
 	}
@@ -115,10 +257,29 @@ func runScan(cmd *cobra.Command, args []string) error {
 
 	// Run the scan.
 	var discoveredBOMs []generator.DiscoveredBOM
-	err := runScanDirectory(inputPath, mode, hfToken, timeout, quiet, &discoveredBOMs)
+	application := viper.GetBool("scan.application")
+	perProject := viper.GetBool("scan.per-project")
+	if application && perProject {
+		return apperr.User("--application and --per-project cannot be used together")
+	}
+	appOverrides := generator.ApplicationInfo{
+		Name:    strings.TrimSpace(viper.GetString("scan.app-name")),
+		Version: strings.TrimSpace(viper.GetString("scan.app-version")),
+		VCSURL:  strings.TrimSpace(viper.GetString("scan.app-vcs-url")),
+	}
+	if !application && (appOverrides.Name != "" || appOverrides.Version != "" || appOverrides.VCSURL != "") {
+		return apperr.User("--app-name, --app-version and --app-vcs-url require --application")
+	}
+	var discoveries []scanner.Discovery
+	bomCache := openBOMCache(viper.GetBool("scan.no-bom-cache"))
+	cmd.SetContext(withBOMCache(cmd.Context(), bomCache))
+	err = runScanDirectory(inputPath, mode, hfToken, timeout, quiet, application, perProject, appOverrides, bomCache, &discoveredBOMs, &discoveries)
 	if err != nil {
 		return err
 	}
+	if checkout != nil {
+		trimCheckoutPaths(checkout.Dir, discoveries, discoveredBOMs)
+	}
 
 	// Determine output settings.
 	output := viper.GetString("scan.output")
@@ -133,9 +294,12 @@ func runScan(cmd *cobra.Command, args []string) error {
 	fmtChosen := outputFormat
 	if fmtChosen == "auto" || fmtChosen == "" {
 		ext := filepath.Ext(output)
-		if ext == ".xml" {
+		switch {
+		case ext == ".xml":
 			fmtChosen = "xml"
-		} else {
+		case strings.HasSuffix(strings.ToLower(output), ".spdx.json"):
+			fmtChosen = bomio.FormatSPDXJSON
+		default:
 			fmtChosen = "json"
 		}
 	}
@@ -150,16 +314,56 @@ func runScan(cmd *cobra.Command, args []string) error {
 	}
 
 	fileExt := ".json"
-	if fmtChosen == "xml" {
+	switch fmtChosen {
+	case "xml":
 		fileExt = ".xml"
+	case bomio.FormatSPDXJSON:
+		fileExt = ".spdx.json"
 	}
 
 	// Write output files.
-	written, err := bomio.WriteOutputFiles(discoveredBOMs, outputDir, fileExt, fmtChosen, specVersion)
+	generator.ApplyLifecycle(discoveredBOMs, lifecycle)
+
+	chain := bomio.ChainOptions{
+		Disabled:    viper.GetBool("scan.no-version-chain"),
+		PreviousDir: viper.GetString("scan.previous"),
+		Compare:     compareScores,
+	}
+	written, err := writeTraced(discoveredBOMs, func() ([]string, error) {
+		return bomio.WriteOutputFiles(discoveredBOMs, outputDir, fileExt, fmtChosen, specVersion, chain)
+	})
+	noteWritten(discoveredBOMs, written)
 	if err != nil {
 		return err
 	}
 
+	if reportPath != "" {
+		scanMode := scanreport.ModeModels
+		switch {
+		case perProject:
+			scanMode = scanreport.ModePerProject
+		case application:
+			scanMode = scanreport.ModeApplication
+		}
+		absInput, _ := filepath.Abs(inputPath)
+		if checkout != nil {
+			absInput = gitrepo.Redact(repo)
+		}
+		rep := scanreport.Build(scanreport.Input{
+			Path:        absInput,
+			Mode:        scanMode,
+			ToolVersion: cmd.Root().Version,
+			Discoveries: discoveries,
+			BOMs:        discoveredBOMs,
+			Files:       written,
+			Format:      fmtChosen,
+			Failures:    runSummary.Failures,
+		})
+		if err := writeScanReport(cmd.OutOrStdout(), reportPath, rep); err != nil {
+			return err
+		}
+	}
+
 	// Print summary.
 	if len(written) == 0 {
 		genUI := ui.NewGenerateUI(cmd.OutOrStdout(), quiet)
@@ -169,10 +373,32 @@ func runScan(cmd *cobra.Command, args []string) error {
 
 	genUI := ui.NewGenerateUI(cmd.OutOrStdout(), quiet)
 	genUI.PrintSummary(len(written), outputDir, fmtChosen)
-	return nil
+	return runPostGenerateHooks(cmd, genUI, discoveredBOMs, written, outputDir, fmtChosen, viper.GetBool("scan.no-hooks"))
+}
+
+// writeScanReport writes the machine-readable report of a scan to path, or to.
+// w when path is "-".
+func writeScanReport(w io.Writer, path string, rep scanreport.Report) error {
+	if path == "-" {
+		return rep.Write(w)
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to write scan report: %w", err)
+	}
+	if err := rep.Write(f); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write scan report: %w", err)
+	}
+	return f.Close()
 }
 
-func runScanDirectory(inputPath, mode, hfToken string, timeout time.Duration, quiet bool, results *[]generator.DiscoveredBOM) error {
+func runScanDirectory(inputPath, mode, hfToken string, timeout time.Duration, quiet, application, perProject bool, appOverrides generator.ApplicationInfo, cache *bomcache.Cache, results *[]generator.DiscoveredBOM, found *[]scanner.Discovery) error {
 	hasToken := strings.TrimSpace(hfToken) != ""
 	absTarget, err := filepath.Abs(inputPath)
 	if err != nil {
@@ -188,6 +414,12 @@ func runScanDirectory(inputPath, mode, hfToken string, timeout time.Duration, qu
 		if err != nil {
 			return err
 		}
+		if application || perProject {
+			boms, err = buildApplicationBOM(absTarget, appOverrides, boms, nil)
+			if err != nil {
+				return err
+			}
+		}
 		*results = boms
 		return nil
 	}
@@ -195,6 +427,7 @@ func runScanDirectory(inputPath, mode, hfToken string, timeout time.Duration, qu
 	// Track per-model outcome for the final summary (same pattern as generate command).
 	pendingModels := make(map[string]*modelTracker)
 	var modelOrder []string
+	var hubErr error // set when the HF Hub was found unreachable
 
 	// Create workflow (only if not quiet).
 	var workflow *ui.Workflow
@@ -213,7 +446,19 @@ func runScanDirectory(inputPath, mode, hfToken string, timeout time.Duration, qu
 		workflow.StartTask(scanTaskIdx, ui.Dim.Render(absTarget))
 	}
 
-	discoveries, err := scanner.Scan(absTarget)
+	scanOpts := scanOptions()
+	if !quiet && workflow != nil {
+		scanOpts.Hash.OnProgress = func(p scanner.HashProgress) {
+			if p.Total <= 0 || p.Done >= p.Total {
+				workflow.UpdateMessage(scanTaskIdx, ui.Dim.Render(absTarget))
+				return
+			}
+			pct := p.Done * 100 / p.Total
+			workflow.UpdateMessage(scanTaskIdx, ui.Dim.Render(fmt.Sprintf("hashing %s (%d%%)", filepath.Base(p.Path), pct)))
+		}
+	}
+
+	discoveries, projects, removed, err := detect(absTarget, inputPath, perProject, scanOpts)
 	if err != nil {
 		if !quiet && workflow != nil {
 			workflow.FailTask(scanTaskIdx, err.Error())
@@ -221,17 +466,37 @@ func runScanDirectory(inputPath, mode, hfToken string, timeout time.Duration, qu
 		}
 		return err
 	}
+	*found = discoveries
+
+	// Templated references cannot be fetched; they are listed after the run.
+	// Hosted models are recorded as services instead of being fetched.
+	discoveries, parameterized := splitParameterized(discoveries)
+	discoveries, hosted := splitHosted(discoveries)
+	printParameterized := func() {
+		ui.NewGenerateUI(os.Stdout, quiet).PrintParameterized(parameterized)
+	}
 
 	if !quiet && workflow != nil {
-		workflow.CompleteTask(scanTaskIdx, fmt.Sprintf("found %d possible model(s)", len(discoveries)))
+		msg := fmt.Sprintf("found %d possible model(s)", len(discoveries))
+		if len(hosted) > 0 {
+			msg += fmt.Sprintf(" and %d hosted model API(s)", len(hosted))
+		}
+		if perProject {
+			msg += fmt.Sprintf(" in %d project(s)", len(projects))
+		}
+		if removed > 0 {
+			msg += fmt.Sprintf(", %d removed from the git history", removed)
+		}
+		workflow.CompleteTask(scanTaskIdx, msg)
 	}
 
-	if len(discoveries) == 0 {
+	if len(discoveries) == 0 && len(hosted) == 0 {
 		if !quiet && workflow != nil {
 			workflow.SkipTask(processTaskIdx, "no models to process")
 			workflow.SkipTask(writeTaskIdx, "no files to write")
 			workflow.Stop()
 		}
+		printParameterized()
 		*results = []generator.DiscoveredBOM{}
 		return nil
 	}
@@ -245,6 +510,8 @@ func runScanDirectory(inputPath, mode, hfToken string, timeout time.Duration, qu
 	}
 
 	onProgress := func(evt generator.ProgressEvent) {
+		noteProgress(evt)
+		streamProgress(evt)
 		if quiet || workflow == nil {
 			return
 		}
@@ -258,6 +525,13 @@ func runScanDirectory(inputPath, mode, hfToken string, timeout time.Duration, qu
 			workflow.UpdateMessage(processTaskIdx, ui.Dim.Render(fmt.Sprintf("%d/%d: %s (fetching)", modelsCompleted, totalModels, evt.ModelID)))
 		case generator.EventFetchAPIComplete:
 			pendingModels[evt.ModelID].apiOK = true
+		case generator.EventCacheHit:
+			pendingModels[evt.ModelID].cached = true
+		case generator.EventRetry:
+			workflow.UpdateMessage(processTaskIdx, ui.Dim.Render(fmt.Sprintf("%d/%d: %s (%s)", modelsCompleted, totalModels, evt.ModelID, evt.Message)))
+		case generator.EventHubUnreachable:
+			hubErr = evt.Error
+			workflow.UpdateMessage(processTaskIdx, ui.Warning.Render(fmt.Sprintf("%d/%d: %s", modelsCompleted, totalModels, evt.Message)))
 		case generator.EventBuildStart:
 			workflow.UpdateMessage(processTaskIdx, ui.Dim.Render(fmt.Sprintf("%d/%d: %s (building)", modelsCompleted, totalModels, evt.ModelID)))
 		case generator.EventDatasetStart:
@@ -276,7 +550,9 @@ func runScanDirectory(inputPath, mode, hfToken string, timeout time.Duration, qu
 		case generator.EventError:
 			if evt.Message != "BOM build failed" {
 				t := pendingModels[evt.ModelID]
-				if fetcher.IsNotFound(evt.Error) {
+				if errors.Is(evt.Error, fetcher.ErrHubUnreachable) {
+					t.hubDown = true
+				} else if fetcher.IsNotFound(evt.Error) {
 					t.notFound = true
 				} else if fetcher.IsUnauthorized(evt.Error) && !t.apiOK {
 					// 401/403 before the model API succeeded = model is private or non-existent.
@@ -293,13 +569,55 @@ func runScanDirectory(inputPath, mode, hfToken string, timeout time.Duration, qu
 	}
 
 	opts := generator.GenerateOptions{
-		HFToken:          hfToken,
-		Timeout:          timeout,
-		OnProgress:       onProgress,
-		SkipSecurityScan: scanNoSecurityScan,
+		HFToken:            hfToken,
+		Timeout:            timeout,
+		OnProgress:         onProgress,
+		SkipSecurityScan:   scanNoSecurityScan,
+		Cache:              cache,
+		MaxRetries:         viper.GetInt("scan.hf-retries"),
+		MaxBackoff:         time.Duration(viper.GetInt("scan.hf-max-backoff")) * time.Second,
+		APITimeout:         time.Duration(viper.GetInt("scan.hf-api-timeout")) * time.Second,
+		ReadmeTimeout:      time.Duration(viper.GetInt("scan.hf-readme-timeout")) * time.Second,
+		DatasetTimeout:     time.Duration(viper.GetInt("scan.hf-dataset-timeout")) * time.Second,
+		ModelDeadline:      time.Duration(viper.GetInt("scan.model-deadline")) * time.Second,
+		ProbeWeightURLs:    viper.GetBool("scan.probe-urls"),
+		IncludeRawMetadata: viper.GetBool("scan.include-raw-metadata"),
+		BaseModelDepth:     viper.GetInt("scan.base-model-depth"),
+		Context:            traceCtx,
 	}
 
-	boms, err := generator.BuildPerDiscovery(discoveries, opts)
+	var boms []generator.DiscoveredBOM
+	switch {
+	case perProject:
+		boms, err = generator.BuildPerProject(projects, opts)
+	case application:
+		boms, err = generator.BuildPerDiscovery(discoveries, opts)
+		if err == nil {
+			var bundles []generator.DiscoveredBOM
+			if bundles, err = generator.BuildServingBundleBOMs(boms); err == nil {
+				boms, err = buildApplicationBOM(absTarget, appOverrides, boms, hosted)
+				boms = append(boms, bundles...)
+			}
+		}
+	default:
+		boms, err = generator.BuildPerDiscovery(discoveries, opts)
+		if err == nil {
+			// BentoML services and KServe InferenceServices get a BOM of.
+			// their own next to the models they package.
+			var bundles []generator.DiscoveredBOM
+			bundles, err = generator.BuildServingBundleBOMs(boms)
+			boms = append(boms, bundles...)
+		}
+		if err == nil {
+			// Hosted model APIs are services of the scanned application.
+			var app generator.DiscoveredBOM
+			var ok bool
+			app, ok, err = generator.BuildHostedServicesBOM(generator.InferApplicationInfo(absTarget), hosted)
+			if ok {
+				boms = append(boms, app)
+			}
+		}
+	}
 	if err != nil {
 		if !quiet && workflow != nil {
 			workflow.FailTask(processTaskIdx, err.Error())
@@ -309,32 +627,247 @@ func runScanDirectory(inputPath, mode, hfToken string, timeout time.Duration, qu
 	}
 
 	if !quiet && workflow != nil {
-		workflow.CompleteTask(processTaskIdx, fmt.Sprintf("%d possible model(s)", len(discoveries)))
+		msg := fmt.Sprintf("%d possible model(s)", len(discoveries))
+		if len(hosted) > 0 {
+			msg += fmt.Sprintf(", %d hosted model API(s)", len(hosted))
+		}
+		workflow.CompleteTask(processTaskIdx, msg)
 		workflow.StartTask(writeTaskIdx, "")
 		workflow.CompleteTask(writeTaskIdx, fmt.Sprintf("%d file(s)", len(boms)))
 		workflow.Stop()
 
 		// Print individual model results after workflow completes.
 		fmt.Println()
-		for _, id := range modelOrder {
-			printModelResult(id, pendingModels[id], hasToken)
-		}
+		printModelResults(modelOrder, pendingModels, hubErr, hasToken)
 	}
+	printParameterized()
 
 	*results = boms
 	return nil
 }
 
+// formatSARIF is the scan output format listing the discoveries as SARIF.
+// results instead of generating AIBOMs.
+const formatSARIF = "sarif"
+
+// runScanSARIF writes the discoveries of the scan as a SARIF log for code.
+// scanning. Nothing is fetched from Hugging Face and no AIBOM is written.
+func runScanSARIF(cmd *cobra.Command, inputPath, mode string, quiet bool) error {
+	switch {
+	case mode == "dummy":
+		return apperr.User("--format sarif cannot be used with --hf-mode=dummy")
+	case viper.GetBool("scan.application"):
+		return apperr.User("--format sarif cannot be used with --application")
+	case strings.TrimSpace(viper.GetString("scan.report")) != "":
+		return apperr.User("--format sarif cannot be used with --report")
+	}
+	absTarget, err := filepath.Abs(inputPath)
+	if err != nil {
+		return err
+	}
+	discoveries, _, _, err := detect(absTarget, inputPath, viper.GetBool("scan.per-project"), scanOptions())
+	if err != nil {
+		return err
+	}
+	log := sarif.Build(discoveries, sarif.Options{ToolVersion: cmd.Root().Version, BaseDir: repositoryRoot(absTarget)})
+
+	// Like the AIBOMs, the log goes to the directory of --output unless it.
+	// names a .sarif or .json file.
+	output := strings.TrimSpace(viper.GetString("scan.output"))
+	if output == "-" {
+		return log.Write(cmd.OutOrStdout())
+	}
+	if ext := strings.ToLower(filepath.Ext(output)); ext != ".sarif" && ext != ".json" {
+		dir := "dist"
+		if output != "" {
+			dir = filepath.Dir(output)
+		}
+		output = filepath.Join(dir, "aibomgen.sarif")
+	}
+	if dir := filepath.Dir(output); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	f, err := os.Create(output)
+	if err != nil {
+		return fmt.Errorf("failed to write SARIF log: %w", err)
+	}
+	if err := log.Write(f); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write SARIF log: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write SARIF log: %w", err)
+	}
+	if !quiet {
+		fmt.Fprintln(cmd.OutOrStdout(), ui.SuccessBox.Render(fmt.Sprintf("%s Wrote %d result(s) to %s", ui.GetCheckMark(), len(discoveries), output)))
+	}
+	return nil
+}
+
+// repositoryRoot returns the root of the git work tree containing dir, or dir.
+// itself outside a repository. SARIF locations are relative to it, as code.
+// scanning expects.
+func repositoryRoot(dir string) string {
+	for d := dir; ; {
+		if _, err := os.Stat(filepath.Join(d, ".git")); err == nil {
+			return d
+		}
+		parent := filepath.Dir(d)
+		if parent == d {
+			return dir
+		}
+		d = parent
+	}
+}
+
+// scanOptions returns the scanner options set by the scan flags.
+func scanOptions() scanner.Options {
+	return scanner.Options{
+		IncludeComments: viper.GetBool("scan.include-comments"),
+		Ignore:          viper.GetStringSlice("scan.ignore"),
+		Hash: scanner.HashOptions{
+			Workers:    viper.GetInt("scan.hash-workers"),
+			ChunkSize:  int64(viper.GetInt("scan.hash-chunk-size")) << 20,
+			UseSidecar: viper.GetBool("scan.hash-sidecar"),
+			BLAKE3:     viper.GetBool("scan.hash-blake3"),
+		},
+	}
+}
+
+// detect runs the scanner on absTarget, the python environments and, when.
+// enabled, the git history. removed counts the references found only in the.
+// history.
+func detect(absTarget, inputPath string, perProject bool, scanOpts scanner.Options) (discoveries []scanner.Discovery, projects []scanner.Project, removed int, err error) {
+	if perProject {
+		projects, err = scanner.ScanProjects(absTarget, scanOpts)
+		discoveries = scanner.ProjectDiscoveries(projects)
+	} else {
+		discoveries, err = scanner.ScanWithOptions(absTarget, scanOpts)
+	}
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	// Installed model weights are reported next to the references in code.
+	for _, env := range viper.GetStringSlice("scan.python-env") {
+		found, err := scanner.ScanPythonEnv(env, scanOpts)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		discoveries = append(discoveries, found...)
+	}
+	// References removed from the code are recovered from the git history.
+	if viper.GetBool("scan.git-history") {
+		history, err := scanner.ScanGitHistory(absTarget, viper.GetInt("scan.git-history-max-commits"), scanOpts)
+		if errors.Is(err, scanner.ErrNotGitRepository) {
+			return nil, nil, 0, apperr.Userf("cannot use --git-history: %s is not in a git repository", inputPath)
+		}
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		n := len(discoveries)
+		discoveries = scanner.MergeHistory(discoveries, history)
+		removed = len(discoveries) - n
+	}
+	return discoveries, projects, removed, nil
+}
+
+// splitParameterized separates templated model references from the.
+// discoveries that can be resolved to a model.
+func splitParameterized(discoveries []scanner.Discovery) (resolvable, parameterized []scanner.Discovery) {
+	for _, d := range discoveries {
+		if d.Type == scanner.DiscoveryTypeParameterized {
+			parameterized = append(parameterized, d)
+		} else {
+			resolvable = append(resolvable, d)
+		}
+	}
+	return resolvable, parameterized
+}
+
+// splitHosted separates the hosted model APIs, which become services of the.
+// application, from the discoveries that are built into AIBOMs of their own.
+func splitHosted(discoveries []scanner.Discovery) (models, hosted []scanner.Discovery) {
+	for _, d := range discoveries {
+		if d.Type == scanner.DiscoveryTypeHostedModel {
+			hosted = append(hosted, d)
+		} else {
+			models = append(models, d)
+		}
+	}
+	return models, hosted
+}
+
+// buildApplicationBOM combines the per-model BOMs into one BOM for the.
+// application rooted at absTarget, with the hosted model APIs among hosted.
+// as services. Non-empty fields of overrides replace the inferred.
+// application identity.
+func buildApplicationBOM(absTarget string, overrides generator.ApplicationInfo, boms []generator.DiscoveredBOM, hosted []scanner.Discovery) ([]generator.DiscoveredBOM, error) {
+	if len(boms) == 0 && len(hosted) == 0 {
+		return boms, nil
+	}
+	info := generator.InferApplicationInfo(absTarget)
+	if overrides.Name != "" {
+		info.Name = overrides.Name
+	}
+	if overrides.Version != "" {
+		info.Version = overrides.Version
+	}
+	if overrides.VCSURL != "" {
+		info.VCSURL = overrides.VCSURL
+	}
+	app, err := generator.BuildApplicationBOM(info, boms)
+	if err != nil {
+		return nil, err
+	}
+	generator.AddHostedServices(app.BOM, hosted)
+	return []generator.DiscoveredBOM{app}, nil
+}
+
 func init() {
 	scanCmd.Flags().StringVarP(&scanPath, "input", "i", "", "Path to scan (defaults to current directory)")
 	scanCmd.Flags().StringVarP(&scanOutput, "output", "o", "", "Output file path (directory is used)")
-	scanCmd.Flags().StringVarP(&scanOutputFormat, "format", "f", "", "Output BOM format: json|xml|auto")
+	scanCmd.Flags().StringVarP(&scanOutputFormat, "format", "f", "", "Output format: json|xml|spdx-json|auto, or sarif to write the discoveries as a SARIF log instead of AIBOMs")
 	scanCmd.Flags().StringVar(&scanSpecVersion, "spec", "", "CycloneDX spec version for output (e.g., 1.4, 1.5, 1.6)")
 	scanCmd.Flags().StringVar(&scanHfMode, "hf-mode", "", "Hugging Face metadata mode: online|dummy")
 	scanCmd.Flags().IntVar(&scanHfTimeoutSec, "hf-timeout", 0, "Timeout in seconds per Hugging Face API request (default 10)")
+	scanCmd.Flags().IntVar(&scanHfAPITimeout, "hf-api-timeout", 0, "Timeout in seconds per model API and repository file request (default: --hf-timeout)")
+	scanCmd.Flags().IntVar(&scanHfReadmeTimeout, "hf-readme-timeout", 0, "Timeout in seconds per model card request (default: --hf-timeout)")
+	scanCmd.Flags().IntVar(&scanHfDatasetTimeout, "hf-dataset-timeout", 0, "Timeout in seconds per dataset API and dataset card request (default: --hf-timeout)")
+	scanCmd.Flags().IntVar(&scanModelDeadline, "model-deadline", 0, "Deadline in seconds for all requests of one model, retries included (0: none)")
+	scanCmd.Flags().IntVar(&scanHfRetries, "hf-retries", 3, "Retries of rate-limited (429) or transiently failing Hugging Face requests (0 disables retries)")
+	scanCmd.Flags().IntVar(&scanHfMaxBackoff, "hf-max-backoff", 30, "Longest wait in seconds before retrying a Hugging Face request")
 	scanCmd.Flags().StringVar(&scanHfToken, "hf-token", "", "Hugging Face access token")
 	scanCmd.Flags().StringVar(&scanLogLevel, "log-level", "", "Log level: quiet|standard|debug")
+	scanCmd.Flags().StringVar(&scanProgress, "progress", "", "Progress output: ui|jsonl (jsonl writes one JSON object per progress event to stderr)")
 	scanCmd.Flags().BoolVar(&scanNoSecurityScan, "no-security-scan", false, "Skip fetching the HuggingFace security scan tree")
+	scanCmd.Flags().BoolVar(&scanNoBOMCache, "no-bom-cache", false, "Bypass the local cache of generated BOMs (cached BOMs keep their dataset and base model components until the model itself changes)")
+	scanCmd.Flags().BoolVar(&scanApplication, "application", false, "Write one BOM for the scanned application with all discovered models and datasets as components")
+	scanCmd.Flags().BoolVar(&scanPerProject, "per-project", false, "Detect projects (pyproject.toml, package.json, go.mod) in a monorepo and write one application BOM per project")
+	scanCmd.Flags().StringVar(&scanAppName, "app-name", "", "Application name for --application (default: inferred from go.mod, pyproject.toml, package.json or git)")
+	scanCmd.Flags().StringVar(&scanAppVersion, "app-version", "", "Application version for --application (default: inferred from project files or the latest git tag)")
+	scanCmd.Flags().StringVar(&scanAppVCSURL, "app-vcs-url", "", "Application repository URL for --application (default: inferred from project files or the git origin remote)")
+	scanCmd.Flags().BoolVar(&scanIncludeComments, "include-comments", false, "Also report model IDs found in Python comments and docstrings (marked low confidence)")
+	scanCmd.Flags().StringSliceVar(&scanIgnore, "ignore", nil, "Glob patterns of files or directories to skip (e.g. examples/,*.ipynb; can be repeated)")
+	scanCmd.Flags().BoolVar(&scanProbeURLs, "probe-urls", false, "Send a HEAD request to external weight URLs (S3, GCS, HTTPS) to record their size and ETag")
+	scanCmd.Flags().BoolVar(&scanIncludeRawMetadata, "include-raw-metadata", false, "Store the compressed raw Hugging Face API response and model card front matter on each model component")
+	scanCmd.Flags().StringVar(&scanReport, "report", "", "Write a versioned machine-readable JSON report of the scan to this file (\"-\": stdout, replacing the human output)")
+	scanCmd.Flags().IntVar(&scanBaseModelDepth, "base-model-depth", 0, "Follow the base_model chain of each model card up to this many levels, adding every ancestor as a component")
+	scanCmd.Flags().StringVar(&scanPrevious, "previous", "", "Directory with the previous revisions of the BOMs (default: the output directory)")
+	scanCmd.Flags().BoolVar(&scanNoVersionChain, "no-version-chain", false, "Write new BOMs (fresh serial number, version 1) instead of continuing previous revisions")
+	scanCmd.Flags().BoolVar(&scanNoHooks, "no-hooks", false, "Do not run the configured post-generate hooks")
+	scanCmd.Flags().StringVar(&scanLifecycle, "lifecycle", "", "CycloneDX lifecycle phase of the BOMs: design|pre-build|build|post-build|operations|discovery|decommission (default: from the command context)")
+	scanCmd.Flags().StringArrayVar(&scanPythonEnvs, "python-env", nil, "Python environment (virtualenv, conda env, site-packages or model cache directory) whose installed model weights are also reported (repeatable)")
+	scanCmd.Flags().StringVar(&scanRepo, "repo", "", "Clone this Git repository URL (HTTPS or SSH) to a temporary directory and scan it instead of --input")
+	scanCmd.Flags().StringVar(&scanRef, "ref", "", "Branch, tag or full commit SHA of --repo to scan (default: the default branch)")
+	scanCmd.Flags().StringVar(&scanRepoToken, "repo-token", "", "Access token for cloning an HTTPS --repo (\"user:token\" or a bare token); SSH URLs use the SSH agent")
+	scanCmd.Flags().BoolVar(&scanGitHistory, "git-history", false, "Also report model references that earlier commits added and later ones removed, and tag every reference with the commits that introduced and removed it")
+	scanCmd.Flags().IntVar(&scanGitHistoryMaxCommits, "git-history-max-commits", 1000, "Walk at most this many commits of the git history, newest first (0: all)")
+	scanCmd.Flags().IntVar(&scanHashWorkers, "hash-workers", 0, "Concurrent chunk readers per file when hashing model weight files (default: 4)")
+	scanCmd.Flags().IntVar(&scanHashChunkMiB, "hash-chunk-size", 64, "Chunk size in MiB when hashing model weight files")
+	scanCmd.Flags().BoolVar(&scanHashUseSidecar, "hash-sidecar", false, "Reuse digests from <file>.sha256 sidecar files when present")
+	scanCmd.Flags().BoolVar(&scanHashBLAKE3, "hash-blake3", false, "Also record the BLAKE3 digest of model weight files (always reads the files, even with --hash-sidecar)")
 
 	// Bind all flags to viper for config file support.
 	viper.BindPFlag("scan.input", scanCmd.Flags().Lookup("input"))
@@ -343,6 +876,39 @@ func init() {
 	viper.BindPFlag("scan.spec", scanCmd.Flags().Lookup("spec"))
 	viper.BindPFlag("scan.hf-mode", scanCmd.Flags().Lookup("hf-mode"))
 	viper.BindPFlag("scan.hf-timeout", scanCmd.Flags().Lookup("hf-timeout"))
+	viper.BindPFlag("scan.hf-api-timeout", scanCmd.Flags().Lookup("hf-api-timeout"))
+	viper.BindPFlag("scan.hf-readme-timeout", scanCmd.Flags().Lookup("hf-readme-timeout"))
+	viper.BindPFlag("scan.hf-dataset-timeout", scanCmd.Flags().Lookup("hf-dataset-timeout"))
+	viper.BindPFlag("scan.model-deadline", scanCmd.Flags().Lookup("model-deadline"))
+	viper.BindPFlag("scan.hf-retries", scanCmd.Flags().Lookup("hf-retries"))
+	viper.BindPFlag("scan.hf-max-backoff", scanCmd.Flags().Lookup("hf-max-backoff"))
 	viper.BindPFlag("scan.hf-token", scanCmd.Flags().Lookup("hf-token"))
 	viper.BindPFlag("scan.log-level", scanCmd.Flags().Lookup("log-level"))
+	viper.BindPFlag("scan.progress", scanCmd.Flags().Lookup("progress"))
+	viper.BindPFlag("scan.no-bom-cache", scanCmd.Flags().Lookup("no-bom-cache"))
+	viper.BindPFlag("scan.application", scanCmd.Flags().Lookup("application"))
+	viper.BindPFlag("scan.per-project", scanCmd.Flags().Lookup("per-project"))
+	viper.BindPFlag("scan.app-name", scanCmd.Flags().Lookup("app-name"))
+	viper.BindPFlag("scan.app-version", scanCmd.Flags().Lookup("app-version"))
+	viper.BindPFlag("scan.app-vcs-url", scanCmd.Flags().Lookup("app-vcs-url"))
+	viper.BindPFlag("scan.include-comments", scanCmd.Flags().Lookup("include-comments"))
+	viper.BindPFlag("scan.ignore", scanCmd.Flags().Lookup("ignore"))
+	viper.BindPFlag("scan.probe-urls", scanCmd.Flags().Lookup("probe-urls"))
+	viper.BindPFlag("scan.include-raw-metadata", scanCmd.Flags().Lookup("include-raw-metadata"))
+	viper.BindPFlag("scan.base-model-depth", scanCmd.Flags().Lookup("base-model-depth"))
+	viper.BindPFlag("scan.report", scanCmd.Flags().Lookup("report"))
+	viper.BindPFlag("scan.previous", scanCmd.Flags().Lookup("previous"))
+	viper.BindPFlag("scan.no-version-chain", scanCmd.Flags().Lookup("no-version-chain"))
+	viper.BindPFlag("scan.no-hooks", scanCmd.Flags().Lookup("no-hooks"))
+	viper.BindPFlag("scan.lifecycle", scanCmd.Flags().Lookup("lifecycle"))
+	viper.BindPFlag("scan.python-env", scanCmd.Flags().Lookup("python-env"))
+	viper.BindPFlag("scan.repo", scanCmd.Flags().Lookup("repo"))
+	viper.BindPFlag("scan.ref", scanCmd.Flags().Lookup("ref"))
+	viper.BindPFlag("scan.repo-token", scanCmd.Flags().Lookup("repo-token"))
+	viper.BindPFlag("scan.git-history", scanCmd.Flags().Lookup("git-history"))
+	viper.BindPFlag("scan.git-history-max-commits", scanCmd.Flags().Lookup("git-history-max-commits"))
+	viper.BindPFlag("scan.hash-workers", scanCmd.Flags().Lookup("hash-workers"))
+	viper.BindPFlag("scan.hash-chunk-size", scanCmd.Flags().Lookup("hash-chunk-size"))
+	viper.BindPFlag("scan.hash-sidecar", scanCmd.Flags().Lookup("hash-sidecar"))
+	viper.BindPFlag("scan.hash-blake3", scanCmd.Flags().Lookup("hash-blake3"))
 }
@@ -0,0 +1,277 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/idlab-discover/aibomgen-cli/internal/apperr"
+	"github.com/idlab-discover/aibomgen-cli/internal/signing"
+	"github.com/idlab-discover/aibomgen-cli/internal/ui"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// signCmd represents the sign command.
+var signCmd = &cobra.Command{
+	Use:   "sign <bom|dir>...",
+	Short: "Sign AIBOMs with cosign (Sigstore)",
+	Long: `Sign AIBOM files with cosign and write the Sigstore bundle next to each BOM.
+
+With --mode signature (default) the bundle holds a detached signature over the
+BOM file, in <bom>.sigstore.json. With --mode attestation it holds an in-toto
+attestation with the BOM as predicate (type https://cyclonedx.org/bom), in
+<bom>.intoto.sigstore.json.
+
+With --key the BOM is signed with that private key (a file or KMS URI;
+encrypted keys are unlocked with COSIGN_PASSWORD). Without it cosign signs
+keyless: it obtains a short-lived certificate for your OIDC identity (or for
+--identity-token in CI) and records the signature in the Rekor transparency
+log. cosign must be installed.
+
+Directories sign every .json and .xml BOM they contain. generate --sign signs
+the BOMs it writes with these settings.
+
+Example:
+  aibomgen-cli sign dist/
+  aibomgen-cli sign dist/model_aibom.json --key cosign.key
+  aibomgen-cli sign dist/ --mode attestation --identity-token "$SIGSTORE_ID_TOKEN"`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runSign,
+}
+
+// verifySignatureCmd represents the verify-signature command.
+var verifySignatureCmd = &cobra.Command{
+	Use:   "verify-signature <bom|dir>...",
+	Short: "Verify the cosign signatures of AIBOMs",
+	Long: `Verify the Sigstore bundles that sign writes next to AIBOM files, before
+the BOMs are consumed.
+
+Key-based signatures are verified with --key (the public key). Keyless
+signatures are verified against the identity that must have signed them:
+--certificate-identity (e.g. the e-mail address or CI workflow URL) and
+--certificate-oidc-issuer. BOMs without a bundle fail the command.
+
+merge --require-signature and check-advisories --require-signature verify
+their inputs with these settings (the verify-signature section of the config
+file) before reading them.
+
+Example:
+  aibomgen-cli verify-signature dist/ --key cosign.pub
+  aibomgen-cli verify-signature dist/model_aibom.json \
+    --certificate-identity ci@example.com --certificate-oidc-issuer https://accounts.google.com`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runVerifySignature,
+}
+
+func runSign(cmd *cobra.Command, args []string) error {
+	quiet, err := signLogQuiet("sign")
+	if err != nil {
+		return err
+	}
+	opts, err := signOptions()
+	if err != nil {
+		return err
+	}
+	paths, err := bomFiles(args)
+	if err != nil {
+		return err
+	}
+
+	w := cmd.OutOrStdout()
+	bundles, err := signBOMs(cmd.Context(), paths, opts)
+	if !quiet {
+		for _, b := range bundles {
+			fmt.Fprintf(w, "%s %s\n", ui.GetCheckMark(), b)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if !quiet {
+		fmt.Fprintf(w, "\n%s\n", ui.SuccessBox.Render(fmt.Sprintf("%s Signed %d AIBOM(s) (%s)", ui.GetCheckMark(), len(bundles), opts.Mode)))
+	}
+	return nil
+}
+
+func runVerifySignature(cmd *cobra.Command, args []string) error {
+	quiet, err := signLogQuiet("verify-signature")
+	if err != nil {
+		return err
+	}
+	paths, err := bomFiles(args)
+	if err != nil {
+		return err
+	}
+	return verifyBOMSignatures(cmd.Context(), cmd.OutOrStdout(), paths, quiet)
+}
+
+func signLogQuiet(section string) (bool, error) {
+	level := strings.ToLower(strings.TrimSpace(viper.GetString(section + ".log-level")))
+	switch level {
+	case "", "standard", "debug":
+		return false, nil
+	case "quiet":
+		return true, nil
+	}
+	return false, apperr.Userf("invalid --log-level %q (expected quiet|standard|debug)", level)
+}
+
+// signOptions returns the signing settings of the sign config section,.
+// which generate --sign uses too.
+func signOptions() (signing.Options, error) {
+	mode, err := signing.ParseMode(viper.GetString("sign.mode"))
+	if err != nil {
+		return signing.Options{}, apperr.User(err.Error())
+	}
+	return signing.Options{
+		Cosign:        strings.TrimSpace(viper.GetString("sign.cosign")),
+		Mode:          mode,
+		Key:           strings.TrimSpace(viper.GetString("sign.key")),
+		IdentityToken: strings.TrimSpace(viper.GetString("sign.identity-token")),
+	}, nil
+}
+
+// verifySignatureOptions returns the verification settings of the.
+// verify-signature config section.
+func verifySignatureOptions() (signing.Options, error) {
+	mode, err := signing.ParseMode(viper.GetString("verify-signature.mode"))
+	if err != nil {
+		return signing.Options{}, apperr.User(err.Error())
+	}
+	opts := signing.Options{
+		Cosign:                strings.TrimSpace(viper.GetString("verify-signature.cosign")),
+		Mode:                  mode,
+		Key:                   strings.TrimSpace(viper.GetString("verify-signature.key")),
+		CertificateIdentity:   strings.TrimSpace(viper.GetString("verify-signature.certificate-identity")),
+		CertificateOIDCIssuer: strings.TrimSpace(viper.GetString("verify-signature.certificate-oidc-issuer")),
+	}
+	if opts.Key == "" && (opts.CertificateIdentity == "" || opts.CertificateOIDCIssuer == "") {
+		return signing.Options{}, apperr.User("signatures are verified with a public key (verify-signature.key) or a keyless identity (verify-signature.certificate-identity and certificate-oidc-issuer)")
+	}
+	return opts, nil
+}
+
+// signBOMs signs every BOM in paths and returns the written bundles. It stops.
+// at the first failure.
+func signBOMs(ctx context.Context, paths []string, opts signing.Options) ([]string, error) {
+	var bundles []string
+	for _, p := range paths {
+		b, err := signing.Sign(ctx, p, opts)
+		if err != nil {
+			return bundles, err
+		}
+		bundles = append(bundles, b)
+	}
+	return bundles, nil
+}
+
+// verifyBOMSignatures verifies the bundles of paths with the verify-signature.
+// settings and fails when any of them does not verify.
+func verifyBOMSignatures(ctx context.Context, w io.Writer, paths []string, quiet bool) error {
+	opts, err := verifySignatureOptions()
+	if err != nil {
+		return err
+	}
+	failed := 0
+	for _, p := range paths {
+		err := signing.Verify(ctx, p, opts)
+		if err == nil {
+			if !quiet {
+				fmt.Fprintf(w, "%s %s\n", ui.GetCheckMark(), p)
+			}
+			continue
+		}
+		failed++
+		if !quiet {
+			fmt.Fprintf(w, "%s %s\n    %s\n", ui.GetCrossMark(), p, ui.Muted.Render(err.Error()))
+		}
+		if errors.Is(err, signing.ErrNoSignature) && !quiet {
+			fmt.Fprintf(w, "    %s\n", ui.Muted.Render("expected "+signing.BundlePath(p, opts.Mode)))
+		}
+	}
+	if failed > 0 {
+		return apperr.Userf("%d of %d AIBOM signature(s) did not verify", failed, len(paths))
+	}
+	return nil
+}
+
+// bomFiles expands directories among inputs to the BOM files they contain,.
+// leaving signature bundles out.
+func bomFiles(inputs []string) ([]string, error) {
+	var out []string
+	for _, in := range inputs {
+		info, err := os.Stat(in)
+		if err != nil {
+			return nil, apperr.Userf("cannot read %s: %v", in, err)
+		}
+		if !info.IsDir() {
+			out = append(out, in)
+			continue
+		}
+		entries, err := os.ReadDir(in)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			ext := strings.ToLower(filepath.Ext(e.Name()))
+			if e.IsDir() || (ext != ".json" && ext != ".xml") || signing.IsBundle(e.Name()) {
+				continue
+			}
+			out = append(out, filepath.Join(in, e.Name()))
+		}
+	}
+	if len(out) == 0 {
+		return nil, apperr.User("no AIBOM files found")
+	}
+	return out, nil
+}
+
+// ── Flag vars ─────────────────────────────────────────────────────────────────.
+
+var (
+	signMode          string
+	signKey           string
+	signIdentityToken string
+	signCosign        string
+	signLogLevel      string
+
+	verifySigMode     string
+	verifySigKey      string
+	verifySigIdentity string
+	verifySigIssuer   string
+	verifySigCosign   string
+	verifySigLogLevel string
+)
+
+func init() {
+	signCmd.Flags().StringVar(&signMode, "mode", "signature", "What to produce: signature (detached) or attestation (in-toto)")
+	signCmd.Flags().StringVar(&signKey, "key", "", "Private key file or KMS URI (empty: keyless)")
+	signCmd.Flags().StringVar(&signIdentityToken, "identity-token", "", "OIDC identity token for keyless signing (e.g. in CI)")
+	signCmd.Flags().StringVar(&signCosign, "cosign", "", "cosign executable (default: cosign from PATH)")
+	signCmd.Flags().StringVar(&signLogLevel, "log-level", "", "Log level: quiet|standard|debug")
+
+	viper.BindPFlag("sign.mode", signCmd.Flags().Lookup("mode"))
+	viper.BindPFlag("sign.key", signCmd.Flags().Lookup("key"))
+	viper.BindPFlag("sign.identity-token", signCmd.Flags().Lookup("identity-token"))
+	viper.BindPFlag("sign.cosign", signCmd.Flags().Lookup("cosign"))
+	viper.BindPFlag("sign.log-level", signCmd.Flags().Lookup("log-level"))
+
+	verifySignatureCmd.Flags().StringVar(&verifySigMode, "mode", "signature", "What to verify: signature (detached) or attestation (in-toto)")
+	verifySignatureCmd.Flags().StringVar(&verifySigKey, "key", "", "Public key file or KMS URI of key-based signatures")
+	verifySignatureCmd.Flags().StringVar(&verifySigIdentity, "certificate-identity", "", "Signer identity keyless signatures must have")
+	verifySignatureCmd.Flags().StringVar(&verifySigIssuer, "certificate-oidc-issuer", "", "OIDC issuer of the keyless signer identity")
+	verifySignatureCmd.Flags().StringVar(&verifySigCosign, "cosign", "", "cosign executable (default: cosign from PATH)")
+	verifySignatureCmd.Flags().StringVar(&verifySigLogLevel, "log-level", "", "Log level: quiet|standard|debug")
+
+	viper.BindPFlag("verify-signature.mode", verifySignatureCmd.Flags().Lookup("mode"))
+	viper.BindPFlag("verify-signature.key", verifySignatureCmd.Flags().Lookup("key"))
+	viper.BindPFlag("verify-signature.certificate-identity", verifySignatureCmd.Flags().Lookup("certificate-identity"))
+	viper.BindPFlag("verify-signature.certificate-oidc-issuer", verifySignatureCmd.Flags().Lookup("certificate-oidc-issuer"))
+	viper.BindPFlag("verify-signature.cosign", verifySignatureCmd.Flags().Lookup("cosign"))
+	viper.BindPFlag("verify-signature.log-level", verifySignatureCmd.Flags().Lookup("log-level"))
+}
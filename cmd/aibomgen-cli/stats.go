@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/idlab-discover/aibomgen-cli/internal/apperr"
+	"github.com/idlab-discover/aibomgen-cli/internal/bomcache"
+	"github.com/idlab-discover/aibomgen-cli/internal/fetcher"
+	"github.com/idlab-discover/aibomgen-cli/internal/metrics"
+	"github.com/idlab-discover/aibomgen-cli/internal/ui"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// statsCmd represents the stats command.
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show local usage metrics (durations, API calls, cache hit rates)",
+	Long: `Summarise the opt-in local metrics file: run counts and durations per
+command, Hugging Face API calls and BOM cache hit rates.
+
+Metrics are only recorded when enabled with "metrics.enabled: true" in the
+config file (or AIBOMGEN_METRICS_ENABLED=true). They never leave your machine.`,
+	RunE: runStats,
+}
+
+var (
+	statsFile    string
+	statsCommand string
+	statsClear   bool
+
+	// Per-run metrics state, set in the root PersistentPreRun.
+	metricsStart time.Time
+	// runScores holds the completeness scores of the BOMs written by the.
+	// current run.
+	runScores []float64
+)
+
+func runStats(cmd *cobra.Command, _ []string) error {
+	path, err := metricsPath(viper.GetString("stats.file"))
+	if err != nil {
+		return err
+	}
+
+	w := cmd.OutOrStdout()
+
+	if viper.GetBool("stats.clear") {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to clear metrics file: %w", err)
+		}
+		fmt.Fprintln(w, ui.SuccessBox.Render(ui.GetCheckMark()+" Metrics cleared"))
+		return nil
+	}
+
+	runs, err := metrics.Load(path)
+	if err != nil {
+		return fmt.Errorf("failed to read metrics file: %w", err)
+	}
+
+	filter := strings.TrimSpace(viper.GetString("stats.command"))
+	if filter != "" {
+		kept := runs[:0]
+		for _, r := range runs {
+			if r.Command == filter {
+				kept = append(kept, r)
+			}
+		}
+		runs = kept
+	}
+
+	if len(runs) == 0 {
+		msg := "No metrics recorded yet."
+		if !viper.GetBool("metrics.enabled") {
+			msg += " Enable them with \"metrics.enabled: true\" in your config file."
+		}
+		fmt.Fprintln(w, ui.Muted.Render(msg))
+		return nil
+	}
+
+	fmt.Fprintf(w, "%s %s\n\n", ui.Primary.Render("Usage metrics"), ui.Muted.Render("("+path+")"))
+	for _, s := range metrics.Summarize(runs) {
+		fmt.Fprintf(w, "%s  %s\n", ui.Bold.Render(s.Command), ui.Muted.Render(fmt.Sprintf("%d run(s)", s.Runs)))
+		fmt.Fprintf(w, "    Duration:  avg %s · median %s · max %s\n",
+			formatStatDuration(s.Average()), formatStatDuration(s.Median), formatStatDuration(s.Max))
+		fmt.Fprintf(w, "    API calls: %d total · %.1f per run\n", s.APICalls, float64(s.APICalls)/float64(s.Runs))
+		if rate := s.CacheHitRate(); rate >= 0 {
+			fmt.Fprintf(w, "    BOM cache: %s hit rate (%d hit(s), %d miss(es))\n",
+				ui.Highlight.Render(fmt.Sprintf("%.0f%%", rate*100)), s.CacheHits, s.CacheMisses)
+		}
+		fmt.Fprintln(w)
+	}
+	return nil
+}
+
+func formatStatDuration(d time.Duration) string {
+	if d < time.Second {
+		return d.Round(time.Millisecond).String()
+	}
+	return d.Round(100 * time.Millisecond).String()
+}
+
+// metricsPath resolves the metrics file from an explicit value, the.
+// metrics.file config key, or the default location.
+func metricsPath(explicit string) (string, error) {
+	if p := strings.TrimSpace(explicit); p != "" {
+		return p, nil
+	}
+	if p := strings.TrimSpace(viper.GetString("metrics.file")); p != "" {
+		return p, nil
+	}
+	p, err := metrics.DefaultPath()
+	if err != nil {
+		return "", apperr.Userf("cannot determine metrics file location: %v (set metrics.file)", err)
+	}
+	return p, nil
+}
+
+// startRunMetrics marks the start of a command run.
+func startRunMetrics() {
+	metricsStart = time.Now()
+}
+
+// bomCacheKey is the context key of the BOM cache opened by a run.
+type bomCacheKey struct{}
+
+// withBOMCache returns ctx carrying the BOM cache c of the run, whose hit.
+// rate recordRunMetrics records.
+func withBOMCache(ctx context.Context, c *bomcache.Cache) context.Context {
+	return context.WithValue(ctx, bomCacheKey{}, c)
+}
+
+// runBOMCache returns the BOM cache stored in ctx by withBOMCache, or nil.
+func runBOMCache(ctx context.Context) *bomcache.Cache {
+	c, _ := ctx.Value(bomCacheKey{}).(*bomcache.Cache)
+	return c
+}
+
+// recordRunMetrics appends the finished run to the metrics file when metrics.
+// are enabled, with the hit rate of cache, the BOM cache the run used (nil:.
+// none). Failures are ignored: metrics must never break a command.
+func recordRunMetrics(cmd *cobra.Command, cache *bomcache.Cache) {
+	if !viper.GetBool("metrics.enabled") || metricsStart.IsZero() || cmd == nil || !cmd.HasParent() {
+		return
+	}
+	switch cmd.Name() {
+	case "stats", "help", "completion":
+		return
+	}
+	path, err := metricsPath("")
+	if err != nil {
+		return
+	}
+	hits, misses := cache.Stats()
+	_ = metrics.Append(path, metrics.Run{
+		Command:     cmd.Name(),
+		Started:     metricsStart,
+		DurationMS:  time.Since(metricsStart).Milliseconds(),
+		APICalls:    fetcher.RequestCount(),
+		CacheHits:   hits,
+		CacheMisses: misses,
+		APIErrors:   fetcher.ErrorCount(),
+		BOMsWritten: len(runScores),
+		Scores:      runScores,
+	})
+}
+
+func init() {
+	statsCmd.Flags().StringVar(&statsFile, "file", "", "Metrics file (default: metrics.file or the user cache directory)")
+	statsCmd.Flags().StringVar(&statsCommand, "command", "", "Only show metrics for this command")
+	statsCmd.Flags().BoolVar(&statsClear, "clear", false, "Delete all recorded metrics")
+
+	viper.BindPFlag("stats.file", statsCmd.Flags().Lookup("file"))
+	viper.BindPFlag("stats.command", statsCmd.Flags().Lookup("command"))
+	viper.BindPFlag("stats.clear", statsCmd.Flags().Lookup("clear"))
+}
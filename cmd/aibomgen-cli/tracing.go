@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/idlab-discover/aibomgen-cli/internal/tracing"
+	"github.com/idlab-discover/aibomgen-cli/internal/ui"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/completeness"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/generator"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var (
+	tracingEndpoint string
+
+	// traceCtx is the context of the current run; it carries the root span.
+	// when tracing is enabled. Commands wrapped by withTracing set it to their.
+	// command context; it stays a plain background context for the others.
+	traceCtx = context.Background()
+)
+
+// withTracing wraps the RunE of cmds in a root span that is exported over.
+// OTLP when tracing is configured (see the tracing package).
+func withTracing(cmds ...*cobra.Command) {
+	for _, c := range cmds {
+		run := c.RunE
+		c.RunE = func(cmd *cobra.Command, args []string) error {
+			traceCtx = cmd.Context()
+			endpoint := viper.GetString("tracing.endpoint")
+			if !tracing.Enabled(endpoint) {
+				return run(cmd, args)
+			}
+			shutdown, err := tracing.Setup(cmd.Context(), endpoint, rootCmd.Version)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, ui.GetWarnMark()+" "+ui.Warning.Render(fmt.Sprintf("tracing disabled: %v", err)))
+				return run(cmd, args)
+			}
+			ctx, span := tracing.Start(cmd.Context(), "aibomgen-cli "+cmd.Name())
+			traceCtx = ctx
+			err = run(cmd, args)
+			tracing.End(span, err)
+
+			flushCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			if serr := shutdown(flushCtx); serr != nil {
+				fmt.Fprintln(os.Stderr, ui.GetWarnMark()+" "+ui.Warning.Render(fmt.Sprintf("traces not exported: %v", serr)))
+			}
+			return err
+		}
+	}
+}
+
+// writeTraced runs write, which writes the generated BOMs, in a span and.
+// records the completeness score of every written BOM in a span of its own.
+func writeTraced(boms []generator.DiscoveredBOM, write func() ([]string, error)) ([]string, error) {
+	_, span := tracing.Start(traceCtx, "write BOMs", attribute.Int("aibomgen.bom.count", len(boms)))
+	written, err := write()
+	tracing.End(span, err)
+
+	if tracing.Active() {
+		ctx, span := tracing.Start(traceCtx, "completeness")
+		for _, d := range boms[:len(written)] {
+			_, s := tracing.Start(ctx, "completeness check")
+			res := completeness.Check(d.BOM)
+			s.SetAttributes(
+				attribute.String("aibomgen.model.id", res.ModelID),
+				attribute.Float64("aibomgen.completeness.score", res.Score),
+			)
+			s.End()
+		}
+		span.End()
+	}
+	return written, err
+}
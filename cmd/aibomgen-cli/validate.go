@@ -2,9 +2,12 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
+	cdx "github.com/CycloneDX/cyclonedx-go"
 	"github.com/idlab-discover/aibomgen-cli/internal/apperr"
+	"github.com/idlab-discover/aibomgen-cli/internal/fsutil"
 	"github.com/idlab-discover/aibomgen-cli/internal/ui"
 	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/bomio"
 	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/validator"
@@ -18,18 +21,54 @@ var (
 	validateStrict         bool
 	validateMinScore       float64
 	validateCheckModelCard bool
+	validateCheckConsent   bool
+	validateCheckAttrib    bool
+	validateCheckSecurity  bool
+	validateProfile        string
+	validateSpec           string
 	validateLogLevel       string
+
+	validateAdmission        bool
+	validateAdmissionDir     string
+	validateAdmissionTimeout int
 )
 
 var validateCmd = &cobra.Command{
-	Use:   "validate",
+	Use:   "validate [bom]",
 	Short: "Validate an existing AIBOM file",
-	Long:  "Validates that a CycloneDX AIBOM JSON is well-formed and optionally checks for required model card fields in strict mode.",
+	Long: `Validates that a CycloneDX AIBOM JSON is well-formed and optionally checks for required model card fields in strict mode.
+
+With --admission, the command reads a Kubernetes AdmissionReview from stdin
+and writes the AdmissionReview response to stdout, for a validating webhook
+that enforces AIBOM presence in a cluster. The AIBOM of a workload is the
+file named by its aibomgen.io/aibom annotation (inside --admission-dir), else
+the AIBOM attached to its container images (see attach). The request is
+allowed when an AIBOM was found and every AIBOM passes the validation policy
+(--strict, --min-score, --profile, --check-model-card); an
+aibomgen.io/model annotation must name a model one of them describes.
+
+With --spec, the document is also checked against the official CycloneDX
+JSON schema of that version (auto: the version the document declares), and
+against the ML-BOM rules the schema cannot express: only
+machine-learning-model components have a model card, and every dataset a
+model card references resolves to a component.
+
+Example:
+  aibomgen-cli validate dist/google-bert_bert-base-uncased_aibom.json --spec 1.6
+  aibomgen-cli validate --admission --strict --admission-dir /etc/aiboms < review.json`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if viper.GetBool("validate.admission") {
+			return runAdmission(cmd)
+		}
+
 		// Get input from viper (respects config file and CLI flag).
 		inputPath := viper.GetString("validate.input")
+		if len(args) == 1 {
+			inputPath = args[0]
+		}
 		if inputPath == "" {
-			return apperr.User("--input is required")
+			return apperr.User("an input AIBOM is required (argument or --input)")
 		}
 
 		// Get log level from viper (respects config file).
@@ -50,20 +89,63 @@ var validateCmd = &cobra.Command{
 			format = "auto"
 		}
 
+		profile, err := loadCompletenessProfile(viper.GetString("validate.profile"))
+		if err != nil {
+			return err
+		}
+
+		// Resolve the schema version to check against ("": no schema check).
+		spec := strings.ToLower(strings.TrimSpace(viper.GetString("validate.spec")))
+		var schemaVersion cdx.SpecVersion
+		if spec != "" && spec != "auto" {
+			sv, ok := bomio.ParseSpecVersion(spec)
+			if !ok {
+				return apperr.Userf("invalid --spec %q (expected 1.2-1.6 or auto)", spec)
+			}
+			schemaVersion = sv
+		}
+		fileFormat, err := bomio.InputFormat(inputPath, format)
+		if err != nil {
+			return apperr.Userf("%v", err)
+		}
+		if spec != "" && fileFormat != "json" {
+			return apperr.User("--spec checks CycloneDX JSON documents only (the XML schema is not checked)")
+		}
+
 		// Read BOM.
 		bom, err := bomio.ReadBOM(inputPath, format)
 		if err != nil {
 			return fmt.Errorf("failed to read BOM: %w", err)
 		}
+		if spec == "auto" {
+			schemaVersion = bom.SpecVersion
+		}
 
 		// Get validation options from viper.
 		opts := validator.ValidationOptions{
 			StrictMode:           viper.GetBool("validate.strict"),
 			MinCompletenessScore: viper.GetFloat64("validate.min-score"),
 			CheckModelCard:       viper.GetBool("validate.check-model-card"),
+			CheckDataConsent:     viper.GetBool("validate.check-consent"),
+			CheckAttribution:     viper.GetBool("validate.check-attribution"),
+			CheckSecurityContact: viper.GetBool("validate.check-security-contact"),
+			CheckMLConstraints:   spec != "",
+			Profile:              profile,
 		}
 
 		result := validator.Validate(bom, opts)
+		if spec != "" {
+			doc, err := os.ReadFile(fsutil.LongPath(inputPath))
+			if err != nil {
+				return fmt.Errorf("failed to read BOM: %w", err)
+			}
+			violations, err := validator.ValidateSchema(doc, cdx.BOMFileFormatJSON, schemaVersion)
+			if err != nil {
+				return apperr.Userf("schema validation: %v", err)
+			}
+			result.AddSchemaViolations(schemaVersion, violations)
+		}
+		runSummary.PolicyViolations = result.Errors
 
 		// Use the new UI for rendering if not in quiet mode.
 		ui := ui.NewValidationUI(cmd.OutOrStdout(), level == "quiet")
@@ -79,10 +161,18 @@ var validateCmd = &cobra.Command{
 
 func init() {
 	validateCmd.Flags().StringVarP(&validateInput, "input", "i", "", "Path to AIBOM file (required)")
-	validateCmd.Flags().StringVarP(&validateFormat, "format", "f", "", "Input format: json|xml|auto")
+	validateCmd.Flags().StringVarP(&validateFormat, "format", "f", "", "Input format: json|xml|spdx-json|auto")
 	validateCmd.Flags().BoolVar(&validateStrict, "strict", false, "Strict mode: fail on missing required fields")
 	validateCmd.Flags().Float64Var(&validateMinScore, "min-score", 0.0, "Minimum completeness score (0.0-1.0)")
 	validateCmd.Flags().BoolVar(&validateCheckModelCard, "check-model-card", false, "Validate model card fields")
+	validateCmd.Flags().BoolVar(&validateCheckConsent, "check-consent", false, "Flag training datasets without a consent or licensing basis (license, consent statement or opt-out mechanism)")
+	validateCmd.Flags().BoolVar(&validateCheckAttrib, "check-attribution", false, "Flag attribution-required licenses (CC-BY) without a recorded copyright notice or attribution statement")
+	validateCmd.Flags().BoolVar(&validateCheckSecurity, "check-security-contact", false, "Fail production models (lifecycle phase operations) that name no security contact")
+	validateCmd.Flags().StringVar(&validateSpec, "spec", "", "Check the document against the CycloneDX JSON schema of this version (1.2-1.6, or auto for the version it declares) and the ML-BOM constraints (CycloneDX JSON only)")
+	validateCmd.Flags().StringVar(&validateProfile, "profile", "", "Weights profile (YAML/JSON) overriding the completeness weights and required fields")
+	validateCmd.Flags().BoolVar(&validateAdmission, "admission", false, "Answer a Kubernetes AdmissionReview read from stdin (writes the AdmissionReview response to stdout)")
+	validateCmd.Flags().StringVar(&validateAdmissionDir, "admission-dir", "", "Directory the aibomgen.io/aibom annotation of workloads names files in")
+	validateCmd.Flags().IntVar(&validateAdmissionTimeout, "admission-timeout", 8, "Seconds allowed for fetching the AIBOMs attached to images in admission mode")
 	validateCmd.Flags().StringVar(&validateLogLevel, "log-level", "", "Log level: quiet|standard|debug")
 
 	// Bind all flags to viper for config file support.
@@ -91,5 +181,13 @@ func init() {
 	viper.BindPFlag("validate.strict", validateCmd.Flags().Lookup("strict"))
 	viper.BindPFlag("validate.min-score", validateCmd.Flags().Lookup("min-score"))
 	viper.BindPFlag("validate.check-model-card", validateCmd.Flags().Lookup("check-model-card"))
+	viper.BindPFlag("validate.check-consent", validateCmd.Flags().Lookup("check-consent"))
+	viper.BindPFlag("validate.check-attribution", validateCmd.Flags().Lookup("check-attribution"))
+	viper.BindPFlag("validate.check-security-contact", validateCmd.Flags().Lookup("check-security-contact"))
+	viper.BindPFlag("validate.profile", validateCmd.Flags().Lookup("profile"))
+	viper.BindPFlag("validate.spec", validateCmd.Flags().Lookup("spec"))
+	viper.BindPFlag("validate.admission", validateCmd.Flags().Lookup("admission"))
+	viper.BindPFlag("validate.admission-dir", validateCmd.Flags().Lookup("admission-dir"))
+	viper.BindPFlag("validate.admission-timeout", validateCmd.Flags().Lookup("admission-timeout"))
 	viper.BindPFlag("validate.log-level", validateCmd.Flags().Lookup("log-level"))
 }
@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/internal/apperr"
+	"github.com/idlab-discover/aibomgen-cli/internal/artifacts"
+	"github.com/idlab-discover/aibomgen-cli/internal/signing"
+	"github.com/idlab-discover/aibomgen-cli/internal/ui"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/bomio"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/scanner"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// verifyCmd represents the verify command.
+var verifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Check deployed model weight files against the digests in AIBOMs",
+	Long: `Recompute the digests of the model weight files in a directory and compare
+them with the hashes recorded in one or more AIBOMs, so that the weights that
+are deployed are shown to be the weights that were documented.
+
+Weight files are detected by content, as scan does (GGUF, safetensors,
+PyTorch, TorchScript, ONNX). Each file is matched to the components that
+recorded it, by the discovery path stored at generation time or else by file
+name, and hashed with every algorithm they record (SHA-256, SHA-384, SHA-512).
+Digest sidecar files are never trusted. Mismatches fail the command; weight
+files that no AIBOM records, and recorded files that are not found, fail it
+with --strict.
+
+Example:
+  aibomgen-cli verify -i dist/ --artifacts ./models/
+  aibomgen-cli verify -i dist/app_aibom.json --artifacts /srv/models --strict`,
+	RunE: runVerify,
+}
+
+func runVerify(cmd *cobra.Command, _ []string) error {
+	inputs := viper.GetStringSlice("verify.input")
+	if len(inputs) == 0 {
+		return apperr.User("--input is required")
+	}
+	dir := strings.TrimSpace(viper.GetString("verify.artifacts"))
+	if dir == "" {
+		return apperr.User("--artifacts is required")
+	}
+	if info, err := os.Stat(dir); err != nil {
+		return apperr.Userf("cannot read --artifacts %s: %v", dir, err)
+	} else if !info.IsDir() {
+		return apperr.Userf("--artifacts %s is not a directory", dir)
+	}
+	inputFormat := viper.GetString("verify.format")
+	if inputFormat == "" {
+		inputFormat = "auto"
+	}
+
+	boms, err := readInputBOMs(inputs, inputFormat)
+	if err != nil {
+		return err
+	}
+	var recorded []artifacts.Recorded
+	for _, b := range boms {
+		recorded = append(recorded, artifacts.RecordedArtifacts(b.bom, b.path)...)
+	}
+
+	rep, err := artifacts.Verify(dir, recorded, artifacts.Options{
+		Hash: scanner.HashOptions{
+			Workers:   viper.GetInt("verify.hash-workers"),
+			ChunkSize: int64(viper.GetInt("verify.hash-chunk-size")) << 20,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	printArtifactReport(cmd.OutOrStdout(), rep)
+
+	if rep.Failed() {
+		return apperr.Userf("%d weight file(s) do not match the digests recorded in the AIBOM", len(rep.Mismatched))
+	}
+	if viper.GetBool("verify.strict") && (len(rep.Unrecorded) > 0 || len(rep.Missing) > 0) {
+		return apperr.Userf("%d weight file(s) not recorded, %d recorded file(s) not found", len(rep.Unrecorded), len(rep.Missing))
+	}
+	return nil
+}
+
+// inputBOM is an AIBOM read from an --input argument.
+type inputBOM struct {
+	path string
+	bom  *cdx.BOM
+}
+
+// readInputBOMs reads the given AIBOM files and the AIBOM files (.json, .xml).
+// directly inside the given directories. Files in a directory that are not.
+// AIBOMs are skipped with a warning.
+func readInputBOMs(inputs []string, format string) ([]inputBOM, error) {
+	var out []inputBOM
+	for _, in := range inputs {
+		info, err := os.Stat(in)
+		if err != nil {
+			return nil, apperr.Userf("cannot read --input %s: %v", in, err)
+		}
+		if !info.IsDir() {
+			bom, err := bomio.ReadBOM(in, format)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read input BOM %s: %w", in, err)
+			}
+			out = append(out, inputBOM{path: in, bom: bom})
+			continue
+		}
+		entries, err := os.ReadDir(in)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			ext := strings.ToLower(filepath.Ext(e.Name()))
+			if e.IsDir() || (ext != ".json" && ext != ".xml") || signing.IsBundle(e.Name()) {
+				continue
+			}
+			path := filepath.Join(in, e.Name())
+			bom, err := bomio.ReadBOM(path, format)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, ui.GetWarnMark()+" "+ui.Warning.Render(fmt.Sprintf("skipping %s: %v", path, err)))
+				continue
+			}
+			out = append(out, inputBOM{path: path, bom: bom})
+		}
+	}
+	return out, nil
+}
+
+// printArtifactReport writes the artifact verification report to w.
+func printArtifactReport(w io.Writer, rep artifacts.Report) {
+	fmt.Fprintln(w)
+	if len(rep.Verified) > 0 {
+		fmt.Fprintln(w, ui.SectionHeader.Render("Verified"))
+		for _, r := range rep.Verified {
+			fmt.Fprintf(w, "  %s %s %s\n", ui.GetCheckMark(), r.Path, ui.Muted.Render("("+r.Recorded[0].File+")"))
+		}
+		fmt.Fprintln(w)
+	}
+	if len(rep.Mismatched) > 0 {
+		fmt.Fprintln(w, ui.SectionHeader.Render("Digest mismatch"))
+		for _, r := range rep.Mismatched {
+			fmt.Fprintf(w, "  %s %s\n", ui.GetCrossMark(), ui.Bold.Render(r.Path))
+			for _, m := range r.Mismatches {
+				fmt.Fprintf(w, "      %s %s\n", m.Algorithm, ui.Muted.Render("("+m.Recorded.File+")"))
+				fmt.Fprintf(w, "        recorded: %s\n", m.Expected)
+				fmt.Fprintf(w, "        actual:   %s\n", m.Actual)
+			}
+		}
+		fmt.Fprintln(w)
+	}
+	if len(rep.Unrecorded) > 0 {
+		fmt.Fprintln(w, ui.SectionHeader.Render("Not recorded in any AIBOM"))
+		for _, r := range rep.Unrecorded {
+			fmt.Fprintf(w, "  %s %s %s\n", ui.GetWarnMark(), ui.Bold.Render(r.Path), ui.Muted.Render("("+r.Format+")"))
+		}
+		fmt.Fprintln(w)
+	}
+	if len(rep.Missing) > 0 {
+		fmt.Fprintln(w, ui.SectionHeader.Render("Recorded but not found"))
+		for _, r := range rep.Missing {
+			fmt.Fprintf(w, "  %s %s %s\n", ui.GetWarnMark(), ui.Bold.Render(r.Name), ui.Muted.Render("("+r.File+")"))
+		}
+		fmt.Fprintln(w)
+	}
+
+	summary := fmt.Sprintf("%d verified, %d mismatched, %d not recorded, %d not found", len(rep.Verified), len(rep.Mismatched), len(rep.Unrecorded), len(rep.Missing))
+	if !rep.Failed() {
+		fmt.Fprintf(w, "%s\n", ui.SuccessBox.Render(fmt.Sprintf("%s %s", ui.GetCheckMark(), summary)))
+		return
+	}
+	fmt.Fprintf(w, "%s\n", ui.ErrorBox.Render(fmt.Sprintf("%s %s", ui.GetCrossMark(), summary)))
+}
+
+// ── Flag vars ─────────────────────────────────────────────────────────────────.
+
+var (
+	verifyInputs       []string
+	verifyFormat       string
+	verifyArtifacts    string
+	verifyStrict       bool
+	verifyHashWorkers  int
+	verifyHashChunkMiB int
+)
+
+func init() {
+	verifyCmd.Flags().StringArrayVarP(&verifyInputs, "input", "i", nil, "AIBOM file or directory of AIBOMs (repeatable; required)")
+	verifyCmd.Flags().StringVarP(&verifyFormat, "format", "f", "", "Input BOM format: json|xml|spdx-json|auto")
+	verifyCmd.Flags().StringVar(&verifyArtifacts, "artifacts", "", "Directory with the deployed model weight files (required)")
+	verifyCmd.Flags().BoolVar(&verifyStrict, "strict", false, "Also fail on weight files no AIBOM records and recorded files that are not found")
+	verifyCmd.Flags().IntVar(&verifyHashWorkers, "hash-workers", 0, "Concurrent chunk readers per file when hashing model weight files (default: 4)")
+	verifyCmd.Flags().IntVar(&verifyHashChunkMiB, "hash-chunk-size", 64, "Chunk size in MiB when hashing model weight files")
+
+	viper.BindPFlag("verify.input", verifyCmd.Flags().Lookup("input"))
+	viper.BindPFlag("verify.format", verifyCmd.Flags().Lookup("format"))
+	viper.BindPFlag("verify.artifacts", verifyCmd.Flags().Lookup("artifacts"))
+	viper.BindPFlag("verify.strict", verifyCmd.Flags().Lookup("strict"))
+	viper.BindPFlag("verify.hash-workers", verifyCmd.Flags().Lookup("hash-workers"))
+	viper.BindPFlag("verify.hash-chunk-size", verifyCmd.Flags().Lookup("hash-chunk-size"))
+}
@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/idlab-discover/aibomgen-cli/internal/apperr"
+	"github.com/idlab-discover/aibomgen-cli/internal/runtimeinv"
+	"github.com/idlab-discover/aibomgen-cli/internal/ui"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// verifyRuntimeCmd represents the verify-runtime command.
+var verifyRuntimeCmd = &cobra.Command{
+	Use:   "verify-runtime",
+	Short: "Compare the models a deployment serves with the AIBOM inventory",
+	Long: `Query which models a deployment actually serves and compare them with the
+models documented in one or more AIBOMs. Drift is reported both ways: models
+that are served but not documented, and documented models that are not served.
+
+The served models are read from an OpenAI-compatible endpoint (GET /v1/models,
+as exposed by vLLM, TGI, Ollama or LiteLLM) and/or from the output of
+"kubectl get pods -o json", where model IDs are taken from the container
+command line (--model, --model-id, vllm serve <model>, hf:// URIs) and from
+the MODEL, MODEL_ID, MODEL_NAME, HF_MODEL and HF_MODEL_ID environment variables.
+
+Example:
+  aibomgen-cli verify-runtime -i dist/ --endpoint http://vllm:8000
+  kubectl get pods -n ml -o json | aibomgen-cli verify-runtime -i dist/ --kube-pods - --fail-on-drift`,
+	RunE: runVerifyRuntime,
+}
+
+func runVerifyRuntime(cmd *cobra.Command, _ []string) error {
+	inputs := viper.GetStringSlice("verify-runtime.input")
+	if len(inputs) == 0 {
+		return apperr.User("--input is required")
+	}
+	endpoint := strings.TrimSpace(viper.GetString("verify-runtime.endpoint"))
+	podsPath := strings.TrimSpace(viper.GetString("verify-runtime.kube-pods"))
+	if endpoint == "" && podsPath == "" {
+		return apperr.User("--endpoint or --kube-pods is required")
+	}
+	inputFormat := viper.GetString("verify-runtime.format")
+	if inputFormat == "" {
+		inputFormat = "auto"
+	}
+	timeout := viper.GetInt("verify-runtime.timeout")
+	if timeout <= 0 {
+		timeout = 15
+	}
+
+	documented, err := readDocumentedModels(inputs, inputFormat)
+	if err != nil {
+		return err
+	}
+
+	var served []runtimeinv.Served
+	if endpoint != "" {
+		s, err := runtimeinv.FetchServed(context.Background(), runtimeinv.Options{
+			Endpoint: endpoint,
+			APIKey:   viper.GetString("verify-runtime.api-key"),
+			Timeout:  time.Duration(timeout) * time.Second,
+		})
+		if err != nil {
+			return err
+		}
+		served = append(served, s...)
+	}
+	if podsPath != "" {
+		s, err := readServedPods(cmd, podsPath)
+		if err != nil {
+			return err
+		}
+		served = append(served, s...)
+	}
+
+	rep := runtimeinv.Compare(served, documented)
+	printRuntimeReport(cmd.OutOrStdout(), rep, len(served), len(documented))
+
+	if rep.Drift() && viper.GetBool("verify-runtime.fail-on-drift") {
+		return apperr.Userf("runtime drift: %d undocumented, %d absent model(s)", len(rep.Undocumented), len(rep.Absent))
+	}
+	return nil
+}
+
+// readDocumentedModels lists the model components of the given AIBOMs.
+func readDocumentedModels(inputs []string, format string) ([]runtimeinv.Documented, error) {
+	boms, err := readInputBOMs(inputs, format)
+	if err != nil {
+		return nil, err
+	}
+	var out []runtimeinv.Documented
+	for _, b := range boms {
+		out = append(out, runtimeinv.DocumentedModels(b.bom, b.path)...)
+	}
+	return out, nil
+}
+
+// readServedPods parses a kubectl pod list from path, or stdin for "-".
+func readServedPods(cmd *cobra.Command, path string) ([]runtimeinv.Served, error) {
+	var r io.Reader = cmd.InOrStdin()
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, apperr.Userf("cannot read --kube-pods %s: %v", path, err)
+		}
+		defer f.Close()
+		r = f
+	}
+	return runtimeinv.ParsePods(r)
+}
+
+// printRuntimeReport writes the drift report to w.
+func printRuntimeReport(w io.Writer, rep runtimeinv.Report, served, documented int) {
+	fmt.Fprintln(w)
+	if len(rep.Matched) > 0 {
+		fmt.Fprintln(w, ui.SectionHeader.Render("Served and documented"))
+		for _, m := range rep.Matched {
+			name := m.Served.ID
+			if m.Served.Root != "" {
+				name += " → " + m.Served.Root
+			}
+			fmt.Fprintf(w, "  %s %s %s\n", ui.GetCheckMark(), name, ui.Muted.Render("("+m.Documented.File+")"))
+		}
+		fmt.Fprintln(w)
+	}
+	if len(rep.Undocumented) > 0 {
+		fmt.Fprintln(w, ui.SectionHeader.Render("Served but not documented"))
+		for _, s := range rep.Undocumented {
+			name := s.ID
+			if s.Root != "" {
+				name += " → " + s.Root
+			}
+			fmt.Fprintf(w, "  %s %s %s\n", ui.GetCrossMark(), ui.Bold.Render(name), ui.Muted.Render("("+s.Source+")"))
+		}
+		fmt.Fprintln(w)
+	}
+	if len(rep.Absent) > 0 {
+		fmt.Fprintln(w, ui.SectionHeader.Render("Documented but not served"))
+		for _, d := range rep.Absent {
+			fmt.Fprintf(w, "  %s %s %s\n", ui.GetWarnMark(), ui.Bold.Render(d.ID), ui.Muted.Render("("+d.File+")"))
+		}
+		fmt.Fprintln(w)
+	}
+
+	summary := fmt.Sprintf("%d served, %d documented model(s)", served, documented)
+	if !rep.Drift() {
+		fmt.Fprintf(w, "%s\n", ui.SuccessBox.Render(fmt.Sprintf("%s No drift: %s", ui.GetCheckMark(), summary)))
+		return
+	}
+	fmt.Fprintf(w, "%s\n", ui.ErrorBox.Render(fmt.Sprintf("%s Drift: %d undocumented, %d absent (%s)", ui.GetCrossMark(), len(rep.Undocumented), len(rep.Absent), summary)))
+}
+
+// ── Flag vars ─────────────────────────────────────────────────────────────────.
+
+var (
+	verifyRuntimeInputs      []string
+	verifyRuntimeFormat      string
+	verifyRuntimeEndpoint    string
+	verifyRuntimeAPIKey      string
+	verifyRuntimeKubePods    string
+	verifyRuntimeTimeout     int
+	verifyRuntimeFailOnDrift bool
+)
+
+func init() {
+	verifyRuntimeCmd.Flags().StringArrayVarP(&verifyRuntimeInputs, "input", "i", nil, "AIBOM file or directory of AIBOMs (repeatable; required)")
+	verifyRuntimeCmd.Flags().StringVarP(&verifyRuntimeFormat, "format", "f", "", "Input BOM format: json|xml|spdx-json|auto")
+	verifyRuntimeCmd.Flags().StringVar(&verifyRuntimeEndpoint, "endpoint", "", "Base URL of an OpenAI-compatible API whose /v1/models lists the served models")
+	verifyRuntimeCmd.Flags().StringVar(&verifyRuntimeAPIKey, "api-key", "", "Bearer token for --endpoint")
+	verifyRuntimeCmd.Flags().StringVar(&verifyRuntimeKubePods, "kube-pods", "", "Output of 'kubectl get pods -o json' (file path, or - for stdin)")
+	verifyRuntimeCmd.Flags().IntVar(&verifyRuntimeTimeout, "timeout", 15, "Endpoint request timeout in seconds")
+	verifyRuntimeCmd.Flags().BoolVar(&verifyRuntimeFailOnDrift, "fail-on-drift", false, "Exit with an error when served and documented models differ")
+
+	viper.BindPFlag("verify-runtime.input", verifyRuntimeCmd.Flags().Lookup("input"))
+	viper.BindPFlag("verify-runtime.format", verifyRuntimeCmd.Flags().Lookup("format"))
+	viper.BindPFlag("verify-runtime.endpoint", verifyRuntimeCmd.Flags().Lookup("endpoint"))
+	viper.BindPFlag("verify-runtime.api-key", verifyRuntimeCmd.Flags().Lookup("api-key"))
+	viper.BindPFlag("verify-runtime.kube-pods", verifyRuntimeCmd.Flags().Lookup("kube-pods"))
+	viper.BindPFlag("verify-runtime.timeout", verifyRuntimeCmd.Flags().Lookup("timeout"))
+	viper.BindPFlag("verify-runtime.fail-on-drift", verifyRuntimeCmd.Flags().Lookup("fail-on-drift"))
+}
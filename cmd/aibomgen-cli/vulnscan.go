@@ -334,8 +334,8 @@ var (
 func init() {
 	vulnScanCmd.Flags().StringVarP(&vulnScanInput, "input", "i", "", "Path to existing AIBOM (required)")
 	vulnScanCmd.Flags().StringVarP(&vulnScanOutput, "output", "o", "", "Output path when --enrich is set (default: overwrite input)")
-	vulnScanCmd.Flags().StringVarP(&vulnScanInputFormat, "format", "f", "", "Input BOM format: json|xml|auto")
-	vulnScanCmd.Flags().StringVar(&vulnScanOutputFormat, "output-format", "", "Output BOM format: json|xml|auto")
+	vulnScanCmd.Flags().StringVarP(&vulnScanInputFormat, "format", "f", "", "Input BOM format: json|xml|spdx-json|auto")
+	vulnScanCmd.Flags().StringVar(&vulnScanOutputFormat, "output-format", "", "Output BOM format: json|xml|spdx-json|auto")
 	vulnScanCmd.Flags().StringVar(&vulnScanSpecVersion, "spec", "", "CycloneDX spec version for output")
 
 	vulnScanCmd.Flags().BoolVar(&vulnScanEnrich, "enrich", false, "Inject discovered vulnerabilities back into the AIBOM")
@@ -0,0 +1,288 @@
+// Package admission answers Kubernetes AdmissionReview requests with the.
+// AIBOM policy of the validate command, so a thin validating webhook can.
+// refuse workloads whose models are not covered by a valid AIBOM.
+//.
+// The AIBOMs of a workload are found through the annotations of the object.
+// (or of its pod template):.
+//.
+//	aibomgen.io/aibom – an AIBOM file, resolved by the caller (e.g. in a.
+//	                    directory of AIBOMs mounted into the webhook).
+//	aibomgen.io/model – a model ID that one of the AIBOMs must describe.
+//.
+// Without the aibom annotation, the AIBOMs attached to the container images.
+// (see the attach command) are used. A workload is admitted when at least one.
+// AIBOM was found, every AIBOM found passes validation and the annotated.
+// model, if any, is described. Objects without a pod spec or annotations are.
+// admitted unchecked.
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/validator"
+)
+
+// Workload annotations.
+const (
+	AnnotationAIBOM = "aibomgen.io/aibom"
+	AnnotationModel = "aibomgen.io/model"
+)
+
+// Review is an admission.k8s.io/v1 AdmissionReview.
+type Review struct {
+	APIVersion string    `json:"apiVersion"`
+	Kind       string    `json:"kind"`
+	Request    *Request  `json:"request,omitempty"`
+	Response   *Response `json:"response,omitempty"`
+}
+
+// Request is the request of an AdmissionReview, reduced to the fields used.
+type Request struct {
+	UID       string           `json:"uid"`
+	Kind      GroupVersionKind `json:"kind"`
+	Name      string           `json:"name,omitempty"`
+	Namespace string           `json:"namespace,omitempty"`
+	Operation string           `json:"operation,omitempty"`
+	Object    json.RawMessage  `json:"object,omitempty"`
+}
+
+// GroupVersionKind names the kind of the admitted object.
+type GroupVersionKind struct {
+	Group   string `json:"group"`
+	Version string `json:"version"`
+	Kind    string `json:"kind"`
+}
+
+// Response is the response of an AdmissionReview.
+type Response struct {
+	UID      string   `json:"uid"`
+	Allowed  bool     `json:"allowed"`
+	Status   *Status  `json:"status,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// Status explains a response.
+type Status struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// Decode reads an AdmissionReview with a request from r.
+func Decode(r io.Reader) (*Review, error) {
+	var rv Review
+	if err := json.NewDecoder(r).Decode(&rv); err != nil {
+		return nil, fmt.Errorf("decode AdmissionReview: %w", err)
+	}
+	if rv.Kind != "AdmissionReview" || rv.Request == nil {
+		return nil, fmt.Errorf("not an AdmissionReview request (kind %q)", rv.Kind)
+	}
+	return &rv, nil
+}
+
+// Reply returns the AdmissionReview answering rv with resp.
+func (rv *Review) Reply(resp Response) Review {
+	apiVersion := rv.APIVersion
+	if apiVersion == "" {
+		apiVersion = "admission.k8s.io/v1"
+	}
+	resp.UID = rv.Request.UID
+	return Review{APIVersion: apiVersion, Kind: "AdmissionReview", Response: &resp}
+}
+
+// Workload is what a request says about the AIBOMs of the admitted object.
+type Workload struct {
+	Annotations map[string]string
+	// Images are the container images of the pod spec, without duplicates.
+	Images []string
+	// HasPodSpec reports whether the object runs containers.
+	HasPodSpec bool
+}
+
+// podSpec holds the fields of a pod spec that name images.
+type podSpec struct {
+	Containers          []container `json:"containers"`
+	InitContainers      []container `json:"initContainers"`
+	EphemeralContainers []container `json:"ephemeralContainers"`
+}
+
+type container struct {
+	Image string `json:"image"`
+}
+
+type objectMeta struct {
+	Annotations map[string]string `json:"annotations"`
+}
+
+// template is a pod template.
+type template struct {
+	Metadata objectMeta `json:"metadata"`
+	Spec     *podSpec   `json:"spec"`
+}
+
+// ParseWorkload extracts the annotations and images of a Pod, of a workload.
+// with a pod template (Deployment, StatefulSet, DaemonSet, ReplicaSet, Job,.
+// ...) or of a CronJob. Annotations of the pod template override those of.
+// the object.
+func ParseWorkload(object json.RawMessage) (Workload, error) {
+	var obj struct {
+		Kind     string     `json:"kind"`
+		Metadata objectMeta `json:"metadata"`
+		Spec     struct {
+			podSpec
+			Template    *template `json:"template"`
+			JobTemplate *struct {
+				Spec struct {
+					Template *template `json:"template"`
+				} `json:"spec"`
+			} `json:"jobTemplate"`
+		} `json:"spec"`
+	}
+	if len(object) == 0 {
+		return Workload{}, nil
+	}
+	if err := json.Unmarshal(object, &obj); err != nil {
+		return Workload{}, fmt.Errorf("decode object: %w", err)
+	}
+
+	w := Workload{Annotations: map[string]string{}}
+	for k, v := range obj.Metadata.Annotations {
+		w.Annotations[k] = v
+	}
+	var spec *podSpec
+	tmpl := obj.Spec.Template
+	if obj.Spec.JobTemplate != nil {
+		tmpl = obj.Spec.JobTemplate.Spec.Template
+	}
+	switch {
+	case tmpl != nil:
+		for k, v := range tmpl.Metadata.Annotations {
+			w.Annotations[k] = v
+		}
+		spec = tmpl.Spec
+	case obj.Kind == "Pod":
+		spec = &obj.Spec.podSpec
+	}
+	if spec == nil {
+		return w, nil
+	}
+	w.HasPodSpec = true
+	seen := map[string]bool{}
+	for _, list := range [][]container{spec.InitContainers, spec.Containers, spec.EphemeralContainers} {
+		for _, c := range list {
+			if c.Image != "" && !seen[c.Image] {
+				seen[c.Image] = true
+				w.Images = append(w.Images, c.Image)
+			}
+		}
+	}
+	return w, nil
+}
+
+// Options configures Evaluate.
+type Options struct {
+	// LoadAIBOM reads the AIBOM named by the aibom annotation.
+	LoadAIBOM func(name string) (*cdx.BOM, error)
+	// FetchAttached returns the AIBOM attached to an image, or nil when.
+	// there is none.
+	FetchAttached func(ctx context.Context, image string) (*cdx.BOM, error)
+	// Validate applies the AIBOM policy.
+	Validate func(*cdx.BOM) validator.ValidationResult
+}
+
+// Evaluate decides on a workload. Lookup failures deny the request.
+func Evaluate(ctx context.Context, w Workload, opts Options) Response {
+	aibomName := strings.TrimSpace(w.Annotations[AnnotationAIBOM])
+	model := strings.TrimSpace(w.Annotations[AnnotationModel])
+	if aibomName == "" && model == "" && !w.HasPodSpec {
+		return Response{Allowed: true, Status: &Status{Code: http.StatusOK, Message: "no workload to check"}}
+	}
+
+	type source struct {
+		name string
+		bom  *cdx.BOM
+	}
+	var (
+		boms     []source
+		problems []string
+	)
+	if aibomName != "" {
+		bom, err := opts.LoadAIBOM(aibomName)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s %s: %v", AnnotationAIBOM, aibomName, err))
+		} else {
+			boms = append(boms, source{aibomName, bom})
+		}
+	} else {
+		for _, image := range w.Images {
+			bom, err := opts.FetchAttached(ctx, image)
+			switch {
+			case err != nil:
+				problems = append(problems, fmt.Sprintf("image %s: %v", image, err))
+			case bom != nil:
+				boms = append(boms, source{image, bom})
+			}
+		}
+	}
+	if len(boms) == 0 && len(problems) == 0 {
+		problems = append(problems, fmt.Sprintf("no AIBOM found: set the %s annotation or attach an AIBOM to the image", AnnotationAIBOM))
+	}
+
+	var warnings []string
+	for _, s := range boms {
+		res := opts.Validate(s.bom)
+		if !res.Valid {
+			problems = append(problems, fmt.Sprintf("AIBOM of %s: %s", s.name, strings.Join(res.Errors, "; ")))
+		}
+		if len(res.Warnings) > 0 {
+			warnings = append(warnings, fmt.Sprintf("AIBOM of %s: %d optional field(s) missing", s.name, len(res.Warnings)))
+		}
+	}
+	if model != "" && len(boms) > 0 {
+		found := false
+		for _, s := range boms {
+			if describesModel(s.bom, model) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			problems = append(problems, fmt.Sprintf("no AIBOM describes model %s (%s)", model, AnnotationModel))
+		}
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return Response{Status: &Status{Code: http.StatusForbidden, Message: "AIBOM policy: " + strings.Join(problems, "; ")}, Warnings: warnings}
+	}
+	return Response{Allowed: true, Status: &Status{Code: http.StatusOK, Message: fmt.Sprintf("%d AIBOM(s) valid", len(boms))}, Warnings: warnings}
+}
+
+// describesModel reports whether the main component or a model component of.
+// bom is the model id (name or group/name, case-insensitive).
+func describesModel(bom *cdx.BOM, id string) bool {
+	matches := func(c *cdx.Component) bool {
+		if c == nil {
+			return false
+		}
+		return strings.EqualFold(c.Name, id) || (c.Group != "" && strings.EqualFold(c.Group+"/"+c.Name, id))
+	}
+	if bom.Metadata != nil && matches(bom.Metadata.Component) {
+		return true
+	}
+	if bom.Components != nil {
+		for i := range *bom.Components {
+			c := &(*bom.Components)[i]
+			if c.Type == cdx.ComponentTypeMachineLearningModel && matches(c) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,127 @@
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/validator"
+)
+
+const deploymentReview = `{
+  "apiVersion": "admission.k8s.io/v1",
+  "kind": "AdmissionReview",
+  "request": {
+    "uid": "705ab4f5-6393-11e8-b7cc-42010a800002",
+    "kind": {"group": "apps", "version": "v1", "kind": "Deployment"},
+    "operation": "CREATE",
+    "object": {
+      "kind": "Deployment",
+      "metadata": {"name": "chat", "annotations": {"aibomgen.io/model": "meta-llama/Llama-3.1-8B"}},
+      "spec": {"template": {
+        "metadata": {"annotations": {"aibomgen.io/model": "org/model"}},
+        "spec": {
+          "initContainers": [{"name": "fetch", "image": "ghcr.io/org/fetch:1"}],
+          "containers": [{"name": "serve", "image": "ghcr.io/org/serve:1"}, {"name": "proxy", "image": "ghcr.io/org/fetch:1"}]
+        }
+      }}
+    }
+  }
+}`
+
+func TestDecodeAndParseWorkload(t *testing.T) {
+	rv, err := Decode(strings.NewReader(deploymentReview))
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	w, err := ParseWorkload(rv.Request.Object)
+	if err != nil {
+		t.Fatalf("ParseWorkload: %v", err)
+	}
+	if !w.HasPodSpec || !reflect.DeepEqual(w.Images, []string{"ghcr.io/org/fetch:1", "ghcr.io/org/serve:1"}) {
+		t.Errorf("workload = %+v", w)
+	}
+	if w.Annotations[AnnotationModel] != "org/model" {
+		t.Errorf("pod template annotations must override the object's, got %q", w.Annotations[AnnotationModel])
+	}
+
+	out := rv.Reply(Response{Allowed: true})
+	data, _ := json.Marshal(out)
+	if !strings.Contains(string(data), `"uid":"705ab4f5-6393-11e8-b7cc-42010a800002"`) || out.APIVersion != "admission.k8s.io/v1" || out.Request != nil {
+		t.Errorf("reply = %s", data)
+	}
+
+	cron, _ := ParseWorkload(json.RawMessage(`{"kind":"CronJob","spec":{"jobTemplate":{"spec":{"template":{"spec":{"containers":[{"image":"job:1"}]}}}}}}`))
+	if !reflect.DeepEqual(cron.Images, []string{"job:1"}) {
+		t.Errorf("cronjob images = %v", cron.Images)
+	}
+	cm, _ := ParseWorkload(json.RawMessage(`{"kind":"ConfigMap","metadata":{"name":"x"},"data":{"a":"b"}}`))
+	if cm.HasPodSpec {
+		t.Errorf("ConfigMap has no pod spec")
+	}
+	if _, err := Decode(strings.NewReader(`{"kind":"Pod"}`)); err == nil {
+		t.Errorf("expected an error for a non-AdmissionReview")
+	}
+}
+
+func modelBOM(group, name string) *cdx.BOM {
+	return &cdx.BOM{Metadata: &cdx.Metadata{Component: &cdx.Component{Type: cdx.ComponentTypeMachineLearningModel, Group: group, Name: name}}}
+}
+
+func TestEvaluate(t *testing.T) {
+	valid := func(b *cdx.BOM) validator.ValidationResult {
+		if b.Metadata.Component.Name == "broken" {
+			return validator.ValidationResult{Errors: []string{"required field missing: licenses"}}
+		}
+		return validator.ValidationResult{Valid: true}
+	}
+	attached := map[string]*cdx.BOM{"serve:1": modelBOM("org", "model"), "bad:1": modelBOM("", "broken")}
+	opts := Options{
+		LoadAIBOM: func(name string) (*cdx.BOM, error) {
+			if name == "model.json" {
+				return modelBOM("", "org/model"), nil
+			}
+			return nil, errors.New("not found")
+		},
+		FetchAttached: func(_ context.Context, image string) (*cdx.BOM, error) {
+			if image == "down:1" {
+				return nil, errors.New("registry unavailable")
+			}
+			return attached[image], nil
+		},
+		Validate: valid,
+	}
+
+	tests := []struct {
+		name    string
+		w       Workload
+		allowed bool
+		message string
+	}{
+		{"attached", Workload{HasPodSpec: true, Images: []string{"sidecar:1", "serve:1"}}, true, "1 AIBOM(s) valid"},
+		{"annotated model", Workload{HasPodSpec: true, Images: []string{"serve:1"}, Annotations: map[string]string{AnnotationModel: "ORG/Model"}}, true, ""},
+		{"other model", Workload{HasPodSpec: true, Images: []string{"serve:1"}, Annotations: map[string]string{AnnotationModel: "gpt2"}}, false, "no AIBOM describes model gpt2"},
+		{"missing", Workload{HasPodSpec: true, Images: []string{"sidecar:1"}}, false, "no AIBOM found"},
+		{"invalid", Workload{HasPodSpec: true, Images: []string{"serve:1", "bad:1"}}, false, "AIBOM of bad:1: required field missing: licenses"},
+		{"lookup failure", Workload{HasPodSpec: true, Images: []string{"serve:1", "down:1"}}, false, "image down:1: registry unavailable"},
+		{"annotation", Workload{HasPodSpec: true, Images: []string{"sidecar:1"}, Annotations: map[string]string{AnnotationAIBOM: "model.json", AnnotationModel: "org/model"}}, true, ""},
+		{"annotation missing file", Workload{Annotations: map[string]string{AnnotationAIBOM: "nope.json"}}, false, "aibomgen.io/aibom nope.json: not found"},
+		{"no workload", Workload{}, true, "no workload to check"},
+	}
+	for _, tt := range tests {
+		resp := Evaluate(context.Background(), tt.w, opts)
+		if resp.Allowed != tt.allowed {
+			t.Errorf("%s: allowed = %v (%+v)", tt.name, resp.Allowed, resp.Status)
+		}
+		if tt.message != "" && (resp.Status == nil || !strings.Contains(resp.Status.Message, tt.message)) {
+			t.Errorf("%s: status = %+v, want %q", tt.name, resp.Status, tt.message)
+		}
+		if !tt.allowed && resp.Status.Code != 403 {
+			t.Errorf("%s: code = %d", tt.name, resp.Status.Code)
+		}
+	}
+}
@@ -0,0 +1,310 @@
+// Package advisories matches the model and dataset components of an AIBOM.
+// against a curated advisories feed.
+//.
+// The feed is a signed JSON envelope: the advisories document is carried as a.
+// base64 payload next to an Ed25519 signature over the raw payload bytes. The.
+// payload is only decoded after the signature has been verified against the.
+// configured public key, so a tampered or spoofed feed is rejected outright.
+//.
+// Advisories cover events that make an otherwise well-formed AIBOM stale or.
+// risky: repository takedowns, license changes and weight-poisoning reports.
+package advisories
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+// Advisory types understood by the CLI. Unknown types are still matched and.
+// reported; they are simply shown with their raw type string.
+const (
+	TypeTakedown        = "takedown"
+	TypeLicenseChange   = "license-change"
+	TypeWeightPoisoning = "weight-poisoning"
+)
+
+// maxFeedSize caps the number of bytes read from a feed source.
+const maxFeedSize = 16 << 20
+
+// Envelope is the signed wire format of an advisories feed.
+type Envelope struct {
+	// Payload is the base64 (standard encoding) JSON-encoded Feed.
+	Payload string `json:"payload"`
+	// Signature is the base64 Ed25519 signature over the decoded payload bytes.
+	Signature string `json:"signature"`
+	// KeyID optionally names the key that produced the signature.
+	KeyID string `json:"keyId,omitempty"`
+}
+
+// Feed is the verified advisories document.
+type Feed struct {
+	Version    int        `json:"version"`
+	Updated    string     `json:"updated"`
+	Advisories []Advisory `json:"advisories"`
+}
+
+// Advisory describes a single published advisory.
+type Advisory struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	Severity  string `json:"severity"`
+	Summary   string `json:"summary"`
+	Published string `json:"published"`
+
+	// Models lists affected Hugging Face model IDs. A trailing "/*" matches.
+	// every repository of an organisation (e.g. "some-org/*").
+	Models []string `json:"models"`
+	// Datasets lists affected Hugging Face dataset IDs (same syntax as Models).
+	Datasets []string `json:"datasets"`
+	// Revisions optionally restricts the advisory to specific commit SHAs.
+	// When empty every revision is affected.
+	Revisions []string `json:"revisions"`
+
+	References []string `json:"references"`
+}
+
+// Match pairs an affected BOM component with the advisory that matched it.
+type Match struct {
+	ComponentRef string
+	ComponentID  string
+	Kind         string // "model" or "dataset"
+	Revision     string
+	Advisory     Advisory
+}
+
+// Options configures how a feed is loaded.
+type Options struct {
+	// Source is an http(s) URL or a local file path.
+	Source string
+	// PublicKey is the base64-encoded Ed25519 public key used to verify the feed.
+	PublicKey string
+	// SkipVerify disables signature verification (for local testing only).
+	SkipVerify bool
+	Timeout    time.Duration
+	Client     *http.Client
+}
+
+// Load reads the feed envelope from opts.Source, verifies its signature and.
+// returns the decoded Feed.
+func Load(opts Options) (*Feed, error) {
+	raw, err := readSource(opts)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(raw, opts.PublicKey, opts.SkipVerify)
+}
+
+// Parse verifies a raw feed envelope and decodes its payload.
+func Parse(raw []byte, publicKey string, skipVerify bool) (*Feed, error) {
+	var env Envelope
+	if err := json.Unmarshal(raw, &env); err != nil {
+		return nil, fmt.Errorf("decode advisories envelope: %w", err)
+	}
+	if strings.TrimSpace(env.Payload) == "" {
+		return nil, fmt.Errorf("advisories envelope has no payload")
+	}
+
+	payload, err := base64.StdEncoding.DecodeString(strings.TrimSpace(env.Payload))
+	if err != nil {
+		return nil, fmt.Errorf("decode advisories payload: %w", err)
+	}
+
+	if !skipVerify {
+		if err := Verify(payload, env.Signature, publicKey); err != nil {
+			return nil, err
+		}
+	}
+
+	var feed Feed
+	if err := json.Unmarshal(payload, &feed); err != nil {
+		return nil, fmt.Errorf("decode advisories feed: %w", err)
+	}
+	return &feed, nil
+}
+
+// Verify checks an Ed25519 signature (base64) over payload with the given.
+// base64-encoded public key.
+func Verify(payload []byte, signature, publicKey string) error {
+	if strings.TrimSpace(publicKey) == "" {
+		return fmt.Errorf("no public key configured to verify the advisories feed")
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(publicKey))
+	if err != nil {
+		return fmt.Errorf("decode public key: %w", err)
+	}
+	if len(key) != ed25519.PublicKeySize {
+		return fmt.Errorf("invalid public key length %d (expected %d)", len(key), ed25519.PublicKeySize)
+	}
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(signature))
+	if err != nil {
+		return fmt.Errorf("decode signature: %w", err)
+	}
+	if !ed25519.Verify(ed25519.PublicKey(key), payload, sig) {
+		return fmt.Errorf("advisories feed signature verification failed")
+	}
+	return nil
+}
+
+// readSource loads the raw envelope bytes from a URL or a local path.
+func readSource(opts Options) ([]byte, error) {
+	src := strings.TrimSpace(opts.Source)
+	if src == "" {
+		return nil, fmt.Errorf("no advisories feed source configured")
+	}
+
+	if !strings.HasPrefix(src, "http://") && !strings.HasPrefix(src, "https://") {
+		path := strings.TrimPrefix(src, "file://")
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		return io.ReadAll(io.LimitReader(f, maxFeedSize))
+	}
+
+	client := opts.Client
+	if client == nil {
+		timeout := opts.Timeout
+		if timeout <= 0 {
+			timeout = 15 * time.Second
+		}
+		client = &http.Client{Timeout: timeout}
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, src, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch advisories feed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch advisories feed: status %d", resp.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxFeedSize))
+}
+
+// CheckBOM returns every (component, advisory) pair in which a model or.
+// dataset component of bom is affected by an advisory in feed.
+func CheckBOM(bom *cdx.BOM, feed *Feed) []Match {
+	if bom == nil || feed == nil {
+		return nil
+	}
+
+	var matches []Match
+	check := func(c *cdx.Component, kind string) {
+		id, rev := componentIdentity(c, kind)
+		if id == "" {
+			return
+		}
+		for _, adv := range feed.Advisories {
+			patterns := adv.Models
+			if kind == "dataset" {
+				patterns = adv.Datasets
+			}
+			if !matchesAny(patterns, id) || !matchesRevision(adv.Revisions, rev) {
+				continue
+			}
+			matches = append(matches, Match{
+				ComponentRef: c.BOMRef,
+				ComponentID:  id,
+				Kind:         kind,
+				Revision:     rev,
+				Advisory:     adv,
+			})
+		}
+	}
+
+	if bom.Metadata != nil && bom.Metadata.Component != nil {
+		check(bom.Metadata.Component, "model")
+	}
+	if bom.Components != nil {
+		for i := range *bom.Components {
+			c := &(*bom.Components)[i]
+			switch c.Type {
+			case cdx.ComponentTypeMachineLearningModel:
+				check(c, "model")
+			case cdx.ComponentTypeData:
+				check(c, "dataset")
+			}
+		}
+	}
+	return matches
+}
+
+// componentIdentity derives the Hugging Face ID and revision of a component.
+// from its PURL, falling back to the component name.
+func componentIdentity(c *cdx.Component, kind string) (id, revision string) {
+	prefix := "pkg:huggingface/"
+	if kind == "dataset" {
+		prefix = "pkg:huggingface/datasets/"
+	}
+	if rest, ok := strings.CutPrefix(c.PackageURL, prefix); ok {
+		if i := strings.Index(rest, "?"); i >= 0 {
+			rest = rest[:i]
+		}
+		if i := strings.Index(rest, "@"); i >= 0 {
+			revision = rest[i+1:]
+			rest = rest[:i]
+		}
+		id = rest
+	}
+	if id == "" {
+		id = strings.TrimPrefix(strings.TrimSpace(c.Name), "datasets/")
+	}
+	if revision == "" && c.Hashes != nil && len(*c.Hashes) > 0 {
+		revision = (*c.Hashes)[0].Value
+	}
+	return strings.TrimSpace(id), strings.ToLower(strings.TrimSpace(revision))
+}
+
+func matchesAny(patterns []string, id string) bool {
+	for _, p := range patterns {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		if org, ok := strings.CutSuffix(p, "/*"); ok {
+			if strings.HasPrefix(strings.ToLower(id), strings.ToLower(org)+"/") {
+				return true
+			}
+			continue
+		}
+		if strings.EqualFold(p, id) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesRevision(revisions []string, rev string) bool {
+	if len(revisions) == 0 {
+		return true
+	}
+	if rev == "" {
+		// Unknown revision: report conservatively.
+		return true
+	}
+	for _, r := range revisions {
+		r = strings.ToLower(strings.TrimSpace(r))
+		if r != "" && (strings.HasPrefix(rev, r) || strings.HasPrefix(r, rev)) {
+			return true
+		}
+	}
+	return false
+}
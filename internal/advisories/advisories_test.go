@@ -0,0 +1,131 @@
+package advisories
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+func signedEnvelope(t *testing.T, feed Feed) ([]byte, string) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	payload, err := json.Marshal(feed)
+	if err != nil {
+		t.Fatalf("marshal feed: %v", err)
+	}
+	env := Envelope{
+		Payload:   base64.StdEncoding.EncodeToString(payload),
+		Signature: base64.StdEncoding.EncodeToString(ed25519.Sign(priv, payload)),
+	}
+	raw, err := json.Marshal(env)
+	if err != nil {
+		t.Fatalf("marshal envelope: %v", err)
+	}
+	return raw, base64.StdEncoding.EncodeToString(pub)
+}
+
+func testFeed() Feed {
+	return Feed{
+		Version: 1,
+		Advisories: []Advisory{
+			{ID: "ADV-1", Type: TypeTakedown, Severity: "high", Models: []string{"Bad-Org/*"}},
+			{ID: "ADV-2", Type: TypeWeightPoisoning, Severity: "critical", Models: []string{"org/model"}, Revisions: []string{"abc123"}},
+			{ID: "ADV-3", Type: TypeLicenseChange, Severity: "medium", Datasets: []string{"org/data"}},
+		},
+	}
+}
+
+func TestParse_VerifiesSignature(t *testing.T) {
+	raw, pub := signedEnvelope(t, testFeed())
+
+	feed, err := Parse(raw, pub, false)
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if len(feed.Advisories) != 3 {
+		t.Fatalf("expected 3 advisories, got %d", len(feed.Advisories))
+	}
+
+	_, otherPub := signedEnvelope(t, testFeed())
+	if _, err := Parse(raw, otherPub, false); err == nil {
+		t.Fatalf("expected verification failure with wrong key")
+	}
+	if _, err := Parse(raw, "", false); err == nil {
+		t.Fatalf("expected error without public key")
+	}
+	if _, err := Parse(raw, "", true); err != nil {
+		t.Fatalf("expected skip-verify to succeed, got %v", err)
+	}
+}
+
+func TestLoad_FromFileAndHTTP(t *testing.T) {
+	raw, pub := signedEnvelope(t, testFeed())
+
+	path := filepath.Join(t.TempDir(), "feed.json")
+	if err := os.WriteFile(path, raw, 0o644); err != nil {
+		t.Fatalf("write feed: %v", err)
+	}
+	if _, err := Load(Options{Source: "file://" + path, PublicKey: pub}); err != nil {
+		t.Fatalf("Load file error: %v", err)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(raw)
+	}))
+	defer srv.Close()
+	if _, err := Load(Options{Source: srv.URL, PublicKey: pub}); err != nil {
+		t.Fatalf("Load http error: %v", err)
+	}
+
+	notFound := httptest.NewServer(http.NotFoundHandler())
+	defer notFound.Close()
+	if _, err := Load(Options{Source: notFound.URL, PublicKey: pub}); err == nil {
+		t.Fatalf("expected error for 404 feed")
+	}
+}
+
+func TestCheckBOM(t *testing.T) {
+	feed := testFeed()
+	bom := cdx.NewBOM()
+	bom.Metadata = &cdx.Metadata{Component: &cdx.Component{
+		Type:       cdx.ComponentTypeMachineLearningModel,
+		Name:       "org/model",
+		PackageURL: "pkg:huggingface/org/model@abc123def",
+	}}
+	bom.Components = &[]cdx.Component{
+		{Type: cdx.ComponentTypeMachineLearningModel, Name: "bad-org/thing"},
+		{Type: cdx.ComponentTypeMachineLearningModel, Name: "org/model", PackageURL: "pkg:huggingface/org/model@fff000"},
+		{Type: cdx.ComponentTypeData, Name: "org/data", PackageURL: "pkg:huggingface/datasets/org/data"},
+		{Type: cdx.ComponentTypeLibrary, Name: "org/data"},
+	}
+
+	matches := CheckBOM(bom, &feed)
+	got := map[string]string{}
+	for _, m := range matches {
+		got[m.Advisory.ID+"|"+m.ComponentID] = m.Kind
+	}
+
+	want := map[string]string{
+		"ADV-2|org/model":     "model",
+		"ADV-1|bad-org/thing": "model",
+		"ADV-3|org/data":      "dataset",
+	}
+	if len(got) != len(want) || len(matches) != len(want) {
+		t.Fatalf("matches = %v, want %v", got, want)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Fatalf("missing match %s (%s); got %v", k, v, got)
+		}
+	}
+}
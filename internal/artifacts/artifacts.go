@@ -0,0 +1,235 @@
+// Package artifacts checks model weight files on disk against the digests.
+// recorded for them in AIBOMs.
+//.
+// Every weight file below a directory (detected by content, as scan does) is.
+// matched to the components whose digest it should have: first by the.
+// recorded discovery path, then by file name. The file is hashed with every.
+// algorithm the matching components record, and the result is reported per.
+// file: verified, mismatched, or not recorded in any AIBOM. Recorded files.
+// that are not on disk are reported as missing.
+package artifacts
+
+import (
+	"crypto/sha512"
+	"encoding/hex"
+	"hash"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/idlab-discover/aibomgen-cli/internal/taxonomy"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/scanner"
+	"lukechampine.com/blake3"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+// fileDigests are the hash algorithms that identify file content. Other.
+// algorithms are not checked: the SHA-1 of a Hugging Face model component is.
+// the commit of its repo, not a file digest.
+var fileDigests = map[cdx.HashAlgorithm]func() hash.Hash{
+	cdx.HashAlgoSHA256: nil, // scanner.HashFile
+	cdx.HashAlgoSHA384: sha512.New384,
+	cdx.HashAlgoSHA512: sha512.New,
+	cdx.HashAlgoBlake3: func() hash.Hash { return blake3.New(32, nil) },
+}
+
+// Recorded is a component with at least one file digest.
+type Recorded struct {
+	Name   string
+	Path   string // discovery path recorded at generation time, or ""
+	BOMRef string
+	File   string // the AIBOM that lists the component
+	Hashes []cdx.Hash
+}
+
+// Result is the outcome for one weight file.
+type Result struct {
+	// Path is relative to the artifacts directory, with forward slashes.
+	Path   string
+	Format string
+	// Recorded lists the components the file was matched to.
+	Recorded []Recorded
+	// Mismatches lists the recorded digests the file does not have.
+	Mismatches []Mismatch
+}
+
+// Mismatch is a recorded digest that differs from the file content.
+type Mismatch struct {
+	Recorded  Recorded
+	Algorithm cdx.HashAlgorithm
+	Expected  string
+	Actual    string
+}
+
+// Report is the result of Verify.
+type Report struct {
+	Verified   []Result
+	Mismatched []Result
+	Unrecorded []Result   // weight files without a recorded digest
+	Missing    []Recorded // recorded files not found on disk
+}
+
+// Failed reports whether any file differs from its recorded digest.
+func (r Report) Failed() bool {
+	return len(r.Mismatched) > 0
+}
+
+// Options configures Verify.
+type Options struct {
+	// Hash configures SHA-256 hashing. Sidecar digests are never used: the.
+	// point is to read the bits.
+	Hash scanner.HashOptions
+}
+
+// RecordedArtifacts lists the components of bom that record a file digest.
+// file is recorded on every entry.
+func RecordedArtifacts(bom *cdx.BOM, file string) []Recorded {
+	if bom == nil {
+		return nil
+	}
+	var out []Recorded
+	var add func(c *cdx.Component)
+	add = func(c *cdx.Component) {
+		if c == nil {
+			return
+		}
+		if c.Hashes != nil {
+			var hashes []cdx.Hash
+			for _, h := range *c.Hashes {
+				if _, ok := fileDigests[h.Algorithm]; ok && strings.TrimSpace(h.Value) != "" {
+					hashes = append(hashes, h)
+				}
+			}
+			if len(hashes) > 0 {
+				path, _ := taxonomy.Get(c.Properties, taxonomy.DiscoveryPath)
+				out = append(out, Recorded{Name: c.Name, Path: path, BOMRef: c.BOMRef, File: file, Hashes: hashes})
+			}
+		}
+		if c.Components != nil {
+			for i := range *c.Components {
+				add(&(*c.Components)[i])
+			}
+		}
+	}
+	if bom.Metadata != nil {
+		add(bom.Metadata.Component)
+	}
+	if bom.Components != nil {
+		for i := range *bom.Components {
+			add(&(*bom.Components)[i])
+		}
+	}
+	return out
+}
+
+// Verify hashes the weight files below dir and compares them with the.
+// recorded digests.
+func Verify(dir string, recorded []Recorded, opts Options) (Report, error) {
+	var rep Report
+	used := make([]bool, len(recorded))
+	opts.Hash.UseSidecar = false
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != dir && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		format := scanner.DetectModelFile(path)
+		if format == "" {
+			return nil
+		}
+		rel, _ := filepath.Rel(dir, path)
+		res := Result{Path: filepath.ToSlash(rel), Format: format}
+
+		matches := match(res.Path, recorded)
+		if len(matches) == 0 {
+			rep.Unrecorded = append(rep.Unrecorded, res)
+			return nil
+		}
+
+		digests := make(map[cdx.HashAlgorithm]string)
+		for _, i := range matches {
+			used[i] = true
+			res.Recorded = append(res.Recorded, recorded[i])
+			for _, h := range recorded[i].Hashes {
+				actual, ok := digests[h.Algorithm]
+				if !ok {
+					if actual, err = digest(path, h.Algorithm, opts.Hash); err != nil {
+						return err
+					}
+					digests[h.Algorithm] = actual
+				}
+				if !strings.EqualFold(strings.TrimSpace(h.Value), actual) {
+					res.Mismatches = append(res.Mismatches, Mismatch{Recorded: recorded[i], Algorithm: h.Algorithm, Expected: h.Value, Actual: actual})
+				}
+			}
+		}
+		if len(res.Mismatches) > 0 {
+			rep.Mismatched = append(rep.Mismatched, res)
+		} else {
+			rep.Verified = append(rep.Verified, res)
+		}
+		return nil
+	})
+	if err != nil {
+		return Report{}, err
+	}
+
+	for i, r := range recorded {
+		if !used[i] {
+			rep.Missing = append(rep.Missing, r)
+		}
+	}
+	sort.SliceStable(rep.Missing, func(i, j int) bool { return rep.Missing[i].Name < rep.Missing[j].Name })
+	return rep, nil
+}
+
+// match returns the indexes of the recorded components for the file at rel.
+// Components whose recorded path ends with rel match first; otherwise.
+// components named like the file.
+func match(rel string, recorded []Recorded) []int {
+	var byPath, byName []int
+	base := path.Base(rel)
+	for i, r := range recorded {
+		p := filepath.ToSlash(r.Path)
+		switch {
+		case p != "" && (p == rel || strings.HasSuffix(p, "/"+rel)):
+			byPath = append(byPath, i)
+		case r.Name == base:
+			byName = append(byName, i)
+		}
+	}
+	if len(byPath) > 0 {
+		return byPath
+	}
+	return byName
+}
+
+// digest computes the hex digest of file with alg.
+func digest(file string, alg cdx.HashAlgorithm, opts scanner.HashOptions) (string, error) {
+	newHash := fileDigests[alg]
+	if newHash == nil {
+		sum, err := scanner.HashFile(file, opts)
+		return sum.SHA256, err
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := newHash()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,114 @@
+package artifacts
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/idlab-discover/aibomgen-cli/internal/taxonomy"
+	"lukechampine.com/blake3"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+// gguf returns a minimal GGUF file body with the given payload.
+func gguf(payload string) []byte {
+	b := append([]byte("GGUF\x03\x00\x00\x00"), make([]byte, 64)...)
+	return append(b, payload...)
+}
+
+func sha256Hex(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func writeFile(t *testing.T, path string, data []byte) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRecordedArtifacts(t *testing.T) {
+	bom := &cdx.BOM{
+		Metadata: &cdx.Metadata{Component: &cdx.Component{
+			Name:       "app",
+			Components: &[]cdx.Component{{Name: "nested.gguf", Hashes: &[]cdx.Hash{{Algorithm: cdx.HashAlgoSHA512, Value: "ab"}}}},
+		}},
+		Components: &[]cdx.Component{
+			{
+				Name:       "model.gguf",
+				Hashes:     &[]cdx.Hash{{Algorithm: cdx.HashAlgoSHA256, Value: "cd"}, {Algorithm: cdx.HashAlgoMD5, Value: "ef"}},
+				Properties: &[]cdx.Property{{Name: taxonomy.DiscoveryPath, Value: "/repo/models/model.gguf"}},
+			},
+			// The SHA-1 of a Hugging Face model is its commit, not a file digest.
+			{Name: "org/model", Hashes: &[]cdx.Hash{{Algorithm: cdx.HashAlgoSHA1, Value: "0123"}}},
+		},
+	}
+	got := RecordedArtifacts(bom, "aibom.json")
+	if len(got) != 2 {
+		t.Fatalf("expected 2 recorded artifacts, got %+v", got)
+	}
+	if got[0].Name != "nested.gguf" || got[1].Name != "model.gguf" || got[1].Path != "/repo/models/model.gguf" || got[1].File != "aibom.json" {
+		t.Errorf("unexpected recorded artifacts %+v", got)
+	}
+	if len(got[1].Hashes) != 1 || got[1].Hashes[0].Algorithm != cdx.HashAlgoSHA256 {
+		t.Errorf("only file digests should be kept, got %+v", got[1].Hashes)
+	}
+	if RecordedArtifacts(nil, "") != nil {
+		t.Error("nil BOM should record nothing")
+	}
+}
+
+func TestVerify(t *testing.T) {
+	dir := t.TempDir()
+	good := gguf("good")
+	tampered := gguf("tampered")
+	renamed := gguf("renamed")
+	writeFile(t, filepath.Join(dir, "llm", "model.gguf"), good)
+	writeFile(t, filepath.Join(dir, "other", "model.gguf"), tampered)
+	writeFile(t, filepath.Join(dir, "copy.gguf"), renamed)
+	writeFile(t, filepath.Join(dir, "unknown.gguf"), gguf("unknown"))
+	writeFile(t, filepath.Join(dir, "README.md"), []byte("not a weight file, long enough to be sniffed by the scanner"))
+	// A sidecar must not be trusted.
+	writeFile(t, filepath.Join(dir, "other", "model.gguf.sha256"), []byte(sha256Hex(good)))
+
+	sum512 := sha512.Sum512(renamed)
+	b3 := blake3.Sum256(good)
+	recorded := []Recorded{
+		{Name: "model.gguf", Path: "/build/llm/model.gguf", File: "a.json", Hashes: []cdx.Hash{{Algorithm: cdx.HashAlgoSHA256, Value: sha256Hex(good)}, {Algorithm: cdx.HashAlgoBlake3, Value: hex.EncodeToString(b3[:])}}},
+		{Name: "model.gguf", Path: "/build/other/model.gguf", File: "b.json", Hashes: []cdx.Hash{{Algorithm: cdx.HashAlgoSHA256, Value: sha256Hex(good)}}},
+		{Name: "copy.gguf", File: "c.json", Hashes: []cdx.Hash{{Algorithm: cdx.HashAlgoSHA512, Value: hex.EncodeToString(sum512[:])}}},
+		{Name: "gone.gguf", File: "d.json", Hashes: []cdx.Hash{{Algorithm: cdx.HashAlgoSHA256, Value: "00"}}},
+	}
+
+	rep, err := Verify(dir, recorded, Options{})
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if len(rep.Verified) != 2 || rep.Verified[0].Path != "copy.gguf" || rep.Verified[1].Path != "llm/model.gguf" {
+		t.Errorf("unexpected verified files %+v", rep.Verified)
+	}
+	if len(rep.Mismatched) != 1 || rep.Mismatched[0].Path != "other/model.gguf" {
+		t.Fatalf("unexpected mismatched files %+v", rep.Mismatched)
+	}
+	m := rep.Mismatched[0].Mismatches[0]
+	if m.Recorded.File != "b.json" || m.Actual != sha256Hex(tampered) {
+		t.Errorf("unexpected mismatch %+v", m)
+	}
+	if len(rep.Unrecorded) != 1 || rep.Unrecorded[0].Path != "unknown.gguf" || rep.Unrecorded[0].Format != "gguf" {
+		t.Errorf("unexpected unrecorded files %+v", rep.Unrecorded)
+	}
+	if len(rep.Missing) != 1 || rep.Missing[0].Name != "gone.gguf" {
+		t.Errorf("unexpected missing files %+v", rep.Missing)
+	}
+	if !rep.Failed() {
+		t.Error("a mismatch should fail the report")
+	}
+}
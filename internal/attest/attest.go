@@ -0,0 +1,300 @@
+// Package attest signs manually supplied BOM fields as individual claims, so.
+// that reviewers can check which statement came from which approver.
+//.
+// Each claim is an in-toto Statement whose subject names the BOM serial number.
+// and field key and carries the SHA-256 digest of the claimed value. The.
+// statement is signed as a compact JWS (EdDSA, Ed25519). Claim files hold one.
+// JWS per line.
+package attest
+
+import (
+	"bufio"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/idlab-discover/aibomgen-cli/internal/audit"
+)
+
+// Statement and predicate types.
+const (
+	StatementType = "https://in-toto.io/Statement/v1"
+	PredicateType = "https://github.com/idlab-discover/aibomgen-cli/attestations/field-claim/v1"
+
+	payloadType = "application/vnd.in-toto+json"
+)
+
+// Claim is the predicate of a field claim.
+type Claim struct {
+	SerialNumber string    `json:"serialNumber"`
+	Component    string    `json:"component"`
+	BOMRef       string    `json:"bomRef,omitempty"`
+	Dataset      bool      `json:"dataset,omitempty"`
+	Field        string    `json:"field"`
+	Value        string    `json:"value,omitempty"`
+	Removed      bool      `json:"removed,omitempty"`
+	Source       string    `json:"source"`
+	Actor        string    `json:"actor"`
+	Time         time.Time `json:"time"`
+}
+
+// Subject is an in-toto resource descriptor.
+type Subject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// Statement is an in-toto Statement holding one Claim.
+type Statement struct {
+	Type          string    `json:"_type"`
+	Subject       []Subject `json:"subject"`
+	PredicateType string    `json:"predicateType"`
+	Predicate     Claim     `json:"predicate"`
+}
+
+type header struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ,omitempty"`
+	Cty string `json:"cty,omitempty"`
+	Kid string `json:"kid,omitempty"`
+}
+
+// FromEntry returns the claim for an audit entry.
+func FromEntry(e audit.Entry) Claim {
+	return Claim{
+		SerialNumber: e.SerialNumber,
+		Component:    e.Component,
+		BOMRef:       e.BOMRef,
+		Dataset:      e.Dataset,
+		Field:        e.Field,
+		Value:        e.New,
+		Removed:      e.Source == audit.SourceUnset,
+		Source:       e.Source,
+		Actor:        e.Actor,
+		Time:         e.Time,
+	}
+}
+
+// SubjectName identifies the claimed field: the BOM serial number, then the.
+// component bom-ref (or name) and the field key.
+func (c Claim) SubjectName() string {
+	ref := c.BOMRef
+	if ref == "" {
+		ref = c.Component
+	}
+	return c.SerialNumber + "#" + ref + "#" + c.Field
+}
+
+// Statement wraps c in an in-toto Statement.
+func (c Claim) Statement() Statement {
+	sum := sha256.Sum256([]byte(c.Value))
+	return Statement{
+		Type:          StatementType,
+		Subject:       []Subject{{Name: c.SubjectName(), Digest: map[string]string{"sha256": hex.EncodeToString(sum[:])}}},
+		PredicateType: PredicateType,
+		Predicate:     c,
+	}
+}
+
+// Sign returns c as a compact JWS signed with key.
+func Sign(c Claim, key ed25519.PrivateKey) (string, error) {
+	h, err := json.Marshal(header{Alg: "EdDSA", Typ: "JOSE", Cty: payloadType, Kid: KeyID(key.Public().(ed25519.PublicKey))})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(c.Statement())
+	if err != nil {
+		return "", err
+	}
+	input := b64(h) + "." + b64(payload)
+	return input + "." + b64(ed25519.Sign(key, []byte(input))), nil
+}
+
+// Verified is a claim whose signature was checked.
+type Verified struct {
+	Statement Statement
+	KeyID     string
+}
+
+// Verify checks the signature of a compact JWS with the first of keys whose.
+// key ID matches its header, and checks the statement: its type, predicate.
+// type and the subject digest of the claimed value.
+func Verify(jws string, keys []ed25519.PublicKey) (*Verified, error) {
+	parts := strings.Split(strings.TrimSpace(jws), ".")
+	if len(parts) != 3 {
+		return nil, errors.New("not a compact JWS")
+	}
+	var h header
+	if err := decodeJSON(parts[0], &h); err != nil {
+		return nil, fmt.Errorf("header: %w", err)
+	}
+	if h.Alg != "EdDSA" {
+		return nil, fmt.Errorf("unsupported algorithm %q", h.Alg)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("signature: %w", err)
+	}
+	var st Statement
+	if err := decodeJSON(parts[1], &st); err != nil {
+		return nil, fmt.Errorf("payload: %w", err)
+	}
+
+	var keyID string
+	for _, k := range keys {
+		if id := KeyID(k); (h.Kid == "" || h.Kid == id) && ed25519.Verify(k, []byte(parts[0]+"."+parts[1]), sig) {
+			keyID = id
+			break
+		}
+	}
+	if keyID == "" {
+		return nil, fmt.Errorf("signature does not match any trusted key (kid %q)", h.Kid)
+	}
+
+	if st.Type != StatementType || st.PredicateType != PredicateType {
+		return nil, fmt.Errorf("unexpected statement type %q / %q", st.Type, st.PredicateType)
+	}
+	want := st.Predicate.Statement().Subject[0]
+	if len(st.Subject) != 1 || st.Subject[0].Name != want.Name || st.Subject[0].Digest["sha256"] != want.Digest["sha256"] {
+		return nil, errors.New("subject does not match the claimed field and value")
+	}
+	return &Verified{Statement: st, KeyID: keyID}, nil
+}
+
+// KeyID is the identifier of a public key used in JWS headers: the first 16.
+// hex digits of the SHA-256 of the key.
+func KeyID(pub ed25519.PublicKey) string {
+	sum := sha256.Sum256(pub)
+	return hex.EncodeToString(sum[:8])
+}
+
+// Write appends the signed claims to path, one JWS per line.
+func Write(path string, claims []string) error {
+	if len(claims) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteString(strings.Join(claims, "\n") + "\n"); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Read returns the claims in path, skipping blank lines.
+func Read(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var out []string
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for sc.Scan() {
+		if line := strings.TrimSpace(sc.Text()); line != "" {
+			out = append(out, line)
+		}
+	}
+	return out, sc.Err()
+}
+
+// ParsePrivateKey reads an Ed25519 private key from s: a PEM (PKCS #8) key,.
+// a base64 seed or full private key, or the path of a file holding either.
+func ParsePrivateKey(s string) (ed25519.PrivateKey, error) {
+	data, err := keyData(s)
+	if err != nil {
+		return nil, err
+	}
+	if block, _ := pem.Decode(data); block != nil {
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		if k, ok := key.(ed25519.PrivateKey); ok {
+			return k, nil
+		}
+		return nil, fmt.Errorf("not an Ed25519 private key (%T)", key)
+	}
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key encoding: %w", err)
+	}
+	switch len(raw) {
+	case ed25519.SeedSize:
+		return ed25519.NewKeyFromSeed(raw), nil
+	case ed25519.PrivateKeySize:
+		return ed25519.PrivateKey(raw), nil
+	}
+	return nil, fmt.Errorf("invalid private key length %d (expected %d or %d)", len(raw), ed25519.SeedSize, ed25519.PrivateKeySize)
+}
+
+// ParsePublicKey reads an Ed25519 public key from s: a PEM (PKIX) key, a.
+// base64 key, or the path of a file holding either.
+func ParsePublicKey(s string) (ed25519.PublicKey, error) {
+	data, err := keyData(s)
+	if err != nil {
+		return nil, err
+	}
+	if block, _ := pem.Decode(data); block != nil {
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		if k, ok := key.(ed25519.PublicKey); ok {
+			return k, nil
+		}
+		return nil, fmt.Errorf("not an Ed25519 public key (%T)", key)
+	}
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key encoding: %w", err)
+	}
+	if len(raw) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid public key length %d (expected %d)", len(raw), ed25519.PublicKeySize)
+	}
+	return ed25519.PublicKey(raw), nil
+}
+
+// keyData returns s, or the contents of the file s names.
+func keyData(s string) ([]byte, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, errors.New("empty key")
+	}
+	if strings.HasPrefix(s, "-----BEGIN") {
+		return []byte(s), nil
+	}
+	if info, err := os.Stat(s); err == nil && info.Mode().IsRegular() {
+		return os.ReadFile(s)
+	}
+	return []byte(s), nil
+}
+
+func b64(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeJSON(part string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
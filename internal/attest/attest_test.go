@@ -0,0 +1,114 @@
+package attest
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/idlab-discover/aibomgen-cli/internal/audit"
+)
+
+func testKey(t *testing.T, seed byte) ed25519.PrivateKey {
+	t.Helper()
+	return ed25519.NewKeyFromSeed([]byte(strings.Repeat(string(rune('a'+seed)), ed25519.SeedSize)))
+}
+
+func TestSignVerify(t *testing.T) {
+	alice, bob := testKey(t, 0), testKey(t, 1)
+	claim := FromEntry(audit.Entry{
+		Time:         time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Actor:        "alice",
+		SerialNumber: "urn:uuid:1",
+		Component:    "m",
+		BOMRef:       "pkg:huggingface/m",
+		Field:        "BOM.metadata.component.licenses",
+		New:          "mit",
+		Source:       audit.SourceAnswer,
+	})
+	jws, err := Sign(claim, alice)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	v, err := Verify(jws, []ed25519.PublicKey{bob.Public().(ed25519.PublicKey), alice.Public().(ed25519.PublicKey)})
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if v.KeyID != KeyID(alice.Public().(ed25519.PublicKey)) {
+		t.Errorf("KeyID = %s, want alice's key", v.KeyID)
+	}
+	st := v.Statement
+	if st.Subject[0].Name != "urn:uuid:1#pkg:huggingface/m#BOM.metadata.component.licenses" || st.Predicate.Value != "mit" || st.Predicate.Actor != "alice" {
+		t.Errorf("statement = %+v", st)
+	}
+
+	if _, err := Verify(jws, []ed25519.PublicKey{bob.Public().(ed25519.PublicKey)}); err == nil {
+		t.Errorf("Verify with an untrusted key succeeded")
+	}
+
+	// Changing the claimed value breaks the signature.
+	parts := strings.Split(jws, ".")
+	payload, _ := base64.RawURLEncoding.DecodeString(parts[1])
+	parts[1] = base64.RawURLEncoding.EncodeToString([]byte(strings.Replace(string(payload), `"value":"mit"`, `"value":"gpl"`, 1)))
+	if _, err := Verify(strings.Join(parts, "."), []ed25519.PublicKey{alice.Public().(ed25519.PublicKey)}); err == nil {
+		t.Errorf("Verify of a modified claim succeeded")
+	}
+}
+
+func TestWriteRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bom.json.claims.jsonl")
+	if err := Write(path, []string{"a.b.c"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := Write(path, []string{"d.e.f", "g.h.i"}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Read(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(got, " ") != "a.b.c d.e.f g.h.i" {
+		t.Errorf("Read = %v", got)
+	}
+}
+
+func TestParseKeys(t *testing.T) {
+	key := testKey(t, 2)
+	pub := key.Public().(ed25519.PublicKey)
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privPEM := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	der, err = x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubFile := filepath.Join(t.TempDir(), "key.pub.pem")
+	if err := os.WriteFile(pubFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, in := range []string{privPEM, base64.StdEncoding.EncodeToString(key.Seed()), base64.StdEncoding.EncodeToString(key)} {
+		got, err := ParsePrivateKey(in)
+		if err != nil || !got.Equal(key) {
+			t.Errorf("ParsePrivateKey(%.20q) = %v", in, err)
+		}
+	}
+	for _, in := range []string{pubFile, base64.StdEncoding.EncodeToString(pub)} {
+		got, err := ParsePublicKey(in)
+		if err != nil || !got.Equal(pub) {
+			t.Errorf("ParsePublicKey(%q) = %v", in, err)
+		}
+	}
+	if _, err := ParsePublicKey("c2hvcnQ="); err == nil {
+		t.Errorf("ParsePublicKey accepted a short key")
+	}
+}
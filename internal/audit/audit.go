@@ -0,0 +1,243 @@
+// Package audit records manually supplied BOM field values.
+//.
+// Values entered by a person (interactive prompts, --answer, enrichment and.
+// answers files) or removed with --unset are claims that compliance reviews.
+// need to attribute. Every change is appended as one JSON line to an audit.
+// log file and added to the BOM as a CycloneDX annotation.
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+// Sources of a change.
+const (
+	SourceInteractive = "interactive"
+	SourceAnswer      = "answer"
+	SourceDraft       = "draft"
+	SourceFile        = "file"
+	SourceAnswersFile = "answers-file"
+	SourceUnset       = "unset"
+)
+
+// Entry is one manually set or removed field.
+type Entry struct {
+	Time         time.Time `json:"time"`
+	Actor        string    `json:"actor"`
+	File         string    `json:"file,omitempty"`
+	SerialNumber string    `json:"serialNumber,omitempty"`
+	// Component is the name of the model or dataset component; BOMRef its.
+	// bom-ref.
+	Component string `json:"component"`
+	BOMRef    string `json:"bomRef,omitempty"`
+	Dataset   bool   `json:"dataset,omitempty"`
+	Field     string `json:"field"`
+	// Old is the removed or replaced value (only known for unset fields and.
+	// values from answers files, the only ones enrichment overwrites); New is.
+	// empty for removals.
+	Old    string `json:"old,omitempty"`
+	New    string `json:"new,omitempty"`
+	Source string `json:"source"`
+}
+
+// DefaultPath returns the per-user audit log location.
+func DefaultPath() (string, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "aibomgen-cli", "enrich-audit.jsonl"), nil
+}
+
+// DefaultActor returns the login name of the current user.
+func DefaultActor() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+	return "unknown"
+}
+
+// Append adds entries to the audit log at path, creating it when needed.
+// Existing lines are never rewritten.
+func Append(path string, entries []Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	var buf []byte
+	for _, e := range entries {
+		line, err := json.Marshal(e)
+		if err != nil {
+			f.Close()
+			return err
+		}
+		buf = append(append(buf, line...), '\n')
+	}
+	// One write keeps the lines of a run together when several runs share.
+	// the file.
+	if _, err := f.Write(buf); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Annotate adds one annotation per entry to bom, attributed to the entry's.
+// actor.
+func Annotate(bom *cdx.BOM, entries []Entry) {
+	if bom == nil || len(entries) == 0 {
+		return
+	}
+	var annotations []cdx.Annotation
+	if bom.Annotations != nil {
+		annotations = *bom.Annotations
+	}
+	for _, e := range entries {
+		a := cdx.Annotation{
+			Annotator: &cdx.Annotator{Individual: &cdx.OrganizationalContact{Name: e.Actor}},
+			Timestamp: e.Time.UTC().Format(time.RFC3339),
+			Text:      e.Text(),
+		}
+		if e.BOMRef != "" {
+			a.Subjects = &[]cdx.BOMReference{cdx.BOMReference(e.BOMRef)}
+		}
+		annotations = append(annotations, a)
+	}
+	bom.Annotations = &annotations
+}
+
+// maxTextValue caps the old value quoted in annotation texts; the audit log.
+// keeps it in full.
+const maxTextValue = 200
+
+// Text describes the change in one sentence.
+func (e Entry) Text() string {
+	target := e.Field
+	if e.Dataset {
+		target = fmt.Sprintf("%s of dataset %q", e.Field, e.Component)
+	}
+	if e.Source == SourceUnset {
+		return fmt.Sprintf("Manually removed %s (was %s).", target, clip(orNone(e.Old)))
+	}
+	text := fmt.Sprintf("Manually set %s to %q (source: %s", target, e.New, e.Source)
+	if e.Old != "" {
+		text += ", was " + clip(e.Old)
+	}
+	return text + ")."
+}
+
+func clip(s string) string {
+	if r := []rune(s); len(r) > maxTextValue {
+		return string(r[:maxTextValue]) + "…"
+	}
+	return s
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "empty"
+	}
+	return s
+}
+
+// Snapshot returns a copy of v in its JSON form, for use with Removed when v.
+// is about to be modified in place.
+func Snapshot(v any) any {
+	return generic(v)
+}
+
+// Removed describes the parts of before that are missing or different in.
+// after, as "path: JSON value" pairs joined by "; ". Both values are compared.
+// in their JSON form; lists of named objects (such as properties) are.
+// compared by name.
+func Removed(before, after any) string {
+	var parts []string
+	diff("", generic(before), generic(after), &parts)
+	return strings.Join(parts, "; ")
+}
+
+func generic(v any) any {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var out any
+	_ = json.Unmarshal(data, &out)
+	return out
+}
+
+func diff(path string, before, after any, out *[]string) {
+	if before == nil || reflect.DeepEqual(before, after) {
+		return
+	}
+	if bm, ok := before.(map[string]any); ok {
+		if am, ok := after.(map[string]any); ok {
+			keys := make([]string, 0, len(bm))
+			for k := range bm {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				diff(joinPath(path, k), bm[k], am[k], out)
+			}
+			return
+		}
+	}
+	if bl, ok := before.([]any); ok {
+		if al, ok := after.([]any); ok {
+			if bn, an := byName(bl), byName(al); bn != nil && an != nil {
+				for _, item := range bl {
+					name := item.(map[string]any)["name"].(string)
+					diff(path+"["+name+"]", item, an[name], out)
+				}
+				return
+			}
+		}
+	}
+	data, _ := json.Marshal(before)
+	*out = append(*out, path+": "+string(data))
+}
+
+// byName indexes a list of objects by their "name", or returns nil when an.
+// item has no name.
+func byName(list []any) map[string]any {
+	out := make(map[string]any, len(list))
+	for _, item := range list {
+		m, ok := item.(map[string]any)
+		if !ok {
+			return nil
+		}
+		name, ok := m["name"].(string)
+		if !ok {
+			return nil
+		}
+		out[name] = m
+	}
+	return out
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
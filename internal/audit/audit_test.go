@@ -0,0 +1,99 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+func TestAppend(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "audit.jsonl")
+	first := []Entry{{Actor: "alice", Component: "m", Field: "licenses", New: "mit", Source: SourceAnswer}}
+	second := []Entry{
+		{Actor: "bob", Component: "m", Field: "licenses", Old: "licenses: \"mit\"", Source: SourceUnset},
+		{Actor: "bob", Component: "d", Dataset: true, Field: "description", New: "x", Source: SourceInteractive},
+	}
+	for _, entries := range [][]Entry{first, nil, second} {
+		if err := Append(path, entries); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var got []Entry
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		var e Entry
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			t.Fatalf("line %q: %v", sc.Text(), err)
+		}
+		got = append(got, e)
+	}
+	if len(got) != 3 || got[0].Actor != "alice" || got[1].Source != SourceUnset || !got[2].Dataset {
+		t.Errorf("log entries = %+v", got)
+	}
+}
+
+func TestAnnotate(t *testing.T) {
+	bom := cdx.NewBOM()
+	bom.Annotations = &[]cdx.Annotation{{Text: "existing"}}
+	when := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	Annotate(bom, []Entry{
+		{Time: when, Actor: "alice", BOMRef: "pkg:huggingface/m", Component: "m", Field: "licenses", New: "mit", Source: SourceAnswer},
+		{Time: when, Actor: "bob", Component: "imdb", Dataset: true, Field: "description", Old: strings.Repeat("x", 300), Source: SourceUnset},
+	})
+
+	got := *bom.Annotations
+	if len(got) != 3 {
+		t.Fatalf("got %d annotations, want 3", len(got))
+	}
+	set := got[1]
+	if set.Annotator.Individual.Name != "alice" || set.Timestamp != "2026-01-02T03:04:05Z" {
+		t.Errorf("annotator/timestamp = %q/%q", set.Annotator.Individual.Name, set.Timestamp)
+	}
+	if set.Subjects == nil || (*set.Subjects)[0] != "pkg:huggingface/m" {
+		t.Errorf("subjects = %v", set.Subjects)
+	}
+	if want := `Manually set licenses to "mit" (source: answer).`; set.Text != want {
+		t.Errorf("text = %q, want %q", set.Text, want)
+	}
+	removed := got[2]
+	if removed.Subjects != nil {
+		t.Errorf("subjects without bom-ref = %v", removed.Subjects)
+	}
+	if !strings.HasPrefix(removed.Text, `Manually removed description of dataset "imdb" (was xxx`) || !strings.HasSuffix(removed.Text, "…).") {
+		t.Errorf("text = %q", removed.Text)
+	}
+}
+
+func TestRemoved(t *testing.T) {
+	comp := &cdx.Component{
+		Name:        "m",
+		Description: "old description",
+		Properties: &[]cdx.Property{
+			{Name: "keep", Value: "1"},
+			{Name: "drop", Value: "2"},
+		},
+	}
+	before := Snapshot(comp)
+	comp.Description = ""
+	comp.Properties = &[]cdx.Property{{Name: "keep", Value: "1"}}
+
+	want := `description: "old description"; properties[drop]: {"name":"drop","value":"2"}`
+	if got := Removed(before, comp); got != want {
+		t.Errorf("Removed = %q, want %q", got, want)
+	}
+	if got := Removed(Snapshot(comp), comp); got != "" {
+		t.Errorf("Removed without change = %q", got)
+	}
+}
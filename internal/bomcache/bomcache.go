@@ -0,0 +1,148 @@
+// Package bomcache implements a content-addressed on-disk cache of generated.
+// AIBOMs.
+//.
+// Entries are keyed by what determines the generated document: the Hugging.
+// Face model ID, the repository revision SHA reported by the Hub API, the CLI.
+// version, a free-form profile string describing generation options and the.
+// scan discovery the BOM was built for. When none of these changed since the.
+// previous run, the cached BOM is reused and all remaining Hub requests and.
+// the build are skipped. The revisions of the datasets and base models the.
+// BOM lists are not part of the key, so their components are only refreshed.
+// when the model changes.
+package bomcache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+// Key identifies a cache entry.
+type Key struct {
+	ModelID    string
+	Revision   string
+	CLIVersion string
+	// Profile captures generation options that influence the output.
+	// (e.g. whether the security scan was skipped).
+	Profile string
+	// Discovery identifies the scan discovery the BOM was built for, whose.
+	// path, evidence and other fields end up in the BOM.
+	Discovery string
+}
+
+// Valid reports whether the key is specific enough to be cached. Without a.
+// revision SHA there is no way to tell whether the model changed.
+func (k Key) Valid() bool {
+	return strings.TrimSpace(k.ModelID) != "" && strings.TrimSpace(k.Revision) != ""
+}
+
+// Digest returns the content address of the key.
+func (k Key) Digest() string {
+	h := sha256.New()
+	for _, part := range []string{k.ModelID, k.Revision, k.CLIVersion, k.Profile} {
+		h.Write([]byte(strings.ToLower(strings.TrimSpace(part))))
+		h.Write([]byte{0})
+	}
+	// Paths in the discovery are case-sensitive.
+	h.Write([]byte(k.Discovery))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Cache stores BOMs as CycloneDX JSON files under Dir.
+type Cache struct {
+	Dir string
+
+	hits, misses atomic.Int64
+}
+
+// Stats returns the number of cache hits and misses since the cache was opened.
+func (c *Cache) Stats() (hits, misses int64) {
+	if c == nil {
+		return 0, 0
+	}
+	return c.hits.Load(), c.misses.Load()
+}
+
+// New returns a cache rooted at dir. An empty dir selects DefaultDir.
+func New(dir string) (*Cache, error) {
+	if strings.TrimSpace(dir) == "" {
+		d, err := DefaultDir()
+		if err != nil {
+			return nil, err
+		}
+		dir = d
+	}
+	return &Cache{Dir: dir}, nil
+}
+
+// DefaultDir returns the per-user cache directory for generated BOMs.
+func DefaultDir() (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "aibomgen-cli", "boms"), nil
+}
+
+func (c *Cache) path(k Key) string {
+	d := k.Digest()
+	return filepath.Join(c.Dir, d[:2], d+".json")
+}
+
+// Get returns the cached BOM for k, if any. Unreadable or corrupt entries are.
+// treated as misses.
+func (c *Cache) Get(k Key) (*cdx.BOM, bool) {
+	if c == nil || !k.Valid() {
+		return nil, false
+	}
+	data, err := os.ReadFile(c.path(k))
+	if err != nil {
+		c.misses.Add(1)
+		return nil, false
+	}
+	bom := new(cdx.BOM)
+	if err := cdx.NewBOMDecoder(bytes.NewReader(data), cdx.BOMFileFormatJSON).Decode(bom); err != nil {
+		c.misses.Add(1)
+		return nil, false
+	}
+	c.hits.Add(1)
+	return bom, true
+}
+
+// Put stores bom under k. The file is written atomically so concurrent runs.
+// never observe a partially written entry.
+func (c *Cache) Put(k Key, bom *cdx.BOM) error {
+	if c == nil || bom == nil || !k.Valid() {
+		return nil
+	}
+	dst := c.path(k)
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := cdx.NewBOMEncoder(&buf, cdx.BOMFileFormatJSON).Encode(bom); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(dst), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), dst)
+}
@@ -0,0 +1,60 @@
+package bomcache
+
+import (
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+func TestCachePutGet(t *testing.T) {
+	c, err := New(t.TempDir())
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	key := Key{ModelID: "org/model", Revision: "abc123", CLIVersion: "v1.0.0"}
+	if _, ok := c.Get(key); ok {
+		t.Fatalf("expected miss on empty cache")
+	}
+
+	bom := cdx.NewBOM()
+	bom.SerialNumber = "urn:uuid:11111111-2222-3333-4444-555555555555"
+	bom.Metadata = &cdx.Metadata{Component: &cdx.Component{Type: cdx.ComponentTypeMachineLearningModel, Name: "org/model"}}
+	if err := c.Put(key, bom); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	got, ok := c.Get(key)
+	if !ok {
+		t.Fatalf("expected hit after Put")
+	}
+	if got.SerialNumber != bom.SerialNumber || got.Metadata.Component.Name != "org/model" {
+		t.Fatalf("unexpected cached BOM: %+v", got)
+	}
+
+	for _, other := range []Key{
+		{ModelID: "org/model", Revision: "def456", CLIVersion: "v1.0.0"},
+		{ModelID: "org/model", Revision: "abc123", CLIVersion: "v1.1.0"},
+		{ModelID: "org/model", Revision: "abc123", CLIVersion: "v1.0.0", Profile: "no-security-scan"},
+	} {
+		if _, ok := c.Get(other); ok {
+			t.Fatalf("expected miss for %+v", other)
+		}
+	}
+}
+
+func TestCacheSkipsKeysWithoutRevision(t *testing.T) {
+	c := &Cache{Dir: t.TempDir()}
+	key := Key{ModelID: "org/model"}
+	if err := c.Put(key, cdx.NewBOM()); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if _, ok := c.Get(key); ok {
+		t.Fatalf("keys without a revision must not be cached")
+	}
+
+	var nilCache *Cache
+	if _, ok := nilCache.Get(Key{ModelID: "a", Revision: "b"}); ok {
+		t.Fatalf("nil cache must always miss")
+	}
+}
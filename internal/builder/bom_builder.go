@@ -1,9 +1,12 @@
 package builder
 
 import (
+	"strconv"
 	"strings"
 
 	"github.com/idlab-discover/aibomgen-cli/internal/metadata"
+	"github.com/idlab-discover/aibomgen-cli/internal/taxonomy"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/scanner"
 
 	cdx "github.com/CycloneDX/cyclonedx-go"
 )
@@ -32,6 +35,9 @@ func (b BOMBuilder) Build(ctx BuildContext) (*cdx.BOM, error) {
 	if err := AddMetaTools(bom, "", GetAIBoMGenVersion()); err != nil {
 		return nil, err
 	}
+	if err := AddMetaTaxonomyVersion(bom); err != nil {
+		return nil, err
+	}
 
 	// Apply registry exactly once (no duplication).
 	src := metadata.Source{
@@ -40,6 +46,13 @@ func (b BOMBuilder) Build(ctx BuildContext) (*cdx.BOM, error) {
 		HF:           ctx.HF,
 		Readme:       ctx.Readme,
 		SecurityTree: ctx.SecurityTree,
+		LicenseFile:  ctx.LicenseFile,
+		Config:       ctx.Config,
+		Kaggle:       ctx.Kaggle,
+		Ollama:       ctx.Ollama,
+		GGUF:         ctx.GGUF,
+		ONNX:         ctx.ONNX,
+		Safetensors:  ctx.Safetensors,
 	}
 	tgt := metadata.Target{
 		BOM:                       bom,
@@ -53,8 +66,19 @@ func (b BOMBuilder) Build(ctx BuildContext) (*cdx.BOM, error) {
 		metadata.ApplyFromSources(spec, src, tgt)
 	}
 
+	// Weights installed in a Python environment carry the version of the.
+	// package or cache snapshot they came from.
+	if v := strings.TrimSpace(ctx.Scan.Version); v != "" && comp.Version == "" {
+		comp.Version = v
+	}
+
 	// Now properties, hashes and tags are populated — compute deterministic PURL and BOMRef.
-	AddComponentPurl(comp)
+	// Locally vendored weight files, adapters, external weight URLs, Kaggle.
+	// resources and models of other providers have no Hugging Face identity,.
+	// so they keep a UUID BOMRef instead of a pkg:huggingface PURL.
+	if hasHubIdentity(ctx.Scan) {
+		AddComponentPurl(comp)
+	}
 	AddComponentBOMRef(comp)
 
 	// Inject security scan findings as Component.Properties and BOM.Vulnerabilities.
@@ -63,6 +87,31 @@ func (b BOMBuilder) Build(ctx BuildContext) (*cdx.BOM, error) {
 	return bom, nil
 }
 
+// hasHubIdentity reports whether a discovery names a Hugging Face repository.
+func hasHubIdentity(d scanner.Discovery) bool {
+	switch d.Type {
+	case scanner.DiscoveryTypeModelFile, scanner.DiscoveryTypeAdapter, scanner.DiscoveryTypeWeightURL, scanner.DiscoveryTypeDataFile,
+		scanner.DiscoveryTypeProviderModel, scanner.DiscoveryTypeKaggle, scanner.DiscoveryTypeTrackedArtifact:
+		return false
+	}
+	return true
+}
+
+// addDataFileEvidence records the discovery of a dataset file as properties.
+func addDataFileEvidence(comp *cdx.Component, d scanner.Discovery) {
+	for _, p := range [][2]string{
+		{taxonomy.DiscoveryType, d.Type},
+		{taxonomy.DiscoveryEvidence, d.Evidence},
+		{taxonomy.DiscoveryPath, d.Path},
+		{taxonomy.FileFormat, d.Format},
+	} {
+		comp.Properties = taxonomy.Set(comp.Properties, p[0], p[1])
+	}
+	if d.Size > 0 {
+		comp.Properties = taxonomy.Set(comp.Properties, taxonomy.FileSize, strconv.FormatInt(d.Size, 10))
+	}
+}
+
 // BuildDataset builds a dataset component into BOM.components.
 func (b BOMBuilder) BuildDataset(ctx DatasetBuildContext) (*cdx.Component, error) {
 
@@ -74,6 +123,7 @@ func (b BOMBuilder) BuildDataset(ctx DatasetBuildContext) (*cdx.Component, error
 		Scan:      ctx.Scan,
 		HF:        ctx.HF,
 		Readme:    ctx.Readme,
+		Kaggle:    ctx.Kaggle,
 	}
 	tgt := metadata.DatasetTarget{
 		Component:                 comp,
@@ -85,7 +135,17 @@ func (b BOMBuilder) BuildDataset(ctx DatasetBuildContext) (*cdx.Component, error
 		metadata.ApplyDatasetFromSources(spec, src, tgt)
 	}
 
-	AddComponentPurl(comp)
+	// Dataset artifacts tracked by DVC, Git LFS or an experiment tracker and.
+	// Kaggle datasets keep a UUID BOMRef and record where they were found.
+	switch ctx.Scan.Type {
+	case scanner.DiscoveryTypeDataFile, scanner.DiscoveryTypeKaggle, scanner.DiscoveryTypeTrackedArtifact:
+		if b.Opts.IncludeEvidenceProperties {
+			addDataFileEvidence(comp, ctx.Scan)
+		}
+	}
+	if hasHubIdentity(ctx.Scan) {
+		AddComponentPurl(comp)
+	}
 	AddComponentBOMRef(comp)
 	return comp, nil
 }
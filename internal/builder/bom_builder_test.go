@@ -2,9 +2,12 @@ package builder
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 
 	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/internal/fetcher"
+	"github.com/idlab-discover/aibomgen-cli/internal/taxonomy"
 	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/scanner"
 )
 
@@ -81,6 +84,33 @@ func TestBOMBuilder_Build(t *testing.T) {
 	}
 }
 
+func TestBOMBuilder_Build_SetsModelClassifier(t *testing.T) {
+	b := NewBOMBuilder(DefaultOptions())
+	bom, err := b.Build(BuildContext{
+		ModelID: "org/diffusion-model",
+		HF:      &fetcher.ModelAPIResponse{PipelineTag: "text-to-image", LibraryName: "diffusers"},
+	})
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	comp := bom.Metadata.Component
+	if comp.Type != cdx.ComponentTypeMachineLearningModel {
+		t.Fatalf("expected component type MachineLearningModel, got %v", comp.Type)
+	}
+	if comp.Properties == nil {
+		t.Fatalf("expected classifier property")
+	}
+	for _, p := range *comp.Properties {
+		if p.Name == "aibomgen:classifier" {
+			if p.Value != "diffusion" {
+				t.Fatalf("classifier = %q, want diffusion", p.Value)
+			}
+			return
+		}
+	}
+	t.Fatalf("expected aibomgen:classifier property, got %v", *comp.Properties)
+}
+
 func TestBOMBuilder_BuildDataset(t *testing.T) {
 	type fields struct {
 		Opts Options
@@ -165,3 +195,28 @@ func Test_buildDatasetComponent(t *testing.T) {
 		})
 	}
 }
+
+func TestBOMBuilder_BuildDatasetFromDataFile(t *testing.T) {
+	b := BOMBuilder{Opts: DefaultOptions()}
+	comp, err := b.BuildDataset(DatasetBuildContext{DatasetID: "train.parquet", Scan: scanner.Discovery{
+		Name:          "train.parquet",
+		Type:          scanner.DiscoveryTypeDataFile,
+		Path:          "data/train.parquet",
+		Format:        "parquet",
+		Size:          123,
+		Hash:          "a304afb96060aad90176268345e10355",
+		HashAlgorithm: scanner.HashAlgorithmMD5,
+	}})
+	if err != nil {
+		t.Fatalf("BuildDataset: %v", err)
+	}
+	if comp.PackageURL != "" || !strings.HasPrefix(comp.BOMRef, "urn:uuid:") {
+		t.Fatalf("expected a UUID BOMRef without purl, got %q / %q", comp.PackageURL, comp.BOMRef)
+	}
+	if comp.Hashes == nil || len(*comp.Hashes) != 1 || (*comp.Hashes)[0].Algorithm != cdx.HashAlgoMD5 {
+		t.Fatalf("hashes = %+v", comp.Hashes)
+	}
+	if !taxonomy.Has(comp.Properties, taxonomy.FileFormat) {
+		t.Fatalf("expected file format property, got %+v", comp.Properties)
+	}
+}
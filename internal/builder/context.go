@@ -11,6 +11,13 @@ type BuildContext struct {
 	HF           *fetcher.ModelAPIResponse
 	Readme       *fetcher.ModelReadmeCard
 	SecurityTree []fetcher.SecurityFileEntry
+	LicenseFile  *fetcher.LicenseFile
+	Config       *fetcher.ModelConfig
+	Kaggle       *fetcher.KaggleModel
+	Ollama       *fetcher.OllamaModel
+	GGUF         *fetcher.GGUFHeader
+	ONNX         *fetcher.ONNXModel
+	Safetensors  *fetcher.SafetensorsHeader
 }
 
 // DatasetBuildContext for dataset component building.
@@ -19,6 +26,7 @@ type DatasetBuildContext struct {
 	Scan      scanner.Discovery
 	HF        *fetcher.DatasetAPIResponse
 	Readme    *fetcher.DatasetReadmeCard
+	Kaggle    *fetcher.KaggleDataset
 }
 
 type Options struct {
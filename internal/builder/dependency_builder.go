@@ -1,11 +1,19 @@
 package builder
 
-import cdx "github.com/CycloneDX/cyclonedx-go"
+import (
+	"slices"
+	"strings"
+
+	"github.com/idlab-discover/aibomgen-cli/internal/taxonomy"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
 
 // AddDependencies builds a minimal dependency graph for the BOM where the.
-// model (metadata component) depends on all dataset components. The function.
+// model (metadata component) depends on all dataset components and on any.
+// other model components, such as the base model of an adapter. The function.
 // creates one dependency entry for the model (with a dependsOn list) and a.
-// dependency entry for each dataset (with no dependsOn entries) — matching.
+// dependency entry for each dependency (with no dependsOn entries) — matching.
 // the example structure used elsewhere in the codebase.
 func AddDependencies(bom *cdx.BOM) {
 	if bom == nil {
@@ -21,33 +29,253 @@ func AddDependencies(bom *cdx.BOM) {
 		return
 	}
 
-	// Collect dataset BOMRefs.
-	var datasetRefs []string
+	// Collect dataset and model BOMRefs.
+	var depRefs []string
 	if bom.Components != nil {
 		for _, comp := range *bom.Components {
-			if comp.Type == cdx.ComponentTypeData && comp.BOMRef != "" {
-				datasetRefs = append(datasetRefs, comp.BOMRef)
+			if (comp.Type == cdx.ComponentTypeData || comp.Type == cdx.ComponentTypeMachineLearningModel) && comp.BOMRef != "" {
+				depRefs = append(depRefs, comp.BOMRef)
 			}
 		}
 	}
 
-	// Build dependencies slice: model entry (with dependsOn) + dataset entries.
-	deps := make([]cdx.Dependency, 0, 1+len(datasetRefs))
+	// Build dependencies slice: model entry (with dependsOn) + dependency entries.
+	deps := make([]cdx.Dependency, 0, 1+len(depRefs))
 
-	// Model dependency (depends on datasets if present).
+	// Model dependency (depends on datasets and models if present).
 	modelDep := cdx.Dependency{Ref: modelRef}
-	if len(datasetRefs) > 0 {
+	if len(depRefs) > 0 {
 		// copy to avoid referencing underlying slice later.
-		cp := make([]string, len(datasetRefs))
-		copy(cp, datasetRefs)
+		cp := make([]string, len(depRefs))
+		copy(cp, depRefs)
 		modelDep.Dependencies = &cp
 	}
 	deps = append(deps, modelDep)
 
-	// Add dataset nodes (no further dependencies).
-	for _, ds := range datasetRefs {
-		deps = append(deps, cdx.Dependency{Ref: ds})
+	// Add dependency nodes (no further dependencies).
+	for _, ref := range depRefs {
+		deps = append(deps, cdx.Dependency{Ref: ref})
+	}
+
+	bom.Dependencies = &deps
+}
+
+// AddBaseModel adds base as a component of an adapter BOM and records it as.
+// the ancestor in the pedigree of the adapter (metadata component). The.
+// ancestor copy carries no BOMRef so references in the BOM stay unique; call.
+// [AddDependencies] afterwards to link the adapter to the base model.
+func AddBaseModel(bom *cdx.BOM, base *cdx.Component) {
+	if bom == nil || bom.Metadata == nil || bom.Metadata.Component == nil || base == nil {
+		return
+	}
+
+	if bom.Components == nil {
+		bom.Components = &[]cdx.Component{}
+	}
+	*bom.Components = append(*bom.Components, *base)
+
+	addAncestor(bom.Metadata.Component, base)
+}
+
+// AddBaseModelChain adds the base-model chain of a model as components of.
+// bom: chain[0] is the model's direct base model, chain[1] the base model of.
+// chain[0], and so on. The metadata component and every link record the next.
+// link as the ancestor in their pedigree, and the dependency graph is rebuilt.
+// with [AddDependencies] so that the model depends on chain[0] (and its.
+// datasets) and every link depends on the next one.
+func AddBaseModelChain(bom *cdx.BOM, chain []*cdx.Component) {
+	if bom == nil || bom.Metadata == nil || bom.Metadata.Component == nil || len(chain) == 0 {
+		return
+	}
+
+	if bom.Components == nil {
+		bom.Components = &[]cdx.Component{}
+	}
+	addAncestor(bom.Metadata.Component, chain[0])
+	for i, link := range chain {
+		comp := *link
+		if i+1 < len(chain) {
+			addAncestor(&comp, chain[i+1])
+		}
+		*bom.Components = append(*bom.Components, comp)
+	}
+
+	AddDependencies(bom)
+	if bom.Dependencies == nil {
+		return
+	}
+
+	// The model only depends on its direct base model; every other link is.
+	// reached through the chain.
+	next := make(map[string]string)
+	indirect := make(map[string]bool)
+	for i, link := range chain {
+		if link.BOMRef == "" {
+			continue
+		}
+		if i > 0 {
+			indirect[link.BOMRef] = true
+		}
+		if i+1 < len(chain) && chain[i+1].BOMRef != "" {
+			next[link.BOMRef] = chain[i+1].BOMRef
+		}
 	}
+	modelRef := bom.Metadata.Component.BOMRef
+	for i := range *bom.Dependencies {
+		dep := &(*bom.Dependencies)[i]
+		if dep.Ref == modelRef && dep.Dependencies != nil {
+			kept := make([]string, 0, len(*dep.Dependencies))
+			for _, ref := range *dep.Dependencies {
+				if !indirect[ref] {
+					kept = append(kept, ref)
+				}
+			}
+			dep.Dependencies = &kept
+		}
+		if ref, ok := next[dep.Ref]; ok {
+			dep.Dependencies = &[]string{ref}
+		}
+	}
+}
 
+// addAncestor records base as an ancestor in the pedigree of comp. The.
+// ancestor copy carries no BOMRef so references in the BOM stay unique.
+func addAncestor(comp *cdx.Component, base *cdx.Component) {
+	if comp.Pedigree == nil {
+		comp.Pedigree = &cdx.Pedigree{}
+	}
+	if comp.Pedigree.Ancestors == nil {
+		comp.Pedigree.Ancestors = &[]cdx.Component{}
+	}
+	*comp.Pedigree.Ancestors = append(*comp.Pedigree.Ancestors, cdx.Component{
+		Type:       base.Type,
+		Name:       base.Name,
+		Version:    base.Version,
+		PackageURL: base.PackageURL,
+	})
+}
+
+// AddGeneratingModels links every synthetic dataset component of bom to the.
+// model that generated it, named by its aibomgen:data:generatingModel.
+// property: the dataset depends on the model component of that name, which.
+// is added with just its name and purl when the BOM has none. A dataset.
+// generated by the metadata component itself is not linked, so that the.
+// dependency graph stays acyclic. Call it after [AddDependencies] and.
+// [AddBaseModelChain], which do not know about generating models.
+func AddGeneratingModels(bom *cdx.BOM) {
+	if bom == nil || bom.Components == nil {
+		return
+	}
+
+	var modelRef string
+	models := make(map[string]string) // lowercase model name -> BOMRef
+	if bom.Metadata != nil && bom.Metadata.Component != nil {
+		modelRef = bom.Metadata.Component.BOMRef
+		models[strings.ToLower(bom.Metadata.Component.Name)] = modelRef
+	}
+	for _, comp := range *bom.Components {
+		if comp.Type == cdx.ComponentTypeMachineLearningModel && comp.BOMRef != "" {
+			models[strings.ToLower(comp.Name)] = comp.BOMRef
+		}
+	}
+
+	var added []cdx.Component
+	links := make(map[string]string) // dataset BOMRef -> generating model BOMRef
+	var order []string
+	for _, comp := range *bom.Components {
+		id, _ := taxonomy.Get(comp.Properties, taxonomy.DataGeneratingModel)
+		if comp.Type != cdx.ComponentTypeData || comp.BOMRef == "" || id == "" {
+			continue
+		}
+		ref, ok := models[strings.ToLower(id)]
+		if !ok {
+			gen := cdx.Component{Type: cdx.ComponentTypeMachineLearningModel, Name: id}
+			AddComponentPurl(&gen)
+			AddComponentBOMRef(&gen)
+			added = append(added, gen)
+			ref = gen.BOMRef
+			models[strings.ToLower(id)] = ref
+		}
+		if ref == "" || ref == modelRef {
+			continue
+		}
+		links[comp.BOMRef] = ref
+		order = append(order, comp.BOMRef)
+	}
+	*bom.Components = append(*bom.Components, added...)
+	if len(links) == 0 {
+		return
+	}
+
+	if bom.Dependencies == nil {
+		bom.Dependencies = &[]cdx.Dependency{}
+	}
+	deps := bom.Dependencies
+	linked := make(map[string]bool)
+	for i := range *deps {
+		dep := &(*deps)[i]
+		if ref, ok := links[dep.Ref]; ok {
+			if dep.Dependencies == nil {
+				dep.Dependencies = &[]string{}
+			}
+			if !slices.Contains(*dep.Dependencies, ref) {
+				*dep.Dependencies = append(*dep.Dependencies, ref)
+			}
+			linked[dep.Ref] = true
+		}
+	}
+	for _, dsRef := range order {
+		if !linked[dsRef] {
+			*deps = append(*deps, cdx.Dependency{Ref: dsRef, Dependencies: &[]string{links[dsRef]}})
+		}
+	}
+	for _, gen := range added {
+		*deps = append(*deps, cdx.Dependency{Ref: gen.BOMRef})
+	}
+}
+
+// AddApplicationDependencies makes the application (metadata component).
+// depend on every machine-learning model component and every service in the.
+// BOM. The entry is placed first in the dependency graph; existing model and.
+// dataset entries are kept as they are.
+func AddApplicationDependencies(bom *cdx.BOM) {
+	if bom == nil || bom.Metadata == nil || bom.Metadata.Component == nil {
+		return
+	}
+	appRef := bom.Metadata.Component.BOMRef
+	if appRef == "" {
+		return
+	}
+
+	var refs []string
+	if bom.Components != nil {
+		for _, comp := range *bom.Components {
+			if comp.Type == cdx.ComponentTypeMachineLearningModel && comp.BOMRef != "" {
+				refs = append(refs, comp.BOMRef)
+			}
+		}
+	}
+
+	if bom.Services != nil {
+		for _, svc := range *bom.Services {
+			if svc.BOMRef != "" {
+				refs = append(refs, svc.BOMRef)
+			}
+		}
+	}
+
+	appDep := cdx.Dependency{Ref: appRef}
+	if len(refs) > 0 {
+		appDep.Dependencies = &refs
+	}
+
+	deps := []cdx.Dependency{appDep}
+	if bom.Dependencies != nil {
+		for _, d := range *bom.Dependencies {
+			if d.Ref != appRef {
+				deps = append(deps, d)
+			}
+		}
+	}
 	bom.Dependencies = &deps
 }
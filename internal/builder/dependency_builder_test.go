@@ -1,8 +1,11 @@
 package builder
 
 import (
+	"reflect"
 	"testing"
 
+	"github.com/idlab-discover/aibomgen-cli/internal/taxonomy"
+
 	cdx "github.com/CycloneDX/cyclonedx-go"
 )
 
@@ -141,3 +144,156 @@ func TestAddDependencies(t *testing.T) {
 		})
 	}
 }
+
+func TestAddApplicationDependencies(t *testing.T) {
+	b := cdx.NewBOM()
+	b.Metadata = &cdx.Metadata{Component: &cdx.Component{BOMRef: "app-ref", Type: cdx.ComponentTypeApplication}}
+	comps := []cdx.Component{
+		{BOMRef: "model-1", Type: cdx.ComponentTypeMachineLearningModel},
+		{BOMRef: "dataset-1", Type: cdx.ComponentTypeData},
+		{BOMRef: "model-2", Type: cdx.ComponentTypeMachineLearningModel},
+	}
+	b.Components = &comps
+	existing := []cdx.Dependency{
+		{Ref: "model-1", Dependencies: &[]string{"dataset-1"}},
+		{Ref: "dataset-1"},
+		{Ref: "model-2"},
+	}
+	b.Dependencies = &existing
+
+	AddApplicationDependencies(b)
+
+	deps := *b.Dependencies
+	if len(deps) != 4 {
+		t.Fatalf("len(bom.Dependencies) = %d, want 4", len(deps))
+	}
+	if deps[0].Ref != "app-ref" {
+		t.Fatalf("first dependency = %q, want app-ref", deps[0].Ref)
+	}
+	if deps[0].Dependencies == nil || !reflect.DeepEqual(*deps[0].Dependencies, []string{"model-1", "model-2"}) {
+		t.Fatalf("application dependsOn = %v, want [model-1 model-2]", deps[0].Dependencies)
+	}
+	if deps[1].Ref != "model-1" || deps[1].Dependencies == nil || (*deps[1].Dependencies)[0] != "dataset-1" {
+		t.Fatalf("model dependency not preserved: %+v", deps[1])
+	}
+
+	// Calling it again must not duplicate the application entry.
+	AddApplicationDependencies(b)
+	if got := len(*b.Dependencies); got != 4 {
+		t.Fatalf("len(bom.Dependencies) after second call = %d, want 4", got)
+	}
+
+	// No-ops.
+	AddApplicationDependencies(nil)
+	noRef := cdx.NewBOM()
+	noRef.Metadata = &cdx.Metadata{Component: &cdx.Component{Type: cdx.ComponentTypeApplication}}
+	AddApplicationDependencies(noRef)
+	if noRef.Dependencies != nil {
+		t.Fatalf("expected Dependencies to remain nil when application has no BOM-ref")
+	}
+}
+
+func TestAddBaseModel(t *testing.T) {
+	bom := &cdx.BOM{Metadata: &cdx.Metadata{Component: &cdx.Component{BOMRef: "adapter", Name: "sql-lora"}}}
+	base := &cdx.Component{
+		BOMRef:     "pkg:huggingface/org/base",
+		Type:       cdx.ComponentTypeMachineLearningModel,
+		Name:       "org/base",
+		PackageURL: "pkg:huggingface/org/base",
+	}
+
+	AddBaseModel(bom, base)
+	AddDependencies(bom)
+
+	if bom.Components == nil || len(*bom.Components) != 1 || (*bom.Components)[0].BOMRef != base.BOMRef {
+		t.Fatalf("base model not added as component: %+v", bom.Components)
+	}
+	anc := bom.Metadata.Component.Pedigree.Ancestors
+	if anc == nil || len(*anc) != 1 || (*anc)[0].PackageURL != base.PackageURL || (*anc)[0].BOMRef != "" {
+		t.Fatalf("unexpected pedigree ancestors: %+v", anc)
+	}
+	want := []cdx.Dependency{
+		{Ref: "adapter", Dependencies: &[]string{base.BOMRef}},
+		{Ref: base.BOMRef},
+	}
+	if !reflect.DeepEqual(*bom.Dependencies, want) {
+		t.Fatalf("dependencies = %+v, want %+v", *bom.Dependencies, want)
+	}
+}
+
+func TestAddBaseModelChain(t *testing.T) {
+	bom := &cdx.BOM{
+		Metadata: &cdx.Metadata{Component: &cdx.Component{BOMRef: "model", Name: "org/sql-llama"}},
+		Components: &[]cdx.Component{
+			{BOMRef: "data", Type: cdx.ComponentTypeData, Name: "org/sql"},
+		},
+	}
+	link := func(name string) *cdx.Component {
+		return &cdx.Component{BOMRef: "pkg:huggingface/" + name, Type: cdx.ComponentTypeMachineLearningModel, Name: name, PackageURL: "pkg:huggingface/" + name}
+	}
+	instruct, base := link("meta-llama/Llama-3.1-8B-Instruct"), link("meta-llama/Llama-3.1-8B")
+
+	AddBaseModelChain(bom, []*cdx.Component{instruct, base})
+
+	if len(*bom.Components) != 3 {
+		t.Fatalf("expected the dataset and two ancestors, got %+v", *bom.Components)
+	}
+	anc := bom.Metadata.Component.Pedigree.Ancestors
+	if anc == nil || len(*anc) != 1 || (*anc)[0].PackageURL != instruct.PackageURL {
+		t.Fatalf("model pedigree = %+v, want the direct base model", anc)
+	}
+	got := (*bom.Components)[1]
+	if got.Pedigree == nil || (*got.Pedigree.Ancestors)[0].PackageURL != base.PackageURL || (*got.Pedigree.Ancestors)[0].BOMRef != "" {
+		t.Fatalf("first link pedigree = %+v, want the second link by purl", got.Pedigree)
+	}
+	if last := (*bom.Components)[2]; last.Pedigree != nil {
+		t.Errorf("last link must have no ancestors: %+v", last.Pedigree)
+	}
+	want := []cdx.Dependency{
+		{Ref: "model", Dependencies: &[]string{"data", instruct.BOMRef}},
+		{Ref: "data"},
+		{Ref: instruct.BOMRef, Dependencies: &[]string{base.BOMRef}},
+		{Ref: base.BOMRef},
+	}
+	if !reflect.DeepEqual(*bom.Dependencies, want) {
+		t.Fatalf("dependencies = %+v, want %+v", *bom.Dependencies, want)
+	}
+}
+
+func TestAddGeneratingModels(t *testing.T) {
+	generated := func(ref, model string) cdx.Component {
+		return cdx.Component{BOMRef: ref, Type: cdx.ComponentTypeData, Name: ref, Properties: &[]cdx.Property{{Name: taxonomy.DataGeneratingModel, Value: model}}}
+	}
+	bom := &cdx.BOM{
+		Metadata: &cdx.Metadata{Component: &cdx.Component{BOMRef: "model", Name: "org/student"}},
+		Components: &[]cdx.Component{
+			generated("distilled", "Org/Teacher"),
+			generated("generated", "other/generator"),
+			generated("self", "org/student"),
+			{BOMRef: "collected", Type: cdx.ComponentTypeData, Name: "collected"},
+			{BOMRef: "pkg:huggingface/org/teacher", Type: cdx.ComponentTypeMachineLearningModel, Name: "org/teacher"},
+		},
+	}
+	AddDependencies(bom)
+	AddGeneratingModels(bom)
+
+	if len(*bom.Components) != 6 {
+		t.Fatalf("expected the generator to be added once, got %+v", *bom.Components)
+	}
+	gen := (*bom.Components)[5]
+	if gen.Type != cdx.ComponentTypeMachineLearningModel || gen.Name != "other/generator" || gen.BOMRef != "pkg:huggingface/other/generator" {
+		t.Fatalf("generator component = %+v", gen)
+	}
+	want := []cdx.Dependency{
+		{Ref: "model", Dependencies: &[]string{"distilled", "generated", "self", "collected", "pkg:huggingface/org/teacher"}},
+		{Ref: "distilled", Dependencies: &[]string{"pkg:huggingface/org/teacher"}},
+		{Ref: "generated", Dependencies: &[]string{gen.BOMRef}},
+		{Ref: "self"},
+		{Ref: "collected"},
+		{Ref: "pkg:huggingface/org/teacher"},
+		{Ref: gen.BOMRef},
+	}
+	if !reflect.DeepEqual(*bom.Dependencies, want) {
+		t.Fatalf("dependencies = %+v, want %+v", *bom.Dependencies, want)
+	}
+}
@@ -1,11 +1,14 @@
 package builder
 
 import (
+	"bytes"
+	"encoding/json"
 	"strings"
 	"time"
 
 	"github.com/CycloneDX/cyclonedx-go"
 	"github.com/google/uuid"
+	"github.com/idlab-discover/aibomgen-cli/internal/taxonomy"
 )
 
 // AddMetaSerialNumber sets a serial number if not already set.
@@ -16,6 +19,73 @@ func AddMetaSerialNumber(bom *cyclonedx.BOM) error {
 	return nil
 }
 
+// SetMetaLifecycle records phase as the lifecycle phase the BOM describes,.
+// replacing any phase set before.
+func SetMetaLifecycle(bom *cyclonedx.BOM, phase cyclonedx.LifecyclePhase) {
+	if bom == nil || phase == "" {
+		return
+	}
+	if bom.Metadata == nil {
+		bom.Metadata = &cyclonedx.Metadata{}
+	}
+	bom.Metadata.Lifecycles = &[]cyclonedx.Lifecycle{{Phase: phase}}
+}
+
+// ChainBOMVersion continues the revision history of prev, an earlier BOM for.
+// the same subject: bom takes over the serial number of prev and gets the next.
+// version, or the same version and timestamp when nothing else changed. BOMs.
+// describing a different metadata component are left alone. It reports.
+// whether bom was chained to prev.
+func ChainBOMVersion(bom, prev *cyclonedx.BOM) bool {
+	if bom == nil || prev == nil || prev.SerialNumber == "" || !sameSubject(bom, prev) {
+		return false
+	}
+	prevVersion := prev.Version
+	if prevVersion < 1 {
+		prevVersion = 1
+	}
+	bom.SerialNumber = prev.SerialNumber
+	if sameContent(bom, prev) {
+		bom.Version = prevVersion
+		if bom.Metadata != nil && prev.Metadata != nil && prev.Metadata.Timestamp != "" {
+			bom.Metadata.Timestamp = prev.Metadata.Timestamp
+		}
+		return true
+	}
+	bom.Version = prevVersion + 1
+	return true
+}
+
+func sameSubject(a, b *cyclonedx.BOM) bool {
+	if a.Metadata == nil || a.Metadata.Component == nil || b.Metadata == nil || b.Metadata.Component == nil {
+		return false
+	}
+	ca, cb := a.Metadata.Component, b.Metadata.Component
+	return ca.Type == cb.Type && strings.EqualFold(strings.TrimSpace(ca.Name), strings.TrimSpace(cb.Name))
+}
+
+// sameContent compares two BOMs ignoring serial number, version and timestamp.
+func sameContent(a, b *cyclonedx.BOM) bool {
+	ja, errA := json.Marshal(withoutRevision(a))
+	jb, errB := json.Marshal(withoutRevision(b))
+	return errA == nil && errB == nil && bytes.Equal(ja, jb)
+}
+
+func withoutRevision(bom *cyclonedx.BOM) cyclonedx.BOM {
+	c := *bom
+	c.SerialNumber = ""
+	c.Version = 0
+	// The spec version depends on how the BOM was encoded, not on its content.
+	c.SpecVersion = 0
+	c.JSONSchema = ""
+	if bom.Metadata != nil {
+		m := *bom.Metadata
+		m.Timestamp = ""
+		c.Metadata = &m
+	}
+	return c
+}
+
 // Generate UUID using google/uuid.
 func generateUUID() string {
 	return uuid.New().String()
@@ -87,6 +157,19 @@ func AddMetaTools(bom *cyclonedx.BOM, toolName string, toolVersion string) error
 	return nil
 }
 
+// AddMetaTaxonomyVersion records the version of the property taxonomy the BOM.
+// follows in bom.metadata.properties, if not already set.
+func AddMetaTaxonomyVersion(bom *cyclonedx.BOM) error {
+	if bom.Metadata == nil {
+		bom.Metadata = &cyclonedx.Metadata{}
+	}
+	if taxonomy.Has(bom.Metadata.Properties, taxonomy.TaxonomyVersion) {
+		return nil
+	}
+	bom.Metadata.Properties = taxonomy.Set(bom.Metadata.Properties, taxonomy.TaxonomyVersion, taxonomy.Version)
+	return nil
+}
+
 // GeneratePurl generates a package URL (purl) for a given kind, id, and version.
 // URL-encode segments.
 func GeneratePurl(kind string, id string, version string) string {
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/internal/taxonomy"
 )
 
 func TestAddMetaSerialNumber(t *testing.T) {
@@ -94,6 +95,22 @@ func TestAddMetaTimestamp(t *testing.T) {
 	}
 }
 
+func TestAddMetaTaxonomyVersion(t *testing.T) {
+	bom := &cyclonedx.BOM{}
+	if err := AddMetaTaxonomyVersion(bom); err != nil {
+		t.Fatalf("AddMetaTaxonomyVersion() error = %v", err)
+	}
+	if got, _ := taxonomy.Get(bom.Metadata.Properties, taxonomy.TaxonomyVersion); got != taxonomy.Version {
+		t.Fatalf("taxonomy version = %q, want %q", got, taxonomy.Version)
+	}
+	if err := AddMetaTaxonomyVersion(bom); err != nil {
+		t.Fatalf("AddMetaTaxonomyVersion() error = %v", err)
+	}
+	if n := len(*bom.Metadata.Properties); n != 1 {
+		t.Fatalf("expected the version to be recorded once, got %d properties", n)
+	}
+}
+
 func TestCurrentTimestampRFC3339(t *testing.T) {
 	tests := []struct {
 		name string
@@ -316,3 +333,43 @@ func TestAddComponentBOMRef(t *testing.T) {
 		})
 	}
 }
+
+func TestChainBOMVersion(t *testing.T) {
+	newBOM := func(desc string) *cyclonedx.BOM {
+		bom := cyclonedx.NewBOM()
+		bom.Metadata = &cyclonedx.Metadata{Component: &cyclonedx.Component{Type: cyclonedx.ComponentTypeMachineLearningModel, Name: "org/model", Description: desc}}
+		_ = AddMetaSerialNumber(bom)
+		_ = AddMetaTimestamp(bom)
+		return bom
+	}
+
+	prev := newBOM("v1")
+	prev.Version = 3
+	prev.Metadata.Timestamp = "2020-01-01T00:00:00Z"
+
+	same := newBOM("v1")
+	if !ChainBOMVersion(same, prev) {
+		t.Fatalf("expected unchanged BOM to be chained")
+	}
+	if same.SerialNumber != prev.SerialNumber || same.Version != 3 || same.Metadata.Timestamp != prev.Metadata.Timestamp {
+		t.Errorf("unchanged BOM: serial %q version %d timestamp %q", same.SerialNumber, same.Version, same.Metadata.Timestamp)
+	}
+
+	changed := newBOM("v2")
+	if !ChainBOMVersion(changed, prev) {
+		t.Fatalf("expected changed BOM to be chained")
+	}
+	if changed.SerialNumber != prev.SerialNumber || changed.Version != 4 || changed.Metadata.Timestamp == prev.Metadata.Timestamp {
+		t.Errorf("changed BOM: serial %q version %d timestamp %q", changed.SerialNumber, changed.Version, changed.Metadata.Timestamp)
+	}
+
+	other := newBOM("v1")
+	other.Metadata.Component.Name = "org/other"
+	serial := other.SerialNumber
+	if ChainBOMVersion(other, prev) || other.SerialNumber != serial || other.Version != 1 {
+		t.Errorf("BOM for another model must not be chained")
+	}
+	if ChainBOMVersion(newBOM("v1"), nil) {
+		t.Errorf("expected no chaining without a previous BOM")
+	}
+}
@@ -0,0 +1,59 @@
+package builder
+
+import (
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/internal/fetcher"
+	"github.com/idlab-discover/aibomgen-cli/internal/taxonomy"
+)
+
+// nonModelClassSuffixes mark pipeline parts that hold configuration or.
+// vocabulary files rather than weights.
+var nonModelClassSuffixes = []string{"Scheduler", "Tokenizer", "TokenizerFast", "FeatureExtractor", "ImageProcessor", "Processor"}
+
+// AddPipelineComponents nests the parts of a diffusers pipeline (unet, vae,.
+// text_encoder, ...) under the pipeline, the metadata component of bom. Each.
+// part is named after its subfolder and, when the pipeline has a PURL,.
+// identified by that PURL with the subfolder as subpath.
+// (pkg:huggingface/org/pipeline@rev#unet). Parts holding weights are.
+// machine-learning models; schedulers, tokenizers and processors are files.
+func AddPipelineComponents(bom *cdx.BOM, index *fetcher.ModelIndex) {
+	if bom == nil || bom.Metadata == nil || bom.Metadata.Component == nil || index == nil || len(index.Components) == 0 {
+		return
+	}
+	pipeline := bom.Metadata.Component
+
+	pipeline.Properties = taxonomy.Set(pipeline.Properties, taxonomy.HFPipelineClass, index.ClassName)
+
+	parts := make([]cdx.Component, 0, len(index.Components))
+	for _, pc := range index.Components {
+		part := cdx.Component{
+			Type:        pipelinePartType(pc.Class),
+			Name:        pipeline.Name + "/" + pc.Name,
+			Description: pc.Library + " " + pc.Class,
+		}
+		if pipeline.PackageURL != "" {
+			part.PackageURL = pipeline.PackageURL + "#" + pc.Name
+		}
+		part.Properties = taxonomy.Set(part.Properties, taxonomy.HFPipelineComponent, pc.Name)
+		part.Properties = taxonomy.Set(part.Properties, taxonomy.HFPipelinePartLib, pc.Library)
+		part.Properties = taxonomy.Set(part.Properties, taxonomy.HFPipelinePartClass, pc.Class)
+		AddComponentBOMRef(&part)
+		parts = append(parts, part)
+	}
+
+	if pipeline.Components == nil {
+		pipeline.Components = &[]cdx.Component{}
+	}
+	*pipeline.Components = append(*pipeline.Components, parts...)
+}
+
+func pipelinePartType(class string) cdx.ComponentType {
+	for _, suffix := range nonModelClassSuffixes {
+		if strings.HasSuffix(class, suffix) {
+			return cdx.ComponentTypeFile
+		}
+	}
+	return cdx.ComponentTypeMachineLearningModel
+}
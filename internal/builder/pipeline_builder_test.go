@@ -0,0 +1,44 @@
+package builder
+
+import (
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/internal/fetcher"
+	"github.com/idlab-discover/aibomgen-cli/internal/taxonomy"
+)
+
+func TestAddPipelineComponents(t *testing.T) {
+	bom := &cdx.BOM{Metadata: &cdx.Metadata{Component: &cdx.Component{
+		Name:       "org/sd",
+		PackageURL: "pkg:huggingface/org/sd@abc",
+		BOMRef:     "pkg:huggingface/org/sd@abc",
+	}}}
+	index := &fetcher.ModelIndex{
+		ClassName: "StableDiffusionPipeline",
+		Components: []fetcher.PipelineComponent{
+			{Name: "scheduler", Library: "diffusers", Class: "PNDMScheduler"},
+			{Name: "unet", Library: "diffusers", Class: "UNet2DConditionModel"},
+		},
+	}
+
+	AddPipelineComponents(bom, index)
+
+	pipeline := bom.Metadata.Component
+	if pipeline.Components == nil || len(*pipeline.Components) != 2 {
+		t.Fatalf("expected 2 nested components, got %+v", pipeline.Components)
+	}
+	scheduler, unet := (*pipeline.Components)[0], (*pipeline.Components)[1]
+	if scheduler.Type != cdx.ComponentTypeFile || unet.Type != cdx.ComponentTypeMachineLearningModel {
+		t.Errorf("types = %q, %q", scheduler.Type, unet.Type)
+	}
+	if unet.Name != "org/sd/unet" || unet.PackageURL != "pkg:huggingface/org/sd@abc#unet" || unet.BOMRef != unet.PackageURL {
+		t.Errorf("unexpected unet component: %+v", unet)
+	}
+	if pipeline.Properties == nil || (*pipeline.Properties)[0] != (cdx.Property{Name: taxonomy.HFPipelineClass, Value: "StableDiffusionPipeline"}) {
+		t.Errorf("pipeline class property missing: %+v", pipeline.Properties)
+	}
+	if bom.Components != nil {
+		t.Errorf("parts must be nested, not top-level components: %+v", bom.Components)
+	}
+}
@@ -0,0 +1,70 @@
+package builder
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"io"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/internal/fetcher"
+	"github.com/idlab-discover/aibomgen-cli/internal/taxonomy"
+)
+
+// AddRawMetadata stores the Hugging Face API response and the model card.
+// front matter of the metadata component as received, so that the BOM can be.
+// re-analysed (or a disputed value traced back) without fetching them again.
+// Values are gzip-compressed and base64-encoded; see DecodeRawMetadata.
+func AddRawMetadata(bom *cdx.BOM, resp *fetcher.ModelAPIResponse, readme *fetcher.ModelReadmeCard) error {
+	if bom == nil || bom.Metadata == nil || bom.Metadata.Component == nil {
+		return nil
+	}
+	comp := bom.Metadata.Component
+
+	if resp != nil && len(resp.Raw) > 0 {
+		v, err := encodeRawMetadata(resp.Raw)
+		if err != nil {
+			return err
+		}
+		comp.Properties = taxonomy.Set(comp.Properties, taxonomy.RawHFAPI, v)
+	}
+	if fm := readme.RawFrontMatter(); fm != "" {
+		v, err := encodeRawMetadata([]byte(fm))
+		if err != nil {
+			return err
+		}
+		comp.Properties = taxonomy.Set(comp.Properties, taxonomy.RawReadmeFrontMatter, v)
+	}
+	return nil
+}
+
+// DecodeRawMetadata returns the raw metadata stored in a property value.
+// written by AddRawMetadata.
+func DecodeRawMetadata(value string) ([]byte, error) {
+	compressed, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("decode raw metadata: %w", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, fmt.Errorf("decompress raw metadata: %w", err)
+	}
+	defer zr.Close()
+	return io.ReadAll(zr)
+}
+
+func encodeRawMetadata(data []byte) (string, error) {
+	var buf bytes.Buffer
+	zw, err := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+	if err != nil {
+		return "", err
+	}
+	if _, err := zw.Write(data); err != nil {
+		return "", err
+	}
+	if err := zw.Close(); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
@@ -0,0 +1,52 @@
+package builder
+
+import (
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/internal/fetcher"
+	"github.com/idlab-discover/aibomgen-cli/internal/taxonomy"
+)
+
+func TestAddRawMetadata(t *testing.T) {
+	bom := &cdx.BOM{Metadata: &cdx.Metadata{Component: &cdx.Component{Name: "org/model"}}}
+	resp := &fetcher.ModelAPIResponse{ID: "org/model", Raw: []byte(`{"id":"org/model","downloads":42}`)}
+	readme := &fetcher.ModelReadmeCard{Raw: "---\nlicense: mit\ntags:\n- nlp\n---\n\n# Model\n"}
+
+	if err := AddRawMetadata(bom, resp, readme); err != nil {
+		t.Fatalf("AddRawMetadata: %v", err)
+	}
+
+	props := map[string]string{}
+	for _, p := range *bom.Metadata.Component.Properties {
+		props[p.Name] = p.Value
+	}
+	for name, want := range map[string]string{
+		taxonomy.RawHFAPI:             `{"id":"org/model","downloads":42}`,
+		taxonomy.RawReadmeFrontMatter: "license: mit\ntags:\n- nlp",
+	} {
+		got, err := DecodeRawMetadata(props[name])
+		if err != nil {
+			t.Fatalf("DecodeRawMetadata(%s): %v", name, err)
+		}
+		if string(got) != want {
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestAddRawMetadataSkipsMissing(t *testing.T) {
+	bom := &cdx.BOM{Metadata: &cdx.Metadata{Component: &cdx.Component{Name: "org/model"}}}
+	if err := AddRawMetadata(bom, &fetcher.ModelAPIResponse{}, &fetcher.ModelReadmeCard{Raw: "# No front matter"}); err != nil {
+		t.Fatalf("AddRawMetadata: %v", err)
+	}
+	if bom.Metadata.Component.Properties != nil {
+		t.Fatalf("expected no properties, got %+v", *bom.Metadata.Component.Properties)
+	}
+	if err := AddRawMetadata(nil, nil, nil); err != nil {
+		t.Fatalf("AddRawMetadata(nil): %v", err)
+	}
+	if _, err := DecodeRawMetadata("not base64!"); err == nil {
+		t.Fatalf("expected decode error")
+	}
+}
@@ -0,0 +1,118 @@
+package builder
+
+import (
+	"github.com/idlab-discover/aibomgen-cli/internal/taxonomy"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/scanner"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+// hostedAPI describes a provider of hosted model APIs.
+type hostedAPI struct {
+	organization string // organization offering the API
+	url          string // website of the organization
+	name         string // name of the API
+	endpoint     string // base URL of the API; "" when it depends on the account
+	docs         string // documentation of the models the API serves
+}
+
+// hostedAPIs maps the providers of "hosted-model" discoveries to their API.
+var hostedAPIs = map[string]hostedAPI{
+	scanner.HostedOpenAI: {
+		organization: "OpenAI", url: "https://openai.com", name: "OpenAI API",
+		endpoint: "https://api.openai.com/v1", docs: "https://platform.openai.com/docs/models",
+	},
+	scanner.HostedAnthropic: {
+		organization: "Anthropic", url: "https://www.anthropic.com", name: "Anthropic API",
+		endpoint: "https://api.anthropic.com/v1", docs: "https://docs.anthropic.com/en/docs/about-claude/models",
+	},
+	scanner.HostedAzureOpenAI: {
+		organization: "Microsoft", url: "https://azure.microsoft.com", name: "Azure OpenAI",
+		docs: "https://learn.microsoft.com/azure/ai-services/openai/concepts/models",
+	},
+	scanner.HostedBedrock: {
+		organization: "Amazon Web Services", url: "https://aws.amazon.com", name: "Amazon Bedrock",
+		docs: "https://docs.aws.amazon.com/bedrock/latest/userguide/models-supported.html",
+	},
+}
+
+// HostedService returns the CycloneDX service of a "hosted-model" discovery:.
+// the model (or, for a client without a model, the API) offered by the.
+// provider, an authenticated service outside the trust boundary of the.
+// project. ok is false for other discoveries.
+func HostedService(d scanner.Discovery) (svc cdx.Service, ok bool) {
+	provider, model, ok := scanner.HostedRef(d)
+	if !ok {
+		return cdx.Service{}, false
+	}
+	api, known := hostedAPIs[provider]
+	if !known {
+		api = hostedAPI{organization: provider, name: provider}
+	}
+
+	yes := true
+	svc = cdx.Service{
+		BOMRef:               "urn:uuid:" + generateUUID(),
+		Group:                api.name,
+		Name:                 model,
+		Description:          "Model " + model + " used through the " + api.name + ".",
+		Authenticated:        &yes,
+		CrossesTrustBoundary: &yes,
+	}
+	if model == "" {
+		svc.Group = ""
+		svc.Name = api.name
+		svc.Description = "Client of the " + api.name + "; the project does not name the model it uses."
+	}
+	svc.Provider = &cdx.OrganizationalEntity{Name: api.organization}
+	if api.url != "" {
+		svc.Provider.URL = &[]string{api.url}
+	}
+	if api.endpoint != "" {
+		svc.Endpoints = &[]string{api.endpoint}
+	}
+	if api.docs != "" {
+		svc.ExternalReferences = &[]cdx.ExternalReference{{Type: cdx.ERTypeDocumentation, URL: api.docs}}
+	}
+	for _, p := range [][2]string{
+		{taxonomy.HostedProvider, provider},
+		{taxonomy.HostedEvidence, d.Evidence},
+		{taxonomy.HostedPath, d.Path},
+	} {
+		svc.Properties = taxonomy.Set(svc.Properties, p[0], p[1])
+	}
+	return svc, true
+}
+
+// AddHostedServices records the "hosted-model" discoveries among discoveries.
+// as services of bom, once per model, and makes the metadata component.
+// depend on them. It returns the number of services added.
+func AddHostedServices(bom *cdx.BOM, discoveries []scanner.Discovery) int {
+	if bom == nil {
+		return 0
+	}
+	var services []cdx.Service
+	if bom.Services != nil {
+		services = *bom.Services
+	}
+	seen := make(map[string]bool)
+	added := 0
+	for _, d := range discoveries {
+		if seen[d.ID] {
+			continue
+		}
+		svc, ok := HostedService(d)
+		if !ok {
+			continue
+		}
+		seen[d.ID] = true
+		services = append(services, svc)
+		added++
+	}
+	if added == 0 {
+		return 0
+	}
+	bom.Services = &services
+	AddApplicationDependencies(bom)
+	return added
+}
@@ -0,0 +1,74 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/idlab-discover/aibomgen-cli/internal/taxonomy"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/scanner"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+func TestHostedService(t *testing.T) {
+	d := scanner.Discovery{
+		ID:       "openai:gpt-4o",
+		Name:     "gpt-4o",
+		Type:     scanner.DiscoveryTypeHostedModel,
+		Path:     "app/chat.py",
+		Evidence: `openai_model at line 4: client.chat.completions.create(model="gpt-4o")`,
+	}
+	svc, ok := HostedService(d)
+	if !ok {
+		t.Fatal("HostedService returned ok=false")
+	}
+	if svc.Name != "gpt-4o" || svc.Group != "OpenAI API" || svc.Provider == nil || svc.Provider.Name != "OpenAI" {
+		t.Errorf("unexpected service identity: %+v", svc)
+	}
+	if svc.Endpoints == nil || (*svc.Endpoints)[0] != "https://api.openai.com/v1" {
+		t.Errorf("Endpoints = %v", svc.Endpoints)
+	}
+	if svc.Authenticated == nil || !*svc.Authenticated || svc.CrossesTrustBoundary == nil || !*svc.CrossesTrustBoundary {
+		t.Errorf("expected an authenticated service crossing the trust boundary, got %+v", svc)
+	}
+	if v, _ := taxonomy.Get(svc.Properties, taxonomy.HostedProvider); v != scanner.HostedOpenAI {
+		t.Errorf("%s = %q", taxonomy.HostedProvider, v)
+	}
+
+	client, _ := HostedService(scanner.Discovery{ID: "bedrock", Name: "bedrock", Type: scanner.DiscoveryTypeHostedModel})
+	if client.Name != "Amazon Bedrock" || client.Group != "" || client.Endpoints != nil {
+		t.Errorf("unexpected client service: %+v", client)
+	}
+
+	if _, ok := HostedService(scanner.Discovery{ID: "org/model", Type: "model"}); ok {
+		t.Error("expected ok=false for a Hugging Face discovery")
+	}
+}
+
+func TestAddHostedServices(t *testing.T) {
+	bom := cdx.NewBOM()
+	bom.Metadata = &cdx.Metadata{Component: &cdx.Component{BOMRef: "app", Type: cdx.ComponentTypeApplication, Name: "app"}}
+	bom.Components = &[]cdx.Component{{BOMRef: "model", Type: cdx.ComponentTypeMachineLearningModel, Name: "org/model"}}
+
+	hosted := scanner.Discovery{ID: "anthropic:claude-3-5-sonnet-20241022", Name: "claude-3-5-sonnet-20241022", Type: scanner.DiscoveryTypeHostedModel}
+	n := AddHostedServices(bom, []scanner.Discovery{
+		{ID: "org/model", Name: "org/model", Type: "model"},
+		hosted,
+		hosted,
+	})
+	if n != 1 || bom.Services == nil || len(*bom.Services) != 1 {
+		t.Fatalf("expected one service, got %d: %+v", n, bom.Services)
+	}
+	svcRef := (*bom.Services)[0].BOMRef
+
+	if bom.Dependencies == nil || (*bom.Dependencies)[0].Ref != "app" {
+		t.Fatalf("expected the application dependency first, got %+v", bom.Dependencies)
+	}
+	deps := *(*bom.Dependencies)[0].Dependencies
+	if len(deps) != 2 || deps[0] != "model" || deps[1] != svcRef {
+		t.Errorf("application dependencies = %v, want [model %s]", deps, svcRef)
+	}
+
+	if n := AddHostedServices(bom, nil); n != 0 || len(*bom.Services) != 1 {
+		t.Errorf("AddHostedServices(nil) added %d services", n)
+	}
+}
@@ -0,0 +1,413 @@
+// Package compare lays the AIBOMs of candidate models side by side, to.
+// support model selection: license, size, task, datasets, benchmark results,.
+// completeness and risk, one column per model.
+//.
+// Risk is a coarse level (low, medium or high) with the factors behind it,.
+// derived from what the AIBOM records: the Hugging Face security scan and.
+// the vulnerabilities it produced, the license terms and the required fields.
+// that are missing. It points reviewers at what to check, it is not a verdict.
+package compare
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/internal/locale"
+	"github.com/idlab-discover/aibomgen-cli/internal/taxonomy"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/completeness"
+)
+
+// Risk levels, in increasing order.
+const (
+	RiskLow    = "low"
+	RiskMedium = "medium"
+	RiskHigh   = "high"
+)
+
+// Risk is the risk level of a model and the factors that raised it.
+type Risk struct {
+	Level   string   `json:"level"`
+	Factors []string `json:"factors"`
+}
+
+// raise records a factor and raises the level to at least level.
+func (r *Risk) raise(level, factor string) {
+	if riskRank(level) > riskRank(r.Level) {
+		r.Level = level
+	}
+	r.Factors = append(r.Factors, factor)
+}
+
+func riskRank(level string) int {
+	switch level {
+	case RiskHigh:
+		return 2
+	case RiskMedium:
+		return 1
+	}
+	return 0
+}
+
+// Model is the column of one candidate model.
+type Model struct {
+	ID       string   `json:"id"`
+	Version  string   `json:"version"`
+	Licenses []string `json:"licenses"`
+	Task     string   `json:"task"`
+	Library  string   `json:"library"`
+	// SizeBytes is the storage used by the model repository, or the size of.
+	// its weight file (0: unknown).
+	SizeBytes int64    `json:"size_bytes"`
+	Downloads string   `json:"downloads"`
+	Likes     string   `json:"likes"`
+	Datasets  []string `json:"datasets"`
+	// Benchmarks maps performance metric types (with their slice, if any) to.
+	// the values reported on the model card.
+	Benchmarks      map[string]string `json:"benchmarks"`
+	Completeness    float64           `json:"completeness"`
+	MissingRequired []string          `json:"missing_required"`
+	Risk            Risk              `json:"risk"`
+}
+
+// Matrix is the comparison of the candidate models.
+type Matrix struct {
+	Models []Model `json:"models"`
+	// Benchmarks lists the metric types of all models, sorted.
+	Benchmarks []string `json:"benchmarks"`
+
+	// locale formats the sizes, counts and scores of Rows; the JSON form.
+	// keeps the raw values.
+	locale locale.Locale
+}
+
+// Options configures Build.
+type Options struct {
+	// Profile is the weights profile completeness is scored with (nil: the.
+	// registry defaults).
+	Profile *completeness.Profile
+	// Locale formats the numbers of the text and Markdown tables (zero: raw.
+	// values).
+	Locale locale.Locale
+}
+
+// Build compares the models described by boms, in order. BOMs without a.
+// metadata component are skipped.
+func Build(boms []*cdx.BOM, opts Options) Matrix {
+	m := Matrix{Models: []Model{}, Benchmarks: []string{}, locale: opts.Locale}
+	seen := map[string]bool{}
+	for _, bom := range boms {
+		if bom == nil || bom.Metadata == nil || bom.Metadata.Component == nil {
+			continue
+		}
+		model := buildModel(bom, opts)
+		for k := range model.Benchmarks {
+			if !seen[k] {
+				seen[k] = true
+				m.Benchmarks = append(m.Benchmarks, k)
+			}
+		}
+		m.Models = append(m.Models, model)
+	}
+	sort.Strings(m.Benchmarks)
+	return m
+}
+
+func buildModel(bom *cdx.BOM, opts Options) Model {
+	c := bom.Metadata.Component
+	model := Model{
+		ID:              c.Name,
+		Version:         c.Version,
+		Licenses:        licenses(c),
+		Datasets:        []string{},
+		Benchmarks:      map[string]string{},
+		MissingRequired: []string{},
+	}
+	if c.Group != "" && !strings.HasPrefix(c.Name, c.Group+"/") {
+		model.ID = c.Group + "/" + c.Name
+	}
+	model.Library, _ = taxonomy.Get(c.Properties, taxonomy.HFLibraryName)
+	model.Downloads, _ = taxonomy.Get(c.Properties, taxonomy.HFDownloads)
+	model.Likes, _ = taxonomy.Get(c.Properties, taxonomy.HFLikes)
+	for _, name := range []string{taxonomy.HFUsedStorage, taxonomy.FileSize, taxonomy.KaggleTotalBytes} {
+		if v, ok := taxonomy.Get(c.Properties, name); ok {
+			if n, err := strconv.ParseInt(v, 10, 64); err == nil && n > 0 {
+				model.SizeBytes = n
+				break
+			}
+		}
+	}
+
+	datasetNames := map[string]string{}
+	if bom.Components != nil {
+		for _, comp := range *bom.Components {
+			if comp.Type == cdx.ComponentTypeData && comp.BOMRef != "" {
+				datasetNames[comp.BOMRef] = comp.Name
+			}
+		}
+	}
+	if mc := c.ModelCard; mc != nil {
+		if mp := mc.ModelParameters; mp != nil {
+			model.Task = mp.Task
+			if mp.Datasets != nil {
+				for _, ds := range *mp.Datasets {
+					name := ds.Ref
+					if n, ok := datasetNames[ds.Ref]; ok {
+						name = n
+					} else if ds.ComponentData != nil && ds.ComponentData.Name != "" {
+						name = ds.ComponentData.Name
+					}
+					name = strings.TrimPrefix(name, "dataset:")
+					if name != "" {
+						model.Datasets = append(model.Datasets, name)
+					}
+				}
+			}
+		}
+		if qa := mc.QuantitativeAnalysis; qa != nil && qa.PerformanceMetrics != nil {
+			for _, pm := range *qa.PerformanceMetrics {
+				key := strings.TrimSpace(pm.Type)
+				if key == "" {
+					continue
+				}
+				if pm.Slice != "" {
+					key += " (" + pm.Slice + ")"
+				}
+				if _, ok := model.Benchmarks[key]; !ok {
+					model.Benchmarks[key] = pm.Value
+				}
+			}
+		}
+	}
+
+	res := completeness.CheckWithProfile(bom, opts.Profile)
+	model.Completeness = res.Score
+	for _, k := range res.MissingRequired {
+		model.MissingRequired = append(model.MissingRequired, string(k))
+	}
+	model.Risk = assessRisk(bom, model)
+	return model
+}
+
+// assessRisk derives the risk of a model from its BOM.
+func assessRisk(bom *cdx.BOM, model Model) Risk {
+	c := bom.Metadata.Component
+	risk := Risk{Level: RiskLow, Factors: []string{}}
+
+	if status, _ := taxonomy.Get(c.Properties, taxonomy.HFSecurityOverallStatus); strings.EqualFold(status, "unsafe") {
+		risk.raise(RiskHigh, "security scan: unsafe")
+	}
+	if n := intProperty(c, taxonomy.HFSecurityUnsafeFileCount); n > 0 {
+		risk.raise(RiskHigh, fmt.Sprintf("%d unsafe file(s)", n))
+	}
+	if n := intProperty(c, taxonomy.HFSecurityCautionFileCount); n > 0 {
+		risk.raise(RiskMedium, fmt.Sprintf("%d file(s) flagged for caution", n))
+	}
+	if bom.Vulnerabilities != nil {
+		var severe, other int
+		for _, v := range *bom.Vulnerabilities {
+			if vulnerabilityRank(v) >= 2 {
+				severe++
+			} else {
+				other++
+			}
+		}
+		if severe > 0 {
+			risk.raise(RiskHigh, fmt.Sprintf("%d critical or high vulnerability(ies)", severe))
+		}
+		if other > 0 {
+			risk.raise(RiskMedium, fmt.Sprintf("%d other vulnerability(ies)", other))
+		}
+	}
+
+	switch {
+	case len(model.Licenses) == 0:
+		risk.raise(RiskMedium, "no license")
+	default:
+		for _, l := range model.Licenses {
+			switch licenseClass(l) {
+			case "non-commercial":
+				risk.raise(RiskMedium, "non-commercial license "+l)
+			case "custom":
+				risk.raise(RiskMedium, "custom license terms ("+l+")")
+			}
+		}
+	}
+	if n := len(model.MissingRequired); n > 0 {
+		risk.raise(RiskMedium, fmt.Sprintf("%d required field(s) missing", n))
+	}
+	if len(model.Datasets) == 0 {
+		risk.raise(RiskLow, "training data not documented")
+	}
+	return risk
+}
+
+// vulnerabilityRank is 2 for critical and high ratings, 1 otherwise.
+func vulnerabilityRank(v cdx.Vulnerability) int {
+	if v.Ratings == nil {
+		return 1
+	}
+	for _, r := range *v.Ratings {
+		if r.Severity == cdx.SeverityCritical || r.Severity == cdx.SeverityHigh {
+			return 2
+		}
+	}
+	return 1
+}
+
+// licenseClass classifies a license ID or name: "non-commercial" for Creative.
+// Commons NC and research-only licenses, "custom" for licenses Hugging Face.
+// lists as "other" or as a model-specific agreement, "" otherwise.
+func licenseClass(license string) string {
+	l := strings.ToLower(license)
+	switch {
+	case strings.Contains(l, "-nc") || strings.Contains(l, "noncommercial") || strings.Contains(l, "non-commercial") || strings.Contains(l, "research"):
+		return "non-commercial"
+	case l == "other" || l == "unknown" || strings.Contains(l, "llama") || strings.Contains(l, "gemma") || strings.Contains(l, "openrail"):
+		return "custom"
+	}
+	return ""
+}
+
+func licenses(c *cdx.Component) []string {
+	out := []string{}
+	if c.Licenses == nil {
+		return out
+	}
+	for _, l := range *c.Licenses {
+		switch {
+		case l.Expression != "":
+			out = append(out, l.Expression)
+		case l.License != nil && l.License.ID != "":
+			out = append(out, l.License.ID)
+		case l.License != nil && l.License.Name != "":
+			out = append(out, l.License.Name)
+		}
+	}
+	return out
+}
+
+func intProperty(c *cdx.Component, name string) int {
+	v, _ := taxonomy.Get(c.Properties, name)
+	n, _ := strconv.Atoi(strings.TrimSpace(v))
+	return n
+}
+
+// Row is one attribute of the matrix, with a value per model.
+type Row struct {
+	Label  string
+	Values []string
+}
+
+// Rows returns the matrix as rows of text, one per attribute.
+func (m Matrix) Rows() []Row {
+	row := func(label string, value func(Model) string) Row {
+		r := Row{Label: label, Values: make([]string, len(m.Models))}
+		for i, model := range m.Models {
+			r.Values[i] = value(model)
+		}
+		return r
+	}
+	rows := []Row{
+		row("Version", func(x Model) string { return x.Version }),
+		row("License", func(x Model) string { return strings.Join(x.Licenses, ", ") }),
+		row("Size", func(x Model) string { return formatBytes(m.locale, x.SizeBytes) }),
+		row("Task", func(x Model) string { return x.Task }),
+		row("Library", func(x Model) string { return x.Library }),
+		row("Downloads", func(x Model) string { return formatCount(m.locale, x.Downloads) }),
+		row("Likes", func(x Model) string { return formatCount(m.locale, x.Likes) }),
+		row("Datasets", func(x Model) string { return strings.Join(x.Datasets, ", ") }),
+	}
+	for _, b := range m.Benchmarks {
+		rows = append(rows, row(b, func(x Model) string { return x.Benchmarks[b] }))
+	}
+	rows = append(rows,
+		row("Completeness", func(x Model) string { return m.locale.Percent(x.Completeness, 1) }),
+		row("Risk", func(x Model) string { return x.Risk.Level }),
+		row("Risk factors", func(x Model) string { return strings.Join(x.Risk.Factors, "; ") }),
+	)
+	return rows
+}
+
+// WriteText writes the matrix as an aligned plain-text table. Values longer.
+// than width runes are cut (0: no limit).
+func WriteText(w io.Writer, m Matrix, width int) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	header := []string{""}
+	for _, model := range m.Models {
+		header = append(header, cut(model.ID, width))
+	}
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, r := range m.Rows() {
+		cells := []string{r.Label}
+		for _, v := range r.Values {
+			if v == "" {
+				v = "-"
+			}
+			cells = append(cells, cut(v, width))
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	return tw.Flush()
+}
+
+// WriteMarkdown writes the matrix as a Markdown table.
+func WriteMarkdown(w io.Writer, m Matrix) error {
+	var sb strings.Builder
+	sb.WriteString("| |")
+	for _, model := range m.Models {
+		sb.WriteString(" " + mdCell(model.ID) + " |")
+	}
+	sb.WriteString("\n| --- |")
+	for range m.Models {
+		sb.WriteString(" --- |")
+	}
+	sb.WriteString("\n")
+	for _, r := range m.Rows() {
+		sb.WriteString("| **" + mdCell(r.Label) + "** |")
+		for _, v := range r.Values {
+			if v == "" {
+				v = "–"
+			}
+			sb.WriteString(" " + mdCell(v) + " |")
+		}
+		sb.WriteString("\n")
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func mdCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(s, "\n", " ")
+}
+
+func cut(s string, width int) string {
+	r := []rune(s)
+	if width <= 0 || len(r) <= width {
+		return s
+	}
+	return string(r[:width-1]) + "…"
+}
+
+// formatBytes formats n in binary units for l ("" for 0).
+func formatBytes(l locale.Locale, n int64) string {
+	if n <= 0 {
+		return ""
+	}
+	return l.Bytes(n)
+}
+
+// formatCount formats a count property for l, leaving values that are not.
+// integers as they are.
+func formatCount(l locale.Locale, v string) string {
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return v
+	}
+	return l.Int(n)
+}
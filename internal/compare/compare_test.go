@@ -0,0 +1,134 @@
+package compare
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/internal/locale"
+	"github.com/idlab-discover/aibomgen-cli/internal/taxonomy"
+)
+
+func modelBOM(name, license string, props []cdx.Property, metrics []cdx.MLPerformanceMetric) *cdx.BOM {
+	bom := cdx.NewBOM()
+	c := &cdx.Component{
+		BOMRef:     "pkg:huggingface/" + name,
+		Type:       cdx.ComponentTypeMachineLearningModel,
+		Name:       name,
+		Properties: &props,
+		ModelCard: &cdx.MLModelCard{
+			ModelParameters: &cdx.MLModelParameters{
+				Task:     "text-classification",
+				Datasets: &[]cdx.MLDatasetChoice{{Ref: "dataset:imdb"}},
+			},
+			QuantitativeAnalysis: &cdx.MLQuantitativeAnalysis{PerformanceMetrics: &metrics},
+		},
+	}
+	if license != "" {
+		c.Licenses = &cdx.Licenses{{License: &cdx.License{ID: license}}}
+	}
+	bom.Metadata = &cdx.Metadata{Component: c}
+	bom.Components = &[]cdx.Component{{BOMRef: "dataset:imdb", Type: cdx.ComponentTypeData, Name: "imdb"}}
+	return bom
+}
+
+func TestBuild(t *testing.T) {
+	a := modelBOM("org/a", "apache-2.0",
+		[]cdx.Property{{Name: taxonomy.HFUsedStorage, Value: "2147483648"}},
+		[]cdx.MLPerformanceMetric{{Type: "accuracy", Value: "0.91"}})
+	b := modelBOM("org/b", "cc-by-nc-4.0",
+		[]cdx.Property{{Name: taxonomy.HFSecurityUnsafeFileCount, Value: "1"}},
+		[]cdx.MLPerformanceMetric{{Type: "f1", Value: "0.88", Slice: "test"}})
+	m := Build([]*cdx.BOM{a, nil, b}, Options{})
+
+	if len(m.Models) != 2 || m.Models[0].ID != "org/a" || m.Models[1].ID != "org/b" {
+		t.Fatalf("models = %+v", m.Models)
+	}
+	if got := strings.Join(m.Benchmarks, ","); got != "accuracy,f1 (test)" {
+		t.Errorf("benchmarks = %q", got)
+	}
+	ma, mb := m.Models[0], m.Models[1]
+	if ma.SizeBytes != 2147483648 || len(ma.Datasets) != 1 || ma.Datasets[0] != "imdb" || ma.Task != "text-classification" {
+		t.Errorf("model a = %+v", ma)
+	}
+	if mb.Risk.Level != RiskHigh {
+		t.Errorf("risk b = %+v, want high", mb.Risk)
+	}
+	var nc bool
+	for _, f := range mb.Risk.Factors {
+		nc = nc || strings.Contains(f, "non-commercial")
+	}
+	if !nc {
+		t.Errorf("risk b factors = %v, want non-commercial license", mb.Risk.Factors)
+	}
+	if ma.Risk.Level == RiskHigh {
+		t.Errorf("risk a = %+v", ma.Risk)
+	}
+}
+
+func TestRiskWithoutLicense(t *testing.T) {
+	m := Build([]*cdx.BOM{modelBOM("org/x", "", nil, nil)}, Options{})
+	if r := m.Models[0].Risk; r.Level != RiskMedium || !strings.Contains(strings.Join(r.Factors, ";"), "no license") {
+		t.Errorf("risk = %+v", r)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	m := Build([]*cdx.BOM{
+		modelBOM("org/a", "mit", nil, []cdx.MLPerformanceMetric{{Type: "accuracy", Value: "0.9"}}),
+		modelBOM("org/b|c", "mit", nil, nil),
+	}, Options{})
+
+	var text bytes.Buffer
+	if err := WriteText(&text, m, 0); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"org/a", "License", "accuracy", "0.9", "Completeness", "Risk"} {
+		if !strings.Contains(text.String(), want) {
+			t.Errorf("text output missing %q:\n%s", want, text.String())
+		}
+	}
+
+	var md bytes.Buffer
+	if err := WriteMarkdown(&md, m); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(md.String()), "\n")
+	if lines[0] != `| | org/a | org/b\|c |` || lines[1] != "| --- | --- | --- |" {
+		t.Errorf("markdown header:\n%s", md.String())
+	}
+}
+
+func TestWriteLocale(t *testing.T) {
+	de, err := locale.Parse("de-DE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	props := []cdx.Property{
+		{Name: taxonomy.HFUsedStorage, Value: "1610612736"},
+		{Name: taxonomy.HFDownloads, Value: "1234567"},
+	}
+	m := Build([]*cdx.BOM{modelBOM("org/a", "mit", props, nil)}, Options{Locale: de})
+
+	var text bytes.Buffer
+	if err := WriteText(&text, m, 0); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"1,5 GiB", "1.234.567", "\u00a0%"} {
+		if !strings.Contains(text.String(), want) {
+			t.Errorf("text output missing %q:\n%s", want, text.String())
+		}
+	}
+	if m.Models[0].Downloads != "1234567" {
+		t.Errorf("downloads = %q, want the raw value", m.Models[0].Downloads)
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	for n, want := range map[int64]string{0: "", 512: "512 B", 1536: "1.5 KiB", 3 << 30: "3.0 GiB"} {
+		if got := formatBytes(locale.Raw, n); got != want {
+			t.Errorf("formatBytes(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
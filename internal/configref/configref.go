@@ -0,0 +1,221 @@
+// Package configref resolves references in configuration values, so that.
+// config files do not have to hold secrets in plain text.
+//.
+// String values may contain ${NAME} references to environment variables.
+// ($${ yields a literal "${"). A value may also be replaced by a mapping with.
+// a single secretRef key naming where the secret is kept:.
+//.
+//	generate:.
+//	  hf-token:.
+//	    secretRef: keyring:huggingface.
+//.
+// The keyring scheme reads the secret stored for the service "aibomgen-cli".
+// and the given account from the OS keyring; the env scheme reads an.
+// environment variable. References that cannot be resolved are errors.
+package configref
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// SecretRefKey is the key of a secret reference mapping.
+const SecretRefKey = "secretRef"
+
+// Resolve replaces the references in settings, in place. The error lists.
+// every reference that could not be resolved, by config key.
+func Resolve(settings map[string]any) error {
+	var errs []error
+	resolveMap(settings, "", &errs)
+	return errors.Join(errs...)
+}
+
+// Keys returns the config keys in settings whose values hold a reference.
+func Keys(settings map[string]any) []string {
+	var keys []string
+	findRefs(settings, "", &keys)
+	sort.Strings(keys)
+	return keys
+}
+
+func resolveMap(m map[string]any, prefix string, errs *[]error) {
+	for k, val := range m {
+		key := join(prefix, k)
+		resolved, err := resolveValue(val, key, errs)
+		if err != nil {
+			*errs = append(*errs, fmt.Errorf("config key %s: %w", key, err))
+			continue
+		}
+		m[k] = resolved
+	}
+}
+
+func resolveValue(val any, key string, errs *[]error) (any, error) {
+	switch v := val.(type) {
+	case string:
+		return Expand(v)
+	case []any:
+		for i, item := range v {
+			resolved, err := resolveValue(item, fmt.Sprintf("%s[%d]", key, i), errs)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = resolved
+		}
+		return v, nil
+	case []string:
+		for i, item := range v {
+			resolved, err := Expand(item)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = resolved
+		}
+		return v, nil
+	case map[string]any:
+		if ref, ok := secretRef(v); ok {
+			return lookupSecret(ref)
+		}
+		resolveMap(v, key, errs)
+		return v, nil
+	}
+	return val, nil
+}
+
+func findRefs(m map[string]any, prefix string, keys *[]string) {
+	for k, val := range m {
+		key := join(prefix, k)
+		if hasRef(val) {
+			*keys = append(*keys, key)
+			continue
+		}
+		if sub, ok := val.(map[string]any); ok {
+			findRefs(sub, key, keys)
+		}
+	}
+}
+
+func hasRef(val any) bool {
+	switch v := val.(type) {
+	case string:
+		return strings.Contains(v, "${")
+	case []any:
+		for _, item := range v {
+			if hasRef(item) {
+				return true
+			}
+		}
+	case []string:
+		for _, item := range v {
+			if hasRef(item) {
+				return true
+			}
+		}
+	case map[string]any:
+		_, ok := secretRef(v)
+		return ok
+	}
+	return false
+}
+
+// secretRef returns the reference of a {secretRef: ...} mapping. Config.
+// loaders lower-case keys, so the key is matched case-insensitively.
+func secretRef(m map[string]any) (string, bool) {
+	if len(m) != 1 {
+		return "", false
+	}
+	for k, v := range m {
+		if !strings.EqualFold(k, SecretRefKey) {
+			return "", false
+		}
+		s, ok := v.(string)
+		return strings.TrimSpace(s), ok
+	}
+	return "", false
+}
+
+// Expand replaces the ${NAME} references in s with the values of the.
+// environment variables. A variable that is not set is an error; "$${".
+// is kept as a literal "${".
+func Expand(s string) (string, error) {
+	if !strings.Contains(s, "${") {
+		return s, nil
+	}
+	var b strings.Builder
+	for {
+		i := strings.Index(s, "${")
+		if i < 0 {
+			b.WriteString(s)
+			return b.String(), nil
+		}
+		if i > 0 && s[i-1] == '$' {
+			b.WriteString(s[:i-1] + "${")
+			s = s[i+2:]
+			continue
+		}
+		b.WriteString(s[:i])
+		end := strings.IndexByte(s[i:], '}')
+		if end < 0 {
+			return "", fmt.Errorf("unterminated ${ in %q", s)
+		}
+		name := s[i+2 : i+end]
+		if !validName(name) {
+			return "", fmt.Errorf("invalid environment variable name %q", name)
+		}
+		val, ok := os.LookupEnv(name)
+		if !ok {
+			return "", fmt.Errorf("environment variable %s is not set", name)
+		}
+		b.WriteString(val)
+		s = s[i+end+1:]
+	}
+}
+
+func validName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		switch {
+		case r == '_', r >= 'A' && r <= 'Z', r >= 'a' && r <= 'z':
+		case r >= '0' && r <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// lookupSecret resolves a "<scheme>:<name>" secret reference.
+func lookupSecret(ref string) (string, error) {
+	scheme, name, ok := strings.Cut(ref, ":")
+	name = strings.TrimSpace(name)
+	if !ok || name == "" {
+		return "", fmt.Errorf("invalid %s %q: want <scheme>:<name>", SecretRefKey, ref)
+	}
+	switch strings.ToLower(strings.TrimSpace(scheme)) {
+	case "keyring":
+		secret, err := keyringLookup(name)
+		if err != nil {
+			return "", fmt.Errorf("keyring secret %q: %w", name, err)
+		}
+		return secret, nil
+	case "env":
+		secret, ok := os.LookupEnv(name)
+		if !ok {
+			return "", fmt.Errorf("environment variable %s is not set", name)
+		}
+		return secret, nil
+	}
+	return "", fmt.Errorf("unsupported %s scheme %q (want keyring or env)", SecretRefKey, scheme)
+}
+
+func join(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
@@ -0,0 +1,113 @@
+package configref
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestExpand(t *testing.T) {
+	t.Setenv("AIBOMGEN_TEST_TOKEN", "hf_secret")
+	t.Setenv("AIBOMGEN_TEST_EMPTY", "")
+
+	cases := []struct {
+		in, want string
+		wantErr  bool
+	}{
+		{in: "plain", want: "plain"},
+		{in: "${AIBOMGEN_TEST_TOKEN}", want: "hf_secret"},
+		{in: "Bearer ${AIBOMGEN_TEST_TOKEN}!", want: "Bearer hf_secret!"},
+		{in: "x${AIBOMGEN_TEST_EMPTY}y", want: "xy"},
+		{in: "$${AIBOMGEN_TEST_TOKEN}", want: "${AIBOMGEN_TEST_TOKEN}"},
+		{in: "$HOME stays", want: "$HOME stays"},
+		{in: "${AIBOMGEN_TEST_UNSET}", wantErr: true},
+		{in: "${AIBOMGEN_TEST_TOKEN", wantErr: true},
+		{in: "${1BAD}", wantErr: true},
+	}
+	for _, c := range cases {
+		got, err := Expand(c.in)
+		if c.wantErr {
+			if err == nil {
+				t.Errorf("Expand(%q) = %q, want error", c.in, got)
+			}
+			continue
+		}
+		if err != nil || got != c.want {
+			t.Errorf("Expand(%q) = %q, %v; want %q", c.in, got, err, c.want)
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	t.Setenv("AIBOMGEN_TEST_DIR", "/tmp/boms")
+	t.Setenv("AIBOMGEN_TEST_TOKEN", "hf_env")
+	orig := keyringLookup
+	t.Cleanup(func() { keyringLookup = orig })
+	keyringLookup = func(account string) (string, error) {
+		if account == "huggingface" {
+			return "hf_keyring", nil
+		}
+		return "", errors.New("not found")
+	}
+
+	settings := map[string]any{
+		"generate": map[string]any{
+			"hf-token": map[string]any{"secretref": "keyring:huggingface"},
+			"output":   "${AIBOMGEN_TEST_DIR}/out.json",
+			"hf-mode":  "online",
+		},
+		"scan": map[string]any{
+			"hf-token": map[string]any{"secretRef": "env:AIBOMGEN_TEST_TOKEN"},
+			"ignore":   []any{"${AIBOMGEN_TEST_DIR}/cache/", "examples/"},
+			"timeout":  10,
+		},
+	}
+	if got := Keys(settings); strings.Join(got, ",") != "generate.hf-token,generate.output,scan.hf-token,scan.ignore" {
+		t.Errorf("Keys = %v", got)
+	}
+	if err := Resolve(settings); err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+
+	gen := settings["generate"].(map[string]any)
+	scan := settings["scan"].(map[string]any)
+	if gen["hf-token"] != "hf_keyring" {
+		t.Errorf("generate.hf-token = %v", gen["hf-token"])
+	}
+	if gen["output"] != "/tmp/boms/out.json" {
+		t.Errorf("generate.output = %v", gen["output"])
+	}
+	if scan["hf-token"] != "hf_env" {
+		t.Errorf("scan.hf-token = %v", scan["hf-token"])
+	}
+	if ignore := scan["ignore"].([]any); ignore[0] != "/tmp/boms/cache/" || ignore[1] != "examples/" {
+		t.Errorf("scan.ignore = %v", ignore)
+	}
+	if scan["timeout"] != 10 || gen["hf-mode"] != "online" {
+		t.Errorf("values without references changed: %v", settings)
+	}
+	if len(Keys(settings)) != 0 {
+		t.Errorf("references left after Resolve: %v", Keys(settings))
+	}
+}
+
+func TestResolveErrors(t *testing.T) {
+	orig := keyringLookup
+	t.Cleanup(func() { keyringLookup = orig })
+	keyringLookup = func(string) (string, error) { return "", errors.New("not found") }
+
+	settings := map[string]any{
+		"generate": map[string]any{"hf-token": map[string]any{"secretRef": "keyring:missing"}},
+		"enrich":   map[string]any{"hf-token": map[string]any{"secretRef": "vault:x"}},
+		"scan":     map[string]any{"output": "${AIBOMGEN_TEST_UNSET}"},
+	}
+	err := Resolve(settings)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	for _, want := range []string{"generate.hf-token", "enrich.hf-token", "unsupported secretRef scheme", "scan.output", "AIBOMGEN_TEST_UNSET"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+}
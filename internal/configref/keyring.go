@@ -0,0 +1,53 @@
+package configref
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// KeyringService is the service name secrets are stored under in the OS.
+// keyring. Store a token with, for example:.
+//.
+//	secret-tool store --label "aibomgen-cli" service aibomgen-cli account huggingface   (Linux).
+//	security add-generic-password -s aibomgen-cli -a huggingface -w                     (macOS).
+const KeyringService = "aibomgen-cli"
+
+// keyringLookup is replaced in tests.
+var keyringLookup = lookupOSKeyring
+
+// lookupOSKeyring reads a secret through the keyring tool of the platform:.
+// secret-tool (libsecret) on Linux and the BSDs, security on macOS.
+func lookupOSKeyring(account string) (string, error) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("security", "find-generic-password", "-s", KeyringService, "-a", account, "-w")
+	case "linux", "freebsd", "openbsd", "netbsd":
+		cmd = exec.Command("secret-tool", "lookup", "service", KeyringService, "account", account)
+	default:
+		return "", fmt.Errorf("the OS keyring is not supported on %s", runtime.GOOS)
+	}
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return "", fmt.Errorf("%s not found: install it to read secrets from the OS keyring", cmd.Path)
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%s: %s", err, msg)
+		}
+		// secret-tool exits 1 without a message when nothing is stored.
+		return "", errors.New("not found")
+	}
+	secret := strings.TrimRight(string(out), "\r\n")
+	if secret == "" {
+		return "", errors.New("not found")
+	}
+	return secret, nil
+}
@@ -0,0 +1,453 @@
+// Package diff compares two AIBOMs field by field.
+//.
+// Components are compared as model cards, not as JSON trees: the metadata.
+// component of both BOMs is the model, other components are matched by.
+// bom-ref (or by type and name when the bom-ref is a random UUID), and list.
+// fields such as licenses, datasets, use cases or limitations are compared as.
+// sets, so reordering is not a change. Performance metrics, hashes,.
+// properties and energy consumptions are keyed by their type, algorithm,.
+// name or activity. The report also lists vulnerabilities that appeared or.
+// disappeared and the change of the completeness score.
+package diff
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/completeness"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+// Kind says how a field or component changed.
+type Kind string
+
+const (
+	Added   Kind = "added"
+	Removed Kind = "removed"
+	Changed Kind = "changed"
+)
+
+// Change is a difference in one field of a component. Fields are named.
+// after their CycloneDX path, e.g. "modelCard.modelParameters.task" or.
+// "hashes[SHA-256]". For list fields every added or removed entry is a.
+// separate change.
+type Change struct {
+	Field string `json:"field"`
+	Kind  Kind   `json:"kind"`
+	Old   string `json:"old,omitempty"`
+	New   string `json:"new,omitempty"`
+}
+
+// ComponentDiff lists the changes of one component.
+type ComponentDiff struct {
+	Ref     string   `json:"ref,omitempty"`
+	Name    string   `json:"name"`
+	Type    string   `json:"type"`
+	Kind    Kind     `json:"kind"`
+	Changes []Change `json:"changes,omitempty"`
+}
+
+// Completeness is the completeness score of the model in both BOMs.
+type Completeness struct {
+	Old   float64 `json:"old"`
+	New   float64 `json:"new"`
+	Delta float64 `json:"delta"`
+}
+
+// Vulnerabilities lists the vulnerability IDs only one of the BOMs has.
+type Vulnerabilities struct {
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+}
+
+// Report is the result of Compare.
+type Report struct {
+	// Model holds the changes of the metadata component, or nil when it did.
+	// not change.
+	Model           *ComponentDiff  `json:"model,omitempty"`
+	Components      []ComponentDiff `json:"components,omitempty"`
+	Vulnerabilities Vulnerabilities `json:"vulnerabilities"`
+	Completeness    Completeness    `json:"completeness"`
+}
+
+// Empty reports whether the BOMs have no differences.
+func (r Report) Empty() bool {
+	return r.Model == nil && len(r.Components) == 0 && len(r.Vulnerabilities.Added) == 0 &&
+		len(r.Vulnerabilities.Removed) == 0 && r.Completeness.Delta == 0
+}
+
+// Compare returns the differences from old to new.
+func Compare(old, new *cdx.BOM) Report {
+	var rep Report
+
+	oldModel, newModel := metadataComponent(old), metadataComponent(new)
+	if oldModel != nil || newModel != nil {
+		if d := compareComponent(oldModel, newModel); d.Kind != Changed || len(d.Changes) > 0 {
+			rep.Model = &d
+		}
+	}
+
+	oldComps, newComps := indexComponents(old), indexComponents(new)
+	for _, key := range oldComps.keys {
+		if d := compareComponent(oldComps.byKey[key], newComps.byKey[key]); d.Kind != Changed || len(d.Changes) > 0 {
+			rep.Components = append(rep.Components, d)
+		}
+	}
+	for _, key := range newComps.keys {
+		if _, ok := oldComps.byKey[key]; !ok {
+			rep.Components = append(rep.Components, compareComponent(nil, newComps.byKey[key]))
+		}
+	}
+	sort.SliceStable(rep.Components, func(i, j int) bool {
+		a, b := rep.Components[i], rep.Components[j]
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.Name < b.Name
+	})
+
+	rep.Vulnerabilities.Added, rep.Vulnerabilities.Removed = setDiff(vulnerabilityIDs(old), vulnerabilityIDs(new))
+
+	if old != nil && new != nil {
+		o, n := completeness.Check(old).Score, completeness.Check(new).Score
+		rep.Completeness = Completeness{Old: o, New: n, Delta: n - o}
+	}
+	return rep
+}
+
+func metadataComponent(bom *cdx.BOM) *cdx.Component {
+	if bom == nil || bom.Metadata == nil {
+		return nil
+	}
+	return bom.Metadata.Component
+}
+
+// componentIndex holds the components of a BOM by match key, in BOM order.
+type componentIndex struct {
+	keys  []string
+	byKey map[string]*cdx.Component
+}
+
+// indexComponents indexes the components of bom, including components.
+// nested under the metadata component (e.g. diffusers pipeline parts) and.
+// under other components.
+func indexComponents(bom *cdx.BOM) componentIndex {
+	idx := componentIndex{byKey: make(map[string]*cdx.Component)}
+	var add func(list *[]cdx.Component)
+	add = func(list *[]cdx.Component) {
+		if list == nil {
+			return
+		}
+		for i := range *list {
+			c := &(*list)[i]
+			key := matchKey(c)
+			if _, dup := idx.byKey[key]; !dup {
+				idx.keys = append(idx.keys, key)
+				idx.byKey[key] = c
+			}
+			add(c.Components)
+		}
+	}
+	if m := metadataComponent(bom); m != nil {
+		add(m.Components)
+	}
+	if bom != nil {
+		add(bom.Components)
+	}
+	return idx
+}
+
+// matchKey identifies a component across two BOMs. Random bom-refs differ.
+// between runs, so those components are matched by type and name instead.
+func matchKey(c *cdx.Component) string {
+	if c.BOMRef != "" && !strings.HasPrefix(c.BOMRef, "urn:uuid:") {
+		return c.BOMRef
+	}
+	return string(c.Type) + "/" + c.Group + "/" + c.Name
+}
+
+// compareComponent compares two versions of a component; either may be nil.
+// The fields of an added or removed component are not listed.
+func compareComponent(old, new *cdx.Component) ComponentDiff {
+	var d ComponentDiff
+	switch {
+	case old == nil:
+		d.Kind = Added
+		d.Ref, d.Name, d.Type = new.BOMRef, new.Name, string(new.Type)
+	case new == nil:
+		d.Kind = Removed
+		d.Ref, d.Name, d.Type = old.BOMRef, old.Name, string(old.Type)
+	default:
+		d.Kind = Changed
+		d.Ref, d.Name, d.Type = new.BOMRef, new.Name, string(new.Type)
+		d.Changes = compareFields(fieldsOf(old), fieldsOf(new))
+	}
+	return d
+}
+
+// fields maps a field path to its values.
+type fields map[string][]string
+
+func (f fields) add(field string, values ...string) {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			f[field] = append(f[field], v)
+		}
+	}
+}
+
+// fieldsOf flattens the fields of c that the diff compares.
+func fieldsOf(c *cdx.Component) fields {
+	f := fields{}
+	if c == nil {
+		return f
+	}
+	f.add("name", c.Name)
+	f.add("group", c.Group)
+	f.add("version", c.Version)
+	f.add("type", string(c.Type))
+	f.add("purl", c.PackageURL)
+	f.add("description", c.Description)
+	if c.Manufacturer != nil {
+		f.add("manufacturer", c.Manufacturer.Name)
+	}
+	if c.Supplier != nil {
+		f.add("supplier", c.Supplier.Name)
+	}
+	if c.Authors != nil {
+		for _, a := range *c.Authors {
+			f.add("authors", a.Name)
+		}
+	}
+	if c.Licenses != nil {
+		for _, l := range *c.Licenses {
+			f.add("licenses", licenseName(l))
+		}
+	}
+	if c.Hashes != nil {
+		for _, h := range *c.Hashes {
+			f.add("hashes["+string(h.Algorithm)+"]", h.Value)
+		}
+	}
+	if c.Tags != nil {
+		f.add("tags", *c.Tags...)
+	}
+	if c.ExternalReferences != nil {
+		for _, r := range *c.ExternalReferences {
+			f.add("externalReferences["+string(r.Type)+"]", r.URL)
+		}
+	}
+	if c.Properties != nil {
+		for _, p := range *c.Properties {
+			f.add("properties["+p.Name+"]", p.Value)
+		}
+	}
+	if c.ModelCard != nil {
+		modelCardFields(f, c.ModelCard)
+	}
+	if c.Data != nil {
+		for _, d := range *c.Data {
+			dataFields(f, d)
+		}
+	}
+	return f
+}
+
+func modelCardFields(f fields, mc *cdx.MLModelCard) {
+	if p := mc.ModelParameters; p != nil {
+		if p.Approach != nil {
+			f.add("modelCard.modelParameters.approach.type", string(p.Approach.Type))
+		}
+		f.add("modelCard.modelParameters.task", p.Task)
+		f.add("modelCard.modelParameters.architectureFamily", p.ArchitectureFamily)
+		f.add("modelCard.modelParameters.modelArchitecture", p.ModelArchitecture)
+		if p.Datasets != nil {
+			for _, ds := range *p.Datasets {
+				ref := ds.Ref
+				if ref == "" && ds.ComponentData != nil {
+					ref = ds.ComponentData.Name
+				}
+				f.add("modelCard.modelParameters.datasets", ref)
+			}
+		}
+		if p.Inputs != nil {
+			for _, in := range *p.Inputs {
+				f.add("modelCard.modelParameters.inputs", in.Format)
+			}
+		}
+		if p.Outputs != nil {
+			for _, out := range *p.Outputs {
+				f.add("modelCard.modelParameters.outputs", out.Format)
+			}
+		}
+	}
+	if qa := mc.QuantitativeAnalysis; qa != nil && qa.PerformanceMetrics != nil {
+		for _, m := range *qa.PerformanceMetrics {
+			key := m.Type
+			if m.Slice != "" {
+				key += "/" + m.Slice
+			}
+			f.add("modelCard.quantitativeAnalysis.performanceMetrics["+key+"]", m.Value)
+		}
+	}
+	cons := mc.Considerations
+	if cons == nil {
+		return
+	}
+	for field, list := range map[string]*[]string{
+		"users":                cons.Users,
+		"useCases":             cons.UseCases,
+		"technicalLimitations": cons.TechnicalLimitations,
+		"performanceTradeoffs": cons.PerformanceTradeoffs,
+	} {
+		if list != nil {
+			f.add("modelCard.considerations."+field, *list...)
+		}
+	}
+	if cons.EthicalConsiderations != nil {
+		for _, e := range *cons.EthicalConsiderations {
+			f.add("modelCard.considerations.ethicalConsiderations", joinNonEmpty(": ", e.Name, e.MitigationStrategy))
+		}
+	}
+	if cons.FairnessAssessments != nil {
+		for _, a := range *cons.FairnessAssessments {
+			f.add("modelCard.considerations.fairnessAssessments", joinNonEmpty(": ", a.GroupAtRisk, joinNonEmpty("; ", a.Harms, a.MitigationStrategy)))
+		}
+	}
+	if env := cons.EnvironmentalConsiderations; env != nil && env.EnergyConsumptions != nil {
+		for _, e := range *env.EnergyConsumptions {
+			f.add("modelCard.considerations.environmentalConsiderations.energyConsumptions["+string(e.Activity)+"]",
+				fmt.Sprintf("%g %s", e.ActivityEnergyCost.Value, e.ActivityEnergyCost.Unit))
+		}
+	}
+}
+
+func dataFields(f fields, d cdx.ComponentData) {
+	f.add("data.type", string(d.Type))
+	f.add("data.name", d.Name)
+	f.add("data.classification", d.Classification)
+	f.add("data.description", d.Description)
+	if d.Contents != nil {
+		f.add("data.contents.url", d.Contents.URL)
+	}
+	if d.SensitiveData != nil {
+		f.add("data.sensitiveData", *d.SensitiveData...)
+	}
+	if g := d.Governance; g != nil {
+		for role, list := range map[string]*[]cdx.ComponentDataGovernanceResponsibleParty{
+			"owners":     g.Owners,
+			"custodians": g.Custodians,
+			"stewards":   g.Stewards,
+		} {
+			if list == nil {
+				continue
+			}
+			for _, p := range *list {
+				if p.Organization != nil {
+					f.add("data.governance."+role, p.Organization.Name)
+				}
+				if p.Contact != nil {
+					f.add("data.governance."+role, p.Contact.Name)
+				}
+			}
+		}
+	}
+}
+
+// licenseName returns the ID, name or expression of a license choice.
+func licenseName(l cdx.LicenseChoice) string {
+	if l.License != nil {
+		if l.License.ID != "" {
+			return l.License.ID
+		}
+		return l.License.Name
+	}
+	return l.Expression
+}
+
+func joinNonEmpty(sep string, parts ...string) string {
+	var out []string
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return strings.Join(out, sep)
+}
+
+// compareFields returns the changes from old to new, sorted by field. A.
+// field with a single value on both sides is changed in place; other fields.
+// are compared as sets.
+func compareFields(old, new fields) []Change {
+	names := make(map[string]bool)
+	for k := range old {
+		names[k] = true
+	}
+	for k := range new {
+		names[k] = true
+	}
+	sorted := make([]string, 0, len(names))
+	for k := range names {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	var changes []Change
+	for _, field := range sorted {
+		o, n := old[field], new[field]
+		if len(o) == 1 && len(n) == 1 {
+			if o[0] != n[0] {
+				changes = append(changes, Change{Field: field, Kind: Changed, Old: o[0], New: n[0]})
+			}
+			continue
+		}
+		added, removed := setDiff(o, n)
+		for _, v := range removed {
+			changes = append(changes, Change{Field: field, Kind: Removed, Old: v})
+		}
+		for _, v := range added {
+			changes = append(changes, Change{Field: field, Kind: Added, New: v})
+		}
+	}
+	return changes
+}
+
+// setDiff returns the sorted values only in new (added) and only in old.
+// (removed).
+func setDiff(old, new []string) (added, removed []string) {
+	inOld, inNew := make(map[string]bool), make(map[string]bool)
+	for _, v := range old {
+		inOld[v] = true
+	}
+	for _, v := range new {
+		inNew[v] = true
+	}
+	for v := range inNew {
+		if !inOld[v] {
+			added = append(added, v)
+		}
+	}
+	for v := range inOld {
+		if !inNew[v] {
+			removed = append(removed, v)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
+
+func vulnerabilityIDs(bom *cdx.BOM) []string {
+	if bom == nil || bom.Vulnerabilities == nil {
+		return nil
+	}
+	var ids []string
+	for _, v := range *bom.Vulnerabilities {
+		if v.ID != "" {
+			ids = append(ids, v.ID)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,108 @@
+package diff
+
+import (
+	"reflect"
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+func testBOM(task, license string, useCases []string, datasets ...string) *cdx.BOM {
+	var refs []cdx.MLDatasetChoice
+	var comps []cdx.Component
+	for _, ds := range datasets {
+		refs = append(refs, cdx.MLDatasetChoice{Ref: "pkg:huggingface/" + ds})
+		comps = append(comps, cdx.Component{
+			BOMRef: "pkg:huggingface/" + ds,
+			Type:   cdx.ComponentTypeData,
+			Name:   ds,
+		})
+	}
+	return &cdx.BOM{
+		Metadata: &cdx.Metadata{Component: &cdx.Component{
+			BOMRef:   "urn:uuid:" + task,
+			Type:     cdx.ComponentTypeMachineLearningModel,
+			Name:     "org/model",
+			Licenses: &cdx.Licenses{{License: &cdx.License{ID: license}}},
+			ModelCard: &cdx.MLModelCard{
+				ModelParameters: &cdx.MLModelParameters{Task: task, Datasets: &refs},
+				Considerations:  &cdx.MLModelCardConsiderations{UseCases: &useCases},
+			},
+		}},
+		Components: &comps,
+	}
+}
+
+func TestCompareIdentical(t *testing.T) {
+	rep := Compare(testBOM("fill-mask", "mit", []string{"a", "b"}, "ds1"), testBOM("fill-mask", "mit", []string{"b", "a"}, "ds1"))
+	if !rep.Empty() {
+		t.Fatalf("expected no differences, got %+v", rep)
+	}
+}
+
+func TestCompareModelCard(t *testing.T) {
+	old := testBOM("fill-mask", "mit", []string{"a", "b"}, "ds1")
+	new := testBOM("text-classification", "apache-2.0", []string{"b", "c"}, "ds1", "ds2")
+
+	rep := Compare(old, new)
+	if rep.Model == nil || rep.Model.Kind != Changed {
+		t.Fatalf("expected model changes, got %+v", rep.Model)
+	}
+	want := []Change{
+		{Field: "licenses", Kind: Changed, Old: "mit", New: "apache-2.0"},
+		{Field: "modelCard.considerations.useCases", Kind: Removed, Old: "a"},
+		{Field: "modelCard.considerations.useCases", Kind: Added, New: "c"},
+		{Field: "modelCard.modelParameters.datasets", Kind: Added, New: "pkg:huggingface/ds2"},
+		{Field: "modelCard.modelParameters.task", Kind: Changed, Old: "fill-mask", New: "text-classification"},
+	}
+	if !reflect.DeepEqual(rep.Model.Changes, want) {
+		t.Fatalf("changes = %+v\nwant %+v", rep.Model.Changes, want)
+	}
+
+	if len(rep.Components) != 1 || rep.Components[0].Name != "ds2" || rep.Components[0].Kind != Added {
+		t.Fatalf("expected ds2 added, got %+v", rep.Components)
+	}
+}
+
+func TestCompareHashesAndProperties(t *testing.T) {
+	comp := func(sha, prop string) cdx.Component {
+		return cdx.Component{
+			BOMRef:     "urn:uuid:random-" + sha,
+			Type:       cdx.ComponentTypeMachineLearningModel,
+			Name:       "model.gguf",
+			Hashes:     &[]cdx.Hash{{Algorithm: cdx.HashAlgoSHA256, Value: sha}},
+			Properties: &[]cdx.Property{{Name: "aibomgen:discovery:path", Value: prop}},
+		}
+	}
+	old := &cdx.BOM{Components: &[]cdx.Component{comp("aa", "/a")}}
+	new := &cdx.BOM{Components: &[]cdx.Component{comp("bb", "/a")}}
+
+	rep := Compare(old, new)
+	if len(rep.Components) != 1 {
+		t.Fatalf("components with random bom-refs should match by name, got %+v", rep.Components)
+	}
+	want := []Change{{Field: "hashes[SHA-256]", Kind: Changed, Old: "aa", New: "bb"}}
+	if got := rep.Components[0].Changes; !reflect.DeepEqual(got, want) {
+		t.Fatalf("changes = %+v, want %+v", got, want)
+	}
+}
+
+func TestCompareVulnerabilities(t *testing.T) {
+	old := &cdx.BOM{Vulnerabilities: &[]cdx.Vulnerability{{ID: "CVE-1"}, {ID: "CVE-2"}}}
+	new := &cdx.BOM{Vulnerabilities: &[]cdx.Vulnerability{{ID: "CVE-2"}, {ID: "CVE-3"}}}
+
+	rep := Compare(old, new)
+	if !reflect.DeepEqual(rep.Vulnerabilities.Added, []string{"CVE-3"}) || !reflect.DeepEqual(rep.Vulnerabilities.Removed, []string{"CVE-1"}) {
+		t.Fatalf("vulnerabilities = %+v", rep.Vulnerabilities)
+	}
+}
+
+func TestCompareCompleteness(t *testing.T) {
+	old := testBOM("fill-mask", "", nil)
+	new := testBOM("fill-mask", "mit", []string{"a"})
+
+	rep := Compare(old, new)
+	if rep.Completeness.Delta <= 0 || rep.Completeness.New <= rep.Completeness.Old {
+		t.Fatalf("completeness = %+v, want a positive delta", rep.Completeness)
+	}
+}
@@ -0,0 +1,105 @@
+package enricher
+
+import (
+	"strings"
+
+	"github.com/idlab-discover/aibomgen-cli/internal/apperr"
+	"github.com/idlab-discover/aibomgen-cli/internal/metadata"
+)
+
+// AnswerEnvPrefix is the environment variable prefix for pre-answered fields,.
+// e.g. AIBOMGEN_ANSWER_LICENSES=apache-2.0.
+const AnswerEnvPrefix = "AIBOMGEN_ANSWER_"
+
+// Answers holds pre-supplied values for interactive prompts. Keys are.
+// normalized with normalizeAnswerKey, so "modelCard.considerations.useCases",.
+// "MODELCARD_CONSIDERATIONS_USECASES" and the full registry key all match the.
+// same field.
+type Answers map[string]string
+
+// ParseAnswers builds Answers from AIBOMGEN_ANSWER_* entries in environ and.
+// "key=value" pairs (from --answer). Pairs take precedence over the environment.
+func ParseAnswers(pairs []string, environ []string) (Answers, error) {
+	answers := make(Answers)
+	for _, kv := range environ {
+		key, value, ok := strings.Cut(kv, "=")
+		if !ok || !strings.HasPrefix(key, AnswerEnvPrefix) {
+			continue
+		}
+		if k := normalizeAnswerKey(strings.TrimPrefix(key, AnswerEnvPrefix)); k != "" && value != "" {
+			answers[k] = value
+		}
+	}
+	for _, kv := range pairs {
+		key, value, ok := strings.Cut(kv, "=")
+		k := normalizeAnswerKey(key)
+		if !ok || k == "" {
+			return nil, apperr.Userf("invalid --answer %q (expected key=value)", kv)
+		}
+		answers[k] = value
+	}
+	return answers, nil
+}
+
+// model returns the answer for a model field, if any.
+func (a Answers) model(key metadata.Key) (string, bool) {
+	return a.lookup(modelKeyForms(key))
+}
+
+// dataset returns the answer for a dataset field, if any.
+func (a Answers) dataset(key metadata.DatasetKey) (string, bool) {
+	return a.lookup(datasetKeyForms(key))
+}
+
+func (a Answers) lookup(keys []string) (string, bool) {
+	for _, k := range keys {
+		if v, ok := a[normalizeAnswerKey(k)]; ok && strings.TrimSpace(v) != "" {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// modelKeyForms returns the accepted spellings of a model field key: the full.
+// registry key and the key without the "BOM.metadata.component." prefix.
+func modelKeyForms(key metadata.Key) []string {
+	full := string(key)
+	return []string{full, strings.TrimPrefix(full, "BOM.metadata.component.")}
+}
+
+// datasetKeyForms returns the accepted spellings of a dataset field key. The.
+// short forms use a "dataset." prefix to keep them apart from model fields of.
+// the same name, and may drop the "data." segment (dataset.description).
+func datasetKeyForms(key metadata.DatasetKey) []string {
+	full := string(key)
+	short := strings.TrimPrefix(full, "BOM.components[DATA].")
+	forms := []string{full, "dataset." + short}
+	if rest, ok := strings.CutPrefix(short, "data."); ok {
+		forms = append(forms, "dataset."+rest)
+	}
+	return forms
+}
+
+// normalizeAnswerKey upper-cases k and collapses every run of characters that.
+// are not letters or digits into a single underscore, so flag keys, field keys.
+// and environment variable names compare equal.
+func normalizeAnswerKey(k string) string {
+	var b strings.Builder
+	sep := false
+	for _, r := range strings.TrimSpace(k) {
+		switch {
+		case r >= 'a' && r <= 'z':
+			r -= 'a' - 'A'
+			fallthrough
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			if sep && b.Len() > 0 {
+				b.WriteByte('_')
+			}
+			sep = false
+			b.WriteRune(r)
+		default:
+			sep = true
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,386 @@
+package enricher
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/internal/apperr"
+	"github.com/idlab-discover/aibomgen-cli/internal/audit"
+	"github.com/idlab-discover/aibomgen-cli/internal/metadata"
+	"github.com/idlab-discover/aibomgen-cli/internal/review"
+	"go.yaml.in/yaml/v3"
+)
+
+// AnswersWildcard is the answers file entry that applies to every model, and.
+// the dataset entry that applies to every dataset of a model.
+const AnswersWildcard = "*"
+
+// AnswersFile holds the field values of an answers file (enrich --answers),.
+// per model ID. Keys use the same forms as --answer.
+//.
+//
+//	"*":
+//	  manufacturer: ML Platform Team
+//	google-bert/bert-base-uncased:
+//	  licenses: apache-2.0
+//	  tags: [nlp, fill-mask]
+//	  datasets:
+//	    wikipedia:
+//	      licenses: cc-by-sa-3.0
+type AnswersFile struct {
+	Path   string
+	Models []ModelAnswers // in file order
+}
+
+// ModelAnswers are the values of one model entry.
+type ModelAnswers struct {
+	ModelID  string // a model ID, or AnswersWildcard
+	Fields   []AnswerValue
+	Datasets []DatasetAnswers
+}
+
+// DatasetAnswers are the values for one dataset component of a model.
+type DatasetAnswers struct {
+	Name   string // a dataset component name, or AnswersWildcard
+	Fields []AnswerValue
+}
+
+// AnswerValue is one field value as written in the file. List values are.
+// joined with ", ", like comma-separated answers.
+type AnswerValue struct {
+	Key   string
+	Value string
+	Line  int
+}
+
+// LoadAnswersFile reads and parses the answers file at path.
+func LoadAnswersFile(path string) (*AnswersFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, apperr.Userf("cannot read answers file: %v", err)
+	}
+	file, err := ParseAnswersFile(data)
+	if err != nil {
+		return nil, apperr.Userf("answers file %s: %v", path, err)
+	}
+	file.Path = path
+	return file, nil
+}
+
+// ParseAnswersFile parses the YAML of an answers file. Keys are read as.
+// written, so field keys may contain dots.
+func ParseAnswersFile(data []byte) (*AnswersFile, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	file := &AnswersFile{}
+	if len(doc.Content) == 0 {
+		return file, nil
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("line %d: want a mapping of model IDs to field values", root.Line)
+	}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		id, body := root.Content[i], root.Content[i+1]
+		if body.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("line %d: model %q: want a mapping of field keys to values", body.Line, id.Value)
+		}
+		model := ModelAnswers{ModelID: strings.TrimSpace(id.Value)}
+		for j := 0; j+1 < len(body.Content); j += 2 {
+			key, value := body.Content[j], body.Content[j+1]
+			if strings.EqualFold(strings.TrimSpace(key.Value), "datasets") {
+				datasets, err := parseDatasetAnswers(model.ModelID, value)
+				if err != nil {
+					return nil, err
+				}
+				model.Datasets = append(model.Datasets, datasets...)
+				continue
+			}
+			v, err := parseAnswerValue(key, value)
+			if err != nil {
+				return nil, err
+			}
+			model.Fields = append(model.Fields, v)
+		}
+		file.Models = append(file.Models, model)
+	}
+	return file, nil
+}
+
+func parseDatasetAnswers(modelID string, node *yaml.Node) ([]DatasetAnswers, error) {
+	if node.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("line %d: model %q: datasets: want a mapping of dataset names to field values", node.Line, modelID)
+	}
+	var out []DatasetAnswers
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		name, body := node.Content[i], node.Content[i+1]
+		if body.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("line %d: dataset %q: want a mapping of field keys to values", body.Line, name.Value)
+		}
+		ds := DatasetAnswers{Name: strings.TrimSpace(name.Value)}
+		for j := 0; j+1 < len(body.Content); j += 2 {
+			v, err := parseAnswerValue(body.Content[j], body.Content[j+1])
+			if err != nil {
+				return nil, err
+			}
+			ds.Fields = append(ds.Fields, v)
+		}
+		out = append(out, ds)
+	}
+	return out, nil
+}
+
+func parseAnswerValue(key, value *yaml.Node) (AnswerValue, error) {
+	v := AnswerValue{Key: strings.TrimSpace(key.Value), Line: key.Line}
+	switch value.Kind {
+	case yaml.ScalarNode:
+		if value.Tag != "!!null" {
+			v.Value = strings.TrimSpace(value.Value)
+		}
+	case yaml.SequenceNode:
+		items := make([]string, 0, len(value.Content))
+		for _, item := range value.Content {
+			if item.Kind != yaml.ScalarNode {
+				return v, fmt.Errorf("line %d: %s: list items must be plain values", item.Line, v.Key)
+			}
+			if s := strings.TrimSpace(item.Value); s != "" {
+				items = append(items, s)
+			}
+		}
+		v.Value = strings.Join(items, ", ")
+	default:
+		return v, fmt.Errorf("line %d: %s: want a value or a list of values", value.Line, v.Key)
+	}
+	return v, nil
+}
+
+// AnswerResult is the outcome of one value of an answers file.
+type AnswerResult struct {
+	Component string // model or dataset component name
+	Dataset   bool
+	Field     string // registry key, or the key as written when it is unknown
+	Value     string
+	Line      int
+	Applied   bool
+	Reason    string // why the value was rejected
+}
+
+// AnswersReport lists the values of an answers file applied to a BOM and.
+// those rejected, in file order.
+type AnswersReport struct {
+	ModelID string
+	Results []AnswerResult
+}
+
+// Applied returns the number of applied values.
+func (r AnswersReport) Applied() int {
+	n := 0
+	for _, res := range r.Results {
+		if res.Applied {
+			n++
+		}
+	}
+	return n
+}
+
+// Rejected returns the number of rejected values.
+func (r AnswersReport) Rejected() int {
+	return len(r.Results) - r.Applied()
+}
+
+// answerOp is a value of an answers file resolved to a field of a component.
+type answerOp struct {
+	comp    *cdx.Component
+	dataset bool
+	model   metadata.FieldSpec
+	data    metadata.DatasetFieldSpec
+	value   AnswerValue
+}
+
+func (op answerOp) field() string {
+	if op.dataset {
+		return string(op.data.Key)
+	}
+	return string(op.model.Key)
+}
+
+// ApplyAnswersFile applies the entries of file for the model of bom: the "*".
+// entry first, then the entry of its model ID, whose values win. Values go.
+// through the same parsing as prompted values and overwrite present ones.
+// Fields in Config.Unset are removed first. Every value is reported as.
+// applied or rejected; rejected values leave the BOM unchanged. It fails when.
+// the file has no entry for the model.
+func (e *Enricher) ApplyAnswersFile(bom *cdx.BOM, file *AnswersFile) (AnswersReport, error) {
+	if bom == nil {
+		return AnswersReport{}, fmt.Errorf("nil BOM")
+	}
+	e.auditLog, e.unsetOld = nil, make(map[string]string)
+
+	report := AnswersReport{ModelID: extractModelID(bom)}
+	var entries []ModelAnswers
+	for _, m := range file.Models {
+		if m.ModelID == AnswersWildcard {
+			entries = append([]ModelAnswers{m}, entries...)
+		} else if report.ModelID != "" && strings.EqualFold(m.ModelID, report.ModelID) {
+			entries = append(entries, m)
+		}
+	}
+	if len(entries) == 0 {
+		return report, apperr.Userf("answers file %s has no entry for model %q (or %q)", file.Path, report.ModelID, AnswersWildcard)
+	}
+
+	if _, err := e.applyUnset(bom); err != nil {
+		return report, err
+	}
+
+	// Resolve every value to a field first, so that a value for the same.
+	// field of the same component given later replaces the earlier one.
+	var ops []answerOp
+	reject := func(comp string, dataset bool, v AnswerValue, field, reason string) {
+		report.Results = append(report.Results, AnswerResult{Component: comp, Dataset: dataset, Field: field, Value: v.Value, Line: v.Line, Reason: reason})
+	}
+	model := bomComponent(bom)
+	for _, entry := range entries {
+		for _, v := range entry.Fields {
+			key := normalizeAnswerKey(v.Key)
+			if spec, ok := findAnswerSpec(key); ok {
+				ops = append(ops, answerOp{comp: model, model: spec, value: v})
+				continue
+			}
+			spec, ok := findDatasetSpec(key)
+			if !ok {
+				reject(report.ModelID, false, v, v.Key, "unknown field")
+				continue
+			}
+			for _, comp := range e.scopedDatasets(bom) {
+				ops = append(ops, answerOp{comp: comp, dataset: true, data: spec, value: v})
+			}
+		}
+		for _, ds := range entry.Datasets {
+			comps := e.scopedDatasets(bom)
+			if ds.Name != AnswersWildcard {
+				comps = datasetsNamed(comps, ds.Name)
+			}
+			for _, v := range ds.Fields {
+				key := normalizeAnswerKey(v.Key)
+				spec, ok := findDatasetSpec(key)
+				if !ok {
+					spec, ok = findDatasetSpec(normalizeAnswerKey("dataset." + v.Key))
+				}
+				switch {
+				case !ok:
+					reject(ds.Name, true, v, v.Key, "unknown dataset field")
+				case len(comps) == 0 && ds.Name != AnswersWildcard:
+					reject(ds.Name, true, v, string(spec.Key), "no dataset component of that name")
+				}
+				if !ok {
+					continue
+				}
+				for _, comp := range comps {
+					ops = append(ops, answerOp{comp: comp, dataset: true, data: spec, value: v})
+				}
+			}
+		}
+	}
+
+	last := make(map[string]int, len(ops))
+	for i, op := range ops {
+		last[auditKey(op.comp, op.field())] = i
+	}
+	for i, op := range ops {
+		if last[auditKey(op.comp, op.field())] != i {
+			continue
+		}
+		report.Results = append(report.Results, e.applyAnswer(bom, op))
+	}
+	sort.SliceStable(report.Results, func(i, j int) bool {
+		return report.Results[i].Line < report.Results[j].Line
+	})
+	return report, nil
+}
+
+// applyAnswer applies one resolved value and records it in the audit log.
+func (e *Enricher) applyAnswer(bom *cdx.BOM, op answerOp) AnswerResult {
+	res := AnswerResult{Dataset: op.dataset, Field: op.field(), Value: op.value.Value, Line: op.value.Line}
+	if op.comp != nil {
+		res.Component = op.comp.Name
+	}
+	if op.value.Value == "" {
+		res.Reason = "empty value"
+		return res
+	}
+
+	allowed, inputType := e.config.Options.model(op.model.Key), op.model.InputType
+	if op.dataset {
+		allowed, inputType = e.config.Options.dataset(op.data.Key), op.data.InputType
+	}
+	if err := e.checkOption(res.Field, allowed, res.Value, inputType); err != nil {
+		res.Reason = err.Error()
+		return res
+	}
+
+	before := audit.Snapshot(op.comp)
+	var err error
+	if op.dataset {
+		err = metadata.ApplyDatasetUserValue(op.data, res.Value, metadata.DatasetTarget{Component: op.comp})
+	} else {
+		err = metadata.ApplyUserValue(op.model, res.Value, metadata.Target{
+			BOM:                bom,
+			Component:          op.comp,
+			ModelCard:          bomModelCard(bom),
+			HuggingFaceBaseURL: e.config.HFBaseURL,
+		})
+	}
+	if err != nil {
+		res.Reason = err.Error()
+		return res
+	}
+	res.Applied = true
+
+	old := e.unsetOld[auditKey(op.comp, res.Field)]
+	if o := audit.Removed(before, op.comp); o != "" {
+		old = o
+		review.Reset(op.comp, res.Field)
+	}
+	e.auditLog = append(e.auditLog, e.auditEntry(bom, op.comp, op.dataset, audit.Entry{
+		Field:  res.Field,
+		Old:    old,
+		New:    res.Value,
+		Source: audit.SourceAnswersFile,
+	}))
+	return res
+}
+
+// findAnswerSpec is findModelSpec extended to the fields that accept user.
+// values without counting towards completeness, such as the contacts.
+func findAnswerSpec(key string) (metadata.FieldSpec, bool) {
+	if spec, ok := findModelSpec(key); ok {
+		return spec, true
+	}
+	for _, spec := range metadata.Registry() {
+		if spec.Parse == nil || spec.Apply == nil {
+			continue
+		}
+		for _, form := range modelKeyForms(spec.Key) {
+			if normalizeAnswerKey(form) == key {
+				return spec, true
+			}
+		}
+	}
+	return metadata.FieldSpec{}, false
+}
+
+func datasetsNamed(comps []*cdx.Component, name string) []*cdx.Component {
+	var out []*cdx.Component
+	for _, comp := range comps {
+		if strings.EqualFold(comp.Name, name) {
+			out = append(out, comp)
+		}
+	}
+	return out
+}
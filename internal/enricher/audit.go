@@ -0,0 +1,107 @@
+package enricher
+
+import (
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/internal/audit"
+	"github.com/idlab-discover/aibomgen-cli/internal/metadata"
+)
+
+// AuditEntries returns the fields set or removed by the last Enrich call, in.
+// the order they were applied.
+func (e *Enricher) AuditEntries() []audit.Entry {
+	return e.auditLog
+}
+
+// auditUnset records a field removed by --unset. before is the snapshot of.
+// comp taken before the field was cleared.
+func (e *Enricher) auditUnset(bom *cdx.BOM, comp *cdx.Component, dataset bool, field string, before any) {
+	old := audit.Removed(before, comp)
+	e.unsetOld[auditKey(comp, field)] = old
+	e.auditLog = append(e.auditLog, e.auditEntry(bom, comp, dataset, audit.Entry{
+		Field:  field,
+		Old:    old,
+		Source: audit.SourceUnset,
+	}))
+}
+
+// auditChanges records the values applied to the model and dataset.
+// components. Fields removed by --unset earlier in the run keep their old.
+// value.
+func (e *Enricher) auditChanges(bom *cdx.BOM, modelChanges map[metadata.Key]string, datasetChanges map[string]map[metadata.DatasetKey]string) {
+	model := bomComponent(bom)
+	for _, spec := range metadata.Registry() {
+		value, ok := modelChanges[spec.Key]
+		if !ok {
+			continue
+		}
+		_, answered := e.config.Answers.model(spec.Key)
+		_, resumed := e.resume.model(spec.Key)
+		e.auditLog = append(e.auditLog, e.auditEntry(bom, model, false, audit.Entry{
+			Field:  string(spec.Key),
+			Old:    e.unsetOld[auditKey(model, string(spec.Key))],
+			New:    value,
+			Source: e.auditSource(answered, resumed),
+		}))
+	}
+
+	if bom.Components == nil {
+		return
+	}
+	for i := range *bom.Components {
+		comp := &(*bom.Components)[i]
+		changes := datasetChanges[comp.Name]
+		if comp.Type != cdx.ComponentTypeData || len(changes) == 0 {
+			continue
+		}
+		for _, spec := range metadata.DatasetRegistry() {
+			value, ok := changes[spec.Key]
+			if !ok {
+				continue
+			}
+			_, answered := e.config.Answers.dataset(spec.Key)
+			_, resumed := e.resume.dataset(comp.Name, spec.Key)
+			e.auditLog = append(e.auditLog, e.auditEntry(bom, comp, true, audit.Entry{
+				Field:  string(spec.Key),
+				Old:    e.unsetOld[auditKey(comp, string(spec.Key))],
+				New:    value,
+				Source: e.auditSource(answered, resumed),
+			}))
+		}
+	}
+}
+
+// auditSource mirrors the precedence of applyAnswers: answers, then the.
+// resumed draft, then the prompt.
+func (e *Enricher) auditSource(answered, resumed bool) string {
+	switch {
+	case e.config.Strategy == "file":
+		return audit.SourceFile
+	case answered:
+		return audit.SourceAnswer
+	case resumed:
+		return audit.SourceDraft
+	default:
+		return audit.SourceInteractive
+	}
+}
+
+func (e *Enricher) auditEntry(bom *cdx.BOM, comp *cdx.Component, dataset bool, entry audit.Entry) audit.Entry {
+	entry.Time = time.Now().UTC()
+	entry.Actor = e.config.Actor
+	entry.SerialNumber = bom.SerialNumber
+	entry.Dataset = dataset
+	if comp != nil {
+		entry.Component = comp.Name
+		entry.BOMRef = comp.BOMRef
+	}
+	return entry
+}
+
+func auditKey(comp *cdx.Component, field string) string {
+	if comp == nil {
+		return field
+	}
+	return comp.BOMRef + "\x00" + comp.Name + "\x00" + field
+}
@@ -0,0 +1,116 @@
+package enricher
+
+import (
+	"encoding/json"
+	"fmt"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/internal/audit"
+	"github.com/idlab-discover/aibomgen-cli/internal/metadata"
+)
+
+// CheckClaimedValue reports whether bom still holds what a signed field claim.
+// states: value for field of the model component, or of the dataset component.
+// with bomRef (or named component) when dataset is true, or no value at all.
+// when removed is true. The field holds value when applying value again to a.
+// copy of the BOM changes nothing.
+func CheckClaimedValue(bom *cdx.BOM, dataset bool, component, bomRef, field, value string, removed bool) error {
+	if dataset {
+		return checkDatasetValue(bom, component, bomRef, field, value, removed)
+	}
+	if bomComponent(bom) == nil {
+		return fmt.Errorf("BOM has no model component")
+	}
+	for _, spec := range metadata.Registry() {
+		if string(spec.Key) != field {
+			continue
+		}
+		if err := checkPresence(spec.Present(bom), removed); err != nil || removed {
+			return err
+		}
+		var cp cdx.BOM
+		if err := clone(bom, &cp); err != nil {
+			return err
+		}
+		err := metadata.ApplyUserValue(spec, value, metadata.Target{
+			BOM:       &cp,
+			Component: bomComponent(&cp),
+			ModelCard: bomModelCard(&cp),
+		})
+		if err != nil {
+			return fmt.Errorf("signed value %q: %w", value, err)
+		}
+		return compareClaimed(bom, &cp)
+	}
+	return fmt.Errorf("unknown field %s", field)
+}
+
+func checkDatasetValue(bom *cdx.BOM, component, bomRef, field, value string, removed bool) error {
+	var comp *cdx.Component
+	if bom.Components != nil {
+		for i := range *bom.Components {
+			c := &(*bom.Components)[i]
+			if c.Type == cdx.ComponentTypeData && (bomRef != "" && c.BOMRef == bomRef || bomRef == "" && c.Name == component) {
+				comp = c
+				break
+			}
+		}
+	}
+	if comp == nil {
+		return fmt.Errorf("dataset %q is no longer in the BOM", component)
+	}
+	for _, spec := range metadata.DatasetRegistry() {
+		if string(spec.Key) != field {
+			continue
+		}
+		if err := checkPresence(spec.Present(comp), removed); err != nil || removed {
+			return err
+		}
+		var cp cdx.Component
+		if err := clone(comp, &cp); err != nil {
+			return err
+		}
+		if err := metadata.ApplyDatasetUserValue(spec, value, metadata.DatasetTarget{Component: &cp}); err != nil {
+			return fmt.Errorf("signed value %q: %w", value, err)
+		}
+		return compareClaimed(comp, &cp)
+	}
+	return fmt.Errorf("unknown dataset field %s", field)
+}
+
+// checkPresence compares whether a claimed field is set with the claim.
+func checkPresence(present, removed bool) error {
+	switch {
+	case removed && present:
+		return fmt.Errorf("field was removed but is set again")
+	case !removed && !present:
+		return fmt.Errorf("field has been removed")
+	}
+	return nil
+}
+
+// compareClaimed reports an error when want, current with the signed value.
+// applied again, differs from current.
+func compareClaimed(current, want any) error {
+	a, errA := json.Marshal(current)
+	b, errB := json.Marshal(want)
+	if errA != nil || errB != nil {
+		return fmt.Errorf("cannot compare the field value")
+	}
+	if string(a) == string(b) {
+		return nil
+	}
+	if changed := audit.Removed(current, want); changed != "" {
+		return fmt.Errorf("current value differs from the signed one (%s)", changed)
+	}
+	return fmt.Errorf("current value differs from the signed one")
+}
+
+// clone deep-copies src into dst through JSON.
+func clone(src, dst any) error {
+	data, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dst)
+}
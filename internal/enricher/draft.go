@@ -0,0 +1,169 @@
+package enricher
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"charm.land/huh/v2"
+	"github.com/idlab-discover/aibomgen-cli/internal/metadata"
+	"github.com/idlab-discover/aibomgen-cli/internal/ui"
+)
+
+// Draft holds answers entered in an interactive session that was quit before.
+// the enriched BOM was saved. It is keyed by the input BOM so the next.
+// invocation on the same file can resume where the user left off.
+type Draft struct {
+	Saved    time.Time                    `json:"saved"`
+	Model    map[string]string            `json:"model,omitempty"`
+	Datasets map[string]map[string]string `json:"datasets,omitempty"`
+}
+
+// DraftPath returns the draft file location for the BOM at input.
+func DraftPath(input string) (string, error) {
+	abs, err := filepath.Abs(input)
+	if err != nil {
+		return "", err
+	}
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(abs))
+	return filepath.Join(base, "aibomgen-cli", "drafts", hex.EncodeToString(sum[:8])+".json"), nil
+}
+
+// LoadDraft reads the draft at path. A missing file yields nil and no error.
+func LoadDraft(path string) (*Draft, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	d := new(Draft)
+	if err := json.Unmarshal(data, d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Save writes the draft to path, creating parent directories as needed.
+func (d *Draft) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	d.Saved = time.Now().UTC()
+	data, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// Len returns the number of saved values.
+func (d *Draft) Len() int {
+	if d == nil {
+		return 0
+	}
+	n := len(d.Model)
+	for _, ds := range d.Datasets {
+		n += len(ds)
+	}
+	return n
+}
+
+func (d *Draft) model(key metadata.Key) (string, bool) {
+	if d == nil {
+		return "", false
+	}
+	v, ok := d.Model[string(key)]
+	return v, ok && v != ""
+}
+
+func (d *Draft) dataset(name string, key metadata.DatasetKey) (string, bool) {
+	if d == nil {
+		return "", false
+	}
+	v, ok := d.Datasets[name][string(key)]
+	return v, ok && v != ""
+}
+
+func (d *Draft) setModel(key metadata.Key, value string) {
+	if d.Model == nil {
+		d.Model = make(map[string]string)
+	}
+	d.Model[string(key)] = value
+}
+
+func (d *Draft) setDataset(name string, key metadata.DatasetKey, value string) {
+	if d.Datasets == nil {
+		d.Datasets = make(map[string]map[string]string)
+	}
+	if d.Datasets[name] == nil {
+		d.Datasets[name] = make(map[string]string)
+	}
+	d.Datasets[name][string(key)] = value
+}
+
+// loadDraft asks whether to resume the draft at Config.DraftPath, if any.
+// Declining discards the draft.
+func (e *Enricher) loadDraft() {
+	if e.config.DraftPath == "" {
+		return
+	}
+	d, err := LoadDraft(e.config.DraftPath)
+	if err != nil {
+		fmt.Fprintf(e.writer, "warning: ignoring unreadable enrichment draft %s: %v\n", e.config.DraftPath, err)
+		return
+	}
+	if d.Len() == 0 {
+		return
+	}
+
+	resume := true
+	err = huh.NewConfirm().
+		Title("Resume previous enrichment session?").
+		Description(fmt.Sprintf("%d value(s) saved %s", d.Len(), d.Saved.Local().Format("2006-01-02 15:04"))).
+		Affirmative("Resume").
+		Negative("Start over").
+		Value(&resume).
+		Run()
+	if err != nil {
+		return
+	}
+	if !resume {
+		e.DiscardDraft()
+		return
+	}
+	e.resume = d
+}
+
+// saveDraft persists the values entered so far so the next run can resume.
+func (e *Enricher) saveDraft() {
+	if e.config.DraftPath == "" || e.session.Len() == 0 {
+		return
+	}
+	if err := e.session.Save(e.config.DraftPath); err != nil {
+		fmt.Fprintf(e.writer, "warning: failed to save enrichment draft: %v\n", err)
+		return
+	}
+	fmt.Fprintf(e.writer, "%s Draft saved (%d value(s)); run the same command again to resume.\n", ui.GetInfoMark(), e.session.Len())
+}
+
+// DiscardDraft removes the session draft. Call it once the enriched BOM has.
+// been written.
+func (e *Enricher) DiscardDraft() {
+	if e.config.DraftPath == "" {
+		return
+	}
+	if err := os.Remove(e.config.DraftPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		fmt.Fprintf(e.writer, "warning: failed to remove enrichment draft: %v\n", err)
+	}
+}
@@ -4,11 +4,15 @@ import (
 	"bufio"
 	"fmt"
 	"io"
+	"net/http"
+	"sort"
 	"strings"
 	"time"
 
+	"charm.land/huh/v2"
 	cdx "github.com/CycloneDX/cyclonedx-go"
 	"github.com/idlab-discover/aibomgen-cli/internal/apperr"
+	"github.com/idlab-discover/aibomgen-cli/internal/audit"
 	"github.com/idlab-discover/aibomgen-cli/internal/fetcher"
 	"github.com/idlab-discover/aibomgen-cli/internal/metadata"
 	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/completeness"
@@ -16,16 +20,27 @@ import (
 
 // Config holds enrichment configuration.
 type Config struct {
-	Strategy     string  // "interactive" or "file"
-	ConfigFile   string  // path to config file (for file strategy)
-	RequiredOnly bool    // only enrich required fields
-	MinWeight    float64 // minimum weight threshold
-	Refetch      bool    // refetch from Hugging Face
-	NoPreview    bool    // skip preview
-	SpecVersion  string  // CycloneDX spec version
-	HFToken      string  // Hugging Face token
-	HFBaseURL    string  // Hugging Face base URL
-	HFTimeout    int     // timeout in seconds
+	Strategy      string       // "interactive" or "file"
+	ConfigFile    string       // path to config file (for file strategy)
+	RequiredOnly  bool         // only enrich required fields
+	MinWeight     float64      // minimum weight threshold
+	Refetch       bool         // refetch from Hugging Face
+	NoPreview     bool         // skip preview
+	SpecVersion   string       // CycloneDX spec version
+	HFToken       string       // Hugging Face token
+	HFBaseURL     string       // Hugging Face base URL
+	HFTimeout     int          // timeout in seconds
+	Answers       Answers      // pre-supplied values that skip interactive prompts
+	DraftPath     string       // interactive session draft file ("" disables drafts)
+	HistoryPath   string       // answers history file ("" disables prior-answer suggestions)
+	Options       FieldOptions // canonical option lists per field key
+	StrictOptions bool         // only accept configured options as values
+	TargetScore   float64      // completeness policy threshold shown in the live preview (0: none)
+	Datasets      []string     // only enrich these dataset components (empty: all, or ask when interactive)
+	SkipModel     bool         // do not enrich the model component
+	SkipDatasets  bool         // do not enrich dataset components
+	Unset         []string     // field keys to remove from the BOM before enrichment
+	Actor         string       // person recorded in audit entries
 }
 
 // Options for creating an Enricher.
@@ -41,15 +56,40 @@ type Enricher struct {
 	writer io.Writer
 	config Config
 	scan   *bufio.Scanner
+
+	// resume holds values from a previous, unfinished session that the user.
+	// chose to resume; session collects the values entered in this one.
+	resume  *Draft
+	session *Draft
+	// history offers values used for the same fields on other models.
+	history *History
+
+	// auditLog collects the fields set or removed by the current run; unsetOld.
+	// keeps the removed values so that re-entered fields show old and new.
+	auditLog []audit.Entry
+	unsetOld map[string]string
+
+	// client is the Hugging Face client of refetches, created on first use.
+	client *http.Client
+}
+
+// hfClient returns the Hugging Face client of the enricher, so that all.
+// refetches of a run go through one client and its connections.
+func (e *Enricher) hfClient() *http.Client {
+	if e.client == nil {
+		e.client = fetcher.NewHFClient(time.Duration(e.config.HFTimeout)*time.Second, e.config.HFToken)
+	}
+	return e.client
 }
 
 // New creates a new Enricher.
 func New(opts Options) *Enricher {
 	return &Enricher{
-		reader: opts.Reader,
-		writer: opts.Writer,
-		config: opts.Config,
-		scan:   bufio.NewScanner(opts.Reader),
+		reader:  opts.Reader,
+		writer:  opts.Writer,
+		config:  opts.Config,
+		scan:    bufio.NewScanner(opts.Reader),
+		session: &Draft{},
 	}
 }
 
@@ -58,6 +98,7 @@ func (e *Enricher) Enrich(bom *cdx.BOM, configViper interface{}) (*cdx.BOM, erro
 	if bom == nil {
 		return nil, fmt.Errorf("nil BOM")
 	}
+	e.auditLog, e.unsetOld = nil, make(map[string]string)
 
 	// Get model ID from BOM.
 	modelID := extractModelID(bom)
@@ -65,6 +106,12 @@ func (e *Enricher) Enrich(bom *cdx.BOM, configViper interface{}) (*cdx.BOM, erro
 		fmt.Fprintf(e.writer, "warning: no model ID found in BOM; enrichment will proceed without a model ID\n")
 	}
 
+	// Offer to resume an unfinished interactive session on this BOM.
+	if e.config.Strategy == "interactive" {
+		e.loadDraft()
+		e.loadHistory()
+	}
+
 	// Run initial completeness check.
 	initialResult := completeness.Check(bom)
 
@@ -88,27 +135,43 @@ func (e *Enricher) Enrich(bom *cdx.BOM, configViper interface{}) (*cdx.BOM, erro
 		postRefetchResult = initialResult
 	}
 
-	// STEP 1: Enrich model fields.
-	modelChanges, err := e.enrichModel(bom, modelID, hfAPI, hfReadme, postRefetchResult, configViper)
+	// Remove fields the user asked to unset, after refetch so a wrong value.
+	// from Hugging Face is not put straight back. Cleared fields count as.
+	// missing again and are offered for enrichment.
+	cleared, err := e.applyUnset(bom)
 	if err != nil {
-		return nil, fmt.Errorf("failed to enrich model: %w", err)
+		return nil, err
+	}
+	if cleared > 0 {
+		postRefetchResult = completeness.Check(bom)
+	}
+
+	// STEP 1: Enrich model fields.
+	var modelChanges map[metadata.Key]string
+	if !e.config.SkipModel {
+		modelChanges, err = e.enrichModel(bom, modelID, hfAPI, hfReadme, postRefetchResult, configViper)
+		if err != nil {
+			return nil, fmt.Errorf("failed to enrich model: %w", err)
+		}
 	}
 
 	// STEP 2: Enrich dataset components if they exist.
 	datasetChanges := make(map[string]map[metadata.DatasetKey]string)
-	if bom.Components != nil {
-		for i := range *bom.Components {
-			comp := &(*bom.Components)[i]
-			if comp.Type == cdx.ComponentTypeData {
-				dsChanges, err := e.enrichDataset(bom, comp, configViper)
-				if err != nil {
-					fmt.Fprintf(e.writer, "warning: failed to enrich dataset %q: %v\n", comp.Name, err)
-					continue
-				}
-				if len(dsChanges) > 0 {
-					datasetChanges[comp.Name] = dsChanges
-				}
-			}
+	var datasets []*cdx.Component
+	if !e.config.SkipDatasets {
+		datasets, err = e.selectDatasets(bom)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, comp := range datasets {
+		dsChanges, err := e.enrichDataset(bom, comp, configViper)
+		if err != nil {
+			fmt.Fprintf(e.writer, "warning: failed to enrich dataset %q: %v\n", comp.Name, err)
+			continue
+		}
+		if len(dsChanges) > 0 {
+			datasetChanges[comp.Name] = dsChanges
 		}
 	}
 
@@ -119,9 +182,11 @@ func (e *Enricher) Enrich(bom *cdx.BOM, configViper interface{}) (*cdx.BOM, erro
 			return nil, fmt.Errorf("preview error: %w", err)
 		}
 		if !confirm {
+			e.saveDraft()
 			return nil, apperr.ErrCancelled
 		}
 	}
+	e.auditChanges(bom, modelChanges, datasetChanges)
 
 	return bom, nil
 }
@@ -186,6 +251,9 @@ func (e *Enricher) enrichModelFromFile(
 		}
 
 		if value != nil {
+			if err := e.checkOption(string(spec.Key), e.config.Options.model(spec.Key), formatValue(value), spec.InputType); err != nil {
+				return nil, err
+			}
 			err = e.applyValue(spec, &src, &tgt, value)
 			if err != nil {
 				return nil, err
@@ -213,6 +281,9 @@ func (e *Enricher) enrichDatasetFromFile(
 		}
 
 		if value != nil {
+			if err := e.checkOption(string(spec.Key), e.config.Options.dataset(spec.Key), formatValue(value), spec.InputType); err != nil {
+				return nil, err
+			}
 			err = e.applyDatasetValue(spec, &src, &tgt, value)
 			if err != nil {
 				return nil, fmt.Errorf("failed to apply value for %s: %w", spec.Key, err)
@@ -224,6 +295,76 @@ func (e *Enricher) enrichDatasetFromFile(
 	return changes, nil
 }
 
+// selectDatasets returns the dataset components to enrich. Config.Datasets.
+// restricts the set by name; otherwise an interactive run with more than one.
+// incomplete dataset asks the user to pick them.
+func (e *Enricher) selectDatasets(bom *cdx.BOM) ([]*cdx.Component, error) {
+	if bom.Components == nil {
+		return nil, nil
+	}
+
+	var all []*cdx.Component
+	for i := range *bom.Components {
+		if comp := &(*bom.Components)[i]; comp.Type == cdx.ComponentTypeData {
+			all = append(all, comp)
+		}
+	}
+
+	if len(e.config.Datasets) > 0 {
+		var selected []*cdx.Component
+		for _, name := range e.config.Datasets {
+			found := false
+			for _, comp := range all {
+				if strings.EqualFold(comp.Name, strings.TrimSpace(name)) {
+					selected = append(selected, comp)
+					found = true
+				}
+			}
+			if !found {
+				fmt.Fprintf(e.writer, "warning: dataset %q not found in BOM\n", name)
+			}
+		}
+		return selected, nil
+	}
+
+	if e.config.Strategy != "interactive" {
+		return all, nil
+	}
+
+	// Only offer datasets that still have fields to fill in.
+	var incomplete []*cdx.Component
+	var options []huh.Option[int]
+	for _, comp := range all {
+		missing := len(e.collectMissingDatasetFields(completeness.CheckDataset(comp)))
+		if missing == 0 {
+			continue
+		}
+		label := fmt.Sprintf("%s (%d missing)", comp.Name, missing)
+		options = append(options, huh.NewOption(label, len(incomplete)).Selected(true))
+		incomplete = append(incomplete, comp)
+	}
+	if len(incomplete) <= 1 {
+		return incomplete, nil
+	}
+
+	var picked []int
+	err := huh.NewMultiSelect[int]().
+		Title("Datasets to enrich").
+		Description("Space toggles a dataset, Enter continues.").
+		Options(options...).
+		Value(&picked).
+		Run()
+	if err != nil {
+		return nil, err
+	}
+	sort.Ints(picked) // keep BOM order
+	selected := make([]*cdx.Component, 0, len(picked))
+	for _, i := range picked {
+		selected = append(selected, incomplete[i])
+	}
+	return selected, nil
+}
+
 // enrichDataset enriches a single dataset component.
 func (e *Enricher) enrichDataset(bom *cdx.BOM, comp *cdx.Component, configViper interface{}) (map[metadata.DatasetKey]string, error) {
 	datasetID := comp.Name
@@ -316,7 +457,7 @@ func (e *Enricher) collectMissingFields(result completeness.Result) []metadata.F
 
 // refetchMetadata fetches fresh metadata from Hugging Face.
 func (e *Enricher) refetchMetadata(modelID string) (*fetcher.ModelAPIResponse, *fetcher.ModelReadmeCard) {
-	client := fetcher.NewHFClient(time.Duration(e.config.HFTimeout)*time.Second, e.config.HFToken)
+	client := e.hfClient()
 
 	apiResp, err := (&fetcher.ModelAPIFetcher{
 		Client:  client,
@@ -483,7 +624,7 @@ func (e *Enricher) collectMissingDatasetFields(result completeness.DatasetResult
 
 // refetchDatasetMetadata fetches fresh metadata for a dataset from Hugging Face.
 func (e *Enricher) refetchDatasetMetadata(datasetID string) (*fetcher.DatasetAPIResponse, *fetcher.DatasetReadmeCard) {
-	client := fetcher.NewHFClient(time.Duration(e.config.HFTimeout)*time.Second, e.config.HFToken)
+	client := e.hfClient()
 
 	apiResp, err := (&fetcher.DatasetAPIFetcher{
 		Client:  client,
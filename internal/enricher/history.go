@@ -0,0 +1,149 @@
+package enricher
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+const (
+	// maxPriorAnswers is the number of previously used values offered per field.
+	maxPriorAnswers = 5
+	// maxHistoryValueLen skips long free-text answers that are unlikely to be.
+	// reused verbatim.
+	maxHistoryValueLen = 200
+)
+
+// History records values previously entered per field key so they can be.
+// offered again when enriching other models. Pointing several users at the.
+// same history file shares answers across an organization.
+type History struct {
+	Fields map[string][]HistoryEntry `json:"fields"`
+}
+
+// HistoryEntry is one previously used value for a field.
+type HistoryEntry struct {
+	Value    string    `json:"value"`
+	Count    int       `json:"count"`
+	LastUsed time.Time `json:"lastUsed"`
+}
+
+// DefaultHistoryPath returns the per-user answers history location.
+func DefaultHistoryPath() (string, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "aibomgen-cli", "answers-history.json"), nil
+}
+
+// LoadHistory reads the history at path. A missing file yields an empty history.
+func LoadHistory(path string) (*History, error) {
+	h := &History{Fields: make(map[string][]HistoryEntry)}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return h, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, h); err != nil {
+		return nil, err
+	}
+	if h.Fields == nil {
+		h.Fields = make(map[string][]HistoryEntry)
+	}
+	return h, nil
+}
+
+// Save writes the history to path, creating parent directories as needed.
+func (h *History) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// Record notes that value was used for key.
+func (h *History) Record(key, value string) {
+	if h == nil || value == "" || len(value) > maxHistoryValueLen {
+		return
+	}
+	now := time.Now().UTC()
+	entries := h.Fields[key]
+	for i := range entries {
+		if entries[i].Value == value {
+			entries[i].Count++
+			entries[i].LastUsed = now
+			return
+		}
+	}
+	h.Fields[key] = append(entries, HistoryEntry{Value: value, Count: 1, LastUsed: now})
+}
+
+// Suggest returns up to n values previously used for key, most used first.
+func (h *History) Suggest(key string, n int) []string {
+	if h == nil || len(h.Fields[key]) == 0 {
+		return nil
+	}
+	entries := append([]HistoryEntry(nil), h.Fields[key]...)
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Count != entries[j].Count {
+			return entries[i].Count > entries[j].Count
+		}
+		return entries[i].LastUsed.After(entries[j].LastUsed)
+	})
+	if len(entries) > n {
+		entries = entries[:n]
+	}
+	values := make([]string, len(entries))
+	for i, e := range entries {
+		values[i] = e.Value
+	}
+	return values
+}
+
+// loadHistory opens the answers history at Config.HistoryPath, if configured.
+func (e *Enricher) loadHistory() {
+	if e.config.HistoryPath == "" {
+		return
+	}
+	h, err := LoadHistory(e.config.HistoryPath)
+	if err != nil {
+		fmt.Fprintf(e.writer, "warning: ignoring unreadable answers history %s: %v\n", e.config.HistoryPath, err)
+		return
+	}
+	e.history = h
+}
+
+// RecordHistory adds the values entered in this session to the answers.
+// history. Call it once the enriched BOM has been written.
+func (e *Enricher) RecordHistory() {
+	if e.history == nil || e.session.Len() == 0 {
+		return
+	}
+	for key, value := range e.session.Model {
+		e.history.Record(key, value)
+	}
+	for _, fields := range e.session.Datasets {
+		for key, value := range fields {
+			e.history.Record(key, value)
+		}
+	}
+	if err := e.history.Save(e.config.HistoryPath); err != nil {
+		fmt.Fprintf(e.writer, "warning: failed to save answers history: %v\n", err)
+	}
+}
+
+// priorAnswers returns previously used values for key.
+func (e *Enricher) priorAnswers(key string) []string {
+	return e.history.Suggest(key, maxPriorAnswers)
+}
@@ -4,12 +4,14 @@ package enricher
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"charm.land/huh/v2"
 	cdx "github.com/CycloneDX/cyclonedx-go"
 	"github.com/idlab-discover/aibomgen-cli/internal/metadata"
 	"github.com/idlab-discover/aibomgen-cli/internal/ui"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/completeness"
 )
 
 // InteractiveEnricher provides a form-based interactive enrichment experience.
@@ -35,6 +37,17 @@ func (ie *InteractiveEnricher) EnrichInteractive(
 		return nil, nil
 	}
 
+	// Apply pre-supplied answers (--answer, AIBOMGEN_ANSWER_*) and resumed.
+	// draft values, and only prompt for the remaining fields.
+	changes := make(map[metadata.Key]string)
+	missingFields = ie.applyAnswers(missingFields, tgt, changes)
+	for k, v := range changes {
+		ie.enricher.session.setModel(k, v)
+	}
+	if len(missingFields) == 0 {
+		return changes, nil
+	}
+
 	// Storage for form values - use map of pointers.
 	valueStore := make(map[metadata.Key]*string)
 	for _, spec := range missingFields {
@@ -42,40 +55,64 @@ func (ie *InteractiveEnricher) EnrichInteractive(
 		valueStore[spec.Key] = &val
 	}
 
-	// Create form groups - one form with all fields.
+	// Create form groups - one form with all sections.
 	formGroups := []*huh.Group{}
 
 	// Add intro note.
 	formGroups = append(formGroups, huh.NewGroup(
 		huh.NewNote().
 			Title("Model Enrichment").
-			Description("Please provide values for the missing fields.\nPress Enter to skip optional fields.").
+			Description("Please provide values for the missing fields.\nFields are grouped into sections; skip a whole section or press Enter to skip optional fields.").
 			Next(true).
 			NextLabel("Continue"),
 	))
 
-	// Create inputs for each field.
+	// Create inputs for each field, grouped into skippable sections.
+	sections := newFormSections(modelSectionOrder)
+
+	// Show the projected completeness score at the top of every page.
+	preview := scorePreview{
+		label:   "Completeness",
+		current: completeness.Check(bom).Score,
+		max:     modelWeightTotal(),
+		target:  ie.enricher.config.TargetScore,
+		filled: func() float64 {
+			var w float64
+			for _, spec := range missingFields {
+				if strings.TrimSpace(*valueStore[spec.Key]) != "" {
+					w += spec.Weight
+				}
+			}
+			return w
+		},
+	}
+	sections.header = func() huh.Field { return preview.note(valueStore) }
+
 	for _, spec := range missingFields {
-		fieldInputs := ie.createFieldInput(spec, src, valueStore[spec.Key])
-		if len(fieldInputs) > 0 {
-			formGroups = append(formGroups, huh.NewGroup(fieldInputs...))
-		}
+		sections.add(modelSection(spec.Key), ie.createFieldInput(spec, src, valueStore[spec.Key])...)
 	}
+	formGroups = append(formGroups, sections.groups()...)
 
 	// Skip if no inputs were created.
 	if len(formGroups) <= 1 {
-		return nil, nil
+		return changes, nil
 	}
 
 	// Create and run form.
 	form := huh.NewForm(formGroups...)
 	err := form.Run()
 	if err != nil {
+		// Keep whatever was typed before the form was quit.
+		for _, spec := range missingFields {
+			if v := *valueStore[spec.Key]; v != "" {
+				ie.enricher.session.setModel(spec.Key, v)
+			}
+		}
+		ie.enricher.saveDraft()
 		return nil, err
 	}
 
 	// Now read the values from the pointers and apply them.
-	changes := make(map[metadata.Key]string)
 	for _, spec := range missingFields {
 		strValue := *valueStore[spec.Key]
 		if strValue == "" {
@@ -89,11 +126,36 @@ func (ie *InteractiveEnricher) EnrichInteractive(
 			continue
 		}
 		changes[spec.Key] = strValue
+		ie.enricher.session.setModel(spec.Key, strValue)
 	}
 
 	return changes, nil
 }
 
+// applyAnswers applies pre-supplied answers, falling back to resumed draft.
+// values, to the model fields they match, records them in changes and returns.
+// the fields that still need a prompt. Values the field rejects are ignored so.
+// the user is prompted instead.
+func (ie *InteractiveEnricher) applyAnswers(fields []metadata.FieldSpec, tgt metadata.Target, changes map[metadata.Key]string) []metadata.FieldSpec {
+	remaining := fields[:0:0]
+	for _, spec := range fields {
+		v, ok := ie.enricher.config.Answers.model(spec.Key)
+		if !ok {
+			v, ok = ie.enricher.resume.model(spec.Key)
+		}
+		if ok {
+			if err := ie.enricher.checkOption(string(spec.Key), ie.enricher.config.Options.model(spec.Key), v, spec.InputType); err != nil {
+				fmt.Fprintf(ie.enricher.writer, "warning: ignoring pre-supplied value: %v\n", err)
+			} else if err := metadata.ApplyUserValue(spec, v, tgt); err == nil {
+				changes[spec.Key] = v
+				continue
+			}
+		}
+		remaining = append(remaining, spec)
+	}
+	return remaining
+}
+
 // createFieldInput creates form inputs for a field spec.
 func (ie *InteractiveEnricher) createFieldInput(
 	spec metadata.FieldSpec,
@@ -101,17 +163,27 @@ func (ie *InteractiveEnricher) createFieldInput(
 	valuePtr *string,
 ) []huh.Field {
 	var inputs []huh.Field
+	placeholder := placeholderFor(spec.Placeholder, spec.Help)
+
+	// Configured options turn select fields (and strictly enforced text.
+	// fields) into a fixed choice list.
+	if allowed := ie.enricher.config.Options.model(spec.Key); len(allowed) > 0 {
+		title := ie.formatTitle(spec.Key, spec.Weight, spec.Required)
+		if field := ie.createOptionsInput(title, placeholder, spec.InputType, spec.Required, allowed, string(spec.Key), valuePtr); field != nil {
+			return append(inputs, field)
+		}
+	}
 
 	// Create appropriate input based on spec.InputType.
 	switch spec.InputType {
 	case metadata.InputTypeTextArea:
-		inputs = append(inputs, ie.createTextAreaInput(spec.Key, spec.Required, spec.Weight, spec.Placeholder, spec.Suggestions, spec.Sources, src, valuePtr))
+		inputs = append(inputs, ie.createTextAreaInput(spec.Key, spec.Required, spec.Weight, placeholder, spec.Suggestions, spec.Sources, src, valuePtr))
 	case metadata.InputTypeSelect:
-		inputs = append(inputs, ie.createSelectInput(spec.Key, spec.Required, spec.Weight, spec.Placeholder, spec.Suggestions, spec.Sources, src, valuePtr))
+		inputs = append(inputs, ie.createSelectInput(spec.Key, spec.Required, spec.Weight, placeholder, spec.Suggestions, spec.Sources, src, valuePtr))
 	case metadata.InputTypeMultiText:
-		inputs = append(inputs, ie.createMultiTextInput(spec.Key, spec.Required, spec.Weight, spec.Placeholder, spec.Suggestions, spec.Sources, src, valuePtr))
+		inputs = append(inputs, ie.createMultiTextInput(spec.Key, spec.Required, spec.Weight, placeholder, spec.Suggestions, spec.Sources, src, valuePtr))
 	default: // InputTypeText
-		inputs = append(inputs, ie.createTextInput(spec.Key, spec.Required, spec.Weight, spec.Placeholder, spec.Suggestions, spec.Sources, src, valuePtr))
+		inputs = append(inputs, ie.createTextInput(spec.Key, spec.Required, spec.Weight, placeholder, spec.Suggestions, spec.Sources, src, valuePtr))
 	}
 
 	return inputs
@@ -134,12 +206,15 @@ func (ie *InteractiveEnricher) createTextInput(
 	}
 
 	description := ie.formatDescription(suggestions, placeholder, false)
+	prior := ie.enricher.priorAnswers(string(key))
+	description = withPriorAnswers(description, prior, true)
 	title := ie.formatTitle(key, weight, required)
 
 	input := huh.NewInput().
 		Title(title).
-		Description(description).
+		Description(withFieldHelp(string(key), description)).
 		Placeholder(placeholder).
+		Suggestions(prior).
 		Value(valuePtr).
 		Validate(func(s string) error {
 			if required && strings.TrimSpace(s) == "" {
@@ -167,12 +242,15 @@ func (ie *InteractiveEnricher) createMultiTextInput(
 	}
 
 	description := ie.formatDescription(suggestions, placeholder, true)
+	prior := ie.enricher.priorAnswers(string(key))
+	description = withPriorAnswers(description, prior, true)
 	title := ie.formatTitle(key, weight, required)
 
 	input := huh.NewInput().
 		Title(title).
-		Description(description).
+		Description(withFieldHelp(string(key), description)).
 		Placeholder(placeholder).
+		Suggestions(prior).
 		Value(valuePtr).
 		Validate(func(s string) error {
 			if required && strings.TrimSpace(s) == "" {
@@ -201,10 +279,12 @@ func (ie *InteractiveEnricher) createTextAreaInput(
 
 	title := ie.formatTitle(key, weight, required)
 	description := ie.formatDescription(suggestions, placeholder, false)
+	prior := ie.enricher.priorAnswers(string(key))
+	description = withPriorAnswers(description, prior, false)
 
 	input := huh.NewText().
 		Title(title).
-		Description(description).
+		Description(withFieldHelp(string(key), description)).
 		Placeholder(placeholder).
 		Value(valuePtr).
 		Lines(5).
@@ -240,10 +320,16 @@ func (ie *InteractiveEnricher) createSelectInput(
 	for _, suggestion := range suggestions {
 		options = append(options, huh.NewOption(suggestion, suggestion))
 	}
+	// Offer previously used values that are not already listed.
+	for _, v := range ie.enricher.priorAnswers(string(key)) {
+		if !slices.Contains(suggestions, v) {
+			options = append(options, huh.NewOption(v+" (previously used)", v))
+		}
+	}
 
 	input := huh.NewSelect[string]().
 		Title(title).
-		Description(placeholder).
+		Description(withFieldHelp(string(key), placeholder)).
 		Options(options...).
 		Value(valuePtr).
 		Validate(func(s string) error {
@@ -256,8 +342,126 @@ func (ie *InteractiveEnricher) createSelectInput(
 	return input
 }
 
+// createOptionsInput builds an input from the configured options of a field.
+// Select fields, and text fields under strict options, become a select over.
+// the options. Other text and multi-value fields keep a text input with the.
+// options as Tab completions and, when strict, reject values outside them.
+// It returns nil for text areas, which keep their regular input.
+func (ie *InteractiveEnricher) createOptionsInput(
+	title string,
+	placeholder string,
+	inputType metadata.InputType,
+	required bool,
+	allowed []string,
+	key string,
+	valuePtr *string,
+) huh.Field {
+	strict := ie.enricher.config.StrictOptions
+
+	switch {
+	case inputType == metadata.InputTypeSelect || (strict && inputType == metadata.InputTypeText):
+		options := make([]huh.Option[string], 0, len(allowed))
+		for _, v := range allowed {
+			options = append(options, huh.NewOption(v, v))
+		}
+		if !strict {
+			for _, v := range ie.enricher.priorAnswers(key) {
+				if !slices.Contains(allowed, v) {
+					options = append(options, huh.NewOption(v+" (previously used)", v))
+				}
+			}
+		}
+		if !required {
+			options = append(options, huh.NewOption(ui.Muted.Render("(skip)"), ""))
+		}
+		return huh.NewSelect[string]().
+			Title(title).
+			Description(withFieldHelp(key, placeholder)).
+			Options(options...).
+			Value(valuePtr)
+
+	case inputType == metadata.InputTypeText || inputType == metadata.InputTypeMultiText:
+		multi := inputType == metadata.InputTypeMultiText
+		description := ui.Dim.Render("Options: ") + strings.Join(allowed, ", ")
+		if multi {
+			description += " • " + ui.Muted.Render("Enter comma-separated values")
+		}
+		description += ui.Muted.Render(" (Tab to complete)")
+		completions := slices.Clone(allowed)
+		for _, v := range ie.enricher.priorAnswers(key) {
+			if !slices.Contains(completions, v) {
+				completions = append(completions, v)
+			}
+		}
+		return huh.NewInput().
+			Title(title).
+			Description(withFieldHelp(key, description)).
+			Placeholder(placeholder).
+			Suggestions(completions).
+			Value(valuePtr).
+			Validate(func(s string) error {
+				if required && strings.TrimSpace(s) == "" {
+					return fmt.Errorf("this field is required")
+				}
+				if strict && !permits(allowed, s, multi) {
+					return fmt.Errorf("only these values are allowed: %s", strings.Join(allowed, ", "))
+				}
+				return nil
+			})
+	}
+	return nil
+}
+
 // Helper methods.
 
+// placeholderFor returns the input placeholder of a field, falling back to.
+// its documented example.
+func placeholderFor(placeholder string, help metadata.FieldHelp) string {
+	if placeholder == "" && help.Example != "" {
+		return "e.g., " + help.Example
+	}
+	return placeholder
+}
+
+// withFieldHelp puts the documented meaning of a field in front of its form.
+// description and its CycloneDX path after it, read out in plain language.
+// (Model › Model card › Considerations) so the focused field explains itself.
+func withFieldHelp(key, description string) string {
+	help, ok := metadata.LookupHelp(key)
+	if !ok {
+		return description
+	}
+	parts := []string{help.Description}
+	if description != "" {
+		parts = append(parts, description)
+	}
+	if help.SpecPath != "" {
+		path := help.SpecPath
+		if e, ok := metadata.ExplainPath(key); ok && e.Location != "" {
+			path += " (" + e.Location + ")"
+		}
+		parts = append(parts, ui.Muted.Render("CycloneDX: ")+path)
+	}
+	return strings.Join(parts, " • ")
+}
+
+// withPriorAnswers appends previously used values to a field description.
+// Text inputs can complete them with Tab; text areas only list them.
+func withPriorAnswers(description string, prior []string, completable bool) string {
+	if len(prior) == 0 {
+		return description
+	}
+	list := strings.Join(prior, ", ")
+	if len(list) > 60 {
+		list = list[:60] + "..."
+	}
+	description += " • " + ui.Dim.Render("Previously used: ") + list
+	if completable {
+		description += ui.Muted.Render(" (Tab to complete)")
+	}
+	return description
+}
+
 func (ie *InteractiveEnricher) formatTitle(key metadata.Key, weight float64, required bool) string {
 	requiredLabel := ""
 	if required {
@@ -354,6 +558,17 @@ func (ie *InteractiveEnricher) EnrichDatasetInteractive(
 		return nil, nil
 	}
 
+	// Apply pre-supplied answers (--answer, AIBOMGEN_ANSWER_*) and resumed.
+	// draft values, and only prompt for the remaining fields.
+	changes := make(map[metadata.DatasetKey]string)
+	missingFields = ie.applyAnswersToDataset(comp.Name, missingFields, tgt, changes)
+	for k, v := range changes {
+		ie.enricher.session.setDataset(comp.Name, k, v)
+	}
+	if len(missingFields) == 0 {
+		return changes, nil
+	}
+
 	// Storage for form values - use map of pointers.
 	valueStore := make(map[metadata.DatasetKey]*string)
 	for _, spec := range missingFields {
@@ -368,33 +583,57 @@ func (ie *InteractiveEnricher) EnrichDatasetInteractive(
 	formGroups = append(formGroups, huh.NewGroup(
 		huh.NewNote().
 			Title(fmt.Sprintf("Dataset Enrichment: %s", comp.Name)).
-			Description("Please provide values for the missing dataset fields.\nPress Enter to skip optional fields.").
+			Description("Please provide values for the missing dataset fields.\nFields are grouped into sections; skip a whole section or press Enter to skip optional fields.").
 			Next(true).
 			NextLabel("Continue"),
 	))
 
-	// Create inputs for each field.
+	// Create inputs for each field, grouped into skippable sections.
+	sections := newFormSections(datasetSectionOrder)
+
+	// Show the projected dataset completeness score at the top of every page.
+	preview := scorePreview{
+		label:   "Dataset completeness",
+		current: completeness.CheckDataset(comp).Score,
+		max:     datasetWeightTotal(),
+		target:  ie.enricher.config.TargetScore,
+		filled: func() float64 {
+			var w float64
+			for _, spec := range missingFields {
+				if strings.TrimSpace(*valueStore[spec.Key]) != "" {
+					w += spec.Weight
+				}
+			}
+			return w
+		},
+	}
+	sections.header = func() huh.Field { return preview.note(valueStore) }
+
 	for _, spec := range missingFields {
-		fieldInputs := ie.createDatasetFieldInput(spec, src, valueStore[spec.Key])
-		if len(fieldInputs) > 0 {
-			formGroups = append(formGroups, huh.NewGroup(fieldInputs...))
-		}
+		sections.add(datasetSection(spec.Key), ie.createDatasetFieldInput(spec, src, valueStore[spec.Key])...)
 	}
+	formGroups = append(formGroups, sections.groups()...)
 
 	// Skip if no inputs were created.
 	if len(formGroups) <= 1 {
-		return nil, nil
+		return changes, nil
 	}
 
 	// Create and run form.
 	form := huh.NewForm(formGroups...)
 	err := form.Run()
 	if err != nil {
+		// Keep whatever was typed before the form was quit.
+		for _, spec := range missingFields {
+			if v := *valueStore[spec.Key]; v != "" {
+				ie.enricher.session.setDataset(comp.Name, spec.Key, v)
+			}
+		}
+		ie.enricher.saveDraft()
 		return nil, err
 	}
 
 	// Now read the values from the pointers and apply them.
-	changes := make(map[metadata.DatasetKey]string)
 	for _, spec := range missingFields {
 		strValue := *valueStore[spec.Key]
 		if strValue == "" {
@@ -408,11 +647,33 @@ func (ie *InteractiveEnricher) EnrichDatasetInteractive(
 			continue
 		}
 		changes[spec.Key] = strValue
+		ie.enricher.session.setDataset(comp.Name, spec.Key, strValue)
 	}
 
 	return changes, nil
 }
 
+// applyAnswersToDataset is the dataset counterpart of applyAnswers.
+func (ie *InteractiveEnricher) applyAnswersToDataset(name string, fields []metadata.DatasetFieldSpec, tgt metadata.DatasetTarget, changes map[metadata.DatasetKey]string) []metadata.DatasetFieldSpec {
+	remaining := fields[:0:0]
+	for _, spec := range fields {
+		v, ok := ie.enricher.config.Answers.dataset(spec.Key)
+		if !ok {
+			v, ok = ie.enricher.resume.dataset(name, spec.Key)
+		}
+		if ok {
+			if err := ie.enricher.checkOption(string(spec.Key), ie.enricher.config.Options.dataset(spec.Key), v, spec.InputType); err != nil {
+				fmt.Fprintf(ie.enricher.writer, "warning: ignoring pre-supplied value: %v\n", err)
+			} else if err := metadata.ApplyDatasetUserValue(spec, v, tgt); err == nil {
+				changes[spec.Key] = v
+				continue
+			}
+		}
+		remaining = append(remaining, spec)
+	}
+	return remaining
+}
+
 // createDatasetFieldInput creates form inputs for a dataset field spec.
 func (ie *InteractiveEnricher) createDatasetFieldInput(
 	spec metadata.DatasetFieldSpec,
@@ -420,17 +681,27 @@ func (ie *InteractiveEnricher) createDatasetFieldInput(
 	valuePtr *string,
 ) []huh.Field {
 	var inputs []huh.Field
+	placeholder := placeholderFor(spec.Placeholder, spec.Help)
+
+	// Configured options turn select fields (and strictly enforced text.
+	// fields) into a fixed choice list.
+	if allowed := ie.enricher.config.Options.dataset(spec.Key); len(allowed) > 0 {
+		title := ie.formatDatasetTitle(spec.Key, spec.Weight, spec.Required)
+		if field := ie.createOptionsInput(title, placeholder, spec.InputType, spec.Required, allowed, string(spec.Key), valuePtr); field != nil {
+			return append(inputs, field)
+		}
+	}
 
 	// Create appropriate input based on spec.InputType.
 	switch spec.InputType {
 	case metadata.InputTypeTextArea:
-		inputs = append(inputs, ie.createDatasetTextAreaInput(spec.Key, spec.Required, spec.Weight, spec.Placeholder, spec.Suggestions, spec.Sources, src, valuePtr))
+		inputs = append(inputs, ie.createDatasetTextAreaInput(spec.Key, spec.Required, spec.Weight, placeholder, spec.Suggestions, spec.Sources, src, valuePtr))
 	case metadata.InputTypeSelect:
-		inputs = append(inputs, ie.createDatasetSelectInput(spec.Key, spec.Required, spec.Weight, spec.Placeholder, spec.Suggestions, spec.Sources, src, valuePtr))
+		inputs = append(inputs, ie.createDatasetSelectInput(spec.Key, spec.Required, spec.Weight, placeholder, spec.Suggestions, spec.Sources, src, valuePtr))
 	case metadata.InputTypeMultiText:
-		inputs = append(inputs, ie.createDatasetMultiTextInput(spec.Key, spec.Required, spec.Weight, spec.Placeholder, spec.Suggestions, spec.Sources, src, valuePtr))
+		inputs = append(inputs, ie.createDatasetMultiTextInput(spec.Key, spec.Required, spec.Weight, placeholder, spec.Suggestions, spec.Sources, src, valuePtr))
 	default: // InputTypeText
-		inputs = append(inputs, ie.createDatasetTextInput(spec.Key, spec.Required, spec.Weight, spec.Placeholder, spec.Suggestions, spec.Sources, src, valuePtr))
+		inputs = append(inputs, ie.createDatasetTextInput(spec.Key, spec.Required, spec.Weight, placeholder, spec.Suggestions, spec.Sources, src, valuePtr))
 	}
 
 	return inputs
@@ -453,11 +724,14 @@ func (ie *InteractiveEnricher) createDatasetTextInput(
 
 	title := ie.formatDatasetTitle(key, weight, required)
 	description := ie.formatDescription(suggestions, placeholder, false)
+	prior := ie.enricher.priorAnswers(string(key))
+	description = withPriorAnswers(description, prior, true)
 
 	input := huh.NewInput().
 		Title(title).
-		Description(description).
+		Description(withFieldHelp(string(key), description)).
 		Placeholder(placeholder).
+		Suggestions(prior).
 		Value(valuePtr).
 		Validate(func(s string) error {
 			if required && strings.TrimSpace(s) == "" {
@@ -485,11 +759,14 @@ func (ie *InteractiveEnricher) createDatasetMultiTextInput(
 
 	title := ie.formatDatasetTitle(key, weight, required)
 	description := ie.formatDescription(suggestions, placeholder, true)
+	prior := ie.enricher.priorAnswers(string(key))
+	description = withPriorAnswers(description, prior, true)
 
 	input := huh.NewInput().
 		Title(title).
-		Description(description).
+		Description(withFieldHelp(string(key), description)).
 		Placeholder(placeholder).
+		Suggestions(prior).
 		Value(valuePtr).
 		Validate(func(s string) error {
 			if required && strings.TrimSpace(s) == "" {
@@ -517,10 +794,12 @@ func (ie *InteractiveEnricher) createDatasetTextAreaInput(
 
 	title := ie.formatDatasetTitle(key, weight, required)
 	description := ie.formatDescription(suggestions, placeholder, false)
+	prior := ie.enricher.priorAnswers(string(key))
+	description = withPriorAnswers(description, prior, false)
 
 	input := huh.NewText().
 		Title(title).
-		Description(description).
+		Description(withFieldHelp(string(key), description)).
 		Placeholder(placeholder).
 		Value(valuePtr).
 		Lines(5).
@@ -549,10 +828,16 @@ func (ie *InteractiveEnricher) createDatasetSelectInput(
 	for _, suggestion := range suggestions {
 		options = append(options, huh.NewOption(suggestion, suggestion))
 	}
+	// Offer previously used values that are not already listed.
+	for _, v := range ie.enricher.priorAnswers(string(key)) {
+		if !slices.Contains(suggestions, v) {
+			options = append(options, huh.NewOption(v+" (previously used)", v))
+		}
+	}
 
 	input := huh.NewSelect[string]().
 		Title(title).
-		Description(placeholder).
+		Description(withFieldHelp(string(key), placeholder)).
 		Options(options...).
 		Value(valuePtr).
 		Validate(func(s string) error {
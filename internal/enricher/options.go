@@ -0,0 +1,117 @@
+package enricher
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/idlab-discover/aibomgen-cli/internal/apperr"
+	"github.com/idlab-discover/aibomgen-cli/internal/metadata"
+)
+
+// FieldOptions holds canonical option lists per field key (e.g. approved.
+// license identifiers or internal team names for the manufacturer). Keys are.
+// normalized like Answers keys.
+type FieldOptions map[string][]string
+
+// ParseFieldOptions builds FieldOptions from the raw "enrich.options" config.
+// value. Keys may be nested YAML maps or dotted/underscored strings, so.
+// "dataset.licenses" and {dataset: {licenses: [...]}} are equivalent.
+func ParseFieldOptions(raw any) (FieldOptions, error) {
+	opts := make(FieldOptions)
+	if raw == nil {
+		return opts, nil
+	}
+	if err := flattenOptions(opts, "", raw); err != nil {
+		return nil, err
+	}
+	return opts, nil
+}
+
+func flattenOptions(opts FieldOptions, prefix string, raw any) error {
+	switch v := raw.(type) {
+	case map[string]any:
+		for k, child := range v {
+			if err := flattenOptions(opts, joinOptionKey(prefix, k), child); err != nil {
+				return err
+			}
+		}
+	case map[any]any:
+		for k, child := range v {
+			if err := flattenOptions(opts, joinOptionKey(prefix, fmt.Sprint(k)), child); err != nil {
+				return err
+			}
+		}
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s := strings.TrimSpace(fmt.Sprint(item)); s != "" {
+				values = append(values, s)
+			}
+		}
+		opts[normalizeAnswerKey(prefix)] = values
+	case []string:
+		opts[normalizeAnswerKey(prefix)] = v
+	default:
+		return apperr.Userf("enrich.options.%s must be a list of values", prefix)
+	}
+	return nil
+}
+
+func joinOptionKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+// model returns the configured options for a model field.
+func (o FieldOptions) model(key metadata.Key) []string {
+	return o.lookup(modelKeyForms(key))
+}
+
+// dataset returns the configured options for a dataset field.
+func (o FieldOptions) dataset(key metadata.DatasetKey) []string {
+	return o.lookup(datasetKeyForms(key))
+}
+
+func (o FieldOptions) lookup(keys []string) []string {
+	for _, k := range keys {
+		if v := o[normalizeAnswerKey(k)]; len(v) > 0 {
+			return v
+		}
+	}
+	return nil
+}
+
+// permits reports whether value is acceptable for a field with the given.
+// options under strict enforcement. Multi-value fields are checked item by.
+// item. Fields without options accept anything.
+func permits(allowed []string, value string, multi bool) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	items := []string{value}
+	if multi {
+		items = strings.Split(value, ",")
+	}
+	for _, item := range items {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		if !slices.ContainsFunc(allowed, func(a string) bool { return strings.EqualFold(a, item) }) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkOption returns an error when strict options are enforced and value is.
+// not one of allowed.
+func (e *Enricher) checkOption(key string, allowed []string, value string, inputType metadata.InputType) error {
+	if !e.config.StrictOptions || permits(allowed, value, inputType == metadata.InputTypeMultiText) {
+		return nil
+	}
+	return fmt.Errorf("%q is not an allowed value for %s (allowed: %s)", value, key, strings.Join(allowed, ", "))
+}
@@ -0,0 +1,87 @@
+package enricher
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/huh/v2"
+	"github.com/idlab-discover/aibomgen-cli/internal/metadata"
+	"github.com/idlab-discover/aibomgen-cli/internal/ui"
+)
+
+// scorePreview projects the completeness score while a form is being filled.
+// in. Each non-empty answer adds its field weight to the score earned so far.
+type scorePreview struct {
+	label   string
+	current float64 // score before the form, 0..1
+	max     float64 // total weight of all scored fields
+	target  float64 // policy threshold, 0 when unset
+	// filled returns the total weight of the fields answered so far.
+	filled func() float64
+}
+
+// projected returns the score if the current answers are applied.
+func (p scorePreview) projected() float64 {
+	if p.max <= 0 {
+		return p.current
+	}
+	return min(p.current+p.filled()/p.max, 1)
+}
+
+// String renders the preview line.
+func (p scorePreview) String() string {
+	now := p.projected()
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s → %s", p.label, ui.Dim.Render(fmt.Sprintf("%.1f%%", p.current*100)), renderScore(now, p.target))
+	if gain := (now - p.current) * 100; gain > 0.05 {
+		b.WriteString(ui.Success.Render(fmt.Sprintf(" (+%.1f)", gain)))
+	}
+	if p.target > 0 {
+		if now >= p.target {
+			b.WriteString(" · " + ui.Success.Render(fmt.Sprintf("✓ target %.0f%% reached", p.target*100)))
+		} else {
+			b.WriteString(" · " + ui.Muted.Render(fmt.Sprintf("target %.0f%% (%.1f to go)", p.target*100, (p.target-now)*100)))
+		}
+	}
+	return b.String()
+}
+
+// note returns a form note that re-renders the preview whenever one of the.
+// bound values changes.
+func (p scorePreview) note(bindings any) huh.Field {
+	return huh.NewNote().TitleFunc(p.String, bindings)
+}
+
+func renderScore(score, target float64) string {
+	s := fmt.Sprintf("%.1f%%", score*100)
+	switch {
+	case target > 0 && score >= target, target <= 0 && score >= 0.8:
+		return ui.Success.Render(s)
+	case score >= 0.5:
+		return ui.Warning.Render(s)
+	default:
+		return ui.Error.Render(s)
+	}
+}
+
+// modelWeightTotal returns the total weight of all scored model fields.
+func modelWeightTotal() float64 {
+	var total float64
+	for _, spec := range metadata.Registry() {
+		if spec.Weight > 0 {
+			total += spec.Weight
+		}
+	}
+	return total
+}
+
+// datasetWeightTotal returns the total weight of all scored dataset fields.
+func datasetWeightTotal() float64 {
+	var total float64
+	for _, spec := range metadata.DatasetRegistry() {
+		if spec.Weight > 0 {
+			total += spec.Weight
+		}
+	}
+	return total
+}
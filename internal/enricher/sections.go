@@ -0,0 +1,123 @@
+package enricher
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/huh/v2"
+	"github.com/idlab-discover/aibomgen-cli/internal/metadata"
+)
+
+// fieldsPerPage caps how many inputs share one form page.
+const fieldsPerPage = 5
+
+// Form sections, in the order they are presented.
+const (
+	sectionIdentity      = "Identity"
+	sectionLicensing     = "Licensing"
+	sectionModelCard     = "Model card"
+	sectionEnvironmental = "Environmental"
+	sectionData          = "Data"
+	sectionOther         = "Other"
+)
+
+var (
+	modelSectionOrder   = []string{sectionIdentity, sectionLicensing, sectionModelCard, sectionEnvironmental, sectionOther}
+	datasetSectionOrder = []string{sectionIdentity, sectionLicensing, sectionData, sectionOther}
+)
+
+// modelSection returns the form section of a model field.
+func modelSection(key metadata.Key) string {
+	k := string(key)
+	switch {
+	case strings.Contains(k, ".licenses"):
+		return sectionLicensing
+	case strings.Contains(k, ".environmentalConsiderations"):
+		return sectionEnvironmental
+	case strings.Contains(k, ".modelCard."), strings.Contains(k, ":fairness:"), strings.Contains(k, ":weights:"), strings.Contains(k, ":aiact:"):
+		return sectionModelCard
+	case strings.HasPrefix(k, "BOM.metadata.component."):
+		return sectionIdentity
+	default:
+		return sectionOther
+	}
+}
+
+// datasetSection returns the form section of a dataset field.
+func datasetSection(key metadata.DatasetKey) string {
+	k := string(key)
+	switch {
+	case strings.Contains(k, ".licenses"):
+		return sectionLicensing
+	case strings.Contains(k, ".data."), strings.Contains(k, ":data:"):
+		return sectionData
+	case strings.HasPrefix(k, "BOM.components[DATA]."):
+		return sectionIdentity
+	default:
+		return sectionOther
+	}
+}
+
+// formSections groups form inputs by section so long forms can be navigated.
+// (and skipped) section by section instead of one field at a time.
+type formSections struct {
+	order  []string
+	fields map[string][]huh.Field
+	// header, when set, builds a field shown at the top of every page (the.
+	// live completeness preview).
+	header func() huh.Field
+}
+
+func newFormSections(order []string) *formSections {
+	return &formSections{order: order, fields: make(map[string][]huh.Field)}
+}
+
+func (s *formSections) add(section string, fields ...huh.Field) {
+	s.fields[section] = append(s.fields[section], fields...)
+}
+
+// groups returns the form groups for all non-empty sections. Each section.
+// starts with a page offering to fill it in or skip it, followed by its.
+// inputs in pages of at most fieldsPerPage. Titles show the section position.
+// so users can see how far along they are.
+func (s *formSections) groups() []*huh.Group {
+	var names []string
+	for _, name := range s.order {
+		if len(s.fields[name]) > 0 {
+			names = append(names, name)
+		}
+	}
+
+	var groups []*huh.Group
+	for i, name := range names {
+		fields := s.fields[name]
+		progress := fmt.Sprintf("Section %d/%d", i+1, len(names))
+
+		fill := true
+		groups = append(groups, huh.NewGroup(
+			huh.NewConfirm().
+				Title(fmt.Sprintf("%s · %s", progress, name)).
+				Description(fmt.Sprintf("%d field(s) to review", len(fields))).
+				Affirmative("Fill in").
+				Negative("Skip section").
+				Value(&fill),
+		))
+
+		pages := (len(fields) + fieldsPerPage - 1) / fieldsPerPage
+		for p := 0; p < pages; p++ {
+			end := min((p+1)*fieldsPerPage, len(fields))
+			page := fields[p*fieldsPerPage : end : end]
+			title := fmt.Sprintf("%s · %s", progress, name)
+			if pages > 1 {
+				title += fmt.Sprintf(" (page %d/%d)", p+1, pages)
+			}
+			if s.header != nil {
+				page = append([]huh.Field{s.header()}, page...)
+			}
+			groups = append(groups, huh.NewGroup(page...).
+				Title(title).
+				WithHideFunc(func() bool { return !fill }))
+		}
+	}
+	return groups
+}
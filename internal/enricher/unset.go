@@ -0,0 +1,141 @@
+package enricher
+
+import (
+	"fmt"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/internal/apperr"
+	"github.com/idlab-discover/aibomgen-cli/internal/audit"
+	"github.com/idlab-discover/aibomgen-cli/internal/metadata"
+	"github.com/idlab-discover/aibomgen-cli/internal/review"
+)
+
+// applyUnset removes the fields named in Config.Unset from bom. Keys use the.
+// same forms as --answer; dataset keys apply to the datasets named in.
+// Config.Datasets, or to every dataset component when none are named. It.
+// returns the number of fields that were present and have been removed.
+func (e *Enricher) applyUnset(bom *cdx.BOM) (int, error) {
+	if len(e.config.Unset) == 0 {
+		return 0, nil
+	}
+
+	tgt := metadata.Target{
+		BOM:       bom,
+		Component: bomComponent(bom),
+		ModelCard: bomModelCard(bom),
+	}
+
+	cleared := 0
+	for _, raw := range e.config.Unset {
+		key := normalizeAnswerKey(raw)
+		if key == "" {
+			continue
+		}
+
+		if spec, ok := findModelSpec(key); ok {
+			if !spec.Present(bom) {
+				continue
+			}
+			before := audit.Snapshot(tgt.Component)
+			if err := metadata.ClearUserValue(spec, tgt); err != nil {
+				return cleared, apperr.Userf("cannot unset %q: %v", raw, err)
+			}
+			e.auditUnset(bom, tgt.Component, false, string(spec.Key), before)
+			review.Reset(tgt.Component, string(spec.Key))
+			fmt.Fprintf(e.writer, "cleared %s\n", spec.Key)
+			cleared++
+			continue
+		}
+
+		spec, ok := findDatasetSpec(key)
+		if !ok {
+			return cleared, apperr.Userf("unknown field %q for --unset", raw)
+		}
+		for _, comp := range e.scopedDatasets(bom) {
+			if !spec.Present(comp) {
+				continue
+			}
+			before := audit.Snapshot(comp)
+			if err := metadata.ClearDatasetUserValue(spec, metadata.DatasetTarget{Component: comp}); err != nil {
+				return cleared, apperr.Userf("cannot unset %q: %v", raw, err)
+			}
+			e.auditUnset(bom, comp, true, string(spec.Key), before)
+			review.Reset(comp, string(spec.Key))
+			fmt.Fprintf(e.writer, "cleared %s on dataset %q\n", spec.Key, comp.Name)
+			cleared++
+		}
+	}
+	return cleared, nil
+}
+
+// scopedDatasets returns the dataset components that dataset keys in --unset.
+// and answers files apply to.
+func (e *Enricher) scopedDatasets(bom *cdx.BOM) []*cdx.Component {
+	if bom.Components == nil {
+		return nil
+	}
+	var out []*cdx.Component
+	for i := range *bom.Components {
+		comp := &(*bom.Components)[i]
+		if comp.Type != cdx.ComponentTypeData {
+			continue
+		}
+		if len(e.config.Datasets) > 0 && !containsFold(e.config.Datasets, comp.Name) {
+			continue
+		}
+		out = append(out, comp)
+	}
+	return out
+}
+
+// FieldKey resolves raw, in any of the key forms --answer and --unset accept,.
+// to the registry key of a model field, or of a dataset field when dataset is.
+// true.
+func FieldKey(raw string) (key string, dataset, ok bool) {
+	k := normalizeAnswerKey(raw)
+	if k == "" {
+		return "", false, false
+	}
+	if spec, ok := findModelSpec(k); ok {
+		return string(spec.Key), false, true
+	}
+	if spec, ok := findDatasetSpec(k); ok {
+		return string(spec.Key), true, true
+	}
+	return "", false, false
+}
+
+func findModelSpec(key string) (metadata.FieldSpec, bool) {
+	for _, spec := range metadata.Registry() {
+		if spec.Weight <= 0 {
+			continue
+		}
+		for _, form := range modelKeyForms(spec.Key) {
+			if normalizeAnswerKey(form) == key {
+				return spec, true
+			}
+		}
+	}
+	return metadata.FieldSpec{}, false
+}
+
+func findDatasetSpec(key string) (metadata.DatasetFieldSpec, bool) {
+	for _, spec := range metadata.DatasetRegistry() {
+		for _, form := range datasetKeyForms(spec.Key) {
+			if normalizeAnswerKey(form) == key {
+				return spec, true
+			}
+		}
+	}
+	return metadata.DatasetFieldSpec{}, false
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(strings.TrimSpace(v), s) {
+			return true
+		}
+	}
+	return false
+}
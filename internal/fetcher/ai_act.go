@@ -0,0 +1,88 @@
+package fetcher
+
+import (
+	"regexp"
+	"strings"
+)
+
+// RiskClassifications are the risk classes of the EU AI Act, from most to.
+// least restricted.
+var RiskClassifications = []string{"prohibited", "high", "limited", "minimal"}
+
+// riskClassRe matches a risk class as cards state it: "high-risk",.
+// "minimal risk", "Risk class: limited".
+var riskClassRe = regexp.MustCompile(`(?i)\b(?:(prohibited|unacceptable|high|limited|minimal)[\s-]+risk\b|risk\s+(?:class(?:ification)?|category|level)\s*\**\s*[:|]\s*\**\s*(prohibited|unacceptable|high|limited|minimal)\b)`)
+
+// ParseRiskClassification returns the EU AI Act risk class stated in s, or.
+// "" when it states none. "Unacceptable risk" is the prohibited class.
+func ParseRiskClassification(s string) string {
+	m := riskClassRe.FindStringSubmatch(s)
+	if m == nil {
+		return ""
+	}
+	class := strings.ToLower(m[1] + m[2])
+	if class == "unacceptable" {
+		class = "prohibited"
+	}
+	return class
+}
+
+// firstSection returns the first non-empty section of markdown under one of.
+// headings.
+func firstSection(markdown string, headings ...string) string {
+	for _, h := range headings {
+		if s := strings.TrimSpace(extractSection(markdown, h)); s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+// listItems returns the bullet items of markdown, or the whole text as a.
+// single statement when it has none.
+func listItems(markdown string) []string {
+	var items []string
+	for _, line := range strings.Split(markdown, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "- ") || strings.HasPrefix(line, "* ") {
+			if s := clipStatement(line); s != "" {
+				items = append(items, s)
+			}
+		}
+	}
+	if len(items) == 0 {
+		if s := clipStatement(markdown); s != "" {
+			items = append(items, s)
+		}
+	}
+	return items
+}
+
+// parseAIAct fills the fields of card the EU AI Act asks providers to.
+// document: the intended users, deployment context and human oversight.
+// measures from their front matter keys or sections, and the risk class from.
+// the "ai_act_risk" front matter key or an "EU AI Act" or "Risk.
+// Classification" section.
+func parseAIAct(card *ModelReadmeCard) {
+	fm, body := card.FrontMatter, card.Body
+
+	card.IntendedUsers = stringSliceFromAny(fm["intended_users"])
+	if len(card.IntendedUsers) == 0 {
+		card.IntendedUsers = listItems(firstSection(body, "Intended Users", "Primary Intended Users", "Users"))
+	}
+
+	card.DeploymentContext = clipStatement(stringFromAny(fm["deployment_context"]))
+	if card.DeploymentContext == "" {
+		card.DeploymentContext = clipStatement(firstSection(body, "Deployment Context", "Deployment"))
+	}
+
+	card.HumanOversight = clipStatement(stringFromAny(fm["human_oversight"]))
+	if card.HumanOversight == "" {
+		card.HumanOversight = clipStatement(firstSection(body, "Human Oversight", "Human Oversight Measures"))
+	}
+
+	card.RiskClassification = ParseRiskClassification(stringFromAny(fm["ai_act_risk"]) + " risk")
+	if card.RiskClassification == "" {
+		card.RiskClassification = ParseRiskClassification(firstSection(body, "EU AI Act", "Risk Classification"))
+	}
+}
@@ -0,0 +1,67 @@
+package fetcher
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseAIAct(t *testing.T) {
+	card := parseReadmeCard(`---
+license: apache-2.0
+---
+# Triage model
+
+## Intended Users
+
+- **Clinicians** in emergency departments
+- Hospital IT departments
+
+## Deployment Context
+
+Runs inside the hospital network as part of the triage dashboard.
+
+### Human Oversight
+
+A nurse confirms every suggested priority before it is saved.
+
+## EU AI Act
+
+We consider this a high-risk system under Annex III.
+`)
+	if want := []string{"Clinicians in emergency departments", "Hospital IT departments"}; !reflect.DeepEqual(card.IntendedUsers, want) {
+		t.Errorf("users = %q, want %q", card.IntendedUsers, want)
+	}
+	if card.DeploymentContext != "Runs inside the hospital network as part of the triage dashboard." {
+		t.Errorf("deployment context = %q", card.DeploymentContext)
+	}
+	if card.HumanOversight != "A nurse confirms every suggested priority before it is saved." {
+		t.Errorf("human oversight = %q", card.HumanOversight)
+	}
+	if card.RiskClassification != "high" {
+		t.Errorf("risk class = %q, want high", card.RiskClassification)
+	}
+
+	card = parseReadmeCard("---\nintended_users: [researchers]\nai_act_risk: unacceptable\n---\n# Model\n\nThe bias section mentions a high risk of misuse.\n")
+	if !reflect.DeepEqual(card.IntendedUsers, []string{"researchers"}) || card.RiskClassification != "prohibited" {
+		t.Errorf("front matter: users = %q, risk class = %q", card.IntendedUsers, card.RiskClassification)
+	}
+
+	// Risk wording outside an AI Act section is not a classification.
+	if card := parseReadmeCard("# Model\n\n## Bias\n\nThere is a high risk of bias.\n"); card.RiskClassification != "" {
+		t.Errorf("risk class from bias section = %q", card.RiskClassification)
+	}
+}
+
+func TestParseRiskClassification(t *testing.T) {
+	for in, want := range map[string]string{
+		"Minimal risk":              "minimal",
+		"limited-risk AI system":    "limited",
+		"Risk classification: High": "high",
+		"unacceptable risk":         "prohibited",
+		"no statement":              "",
+	} {
+		if got := ParseRiskClassification(in); got != want {
+			t.Errorf("ParseRiskClassification(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
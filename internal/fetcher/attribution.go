@@ -0,0 +1,111 @@
+package fetcher
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// copyrightLineRe matches a copyright notice: "Copyright", "(c)" or "©".
+	// followed by a year or a holder, at the start of a line.
+	copyrightLineRe = regexp.MustCompile(`(?i)^(?:copyright\b|\(c\)|©)\s*(?:\(c\)|©)?\s*(.+)$`)
+
+	// copyrightNotNoticeRe matches text after "Copyright" that is prose about.
+	// copyright rather than a notice (the MIT and Apache boilerplate, "Copyright.
+	// holders may ...") and the placeholders of license templates.
+	copyrightNotNoticeRe = regexp.MustCompile(`(?i)^(?:notices?|holders?|owners?|statements?|law|laws|act|and|or|license|licen[cs]e|protection|infringement|the\s|this\s|by\s|of\s|to\s|in\s|is\s|may\s|[:.,;])|\[yyyy\]|\[name of|\{yyyy\}|<year>|\[year\]`)
+
+	// copyrightYearsRe matches the years of a notice (2019, 2019-2024, 2019, 2021).
+	copyrightYearsRe = regexp.MustCompile(`^(?:\d{4}(?:\s*[-–,]\s*(?:\d{4}|present))*\s*,?\s*)+`)
+
+	// allRightsReservedRe matches the trailing "All rights reserved." of a notice.
+	allRightsReservedRe = regexp.MustCompile(`(?i)[,;]?\s*all rights reserved\.?\s*$`)
+
+	// abbreviationEndRe matches a holder ending in an abbreviation whose.
+	// period is not a full stop (Inc., Ltd., et al.).
+	abbreviationEndRe = regexp.MustCompile(`(?i)\b(?:inc|ltd|co|corp|llc|gmbh|plc|al)\.$`)
+
+	// attributionRe matches a sentence asking users to credit the authors.
+	attributionRe = regexp.MustCompile(`(?i)\battribution\s+(?:is\s+)?required\b|\brequired\s+attribution\b|\bplease\s+(?:cite|credit|attribute)\b|\b(?:must|should|shall)\s+(?:be\s+)?(?:credit|attribut)(?:ed|e)?\b|\b(?:must|should|shall)\s+give\s+(?:appropriate\s+)?credit\b|\bwhen\s+using\s+(?:this|the)\s+\w+,?\s+(?:please\s+)?(?:credit|cite|attribute|acknowledge)\b`)
+)
+
+// ParseCopyright returns the copyright notices in text, one per line, in the.
+// order they appear and without duplicates. Lines about copyright that are not.
+// notices (license boilerplate, template placeholders) are skipped.
+func ParseCopyright(text string) []string {
+	var out []string
+	seen := map[string]bool{}
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "-*>#/ \t"))
+		line = strings.ReplaceAll(line, "**", "")
+		m := copyrightLineRe.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		rest := strings.TrimSpace(m[1])
+		if rest == "" || copyrightNotNoticeRe.MatchString(rest) || copyrightHolder(line) == "" {
+			continue
+		}
+		// A bare "(c)" also enumerates list items ("(c) You must retain ..."),.
+		// so it only starts a notice when a year follows.
+		if !strings.HasPrefix(strings.ToLower(line), "copyright") && !copyrightYearsRe.MatchString(rest) {
+			continue
+		}
+		line = clipStatement(line)
+		if !seen[line] {
+			seen[line] = true
+			out = append(out, line)
+		}
+	}
+	return out
+}
+
+// CopyrightHolders returns the holders named by notices, without years,.
+// copyright markers and "All rights reserved".
+func CopyrightHolders(notices []string) []string {
+	var out []string
+	seen := map[string]bool{}
+	for _, n := range notices {
+		h := copyrightHolder(n)
+		if h != "" && !seen[strings.ToLower(h)] {
+			seen[strings.ToLower(h)] = true
+			out = append(out, h)
+		}
+	}
+	return out
+}
+
+// copyrightHolder returns the holder named by the copyright notice n, or "".
+func copyrightHolder(n string) string {
+	m := copyrightLineRe.FindStringSubmatch(strings.TrimSpace(n))
+	if m == nil {
+		return ""
+	}
+	h := strings.TrimSpace(m[1])
+	h = strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(h, "(c)"), "(C)"))
+	h = strings.TrimSpace(strings.TrimPrefix(h, "©"))
+	h = strings.TrimSpace(copyrightYearsRe.ReplaceAllString(h, ""))
+	h = strings.TrimSpace(allRightsReservedRe.ReplaceAllString(h, ""))
+	h = strings.TrimPrefix(strings.TrimSpace(strings.TrimPrefix(h, "by ")), "the ")
+	h = strings.TrimRight(h, " ,;:")
+	if !abbreviationEndRe.MatchString(h) {
+		h = strings.TrimRight(h, ".")
+	}
+	if h == "" || copyrightYearsRe.MatchString(h) {
+		return ""
+	}
+	return h
+}
+
+// parseAttribution returns the attribution the authors of a card require:.
+// the "attribution" front matter key, an "Attribution" section or the first.
+// sentence asking users to credit them, in that order.
+func parseAttribution(fm map[string]any, body string) string {
+	if s := strings.TrimSpace(stringFromAny(fm["attribution"])); s != "" {
+		return clipStatement(s)
+	}
+	if s := clipStatement(extractSection(body, "Attribution")); s != "" {
+		return s
+	}
+	return firstSentence(body, attributionRe)
+}
@@ -0,0 +1,68 @@
+package fetcher
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCopyright(t *testing.T) {
+	text := `MIT License
+
+Copyright (c) 2024 dummy-org
+© 2019, 2021 The Allen Institute for AI. All rights reserved.
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+Copyright [yyyy] [name of copyright owner]
+copyright owner or by an individual or Legal Entity authorized to submit
+      (c) You must retain, in the Source form of any Derivative Works
+Copyright (c) 2024 dummy-org
+Copyright 2024
+`
+	want := []string{
+		"Copyright (c) 2024 dummy-org",
+		"© 2019, 2021 The Allen Institute for AI. All rights reserved.",
+	}
+	got := ParseCopyright(text)
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("notices = %q, want %q", got, want)
+	}
+	if holders := CopyrightHolders(append(got, "Copyright 2023-present Meta Platforms, Inc.")); !reflect.DeepEqual(holders, []string{"dummy-org", "The Allen Institute for AI", "Meta Platforms, Inc."}) {
+		t.Errorf("holders = %q", holders)
+	}
+}
+
+func TestParseAttribution(t *testing.T) {
+	card := parseReadmeCard(`---
+license: cc-by-4.0
+---
+# Model
+
+Trained on news articles.
+
+## Attribution
+
+Please credit **Example Lab** and link to example.org.
+`)
+	if card.Attribution != "Please credit Example Lab and link to example.org." {
+		t.Errorf("attribution section = %q", card.Attribution)
+	}
+
+	ds := parseDatasetReadmeCard("---\nattribution: Data by the City of Ghent\n---\nCopyright 2022 City of Ghent\n")
+	if ds.Attribution != "Data by the City of Ghent" {
+		t.Errorf("front matter attribution = %q", ds.Attribution)
+	}
+	if !reflect.DeepEqual(ds.Copyright, []string{"Copyright 2022 City of Ghent"}) {
+		t.Errorf("dataset copyright = %q", ds.Copyright)
+	}
+
+	ds = parseDatasetReadmeCard("# Corpus\n\nCollected in 2020. When using this dataset, please cite the accompanying paper.\n")
+	if ds.Attribution != "When using this dataset, please cite the accompanying paper." {
+		t.Errorf("attribution sentence = %q", ds.Attribution)
+	}
+
+	if card := parseReadmeCard("# Plain\n\nA classifier.\n"); card.Attribution != "" || len(card.Copyright) != 0 {
+		t.Errorf("plain card = %q, %q", card.Attribution, card.Copyright)
+	}
+}
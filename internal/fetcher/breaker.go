@@ -0,0 +1,144 @@
+package fetcher
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrHubUnreachable is wrapped by the errors of Hugging Face requests made.
+// while the Hub is unreachable: once breakerThreshold requests to a host in.
+// a row failed to connect (a DNS, dial or TLS error, or a gateway error),.
+// further requests to it fail at once instead of each waiting for its timeout and retries. After.
+// breakerCooldown a single request is let through to see whether the Hub is.
+// back.
+var ErrHubUnreachable = errors.New("huggingface hub unreachable")
+
+// breakerThreshold is the number of connection failures in a row after.
+// which a host is considered unreachable. It is above the attempts of one.
+// request with the default retries, so one flaky request does not trip it.
+const breakerThreshold = 5
+
+// breakerCooldown is how long requests to an unreachable host fail at once.
+// before one is let through again.
+var breakerCooldown = 30 * time.Second
+
+// hubBreakers holds the circuit breaker of every host the Hugging Face.
+// clients talk to: fetchers with another BaseURL are unreachable on their own.
+var hubBreakers struct {
+	mu    sync.Mutex
+	hosts map[string]*breaker
+}
+
+// breaker is the circuit breaker of one host.
+type breaker struct {
+	failures int       // connection failures in a row
+	openedAt time.Time // zero while the host is reachable
+	probing  bool      // a request is checking whether the host is back
+	cause    error     // the last connection failure
+}
+
+// hostBreaker returns the breaker of host, creating it on first use.
+func hostBreaker(host string) *breaker {
+	hubBreakers.mu.Lock()
+	defer hubBreakers.mu.Unlock()
+	if hubBreakers.hosts == nil {
+		hubBreakers.hosts = make(map[string]*breaker)
+	}
+	b, ok := hubBreakers.hosts[host]
+	if !ok {
+		b = &breaker{}
+		hubBreakers.hosts[host] = b
+	}
+	return b
+}
+
+// allow reports whether a request to host may be sent, or the error it.
+// fails with while the host is unreachable.
+func (b *breaker) allow(host string) error {
+	hubBreakers.mu.Lock()
+	defer hubBreakers.mu.Unlock()
+	if b.openedAt.IsZero() {
+		return nil
+	}
+	if b.probing || time.Since(b.openedAt) < breakerCooldown {
+		return b.err(host)
+	}
+	b.probing = true
+	return nil
+}
+
+// record notes the outcome of a request sent to host. It returns err, or.
+// an error wrapping ErrHubUnreachable when this failure made the host.
+// unreachable. Requests cancelled or cut short by a deadline leave the.
+// breaker as it was, as they say nothing about the host.
+func (b *breaker) record(host string, resp *http.Response, err error) error {
+	hubBreakers.mu.Lock()
+	defer hubBreakers.mu.Unlock()
+	wasProbing := b.probing
+	b.probing = false
+	switch {
+	case err != nil && !connectionFailure(err):
+		return err
+	case err == nil && !gatewayError(resp):
+		b.failures, b.openedAt, b.cause = 0, time.Time{}, nil
+		return nil
+	}
+	b.failures++
+	if err != nil {
+		b.cause = err
+	} else {
+		b.cause = &HFError{StatusCode: resp.StatusCode}
+	}
+	if b.failures < breakerThreshold && !wasProbing {
+		return err
+	}
+	b.openedAt = time.Now()
+	if err == nil {
+		// The gateway error is still handed back as a response.
+		return nil
+	}
+	return b.err(host)
+}
+
+// err returns the error of requests to host while it is unreachable.
+func (b *breaker) err(host string) error {
+	return fmt.Errorf("%w: %d requests to %s failed in a row, last: %w", ErrHubUnreachable, b.failures, host, b.cause)
+}
+
+// connectionFailure reports whether a transport error means the host could.
+// not be reached: a DNS, dial or TLS handshake failure. Cancelled requests.
+// and timeouts (a Client.Timeout, a model deadline) do not count: they also.
+// hit a slow but reachable host.
+func connectionFailure(err error) bool {
+	var netErr net.Error
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) ||
+		(errors.As(err, &netErr) && netErr.Timeout()) {
+		return false
+	}
+	var (
+		dnsErr    *net.DNSError
+		opErr     *net.OpError
+		certErr   *tls.CertificateVerificationError
+		recordErr tls.RecordHeaderError
+		alertErr  tls.AlertError
+	)
+	switch {
+	case errors.As(err, &dnsErr), errors.As(err, &certErr), errors.As(err, &recordErr), errors.As(err, &alertErr):
+		return true
+	case errors.As(err, &opErr):
+		return opErr.Op == "dial" || opErr.Op == "proxyconnect"
+	}
+	return false
+}
+
+// gatewayError reports whether resp is a gateway error from a proxy or load.
+// balancer in front of the Hub.
+func gatewayError(resp *http.Response) bool {
+	return resp.StatusCode == http.StatusBadGateway || resp.StatusCode == http.StatusGatewayTimeout
+}
@@ -0,0 +1,191 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHubBreaker(t *testing.T) {
+	old := breakerCooldown
+	breakerCooldown = 50 * time.Millisecond
+	t.Cleanup(func() { breakerCooldown = old })
+
+	var down atomic.Bool
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if down.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		io.WriteString(w, "ok")
+	}))
+	defer srv.Close()
+
+	client := NewHFClient(5*time.Second, "")
+	get := func() (int, error) {
+		t.Helper()
+		resp, err := client.Get(srv.URL + "/api/models/org/model")
+		if err != nil {
+			return 0, err
+		}
+		resp.Body.Close()
+		return resp.StatusCode, nil
+	}
+
+	down.Store(true)
+	for i := 0; i < breakerThreshold; i++ {
+		if status, err := get(); err != nil || status != http.StatusBadGateway {
+			t.Fatalf("request %d = %d, %v; want the gateway error", i+1, status, err)
+		}
+	}
+
+	// The host is now unreachable: requests fail without being sent.
+	if _, err := get(); !errors.Is(err, ErrHubUnreachable) {
+		t.Fatalf("err = %v, want ErrHubUnreachable", err)
+	}
+	if n := requests.Load(); n != breakerThreshold {
+		t.Fatalf("%d requests sent, want %d", n, breakerThreshold)
+	}
+
+	// After the cooldown one request checks whether the host is back.
+	down.Store(false)
+	time.Sleep(2 * breakerCooldown)
+	if status, err := get(); err != nil || status != http.StatusOK {
+		t.Fatalf("after cooldown = %d, %v", status, err)
+	}
+	if status, err := get(); err != nil || status != http.StatusOK {
+		t.Fatalf("after recovery = %d, %v", status, err)
+	}
+}
+
+func TestHubBreakerStopsRetries(t *testing.T) {
+	old := retryBaseBackoff
+	retryBaseBackoff = time.Millisecond
+	t.Cleanup(func() { retryBaseBackoff = old })
+
+	// A closed server refuses every connection.
+	srv := httptest.NewServer(http.NotFoundHandler())
+	url := srv.URL + "/api/models/org/model"
+	srv.Close()
+
+	var retries int
+	client := NewHFClientWithRetry(time.Second, "", RetryPolicy{
+		MaxRetries: 3,
+		OnRetry:    func(RetryAttempt) { retries++ },
+	})
+	if _, err := client.Get(url); err == nil || errors.Is(err, ErrHubUnreachable) {
+		t.Fatalf("first request err = %v, want a connection error", err)
+	}
+	if retries != 3 {
+		t.Fatalf("first request retried %d times, want 3", retries)
+	}
+
+	// The failure that trips the breaker is not retried, nor are later requests.
+	retries = 0
+	for i := 0; i < 2; i++ {
+		if _, err := client.Get(url); !errors.Is(err, ErrHubUnreachable) {
+			t.Fatalf("request %d err = %v, want ErrHubUnreachable", i+2, err)
+		}
+	}
+	if retries != 0 {
+		t.Fatalf("retried %d times while unreachable", retries)
+	}
+}
+
+func TestHubBreakerIgnoresTimeouts(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer srv.Close()
+
+	// A slow host is not an unreachable one, whether the request is cut short.
+	// by the client timeout or by a deadline on its context.
+	get := func(timeout, ctxTimeout time.Duration) error {
+		ctx, cancel := context.WithTimeout(context.Background(), ctxTimeout)
+		defer cancel()
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/api/models/org/model", nil)
+		_, err := NewHFClient(timeout, "").Do(req)
+		return err
+	}
+	for i := 0; i <= breakerThreshold; i++ {
+		if err := get(10*time.Millisecond, time.Minute); err == nil || errors.Is(err, ErrHubUnreachable) {
+			t.Fatalf("request %d err = %v, want a timeout", i+1, err)
+		}
+		if err := get(0, 10*time.Millisecond); err == nil || errors.Is(err, ErrHubUnreachable) {
+			t.Fatalf("request %d err = %v, want a deadline error", i+1, err)
+		}
+	}
+}
+
+func TestHubBreakerCancelledProbe(t *testing.T) {
+	const host = "cancelled-probe.example"
+	b := hostBreaker(host)
+	dialErr := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	for i := 0; i < breakerThreshold; i++ {
+		b.record(host, nil, dialErr)
+	}
+	if err := b.allow(host); !errors.Is(err, ErrHubUnreachable) {
+		t.Fatalf("allow = %v, want ErrHubUnreachable", err)
+	}
+
+	// A probe cancelled by its caller leaves the host unreachable, and the.
+	// next request probes again.
+	b.openedAt = time.Now().Add(-breakerCooldown)
+	if err := b.allow(host); err != nil {
+		t.Fatalf("probe allow = %v", err)
+	}
+	if err := b.record(host, nil, context.Canceled); !errors.Is(err, context.Canceled) {
+		t.Fatalf("record = %v, want context.Canceled", err)
+	}
+	if b.openedAt.IsZero() || b.failures != breakerThreshold {
+		t.Fatalf("breaker reset by a cancelled probe: failures = %d", b.failures)
+	}
+	if err := b.allow(host); err != nil {
+		t.Fatalf("second probe allow = %v", err)
+	}
+}
+
+func TestHTTPCacheServesStaleWhileUnreachable(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"id":"org/model"}`)
+	}))
+	url := srv.URL + "/api/models/org/model"
+
+	cache, err := NewHTTPCache(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetHTTPCache(cache)
+	defer SetHTTPCache(nil)
+
+	client := NewHFClient(time.Second, "")
+	resp, err := client.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	srv.Close()
+
+	var body []byte
+	for i := 0; i <= breakerThreshold; i++ {
+		resp, err = client.Get(url)
+		if err == nil {
+			body, _ = io.ReadAll(resp.Body)
+			resp.Body.Close()
+		}
+	}
+	if err != nil || string(body) != `{"id":"org/model"}` {
+		t.Fatalf("got %q, %v; want the expired entry", body, err)
+	}
+}
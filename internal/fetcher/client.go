@@ -3,32 +3,93 @@ package fetcher
 import (
 	"net/http"
 	"strings"
+	"sync/atomic"
 	"time"
 )
 
+// requestCount counts every request sent through a client from NewHFClient;.
+// errorCount counts those that failed (see ErrorCount).
+var requestCount, errorCount atomic.Int64
+
+// RequestCount returns the number of Hugging Face requests issued by this.
+// process so far. It backs the opt-in local usage metrics.
+func RequestCount() int64 {
+	return requestCount.Load()
+}
+
+// ErrorCount returns the number of Hugging Face requests issued by this.
+// process that failed: transport errors and HTTP errors other than 404, which.
+// the fetchers use to probe for optional files.
+func ErrorCount() int64 {
+	return errorCount.Load()
+}
+
+// sharedTransport is the connection pool of every client of this package:.
+// idle connections to the Hub, Kaggle and Ollama stay open between requests.
+// and across clients, and HTTP/2 is negotiated when the server offers it, so.
+// the many small metadata requests of a run reuse a few connections instead.
+// of paying a TLS handshake each.
+var sharedTransport = newSharedTransport()
+
+func newSharedTransport() http.RoundTripper {
+	base, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return http.DefaultTransport
+	}
+	t := base.Clone()
+	t.ForceAttemptHTTP2 = true
+	t.MaxIdleConns = 100
+	t.MaxIdleConnsPerHost = 16
+	t.IdleConnTimeout = 90 * time.Second
+	return t
+}
+
+// SharedTransport returns the pooled transport of the clients of this.
+// package, for other clients that want to reuse its connections. It sends.
+// no credentials.
+func SharedTransport() http.RoundTripper {
+	return sharedTransport
+}
+
+// defaultClient is the client of fetchers without a Client.
+var defaultClient = &http.Client{Transport: sharedTransport}
+
 // hfTransport injects a Bearer token into every request when a token is set.
+// Requests to a host found unreachable fail at once (see ErrHubUnreachable).
 type hfTransport struct {
 	base  http.RoundTripper
 	token string
 }
 
 func (t *hfTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	requestCount.Add(1)
 	if t.token != "" {
 		req = req.Clone(req.Context())
 		req.Header.Set("Authorization", "Bearer "+t.token)
 	}
-	return t.base.RoundTrip(req)
+	b := hostBreaker(req.URL.Host)
+	if err := b.allow(req.URL.Host); err != nil {
+		errorCount.Add(1)
+		return nil, err
+	}
+	resp, err := t.base.RoundTrip(req)
+	err = b.record(req.URL.Host, resp, err)
+	if err != nil || (resp.StatusCode >= 400 && resp.StatusCode != http.StatusNotFound) {
+		errorCount.Add(1)
+	}
+	return resp, err
 }
 
 // NewHFClient creates an *http.Client configured for Hugging Face API calls.
 // timeout is the per-request deadline (0 = no timeout).
 // token is automatically injected as a Bearer token on every request when non-empty.
+// GET requests go through the HTTP cache set with SetHTTPCache, if any.
+// All clients share one pool of connections (see SharedTransport).
 func NewHFClient(timeout time.Duration, token string) *http.Client {
 	token = strings.TrimSpace(token)
-	base := http.DefaultTransport
-	transport := base
-	if token != "" {
-		transport = &hfTransport{base: base, token: token}
+	var transport http.RoundTripper = &hfTransport{base: sharedTransport, token: token}
+	if c := httpCache.Load(); c != nil {
+		transport = &cacheTransport{base: transport, cache: c, token: token}
 	}
 	return &http.Client{Timeout: timeout, Transport: transport}
 }
@@ -0,0 +1,45 @@
+package fetcher
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClientsShareConnections(t *testing.T) {
+	var conns atomic.Int64
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"id":"org/model"}`)
+	}))
+	srv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			conns.Add(1)
+		}
+	}
+	srv.Start()
+	defer srv.Close()
+
+	// Every call of a fetcher used to get a new client; they must all reuse.
+	// the idle connection of the first.
+	for i := 0; i < 5; i++ {
+		for _, client := range []*http.Client{
+			NewHFClient(5*time.Second, ""),
+			NewHFClientWithRetry(5*time.Second, "", RetryPolicy{MaxRetries: 1}),
+			defaultClient,
+		} {
+			resp, err := client.Get(srv.URL + "/api/models/org/model")
+			if err != nil {
+				t.Fatal(err)
+			}
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+	}
+	if n := conns.Load(); n != 1 {
+		t.Fatalf("connections = %d, want 1", n)
+	}
+}
@@ -0,0 +1,150 @@
+package fetcher
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Contact roles of a model card contact.
+const (
+	ContactRoleGeneral  = "contact"  // questions about the model or its card
+	ContactRoleSecurity = "security" // vulnerability and misuse reports
+	ContactRoleIssues   = "issues"   // issue tracker or discussion board
+)
+
+// Contact is a way to reach the people behind a model, as the model card.
+// states it. Either Email or URL is set.
+type Contact struct {
+	Role  string
+	Name  string
+	Email string
+	URL   string
+}
+
+var (
+	emailRe = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+	urlRe   = regexp.MustCompile(`https?://[^\s<>()\[\]"'` + "`" + `]+`)
+
+	// mailtoLinkRe and urlLinkRe match Markdown links, so that their text can.
+	// name the address they point to.
+	mailtoLinkRe = regexp.MustCompile(`\[([^\]]*)\]\(mailto:([^)\s]+)\)`)
+	urlLinkRe    = regexp.MustCompile(`\[([^\]]*)\]\((https?://[^)\s]+)\)`)
+
+	// securityMailboxRe matches the mailboxes that receive security reports.
+	// wherever the card mentions them: security@, secure@, psirt@.
+	securityMailboxRe = regexp.MustCompile(`(?i)^(?:security|secure|psirt|vulnerabilities|vuln)@`)
+
+	// issueTrackerRe matches the URL of an issue tracker or discussion board.
+	issueTrackerRe = regexp.MustCompile(`(?i)/(?:issues|discussions)(?:/|$)|/-/issues`)
+)
+
+// securityHeadings are the sections that name a security contact.
+var securityHeadings = []string{
+	"Security", "Security Contact", "Security Policy", "Reporting Security Issues",
+	"Reporting a Vulnerability", "Vulnerability Disclosure", "Responsible Disclosure",
+}
+
+// parseContacts fills the contacts of card: the addresses of the "Model Card.
+// Contact" section and the contact or contact_email front matter keys, the.
+// security contacts of a security section, a security_contact key or a.
+// security@ mailbox anywhere in the body, and issue trackers (an.
+// issue_tracker key or an issues or discussions URL among the contacts).
+func parseContacts(card *ModelReadmeCard) {
+	var contacts []Contact
+	add := func(role, text string) {
+		for _, c := range ContactsIn(text) {
+			switch {
+			case c.Email != "" && securityMailboxRe.MatchString(c.Email):
+				c.Role = ContactRoleSecurity
+			case c.URL != "" && issueTrackerRe.MatchString(c.URL):
+				c.Role = ContactRoleIssues
+			default:
+				c.Role = role
+			}
+			contacts = append(contacts, c)
+		}
+	}
+	fm := card.FrontMatter
+	for _, key := range []string{"security_contact", "security"} {
+		add(ContactRoleSecurity, strings.Join(stringSliceFromAny(fm[key]), "\n"))
+	}
+	add(ContactRoleSecurity, firstSection(card.Body, securityHeadings...))
+	add(ContactRoleIssues, strings.Join(stringSliceFromAny(fm["issue_tracker"]), "\n"))
+	for _, key := range []string{"contact", "contact_email"} {
+		add(ContactRoleGeneral, strings.Join(stringSliceFromAny(fm[key]), "\n"))
+	}
+	add(ContactRoleGeneral, card.ModelCardContact)
+	for _, email := range emailRe.FindAllString(card.Body, -1) {
+		if securityMailboxRe.MatchString(email) {
+			contacts = append(contacts, Contact{Role: ContactRoleSecurity, Email: email})
+		}
+	}
+	card.Contacts = dedupeContacts(contacts)
+}
+
+// ContactsIn returns the email addresses and URLs of text, in order. The.
+// text of a Markdown link names the address it points to; else a few words.
+// before an address on its line name it ("Jane Doe <jane@example.org>"),.
+// unless they end in a colon ("Email: jane@example.org").
+func ContactsIn(text string) []Contact {
+	text = mailtoLinkRe.ReplaceAllString(text, "; $1 <$2>")
+	text = urlLinkRe.ReplaceAllString(text, "; $1 <$2>")
+	var contacts []Contact
+	for _, line := range strings.Split(text, "\n") {
+		urls := urlRe.FindAllStringIndex(line, -1)
+		for _, loc := range emailRe.FindAllStringIndex(line, -1) {
+			if insideAny(loc, urls) {
+				continue
+			}
+			contacts = append(contacts, Contact{Name: contactName(line[:loc[0]]), Email: strings.TrimSuffix(line[loc[0]:loc[1]], ".")})
+		}
+		for _, loc := range urls {
+			url := strings.TrimRight(line[loc[0]:loc[1]], ".,;:")
+			contacts = append(contacts, Contact{Name: contactName(line[:loc[0]]), URL: url})
+		}
+	}
+	return contacts
+}
+
+// contactName returns the name that prefix gives an address, or "".
+func contactName(prefix string) string {
+	if i := strings.LastIndexAny(prefix, ",;"); i >= 0 {
+		prefix = prefix[i+1:]
+	}
+	name := strings.TrimSpace(strings.ReplaceAll(prefix, "**", ""))
+	name = strings.TrimLeft(name, "-*># ")
+	name = strings.TrimRight(name, "<([ ")
+	if name == "" || strings.HasSuffix(name, ":") || len(strings.Fields(name)) > 4 || strings.ContainsAny(name, "@/") {
+		return ""
+	}
+	return name
+}
+
+// insideAny reports whether the span loc lies within one of spans.
+func insideAny(loc []int, spans [][]int) bool {
+	for _, s := range spans {
+		if loc[0] >= s[0] && loc[1] <= s[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// dedupeContacts drops the repeated addresses of contacts, keeping the first.
+// (most specific) role and the first name found for each.
+func dedupeContacts(contacts []Contact) []Contact {
+	seen := map[string]int{}
+	var out []Contact
+	for _, c := range contacts {
+		addr := strings.ToLower(c.Email + c.URL)
+		if i, ok := seen[addr]; ok {
+			if out[i].Name == "" {
+				out[i].Name = c.Name
+			}
+			continue
+		}
+		seen[addr] = len(out)
+		out = append(out, c)
+	}
+	return out
+}
@@ -0,0 +1,46 @@
+package fetcher
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseContacts(t *testing.T) {
+	card := parseReadmeCard(`---
+license: apache-2.0
+issue_tracker: https://github.com/example/triage/issues
+---
+# Triage model
+
+Questions? Write to ml-team@example.org.
+
+## Reporting Security Issues
+
+Please report vulnerabilities privately to [Example PSIRT](mailto:psirt@example.org).
+
+## Model Card Contact
+
+- Jane Doe <jane@example.org>
+- Email: ml-team@example.org
+- Discussions: https://huggingface.co/example/triage/discussions
+`)
+	want := []Contact{
+		{Role: ContactRoleSecurity, Name: "Example PSIRT", Email: "psirt@example.org"},
+		{Role: ContactRoleIssues, URL: "https://github.com/example/triage/issues"},
+		{Role: ContactRoleGeneral, Name: "Jane Doe", Email: "jane@example.org"},
+		{Role: ContactRoleGeneral, Email: "ml-team@example.org"},
+		{Role: ContactRoleIssues, URL: "https://huggingface.co/example/triage/discussions"},
+	}
+	if !reflect.DeepEqual(card.Contacts, want) {
+		t.Errorf("contacts =\n%+v\nwant\n%+v", card.Contacts, want)
+	}
+
+	card = parseReadmeCard("---\nsecurity_contact: security@example.org\n---\n# Model\n\nMail security@example.org or secure@example.org.\n")
+	want = []Contact{
+		{Role: ContactRoleSecurity, Email: "security@example.org"},
+		{Role: ContactRoleSecurity, Email: "secure@example.org"},
+	}
+	if !reflect.DeepEqual(card.Contacts, want) {
+		t.Errorf("contacts = %+v, want %+v", card.Contacts, want)
+	}
+}
@@ -36,7 +36,7 @@ type DatasetAPIFetcher struct {
 func (f *DatasetAPIFetcher) Fetch(datasetID string) (*DatasetAPIResponse, error) {
 	client := f.Client
 	if client == nil {
-		client = http.DefaultClient
+		client = defaultClient
 	}
 
 	trimmedDatasetID := strings.TrimPrefix(strings.TrimSpace(datasetID), "/")
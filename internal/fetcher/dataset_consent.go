@@ -0,0 +1,88 @@
+package fetcher
+
+import (
+	"regexp"
+	"strings"
+)
+
+// consentStatementLimit bounds the length of the statements taken from a.
+// dataset card.
+const consentStatementLimit = 400
+
+var (
+	// sentenceRe splits Markdown prose into sentences and list items.
+	sentenceRe = regexp.MustCompile(`(?s)[^.!?\n]+(?:[.!?]+|\n|$)`)
+
+	consentRe = regexp.MustCompile(`(?i)\bconsent(?:ed|s)?\b|\binformed\s+agreement\b`)
+	optOutRe  = regexp.MustCompile(`(?i)\bopt(?:ed)?[\s-]?outs?\b|\b(?:removal|takedown|take-down|deletion)\s+requests?\b|\brequest(?:ed)?\s+(?:the\s+)?(?:removal|deletion)\b|\bhave\s*i\s*been\s*trained\b`)
+
+	// doNotTrainSignals are the machine-readable do-not-train signals a.
+	// dataset card can say the collection honoured, in reporting order.
+	doNotTrainSignals = []struct {
+		name string
+		re   *regexp.Regexp
+	}{
+		{"robots.txt", regexp.MustCompile(`(?i)\brobots\.txt\b|\brobots\s+exclusion\b`)},
+		{"noai", regexp.MustCompile(`(?i)\bnoai\b|\bnoimageai\b`)},
+		{"ai.txt", regexp.MustCompile(`(?i)\bai\.txt\b`)},
+		{"tdm-reservation", regexp.MustCompile(`(?i)\btdm[\s-]?rep\b|\btdm[\s-]reservation\b|\btext\s+and\s+data\s+mining\s+(?:opt[\s-]?out|reservation)`)},
+		{"do-not-train", regexp.MustCompile(`(?i)\bdo[\s-]not[\s-]train\b`)},
+	}
+)
+
+// parseDatasetConsent fills the consent fields of card from its front matter.
+// and body. Front matter keys (consent, opt_out) win over the prose; in the.
+// prose a "Consent" section wins over the first sentence mentioning consent.
+func parseDatasetConsent(card *DatasetReadmeCard) {
+	fm, body := card.FrontMatter, card.Body
+
+	card.ConsentStatement = strings.TrimSpace(stringFromAny(fm["consent"]))
+	if card.ConsentStatement == "" {
+		card.ConsentStatement = clipStatement(extractSection(body, "Consent"))
+	}
+	if card.ConsentStatement == "" {
+		card.ConsentStatement = firstSentence(body, consentRe)
+	}
+
+	for _, key := range []string{"opt_out", "opt-out", "optout"} {
+		if card.OptOut = strings.TrimSpace(stringFromAny(fm[key])); card.OptOut != "" {
+			break
+		}
+	}
+	if card.OptOut == "" {
+		card.OptOut = firstSentence(body, optOutRe)
+	}
+
+	text := card.Raw
+	for _, s := range doNotTrainSignals {
+		if s.re.MatchString(text) {
+			card.DoNotTrain = append(card.DoNotTrain, s.name)
+		}
+	}
+}
+
+// firstSentence returns the first sentence of markdown matching re.
+func firstSentence(markdown string, re *regexp.Regexp) string {
+	for _, s := range sentenceRe.FindAllString(markdown, -1) {
+		if re.MatchString(s) {
+			return clipStatement(s)
+		}
+	}
+	return ""
+}
+
+// clipStatement strips list and emphasis markup from s, joins its lines and.
+// shortens it to consentStatementLimit.
+func clipStatement(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	s = strings.TrimLeft(s, "-*># ")
+	s = strings.ReplaceAll(s, "**", "")
+	if len(s) > consentStatementLimit {
+		cut := strings.LastIndex(s[:consentStatementLimit], " ")
+		if cut <= 0 {
+			cut = consentStatementLimit
+		}
+		s = s[:cut] + "…"
+	}
+	return strings.TrimSpace(s)
+}
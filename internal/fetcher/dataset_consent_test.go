@@ -0,0 +1,49 @@
+package fetcher
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseDatasetConsent(t *testing.T) {
+	card := parseDatasetReadmeCard(`---
+license: cc-by-4.0
+---
+# Speech corpus
+
+## Dataset Description
+
+Recordings of read speech. Pages whose robots.txt disallows crawling and images
+tagged noai were skipped.
+
+### Personal and Sensitive Information
+
+Speakers can opt out at any time: send a removal request to data@example.com.
+
+### Consent
+
+All speakers gave **written** informed consent
+to the release of their recordings.
+
+## Other
+`)
+	if card.ConsentStatement != "All speakers gave written informed consent to the release of their recordings." {
+		t.Errorf("consent = %q", card.ConsentStatement)
+	}
+	if !strings.HasPrefix(card.OptOut, "Speakers can opt out at any time") {
+		t.Errorf("opt-out = %q", card.OptOut)
+	}
+	if got := strings.Join(card.DoNotTrain, ","); got != "robots.txt,noai" {
+		t.Errorf("do-not-train = %q", got)
+	}
+
+	card = parseDatasetReadmeCard("---\nconsent: Collected under GDPR Art. 6(1)(a)\nopt_out: https://example.com/opt-out\n---\nNo statements here.\n")
+	if card.ConsentStatement != "Collected under GDPR Art. 6(1)(a)" || card.OptOut != "https://example.com/opt-out" {
+		t.Errorf("front matter: consent = %q, opt-out = %q", card.ConsentStatement, card.OptOut)
+	}
+
+	card = parseDatasetReadmeCard("# Plain\n\nMovie reviews collected from IMDb.\n")
+	if card.ConsentStatement != "" || card.OptOut != "" || len(card.DoNotTrain) != 0 {
+		t.Errorf("plain card = %q, %q, %v", card.ConsentStatement, card.OptOut, card.DoNotTrain)
+	}
+}
@@ -0,0 +1,68 @@
+package fetcher
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	// collectionPeriodRe matches a statement of when data was collected:.
+	// "collected between 2019 and 2021", "scraped from March 2020 to June.
+	// 2021".
+	collectionPeriodRe = regexp.MustCompile(`(?i)\b(?:collected|gathered|scraped|crawled|recorded|sampled|published)\b[^.\n]{0,60}?\b(?:between|from)\s+((?:[a-z]+\s+)?\d{4})\s*(?:and|to|until|through|-|–)\s*((?:[a-z]+\s+)?\d{4})\b`)
+
+	months = map[string]int{
+		"january": 1, "february": 2, "march": 3, "april": 4, "may": 5, "june": 6,
+		"july": 7, "august": 8, "september": 9, "october": 10, "november": 11, "december": 12,
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "jun": 6, "jul": 7, "aug": 8,
+		"sep": 9, "sept": 9, "oct": 10, "nov": 11, "dec": 12,
+	}
+)
+
+// parseDatasetCoverage fills the temporal and geographic coverage of card.
+// Front matter keys (temporal_coverage, geographic_coverage and their.
+// synonyms) win over "Temporal Coverage" and "Geographic Coverage" sections;.
+// the collection period is else taken from the first "collected between X.
+// and Y" statement of the body, as an ISO 8601 interval (2019/2021).
+func parseDatasetCoverage(card *DatasetReadmeCard) {
+	fm, body := card.FrontMatter, card.Body
+
+	card.TemporalCoverage = frontMatterStatement(fm, "temporal_coverage", "collection_period", "time_period")
+	if card.TemporalCoverage == "" {
+		card.TemporalCoverage = clipStatement(firstSection(body, "Temporal Coverage", "Collection Period", "Time Period"))
+	}
+	if card.TemporalCoverage == "" {
+		if m := collectionPeriodRe.FindStringSubmatch(body); m != nil {
+			card.TemporalCoverage = isoPeriod(m[1]) + "/" + isoPeriod(m[2])
+		}
+	}
+
+	card.GeographicCoverage = frontMatterStatement(fm, "geographic_coverage", "spatial_coverage", "countries", "regions")
+	if card.GeographicCoverage == "" {
+		card.GeographicCoverage = clipStatement(firstSection(body, "Geographic Coverage", "Geographic Scope", "Spatial Coverage"))
+	}
+}
+
+// frontMatterStatement returns the first of the front matter keys that is.
+// set, with list values joined by commas.
+func frontMatterStatement(fm map[string]any, keys ...string) string {
+	for _, key := range keys {
+		if s := strings.Join(stringSliceFromAny(fm[key]), ", "); s != "" {
+			return clipStatement(s)
+		}
+	}
+	return ""
+}
+
+// isoPeriod converts "2019" or "March 2020" to 2019 or 2020-03.
+func isoPeriod(s string) string {
+	fields := strings.Fields(s)
+	year := fields[len(fields)-1]
+	if len(fields) == 2 {
+		if m, ok := months[strings.ToLower(fields[0])]; ok {
+			return fmt.Sprintf("%s-%02d", year, m)
+		}
+	}
+	return year
+}
@@ -0,0 +1,26 @@
+package fetcher
+
+import "testing"
+
+func TestParseDatasetCoverage(t *testing.T) {
+	tests := []struct {
+		name       string
+		card       string
+		temporal   string
+		geographic string
+	}{
+		{"front matter", "---\ntemporal_coverage: 2018/2020\ncountries:\n- Belgium\n- Netherlands\n---\n# Data\n", "2018/2020", "Belgium, Netherlands"},
+		{"sections", "# Data\n\n## Collection Period\n\nJanuary 2021 to March 2022.\n\n## Geographic Coverage\n\nNews outlets from Flanders.\n", "January 2021 to March 2022.", "News outlets from Flanders."},
+		{"years statement", "# Data\n\nThe posts were collected between 2019 and 2021 from public forums.\n", "2019/2021", ""},
+		{"months statement", "# Data\n\nTweets scraped from March 2020 to June 2021.\n", "2020-03/2021-06", ""},
+		{"none", "# Data\n\nA corpus of reviews.\n", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			card := parseDatasetReadmeCard(tt.card)
+			if card.TemporalCoverage != tt.temporal || card.GeographicCoverage != tt.geographic {
+				t.Errorf("temporal = %q, geographic = %q, want %q, %q", card.TemporalCoverage, card.GeographicCoverage, tt.temporal, tt.geographic)
+			}
+		})
+	}
+}
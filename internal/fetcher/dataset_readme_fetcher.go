@@ -36,6 +36,23 @@ type DatasetReadmeCard struct {
 	PersonalSensitiveInfo string // BOM.metadata.component.data.sensitive data
 	BiasRisksLimitations  string // BOM.metadata.component.data.sensitive data
 	DatasetCardContact    string // BOM.metadata.component.properties (datasetcardcontact)
+
+	// Data governance signals (see parseDatasetConsent).
+	ConsentStatement string   // BOM.components[DATA].properties (aibomgen:governance:consent)
+	OptOut           string   // BOM.components[DATA].properties (aibomgen:governance:optOut)
+	DoNotTrain       []string // BOM.components[DATA].properties (aibomgen:governance:doNotTrain)
+
+	// Attribution (see ParseCopyright and parseAttribution).
+	Copyright   []string // BOM.components[DATA].copyright
+	Attribution string   // BOM.components[DATA].properties (aibomgen:attribution:statement)
+
+	// Synthetic origin (see parseDatasetSynthetic).
+	Synthetic       bool   // BOM.components[DATA].properties (aibomgen:data:synthetic)
+	GeneratingModel string // BOM.components[DATA].properties (aibomgen:data:generatingModel)
+
+	// Coverage (see parseDatasetCoverage).
+	TemporalCoverage   string // BOM.components[DATA].properties (aibomgen:data:temporalCoverage)
+	GeographicCoverage string // BOM.components[DATA].properties (aibomgen:data:geographicCoverage)
 }
 
 // DatasetConfig represents a configuration with data files splits.
@@ -60,7 +77,7 @@ type DatasetReadmeFetcher struct {
 func (f *DatasetReadmeFetcher) Fetch(datasetID string) (*DatasetReadmeCard, error) {
 	client := f.Client
 	if client == nil {
-		client = http.DefaultClient
+		client = defaultClient
 	}
 
 	trimmedDatasetID := strings.TrimPrefix(strings.TrimSpace(datasetID), "/")
@@ -148,6 +165,11 @@ func parseDatasetReadmeCard(raw string) *DatasetReadmeCard {
 	card.PersonalSensitiveInfo = strings.TrimSpace(extractSection(body, "Personal and Sensitive Information"))
 	card.BiasRisksLimitations = strings.TrimSpace(extractSection(body, "Bias, Risks, and Limitations"))
 	card.DatasetCardContact = strings.TrimSpace(extractSection(body, "Dataset Card Contact"))
+	parseDatasetConsent(card)
+	card.Copyright = ParseCopyright(body)
+	card.Attribution = parseAttribution(fm, body)
+	parseDatasetSynthetic(card)
+	parseDatasetCoverage(card)
 
 	return card
 }
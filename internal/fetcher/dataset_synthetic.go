@@ -0,0 +1,62 @@
+package fetcher
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// generatingModelKeys are the front matter keys that name the model a.
+	// synthetic dataset was generated with, in order of preference.
+	generatingModelKeys = []string{"generated_by", "generation_model", "generator_model", "teacher_model"}
+
+	// generatedByRe matches a card statement naming the Hugging Face model.
+	// that generated the data: "generated with `meta-llama/Llama-3-70B`".
+	generatedByRe = regexp.MustCompile("(?i)\\b(?:generated|synthesi[sz]ed|produced)\\s+(?:by|with|using)\\s+(?:the\\s+)?(?:model\\s+)?[\\[`*]*([A-Za-z0-9][\\w.-]*/[A-Za-z0-9][\\w.-]*[A-Za-z0-9])")
+)
+
+// parseDatasetSynthetic fills the synthetic-origin fields of card. A dataset.
+// is synthetic when its front matter says so ("synthetic: true"), it is.
+// tagged synthetic, its language creators are "machine-generated" (machine.
+// generated annotations of real data do not count), or its card names the.
+// model that generated it: a generated_by (or similar) front matter key, else.
+// the first "generated with <org/model>" statement of the body.
+func parseDatasetSynthetic(card *DatasetReadmeCard) {
+	fm := card.FrontMatter
+
+	for _, key := range generatingModelKeys {
+		if card.GeneratingModel = strings.TrimSpace(stringFromAny(fm[key])); card.GeneratingModel != "" {
+			break
+		}
+	}
+	if card.GeneratingModel == "" {
+		if m := generatedByRe.FindStringSubmatch(card.Body); m != nil {
+			card.GeneratingModel = m[1]
+		}
+	}
+
+	card.Synthetic = card.GeneratingModel != "" || isTrue(fm["synthetic"])
+	for _, tag := range card.Tags {
+		if t := strings.ToLower(tag); t == "synthetic" || t == "synthetic-data" || t == "synthetic data" {
+			card.Synthetic = true
+		}
+	}
+	for _, c := range stringSliceFromAny(fm["language_creators"]) {
+		if strings.EqualFold(c, "machine-generated") {
+			card.Synthetic = true
+		}
+	}
+}
+
+// isTrue reports whether a front matter value is a YAML true or a "true" or.
+// "yes" string.
+func isTrue(v any) bool {
+	switch t := v.(type) {
+	case bool:
+		return t
+	case string:
+		s := strings.ToLower(strings.TrimSpace(t))
+		return s == "true" || s == "yes"
+	}
+	return false
+}
@@ -0,0 +1,28 @@
+package fetcher
+
+import "testing"
+
+func TestParseDatasetSynthetic(t *testing.T) {
+	tests := []struct {
+		name      string
+		card      string
+		synthetic bool
+		model     string
+	}{
+		{"front matter model", "---\ngenerated_by: meta-llama/Meta-Llama-3-70B-Instruct\n---\n# Data\n", true, "meta-llama/Meta-Llama-3-70B-Instruct"},
+		{"body statement", "# Data\n\nAll dialogues were generated with `mistralai/Mixtral-8x7B-Instruct-v0.1` from seed topics.\n", true, "mistralai/Mixtral-8x7B-Instruct-v0.1"},
+		{"tag", "---\ntags:\n- synthetic\n---\n# Data\n", true, ""},
+		{"flag", "---\nsynthetic: true\n---\n# Data\n", true, ""},
+		{"machine-generated text", "---\nlanguage_creators:\n- machine-generated\n---\n# Data\n", true, ""},
+		{"machine-generated labels", "---\nannotations_creators:\n- machine-generated\nlanguage_creators:\n- found\n---\n# Data\n", false, ""},
+		{"collected", "# Data\n\nReviews produced by users of the site.\n", false, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			card := parseDatasetReadmeCard(tt.card)
+			if card.Synthetic != tt.synthetic || card.GeneratingModel != tt.model {
+				t.Errorf("synthetic = %v, generating model = %q, want %v, %q", card.Synthetic, card.GeneratingModel, tt.synthetic, tt.model)
+			}
+		})
+	}
+}
@@ -27,7 +27,7 @@ func (f *DatasetTreeFetcher) Fetch(datasetID string) ([]SecurityFileEntry, error
 	}
 	client := f.Client
 	if client == nil {
-		client = http.DefaultClient
+		client = defaultClient
 	}
 
 	var all []SecurityFileEntry
@@ -35,5 +35,7 @@ func (f *DummyDatasetReadmeFetcher) Fetch(datasetID string) (*DatasetReadmeCard,
 		PersonalSensitiveInfo: "This dataset may contain synthetic personal information for testing purposes",
 		BiasRisksLimitations:  "Dataset may contain inherent biases from the synthetic generation process",
 		DatasetCardContact:    "test@example.com",
+		Synthetic:             true,
+		GeneratingModel:       "dummy-org/dummy-generator",
 	}, nil
 }
@@ -48,5 +48,12 @@ func (f *DummyModelAPIFetcher) Fetch(modelID string) (*ModelAPIResponse, error)
 			ModelType:     "gpt2",
 			Architectures: []string{"GPT2LMHeadModel"},
 		},
+		Siblings: []ModelSibling{
+			{RFilename: ".gitattributes"},
+			{RFilename: "LICENSE"},
+			{RFilename: "README.md"},
+			{RFilename: "config.json"},
+			{RFilename: "model.safetensors"},
+		},
 	}, nil
 }
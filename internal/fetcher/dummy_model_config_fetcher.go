@@ -0,0 +1,18 @@
+package fetcher
+
+// DummyModelConfigFetcher returns a fixed GPT-2 config.json for testing/demo.
+// purposes without making any HTTP requests.
+type DummyModelConfigFetcher struct{}
+
+// Fetch returns the config of the dummy GPT-2 model.
+func (f *DummyModelConfigFetcher) Fetch(_ string) (*ModelConfig, error) {
+	return ParseModelConfig([]byte(`{
+  "architectures": ["GPT2LMHeadModel"],
+  "model_type": "gpt2",
+  "n_ctx": 1024,
+  "n_embd": 768,
+  "n_layer": 12,
+  "n_positions": 1024,
+  "vocab_size": 50257
+}`))
+}
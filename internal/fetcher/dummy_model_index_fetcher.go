@@ -0,0 +1,21 @@
+package fetcher
+
+// DummyModelIndexFetcher returns a fixed Stable Diffusion style pipeline.
+// index for testing/demo purposes without making any HTTP requests.
+type DummyModelIndexFetcher struct{}
+
+// Fetch returns a pipeline index with the usual Stable Diffusion components.
+func (f *DummyModelIndexFetcher) Fetch(_ string) (*ModelIndex, error) {
+	return ParseModelIndex([]byte(`{
+  "_class_name": "StableDiffusionPipeline",
+  "_diffusers_version": "0.30.0",
+  "feature_extractor": ["transformers", "CLIPImageProcessor"],
+  "safety_checker": [null, null],
+  "scheduler": ["diffusers", "PNDMScheduler"],
+  "text_encoder": ["transformers", "CLIPTextModel"],
+  "tokenizer": ["transformers", "CLIPTokenizer"],
+  "unet": ["diffusers", "UNet2DConditionModel"],
+  "vae": ["diffusers", "AutoencoderKL"],
+  "requires_safety_checker": false
+}`))
+}
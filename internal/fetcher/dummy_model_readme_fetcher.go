@@ -34,6 +34,10 @@ func (f *DummyModelReadmeFetcher) Fetch(modelID string) (*ModelReadmeCard, error
 		BiasRisksLimitations: "The model may exhibit biases present in the training data, including but not limited to gender, racial, and cultural biases. Users should be aware of potential risks when deploying in production environments.",
 		BiasRecommendations:  "We recommend implementing content filtering, human review for sensitive applications, and regular bias audits when using this model in production.",
 		ModelCardContact:     "contact@dummy-org.example.com",
+		Contacts: []Contact{
+			{Role: ContactRoleSecurity, Email: "security@dummy-org.example.com"},
+			{Role: ContactRoleGeneral, Email: "contact@dummy-org.example.com"},
+		},
 
 		// Environmental Impact.
 		EnvironmentalHardwareType:  "NVIDIA A100 GPU",
@@ -170,6 +174,10 @@ The model uses a standard GPT-2 architecture with the following specifications:
 
 Training was performed on AWS infrastructure using NVIDIA A100 GPUs.
 
+## Security
+
+Report vulnerabilities to security@dummy-org.example.com.
+
 ## Model Card Contact
 
 contact@dummy-org.example.com
@@ -0,0 +1,180 @@
+package fetcher
+
+import (
+	"regexp"
+	"strings"
+)
+
+// FairnessMetric is an evaluation result for one group of a fairness.
+// evaluation, such as the accuracy of a model for female speakers.
+type FairnessMetric struct {
+	Group string
+	Type  string
+	Value string
+}
+
+var (
+	// fairnessHeadingRe matches the headings of model card sections that.
+	// report bias and fairness evaluations.
+	fairnessHeadingRe = regexp.MustCompile(`(?i)\b(?:bias|biases|fairness)\b`)
+	headingLineRe     = regexp.MustCompile(`^(#{1,6})\s+(.+?)\s*#*\s*$`)
+
+	// fairnessMetricRe matches the model-index metric types and names of.
+	// fairness and bias benchmarks.
+	fairnessMetricRe = regexp.MustCompile(`(?i)parity|equali[sz]ed[\s_-]*odds|equal[\s_-]*opportunity|disparate[\s_-]*impact|fairness|\bbias\b|stereoset|crows[\s_-]*pairs|winobias|winogender|\bbbq\b|\bbold\b|\bregard\b|\bhonest\b`)
+
+	// fairnessCriteria are the fairness criteria a card can say it evaluated,.
+	// in reporting order.
+	fairnessCriteria = []struct {
+		name string
+		re   *regexp.Regexp
+	}{
+		{"demographic parity", regexp.MustCompile(`(?i)demographic[\s_-]*parity`)},
+		{"statistical parity", regexp.MustCompile(`(?i)statistical[\s_-]*parity`)},
+		{"equalized odds", regexp.MustCompile(`(?i)equali[sz]ed[\s_-]*odds`)},
+		{"equal opportunity", regexp.MustCompile(`(?i)equal(?:ity\s+of)?[\s_-]*opportunit(?:y|ies)`)},
+		{"disparate impact", regexp.MustCompile(`(?i)disparate[\s_-]*impact`)},
+		{"predictive parity", regexp.MustCompile(`(?i)predictive[\s_-]*parity`)},
+		{"treatment equality", regexp.MustCompile(`(?i)treatment[\s_-]*equality`)},
+		{"counterfactual fairness", regexp.MustCompile(`(?i)counterfactual[\s_-]*fairness`)},
+		{"individual fairness", regexp.MustCompile(`(?i)individual[\s_-]*fairness`)},
+	}
+)
+
+// fairnessSections returns the text of the sections of markdown whose heading.
+// mentions bias or fairness, including their subsections.
+func fairnessSections(markdown string) string {
+	var out []string
+	level := 0 // level of the heading being captured, 0 when not capturing
+	for _, line := range strings.Split(markdown, "\n") {
+		if m := headingLineRe.FindStringSubmatch(line); m != nil {
+			l := len(m[1])
+			if level > 0 && l <= level {
+				level = 0
+			}
+			if level == 0 && fairnessHeadingRe.MatchString(m[2]) {
+				level = l
+				continue
+			}
+		}
+		if level > 0 {
+			out = append(out, line)
+		}
+	}
+	return strings.TrimSpace(strings.Join(out, "\n"))
+}
+
+// parseFairness fills the fairness fields of card from the tables in its.
+// bias and fairness sections and from the fairness metrics of its.
+// model-index.
+func parseFairness(card *ModelReadmeCard) {
+	section := fairnessSections(card.Body)
+	card.FairnessMetrics = append(card.FairnessMetrics, parseFairnessTables(section)...)
+	if mi, ok := card.FrontMatter["model-index"]; ok {
+		card.FairnessMetrics = append(card.FairnessMetrics, modelIndexFairnessMetrics(mi)...)
+	}
+
+	text := section
+	for _, m := range card.FairnessMetrics {
+		text += "\n" + m.Type
+	}
+	for _, c := range fairnessCriteria {
+		if c.re.MatchString(text) {
+			card.FairnessCriteria = append(card.FairnessCriteria, c.name)
+		}
+	}
+}
+
+// parseFairnessTables reads per-group results from the Markdown tables in.
+// markdown: the first column names the group and every other column header.
+// a metric.
+func parseFairnessTables(markdown string) []FairnessMetric {
+	var out []FairnessMetric
+	var header []string
+	for _, line := range strings.Split(markdown, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "|") {
+			header = nil
+			continue
+		}
+		cells := tableCells(line)
+		switch {
+		case header == nil:
+			header = cells
+		case isTableSeparator(cells):
+		default:
+			if len(cells) == 0 || cells[0] == "" {
+				continue
+			}
+			for i := 1; i < len(cells) && i < len(header); i++ {
+				v := cells[i]
+				if v == "" || v == "-" || v == "—" || header[i] == "" {
+					continue
+				}
+				out = append(out, FairnessMetric{Group: cells[0], Type: header[i], Value: v})
+			}
+		}
+	}
+	return out
+}
+
+// tableCells splits a Markdown table row into its trimmed cells, without.
+// emphasis markup.
+func tableCells(row string) []string {
+	row = strings.TrimSuffix(strings.TrimPrefix(row, "|"), "|")
+	cells := strings.Split(row, "|")
+	for i := range cells {
+		cells[i] = strings.Trim(strings.TrimSpace(cells[i]), "*_`")
+	}
+	return cells
+}
+
+func isTableSeparator(cells []string) bool {
+	for _, c := range cells {
+		if strings.Trim(c, ":- ") != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// modelIndexFairnessMetrics returns the fairness metrics of every result of.
+// a model-index. The group is the metric config, else the dataset config,.
+// else the dataset name.
+func modelIndexFairnessMetrics(mi any) []FairnessMetric {
+	var out []FairnessMetric
+	entries, _ := mi.([]any)
+	for _, e := range entries {
+		entry, _ := e.(map[string]any)
+		results, _ := entry["results"].([]any)
+		for _, r := range results {
+			res, _ := r.(map[string]any)
+			var dsConfig, dsName string
+			if ds, ok := res["dataset"].(map[string]any); ok {
+				dsConfig = strings.TrimSpace(stringFromAny(ds["config"]))
+				dsName = strings.TrimSpace(stringFromAny(ds["name"]))
+			}
+			metrics, _ := res["metrics"].([]any)
+			for _, m := range metrics {
+				mm, _ := m.(map[string]any)
+				mt := strings.TrimSpace(stringFromAny(mm["type"]))
+				name := strings.TrimSpace(stringFromAny(mm["name"]))
+				mv := strings.TrimSpace(stringFromAny(mm["value"]))
+				if mv == "" || !fairnessMetricRe.MatchString(mt+" "+name) {
+					continue
+				}
+				if name != "" {
+					mt = name
+				}
+				group := strings.TrimSpace(stringFromAny(mm["config"]))
+				for _, g := range []string{dsConfig, dsName} {
+					if group == "" {
+						group = g
+					}
+				}
+				out = append(out, FairnessMetric{Group: group, Type: mt, Value: mv})
+			}
+		}
+	}
+	return out
+}
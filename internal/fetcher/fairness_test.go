@@ -0,0 +1,62 @@
+package fetcher
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseFairness(t *testing.T) {
+	card := parseReadmeCard(`---
+model-index:
+- name: asr-model
+  results:
+  - task:
+      type: automatic-speech-recognition
+    dataset:
+      type: common_voice
+      name: Common Voice
+      config: en
+    metrics:
+    - type: wer
+      value: 12.1
+    - type: demographic_parity_difference
+      name: Demographic parity difference
+      value: 0.04
+      config: gender
+---
+# ASR model
+
+## Bias, Risks, and Limitations
+
+We measured equalized odds across speaker groups.
+
+### Results per group
+
+| Group | **WER** | Accuracy |
+|-------|--------:|----------|
+| female | 11.8 | 0.91 |
+| male | 12.4 | - |
+
+## Training
+
+| Group | Steps |
+|---|---|
+| all | 1000 |
+`)
+	want := []FairnessMetric{
+		{Group: "female", Type: "WER", Value: "11.8"},
+		{Group: "female", Type: "Accuracy", Value: "0.91"},
+		{Group: "male", Type: "WER", Value: "12.4"},
+		{Group: "gender", Type: "Demographic parity difference", Value: "0.04"},
+	}
+	if !reflect.DeepEqual(card.FairnessMetrics, want) {
+		t.Errorf("metrics = %+v\nwant      %+v", card.FairnessMetrics, want)
+	}
+	if !reflect.DeepEqual(card.FairnessCriteria, []string{"demographic parity", "equalized odds"}) {
+		t.Errorf("criteria = %q", card.FairnessCriteria)
+	}
+
+	if card := parseReadmeCard("# Plain\n\n| a | b |\n|---|---|\n| x | 1 |\n"); len(card.FairnessMetrics) != 0 || len(card.FairnessCriteria) != 0 {
+		t.Errorf("plain card = %+v, %q", card.FairnessMetrics, card.FairnessCriteria)
+	}
+}
@@ -0,0 +1,312 @@
+package fetcher
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// GGUF header limits. They bound what a corrupt or hostile file can make the.
+// reader allocate or skip; real headers are far below them.
+const (
+	maxGGUFKeyValues = 1 << 16
+	maxGGUFTensors   = 1 << 20
+	maxGGUFString    = 16 << 20
+	maxGGUFArray     = 1 << 26
+	maxGGUFDims      = 8
+)
+
+// GGUF value types.
+const (
+	ggufUint8 uint32 = iota
+	ggufInt8
+	ggufUint16
+	ggufInt16
+	ggufUint32
+	ggufInt32
+	ggufFloat32
+	ggufBool
+	ggufString
+	ggufArray
+	ggufUint64
+	ggufInt64
+	ggufFloat64
+)
+
+// ggufFileTypes names the general.file_type values (llama.cpp llama_ftype).
+var ggufFileTypes = map[uint64]string{
+	0: "F32", 1: "F16", 2: "Q4_0", 3: "Q4_1", 7: "Q8_0", 8: "Q5_0", 9: "Q5_1",
+	10: "Q2_K", 11: "Q3_K_S", 12: "Q3_K_M", 13: "Q3_K_L", 14: "Q4_K_S", 15: "Q4_K_M",
+	16: "Q5_K_S", 17: "Q5_K_M", 18: "Q6_K", 19: "IQ2_XXS", 20: "IQ2_XS", 21: "Q2_K_S",
+	22: "IQ3_XS", 23: "IQ3_XXS", 24: "IQ1_S", 25: "IQ4_NL", 26: "IQ3_S", 27: "IQ3_M",
+	28: "IQ2_S", 29: "IQ2_M", 30: "IQ4_XS", 31: "IQ1_M", 32: "BF16", 36: "TQ1_0", 37: "TQ2_0",
+}
+
+// GGUFHeader is the metadata of a GGUF weight file (llama.cpp), read from its.
+// key-value header and tensor index without loading any weights.
+type GGUFHeader struct {
+	Version      uint32
+	Architecture string // general.architecture (llama, qwen2, ...)
+	Name         string // general.name
+	SizeLabel    string // general.size_label (8B, 7x8B, ...)
+	License      string // general.license
+	// Quantization is the file type of the weights (Q4_K_M, F16, ...).
+	Quantization   string
+	ContextLength  uint64 // <arch>.context_length
+	EmbeddingSize  uint64 // <arch>.embedding_length
+	BlockCount     uint64 // <arch>.block_count
+	Tokenizer      string // tokenizer.ggml.model (llama, gpt2, ...)
+	VocabularySize uint64 // number of tokenizer.ggml.tokens
+	TensorCount    uint64
+	// ParameterCount is the number of weights in all tensors.
+	ParameterCount uint64
+}
+
+// ReadGGUF reads the header of the GGUF file at path. Versions 2 and 3 are.
+// supported.
+func ReadGGUF(path string) (*GGUFHeader, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseGGUF(f)
+}
+
+// ParseGGUF reads a GGUF header from r.
+func ParseGGUF(r io.Reader) (*GGUFHeader, error) {
+	g := ggufReader{r: bufio.NewReaderSize(r, 1<<16)}
+	magic := make([]byte, 4)
+	if _, err := io.ReadFull(g.r, magic); err != nil {
+		return nil, fmt.Errorf("gguf: %w", err)
+	}
+	if string(magic) != "GGUF" {
+		return nil, errors.New("gguf: not a GGUF file")
+	}
+	h := &GGUFHeader{Version: g.u32()}
+	if g.err == nil && (h.Version < 2 || h.Version > 3) {
+		return nil, fmt.Errorf("gguf: unsupported version %d", h.Version)
+	}
+	h.TensorCount = g.u64()
+	kvCount := g.u64()
+	if g.err == nil && (kvCount > maxGGUFKeyValues || h.TensorCount > maxGGUFTensors) {
+		return nil, errors.New("gguf: header too large")
+	}
+
+	// The <arch>.* keys can precede general.architecture, so they are kept.
+	// by name until the architecture is known.
+	numbers := map[string]uint64{}
+	for i := uint64(0); i < kvCount && g.err == nil; i++ {
+		key := g.str()
+		typ := g.u32()
+		switch {
+		case typ == ggufString && ggufStringKeys[key]:
+			v := strings.TrimSpace(g.str())
+			switch key {
+			case "general.architecture":
+				h.Architecture = v
+			case "general.name":
+				h.Name = v
+			case "general.size_label":
+				h.SizeLabel = v
+			case "general.license":
+				h.License = v
+			case "tokenizer.ggml.model":
+				h.Tokenizer = v
+			}
+		case typ == ggufArray && key == "tokenizer.ggml.tokens":
+			h.VocabularySize = g.skipArray()
+		case isGGUFInteger(typ):
+			numbers[key] = g.uint(typ)
+		default:
+			g.skip(typ)
+		}
+	}
+
+	if ft, ok := numbers["general.file_type"]; ok {
+		if name, ok := ggufFileTypes[ft]; ok {
+			h.Quantization = name
+		} else {
+			h.Quantization = "type " + strconv.FormatUint(ft, 10)
+		}
+	}
+	if h.Architecture != "" {
+		h.ContextLength = numbers[h.Architecture+".context_length"]
+		h.EmbeddingSize = numbers[h.Architecture+".embedding_length"]
+		h.BlockCount = numbers[h.Architecture+".block_count"]
+	}
+
+	for i := uint64(0); i < h.TensorCount && g.err == nil; i++ {
+		g.discardString()
+		dims := g.u32()
+		if g.err == nil && dims > maxGGUFDims {
+			return nil, errors.New("gguf: invalid tensor dimensions")
+		}
+		n := uint64(1)
+		for j := uint32(0); j < dims; j++ {
+			n *= g.u64()
+		}
+		g.u32() // type
+		g.u64() // offset
+		h.ParameterCount += n
+	}
+	if g.err != nil {
+		return nil, fmt.Errorf("gguf: %w", g.err)
+	}
+	return h, nil
+}
+
+// ggufStringKeys are the string keys GGUFHeader records.
+var ggufStringKeys = map[string]bool{
+	"general.architecture": true,
+	"general.name":         true,
+	"general.size_label":   true,
+	"general.license":      true,
+	"tokenizer.ggml.model": true,
+}
+
+func isGGUFInteger(typ uint32) bool {
+	switch typ {
+	case ggufUint8, ggufInt8, ggufUint16, ggufInt16, ggufUint32, ggufInt32, ggufUint64, ggufInt64:
+		return true
+	}
+	return false
+}
+
+// ggufReader reads little-endian GGUF values. The first error sticks and.
+// turns every later read into a no-op.
+type ggufReader struct {
+	r   *bufio.Reader
+	err error
+	buf [8]byte
+}
+
+func (g *ggufReader) read(n int) []byte {
+	if g.err != nil {
+		return g.buf[:n]
+	}
+	if _, err := io.ReadFull(g.r, g.buf[:n]); err != nil {
+		g.err = err
+	}
+	return g.buf[:n]
+}
+
+func (g *ggufReader) u32() uint32 { return binary.LittleEndian.Uint32(g.read(4)) }
+func (g *ggufReader) u64() uint64 { return binary.LittleEndian.Uint64(g.read(8)) }
+
+// uint reads an integer of type typ; negative values read as 0.
+func (g *ggufReader) uint(typ uint32) uint64 {
+	switch typ {
+	case ggufUint8:
+		return uint64(g.read(1)[0])
+	case ggufInt8:
+		return uint64(max(0, int8(g.read(1)[0])))
+	case ggufUint16:
+		return uint64(binary.LittleEndian.Uint16(g.read(2)))
+	case ggufInt16:
+		return uint64(max(0, int16(binary.LittleEndian.Uint16(g.read(2)))))
+	case ggufUint32:
+		return uint64(g.u32())
+	case ggufInt32:
+		return uint64(max(0, int32(g.u32())))
+	case ggufInt64:
+		return uint64(max(0, int64(g.u64())))
+	}
+	return g.u64()
+}
+
+func (g *ggufReader) strLen() uint64 {
+	n := g.u64()
+	if g.err == nil && n > maxGGUFString {
+		g.err = errors.New("string too long")
+	}
+	return n
+}
+
+func (g *ggufReader) str() string {
+	n := g.strLen()
+	if g.err != nil {
+		return ""
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(g.r, b); err != nil {
+		g.err = err
+		return ""
+	}
+	return string(b)
+}
+
+func (g *ggufReader) discard(n uint64) {
+	if g.err != nil {
+		return
+	}
+	if _, err := g.r.Discard(int(n)); err != nil {
+		g.err = err
+	}
+}
+
+func (g *ggufReader) discardString() { g.discard(g.strLen()) }
+
+// skipArray skips an array value and returns its length.
+func (g *ggufReader) skipArray() uint64 {
+	typ := g.u32()
+	n := g.u64()
+	if g.err != nil {
+		return 0
+	}
+	if n > maxGGUFArray || typ == ggufArray {
+		g.err = errors.New("unsupported array")
+		return 0
+	}
+	if size := ggufValueSize(typ); size > 0 {
+		if n > math.MaxInt64/size {
+			g.err = errors.New("array too large")
+			return 0
+		}
+		g.discard(n * size)
+		return n
+	}
+	for i := uint64(0); i < n && g.err == nil; i++ {
+		g.skip(typ)
+	}
+	return n
+}
+
+func (g *ggufReader) skip(typ uint32) {
+	switch typ {
+	case ggufString:
+		g.discardString()
+	case ggufArray:
+		g.skipArray()
+	default:
+		size := ggufValueSize(typ)
+		if size == 0 {
+			if g.err == nil {
+				g.err = fmt.Errorf("unknown value type %d", typ)
+			}
+			return
+		}
+		g.discard(size)
+	}
+}
+
+// ggufValueSize is the size in bytes of a fixed-size value type, or 0.
+func ggufValueSize(typ uint32) uint64 {
+	switch typ {
+	case ggufUint8, ggufInt8, ggufBool:
+		return 1
+	case ggufUint16, ggufInt16:
+		return 2
+	case ggufUint32, ggufInt32, ggufFloat32:
+		return 4
+	case ggufUint64, ggufInt64, ggufFloat64:
+		return 8
+	}
+	return 0
+}
@@ -0,0 +1,95 @@
+package fetcher
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// ggufWriter writes GGUF test files.
+type ggufWriter struct{ bytes.Buffer }
+
+func (w *ggufWriter) u32(v uint32) { binary.Write(&w.Buffer, binary.LittleEndian, v) }
+func (w *ggufWriter) u64(v uint64) { binary.Write(&w.Buffer, binary.LittleEndian, v) }
+func (w *ggufWriter) str(s string) { w.u64(uint64(len(s))); w.WriteString(s) }
+
+func (w *ggufWriter) kvString(key, v string) { w.str(key); w.u32(ggufString); w.str(v) }
+func (w *ggufWriter) kvU32(key string, v uint32) {
+	w.str(key)
+	w.u32(ggufUint32)
+	w.u32(v)
+}
+
+func TestReadGGUF(t *testing.T) {
+	var w ggufWriter
+	w.WriteString("GGUF")
+	w.u32(3)
+	w.u64(2) // tensors
+	w.u64(9) // key-values
+	w.kvU32("llama.context_length", 8192)
+	w.kvString("general.architecture", "llama")
+	w.kvString("general.name", "Meta Llama 3 8B Instruct")
+	w.kvString("general.license", "llama3")
+	w.kvU32("general.file_type", 15)
+	w.kvU32("llama.block_count", 32)
+	w.str("general.tags")
+	w.u32(ggufArray)
+	w.u32(ggufFloat32)
+	w.u64(2)
+	w.u32(0)
+	w.u32(0)
+	w.kvString("tokenizer.ggml.model", "gpt2")
+	w.str("tokenizer.ggml.tokens")
+	w.u32(ggufArray)
+	w.u32(ggufString)
+	w.u64(3)
+	for _, tok := range []string{"a", "b", "<eos>"} {
+		w.str(tok)
+	}
+	for _, tensor := range []struct {
+		name string
+		dims []uint64
+	}{{"token_embd.weight", []uint64{4096, 3}}, {"output_norm.weight", []uint64{4096}}} {
+		w.str(tensor.name)
+		w.u32(uint32(len(tensor.dims)))
+		for _, d := range tensor.dims {
+			w.u64(d)
+		}
+		w.u32(12)
+		w.u64(0)
+	}
+	path := filepath.Join(t.TempDir(), "model.Q4_K_M.gguf")
+	if err := os.WriteFile(path, w.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	h, err := ReadGGUF(path)
+	if err != nil {
+		t.Fatalf("ReadGGUF: %v", err)
+	}
+	want := GGUFHeader{
+		Version:        3,
+		Architecture:   "llama",
+		Name:           "Meta Llama 3 8B Instruct",
+		License:        "llama3",
+		Quantization:   "Q4_K_M",
+		ContextLength:  8192,
+		BlockCount:     32,
+		Tokenizer:      "gpt2",
+		VocabularySize: 3,
+		TensorCount:    2,
+		ParameterCount: 4096*3 + 4096,
+	}
+	if *h != want {
+		t.Errorf("header = %+v\nwant     %+v", *h, want)
+	}
+
+	if _, err := ParseGGUF(bytes.NewReader(w.Bytes()[:40])); err == nil {
+		t.Errorf("truncated header: want error")
+	}
+	if _, err := ParseGGUF(bytes.NewReader([]byte("GGML\x03\x00\x00\x00"))); err == nil {
+		t.Errorf("wrong magic: want error")
+	}
+}
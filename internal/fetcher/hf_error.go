@@ -3,6 +3,7 @@ package fetcher
 import (
 	"errors"
 	"fmt"
+	"io/fs"
 	"net/http"
 )
 
@@ -17,15 +18,33 @@ func (e *HFError) Error() string {
 	return fmt.Sprintf("huggingface api status %d", e.StatusCode)
 }
 
-// IsNotFound reports whether err is an HFError with HTTP 404.
+// IsNotFound reports whether err is an HFError, KaggleError or OllamaError.
+// with HTTP 404, or a file missing from a local model repo (see.
+// LocalModelAPIFetcher).
 func IsNotFound(err error) bool {
-	var e *HFError
-	return errors.As(err, &e) && e.StatusCode == http.StatusNotFound
+	return apiStatus(err) == http.StatusNotFound || errors.Is(err, fs.ErrNotExist)
 }
 
-// IsUnauthorized reports whether err is an HFError with HTTP 401 or 403.
-// This typically means the repo is private and no (or an invalid) token was provided.
+// IsUnauthorized reports whether err is an HFError, KaggleError or.
+// OllamaError with HTTP 401 or 403. This typically means the repo is private.
+// and no (or an invalid) token was provided.
 func IsUnauthorized(err error) bool {
-	var e *HFError
-	return errors.As(err, &e) && (e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden)
+	status := apiStatus(err)
+	return status == http.StatusUnauthorized || status == http.StatusForbidden
+}
+
+// apiStatus returns the status code of the API error in err (0: none).
+func apiStatus(err error) int {
+	var hf *HFError
+	var kaggle *KaggleError
+	var ollama *OllamaError
+	switch {
+	case errors.As(err, &hf):
+		return hf.StatusCode
+	case errors.As(err, &kaggle):
+		return kaggle.StatusCode
+	case errors.As(err, &ollama):
+		return ollama.StatusCode
+	}
+	return 0
 }
@@ -0,0 +1,235 @@
+package fetcher
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// maxCachedBody is the largest response body the HTTP cache stores.
+const maxCachedBody = 16 << 20
+
+// HTTPCache is an on-disk cache of Hugging Face API responses.
+//.
+// GET responses with status 200 or 404 are stored per URL (and per token, so.
+// that private and public answers never mix). Within TTL an entry is served.
+// without contacting the Hub. After that it is revalidated with a.
+// conditional GET (If-None-Match with the stored ETag and If-Modified-Since.
+// with the stored Last-Modified, whichever are known):.
+// a 304 answer refreshes the entry and the cached body is used. While the.
+// Hub is unreachable (see ErrHubUnreachable) expired entries are served as is.
+type HTTPCache struct {
+	Dir string
+	TTL time.Duration
+
+	hits, revalidated, misses atomic.Int64
+}
+
+// httpCache is the cache used by clients from NewHFClient (nil: disabled).
+var httpCache atomic.Pointer[HTTPCache]
+
+// SetHTTPCache makes every client created by NewHFClient afterwards use c.
+// A nil c disables the cache.
+func SetHTTPCache(c *HTTPCache) {
+	httpCache.Store(c)
+}
+
+// NewHTTPCache returns a cache rooted at dir. An empty dir selects.
+// DefaultHTTPCacheDir.
+func NewHTTPCache(dir string, ttl time.Duration) (*HTTPCache, error) {
+	if strings.TrimSpace(dir) == "" {
+		d, err := DefaultHTTPCacheDir()
+		if err != nil {
+			return nil, err
+		}
+		dir = d
+	}
+	return &HTTPCache{Dir: dir, TTL: ttl}, nil
+}
+
+// DefaultHTTPCacheDir returns the per-user cache directory for API responses.
+func DefaultHTTPCacheDir() (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "aibomgen-cli", "http"), nil
+}
+
+// Stats returns the number of responses served from the cache without a.
+// request, served after a 304 revalidation, and fetched in full.
+func (c *HTTPCache) Stats() (hits, revalidated, misses int64) {
+	if c == nil {
+		return 0, 0, 0
+	}
+	return c.hits.Load(), c.revalidated.Load(), c.misses.Load()
+}
+
+// cacheEntry is a stored response.
+type cacheEntry struct {
+	URL        string      `json:"url"`
+	StatusCode int         `json:"status"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+	Stored     time.Time   `json:"stored"`
+}
+
+func (c *HTTPCache) path(url, token string) string {
+	h := sha256.New()
+	h.Write([]byte(url))
+	h.Write([]byte{0})
+	h.Write([]byte(token))
+	d := hex.EncodeToString(h.Sum(nil))
+	return filepath.Join(c.Dir, d[:2], d+".json")
+}
+
+func (c *HTTPCache) load(path string) (*cacheEntry, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var e cacheEntry
+	if json.Unmarshal(data, &e) != nil {
+		return nil, false
+	}
+	return &e, true
+}
+
+// store writes e atomically so concurrent runs never read a partial entry.
+func (c *HTTPCache) store(path string, e *cacheEntry) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// cacheTransport serves GET requests from an HTTPCache.
+type cacheTransport struct {
+	base  http.RoundTripper
+	cache *HTTPCache
+	token string
+}
+
+func (t *cacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || req.Header.Get("Range") != "" {
+		return t.base.RoundTrip(req)
+	}
+	path := t.cache.path(req.URL.String(), t.token)
+	entry, ok := t.cache.load(path)
+	if ok && time.Since(entry.Stored) < t.cache.TTL {
+		t.cache.hits.Add(1)
+		return entry.response(req), nil
+	}
+
+	if ok {
+		req = req.Clone(req.Context())
+		// Send every validator the entry has: servers that ignore the ETag.
+		// (or send weak ones through a proxy) can still answer 304 on the date.
+		if etag := entry.Header.Get("ETag"); etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		if lm := entry.Header.Get("Last-Modified"); lm != "" {
+			req.Header.Set("If-Modified-Since", lm)
+		}
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		// A stale answer beats none while the Hub is unreachable.
+		if ok && errors.Is(err, ErrHubUnreachable) {
+			t.cache.hits.Add(1)
+			return entry.response(req), nil
+		}
+		return nil, err
+	}
+
+	if ok && resp.StatusCode == http.StatusNotModified {
+		resp.Body.Close()
+		for _, k := range []string{"ETag", "Last-Modified", "Cache-Control", "Date"} {
+			if v := resp.Header.Get(k); v != "" {
+				entry.Header.Set(k, v)
+			}
+		}
+		entry.Stored = time.Now()
+		_ = t.cache.store(path, entry) // best effort
+		t.cache.revalidated.Add(1)
+		return entry.response(req), nil
+	}
+
+	t.cache.misses.Add(1)
+	if (resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound) || resp.ContentLength > maxCachedBody {
+		return resp, nil
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxCachedBody+1))
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	if len(body) > maxCachedBody {
+		// Too large to cache: hand back what was read and the rest.
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+		return resp, nil
+	}
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	header := resp.Header.Clone()
+	header.Del("Set-Cookie")
+	header.Del("Content-Length")
+	_ = t.cache.store(path, &cacheEntry{
+		URL:        req.URL.String(),
+		StatusCode: resp.StatusCode,
+		Header:     header,
+		Body:       body,
+		Stored:     time.Now(),
+	})
+	return resp, nil
+}
+
+// response rebuilds the stored response for req.
+func (e *cacheEntry) response(req *http.Request) *http.Response {
+	header := e.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        strconv.Itoa(e.StatusCode) + " " + http.StatusText(e.StatusCode),
+		StatusCode:    e.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,125 @@
+package fetcher
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHTTPCache(t *testing.T) {
+	var requests int
+	var lastIfNoneMatch string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		lastIfNoneMatch = r.Header.Get("If-None-Match")
+		switch r.URL.Path {
+		case "/api/models/org/model":
+			if lastIfNoneMatch == `"v1"` {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("ETag", `"v1"`)
+			io.WriteString(w, `{"id":"org/model"}`)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+
+	cache, err := NewHTTPCache(t.TempDir(), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetHTTPCache(cache)
+	defer SetHTTPCache(nil)
+
+	get := func(client *http.Client, path string) (int, string) {
+		t.Helper()
+		resp, err := client.Get(srv.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(body)
+	}
+
+	client := NewHFClient(5*time.Second, "")
+	for i := 0; i < 2; i++ {
+		if status, body := get(client, "/api/models/org/model"); status != http.StatusOK || body != `{"id":"org/model"}` {
+			t.Fatalf("get %d = %d %q", i, status, body)
+		}
+	}
+	if requests != 1 {
+		t.Fatalf("requests = %d, want 1 (second answer from the cache)", requests)
+	}
+
+	// Another token must not see the cached answer.
+	get(NewHFClient(5*time.Second, "hf_other"), "/api/models/org/model")
+	if requests != 2 {
+		t.Fatalf("requests = %d, want 2", requests)
+	}
+
+	// A stale entry is revalidated and the cached body is served on a 304.
+	cache.TTL = 0
+	if status, body := get(client, "/api/models/org/model"); status != http.StatusOK || body != `{"id":"org/model"}` {
+		t.Fatalf("revalidated get = %d %q", status, body)
+	}
+	if requests != 3 || lastIfNoneMatch != `"v1"` {
+		t.Fatalf("requests = %d, If-None-Match = %q", requests, lastIfNoneMatch)
+	}
+	if hits, revalidated, misses := cache.Stats(); hits != 1 || revalidated != 1 || misses != 2 {
+		t.Fatalf("stats = %d, %d, %d", hits, revalidated, misses)
+	}
+
+	// Server errors are not cached.
+	cache.TTL = time.Hour
+	get(client, "/broken")
+	get(client, "/broken")
+	if requests != 5 {
+		t.Fatalf("requests = %d, want 5", requests)
+	}
+}
+
+func TestHTTPCacheSendsAllValidators(t *testing.T) {
+	var ifNoneMatch, ifModifiedSince string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ifNoneMatch, ifModifiedSince = r.Header.Get("If-None-Match"), r.Header.Get("If-Modified-Since")
+		if ifModifiedSince != "" {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `W/"v1"`)
+		w.Header().Set("Last-Modified", "Mon, 02 Mar 2026 09:00:00 GMT")
+		io.WriteString(w, `{"id":"org/model"}`)
+	}))
+	defer srv.Close()
+
+	cache, err := NewHTTPCache(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetHTTPCache(cache)
+	defer SetHTTPCache(nil)
+
+	client := NewHFClient(5*time.Second, "")
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(srv.URL + "/api/models/org/model")
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK || string(body) != `{"id":"org/model"}` {
+			t.Fatalf("get %d = %d %q", i, resp.StatusCode, body)
+		}
+	}
+	if ifNoneMatch != `W/"v1"` || ifModifiedSince != "Mon, 02 Mar 2026 09:00:00 GMT" {
+		t.Fatalf("If-None-Match = %q, If-Modified-Since = %q", ifNoneMatch, ifModifiedSince)
+	}
+	if _, revalidated, _ := cache.Stats(); revalidated != 1 {
+		t.Fatalf("revalidated = %d, want 1", revalidated)
+	}
+}
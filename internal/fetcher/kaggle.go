@@ -0,0 +1,248 @@
+package fetcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// KaggleError is returned when the Kaggle API responds with a non-2xx HTTP.
+// status. IsNotFound and IsUnauthorized recognise it like HFError.
+type KaggleError struct {
+	StatusCode int
+}
+
+func (e *KaggleError) Error() string {
+	return fmt.Sprintf("kaggle api status %d", e.StatusCode)
+}
+
+// KaggleCredentials authenticate Kaggle API requests (HTTP basic auth with.
+// the user name and API key). Public models and datasets can be read without.
+// them.
+type KaggleCredentials struct {
+	Username string `json:"username"`
+	Key      string `json:"key"`
+}
+
+// LoadKaggleCredentials returns the credentials the Kaggle CLI and kagglehub.
+// use: KAGGLE_USERNAME and KAGGLE_KEY, else the kaggle.json file in.
+// KAGGLE_CONFIG_DIR, ~/.kaggle or ~/.config/kaggle. It returns the zero value.
+// when none are configured.
+func LoadKaggleCredentials() KaggleCredentials {
+	creds := KaggleCredentials{
+		Username: strings.TrimSpace(os.Getenv("KAGGLE_USERNAME")),
+		Key:      strings.TrimSpace(os.Getenv("KAGGLE_KEY")),
+	}
+	if creds.Username != "" && creds.Key != "" {
+		return creds
+	}
+
+	var dirs []string
+	if dir := strings.TrimSpace(os.Getenv("KAGGLE_CONFIG_DIR")); dir != "" {
+		dirs = append(dirs, dir)
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, filepath.Join(home, ".kaggle"), filepath.Join(home, ".config", "kaggle"))
+	}
+	for _, dir := range dirs {
+		data, err := os.ReadFile(filepath.Join(dir, "kaggle.json"))
+		if err != nil {
+			continue
+		}
+		var file KaggleCredentials
+		if json.Unmarshal(data, &file) == nil && file.Username != "" && file.Key != "" {
+			return file
+		}
+	}
+	return KaggleCredentials{}
+}
+
+// kaggleTransport adds the Kaggle credentials to every request when set.
+type kaggleTransport struct {
+	base  http.RoundTripper
+	creds KaggleCredentials
+}
+
+func (t *kaggleTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.creds.Username != "" && t.creds.Key != "" {
+		req = req.Clone(req.Context())
+		req.SetBasicAuth(t.creds.Username, t.creds.Key)
+	}
+	return t.base.RoundTrip(req)
+}
+
+// NewKaggleClient creates an *http.Client for Kaggle API calls that sends.
+// creds and retries like NewHFClientWithRetry. It never sends the Hugging.
+// Face token. GET requests go through the HTTP cache set with SetHTTPCache,.
+// keyed by the Kaggle user.
+func NewKaggleClient(timeout time.Duration, creds KaggleCredentials, policy RetryPolicy) *http.Client {
+	return newAPIClient(&kaggleTransport{base: sharedTransport, creds: creds}, timeout, policy, "kaggle:"+creds.Username)
+}
+
+// newAPIClient wraps transport in the retries of policy and the HTTP cache.
+// for a client of an API other than the Hugging Face Hub. cacheToken keeps.
+// its cache entries apart from those of other APIs and users.
+func newAPIClient(transport http.RoundTripper, timeout time.Duration, policy RetryPolicy, cacheToken string) *http.Client {
+	clientTimeout := timeout
+	if policy.MaxRetries > 0 {
+		if policy.MaxBackoff <= 0 {
+			policy.MaxBackoff = DefaultMaxBackoff
+		}
+		transport = &retryTransport{base: transport, policy: policy, timeout: timeout}
+		clientTimeout = 0
+	}
+	if c := httpCache.Load(); c != nil {
+		transport = &cacheTransport{base: transport, cache: c, token: cacheToken}
+	}
+	return &http.Client{Timeout: clientTimeout, Transport: transport}
+}
+
+// KaggleTag is a tag of a Kaggle model or dataset.
+type KaggleTag struct {
+	Ref  string `json:"ref"`
+	Name string `json:"name"`
+}
+
+// KaggleModel is the decoded response from GET.
+// https://www.kaggle.com/api/v1/models/:owner/:model/get, with the variation.
+// the handle names.
+type KaggleModel struct {
+	ID          int64       `json:"id"`
+	Ref         string      `json:"ref"`
+	Title       string      `json:"title"`
+	Subtitle    string      `json:"subtitle"`
+	Author      string      `json:"author"`
+	Slug        string      `json:"slug"`
+	IsPrivate   bool        `json:"isPrivate"`
+	Description string      `json:"description"`
+	PublishTime string      `json:"publishTime"`
+	URL         string      `json:"url"`
+	VoteCount   int         `json:"voteCount"`
+	Tags        []KaggleTag `json:"tags"`
+
+	// Owner is the owner segment of the handle.
+	Owner string `json:"-"`
+	// Instance is the variation (framework and variation slug) of the handle.
+	Instance *KaggleModelInstance `json:"-"`
+}
+
+// KaggleModelInstance is the decoded response from GET.
+// https://www.kaggle.com/api/v1/models/:owner/:model/:framework/:variation/get.
+type KaggleModelInstance struct {
+	ID                     int64    `json:"id"`
+	Slug                   string   `json:"slug"`
+	Framework              string   `json:"framework"`
+	FineTunable            bool     `json:"fineTunable"`
+	Overview               string   `json:"overview"`
+	Usage                  string   `json:"usage"`
+	VersionNumber          int      `json:"versionNumber"`
+	URL                    string   `json:"url"`
+	LicenseName            string   `json:"licenseName"`
+	ModelInstanceType      string   `json:"modelInstanceType"`
+	ExternalBaseModelURL   string   `json:"externalBaseModelUrl"`
+	TrainingData           []string `json:"trainingData"`
+	TotalUncompressedBytes int64    `json:"totalUncompressedBytes"`
+}
+
+// KaggleDataset is the decoded response from GET.
+// https://www.kaggle.com/api/v1/datasets/view/:owner/:dataset.
+type KaggleDataset struct {
+	ID                   int64       `json:"id"`
+	Ref                  string      `json:"ref"`
+	Title                string      `json:"title"`
+	Subtitle             string      `json:"subtitle"`
+	Description          string      `json:"description"`
+	CreatorName          string      `json:"creatorName"`
+	OwnerName            string      `json:"ownerName"`
+	OwnerRef             string      `json:"ownerRef"`
+	URL                  string      `json:"url"`
+	LastUpdated          string      `json:"lastUpdated"`
+	LicenseName          string      `json:"licenseName"`
+	TotalBytes           int64       `json:"totalBytes"`
+	DownloadCount        int         `json:"downloadCount"`
+	VoteCount            int         `json:"voteCount"`
+	UsabilityRating      float64     `json:"usabilityRating"`
+	CurrentVersionNumber int         `json:"currentVersionNumber"`
+	IsPrivate            bool        `json:"isPrivate"`
+	Tags                 []KaggleTag `json:"tags"`
+}
+
+// KaggleFetcher fetches model and dataset metadata from the Kaggle API.
+type KaggleFetcher struct {
+	Client  *http.Client
+	BaseURL string // optional; defaults to "https://www.kaggle.com"
+}
+
+// FetchModel fetches the model and the variation of handle.
+// (owner/model/framework/variation).
+func (f *KaggleFetcher) FetchModel(handle string) (*KaggleModel, error) {
+	parts := strings.Split(strings.Trim(strings.TrimSpace(handle), "/"), "/")
+	if len(parts) < 4 {
+		return nil, fmt.Errorf("invalid Kaggle model handle %q (expected owner/model/framework/variation)", handle)
+	}
+	owner, model, framework, variation := parts[0], parts[1], parts[2], parts[3]
+
+	var m KaggleModel
+	if err := f.get(fmt.Sprintf("models/%s/%s/get", owner, model), &m); err != nil {
+		return nil, err
+	}
+	var inst KaggleModelInstance
+	if err := f.get(fmt.Sprintf("models/%s/%s/%s/%s/get", owner, model, framework, variation), &inst); err != nil {
+		return nil, err
+	}
+	if inst.Framework == "" {
+		inst.Framework = framework
+	}
+	if inst.Slug == "" {
+		inst.Slug = variation
+	}
+	m.Owner = owner
+	m.Instance = &inst
+	return &m, nil
+}
+
+// FetchDataset fetches the dataset of handle (owner/dataset).
+func (f *KaggleFetcher) FetchDataset(handle string) (*KaggleDataset, error) {
+	parts := strings.Split(strings.Trim(strings.TrimSpace(handle), "/"), "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid Kaggle dataset handle %q (expected owner/dataset)", handle)
+	}
+	var d KaggleDataset
+	if err := f.get(fmt.Sprintf("datasets/view/%s/%s", parts[0], parts[1]), &d); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (f *KaggleFetcher) get(path string, out any) error {
+	client := f.Client
+	if client == nil {
+		client = defaultClient
+	}
+	baseURL := strings.TrimRight(strings.TrimSpace(f.BaseURL), "/")
+	if baseURL == "" {
+		baseURL = "https://www.kaggle.com"
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, baseURL+"/api/v1/"+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return &KaggleError{StatusCode: resp.StatusCode}
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
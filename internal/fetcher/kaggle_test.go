@@ -0,0 +1,78 @@
+package fetcher
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestKaggleFetcher(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, key, ok := r.BasicAuth(); !ok || user != "alice" || key != "secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/api/v1/models/google/gemma/get":
+			w.Write([]byte(`{"id":1,"title":"Gemma","author":"google","voteCount":42,"tags":[{"ref":"nlp","name":"NLP"}]}`))
+		case "/api/v1/models/google/gemma/transformers/2b/get":
+			w.Write([]byte(`{"id":2,"framework":"transformers","licenseName":"Gemma","versionNumber":3,"trainingData":["web documents"]}`))
+		case "/api/v1/datasets/view/uciml/iris":
+			w.Write([]byte(`{"ref":"uciml/iris","title":"Iris Species","licenseName":"CC0: Public Domain","totalBytes":3687,"usabilityRating":0.79}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	f := &KaggleFetcher{Client: NewKaggleClient(0, KaggleCredentials{Username: "alice", Key: "secret"}, RetryPolicy{}), BaseURL: srv.URL}
+
+	m, err := f.FetchModel("google/gemma/transformers/2b")
+	if err != nil {
+		t.Fatalf("FetchModel: %v", err)
+	}
+	if m.Title != "Gemma" || m.Owner != "google" || m.VoteCount != 42 || m.Instance == nil || m.Instance.LicenseName != "Gemma" || m.Instance.Slug != "2b" || len(m.Instance.TrainingData) != 1 {
+		t.Errorf("model = %+v, instance = %+v", m, m.Instance)
+	}
+
+	d, err := f.FetchDataset("uciml/iris")
+	if err != nil {
+		t.Fatalf("FetchDataset: %v", err)
+	}
+	if d.Title != "Iris Species" || d.TotalBytes != 3687 || d.UsabilityRating != 0.79 {
+		t.Errorf("dataset = %+v", d)
+	}
+
+	if _, err := f.FetchDataset("uciml/missing"); !IsNotFound(err) {
+		t.Errorf("missing dataset: err = %v, want not found", err)
+	}
+	anon := &KaggleFetcher{Client: NewKaggleClient(0, KaggleCredentials{}, RetryPolicy{}), BaseURL: srv.URL}
+	if _, err := anon.FetchDataset("uciml/iris"); !IsUnauthorized(err) {
+		t.Errorf("anonymous request: err = %v, want unauthorized", err)
+	}
+	if _, err := f.FetchModel("google/gemma"); err == nil {
+		t.Errorf("expected an error for an incomplete model handle")
+	}
+}
+
+func TestLoadKaggleCredentials(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "kaggle.json"), []byte(`{"username":"bob","key":"k"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("KAGGLE_USERNAME", "")
+	t.Setenv("KAGGLE_KEY", "")
+	t.Setenv("KAGGLE_CONFIG_DIR", dir)
+	if got := LoadKaggleCredentials(); got.Username != "bob" || got.Key != "k" {
+		t.Errorf("from kaggle.json: %+v", got)
+	}
+
+	t.Setenv("KAGGLE_USERNAME", "alice")
+	t.Setenv("KAGGLE_KEY", "secret")
+	if got := LoadKaggleCredentials(); got.Username != "alice" || got.Key != "secret" {
+		t.Errorf("from the environment: %+v", got)
+	}
+}
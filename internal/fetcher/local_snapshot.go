@@ -0,0 +1,286 @@
+package fetcher
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// The local fetchers read the metadata of a model from a repo that was.
+// downloaded or cloned from the Hugging Face Hub, so that AIBOMs can be.
+// generated without network access. Supported layouts are a git clone, a.
+// "huggingface-cli download --local-dir" directory and a snapshot directory.
+// of the Hugging Face cache (models--org--name/snapshots/<revision>).
+//.
+// The model ID passed to Fetch is ignored: the directory is the repo. A.
+// missing file is reported as an fs.ErrNotExist error, which IsNotFound.
+// recognises.
+
+// revisionPattern matches a full git commit hash.
+var revisionPattern = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// snapshotSkipDirs are directories of a local repo that are not repo files.
+var snapshotSkipDirs = map[string]bool{".git": true, ".cache": true}
+
+// LocalModelAPIFetcher builds a ModelAPIResponse from a local model repo:.
+// the README front matter supplies the card data, license, tags, pipeline tag.
+// and library, config.json the model type and architectures, and the file.
+// listing the siblings and storage size.
+type LocalModelAPIFetcher struct {
+	Dir string
+}
+
+func (f *LocalModelAPIFetcher) Fetch(modelID string) (*ModelAPIResponse, error) {
+	info, err := os.Stat(f.Dir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", f.Dir)
+	}
+
+	id := strings.TrimSpace(modelID)
+	if id == "" {
+		id = LocalModelID(f.Dir)
+	}
+	resp := &ModelAPIResponse{ID: id, ModelID: id, SHA: localRevision(f.Dir)}
+	if owner, _, ok := strings.Cut(id, "/"); ok {
+		resp.Author = owner
+	}
+
+	if data, err := os.ReadFile(filepath.Join(f.Dir, "config.json")); err == nil {
+		if err := json.Unmarshal(data, &resp.Config); err != nil {
+			return nil, fmt.Errorf("parse config.json: %w", err)
+		}
+	}
+
+	if data, err := os.ReadFile(filepath.Join(f.Dir, "README.md")); err == nil {
+		fm, _ := splitFrontMatter(strings.ReplaceAll(string(data), "\r\n", "\n"))
+		resp.CardData = fm
+		resp.License = strings.TrimSpace(stringFromAny(fm["license"]))
+		resp.PipelineTag = strings.TrimSpace(stringFromAny(fm["pipeline_tag"]))
+		resp.LibraryName = strings.TrimSpace(stringFromAny(fm["library_name"]))
+		resp.Tags = stringSliceFromAny(fm["tags"])
+	}
+
+	err = filepath.WalkDir(f.Dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(f.Dir, path)
+		if d.IsDir() {
+			if snapshotSkipDirs[rel] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		// Files of the Hugging Face cache are symlinks to blobs.
+		fi, err := os.Stat(path)
+		if err != nil || fi.IsDir() {
+			return nil
+		}
+		resp.Siblings = append(resp.Siblings, ModelSibling{RFilename: filepath.ToSlash(rel)})
+		resp.UsedStorage += fi.Size()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.LibraryName == "" {
+		resp.LibraryName = localLibrary(resp)
+	}
+	return resp, nil
+}
+
+// localLibrary infers the library of a repo whose card does not name one.
+func localLibrary(resp *ModelAPIResponse) string {
+	for _, s := range resp.Siblings {
+		switch s.RFilename {
+		case "model_index.json":
+			return "diffusers"
+		case "adapter_config.json":
+			return "peft"
+		}
+	}
+	if resp.Config.ModelType != "" || len(resp.Config.Architectures) > 0 {
+		return "transformers"
+	}
+	return ""
+}
+
+// LocalModelReadmeFetcher reads the model card (README.md) of a local model.
+// repo.
+type LocalModelReadmeFetcher struct {
+	Dir string
+}
+
+func (f *LocalModelReadmeFetcher) Fetch(string) (*ModelReadmeCard, error) {
+	data, err := os.ReadFile(filepath.Join(f.Dir, "README.md"))
+	if err != nil {
+		return nil, err
+	}
+	return parseReadmeCard(string(data)), nil
+}
+
+// LocalModelIndexFetcher reads model_index.json from a local diffusers.
+// pipeline repo.
+type LocalModelIndexFetcher struct {
+	Dir string
+}
+
+func (f *LocalModelIndexFetcher) Fetch(string) (*ModelIndex, error) {
+	data, err := os.ReadFile(filepath.Join(f.Dir, "model_index.json"))
+	if err != nil {
+		return nil, err
+	}
+	return ParseModelIndex(data)
+}
+
+// LocalModelConfigFetcher reads config.json from a local model repo.
+type LocalModelConfigFetcher struct {
+	Dir string
+}
+
+func (f *LocalModelConfigFetcher) Fetch(string) (*ModelConfig, error) {
+	data, err := os.ReadFile(filepath.Join(f.Dir, "config.json"))
+	if err != nil {
+		return nil, err
+	}
+	return ParseModelConfig(data)
+}
+
+// LocalModelLicenseFetcher reads the license file of a local model repo.
+type LocalModelLicenseFetcher struct {
+	Dir string
+}
+
+func (f *LocalModelLicenseFetcher) Fetch(_, path string) (*LicenseFile, error) {
+	if path == "" {
+		return nil, fmt.Errorf("empty license path")
+	}
+	file, err := os.Open(filepath.Join(f.Dir, filepath.FromSlash(path)))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxLicenseFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxLicenseFileSize {
+		return nil, fmt.Errorf("license file %s exceeds %d bytes", path, maxLicenseFileSize)
+	}
+	return NewLicenseFile(path, data), nil
+}
+
+// LocalModelID derives the Hugging Face ID of the model in dir: from the.
+// Hugging Face cache layout (models--org--name/snapshots/<revision>), else.
+// from the _name_or_path of config.json when it names a Hub repo, else the.
+// directory name.
+func LocalModelID(dir string) string {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		abs = filepath.Clean(dir)
+	}
+	if filepath.Base(filepath.Dir(abs)) == "snapshots" {
+		repo := filepath.Base(filepath.Dir(filepath.Dir(abs)))
+		if rest, ok := strings.CutPrefix(repo, "models--"); ok && rest != "" {
+			return strings.ReplaceAll(rest, "--", "/")
+		}
+	}
+
+	if data, err := os.ReadFile(filepath.Join(abs, "config.json")); err == nil {
+		var cfg struct {
+			NameOrPath string `json:"_name_or_path"`
+		}
+		if json.Unmarshal(data, &cfg) == nil && isHubID(cfg.NameOrPath) {
+			return cfg.NameOrPath
+		}
+	}
+	return filepath.Base(abs)
+}
+
+// isHubID reports whether s looks like an "org/name" Hub repo ID rather than.
+// a filesystem path.
+func isHubID(s string) bool {
+	if strings.ContainsAny(s, `\:`) || strings.HasPrefix(s, "/") || strings.HasPrefix(s, ".") || strings.HasPrefix(s, "~") {
+		return false
+	}
+	org, name, ok := strings.Cut(s, "/")
+	return ok && org != "" && name != "" && !strings.Contains(name, "/")
+}
+
+// localRevision returns the commit of a local model repo, or "" when it is.
+// unknown. It is read from the snapshot directory name of the Hugging Face.
+// cache, from the download metadata that huggingface-cli writes to a.
+// --local-dir, or from the HEAD of a git clone.
+func localRevision(dir string) string {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		abs = filepath.Clean(dir)
+	}
+	if base := filepath.Base(abs); filepath.Base(filepath.Dir(abs)) == "snapshots" && revisionPattern.MatchString(base) {
+		return base
+	}
+
+	metadata, _ := filepath.Glob(filepath.Join(abs, ".cache", "huggingface", "download", "*.metadata"))
+	for _, m := range metadata {
+		if rev := firstLine(m); revisionPattern.MatchString(rev) {
+			return rev
+		}
+	}
+
+	return gitHead(filepath.Join(abs, ".git"))
+}
+
+// gitHead resolves HEAD of the git directory gitDir to a commit hash.
+func gitHead(gitDir string) string {
+	head := firstLine(filepath.Join(gitDir, "HEAD"))
+	ref, ok := strings.CutPrefix(head, "ref: ")
+	if !ok {
+		if revisionPattern.MatchString(head) {
+			return head
+		}
+		return ""
+	}
+	if rev := firstLine(filepath.Join(gitDir, filepath.FromSlash(ref))); revisionPattern.MatchString(rev) {
+		return rev
+	}
+
+	// The ref may only be listed in packed-refs.
+	f, err := os.Open(filepath.Join(gitDir, "packed-refs"))
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		rev, name, ok := strings.Cut(strings.TrimSpace(sc.Text()), " ")
+		if ok && name == ref && revisionPattern.MatchString(rev) {
+			return rev
+		}
+	}
+	return ""
+}
+
+// firstLine returns the trimmed first line of the file at path, or "".
+func firstLine(path string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	if sc.Scan() {
+		return strings.TrimSpace(sc.Text())
+	}
+	return ""
+}
@@ -0,0 +1,144 @@
+package fetcher
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestLocalModelAPIFetcher_Fetch(t *testing.T) {
+	rev := "0123456789abcdef0123456789abcdef01234567"
+	dir := filepath.Join(t.TempDir(), "models--acme--tiny-bert", "snapshots", rev)
+	writeFiles(t, dir, map[string]string{
+		"README.md":         "---\nlicense: mit\npipeline_tag: fill-mask\ntags: [bert]\ndatasets: [wikipedia]\n---\n# tiny-bert\n",
+		"config.json":       `{"model_type":"bert","architectures":["BertForMaskedLM"]}`,
+		"model.safetensors": "weights",
+		"LICENSE":           "MIT License\n",
+		".git/HEAD":         "ref: refs/heads/main\n",
+	})
+
+	resp, err := (&LocalModelAPIFetcher{Dir: dir}).Fetch("")
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if resp.ID != "acme/tiny-bert" || resp.Author != "acme" || resp.SHA != rev {
+		t.Fatalf("unexpected identity %q %q %q", resp.ID, resp.Author, resp.SHA)
+	}
+	if resp.License != "mit" || resp.PipelineTag != "fill-mask" || resp.LibraryName != "transformers" {
+		t.Fatalf("unexpected card fields %+v", resp)
+	}
+	if resp.Config.ModelType != "bert" || len(resp.Config.Architectures) != 1 {
+		t.Fatalf("unexpected config %+v", resp.Config)
+	}
+	if resp.CardData["datasets"] == nil {
+		t.Fatalf("card data not read: %v", resp.CardData)
+	}
+	var files []string
+	for _, s := range resp.Siblings {
+		files = append(files, s.RFilename)
+	}
+	want := []string{"LICENSE", "README.md", "config.json", "model.safetensors"}
+	if len(files) != len(want) {
+		t.Fatalf("siblings = %v, want %v", files, want)
+	}
+	for i := range want {
+		if files[i] != want[i] {
+			t.Fatalf("siblings = %v, want %v", files, want)
+		}
+	}
+	if resp.UsedStorage == 0 {
+		t.Fatal("UsedStorage not computed")
+	}
+	if got := FindLicenseFile(resp); got != "LICENSE" {
+		t.Fatalf("FindLicenseFile = %q", got)
+	}
+
+	lf, err := (&LocalModelLicenseFetcher{Dir: dir}).Fetch(resp.ID, "LICENSE")
+	if err != nil || lf.Text != "MIT License\n" {
+		t.Fatalf("license file = %+v, %v", lf, err)
+	}
+	card, err := (&LocalModelReadmeFetcher{Dir: dir}).Fetch(resp.ID)
+	if err != nil || card.License != "mit" || len(card.Datasets) != 1 {
+		t.Fatalf("readme = %+v, %v", card, err)
+	}
+	if _, err := (&LocalModelIndexFetcher{Dir: dir}).Fetch(resp.ID); !IsNotFound(err) {
+		t.Fatalf("missing model_index.json: err = %v, want not found", err)
+	}
+}
+
+func TestLocalModelAPIFetcher_MissingDir(t *testing.T) {
+	if _, err := (&LocalModelAPIFetcher{Dir: filepath.Join(t.TempDir(), "missing")}).Fetch(""); !IsNotFound(err) {
+		t.Fatalf("err = %v, want not found", err)
+	}
+}
+
+func TestLocalModelID(t *testing.T) {
+	root := t.TempDir()
+
+	named := filepath.Join(root, "checkout")
+	writeFiles(t, named, map[string]string{"config.json": `{"_name_or_path":"org/model"}`})
+	pathName := filepath.Join(root, "finetuned")
+	writeFiles(t, pathName, map[string]string{"config.json": `{"_name_or_path":"/home/me/base"}`})
+
+	tests := []struct {
+		dir  string
+		want string
+	}{
+		{filepath.Join(root, "models--org--name", "snapshots", "abc"), "org/name"},
+		{named, "org/model"},
+		{pathName, "finetuned"},
+		{filepath.Join(root, "plain"), "plain"},
+	}
+	for _, tt := range tests {
+		if got := LocalModelID(tt.dir); got != tt.want {
+			t.Errorf("LocalModelID(%s) = %q, want %q", tt.dir, got, tt.want)
+		}
+	}
+}
+
+func TestLocalRevision(t *testing.T) {
+	rev := "89abcdef0123456789abcdef0123456789abcdef"
+
+	clone := t.TempDir()
+	writeFiles(t, clone, map[string]string{
+		".git/HEAD":        "ref: refs/heads/main\n",
+		".git/packed-refs": "# pack-refs with: peeled\n" + rev + " refs/heads/main\n",
+	})
+	if got := localRevision(clone); got != rev {
+		t.Errorf("packed ref: got %q", got)
+	}
+
+	loose := t.TempDir()
+	writeFiles(t, loose, map[string]string{
+		".git/HEAD":            "ref: refs/heads/main\n",
+		".git/refs/heads/main": rev + "\n",
+	})
+	if got := localRevision(loose); got != rev {
+		t.Errorf("loose ref: got %q", got)
+	}
+
+	download := t.TempDir()
+	writeFiles(t, download, map[string]string{
+		".cache/huggingface/download/config.json.metadata": rev + "\n\"etag\"\n1700000000.0\n",
+	})
+	if got := localRevision(download); got != rev {
+		t.Errorf("download metadata: got %q", got)
+	}
+
+	if got := localRevision(t.TempDir()); got != "" {
+		t.Errorf("no revision: got %q", got)
+	}
+}
@@ -11,25 +11,33 @@ import (
 
 // ---- Markdown extraction helpers (shared with model_readme_fetcher and dataset_readme_fetcher) ----.
 
-func splitFrontMatter(raw string) (map[string]any, string) {
-	raw = strings.TrimSpace(raw)
+// cutFrontMatter splits raw (already trimmed) into its YAML front matter and.
+// the remainder starting at the closing '---' marker.
+func cutFrontMatter(raw string) (yamlText, rest string, ok bool) {
 	if !strings.HasPrefix(raw, "---\n") {
-		return nil, raw
+		return "", "", false
 	}
 	// Find the second '---' marker at start of a line.
 	// We only treat it as front matter if it starts at the very beginning.
-	rest := strings.TrimPrefix(raw, "---\n")
+	rest = strings.TrimPrefix(raw, "---\n")
 	idx := strings.Index(rest, "\n---\n")
 	if idx < 0 {
 		// allow file ending marker.
 		idx = strings.Index(rest, "\n---")
 		if idx < 0 {
-			return nil, raw
+			return "", "", false
 		}
 	}
+	return rest[:idx], rest[idx:], true
+}
 
-	y := rest[:idx]
-	body := strings.TrimSpace(rest[idx:])
+func splitFrontMatter(raw string) (map[string]any, string) {
+	raw = strings.TrimSpace(raw)
+	y, rest, ok := cutFrontMatter(raw)
+	if !ok {
+		return nil, raw
+	}
+	body := strings.TrimSpace(rest)
 	body = strings.TrimPrefix(body, "\n---\n")
 	body = strings.TrimPrefix(body, "\n---")
 	body = strings.TrimSpace(body)
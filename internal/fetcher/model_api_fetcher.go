@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"strings"
 )
@@ -75,12 +76,31 @@ type ModelAPIResponse struct {
 		ModelType     string   `json:"model_type"`
 		Architectures []string `json:"architectures"`
 	} `json:"config"`
+	Siblings []ModelSibling `json:"siblings"`
+	// Safetensors is the parameter count the Hub reads from the safetensors.
+	// headers of the repo, or nil when it has none.
+	Safetensors *SafetensorsInfo `json:"safetensors,omitempty"`
+
+	// Raw is the response body as received, kept for --include-raw-metadata.
+	Raw []byte `json:"-"`
+}
+
+// SafetensorsInfo is the parameter count of the safetensors weights of a repo,.
+// in total and per data type.
+type SafetensorsInfo struct {
+	Parameters map[string]uint64 `json:"parameters"`
+	Total      uint64            `json:"total"`
+}
+
+// ModelSibling is one file of the repo as listed in the API response.
+type ModelSibling struct {
+	RFilename string `json:"rfilename"`
 }
 
 func (f *ModelAPIFetcher) Fetch(modelID string) (*ModelAPIResponse, error) {
 	client := f.Client
 	if client == nil {
-		client = http.DefaultClient
+		client = defaultClient
 	}
 
 	trimmedModelID := strings.TrimPrefix(strings.TrimSpace(modelID), "/")
@@ -107,9 +127,14 @@ func (f *ModelAPIFetcher) Fetch(modelID string) (*ModelAPIResponse, error) {
 		return nil, &HFError{StatusCode: resp.StatusCode}
 	}
 
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
 	var parsed ModelAPIResponse
-	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+	if err := json.Unmarshal(body, &parsed); err != nil {
 		return nil, err
 	}
+	parsed.Raw = body
 	return &parsed, nil
 }
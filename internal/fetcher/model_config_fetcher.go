@@ -0,0 +1,123 @@
+package fetcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxModelConfigSize bounds how much of a config.json is downloaded.
+const maxModelConfigSize = 4 << 20
+
+// contextLengthKeys are the config.json keys that hold the context length of.
+// a model, in order of preference.
+var contextLengthKeys = []string{
+	"max_position_embeddings", "n_positions", "n_ctx", "max_sequence_length",
+	"seq_length", "max_seq_len",
+}
+
+// ModelConfig is the part of a transformers config.json AIBoMGen records.
+type ModelConfig struct {
+	ModelType string
+	// ContextLength is the maximum number of tokens the model attends to,.
+	// or 0 when the config does not declare it.
+	ContextLength uint64
+}
+
+// ModelConfigFetcher fetches config.json for a model repo.
+// .
+// It uses URLs like:.
+// .
+//
+//	GET https://huggingface.co/{modelID}/resolve/main/config.json.
+type ModelConfigFetcher struct {
+	Client  *http.Client
+	BaseURL string // optional; defaults to "https://huggingface.co"
+}
+
+func (f *ModelConfigFetcher) Fetch(modelID string) (*ModelConfig, error) {
+	client := f.Client
+	if client == nil {
+		client = defaultClient
+	}
+
+	trimmedModelID := strings.TrimPrefix(strings.TrimSpace(modelID), "/")
+	if trimmedModelID == "" {
+		return nil, fmt.Errorf("empty model id")
+	}
+
+	baseURL := strings.TrimRight(strings.TrimSpace(f.BaseURL), "/")
+	if baseURL == "" {
+		baseURL = "https://huggingface.co"
+	}
+
+	url := fmt.Sprintf("%s/%s/resolve/main/config.json", baseURL, trimmedModelID)
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &HFError{StatusCode: resp.StatusCode}
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxModelConfigSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxModelConfigSize {
+		return nil, fmt.Errorf("config.json exceeds %d bytes", maxModelConfigSize)
+	}
+	return ParseModelConfig(data)
+}
+
+// ParseModelConfig decodes a config.json. Multimodal configs nest the.
+// language model under text_config or llm_config; its context length is used.
+// when the top level declares none.
+func ParseModelConfig(data []byte) (*ModelConfig, error) {
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	cfg := &ModelConfig{ModelType: strings.TrimSpace(stringFromAny(raw["model_type"]))}
+	cfg.ContextLength = configContextLength(raw)
+	for _, nested := range []string{"text_config", "llm_config"} {
+		if sub, ok := raw[nested].(map[string]any); ok && cfg.ContextLength == 0 {
+			cfg.ContextLength = configContextLength(sub)
+		}
+	}
+	return cfg, nil
+}
+
+func configContextLength(raw map[string]any) uint64 {
+	for _, key := range contextLengthKeys {
+		if n, ok := raw[key].(float64); ok && n > 0 && n == float64(uint64(n)) {
+			return uint64(n)
+		}
+	}
+	return 0
+}
+
+// HasModelConfig reports whether the repo of resp has a config.json at its.
+// root.
+func HasModelConfig(resp *ModelAPIResponse) bool {
+	if resp == nil {
+		return false
+	}
+	for _, s := range resp.Siblings {
+		if s.RFilename == "config.json" {
+			return true
+		}
+	}
+	return false
+}
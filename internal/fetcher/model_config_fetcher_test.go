@@ -0,0 +1,43 @@
+package fetcher
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestModelConfigFetcher(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/org/llm/resolve/main/config.json":
+			_, _ = w.Write([]byte(`{"model_type": "llama", "max_position_embeddings": 8192, "n_positions": 512}`))
+		case "/org/vlm/resolve/main/config.json":
+			_, _ = w.Write([]byte(`{"model_type": "llava", "text_config": {"max_position_embeddings": 4096}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	f := &ModelConfigFetcher{Client: srv.Client(), BaseURL: srv.URL}
+	cfg, err := f.Fetch("org/llm")
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if cfg.ModelType != "llama" || cfg.ContextLength != 8192 {
+		t.Errorf("config = %+v", cfg)
+	}
+	if cfg, err := f.Fetch("org/vlm"); err != nil || cfg.ContextLength != 4096 {
+		t.Errorf("nested text config = %+v, %v", cfg, err)
+	}
+	if _, err := f.Fetch("org/missing"); !IsNotFound(err) {
+		t.Errorf("missing config: err = %v", err)
+	}
+
+	if !HasModelConfig(&ModelAPIResponse{Siblings: []ModelSibling{{RFilename: "config.json"}}}) {
+		t.Errorf("HasModelConfig: want true")
+	}
+	if HasModelConfig(&ModelAPIResponse{Siblings: []ModelSibling{{RFilename: "unet/config.json"}}}) {
+		t.Errorf("HasModelConfig: subfolder config counted")
+	}
+}
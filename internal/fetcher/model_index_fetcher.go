@@ -0,0 +1,114 @@
+package fetcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// PipelineComponent is one part of a diffusers pipeline as declared in.
+// model_index.json: the subfolder Name holds a Class loaded from Library.
+// (e.g. "unet" → diffusers UNet2DConditionModel).
+type PipelineComponent struct {
+	Name    string
+	Library string
+	Class   string
+}
+
+// ModelIndex is the decoded model_index.json of a diffusers pipeline repo.
+type ModelIndex struct {
+	ClassName        string
+	DiffusersVersion string
+	// Components is sorted by Name. Optional parts declared as [null, null].
+	// are left out.
+	Components []PipelineComponent
+}
+
+// ModelIndexFetcher fetches model_index.json for a diffusers pipeline repo.
+// .
+// It uses URLs like:.
+// .
+//
+//	GET https://huggingface.co/{modelID}/resolve/main/model_index.json.
+type ModelIndexFetcher struct {
+	Client  *http.Client
+	BaseURL string // optional; defaults to "https://huggingface.co"
+}
+
+func (f *ModelIndexFetcher) Fetch(modelID string) (*ModelIndex, error) {
+	client := f.Client
+	if client == nil {
+		client = defaultClient
+	}
+
+	trimmedModelID := strings.TrimPrefix(strings.TrimSpace(modelID), "/")
+	if trimmedModelID == "" {
+		return nil, fmt.Errorf("empty model id")
+	}
+
+	baseURL := strings.TrimRight(strings.TrimSpace(f.BaseURL), "/")
+	if baseURL == "" {
+		baseURL = "https://huggingface.co"
+	}
+
+	url := fmt.Sprintf("%s/%s/resolve/main/model_index.json", baseURL, trimmedModelID)
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &HFError{StatusCode: resp.StatusCode}
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return ParseModelIndex(data)
+}
+
+// ParseModelIndex decodes a diffusers model_index.json. Keys starting with.
+// an underscore are pipeline metadata; every other key whose value is a.
+// [library, class] pair is a pipeline component. Other values, such as.
+// boolean pipeline flags, are ignored.
+func ParseModelIndex(data []byte) (*ModelIndex, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	idx := &ModelIndex{}
+	for key, val := range raw {
+		switch {
+		case key == "_class_name":
+			_ = json.Unmarshal(val, &idx.ClassName)
+		case key == "_diffusers_version":
+			_ = json.Unmarshal(val, &idx.DiffusersVersion)
+		case strings.HasPrefix(key, "_"):
+		default:
+			var pair []*string
+			if err := json.Unmarshal(val, &pair); err != nil || len(pair) != 2 || pair[0] == nil || pair[1] == nil {
+				continue
+			}
+			idx.Components = append(idx.Components, PipelineComponent{
+				Name:    key,
+				Library: strings.TrimSpace(*pair[0]),
+				Class:   strings.TrimSpace(*pair[1]),
+			})
+		}
+	}
+	sort.Slice(idx.Components, func(i, j int) bool { return idx.Components[i].Name < idx.Components[j].Name })
+	return idx, nil
+}
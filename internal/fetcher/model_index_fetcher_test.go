@@ -0,0 +1,47 @@
+package fetcher
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestModelIndexFetcher_Fetch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/org/sd/resolve/main/model_index.json" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{
+  "_class_name": "StableDiffusionPipeline",
+  "_diffusers_version": "0.30.0",
+  "vae": ["diffusers", "AutoencoderKL"],
+  "text_encoder": ["transformers", "CLIPTextModel"],
+  "safety_checker": [null, null],
+  "requires_safety_checker": false
+}`))
+	}))
+	defer srv.Close()
+
+	f := &ModelIndexFetcher{Client: srv.Client(), BaseURL: srv.URL + "/"}
+	idx, err := f.Fetch("/org/sd")
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	want := &ModelIndex{
+		ClassName:        "StableDiffusionPipeline",
+		DiffusersVersion: "0.30.0",
+		Components: []PipelineComponent{
+			{Name: "text_encoder", Library: "transformers", Class: "CLIPTextModel"},
+			{Name: "vae", Library: "diffusers", Class: "AutoencoderKL"},
+		},
+	}
+	if !reflect.DeepEqual(idx, want) {
+		t.Fatalf("got %+v, want %+v", idx, want)
+	}
+
+	if _, err := f.Fetch("org/single-vae"); !IsNotFound(err) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
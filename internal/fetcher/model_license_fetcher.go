@@ -0,0 +1,141 @@
+package fetcher
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// MaxLicenseTextSize is the largest license file whose text is embedded in.
+// the BOM. Larger files are only recorded by path and digest.
+const MaxLicenseTextSize = 256 << 10
+
+// maxLicenseFileSize bounds how much of a license file is downloaded.
+const maxLicenseFileSize = 4 << 20
+
+// licenseFileNames are the root-level file names recognised as the license.
+// of a repo, compared case-insensitively and without extension.
+var licenseFileNames = []string{"license", "licence", "copying"}
+
+// LicenseFile is the license file of a model repo.
+type LicenseFile struct {
+	Path   string
+	SHA256 string
+	Size   int64
+	// Text is the file content, or empty when the file is larger than.
+	// MaxLicenseTextSize.
+	Text string
+	// Copyright are the copyright notices in the file.
+	Copyright []string
+}
+
+// ContentType returns the MIME type of the license text.
+func (l *LicenseFile) ContentType() string {
+	if strings.HasSuffix(strings.ToLower(l.Path), ".md") {
+		return "text/markdown"
+	}
+	return "text/plain"
+}
+
+// FindLicenseFile returns the path of the license file at the root of the.
+// repo described by resp (LICENSE, LICENSE.md, LICENCE.txt, COPYING, ...),.
+// or "" when the repo has none.
+func FindLicenseFile(resp *ModelAPIResponse) string {
+	if resp == nil {
+		return ""
+	}
+	for _, want := range licenseFileNames {
+		for _, s := range resp.Siblings {
+			name := s.RFilename
+			if strings.Contains(name, "/") {
+				continue
+			}
+			stem := strings.ToLower(name)
+			if i := strings.IndexByte(stem, '.'); i >= 0 {
+				switch stem[i:] {
+				case ".md", ".txt":
+					stem = stem[:i]
+				default:
+					continue
+				}
+			}
+			if stem == want {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// ModelLicenseFetcher downloads the license file of a model repo.
+// .
+// It uses URLs like:.
+// .
+//
+//	GET https://huggingface.co/{modelID}/resolve/main/{path}.
+type ModelLicenseFetcher struct {
+	Client  *http.Client
+	BaseURL string // optional; defaults to "https://huggingface.co"
+}
+
+func (f *ModelLicenseFetcher) Fetch(modelID, path string) (*LicenseFile, error) {
+	client := f.Client
+	if client == nil {
+		client = defaultClient
+	}
+
+	trimmedModelID := strings.TrimPrefix(strings.TrimSpace(modelID), "/")
+	if trimmedModelID == "" || path == "" {
+		return nil, fmt.Errorf("empty model id or license path")
+	}
+
+	baseURL := strings.TrimRight(strings.TrimSpace(f.BaseURL), "/")
+	if baseURL == "" {
+		baseURL = "https://huggingface.co"
+	}
+
+	url := fmt.Sprintf("%s/%s/resolve/main/%s", baseURL, trimmedModelID, path)
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/plain, text/markdown, */*")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &HFError{StatusCode: resp.StatusCode}
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxLicenseFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxLicenseFileSize {
+		return nil, fmt.Errorf("license file %s exceeds %d bytes", path, maxLicenseFileSize)
+	}
+	return NewLicenseFile(path, data), nil
+}
+
+// NewLicenseFile builds a LicenseFile from the content of the file at path.
+func NewLicenseFile(path string, data []byte) *LicenseFile {
+	sum := sha256.Sum256(data)
+	lf := &LicenseFile{
+		Path:   path,
+		SHA256: hex.EncodeToString(sum[:]),
+		Size:   int64(len(data)),
+	}
+	lf.Copyright = ParseCopyright(string(data))
+	if len(data) <= MaxLicenseTextSize {
+		lf.Text = string(data)
+	}
+	return lf
+}
@@ -0,0 +1,72 @@
+package fetcher
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFindLicenseFile(t *testing.T) {
+	tests := []struct {
+		files []string
+		want  string
+	}{
+		{[]string{"README.md", "LICENSE"}, "LICENSE"},
+		{[]string{"COPYING", "License.md"}, "License.md"},
+		{[]string{"LICENCE.txt"}, "LICENCE.txt"},
+		{[]string{"docs/LICENSE", "LICENSE.py", "license_notes.md"}, ""},
+		{nil, ""},
+	}
+	for _, tt := range tests {
+		resp := &ModelAPIResponse{}
+		for _, f := range tt.files {
+			resp.Siblings = append(resp.Siblings, ModelSibling{RFilename: f})
+		}
+		if got := FindLicenseFile(resp); got != tt.want {
+			t.Errorf("FindLicenseFile(%v) = %q, want %q", tt.files, got, tt.want)
+		}
+	}
+	if got := FindLicenseFile(nil); got != "" {
+		t.Errorf("FindLicenseFile(nil) = %q", got)
+	}
+}
+
+func TestModelLicenseFetcher_Fetch(t *testing.T) {
+	large := strings.Repeat("x", MaxLicenseTextSize+1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/org/model/resolve/main/LICENSE.md":
+			_, _ = w.Write([]byte("MIT License\n"))
+		case "/org/large/resolve/main/LICENSE":
+			_, _ = w.Write([]byte(large))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	f := &ModelLicenseFetcher{Client: srv.Client(), BaseURL: srv.URL}
+	lf, err := f.Fetch("org/model", "LICENSE.md")
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if lf.Text != "MIT License\n" || lf.Size != 12 || lf.ContentType() != "text/markdown" {
+		t.Fatalf("unexpected license file %+v", lf)
+	}
+	if lf.SHA256 != "267f7a2e19dfa9df99af774520985a0e521925293ea5b7e767ab06969d06bf91" {
+		t.Fatalf("unexpected digest %q", lf.SHA256)
+	}
+
+	lf, err = f.Fetch("org/large", "LICENSE")
+	if err != nil {
+		t.Fatalf("Fetch large: %v", err)
+	}
+	if lf.Text != "" || lf.Size != int64(len(large)) || lf.SHA256 == "" {
+		t.Fatalf("large license should only keep size and digest, got size=%d text=%d", lf.Size, len(lf.Text))
+	}
+
+	if _, err := f.Fetch("org/none", "LICENSE"); !IsNotFound(err) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
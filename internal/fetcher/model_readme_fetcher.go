@@ -52,6 +52,37 @@ type ModelReadmeCard struct {
 	// Quantitative Analysis sections (from Markdown body).
 	TestingMetrics string
 	Results        string
+
+	// Attribution (see ParseCopyright and parseAttribution).
+	Copyright   []string
+	Attribution string
+
+	// Fairness evaluation (see parseFairness).
+	FairnessMetrics  []FairnessMetric
+	FairnessCriteria []string
+
+	// Model size the body states (see parseModelSize); 0 when it states none.
+	ParameterCount uint64
+	ContextLength  uint64
+
+	// EU AI Act documentation (see parseAIAct).
+	IntendedUsers      []string
+	DeploymentContext  string
+	HumanOversight     string
+	RiskClassification string
+
+	// Contacts and escalation paths (see parseContacts).
+	Contacts []Contact
+}
+
+// RawFrontMatter returns the YAML front matter of the model card as written,.
+// or "" when the card has none.
+func (c *ModelReadmeCard) RawFrontMatter() string {
+	if c == nil {
+		return ""
+	}
+	y, _, _ := cutFrontMatter(strings.TrimSpace(c.Raw))
+	return y
 }
 
 type ModelIndexMetric struct {
@@ -77,7 +108,7 @@ type ModelReadmeFetcher struct {
 func (f *ModelReadmeFetcher) Fetch(modelID string) (*ModelReadmeCard, error) {
 	client := f.Client
 	if client == nil {
-		client = http.DefaultClient
+		client = defaultClient
 	}
 
 	trimmedModelID := strings.TrimPrefix(strings.TrimSpace(modelID), "/")
@@ -175,6 +206,13 @@ func parseReadmeCard(raw string) *ModelReadmeCard {
 	card.EnvironmentalComputeRegion = strings.TrimSpace(extractBulletValue(body, "Compute Region"))
 	card.EnvironmentalCarbonEmitted = strings.TrimSpace(extractBulletValue(body, "Carbon Emitted"))
 
+	card.Copyright = ParseCopyright(body)
+	card.Attribution = parseAttribution(fm, body)
+	parseFairness(card)
+	parseModelSize(card)
+	parseAIAct(card)
+	parseContacts(card)
+
 	// Note: We keep placeholders in the card structure. (for templates/model-card-example).
 	// The fieldspecs layer can decide whether to use them or filter them out.
 
@@ -32,7 +32,7 @@ type ModelSearcher struct {
 func (s *ModelSearcher) Search(query string, limit int) ([]ModelSearchResult, error) {
 	client := s.Client
 	if client == nil {
-		client = http.DefaultClient
+		client = defaultClient
 	}
 
 	baseURL := strings.TrimRight(strings.TrimSpace(s.BaseURL), "/")
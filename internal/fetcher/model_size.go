@@ -0,0 +1,72 @@
+package fetcher
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// parameterCountRe matches a parameter count stated in a model card:.
+	// "7B parameters", "1.5 billion params", "Parameters: 110M".
+	parameterCountRe = regexp.MustCompile(`(?i)(?:\b(\d+(?:[.,]\d+)?)\s*(k|m|b|t|thousand|million|billion|trillion)?[\s-]+param(?:eter)?s?\b|\b(?:number of parameters|parameters|params|model size)\s*\**\s*[:|]\s*\**\s*(\d+(?:[.,]\d+)?)\s*(k|m|b|t|thousand|million|billion|trillion)?\b)`)
+
+	// contextLengthRe matches a context length stated in a model card:.
+	// "context length of 4096 tokens", "Context window: 8k", "32K-token context".
+	contextLengthRe = regexp.MustCompile(`(?i)(?:\b(?:context|sequence)[\s-]+(?:length|window|size)\s*\**\s*(?:[:|]|of|is)?\s*\**\s*(?:up to\s+)?(\d[\d,]*|\d+(?:\.\d+)?\s*k)\b|\b(\d[\d,]*|\d+(?:\.\d+)?\s*k)[\s-]+tokens?[\s-]+context\b)`)
+)
+
+// sizeMultipliers maps the magnitude suffixes of parameter counts and.
+// context lengths to their value.
+var sizeMultipliers = map[string]float64{
+	"": 1, "k": 1e3, "thousand": 1e3, "m": 1e6, "million": 1e6,
+	"b": 1e9, "billion": 1e9, "t": 1e12, "trillion": 1e12,
+}
+
+// ParseModelSize parses a parameter count or context length written with an.
+// optional magnitude suffix ("110M", "7 billion", "8k", "4,096"). It reports.
+// false for values that are not a positive count.
+func ParseModelSize(s string) (uint64, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	i := strings.IndexFunc(s, func(r rune) bool { return (r < '0' || r > '9') && r != '.' && r != ',' })
+	num, suffix := s, ""
+	if i >= 0 {
+		num, suffix = s[:i], strings.TrimSpace(s[i:])
+	}
+	mult, ok := sizeMultipliers[suffix]
+	if !ok || num == "" {
+		return 0, false
+	}
+	if suffix == "" {
+		// Thousands separators: 4,096 and 1,000,000.
+		num = strings.ReplaceAll(num, ",", "")
+	} else {
+		// Decimal comma: 1,5B.
+		num = strings.ReplaceAll(num, ",", ".")
+	}
+	f, err := strconv.ParseFloat(num, 64)
+	if err != nil || f <= 0 {
+		return 0, false
+	}
+	return uint64(math.Round(f * mult)), true
+}
+
+// parseModelSize fills the parameter count and context length of card from.
+// the first statement of each in its body.
+func parseModelSize(card *ModelReadmeCard) {
+	if m := parameterCountRe.FindStringSubmatch(card.Body); m != nil {
+		num, suffix := m[1], m[2]
+		if num == "" {
+			num, suffix = m[3], m[4]
+		}
+		card.ParameterCount, _ = ParseModelSize(num + suffix)
+	}
+	if m := contextLengthRe.FindStringSubmatch(card.Body); m != nil {
+		v := m[1]
+		if v == "" {
+			v = m[2]
+		}
+		card.ContextLength, _ = ParseModelSize(strings.ReplaceAll(v, " ", ""))
+	}
+}
@@ -0,0 +1,40 @@
+package fetcher
+
+import "testing"
+
+func TestParseModelSize(t *testing.T) {
+	for in, want := range map[string]uint64{
+		"110M":        110000000,
+		"7B":          7000000000,
+		"1.5 billion": 1500000000,
+		"1,5B":        1500000000,
+		"8k":          8000,
+		"4,096":       4096,
+		"131072":      131072,
+		"":            0,
+		"many":        0,
+		"7 bananas":   0,
+	} {
+		got, ok := ParseModelSize(in)
+		if got != want || ok != (want > 0) {
+			t.Errorf("ParseModelSize(%q) = %d, %v; want %d", in, got, ok, want)
+		}
+	}
+}
+
+func TestParseReadmeModelSize(t *testing.T) {
+	card := parseReadmeCard("# Model\n\nA 7B parameter language model with a context length of 4,096 tokens.\n")
+	if card.ParameterCount != 7000000000 || card.ContextLength != 4096 {
+		t.Errorf("sentence: parameters = %d, context = %d", card.ParameterCount, card.ContextLength)
+	}
+
+	card = parseReadmeCard("# Model\n\n| Parameters | 110M |\n|---|---|\n\nSupports a 32K-token context window.\n")
+	if card.ParameterCount != 110000000 || card.ContextLength != 32000 {
+		t.Errorf("table: parameters = %d, context = %d", card.ParameterCount, card.ContextLength)
+	}
+
+	card = parseReadmeCard("# Model\n\nFine-tuned with the default parameters.\n")
+	if card.ParameterCount != 0 || card.ContextLength != 0 {
+		t.Errorf("plain card: parameters = %d, context = %d", card.ParameterCount, card.ContextLength)
+	}
+}
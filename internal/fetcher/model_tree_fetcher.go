@@ -82,7 +82,7 @@ func (f *ModelTreeFetcher) Fetch(modelID string) ([]SecurityFileEntry, error) {
 	}
 	client := f.Client
 	if client == nil {
-		client = http.DefaultClient
+		client = defaultClient
 	}
 
 	var all []SecurityFileEntry
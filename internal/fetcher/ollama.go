@@ -0,0 +1,185 @@
+package fetcher
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// OllamaError is returned when the Ollama registry responds with a non-2xx.
+// HTTP status. IsNotFound and IsUnauthorized recognise it like HFError.
+type OllamaError struct {
+	StatusCode int
+}
+
+func (e *OllamaError) Error() string {
+	return fmt.Sprintf("ollama registry status %d", e.StatusCode)
+}
+
+// NewOllamaClient creates an *http.Client for the Ollama registry that.
+// retries like NewHFClientWithRetry. The registry is public: the client sends.
+// no credentials, in particular not the Hugging Face token. GET requests go.
+// through the HTTP cache set with SetHTTPCache.
+func NewOllamaClient(timeout time.Duration, policy RetryPolicy) *http.Client {
+	return newAPIClient(sharedTransport, timeout, policy, "ollama:")
+}
+
+// Media types of an Ollama model manifest and of its layers.
+const (
+	ollamaManifestMediaType = "application/vnd.docker.distribution.manifest.v2+json"
+	OllamaLayerModel        = "application/vnd.ollama.image.model"
+	OllamaLayerLicense      = "application/vnd.ollama.image.license"
+)
+
+// maxOllamaLicense bounds the license text read from the registry.
+const maxOllamaLicense = 1 << 20
+
+// OllamaLayer is a layer (or the config) of an Ollama model manifest.
+type OllamaLayer struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+	Size      int64  `json:"size"`
+}
+
+// OllamaManifest is the decoded response from GET.
+// https://registry.ollama.ai/v2/:namespace/:model/manifests/:tag.
+type OllamaManifest struct {
+	SchemaVersion int           `json:"schemaVersion"`
+	MediaType     string        `json:"mediaType"`
+	Config        OllamaLayer   `json:"config"`
+	Layers        []OllamaLayer `json:"layers"`
+}
+
+// OllamaConfig is the config blob of an Ollama model.
+type OllamaConfig struct {
+	ModelFormat   string   `json:"model_format"`
+	ModelFamily   string   `json:"model_family"`
+	ModelFamilies []string `json:"model_families"`
+	// ModelType is the parameter size ("8.0B").
+	ModelType string `json:"model_type"`
+	// FileType is the quantization of the weights ("Q4_0").
+	FileType string `json:"file_type"`
+}
+
+// OllamaModel is a tag of a model of the Ollama registry: its manifest, the.
+// digest of the manifest, the config and the license text.
+type OllamaModel struct {
+	Namespace string
+	Model     string
+	Tag       string
+	Digest    string
+	Manifest  OllamaManifest
+	Config    OllamaConfig
+	License   string
+}
+
+// Layer returns the first layer of mediaType.
+func (m *OllamaModel) Layer(mediaType string) (OllamaLayer, bool) {
+	for _, l := range m.Manifest.Layers {
+		if l.MediaType == mediaType {
+			return l, true
+		}
+	}
+	return OllamaLayer{}, false
+}
+
+// Size returns the size of the model in bytes, the sum of its layers.
+func (m *OllamaModel) Size() int64 {
+	var n int64
+	for _, l := range m.Manifest.Layers {
+		n += l.Size
+	}
+	return n
+}
+
+// OllamaFetcher fetches model manifests from the Ollama registry.
+type OllamaFetcher struct {
+	Client  *http.Client
+	BaseURL string // optional; defaults to "https://registry.ollama.ai"
+}
+
+// Fetch fetches the manifest, config and license of namespace/model:tag. A.
+// license that cannot be fetched is left empty.
+func (f *OllamaFetcher) Fetch(namespace, model, tag string) (*OllamaModel, error) {
+	namespace, model, tag = strings.TrimSpace(namespace), strings.TrimSpace(model), strings.TrimSpace(tag)
+	if namespace == "" || model == "" || tag == "" {
+		return nil, fmt.Errorf("invalid Ollama model %s/%s:%s", namespace, model, tag)
+	}
+	repo := namespace + "/" + model
+
+	body, header, err := f.get(repo+"/manifests/"+tag, ollamaManifestMediaType, -1)
+	if err != nil {
+		return nil, err
+	}
+	m := &OllamaModel{Namespace: namespace, Model: model, Tag: tag}
+	if err := json.Unmarshal(body, &m.Manifest); err != nil {
+		return nil, fmt.Errorf("decode Ollama manifest: %w", err)
+	}
+	m.Digest = strings.TrimSpace(header.Get("Docker-Content-Digest"))
+	if m.Digest == "" {
+		sum := sha256.Sum256(body)
+		m.Digest = "sha256:" + hex.EncodeToString(sum[:])
+	}
+
+	if d := m.Manifest.Config.Digest; d != "" {
+		body, _, err := f.get(repo+"/blobs/"+d, "", -1)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(body, &m.Config); err != nil {
+			return nil, fmt.Errorf("decode Ollama config: %w", err)
+		}
+	}
+	if l, ok := m.Layer(OllamaLayerLicense); ok {
+		if body, _, err := f.get(repo+"/blobs/"+l.Digest, "", maxOllamaLicense); err == nil {
+			m.License = strings.TrimSpace(string(body))
+		}
+	}
+	return m, nil
+}
+
+// get reads the registry path below /v2/, at most limit bytes when limit is.
+// not negative.
+func (f *OllamaFetcher) get(path, accept string, limit int64) ([]byte, http.Header, error) {
+	client := f.Client
+	if client == nil {
+		client = defaultClient
+	}
+	baseURL := strings.TrimRight(strings.TrimSpace(f.BaseURL), "/")
+	if baseURL == "" {
+		baseURL = "https://registry.ollama.ai"
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, baseURL+"/v2/"+path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, &OllamaError{StatusCode: resp.StatusCode}
+	}
+	var r io.Reader = resp.Body
+	if limit >= 0 {
+		r = io.LimitReader(resp.Body, limit)
+	}
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	return body, resp.Header, nil
+}
@@ -0,0 +1,51 @@
+package fetcher
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestOllamaFetcher(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" {
+			t.Errorf("unexpected credentials sent to the registry")
+		}
+		switch r.URL.Path {
+		case "/v2/library/llama3/manifests/8b":
+			if r.Header.Get("Accept") != ollamaManifestMediaType {
+				t.Errorf("Accept = %q", r.Header.Get("Accept"))
+			}
+			w.Header().Set("Docker-Content-Digest", "sha256:manifest")
+			w.Write([]byte(`{"schemaVersion":2,"config":{"digest":"sha256:cfg","size":10},"layers":[
+				{"mediaType":"application/vnd.ollama.image.model","digest":"sha256:weights","size":4000},
+				{"mediaType":"application/vnd.ollama.image.license","digest":"sha256:lic","size":20}]}`))
+		case "/v2/library/llama3/blobs/sha256:cfg":
+			w.Write([]byte(`{"model_format":"gguf","model_family":"llama","model_type":"8.0B","file_type":"Q4_0"}`))
+		case "/v2/library/llama3/blobs/sha256:lic":
+			w.Write([]byte("META LLAMA 3 COMMUNITY LICENSE AGREEMENT\n..."))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	f := &OllamaFetcher{Client: NewOllamaClient(0, RetryPolicy{}), BaseURL: srv.URL}
+	m, err := f.Fetch("library", "llama3", "8b")
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if m.Digest != "sha256:manifest" || m.Config.FileType != "Q4_0" || m.Config.ModelType != "8.0B" || m.Size() != 4020 {
+		t.Errorf("model = %+v", m)
+	}
+	if l, ok := m.Layer(OllamaLayerModel); !ok || l.Digest != "sha256:weights" {
+		t.Errorf("model layer = %+v, %v", l, ok)
+	}
+	if m.License != "META LLAMA 3 COMMUNITY LICENSE AGREEMENT\n..." {
+		t.Errorf("license = %q", m.License)
+	}
+
+	if _, err := f.Fetch("library", "missing", "latest"); !IsNotFound(err) {
+		t.Errorf("missing model: err = %v, want not found", err)
+	}
+}
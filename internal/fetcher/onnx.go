@@ -0,0 +1,434 @@
+package fetcher
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ONNX reader limits. Strings are clipped and values past the limits are.
+// skipped, so a corrupt or hostile file cannot make the reader allocate much.
+const (
+	maxONNXString = 64 << 10
+	maxONNXValues = 1024
+	maxONNXDims   = 64
+	// maxONNXInitializers bounds the initializer names kept to tell inputs.
+	// from weights; their element counts are summed regardless.
+	maxONNXInitializers = 1 << 16
+)
+
+// onnxElemTypes names the TensorProto.DataType values.
+var onnxElemTypes = map[uint64]string{
+	1: "float32", 2: "uint8", 3: "int8", 4: "uint16", 5: "int16", 6: "int32", 7: "int64",
+	8: "string", 9: "bool", 10: "float16", 11: "float64", 12: "uint32", 13: "uint64",
+	14: "complex64", 15: "complex128", 16: "bfloat16", 17: "float8e4m3fn", 18: "float8e4m3fnuz",
+	19: "float8e5m2", 20: "float8e5m2fnuz", 21: "uint4", 22: "int4",
+}
+
+// ONNXModel is the metadata of an ONNX model file, read from its ModelProto.
+// without keeping any weights.
+type ONNXModel struct {
+	IRVersion       int64
+	ProducerName    string // exporter (pytorch, tf2onnx, skl2onnx, ...)
+	ProducerVersion string
+	Domain          string
+	ModelVersion    int64
+	DocString       string // model doc_string, else the graph's
+	Opsets          []ONNXOpset
+	GraphName       string
+	Inputs          []ONNXValueInfo // graph inputs that are not initializers
+	Outputs         []ONNXValueInfo
+	// Metadata are the metadata_props of the model.
+	Metadata  map[string]string
+	NodeCount int
+	// ParameterCount is the number of elements in all initializers.
+	ParameterCount uint64
+}
+
+// ONNXOpset is an operator set the model imports. Domain is "ai.onnx" for.
+// the default domain.
+type ONNXOpset struct {
+	Domain  string
+	Version int64
+}
+
+// ONNXValueInfo is a graph input or output. Shape holds one entry per.
+// dimension: its size, its symbolic name or "?" when unknown.
+type ONNXValueInfo struct {
+	Name     string
+	ElemType string
+	Shape    []string
+}
+
+// String formats v as "name: float32[batch, 3, 224, 224]".
+func (v ONNXValueInfo) String() string {
+	s := v.ElemType
+	if s == "" {
+		s = "tensor"
+	}
+	if v.Shape != nil {
+		s += "[" + strings.Join(v.Shape, ", ") + "]"
+	}
+	if v.Name == "" {
+		return s
+	}
+	return v.Name + ": " + s
+}
+
+// Producer returns the producer name and version ("pytorch 2.1.0").
+func (m *ONNXModel) Producer() string {
+	return strings.TrimSpace(m.ProducerName + " " + m.ProducerVersion)
+}
+
+// OpsetString returns the imported operator sets ("ai.onnx 17, com.microsoft 1").
+func (m *ONNXModel) OpsetString() string {
+	parts := make([]string, 0, len(m.Opsets))
+	for _, o := range m.Opsets {
+		parts = append(parts, o.Domain+" "+strconv.FormatInt(o.Version, 10))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ReadONNX reads the model metadata of the ONNX file at path.
+func ReadONNX(path string) (*ONNXModel, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseONNX(f)
+}
+
+// ParseONNX reads an ONNX ModelProto from r. Initializer data is skipped, not.
+// loaded.
+func ParseONNX(r io.Reader) (*ONNXModel, error) {
+	p := &pbReader{r: bufio.NewReaderSize(r, 1<<16)}
+	m := &ONNXModel{}
+	hasGraph := false
+	var graphDoc string
+	p.fields(-1, func(f pbField) {
+		switch {
+		case f.num == 1 && f.wire == pbVarint:
+			m.IRVersion = int64(f.val)
+		case f.num == 2 && f.wire == pbBytes:
+			m.ProducerName = p.str(f.end)
+		case f.num == 3 && f.wire == pbBytes:
+			m.ProducerVersion = p.str(f.end)
+		case f.num == 4 && f.wire == pbBytes:
+			m.Domain = p.str(f.end)
+		case f.num == 5 && f.wire == pbVarint:
+			m.ModelVersion = int64(f.val)
+		case f.num == 6 && f.wire == pbBytes:
+			m.DocString = p.str(f.end)
+		case f.num == 7 && f.wire == pbBytes:
+			hasGraph = true
+			graphDoc = p.graph(f.end, m)
+		case f.num == 8 && f.wire == pbBytes:
+			if len(m.Opsets) < maxONNXValues {
+				m.Opsets = append(m.Opsets, p.opset(f.end))
+			}
+		case f.num == 14 && f.wire == pbBytes:
+			k, v := p.stringEntry(f.end)
+			if k != "" && len(m.Metadata) < maxONNXValues {
+				if m.Metadata == nil {
+					m.Metadata = map[string]string{}
+				}
+				m.Metadata[k] = v
+			}
+		}
+	})
+	if p.err != nil {
+		return nil, fmt.Errorf("onnx: %w", p.err)
+	}
+	if m.IRVersion <= 0 || !hasGraph {
+		return nil, errors.New("onnx: not an ONNX model")
+	}
+	if m.DocString == "" {
+		m.DocString = graphDoc
+	}
+	sort.SliceStable(m.Opsets, func(i, j int) bool { return m.Opsets[i].Domain < m.Opsets[j].Domain })
+	return m, nil
+}
+
+// graph reads a GraphProto into m and returns its doc_string.
+func (p *pbReader) graph(end int64, m *ONNXModel) string {
+	var doc string
+	var inputs []ONNXValueInfo
+	initializers := map[string]bool{}
+	p.fields(end, func(f pbField) {
+		if f.wire != pbBytes {
+			return
+		}
+		switch f.num {
+		case 1:
+			m.NodeCount++
+		case 2:
+			m.GraphName = p.str(f.end)
+		case 5:
+			name, n := p.initializer(f.end)
+			m.ParameterCount += n
+			if name != "" && len(initializers) < maxONNXInitializers {
+				initializers[name] = true
+			}
+		case 10:
+			doc = p.str(f.end)
+		case 11:
+			if len(inputs) < maxONNXValues {
+				inputs = append(inputs, p.valueInfo(f.end))
+			}
+		case 12:
+			if len(m.Outputs) < maxONNXValues {
+				m.Outputs = append(m.Outputs, p.valueInfo(f.end))
+			}
+		}
+	})
+	// Models below IR version 4 list their initializers as graph inputs too.
+	for _, in := range inputs {
+		if !initializers[in.Name] {
+			m.Inputs = append(m.Inputs, in)
+		}
+	}
+	return doc
+}
+
+// initializer reads a TensorProto and returns its name and element count.
+func (p *pbReader) initializer(end int64) (string, uint64) {
+	var name string
+	n, dims := uint64(1), 0
+	dim := func(v uint64) {
+		dims++
+		n *= v
+	}
+	p.fields(end, func(f pbField) {
+		switch {
+		case f.num == 1 && f.wire == pbVarint:
+			dim(f.val)
+		case f.num == 1 && f.wire == pbBytes:
+			for p.off < f.end && p.err == nil {
+				dim(p.varint())
+			}
+		case f.num == 8 && f.wire == pbBytes:
+			name = p.str(f.end)
+		}
+	})
+	if dims > maxONNXDims {
+		return name, 0
+	}
+	return name, n
+}
+
+// valueInfo reads a ValueInfoProto of a tensor.
+func (p *pbReader) valueInfo(end int64) ONNXValueInfo {
+	var v ONNXValueInfo
+	p.fields(end, func(f pbField) {
+		switch {
+		case f.num == 1 && f.wire == pbBytes:
+			v.Name = p.str(f.end)
+		case f.num == 2 && f.wire == pbBytes:
+			// TypeProto.tensor_type.
+			p.fields(f.end, func(t pbField) {
+				if t.num == 1 && t.wire == pbBytes {
+					p.tensorType(t.end, &v)
+				}
+			})
+		}
+	})
+	return v
+}
+
+// tensorType reads a TypeProto.Tensor into v.
+func (p *pbReader) tensorType(end int64, v *ONNXValueInfo) {
+	p.fields(end, func(f pbField) {
+		switch {
+		case f.num == 1 && f.wire == pbVarint:
+			v.ElemType = onnxElemTypes[f.val]
+			if v.ElemType == "" {
+				v.ElemType = "type " + strconv.FormatUint(f.val, 10)
+			}
+		case f.num == 2 && f.wire == pbBytes:
+			v.Shape = []string{}
+			p.fields(f.end, func(d pbField) {
+				if d.num == 1 && d.wire == pbBytes && len(v.Shape) < maxONNXDims {
+					v.Shape = append(v.Shape, p.dimension(d.end))
+				}
+			})
+		}
+	})
+}
+
+// dimension reads a TensorShapeProto.Dimension.
+func (p *pbReader) dimension(end int64) string {
+	s := "?"
+	p.fields(end, func(f pbField) {
+		switch {
+		case f.num == 1 && f.wire == pbVarint:
+			s = strconv.FormatInt(int64(f.val), 10)
+		case f.num == 2 && f.wire == pbBytes:
+			if name := p.str(f.end); name != "" {
+				s = name
+			}
+		}
+	})
+	return s
+}
+
+// opset reads an OperatorSetIdProto.
+func (p *pbReader) opset(end int64) ONNXOpset {
+	o := ONNXOpset{}
+	p.fields(end, func(f pbField) {
+		switch {
+		case f.num == 1 && f.wire == pbBytes:
+			o.Domain = p.str(f.end)
+		case f.num == 2 && f.wire == pbVarint:
+			o.Version = int64(f.val)
+		}
+	})
+	if o.Domain == "" {
+		o.Domain = "ai.onnx"
+	}
+	return o
+}
+
+// stringEntry reads a StringStringEntryProto.
+func (p *pbReader) stringEntry(end int64) (key, value string) {
+	p.fields(end, func(f pbField) {
+		switch {
+		case f.num == 1 && f.wire == pbBytes:
+			key = p.str(f.end)
+		case f.num == 2 && f.wire == pbBytes:
+			value = p.str(f.end)
+		}
+	})
+	return key, value
+}
+
+// Protobuf wire types.
+const (
+	pbVarint  = 0
+	pbFixed64 = 1
+	pbBytes   = 2
+	pbFixed32 = 5
+)
+
+// pbField is a protobuf field. val is the value of numeric fields and the.
+// length of length-delimited ones, whose value ends at offset end.
+type pbField struct {
+	num  uint64
+	wire int
+	val  uint64
+	end  int64
+}
+
+// pbReader reads protobuf wire format in a single pass. The first error.
+// sticks and turns every later read into a no-op.
+type pbReader struct {
+	r   *bufio.Reader
+	off int64
+	err error
+}
+
+func (p *pbReader) readByte() byte {
+	if p.err != nil {
+		return 0
+	}
+	b, err := p.r.ReadByte()
+	if err != nil {
+		p.err = err
+		return 0
+	}
+	p.off++
+	return b
+}
+
+func (p *pbReader) varint() uint64 {
+	var v uint64
+	for shift := uint(0); shift < 64 && p.err == nil; shift += 7 {
+		b := p.readByte()
+		v |= uint64(b&0x7f) << shift
+		if b < 0x80 {
+			return v
+		}
+	}
+	if p.err == nil {
+		p.err = errors.New("invalid varint")
+	}
+	return 0
+}
+
+func (p *pbReader) discard(n int64) {
+	for n > 0 && p.err == nil {
+		step := min(n, 1<<30)
+		d, err := p.r.Discard(int(step))
+		p.off += int64(d)
+		n -= int64(d)
+		if err != nil {
+			p.err = err
+		}
+	}
+}
+
+// str reads the string value that ends at end, clipped to maxONNXString.
+func (p *pbReader) str(end int64) string {
+	n := end - p.off
+	if p.err != nil || n <= 0 {
+		return ""
+	}
+	b := make([]byte, min(n, maxONNXString))
+	if _, err := io.ReadFull(p.r, b); err != nil {
+		p.err = err
+		return ""
+	}
+	p.off += int64(len(b))
+	return strings.TrimSpace(string(b))
+}
+
+// fields reads the fields of the message that ends at end (-1: at the end of.
+// the input) and calls fn for each. fn may read the value of a.
+// length-delimited field; what it leaves unread is skipped.
+func (p *pbReader) fields(end int64, fn func(pbField)) {
+	for p.err == nil {
+		if end >= 0 && p.off >= end {
+			return
+		}
+		if end < 0 {
+			if _, err := p.r.Peek(1); err == io.EOF {
+				return
+			}
+		}
+		tag := p.varint()
+		f := pbField{num: tag >> 3, wire: int(tag & 7)}
+		switch f.wire {
+		case pbVarint:
+			f.val = p.varint()
+		case pbFixed64:
+			p.discard(8)
+		case pbFixed32:
+			p.discard(4)
+		case pbBytes:
+			f.val = p.varint()
+			f.end = p.off + int64(f.val)
+			if p.err == nil && (f.val > 1<<62 || (end >= 0 && f.end > end)) {
+				p.err = errors.New("field exceeds its message")
+			}
+		default:
+			if p.err == nil {
+				p.err = fmt.Errorf("unsupported wire type %d", f.wire)
+			}
+		}
+		if p.err != nil {
+			return
+		}
+		fn(f)
+		if f.wire == pbBytes && p.off < f.end {
+			p.discard(f.end - p.off)
+		}
+		if p.err == nil && end >= 0 && p.off > end {
+			p.err = errors.New("field exceeds its message")
+		}
+	}
+}
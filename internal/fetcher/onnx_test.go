@@ -0,0 +1,104 @@
+package fetcher
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// pb builds protobuf messages for ONNX test files.
+type pb struct{ bytes.Buffer }
+
+func (b *pb) varint(field int, v uint64) *pb {
+	b.Write(binary.AppendUvarint(nil, uint64(field)<<3))
+	b.Write(binary.AppendUvarint(nil, v))
+	return b
+}
+
+func (b *pb) bytes(field int, v []byte) *pb {
+	b.Write(binary.AppendUvarint(nil, uint64(field)<<3|2))
+	b.Write(binary.AppendUvarint(nil, uint64(len(v))))
+	b.Write(v)
+	return b
+}
+
+func (b *pb) str(field int, s string) *pb { return b.bytes(field, []byte(s)) }
+func (b *pb) msg(field int, m *pb) *pb    { return b.bytes(field, m.Bytes()) }
+
+// onnxTensor builds a ValueInfoProto; dims are sizes (uint64) or names (string).
+func onnxTensor(name string, elemType uint64, dims ...any) *pb {
+	shape := &pb{}
+	for _, d := range dims {
+		switch d := d.(type) {
+		case uint64:
+			shape.msg(1, (&pb{}).varint(1, d))
+		case string:
+			shape.msg(1, (&pb{}).str(2, d))
+		}
+	}
+	tensor := (&pb{}).varint(1, elemType).msg(2, shape)
+	return (&pb{}).str(1, name).msg(2, (&pb{}).msg(1, tensor))
+}
+
+func TestReadONNX(t *testing.T) {
+	packedDims := append(binary.AppendUvarint(nil, 768), binary.AppendUvarint(nil, 30522)...)
+	graph := (&pb{}).
+		msg(1, (&pb{}).str(4, "MatMul")).
+		msg(1, (&pb{}).str(4, "Softmax")).
+		str(2, "BertForSequenceClassification").
+		msg(5, (&pb{}).bytes(1, packedDims).varint(2, 1).str(8, "embeddings.weight").bytes(9, make([]byte, 64))).
+		msg(5, (&pb{}).varint(1, 2).varint(1, 768).str(8, "classifier.weight")).
+		str(10, "graph doc").
+		msg(11, onnxTensor("input_ids", 7, "batch", "sequence")).
+		msg(11, onnxTensor("classifier.weight", 1, uint64(2), uint64(768))).
+		msg(12, onnxTensor("logits", 1, "batch", uint64(2)))
+	model := (&pb{}).
+		varint(1, 8).
+		str(2, "pytorch").
+		str(3, "2.1.0").
+		msg(8, (&pb{}).str(1, "com.microsoft").varint(2, 1)).
+		msg(8, (&pb{}).varint(2, 17)).
+		msg(7, graph).
+		msg(14, (&pb{}).str(1, "model_type").str(2, "bert"))
+
+	path := filepath.Join(t.TempDir(), "model.onnx")
+	if err := os.WriteFile(path, model.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m, err := ReadONNX(path)
+	if err != nil {
+		t.Fatalf("ReadONNX: %v", err)
+	}
+	want := &ONNXModel{
+		IRVersion:       8,
+		ProducerName:    "pytorch",
+		ProducerVersion: "2.1.0",
+		DocString:       "graph doc",
+		Opsets:          []ONNXOpset{{Domain: "ai.onnx", Version: 17}, {Domain: "com.microsoft", Version: 1}},
+		GraphName:       "BertForSequenceClassification",
+		Inputs:          []ONNXValueInfo{{Name: "input_ids", ElemType: "int64", Shape: []string{"batch", "sequence"}}},
+		Outputs:         []ONNXValueInfo{{Name: "logits", ElemType: "float32", Shape: []string{"batch", "2"}}},
+		Metadata:        map[string]string{"model_type": "bert"},
+		NodeCount:       2,
+		ParameterCount:  768*30522 + 2*768,
+	}
+	if !reflect.DeepEqual(m, want) {
+		t.Errorf("model = %+v\nwant    %+v", m, want)
+	}
+	if got := m.Producer() + " / " + m.OpsetString(); got != "pytorch 2.1.0 / ai.onnx 17, com.microsoft 1" {
+		t.Errorf("producer / opsets = %q", got)
+	}
+	if got := m.Inputs[0].String(); got != "input_ids: int64[batch, sequence]" {
+		t.Errorf("input = %q", got)
+	}
+
+	if _, err := ParseONNX(bytes.NewReader(model.Bytes()[:len(model.Bytes())-5])); err == nil {
+		t.Errorf("truncated model: want error")
+	}
+	if _, err := ParseONNX(bytes.NewReader([]byte("GGUF\x03\x00\x00\x00"))); err == nil {
+		t.Errorf("GGUF file: want error")
+	}
+}
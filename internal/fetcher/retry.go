@@ -0,0 +1,285 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultMaxBackoff is the wait cap of a RetryPolicy without MaxBackoff.
+const DefaultMaxBackoff = 30 * time.Second
+
+// retryBaseBackoff is the wait before the first retry when the Hub does not.
+// say how long to wait; it doubles with every further retry.
+var retryBaseBackoff = time.Second
+
+// RetryPolicy configures how a client from NewHFClientWithRetry retries.
+// rate-limited (429) and transient (5xx, connection error) requests.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt (0: none).
+	MaxRetries int
+	// MaxBackoff caps the wait before a retry (0: DefaultMaxBackoff). When.
+	// the Hub asks for a longer wait (Retry-After, rate-limit reset), the.
+	// request is not retried and its 429 or 503 answer is returned.
+	MaxBackoff time.Duration
+	// OnRetry, when non-nil, is called before every retry. It may be called.
+	// from several goroutines at once.
+	OnRetry func(RetryAttempt)
+}
+
+// RetryAttempt describes a retry about to happen.
+type RetryAttempt struct {
+	URL        string
+	Attempt    int // 1 for the first retry
+	MaxRetries int
+	Wait       time.Duration
+	StatusCode int   // status of the failed attempt; 0 for a connection error
+	Err        error // connection error of the failed attempt, if any
+}
+
+// Reason returns the failure that caused the retry, e.g. "HTTP 429".
+func (a RetryAttempt) Reason() string {
+	if a.StatusCode != 0 {
+		return "HTTP " + strconv.Itoa(a.StatusCode)
+	}
+	if a.Err != nil {
+		return a.Err.Error()
+	}
+	return "request failed"
+}
+
+// NewHFClientWithRetry is NewHFClient with retries. timeout then applies to.
+// every attempt on its own instead of to the request as a whole.
+func NewHFClientWithRetry(timeout time.Duration, token string, policy RetryPolicy) *http.Client {
+	if policy.MaxRetries <= 0 {
+		return NewHFClient(timeout, token)
+	}
+	if policy.MaxBackoff <= 0 {
+		policy.MaxBackoff = DefaultMaxBackoff
+	}
+	token = strings.TrimSpace(token)
+	var transport http.RoundTripper = &retryTransport{
+		base:    &hfTransport{base: sharedTransport, token: token},
+		policy:  policy,
+		timeout: timeout,
+	}
+	if c := httpCache.Load(); c != nil {
+		transport = &cacheTransport{base: transport, cache: c, token: token}
+	}
+	return &http.Client{Transport: transport}
+}
+
+// retryTransport retries GET and HEAD requests that were rate limited or.
+// failed transiently. When a response reports that the rate limit is used up,.
+// later requests wait for its reset instead of running into a 429.
+type retryTransport struct {
+	base    http.RoundTripper
+	policy  RetryPolicy
+	timeout time.Duration
+}
+
+// rateLimitPause holds back the requests of every retrying client until the.
+// Hub's rate limit resets: the limit applies per token, not per client.
+var rateLimitPause struct {
+	mu    sync.Mutex
+	until time.Time
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return t.attempt(req)
+	}
+	for attempt := 1; ; attempt++ {
+		if err := waitForRateLimit(req.Context()); err != nil {
+			return nil, err
+		}
+		resp, err := t.attempt(req)
+		if resp != nil {
+			noteRateLimit(resp, t.policy.MaxBackoff)
+		}
+		if req.Context().Err() != nil || !retryable(resp, err) || attempt > t.policy.MaxRetries {
+			return resp, err
+		}
+
+		// Only rate-limit answers say when to come back; the RateLimit header.
+		// of other answers describes the window, not the failure.
+		wait := t.backoff(attempt)
+		if resp != nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) {
+			if d, ok := rateLimitWait(resp.Header, time.Now()); ok {
+				if d > t.policy.MaxBackoff {
+					return resp, nil
+				}
+				wait = d
+			}
+		}
+		a := RetryAttempt{URL: redactURL(req), Attempt: attempt, MaxRetries: t.policy.MaxRetries, Wait: wait, Err: err}
+		if resp != nil {
+			a.StatusCode = resp.StatusCode
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+			resp.Body.Close()
+		}
+		if t.policy.OnRetry != nil {
+			t.policy.OnRetry(a)
+		}
+		if err := sleepContext(req.Context(), wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// attempt sends req once, bounded by the per-attempt timeout.
+func (t *retryTransport) attempt(req *http.Request) (*http.Response, error) {
+	if t.timeout <= 0 {
+		return t.base.RoundTrip(req)
+	}
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	// The deadline also covers reading the body.
+	resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// noteRateLimit makes later requests wait for the rate-limit reset when resp.
+// reports that no requests are left in the current window.
+func noteRateLimit(resp *http.Response, maxWait time.Duration) {
+	if resp.StatusCode == http.StatusTooManyRequests || !rateLimitExhausted(resp.Header) {
+		return
+	}
+	d, ok := rateLimitWait(resp.Header, time.Now())
+	if !ok || d > maxWait {
+		return
+	}
+	rateLimitPause.mu.Lock()
+	if until := time.Now().Add(d); until.After(rateLimitPause.until) {
+		rateLimitPause.until = until
+	}
+	rateLimitPause.mu.Unlock()
+}
+
+func waitForRateLimit(ctx context.Context) error {
+	rateLimitPause.mu.Lock()
+	d := time.Until(rateLimitPause.until)
+	rateLimitPause.mu.Unlock()
+	if d <= 0 {
+		return nil
+	}
+	return sleepContext(ctx, d)
+}
+
+// backoff returns the exponential wait before retry attempt, with jitter so.
+// that concurrent workers do not retry in lockstep.
+func (t *retryTransport) backoff(attempt int) time.Duration {
+	d := retryBaseBackoff << (attempt - 1)
+	if d <= 0 || d > t.policy.MaxBackoff {
+		d = t.policy.MaxBackoff
+	}
+	return d/2 + rand.N(d/2+1)
+}
+
+// retryable reports whether a failed attempt is worth retrying: connection.
+// errors, timeouts, 429 and the 5xx answers of an overloaded or restarting.
+// server.
+func retryable(resp *http.Response, err error) bool {
+	if err != nil {
+		// Retrying cannot help while the Hub is unreachable.
+		return !errors.Is(err, ErrHubUnreachable)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// rateLimitWait returns the wait the Hub asks for in h: Retry-After (seconds.
+// or an HTTP date), else X-RateLimit-Reset (seconds, or a Unix time), else the.
+// t parameter of the RateLimit header (`"api";r=0;t=42`).
+func rateLimitWait(h http.Header, now time.Time) (time.Duration, bool) {
+	if v := strings.TrimSpace(h.Get("Retry-After")); v != "" {
+		if s, err := strconv.Atoi(v); err == nil && s >= 0 {
+			return time.Duration(s) * time.Second, true
+		}
+		if at, err := http.ParseTime(v); err == nil {
+			return max(at.Sub(now), 0), true
+		}
+	}
+	if v := strings.TrimSpace(h.Get("X-RateLimit-Reset")); v != "" {
+		if s, err := strconv.ParseInt(v, 10, 64); err == nil && s >= 0 {
+			// Values this large are a point in time, not a number of seconds.
+			if s > 1_000_000_000 {
+				return max(time.Unix(s, 0).Sub(now), 0), true
+			}
+			return time.Duration(s) * time.Second, true
+		}
+	}
+	if v, ok := rateLimitParam(h.Get("RateLimit"), "t"); ok {
+		return time.Duration(v) * time.Second, true
+	}
+	return 0, false
+}
+
+// rateLimitExhausted reports whether h says no requests are left in the.
+// current rate-limit window.
+func rateLimitExhausted(h http.Header) bool {
+	if v := strings.TrimSpace(h.Get("X-RateLimit-Remaining")); v != "" {
+		return v == "0"
+	}
+	r, ok := rateLimitParam(h.Get("RateLimit"), "r")
+	return ok && r == 0
+}
+
+// rateLimitParam returns the integer parameter key of a RateLimit header.
+// value such as `"api";r=0;t=42`.
+func rateLimitParam(value, key string) (int64, bool) {
+	for _, part := range strings.Split(value, ";") {
+		k, v, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok || k != key {
+			continue
+		}
+		n, err := strconv.ParseInt(v, 10, 64)
+		return n, err == nil && n >= 0
+	}
+	return 0, false
+}
+
+// redactURL returns the URL of req without its query, which may hold search.
+// terms or signed parameters.
+func redactURL(req *http.Request) string {
+	u := *req.URL
+	u.RawQuery, u.User = "", nil
+	return u.String()
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// cancelBody releases the context of an attempt once its body is closed.
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
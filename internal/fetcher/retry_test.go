@@ -0,0 +1,106 @@
+package fetcher
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryTransport(t *testing.T) {
+	old := retryBaseBackoff
+	retryBaseBackoff = time.Millisecond
+	t.Cleanup(func() { retryBaseBackoff = old })
+
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := requests.Add(1)
+		switch r.URL.Path {
+		case "/flaky":
+			if n < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			io.WriteString(w, "ok")
+		case "/slow-down":
+			w.Header().Set("Retry-After", "120")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+
+	var attempts []RetryAttempt
+	client := NewHFClientWithRetry(5*time.Second, "", RetryPolicy{
+		MaxRetries: 3,
+		MaxBackoff: time.Second,
+		OnRetry:    func(a RetryAttempt) { attempts = append(attempts, a) },
+	})
+	get := func(path string) (int, string) {
+		t.Helper()
+		requests.Store(0)
+		attempts = nil
+		resp, err := client.Get(srv.URL + path + "?q=secret")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(body)
+	}
+
+	if status, body := get("/flaky"); status != http.StatusOK || body != "ok" {
+		t.Fatalf("flaky = %d %q", status, body)
+	}
+	if len(attempts) != 2 || attempts[1].Attempt != 2 || attempts[0].StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("attempts = %+v", attempts)
+	}
+	if attempts[0].URL != srv.URL+"/flaky" || attempts[0].Wait > time.Second {
+		t.Fatalf("attempt = %+v", attempts[0])
+	}
+
+	// A wait beyond MaxBackoff is not sat out.
+	if status, _ := get("/slow-down"); status != http.StatusTooManyRequests || requests.Load() != 1 {
+		t.Fatalf("slow-down = %d after %d request(s)", status, requests.Load())
+	}
+
+	// Permanent errors are not retried; transient ones only MaxRetries times.
+	if status, _ := get("/missing"); status != http.StatusNotFound || requests.Load() != 1 {
+		t.Fatalf("missing = %d after %d request(s)", status, requests.Load())
+	}
+	if status, _ := get("/broken"); status != http.StatusInternalServerError || requests.Load() != 4 || len(attempts) != 3 {
+		t.Fatalf("broken = %d after %d request(s)", status, requests.Load())
+	}
+}
+
+func TestRateLimitWait(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		header http.Header
+		want   time.Duration
+		ok     bool
+	}{
+		{"retry-after seconds", http.Header{"Retry-After": {"7"}}, 7 * time.Second, true},
+		{"retry-after date", http.Header{"Retry-After": {now.Add(90 * time.Second).Format(http.TimeFormat)}}, 90 * time.Second, true},
+		{"reset seconds", http.Header{"X-Ratelimit-Reset": {"12"}}, 12 * time.Second, true},
+		{"reset unix time", http.Header{"X-Ratelimit-Reset": {"1748779260"}}, time.Minute, true},
+		{"ratelimit header", http.Header{"Ratelimit": {`"api";r=0;t=42`}}, 42 * time.Second, true},
+		{"none", http.Header{}, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := rateLimitWait(tt.header, now)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("%s: rateLimitWait = %v, %v; want %v, %v", tt.name, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	if !rateLimitExhausted(http.Header{"Ratelimit": {`"api";r=0;t=42`}}) || rateLimitExhausted(http.Header{"X-Ratelimit-Remaining": {"10"}}) {
+		t.Error("rateLimitExhausted misread the remaining requests")
+	}
+}
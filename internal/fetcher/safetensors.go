@@ -0,0 +1,130 @@
+package fetcher
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxSafetensorsHeader bounds the JSON header length of a safetensors file.
+// (the reference implementation caps it at 100 MB).
+const maxSafetensorsHeader = 100 << 20
+
+// SafetensorsHeader is the metadata of a safetensors weight file, read from.
+// its JSON header without loading any weights.
+type SafetensorsHeader struct {
+	TensorCount int
+	// DTypes are the tensor data types (BF16, F32, ...) with the number of.
+	// parameters stored in each, largest first.
+	DTypes []SafetensorsDType
+	// ParameterCount is the number of weights in all tensors.
+	ParameterCount uint64
+	// Metadata holds the free-form __metadata__ entries (format, ...).
+	Metadata map[string]string
+}
+
+// SafetensorsDType is the number of parameters stored in one data type.
+type SafetensorsDType struct {
+	DType          string
+	ParameterCount uint64
+}
+
+// DTypeString lists the data types of the header, largest first: "BF16, F32".
+func (h *SafetensorsHeader) DTypeString() string {
+	names := make([]string, len(h.DTypes))
+	for i, d := range h.DTypes {
+		names[i] = d.DType
+	}
+	return strings.Join(names, ", ")
+}
+
+// MetadataString lists the __metadata__ entries of the header as.
+// "key=value" pairs in key order, separated by semicolons.
+func (h *SafetensorsHeader) MetadataString() string {
+	keys := make([]string, 0, len(h.Metadata))
+	for k := range h.Metadata {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		if v := strings.TrimSpace(h.Metadata[k]); v != "" {
+			pairs = append(pairs, k+"="+v)
+		}
+	}
+	return strings.Join(pairs, "; ")
+}
+
+// ReadSafetensors reads the header of the safetensors file at path.
+func ReadSafetensors(path string) (*SafetensorsHeader, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseSafetensors(f)
+}
+
+// ParseSafetensors reads a safetensors header from r: a little-endian uint64.
+// length followed by a JSON object that maps tensor names to their dtype,.
+// shape and data offsets, plus an optional __metadata__ object of strings.
+func ParseSafetensors(r io.Reader) (*SafetensorsHeader, error) {
+	var size [8]byte
+	if _, err := io.ReadFull(r, size[:]); err != nil {
+		return nil, fmt.Errorf("safetensors: %w", err)
+	}
+	n := binary.LittleEndian.Uint64(size[:])
+	if n < 2 || n > maxSafetensorsHeader {
+		return nil, errors.New("safetensors: invalid header length")
+	}
+	raw := make([]byte, n)
+	if _, err := io.ReadFull(r, raw); err != nil {
+		return nil, fmt.Errorf("safetensors: %w", err)
+	}
+	var entries map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &entries); err != nil {
+		return nil, fmt.Errorf("safetensors: header: %w", err)
+	}
+
+	h := &SafetensorsHeader{}
+	perDType := map[string]uint64{}
+	for name, v := range entries {
+		if name == "__metadata__" {
+			if err := json.Unmarshal(v, &h.Metadata); err != nil {
+				return nil, fmt.Errorf("safetensors: __metadata__: %w", err)
+			}
+			continue
+		}
+		var t struct {
+			DType string   `json:"dtype"`
+			Shape []uint64 `json:"shape"`
+		}
+		if err := json.Unmarshal(v, &t); err != nil || t.DType == "" {
+			return nil, fmt.Errorf("safetensors: tensor %s: invalid entry", strconv.Quote(name))
+		}
+		count := uint64(1)
+		for _, d := range t.Shape {
+			count *= d
+		}
+		h.TensorCount++
+		h.ParameterCount += count
+		perDType[t.DType] += count
+	}
+
+	for dt, count := range perDType {
+		h.DTypes = append(h.DTypes, SafetensorsDType{DType: dt, ParameterCount: count})
+	}
+	sort.Slice(h.DTypes, func(i, j int) bool {
+		if h.DTypes[i].ParameterCount != h.DTypes[j].ParameterCount {
+			return h.DTypes[i].ParameterCount > h.DTypes[j].ParameterCount
+		}
+		return h.DTypes[i].DType < h.DTypes[j].DType
+	})
+	return h, nil
+}
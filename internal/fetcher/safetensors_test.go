@@ -0,0 +1,66 @@
+package fetcher
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// safetensorsFile returns a safetensors file with the JSON header header and.
+// no tensor data.
+func safetensorsFile(header string) []byte {
+	b := binary.LittleEndian.AppendUint64(nil, uint64(len(header)))
+	return append(b, header...)
+}
+
+func TestReadSafetensors(t *testing.T) {
+	header := `{
+		"__metadata__": {"format": "pt", "total_size": "3584"},
+		"embed.weight": {"dtype": "BF16", "shape": [32, 16], "data_offsets": [0, 1024]},
+		"lm_head.weight": {"dtype": "BF16", "shape": [16, 32], "data_offsets": [1024, 2048]},
+		"norm.weight": {"dtype": "F32", "shape": [16], "data_offsets": [2048, 2112]},
+		"step": {"dtype": "I64", "shape": [], "data_offsets": [2112, 2120]}
+	}`
+	path := filepath.Join(t.TempDir(), "model.safetensors")
+	if err := os.WriteFile(path, safetensorsFile(header), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	h, err := ReadSafetensors(path)
+	if err != nil {
+		t.Fatalf("ReadSafetensors: %v", err)
+	}
+	want := &SafetensorsHeader{
+		TensorCount:    4,
+		ParameterCount: 32*16*2 + 16 + 1,
+		DTypes: []SafetensorsDType{
+			{DType: "BF16", ParameterCount: 1024},
+			{DType: "F32", ParameterCount: 16},
+			{DType: "I64", ParameterCount: 1},
+		},
+		Metadata: map[string]string{"format": "pt", "total_size": "3584"},
+	}
+	if !reflect.DeepEqual(h, want) {
+		t.Errorf("header = %+v\nwant     %+v", h, want)
+	}
+	if got := h.DTypeString(); got != "BF16, F32, I64" {
+		t.Errorf("dtypes = %q", got)
+	}
+	if got := h.MetadataString(); got != "format=pt; total_size=3584" {
+		t.Errorf("metadata = %q", got)
+	}
+
+	for name, data := range map[string][]byte{
+		"truncated":      safetensorsFile(header)[:40],
+		"bad tensor":     safetensorsFile(`{"w": {"shape": [2]}}`),
+		"not json":       safetensorsFile(`{not json}`),
+		"huge length":    binary.LittleEndian.AppendUint64(nil, 1<<40),
+		"bad __metadata": safetensorsFile(`{"__metadata__": {"n": 1}}`),
+	} {
+		if _, err := ParseSafetensors(bytes.NewReader(data)); err == nil {
+			t.Errorf("%s: want error", name)
+		}
+	}
+}
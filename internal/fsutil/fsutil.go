@@ -0,0 +1,44 @@
+// Package fsutil holds small cross-platform filesystem path helpers shared by.
+// the scanner and BOM I/O.
+package fsutil
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// longPathPrefix is the Windows extended-length path prefix.
+const longPathPrefix = `\\?\`
+
+// Normalize returns p as a forward-slash path without the Windows.
+// extended-length prefix, so paths recorded in BOM evidence look the same on.
+// every platform.
+func Normalize(p string) string {
+	if rest, ok := strings.CutPrefix(p, longPathPrefix+`UNC\`); ok {
+		p = `\\` + rest
+	} else {
+		p = strings.TrimPrefix(p, longPathPrefix)
+	}
+	return filepath.ToSlash(p)
+}
+
+// Relocator returns a function that rewrites the normalized paths found.
+// below LongPath(root) in a string to the same paths below root as given. A.
+// walk of a relative root goes through its absolute long form on Windows;.
+// the paths it reports stay relative, like those of a walk of root itself.
+func Relocator(root string) func(string) string {
+	from := Normalize(LongPath(root))
+	to := Normalize(filepath.Clean(root))
+	if from == to {
+		return func(s string) string { return s }
+	}
+	return func(s string) string {
+		if s == from {
+			return to
+		}
+		if to == "." {
+			return strings.ReplaceAll(s, from+"/", "")
+		}
+		return strings.ReplaceAll(s, from+"/", to+"/")
+	}
+}
@@ -0,0 +1,23 @@
+package fsutil
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: filepath.Join("a", "b", "c.py"), want: "a/b/c.py"},
+		{in: `\\?\C:\repo\model.py`, want: filepath.ToSlash(`C:\repo\model.py`)},
+		{in: `\\?\UNC\server\share\x.py`, want: filepath.ToSlash(`\\server\share\x.py`)},
+		{in: "", want: ""},
+	}
+	for _, tt := range tests {
+		if got := Normalize(tt.in); got != tt.want {
+			t.Errorf("Normalize(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,8 @@
+//go:build !windows
+
+package fsutil
+
+// LongPath returns p unchanged; only Windows needs extended-length paths.
+func LongPath(p string) string {
+	return p
+}
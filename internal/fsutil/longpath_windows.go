@@ -0,0 +1,27 @@
+//go:build windows
+
+package fsutil
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// LongPath returns p as an absolute path in extended-length form.
+// (\\?\C:\... or \\?\UNC\server\...), which lifts the MAX_PATH limit for p.
+// and everything opened or walked beneath it. Relative paths are made.
+// absolute first, since Windows only accepts the prefix on absolute paths;.
+// [Relocator] turns the paths found beneath them back into relative ones.
+func LongPath(p string) string {
+	if p == "" || strings.HasPrefix(p, longPathPrefix) || strings.HasPrefix(p, `\\.\`) {
+		return p
+	}
+	abs, err := filepath.Abs(p)
+	if err != nil {
+		return p
+	}
+	if rest, ok := strings.CutPrefix(abs, `\\`); ok {
+		return longPathPrefix + `UNC\` + rest
+	}
+	return longPathPrefix + abs
+}
@@ -0,0 +1,287 @@
+// Package gitrepo checks out remote Git repositories so that they can be.
+// scanned without a local working copy.
+//.
+// The repository is fetched with go-git into a temporary directory,.
+// shallowly unless the history is needed, so no git installation is needed.
+// for HTTPS, SSH and git:// URLs. Nothing prompts: SSH URLs authenticate with.
+// the keys of the SSH agent (or of ~/.ssh), HTTPS URLs with an access token.
+// sent as basic auth, so that it appears neither in the remote URL of the.
+// clone nor in the process list.
+package gitrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
+)
+
+// checkoutRef is the local ref the fetched commit is stored under.
+const checkoutRef = plumbing.ReferenceName("refs/aibomgen/checkout")
+
+// Options configures Clone.
+type Options struct {
+	// URL is the remote repository: an HTTPS, SSH (ssh:// or scp-like.
+	// git@host:org/repo.git), git:// or file:// URL.
+	URL string
+	// Ref is the branch, tag or full commit SHA to check out ("": the.
+	// default branch of the remote).
+	Ref string
+	// Token authenticates HTTPS fetches. A "user:token" value is sent as is;.
+	// a bare token is sent with the user name x-access-token, which GitHub,.
+	// GitLab and Gitea accept. It is ignored for SSH URLs.
+	Token string
+	// Depth is the number of commits fetched (0: the whole history).
+	Depth int
+}
+
+// Checkout is a repository checked out by Clone.
+type Checkout struct {
+	// Dir is the working tree. Its base name is the name of the repository.
+	Dir string
+	// Commit is the SHA of the checked out commit.
+	Commit string
+
+	tmp string
+}
+
+// Remove deletes the checkout.
+func (c *Checkout) Remove() error {
+	return os.RemoveAll(c.tmp)
+}
+
+// Clone checks out opts.Ref of the repository at opts.URL into a new.
+// temporary directory. The remote of the checkout is opts.URL without.
+// credentials. The caller removes the checkout when done.
+func Clone(ctx context.Context, opts Options) (*Checkout, error) {
+	remote := strings.TrimSpace(opts.URL)
+	ref := strings.TrimSpace(opts.Ref)
+	switch {
+	case remote == "":
+		return nil, errors.New("no repository URL")
+	case strings.HasPrefix(remote, "-"):
+		// A local path or file:// URL ends up on the command line of.
+		// git-upload-pack, where it would read as an option.
+		return nil, fmt.Errorf("invalid repository URL %q", Redact(remote))
+	case strings.HasPrefix(ref, "-"):
+		return nil, fmt.Errorf("invalid ref %q", ref)
+	}
+	auth, err := authMethod(remote, opts.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	tmp, err := os.MkdirTemp("", "aibomgen-repo-")
+	if err != nil {
+		return nil, err
+	}
+	c := &Checkout{Dir: filepath.Join(tmp, Name(remote)), tmp: tmp}
+	commit, err := checkout(ctx, c.Dir, remote, ref, opts.Depth, auth)
+	if err != nil {
+		c.Remove()
+		// Transport errors may quote the URL with its credentials.
+		return nil, errors.New(strings.ReplaceAll(err.Error(), remote, Redact(remote)))
+	}
+	c.Commit = commit.String()
+	return c, nil
+}
+
+// checkout fetches ref of remote into a new repository in dir, checks it out.
+// and returns its commit.
+func checkout(ctx context.Context, dir, remote, ref string, depth int, auth transport.AuthMethod) (plumbing.Hash, error) {
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	origin, err := repo.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{remote}})
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	var commit plumbing.Hash
+	if plumbing.IsHash(ref) {
+		commit, err = fetchCommit(ctx, repo, origin, plumbing.NewHash(ref), depth, auth)
+	} else {
+		commit, err = fetchRef(ctx, repo, origin, ref, depth, auth)
+	}
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	if err := wt.Checkout(&git.CheckoutOptions{Hash: commit, Force: true}); err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("checkout %s: %w", commit, err)
+	}
+
+	// Credentials in the URL do not stay in the checkout.
+	cfg, err := repo.Config()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	cfg.Remotes["origin"].URLs = []string{Redact(remote)}
+	return commit, repo.SetConfig(cfg)
+}
+
+// fetchRef fetches the branch or tag ref of origin ("": its HEAD) and returns.
+// the commit it points to.
+func fetchRef(ctx context.Context, repo *git.Repository, origin *git.Remote, ref string, depth int, auth transport.AuthMethod) (plumbing.Hash, error) {
+	refs, err := origin.ListContext(ctx, &git.ListOptions{Auth: auth})
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("list refs: %w", err)
+	}
+	src, ok := findRef(refs, ref)
+	if !ok {
+		return plumbing.ZeroHash, fmt.Errorf("no branch or tag %q in the repository", ref)
+	}
+	err = origin.FetchContext(ctx, &git.FetchOptions{
+		RefSpecs: []config.RefSpec{config.RefSpec("+" + src + ":" + checkoutRef)},
+		Depth:    depth,
+		Auth:     auth,
+		Tags:     git.NoTags,
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return plumbing.ZeroHash, fmt.Errorf("fetch %s: %w", src.Short(), err)
+	}
+	fetched, err := repo.Reference(checkoutRef, true)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	// An annotated tag points to a tag object rather than the commit.
+	if tag, err := repo.TagObject(fetched.Hash()); err == nil {
+		c, err := tag.Commit()
+		if err != nil {
+			return plumbing.ZeroHash, fmt.Errorf("tag %s: %w", ref, err)
+		}
+		return c.Hash, nil
+	}
+	return fetched.Hash(), nil
+}
+
+// findRef returns the name of the ref of refs that ref names: HEAD when ref.
+// is empty, else a full ref name, a branch or a tag, in that order.
+func findRef(refs []*plumbing.Reference, ref string) (plumbing.ReferenceName, bool) {
+	candidates := []plumbing.ReferenceName{plumbing.HEAD}
+	if ref != "" {
+		candidates = []plumbing.ReferenceName{
+			plumbing.ReferenceName(ref),
+			plumbing.NewBranchReferenceName(ref),
+			plumbing.NewTagReferenceName(ref),
+		}
+	}
+	for _, name := range candidates {
+		for _, r := range refs {
+			if r.Name() == name {
+				return name, true
+			}
+		}
+	}
+	return "", false
+}
+
+// fetchCommit fetches commit from origin. Servers that do not serve commits.
+// by SHA get the whole history fetched instead.
+func fetchCommit(ctx context.Context, repo *git.Repository, origin *git.Remote, commit plumbing.Hash, depth int, auth transport.AuthMethod) (plumbing.Hash, error) {
+	err := origin.FetchContext(ctx, &git.FetchOptions{
+		RefSpecs: []config.RefSpec{config.RefSpec("+" + commit.String() + ":" + checkoutRef.String())},
+		Depth:    depth,
+		Auth:     auth,
+		Tags:     git.NoTags,
+	})
+	if errors.Is(err, git.ErrExactSHA1NotSupported) {
+		err = origin.FetchContext(ctx, &git.FetchOptions{
+			RefSpecs: []config.RefSpec{"+refs/heads/*:refs/remotes/origin/*", "+refs/tags/*:refs/tags/*"},
+			Auth:     auth,
+		})
+	}
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return plumbing.ZeroHash, fmt.Errorf("fetch %s: %w", commit, err)
+	}
+	if _, err := repo.CommitObject(commit); err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("commit %s: %w", commit, err)
+	}
+	return commit, nil
+}
+
+// authMethod returns how to authenticate to remote: token as basic auth for.
+// HTTP URLs, the SSH agent or an unencrypted key of ~/.ssh for SSH URLs, and.
+// nil (anonymous, or the credentials of the URL) otherwise.
+func authMethod(remote, token string) (transport.AuthMethod, error) {
+	ep, err := transport.NewEndpoint(remote)
+	if err != nil {
+		return nil, fmt.Errorf("invalid repository URL %q: %w", Redact(remote), err)
+	}
+	switch ep.Protocol {
+	case "http", "https":
+		token = strings.TrimSpace(token)
+		if token == "" {
+			return nil, nil
+		}
+		user, password, ok := strings.Cut(token, ":")
+		if !ok {
+			user, password = "x-access-token", token
+		}
+		return &githttp.BasicAuth{Username: user, Password: password}, nil
+	case "ssh":
+		user := ep.User
+		if user == "" {
+			user = "git"
+		}
+		if auth, err := gitssh.NewSSHAgentAuth(user); err == nil {
+			return auth, nil
+		}
+		home, _ := os.UserHomeDir()
+		for _, key := range []string{"id_ed25519", "id_ecdsa", "id_rsa"} {
+			if auth, err := gitssh.NewPublicKeysFromFile(user, filepath.Join(home, ".ssh", key), ""); err == nil {
+				return auth, nil
+			}
+		}
+		return nil, errors.New("no SSH agent (SSH_AUTH_SOCK) and no unencrypted key in ~/.ssh")
+	}
+	return nil, nil
+}
+
+// Name returns the name of the repository at remote: the last element of.
+// its path without the .git suffix ("repo" when there is none).
+func Name(remote string) string {
+	p := strings.TrimSpace(remote)
+	if u, err := url.Parse(p); err == nil && u.Scheme != "" {
+		p = u.Path
+	} else if _, after, ok := strings.Cut(p, ":"); ok && !filepath.IsAbs(p) {
+		// scp-like syntax: git@host:org/repo.git.
+		p = after
+	}
+	name := strings.TrimSuffix(path.Base(strings.TrimRight(filepath.ToSlash(p), "/")), ".git")
+	if name == "" || name == "." || name == "/" {
+		return "repo"
+	}
+	return name
+}
+
+// Redact returns remote with its credentials replaced, for error messages.
+// and the remote of a checkout: the password of its user info, and for HTTP.
+// URLs also a user name, which is then usually a token.
+func Redact(remote string) string {
+	u, err := url.Parse(remote)
+	if err != nil || u.User == nil {
+		return remote
+	}
+	if _, ok := u.User.Password(); !ok && (u.Scheme == "https" || u.Scheme == "http") {
+		u.User = url.User("xxxxx")
+		return u.String()
+	}
+	return u.Redacted()
+}
@@ -0,0 +1,344 @@
+// Package graph renders the supply-chain graph of an AIBOM (application →.
+// models → datasets → base models) as Graphviz DOT or Mermaid, to embed the.
+// diagram in documentation and reviews.
+//.
+// Nodes are the metadata component and the components of the BOM, nested.
+// ones included. Edges come from the dependency graph ("depends on"), from.
+// nested components ("part") and from pedigree ancestors ("base model").
+// An ancestor that is not a component of the BOM gets a node of its own. By.
+// default only the application, model and dataset components are drawn;.
+// Options.All keeps every component, e.g. the libraries of a merged SBOM.
+package graph
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+// NodeKind classifies a node by the type of its component.
+type NodeKind string
+
+const (
+	KindApplication NodeKind = "application"
+	KindModel       NodeKind = "model"
+	KindDataset     NodeKind = "dataset"
+	KindOther       NodeKind = "other"
+)
+
+// EdgeKind says how two nodes are related.
+type EdgeKind string
+
+const (
+	EdgeDependsOn EdgeKind = "depends on"
+	EdgePart      EdgeKind = "part"
+	EdgeBaseModel EdgeKind = "base model"
+)
+
+// Node is a component of the graph.
+type Node struct {
+	ID      string
+	Label   string
+	Version string
+	Kind    NodeKind
+}
+
+// Edge links two nodes by ID. Part edges point from the parent to the part,.
+// base-model edges from the derived model to its ancestor.
+type Edge struct {
+	From string
+	To   string
+	Kind EdgeKind
+}
+
+// Graph is the graph of one BOM, in BOM order.
+type Graph struct {
+	Nodes []Node
+	Edges []Edge
+}
+
+// Options configures Build.
+type Options struct {
+	// All keeps every component instead of the application, model and.
+	// dataset components only.
+	All bool
+}
+
+// Build returns the graph of bom.
+func Build(bom *cdx.BOM, opts Options) Graph {
+	b := builder{
+		opts:   opts,
+		byRef:  make(map[string]string),
+		byPURL: make(map[string]string),
+		byName: make(map[string]string),
+		edges:  make(map[[2]string]int),
+		ids:    make(map[*cdx.Component]string),
+	}
+	if bom == nil {
+		return b.g
+	}
+
+	var root *cdx.Component
+	if bom.Metadata != nil {
+		root = bom.Metadata.Component
+	}
+	if root != nil {
+		b.addNode(root, true)
+	}
+	var components []cdx.Component
+	if bom.Components != nil {
+		components = *bom.Components
+	}
+	for i := range components {
+		b.addNode(&components[i], false)
+	}
+
+	// Part edges of nested components.
+	if root != nil {
+		b.addParts(root)
+	}
+	for i := range components {
+		b.addParts(&components[i])
+	}
+
+	if bom.Dependencies != nil {
+		for _, dep := range *bom.Dependencies {
+			from, ok := b.byRef[dep.Ref]
+			if !ok || dep.Dependencies == nil {
+				continue
+			}
+			for _, ref := range *dep.Dependencies {
+				if to, ok := b.byRef[ref]; ok {
+					b.addEdge(from, to, EdgeDependsOn)
+				}
+			}
+		}
+	}
+
+	// A base model is also a dependency: the pedigree says more.
+	if root != nil {
+		b.walk(root, b.addAncestors)
+	}
+	for i := range components {
+		b.walk(&components[i], b.addAncestors)
+	}
+	return b.g
+}
+
+type builder struct {
+	opts Options
+	g    Graph
+	// Node IDs by bom-ref, purl and group/name.
+	byRef  map[string]string
+	byPURL map[string]string
+	byName map[string]string
+	// edges maps the endpoints of an edge to its index in g.Edges.
+	edges map[[2]string]int
+	// ids maps components to their node IDs.
+	ids map[*cdx.Component]string
+}
+
+// addNode adds c and its nested components. The root is kept whatever its.
+// type.
+func (b *builder) addNode(c *cdx.Component, root bool) {
+	kind := kindOf(c.Type)
+	if root || b.opts.All || kind != KindOther {
+		id := fmt.Sprintf("n%d", len(b.g.Nodes))
+		b.g.Nodes = append(b.g.Nodes, Node{ID: id, Label: label(c), Version: c.Version, Kind: kind})
+		b.ids[c] = id
+		if c.BOMRef != "" {
+			b.byRef[c.BOMRef] = id
+		}
+		if c.PackageURL != "" {
+			b.byPURL[c.PackageURL] = id
+		}
+		if _, ok := b.byName[label(c)]; !ok {
+			b.byName[label(c)] = id
+		}
+	}
+	if c.Components != nil {
+		for i := range *c.Components {
+			b.addNode(&(*c.Components)[i], false)
+		}
+	}
+}
+
+// addParts links the nested components of c (and of its parts) to c.
+func (b *builder) addParts(c *cdx.Component) {
+	if c.Components == nil {
+		return
+	}
+	for i := range *c.Components {
+		part := &(*c.Components)[i]
+		parent, ok1 := b.ids[c]
+		child, ok2 := b.ids[part]
+		if ok1 && ok2 {
+			b.addEdge(parent, child, EdgePart)
+		}
+		b.addParts(part)
+	}
+}
+
+// walk calls fn for c and its nested components.
+func (b *builder) walk(c *cdx.Component, fn func(*cdx.Component)) {
+	fn(c)
+	if c.Components != nil {
+		for i := range *c.Components {
+			b.walk(&(*c.Components)[i], fn)
+		}
+	}
+}
+
+// addAncestors adds the base-model edges of c. Ancestors are matched to.
+// nodes by purl, then by name; unmatched ones get a node of their own.
+func (b *builder) addAncestors(c *cdx.Component) {
+	from, ok := b.ids[c]
+	if !ok || c.Pedigree == nil || c.Pedigree.Ancestors == nil {
+		return
+	}
+	for i := range *c.Pedigree.Ancestors {
+		anc := &(*c.Pedigree.Ancestors)[i]
+		to, ok := "", false
+		if anc.PackageURL != "" {
+			to, ok = b.byPURL[anc.PackageURL]
+		}
+		if !ok {
+			to, ok = b.byName[label(anc)]
+		}
+		if !ok {
+			to = fmt.Sprintf("n%d", len(b.g.Nodes))
+			kind := kindOf(anc.Type)
+			if kind == KindOther {
+				kind = KindModel
+			}
+			b.g.Nodes = append(b.g.Nodes, Node{ID: to, Label: label(anc), Version: anc.Version, Kind: kind})
+			if anc.PackageURL != "" {
+				b.byPURL[anc.PackageURL] = to
+			}
+			b.byName[label(anc)] = to
+		}
+		b.addEdge(from, to, EdgeBaseModel)
+	}
+}
+
+// addEdge adds an edge between two nodes once. A base-model edge replaces.
+// the dependency edge between the same nodes.
+func (b *builder) addEdge(from, to string, kind EdgeKind) {
+	if from == to {
+		return
+	}
+	key := [2]string{from, to}
+	if i, ok := b.edges[key]; ok {
+		if kind == EdgeBaseModel {
+			b.g.Edges[i].Kind = kind
+		}
+		return
+	}
+	b.edges[key] = len(b.g.Edges)
+	b.g.Edges = append(b.g.Edges, Edge{From: from, To: to, Kind: kind})
+}
+
+func kindOf(t cdx.ComponentType) NodeKind {
+	switch t {
+	case cdx.ComponentTypeApplication:
+		return KindApplication
+	case cdx.ComponentTypeMachineLearningModel:
+		return KindModel
+	case cdx.ComponentTypeData:
+		return KindDataset
+	}
+	return KindOther
+}
+
+// label names a component by group/name, or by its bom-ref when it has no.
+// name. Datasets without an owner have their name as group.
+func label(c *cdx.Component) string {
+	name := c.Name
+	if c.Group != "" && c.Group != name && !strings.HasPrefix(name, c.Group+"/") {
+		name = c.Group + "/" + name
+	}
+	if name == "" {
+		name = c.BOMRef
+	}
+	return name
+}
+
+// WriteDOT writes g as a Graphviz digraph.
+func WriteDOT(w io.Writer, g Graph) error {
+	var sb strings.Builder
+	sb.WriteString("digraph aibom {\n")
+	sb.WriteString("  rankdir=LR;\n")
+	sb.WriteString("  node [fontname=\"Helvetica\"];\n")
+	for _, n := range g.Nodes {
+		shape := map[NodeKind]string{KindApplication: "box", KindModel: "ellipse", KindDataset: "cylinder"}[n.Kind]
+		if shape == "" {
+			shape = "note"
+		}
+		text := n.Label
+		if n.Version != "" {
+			text += "\n" + n.Version
+		}
+		fmt.Fprintf(&sb, "  %s [label=%s, shape=%s];\n", n.ID, dotQuote(text), shape)
+	}
+	for _, e := range g.Edges {
+		switch e.Kind {
+		case EdgeBaseModel:
+			fmt.Fprintf(&sb, "  %s -> %s [label=%s, style=dashed];\n", e.From, e.To, dotQuote(string(e.Kind)))
+		case EdgePart:
+			fmt.Fprintf(&sb, "  %s -> %s [label=%s, arrowhead=diamond];\n", e.From, e.To, dotQuote(string(e.Kind)))
+		default:
+			fmt.Fprintf(&sb, "  %s -> %s;\n", e.From, e.To)
+		}
+	}
+	sb.WriteString("}\n")
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// WriteMermaid writes g as a Mermaid flowchart.
+func WriteMermaid(w io.Writer, g Graph) error {
+	var sb strings.Builder
+	sb.WriteString("flowchart LR\n")
+	for _, n := range g.Nodes {
+		text := mermaidQuote(n.Label)
+		if n.Version != "" {
+			text = mermaidQuote(n.Label + "<br/>" + n.Version)
+		}
+		switch n.Kind {
+		case KindApplication:
+			fmt.Fprintf(&sb, "  %s[%s]\n", n.ID, text)
+		case KindModel:
+			fmt.Fprintf(&sb, "  %s([%s])\n", n.ID, text)
+		case KindDataset:
+			fmt.Fprintf(&sb, "  %s[(%s)]\n", n.ID, text)
+		default:
+			fmt.Fprintf(&sb, "  %s>%s]\n", n.ID, text)
+		}
+	}
+	for _, e := range g.Edges {
+		switch e.Kind {
+		case EdgeBaseModel:
+			fmt.Fprintf(&sb, "  %s -. %s .-> %s\n", e.From, e.Kind, e.To)
+		case EdgePart:
+			fmt.Fprintf(&sb, "  %s -- %s --> %s\n", e.From, e.Kind, e.To)
+		default:
+			fmt.Fprintf(&sb, "  %s --> %s\n", e.From, e.To)
+		}
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// dotQuote quotes s as a DOT string; newlines become line breaks.
+func dotQuote(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `"` + r.Replace(s) + `"`
+}
+
+// mermaidQuote quotes s as a Mermaid label. Quotes cannot be escaped with a.
+// backslash, only as an entity.
+func mermaidQuote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, "#quot;") + `"`
+}
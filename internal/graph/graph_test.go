@@ -0,0 +1,112 @@
+package graph
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+// chatBOM is an application BOM with a LoRA fine-tune, its base model and a.
+// dataset, a library and a base model that is only named in the pedigree.
+func chatBOM() *cdx.BOM {
+	return &cdx.BOM{
+		Metadata: &cdx.Metadata{Component: &cdx.Component{BOMRef: "app", Type: cdx.ComponentTypeApplication, Name: "chat", Version: "1.0"}},
+		Components: &[]cdx.Component{
+			{
+				BOMRef: "pkg:huggingface/org/sql-lora", Type: cdx.ComponentTypeMachineLearningModel, Name: "sql-lora", Group: "org", PackageURL: "pkg:huggingface/org/sql-lora",
+				Pedigree: &cdx.Pedigree{Ancestors: &[]cdx.Component{{Type: cdx.ComponentTypeMachineLearningModel, Name: "meta-llama/Llama-3.1-8B", PackageURL: "pkg:huggingface/meta-llama/Llama-3.1-8B"}}},
+			},
+			{
+				BOMRef: "pkg:huggingface/meta-llama/Llama-3.1-8B", Type: cdx.ComponentTypeMachineLearningModel, Name: "meta-llama/Llama-3.1-8B", PackageURL: "pkg:huggingface/meta-llama/Llama-3.1-8B",
+				Pedigree: &cdx.Pedigree{Ancestors: &[]cdx.Component{{Name: "meta-llama/Llama-3.1"}}},
+			},
+			{BOMRef: "data", Type: cdx.ComponentTypeData, Name: `org/"sql" pairs`},
+			{BOMRef: "torch", Type: cdx.ComponentTypeLibrary, Name: "torch", Version: "2.3.0"},
+		},
+		Dependencies: &[]cdx.Dependency{
+			{Ref: "app", Dependencies: &[]string{"pkg:huggingface/org/sql-lora", "torch"}},
+			{Ref: "pkg:huggingface/org/sql-lora", Dependencies: &[]string{"data", "pkg:huggingface/meta-llama/Llama-3.1-8B"}},
+		},
+	}
+}
+
+func TestBuild(t *testing.T) {
+	g := Build(chatBOM(), Options{})
+
+	var labels []string
+	for _, n := range g.Nodes {
+		labels = append(labels, n.Label)
+	}
+	want := []string{"chat", "org/sql-lora", "meta-llama/Llama-3.1-8B", `org/"sql" pairs`, "meta-llama/Llama-3.1"}
+	if !reflect.DeepEqual(labels, want) {
+		t.Fatalf("nodes = %v, want %v (libraries left out, pedigree-only ancestor added)", labels, want)
+	}
+	wantEdges := []Edge{
+		{From: "n0", To: "n1", Kind: EdgeDependsOn},
+		{From: "n1", To: "n3", Kind: EdgeDependsOn},
+		{From: "n1", To: "n2", Kind: EdgeBaseModel},
+		{From: "n2", To: "n4", Kind: EdgeBaseModel},
+	}
+	if !reflect.DeepEqual(g.Edges, wantEdges) {
+		t.Errorf("edges = %+v, want %+v", g.Edges, wantEdges)
+	}
+
+	all := Build(chatBOM(), Options{All: true})
+	if len(all.Nodes) != 6 || len(all.Edges) != 5 {
+		t.Errorf("with All: %d nodes, %d edges, want the library and its edge", len(all.Nodes), len(all.Edges))
+	}
+}
+
+func TestBuildNestedParts(t *testing.T) {
+	bom := &cdx.BOM{Metadata: &cdx.Metadata{Component: &cdx.Component{
+		BOMRef: "sd", Type: cdx.ComponentTypeMachineLearningModel, Name: "org/sd",
+		Components: &[]cdx.Component{{BOMRef: "sd#unet", Type: cdx.ComponentTypeMachineLearningModel, Name: "org/sd/unet"}},
+	}}}
+	g := Build(bom, Options{})
+	if len(g.Nodes) != 2 || !reflect.DeepEqual(g.Edges, []Edge{{From: "n0", To: "n1", Kind: EdgePart}}) {
+		t.Errorf("graph = %+v", g)
+	}
+	if empty := Build(nil, Options{}); len(empty.Nodes) != 0 {
+		t.Errorf("nil BOM: %+v", empty)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	g := Build(chatBOM(), Options{})
+
+	var dot bytes.Buffer
+	if err := WriteDOT(&dot, g); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"digraph aibom {",
+		`n0 [label="chat\n1.0", shape=box];`,
+		`n3 [label="org/\"sql\" pairs", shape=cylinder];`,
+		"n0 -> n1;",
+		`n1 -> n2 [label="base model", style=dashed];`,
+	} {
+		if !strings.Contains(dot.String(), want) {
+			t.Errorf("DOT output lacks %q:\n%s", want, dot.String())
+		}
+	}
+
+	var mmd bytes.Buffer
+	if err := WriteMermaid(&mmd, g); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"flowchart LR\n",
+		`n0["chat<br/>1.0"]`,
+		`n1(["org/sql-lora"])`,
+		`n3[("org/#quot;sql#quot; pairs")]`,
+		"n0 --> n1",
+		"n1 -. base model .-> n2",
+	} {
+		if !strings.Contains(mmd.String(), want) {
+			t.Errorf("Mermaid output lacks %q:\n%s", want, mmd.String())
+		}
+	}
+}
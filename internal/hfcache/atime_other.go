@@ -0,0 +1,14 @@
+//go:build !linux && !darwin && !windows
+
+package hfcache
+
+import (
+	"os"
+	"time"
+)
+
+// accessTime returns the modification time of a file; access times are only.
+// read on Linux, macOS and Windows.
+func accessTime(info os.FileInfo) time.Time {
+	return info.ModTime()
+}
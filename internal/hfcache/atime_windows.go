@@ -0,0 +1,18 @@
+//go:build windows
+
+package hfcache
+
+import (
+	"os"
+	"syscall"
+	"time"
+)
+
+// accessTime returns the last access time of a file, or its modification.
+// time when the file system does not record it.
+func accessTime(info os.FileInfo) time.Time {
+	if attr, ok := info.Sys().(*syscall.Win32FileAttributeData); ok {
+		return time.Unix(0, attr.LastAccessTime.Nanoseconds())
+	}
+	return info.ModTime()
+}
@@ -0,0 +1,279 @@
+// Package hfcache reads the Hugging Face hub cache (~/.cache/huggingface/hub).
+// to tell which model, dataset and space revisions were downloaded on a.
+// machine, how much disk they use and when they were last read.
+//.
+// The cache layout is the one of huggingface_hub:.
+//
+//	models--org--name/
+//	  blobs/<etag>             file contents
+//	  refs/main                commit the "main" ref resolved to
+//	  snapshots/<commit>/...   symlinks into blobs/
+//
+// A revision is one snapshot directory. Revisions that no ref points to are.
+// "detached": they were replaced by a newer download of the same ref.
+package hfcache
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Repo types, as used in the cache directory names.
+const (
+	TypeModel   = "model"
+	TypeDataset = "dataset"
+	TypeSpace   = "space"
+)
+
+// Repo is a cached Hugging Face repo.
+type Repo struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+	Path string `json:"path"`
+	// Size is the disk usage of all revisions together; files shared by.
+	// revisions are counted once.
+	Size         int64      `json:"size"`
+	LastAccessed time.Time  `json:"lastAccessed"`
+	LastModified time.Time  `json:"lastModified"`
+	Revisions    []Revision `json:"revisions"`
+}
+
+// Revision is a snapshot of a cached repo.
+type Revision struct {
+	Commit string `json:"commit"`
+	// Refs are the refs (branches, tags, PR refs) pointing to the commit.
+	Refs         []string  `json:"refs,omitempty"`
+	Path         string    `json:"path"`
+	Files        int       `json:"files"`
+	Size         int64     `json:"size"`
+	LastAccessed time.Time `json:"lastAccessed"`
+	LastModified time.Time `json:"lastModified"`
+
+	// blobs maps the resolved path of every file of the snapshot to its size.
+	blobs map[string]int64
+}
+
+// Detached reports whether no ref points to the revision.
+func (r Revision) Detached() bool {
+	return len(r.Refs) == 0
+}
+
+// Latest returns the revision the "main" ref points to, else the most.
+// recently modified one. ok is false when the repo has no revisions.
+func (r Repo) Latest() (rev Revision, ok bool) {
+	for _, v := range r.Revisions {
+		for _, ref := range v.Refs {
+			if ref == "main" {
+				return v, true
+			}
+		}
+	}
+	for _, v := range r.Revisions {
+		if !ok || v.LastModified.After(rev.LastModified) {
+			rev, ok = v, true
+		}
+	}
+	return rev, ok
+}
+
+// DefaultDir returns the hub cache directory huggingface_hub uses:.
+// $HF_HUB_CACHE, else $HF_HOME/hub, else $XDG_CACHE_HOME/huggingface/hub,.
+// else ~/.cache/huggingface/hub.
+func DefaultDir() (string, error) {
+	if dir := os.Getenv("HF_HUB_CACHE"); dir != "" {
+		return dir, nil
+	}
+	if dir := os.Getenv("HF_HOME"); dir != "" {
+		return filepath.Join(dir, "hub"), nil
+	}
+	if dir := os.Getenv("XDG_CACHE_HOME"); dir != "" {
+		return filepath.Join(dir, "huggingface", "hub"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".cache", "huggingface", "hub"), nil
+}
+
+// Scan reads every repo in the cache directory dir, sorted by type and ID.
+// Entries that are not repo directories (lock files, version.txt) are.
+// skipped.
+func Scan(dir string) ([]Repo, error) {
+	// Resolve dir so that blob paths from snapshot links compare equal to.
+	// the paths listed in blobs/.
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+		dir = resolved
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var repos []Repo
+	for _, e := range entries {
+		typ, id, ok := parseRepoDir(e.Name())
+		if !ok || !e.IsDir() {
+			continue
+		}
+		repo, err := scanRepo(filepath.Join(dir, e.Name()), typ, id)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", e.Name(), err)
+		}
+		repos = append(repos, repo)
+	}
+	sort.Slice(repos, func(i, j int) bool {
+		if repos[i].Type != repos[j].Type {
+			return repos[i].Type < repos[j].Type
+		}
+		return repos[i].ID < repos[j].ID
+	})
+	return repos, nil
+}
+
+// parseRepoDir splits a cache directory name (models--org--name) into the.
+// repo type and ID.
+func parseRepoDir(name string) (typ, id string, ok bool) {
+	prefix, rest, found := strings.Cut(name, "--")
+	if !found || rest == "" {
+		return "", "", false
+	}
+	switch prefix {
+	case "models":
+		typ = TypeModel
+	case "datasets":
+		typ = TypeDataset
+	case "spaces":
+		typ = TypeSpace
+	default:
+		return "", "", false
+	}
+	return typ, strings.ReplaceAll(rest, "--", "/"), true
+}
+
+func scanRepo(path, typ, id string) (Repo, error) {
+	repo := Repo{Type: typ, ID: id, Path: path}
+
+	refs, err := readRefs(filepath.Join(path, "refs"))
+	if err != nil {
+		return repo, err
+	}
+
+	snapshots, err := os.ReadDir(filepath.Join(path, "snapshots"))
+	if err != nil && !os.IsNotExist(err) {
+		return repo, err
+	}
+	for _, s := range snapshots {
+		if !s.IsDir() {
+			continue
+		}
+		rev, err := scanRevision(filepath.Join(path, "snapshots", s.Name()))
+		if err != nil {
+			return repo, err
+		}
+		rev.Refs = refs[rev.Commit]
+		repo.Revisions = append(repo.Revisions, rev)
+	}
+	sort.Slice(repo.Revisions, func(i, j int) bool {
+		return repo.Revisions[i].LastModified.After(repo.Revisions[j].LastModified)
+	})
+
+	// Count each blob once, including blobs no snapshot links to any more.
+	seen := make(map[string]bool)
+	for _, rev := range repo.Revisions {
+		for p, size := range rev.blobs {
+			if !seen[p] {
+				seen[p] = true
+				repo.Size += size
+			}
+		}
+		repo.LastAccessed = latest(repo.LastAccessed, rev.LastAccessed)
+		repo.LastModified = latest(repo.LastModified, rev.LastModified)
+	}
+	blobs, _ := os.ReadDir(filepath.Join(path, "blobs"))
+	for _, b := range blobs {
+		p := filepath.Join(path, "blobs", b.Name())
+		if info, err := b.Info(); err == nil && info.Mode().IsRegular() && !seen[p] {
+			seen[p] = true
+			repo.Size += info.Size()
+		}
+	}
+	return repo, nil
+}
+
+// readRefs maps each commit to the names of the refs pointing to it. Ref.
+// names can contain slashes (refs/pr/1).
+func readRefs(dir string) (map[string][]string, error) {
+	refs := make(map[string][]string)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == dir {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		name, _ := filepath.Rel(dir, path)
+		commit := strings.TrimSpace(string(data))
+		refs[commit] = append(refs[commit], filepath.ToSlash(name))
+		return nil
+	})
+	for _, names := range refs {
+		sort.Strings(names)
+	}
+	return refs, err
+}
+
+// scanRevision sums the files of a snapshot directory. Snapshot files are.
+// symlinks into blobs/; the times and sizes are those of the blobs.
+func scanRevision(dir string) (Revision, error) {
+	rev := Revision{Commit: filepath.Base(dir), Path: dir, blobs: make(map[string]int64)}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		target := path
+		if d.Type()&fs.ModeSymlink != 0 {
+			if resolved, err := filepath.EvalSymlinks(path); err == nil {
+				target = resolved
+			}
+		}
+		info, err := os.Stat(target)
+		if err != nil {
+			return nil // dangling link to a deleted blob
+		}
+		rev.Files++
+		if _, ok := rev.blobs[target]; !ok {
+			rev.blobs[target] = info.Size()
+			rev.Size += info.Size()
+		}
+		rev.LastAccessed = latest(rev.LastAccessed, accessTime(info))
+		rev.LastModified = latest(rev.LastModified, info.ModTime())
+		return nil
+	})
+	return rev, err
+}
+
+func latest(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
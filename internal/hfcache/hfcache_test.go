@@ -0,0 +1,169 @@
+package hfcache
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// writeRepo creates a cache repo with one snapshot per revision; files maps.
+// a revision to its file names, each linked to the blob of the same name.
+func writeRepo(t *testing.T, cache, dir string, refs map[string]string, files map[string][]string) string {
+	t.Helper()
+	repo := filepath.Join(cache, dir)
+	for ref, commit := range refs {
+		p := filepath.Join(repo, "refs", filepath.FromSlash(ref))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(commit), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for commit, names := range files {
+		snap := filepath.Join(repo, "snapshots", commit)
+		if err := os.MkdirAll(snap, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		for _, name := range names {
+			blob := filepath.Join(repo, "blobs", name)
+			if _, err := os.Stat(blob); os.IsNotExist(err) {
+				if err := os.MkdirAll(filepath.Dir(blob), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(blob, []byte(name+"-contents"), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := os.Symlink(filepath.Join("..", "..", "blobs", name), filepath.Join(snap, name)); err != nil {
+				t.Skipf("symlinks not supported: %v", err)
+			}
+		}
+	}
+	return repo
+}
+
+func touch(t *testing.T, path string, at time.Time) {
+	t.Helper()
+	if err := os.Chtimes(path, at, at); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestScan(t *testing.T) {
+	cache := t.TempDir()
+	repo := writeRepo(t, cache, "models--acme--bert",
+		map[string]string{"main": "new", "refs/pr/1": "new"},
+		map[string][]string{"old": {"config.json", "weights-a"}, "new": {"config.json", "weights-b"}})
+	writeRepo(t, cache, "datasets--acme--corpus", map[string]string{"main": "c1"}, map[string][]string{"c1": {"data"}})
+	os.WriteFile(filepath.Join(cache, "version.txt"), []byte("1"), 0o644)
+
+	old := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	recent := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	touch(t, filepath.Join(repo, "blobs", "weights-a"), old)
+	touch(t, filepath.Join(repo, "blobs", "config.json"), old)
+	touch(t, filepath.Join(repo, "blobs", "weights-b"), recent)
+
+	repos, err := Scan(cache)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(repos) != 2 || repos[0].Type != TypeDataset || repos[1].ID != "acme/bert" {
+		t.Fatalf("repos = %+v", repos)
+	}
+
+	bert := repos[1]
+	if len(bert.Revisions) != 2 {
+		t.Fatalf("revisions = %+v", bert.Revisions)
+	}
+	newRev, oldRev := bert.Revisions[0], bert.Revisions[1]
+	if newRev.Commit != "new" || !reflect.DeepEqual(newRev.Refs, []string{"main", "refs/pr/1"}) || newRev.Files != 2 {
+		t.Fatalf("new revision = %+v", newRev)
+	}
+	if !oldRev.Detached() || !oldRev.LastModified.Equal(old) {
+		t.Fatalf("old revision = %+v", oldRev)
+	}
+	if !newRev.LastModified.Equal(recent) {
+		t.Fatalf("new revision modified %v, want %v", newRev.LastModified, recent)
+	}
+
+	// config.json is shared and only counted once.
+	size := func(name string) int64 { return int64(len(name + "-contents")) }
+	if want := size("config.json") + size("weights-a") + size("weights-b"); bert.Size != want {
+		t.Fatalf("repo size = %d, want %d", bert.Size, want)
+	}
+	if latest, ok := bert.Latest(); !ok || latest.Commit != "new" {
+		t.Fatalf("latest = %+v", latest)
+	}
+}
+
+func TestCleanupPlan(t *testing.T) {
+	cache := t.TempDir()
+	repo := writeRepo(t, cache, "models--acme--bert",
+		map[string]string{"main": "new"},
+		map[string][]string{"old": {"config.json", "weights-a"}, "new": {"config.json", "weights-b"}})
+	gpt := writeRepo(t, cache, "models--gpt2", map[string]string{"main": "g1"}, map[string][]string{"g1": {"model"}})
+
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	for _, p := range []string{filepath.Join(repo, "blobs"), filepath.Join(gpt, "blobs")} {
+		entries, _ := os.ReadDir(p)
+		for _, e := range entries {
+			touch(t, filepath.Join(p, e.Name()), now.Add(-24*time.Hour))
+		}
+	}
+	touch(t, filepath.Join(gpt, "blobs", "model"), now.Add(-100*24*time.Hour))
+
+	repos, err := Scan(cache)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resolved, _ := filepath.EvalSymlinks(cache)
+
+	plan := CleanupPlan(repos, PlanOptions{Now: now})
+	if len(plan.Items) != 1 {
+		t.Fatalf("plan = %+v", plan)
+	}
+	item := plan.Items[0]
+	wantPaths := []string{
+		filepath.Join(resolved, "models--acme--bert", "snapshots", "old"),
+		filepath.Join(resolved, "models--acme--bert", "blobs", "weights-a"),
+	}
+	if item.Commit != "old" || !reflect.DeepEqual(item.Paths, wantPaths) || item.Size != int64(len("weights-a-contents")) {
+		t.Fatalf("item = %+v", item)
+	}
+
+	plan = CleanupPlan(repos, PlanOptions{Now: now, UnusedFor: 30 * 24 * time.Hour})
+	if len(plan.Items) != 2 {
+		t.Fatalf("plan = %+v", plan)
+	}
+	whole := plan.Items[1]
+	if whole.ID != "gpt2" || whole.Commit != "" || whole.Reason != "not used for 100 days" ||
+		!reflect.DeepEqual(whole.Paths, []string{filepath.Join(resolved, "models--gpt2")}) {
+		t.Fatalf("item = %+v", whole)
+	}
+	if plan.Size != item.Size+whole.Size {
+		t.Fatalf("plan size = %d", plan.Size)
+	}
+}
+
+func TestParseRepoDir(t *testing.T) {
+	tests := []struct {
+		name, typ, id string
+		ok            bool
+	}{
+		{"models--org--name", TypeModel, "org/name", true},
+		{"models--gpt2", TypeModel, "gpt2", true},
+		{"datasets--org--data", TypeDataset, "org/data", true},
+		{"spaces--org--app", TypeSpace, "org/app", true},
+		{".locks", "", "", false},
+		{"version.txt", "", "", false},
+	}
+	for _, tt := range tests {
+		typ, id, ok := parseRepoDir(tt.name)
+		if typ != tt.typ || id != tt.id || ok != tt.ok {
+			t.Errorf("parseRepoDir(%q) = %q, %q, %v", tt.name, typ, id, ok)
+		}
+	}
+}
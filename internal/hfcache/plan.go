@@ -0,0 +1,117 @@
+package hfcache
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// PlanOptions selects the revisions a cleanup plan removes.
+type PlanOptions struct {
+	// UnusedFor also removes revisions that were not read for this long.
+	// Zero only removes detached revisions.
+	UnusedFor time.Duration
+	// Now is the reference time for UnusedFor (default time.Now()).
+	Now time.Time
+}
+
+// PlanItem is a revision, or a whole repo, that a cleanup plan removes.
+type PlanItem struct {
+	Type   string `json:"type"`
+	ID     string `json:"id"`
+	Commit string `json:"commit,omitempty"` // empty when the whole repo goes
+	Reason string `json:"reason"`
+	// Paths are the files and directories to delete: the snapshot, the refs.
+	// pointing to it and the blobs no kept revision uses.
+	Paths []string `json:"paths"`
+	// Size is the disk space freed.
+	Size int64 `json:"size"`
+}
+
+// Plan lists what to delete to clean up a cache. It never deletes anything.
+type Plan struct {
+	Items []PlanItem `json:"items"`
+	Size  int64      `json:"size"`
+}
+
+// CleanupPlan returns the plan that removes the detached revisions of repos.
+// and, with opts.UnusedFor, the revisions not read for that long. A repo.
+// whose revisions all go is removed as a whole.
+func CleanupPlan(repos []Repo, opts PlanOptions) Plan {
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	var plan Plan
+	for _, repo := range repos {
+		var removed, kept []Revision
+		reasons := make(map[string]string)
+		for _, rev := range repo.Revisions {
+			switch {
+			case rev.Detached():
+				reasons[rev.Commit] = "detached (no ref points to it)"
+			case opts.UnusedFor > 0 && now.Sub(rev.LastAccessed) > opts.UnusedFor:
+				reasons[rev.Commit] = fmt.Sprintf("not used for %d days", int(now.Sub(rev.LastAccessed).Hours()/24))
+			default:
+				kept = append(kept, rev)
+				continue
+			}
+			removed = append(removed, rev)
+		}
+		if len(removed) == 0 {
+			continue
+		}
+
+		if len(kept) == 0 {
+			reason := reasons[removed[0].Commit]
+			if len(removed) > 1 {
+				reason = "no revision in use"
+			}
+			plan.Items = append(plan.Items, PlanItem{
+				Type:   repo.Type,
+				ID:     repo.ID,
+				Reason: reason,
+				Paths:  []string{repo.Path},
+				Size:   repo.Size,
+			})
+			plan.Size += repo.Size
+			continue
+		}
+
+		inUse := make(map[string]bool)
+		for _, rev := range kept {
+			for p := range rev.blobs {
+				inUse[p] = true
+			}
+		}
+		freed := make(map[string]bool)
+		for _, rev := range removed {
+			item := PlanItem{
+				Type:   repo.Type,
+				ID:     repo.ID,
+				Commit: rev.Commit,
+				Reason: reasons[rev.Commit],
+				Paths:  []string{rev.Path},
+			}
+			for _, ref := range rev.Refs {
+				item.Paths = append(item.Paths, filepath.Join(repo.Path, "refs", filepath.FromSlash(ref)))
+			}
+			var blobs []string
+			for p, size := range rev.blobs {
+				if inUse[p] || freed[p] {
+					continue
+				}
+				freed[p] = true
+				blobs = append(blobs, p)
+				item.Size += size
+			}
+			sort.Strings(blobs)
+			item.Paths = append(item.Paths, blobs...)
+			plan.Items = append(plan.Items, item)
+			plan.Size += item.Size
+		}
+	}
+	return plan
+}
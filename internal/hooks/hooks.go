@@ -0,0 +1,222 @@
+// Package hooks runs user-configured commands after BOMs are written, so that.
+// publishing steps (uploading, signing, registering) do not require wrapping.
+// the CLI.
+//.
+// A hook is a command line such as "./upload.sh {{.File}}". It is split into.
+// arguments first (single and double quotes group words) and every argument.
+// is then rendered as a text/template with the Data of the written BOM. The.
+// command is executed directly, not through a shell, so values taken from.
+// scanned files cannot inject shell syntax. Each run is recorded with its.
+// exit code and combined output in a Manifest.
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// maxOutput caps the hook output kept in the manifest.
+const maxOutput = 64 << 10
+
+// Data is the template data of one written BOM.
+type Data struct {
+	// File is the path of the written BOM.
+	File string
+	// Name is the name of the BOM's main component.
+	Name string
+	// Version is the BOM version.
+	Version int
+	// SerialNumber is the BOM serial number.
+	SerialNumber string
+	// Format is the output format (json or xml).
+	Format string
+	// OutputDir is the directory the BOMs were written to.
+	OutputDir string
+}
+
+// Result records one hook run.
+type Result struct {
+	Command  []string `json:"command"`
+	ExitCode int      `json:"exitCode"`
+	Output   string   `json:"output,omitempty"`
+	Error    string   `json:"error,omitempty"`
+	Duration string   `json:"duration"`
+}
+
+// FileResult holds the hook runs for one written BOM.
+type FileResult struct {
+	File  string   `json:"file"`
+	Hooks []Result `json:"hooks"`
+}
+
+// Manifest records the hook runs of one invocation.
+type Manifest struct {
+	Stage string       `json:"stage"`
+	Files []FileResult `json:"files"`
+}
+
+// Failed returns the number of hook runs in the manifest that failed.
+func (m *Manifest) Failed() int {
+	n := 0
+	for _, f := range m.Files {
+		for _, r := range f.Hooks {
+			if r.ExitCode != 0 || r.Error != "" {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// Write stores the manifest as indented JSON at path.
+func (m *Manifest) Write(path string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// Run executes the hooks for every BOM in files, in order, and returns the.
+// manifest of the runs. A hook that fails does not stop the others; callers.
+// check Manifest.Failed. timeout limits each run (0: no limit). An error is.
+// returned only for hooks that cannot be parsed.
+func Run(ctx context.Context, stage string, commands []string, files []Data, timeout time.Duration) (*Manifest, error) {
+	parsed := make([][]*template.Template, 0, len(commands))
+	for _, c := range commands {
+		args, err := Split(c)
+		if err != nil {
+			return nil, fmt.Errorf("hook %q: %w", c, err)
+		}
+		if len(args) == 0 {
+			continue
+		}
+		tmpls := make([]*template.Template, len(args))
+		for i, a := range args {
+			t, err := template.New("hook").Option("missingkey=error").Parse(a)
+			if err != nil {
+				return nil, fmt.Errorf("hook %q: %w", c, err)
+			}
+			tmpls[i] = t
+		}
+		parsed = append(parsed, tmpls)
+	}
+
+	m := &Manifest{Stage: stage, Files: make([]FileResult, 0, len(files))}
+	for _, d := range files {
+		fr := FileResult{File: d.File, Hooks: make([]Result, 0, len(parsed))}
+		for _, tmpls := range parsed {
+			fr.Hooks = append(fr.Hooks, runOne(ctx, tmpls, d, timeout))
+		}
+		m.Files = append(m.Files, fr)
+	}
+	return m, nil
+}
+
+func runOne(ctx context.Context, tmpls []*template.Template, d Data, timeout time.Duration) Result {
+	args := make([]string, len(tmpls))
+	for i, t := range tmpls {
+		var b strings.Builder
+		if err := t.Execute(&b, d); err != nil {
+			return Result{Command: args[:i], ExitCode: -1, Error: err.Error()}
+		}
+		args[i] = b.String()
+	}
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+
+	start := time.Now()
+	err := cmd.Run()
+	r := Result{Command: args, Duration: time.Since(start).Round(time.Millisecond).String()}
+	r.Output = out.String()
+	if len(r.Output) > maxOutput {
+		r.Output = r.Output[:maxOutput] + "\n[output truncated]"
+	}
+
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+	case errors.As(err, &exitErr):
+		r.ExitCode = exitErr.ExitCode()
+		if ctx.Err() != nil {
+			r.Error = fmt.Sprintf("timed out after %s", timeout)
+		}
+	default:
+		r.ExitCode = -1
+		r.Error = err.Error()
+	}
+	return r
+}
+
+// Split splits a hook command line into arguments at unquoted whitespace.
+// Single quotes keep their content literally; inside double quotes a.
+// backslash escapes a double quote or backslash.
+func Split(s string) ([]string, error) {
+	var (
+		args    []string
+		cur     strings.Builder
+		inArg   bool
+		quote   rune
+		escaped bool
+	)
+	for _, r := range s {
+		switch {
+		case escaped:
+			if r != '"' && r != '\\' {
+				cur.WriteRune('\\')
+			}
+			cur.WriteRune(r)
+			escaped = false
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				cur.WriteRune(r)
+			}
+		case quote == '"':
+			switch r {
+			case '"':
+				quote = 0
+			case '\\':
+				escaped = true
+			default:
+				cur.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, cur.String())
+				cur.Reset()
+				inArg = false
+			}
+		default:
+			cur.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 || escaped {
+		return nil, errors.New("unterminated quote")
+	}
+	if inArg {
+		args = append(args, cur.String())
+	}
+	return args, nil
+}
@@ -0,0 +1,94 @@
+package hooks
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSplit(t *testing.T) {
+	cases := map[string][]string{
+		"./upload.sh {{.File}}":              {"./upload.sh", "{{.File}}"},
+		"  curl  -F 'file=@{{.File}}'  url ": {"curl", "-F", "file=@{{.File}}", "url"},
+		`sh -c "echo \"$1\" \x" _ {{.Name}}`: {"sh", "-c", `echo "$1" \x`, "_", "{{.Name}}"},
+		`a '' b`:                             {"a", "", "b"},
+		"":                                   nil,
+	}
+	for in, want := range cases {
+		got, err := Split(in)
+		if err != nil || !reflect.DeepEqual(got, want) {
+			t.Errorf("Split(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	for _, in := range []string{`a "b`, `a 'b`} {
+		if _, err := Split(in); err == nil {
+			t.Errorf("Split(%q): expected an error", in)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script hooks")
+	}
+	dir := t.TempDir()
+	script := filepath.Join(dir, "upload.sh")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\necho \"uploaded $1 as $2\"\n[ \"$2\" != bad ] || { echo denied >&2; exit 4; }\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	files := []Data{
+		{File: "out/a_aibom.json", Name: "org/a; rm -rf /", Format: "json"},
+		{File: "out/b_aibom.json", Name: "bad", Format: "json"},
+	}
+	m, err := Run(context.Background(), "post-generate", []string{script + " {{.File}} '{{.Name}}'"}, files, time.Minute)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(m.Files) != 2 || len(m.Files[0].Hooks) != 1 {
+		t.Fatalf("unexpected manifest: %+v", m)
+	}
+	first := m.Files[0].Hooks[0]
+	if first.ExitCode != 0 || first.Output != "uploaded out/a_aibom.json as org/a; rm -rf /\n" {
+		t.Errorf("first run = %+v", first)
+	}
+	second := m.Files[1].Hooks[0]
+	if second.ExitCode != 4 || !strings.Contains(second.Output, "denied") {
+		t.Errorf("second run = %+v", second)
+	}
+	if m.Failed() != 1 {
+		t.Errorf("Failed = %d, want 1", m.Failed())
+	}
+
+	path := filepath.Join(dir, "manifest.json")
+	if err := m.Write(path); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), `"exitCode": 4`) || !strings.Contains(string(data), `"stage": "post-generate"`) {
+		t.Errorf("manifest = %s", data)
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	if _, err := Run(context.Background(), "post-generate", []string{"x '{{.File}}"}, nil, 0); err == nil {
+		t.Error("expected an error for an unterminated quote")
+	}
+	if _, err := Run(context.Background(), "post-generate", []string{"x {{.File"}, nil, 0); err == nil {
+		t.Error("expected an error for a bad template")
+	}
+	m, err := Run(context.Background(), "post-generate", []string{"x {{.Missing}}", "/nonexistent/hook"}, []Data{{File: "a"}}, 0)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	for _, r := range m.Files[0].Hooks {
+		if r.Error == "" || r.ExitCode != -1 {
+			t.Errorf("expected a failed run, got %+v", r)
+		}
+	}
+}
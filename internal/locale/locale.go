@@ -0,0 +1,233 @@
+// Package locale formats the numbers, sizes and dates of reports for a.
+// reader's locale (--locale), so management-facing summaries show.
+// "1.234.567" and "18.10.2026" to a German reader instead of raw integers and.
+// ISO timestamps.
+//.
+// The zero Locale is the raw locale: integers without grouping, "." as the.
+// decimal separator and ISO dates, which is what reports print by default.
+// Machine-readable output (JSON) never goes through a Locale.
+package locale
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Locale holds the formatting conventions of one locale.
+type Locale struct {
+	tag string // BCP 47 tag as given (de-DE), "" for the raw locale
+
+	group    string // digit group separator
+	decimal  string // decimal separator
+	minGroup int    // minimum number of integer digits before grouping
+	pctSpace bool   // a no-break space before "%"
+	date     string // time layout of dates
+	clock    string // time layout of times of day
+}
+
+// Raw is the raw locale, the zero Locale.
+var Raw Locale
+
+// conventions per language, and per language and region where they differ.
+// Layouts with month names are only used for English.
+var conventions = map[string]Locale{
+	"en":    {group: ",", decimal: ".", date: "Jan 2, 2006", clock: "3:04 PM"},
+	"en-gb": {group: ",", decimal: ".", date: "2 Jan 2006", clock: "15:04"},
+	"en-ie": {group: ",", decimal: ".", date: "2 Jan 2006", clock: "15:04"},
+	"en-au": {group: ",", decimal: ".", date: "2 Jan 2006", clock: "3:04 PM"},
+	"de":    {group: ".", decimal: ",", pctSpace: true, date: "02.01.2006", clock: "15:04"},
+	"de-ch": {group: "’", decimal: ".", date: "02.01.2006", clock: "15:04"},
+	"fr":    {group: "\u202f", decimal: ",", pctSpace: true, date: "02/01/2006", clock: "15:04"},
+	"fr-ca": {group: "\u00a0", decimal: ",", pctSpace: true, date: "2006-01-02", clock: "15 h 04"},
+	"fr-ch": {group: "\u202f", decimal: ",", pctSpace: true, date: "02.01.2006", clock: "15:04"},
+	"nl":    {group: ".", decimal: ",", date: "02-01-2006", clock: "15:04"},
+	"es":    {group: ".", decimal: ",", minGroup: 5, pctSpace: true, date: "02/01/2006", clock: "15:04"},
+	"it":    {group: ".", decimal: ",", date: "02/01/2006", clock: "15:04"},
+	"pt":    {group: ".", decimal: ",", date: "02/01/2006", clock: "15:04"},
+	"pt-pt": {group: "\u00a0", decimal: ",", minGroup: 5, date: "02/01/2006", clock: "15:04"},
+	"sv":    {group: "\u00a0", decimal: ",", pctSpace: true, date: "2006-01-02", clock: "15:04"},
+	"da":    {group: ".", decimal: ",", pctSpace: true, date: "02.01.2006", clock: "15.04"},
+	"nb":    {group: "\u00a0", decimal: ",", pctSpace: true, date: "02.01.2006", clock: "15:04"},
+	"fi":    {group: "\u00a0", decimal: ",", pctSpace: true, date: "2.1.2006", clock: "15.04"},
+	"pl":    {group: "\u00a0", decimal: ",", minGroup: 5, pctSpace: true, date: "02.01.2006", clock: "15:04"},
+	"cs":    {group: "\u00a0", decimal: ",", pctSpace: true, date: "02.01.2006", clock: "15:04"},
+	"ja":    {group: ",", decimal: ".", date: "2006/01/02", clock: "15:04"},
+	"zh":    {group: ",", decimal: ".", date: "2006-01-02", clock: "15:04"},
+	"ko":    {group: ",", decimal: ".", date: "2006. 1. 2.", clock: "15:04"},
+}
+
+// aliases maps language codes to the code of conventions they share.
+var aliases = map[string]string{"no": "nb", "nn": "nb"}
+
+// Parse returns the locale named by tag: a BCP 47 tag (de-DE, en) or a POSIX.
+// locale name (de_DE.UTF-8). "" and the POSIX names C and POSIX are the raw.
+// locale; "auto" reads LC_ALL, LC_NUMERIC and LANG, in that order.
+func Parse(tag string) (Locale, error) {
+	tag = strings.TrimSpace(tag)
+	if strings.EqualFold(tag, "auto") {
+		return Parse(environment())
+	}
+	// Drop the encoding and modifier of POSIX names.
+	if i := strings.IndexAny(tag, ".@"); i >= 0 {
+		tag = tag[:i]
+	}
+	if tag == "" || tag == "C" || tag == "POSIX" {
+		return Raw, nil
+	}
+
+	parts := strings.Split(strings.ReplaceAll(tag, "_", "-"), "-")
+	lang := strings.ToLower(parts[0])
+	if a, ok := aliases[lang]; ok {
+		lang = a
+	}
+	norm := lang
+	region := ""
+	for _, p := range parts[1:] {
+		// The region is the two-letter or three-digit subtag; scripts and.
+		// variants do not change the conventions.
+		if len(p) == 2 || (len(p) == 3 && p[0] >= '0' && p[0] <= '9') {
+			region = strings.ToUpper(p)
+			break
+		}
+	}
+	l, ok := conventions[lang+"-"+strings.ToLower(region)]
+	if !ok {
+		l, ok = conventions[lang]
+	}
+	if !ok {
+		return Raw, fmt.Errorf("unsupported locale %q (supported languages: %s)", tag, strings.Join(Languages(), ", "))
+	}
+	if region != "" {
+		norm += "-" + region
+	}
+	l.tag = norm
+	return l, nil
+}
+
+// environment returns the locale of the process environment.
+func environment() string {
+	for _, name := range []string{"LC_ALL", "LC_NUMERIC", "LANG"} {
+		if v := strings.TrimSpace(os.Getenv(name)); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// Languages returns the language codes Parse supports, sorted.
+func Languages() []string {
+	seen := map[string]bool{}
+	for k := range conventions {
+		seen[strings.SplitN(k, "-", 2)[0]] = true
+	}
+	for k := range aliases {
+		seen[k] = true
+	}
+	out := make([]string, 0, len(seen))
+	for k := range seen {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Tag returns the normalized BCP 47 tag of l (de-DE), or "" for the raw.
+// locale.
+func (l Locale) Tag() string {
+	return l.tag
+}
+
+// IsRaw reports whether l is the raw locale.
+func (l Locale) IsRaw() bool {
+	return l.tag == ""
+}
+
+// Int formats n with digit grouping.
+func (l Locale) Int(n int64) string {
+	s := strconv.FormatInt(n, 10)
+	if l.IsRaw() {
+		return s
+	}
+	sign := ""
+	if n < 0 {
+		sign, s = "-", s[1:]
+	}
+	return sign + l.groupDigits(s)
+}
+
+// Decimal formats f with prec digits after the decimal separator.
+func (l Locale) Decimal(f float64, prec int) string {
+	s := strconv.FormatFloat(f, 'f', prec, 64)
+	if l.IsRaw() {
+		return s
+	}
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	whole, frac, _ := strings.Cut(s, ".")
+	out := sign + l.groupDigits(whole)
+	if frac != "" {
+		out += l.decimal + frac
+	}
+	return out
+}
+
+// Percent formats the fraction f (0.739) as a percentage with prec digits.
+// after the decimal separator (73.9%).
+func (l Locale) Percent(f float64, prec int) string {
+	if l.pctSpace {
+		return l.Decimal(f*100, prec) + "\u00a0%"
+	}
+	return l.Decimal(f*100, prec) + "%"
+}
+
+// Bytes formats a size in bytes with a binary unit (1.5 GiB).
+func (l Locale) Bytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return l.Int(n) + " B"
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%s %ciB", l.Decimal(float64(n)/float64(div), 1), "KMGTPE"[exp])
+}
+
+// Date formats the date of t (2026-10-18 in the raw locale).
+func (l Locale) Date(t time.Time) string {
+	if l.IsRaw() {
+		return t.Format("2006-01-02")
+	}
+	return t.Format(l.date)
+}
+
+// DateTime formats the date and time of day of t, to the minute.
+// (2026-10-18 09:12 in the raw locale).
+func (l Locale) DateTime(t time.Time) string {
+	if l.IsRaw() {
+		return t.Format("2006-01-02 15:04")
+	}
+	return t.Format(l.date + " " + l.clock)
+}
+
+// groupDigits inserts the group separator into a string of digits.
+func (l Locale) groupDigits(digits string) string {
+	if len(digits) <= 3 || len(digits) < l.minGroup {
+		return digits
+	}
+	var b strings.Builder
+	for i, r := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteString(l.group)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,101 @@
+package locale
+
+import (
+	"testing"
+	"time"
+)
+
+func mustParse(t *testing.T, tag string) Locale {
+	t.Helper()
+	l, err := Parse(tag)
+	if err != nil {
+		t.Fatalf("Parse(%q): %v", tag, err)
+	}
+	return l
+}
+
+func TestParse(t *testing.T) {
+	for tag, want := range map[string]string{
+		"":            "",
+		"C":           "",
+		"POSIX":       "",
+		"en":          "en",
+		"de-de":       "de-DE",
+		"de_CH.UTF-8": "de-CH",
+		"zh-Hans-CN":  "zh-CN",
+		"es-419":      "es-419",
+		"no":          "nb",
+	} {
+		if got := mustParse(t, tag).Tag(); got != want {
+			t.Errorf("Parse(%q).Tag() = %q, want %q", tag, got, want)
+		}
+	}
+	if _, err := Parse("xx-YY"); err == nil {
+		t.Error("Parse(xx-YY) succeeded, want an error")
+	}
+
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_NUMERIC", "fr_FR.UTF-8")
+	t.Setenv("LANG", "en_US.UTF-8")
+	if got := mustParse(t, "auto").Tag(); got != "fr-FR" {
+		t.Errorf("Parse(auto).Tag() = %q, want fr-FR", got)
+	}
+}
+
+func TestNumbers(t *testing.T) {
+	tests := []struct {
+		tag     string
+		int     string
+		small   string
+		decimal string
+		percent string
+		bytes   string
+	}{
+		{"", "1234567", "1234", "-1234.57", "73.9%", "1.5 GiB"},
+		{"en-US", "1,234,567", "1,234", "-1,234.57", "73.9%", "1.5 GiB"},
+		{"de-DE", "1.234.567", "1.234", "-1.234,57", "73,9\u00a0%", "1,5 GiB"},
+		{"de-CH", "1’234’567", "1’234", "-1’234.57", "73.9%", "1.5 GiB"},
+		{"fr-FR", "1\u202f234\u202f567", "1\u202f234", "-1\u202f234,57", "73,9\u00a0%", "1,5 GiB"},
+		{"es-ES", "1.234.567", "1234", "-1234,57", "73,9\u00a0%", "1,5 GiB"},
+	}
+	for _, tt := range tests {
+		l := mustParse(t, tt.tag)
+		if got := l.Int(1234567); got != tt.int {
+			t.Errorf("%q Int = %q, want %q", tt.tag, got, tt.int)
+		}
+		if got := l.Int(1234); got != tt.small {
+			t.Errorf("%q Int(1234) = %q, want %q", tt.tag, got, tt.small)
+		}
+		if got := l.Decimal(-1234.567, 2); got != tt.decimal {
+			t.Errorf("%q Decimal = %q, want %q", tt.tag, got, tt.decimal)
+		}
+		if got := l.Percent(0.739, 1); got != tt.percent {
+			t.Errorf("%q Percent = %q, want %q", tt.tag, got, tt.percent)
+		}
+		if got := l.Bytes(3 << 29); got != tt.bytes {
+			t.Errorf("%q Bytes = %q, want %q", tt.tag, got, tt.bytes)
+		}
+	}
+	if got := Raw.Bytes(512); got != "512 B" {
+		t.Errorf("Raw.Bytes(512) = %q", got)
+	}
+}
+
+func TestDates(t *testing.T) {
+	at := time.Date(2026, 10, 8, 14, 5, 0, 0, time.UTC)
+	for tag, want := range map[string]string{
+		"":      "2026-10-08 14:05",
+		"en-US": "Oct 8, 2026 2:05 PM",
+		"en-GB": "8 Oct 2026 14:05",
+		"de-DE": "08.10.2026 14:05",
+		"nl-BE": "08-10-2026 14:05",
+		"ja-JP": "2026/10/08 14:05",
+	} {
+		if got := mustParse(t, tag).DateTime(at); got != want {
+			t.Errorf("%q DateTime = %q, want %q", tag, got, want)
+		}
+	}
+	if got := mustParse(t, "fr").Date(at); got != "08/10/2026" {
+		t.Errorf("fr Date = %q", got)
+	}
+}
@@ -37,6 +37,15 @@ func ApplyUserValue(spec FieldSpec, value string, tgt Target) error {
 	return spec.Apply(tgt, applyInput{Value: parsed, Force: true})
 }
 
+// ClearUserValue removes a previously applied value using spec.Clear, so that.
+// spec.Present reports the field as missing again.
+func ClearUserValue(spec FieldSpec, tgt Target) error {
+	if spec.Clear == nil {
+		return fmt.Errorf("field %s cannot be cleared", spec.Key)
+	}
+	return spec.Clear(tgt)
+}
+
 // ApplyDatasetFromSources applies the first available dataset source value.
 func ApplyDatasetFromSources(spec DatasetFieldSpec, src DatasetSource, tgt DatasetTarget) {
 	if spec.Apply == nil || len(spec.Sources) == 0 {
@@ -66,3 +75,11 @@ func ApplyDatasetUserValue(spec DatasetFieldSpec, value string, tgt DatasetTarge
 	}
 	return spec.Apply(tgt, applyInput{Value: parsed, Force: true})
 }
+
+// ClearDatasetUserValue removes a previously applied dataset value.
+func ClearDatasetUserValue(spec DatasetFieldSpec, tgt DatasetTarget) error {
+	if spec.Clear == nil {
+		return fmt.Errorf("field %s cannot be cleared", spec.Key)
+	}
+	return spec.Clear(tgt)
+}
@@ -0,0 +1,101 @@
+package metadata
+
+import (
+	"strings"
+
+	"github.com/idlab-discover/aibomgen-cli/internal/taxonomy"
+)
+
+// Model classifiers stored in the aibomgen:classifier property.
+const (
+	ClassifierLLM                   = "llm"
+	ClassifierDiffusion             = "diffusion"
+	ClassifierEmbedding             = "embedding"
+	ClassifierVision                = "vision"
+	ClassifierSpeech                = "speech"
+	ClassifierNLP                   = "nlp"
+	ClassifierMultimodal            = "multimodal"
+	ClassifierTabular               = "tabular"
+	ClassifierReinforcementLearning = "reinforcement-learning"
+)
+
+// classifierProperty is the component property holding the model classifier.
+const classifierProperty = taxonomy.Classifier
+
+// knownClassifiers lists the classifiers offered in interactive forms.
+var knownClassifiers = []string{
+	ClassifierLLM, ClassifierDiffusion, ClassifierEmbedding, ClassifierVision, ClassifierSpeech,
+	ClassifierNLP, ClassifierMultimodal, ClassifierTabular, ClassifierReinforcementLearning,
+}
+
+// pipelineClassifiers maps Hugging Face pipeline tags to model classifiers.
+var pipelineClassifiers = map[string]string{
+	"text-generation":                ClassifierLLM,
+	"text2text-generation":           ClassifierLLM,
+	"conversational":                 ClassifierLLM,
+	"text-to-image":                  ClassifierDiffusion,
+	"image-to-image":                 ClassifierDiffusion,
+	"unconditional-image-generation": ClassifierDiffusion,
+	"text-to-video":                  ClassifierDiffusion,
+	"image-to-video":                 ClassifierDiffusion,
+	"feature-extraction":             ClassifierEmbedding,
+	"sentence-similarity":            ClassifierEmbedding,
+	"image-feature-extraction":       ClassifierEmbedding,
+	"image-classification":           ClassifierVision,
+	"object-detection":               ClassifierVision,
+	"image-segmentation":             ClassifierVision,
+	"depth-estimation":               ClassifierVision,
+	"zero-shot-image-classification": ClassifierVision,
+	"zero-shot-object-detection":     ClassifierVision,
+	"mask-generation":                ClassifierVision,
+	"keypoint-detection":             ClassifierVision,
+	"automatic-speech-recognition":   ClassifierSpeech,
+	"text-to-speech":                 ClassifierSpeech,
+	"text-to-audio":                  ClassifierSpeech,
+	"audio-classification":           ClassifierSpeech,
+	"audio-to-audio":                 ClassifierSpeech,
+	"voice-activity-detection":       ClassifierSpeech,
+	"text-classification":            ClassifierNLP,
+	"token-classification":           ClassifierNLP,
+	"question-answering":             ClassifierNLP,
+	"fill-mask":                      ClassifierNLP,
+	"zero-shot-classification":       ClassifierNLP,
+	"summarization":                  ClassifierNLP,
+	"translation":                    ClassifierNLP,
+	"table-question-answering":       ClassifierNLP,
+	"image-text-to-text":             ClassifierMultimodal,
+	"image-to-text":                  ClassifierMultimodal,
+	"visual-question-answering":      ClassifierMultimodal,
+	"document-question-answering":    ClassifierMultimodal,
+	"video-text-to-text":             ClassifierMultimodal,
+	"any-to-any":                     ClassifierMultimodal,
+	"tabular-classification":         ClassifierTabular,
+	"tabular-regression":             ClassifierTabular,
+	"time-series-forecasting":        ClassifierTabular,
+	"reinforcement-learning":         ClassifierReinforcementLearning,
+	"robotics":                       ClassifierReinforcementLearning,
+}
+
+// libraryClassifiers maps libraries that only serve one kind of model.
+var libraryClassifiers = map[string]string{
+	"diffusers":             ClassifierDiffusion,
+	"sentence-transformers": ClassifierEmbedding,
+	"timm":                  ClassifierVision,
+	"ultralytics":           ClassifierVision,
+	"gguf":                  ClassifierLLM,
+	"espnet":                ClassifierSpeech,
+	"nemo":                  ClassifierSpeech,
+	"stable-baselines3":     ClassifierReinforcementLearning,
+	"ml-agents":             ClassifierReinforcementLearning,
+}
+
+// ClassifyModel derives a model classifier from a Hugging Face pipeline tag.
+// and library name. Libraries that only serve one kind of model win over the.
+// pipeline tag (a diffusers model is a diffusion model whatever its task).
+// It returns "" when neither is recognized.
+func ClassifyModel(pipelineTag, libraryName string) string {
+	if c, ok := libraryClassifiers[strings.ToLower(strings.TrimSpace(libraryName))]; ok {
+		return c
+	}
+	return pipelineClassifiers[strings.ToLower(strings.TrimSpace(pipelineTag))]
+}
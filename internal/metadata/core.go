@@ -1,7 +1,10 @@
 package metadata
 
 import (
+	"strings"
+
 	"github.com/idlab-discover/aibomgen-cli/internal/fetcher"
+	"github.com/idlab-discover/aibomgen-cli/internal/taxonomy"
 	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/scanner"
 
 	cdx "github.com/CycloneDX/cyclonedx-go"
@@ -18,10 +21,14 @@ const (
 	ComponentExternalReferences Key = "BOM.metadata.component.externalReferences"
 	ComponentTags               Key = "BOM.metadata.component.tags"
 	ComponentLicenses           Key = "BOM.metadata.component.licenses"
+	ComponentLicenseText        Key = "BOM.metadata.component.licenses.text"
 	ComponentHashes             Key = "BOM.metadata.component.hashes"
 	ComponentManufacturer       Key = "BOM.metadata.component.manufacturer"
 	ComponentGroup              Key = "BOM.metadata.component.group"
 
+	// Kind of model (llm, diffusion, embedding, ...), stored as a component property.
+	ComponentPropertiesModelClassifier Key = "BOM.metadata.component.properties.aibomgen:classifier"
+
 	// Component-level extra properties (stored later as CycloneDX Component.Properties).
 	ComponentPropertiesHuggingFaceLastModified Key = "BOM.metadata.component.properties.huggingface:lastModified"
 	ComponentPropertiesHuggingFaceCreatedAt    Key = "BOM.metadata.component.properties.huggingface:createdAt"
@@ -39,10 +46,15 @@ const (
 	ModelCardModelParametersArchitectureFamily                   Key = "BOM.metadata.component.modelCard.modelParameters.architectureFamily"
 	ModelCardModelParametersModelArchitecture                    Key = "BOM.metadata.component.modelCard.modelParameters.modelArchitecture"
 	ModelCardModelParametersDatasets                             Key = "BOM.metadata.component.modelCard.modelParameters.datasets"
+	ModelCardModelParametersNumberOfParameters                   Key = "BOM.metadata.component.modelCard.modelParameters.numberOfParameters"
+	ModelCardModelParametersInputs                               Key = "BOM.metadata.component.modelCard.modelParameters.inputs"
+	ModelCardModelParametersOutputs                              Key = "BOM.metadata.component.modelCard.modelParameters.outputs"
 	ModelCardConsiderationsUseCases                              Key = "BOM.metadata.component.modelCard.considerations.useCases"
+	ModelCardConsiderationsUsers                                 Key = "BOM.metadata.component.modelCard.considerations.users"
 	ModelCardConsiderationsTechnicalLimitations                  Key = "BOM.metadata.component.modelCard.considerations.technicalLimitations"
 	ModelCardConsiderationsEthicalConsiderations                 Key = "BOM.metadata.component.modelCard.considerations.ethicalConsiderations"
 	ModelCardQuantitativeAnalysisPerformanceMetrics              Key = "BOM.metadata.component.modelCard.quantitativeAnalysis.performanceMetrics"
+	ModelCardQuantitativeAnalysisFairnessMetrics                 Key = "BOM.metadata.component.modelCard.quantitativeAnalysis.performanceMetrics.slice"
 	ModelCardConsiderationsEnvironmentalConsiderationsProperties Key = "BOM.metadata.component.modelCard.considerations.environmentalConsiderations.properties"
 
 	// Security scan summary stored as Component.Properties.
@@ -52,6 +64,38 @@ const (
 	ComponentPropertiesSecurityCautionFiles  Key = "BOM.metadata.component.properties.huggingface:security:cautionFileCount"
 )
 
+// propertyNames maps the property field keys to the taxonomy property they.
+// are stored in. The keys keep their original spelling because they are also.
+// the names of enrich answers and enrichment config entries.
+var propertyNames = map[Key]string{
+	ComponentPropertiesModelClassifier:         taxonomy.Classifier,
+	ComponentPropertiesHuggingFaceLastModified: taxonomy.HFLastModified,
+	ComponentPropertiesHuggingFaceCreatedAt:    taxonomy.HFCreatedAt,
+	ComponentPropertiesHuggingFaceLanguage:     taxonomy.HFLanguage,
+	ComponentPropertiesHuggingFaceUsedStorage:  taxonomy.HFUsedStorage,
+	ComponentPropertiesHuggingFacePrivate:      taxonomy.HFPrivate,
+	ComponentPropertiesHuggingFaceLibraryName:  taxonomy.HFLibraryName,
+	ComponentPropertiesHuggingFaceDownloads:    taxonomy.HFDownloads,
+	ComponentPropertiesHuggingFaceLikes:        taxonomy.HFLikes,
+	ComponentPropertiesHuggingFaceBaseModel:    taxonomy.HFBaseModel,
+	ComponentPropertiesHuggingFaceContact:      taxonomy.HFModelCardContact,
+	ComponentPropertiesSecurityOverallStatus:   taxonomy.HFSecurityOverallStatus,
+	ComponentPropertiesSecurityScannedFiles:    taxonomy.HFSecurityScannedFileCount,
+	ComponentPropertiesSecurityUnsafeFiles:     taxonomy.HFSecurityUnsafeFileCount,
+	ComponentPropertiesSecurityCautionFiles:    taxonomy.HFSecurityCautionFileCount,
+	// CycloneDX model parameters have no parameter count; it is stored in.
+	// the weights property.
+	ModelCardModelParametersNumberOfParameters: taxonomy.WeightsParameterCount,
+}
+
+// propertyName returns the taxonomy property a property field key is stored in.
+func propertyName(key Key) string {
+	if name, ok := propertyNames[key]; ok {
+		return name
+	}
+	return strings.TrimPrefix(key.String(), "BOM.metadata.component.properties.")
+}
+
 // DatasetKey identifies dataset-specific CycloneDX fields.
 type DatasetKey string
 
@@ -85,6 +129,19 @@ type Source struct {
 	HF           *fetcher.ModelAPIResponse
 	Readme       *fetcher.ModelReadmeCard
 	SecurityTree []fetcher.SecurityFileEntry
+	LicenseFile  *fetcher.LicenseFile
+	// Config is the config.json of a Hugging Face model.
+	Config *fetcher.ModelConfig
+	// Kaggle is set instead of HF for "kaggle" model discoveries.
+	Kaggle *fetcher.KaggleModel
+	// Ollama is set instead of HF for Ollama "provider-model" discoveries.
+	Ollama *fetcher.OllamaModel
+	// GGUF is the header of a local GGUF weight file.
+	GGUF *fetcher.GGUFHeader
+	// ONNX is the model metadata of a local ONNX file.
+	ONNX *fetcher.ONNXModel
+	// Safetensors is the header of a local safetensors weight file.
+	Safetensors *fetcher.SafetensorsHeader
 }
 
 // Target is everything FieldSpecs are allowed to mutate.
@@ -104,6 +161,8 @@ type DatasetSource struct {
 	Scan      scanner.Discovery
 	HF        *fetcher.DatasetAPIResponse
 	Readme    *fetcher.DatasetReadmeCard
+	// Kaggle is set instead of HF for "kaggle" dataset discoveries.
+	Kaggle *fetcher.KaggleDataset
 }
 
 // DatasetTarget is the dataset component being built.
@@ -125,26 +184,60 @@ const (
 	InputTypeMultiText InputType = "multitext" // Comma-separated values
 )
 
+// CycloneDXDocsBaseURL is the JSON reference that FieldHelp.DocsURL points into.
+const CycloneDXDocsBaseURL = "https://cyclonedx.org/docs/1.6/json/"
+
+// Completeness categories.
+const (
+	CategoryResponsibleAI = "responsible AI"
+)
+
+// FieldHelp documents a field for interactive forms and validation output.
+type FieldHelp struct {
+	Description string // what the field holds, in one sentence
+	Example     string // a typical value, in the format Parse accepts
+	SpecPath    string // CycloneDX JSON path, e.g. "metadata.component.licenses"
+}
+
+// DocsURL returns the CycloneDX reference documentation for the field, or "".
+// when no SpecPath is known.
+func (h FieldHelp) DocsURL() string {
+	if h.SpecPath == "" {
+		return ""
+	}
+	anchor := strings.ReplaceAll(h.SpecPath, "[]", "_items")
+	anchor = strings.ReplaceAll(anchor, ".", "_")
+	return CycloneDXDocsBaseURL + "#" + anchor
+}
+
 // FieldSpec is a first-class definition of a field:.
 // - how it contributes to completeness.
 // - how it is populated into the BOM.
 // - how its presence is detected.
 // - how user-provided values are set.
+// - how a previously set value is removed again.
 // - how it should be presented in interactive forms.
+// - how it is documented for users.
 type FieldSpec struct {
 	Key      Key
 	Weight   float64
 	Required bool
+	// Category groups the field in a per-category completeness score (see.
+	// the Category* constants). Every field also counts towards the overall.
+	// score.
+	Category string
 
 	Sources []func(Source) (any, bool)
 	Parse   func(string) (any, error)
 	Apply   func(Target, any) error
 	Present func(*cdx.BOM) bool
+	Clear   func(Target) error
 
 	// UI metadata for interactive enrichment.
 	InputType   InputType
 	Placeholder string
 	Suggestions []string
+	Help        FieldHelp
 }
 
 // DatasetFieldSpec is the dataset analog of FieldSpec.
@@ -157,11 +250,13 @@ type DatasetFieldSpec struct {
 	Parse   func(string) (any, error)
 	Apply   func(DatasetTarget, any) error
 	Present func(comp *cdx.Component) bool
+	Clear   func(DatasetTarget) error
 
 	// UI metadata for interactive enrichment.
 	InputType   InputType
 	Placeholder string
 	Suggestions []string
+	Help        FieldHelp
 }
 
 // Registry is the central registry of all known FieldSpecs.
@@ -174,6 +269,31 @@ func Registry() []FieldSpec {
 	specs = append(specs, evidenceFields()...)
 	specs = append(specs, hfPropFields()...)
 	specs = append(specs, modelCardFields()...)
+	specs = append(specs, modelSizeFields()...)
+	specs = append(specs, fairnessFields()...)
+	specs = append(specs, aiActFields()...)
 	specs = append(specs, securityFields()...)
+	specs = append(specs, kaggleFields()...)
+	specs = append(specs, ollamaFields()...)
+	specs = append(specs, weightsFields()...)
+	specs = append(specs, onnxFields()...)
+	specs = append(specs, attributionFields()...)
+	specs = append(specs, contactFields()...)
 	return specs
 }
+
+// LookupHelp returns the documentation of a model (Key) or dataset.
+// (DatasetKey) field.
+func LookupHelp(key string) (FieldHelp, bool) {
+	for _, spec := range Registry() {
+		if string(spec.Key) == key {
+			return spec.Help, spec.Help.Description != ""
+		}
+	}
+	for _, spec := range DatasetRegistry() {
+		if string(spec.Key) == key {
+			return spec.Help, spec.Help.Description != ""
+		}
+	}
+	return FieldHelp{}, false
+}
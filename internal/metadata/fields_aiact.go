@@ -0,0 +1,122 @@
+package metadata
+
+import (
+	"fmt"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/internal/fetcher"
+)
+
+// EU AI Act fields. The intended users go into the users of the model card.
+// considerations, the deployment context, human oversight measures and risk.
+// class into aibomgen:aiact:* properties. Oversight and risk class count.
+// towards the responsible AI category of the completeness score.
+const (
+	ComponentPropertiesAIActDeploymentContext  Key = "BOM.metadata.component.properties.aibomgen:aiact:deploymentContext"
+	ComponentPropertiesAIActHumanOversight     Key = "BOM.metadata.component.properties.aibomgen:aiact:humanOversight"
+	ComponentPropertiesAIActRiskClassification Key = "BOM.metadata.component.properties.aibomgen:aiact:riskClassification"
+)
+
+func aiActFields() []FieldSpec {
+	deployment := hfProp(ComponentPropertiesAIActDeploymentContext, 0.25, FieldHelp{Description: "Setting the model is deployed in: sector, product and who operates it.", Example: "Triage assistant in hospital emergency departments, operated by nursing staff"}, func(src Source) (any, bool) {
+		if src.Readme == nil {
+			return nil, false
+		}
+		s := strings.TrimSpace(src.Readme.DeploymentContext)
+		return s, s != ""
+	})
+	deployment.InputType = InputTypeTextArea
+
+	oversight := hfProp(ComponentPropertiesAIActHumanOversight, 0.5, FieldHelp{Description: "Human oversight measures required when operating the model (EU AI Act, Article 14).", Example: "A clinician reviews every suggestion before it reaches a patient record"}, func(src Source) (any, bool) {
+		if src.Readme == nil {
+			return nil, false
+		}
+		s := strings.TrimSpace(src.Readme.HumanOversight)
+		return s, s != ""
+	})
+	oversight.Category = CategoryResponsibleAI
+	oversight.InputType = InputTypeTextArea
+
+	risk := hfProp(ComponentPropertiesAIActRiskClassification, 0.5, FieldHelp{Description: "EU AI Act risk class of the intended use: prohibited, high, limited or minimal.", Example: "high"}, func(src Source) (any, bool) {
+		if src.Readme == nil {
+			return nil, false
+		}
+		s := src.Readme.RiskClassification
+		return s, s != ""
+	})
+	risk.Category = CategoryResponsibleAI
+	risk.Parse = parseRiskClassification
+	risk.InputType = InputTypeSelect
+	risk.Placeholder = "Select a risk class"
+	risk.Suggestions = fetcher.RiskClassifications
+
+	return []FieldSpec{
+		{
+			Key:      ModelCardConsiderationsUsers,
+			Weight:   0.5,
+			Required: false,
+			Sources: []func(Source) (any, bool){
+				func(src Source) (any, bool) {
+					if src.Readme == nil {
+						return nil, false
+					}
+					users := normalizeStrings(src.Readme.IntendedUsers)
+					return users, len(users) > 0
+				},
+			},
+			Parse: func(value string) (any, error) {
+				return parseCommaList(value, "users")
+			},
+			Apply: func(tgt Target, value any) error {
+				input, ok := value.(applyInput)
+				if !ok {
+					return fmt.Errorf("invalid input for %s", ModelCardConsiderationsUsers)
+				}
+				if tgt.ModelCard == nil {
+					return fmt.Errorf("modelCard is nil")
+				}
+				users, _ := input.Value.([]string)
+				if len(users) == 0 {
+					return fmt.Errorf("users value is empty")
+				}
+				if !input.Force && tgt.ModelCard.Considerations != nil && tgt.ModelCard.Considerations.Users != nil && len(*tgt.ModelCard.Considerations.Users) > 0 {
+					return nil
+				}
+				cons := ensureConsiderations(tgt.ModelCard)
+				cons.Users = &users
+				return nil
+			},
+			Present: func(b *cdx.BOM) bool {
+				c := bomComponent(b)
+				ok := c != nil && c.ModelCard != nil && c.ModelCard.Considerations != nil && c.ModelCard.Considerations.Users != nil && len(*c.ModelCard.Considerations.Users) > 0
+				return ok
+			},
+			Clear: func(tgt Target) error {
+				if cons := targetConsiderations(tgt); cons != nil {
+					cons.Users = nil
+				}
+				return nil
+			},
+			InputType:   InputTypeMultiText,
+			Placeholder: "user group 1, user group 2",
+			Help: FieldHelp{
+				Description: "People or organisations the model is intended to be used by.",
+				Example:     "Clinicians, Hospital IT departments",
+				SpecPath:    "metadata.component.modelCard.considerations.users",
+			},
+		},
+		deployment,
+		oversight,
+		risk,
+	}
+}
+
+// parseRiskClassification accepts one of the EU AI Act risk classes, also.
+// written as "high-risk" or "unacceptable".
+func parseRiskClassification(value string) (any, error) {
+	if class := fetcher.ParseRiskClassification(strings.TrimSpace(value) + " risk"); class != "" {
+		return class, nil
+	}
+	return nil, fmt.Errorf("risk classification %q: want one of %s", value, strings.Join(fetcher.RiskClassifications, ", "))
+}
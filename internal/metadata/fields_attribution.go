@@ -0,0 +1,216 @@
+package metadata
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/internal/fetcher"
+	"github.com/idlab-discover/aibomgen-cli/internal/taxonomy"
+)
+
+// Attribution fields. The copyright notices of the license file or card go.
+// into the CycloneDX copyright field, the holders they name and the.
+// attribution the card requires into aibomgen:attribution:* properties. They.
+// do not count towards completeness.
+const (
+	ComponentCopyright                      Key = "BOM.metadata.component.copyright"
+	ComponentPropertiesAttributionHolders   Key = "BOM.metadata.component.properties.aibomgen:attribution:holders"
+	ComponentPropertiesAttributionStatement Key = "BOM.metadata.component.properties.aibomgen:attribution:statement"
+
+	DatasetCopyright            DatasetKey = "BOM.components[DATA].copyright"
+	DatasetAttributionHolders   DatasetKey = "BOM.components[DATA].properties.aibomgen:attribution:holders"
+	DatasetAttributionStatement DatasetKey = "BOM.components[DATA].properties.aibomgen:attribution:statement"
+)
+
+// attributionLicenseRe matches the licenses that require attribution text:.
+// the Creative Commons Attribution family (CC-BY, CC-BY-SA, CC-BY-NC, ...).
+// and the Open Data Commons Attribution License.
+var attributionLicenseRe = regexp.MustCompile(`(?i)\bcc[-_ ]by\b|\bodc[-_ ]by\b|\bcreative\s+commons\s+attribution\b|\bopen\s+data\s+commons\s+attribution\b`)
+
+// modelCopyright returns the copyright notices of a model: those of its.
+// license file, else those of its model card.
+func modelCopyright(src Source) []string {
+	if src.LicenseFile != nil && len(src.LicenseFile.Copyright) > 0 {
+		return src.LicenseFile.Copyright
+	}
+	if src.Readme != nil {
+		return src.Readme.Copyright
+	}
+	return nil
+}
+
+func attributionFields() []FieldSpec {
+	return []FieldSpec{
+		{
+			Key:      ComponentCopyright,
+			Weight:   0,
+			Required: false,
+			Sources: []func(Source) (any, bool){
+				func(src Source) (any, bool) {
+					s := strings.Join(modelCopyright(src), "\n")
+					return s, s != ""
+				},
+			},
+			Parse: func(value string) (any, error) {
+				return parseNonEmptyString(value, "copyright")
+			},
+			Apply: func(tgt Target, value any) error {
+				input, ok := value.(applyInput)
+				if !ok {
+					return fmt.Errorf("invalid input for %s", ComponentCopyright)
+				}
+				s, _ := input.Value.(string)
+				s = strings.TrimSpace(s)
+				if s == "" {
+					return fmt.Errorf("copyright value is empty")
+				}
+				if tgt.Component == nil {
+					return fmt.Errorf("component is nil")
+				}
+				if !input.Force && strings.TrimSpace(tgt.Component.Copyright) != "" {
+					return nil
+				}
+				tgt.Component.Copyright = s
+				return nil
+			},
+			Present: func(b *cdx.BOM) bool {
+				c := bomComponent(b)
+				return c != nil && strings.TrimSpace(c.Copyright) != ""
+			},
+			Clear: func(tgt Target) error {
+				if tgt.Component == nil {
+					return nil
+				}
+				tgt.Component.Copyright = ""
+				return nil
+			},
+			InputType:   InputTypeText,
+			Placeholder: "Copyright 2024 Example Org",
+			Help: FieldHelp{
+				Description: "Copyright notices of the model, from its license file or model card.",
+				Example:     "Copyright (c) Meta Platforms, Inc. All Rights Reserved.",
+				SpecPath:    "metadata.component.copyright",
+			},
+		},
+		hfProp(ComponentPropertiesAttributionHolders, 0, FieldHelp{Description: "Copyright holders named by the copyright notices of the model, semicolon-separated.", Example: "Meta Platforms, Inc."}, func(src Source) (any, bool) {
+			s := strings.Join(fetcher.CopyrightHolders(modelCopyright(src)), "; ")
+			return s, s != ""
+		}),
+		hfProp(ComponentPropertiesAttributionStatement, 0, FieldHelp{Description: "Attribution the authors of the model require from its users.", Example: "Built with Llama"}, func(src Source) (any, bool) {
+			if src.Readme == nil {
+				return nil, false
+			}
+			s := strings.TrimSpace(src.Readme.Attribution)
+			return s, s != ""
+		}),
+	}
+}
+
+func attributionDatasetFields() []DatasetFieldSpec {
+	return []DatasetFieldSpec{
+		{
+			Key:      DatasetCopyright,
+			Weight:   0,
+			Required: false,
+			Sources: []func(DatasetSource) (any, bool){
+				func(src DatasetSource) (any, bool) {
+					if src.Readme == nil {
+						return nil, false
+					}
+					s := strings.Join(src.Readme.Copyright, "\n")
+					return s, s != ""
+				},
+			},
+			Parse: func(value string) (any, error) {
+				return parseNonEmptyString(value, "copyright")
+			},
+			Apply: func(tgt DatasetTarget, value any) error {
+				input, ok := value.(applyInput)
+				if !ok {
+					return fmt.Errorf("invalid input for %s", DatasetCopyright)
+				}
+				s, _ := input.Value.(string)
+				s = strings.TrimSpace(s)
+				if s == "" {
+					return fmt.Errorf("copyright value is empty")
+				}
+				if tgt.Component == nil {
+					return fmt.Errorf("component is nil")
+				}
+				if !input.Force && strings.TrimSpace(tgt.Component.Copyright) != "" {
+					return nil
+				}
+				tgt.Component.Copyright = s
+				return nil
+			},
+			Present: func(comp *cdx.Component) bool {
+				return comp != nil && strings.TrimSpace(comp.Copyright) != ""
+			},
+			Clear: func(tgt DatasetTarget) error {
+				if tgt.Component == nil {
+					return nil
+				}
+				tgt.Component.Copyright = ""
+				return nil
+			},
+			InputType:   InputTypeText,
+			Placeholder: "Copyright 2024 Example Org",
+			Help: FieldHelp{
+				Description: "Copyright notices of the dataset, from its dataset card.",
+				Example:     "Copyright 2019 The Allen Institute for AI",
+				SpecPath:    "components[].copyright",
+			},
+		},
+		datasetProp(DatasetAttributionHolders, taxonomy.AttributionHolders, FieldHelp{
+			Description: "Copyright holders named by the copyright notices of the dataset, semicolon-separated.",
+			Example:     "The Allen Institute for AI",
+		}, func(src DatasetSource) (any, bool) {
+			if src.Readme == nil {
+				return nil, false
+			}
+			s := strings.Join(fetcher.CopyrightHolders(src.Readme.Copyright), "; ")
+			return s, s != ""
+		}),
+		datasetProp(DatasetAttributionStatement, taxonomy.AttributionStatement, FieldHelp{
+			Description: "Attribution the authors of the dataset require from its users.",
+			Example:     "Please credit the Common Voice project and link to commonvoice.mozilla.org.",
+		}, func(src DatasetSource) (any, bool) {
+			if src.Readme == nil {
+				return nil, false
+			}
+			s := strings.TrimSpace(src.Readme.Attribution)
+			return s, s != ""
+		}),
+	}
+}
+
+// AttributionLicense returns the first license of comp that requires.
+// attribution (CC-BY and its variants, ODC-By), or "" when it has none.
+func AttributionLicense(comp *cdx.Component) string {
+	if comp == nil || comp.Licenses == nil {
+		return ""
+	}
+	for _, choice := range *comp.Licenses {
+		names := []string{choice.Expression}
+		if lic := choice.License; lic != nil {
+			names = append(names, lic.ID, lic.Name)
+		}
+		for _, n := range names {
+			if n = strings.TrimSpace(n); n != "" && attributionLicenseRe.MatchString(n) {
+				return n
+			}
+		}
+	}
+	return ""
+}
+
+// HasAttribution reports whether comp records attribution text: a copyright.
+// notice or the attribution its authors require.
+func HasAttribution(comp *cdx.Component) bool {
+	if comp == nil {
+		return false
+	}
+	return strings.TrimSpace(comp.Copyright) != "" || hasProperty(comp, taxonomy.AttributionStatement)
+}
@@ -2,15 +2,26 @@ package metadata
 
 import (
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/internal/taxonomy"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/scanner"
 )
 
 type componentExternalRefsSource struct {
 	ModelID  string
 	PaperURL string
 	DemoURL  string
+	// DistributionURL is set instead of ModelID for weights downloaded.
+	// directly from a URL.
+	DistributionURL string
+	// WebsiteURL is set instead of ModelID for models of other providers:.
+	// their catalog page.
+	WebsiteURL string
 }
 
 func componentFields() []FieldSpec {
@@ -74,8 +85,20 @@ func componentFields() []FieldSpec {
 				ok := bomHasComponentName(b)
 				return ok
 			},
+			Clear: func(tgt Target) error {
+				if tgt.Component == nil {
+					return nil
+				}
+				tgt.Component.Name = ""
+				return nil
+			},
 			InputType:   InputTypeText,
 			Placeholder: "e.g., organization/model-name",
+			Help: FieldHelp{
+				Description: "Name of the model, usually the Hugging Face repository ID.",
+				Example:     "google-bert/bert-base-uncased",
+				SpecPath:    "metadata.component.name",
+			},
 		},
 		{
 			Key:      ComponentExternalReferences,
@@ -94,6 +117,33 @@ func componentFields() []FieldSpec {
 					}
 					return input, true
 				},
+				func(src Source) (any, bool) {
+					if src.Scan.Type != scanner.DiscoveryTypeWeightURL || strings.TrimSpace(src.Scan.ID) == "" {
+						return nil, false
+					}
+					return componentExternalRefsSource{DistributionURL: strings.TrimSpace(src.Scan.ID)}, true
+				},
+				func(src Source) (any, bool) {
+					// DVC outputs and tracked artifacts: the copy in remote storage.
+					if uri := strings.TrimSpace(src.Scan.StorageURI); uri != "" {
+						return componentExternalRefsSource{DistributionURL: uri}, true
+					}
+					return nil, false
+				},
+				func(src Source) (any, bool) {
+					url := scanner.ProviderModelURL(src.Scan)
+					if url == "" {
+						return nil, false
+					}
+					return componentExternalRefsSource{WebsiteURL: url}, true
+				},
+				func(src Source) (any, bool) {
+					url := scanner.KaggleURL(src.Scan)
+					if url == "" {
+						return nil, false
+					}
+					return componentExternalRefsSource{WebsiteURL: url}, true
+				},
 			},
 			Parse: func(value string) (any, error) {
 				return parseNonEmptyString(value, "externalReferences")
@@ -120,6 +170,20 @@ func componentFields() []FieldSpec {
 						URL:  url,
 					}}
 				case componentExternalRefsSource:
+					if v.DistributionURL != "" {
+						refs = []cdx.ExternalReference{{
+							Type: cdx.ERTypeDistribution,
+							URL:  v.DistributionURL,
+						}}
+						break
+					}
+					if v.WebsiteURL != "" {
+						refs = []cdx.ExternalReference{{
+							Type: cdx.ExternalReferenceType("website"),
+							URL:  v.WebsiteURL,
+						}}
+						break
+					}
 					base := strings.TrimSpace(tgt.HuggingFaceBaseURL)
 					if base == "" {
 						base = "https://huggingface.co/"
@@ -148,6 +212,14 @@ func componentFields() []FieldSpec {
 					return fmt.Errorf("invalid externalReferences value")
 				}
 
+				// Keep the contacts recorded by the contact fields.
+				if tgt.Component.ExternalReferences != nil {
+					for _, ref := range *tgt.Component.ExternalReferences {
+						if ref.Type == cdx.ERTypeSecurityContact || ref.Type == cdx.ERTypeIssueTracker {
+							refs = append(refs, ref)
+						}
+					}
+				}
 				tgt.Component.ExternalReferences = &refs
 				return nil
 			},
@@ -156,6 +228,18 @@ func componentFields() []FieldSpec {
 				ok := c != nil && c.ExternalReferences != nil && len(*c.ExternalReferences) > 0
 				return ok
 			},
+			Clear: func(tgt Target) error {
+				if tgt.Component == nil {
+					return nil
+				}
+				tgt.Component.ExternalReferences = nil
+				return nil
+			},
+			Help: FieldHelp{
+				Description: "Where the model can be found, such as its model page or repository.",
+				Example:     "https://huggingface.co/google-bert/bert-base-uncased",
+				SpecPath:    "metadata.component.externalReferences",
+			},
 		},
 		{
 			Key:      ComponentTags,
@@ -180,6 +264,25 @@ func componentFields() []FieldSpec {
 					}
 					return nil, false
 				},
+				func(src Source) (any, bool) {
+					if src.Kaggle == nil {
+						return nil, false
+					}
+					tags := kaggleTagNames(src.Kaggle.Tags)
+					if src.Kaggle.Instance != nil && src.Kaggle.Instance.Framework != "" {
+						tags = append(tags, src.Kaggle.Instance.Framework)
+					}
+					tags = normalizeStrings(tags)
+					return tags, len(tags) > 0
+				},
+				func(src Source) (any, bool) {
+					if src.Ollama == nil {
+						return nil, false
+					}
+					tags := append([]string{"ollama", src.Ollama.Config.ModelFormat}, src.Ollama.Config.ModelFamilies...)
+					tags = normalizeStrings(append(tags, src.Ollama.Config.ModelFamily))
+					return tags, len(tags) > 0
+				},
 			},
 			Parse: func(value string) (any, error) {
 				return parseTagsPreserveEmpty(value, "tags")
@@ -207,9 +310,21 @@ func componentFields() []FieldSpec {
 				ok := c != nil && c.Tags != nil && len(*c.Tags) > 0
 				return ok
 			},
+			Clear: func(tgt Target) error {
+				if tgt.Component == nil {
+					return nil
+				}
+				tgt.Component.Tags = nil
+				return nil
+			},
 			InputType:   InputTypeMultiText,
 			Placeholder: "pytorch, transformers, nlp",
 			Suggestions: []string{"pytorch", "transformers", "nlp", "vision", "audio", "text-generation"},
+			Help: FieldHelp{
+				Description: "Keywords that classify the model by framework, task or domain.",
+				Example:     "pytorch, transformers, nlp",
+				SpecPath:    "metadata.component.tags",
+			},
 		},
 		{
 			Key:      ComponentLicenses,
@@ -236,6 +351,27 @@ func componentFields() []FieldSpec {
 					}
 					return lic, true
 				},
+				func(src Source) (any, bool) {
+					if src.Kaggle == nil || src.Kaggle.Instance == nil {
+						return nil, false
+					}
+					lic := strings.TrimSpace(src.Kaggle.Instance.LicenseName)
+					return lic, lic != ""
+				},
+				func(src Source) (any, bool) {
+					if src.Ollama == nil {
+						return nil, false
+					}
+					lic := ollamaLicenseName(src.Ollama.License)
+					return lic, lic != ""
+				},
+				func(src Source) (any, bool) {
+					if src.GGUF == nil {
+						return nil, false
+					}
+					lic := strings.TrimSpace(src.GGUF.License)
+					return lic, lic != ""
+				},
 			},
 			Parse: func(value string) (any, error) {
 				return parseNonEmptyString(value, "license")
@@ -267,9 +403,89 @@ func componentFields() []FieldSpec {
 				ok := c != nil && c.Licenses != nil && len(*c.Licenses) > 0
 				return ok
 			},
+			Clear: func(tgt Target) error {
+				if tgt.Component == nil {
+					return nil
+				}
+				tgt.Component.Licenses = nil
+				return nil
+			},
 			InputType:   InputTypeSelect,
 			Placeholder: "Select a license",
 			Suggestions: []string{"Apache-2.0", "MIT", "BSD-3-Clause", "GPL-3.0", "LGPL-3.0", "CC-BY-4.0", "CC-BY-SA-4.0", "CC0-1.0"},
+			Help: FieldHelp{
+				Description: "License under which the model weights are distributed, preferably an SPDX identifier.",
+				Example:     "Apache-2.0",
+				SpecPath:    "metadata.component.licenses",
+			},
+		},
+		{
+			Key:      ComponentLicenseText,
+			Weight:   0,
+			Required: false,
+			Sources: []func(Source) (any, bool){
+				func(src Source) (any, bool) {
+					if src.LicenseFile == nil || strings.TrimSpace(src.ModelID) == "" {
+						return nil, false
+					}
+					return src, true
+				},
+			},
+			Apply: func(tgt Target, value any) error {
+				input, ok := value.(applyInput)
+				if !ok {
+					return fmt.Errorf("invalid input for %s", ComponentLicenseText)
+				}
+				src, ok := input.Value.(Source)
+				if !ok || src.LicenseFile == nil {
+					return fmt.Errorf("invalid license file value")
+				}
+				if tgt.Component == nil {
+					return fmt.Errorf("component is nil")
+				}
+				lf := src.LicenseFile
+
+				// Attach the file to the declared license, or declare one named.
+				// after the file when the repo metadata names none.
+				if tgt.Component.Licenses == nil || len(*tgt.Component.Licenses) == 0 {
+					tgt.Component.Licenses = &cdx.Licenses{{License: &cdx.License{Name: lf.Path}}}
+				}
+				lic := (*tgt.Component.Licenses)[0].License
+				if lic == nil {
+					return nil
+				}
+
+				base := strings.TrimSpace(tgt.HuggingFaceBaseURL)
+				if base == "" {
+					base = "https://huggingface.co/"
+				}
+				if !strings.HasSuffix(base, "/") {
+					base += "/"
+				}
+				if lic.URL == "" {
+					lic.URL = base + strings.TrimPrefix(strings.TrimSpace(src.ModelID), "/") + "/blob/main/" + lf.Path
+				}
+				if lf.Text != "" {
+					lic.Text = &cdx.AttachedText{ContentType: lf.ContentType(), Content: lf.Text}
+				}
+				lic.Properties = taxonomy.Remove(lic.Properties, taxonomy.LicenseFile)
+				lic.Properties = taxonomy.Remove(lic.Properties, taxonomy.LicenseFileSHA256)
+				lic.Properties = taxonomy.Set(lic.Properties, taxonomy.LicenseFile, lf.Path)
+				lic.Properties = taxonomy.Set(lic.Properties, taxonomy.LicenseFileSHA256, lf.SHA256)
+				return nil
+			},
+			Present: func(b *cdx.BOM) bool {
+				c := bomComponent(b)
+				if c == nil || c.Licenses == nil {
+					return false
+				}
+				for _, l := range *c.Licenses {
+					if l.License != nil && (l.License.Text != nil || l.License.Properties != nil) {
+						return true
+					}
+				}
+				return false
+			},
 		},
 		{
 			Key:      ComponentHashes,
@@ -286,6 +502,21 @@ func componentFields() []FieldSpec {
 					}
 					return sha, true
 				},
+				func(src Source) (any, bool) {
+					// Locally vendored weights carry the SHA-256 of the file itself,.
+					// DVC and Git LFS pointers the digest they track.
+					if hs, ok := scanHashes(src.Scan); ok {
+						return hs, true
+					}
+					return nil, false
+				},
+				func(src Source) (any, bool) {
+					// Ollama models are pinned by the digest of their weights layer.
+					if src.Ollama == nil {
+						return nil, false
+					}
+					return ollamaWeightsHash(src.Ollama)
+				},
 			},
 			Parse: func(value string) (any, error) {
 				return parseNonEmptyString(value, "hash")
@@ -295,15 +526,27 @@ func componentFields() []FieldSpec {
 				if !ok {
 					return fmt.Errorf("invalid input for %s", ComponentHashes)
 				}
-				sha, _ := input.Value.(string)
-				sha = strings.TrimSpace(sha)
-				if sha == "" {
+				if hs, isList := input.Value.([]cdx.Hash); isList && len(hs) > 0 {
+					if tgt.Component == nil {
+						return fmt.Errorf("component is nil")
+					}
+					hs = slices.Clone(hs)
+					tgt.Component.Hashes = &hs
+					return nil
+				}
+				h, isHash := input.Value.(cdx.Hash)
+				if !isHash {
+					sha, _ := input.Value.(string)
+					h = cdx.Hash{Algorithm: cdx.HashAlgoSHA1, Value: sha}
+				}
+				h.Value = strings.TrimSpace(h.Value)
+				if h.Value == "" {
 					return fmt.Errorf("hash value is empty")
 				}
 				if tgt.Component == nil {
 					return fmt.Errorf("component is nil")
 				}
-				hs := []cdx.Hash{{Algorithm: cdx.HashAlgoSHA1, Value: sha}}
+				hs := []cdx.Hash{h}
 				tgt.Component.Hashes = &hs
 				return nil
 			},
@@ -312,8 +555,20 @@ func componentFields() []FieldSpec {
 				ok := c != nil && c.Hashes != nil && len(*c.Hashes) > 0
 				return ok
 			},
+			Clear: func(tgt Target) error {
+				if tgt.Component == nil {
+					return nil
+				}
+				tgt.Component.Hashes = nil
+				return nil
+			},
 			InputType:   InputTypeText,
 			Placeholder: "SHA-256 hash value",
+			Help: FieldHelp{
+				Description: "Digest of the model revision or weights, used to verify integrity.",
+				Example:     "a265f773a47193eed794233aa2a0f0bb6d3eaa63",
+				SpecPath:    "metadata.component.hashes",
+			},
 		},
 		{
 			Key:      ComponentManufacturer,
@@ -336,6 +591,17 @@ func componentFields() []FieldSpec {
 					}
 					return nil, false
 				},
+				func(src Source) (any, bool) {
+					if src.Kaggle == nil {
+						return nil, false
+					}
+					for _, s := range []string{src.Kaggle.Author, src.Kaggle.Owner} {
+						if s = strings.TrimSpace(s); s != "" {
+							return s, true
+						}
+					}
+					return nil, false
+				},
 			},
 			Parse: func(value string) (any, error) {
 				return parseNonEmptyString(value, "manufacturer")
@@ -356,7 +622,10 @@ func componentFields() []FieldSpec {
 				if !input.Force && tgt.Component.Manufacturer != nil && strings.TrimSpace(tgt.Component.Manufacturer.Name) != "" {
 					return nil
 				}
-				tgt.Component.Manufacturer = &cdx.OrganizationalEntity{Name: s}
+				if tgt.Component.Manufacturer == nil {
+					tgt.Component.Manufacturer = &cdx.OrganizationalEntity{}
+				}
+				tgt.Component.Manufacturer.Name = s
 				return nil
 			},
 			Present: func(b *cdx.BOM) bool {
@@ -364,8 +633,25 @@ func componentFields() []FieldSpec {
 				ok := c != nil && c.Manufacturer != nil && strings.TrimSpace(c.Manufacturer.Name) != ""
 				return ok
 			},
+			Clear: func(tgt Target) error {
+				if tgt.Component == nil {
+					return nil
+				}
+				// Keep the contacts recorded by ComponentContacts.
+				if m := tgt.Component.Manufacturer; m != nil && m.Contact != nil {
+					m.Name = ""
+					return nil
+				}
+				tgt.Component.Manufacturer = nil
+				return nil
+			},
 			InputType:   InputTypeText,
 			Placeholder: "Organization or author name",
+			Help: FieldHelp{
+				Description: "Organization that created and published the model.",
+				Example:     "Google",
+				SpecPath:    "metadata.component.manufacturer",
+			},
 		},
 		{
 			Key:      ComponentGroup,
@@ -435,8 +721,82 @@ func componentFields() []FieldSpec {
 				ok := c != nil && strings.TrimSpace(c.Group) != ""
 				return ok
 			},
+			Clear: func(tgt Target) error {
+				if tgt.Component == nil {
+					return nil
+				}
+				tgt.Component.Group = ""
+				return nil
+			},
 			InputType:   InputTypeText,
 			Placeholder: "Organization or group name",
+			Help: FieldHelp{
+				Description: "Namespace or organization the model is published under.",
+				Example:     "google-bert",
+				SpecPath:    "metadata.component.group",
+			},
+		},
+		{
+			Key:      ComponentPropertiesModelClassifier,
+			Weight:   0.5,
+			Required: false,
+			Sources: []func(Source) (any, bool){
+				func(src Source) (any, bool) {
+					if src.HF == nil {
+						return nil, false
+					}
+					c := ClassifyModel(src.HF.PipelineTag, src.HF.LibraryName)
+					return c, c != ""
+				},
+				func(src Source) (any, bool) {
+					if src.Readme == nil {
+						return nil, false
+					}
+					c := ClassifyModel(src.Readme.TaskType, "")
+					return c, c != ""
+				},
+			},
+			Parse: func(value string) (any, error) {
+				s, err := parseNonEmptyString(value, "classifier")
+				return strings.ToLower(s), err
+			},
+			Apply: func(tgt Target, value any) error {
+				input, ok := value.(applyInput)
+				if !ok {
+					return fmt.Errorf("invalid input for %s", ComponentPropertiesModelClassifier)
+				}
+				s, _ := input.Value.(string)
+				s = strings.TrimSpace(s)
+				if s == "" {
+					return fmt.Errorf("classifier value is empty")
+				}
+				if tgt.Component == nil {
+					return fmt.Errorf("component is nil")
+				}
+				if hasProperty(tgt.Component, classifierProperty) {
+					if !input.Force {
+						return nil
+					}
+					removeProperty(tgt.Component, classifierProperty)
+				}
+				setProperty(tgt.Component, classifierProperty, s)
+				return nil
+			},
+			Present: func(b *cdx.BOM) bool {
+				return hasProperty(bomComponent(b), classifierProperty)
+			},
+			Clear: func(tgt Target) error {
+				removeProperty(tgt.Component, classifierProperty)
+				return nil
+			},
+			InputType:   InputTypeSelect,
+			Placeholder: "Select the kind of model",
+			Suggestions: knownClassifiers,
+			Help: FieldHelp{
+				Description: "Kind of model, derived from the pipeline tag and library unless overridden.",
+				Example:     "llm",
+				SpecPath:    "metadata.component.properties",
+			},
 		},
 	}
 }
@@ -464,9 +824,23 @@ func evidenceFields() []FieldSpec {
 				if tgt.Component == nil || !tgt.IncludeEvidenceProperties {
 					return nil
 				}
-				setProperty(tgt.Component, "aibomgen.type", src.Scan.Type)
-				setProperty(tgt.Component, "aibomgen.evidence", src.Scan.Evidence)
-				setProperty(tgt.Component, "aibomgen.path", src.Scan.Path)
+				setProperty(tgt.Component, taxonomy.DiscoveryType, src.Scan.Type)
+				setProperty(tgt.Component, taxonomy.DiscoveryEvidence, src.Scan.Evidence)
+				setProperty(tgt.Component, taxonomy.DiscoveryPath, src.Scan.Path)
+				setProperty(tgt.Component, taxonomy.DiscoveryConfidence, src.Scan.Confidence)
+				if h := src.Scan.History; h != nil {
+					setProperty(tgt.Component, taxonomy.DiscoveryIntroducedCommit, h.IntroducedCommit)
+					setProperty(tgt.Component, taxonomy.DiscoveryIntroducedAt, h.IntroducedAt.UTC().Format(time.RFC3339))
+					if h.RemovedCommit != "" {
+						setProperty(tgt.Component, taxonomy.DiscoveryRemovedCommit, h.RemovedCommit)
+						setProperty(tgt.Component, taxonomy.DiscoveryRemovedAt, h.RemovedAt.UTC().Format(time.RFC3339))
+					}
+				}
+				setProperty(tgt.Component, taxonomy.FileFormat, src.Scan.Format)
+				setProperty(tgt.Component, taxonomy.FileETag, src.Scan.ETag)
+				if src.Scan.Size > 0 {
+					setProperty(tgt.Component, taxonomy.FileSize, strconv.FormatInt(src.Scan.Size, 10))
+				}
 				return nil
 			},
 			Present: func(b *cdx.BOM) bool {
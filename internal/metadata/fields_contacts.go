@@ -0,0 +1,249 @@
+package metadata
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/internal/fetcher"
+)
+
+// Contact fields. The contacts a model card states are recorded as.
+// structured CycloneDX data rather than free text: people and mailboxes as.
+// the manufacturer's contacts, the security contact and the issue tracker as.
+// external references of those types. The card's "Model Card Contact" text.
+// stays in huggingface:modelCardContact. They do not count towards.
+// completeness; validate --check-security-contact requires a security.
+// contact for production models.
+const (
+	ComponentContacts        Key = "BOM.metadata.component.manufacturer.contact"
+	ComponentSecurityContact Key = "BOM.metadata.component.externalReferences.security-contact"
+	ComponentIssueTracker    Key = "BOM.metadata.component.externalReferences.issue-tracker"
+)
+
+// cardContacts returns the contacts of the model card with the given role.
+func cardContacts(src Source, role string) []fetcher.Contact {
+	if src.Readme == nil {
+		return nil
+	}
+	var out []fetcher.Contact
+	for _, c := range src.Readme.Contacts {
+		if c.Role == role {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// parseContactList parses user-entered contacts: comma-separated email.
+// addresses or URLs, each optionally named ("Jane Doe <jane@example.org>").
+func parseContactList(value, field string) ([]fetcher.Contact, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, fmt.Errorf("%s value is empty", field)
+	}
+	contacts := fetcher.ContactsIn(value)
+	if len(contacts) == 0 {
+		return nil, fmt.Errorf("%s %q: want email addresses or URLs", field, value)
+	}
+	return contacts, nil
+}
+
+// contactURL returns the external reference URL of c: its URL, or a mailto.
+// URL for an email address.
+func contactURL(c fetcher.Contact) string {
+	if c.URL != "" {
+		return c.URL
+	}
+	return (&url.URL{Scheme: "mailto", Opaque: c.Email}).String()
+}
+
+// hasExternalReference reports whether comp has an external reference of.
+// type t.
+func hasExternalReference(comp *cdx.Component, t cdx.ExternalReferenceType) bool {
+	if comp == nil || comp.ExternalReferences == nil {
+		return false
+	}
+	for _, ref := range *comp.ExternalReferences {
+		if ref.Type == t && strings.TrimSpace(ref.URL) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// removeExternalReferences drops the external references of type t from comp.
+func removeExternalReferences(comp *cdx.Component, t cdx.ExternalReferenceType) {
+	if comp == nil || comp.ExternalReferences == nil {
+		return
+	}
+	var kept []cdx.ExternalReference
+	for _, ref := range *comp.ExternalReferences {
+		if ref.Type != t {
+			kept = append(kept, ref)
+		}
+	}
+	if len(kept) == 0 {
+		comp.ExternalReferences = nil
+		return
+	}
+	comp.ExternalReferences = &kept
+}
+
+// HasSecurityContact reports whether comp names a security contact: an.
+// external reference of type security-contact.
+func HasSecurityContact(comp *cdx.Component) bool {
+	return hasExternalReference(comp, cdx.ERTypeSecurityContact)
+}
+
+// contactRefField is a field recording the card contacts of role as external.
+// references of type t, named by their Comment.
+func contactRefField(key Key, t cdx.ExternalReferenceType, role, name string, help FieldHelp) FieldSpec {
+	return FieldSpec{
+		Key:      key,
+		Weight:   0,
+		Required: false,
+		Sources: []func(Source) (any, bool){
+			func(src Source) (any, bool) {
+				contacts := cardContacts(src, role)
+				return contacts, len(contacts) > 0
+			},
+		},
+		Parse: func(value string) (any, error) {
+			return parseContactList(value, name)
+		},
+		Apply: func(tgt Target, value any) error {
+			input, ok := value.(applyInput)
+			if !ok {
+				return fmt.Errorf("invalid input for %s", key)
+			}
+			contacts, _ := input.Value.([]fetcher.Contact)
+			if len(contacts) == 0 {
+				return fmt.Errorf("%s value is empty", name)
+			}
+			if tgt.Component == nil {
+				return fmt.Errorf("component is nil")
+			}
+			if hasExternalReference(tgt.Component, t) {
+				if !input.Force {
+					return nil
+				}
+				removeExternalReferences(tgt.Component, t)
+			}
+			var refs []cdx.ExternalReference
+			if tgt.Component.ExternalReferences != nil {
+				refs = *tgt.Component.ExternalReferences
+			}
+			for _, c := range contacts {
+				refs = append(refs, cdx.ExternalReference{Type: t, URL: contactURL(c), Comment: c.Name})
+			}
+			tgt.Component.ExternalReferences = &refs
+			return nil
+		},
+		Present: func(b *cdx.BOM) bool {
+			return hasExternalReference(bomComponent(b), t)
+		},
+		Clear: func(tgt Target) error {
+			removeExternalReferences(tgt.Component, t)
+			return nil
+		},
+		InputType: InputTypeMultiText,
+		Help:      help,
+	}
+}
+
+func contactFields() []FieldSpec {
+	security := contactRefField(ComponentSecurityContact, cdx.ERTypeSecurityContact, fetcher.ContactRoleSecurity, "security contact", FieldHelp{
+		Description: "Where to report vulnerabilities and misuse of the model: email addresses or URLs.",
+		Example:     "security@example.org",
+		SpecPath:    "metadata.component.externalReferences",
+	})
+	security.Placeholder = "security@example.org"
+
+	issues := contactRefField(ComponentIssueTracker, cdx.ERTypeIssueTracker, fetcher.ContactRoleIssues, "issue tracker", FieldHelp{
+		Description: "Issue tracker or discussion board of the model.",
+		Example:     "https://github.com/example/model/issues",
+		SpecPath:    "metadata.component.externalReferences",
+	})
+	issues.Placeholder = "https://github.com/example/model/issues"
+
+	contacts := FieldSpec{
+		Key:      ComponentContacts,
+		Weight:   0,
+		Required: false,
+		Sources: []func(Source) (any, bool){
+			func(src Source) (any, bool) {
+				var contacts []fetcher.Contact
+				for _, c := range cardContacts(src, fetcher.ContactRoleGeneral) {
+					if c.Email != "" {
+						contacts = append(contacts, c)
+					}
+				}
+				return contacts, len(contacts) > 0
+			},
+		},
+		Parse: func(value string) (any, error) {
+			contacts, err := parseContactList(value, "contacts")
+			if err != nil {
+				return nil, err
+			}
+			for _, c := range contacts {
+				if c.Email == "" {
+					return nil, fmt.Errorf("contact %q: want an email address", c.URL)
+				}
+			}
+			return contacts, nil
+		},
+		Apply: func(tgt Target, value any) error {
+			input, ok := value.(applyInput)
+			if !ok {
+				return fmt.Errorf("invalid input for %s", ComponentContacts)
+			}
+			contacts, _ := input.Value.([]fetcher.Contact)
+			if len(contacts) == 0 {
+				return fmt.Errorf("contacts value is empty")
+			}
+			if tgt.Component == nil {
+				return fmt.Errorf("component is nil")
+			}
+			m := tgt.Component.Manufacturer
+			if !input.Force && m != nil && m.Contact != nil && len(*m.Contact) > 0 {
+				return nil
+			}
+			if m == nil {
+				m = &cdx.OrganizationalEntity{}
+				tgt.Component.Manufacturer = m
+			}
+			list := make([]cdx.OrganizationalContact, 0, len(contacts))
+			for _, c := range contacts {
+				list = append(list, cdx.OrganizationalContact{Name: c.Name, Email: c.Email})
+			}
+			m.Contact = &list
+			return nil
+		},
+		Present: func(b *cdx.BOM) bool {
+			c := bomComponent(b)
+			return c != nil && c.Manufacturer != nil && c.Manufacturer.Contact != nil && len(*c.Manufacturer.Contact) > 0
+		},
+		Clear: func(tgt Target) error {
+			if tgt.Component == nil || tgt.Component.Manufacturer == nil {
+				return nil
+			}
+			m := tgt.Component.Manufacturer
+			m.Contact = nil
+			if strings.TrimSpace(m.Name) == "" && m.URL == nil {
+				tgt.Component.Manufacturer = nil
+			}
+			return nil
+		},
+		InputType:   InputTypeMultiText,
+		Placeholder: "Jane Doe <jane@example.org>",
+		Help: FieldHelp{
+			Description: "People or mailboxes to contact about the model, comma-separated.",
+			Example:     "Jane Doe <jane@example.org>, ml-team@example.org",
+			SpecPath:    "metadata.component.manufacturer.contact",
+		},
+	}
+
+	return []FieldSpec{contacts, security, issues}
+}
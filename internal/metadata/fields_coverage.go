@@ -0,0 +1,84 @@
+package metadata
+
+import (
+	"strings"
+
+	"github.com/idlab-discover/aibomgen-cli/internal/taxonomy"
+)
+
+// Dataset coverage fields: when (aibomgen:data:temporalCoverage) and where.
+// (aibomgen:data:geographicCoverage) the data was collected and the languages.
+// it is in (aibomgen:data:languages), as data protection assessments ask. They.
+// count towards completeness.
+const (
+	DatasetTemporalCoverage   DatasetKey = "BOM.components[DATA].properties.aibomgen:data:temporalCoverage"
+	DatasetGeographicCoverage DatasetKey = "BOM.components[DATA].properties.aibomgen:data:geographicCoverage"
+	DatasetLanguages          DatasetKey = "BOM.components[DATA].properties.aibomgen:data:languages"
+)
+
+// datasetLanguages returns the languages of a dataset: the language list of.
+// its card, else its language:<code> Hub tags.
+func datasetLanguages(src DatasetSource) []string {
+	if src.Readme != nil && len(src.Readme.Language) > 0 {
+		return normalizeStrings(src.Readme.Language)
+	}
+	var langs []string
+	if src.HF != nil {
+		for _, tag := range src.HF.Tags {
+			if code, ok := strings.CutPrefix(tag, "language:"); ok {
+				langs = append(langs, code)
+			}
+		}
+	}
+	return normalizeStrings(langs)
+}
+
+func coverageDatasetFields() []DatasetFieldSpec {
+	temporal := datasetProp(DatasetTemporalCoverage, taxonomy.DataTemporalCoverage, FieldHelp{
+		Description: "Time range the data was collected in, preferably as an ISO 8601 interval.",
+		Example:     "2019-03/2021-06",
+	}, func(src DatasetSource) (any, bool) {
+		if src.Readme == nil {
+			return nil, false
+		}
+		s := strings.TrimSpace(src.Readme.TemporalCoverage)
+		return s, s != ""
+	})
+	temporal.Weight = 0.5
+	temporal.InputType = InputTypeText
+	temporal.Placeholder = "2019/2021"
+
+	geographic := datasetProp(DatasetGeographicCoverage, taxonomy.DataGeographicCoverage, FieldHelp{
+		Description: "Countries or regions the data was collected in or describes.",
+		Example:     "Belgium, Netherlands",
+	}, func(src DatasetSource) (any, bool) {
+		if src.Readme == nil {
+			return nil, false
+		}
+		s := strings.TrimSpace(src.Readme.GeographicCoverage)
+		return s, s != ""
+	})
+	geographic.Weight = 0.5
+	geographic.InputType = InputTypeText
+	geographic.Placeholder = "Belgium, Netherlands"
+
+	languages := datasetProp(DatasetLanguages, taxonomy.DataLanguages, FieldHelp{
+		Description: "Languages of the dataset, as BCP 47 codes.",
+		Example:     "en, nl",
+	}, func(src DatasetSource) (any, bool) {
+		s := strings.Join(datasetLanguages(src), ", ")
+		return s, s != ""
+	})
+	languages.Weight = 0.5
+	languages.Parse = func(value string) (any, error) {
+		langs, err := parseCommaList(value, "languages")
+		if err != nil {
+			return nil, err
+		}
+		return strings.Join(langs, ", "), nil
+	}
+	languages.InputType = InputTypeMultiText
+	languages.Placeholder = "en, nl"
+
+	return []DatasetFieldSpec{temporal, geographic, languages}
+}
@@ -5,17 +5,22 @@ import (
 	"strings"
 
 	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/internal/taxonomy"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/scanner"
 )
 
 type datasetExternalRefsSource struct {
 	DatasetID string
 	PaperURL  string
 	DemoURL   string
+	// DistributionURL is set instead of DatasetID for dataset files tracked.
+	// by DVC or an experiment tracker: the copy in remote storage.
+	DistributionURL string
 }
 
 // DatasetRegistry returns all dataset field specifications.
 func DatasetRegistry() []DatasetFieldSpec {
-	return []DatasetFieldSpec{
+	specs := []DatasetFieldSpec{
 		{
 			Key:      DatasetName,
 			Weight:   1.0,
@@ -66,14 +71,34 @@ func DatasetRegistry() []DatasetFieldSpec {
 				ok := comp != nil && strings.TrimSpace(comp.Name) != ""
 				return ok
 			},
+			Clear: func(tgt DatasetTarget) error {
+				if tgt.Component == nil {
+					return nil
+				}
+				tgt.Component.Name = ""
+				return nil
+			},
 			InputType:   InputTypeText,
 			Placeholder: "e.g., organization/dataset-name",
+			Help: FieldHelp{
+				Description: "Name of the dataset, usually the Hugging Face repository ID.",
+				Example:     "stanfordnlp/imdb",
+				SpecPath:    "components[].name",
+			},
 		},
 		{
 			Key:      DatasetExternalReferences,
 			Weight:   0.5,
 			Required: false,
 			Sources: []func(DatasetSource) (any, bool){
+				func(src DatasetSource) (any, bool) {
+					// Dataset files tracked by DVC or an experiment tracker are not.
+					// on the Hub: link the copy in remote storage.
+					if uri := strings.TrimSpace(src.Scan.StorageURI); uri != "" {
+						return datasetExternalRefsSource{DistributionURL: uri}, true
+					}
+					return nil, false
+				},
 				func(src DatasetSource) (any, bool) {
 					datasetID := strings.TrimSpace(src.DatasetID)
 					if datasetID == "" {
@@ -86,6 +111,10 @@ func DatasetRegistry() []DatasetFieldSpec {
 					}
 					return input, true
 				},
+				func(src DatasetSource) (any, bool) {
+					url := scanner.KaggleURL(src.Scan)
+					return url, url != ""
+				},
 			},
 			Parse: func(value string) (any, error) {
 				return parseNonEmptyString(value, "externalReferences")
@@ -110,6 +139,13 @@ func DatasetRegistry() []DatasetFieldSpec {
 						URL:  url,
 					}}
 				case datasetExternalRefsSource:
+					if v.DistributionURL != "" {
+						refs = []cdx.ExternalReference{{
+							Type: cdx.ERTypeDistribution,
+							URL:  v.DistributionURL,
+						}}
+						break
+					}
 					base := strings.TrimSpace(tgt.HuggingFaceBaseURL)
 					if base == "" {
 						base = "https://huggingface.co/"
@@ -143,8 +179,20 @@ func DatasetRegistry() []DatasetFieldSpec {
 			Present: func(comp *cdx.Component) bool {
 				return comp != nil && comp.ExternalReferences != nil && len(*comp.ExternalReferences) > 0
 			},
+			Clear: func(tgt DatasetTarget) error {
+				if tgt.Component == nil {
+					return nil
+				}
+				tgt.Component.ExternalReferences = nil
+				return nil
+			},
 			InputType:   InputTypeText,
 			Placeholder: "https://example.com/dataset",
+			Help: FieldHelp{
+				Description: "Where the dataset can be found, such as its dataset page.",
+				Example:     "https://huggingface.co/datasets/stanfordnlp/imdb",
+				SpecPath:    "components[].externalReferences",
+			},
 		},
 		{
 			Key:      DatasetTags,
@@ -169,6 +217,13 @@ func DatasetRegistry() []DatasetFieldSpec {
 					}
 					return nil, false
 				},
+				func(src DatasetSource) (any, bool) {
+					if src.Kaggle == nil {
+						return nil, false
+					}
+					tags := normalizeStrings(kaggleTagNames(src.Kaggle.Tags))
+					return tags, len(tags) > 0
+				},
 			},
 			Parse: func(value string) (any, error) {
 				parts := strings.Split(value, ",")
@@ -193,9 +248,21 @@ func DatasetRegistry() []DatasetFieldSpec {
 			Present: func(comp *cdx.Component) bool {
 				return comp != nil && comp.Tags != nil && len(*comp.Tags) > 0
 			},
+			Clear: func(tgt DatasetTarget) error {
+				if tgt.Component == nil {
+					return nil
+				}
+				tgt.Component.Tags = nil
+				return nil
+			},
 			InputType:   InputTypeMultiText,
 			Placeholder: "nlp, text, en",
 			Suggestions: []string{"nlp", "vision", "audio", "tabular", "multimodal", "text", "image"},
+			Help: FieldHelp{
+				Description: "Keywords that classify the dataset by modality, task or language.",
+				Example:     "nlp, text, en",
+				SpecPath:    "components[].tags",
+			},
 		},
 		{
 			Key:      DatasetLicenses,
@@ -219,6 +286,13 @@ func DatasetRegistry() []DatasetFieldSpec {
 					}
 					return nil, false
 				},
+				func(src DatasetSource) (any, bool) {
+					if src.Kaggle == nil {
+						return nil, false
+					}
+					lic := strings.TrimSpace(src.Kaggle.LicenseName)
+					return lic, lic != "" && lic != "unknown"
+				},
 			},
 			Parse: func(value string) (any, error) {
 				return parseNonEmptyString(value, "license")
@@ -248,9 +322,21 @@ func DatasetRegistry() []DatasetFieldSpec {
 			Present: func(comp *cdx.Component) bool {
 				return comp != nil && comp.Licenses != nil && len(*comp.Licenses) > 0
 			},
+			Clear: func(tgt DatasetTarget) error {
+				if tgt.Component == nil {
+					return nil
+				}
+				tgt.Component.Licenses = nil
+				return nil
+			},
 			InputType:   InputTypeSelect,
 			Placeholder: "Select a license",
 			Suggestions: []string{"Apache-2.0", "MIT", "CC-BY-4.0", "CC-BY-SA-4.0", "CC0-1.0"},
+			Help: FieldHelp{
+				Description: "License under which the dataset is distributed, preferably an SPDX identifier.",
+				Example:     "CC-BY-4.0",
+				SpecPath:    "components[].licenses",
+			},
 		},
 		{
 			Key:      DatasetDescription,
@@ -275,6 +361,17 @@ func DatasetRegistry() []DatasetFieldSpec {
 					}
 					return nil, false
 				},
+				func(src DatasetSource) (any, bool) {
+					if src.Kaggle == nil {
+						return nil, false
+					}
+					for _, desc := range []string{src.Kaggle.Description, src.Kaggle.Subtitle} {
+						if desc = strings.TrimSpace(desc); desc != "" {
+							return desc, true
+						}
+					}
+					return nil, false
+				},
 			},
 			Parse: func(value string) (any, error) {
 				return parseNonEmptyString(value, "description")
@@ -300,8 +397,19 @@ func DatasetRegistry() []DatasetFieldSpec {
 				data := getComponentData(comp)
 				return data != nil && strings.TrimSpace(data.Description) != ""
 			},
+			Clear: func(tgt DatasetTarget) error {
+				if data := getComponentData(tgt.Component); data != nil {
+					data.Description = ""
+				}
+				return nil
+			},
 			InputType:   InputTypeTextArea,
 			Placeholder: "Describe the dataset...",
+			Help: FieldHelp{
+				Description: "Summary of what the dataset contains and how it was collected.",
+				Example:     "50,000 movie reviews labelled as positive or negative",
+				SpecPath:    "components[].data[].description",
+			},
 		},
 		{
 			Key:      DatasetManufacturer,
@@ -321,6 +429,17 @@ func DatasetRegistry() []DatasetFieldSpec {
 					}
 					return nil, false
 				},
+				func(src DatasetSource) (any, bool) {
+					if src.Kaggle == nil {
+						return nil, false
+					}
+					for _, name := range []string{src.Kaggle.OwnerName, src.Kaggle.OwnerRef} {
+						if name = strings.TrimSpace(name); name != "" {
+							return name, true
+						}
+					}
+					return nil, false
+				},
 			},
 			Parse: func(value string) (any, error) {
 				return parseNonEmptyString(value, "manufacturer")
@@ -347,8 +466,20 @@ func DatasetRegistry() []DatasetFieldSpec {
 			Present: func(comp *cdx.Component) bool {
 				return comp != nil && comp.Manufacturer != nil && strings.TrimSpace(comp.Manufacturer.Name) != ""
 			},
+			Clear: func(tgt DatasetTarget) error {
+				if tgt.Component == nil {
+					return nil
+				}
+				tgt.Component.Manufacturer = nil
+				return nil
+			},
 			InputType:   InputTypeText,
 			Placeholder: "Organization or author name",
+			Help: FieldHelp{
+				Description: "Organization that created and published the dataset.",
+				Example:     "Stanford NLP",
+				SpecPath:    "components[].manufacturer",
+			},
 		},
 		{
 			Key:      DatasetAuthors,
@@ -377,6 +508,12 @@ func DatasetRegistry() []DatasetFieldSpec {
 					}
 					return allAuthors, true
 				},
+				func(src DatasetSource) (any, bool) {
+					if src.Kaggle == nil || strings.TrimSpace(src.Kaggle.CreatorName) == "" {
+						return nil, false
+					}
+					return []string{strings.TrimSpace(src.Kaggle.CreatorName)}, true
+				},
 			},
 			Parse: func(value string) (any, error) {
 				parts := strings.Split(value, ",")
@@ -420,8 +557,20 @@ func DatasetRegistry() []DatasetFieldSpec {
 			Present: func(comp *cdx.Component) bool {
 				return comp != nil && comp.Authors != nil && len(*comp.Authors) > 0
 			},
+			Clear: func(tgt DatasetTarget) error {
+				if tgt.Component == nil {
+					return nil
+				}
+				tgt.Component.Authors = nil
+				return nil
+			},
 			InputType:   InputTypeMultiText,
 			Placeholder: "author1, author2, author3",
+			Help: FieldHelp{
+				Description: "People who created the dataset.",
+				Example:     "Andrew Maas, Raymond Daly",
+				SpecPath:    "components[].authors",
+			},
 		},
 		{
 			Key:      DatasetGroup,
@@ -471,8 +620,20 @@ func DatasetRegistry() []DatasetFieldSpec {
 			Present: func(comp *cdx.Component) bool {
 				return comp != nil && strings.TrimSpace(comp.Group) != ""
 			},
+			Clear: func(tgt DatasetTarget) error {
+				if tgt.Component == nil {
+					return nil
+				}
+				tgt.Component.Group = ""
+				return nil
+			},
 			InputType:   InputTypeText,
 			Placeholder: "Organization or group name",
+			Help: FieldHelp{
+				Description: "Namespace or organization the dataset is published under.",
+				Example:     "stanfordnlp",
+				SpecPath:    "components[].group",
+			},
 		},
 		{
 			Key:      DatasetContents,
@@ -524,8 +685,19 @@ func DatasetRegistry() []DatasetFieldSpec {
 				data := getComponentData(comp)
 				return data != nil && data.Contents != nil && data.Contents.Attachment != nil
 			},
+			Clear: func(tgt DatasetTarget) error {
+				if data := getComponentData(tgt.Component); data != nil {
+					data.Contents = nil
+				}
+				return nil
+			},
 			InputType:   InputTypeTextArea,
 			Placeholder: "Describe dataset contents...",
+			Help: FieldHelp{
+				Description: "Description or location of the data itself.",
+				Example:     "Plain-text reviews split into train and test sets",
+				SpecPath:    "components[].data[].contents.attachment",
+			},
 		},
 		{
 			Key:      DatasetSensitiveData,
@@ -594,8 +766,19 @@ func DatasetRegistry() []DatasetFieldSpec {
 				data := getComponentData(comp)
 				return data != nil && data.SensitiveData != nil && len(*data.SensitiveData) > 0
 			},
+			Clear: func(tgt DatasetTarget) error {
+				if data := getComponentData(tgt.Component); data != nil {
+					data.SensitiveData = nil
+				}
+				return nil
+			},
 			InputType:   InputTypeTextArea,
 			Placeholder: "Describe any sensitive data...",
+			Help: FieldHelp{
+				Description: "Personal or otherwise sensitive data the dataset contains.",
+				Example:     "User names in review text",
+				SpecPath:    "components[].data[].sensitiveData",
+			},
 		},
 		{
 			Key:      DatasetClassification,
@@ -639,9 +822,20 @@ func DatasetRegistry() []DatasetFieldSpec {
 				data := getComponentData(comp)
 				return data != nil && strings.TrimSpace(data.Classification) != ""
 			},
+			Clear: func(tgt DatasetTarget) error {
+				if data := getComponentData(tgt.Component); data != nil {
+					data.Classification = ""
+				}
+				return nil
+			},
 			InputType:   InputTypeText,
 			Placeholder: "text, image, audio, etc.",
 			Suggestions: []string{"text", "image", "audio", "video", "tabular"},
+			Help: FieldHelp{
+				Description: "Kind of data the dataset holds.",
+				Example:     "text",
+				SpecPath:    "components[].data[].classification",
+			},
 		},
 		{
 			Key:      DatasetGovernance,
@@ -708,8 +902,19 @@ func DatasetRegistry() []DatasetFieldSpec {
 				data := getComponentData(comp)
 				return data != nil && data.Governance != nil
 			},
+			Clear: func(tgt DatasetTarget) error {
+				if data := getComponentData(tgt.Component); data != nil {
+					data.Governance = nil
+				}
+				return nil
+			},
 			InputType:   InputTypeTextArea,
 			Placeholder: "custodian:OrgName,steward:CuratorName,owner:FunderName",
+			Help: FieldHelp{
+				Description: "Custodians, stewards and owners responsible for the dataset.",
+				Example:     "custodian:Stanford NLP,steward:Data Team,owner:Stanford",
+				SpecPath:    "components[].data[].governance",
+			},
 		},
 		{
 			Key:      DatasetHashes,
@@ -726,6 +931,13 @@ func DatasetRegistry() []DatasetFieldSpec {
 					}
 					return sha, true
 				},
+				func(src DatasetSource) (any, bool) {
+					// Dataset files tracked by DVC or Git LFS carry their digest.
+					if h, ok := scanHash(src.Scan); ok {
+						return h, true
+					}
+					return nil, false
+				},
 			},
 			Parse: func(value string) (any, error) {
 				return parseNonEmptyString(value, "hash")
@@ -735,26 +947,39 @@ func DatasetRegistry() []DatasetFieldSpec {
 				if !ok {
 					return fmt.Errorf("invalid input for %s", DatasetHashes)
 				}
-				hash, _ := input.Value.(string)
-				hash = strings.TrimSpace(hash)
-				if hash == "" {
+				h, isHash := input.Value.(cdx.Hash)
+				if !isHash {
+					sha, _ := input.Value.(string)
+					h = cdx.Hash{Algorithm: cdx.HashAlgoSHA1, Value: sha}
+				}
+				h.Value = strings.TrimSpace(h.Value)
+				if h.Value == "" {
 					return fmt.Errorf("hash value is empty")
 				}
 				if tgt.Component == nil {
 					return fmt.Errorf("component is nil")
 				}
-				hashes := []cdx.Hash{{
-					Algorithm: cdx.HashAlgoSHA1,
-					Value:     hash,
-				}}
+				hashes := []cdx.Hash{h}
 				tgt.Component.Hashes = &hashes
 				return nil
 			},
 			Present: func(comp *cdx.Component) bool {
 				return comp != nil && comp.Hashes != nil && len(*comp.Hashes) > 0
 			},
+			Clear: func(tgt DatasetTarget) error {
+				if tgt.Component == nil {
+					return nil
+				}
+				tgt.Component.Hashes = nil
+				return nil
+			},
 			InputType:   InputTypeText,
 			Placeholder: "SHA-256 hash value",
+			Help: FieldHelp{
+				Description: "Digest of the dataset revision, used to verify integrity.",
+				Example:     "e6281661ce1c48d982bc483cf8a173c1bbeb5d31",
+				SpecPath:    "components[].hashes",
+			},
 		},
 		{
 			Key:      DatasetCreatedAt,
@@ -784,14 +1009,23 @@ func DatasetRegistry() []DatasetFieldSpec {
 					return fmt.Errorf("component is nil")
 				}
 				createdAt, _ := input.Value.(string)
-				setProperty(tgt.Component, "huggingface:createdAt", strings.TrimSpace(createdAt))
+				setProperty(tgt.Component, taxonomy.HFCreatedAt, strings.TrimSpace(createdAt))
 				return nil
 			},
 			Present: func(comp *cdx.Component) bool {
-				return hasProperty(comp, "huggingface:createdAt")
+				return hasProperty(comp, taxonomy.HFCreatedAt)
+			},
+			Clear: func(tgt DatasetTarget) error {
+				removeProperty(tgt.Component, taxonomy.HFCreatedAt)
+				return nil
 			},
 			InputType:   InputTypeText,
 			Placeholder: "YYYY-MM-DD",
+			Help: FieldHelp{
+				Description: "Time the dataset repository was created on Hugging Face.",
+				Example:     "2022-03-02",
+				SpecPath:    "components[].properties",
+			},
 		},
 		{
 			Key:      DatasetUsedStorage,
@@ -817,14 +1051,23 @@ func DatasetRegistry() []DatasetFieldSpec {
 					return fmt.Errorf("component is nil")
 				}
 				usedStorage, _ := input.Value.(string)
-				setProperty(tgt.Component, "huggingface:usedStorage", strings.TrimSpace(usedStorage))
+				setProperty(tgt.Component, taxonomy.HFUsedStorage, strings.TrimSpace(usedStorage))
 				return nil
 			},
 			Present: func(comp *cdx.Component) bool {
-				return hasProperty(comp, "huggingface:usedStorage")
+				return hasProperty(comp, taxonomy.HFUsedStorage)
+			},
+			Clear: func(tgt DatasetTarget) error {
+				removeProperty(tgt.Component, taxonomy.HFUsedStorage)
+				return nil
 			},
 			InputType:   InputTypeText,
 			Placeholder: "Storage size in bytes",
+			Help: FieldHelp{
+				Description: "Storage used by the dataset repository, in bytes.",
+				Example:     "132000000",
+				SpecPath:    "components[].properties",
+			},
 		},
 		{
 			Key:      DatasetLastModified,
@@ -858,29 +1101,27 @@ func DatasetRegistry() []DatasetFieldSpec {
 				if tgt.Component == nil {
 					return fmt.Errorf("component is nil")
 				}
-				if tgt.Component.Tags != nil {
-					tags := *tgt.Component.Tags
-					tags = append(tags, "lastModified:"+lastMod)
-					tgt.Component.Tags = &tags
-				} else {
-					tags := []string{"lastModified:" + lastMod}
-					tgt.Component.Tags = &tags
-				}
+				setProperty(tgt.Component, taxonomy.HFLastModified, lastMod)
 				return nil
 			},
 			Present: func(comp *cdx.Component) bool {
-				if comp == nil || comp.Tags == nil {
-					return false
-				}
-				for _, tag := range *comp.Tags {
-					if strings.HasPrefix(tag, "lastModified:") {
-						return true
-					}
+				return hasProperty(comp, taxonomy.HFLastModified) || hasLegacyLastModifiedTag(comp)
+			},
+			Clear: func(tgt DatasetTarget) error {
+				if tgt.Component == nil {
+					return nil
 				}
-				return false
+				removeProperty(tgt.Component, taxonomy.HFLastModified)
+				removeLegacyLastModifiedTags(tgt.Component)
+				return nil
 			},
 			InputType:   InputTypeText,
 			Placeholder: "YYYY-MM-DD",
+			Help: FieldHelp{
+				Description: "Time the dataset repository was last updated on Hugging Face.",
+				Example:     "2024-01-04",
+				SpecPath:    "components[].properties",
+			},
 		},
 		{
 			Key:      DatasetContact,
@@ -914,14 +1155,28 @@ func DatasetRegistry() []DatasetFieldSpec {
 				if tgt.Component == nil {
 					return fmt.Errorf("component is nil")
 				}
-				setProperty(tgt.Component, "huggingface:datasetContact", contact)
+				setProperty(tgt.Component, taxonomy.HFDatasetContact, contact)
 				return nil
 			},
 			Present: func(comp *cdx.Component) bool {
-				return hasProperty(comp, "huggingface:datasetContact")
+				return hasProperty(comp, taxonomy.HFDatasetContact)
+			},
+			Clear: func(tgt DatasetTarget) error {
+				removeProperty(tgt.Component, taxonomy.HFDatasetContact)
+				return nil
 			},
 			InputType:   InputTypeText,
 			Placeholder: "Contact information",
+			Help: FieldHelp{
+				Description: "Contact for questions about the dataset card.",
+				Example:     "data-team@example.com",
+				SpecPath:    "components[].properties",
+			},
 		},
 	}
+	specs = append(specs, coverageDatasetFields()...)
+	specs = append(specs, governanceDatasetFields()...)
+	specs = append(specs, attributionDatasetFields()...)
+	specs = append(specs, syntheticDatasetFields()...)
+	return append(specs, kaggleDatasetFields()...)
 }
@@ -0,0 +1,125 @@
+package metadata
+
+import (
+	"fmt"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+// Fairness fields. Per-group results of a bias or fairness evaluation go into.
+// the performance metrics of the quantitative analysis with the group as.
+// their slice, the criteria the evaluation used into aibomgen:fairness:criteria.
+// Both count towards the responsible AI category of the completeness score.
+const (
+	ComponentPropertiesFairnessCriteria Key = "BOM.metadata.component.properties.aibomgen:fairness:criteria"
+)
+
+func fairnessFields() []FieldSpec {
+	criteria := hfProp(ComponentPropertiesFairnessCriteria, 0.25, FieldHelp{Description: "Fairness criteria the model was evaluated against.", Example: "demographic parity, equalized odds"}, func(src Source) (any, bool) {
+		if src.Readme == nil {
+			return nil, false
+		}
+		s := strings.Join(src.Readme.FairnessCriteria, ", ")
+		return s, s != ""
+	})
+	criteria.Category = CategoryResponsibleAI
+
+	return []FieldSpec{
+		{
+			Key:      ModelCardQuantitativeAnalysisFairnessMetrics,
+			Weight:   0.5,
+			Required: false,
+			Category: CategoryResponsibleAI,
+			Sources: []func(Source) (any, bool){
+				func(src Source) (any, bool) {
+					if src.Readme == nil {
+						return nil, false
+					}
+					var metrics []cdx.MLPerformanceMetric
+					for _, m := range src.Readme.FairnessMetrics {
+						g, mt := strings.TrimSpace(m.Group), strings.TrimSpace(m.Type)
+						if g == "" || mt == "" {
+							continue
+						}
+						metrics = append(metrics, cdx.MLPerformanceMetric{Type: mt, Value: strings.TrimSpace(m.Value), Slice: g})
+					}
+					return metrics, len(metrics) > 0
+				},
+			},
+			Parse: func(value string) (any, error) {
+				return parseFairnessMetrics(value)
+			},
+			Apply: func(tgt Target, value any) error {
+				input, ok := value.(applyInput)
+				if !ok {
+					return fmt.Errorf("invalid input for %s", ModelCardQuantitativeAnalysisFairnessMetrics)
+				}
+				if tgt.ModelCard == nil {
+					return fmt.Errorf("modelCard is nil")
+				}
+				metrics, _ := input.Value.([]cdx.MLPerformanceMetric)
+				if len(metrics) == 0 {
+					return fmt.Errorf("fairness metrics value is empty")
+				}
+				qa := ensureQuantitativeAnalysis(tgt.ModelCard)
+				overall, sliced := splitSlicedMetrics(qa.PerformanceMetrics)
+				if !input.Force && len(sliced) > 0 {
+					return nil
+				}
+				setPerformanceMetrics(qa, overall, metrics)
+				return nil
+			},
+			Present: func(b *cdx.BOM) bool {
+				c := bomComponent(b)
+				if c == nil || c.ModelCard == nil || c.ModelCard.QuantitativeAnalysis == nil {
+					return false
+				}
+				_, sliced := splitSlicedMetrics(c.ModelCard.QuantitativeAnalysis.PerformanceMetrics)
+				return len(sliced) > 0
+			},
+			Clear: func(tgt Target) error {
+				if tgt.ModelCard != nil && tgt.ModelCard.QuantitativeAnalysis != nil {
+					qa := tgt.ModelCard.QuantitativeAnalysis
+					overall, _ := splitSlicedMetrics(qa.PerformanceMetrics)
+					setPerformanceMetrics(qa, overall)
+				}
+				return nil
+			},
+			InputType:   InputTypeText,
+			Placeholder: "female/accuracy:0.91, male/accuracy:0.93",
+			Help: FieldHelp{
+				Description: "Per-group results of a bias or fairness evaluation, as group/metric:value.",
+				SpecPath:    "metadata.component.modelCard.quantitativeAnalysis.performanceMetrics[].slice",
+				Example:     "female/accuracy:0.91, male/accuracy:0.93",
+			},
+		},
+		criteria,
+	}
+}
+
+// parseFairnessMetrics parses per-group metrics entered as.
+// "group/metric:value, ...". The group is everything before the last slash.
+// of the metric.
+func parseFairnessMetrics(value string) ([]cdx.MLPerformanceMetric, error) {
+	metrics, err := parsePerformanceMetrics(value)
+	if err != nil {
+		return nil, err
+	}
+	for i, m := range metrics {
+		g, mt, ok := cutLast(m.Type, "/")
+		if !ok || strings.TrimSpace(g) == "" || strings.TrimSpace(mt) == "" {
+			return nil, fmt.Errorf("fairness metric %q: want group/metric:value", m.Type)
+		}
+		metrics[i].Slice = strings.TrimSpace(g)
+		metrics[i].Type = strings.TrimSpace(mt)
+	}
+	return metrics, nil
+}
+
+func cutLast(s, sep string) (before, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
@@ -0,0 +1,56 @@
+package metadata
+
+import (
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/internal/taxonomy"
+)
+
+// Data governance property fields. CycloneDX data governance only names the.
+// responsible parties, so the consent basis of a dataset is recorded in.
+// aibomgen:governance:* properties. They do not count towards completeness.
+const (
+	DatasetGovernanceConsent    DatasetKey = "BOM.components[DATA].properties.aibomgen:governance:consent"
+	DatasetGovernanceOptOut     DatasetKey = "BOM.components[DATA].properties.aibomgen:governance:optOut"
+	DatasetGovernanceDoNotTrain DatasetKey = "BOM.components[DATA].properties.aibomgen:governance:doNotTrain"
+)
+
+func governanceDatasetFields() []DatasetFieldSpec {
+	readme := func(get func(src DatasetSource) string) func(DatasetSource) (any, bool) {
+		return func(src DatasetSource) (any, bool) {
+			if src.Readme == nil {
+				return nil, false
+			}
+			s := strings.TrimSpace(get(src))
+			return s, s != ""
+		}
+	}
+	return []DatasetFieldSpec{
+		datasetProp(DatasetGovernanceConsent, taxonomy.GovernanceConsent, FieldHelp{
+			Description: "How the consent of the people whose data is in the dataset was obtained.",
+			Example:     "All participants gave written informed consent to the release of their recordings.",
+		}, readme(func(src DatasetSource) string { return src.Readme.ConsentStatement })),
+		datasetProp(DatasetGovernanceOptOut, taxonomy.GovernanceOptOut, FieldHelp{
+			Description: "How data subjects or rights holders can opt out or have their data removed.",
+			Example:     "Removal requests can be sent to data-removal@example.com.",
+		}, readme(func(src DatasetSource) string { return src.Readme.OptOut })),
+		datasetProp(DatasetGovernanceDoNotTrain, taxonomy.GovernanceDoNotTrain, FieldHelp{
+			Description: "Do-not-train signals honoured when the data was collected, comma-separated.",
+			Example:     "robots.txt, noai",
+		}, readme(func(src DatasetSource) string { return strings.Join(src.Readme.DoNotTrain, ", ") })),
+	}
+}
+
+// HasConsentBasis reports whether the dataset component comp records a.
+// licensing or consent basis for its use: a license, a consent statement or.
+// an opt-out mechanism.
+func HasConsentBasis(comp *cdx.Component) bool {
+	if comp == nil {
+		return false
+	}
+	if comp.Licenses != nil && len(*comp.Licenses) > 0 {
+		return true
+	}
+	return hasProperty(comp, taxonomy.GovernanceConsent) || hasProperty(comp, taxonomy.GovernanceOptOut)
+}
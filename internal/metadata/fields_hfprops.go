@@ -9,7 +9,7 @@ import (
 
 func hfPropFields() []FieldSpec {
 	return []FieldSpec{
-		hfProp(ComponentPropertiesHuggingFaceLastModified, 0.2, func(src Source) (any, bool) {
+		hfProp(ComponentPropertiesHuggingFaceLastModified, 0.2, FieldHelp{Description: "Time the model repository was last updated on Hugging Face.", Example: "2024-01-31T12:00:00.000Z"}, func(src Source) (any, bool) {
 			r := src.HF
 			if r == nil {
 				return nil, false
@@ -17,7 +17,7 @@ func hfPropFields() []FieldSpec {
 			s := strings.TrimSpace(r.LastMod)
 			return s, s != ""
 		}),
-		hfProp(ComponentPropertiesHuggingFaceCreatedAt, 0.2, func(src Source) (any, bool) {
+		hfProp(ComponentPropertiesHuggingFaceCreatedAt, 0.2, FieldHelp{Description: "Time the model repository was created on Hugging Face.", Example: "2022-03-02T23:29:04.000Z"}, func(src Source) (any, bool) {
 			r := src.HF
 			if r == nil {
 				return nil, false
@@ -25,7 +25,7 @@ func hfPropFields() []FieldSpec {
 			s := strings.TrimSpace(r.CreatedAt)
 			return s, s != ""
 		}),
-		hfProp(ComponentPropertiesHuggingFaceLanguage, 0.2, func(src Source) (any, bool) {
+		hfProp(ComponentPropertiesHuggingFaceLanguage, 0.2, FieldHelp{Description: "Natural languages the model supports.", Example: "en,fr"}, func(src Source) (any, bool) {
 			r := src.HF
 			if r == nil {
 				return nil, false
@@ -33,21 +33,21 @@ func hfPropFields() []FieldSpec {
 			s := extractLanguage(r.CardData)
 			return s, s != ""
 		}),
-		hfProp(ComponentPropertiesHuggingFaceUsedStorage, 0.2, func(src Source) (any, bool) {
+		hfProp(ComponentPropertiesHuggingFaceUsedStorage, 0.2, FieldHelp{Description: "Storage used by the model repository, in bytes.", Example: "440473133"}, func(src Source) (any, bool) {
 			r := src.HF
 			if r == nil || r.UsedStorage <= 0 {
 				return nil, false
 			}
 			return r.UsedStorage, true
 		}),
-		hfProp(ComponentPropertiesHuggingFacePrivate, 0.2, func(src Source) (any, bool) {
+		hfProp(ComponentPropertiesHuggingFacePrivate, 0.2, FieldHelp{Description: "Whether the model repository is private.", Example: "false"}, func(src Source) (any, bool) {
 			r := src.HF
 			if r == nil {
 				return nil, false
 			}
 			return r.Private, true
 		}),
-		hfProp(ComponentPropertiesHuggingFaceLibraryName, 0.2, func(src Source) (any, bool) {
+		hfProp(ComponentPropertiesHuggingFaceLibraryName, 0.2, FieldHelp{Description: "Library the model is meant to be loaded with.", Example: "transformers"}, func(src Source) (any, bool) {
 			r := src.HF
 			if r == nil {
 				return nil, false
@@ -55,21 +55,21 @@ func hfPropFields() []FieldSpec {
 			s := strings.TrimSpace(r.LibraryName)
 			return s, s != ""
 		}),
-		hfProp(ComponentPropertiesHuggingFaceDownloads, 0.2, func(src Source) (any, bool) {
+		hfProp(ComponentPropertiesHuggingFaceDownloads, 0.2, FieldHelp{Description: "Number of recent downloads on Hugging Face.", Example: "1000000"}, func(src Source) (any, bool) {
 			r := src.HF
 			if r == nil || r.Downloads <= 0 {
 				return nil, false
 			}
 			return r.Downloads, true
 		}),
-		hfProp(ComponentPropertiesHuggingFaceLikes, 0.2, func(src Source) (any, bool) {
+		hfProp(ComponentPropertiesHuggingFaceLikes, 0.2, FieldHelp{Description: "Number of likes on Hugging Face.", Example: "1500"}, func(src Source) (any, bool) {
 			r := src.HF
 			if r == nil || r.Likes <= 0 {
 				return nil, false
 			}
 			return r.Likes, true
 		}),
-		hfProp(ComponentPropertiesHuggingFaceBaseModel, 0.2, func(src Source) (any, bool) {
+		hfProp(ComponentPropertiesHuggingFaceBaseModel, 0.2, FieldHelp{Description: "Model this model was fine-tuned or derived from.", Example: "google-bert/bert-base-uncased"}, func(src Source) (any, bool) {
 			r := src.Readme
 			if r == nil {
 				return nil, false
@@ -77,7 +77,7 @@ func hfPropFields() []FieldSpec {
 			s := strings.TrimSpace(r.BaseModel)
 			return s, s != ""
 		}),
-		hfProp(ComponentPropertiesHuggingFaceContact, 0.2, func(src Source) (any, bool) {
+		hfProp(ComponentPropertiesHuggingFaceContact, 0.2, FieldHelp{Description: "Contact for questions about the model card.", Example: "ml-team@example.com"}, func(src Source) (any, bool) {
 			r := src.Readme
 			if r == nil {
 				return nil, false
@@ -88,7 +88,9 @@ func hfPropFields() []FieldSpec {
 	}
 }
 
-func hfProp(key Key, weight float64, get func(src Source) (any, bool)) FieldSpec {
+func hfProp(key Key, weight float64, help FieldHelp, get func(src Source) (any, bool)) FieldSpec {
+	help.SpecPath = "metadata.component.properties"
+	propName := propertyName(key)
 	return FieldSpec{
 		Key:      key,
 		Weight:   weight,
@@ -113,15 +115,21 @@ func hfProp(key Key, weight float64, get func(src Source) (any, bool)) FieldSpec
 				return fmt.Errorf("component is nil")
 			}
 			v := input.Value
-			propName := strings.TrimPrefix(key.String(), "BOM.metadata.component.properties.")
+			if input.Force {
+				removeProperty(tgt.Component, propName)
+			}
 			setProperty(tgt.Component, propName, strings.TrimSpace(fmt.Sprint(v)))
 			return nil
 		},
 		Present: func(b *cdx.BOM) bool {
 			c := bomComponent(b)
-			propName := strings.TrimPrefix(key.String(), "BOM.metadata.component.properties.")
 			ok := c != nil && hasProperty(c, propName)
 			return ok
 		},
+		Clear: func(tgt Target) error {
+			removeProperty(tgt.Component, propName)
+			return nil
+		},
+		Help: help,
 	}
 }
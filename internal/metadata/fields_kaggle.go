@@ -0,0 +1,142 @@
+package metadata
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/internal/fetcher"
+	"github.com/idlab-discover/aibomgen-cli/internal/taxonomy"
+)
+
+// Kaggle property fields. They record what the Kaggle API reports about a.
+// model variation or dataset and do not count towards completeness.
+const (
+	ComponentPropertiesKaggleFramework   Key = "BOM.metadata.component.properties.aibomgen:kaggle:framework"
+	ComponentPropertiesKaggleFineTunable Key = "BOM.metadata.component.properties.aibomgen:kaggle:fineTunable"
+	ComponentPropertiesKagglePublishTime Key = "BOM.metadata.component.properties.aibomgen:kaggle:publishTime"
+	ComponentPropertiesKaggleTotalBytes  Key = "BOM.metadata.component.properties.aibomgen:kaggle:totalBytes"
+	ComponentPropertiesKaggleVotes       Key = "BOM.metadata.component.properties.aibomgen:kaggle:votes"
+
+	DatasetKaggleLastUpdated     DatasetKey = "BOM.components[DATA].properties.aibomgen:kaggle:lastUpdated"
+	DatasetKaggleTotalBytes      DatasetKey = "BOM.components[DATA].properties.aibomgen:kaggle:totalBytes"
+	DatasetKaggleDownloads       DatasetKey = "BOM.components[DATA].properties.aibomgen:kaggle:downloads"
+	DatasetKaggleVotes           DatasetKey = "BOM.components[DATA].properties.aibomgen:kaggle:votes"
+	DatasetKaggleUsabilityRating DatasetKey = "BOM.components[DATA].properties.aibomgen:kaggle:usabilityRating"
+)
+
+func kaggleFields() []FieldSpec {
+	instance := func(get func(*fetcher.KaggleModelInstance) (string, bool)) func(Source) (any, bool) {
+		return func(src Source) (any, bool) {
+			if src.Kaggle == nil || src.Kaggle.Instance == nil {
+				return nil, false
+			}
+			return get(src.Kaggle.Instance)
+		}
+	}
+	return []FieldSpec{
+		hfProp(ComponentPropertiesKaggleFramework, 0, FieldHelp{Description: "Framework of the Kaggle model variation.", Example: "transformers"}, instance(func(i *fetcher.KaggleModelInstance) (string, bool) {
+			s := strings.TrimSpace(i.Framework)
+			return s, s != ""
+		})),
+		hfProp(ComponentPropertiesKaggleFineTunable, 0, FieldHelp{Description: "Whether the Kaggle model variation can be fine-tuned.", Example: "true"}, instance(func(i *fetcher.KaggleModelInstance) (string, bool) {
+			return strconv.FormatBool(i.FineTunable), true
+		})),
+		hfProp(ComponentPropertiesKaggleTotalBytes, 0, FieldHelp{Description: "Size of the Kaggle model variation, in bytes.", Example: "5017600000"}, instance(func(i *fetcher.KaggleModelInstance) (string, bool) {
+			return strconv.FormatInt(i.TotalUncompressedBytes, 10), i.TotalUncompressedBytes > 0
+		})),
+		hfProp(ComponentPropertiesKagglePublishTime, 0, FieldHelp{Description: "Time the model was published on Kaggle.", Example: "2024-02-21T13:00:00Z"}, func(src Source) (any, bool) {
+			if src.Kaggle == nil {
+				return nil, false
+			}
+			s := strings.TrimSpace(src.Kaggle.PublishTime)
+			return s, s != ""
+		}),
+		hfProp(ComponentPropertiesKaggleVotes, 0, FieldHelp{Description: "Number of upvotes of the model on Kaggle.", Example: "1200"}, func(src Source) (any, bool) {
+			if src.Kaggle == nil || src.Kaggle.VoteCount <= 0 {
+				return nil, false
+			}
+			return src.Kaggle.VoteCount, true
+		}),
+	}
+}
+
+func kaggleDatasetFields() []DatasetFieldSpec {
+	return []DatasetFieldSpec{
+		kaggleDatasetProp(DatasetKaggleLastUpdated, taxonomy.KaggleLastUpdated, FieldHelp{Description: "Time the dataset was last updated on Kaggle.", Example: "2023-11-07T09:12:00Z"}, func(d *fetcher.KaggleDataset) (string, bool) {
+			s := strings.TrimSpace(d.LastUpdated)
+			return s, s != ""
+		}),
+		kaggleDatasetProp(DatasetKaggleTotalBytes, taxonomy.KaggleTotalBytes, FieldHelp{Description: "Size of the Kaggle dataset, in bytes.", Example: "3687"}, func(d *fetcher.KaggleDataset) (string, bool) {
+			return strconv.FormatInt(d.TotalBytes, 10), d.TotalBytes > 0
+		}),
+		kaggleDatasetProp(DatasetKaggleDownloads, taxonomy.KaggleDownloads, FieldHelp{Description: "Number of downloads of the dataset on Kaggle.", Example: "250000"}, func(d *fetcher.KaggleDataset) (string, bool) {
+			return strconv.Itoa(d.DownloadCount), d.DownloadCount > 0
+		}),
+		kaggleDatasetProp(DatasetKaggleVotes, taxonomy.KaggleVotes, FieldHelp{Description: "Number of upvotes of the dataset on Kaggle.", Example: "4000"}, func(d *fetcher.KaggleDataset) (string, bool) {
+			return strconv.Itoa(d.VoteCount), d.VoteCount > 0
+		}),
+		kaggleDatasetProp(DatasetKaggleUsabilityRating, taxonomy.KaggleUsabilityRating, FieldHelp{Description: "Kaggle usability rating of the dataset (0-1).", Example: "0.79"}, func(d *fetcher.KaggleDataset) (string, bool) {
+			return strconv.FormatFloat(d.UsabilityRating, 'f', -1, 64), d.UsabilityRating > 0
+		}),
+	}
+}
+
+// kaggleDatasetProp is the dataset analog of hfProp for Kaggle properties.
+func kaggleDatasetProp(key DatasetKey, propName string, help FieldHelp, get func(*fetcher.KaggleDataset) (string, bool)) DatasetFieldSpec {
+	return datasetProp(key, propName, help, func(src DatasetSource) (any, bool) {
+		if src.Kaggle == nil {
+			return nil, false
+		}
+		return get(src.Kaggle)
+	})
+}
+
+// datasetProp builds a weight-0 dataset field stored in the propName.
+// component property.
+func datasetProp(key DatasetKey, propName string, help FieldHelp, source func(DatasetSource) (any, bool)) DatasetFieldSpec {
+	help.SpecPath = "components[].properties"
+	return DatasetFieldSpec{
+		Key:     key,
+		Sources: []func(DatasetSource) (any, bool){source},
+		Parse: func(value string) (any, error) {
+			return parseNonEmptyString(value, "property")
+		},
+		Apply: func(tgt DatasetTarget, value any) error {
+			input, ok := value.(applyInput)
+			if !ok {
+				return fmt.Errorf("invalid input for %s", key)
+			}
+			if tgt.Component == nil {
+				return fmt.Errorf("component is nil")
+			}
+			if input.Force {
+				removeProperty(tgt.Component, propName)
+			}
+			setProperty(tgt.Component, propName, strings.TrimSpace(fmt.Sprint(input.Value)))
+			return nil
+		},
+		Present: func(comp *cdx.Component) bool {
+			return hasProperty(comp, propName)
+		},
+		Clear: func(tgt DatasetTarget) error {
+			removeProperty(tgt.Component, propName)
+			return nil
+		},
+		Help: help,
+	}
+}
+
+// kaggleTagNames returns the names of Kaggle tags, or their refs when unnamed.
+func kaggleTagNames(tags []fetcher.KaggleTag) []string {
+	out := make([]string, 0, len(tags))
+	for _, t := range tags {
+		if name := strings.TrimSpace(t.Name); name != "" {
+			out = append(out, name)
+		} else if ref := strings.TrimSpace(t.Ref); ref != "" {
+			out = append(out, ref)
+		}
+	}
+	return out
+}
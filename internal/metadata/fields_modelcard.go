@@ -65,9 +65,20 @@ func modelCardFields() []FieldSpec {
 				ok := mp != nil && strings.TrimSpace(mp.Task) != ""
 				return ok
 			},
+			Clear: func(tgt Target) error {
+				if mp := targetModelParameters(tgt); mp != nil {
+					mp.Task = ""
+				}
+				return nil
+			},
 			InputType:   InputTypeSelect,
 			Placeholder: "Select the primary task",
 			Suggestions: []string{"text-classification", "text-generation", "token-classification", "question-answering", "summarization", "translation", "image-classification", "object-detection", "image-segmentation", "audio-classification", "automatic-speech-recognition"},
+			Help: FieldHelp{
+				Description: "Primary machine learning task the model performs.",
+				Example:     "text-classification",
+				SpecPath:    "metadata.component.modelCard.modelParameters.task",
+			},
 		},
 		{
 			Key:      ModelCardModelParametersArchitectureFamily,
@@ -84,6 +95,20 @@ func modelCardFields() []FieldSpec {
 					}
 					return s, true
 				},
+				func(src Source) (any, bool) {
+					if src.GGUF == nil {
+						return nil, false
+					}
+					s := strings.TrimSpace(src.GGUF.Architecture)
+					return s, s != ""
+				},
+				func(src Source) (any, bool) {
+					if src.ONNX == nil {
+						return nil, false
+					}
+					s := strings.TrimSpace(src.ONNX.Metadata["model_type"])
+					return s, s != ""
+				},
 			},
 			Parse: func(value string) (any, error) {
 				return parseNonEmptyString(value, "architectureFamily")
@@ -110,9 +135,20 @@ func modelCardFields() []FieldSpec {
 				ok := mp != nil && strings.TrimSpace(mp.ArchitectureFamily) != ""
 				return ok
 			},
+			Clear: func(tgt Target) error {
+				if mp := targetModelParameters(tgt); mp != nil {
+					mp.ArchitectureFamily = ""
+				}
+				return nil
+			},
 			InputType:   InputTypeText,
 			Placeholder: "e.g., transformer, cnn, rnn",
 			Suggestions: []string{"transformer", "cnn", "rnn", "lstm", "gru", "diffusion"},
+			Help: FieldHelp{
+				Description: "General family of the model architecture.",
+				Example:     "transformer",
+				SpecPath:    "metadata.component.modelCard.modelParameters.architectureFamily",
+			},
 		},
 		{
 			Key:      ModelCardModelParametersModelArchitecture,
@@ -132,6 +168,13 @@ func modelCardFields() []FieldSpec {
 					}
 					return s, true
 				},
+				func(src Source) (any, bool) {
+					if src.ONNX == nil {
+						return nil, false
+					}
+					s := onnxModelArchitecture(src.ONNX)
+					return s, s != ""
+				},
 			},
 			Parse: func(value string) (any, error) {
 				return parseNonEmptyString(value, "modelArchitecture")
@@ -158,8 +201,19 @@ func modelCardFields() []FieldSpec {
 				ok := mp != nil && strings.TrimSpace(mp.ModelArchitecture) != ""
 				return ok
 			},
+			Clear: func(tgt Target) error {
+				if mp := targetModelParameters(tgt); mp != nil {
+					mp.ModelArchitecture = ""
+				}
+				return nil
+			},
 			InputType:   InputTypeText,
 			Placeholder: "e.g., BertForSequenceClassification",
+			Help: FieldHelp{
+				Description: "Specific architecture class of the model.",
+				Example:     "BertForMaskedLM",
+				SpecPath:    "metadata.component.modelCard.modelParameters.modelArchitecture",
+			},
 		},
 		{
 			Key:      ModelCardModelParametersDatasets,
@@ -199,6 +253,20 @@ func modelCardFields() []FieldSpec {
 					}
 					return choices, true
 				},
+				func(src Source) (any, bool) {
+					if src.Kaggle == nil || src.Kaggle.Instance == nil {
+						return nil, false
+					}
+					ds := normalizeStrings(src.Kaggle.Instance.TrainingData)
+					if len(ds) == 0 {
+						return nil, false
+					}
+					choices := make([]cdx.MLDatasetChoice, 0, len(ds))
+					for _, ref := range ds {
+						choices = append(choices, cdx.MLDatasetChoice{Ref: normalizeDatasetRef(ref)})
+					}
+					return choices, true
+				},
 			},
 			Parse: func(value string) (any, error) {
 				return parseDatasetRefs(value)
@@ -234,8 +302,19 @@ func modelCardFields() []FieldSpec {
 				}
 				return false
 			},
+			Clear: func(tgt Target) error {
+				if mp := targetModelParameters(tgt); mp != nil {
+					mp.Datasets = nil
+				}
+				return nil
+			},
 			InputType:   InputTypeMultiText,
 			Placeholder: "dataset1, dataset2, dataset3",
+			Help: FieldHelp{
+				Description: "Datasets used to train or fine-tune the model.",
+				Example:     "bookcorpus, wikipedia",
+				SpecPath:    "metadata.component.modelCard.modelParameters.datasets",
+			},
 		},
 		{
 			Key:      ModelCardConsiderationsUseCases,
@@ -287,8 +366,19 @@ func modelCardFields() []FieldSpec {
 				ok := c != nil && c.ModelCard != nil && c.ModelCard.Considerations != nil && c.ModelCard.Considerations.UseCases != nil && len(*c.ModelCard.Considerations.UseCases) > 0
 				return ok
 			},
+			Clear: func(tgt Target) error {
+				if cons := targetConsiderations(tgt); cons != nil {
+					cons.UseCases = nil
+				}
+				return nil
+			},
 			InputType:   InputTypeMultiText,
 			Placeholder: "use case 1, use case 2",
+			Help: FieldHelp{
+				Description: "Intended uses of the model.",
+				Example:     "Masked language modeling; fine-tuning for classification",
+				SpecPath:    "metadata.component.modelCard.considerations.useCases",
+			},
 		},
 		{
 			Key:      ModelCardConsiderationsTechnicalLimitations,
@@ -333,13 +423,25 @@ func modelCardFields() []FieldSpec {
 				ok := c != nil && c.ModelCard != nil && c.ModelCard.Considerations != nil && c.ModelCard.Considerations.TechnicalLimitations != nil && len(*c.ModelCard.Considerations.TechnicalLimitations) > 0
 				return ok
 			},
+			Clear: func(tgt Target) error {
+				if cons := targetConsiderations(tgt); cons != nil {
+					cons.TechnicalLimitations = nil
+				}
+				return nil
+			},
 			InputType:   InputTypeTextArea,
 			Placeholder: "limitation1,limitation2,limitation3",
+			Help: FieldHelp{
+				Description: "Known technical limitations and out-of-scope uses.",
+				Example:     "Not trained to produce factual statements about people or events",
+				SpecPath:    "metadata.component.modelCard.considerations.technicalLimitations",
+			},
 		},
 		{
 			Key:      ModelCardConsiderationsEthicalConsiderations,
 			Weight:   0.25,
 			Required: false,
+			Category: CategoryResponsibleAI,
 			Sources: []func(Source) (any, bool){
 				func(src Source) (any, bool) {
 					if src.Readme == nil {
@@ -384,8 +486,19 @@ func modelCardFields() []FieldSpec {
 				ok := c != nil && c.ModelCard != nil && c.ModelCard.Considerations != nil && c.ModelCard.Considerations.EthicalConsiderations != nil && len(*c.ModelCard.Considerations.EthicalConsiderations) > 0
 				return ok
 			},
+			Clear: func(tgt Target) error {
+				if cons := targetConsiderations(tgt); cons != nil {
+					cons.EthicalConsiderations = nil
+				}
+				return nil
+			},
 			InputType:   InputTypeTextArea,
 			Placeholder: "bias:mitigation strategy,privacy concerns,fairness issues",
+			Help: FieldHelp{
+				Description: "Ethical risks of the model, such as bias, with their mitigations.",
+				Example:     "Predictions can reflect social biases in the training data",
+				SpecPath:    "metadata.component.modelCard.considerations.ethicalConsiderations",
+			},
 		},
 		{
 			Key:      ModelCardQuantitativeAnalysisPerformanceMetrics,
@@ -457,20 +570,38 @@ func modelCardFields() []FieldSpec {
 				if len(metrics) == 0 {
 					return fmt.Errorf("performanceMetrics value is empty")
 				}
-				if !input.Force && tgt.ModelCard.QuantitativeAnalysis != nil && tgt.ModelCard.QuantitativeAnalysis.PerformanceMetrics != nil && len(*tgt.ModelCard.QuantitativeAnalysis.PerformanceMetrics) > 0 {
+				// Per-group metrics belong to the fairness metrics field.
+				qa := ensureQuantitativeAnalysis(tgt.ModelCard)
+				overall, sliced := splitSlicedMetrics(qa.PerformanceMetrics)
+				if !input.Force && len(overall) > 0 {
 					return nil
 				}
-				qa := ensureQuantitativeAnalysis(tgt.ModelCard)
-				qa.PerformanceMetrics = &metrics
+				setPerformanceMetrics(qa, metrics, sliced)
 				return nil
 			},
 			Present: func(b *cdx.BOM) bool {
 				c := bomComponent(b)
-				ok := c != nil && c.ModelCard != nil && c.ModelCard.QuantitativeAnalysis != nil && c.ModelCard.QuantitativeAnalysis.PerformanceMetrics != nil && len(*c.ModelCard.QuantitativeAnalysis.PerformanceMetrics) > 0
-				return ok
+				if c == nil || c.ModelCard == nil || c.ModelCard.QuantitativeAnalysis == nil {
+					return false
+				}
+				overall, _ := splitSlicedMetrics(c.ModelCard.QuantitativeAnalysis.PerformanceMetrics)
+				return len(overall) > 0
+			},
+			Clear: func(tgt Target) error {
+				if tgt.ModelCard != nil && tgt.ModelCard.QuantitativeAnalysis != nil {
+					qa := tgt.ModelCard.QuantitativeAnalysis
+					_, sliced := splitSlicedMetrics(qa.PerformanceMetrics)
+					setPerformanceMetrics(qa, sliced)
+				}
+				return nil
 			},
 			InputType:   InputTypeTextArea,
 			Placeholder: "accuracy:0.95,f1:0.92,precision:0.88",
+			Help: FieldHelp{
+				Description: "Evaluation results of the model as metric and value pairs.",
+				Example:     "accuracy:0.91, f1:0.88",
+				SpecPath:    "metadata.component.modelCard.quantitativeAnalysis.performanceMetrics",
+			},
 		},
 		{
 			Key:      ModelCardConsiderationsEnvironmentalConsiderationsProperties,
@@ -534,8 +665,19 @@ func modelCardFields() []FieldSpec {
 				ok := c != nil && c.ModelCard != nil && c.ModelCard.Considerations != nil && c.ModelCard.Considerations.EnvironmentalConsiderations != nil && c.ModelCard.Considerations.EnvironmentalConsiderations.Properties != nil && len(*c.ModelCard.Considerations.EnvironmentalConsiderations.Properties) > 0
 				return ok
 			},
+			Clear: func(tgt Target) error {
+				if cons := targetConsiderations(tgt); cons != nil && cons.EnvironmentalConsiderations != nil {
+					cons.EnvironmentalConsiderations.Properties = nil
+				}
+				return nil
+			},
 			InputType:   InputTypeTextArea,
 			Placeholder: "hardwareType:GPU,hoursUsed:100,carbonEmitted:50kg",
+			Help: FieldHelp{
+				Description: "Training compute and carbon footprint, as name and value pairs.",
+				Example:     "hardwareType:NVIDIA A100, hoursUsed:24, carbonEmitted:12kg",
+				SpecPath:    "metadata.component.modelCard.considerations.environmentalConsiderations.properties",
+			},
 		},
 	}
 }
@@ -0,0 +1,80 @@
+package metadata
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/idlab-discover/aibomgen-cli/internal/fetcher"
+)
+
+// Model size fields. The parameter count (stored in.
+// aibomgen:weights:parameterCount, as CycloneDX has no field for it) and the.
+// context length count towards completeness.
+const (
+	ComponentPropertiesWeightsContextLength Key = "BOM.metadata.component.properties.aibomgen:weights:contextLength"
+)
+
+// modelParameterCount returns the parameter count of a model: counted from.
+// the tensors of a local weight file, else as the Hub reports it for the.
+// safetensors weights of the repo, else as the model card states it.
+func modelParameterCount(src Source) uint64 {
+	switch {
+	case src.GGUF != nil && src.GGUF.ParameterCount > 0:
+		return src.GGUF.ParameterCount
+	case src.ONNX != nil && src.ONNX.ParameterCount > 0:
+		return src.ONNX.ParameterCount
+	case src.Safetensors != nil && src.Safetensors.ParameterCount > 0:
+		return src.Safetensors.ParameterCount
+	case src.HF != nil && src.HF.Safetensors != nil && src.HF.Safetensors.Total > 0:
+		return src.HF.Safetensors.Total
+	case src.Readme != nil:
+		return src.Readme.ParameterCount
+	}
+	return 0
+}
+
+// modelContextLength returns the context length of a model: declared by a.
+// local GGUF file, else by config.json, else as the model card states it.
+func modelContextLength(src Source) uint64 {
+	switch {
+	case src.GGUF != nil && src.GGUF.ContextLength > 0:
+		return src.GGUF.ContextLength
+	case src.Config != nil && src.Config.ContextLength > 0:
+		return src.Config.ContextLength
+	case src.Readme != nil:
+		return src.Readme.ContextLength
+	}
+	return 0
+}
+
+func modelSizeFields() []FieldSpec {
+	count := func(get func(Source) uint64) func(Source) (any, bool) {
+		return func(src Source) (any, bool) {
+			n := get(src)
+			if n == 0 {
+				return nil, false
+			}
+			return strconv.FormatUint(n, 10), true
+		}
+	}
+	// parseCount accepts magnitude suffixes ("7B", "8k") and stores the count.
+	parseCount := func(name string) func(string) (any, error) {
+		return func(value string) (any, error) {
+			n, ok := fetcher.ParseModelSize(value)
+			if !ok {
+				return nil, fmt.Errorf("invalid %s %q (expected a count such as 7000000000, 7B or 8k)", name, value)
+			}
+			return strconv.FormatUint(n, 10), nil
+		}
+	}
+
+	params := hfProp(ModelCardModelParametersNumberOfParameters, 0.5, FieldHelp{Description: "Number of parameters of the model.", Example: "8030261248"}, count(modelParameterCount))
+	params.Parse = parseCount("parameter count")
+	params.Placeholder = "7B"
+
+	context := hfProp(ComponentPropertiesWeightsContextLength, 0.25, FieldHelp{Description: "Context length of the model, in tokens.", Example: "8192"}, count(modelContextLength))
+	context.Parse = parseCount("context length")
+	context.Placeholder = "8192"
+
+	return []FieldSpec{params, context}
+}
@@ -0,0 +1,81 @@
+package metadata
+
+import (
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/internal/fetcher"
+)
+
+// Ollama property fields. They record what the Ollama registry reports about.
+// a model tag and do not count towards completeness.
+const (
+	ComponentPropertiesOllamaDigest        Key = "BOM.metadata.component.properties.aibomgen:ollama:digest"
+	ComponentPropertiesOllamaFamily        Key = "BOM.metadata.component.properties.aibomgen:ollama:family"
+	ComponentPropertiesOllamaParameterSize Key = "BOM.metadata.component.properties.aibomgen:ollama:parameterSize"
+	ComponentPropertiesOllamaQuantization  Key = "BOM.metadata.component.properties.aibomgen:ollama:quantization"
+)
+
+func ollamaFields() []FieldSpec {
+	config := func(get func(fetcher.OllamaConfig) string) func(Source) (any, bool) {
+		return func(src Source) (any, bool) {
+			if src.Ollama == nil {
+				return nil, false
+			}
+			s := strings.TrimSpace(get(src.Ollama.Config))
+			return s, s != ""
+		}
+	}
+	return []FieldSpec{
+		hfProp(ComponentPropertiesOllamaDigest, 0, FieldHelp{Description: "Digest of the manifest of the Ollama model tag.", Example: "sha256:365c0bd3c000a25d28ddbf732fe1c6add414de7275464c4e4d1c3b5fcb5d8ad1"}, func(src Source) (any, bool) {
+			if src.Ollama == nil {
+				return nil, false
+			}
+			s := strings.TrimSpace(src.Ollama.Digest)
+			return s, s != ""
+		}),
+		hfProp(ComponentPropertiesOllamaFamily, 0, FieldHelp{Description: "Model family of the Ollama model.", Example: "llama"}, config(func(c fetcher.OllamaConfig) string {
+			return c.ModelFamily
+		})),
+		hfProp(ComponentPropertiesOllamaParameterSize, 0, FieldHelp{Description: "Parameter size the Ollama registry reports.", Example: "8.0B"}, config(func(c fetcher.OllamaConfig) string {
+			return c.ModelType
+		})),
+		hfProp(ComponentPropertiesOllamaQuantization, 0, FieldHelp{Description: "Quantization of the weights of the Ollama model.", Example: "Q4_0"}, config(func(c fetcher.OllamaConfig) string {
+			return c.FileType
+		})),
+	}
+}
+
+// ollamaLicenseName names the license text of an Ollama model: the SPDX.
+// identifier of well-known licenses, else its title (the first line).
+func ollamaLicenseName(text string) string {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return ""
+	}
+	title, _, _ := strings.Cut(text, "\n")
+	title = strings.TrimSpace(title)
+	switch {
+	case strings.HasPrefix(title, "Apache License") && strings.Contains(text, "Version 2.0"):
+		return "Apache-2.0"
+	case strings.HasPrefix(title, "MIT License") || title == "MIT":
+		return "MIT"
+	}
+	if len(title) > 120 {
+		title = title[:120]
+	}
+	return title
+}
+
+// ollamaWeightsHash returns the SHA-256 digest of the weights layer of m.
+func ollamaWeightsHash(m *fetcher.OllamaModel) (any, bool) {
+	l, ok := m.Layer(fetcher.OllamaLayerModel)
+	if !ok {
+		return nil, false
+	}
+	sum, ok := strings.CutPrefix(l.Digest, "sha256:")
+	if !ok || sum == "" {
+		return nil, false
+	}
+	return cdx.Hash{Algorithm: cdx.HashAlgoSHA256, Value: sum}, true
+}
@@ -0,0 +1,145 @@
+package metadata
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/internal/fetcher"
+)
+
+// ONNX property fields. They record the provenance a local ONNX file declares.
+// and do not count towards completeness.
+const (
+	ComponentPropertiesONNXProducer  Key = "BOM.metadata.component.properties.aibomgen:onnx:producer"
+	ComponentPropertiesONNXIRVersion Key = "BOM.metadata.component.properties.aibomgen:onnx:irVersion"
+	ComponentPropertiesONNXOpsets    Key = "BOM.metadata.component.properties.aibomgen:onnx:opsets"
+	ComponentPropertiesONNXDocString Key = "BOM.metadata.component.properties.aibomgen:onnx:docString"
+)
+
+// maxONNXDocString bounds the length of the aibomgen:onnx:docString property.
+const maxONNXDocString = 1000
+
+// genericONNXGraphNames are the graph names exporters write by default. They.
+// say nothing about the architecture of the model.
+var genericONNXGraphNames = map[string]bool{
+	"torch_jit": true, "torch-jit-export": true, "main_graph": true, "graph": true,
+	"model": true, "tf2onnx": true, "onnx_graph": true, "test": true,
+}
+
+// onnxModelArchitecture returns the graph name of an ONNX model when it is.
+// not an exporter default.
+func onnxModelArchitecture(m *fetcher.ONNXModel) string {
+	name := strings.TrimSpace(m.GraphName)
+	if genericONNXGraphNames[strings.ToLower(name)] {
+		return ""
+	}
+	return name
+}
+
+func onnxFields() []FieldSpec {
+	onnx := func(get func(*fetcher.ONNXModel) string) func(Source) (any, bool) {
+		return func(src Source) (any, bool) {
+			if src.ONNX == nil {
+				return nil, false
+			}
+			s := strings.TrimSpace(get(src.ONNX))
+			return s, s != ""
+		}
+	}
+	return []FieldSpec{
+		modelIOField(ModelCardModelParametersInputs, "inputs", func(m *fetcher.ONNXModel) []fetcher.ONNXValueInfo { return m.Inputs },
+			func(mp *cdx.MLModelParameters) **[]cdx.MLInputOutputParameters { return &mp.Inputs },
+			FieldHelp{Description: "Inputs of the model, as name, element type and shape.", Example: "input_ids: int64[batch, sequence]"}),
+		modelIOField(ModelCardModelParametersOutputs, "outputs", func(m *fetcher.ONNXModel) []fetcher.ONNXValueInfo { return m.Outputs },
+			func(mp *cdx.MLModelParameters) **[]cdx.MLInputOutputParameters { return &mp.Outputs },
+			FieldHelp{Description: "Outputs of the model, as name, element type and shape.", Example: "logits: float32[batch, 2]"}),
+		hfProp(ComponentPropertiesONNXProducer, 0, FieldHelp{Description: "Tool and version that exported the ONNX model.", Example: "pytorch 2.1.0"}, onnx(func(m *fetcher.ONNXModel) string {
+			return m.Producer()
+		})),
+		hfProp(ComponentPropertiesONNXIRVersion, 0, FieldHelp{Description: "ONNX IR version of the model file.", Example: "8"}, onnx(func(m *fetcher.ONNXModel) string {
+			return strconv.FormatInt(m.IRVersion, 10)
+		})),
+		hfProp(ComponentPropertiesONNXOpsets, 0, FieldHelp{Description: "Operator sets the ONNX model imports, with their versions.", Example: "ai.onnx 17, com.microsoft 1"}, onnx(func(m *fetcher.ONNXModel) string {
+			return m.OpsetString()
+		})),
+		hfProp(ComponentPropertiesONNXDocString, 0, FieldHelp{Description: "Documentation string of the ONNX model.", Example: "BERT base fine-tuned on SST-2."}, onnx(func(m *fetcher.ONNXModel) string {
+			s := strings.Join(strings.Fields(m.DocString), " ")
+			if r := []rune(s); len(r) > maxONNXDocString {
+				s = string(r[:maxONNXDocString]) + "…"
+			}
+			return s
+		})),
+	}
+}
+
+// modelIOField builds the FieldSpec of the inputs or outputs of the model.
+// parameters. Values are entered separated by semicolons, as shapes contain.
+// commas. Only local ONNX files supply them, so they do not count towards.
+// completeness.
+func modelIOField(key Key, name string, get func(*fetcher.ONNXModel) []fetcher.ONNXValueInfo, field func(*cdx.MLModelParameters) **[]cdx.MLInputOutputParameters, help FieldHelp) FieldSpec {
+	help.SpecPath = "metadata.component.modelCard.modelParameters." + name
+	present := func(mp *cdx.MLModelParameters) bool {
+		return mp != nil && *field(mp) != nil && len(**field(mp)) > 0
+	}
+	return FieldSpec{
+		Key:      key,
+		Weight:   0,
+		Required: false,
+		Sources: []func(Source) (any, bool){
+			func(src Source) (any, bool) {
+				if src.ONNX == nil {
+					return nil, false
+				}
+				var params []cdx.MLInputOutputParameters
+				for _, v := range get(src.ONNX) {
+					params = append(params, cdx.MLInputOutputParameters{Format: v.String()})
+				}
+				return params, len(params) > 0
+			},
+		},
+		Parse: func(value string) (any, error) {
+			var params []cdx.MLInputOutputParameters
+			for _, part := range strings.Split(value, ";") {
+				if part = strings.TrimSpace(part); part != "" {
+					params = append(params, cdx.MLInputOutputParameters{Format: part})
+				}
+			}
+			if len(params) == 0 {
+				return nil, fmt.Errorf("%s value is empty", name)
+			}
+			return params, nil
+		},
+		Apply: func(tgt Target, value any) error {
+			input, ok := value.(applyInput)
+			if !ok {
+				return fmt.Errorf("invalid input for %s", key)
+			}
+			if tgt.ModelCard == nil {
+				return fmt.Errorf("modelCard is nil")
+			}
+			params, _ := input.Value.([]cdx.MLInputOutputParameters)
+			if len(params) == 0 {
+				return fmt.Errorf("%s value is empty", name)
+			}
+			if !input.Force && present(tgt.ModelCard.ModelParameters) {
+				return nil
+			}
+			*field(ensureModelParameters(tgt.ModelCard)) = &params
+			return nil
+		},
+		Present: func(b *cdx.BOM) bool {
+			return present(bomModelParameters(b))
+		},
+		Clear: func(tgt Target) error {
+			if mp := targetModelParameters(tgt); mp != nil {
+				*field(mp) = nil
+			}
+			return nil
+		},
+		InputType:   InputTypeText,
+		Placeholder: "name: float32[batch, 3, 224, 224]; ...",
+		Help:        help,
+	}
+}
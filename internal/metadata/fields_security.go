@@ -9,13 +9,13 @@ import (
 
 func securityFields() []FieldSpec {
 	return []FieldSpec{
-		hfProp(ComponentPropertiesSecurityOverallStatus, 0.3, func(src Source) (any, bool) {
+		hfProp(ComponentPropertiesSecurityOverallStatus, 0.3, FieldHelp{Description: "Worst security scan result across the repository files.", Example: "safe"}, func(src Source) (any, bool) {
 			if len(src.SecurityTree) == 0 {
 				return nil, false
 			}
 			return overallSecurityStatus(src.SecurityTree), true
 		}),
-		hfProp(ComponentPropertiesSecurityScannedFiles, 0.2, func(src Source) (any, bool) {
+		hfProp(ComponentPropertiesSecurityScannedFiles, 0.2, FieldHelp{Description: "Number of repository files with a security scan result.", Example: "12"}, func(src Source) (any, bool) {
 			if len(src.SecurityTree) == 0 {
 				return nil, false
 			}
@@ -27,7 +27,7 @@ func securityFields() []FieldSpec {
 			}
 			return fmt.Sprintf("%d", n), true
 		}),
-		hfProp(ComponentPropertiesSecurityUnsafeFiles, 0.2, func(src Source) (any, bool) {
+		hfProp(ComponentPropertiesSecurityUnsafeFiles, 0.2, FieldHelp{Description: "Number of files the security scanners flagged as unsafe.", Example: "0"}, func(src Source) (any, bool) {
 			if len(src.SecurityTree) == 0 {
 				return nil, false
 			}
@@ -39,7 +39,7 @@ func securityFields() []FieldSpec {
 			}
 			return fmt.Sprintf("%d", n), true
 		}),
-		hfProp(ComponentPropertiesSecurityCautionFiles, 0.2, func(src Source) (any, bool) {
+		hfProp(ComponentPropertiesSecurityCautionFiles, 0.2, FieldHelp{Description: "Number of files the security scanners flagged for caution.", Example: "1"}, func(src Source) (any, bool) {
 			if len(src.SecurityTree) == 0 {
 				return nil, false
 			}
@@ -0,0 +1,64 @@
+package metadata
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/idlab-discover/aibomgen-cli/internal/taxonomy"
+)
+
+// Synthetic data fields. A dataset generated by a model is flagged in.
+// aibomgen:data:synthetic and names the generating model in.
+// aibomgen:data:generatingModel; builder.AddGeneratingModels links the dataset.
+// to that model's component. They do not count towards completeness.
+const (
+	DatasetSynthetic       DatasetKey = "BOM.components[DATA].properties.aibomgen:data:synthetic"
+	DatasetGeneratingModel DatasetKey = "BOM.components[DATA].properties.aibomgen:data:generatingModel"
+)
+
+func syntheticDatasetFields() []DatasetFieldSpec {
+	synthetic := datasetProp(DatasetSynthetic, taxonomy.DataSynthetic, FieldHelp{
+		Description: "Whether the dataset was generated by a model rather than collected.",
+		Example:     "true",
+	}, func(src DatasetSource) (any, bool) {
+		if src.Readme != nil && src.Readme.Synthetic {
+			return "true", true
+		}
+		if src.HF != nil {
+			for _, tag := range src.HF.Tags {
+				if strings.EqualFold(tag, "synthetic") {
+					return "true", true
+				}
+			}
+		}
+		return nil, false
+	})
+	synthetic.Parse = func(value string) (any, error) {
+		s := strings.ToLower(strings.TrimSpace(value))
+		switch s {
+		case "yes":
+			s = "true"
+		case "no":
+			s = "false"
+		}
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, fmt.Errorf("synthetic %q: want true or false", value)
+		}
+		return strconv.FormatBool(b), nil
+	}
+
+	generatingModel := datasetProp(DatasetGeneratingModel, taxonomy.DataGeneratingModel, FieldHelp{
+		Description: "Hugging Face ID of the model that generated the dataset.",
+		Example:     "meta-llama/Meta-Llama-3-70B-Instruct",
+	}, func(src DatasetSource) (any, bool) {
+		if src.Readme == nil {
+			return nil, false
+		}
+		s := strings.TrimSpace(src.Readme.GeneratingModel)
+		return s, s != ""
+	})
+
+	return []DatasetFieldSpec{synthetic, generatingModel}
+}
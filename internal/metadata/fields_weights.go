@@ -0,0 +1,82 @@
+package metadata
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/idlab-discover/aibomgen-cli/internal/fetcher"
+)
+
+// Weight file property fields. They record what the header of a local weight.
+// file (GGUF, ONNX, safetensors) declares and do not count towards.
+// completeness. The parameter count and context length are model size fields.
+// (see fields_modelsize.go).
+const (
+	ComponentPropertiesWeightsQuantization   Key = "BOM.metadata.component.properties.aibomgen:weights:quantization"
+	ComponentPropertiesWeightsTokenizer      Key = "BOM.metadata.component.properties.aibomgen:weights:tokenizer"
+	ComponentPropertiesWeightsVocabularySize Key = "BOM.metadata.component.properties.aibomgen:weights:vocabularySize"
+	ComponentPropertiesWeightsTensorCount    Key = "BOM.metadata.component.properties.aibomgen:weights:tensorCount"
+	ComponentPropertiesWeightsDTypes         Key = "BOM.metadata.component.properties.aibomgen:weights:dtypes"
+	ComponentPropertiesSafetensorsMetadata   Key = "BOM.metadata.component.properties.aibomgen:safetensors:metadata"
+)
+
+func weightsFields() []FieldSpec {
+	gguf := func(get func(*fetcher.GGUFHeader) string) func(Source) (any, bool) {
+		return func(src Source) (any, bool) {
+			if src.GGUF == nil {
+				return nil, false
+			}
+			s := strings.TrimSpace(get(src.GGUF))
+			return s, s != ""
+		}
+	}
+	count := func(n uint64) string {
+		if n == 0 {
+			return ""
+		}
+		return strconv.FormatUint(n, 10)
+	}
+	return []FieldSpec{
+		hfProp(ComponentPropertiesWeightsQuantization, 0, FieldHelp{Description: "Quantization or precision of the weight file.", Example: "Q4_K_M"}, func(src Source) (any, bool) {
+			var s string
+			switch {
+			case src.GGUF != nil:
+				s = src.GGUF.Quantization
+			case src.Safetensors != nil && len(src.Safetensors.DTypes) > 0:
+				// The data type that holds most parameters.
+				s = src.Safetensors.DTypes[0].DType
+			}
+			return s, s != ""
+		}),
+		hfProp(ComponentPropertiesWeightsTokenizer, 0, FieldHelp{Description: "Tokenizer model embedded in the weight file.", Example: "gpt2"}, gguf(func(h *fetcher.GGUFHeader) string {
+			return h.Tokenizer
+		})),
+		hfProp(ComponentPropertiesWeightsVocabularySize, 0, FieldHelp{Description: "Number of tokens in the vocabulary embedded in the weight file.", Example: "128256"}, gguf(func(h *fetcher.GGUFHeader) string {
+			return count(h.VocabularySize)
+		})),
+		hfProp(ComponentPropertiesWeightsTensorCount, 0, FieldHelp{Description: "Number of tensors in the weight file.", Example: "291"}, func(src Source) (any, bool) {
+			var s string
+			switch {
+			case src.GGUF != nil:
+				s = count(src.GGUF.TensorCount)
+			case src.Safetensors != nil:
+				s = count(uint64(src.Safetensors.TensorCount))
+			}
+			return s, s != ""
+		}),
+		hfProp(ComponentPropertiesWeightsDTypes, 0, FieldHelp{Description: "Data types of the tensors in the weight file, most parameters first.", Example: "BF16, F32"}, func(src Source) (any, bool) {
+			if src.Safetensors == nil {
+				return nil, false
+			}
+			s := src.Safetensors.DTypeString()
+			return s, s != ""
+		}),
+		hfProp(ComponentPropertiesSafetensorsMetadata, 0, FieldHelp{Description: "Free-form __metadata__ entries of the safetensors file, as key=value pairs.", Example: "format=pt"}, func(src Source) (any, bool) {
+			if src.Safetensors == nil {
+				return nil, false
+			}
+			s := src.Safetensors.MetadataString()
+			return s, s != ""
+		}),
+	}
+}
@@ -1,11 +1,13 @@
 package metadata
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 
 	cdx "github.com/CycloneDX/cyclonedx-go"
 	"github.com/idlab-discover/aibomgen-cli/internal/fetcher"
+	"github.com/idlab-discover/aibomgen-cli/internal/taxonomy"
 	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/scanner"
 )
 
@@ -56,6 +58,7 @@ func TestRegistryApplyAndPresent(t *testing.T) {
 		},
 		Readme: &fetcher.ModelReadmeCard{
 			BaseModel:                  "bert-base-uncased",
+			TaskType:                   "text-classification",
 			Tags:                       []string{"tag-readme"},
 			License:                    "apache-2.0",
 			Datasets:                   []string{"glue"},
@@ -71,16 +74,50 @@ func TestRegistryApplyAndPresent(t *testing.T) {
 			EnvironmentalComputeRegion: "us-east-1",
 			EnvironmentalCarbonEmitted: "123g",
 			ModelIndexMetrics:          []fetcher.ModelIndexMetric{{Type: "accuracy", Value: "0.91"}},
+			Attribution:                "Please credit hf-author.",
+			FairnessMetrics:            []fetcher.FairnessMetric{{Group: "female", Type: "accuracy", Value: "0.9"}},
+			FairnessCriteria:           []string{"demographic parity"},
+			IntendedUsers:              []string{"Researchers"},
+			DeploymentContext:          "Internal document triage.",
+			HumanOversight:             "An analyst reviews every label.",
+			RiskClassification:         "limited",
+			Contacts: []fetcher.Contact{
+				{Role: fetcher.ContactRoleGeneral, Email: "contact@example.com"},
+				{Role: fetcher.ContactRoleSecurity, Email: "security@example.com"},
+				{Role: fetcher.ContactRoleIssues, URL: "https://github.com/hf-org/model/issues"},
+			},
 		},
 	}
 	src.HF.Config.ModelType = "bert"
 	src.HF.Config.Architectures = []string{"BertForSequenceClassification"}
+	src.Kaggle = &fetcher.KaggleModel{
+		PublishTime: "2024-02-21T13:00:00Z",
+		VoteCount:   3,
+		Instance:    &fetcher.KaggleModelInstance{Framework: "keras", TotalUncompressedBytes: 10},
+	}
+	src.Ollama = &fetcher.OllamaModel{
+		Digest: "sha256:abc",
+		Config: fetcher.OllamaConfig{ModelFamily: "bert", ModelType: "110M", FileType: "F16"},
+	}
+	src.ONNX = &fetcher.ONNXModel{
+		IRVersion: 8, ProducerName: "pytorch", ProducerVersion: "2.1.0", DocString: "BERT.",
+		Opsets:  []fetcher.ONNXOpset{{Domain: "ai.onnx", Version: 17}},
+		Inputs:  []fetcher.ONNXValueInfo{{Name: "input_ids", ElemType: "int64", Shape: []string{"batch", "sequence"}}},
+		Outputs: []fetcher.ONNXValueInfo{{Name: "logits", ElemType: "float32", Shape: []string{"batch", "2"}}},
+	}
+	src.GGUF = &fetcher.GGUFHeader{ParameterCount: 110000000, Quantization: "F16", ContextLength: 512, Tokenizer: "bert", VocabularySize: 30522, TensorCount: 201}
+	src.Safetensors = &fetcher.SafetensorsHeader{
+		TensorCount: 201, ParameterCount: 110000000,
+		DTypes:   []fetcher.SafetensorsDType{{DType: "F16", ParameterCount: 110000000}},
+		Metadata: map[string]string{"format": "pt"},
+	}
 
 	// Provide a minimal security tree so the security FieldSpecs have data to present.
 	safeStatus := &fetcher.SecurityFileStatus{Status: "safe"}
 	src.SecurityTree = []fetcher.SecurityFileEntry{
 		{Type: "file", OID: "abc", Path: "model.safetensors", SecurityFileStatus: safeStatus},
 	}
+	src.LicenseFile = fetcher.NewLicenseFile("LICENSE", []byte("MIT License\n\nCopyright (c) 2024 hf-author\n"))
 
 	tgt := Target{
 		BOM:                       bom,
@@ -117,10 +154,10 @@ func TestRegistryApplyAndPresent(t *testing.T) {
 	if comp.Group != "hf-org" {
 		t.Fatalf("group mismatch: expected 'hf-org', got %q", comp.Group)
 	}
-	if comp.Properties == nil || !hasProperty(comp, "huggingface:lastModified") {
+	if comp.Properties == nil || !hasProperty(comp, taxonomy.HFLastModified) {
 		t.Fatalf("expected huggingface properties")
 	}
-	if !hasProperty(comp, "huggingface:baseModel") {
+	if !hasProperty(comp, taxonomy.HFBaseModel) {
 		t.Fatalf("expected modelcard baseModel property")
 	}
 	if comp.ModelCard == nil || comp.ModelCard.ModelParameters == nil {
@@ -228,6 +265,43 @@ func TestComponentLicensesSkipMissing(t *testing.T) {
 	}
 }
 
+func TestComponentLicenseTextAttachesFile(t *testing.T) {
+	spec := specFor(t, ComponentLicenseText)
+	lf := fetcher.NewLicenseFile("LICENSE", []byte("MIT License\n"))
+
+	comp := &cdx.Component{Licenses: &cdx.Licenses{{License: &cdx.License{Name: "mit"}}}}
+	bom := cdx.NewBOM()
+	bom.Metadata = &cdx.Metadata{Component: comp}
+	ApplyFromSources(spec, Source{ModelID: "org/model", LicenseFile: lf}, Target{Component: comp})
+
+	lic := (*comp.Licenses)[0].License
+	if lic.Name != "mit" || lic.URL != "https://huggingface.co/org/model/blob/main/LICENSE" {
+		t.Fatalf("unexpected license %+v", lic)
+	}
+	if lic.Text == nil || lic.Text.Content != "MIT License\n" || lic.Text.ContentType != "text/plain" {
+		t.Fatalf("expected attached license text, got %+v", lic.Text)
+	}
+	if lic.Properties == nil || (*lic.Properties)[1].Value != lf.SHA256 {
+		t.Fatalf("expected license file digest property, got %+v", lic.Properties)
+	}
+	if !spec.Present(bom) {
+		t.Fatalf("expected license text to be present")
+	}
+
+	// Without a declared license the file itself becomes the license.
+	comp = &cdx.Component{}
+	ApplyFromSources(spec, Source{ModelID: "org/model", LicenseFile: lf}, Target{Component: comp})
+	if comp.Licenses == nil || (*comp.Licenses)[0].License.Name != "LICENSE" {
+		t.Fatalf("expected license named after the file, got %+v", comp.Licenses)
+	}
+
+	comp = &cdx.Component{}
+	ApplyFromSources(spec, Source{ModelID: "org/model"}, Target{Component: comp})
+	if comp.Licenses != nil {
+		t.Fatalf("expected no license without a license file")
+	}
+}
+
 func TestComponentHashesSkipMissing(t *testing.T) {
 	spec := specFor(t, ComponentHashes)
 	comp := &cdx.Component{}
@@ -305,6 +379,463 @@ func TestHFPropsSkipWithoutHFData(t *testing.T) {
 	}
 }
 
+func TestKaggleSources(t *testing.T) {
+	comp := &cdx.Component{ModelCard: &cdx.MLModelCard{}}
+	bom := cdx.NewBOM()
+	bom.Metadata = &cdx.Metadata{Component: comp}
+	src := Source{
+		Scan: scanner.Discovery{ID: "kaggle:models/google/gemma/keras/gemma_2b_en", Name: "google/gemma/keras/gemma_2b_en", Type: scanner.DiscoveryTypeKaggle, Version: "2"},
+		Kaggle: &fetcher.KaggleModel{
+			Owner: "google",
+			Tags:  []fetcher.KaggleTag{{Name: "Text Generation"}, {Ref: "nlp"}},
+			Instance: &fetcher.KaggleModelInstance{
+				Framework:    "keras",
+				FineTunable:  true,
+				LicenseName:  "Gemma",
+				TrainingData: []string{"web documents", "code"},
+			},
+		},
+	}
+	tgt := Target{BOM: bom, Component: comp, ModelCard: comp.ModelCard}
+	for _, spec := range Registry() {
+		ApplyFromSources(spec, src, tgt)
+	}
+
+	if comp.ExternalReferences == nil || (*comp.ExternalReferences)[0].URL != "https://www.kaggle.com/models/google/gemma/keras/gemma_2b_en/2" {
+		t.Errorf("external references = %+v", comp.ExternalReferences)
+	}
+	if comp.Tags == nil || strings.Join(*comp.Tags, ",") != "Text Generation,nlp,keras" {
+		t.Errorf("tags = %v", comp.Tags)
+	}
+	if comp.Licenses == nil || (*comp.Licenses)[0].License.Name != "Gemma" {
+		t.Errorf("licenses = %+v", comp.Licenses)
+	}
+	if comp.Manufacturer == nil || comp.Manufacturer.Name != "google" {
+		t.Errorf("manufacturer = %+v", comp.Manufacturer)
+	}
+	if mp := comp.ModelCard.ModelParameters; mp == nil || mp.Datasets == nil || (*mp.Datasets)[0].Ref != "dataset:web documents" {
+		t.Errorf("datasets = %+v", comp.ModelCard.ModelParameters)
+	}
+	if v, _ := taxonomy.Get(comp.Properties, taxonomy.KaggleFramework); v != "keras" {
+		t.Errorf("framework property = %q", v)
+	}
+	if v, _ := taxonomy.Get(comp.Properties, taxonomy.KaggleFineTunable); v != "true" {
+		t.Errorf("fineTunable property = %q", v)
+	}
+
+	dcomp := &cdx.Component{}
+	dsrc := DatasetSource{
+		Scan:   scanner.Discovery{ID: "kaggle:datasets/uciml/iris", Name: "uciml/iris", Type: scanner.DiscoveryTypeKaggle},
+		Kaggle: &fetcher.KaggleDataset{OwnerName: "UCI Machine Learning", CreatorName: "A", LicenseName: "CC0: Public Domain", Subtitle: "Iris species", UsabilityRating: 0.79},
+	}
+	for _, spec := range DatasetRegistry() {
+		ApplyDatasetFromSources(spec, dsrc, DatasetTarget{Component: dcomp})
+	}
+	if dcomp.Name != "uciml/iris" || dcomp.Manufacturer == nil || dcomp.Manufacturer.Name != "UCI Machine Learning" {
+		t.Errorf("dataset = %+v", dcomp)
+	}
+	if dcomp.ExternalReferences == nil || (*dcomp.ExternalReferences)[0].URL != "https://www.kaggle.com/datasets/uciml/iris" {
+		t.Errorf("dataset external references = %+v", dcomp.ExternalReferences)
+	}
+	if v, _ := taxonomy.Get(dcomp.Properties, taxonomy.KaggleUsabilityRating); v != "0.79" {
+		t.Errorf("usability rating property = %q", v)
+	}
+}
+
+func TestGovernanceSources(t *testing.T) {
+	comp := &cdx.Component{}
+	src := DatasetSource{
+		DatasetID: "org/speech",
+		Readme: &fetcher.DatasetReadmeCard{
+			ConsentStatement: "All speakers gave informed consent.",
+			DoNotTrain:       []string{"robots.txt", "noai"},
+		},
+	}
+	for _, spec := range DatasetRegistry() {
+		ApplyDatasetFromSources(spec, src, DatasetTarget{Component: comp})
+	}
+	if v, _ := taxonomy.Get(comp.Properties, taxonomy.GovernanceConsent); v != "All speakers gave informed consent." {
+		t.Errorf("consent property = %q", v)
+	}
+	if v, _ := taxonomy.Get(comp.Properties, taxonomy.GovernanceDoNotTrain); v != "robots.txt, noai" {
+		t.Errorf("doNotTrain property = %q", v)
+	}
+	if taxonomy.Has(comp.Properties, taxonomy.GovernanceOptOut) {
+		t.Errorf("unexpected optOut property")
+	}
+	if !HasConsentBasis(comp) {
+		t.Errorf("consent statement not accepted as a consent basis")
+	}
+	if HasConsentBasis(&cdx.Component{Name: "x"}) {
+		t.Errorf("dataset without license or consent has a consent basis")
+	}
+}
+
+func TestSyntheticSources(t *testing.T) {
+	comp := &cdx.Component{}
+	tgt := DatasetTarget{Component: comp}
+	src := DatasetSource{
+		HF:     &fetcher.DatasetAPIResponse{Tags: []string{"Synthetic"}},
+		Readme: &fetcher.DatasetReadmeCard{GeneratingModel: "org/generator"},
+	}
+	for _, spec := range DatasetRegistry() {
+		ApplyDatasetFromSources(spec, src, tgt)
+	}
+	if v, _ := taxonomy.Get(comp.Properties, taxonomy.DataSynthetic); v != "true" {
+		t.Errorf("synthetic = %q, want true from the Hub tag", v)
+	}
+	if v, _ := taxonomy.Get(comp.Properties, taxonomy.DataGeneratingModel); v != "org/generator" {
+		t.Errorf("generating model = %q", v)
+	}
+
+	var synthetic DatasetFieldSpec
+	for _, spec := range DatasetRegistry() {
+		if spec.Key == DatasetSynthetic {
+			synthetic = spec
+		}
+	}
+	if err := ApplyDatasetUserValue(synthetic, "no", tgt); err != nil {
+		t.Fatalf("apply synthetic: %v", err)
+	}
+	if v, _ := taxonomy.Get(comp.Properties, taxonomy.DataSynthetic); v != "false" {
+		t.Errorf("entered synthetic = %q, want false", v)
+	}
+	if err := ApplyDatasetUserValue(synthetic, "maybe", tgt); err == nil {
+		t.Errorf("synthetic %q: want error", "maybe")
+	}
+}
+
+func TestCoverageSources(t *testing.T) {
+	comp := &cdx.Component{}
+	tgt := DatasetTarget{Component: comp}
+	src := DatasetSource{
+		HF:     &fetcher.DatasetAPIResponse{Tags: []string{"language:en", "language:nl", "task_categories:translation"}},
+		Readme: &fetcher.DatasetReadmeCard{TemporalCoverage: "2019/2021"},
+	}
+	for _, spec := range DatasetRegistry() {
+		ApplyDatasetFromSources(spec, src, tgt)
+	}
+	if v, _ := taxonomy.Get(comp.Properties, taxonomy.DataTemporalCoverage); v != "2019/2021" {
+		t.Errorf("temporal coverage = %q", v)
+	}
+	if v, _ := taxonomy.Get(comp.Properties, taxonomy.DataLanguages); v != "en, nl" {
+		t.Errorf("languages = %q, want the Hub language tags", v)
+	}
+
+	// The language list of the card wins over the Hub tags.
+	src.Readme.Language = []string{"fr"}
+	comp.Properties = nil
+	for _, spec := range DatasetRegistry() {
+		ApplyDatasetFromSources(spec, src, tgt)
+	}
+	if v, _ := taxonomy.Get(comp.Properties, taxonomy.DataLanguages); v != "fr" {
+		t.Errorf("languages = %q, want fr from the card", v)
+	}
+}
+
+func TestAttributionSources(t *testing.T) {
+	comp := &cdx.Component{ModelCard: &cdx.MLModelCard{}}
+	bom := cdx.NewBOM()
+	bom.Metadata = &cdx.Metadata{Component: comp}
+	src := Source{
+		ModelID:     "org/model",
+		Readme:      &fetcher.ModelReadmeCard{Copyright: []string{"Copyright 2023 Card Org"}},
+		LicenseFile: fetcher.NewLicenseFile("LICENSE", []byte("Copyright (c) 2023-2024 Meta Platforms, Inc. All Rights Reserved.\n")),
+	}
+	for _, spec := range Registry() {
+		ApplyFromSources(spec, src, Target{BOM: bom, Component: comp, ModelCard: comp.ModelCard})
+	}
+	if comp.Copyright != "Copyright (c) 2023-2024 Meta Platforms, Inc. All Rights Reserved." {
+		t.Errorf("copyright = %q", comp.Copyright)
+	}
+	if v, _ := taxonomy.Get(comp.Properties, taxonomy.AttributionHolders); v != "Meta Platforms, Inc." {
+		t.Errorf("holders property = %q", v)
+	}
+
+	ds := &cdx.Component{Licenses: &cdx.Licenses{{License: &cdx.License{Name: "cc-by-sa-4.0"}}}}
+	if got := AttributionLicense(ds); got != "cc-by-sa-4.0" {
+		t.Errorf("AttributionLicense = %q", got)
+	}
+	if HasAttribution(ds) {
+		t.Errorf("dataset without notices has attribution")
+	}
+	dsrc := DatasetSource{DatasetID: "org/ds", Readme: &fetcher.DatasetReadmeCard{Attribution: "Please cite the paper."}}
+	for _, spec := range DatasetRegistry() {
+		ApplyDatasetFromSources(spec, dsrc, DatasetTarget{Component: ds})
+	}
+	if !HasAttribution(ds) {
+		t.Errorf("attribution statement not recorded: %v", ds.Properties)
+	}
+	if AttributionLicense(&cdx.Component{Licenses: &cdx.Licenses{{Expression: "Apache-2.0"}}}) != "" {
+		t.Errorf("Apache-2.0 requires attribution text")
+	}
+}
+
+func TestContactSources(t *testing.T) {
+	comp := &cdx.Component{ModelCard: &cdx.MLModelCard{}}
+	bom := cdx.NewBOM()
+	bom.Metadata = &cdx.Metadata{Component: comp}
+	tgt := Target{BOM: bom, Component: comp, ModelCard: comp.ModelCard}
+	src := Source{
+		ModelID: "org/model",
+		HF:      &fetcher.ModelAPIResponse{Author: "org"},
+		Readme: &fetcher.ModelReadmeCard{Contacts: []fetcher.Contact{
+			{Role: fetcher.ContactRoleSecurity, Name: "PSIRT", Email: "psirt@example.org"},
+			{Role: fetcher.ContactRoleGeneral, Name: "Jane Doe", Email: "jane@example.org"},
+		}},
+	}
+	for _, spec := range Registry() {
+		ApplyFromSources(spec, src, tgt)
+	}
+	if m := comp.Manufacturer; m == nil || m.Name != "org" || m.Contact == nil || (*m.Contact)[0] != (cdx.OrganizationalContact{Name: "Jane Doe", Email: "jane@example.org"}) {
+		t.Fatalf("manufacturer = %+v", comp.Manufacturer)
+	}
+	if !HasSecurityContact(comp) {
+		t.Fatalf("no security contact in %+v", comp.ExternalReferences)
+	}
+
+	specs := map[Key]FieldSpec{}
+	for _, spec := range Registry() {
+		specs[spec.Key] = spec
+	}
+	// Re-entering the model page or manufacturer keeps the contacts.
+	if err := ApplyUserValue(specs[ComponentExternalReferences], "https://example.org/model", tgt); err != nil {
+		t.Fatalf("apply externalReferences: %v", err)
+	}
+	if err := ApplyUserValue(specs[ComponentManufacturer], "Example Org", tgt); err != nil {
+		t.Fatalf("apply manufacturer: %v", err)
+	}
+	if !HasSecurityContact(comp) || comp.Manufacturer.Contact == nil {
+		t.Fatalf("contacts lost: %+v, %+v", comp.ExternalReferences, comp.Manufacturer)
+	}
+
+	if err := ApplyUserValue(specs[ComponentSecurityContact], "https://example.org/security", tgt); err != nil {
+		t.Fatalf("apply security contact: %v", err)
+	}
+	var urls []string
+	for _, ref := range *comp.ExternalReferences {
+		if ref.Type == cdx.ERTypeSecurityContact {
+			urls = append(urls, ref.URL)
+		}
+	}
+	if len(urls) != 1 || urls[0] != "https://example.org/security" {
+		t.Errorf("security contacts = %q, want the entered URL only", urls)
+	}
+	if err := ApplyUserValue(specs[ComponentContacts], "https://example.org/contact", tgt); err == nil {
+		t.Errorf("contact without an email address: want error")
+	}
+}
+
+func TestOllamaSources(t *testing.T) {
+	comp := &cdx.Component{ModelCard: &cdx.MLModelCard{}}
+	bom := cdx.NewBOM()
+	bom.Metadata = &cdx.Metadata{Component: comp}
+	src := Source{
+		Scan: scanner.Discovery{ID: "ollama:llama3:8b", Name: "llama3", Type: scanner.DiscoveryTypeProviderModel, Version: "8b"},
+		Ollama: &fetcher.OllamaModel{
+			Digest: "sha256:manifest",
+			Manifest: fetcher.OllamaManifest{Layers: []fetcher.OllamaLayer{
+				{MediaType: fetcher.OllamaLayerModel, Digest: "sha256:6a0746a1ec1a", Size: 4661211424},
+			}},
+			Config:  fetcher.OllamaConfig{ModelFormat: "gguf", ModelFamily: "llama", ModelFamilies: []string{"llama"}, ModelType: "8.0B", FileType: "Q4_0"},
+			License: "META LLAMA 3 COMMUNITY LICENSE AGREEMENT\nMeta Llama 3 Version Release Date: April 18, 2024",
+		},
+	}
+	tgt := Target{BOM: bom, Component: comp, ModelCard: comp.ModelCard}
+	for _, spec := range Registry() {
+		ApplyFromSources(spec, src, tgt)
+	}
+
+	if comp.Hashes == nil || (*comp.Hashes)[0] != (cdx.Hash{Algorithm: cdx.HashAlgoSHA256, Value: "6a0746a1ec1a"}) {
+		t.Errorf("hashes = %+v", comp.Hashes)
+	}
+	if comp.Licenses == nil || (*comp.Licenses)[0].License.Name != "META LLAMA 3 COMMUNITY LICENSE AGREEMENT" {
+		t.Errorf("licenses = %+v", comp.Licenses)
+	}
+	if comp.Tags == nil || strings.Join(*comp.Tags, ",") != "ollama,gguf,llama" {
+		t.Errorf("tags = %v", comp.Tags)
+	}
+	if comp.ExternalReferences == nil || (*comp.ExternalReferences)[0].URL != "https://ollama.com/library/llama3:8b" {
+		t.Errorf("external references = %+v", comp.ExternalReferences)
+	}
+	for name, want := range map[string]string{
+		taxonomy.OllamaDigest:        "sha256:manifest",
+		taxonomy.OllamaQuantization:  "Q4_0",
+		taxonomy.OllamaParameterSize: "8.0B",
+		taxonomy.OllamaFamily:        "llama",
+	} {
+		if v, _ := taxonomy.Get(comp.Properties, name); v != want {
+			t.Errorf("%s = %q, want %q", name, v, want)
+		}
+	}
+
+	if got := ollamaLicenseName("                                 Apache License\n                           Version 2.0, January 2004"); got != "Apache-2.0" {
+		t.Errorf("ollamaLicenseName(Apache) = %q", got)
+	}
+}
+
+func TestGGUFSources(t *testing.T) {
+	comp := &cdx.Component{ModelCard: &cdx.MLModelCard{}}
+	bom := cdx.NewBOM()
+	bom.Metadata = &cdx.Metadata{Component: comp}
+	src := Source{
+		Scan: scanner.Discovery{ID: "models/llama.gguf", Name: "llama.gguf", Type: scanner.DiscoveryTypeModelFile, Format: "gguf"},
+		GGUF: &fetcher.GGUFHeader{
+			Architecture:   "llama",
+			License:        "llama3",
+			Quantization:   "Q4_K_M",
+			ContextLength:  8192,
+			Tokenizer:      "gpt2",
+			VocabularySize: 128256,
+			ParameterCount: 8030261248,
+		},
+	}
+	tgt := Target{BOM: bom, Component: comp, ModelCard: comp.ModelCard}
+	for _, spec := range Registry() {
+		ApplyFromSources(spec, src, tgt)
+	}
+
+	if mp := comp.ModelCard.ModelParameters; mp == nil || mp.ArchitectureFamily != "llama" {
+		t.Errorf("model parameters = %+v", comp.ModelCard.ModelParameters)
+	}
+	if comp.Licenses == nil || (*comp.Licenses)[0].License.Name != "llama3" {
+		t.Errorf("licenses = %+v", comp.Licenses)
+	}
+	for name, want := range map[string]string{
+		taxonomy.WeightsParameterCount: "8030261248",
+		taxonomy.WeightsQuantization:   "Q4_K_M",
+		taxonomy.WeightsContextLength:  "8192",
+		taxonomy.WeightsTokenizer:      "gpt2",
+		taxonomy.WeightsVocabularySize: "128256",
+	} {
+		if v, _ := taxonomy.Get(comp.Properties, name); v != want {
+			t.Errorf("%s = %q, want %q", name, v, want)
+		}
+	}
+}
+
+func TestModelSizeSources(t *testing.T) {
+	apply := func(src Source) *cdx.Component {
+		comp := &cdx.Component{ModelCard: &cdx.MLModelCard{}}
+		bom := cdx.NewBOM()
+		bom.Metadata = &cdx.Metadata{Component: comp}
+		for _, spec := range Registry() {
+			ApplyFromSources(spec, src, Target{BOM: bom, Component: comp, ModelCard: comp.ModelCard})
+		}
+		return comp
+	}
+	readme := &fetcher.ModelReadmeCard{ParameterCount: 7000000000, ContextLength: 2048}
+
+	comp := apply(Source{
+		HF:     &fetcher.ModelAPIResponse{ID: "org/llm", Safetensors: &fetcher.SafetensorsInfo{Total: 6738415616}},
+		Config: &fetcher.ModelConfig{ContextLength: 4096},
+		Readme: readme,
+	})
+	if v, _ := taxonomy.Get(comp.Properties, taxonomy.WeightsParameterCount); v != "6738415616" {
+		t.Errorf("parameter count = %q, want the Hub safetensors total", v)
+	}
+	if v, _ := taxonomy.Get(comp.Properties, taxonomy.WeightsContextLength); v != "4096" {
+		t.Errorf("context length = %q, want config.json", v)
+	}
+
+	comp = apply(Source{HF: &fetcher.ModelAPIResponse{ID: "org/llm"}, Readme: readme})
+	if v, _ := taxonomy.Get(comp.Properties, taxonomy.WeightsParameterCount); v != "7000000000" {
+		t.Errorf("parameter count = %q, want the model card", v)
+	}
+	if v, _ := taxonomy.Get(comp.Properties, taxonomy.WeightsContextLength); v != "2048" {
+		t.Errorf("context length = %q, want the model card", v)
+	}
+
+	spec := specFor(t, ModelCardModelParametersNumberOfParameters)
+	if v, err := spec.Parse("1.5B"); err != nil || v != "1500000000" {
+		t.Errorf("Parse(1.5B) = %v, %v", v, err)
+	}
+	if _, err := spec.Parse("large"); err == nil {
+		t.Errorf("Parse(large): want error")
+	}
+}
+
+func TestSafetensorsSources(t *testing.T) {
+	comp := &cdx.Component{ModelCard: &cdx.MLModelCard{}}
+	bom := cdx.NewBOM()
+	bom.Metadata = &cdx.Metadata{Component: comp}
+	src := Source{
+		Scan: scanner.Discovery{ID: "models/model.safetensors", Name: "model.safetensors", Type: scanner.DiscoveryTypeModelFile, Format: "safetensors"},
+		Safetensors: &fetcher.SafetensorsHeader{
+			TensorCount:    3,
+			ParameterCount: 1536,
+			DTypes:         []fetcher.SafetensorsDType{{DType: "BF16", ParameterCount: 1024}, {DType: "F32", ParameterCount: 512}},
+			Metadata:       map[string]string{"format": "pt", "author": "hf-org"},
+		},
+	}
+	tgt := Target{BOM: bom, Component: comp, ModelCard: comp.ModelCard}
+	for _, spec := range Registry() {
+		ApplyFromSources(spec, src, tgt)
+	}
+
+	for name, want := range map[string]string{
+		taxonomy.WeightsParameterCount: "1536",
+		taxonomy.WeightsQuantization:   "BF16",
+		taxonomy.WeightsTensorCount:    "3",
+		taxonomy.WeightsDTypes:         "BF16, F32",
+		taxonomy.SafetensorsMetadata:   "author=hf-org; format=pt",
+	} {
+		if v, _ := taxonomy.Get(comp.Properties, name); v != want {
+			t.Errorf("%s = %q, want %q", name, v, want)
+		}
+	}
+}
+
+func TestONNXSources(t *testing.T) {
+	comp := &cdx.Component{ModelCard: &cdx.MLModelCard{}}
+	bom := cdx.NewBOM()
+	bom.Metadata = &cdx.Metadata{Component: comp}
+	src := Source{
+		Scan: scanner.Discovery{ID: "models/resnet.onnx", Name: "resnet.onnx", Type: scanner.DiscoveryTypeModelFile, Format: "onnx"},
+		ONNX: &fetcher.ONNXModel{
+			IRVersion:       8,
+			ProducerName:    "pytorch",
+			ProducerVersion: "2.1.0",
+			Opsets:          []fetcher.ONNXOpset{{Domain: "ai.onnx", Version: 17}, {Domain: "com.microsoft", Version: 1}},
+			GraphName:       "ResNet50",
+			Inputs:          []fetcher.ONNXValueInfo{{Name: "pixel_values", ElemType: "float32", Shape: []string{"batch", "3", "224", "224"}}},
+			Outputs:         []fetcher.ONNXValueInfo{{Name: "logits", ElemType: "float32", Shape: []string{"batch", "1000"}}},
+			Metadata:        map[string]string{"model_type": "resnet"},
+			ParameterCount:  25557032,
+		},
+	}
+	tgt := Target{BOM: bom, Component: comp, ModelCard: comp.ModelCard}
+	for _, spec := range Registry() {
+		ApplyFromSources(spec, src, tgt)
+	}
+
+	mp := comp.ModelCard.ModelParameters
+	if mp == nil || mp.ArchitectureFamily != "resnet" || mp.ModelArchitecture != "ResNet50" {
+		t.Fatalf("model parameters = %+v", mp)
+	}
+	if mp.Inputs == nil || (*mp.Inputs)[0].Format != "pixel_values: float32[batch, 3, 224, 224]" {
+		t.Errorf("inputs = %+v", mp.Inputs)
+	}
+	if mp.Outputs == nil || (*mp.Outputs)[0].Format != "logits: float32[batch, 1000]" {
+		t.Errorf("outputs = %+v", mp.Outputs)
+	}
+	for name, want := range map[string]string{
+		taxonomy.ONNXProducer:          "pytorch 2.1.0",
+		taxonomy.ONNXIRVersion:         "8",
+		taxonomy.ONNXOpsets:            "ai.onnx 17, com.microsoft 1",
+		taxonomy.WeightsParameterCount: "25557032",
+	} {
+		if v, _ := taxonomy.Get(comp.Properties, name); v != want {
+			t.Errorf("%s = %q, want %q", name, v, want)
+		}
+	}
+
+	src.ONNX.GraphName = "main_graph"
+	if got := onnxModelArchitecture(src.ONNX); got != "" {
+		t.Errorf("exporter default graph name used as architecture: %q", got)
+	}
+}
+
 func TestHelperFunctions(t *testing.T) {
 	if got := extractLicense(map[string]any{"license": " mit "}, nil); got != "mit" {
 		t.Fatalf("extractLicense card data = %q", got)
@@ -339,33 +870,41 @@ func TestHelperFunctions(t *testing.T) {
 	}
 
 	comp := &cdx.Component{}
-	setProperty(comp, " ", "value")
+	setProperty(comp, taxonomy.HFLikes, " ")
 	if comp.Properties != nil {
-		t.Fatalf("expected no properties for blank name")
+		t.Fatalf("expected no properties for blank value")
 	}
-	if hasProperty(comp, "anything") {
+	if hasProperty(comp, taxonomy.HFLikes) {
 		t.Fatalf("expected false when properties nil")
 	}
-	setProperty(comp, "prop", " value ")
-	if !hasProperty(comp, "prop") {
+	setProperty(comp, taxonomy.HFLikes, " value ")
+	if !hasProperty(comp, taxonomy.HFLikes) {
 		t.Fatalf("expected property set")
 	}
-	if hasProperty(comp, "missing") {
+	if hasProperty(comp, taxonomy.HFDownloads) {
 		t.Fatalf("unexpected property match")
 	}
-	comp.Properties = &[]cdx.Property{{Name: " padded ", Value: "trimmed"}, {Name: "empty", Value: " "}}
-	if !hasProperty(comp, "padded") {
-		t.Fatalf("expected trimmed property match")
+	comp.Properties = &[]cdx.Property{{Name: " huggingface:likes ", Value: "trimmed"}, {Name: taxonomy.HFDownloads, Value: " "}}
+	if !hasProperty(comp, taxonomy.HFLikes) {
+		t.Fatalf("expected trimmed legacy property match")
 	}
-	if hasProperty(comp, "empty") {
+	if hasProperty(comp, taxonomy.HFDownloads) {
 		t.Fatalf("expected empty value to be ignored")
 	}
 	if hasProperty(comp, " ") {
 		t.Fatalf("expected blank lookup to be ignored even with properties")
 	}
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatalf("expected panic for unregistered property")
+			}
+		}()
+		setProperty(comp, "prop", "value")
+	}()
 
-	setProperty(nil, "prop", "value")
-	if hasProperty(nil, "prop") {
+	setProperty(nil, taxonomy.HFLikes, "value")
+	if hasProperty(nil, taxonomy.HFLikes) {
 		t.Fatalf("expected false for nil component")
 	}
 
@@ -422,3 +961,334 @@ func TestRegistryPresentHandlesNilBOM(t *testing.T) {
 		}
 	}
 }
+
+func TestFairnessSources(t *testing.T) {
+	comp := &cdx.Component{ModelCard: &cdx.MLModelCard{}}
+	bom := cdx.NewBOM()
+	bom.Metadata = &cdx.Metadata{Component: comp}
+	src := Source{Readme: &fetcher.ModelReadmeCard{
+		ModelIndexMetrics: []fetcher.ModelIndexMetric{{Type: "accuracy", Value: "0.92"}},
+		FairnessMetrics: []fetcher.FairnessMetric{
+			{Group: "female", Type: "accuracy", Value: "0.91"},
+			{Group: "male", Type: "accuracy", Value: "0.93"},
+		},
+		FairnessCriteria: []string{"demographic parity", "equalized odds"},
+	}}
+	tgt := Target{BOM: bom, Component: comp, ModelCard: comp.ModelCard}
+	for _, spec := range Registry() {
+		ApplyFromSources(spec, src, tgt)
+	}
+
+	metrics := *comp.ModelCard.QuantitativeAnalysis.PerformanceMetrics
+	want := []cdx.MLPerformanceMetric{
+		{Type: "accuracy", Value: "0.92"},
+		{Type: "accuracy", Value: "0.91", Slice: "female"},
+		{Type: "accuracy", Value: "0.93", Slice: "male"},
+	}
+	if !reflect.DeepEqual(metrics, want) {
+		t.Fatalf("metrics = %+v", metrics)
+	}
+	if v, _ := taxonomy.Get(comp.Properties, taxonomy.FairnessCriteria); v != "demographic parity, equalized odds" {
+		t.Errorf("criteria = %q", v)
+	}
+
+	// The overall and per-group metrics are set and cleared independently.
+	if err := ApplyUserValue(specFor(t, ModelCardQuantitativeAnalysisPerformanceMetrics), "f1:0.8", tgt); err != nil {
+		t.Fatalf("apply metrics: %v", err)
+	}
+	if err := ClearUserValue(specFor(t, ModelCardQuantitativeAnalysisFairnessMetrics), tgt); err != nil {
+		t.Fatalf("clear fairness metrics: %v", err)
+	}
+	metrics = *comp.ModelCard.QuantitativeAnalysis.PerformanceMetrics
+	if !reflect.DeepEqual(metrics, []cdx.MLPerformanceMetric{{Type: "f1", Value: "0.8"}}) {
+		t.Fatalf("metrics after clear = %+v", metrics)
+	}
+	if err := ApplyUserValue(specFor(t, ModelCardQuantitativeAnalysisFairnessMetrics), "age 18-30/false positive rate:0.1", tgt); err != nil {
+		t.Fatalf("apply fairness metrics: %v", err)
+	}
+	if got := (*comp.ModelCard.QuantitativeAnalysis.PerformanceMetrics)[1]; got.Slice != "age 18-30" || got.Type != "false positive rate" || got.Value != "0.1" {
+		t.Errorf("entered fairness metric = %+v", got)
+	}
+	if _, err := parseFairnessMetrics("accuracy:0.9"); err == nil {
+		t.Errorf("metric without group: want error")
+	}
+}
+
+func TestAIActSources(t *testing.T) {
+	comp := &cdx.Component{ModelCard: &cdx.MLModelCard{}}
+	bom := cdx.NewBOM()
+	bom.Metadata = &cdx.Metadata{Component: comp}
+	src := Source{Readme: &fetcher.ModelReadmeCard{
+		IntendedUsers:      []string{"Clinicians", "Clinicians", "Hospital IT"},
+		DeploymentContext:  "Emergency department triage.",
+		HumanOversight:     "A nurse confirms every suggestion.",
+		RiskClassification: "high",
+	}}
+	tgt := Target{BOM: bom, Component: comp, ModelCard: comp.ModelCard}
+	for _, spec := range Registry() {
+		ApplyFromSources(spec, src, tgt)
+	}
+
+	if users := comp.ModelCard.Considerations.Users; users == nil || !reflect.DeepEqual(*users, []string{"Clinicians", "Hospital IT"}) {
+		t.Fatalf("users = %v", users)
+	}
+	for name, want := range map[string]string{
+		taxonomy.AIActDeploymentContext:  "Emergency department triage.",
+		taxonomy.AIActHumanOversight:     "A nurse confirms every suggestion.",
+		taxonomy.AIActRiskClassification: "high",
+	} {
+		if v, _ := taxonomy.Get(comp.Properties, name); v != want {
+			t.Errorf("%s = %q, want %q", name, v, want)
+		}
+	}
+
+	risk := specFor(t, ComponentPropertiesAIActRiskClassification)
+	if risk.Category != CategoryResponsibleAI || specFor(t, ComponentPropertiesAIActHumanOversight).Category != CategoryResponsibleAI {
+		t.Errorf("oversight and risk class should count towards the responsible AI category")
+	}
+	if err := ApplyUserValue(risk, "Unacceptable", tgt); err != nil {
+		t.Fatalf("apply risk class: %v", err)
+	}
+	if v, _ := taxonomy.Get(comp.Properties, taxonomy.AIActRiskClassification); v != "prohibited" {
+		t.Errorf("entered risk class = %q, want prohibited", v)
+	}
+	if err := ApplyUserValue(risk, "medium", tgt); err == nil {
+		t.Errorf("unknown risk class: want error")
+	}
+}
+
+func TestRegistryClearRemovesAppliedValues(t *testing.T) {
+	comp := &cdx.Component{ModelCard: &cdx.MLModelCard{}}
+	bom := cdx.NewBOM()
+	bom.Metadata = &cdx.Metadata{Component: comp}
+	tgt := Target{BOM: bom, Component: comp, ModelCard: comp.ModelCard}
+
+	src := Source{
+		ModelID: "org/model",
+		HF: &fetcher.ModelAPIResponse{
+			ID:          "org/model",
+			Author:      "org",
+			PipelineTag: "text-classification",
+			Tags:        []string{"nlp", "license:mit", "dataset:imdb"},
+			SHA:         "deadbeef",
+			LastMod:     "2024-01-01",
+			Downloads:   3,
+		},
+		Readme: &fetcher.ModelReadmeCard{
+			DirectUse:                 "Classify text.",
+			BiasRisksLimitations:      "May be biased.",
+			EnvironmentalHardwareType: "GPU",
+			ModelIndexMetrics:         []fetcher.ModelIndexMetric{{Type: "accuracy", Value: "0.9"}},
+			FairnessMetrics:           []fetcher.FairnessMetric{{Group: "female", Type: "accuracy", Value: "0.88"}},
+			FairnessCriteria:          []string{"equal opportunity"},
+			IntendedUsers:             []string{"Moderators"},
+			HumanOversight:            "Moderators confirm every removal.",
+			RiskClassification:        "limited",
+		},
+	}
+	src.HF.Config.ModelType = "bert"
+	src.HF.Config.Architectures = []string{"BertModel"}
+
+	for _, spec := range Registry() {
+		ApplyFromSources(spec, src, tgt)
+	}
+	if err := ApplyUserValue(specFor(t, ComponentPropertiesHuggingFaceContact), "ml@example.com", tgt); err != nil {
+		t.Fatalf("apply contact: %v", err)
+	}
+
+	for _, spec := range Registry() {
+		if spec.Clear == nil {
+			continue
+		}
+		if err := ClearUserValue(spec, tgt); err != nil {
+			t.Fatalf("clear %s: %v", spec.Key, err)
+		}
+		if spec.Present(bom) {
+			t.Fatalf("expected %s to be missing after Clear", spec.Key)
+		}
+	}
+	if comp.Properties != nil {
+		t.Fatalf("expected all properties removed, got %v", *comp.Properties)
+	}
+
+	if err := ClearUserValue(specFor(t, Key("aibomgen.evidence")), tgt); err == nil {
+		t.Fatalf("expected error clearing a spec without Clear")
+	}
+}
+
+func TestDatasetRegistryClearRemovesValues(t *testing.T) {
+	tags := []string{"nlp", "lastModified:2024-01-01"}
+	comp := &cdx.Component{
+		Name:               "imdb",
+		Group:              "stanford",
+		ExternalReferences: &[]cdx.ExternalReference{{URL: "https://example.com"}},
+		Tags:               &tags,
+		Licenses:           &cdx.Licenses{{License: &cdx.License{Name: "mit"}}},
+		Manufacturer:       &cdx.OrganizationalEntity{Name: "Stanford"},
+		Authors:            &[]cdx.OrganizationalContact{{Name: "A"}},
+		Hashes:             &[]cdx.Hash{{Algorithm: cdx.HashAlgoSHA256, Value: "abc"}},
+		Copyright:          "Copyright 2011 Stanford",
+		Data: &[]cdx.ComponentData{{
+			Type:           cdx.ComponentDataTypeDataset,
+			Description:    "Movie reviews",
+			Classification: "text",
+			Contents:       &cdx.ComponentDataContents{Attachment: &cdx.AttachedText{Content: "x"}},
+			SensitiveData:  &[]string{"none"},
+			Governance:     &cdx.DataGovernance{},
+		}},
+		Properties: &[]cdx.Property{
+			{Name: "huggingface:createdAt", Value: "2023-01-01"},
+			{Name: "huggingface:usedStorage", Value: "10"},
+			{Name: "huggingface:datasetContact", Value: "ds@example.com"},
+			{Name: taxonomy.KaggleLastUpdated, Value: "2024-01-01T00:00:00Z"},
+			{Name: taxonomy.KaggleTotalBytes, Value: "10"},
+			{Name: taxonomy.KaggleDownloads, Value: "5"},
+			{Name: taxonomy.KaggleVotes, Value: "2"},
+			{Name: taxonomy.KaggleUsabilityRating, Value: "0.8"},
+			{Name: taxonomy.GovernanceConsent, Value: "Participants gave consent."},
+			{Name: taxonomy.GovernanceOptOut, Value: "Email removal requests."},
+			{Name: taxonomy.GovernanceDoNotTrain, Value: "robots.txt"},
+			{Name: taxonomy.AttributionHolders, Value: "Stanford"},
+			{Name: taxonomy.AttributionStatement, Value: "Please cite Maas et al."},
+			{Name: taxonomy.DataSynthetic, Value: "true"},
+			{Name: taxonomy.DataGeneratingModel, Value: "org/generator"},
+			{Name: taxonomy.DataTemporalCoverage, Value: "2009/2011"},
+			{Name: taxonomy.DataGeographicCoverage, Value: "United States"},
+			{Name: taxonomy.DataLanguages, Value: "en"},
+		},
+	}
+	tgt := DatasetTarget{Component: comp}
+
+	for _, spec := range DatasetRegistry() {
+		if !spec.Present(comp) {
+			t.Fatalf("expected %s to be present before Clear", spec.Key)
+		}
+	}
+	for _, spec := range DatasetRegistry() {
+		if err := ClearDatasetUserValue(spec, tgt); err != nil {
+			t.Fatalf("clear %s: %v", spec.Key, err)
+		}
+		if spec.Present(comp) {
+			t.Fatalf("expected %s to be missing after Clear", spec.Key)
+		}
+	}
+}
+
+func TestClassifyModel(t *testing.T) {
+	cases := []struct {
+		pipeline, library, want string
+	}{
+		{"text-generation", "transformers", ClassifierLLM},
+		{"Text-To-Image", "", ClassifierDiffusion},
+		{"text-to-image", "diffusers", ClassifierDiffusion},
+		{"feature-extraction", "transformers", ClassifierEmbedding},
+		{"sentence-similarity", "sentence-transformers", ClassifierEmbedding},
+		{"", "sentence-transformers", ClassifierEmbedding},
+		{"image-classification", "timm", ClassifierVision},
+		{"automatic-speech-recognition", "", ClassifierSpeech},
+		{"fill-mask", "transformers", ClassifierNLP},
+		{"image-text-to-text", "transformers", ClassifierMultimodal},
+		{"", "transformers", ""},
+		{"unknown-task", "", ""},
+	}
+	for _, tc := range cases {
+		if got := ClassifyModel(tc.pipeline, tc.library); got != tc.want {
+			t.Errorf("ClassifyModel(%q, %q) = %q, want %q", tc.pipeline, tc.library, got, tc.want)
+		}
+	}
+}
+
+func TestModelClassifierOverride(t *testing.T) {
+	comp := &cdx.Component{}
+	bom := cdx.NewBOM()
+	bom.Metadata = &cdx.Metadata{Component: comp}
+	tgt := Target{BOM: bom, Component: comp}
+	spec := specFor(t, ComponentPropertiesModelClassifier)
+
+	ApplyFromSources(spec, Source{HF: &fetcher.ModelAPIResponse{PipelineTag: "text-generation"}}, tgt)
+	if !spec.Present(bom) || (*comp.Properties)[0].Value != ClassifierLLM {
+		t.Fatalf("expected derived classifier, got %v", comp.Properties)
+	}
+
+	// Sources do not replace an existing classifier, user values do.
+	ApplyFromSources(spec, Source{HF: &fetcher.ModelAPIResponse{LibraryName: "diffusers"}}, tgt)
+	if err := ApplyUserValue(spec, " Embedding ", tgt); err != nil {
+		t.Fatalf("ApplyUserValue: %v", err)
+	}
+	if len(*comp.Properties) != 1 || (*comp.Properties)[0].Value != ClassifierEmbedding {
+		t.Fatalf("expected overridden classifier, got %v", *comp.Properties)
+	}
+}
+
+func TestRegistryFieldsAreDocumented(t *testing.T) {
+	for _, spec := range Registry() {
+		if spec.Weight <= 0 {
+			continue
+		}
+		if spec.Help.Description == "" || spec.Help.Example == "" || spec.Help.SpecPath == "" {
+			t.Errorf("%s: incomplete help %+v", spec.Key, spec.Help)
+		}
+	}
+	for _, spec := range DatasetRegistry() {
+		if spec.Help.Description == "" || spec.Help.Example == "" || spec.Help.SpecPath == "" {
+			t.Errorf("%s: incomplete help %+v", spec.Key, spec.Help)
+		}
+	}
+
+	help, ok := LookupHelp(string(DatasetDescription))
+	if !ok {
+		t.Fatalf("expected help for %s", DatasetDescription)
+	}
+	if got, want := help.DocsURL(), CycloneDXDocsBaseURL+"#components_items_data_items_description"; got != want {
+		t.Fatalf("DocsURL() = %q, want %q", got, want)
+	}
+	if _, ok := LookupHelp("aibomgen.evidence"); ok {
+		t.Fatalf("expected no help for internal evidence spec")
+	}
+	if (FieldHelp{}).DocsURL() != "" {
+		t.Fatalf("expected empty DocsURL without SpecPath")
+	}
+}
+
+func TestExplainPath(t *testing.T) {
+	tests := []struct {
+		path     string
+		location string
+		desc     string
+	}{
+		// Paths between registered keys are read from the glossary.
+		{"BOM.metadata.component.modelCard.considerations", "Model › Model card › Considerations", fieldTerms["considerations"].Description},
+		// Registered keys are described by their help.
+		{string(ModelCardConsiderationsUsers), "Model › Model card › Considerations › Users", "People or organisations the model is intended to be used by."},
+		{string(DatasetTemporalCoverage), "Dataset › Properties › aibomgen:data:temporalCoverage", "Time range the data was collected in, preferably as an ISO 8601 interval."},
+		// Schema paths have array indexes and context-dependent names.
+		{"components[0].type", "Components › Type", fieldTerms["type"].Description},
+		{"metadata.component.externalReferences[3].type", "Model › External references › Type", fieldTerms["externalReferences.type"].Description},
+		{"BOM.metadata.component.properties.huggingface:example", "Model › Properties › huggingface:example", propertyNamespaces["huggingface"]},
+	}
+	for _, tt := range tests {
+		got, ok := ExplainPath(tt.path)
+		if !ok || got.Location != tt.location || got.Description != tt.desc {
+			t.Errorf("ExplainPath(%q) = %+v, %v; want {%s %s}", tt.path, got, ok, tt.location, tt.desc)
+		}
+	}
+	for _, path := range []string{"", "(root)", "metadata.component.unknownField", "BOM.metadata.component.properties.other:name"} {
+		if got, ok := ExplainPath(path); ok {
+			t.Errorf("ExplainPath(%q) = %+v, want no explanation", path, got)
+		}
+	}
+}
+
+func TestAbbreviationsIn(t *testing.T) {
+	var got []string
+	for _, term := range AbbreviationsIn("AIBOM Completeness Report: the BOM names no purl (EU AI Act).") {
+		got = append(got, term.Term)
+	}
+	want := []string{"AIBOM", "BOM", "purl", "EU AI Act"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("AbbreviationsIn() = %v, want %v", got, want)
+	}
+	if terms := AbbreviationsIn("An ML-BOM only."); len(terms) != 1 || terms[0].Term != "ML-BOM" {
+		t.Errorf("AbbreviationsIn(ML-BOM) = %v, want only ML-BOM", terms)
+	}
+}
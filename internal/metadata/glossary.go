@@ -0,0 +1,221 @@
+package metadata
+
+import (
+	"regexp"
+	"strings"
+)
+
+// GlossaryTerm explains a CycloneDX field name or an abbreviation in plain.
+// language.
+type GlossaryTerm struct {
+	Term        string
+	Label       string // plain-language name, e.g. "Model card"
+	Description string
+}
+
+// PathExplanation is the plain-language reading of a CycloneDX field path.
+type PathExplanation struct {
+	Location    string // where the field lives, e.g. "Model › Model card › Considerations"
+	Description string // what the field holds
+}
+
+func (e PathExplanation) String() string {
+	if e.Location == "" {
+		return e.Description
+	}
+	return e.Location + ": " + e.Description
+}
+
+// fieldTerms explains the field names that make up the paths of the registry.
+// keys and of schema violations. metadata.component is one term: it is the.
+// model an AIBOM describes. A parent.field entry overrides the entry of a.
+// field whose meaning depends on where it appears.
+var fieldTerms = map[string]GlossaryTerm{
+	"BOM":                         {Label: "", Description: "The AI bill of materials: the CycloneDX document listing a model, its datasets and their metadata."},
+	"metadata":                    {Label: "Document metadata", Description: "Information about the BOM itself: when and by which tool it was made, its lifecycle phase and the model it describes."},
+	"metadata.component":          {Label: "Model", Description: "The model the BOM describes."},
+	"components":                  {Label: "Components", Description: "Everything the BOM lists besides the model it describes: datasets, other models, libraries."},
+	"components[DATA]":            {Label: "Dataset", Description: "A dataset the model was trained or evaluated on."},
+	"component":                   {Label: "Component", Description: "A model, dataset, library or other part listed in the BOM."},
+	"type":                        {Label: "Type", Description: "Kind of component: machine-learning-model, data, library, application and so on."},
+	"name":                        {Label: "Name", Description: "Name of the component, e.g. the model or dataset ID without its organisation."},
+	"group":                       {Label: "Group", Description: "Namespace of the component, e.g. the organisation that publishes the model."},
+	"version":                     {Label: "Version", Description: "Version of the component, e.g. the commit of the Hugging Face repo."},
+	"description":                 {Label: "Description", Description: "What the component is, in prose."},
+	"purl":                        {Label: "Package URL", Description: "Package URL (purl) identifying the component across tools, e.g. pkg:huggingface/org/model."},
+	"bom-ref":                     {Label: "BOM reference", Description: "Identifier other parts of the BOM use to point at the component."},
+	"licenses":                    {Label: "Licenses", Description: "Licenses the component is distributed under, as SPDX IDs or license text."},
+	"license":                     {Label: "License", Description: "A license, by SPDX ID or by name."},
+	"text":                        {Label: "Text", Description: "Full text of the license."},
+	"hashes":                      {Label: "Hashes", Description: "Cryptographic digests of the component's files, to check they are the ones described."},
+	"manufacturer":                {Label: "Manufacturer", Description: "Organisation that made the component."},
+	"contact":                     {Label: "Contacts", Description: "People or mailboxes to contact about the component."},
+	"authors":                     {Label: "Authors", Description: "People who created the component."},
+	"supplier":                    {Label: "Supplier", Description: "Organisation that supplies the component."},
+	"copyright":                   {Label: "Copyright", Description: "Copyright notices of the component."},
+	"tags":                        {Label: "Tags", Description: "Keywords that categorise the component."},
+	"externalReferences":          {Label: "External references", Description: "Links to resources about the component: website, repository, documentation, issue tracker, security contact."},
+	"externalReferences.type":     {Label: "Type", Description: "Kind of link: website, vcs, documentation, issue-tracker, security-contact and so on."},
+	"security-contact":            {Label: "Security contact", Description: "Where to report vulnerabilities and misuse."},
+	"issue-tracker":               {Label: "Issue tracker", Description: "Where to report bugs and ask questions."},
+	"properties":                  {Label: "Properties", Description: "Name-value pairs for information CycloneDX has no dedicated field for."},
+	"evidence":                    {Label: "Evidence", Description: "How the tool identified the component: the files and methods it was found by."},
+	"lifecycles":                  {Label: "Lifecycle", Description: "Phases of the model's life the BOM covers, e.g. design, build or operations (in production)."},
+	"tools":                       {Label: "Tools", Description: "Tools that produced the BOM."},
+	"dependencies":                {Label: "Dependencies", Description: "Which components rely on which other components."},
+	"modelCard":                   {Label: "Model card", Description: "Documentation of a model: how it was built, how well it performs and what to consider when using it."},
+	"modelParameters":             {Label: "Model parameters", Description: "How the model was built: learning approach, task, architecture, datasets, inputs and outputs."},
+	"task":                        {Label: "Task", Description: "What the model does, e.g. text-classification or text-generation."},
+	"architectureFamily":          {Label: "Architecture family", Description: "Family of model architectures, e.g. llama or bert."},
+	"modelArchitecture":           {Label: "Architecture", Description: "Specific architecture of the model, e.g. LlamaForCausalLM."},
+	"datasets":                    {Label: "Datasets", Description: "Datasets the model was trained or evaluated on, as references to dataset components."},
+	"inputs":                      {Label: "Inputs", Description: "Inputs the model accepts."},
+	"outputs":                     {Label: "Outputs", Description: "Outputs the model produces."},
+	"numberOfParameters":          {Label: "Number of parameters", Description: "Number of trainable parameters (weights) of the model."},
+	"quantitativeAnalysis":        {Label: "Quantitative analysis", Description: "Measured performance of the model."},
+	"performanceMetrics":          {Label: "Performance metrics", Description: "Evaluation results such as accuracy or F1, per dataset or slice."},
+	"slice":                       {Label: "Slice", Description: "Subset of the evaluation data a metric was measured on."},
+	"considerations":              {Label: "Considerations", Description: "Who the model is for, what it should be used for, and its limitations, ethical risks and fairness concerns."},
+	"users":                       {Label: "Users", Description: "People or organisations the model is intended to be used by."},
+	"useCases":                    {Label: "Use cases", Description: "What the model is intended to be used for."},
+	"technicalLimitations":        {Label: "Technical limitations", Description: "Known limits of what the model can do reliably."},
+	"performanceTradeoffs":        {Label: "Performance trade-offs", Description: "Trade-offs made between accuracy, speed, size and other qualities."},
+	"ethicalConsiderations":       {Label: "Ethical considerations", Description: "Risks the model poses to people and how they are mitigated."},
+	"fairnessAssessments":         {Label: "Fairness assessments", Description: "How the model's behaviour differs across groups of people."},
+	"environmentalConsiderations": {Label: "Environmental considerations", Description: "Energy use and emissions of training and running the model."},
+	"data":                        {Label: "Data", Description: "What a dataset contains and how it is governed."},
+	"contents":                    {Label: "Contents", Description: "The data itself or where to get it."},
+	"attachments":                 {Label: "Attachments", Description: "Files that hold the data."},
+	"classification":              {Label: "Classification", Description: "Kind of data, e.g. public or personal."},
+	"sensitiveData":               {Label: "Sensitive data", Description: "Personal or otherwise sensitive information the dataset contains."},
+	"governance":                  {Label: "Governance", Description: "Who owns, stewards and looks after the data."},
+	"lastModified":                {Label: "Last modified", Description: "When the dataset was last changed."},
+	"signature":                   {Label: "Signature", Description: "Digital signature over the BOM, to check it was not altered."},
+}
+
+// propertyNamespaces explains the namespaces of the property names.
+// AIBoMGen records.
+var propertyNamespaces = map[string]string{
+	"huggingface":          "Metadata from the Hugging Face Hub, recorded as a property.",
+	"aibomgen:aiact":       "EU AI Act information about the model, recorded as a property.",
+	"aibomgen:attribution": "Copyright holders and attribution requirements, recorded as a property.",
+	"aibomgen:data":        "Coverage and origin of a dataset, recorded as a property.",
+	"aibomgen:fairness":    "Fairness evaluation of the model, recorded as a property.",
+	"aibomgen:governance":  "Consent and opt-out information of a dataset, recorded as a property.",
+	"aibomgen:kaggle":      "Metadata from Kaggle, recorded as a property.",
+	"aibomgen:ollama":      "Metadata from the Ollama registry, recorded as a property.",
+	"aibomgen:onnx":        "Metadata read from the ONNX model file, recorded as a property.",
+	"aibomgen:safetensors": "Metadata read from the safetensors weight file, recorded as a property.",
+	"aibomgen:weights":     "Metadata read from the model's weight file, recorded as a property.",
+	"aibomgen":             "Information AIBoMGen records as a property.",
+}
+
+// abbreviations are the abbreviations and acronyms of the reports.
+var abbreviations = []GlossaryTerm{
+	{Term: "AIBOM", Label: "AI bill of materials", Description: "A BOM for an AI model: the model, its datasets and their metadata."},
+	{Term: "BOM", Label: "Bill of materials", Description: "Machine-readable inventory of the parts of a piece of software."},
+	{Term: "SBOM", Label: "Software bill of materials", Description: "A BOM for software packages and libraries."},
+	{Term: "ML-BOM", Label: "Machine learning bill of materials", Description: "The CycloneDX profile for models and datasets (spec 1.5 and later)."},
+	{Term: "CycloneDX", Label: "CycloneDX", Description: "OWASP standard format for BOMs that AIBoMGen writes."},
+	{Term: "SPDX", Label: "Software Package Data Exchange", Description: "Linux Foundation standard for BOMs and license identifiers (e.g. Apache-2.0)."},
+	{Term: "purl", Label: "Package URL", Description: "Standard identifier of a package across ecosystems, e.g. pkg:huggingface/org/model."},
+	{Term: "HF", Label: "Hugging Face", Description: "The Hugging Face Hub that hosts models and datasets."},
+	{Term: "ONNX", Label: "Open Neural Network Exchange", Description: "Portable file format for models."},
+	{Term: "GGUF", Label: "GGUF", Description: "File format for quantized models used by llama.cpp and Ollama."},
+	{Term: "CVE", Label: "Common Vulnerabilities and Exposures", Description: "Public identifiers of security vulnerabilities."},
+	{Term: "OSV", Label: "Open Source Vulnerabilities", Description: "Vulnerability database for open source packages."},
+	{Term: "VEX", Label: "Vulnerability Exploitability eXchange", Description: "Statements on whether a vulnerability affects a product."},
+	{Term: "PII", Label: "Personally identifiable information", Description: "Data that identifies a person, such as names or email addresses."},
+	{Term: "TDM", Label: "Text and data mining", Description: "Automated analysis of content; rights holders can reserve it (EU DSM Directive, Article 4)."},
+	{Term: "EU AI Act", Label: "EU Artificial Intelligence Act", Description: "EU regulation (2024/1689) on AI systems and general-purpose AI models."},
+}
+
+var (
+	// indexRe matches an array index of a path segment (components[0]).
+	indexRe = regexp.MustCompile(`\[\d+\]`)
+
+	abbreviationRes = func() []*regexp.Regexp {
+		res := make([]*regexp.Regexp, len(abbreviations))
+		for i, a := range abbreviations {
+			res[i] = regexp.MustCompile(`(^|[^\w-])` + regexp.QuoteMeta(a.Term) + `($|[^\w-])`)
+		}
+		return res
+	}()
+)
+
+// ExplainPath reads a CycloneDX field path in plain language: a registry key.
+// such as BOM.metadata.component.modelCard.considerations or a schema path.
+// such as components[0].type. Registered fields are described by their help;.
+// other paths by the last field name of the glossary. It reports false when.
+// the path ends in a field the glossary does not know.
+func ExplainPath(path string) (PathExplanation, bool) {
+	path = strings.TrimSpace(path)
+	segs := strings.Split(indexRe.ReplaceAllString(path, ""), ".")
+	var labels []string
+	desc := ""
+	for i := 0; i < len(segs); i++ {
+		seg := segs[i]
+		if seg == "metadata" && i+1 < len(segs) && segs[i+1] == "component" {
+			seg = "metadata.component"
+			i++
+		}
+		t, ok := GlossaryTerm{}, false
+		if i > 0 {
+			t, ok = fieldTerms[segs[i-1]+"."+seg]
+		}
+		if !ok {
+			t, ok = lookupTerm(seg)
+		}
+		if !ok {
+			labels = append(labels, seg)
+			desc = ""
+			continue
+		}
+		if t.Label != "" {
+			labels = append(labels, t.Label)
+		}
+		desc = t.Description
+	}
+	if help, ok := LookupHelp(path); ok {
+		desc = help.Description
+	}
+	if desc == "" {
+		return PathExplanation{}, false
+	}
+	return PathExplanation{Location: strings.Join(labels, " › "), Description: desc}, true
+}
+
+// lookupTerm returns the glossary entry of a path segment. Property names.
+// are explained by their namespace and keep their name as label.
+func lookupTerm(seg string) (GlossaryTerm, bool) {
+	if t, ok := fieldTerms[seg]; ok {
+		t.Term = seg
+		return t, true
+	}
+	if i := strings.LastIndex(seg, ":"); i > 0 {
+		ns := seg[:i]
+		for ns != "" {
+			if desc, ok := propertyNamespaces[ns]; ok {
+				return GlossaryTerm{Term: seg, Label: seg, Description: desc}, true
+			}
+			j := strings.LastIndex(ns, ":")
+			if j < 0 {
+				break
+			}
+			ns = ns[:j]
+		}
+	}
+	return GlossaryTerm{}, false
+}
+
+// AbbreviationsIn returns the glossary entries of the abbreviations text.
+// uses, in glossary order.
+func AbbreviationsIn(text string) []GlossaryTerm {
+	var out []GlossaryTerm
+	for i, re := range abbreviationRes {
+		if re.MatchString(text) {
+			out = append(out, abbreviations[i])
+		}
+	}
+	return out
+}
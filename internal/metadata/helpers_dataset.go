@@ -1,6 +1,11 @@
 package metadata
 
-import cdx "github.com/CycloneDX/cyclonedx-go"
+import (
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/internal/taxonomy"
+)
 
 // Helper functions for working with Component.Data slice.
 func ensureComponentData(comp *cdx.Component) *cdx.ComponentData {
@@ -27,3 +32,36 @@ func getComponentData(comp *cdx.Component) *cdx.ComponentData {
 	}
 	return &(*comp.Data)[0]
 }
+
+// hasLegacyLastModifiedTag reports whether comp carries the "lastModified:".
+// tag older versions wrote for datasets.
+func hasLegacyLastModifiedTag(comp *cdx.Component) bool {
+	if comp == nil || comp.Tags == nil {
+		return false
+	}
+	for _, tag := range *comp.Tags {
+		if strings.HasPrefix(tag, taxonomy.LegacyLastModifiedTagPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// removeLegacyLastModifiedTags drops the "lastModified:" tags from comp,.
+// leaving Tags nil when none remain.
+func removeLegacyLastModifiedTags(comp *cdx.Component) {
+	if comp.Tags == nil {
+		return
+	}
+	var tags []string
+	for _, tag := range *comp.Tags {
+		if !strings.HasPrefix(tag, taxonomy.LegacyLastModifiedTagPrefix) {
+			tags = append(tags, tag)
+		}
+	}
+	if len(tags) == 0 {
+		comp.Tags = nil
+	} else {
+		comp.Tags = &tags
+	}
+}
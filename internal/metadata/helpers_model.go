@@ -4,6 +4,8 @@ import (
 	"strings"
 
 	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/internal/taxonomy"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/scanner"
 )
 
 func ensureModelParameters(card *cdx.MLModelCard) *cdx.MLModelParameters {
@@ -27,6 +29,36 @@ func ensureQuantitativeAnalysis(card *cdx.MLModelCard) *cdx.MLQuantitativeAnalys
 	return card.QuantitativeAnalysis
 }
 
+// splitSlicedMetrics separates the overall metrics of ms from the metrics.
+// of a slice (a group of a fairness evaluation).
+func splitSlicedMetrics(ms *[]cdx.MLPerformanceMetric) (overall, sliced []cdx.MLPerformanceMetric) {
+	if ms == nil {
+		return nil, nil
+	}
+	for _, m := range *ms {
+		if strings.TrimSpace(m.Slice) != "" {
+			sliced = append(sliced, m)
+		} else {
+			overall = append(overall, m)
+		}
+	}
+	return overall, sliced
+}
+
+// setPerformanceMetrics sets the performance metrics of qa to the.
+// concatenation of parts, or nil when they are all empty.
+func setPerformanceMetrics(qa *cdx.MLQuantitativeAnalysis, parts ...[]cdx.MLPerformanceMetric) {
+	var all []cdx.MLPerformanceMetric
+	for _, p := range parts {
+		all = append(all, p...)
+	}
+	if len(all) == 0 {
+		qa.PerformanceMetrics = nil
+		return
+	}
+	qa.PerformanceMetrics = &all
+}
+
 func normalizeDatasetRef(s string) string {
 	s = strings.TrimSpace(s)
 	if s == "" {
@@ -39,35 +71,70 @@ func normalizeDatasetRef(s string) string {
 	return "dataset:" + s
 }
 
+// scanHash returns the digest the scan recorded for a local or tracked file:.
+// SHA-256 unless the discovery names another algorithm (MD5 for DVC).
+func scanHash(d scanner.Discovery) (cdx.Hash, bool) {
+	h := strings.TrimSpace(d.Hash)
+	if h == "" {
+		return cdx.Hash{}, false
+	}
+	alg := cdx.HashAlgoSHA256
+	if d.HashAlgorithm != "" {
+		alg = cdx.HashAlgorithm(d.HashAlgorithm)
+	}
+	return cdx.Hash{Algorithm: alg, Value: h}, true
+}
+
+// scanHashes returns the digests the scan recorded for a local or tracked.
+// file: that of scanHash, followed by its BLAKE3 digest when one was computed.
+func scanHashes(d scanner.Discovery) ([]cdx.Hash, bool) {
+	h, ok := scanHash(d)
+	if !ok {
+		return nil, false
+	}
+	hs := []cdx.Hash{h}
+	if b3 := strings.TrimSpace(d.BLAKE3); b3 != "" {
+		hs = append(hs, cdx.Hash{Algorithm: cdx.HashAlgoBlake3, Value: b3})
+	}
+	return hs, true
+}
+
+// setProperty adds the taxonomy property name=value to c. Empty values are.
+// skipped.
 func setProperty(c *cdx.Component, name, value string) {
 	if c == nil {
 		return
 	}
-	name = strings.TrimSpace(name)
-	value = strings.TrimSpace(value)
-	if name == "" || value == "" {
-		return
-	}
-	if c.Properties == nil {
-		c.Properties = &[]cdx.Property{}
-	}
-	*c.Properties = append(*c.Properties, cdx.Property{Name: name, Value: value})
+	c.Properties = taxonomy.Set(c.Properties, name, value)
 }
 
+// hasProperty reports whether c holds a non-empty value for the taxonomy.
+// property name, under its current or a legacy name.
 func hasProperty(c *cdx.Component, name string) bool {
-	if c == nil || c.Properties == nil {
-		return false
+	return c != nil && taxonomy.Has(c.Properties, name)
+}
+
+// removeProperty drops every property called name (or a legacy name of it).
+// from c, leaving Properties nil when none remain.
+func removeProperty(c *cdx.Component, name string) {
+	if c == nil {
+		return
 	}
-	name = strings.TrimSpace(name)
-	if name == "" {
-		return false
+	c.Properties = taxonomy.Remove(c.Properties, name)
+}
+
+func targetModelParameters(tgt Target) *cdx.MLModelParameters {
+	if tgt.ModelCard == nil {
+		return nil
 	}
-	for _, p := range *c.Properties {
-		if strings.TrimSpace(p.Name) == name && strings.TrimSpace(p.Value) != "" {
-			return true
-		}
+	return tgt.ModelCard.ModelParameters
+}
+
+func targetConsiderations(tgt Target) *cdx.MLModelCardConsiderations {
+	if tgt.ModelCard == nil {
+		return nil
 	}
-	return false
+	return tgt.ModelCard.Considerations
 }
 
 func bomComponent(b *cdx.BOM) *cdx.Component {
@@ -0,0 +1,149 @@
+// Package metrics records opt-in, local-only usage metrics.
+//.
+// When enabled, every CLI run appends a single JSON line to a metrics file on.
+// the user's machine. Nothing is ever sent over the network: the file exists so.
+// users can tune timeouts, concurrency and caching with the `stats` command.
+package metrics
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Run is one recorded CLI invocation.
+type Run struct {
+	Command     string    `json:"command"`
+	Started     time.Time `json:"started"`
+	DurationMS  int64     `json:"durationMs"`
+	APICalls    int64     `json:"apiCalls"`
+	CacheHits   int64     `json:"cacheHits"`
+	CacheMisses int64     `json:"cacheMisses"`
+	// APIErrors counts failed Hugging Face requests.
+	APIErrors int64 `json:"apiErrors,omitempty"`
+	// BOMsWritten and Scores describe the BOMs written by the run, with the.
+	// completeness score of each.
+	BOMsWritten int       `json:"bomsWritten,omitempty"`
+	Scores      []float64 `json:"scores,omitempty"`
+}
+
+// Duration returns the run duration.
+func (r Run) Duration() time.Duration {
+	return time.Duration(r.DurationMS) * time.Millisecond
+}
+
+// DefaultPath returns the default metrics file location.
+func DefaultPath() (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "aibomgen-cli", "metrics.jsonl"), nil
+}
+
+// Append adds r to the metrics file at path, creating it when needed.
+func Append(path string, r Run) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	line, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(line, '\n'))
+	return err
+}
+
+// Load reads all runs from path. A missing file yields no runs and no error.
+// Malformed lines are skipped.
+func Load(path string) ([]Run, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var runs []Run
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		var r Run
+		if err := json.Unmarshal(sc.Bytes(), &r); err != nil {
+			continue
+		}
+		runs = append(runs, r)
+	}
+	return runs, sc.Err()
+}
+
+// CommandStats aggregates the runs of a single command.
+type CommandStats struct {
+	Command     string
+	Runs        int
+	Total       time.Duration
+	Median      time.Duration
+	Max         time.Duration
+	APICalls    int64
+	CacheHits   int64
+	CacheMisses int64
+}
+
+// Average returns the mean run duration.
+func (s CommandStats) Average() time.Duration {
+	if s.Runs == 0 {
+		return 0
+	}
+	return s.Total / time.Duration(s.Runs)
+}
+
+// CacheHitRate returns the BOM cache hit rate in [0,1], or -1 when the cache.
+// was never consulted.
+func (s CommandStats) CacheHitRate() float64 {
+	lookups := s.CacheHits + s.CacheMisses
+	if lookups == 0 {
+		return -1
+	}
+	return float64(s.CacheHits) / float64(lookups)
+}
+
+// Summarize groups runs by command, sorted by command name.
+func Summarize(runs []Run) []CommandStats {
+	byCmd := make(map[string][]Run)
+	for _, r := range runs {
+		byCmd[r.Command] = append(byCmd[r.Command], r)
+	}
+
+	out := make([]CommandStats, 0, len(byCmd))
+	for name, rs := range byCmd {
+		s := CommandStats{Command: name, Runs: len(rs)}
+		durations := make([]time.Duration, 0, len(rs))
+		for _, r := range rs {
+			d := r.Duration()
+			durations = append(durations, d)
+			s.Total += d
+			if d > s.Max {
+				s.Max = d
+			}
+			s.APICalls += r.APICalls
+			s.CacheHits += r.CacheHits
+			s.CacheMisses += r.CacheMisses
+		}
+		sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+		s.Median = durations[len(durations)/2]
+		out = append(out, s)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Command < out[j].Command })
+	return out
+}
@@ -0,0 +1,52 @@
+package metrics
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAppendLoadSummarize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "metrics.jsonl")
+
+	runs, err := Load(path)
+	if err != nil || len(runs) != 0 {
+		t.Fatalf("expected empty load for missing file, got %v %v", runs, err)
+	}
+
+	for _, r := range []Run{
+		{Command: "scan", DurationMS: 100, APICalls: 4, CacheHits: 1, CacheMisses: 1},
+		{Command: "scan", DurationMS: 300, APICalls: 2, CacheHits: 2},
+		{Command: "validate", DurationMS: 50},
+	} {
+		r.Started = time.Now()
+		if err := Append(path, r); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+	// Malformed lines are ignored.
+	f, _ := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+	_, _ = f.WriteString("not json\n")
+	_ = f.Close()
+
+	runs, err = Load(path)
+	if err != nil || len(runs) != 3 {
+		t.Fatalf("expected 3 runs, got %d (%v)", len(runs), err)
+	}
+
+	stats := Summarize(runs)
+	if len(stats) != 2 || stats[0].Command != "scan" || stats[1].Command != "validate" {
+		t.Fatalf("unexpected summary: %+v", stats)
+	}
+	scan := stats[0]
+	if scan.Runs != 2 || scan.APICalls != 6 || scan.Average() != 200*time.Millisecond || scan.Max != 300*time.Millisecond {
+		t.Fatalf("unexpected scan stats: %+v", scan)
+	}
+	if rate := scan.CacheHitRate(); rate < 0.74 || rate > 0.76 {
+		t.Fatalf("cache hit rate = %v, want 0.75", rate)
+	}
+	if stats[1].CacheHitRate() != -1 {
+		t.Fatalf("expected -1 hit rate without lookups")
+	}
+}
@@ -0,0 +1,227 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Histogram bucket upper bounds.
+var (
+	durationBuckets = []float64{1, 5, 15, 30, 60, 120, 300, 600, 1800, 3600}
+	scoreBuckets    = []float64{0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8, 0.9, 1}
+)
+
+// Collector aggregates runs and daemon job outcomes for the Prometheus text.
+// exposition format. It is safe for concurrent use and serves /metrics as an.
+// http.Handler.
+type Collector struct {
+	mu       sync.Mutex
+	commands map[string]*commandTotals
+	scores   histogram
+	jobs     map[string]*jobTotals
+}
+
+type commandTotals struct {
+	runs, boms, apiCalls, apiErrors, cacheHits, cacheMisses int64
+	duration                                                histogram
+}
+
+type jobTotals struct {
+	success, failure int64
+	lastSuccess      time.Time
+	duration         histogram
+}
+
+type histogram struct {
+	counts []int64
+	count  int64
+	sum    float64
+}
+
+func (h *histogram) observe(bounds []float64, v float64) {
+	if h.counts == nil {
+		h.counts = make([]int64, len(bounds))
+	}
+	for i, b := range bounds {
+		if v <= b {
+			h.counts[i]++
+		}
+	}
+	h.count++
+	h.sum += v
+}
+
+// NewCollector returns an empty Collector.
+func NewCollector() *Collector {
+	return &Collector{
+		commands: make(map[string]*commandTotals),
+		jobs:     make(map[string]*jobTotals),
+	}
+}
+
+// Observe adds a recorded run.
+func (c *Collector) Observe(r Run) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := c.commands[r.Command]
+	if t == nil {
+		t = &commandTotals{}
+		c.commands[r.Command] = t
+	}
+	t.runs++
+	t.boms += int64(r.BOMsWritten)
+	t.apiCalls += r.APICalls
+	t.apiErrors += r.APIErrors
+	t.cacheHits += r.CacheHits
+	t.cacheMisses += r.CacheMisses
+	t.duration.observe(durationBuckets, r.Duration().Seconds())
+	for _, s := range r.Scores {
+		c.scores.observe(scoreBuckets, s)
+	}
+}
+
+// ObserveJob adds the outcome of one run of a daemon job that finished at.
+// end.
+func (c *Collector) ObserveJob(job string, ok bool, d time.Duration, end time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := c.jobs[job]
+	if t == nil {
+		t = &jobTotals{}
+		c.jobs[job] = t
+	}
+	if ok {
+		t.success++
+		t.lastSuccess = end
+	} else {
+		t.failure++
+	}
+	t.duration.observe(durationBuckets, d.Seconds())
+}
+
+// ServeHTTP writes the metrics in the Prometheus text exposition format.
+func (c *Collector) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = c.Write(w)
+}
+
+// Write writes the metrics in the Prometheus text exposition format.
+func (c *Collector) Write(w io.Writer) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e := &expo{w: w}
+
+	cmds := sortedKeys(c.commands)
+	counters := []struct {
+		name, help string
+		value      func(*commandTotals) int64
+	}{
+		{"aibomgen_runs_total", "Completed aibomgen-cli runs.", func(t *commandTotals) int64 { return t.runs }},
+		{"aibomgen_boms_generated_total", "BOMs written.", func(t *commandTotals) int64 { return t.boms }},
+		{"aibomgen_hf_api_requests_total", "Hugging Face API requests.", func(t *commandTotals) int64 { return t.apiCalls }},
+		{"aibomgen_hf_api_errors_total", "Failed Hugging Face API requests.", func(t *commandTotals) int64 { return t.apiErrors }},
+		{"aibomgen_bom_cache_hits_total", "BOM cache hits.", func(t *commandTotals) int64 { return t.cacheHits }},
+		{"aibomgen_bom_cache_misses_total", "BOM cache misses.", func(t *commandTotals) int64 { return t.cacheMisses }},
+	}
+	for _, m := range counters {
+		e.header(m.name, "counter", m.help)
+		for _, cmd := range cmds {
+			e.sample(m.name, labels("command", cmd), float64(m.value(c.commands[cmd])))
+		}
+	}
+	e.header("aibomgen_run_duration_seconds", "histogram", "Duration of completed aibomgen-cli runs.")
+	for _, cmd := range cmds {
+		e.histogram("aibomgen_run_duration_seconds", labels("command", cmd), durationBuckets, c.commands[cmd].duration)
+	}
+	e.header("aibomgen_completeness_score", "histogram", "Completeness scores of the BOMs written.")
+	e.histogram("aibomgen_completeness_score", "", scoreBuckets, c.scores)
+
+	jobs := sortedKeys(c.jobs)
+	e.header("aibomgen_job_runs_total", "counter", "Daemon job runs by outcome.")
+	for _, j := range jobs {
+		e.sample("aibomgen_job_runs_total", labels("job", j, "status", "success"), float64(c.jobs[j].success))
+		e.sample("aibomgen_job_runs_total", labels("job", j, "status", "failure"), float64(c.jobs[j].failure))
+	}
+	e.header("aibomgen_job_duration_seconds", "histogram", "Duration of daemon job runs.")
+	for _, j := range jobs {
+		e.histogram("aibomgen_job_duration_seconds", labels("job", j), durationBuckets, c.jobs[j].duration)
+	}
+	e.header("aibomgen_job_last_success_timestamp_seconds", "gauge", "Unix time of the last successful run of a daemon job.")
+	for _, j := range jobs {
+		if ts := c.jobs[j].lastSuccess; !ts.IsZero() {
+			e.sample("aibomgen_job_last_success_timestamp_seconds", labels("job", j), float64(ts.Unix()))
+		}
+	}
+	return e.err
+}
+
+// expo writes the exposition format and keeps the first write error.
+type expo struct {
+	w   io.Writer
+	err error
+}
+
+func (e *expo) printf(format string, args ...any) {
+	if e.err == nil {
+		_, e.err = fmt.Fprintf(e.w, format, args...)
+	}
+}
+
+func (e *expo) header(name, typ, help string) {
+	e.printf("# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+}
+
+func (e *expo) sample(name, lbls string, v float64) {
+	if lbls != "" {
+		lbls = "{" + lbls + "}"
+	}
+	e.printf("%s%s %s\n", name, lbls, formatValue(v))
+}
+
+func (e *expo) histogram(name, lbls string, bounds []float64, h histogram) {
+	join := func(extra string) string {
+		if lbls == "" {
+			return extra
+		}
+		return lbls + "," + extra
+	}
+	for i, b := range bounds {
+		var n int64
+		if h.counts != nil {
+			n = h.counts[i]
+		}
+		e.sample(name+"_bucket", join(labels("le", formatValue(b))), float64(n))
+	}
+	e.sample(name+"_bucket", join(`le="+Inf"`), float64(h.count))
+	e.sample(name+"_sum", lbls, h.sum)
+	e.sample(name+"_count", lbls, float64(h.count))
+}
+
+// labels renders name/value pairs as a label list.
+func labels(kv ...string) string {
+	parts := make([]string, 0, len(kv)/2)
+	for i := 0; i+1 < len(kv); i += 2 {
+		parts = append(parts, kv[i]+`="`+labelEscaper.Replace(kv[i+1])+`"`)
+	}
+	return strings.Join(parts, ",")
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func formatValue(v float64) string {
+	return fmt.Sprintf("%g", v)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,49 @@
+package metrics
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCollector(t *testing.T) {
+	c := NewCollector()
+	c.Observe(Run{Command: "scan", DurationMS: 2500, APICalls: 10, APIErrors: 2, CacheHits: 3, CacheMisses: 1, BOMsWritten: 2, Scores: []float64{0.45, 0.95}})
+	c.Observe(Run{Command: "scan", DurationMS: 500, APICalls: 4})
+	c.Observe(Run{Command: "generate", DurationMS: 90000, BOMsWritten: 1, Scores: []float64{1}})
+	end := time.Unix(1700000000, 0)
+	c.ObserveJob(`night"ly`, true, 3*time.Second, end)
+	c.ObserveJob(`night"ly`, false, time.Second, end.Add(time.Hour))
+
+	rec := httptest.NewRecorder()
+	c.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	out := rec.Body.String()
+	for _, want := range []string{
+		"# TYPE aibomgen_runs_total counter\n",
+		`aibomgen_runs_total{command="scan"} 2`,
+		`aibomgen_runs_total{command="generate"} 1`,
+		`aibomgen_boms_generated_total{command="scan"} 2`,
+		`aibomgen_hf_api_requests_total{command="scan"} 14`,
+		`aibomgen_hf_api_errors_total{command="scan"} 2`,
+		`aibomgen_bom_cache_hits_total{command="scan"} 3`,
+		`aibomgen_run_duration_seconds_bucket{command="scan",le="1"} 1`,
+		`aibomgen_run_duration_seconds_bucket{command="scan",le="5"} 2`,
+		`aibomgen_run_duration_seconds_bucket{command="generate",le="60"} 0`,
+		`aibomgen_run_duration_seconds_bucket{command="generate",le="+Inf"} 1`,
+		`aibomgen_run_duration_seconds_sum{command="scan"} 3`,
+		`aibomgen_completeness_score_bucket{le="0.5"} 1`,
+		`aibomgen_completeness_score_bucket{le="1"} 3`,
+		"aibomgen_completeness_score_count 3",
+		`aibomgen_job_runs_total{job="night\"ly",status="success"} 1`,
+		`aibomgen_job_runs_total{job="night\"ly",status="failure"} 1`,
+		`aibomgen_job_last_success_timestamp_seconds{job="night\"ly"} 1.7e+09`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+}
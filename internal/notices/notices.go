@@ -0,0 +1,233 @@
+// Package notices builds a third-party notices (attributions) document from.
+// the model and dataset components of one or more AIBOMs.
+//.
+// Every component contributes one entry with its licenses, attached license.
+// texts, copyright holders and the attribution its authors require. Entries are deduplicated across BOMs, sorted.
+// (models first, then datasets, each by name) and rendered through a.
+// text/template, so release teams can supply their own layout.
+package notices
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/internal/taxonomy"
+)
+
+// Entry kinds.
+const (
+	KindModel   = "model"
+	KindDataset = "dataset"
+)
+
+// Entry is the attribution of one model or dataset.
+type Entry struct {
+	Kind       string
+	Name       string
+	Version    string
+	PackageURL string
+	// Licenses are the SPDX IDs, names or expressions of the component.
+	Licenses []string
+	// LicenseTexts are the license texts attached to the component.
+	LicenseTexts []string
+	// Copyright is the copyright statement of the component, if recorded.
+	Copyright string
+	// Holders are the holders named by the copyright notices of the.
+	// component, then its manufacturer and supplier. Authors (e.g. dataset.
+	// annotators) do not necessarily hold the copyright.
+	Holders []string
+	// Attribution is the attribution the authors require, if recorded.
+	Attribution string
+}
+
+// Document is the data passed to the notices template.
+type Document struct {
+	Entries []Entry
+}
+
+// DefaultTemplate is the plain-text layout used when no template is given.
+const DefaultTemplate = `THIRD-PARTY NOTICES
+
+This product bundles the following machine learning models and datasets.
+Their licenses and copyright notices are reproduced below.
+{{range .Entries}}
+--------------------------------------------------------------------------------
+{{.Name}}{{if .Version}} ({{.Version}}){{end}} [{{.Kind}}]
+{{- if .PackageURL}}
+Package URL: {{.PackageURL}}{{end}}
+License: {{if .Licenses}}{{join .Licenses ", "}}{{else}}NOASSERTION{{end}}
+{{- if .Copyright}}
+{{.Copyright}}{{end}}
+{{- if .Holders}}
+Copyright holder(s): {{join .Holders ", "}}{{end}}
+{{- if .Attribution}}
+Attribution: {{.Attribution}}{{end}}
+{{- range .LicenseTexts}}
+
+{{.}}{{end}}
+{{end}}`
+
+// Collect returns the entries for the model and dataset components of boms:.
+// the metadata component and the top-level components. Components that appear.
+// in several BOMs (same PURL, or same name and version) are listed once, with.
+// the union of their licenses and holders.
+func Collect(boms ...*cdx.BOM) []Entry {
+	var entries []Entry
+	index := make(map[string]int)
+
+	add := func(c *cdx.Component) {
+		kind := componentKind(c)
+		if kind == "" || strings.TrimSpace(c.Name) == "" {
+			return
+		}
+		e := newEntry(kind, c)
+		key := e.PackageURL
+		if key == "" {
+			key = kind + ":" + e.Name + "@" + e.Version
+		}
+		if i, ok := index[key]; ok {
+			entries[i].Licenses = appendUnique(entries[i].Licenses, e.Licenses...)
+			entries[i].LicenseTexts = appendUnique(entries[i].LicenseTexts, e.LicenseTexts...)
+			entries[i].Holders = appendUnique(entries[i].Holders, e.Holders...)
+			if entries[i].Copyright == "" {
+				entries[i].Copyright = e.Copyright
+			}
+			if entries[i].Attribution == "" {
+				entries[i].Attribution = e.Attribution
+			}
+			return
+		}
+		index[key] = len(entries)
+		entries = append(entries, e)
+	}
+
+	for _, bom := range boms {
+		if bom == nil {
+			continue
+		}
+		if bom.Metadata != nil && bom.Metadata.Component != nil {
+			add(bom.Metadata.Component)
+		}
+		if bom.Components != nil {
+			for i := range *bom.Components {
+				add(&(*bom.Components)[i])
+			}
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Kind != entries[j].Kind {
+			return entries[i].Kind == KindModel
+		}
+		if a, b := strings.ToLower(entries[i].Name), strings.ToLower(entries[j].Name); a != b {
+			return a < b
+		}
+		return entries[i].Version < entries[j].Version
+	})
+	return entries
+}
+
+// Render writes the notices document for entries to w using tmpl, or.
+// DefaultTemplate when tmpl is empty. Templates can use the "join" function.
+// (strings.Join).
+func Render(w io.Writer, entries []Entry, tmpl string) error {
+	if tmpl == "" {
+		tmpl = DefaultTemplate
+	}
+	t, err := template.New("notices").Funcs(template.FuncMap{"join": strings.Join}).Parse(tmpl)
+	if err != nil {
+		return fmt.Errorf("parse notices template: %w", err)
+	}
+	return t.Execute(w, Document{Entries: entries})
+}
+
+// LoadTemplate reads a notices template from path.
+func LoadTemplate(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("read notices template: %w", err)
+	}
+	return string(data), nil
+}
+
+func componentKind(c *cdx.Component) string {
+	switch c.Type {
+	case cdx.ComponentTypeMachineLearningModel:
+		return KindModel
+	case cdx.ComponentTypeData:
+		return KindDataset
+	}
+	return ""
+}
+
+func newEntry(kind string, c *cdx.Component) Entry {
+	e := Entry{
+		Kind:       kind,
+		Name:       strings.TrimSpace(c.Name),
+		Version:    strings.TrimSpace(c.Version),
+		PackageURL: strings.TrimSpace(c.PackageURL),
+		Copyright:  strings.TrimSpace(c.Copyright),
+	}
+
+	if c.Licenses != nil {
+		for _, choice := range *c.Licenses {
+			if expr := strings.TrimSpace(choice.Expression); expr != "" {
+				e.Licenses = appendUnique(e.Licenses, expr)
+			}
+			lic := choice.License
+			if lic == nil {
+				continue
+			}
+			name := strings.TrimSpace(lic.ID)
+			if name == "" {
+				name = strings.TrimSpace(lic.Name)
+			}
+			e.Licenses = appendUnique(e.Licenses, name)
+			// Base64 attachments are binary documents that cannot be reproduced inline.
+			if lic.Text != nil && lic.Text.Encoding == "" {
+				e.LicenseTexts = appendUnique(e.LicenseTexts, strings.TrimSpace(lic.Text.Content))
+			}
+		}
+	}
+
+	if holders, ok := taxonomy.Get(c.Properties, taxonomy.AttributionHolders); ok {
+		for _, h := range strings.Split(holders, ";") {
+			e.Holders = appendUnique(e.Holders, strings.TrimSpace(h))
+		}
+	}
+	if s, ok := taxonomy.Get(c.Properties, taxonomy.AttributionStatement); ok {
+		e.Attribution = strings.TrimSpace(s)
+	}
+	if c.Manufacturer != nil {
+		e.Holders = appendUnique(e.Holders, strings.TrimSpace(c.Manufacturer.Name))
+	}
+	if c.Supplier != nil {
+		e.Holders = appendUnique(e.Holders, strings.TrimSpace(c.Supplier.Name))
+	}
+	return e
+}
+
+// appendUnique appends the non-empty values of add that are not yet in list.
+func appendUnique(list []string, add ...string) []string {
+	for _, v := range add {
+		if v == "" {
+			continue
+		}
+		dup := false
+		for _, have := range list {
+			if strings.EqualFold(have, v) {
+				dup = true
+				break
+			}
+		}
+		if !dup {
+			list = append(list, v)
+		}
+	}
+	return list
+}
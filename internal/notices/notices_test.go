@@ -0,0 +1,93 @@
+package notices
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+func modelBOM(name, purl, license string, datasets ...cdx.Component) *cdx.BOM {
+	bom := cdx.NewBOM()
+	bom.Metadata = &cdx.Metadata{Component: &cdx.Component{
+		Type:         cdx.ComponentTypeMachineLearningModel,
+		Name:         name,
+		PackageURL:   purl,
+		Manufacturer: &cdx.OrganizationalEntity{Name: strings.Split(name, "/")[0]},
+		Licenses:     &cdx.Licenses{{License: &cdx.License{Name: license}}},
+	}}
+	bom.Components = &datasets
+	return bom
+}
+
+func TestCollectSortsAndDeduplicates(t *testing.T) {
+	imdb := cdx.Component{
+		Type:     cdx.ComponentTypeData,
+		Name:     "imdb",
+		Licenses: &cdx.Licenses{{License: &cdx.License{ID: "CC-BY-4.0"}}},
+	}
+	library := cdx.Component{Type: cdx.ComponentTypeLibrary, Name: "torch"}
+
+	boms := []*cdx.BOM{
+		modelBOM("zeta/model", "pkg:huggingface/zeta/model@1", "mit", imdb),
+		modelBOM("alpha/model", "pkg:huggingface/alpha/model@2", "apache-2.0", imdb, library),
+		nil,
+	}
+
+	entries := Collect(boms...)
+
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Kind+":"+e.Name)
+	}
+	want := []string{"model:alpha/model", "model:zeta/model", "dataset:imdb"}
+	if !reflect.DeepEqual(names, want) {
+		t.Fatalf("entries = %v, want %v", names, want)
+	}
+	if !reflect.DeepEqual(entries[0].Licenses, []string{"apache-2.0"}) || !reflect.DeepEqual(entries[0].Holders, []string{"alpha"}) {
+		t.Errorf("unexpected model entry %+v", entries[0])
+	}
+	if !reflect.DeepEqual(entries[2].Licenses, []string{"CC-BY-4.0"}) {
+		t.Errorf("unexpected dataset entry %+v", entries[2])
+	}
+}
+
+func TestRenderIncludesLicenseTexts(t *testing.T) {
+	bom := modelBOM("org/model", "", "mit")
+	lic := (*bom.Metadata.Component.Licenses)[0].License
+	lic.Text = &cdx.AttachedText{ContentType: "text/plain", Content: "MIT License\n\nCopyright (c) 2024 org\n"}
+	bom.Metadata.Component.Copyright = "Copyright (c) 2024 org"
+	bom.Metadata.Component.Properties = &[]cdx.Property{
+		{Name: "aibomgen:attribution:holders", Value: "Org Labs; org"},
+		{Name: "aibomgen:attribution:statement", Value: "Built with org/model."},
+	}
+
+	var buf bytes.Buffer
+	if err := Render(&buf, Collect(bom), ""); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"THIRD-PARTY NOTICES", "org/model [model]", "License: mit", "Copyright (c) 2024 org", "Copyright holder(s): Org Labs, org", "Attribution: Built with org/model.", "MIT License\n\nCopyright (c) 2024 org"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("notices missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestRenderCustomTemplate(t *testing.T) {
+	entries := Collect(modelBOM("org/model", "", "mit"))
+
+	var buf bytes.Buffer
+	if err := Render(&buf, entries, "{{range .Entries}}{{.Name}}={{join .Licenses \"+\"}};{{end}}"); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if got := buf.String(); got != "org/model=mit;" {
+		t.Fatalf("got %q", got)
+	}
+
+	if err := Render(&buf, entries, "{{.Missing"); err == nil {
+		t.Fatalf("expected template parse error")
+	}
+}
@@ -0,0 +1,187 @@
+// Package notify posts end-of-run summaries to chat and webhook endpoints, so.
+// that scheduled BOM refresh jobs alert their owners without extra scripting.
+//.
+// A target is a URL whose scheme selects the payload:.
+//.
+//	webhook://host/path   the Summary as JSON.
+//	slack://host/path     a Slack incoming-webhook message.
+//	teams://host/path     a Microsoft Teams incoming-webhook message card.
+//.
+// The request is sent over HTTPS to host/path. Appending "+http" to the.
+// scheme (e.g. webhook+http://localhost:8080/hook) sends it over plain HTTP.
+// for local receivers.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Regression is a drop in the completeness score of a regenerated BOM.
+type Regression struct {
+	Name     string  `json:"name"`
+	Previous float64 `json:"previous"`
+	Current  float64 `json:"current"`
+}
+
+// Summary is the end-of-run summary of one command.
+type Summary struct {
+	Command  string `json:"command"`
+	Success  bool   `json:"success"`
+	Error    string `json:"error,omitempty"`
+	Duration string `json:"duration"`
+
+	// ModelsGenerated is the number of BOMs written.
+	ModelsGenerated int      `json:"modelsGenerated"`
+	Files           []string `json:"files,omitempty"`
+	// Failures lists the models for which no BOM could be built.
+	Failures []string `json:"failures,omitempty"`
+	// PolicyViolations lists validation errors.
+	PolicyViolations []string     `json:"policyViolations,omitempty"`
+	ScoreRegressions []Regression `json:"scoreRegressions,omitempty"`
+}
+
+// Text renders the summary as a short Markdown message.
+func (s Summary) Text() string {
+	var b strings.Builder
+	status := "succeeded"
+	if !s.Success {
+		status = "failed"
+	}
+	fmt.Fprintf(&b, "**aibomgen-cli %s %s** in %s", s.Command, status, s.Duration)
+	if s.Error != "" {
+		fmt.Fprintf(&b, ": %s", s.Error)
+	}
+	b.WriteString("\n")
+	if s.ModelsGenerated > 0 {
+		fmt.Fprintf(&b, "- BOMs written: %d\n", s.ModelsGenerated)
+	}
+	writeList(&b, "Failed models", s.Failures)
+	writeList(&b, "Policy violations", s.PolicyViolations)
+	if len(s.ScoreRegressions) > 0 {
+		fmt.Fprintf(&b, "- Score regressions: %d\n", len(s.ScoreRegressions))
+		for _, r := range s.ScoreRegressions {
+			fmt.Fprintf(&b, "  - %s: %.2f → %.2f\n", r.Name, r.Previous, r.Current)
+		}
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// maxListed caps the items listed per section of a message.
+const maxListed = 20
+
+func writeList(b *strings.Builder, title string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "- %s: %d\n", title, len(items))
+	for i, it := range items {
+		if i == maxListed {
+			fmt.Fprintf(b, "  - … and %d more\n", len(items)-maxListed)
+			break
+		}
+		fmt.Fprintf(b, "  - %s\n", it)
+	}
+}
+
+// Send posts s to target. client may be nil.
+func Send(ctx context.Context, client *http.Client, target string, s Summary) error {
+	endpoint, kind, err := parseTarget(target)
+	if err != nil {
+		return err
+	}
+
+	var payload any
+	switch kind {
+	case "webhook":
+		payload = s
+	case "slack":
+		payload = map[string]string{"text": slackText(s.Text())}
+	case "teams":
+		color := "2EB886"
+		if !s.Success {
+			color = "D00000"
+		}
+		payload = map[string]any{
+			"@type":      "MessageCard",
+			"@context":   "https://schema.org/extensions",
+			"summary":    fmt.Sprintf("aibomgen-cli %s", s.Command),
+			"themeColor": color,
+			"text":       s.Text(),
+		}
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if client == nil {
+		client = &http.Client{Timeout: 15 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		// *url.Error repeats the full URL; keep only the cause.
+		var uerr *url.Error
+		if errors.As(err, &uerr) {
+			err = uerr.Err
+		}
+		return fmt.Errorf("notify %s: %w", redact(endpoint), err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("notify %s: HTTP %d", redact(endpoint), resp.StatusCode)
+	}
+	return nil
+}
+
+// parseTarget returns the HTTP endpoint and payload kind of a target URL.
+func parseTarget(target string) (string, string, error) {
+	u, err := url.Parse(strings.TrimSpace(target))
+	if err != nil || u.Host == "" {
+		return "", "", fmt.Errorf("invalid notify target %q: want <webhook|slack|teams>://host/path", target)
+	}
+	kind, transport, _ := strings.Cut(strings.ToLower(u.Scheme), "+")
+	switch kind {
+	case "webhook", "slack", "teams":
+	default:
+		return "", "", fmt.Errorf("unsupported notify target %q (want webhook://, slack:// or teams://)", u.Scheme)
+	}
+	switch transport {
+	case "":
+		u.Scheme = "https"
+	case "http", "https":
+		u.Scheme = transport
+	default:
+		return "", "", fmt.Errorf("unsupported notify transport %q", transport)
+	}
+	return u.String(), kind, nil
+}
+
+// slackText converts the Markdown bold markers to Slack's mrkdwn.
+func slackText(s string) string {
+	return strings.ReplaceAll(s, "**", "*")
+}
+
+// redact drops the path and query of an endpoint: webhook URLs embed their.
+// secret there, and errors end up in CI logs.
+func redact(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "endpoint"
+	}
+	return u.Scheme + "://" + u.Host
+}
@@ -0,0 +1,92 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func testSummary() Summary {
+	return Summary{
+		Command:          "scan",
+		Success:          false,
+		Error:            "validation failed",
+		Duration:         "2s",
+		ModelsGenerated:  2,
+		Failures:         []string{"org/missing"},
+		PolicyViolations: []string{"missing license"},
+		ScoreRegressions: []Regression{{Name: "org/model", Previous: 0.8, Current: 0.6}},
+	}
+}
+
+func TestText(t *testing.T) {
+	text := testSummary().Text()
+	for _, want := range []string{"**aibomgen-cli scan failed** in 2s: validation failed", "BOMs written: 2", "Failed models: 1", "org/missing", "Policy violations: 1", "org/model: 0.80 → 0.60"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Text() = %q, missing %q", text, want)
+		}
+	}
+}
+
+func TestSend(t *testing.T) {
+	var got map[string]any
+	var path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		body, _ := io.ReadAll(r.Body)
+		got = nil
+		_ = json.Unmarshal(body, &got)
+	}))
+	defer srv.Close()
+	host := strings.TrimPrefix(srv.URL, "http://")
+
+	cases := []struct {
+		target string
+		check  func() bool
+	}{
+		{"webhook+http://" + host + "/hook", func() bool { return got["command"] == "scan" && got["modelsGenerated"] == float64(2) }},
+		{"slack+http://" + host + "/services/T/B/X", func() bool {
+			text, _ := got["text"].(string)
+			return strings.HasPrefix(text, "*aibomgen-cli scan failed*")
+		}},
+		{"teams+http://" + host + "/webhookb2/x", func() bool { return got["@type"] == "MessageCard" && got["themeColor"] == "D00000" }},
+	}
+	for _, c := range cases {
+		if err := Send(context.Background(), srv.Client(), c.target, testSummary()); err != nil {
+			t.Fatalf("Send(%s): %v", c.target, err)
+		}
+		if !c.check() {
+			t.Errorf("Send(%s): unexpected payload %v", c.target, got)
+		}
+	}
+	if path != "/webhookb2/x" {
+		t.Errorf("path = %q", path)
+	}
+}
+
+func TestSendErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+	host := strings.TrimPrefix(srv.URL, "http://")
+
+	err := Send(context.Background(), srv.Client(), "slack+http://"+host+"/services/SECRET", testSummary())
+	if err == nil || !strings.Contains(err.Error(), "HTTP 403") || strings.Contains(err.Error(), "SECRET") {
+		t.Errorf("expected a redacted HTTP error, got %v", err)
+	}
+	srv.Close()
+	err = Send(context.Background(), nil, "webhook+http://"+host+"/SECRET", testSummary())
+	if err == nil || strings.Contains(err.Error(), "SECRET") {
+		t.Errorf("expected a redacted connection error, got %v", err)
+	}
+	for _, target := range []string{"ftp://host/x", "webhook+gopher://host/x", "webhook:///nohost", "not a url"} {
+		if err := Send(context.Background(), nil, target, testSummary()); err == nil {
+			t.Errorf("Send(%q): expected an error", target)
+		}
+	}
+}
@@ -0,0 +1,430 @@
+package oci
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// maxManifestSize caps the number of bytes read from a manifest or config.
+const maxManifestSize = 4 << 20
+
+// manifestAccept lists the manifest media types a subject image may have.
+var manifestAccept = strings.Join([]string{
+	MediaTypeImageManifest,
+	MediaTypeImageIndex,
+	MediaTypeDockerManifest,
+	MediaTypeDockerManifestList,
+}, ", ")
+
+// Credentials authenticate against a registry.
+type Credentials struct {
+	Username string
+	Password string
+}
+
+// Client talks to OCI distribution registries.
+type Client struct {
+	HTTP *http.Client
+	// Credentials returns the credentials for a registry; nil or empty.
+	// credentials access it anonymously.
+	Credentials func(registry string) Credentials
+	// PlainHTTP uses http:// instead of https:// (local test registries).
+	PlainHTTP bool
+
+	mu     sync.Mutex
+	tokens map[string]string // "registry|scope" -> bearer token
+}
+
+// Descriptor describes a blob or manifest.
+type Descriptor struct {
+	MediaType    string            `json:"mediaType"`
+	Digest       string            `json:"digest"`
+	Size         int64             `json:"size"`
+	ArtifactType string            `json:"artifactType,omitempty"`
+	Annotations  map[string]string `json:"annotations,omitempty"`
+}
+
+// digestOf returns the sha256 digest of data.
+func digestOf(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func (c *Client) url(ref Reference, path string) string {
+	scheme := "https"
+	if c.PlainHTTP {
+		scheme = "http"
+	}
+	return scheme + "://" + ref.host() + "/v2/" + ref.Repository + path
+}
+
+// do sends a registry request, answering Basic and Bearer challenges with.
+// the configured credentials. body is re-sent when a challenge is answered.
+func (c *Client) do(ctx context.Context, ref Reference, method, rawURL string, header http.Header, body []byte) (*http.Response, error) {
+	scope := "repository:" + ref.Repository + ":pull"
+	if method != http.MethodGet && method != http.MethodHead {
+		scope += ",push"
+	}
+	send := func(auth string) (*http.Response, error) {
+		var r io.Reader
+		if body != nil {
+			r = bytes.NewReader(body)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, rawURL, r)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		if body != nil {
+			req.ContentLength = int64(len(body))
+		}
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		return c.httpClient().Do(req)
+	}
+
+	key := ref.Registry + "|" + scope
+	c.mu.Lock()
+	auth := c.tokens[key]
+	c.mu.Unlock()
+	resp, err := send(auth)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	challenge := resp.Header.Get("WWW-Authenticate")
+	resp.Body.Close()
+
+	auth, err = c.authorize(ctx, ref, challenge, scope)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	if c.tokens == nil {
+		c.tokens = map[string]string{}
+	}
+	c.tokens[key] = auth
+	c.mu.Unlock()
+	return send(auth)
+}
+
+// authorize answers a WWW-Authenticate challenge and returns the value of.
+// the Authorization header to retry with.
+func (c *Client) authorize(ctx context.Context, ref Reference, challenge, scope string) (string, error) {
+	creds := c.credentials(ref.Registry)
+	scheme, params := parseChallenge(challenge)
+	switch scheme {
+	case "basic":
+		if creds.Username == "" {
+			return "", fmt.Errorf("registry %s requires credentials", ref.Registry)
+		}
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(creds.Username+":"+creds.Password)), nil
+	case "bearer":
+	default:
+		return "", fmt.Errorf("registry %s: unsupported authentication challenge %q", ref.Registry, challenge)
+	}
+
+	realm, err := url.Parse(params["realm"])
+	if err != nil || realm.Host == "" {
+		return "", fmt.Errorf("registry %s: invalid token realm %q", ref.Registry, params["realm"])
+	}
+	q := realm.Query()
+	if s := params["service"]; s != "" {
+		q.Set("service", s)
+	}
+	q.Set("scope", scope)
+	realm.RawQuery = q.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	if creds.Username != "" {
+		req.SetBasicAuth(creds.Username, creds.Password)
+	}
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return "", fmt.Errorf("registry %s: fetch token: %w", ref.Registry, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("registry %s: fetch token: status %d", ref.Registry, resp.StatusCode)
+	}
+	var tok struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxManifestSize)).Decode(&tok); err != nil {
+		return "", fmt.Errorf("registry %s: decode token: %w", ref.Registry, err)
+	}
+	if tok.Token == "" {
+		tok.Token = tok.AccessToken
+	}
+	if tok.Token == "" {
+		return "", fmt.Errorf("registry %s: token response has no token", ref.Registry)
+	}
+	return "Bearer " + tok.Token, nil
+}
+
+// parseChallenge splits a WWW-Authenticate header into its lower-cased.
+// scheme and parameters: Bearer realm="...",service="...".
+func parseChallenge(h string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(h), " ")
+	params := map[string]string{}
+	for rest != "" {
+		var kv string
+		// Values are quoted and may contain commas.
+		if i := strings.Index(rest, "\","); i >= 0 {
+			kv, rest = rest[:i+1], rest[i+2:]
+		} else {
+			kv, rest = rest, ""
+		}
+		k, v, _ := strings.Cut(strings.TrimSpace(kv), "=")
+		params[strings.ToLower(k)] = strings.Trim(v, `"`)
+	}
+	return strings.ToLower(scheme), params
+}
+
+func (c *Client) credentials(registry string) Credentials {
+	if c.Credentials == nil {
+		return Credentials{}
+	}
+	return c.Credentials(registry)
+}
+
+func (c *Client) httpClient() *http.Client {
+	if c.HTTP != nil {
+		return c.HTTP
+	}
+	return http.DefaultClient
+}
+
+// Resolve returns the descriptor of the manifest ref names.
+func (c *Client) Resolve(ctx context.Context, ref Reference) (Descriptor, error) {
+	data, mediaType, err := c.GetManifest(ctx, ref, ref.reference())
+	if err != nil {
+		return Descriptor{}, err
+	}
+	return Descriptor{MediaType: mediaType, Digest: digestOf(data), Size: int64(len(data))}, nil
+}
+
+// GetManifest fetches the manifest tagOrDigest in the repository of ref. It.
+// returns the manifest bytes and media type, and ErrNotFound when there is.
+// no such manifest.
+func (c *Client) GetManifest(ctx context.Context, ref Reference, tagOrDigest string) ([]byte, string, error) {
+	resp, err := c.do(ctx, ref, http.MethodGet, c.url(ref, "/manifests/"+tagOrDigest), http.Header{"Accept": {manifestAccept}}, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, "", ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", statusError("get manifest "+tagOrDigest, resp)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxManifestSize))
+	if err != nil {
+		return nil, "", err
+	}
+	mediaType, _, _ := strings.Cut(resp.Header.Get("Content-Type"), ";")
+	if mediaType == "" {
+		var m struct {
+			MediaType string `json:"mediaType"`
+		}
+		_ = json.Unmarshal(data, &m)
+		mediaType = m.MediaType
+	}
+	return data, strings.TrimSpace(mediaType), nil
+}
+
+// PutManifest uploads a manifest under tagOrDigest. It reports whether the.
+// registry processed its subject (OCI-Subject header), i.e. supports the.
+// referrers API.
+func (c *Client) PutManifest(ctx context.Context, ref Reference, tagOrDigest, mediaType string, data []byte) (bool, error) {
+	resp, err := c.do(ctx, ref, http.MethodPut, c.url(ref, "/manifests/"+tagOrDigest), http.Header{"Content-Type": {mediaType}}, data)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return false, statusError("put manifest "+tagOrDigest, resp)
+	}
+	return resp.Header.Get("OCI-Subject") != "", nil
+}
+
+// Referrers lists the manifests whose subject is the manifest digest in the.
+// repository of ref and whose artifact type is artifactType (any when empty),.
+// with the referrers API or, on registries without it, the fallback tag.
+func (c *Client) Referrers(ctx context.Context, ref Reference, digest, artifactType string) ([]Descriptor, error) {
+	u := c.url(ref, "/referrers/"+digest)
+	if artifactType != "" {
+		u += "?artifactType=" + url.QueryEscape(artifactType)
+	}
+	resp, err := c.do(ctx, ref, http.MethodGet, u, http.Header{"Accept": {MediaTypeImageIndex}}, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var data []byte
+	switch resp.StatusCode {
+	case http.StatusOK:
+		if data, err = io.ReadAll(io.LimitReader(resp.Body, maxManifestSize)); err != nil {
+			return nil, err
+		}
+	case http.StatusNotFound:
+		data, _, err = c.GetManifest(ctx, ref, strings.Replace(digest, ":", "-", 1))
+		if errors.Is(err, ErrNotFound) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, statusError("list referrers of "+digest, resp)
+	}
+	var idx index
+	if err := json.Unmarshal(data, &idx); err != nil {
+		return nil, fmt.Errorf("decode referrers of %s: %w", digest, err)
+	}
+	var out []Descriptor
+	for _, d := range idx.Manifests {
+		if artifactType == "" || d.ArtifactType == artifactType {
+			out = append(out, d)
+		}
+	}
+	return out, nil
+}
+
+// GetBlob fetches a blob of the repository of ref.
+func (c *Client) GetBlob(ctx context.Context, ref Reference, digest string) ([]byte, error) {
+	resp, err := c.do(ctx, ref, http.MethodGet, c.url(ref, "/blobs/"+digest), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, statusError("get blob "+digest, resp)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxManifestSize))
+	if err != nil {
+		return nil, err
+	}
+	if digestOf(data) != digest {
+		return nil, fmt.Errorf("blob %s: digest mismatch", digest)
+	}
+	return data, nil
+}
+
+// PushBlob uploads data as a blob of the repository of ref, unless the.
+// registry already has it, and returns its descriptor.
+func (c *Client) PushBlob(ctx context.Context, ref Reference, mediaType string, data []byte) (Descriptor, error) {
+	desc := Descriptor{MediaType: mediaType, Digest: digestOf(data), Size: int64(len(data))}
+	resp, err := c.do(ctx, ref, http.MethodHead, c.url(ref, "/blobs/"+desc.Digest), nil, nil)
+	if err != nil {
+		return Descriptor{}, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return desc, nil
+	}
+
+	resp, err = c.do(ctx, ref, http.MethodPost, c.url(ref, "/blobs/uploads/"), nil, []byte{})
+	if err != nil {
+		return Descriptor{}, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		return Descriptor{}, statusError("start blob upload", resp)
+	}
+	loc, err := resp.Request.URL.Parse(resp.Header.Get("Location"))
+	if err != nil || resp.Header.Get("Location") == "" {
+		return Descriptor{}, fmt.Errorf("start blob upload: invalid Location %q", resp.Header.Get("Location"))
+	}
+	q := loc.Query()
+	q.Set("digest", desc.Digest)
+	loc.RawQuery = q.Encode()
+
+	resp, err = c.do(ctx, ref, http.MethodPut, loc.String(), http.Header{"Content-Type": {"application/octet-stream"}}, data)
+	if err != nil {
+		return Descriptor{}, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return Descriptor{}, statusError("upload blob "+desc.Digest, resp)
+	}
+	return desc, nil
+}
+
+// statusError describes an unexpected registry response, with the message.
+// of the first error in an OCI error body.
+func statusError(op string, resp *http.Response) error {
+	var body struct {
+		Errors []struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	_ = json.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&body)
+	if len(body.Errors) > 0 {
+		return fmt.Errorf("%s: status %d: %s: %s", op, resp.StatusCode, body.Errors[0].Code, body.Errors[0].Message)
+	}
+	return fmt.Errorf("%s: status %d", op, resp.StatusCode)
+}
+
+// DockerCredentials returns the credentials stored for registry in the.
+// docker config file ($DOCKER_CONFIG/config.json, else ~/.docker/config.json).
+// Credential helpers are not consulted.
+func DockerCredentials(registry string) Credentials {
+	dir := os.Getenv("DOCKER_CONFIG")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return Credentials{}
+		}
+		dir = filepath.Join(home, ".docker")
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "config.json"))
+	if err != nil {
+		return Credentials{}
+	}
+	var cfg struct {
+		Auths map[string]struct {
+			Auth string `json:"auth"`
+		} `json:"auths"`
+	}
+	if json.Unmarshal(data, &cfg) != nil {
+		return Credentials{}
+	}
+	keys := []string{registry, "https://" + registry, "https://" + registry + "/v1/"}
+	if registry == dockerHub {
+		keys = append(keys, "https://index.docker.io/v1/", "index.docker.io")
+	}
+	for _, k := range keys {
+		entry, ok := cfg.Auths[k]
+		if !ok || entry.Auth == "" {
+			continue
+		}
+		raw, err := base64.StdEncoding.DecodeString(entry.Auth)
+		if err != nil {
+			continue
+		}
+		user, pass, _ := strings.Cut(string(raw), ":")
+		return Credentials{Username: user, Password: pass}
+	}
+	return Credentials{}
+}
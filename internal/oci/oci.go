@@ -0,0 +1,356 @@
+// Package oci attaches AIBOMs to container images in OCI distribution.
+// registries, so runtime scanners find the AI BOM next to the image SBOM.
+//.
+// Two ways of attaching are supported:.
+//.
+//	referrer – the AIBOM is pushed as an OCI 1.1 artifact whose subject is the.
+//	           image manifest. The image itself is not changed. Registries.
+//	           without the referrers API get the fallback tag sha256-<hex>.
+//	           pointing to an index of the referrers.
+//	label    – the AIBOM is stored in labels of the image configuration and the.
+//	           tag is moved to the rewritten manifest. The image digest.
+//	           changes; tools that only read labels (docker inspect) see it.
+//.
+// FetchAIBOM reads an AIBOM attached either way back, e.g. for admission.
+// control.
+package oci
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Media types.
+const (
+	MediaTypeImageManifest      = "application/vnd.oci.image.manifest.v1+json"
+	MediaTypeImageIndex         = "application/vnd.oci.image.index.v1+json"
+	MediaTypeDockerManifest     = "application/vnd.docker.distribution.manifest.v2+json"
+	MediaTypeDockerManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+	MediaTypeEmpty              = "application/vnd.oci.empty.v1+json"
+
+	// MediaTypeCycloneDX is the artifact type of an attached AIBOM.
+	MediaTypeCycloneDX = "application/vnd.cyclonedx+json"
+)
+
+// Labels set in label mode.
+const (
+	// LabelAIBOM holds the base64 of the gzip-compressed CycloneDX JSON.
+	LabelAIBOM = "io.aibomgen.aibom"
+	// LabelAIBOMDigest holds the sha256 digest of the uncompressed JSON.
+	LabelAIBOMDigest = "io.aibomgen.aibom.digest"
+)
+
+// Annotations of the referrer manifest.
+const (
+	AnnotationCreated = "org.opencontainers.image.created"
+	AnnotationTitle   = "org.opencontainers.image.title"
+)
+
+// ErrNotFound is returned when a manifest does not exist.
+var ErrNotFound = errors.New("manifest not found")
+
+// ErrNoAIBOM is returned by FetchAIBOM when no AIBOM is attached to an image.
+var ErrNoAIBOM = errors.New("no AIBOM attached")
+
+// emptyConfig is the content of the empty descriptor of OCI artifacts.
+var emptyConfig = []byte("{}")
+
+// manifest is an OCI image manifest.
+type manifest struct {
+	SchemaVersion int               `json:"schemaVersion"`
+	MediaType     string            `json:"mediaType"`
+	ArtifactType  string            `json:"artifactType,omitempty"`
+	Config        Descriptor        `json:"config"`
+	Layers        []Descriptor      `json:"layers"`
+	Subject       *Descriptor       `json:"subject,omitempty"`
+	Annotations   map[string]string `json:"annotations,omitempty"`
+}
+
+// index is an OCI image index.
+type index struct {
+	SchemaVersion int          `json:"schemaVersion"`
+	MediaType     string       `json:"mediaType"`
+	Manifests     []Descriptor `json:"manifests"`
+}
+
+// Result describes an attached AIBOM.
+type Result struct {
+	// Subject is the image manifest the AIBOM was attached to.
+	Subject Descriptor
+	// Manifest is the referrer manifest (referrer mode) or the rewritten.
+	// image manifest (label mode).
+	Manifest Descriptor
+	// FallbackTag is the referrers tag updated for registries without the.
+	// referrers API; empty when the registry supports it.
+	FallbackTag string
+}
+
+// AttachReferrer pushes aibom (CycloneDX JSON) as an artifact referring to.
+// the image ref names.
+func (c *Client) AttachReferrer(ctx context.Context, ref Reference, aibom []byte, title string) (Result, error) {
+	subject, err := c.Resolve(ctx, ref)
+	if err != nil {
+		return Result{}, fmt.Errorf("resolve %s: %w", ref, err)
+	}
+	config, err := c.PushBlob(ctx, ref, MediaTypeEmpty, emptyConfig)
+	if err != nil {
+		return Result{}, err
+	}
+	layer, err := c.PushBlob(ctx, ref, MediaTypeCycloneDX, aibom)
+	if err != nil {
+		return Result{}, err
+	}
+	if title != "" {
+		layer.Annotations = map[string]string{AnnotationTitle: title}
+	}
+
+	m := manifest{
+		SchemaVersion: 2,
+		MediaType:     MediaTypeImageManifest,
+		ArtifactType:  MediaTypeCycloneDX,
+		Config:        config,
+		Layers:        []Descriptor{layer},
+		Subject:       &Descriptor{MediaType: subject.MediaType, Digest: subject.Digest, Size: subject.Size},
+		Annotations:   map[string]string{AnnotationCreated: time.Now().UTC().Format(time.RFC3339)},
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		return Result{}, err
+	}
+	desc := Descriptor{MediaType: MediaTypeImageManifest, Digest: digestOf(data), Size: int64(len(data)), ArtifactType: MediaTypeCycloneDX, Annotations: m.Annotations}
+	supported, err := c.PutManifest(ctx, ref, desc.Digest, MediaTypeImageManifest, data)
+	if err != nil {
+		return Result{}, err
+	}
+	res := Result{Subject: subject, Manifest: desc}
+	if !supported {
+		res.FallbackTag = strings.Replace(subject.Digest, ":", "-", 1)
+		if err := c.addFallbackReferrer(ctx, ref, res.FallbackTag, desc); err != nil {
+			return Result{}, err
+		}
+	}
+	return res, nil
+}
+
+// addFallbackReferrer adds desc to the referrers index under tag, the.
+// referrers tag schema of registries without the referrers API.
+func (c *Client) addFallbackReferrer(ctx context.Context, ref Reference, tag string, desc Descriptor) error {
+	idx := index{SchemaVersion: 2, MediaType: MediaTypeImageIndex}
+	data, _, err := c.GetManifest(ctx, ref, tag)
+	switch {
+	case errors.Is(err, ErrNotFound):
+	case err != nil:
+		return err
+	default:
+		if err := json.Unmarshal(data, &idx); err != nil {
+			return fmt.Errorf("decode referrers index %s: %w", tag, err)
+		}
+	}
+	for _, m := range idx.Manifests {
+		if m.Digest == desc.Digest {
+			return nil
+		}
+	}
+	idx.Manifests = append(idx.Manifests, desc)
+	data, err = json.Marshal(idx)
+	if err != nil {
+		return err
+	}
+	_, err = c.PutManifest(ctx, ref, tag, MediaTypeImageIndex, data)
+	return err
+}
+
+// AttachLabel stores aibom (CycloneDX JSON) in the labels of the image.
+// configuration and moves the tag of ref to the rewritten manifest. Only.
+// single-platform images can be relabelled.
+func (c *Client) AttachLabel(ctx context.Context, ref Reference, aibom []byte) (Result, error) {
+	if ref.Tag == "" {
+		return Result{}, fmt.Errorf("label mode needs a tag to move, got %s", ref)
+	}
+	data, mediaType, err := c.GetManifest(ctx, ref, ref.reference())
+	if err != nil {
+		return Result{}, fmt.Errorf("resolve %s: %w", ref, err)
+	}
+	subject := Descriptor{MediaType: mediaType, Digest: digestOf(data), Size: int64(len(data))}
+	if mediaType != MediaTypeImageManifest && mediaType != MediaTypeDockerManifest {
+		return Result{}, fmt.Errorf("%s is a %s, not a single-platform image; use referrer mode", ref, mediaType)
+	}
+
+	// Keep every field of the manifest and configuration that is not changed.
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(data, &m); err != nil {
+		return Result{}, fmt.Errorf("decode manifest: %w", err)
+	}
+	var configDesc Descriptor
+	if err := json.Unmarshal(m["config"], &configDesc); err != nil {
+		return Result{}, fmt.Errorf("decode manifest config: %w", err)
+	}
+	configData, err := c.GetBlob(ctx, ref, configDesc.Digest)
+	if err != nil {
+		return Result{}, err
+	}
+	newConfig, err := setLabels(configData, map[string]string{
+		LabelAIBOM:       encodeLabel(aibom),
+		LabelAIBOMDigest: digestOf(aibom),
+	})
+	if err != nil {
+		return Result{}, err
+	}
+	pushed, err := c.PushBlob(ctx, ref, configDesc.MediaType, newConfig)
+	if err != nil {
+		return Result{}, err
+	}
+
+	var rawConfig map[string]json.RawMessage
+	if err := json.Unmarshal(m["config"], &rawConfig); err != nil {
+		return Result{}, fmt.Errorf("decode manifest config: %w", err)
+	}
+	rawConfig["digest"], _ = json.Marshal(pushed.Digest)
+	rawConfig["size"], _ = json.Marshal(pushed.Size)
+	if m["config"], err = json.Marshal(rawConfig); err != nil {
+		return Result{}, err
+	}
+	newManifest, err := json.Marshal(m)
+	if err != nil {
+		return Result{}, err
+	}
+	if _, err := c.PutManifest(ctx, ref, ref.Tag, mediaType, newManifest); err != nil {
+		return Result{}, err
+	}
+	return Result{Subject: subject, Manifest: Descriptor{MediaType: mediaType, Digest: digestOf(newManifest), Size: int64(len(newManifest))}}, nil
+}
+
+// FetchAIBOM returns the AIBOM attached to the image ref names: the newest.
+// CycloneDX referrer, else the AIBOM label of the image configuration. It.
+// returns ErrNoAIBOM when neither exists.
+func (c *Client) FetchAIBOM(ctx context.Context, ref Reference) ([]byte, error) {
+	data, mediaType, err := c.GetManifest(ctx, ref, ref.reference())
+	if err != nil {
+		return nil, fmt.Errorf("resolve %s: %w", ref, err)
+	}
+	referrers, err := c.Referrers(ctx, ref, digestOf(data), MediaTypeCycloneDX)
+	if err != nil {
+		return nil, err
+	}
+	if len(referrers) > 0 {
+		newest := referrers[0]
+		for _, d := range referrers[1:] {
+			if d.Annotations[AnnotationCreated] > newest.Annotations[AnnotationCreated] {
+				newest = d
+			}
+		}
+		mdata, _, err := c.GetManifest(ctx, ref, newest.Digest)
+		if err != nil {
+			return nil, err
+		}
+		var m manifest
+		if err := json.Unmarshal(mdata, &m); err != nil {
+			return nil, fmt.Errorf("decode referrer manifest: %w", err)
+		}
+		for _, l := range m.Layers {
+			if l.MediaType == MediaTypeCycloneDX {
+				return c.GetBlob(ctx, ref, l.Digest)
+			}
+		}
+	}
+
+	if mediaType != MediaTypeImageManifest && mediaType != MediaTypeDockerManifest {
+		return nil, ErrNoAIBOM
+	}
+	var m manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("decode manifest: %w", err)
+	}
+	configData, err := c.GetBlob(ctx, ref, m.Config.Digest)
+	if err != nil {
+		return nil, err
+	}
+	var cfg struct {
+		Config struct {
+			Labels map[string]string `json:"Labels"`
+		} `json:"config"`
+	}
+	if err := json.Unmarshal(configData, &cfg); err != nil {
+		return nil, fmt.Errorf("decode image config: %w", err)
+	}
+	label, ok := cfg.Config.Labels[LabelAIBOM]
+	if !ok {
+		return nil, ErrNoAIBOM
+	}
+	aibom, err := DecodeLabel(label)
+	if err != nil {
+		return nil, fmt.Errorf("decode %s label: %w", LabelAIBOM, err)
+	}
+	if d := cfg.Config.Labels[LabelAIBOMDigest]; d != "" && d != digestOf(aibom) {
+		return nil, fmt.Errorf("%s label: digest mismatch", LabelAIBOM)
+	}
+	return aibom, nil
+}
+
+// setLabels adds labels to the config.Labels of an image configuration.
+func setLabels(configData []byte, labels map[string]string) ([]byte, error) {
+	var cfg map[string]json.RawMessage
+	if err := json.Unmarshal(configData, &cfg); err != nil {
+		return nil, fmt.Errorf("decode image config: %w", err)
+	}
+	var inner map[string]json.RawMessage
+	if raw, ok := cfg["config"]; ok && string(raw) != "null" {
+		if err := json.Unmarshal(raw, &inner); err != nil {
+			return nil, fmt.Errorf("decode image config: %w", err)
+		}
+	}
+	if inner == nil {
+		inner = map[string]json.RawMessage{}
+	}
+	existing := map[string]string{}
+	if raw, ok := inner["Labels"]; ok && string(raw) != "null" {
+		if err := json.Unmarshal(raw, &existing); err != nil {
+			return nil, fmt.Errorf("decode image labels: %w", err)
+		}
+	}
+	for k, v := range labels {
+		existing[k] = v
+	}
+	var err error
+	if inner["Labels"], err = json.Marshal(existing); err != nil {
+		return nil, err
+	}
+	if cfg["config"], err = json.Marshal(inner); err != nil {
+		return nil, err
+	}
+	return json.Marshal(cfg)
+}
+
+// encodeLabel returns the base64 of the gzip-compressed data.
+func encodeLabel(data []byte) string {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Write(data)
+	zw.Close()
+	return base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+// DecodeLabel reverses the encoding of the LabelAIBOM label.
+func DecodeLabel(value string) ([]byte, error) {
+	raw, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(raw))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	var out bytes.Buffer
+	if _, err := out.ReadFrom(zr); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
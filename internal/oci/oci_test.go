@@ -0,0 +1,265 @@
+package oci
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeRegistry is an in-memory registry for one repository.
+type fakeRegistry struct {
+	mu        sync.Mutex
+	blobs     map[string][]byte
+	manifests map[string][]byte // tag or digest -> manifest
+	types     map[string]string
+	referrers bool   // answer OCI-Subject
+	token     string // required bearer token, if set
+}
+
+func newFakeRegistry() *fakeRegistry {
+	return &fakeRegistry{blobs: map[string][]byte{}, manifests: map[string][]byte{}, types: map[string]string{}}
+}
+
+func (f *fakeRegistry) putManifest(ref string, mediaType string, data []byte) string {
+	d := digestOf(data)
+	f.manifests[ref], f.manifests[d] = data, data
+	f.types[ref], f.types[d] = mediaType, mediaType
+	return d
+}
+
+func (f *fakeRegistry) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if r.URL.Path == "/token" {
+		if r.URL.Query().Get("scope") == "" {
+			http.Error(w, "no scope", http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintf(w, `{"token":%q}`, f.token)
+		return
+	}
+	if f.token != "" && r.Header.Get("Authorization") != "Bearer "+f.token {
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="http://%s/token",service="test"`, r.Host))
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/v2/team/app")
+	body, _ := io.ReadAll(r.Body)
+	switch {
+	case strings.HasPrefix(path, "/manifests/"):
+		ref := strings.TrimPrefix(path, "/manifests/")
+		switch r.Method {
+		case http.MethodGet, http.MethodHead:
+			data, ok := f.manifests[ref]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			w.Header().Set("Content-Type", f.types[ref])
+			w.Write(data)
+		case http.MethodPut:
+			f.putManifest(ref, r.Header.Get("Content-Type"), body)
+			var m manifest
+			if json.Unmarshal(body, &m) == nil && m.Subject != nil && f.referrers {
+				w.Header().Set("OCI-Subject", m.Subject.Digest)
+			}
+			w.WriteHeader(http.StatusCreated)
+		}
+	case strings.HasPrefix(path, "/referrers/"):
+		if !f.referrers {
+			http.NotFound(w, r)
+			return
+		}
+		subject := strings.TrimPrefix(path, "/referrers/")
+		idx := index{SchemaVersion: 2, MediaType: MediaTypeImageIndex, Manifests: []Descriptor{}}
+		for ref, data := range f.manifests {
+			var m manifest
+			if !strings.HasPrefix(ref, "sha256:") || json.Unmarshal(data, &m) != nil || m.Subject == nil || m.Subject.Digest != subject {
+				continue
+			}
+			if at := r.URL.Query().Get("artifactType"); at == "" || at == m.ArtifactType {
+				idx.Manifests = append(idx.Manifests, Descriptor{MediaType: m.MediaType, Digest: ref, Size: int64(len(data)), ArtifactType: m.ArtifactType, Annotations: m.Annotations})
+			}
+		}
+		w.Header().Set("Content-Type", MediaTypeImageIndex)
+		json.NewEncoder(w).Encode(idx)
+	case path == "/blobs/uploads/" && r.Method == http.MethodPost:
+		w.Header().Set("Location", "/v2/team/app/blobs/uploads/session-1?state=x")
+		w.WriteHeader(http.StatusAccepted)
+	case strings.HasPrefix(path, "/blobs/uploads/") && r.Method == http.MethodPut:
+		d := r.URL.Query().Get("digest")
+		if r.URL.Query().Get("state") != "x" || digestOf(body) != d {
+			http.Error(w, "bad upload", http.StatusBadRequest)
+			return
+		}
+		f.blobs[d] = body
+		w.WriteHeader(http.StatusCreated)
+	case strings.HasPrefix(path, "/blobs/"):
+		data, ok := f.blobs[strings.TrimPrefix(path, "/blobs/")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(data)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func setup(t *testing.T, f *fakeRegistry) (*Client, Reference, string) {
+	t.Helper()
+	config := []byte(`{"architecture":"amd64","config":{"Env":["A=1"],"Labels":{"keep":"me"}},"rootfs":{"type":"layers","diff_ids":[]}}`)
+	f.blobs[digestOf(config)] = config
+	img, _ := json.Marshal(map[string]any{
+		"schemaVersion": 2,
+		"mediaType":     MediaTypeDockerManifest,
+		"config":        Descriptor{MediaType: "application/vnd.docker.container.image.v1+json", Digest: digestOf(config), Size: int64(len(config))},
+		"layers":        []Descriptor{},
+	})
+	subject := f.putManifest("v1", MediaTypeDockerManifest, img)
+
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+	ref, err := ParseReference(strings.TrimPrefix(srv.URL, "http://") + "/team/app:v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Client{PlainHTTP: true}, ref, subject
+}
+
+func TestAttachReferrer(t *testing.T) {
+	for _, referrers := range []bool{true, false} {
+		f := newFakeRegistry()
+		f.referrers = referrers
+		f.token = "secret"
+		c, ref, subject := setup(t, f)
+
+		aibom := []byte(`{"bomFormat":"CycloneDX"}`)
+		res, err := c.AttachReferrer(context.Background(), ref, aibom, "aibom.json")
+		if err != nil {
+			t.Fatalf("AttachReferrer: %v", err)
+		}
+		if res.Subject.Digest != subject || res.Subject.MediaType != MediaTypeDockerManifest {
+			t.Errorf("subject = %+v, want %s", res.Subject, subject)
+		}
+
+		var m manifest
+		if err := json.Unmarshal(f.manifests[res.Manifest.Digest], &m); err != nil {
+			t.Fatalf("referrer manifest not pushed: %v", err)
+		}
+		if m.ArtifactType != MediaTypeCycloneDX || m.Subject == nil || m.Subject.Digest != subject || m.Config.MediaType != MediaTypeEmpty {
+			t.Errorf("referrer manifest = %+v", m)
+		}
+		if len(m.Layers) != 1 || string(f.blobs[m.Layers[0].Digest]) != string(aibom) || m.Layers[0].Annotations[AnnotationTitle] != "aibom.json" {
+			t.Errorf("AIBOM layer = %+v", m.Layers)
+		}
+
+		fallback := "sha256-" + strings.TrimPrefix(subject, "sha256:")
+		if referrers {
+			if res.FallbackTag != "" || f.manifests[fallback] != nil {
+				t.Errorf("fallback tag written although the registry supports referrers")
+			}
+			continue
+		}
+		var idx index
+		if res.FallbackTag != fallback || json.Unmarshal(f.manifests[fallback], &idx) != nil || len(idx.Manifests) != 1 || idx.Manifests[0].Digest != res.Manifest.Digest {
+			t.Errorf("fallback tag %q index = %s", res.FallbackTag, f.manifests[fallback])
+		}
+	}
+}
+
+func TestAttachLabel(t *testing.T) {
+	f := newFakeRegistry()
+	c, ref, subject := setup(t, f)
+
+	aibom := []byte(`{"bomFormat":"CycloneDX"}`)
+	res, err := c.AttachLabel(context.Background(), ref, aibom)
+	if err != nil {
+		t.Fatalf("AttachLabel: %v", err)
+	}
+	if res.Subject.Digest != subject || res.Manifest.Digest == subject || digestOf(f.manifests["v1"]) != res.Manifest.Digest {
+		t.Fatalf("tag not moved: %+v", res)
+	}
+
+	var m struct {
+		MediaType string     `json:"mediaType"`
+		Config    Descriptor `json:"config"`
+	}
+	json.Unmarshal(f.manifests["v1"], &m)
+	var cfg struct {
+		Architecture string `json:"architecture"`
+		Config       struct {
+			Env    []string          `json:"Env"`
+			Labels map[string]string `json:"Labels"`
+		} `json:"config"`
+	}
+	if err := json.Unmarshal(f.blobs[m.Config.Digest], &cfg); err != nil {
+		t.Fatalf("config not pushed: %v", err)
+	}
+	if m.MediaType != MediaTypeDockerManifest || cfg.Architecture != "amd64" || len(cfg.Config.Env) != 1 || cfg.Config.Labels["keep"] != "me" {
+		t.Errorf("image fields lost: %+v %+v", m, cfg)
+	}
+	got, err := DecodeLabel(cfg.Config.Labels[LabelAIBOM])
+	if err != nil || string(got) != string(aibom) || cfg.Config.Labels[LabelAIBOMDigest] != digestOf(aibom) {
+		t.Errorf("AIBOM label = %q, %v (digest %s)", got, err, cfg.Config.Labels[LabelAIBOMDigest])
+	}
+}
+
+func TestFetchAIBOM(t *testing.T) {
+	aibom := []byte(`{"bomFormat":"CycloneDX"}`)
+	for _, referrers := range []bool{true, false} {
+		f := newFakeRegistry()
+		f.referrers = referrers
+		c, ref, _ := setup(t, f)
+		if _, err := c.FetchAIBOM(context.Background(), ref); !errors.Is(err, ErrNoAIBOM) {
+			t.Fatalf("expected ErrNoAIBOM before attaching, got %v", err)
+		}
+		if _, err := c.AttachReferrer(context.Background(), ref, aibom, ""); err != nil {
+			t.Fatal(err)
+		}
+		got, err := c.FetchAIBOM(context.Background(), ref)
+		if err != nil || string(got) != string(aibom) {
+			t.Errorf("referrers=%v: FetchAIBOM = %q, %v", referrers, got, err)
+		}
+	}
+
+	f := newFakeRegistry()
+	c, ref, _ := setup(t, f)
+	if _, err := c.AttachLabel(context.Background(), ref, aibom); err != nil {
+		t.Fatal(err)
+	}
+	got, err := c.FetchAIBOM(context.Background(), ref)
+	if err != nil || string(got) != string(aibom) {
+		t.Errorf("label: FetchAIBOM = %q, %v", got, err)
+	}
+}
+
+func TestParseReference(t *testing.T) {
+	tests := map[string]string{
+		"alpine":                         "docker.io/library/alpine:latest",
+		"org/app:1.0":                    "docker.io/org/app:1.0",
+		"ghcr.io/org/app":                "ghcr.io/org/app:latest",
+		"localhost:5000/app:dev":         "localhost:5000/app:dev",
+		"registry.example.com:443/a/b/c": "registry.example.com:443/a/b/c:latest",
+		"ghcr.io/org/app@sha256:abc":     "ghcr.io/org/app@sha256:abc",
+	}
+	for in, want := range tests {
+		ref, err := ParseReference(in)
+		if err != nil || ref.String() != want {
+			t.Errorf("ParseReference(%q) = %q, %v; want %q", in, ref.String(), err, want)
+		}
+	}
+	for _, bad := range []string{"", "Org/App", "app@md5:1"} {
+		if _, err := ParseReference(bad); err == nil {
+			t.Errorf("ParseReference(%q): expected an error", bad)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package oci
+
+import (
+	"fmt"
+	"strings"
+)
+
+// dockerHub is the registry of image references without a registry host.
+const dockerHub = "docker.io"
+
+// Reference names an image in a registry: registry/repository:tag or.
+// registry/repository@digest.
+type Reference struct {
+	Registry   string
+	Repository string
+	Tag        string
+	Digest     string
+}
+
+// ParseReference parses an image reference the way docker does: without a.
+// registry host the image is on Docker Hub (single names are in library/),.
+// and without tag or digest the tag is "latest".
+func ParseReference(s string) (Reference, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Reference{}, fmt.Errorf("empty image reference")
+	}
+	var ref Reference
+	name := s
+	if i := strings.Index(name, "@"); i >= 0 {
+		ref.Digest = name[i+1:]
+		name = name[:i]
+		if !strings.HasPrefix(ref.Digest, "sha256:") && !strings.HasPrefix(ref.Digest, "sha512:") {
+			return Reference{}, fmt.Errorf("invalid digest in image reference %q", s)
+		}
+	}
+	// A tag follows the last ":" after the last "/" (a ":" before it is a port).
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		ref.Tag = name[i+1:]
+		name = name[:i]
+	}
+
+	first, rest, ok := strings.Cut(name, "/")
+	if ok && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		ref.Registry, ref.Repository = first, rest
+	} else {
+		ref.Registry, ref.Repository = dockerHub, name
+		if !ok {
+			ref.Repository = "library/" + name
+		}
+	}
+	if ref.Repository == "" || ref.Repository != strings.ToLower(ref.Repository) {
+		return Reference{}, fmt.Errorf("invalid repository in image reference %q", s)
+	}
+	if ref.Tag == "" && ref.Digest == "" {
+		ref.Tag = "latest"
+	}
+	return ref, nil
+}
+
+// reference returns the tag or digest used in manifest URLs; the digest.
+// wins when both are set.
+func (r Reference) reference() string {
+	if r.Digest != "" {
+		return r.Digest
+	}
+	return r.Tag
+}
+
+// String returns the reference in its canonical form.
+func (r Reference) String() string {
+	s := r.Registry + "/" + r.Repository
+	if r.Tag != "" {
+		s += ":" + r.Tag
+	}
+	if r.Digest != "" {
+		s += "@" + r.Digest
+	}
+	return s
+}
+
+// host returns the host serving the registry API.
+func (r Reference) host() string {
+	if r.Registry == dockerHub {
+		return "registry-1.docker.io"
+	}
+	return r.Registry
+}
@@ -0,0 +1,176 @@
+// Package projectconfig finds and applies project-local configuration files.
+//.
+// A repository can carry its own AIBOM policy in a .aibomgen.yaml file. The.
+// files found in the scan root and its parent directories are merged over the.
+// global configuration, the closest file last, so a subdirectory can refine.
+// the policy of the repository it lives in. Command-line flags still take.
+// precedence over every file.
+package projectconfig
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/idlab-discover/aibomgen-cli/internal/configref"
+	"github.com/spf13/viper"
+)
+
+// FileNames are the accepted names of a project configuration file. When a.
+// directory holds several, the first one is used.
+var FileNames = []string{".aibomgen.yaml", ".aibomgen.yml"}
+
+// policyKeys are the only keys taken from a project configuration file,.
+// as section.key paths; a path also allows every key below it. They describe.
+// the AIBOM policy of the repository: what to scan and ignore, the models to.
+// generate, how BOMs are built, enriched and scored. Everything else belongs.
+// to the user running the tool, not to the repository being scanned:.
+// credentials, signing keys, public keys and signature checks, hosts and.
+// endpoints requests are sent to, output and cache paths, commands to run,.
+// notifications, traces and the remote repository a scan clones.
+var policyKeys = map[string]bool{
+	"scan.ignore":               true,
+	"scan.include-comments":     true,
+	"scan.include-raw-metadata": true,
+	"scan.hf-mode":              true,
+	"scan.lifecycle":            true,
+	"scan.base-model-depth":     true,
+	"scan.application":          true,
+	"scan.per-project":          true,
+	"scan.app-name":             true,
+	"scan.app-version":          true,
+	"scan.app-vcs-url":          true,
+	"scan.format":               true,
+	"scan.spec":                 true,
+
+	"generate.model-ids":            true,
+	"generate.hf-mode":              true,
+	"generate.include-raw-metadata": true,
+	"generate.lifecycle":            true,
+	"generate.base-model-depth":     true,
+	"generate.format":               true,
+	"generate.spec":                 true,
+
+	"enrich.options":        true,
+	"enrich.strategy":       true,
+	"enrich.required-only":  true,
+	"enrich.min-weight":     true,
+	"enrich.target-score":   true,
+	"enrich.datasets":       true,
+	"enrich.strict-options": true,
+
+	"validate.min-score":              true,
+	"validate.profile":                true,
+	"validate.strict":                 true,
+	"validate.spec":                   true,
+	"validate.check-attribution":      true,
+	"validate.check-consent":          true,
+	"validate.check-model-card":       true,
+	"validate.check-security-contact": true,
+
+	"completeness.min-score": true,
+	"completeness.profile":   true,
+	"completeness.verified":  true,
+
+	"export.profile": true,
+	"export.title":   true,
+
+	"verify-claims.require-claim": true,
+}
+
+// Find returns the project configuration files in start and its parent.
+// directories, farthest first.
+func Find(start string) ([]string, error) {
+	dir, err := filepath.Abs(start)
+	if err != nil {
+		return nil, err
+	}
+	if info, err := os.Stat(dir); err == nil && !info.IsDir() {
+		dir = filepath.Dir(dir)
+	}
+
+	var found []string
+	for {
+		for _, name := range FileNames {
+			p := filepath.Join(dir, name)
+			info, err := os.Stat(p)
+			if err == nil && !info.IsDir() {
+				found = append(found, p)
+				break
+			}
+			if err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return nil, err
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+
+	// Farthest first, so that closer files override when merged in order.
+	for i, j := 0, len(found)-1; i < j; i, j = i+1, j-1 {
+		found[i], found[j] = found[j], found[i]
+	}
+	return found, nil
+}
+
+// Apply merges the configuration files at paths into v, in order.
+// Only the keys in policyKeys are taken; the others are dropped.
+// Environment and secret references are refused,.
+// since a scanned repository must not be able to read them into its BOMs.
+func Apply(v *viper.Viper, paths []string) error {
+	for _, p := range paths {
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return fmt.Errorf("read project config: %w", err)
+		}
+		file := viper.New()
+		file.SetConfigType("yaml")
+		if err := file.ReadConfig(bytes.NewReader(data)); err != nil {
+			return fmt.Errorf("parse project config %s: %w", p, err)
+		}
+		settings := file.AllSettings()
+		keepPolicy(settings, "")
+		if keys := configref.Keys(settings); len(keys) > 0 {
+			return fmt.Errorf("project config %s: %s: ${...} and secretRef references are only resolved in the user config file", p, strings.Join(keys, ", "))
+		}
+		if err := v.MergeConfigMap(settings); err != nil {
+			return fmt.Errorf("apply project config %s: %w", p, err)
+		}
+	}
+	return nil
+}
+
+// keepPolicy deletes the keys of m, found at prefix, that policyKeys does.
+// not allow, and the sections left empty.
+func keepPolicy(m map[string]any, prefix string) {
+	for k, val := range m {
+		key := prefix + k
+		if policyKeys[key] {
+			continue
+		}
+		if sub, ok := val.(map[string]any); ok && hasPolicyKeys(key+".") {
+			keepPolicy(sub, key+".")
+			if len(sub) > 0 {
+				continue
+			}
+		}
+		delete(m, k)
+	}
+}
+
+// hasPolicyKeys reports whether policyKeys allows a key under prefix.
+func hasPolicyKeys(prefix string) bool {
+	for key := range policyKeys {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
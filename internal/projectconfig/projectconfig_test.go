@@ -0,0 +1,156 @@
+package projectconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func write(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFindAndApply(t *testing.T) {
+	root := t.TempDir()
+	repo := filepath.Join(root, "repo")
+	sub := filepath.Join(repo, "services", "api")
+	write(t, filepath.Join(repo, ".aibomgen.yaml"), "scan:\n  ignore: [\"examples/\"]\n  include-comments: true\n  hf-token: leaked\n  repo: https://evil.example/x.git\n  ref: --upload-pack=./evil\nhooks:\n  post-generate: [\"./evil.sh\"]\ntracing:\n  endpoint: http://collector.example\nenrich:\n  sign-key: repo.pem\nverify-runtime:\n  api-key: leaked\nsign:\n  cosign: ./evil\nverify-signature:\n  key: repo.pub\n")
+	write(t, filepath.Join(sub, ".aibomgen.yml"), "scan:\n  ignore: [\"fixtures/\"]\n")
+	if err := os.MkdirAll(filepath.Join(sub, "src"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	paths, err := Find(filepath.Join(sub, "src"))
+	if err != nil {
+		t.Fatalf("Find: %v", err)
+	}
+	want := []string{filepath.Join(repo, ".aibomgen.yaml"), filepath.Join(sub, ".aibomgen.yml")}
+	if len(paths) < 2 || paths[len(paths)-2] != want[0] || paths[len(paths)-1] != want[1] {
+		t.Fatalf("Find = %v, want to end with %v", paths, want)
+	}
+
+	v := viper.New()
+	v.Set("scan.hf-token", "")
+	v.SetDefault("scan.log-level", "standard")
+	if err := Apply(v, want); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if got := v.GetStringSlice("scan.ignore"); len(got) != 1 || got[0] != "fixtures/" {
+		t.Errorf("scan.ignore = %v, want the closest file to win", got)
+	}
+	if !v.GetBool("scan.include-comments") {
+		t.Errorf("expected settings of the outer file to be kept")
+	}
+	if v.GetString("scan.hf-token") != "" {
+		t.Errorf("hf-token must not be taken from a project config")
+	}
+	if v.GetString("scan.repo") != "" || v.GetString("scan.ref") != "" {
+		t.Errorf("repo and ref must not be taken from a project config")
+	}
+	if len(v.GetStringSlice("hooks.post-generate")) != 0 {
+		t.Errorf("hooks must not be taken from a project config")
+	}
+	if v.GetString("tracing.endpoint") != "" {
+		t.Errorf("tracing must not be taken from a project config")
+	}
+	if v.GetString("enrich.sign-key") != "" {
+		t.Errorf("sign-key must not be taken from a project config")
+	}
+	if v.GetString("verify-runtime.api-key") != "" {
+		t.Errorf("api-key must not be taken from a project config")
+	}
+	if v.GetString("sign.cosign") != "" || v.GetString("verify-signature.key") != "" {
+		t.Errorf("cosign and verify-signature must not be taken from a project config")
+	}
+	if v.GetString("scan.log-level") != "standard" {
+		t.Errorf("unrelated settings changed")
+	}
+}
+
+func TestFindNone(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "main.py")
+	write(t, file, "")
+	paths, err := Find(file)
+	if err != nil {
+		t.Fatalf("Find: %v", err)
+	}
+	for _, p := range paths {
+		if filepath.Dir(p) == dir {
+			t.Fatalf("unexpected project config %s", p)
+		}
+	}
+}
+
+func TestApplyInvalidFile(t *testing.T) {
+	p := filepath.Join(t.TempDir(), ".aibomgen.yaml")
+	write(t, p, "scan: [unterminated")
+	if err := Apply(viper.New(), []string{p}); err == nil {
+		t.Fatalf("expected parse error")
+	}
+}
+
+func TestApplyRefusesReferences(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".aibomgen.yaml")
+	write(t, path, "scan:\n  app-name: \"${USER}\"\n")
+	if err := Apply(viper.New(), []string{path}); err == nil {
+		t.Fatal("expected references in a project config to be refused")
+	}
+}
+
+func TestApplyKeepsOnlyPolicyKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".aibomgen.yaml")
+	write(t, path, `verify-claims:
+  public-key: repo.pub
+  require-claim: [licenses]
+check-advisories:
+  public-key: repo.pub
+  skip-verify: true
+audit:
+  skip-verify: true
+  osv-url: https://osv.evil.example
+  hf-base-url: https://hf.evil.example
+enrich:
+  hf-base-url: https://hf.evil.example
+  strategy: file
+verify-runtime:
+  endpoint: https://runtime.evil.example
+attach:
+  image: registry.evil.example/x:latest
+scan:
+  report: /etc/aibomgen-report.json
+  output: /tmp/elsewhere
+metrics:
+  file: /etc/metrics.jsonl
+http-cache:
+  dir: /etc/cache
+locale: de-DE
+validate:
+  min-score: 0.6
+`)
+	v := viper.New()
+	if err := Apply(v, []string{path}); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	for _, key := range []string{
+		"verify-claims.public-key", "check-advisories.public-key", "check-advisories.skip-verify",
+		"audit.skip-verify", "audit.osv-url", "audit.hf-base-url", "enrich.hf-base-url",
+		"verify-runtime.endpoint", "attach.image", "scan.report", "scan.output",
+		"metrics.file", "http-cache.dir", "locale",
+	} {
+		if v.IsSet(key) {
+			t.Errorf("%s must not be taken from a project config (got %v)", key, v.Get(key))
+		}
+	}
+	if v.GetFloat64("validate.min-score") != 0.6 || v.GetString("enrich.strategy") != "file" || len(v.GetStringSlice("verify-claims.require-claim")) != 1 {
+		t.Errorf("policy keys must be taken from a project config")
+	}
+}
@@ -0,0 +1,288 @@
+// Package qa selects AIBOMs for manual quality audits and renders the review.
+// packet auditors work through.
+//.
+// Sampling is weighted random sampling without replacement: every AIBOM can.
+// be drawn, but AIBOMs with a low completeness score or a recent change are.
+// drawn more often. A seed makes a sample reproducible.
+package qa
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"math/rand/v2"
+	"sort"
+	"strings"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/internal/taxonomy"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/completeness"
+)
+
+// Weightings select what makes an AIBOM more likely to be sampled.
+const (
+	WeightScore   = "score"
+	WeightRecent  = "recent"
+	WeightBoth    = "both"
+	WeightUniform = "uniform"
+)
+
+// DefaultHalfLife is the age at which a change counts half as much as a.
+// change made now.
+const DefaultHalfLife = 30 * 24 * time.Hour
+
+// weightFloor keeps complete, unchanged AIBOMs in the draw.
+const weightFloor = 0.05
+
+// Candidate is an AIBOM that can be sampled.
+type Candidate struct {
+	Path    string `json:"path"`
+	Model   string `json:"model"`
+	Version string `json:"version,omitempty"`
+	// Score is the completeness score (0..1).
+	Score  float64 `json:"score"`
+	Passed int     `json:"passed"`
+	Total  int     `json:"total"`
+	// Changed is the BOM timestamp, which generate keeps while the content.
+	// is unchanged, or the file modification time.
+	Changed         time.Time `json:"changed"`
+	MissingRequired []string  `json:"missingRequired"`
+	MissingOptional []string  `json:"missingOptional"`
+	// AwaitingReview is the number of fields requested for review.
+	AwaitingReview int `json:"awaitingReview"`
+	// Weight is the sampling weight, set by Sample.
+	Weight float64 `json:"weight"`
+}
+
+// FromBOM describes the AIBOM at path, scored with profile (nil: the registry.
+// defaults). modTime is used when the BOM has no timestamp.
+func FromBOM(path string, bom *cdx.BOM, profile *completeness.Profile, modTime time.Time) Candidate {
+	res := completeness.CheckWithProfile(bom, profile)
+	c := Candidate{
+		Path:            path,
+		Model:           res.ModelID,
+		Score:           res.Score,
+		Passed:          res.Passed,
+		Total:           res.Total,
+		Changed:         modTime,
+		MissingRequired: []string{},
+		MissingOptional: []string{},
+	}
+	for _, k := range res.MissingRequired {
+		c.MissingRequired = append(c.MissingRequired, string(k))
+	}
+	for _, k := range res.MissingOptional {
+		c.MissingOptional = append(c.MissingOptional, string(k))
+	}
+	if bom.Metadata == nil {
+		return c
+	}
+	if t, err := time.Parse(time.RFC3339, strings.TrimSpace(bom.Metadata.Timestamp)); err == nil {
+		c.Changed = t
+	}
+	if comp := bom.Metadata.Component; comp != nil {
+		if c.Model == "" {
+			c.Model = comp.Name
+		}
+		c.Version = comp.Version
+		if comp.Properties != nil {
+			for _, p := range *comp.Properties {
+				if strings.TrimSpace(p.Name) == taxonomy.ReviewRequested {
+					c.AwaitingReview++
+				}
+			}
+		}
+	}
+	return c
+}
+
+// Options configures Sample.
+type Options struct {
+	// N is the number of AIBOMs to sample.
+	N int
+	// Weighting is one of the Weight* constants (default WeightBoth).
+	Weighting string
+	// HalfLife is the recency half-life (default DefaultHalfLife).
+	HalfLife time.Duration
+	// Seed seeds the random draw.
+	Seed uint64
+	// Now is the reference time for recency (default time.Now).
+	Now time.Time
+}
+
+// ValidWeighting reports whether w is a known weighting.
+func ValidWeighting(w string) bool {
+	switch w {
+	case WeightScore, WeightRecent, WeightBoth, WeightUniform:
+		return true
+	}
+	return false
+}
+
+// Weight returns the sampling weight of c.
+func Weight(c Candidate, opts Options) float64 {
+	opts = withDefaults(opts)
+	score := weightFloor + (1 - math.Max(0, math.Min(1, c.Score)))
+	recent := weightFloor
+	if !c.Changed.IsZero() {
+		age := math.Max(0, opts.Now.Sub(c.Changed).Hours())
+		recent += math.Exp2(-age / opts.HalfLife.Hours())
+	}
+	switch opts.Weighting {
+	case WeightScore:
+		return score
+	case WeightRecent:
+		return recent
+	case WeightUniform:
+		return 1
+	}
+	return (score + recent) / 2
+}
+
+// Sample draws opts.N candidates without replacement, with a probability.
+// proportional to their weight (Efraimidis-Spirakis: the N largest keys.
+// u^(1/w) for u uniform in (0,1)). The sample is ordered by weight, highest.
+// first; all candidates are returned when there are at most N.
+func Sample(cands []Candidate, opts Options) []Candidate {
+	opts = withDefaults(opts)
+	rng := rand.New(rand.NewPCG(opts.Seed, opts.Seed^0x9e3779b97f4a7c15))
+
+	type keyed struct {
+		c   Candidate
+		key float64
+	}
+	all := make([]keyed, len(cands))
+	for i, c := range cands {
+		c.Weight = Weight(c, opts)
+		u := rng.Float64()
+		for u == 0 {
+			u = rng.Float64()
+		}
+		all[i] = keyed{c, math.Log(u) / c.Weight}
+	}
+	sort.SliceStable(all, func(i, j int) bool { return all[i].key > all[j].key })
+
+	n := opts.N
+	if n <= 0 || n > len(all) {
+		n = len(all)
+	}
+	out := make([]Candidate, n)
+	for i := range out {
+		out[i] = all[i].c
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Weight > out[j].Weight })
+	return out
+}
+
+func withDefaults(opts Options) Options {
+	if opts.Weighting == "" {
+		opts.Weighting = WeightBoth
+	}
+	if opts.HalfLife <= 0 {
+		opts.HalfLife = DefaultHalfLife
+	}
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+	return opts
+}
+
+// Packet is a review packet: the sample and how it was drawn.
+type Packet struct {
+	Generated  time.Time   `json:"generated"`
+	Seed       uint64      `json:"seed"`
+	Weighting  string      `json:"weighting"`
+	Population int         `json:"population"`
+	Samples    []Candidate `json:"samples"`
+}
+
+// WriteJSON writes p as indented JSON.
+func WriteJSON(w io.Writer, p Packet) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(p)
+}
+
+// WriteMarkdown writes p as a Markdown document with a checklist per sampled.
+// AIBOM. reproduce is the command line that draws the same sample.
+func WriteMarkdown(w io.Writer, p Packet, reproduce string) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# AIBOM QA review packet\n\n")
+	fmt.Fprintf(&b, "%d of %d AIBOMs sampled on %s, weighted by %s (seed %d).\n",
+		len(p.Samples), p.Population, p.Generated.UTC().Format(time.RFC3339), weightingLabel(p.Weighting), p.Seed)
+	if reproduce != "" {
+		fmt.Fprintf(&b, "Draw the same sample with:\n\n```\n%s\n```\n", reproduce)
+	}
+
+	b.WriteString("\n| # | Model | Completeness | Last changed | Weight | File |\n|---|---|---|---|---|---|\n")
+	for i, c := range p.Samples {
+		fmt.Fprintf(&b, "| %d | %s | %.1f%% | %s | %.2f | `%s` |\n",
+			i+1, mdCell(modelLabel(c)), c.Score*100, dateLabel(c.Changed), c.Weight, c.Path)
+	}
+
+	for i, c := range p.Samples {
+		fmt.Fprintf(&b, "\n## %d. %s\n\n", i+1, modelLabel(c))
+		fmt.Fprintf(&b, "- File: `%s`\n", c.Path)
+		fmt.Fprintf(&b, "- Completeness: %.1f%% (%d/%d fields)\n", c.Score*100, c.Passed, c.Total)
+		fmt.Fprintf(&b, "- Last changed: %s\n", dateLabel(c.Changed))
+		fmt.Fprintf(&b, "- Missing required fields: %s\n", listLabel(c.MissingRequired))
+		fmt.Fprintf(&b, "- Missing optional fields: %s\n", listLabel(c.MissingOptional))
+		if c.AwaitingReview > 0 {
+			fmt.Fprintf(&b, "- Fields awaiting review: %d\n", c.AwaitingReview)
+		}
+		b.WriteString("\nChecklist:\n\n")
+		b.WriteString("- [ ] Model name, version and supplier match the deployed model\n")
+		b.WriteString("- [ ] License matches the model card and the terms of use\n")
+		b.WriteString("- [ ] Training datasets are listed and correct\n")
+		b.WriteString("- [ ] Intended use and limitations are documented\n")
+		b.WriteString("- [ ] Missing fields are filled in (enrich) or justified\n")
+		b.WriteString("- [ ] Verified fields approved (review approve)\n")
+		b.WriteString("\nReviewer: ____________  Date: ____________\n\nNotes:\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func weightingLabel(w string) string {
+	switch w {
+	case WeightScore:
+		return "low completeness"
+	case WeightRecent:
+		return "recent changes"
+	case WeightUniform:
+		return "nothing (uniform)"
+	}
+	return "low completeness and recent changes"
+}
+
+func modelLabel(c Candidate) string {
+	name := c.Model
+	if name == "" {
+		name = c.Path
+	}
+	if c.Version != "" {
+		name += "@" + c.Version
+	}
+	return name
+}
+
+func dateLabel(t time.Time) string {
+	if t.IsZero() {
+		return "unknown"
+	}
+	return t.UTC().Format("2006-01-02")
+}
+
+func listLabel(keys []string) string {
+	if len(keys) == 0 {
+		return "none"
+	}
+	return strings.Join(keys, ", ")
+}
+
+// mdCell escapes the pipes of a Markdown table cell.
+func mdCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
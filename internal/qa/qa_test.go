@@ -0,0 +1,129 @@
+package qa
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/internal/taxonomy"
+)
+
+var now = time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+
+func TestWeight(t *testing.T) {
+	opts := Options{Now: now}
+	low := Candidate{Score: 0.2, Changed: now.Add(-365 * 24 * time.Hour)}
+	high := Candidate{Score: 0.9, Changed: now.Add(-365 * 24 * time.Hour)}
+	recent := Candidate{Score: 0.9, Changed: now.Add(-time.Hour)}
+
+	opts.Weighting = WeightScore
+	if Weight(low, opts) <= Weight(high, opts) {
+		t.Errorf("score: low %v <= high %v", Weight(low, opts), Weight(high, opts))
+	}
+	opts.Weighting = WeightRecent
+	if Weight(recent, opts) <= Weight(high, opts) {
+		t.Errorf("recent: recent %v <= old %v", Weight(recent, opts), Weight(high, opts))
+	}
+	opts.Weighting = WeightBoth
+	if Weight(high, opts) <= 0 {
+		t.Errorf("both: complete old BOM has weight %v, want > 0", Weight(high, opts))
+	}
+	opts.Weighting = WeightUniform
+	if Weight(low, opts) != Weight(recent, opts) {
+		t.Errorf("uniform weights differ")
+	}
+}
+
+func TestSample(t *testing.T) {
+	var cands []Candidate
+	for i := range 100 {
+		cands = append(cands, Candidate{Path: fmt.Sprintf("bom-%d.json", i), Score: float64(i) / 100})
+	}
+	opts := Options{N: 20, Weighting: WeightScore, Seed: 42, Now: now}
+
+	a, b := Sample(cands, opts), Sample(cands, opts)
+	if len(a) != 20 {
+		t.Fatalf("len = %d, want 20", len(a))
+	}
+	seen := map[string]bool{}
+	var mean float64
+	for i := range a {
+		if a[i].Path != b[i].Path {
+			t.Fatalf("same seed drew different samples: %s vs %s", a[i].Path, b[i].Path)
+		}
+		if seen[a[i].Path] {
+			t.Fatalf("%s sampled twice", a[i].Path)
+		}
+		seen[a[i].Path] = true
+		if i > 0 && a[i].Weight > a[i-1].Weight {
+			t.Errorf("sample not ordered by weight")
+		}
+		mean += a[i].Score / 20
+	}
+	if mean >= 0.5 {
+		t.Errorf("mean sampled score = %.2f, want below the population mean", mean)
+	}
+
+	if all := Sample(cands[:5], opts); len(all) != 5 {
+		t.Errorf("n > population: len = %d, want 5", len(all))
+	}
+}
+
+func TestFromBOM(t *testing.T) {
+	bom := cdx.NewBOM()
+	bom.Metadata = &cdx.Metadata{
+		Timestamp: "2026-05-01T10:00:00Z",
+		Component: &cdx.Component{
+			Type:    cdx.ComponentTypeMachineLearningModel,
+			Name:    "org/model",
+			Version: "abc123",
+			Properties: &[]cdx.Property{
+				{Name: taxonomy.ReviewRequested, Value: "licenses"},
+				{Name: taxonomy.ReviewRequested, Value: "name"},
+			},
+		},
+	}
+	c := FromBOM("a.json", bom, nil, now)
+	if c.Model != "org/model" || c.Version != "abc123" || c.AwaitingReview != 2 {
+		t.Errorf("candidate = %+v", c)
+	}
+	if !c.Changed.Equal(time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("changed = %v, want the BOM timestamp", c.Changed)
+	}
+	if c.Total == 0 || c.Score >= 1 || len(c.MissingRequired)+len(c.MissingOptional) == 0 {
+		t.Errorf("completeness = %+v", c)
+	}
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	p := Packet{
+		Generated:  now,
+		Seed:       7,
+		Weighting:  WeightBoth,
+		Population: 3,
+		Samples: []Candidate{
+			{Path: "a.json", Model: "org/a|b", Score: 0.5, Passed: 5, Total: 10, MissingRequired: []string{"BOM.metadata.component.licenses"}, Weight: 0.8},
+		},
+	}
+	var buf bytes.Buffer
+	if err := WriteMarkdown(&buf, p, "aibomgen-cli qa sample --seed 7"); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"1 of 3 AIBOMs sampled",
+		"--seed 7",
+		"| 1 | org/a\\|b | 50.0% |",
+		"## 1. org/a|b",
+		"Missing required fields: BOM.metadata.component.licenses",
+		"- [ ] License matches",
+		"Reviewer:",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("markdown missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+// Package review tracks the review status of individual BOM fields.
+//.
+// A field is requested for review or approved by recording its registry key.
+// in the aibomgen:review:requested or aibomgen:review:approved property of the.
+// component it belongs to (the model component for model fields, the dataset.
+// component for dataset fields). Approving a field replaces its request.
+// Every action is also added to the BOM as an annotation naming the reviewer.
+package review
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/internal/taxonomy"
+)
+
+// Review statuses.
+const (
+	StatusNone      = ""
+	StatusRequested = "requested"
+	StatusApproved  = "approved"
+)
+
+// Status returns the review status of field on comp.
+func Status(comp *cdx.Component, field string) string {
+	if comp == nil {
+		return StatusNone
+	}
+	switch {
+	case has(comp.Properties, taxonomy.ReviewApproved, field):
+		return StatusApproved
+	case has(comp.Properties, taxonomy.ReviewRequested, field):
+		return StatusRequested
+	}
+	return StatusNone
+}
+
+// Approved returns the set of approved field keys on comp.
+func Approved(comp *cdx.Component) map[string]bool {
+	out := make(map[string]bool)
+	if comp == nil || comp.Properties == nil {
+		return out
+	}
+	for _, p := range *comp.Properties {
+		if strings.TrimSpace(p.Name) == taxonomy.ReviewApproved {
+			out[strings.TrimSpace(p.Value)] = true
+		}
+	}
+	return out
+}
+
+// Request marks field on comp as awaiting review. It returns false when the.
+// field is already requested or approved.
+func Request(comp *cdx.Component, field string) bool {
+	if Status(comp, field) != StatusNone {
+		return false
+	}
+	comp.Properties = taxonomy.Set(comp.Properties, taxonomy.ReviewRequested, field)
+	return true
+}
+
+// Approve marks field on comp as approved, replacing a pending request. It.
+// returns false when the field is already approved.
+func Approve(comp *cdx.Component, field string) bool {
+	if Status(comp, field) == StatusApproved {
+		return false
+	}
+	comp.Properties = drop(comp.Properties, taxonomy.ReviewRequested, field)
+	comp.Properties = taxonomy.Set(comp.Properties, taxonomy.ReviewApproved, field)
+	return true
+}
+
+// Reset removes any request or approval of field on comp, for use when its.
+// value changes. It reports whether there was one.
+func Reset(comp *cdx.Component, field string) bool {
+	if comp == nil || Status(comp, field) == StatusNone {
+		return false
+	}
+	comp.Properties = drop(comp.Properties, taxonomy.ReviewRequested, field)
+	comp.Properties = drop(comp.Properties, taxonomy.ReviewApproved, field)
+	return true
+}
+
+// Annotate records a review action on comp as a BOM annotation. action is.
+// "requested review of" or "approved", fields the affected field keys.
+func Annotate(bom *cdx.BOM, comp *cdx.Component, reviewer, action string, fields []string, at time.Time) {
+	if bom == nil || len(fields) == 0 {
+		return
+	}
+	a := cdx.Annotation{
+		Annotator: &cdx.Annotator{Individual: &cdx.OrganizationalContact{Name: reviewer}},
+		Timestamp: at.UTC().Format(time.RFC3339),
+		Text:      fmt.Sprintf("%s %s %s.", reviewer, action, strings.Join(fields, ", ")),
+	}
+	if comp != nil && comp.BOMRef != "" {
+		a.Subjects = &[]cdx.BOMReference{cdx.BOMReference(comp.BOMRef)}
+	}
+	var annotations []cdx.Annotation
+	if bom.Annotations != nil {
+		annotations = *bom.Annotations
+	}
+	annotations = append(annotations, a)
+	bom.Annotations = &annotations
+}
+
+func has(props *[]cdx.Property, name, field string) bool {
+	if props == nil {
+		return false
+	}
+	for _, p := range *props {
+		if strings.TrimSpace(p.Name) == name && strings.TrimSpace(p.Value) == field {
+			return true
+		}
+	}
+	return false
+}
+
+// drop removes the name=field property from props; nil when none remain.
+func drop(props *[]cdx.Property, name, field string) *[]cdx.Property {
+	if props == nil {
+		return nil
+	}
+	kept := make([]cdx.Property, 0, len(*props))
+	for _, p := range *props {
+		if strings.TrimSpace(p.Name) != name || strings.TrimSpace(p.Value) != field {
+			kept = append(kept, p)
+		}
+	}
+	if len(kept) == 0 {
+		return nil
+	}
+	*props = kept
+	return props
+}
@@ -0,0 +1,57 @@
+package review
+
+import (
+	"testing"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+func TestRequestApproveReset(t *testing.T) {
+	comp := &cdx.Component{Name: "m", BOMRef: "pkg:huggingface/m"}
+	const field = "BOM.metadata.component.licenses"
+
+	if got := Status(comp, field); got != StatusNone {
+		t.Fatalf("initial status = %q", got)
+	}
+	if !Request(comp, field) || Request(comp, field) {
+		t.Errorf("Request should succeed once")
+	}
+	if got := Status(comp, field); got != StatusRequested {
+		t.Errorf("status after request = %q", got)
+	}
+	if !Approve(comp, field) || Approve(comp, field) {
+		t.Errorf("Approve should succeed once")
+	}
+	if got := Status(comp, field); got != StatusApproved || len(*comp.Properties) != 1 {
+		t.Errorf("status after approve = %q, properties %v", got, *comp.Properties)
+	}
+	if Request(comp, field) {
+		t.Errorf("Request of an approved field succeeded")
+	}
+	if !Approved(comp)[field] {
+		t.Errorf("Approved() misses %s", field)
+	}
+
+	if !Reset(comp, field) || comp.Properties != nil {
+		t.Errorf("Reset left properties %v", comp.Properties)
+	}
+	if Reset(comp, field) {
+		t.Errorf("Reset of an unreviewed field reported a change")
+	}
+}
+
+func TestAnnotate(t *testing.T) {
+	bom := cdx.NewBOM()
+	comp := &cdx.Component{Name: "m", BOMRef: "ref"}
+	Annotate(bom, comp, "alice", "approved", []string{"a", "b"}, time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+	Annotate(bom, comp, "alice", "approved", nil, time.Now())
+
+	if bom.Annotations == nil || len(*bom.Annotations) != 1 {
+		t.Fatalf("annotations = %v", bom.Annotations)
+	}
+	a := (*bom.Annotations)[0]
+	if a.Text != "alice approved a, b." || a.Annotator.Individual.Name != "alice" || (*a.Subjects)[0] != "ref" || a.Timestamp != "2026-01-02T03:04:05Z" {
+		t.Errorf("annotation = %+v", a)
+	}
+}
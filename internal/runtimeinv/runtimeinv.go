@@ -0,0 +1,352 @@
+// Package runtimeinv compares the models an AIBOM documents with the models.
+// a deployment actually serves.
+//.
+// The served models are read from an OpenAI-compatible endpoint (GET.
+// /v1/models, as exposed by vLLM, TGI, Ollama, LiteLLM and others) or from a.
+// Kubernetes pod list (kubectl get pods -o json), where model IDs are taken.
+// from the container command line, well-known environment variables and.
+// hf:// storage URIs. Any drift is reported both ways: models served without.
+// being documented, and documented models that are not served.
+package runtimeinv
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+// maxResponseSize caps the number of bytes read from a models endpoint.
+const maxResponseSize = 8 << 20
+
+// Served is a model reported by the runtime.
+type Served struct {
+	// ID is the name the model is served under.
+	ID string
+	// Root is the model the served name was loaded from, when the runtime.
+	// reports it (vLLM's "root" field, or the Hugging Face ID behind a.
+	// --served-model-name).
+	Root string
+	// Source says where the model was found: the endpoint URL or.
+	// "pod <namespace>/<name>".
+	Source string
+}
+
+// Documented is a model component listed in an AIBOM.
+type Documented struct {
+	ID     string // Hugging Face ID, or the component name
+	BOMRef string
+	File   string
+}
+
+// Match pairs a served model with the documented model it was matched to.
+type Match struct {
+	Served     Served
+	Documented Documented
+}
+
+// Report is the result of Compare.
+type Report struct {
+	Matched      []Match
+	Undocumented []Served     // served but not in any AIBOM
+	Absent       []Documented // documented but not served
+}
+
+// Drift reports whether served and documented models differ.
+func (r Report) Drift() bool {
+	return len(r.Undocumented) > 0 || len(r.Absent) > 0
+}
+
+// Options configures how the models endpoint is queried.
+type Options struct {
+	// Endpoint is the base URL of the OpenAI-compatible API (with or without.
+	// /v1), or the full URL of its models route.
+	Endpoint string
+	// APIKey is sent as a bearer token when set.
+	APIKey  string
+	Timeout time.Duration
+	Client  *http.Client
+}
+
+// modelsURL returns the models route for an endpoint.
+func modelsURL(endpoint string) string {
+	u := strings.TrimRight(strings.TrimSpace(endpoint), "/")
+	switch {
+	case strings.HasSuffix(u, "/models"):
+		return u
+	case strings.HasSuffix(u, "/v1"):
+		return u + "/models"
+	default:
+		return u + "/v1/models"
+	}
+}
+
+// FetchServed lists the models served by an OpenAI-compatible endpoint.
+func FetchServed(ctx context.Context, opts Options) ([]Served, error) {
+	if strings.TrimSpace(opts.Endpoint) == "" {
+		return nil, fmt.Errorf("no endpoint configured")
+	}
+	client := opts.Client
+	if client == nil {
+		timeout := opts.Timeout
+		if timeout <= 0 {
+			timeout = 15 * time.Second
+		}
+		client = &http.Client{Timeout: timeout}
+	}
+
+	url := modelsURL(opts.Endpoint)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if opts.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+opts.APIKey)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("list served models: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("list served models: %s returned status %d", url, resp.StatusCode)
+	}
+
+	var body struct {
+		Data []struct {
+			ID   string `json:"id"`
+			Root string `json:"root"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&body); err != nil {
+		return nil, fmt.Errorf("decode models list: %w", err)
+	}
+	out := make([]Served, 0, len(body.Data))
+	for _, m := range body.Data {
+		if strings.TrimSpace(m.ID) == "" {
+			continue
+		}
+		root := strings.TrimSpace(m.Root)
+		if root == m.ID {
+			root = ""
+		}
+		out = append(out, Served{ID: strings.TrimSpace(m.ID), Root: root, Source: url})
+	}
+	return out, nil
+}
+
+// modelFlags are command-line flags whose value names the served model.
+var modelFlags = map[string]bool{"--model": true, "--model-id": true, "--model-name": true, "--model_id": true}
+
+// modelEnvVars are environment variables whose value names the served model.
+var modelEnvVars = map[string]bool{"MODEL": true, "MODEL_ID": true, "MODEL_NAME": true, "HF_MODEL_ID": true, "HF_MODEL": true}
+
+// servedNameFlags rename the served model; the loaded model becomes its root.
+var servedNameFlags = map[string]bool{"--served-model-name": true}
+
+type podList struct {
+	Items []struct {
+		Metadata struct {
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+		} `json:"metadata"`
+		Spec struct {
+			Containers []struct {
+				Command []string `json:"command"`
+				Args    []string `json:"args"`
+				Env     []struct {
+					Name  string `json:"name"`
+					Value string `json:"value"`
+				} `json:"env"`
+			} `json:"containers"`
+		} `json:"spec"`
+	} `json:"items"`
+}
+
+// ParsePods extracts served models from the JSON output of.
+// "kubectl get pods -o json". Replicas serving the same model are reported.
+// once.
+func ParsePods(r io.Reader) ([]Served, error) {
+	var pods podList
+	if err := json.NewDecoder(r).Decode(&pods); err != nil {
+		return nil, fmt.Errorf("decode pod list: %w", err)
+	}
+	var out []Served
+	seen := make(map[string]bool)
+	for _, pod := range pods.Items {
+		source := "pod " + pod.Metadata.Name
+		if pod.Metadata.Namespace != "" {
+			source = "pod " + pod.Metadata.Namespace + "/" + pod.Metadata.Name
+		}
+		for _, c := range pod.Spec.Containers {
+			var model, servedName string
+			args := append(append([]string{}, c.Command...), c.Args...)
+			for i := 0; i < len(args); i++ {
+				flag, value, hasValue := strings.Cut(args[i], "=")
+				if !hasValue && i+1 < len(args) {
+					value = args[i+1]
+				}
+				switch {
+				case modelFlags[flag] && model == "":
+					model = value
+				case servedNameFlags[flag]:
+					servedName = value
+				case args[i] == "serve" && i+1 < len(args) && model == "" && !strings.HasPrefix(args[i+1], "-"):
+					// vllm serve <model>.
+					model = args[i+1]
+				case strings.HasPrefix(args[i], "hf://") && model == "":
+					model = args[i]
+				}
+			}
+			for _, env := range c.Env {
+				if model == "" && modelEnvVars[env.Name] && strings.TrimSpace(env.Value) != "" {
+					model = env.Value
+				}
+			}
+			model = normalizeID(model)
+			if model == "" {
+				continue
+			}
+			s := Served{ID: model, Source: source}
+			if servedName != "" && servedName != model {
+				s = Served{ID: servedName, Root: model, Source: source}
+			}
+			key := s.ID + "\x00" + s.Root
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+// DocumentedModels lists the model components of bom. file is recorded on.
+// every entry.
+func DocumentedModels(bom *cdx.BOM, file string) []Documented {
+	if bom == nil {
+		return nil
+	}
+	var out []Documented
+	add := func(c *cdx.Component) {
+		if c == nil || c.Type != cdx.ComponentTypeMachineLearningModel {
+			return
+		}
+		id := modelID(c)
+		if id == "" {
+			return
+		}
+		out = append(out, Documented{ID: id, BOMRef: c.BOMRef, File: file})
+	}
+	if bom.Metadata != nil {
+		add(bom.Metadata.Component)
+	}
+	if bom.Components != nil {
+		for i := range *bom.Components {
+			add(&(*bom.Components)[i])
+		}
+	}
+	return out
+}
+
+// modelID derives the Hugging Face ID of a model component from its PURL,.
+// falling back to group/name and the name.
+func modelID(c *cdx.Component) string {
+	if rest, ok := strings.CutPrefix(c.PackageURL, "pkg:huggingface/"); ok {
+		if i := strings.IndexAny(rest, "@?#"); i >= 0 {
+			rest = rest[:i]
+		}
+		if c.Group != "" && !strings.Contains(rest, "/") {
+			rest = c.Group + "/" + rest
+		}
+		return rest
+	}
+	name := strings.TrimSpace(c.Name)
+	if c.Group != "" && name != "" && !strings.Contains(name, "/") {
+		return c.Group + "/" + name
+	}
+	return name
+}
+
+// normalizeID strips hf:// and Hugging Face URL prefixes from a model ID.
+func normalizeID(id string) string {
+	id = strings.TrimSpace(id)
+	for _, p := range []string{"hf://", "https://huggingface.co/", "http://huggingface.co/", "huggingface.co/"} {
+		id = strings.TrimPrefix(id, p)
+	}
+	return strings.TrimRight(id, "/")
+}
+
+// Compare matches served models against documented ones. A served model.
+// matches when its ID or root equals a documented ID (case-insensitive), or,.
+// failing that, when its name equals the last path element of a documented.
+// ID (e.g. a model served as "Llama-3.1-8B" and documented as.
+// "meta-llama/Llama-3.1-8B"). A documented model may be served several times.
+func Compare(served []Served, documented []Documented) Report {
+	var rep Report
+	used := make([]bool, len(documented))
+	find := func(s Served) int {
+		for _, cand := range []string{s.Root, s.ID} {
+			cand = strings.ToLower(normalizeID(cand))
+			if cand == "" {
+				continue
+			}
+			for i, d := range documented {
+				if strings.ToLower(d.ID) == cand {
+					return i
+				}
+			}
+		}
+		for _, cand := range []string{s.Root, s.ID} {
+			cand = strings.ToLower(path.Base(normalizeID(cand)))
+			if cand == "" || cand == "." {
+				continue
+			}
+			for i, d := range documented {
+				if strings.ToLower(path.Base(d.ID)) == cand {
+					return i
+				}
+			}
+		}
+		return -1
+	}
+	for _, s := range served {
+		i := find(s)
+		if i < 0 {
+			rep.Undocumented = append(rep.Undocumented, s)
+			continue
+		}
+		// The same model documented in several AIBOMs is served by this one.
+		for j, d := range documented {
+			if strings.EqualFold(d.ID, documented[i].ID) {
+				used[j] = true
+			}
+		}
+		rep.Matched = append(rep.Matched, Match{Served: s, Documented: documented[i]})
+	}
+
+	absent := make(map[string]bool)
+	for i, d := range documented {
+		if used[i] {
+			continue
+		}
+		// The same model documented in several AIBOMs is reported once.
+		if key := strings.ToLower(d.ID); !absent[key] {
+			absent[key] = true
+			rep.Absent = append(rep.Absent, d)
+		}
+	}
+	sort.SliceStable(rep.Undocumented, func(i, j int) bool { return rep.Undocumented[i].ID < rep.Undocumented[j].ID })
+	sort.SliceStable(rep.Absent, func(i, j int) bool { return rep.Absent[i].ID < rep.Absent[j].ID })
+	return rep
+}
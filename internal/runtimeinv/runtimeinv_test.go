@@ -0,0 +1,102 @@
+package runtimeinv
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+func TestFetchServed(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/models" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"object":"list","data":[{"id":"chat","root":"meta-llama/Llama-3.1-8B"},{"id":"org/embed","root":"org/embed"}]}`))
+	}))
+	defer srv.Close()
+
+	for _, endpoint := range []string{srv.URL, srv.URL + "/v1/", srv.URL + "/v1/models"} {
+		got, err := FetchServed(context.Background(), Options{Endpoint: endpoint, APIKey: "secret"})
+		if err != nil {
+			t.Fatalf("FetchServed(%s): %v", endpoint, err)
+		}
+		if len(got) != 2 || got[0].ID != "chat" || got[0].Root != "meta-llama/Llama-3.1-8B" || got[1].Root != "" {
+			t.Errorf("FetchServed(%s) = %+v", endpoint, got)
+		}
+	}
+	if _, err := FetchServed(context.Background(), Options{Endpoint: srv.URL}); err == nil {
+		t.Errorf("expected an error without the API key")
+	}
+}
+
+func TestParsePods(t *testing.T) {
+	pods := `{"items":[
+	  {"metadata":{"name":"vllm-0","namespace":"ml"},"spec":{"containers":[{"command":["vllm","serve","meta-llama/Llama-3.1-8B"],"args":["--served-model-name","chat"]}]}},
+	  {"metadata":{"name":"vllm-1","namespace":"ml"},"spec":{"containers":[{"command":["vllm","serve","meta-llama/Llama-3.1-8B"],"args":["--served-model-name","chat"]}]}},
+	  {"metadata":{"name":"tgi"},"spec":{"containers":[{"args":["--model-id=hf://org/embed"]}]}},
+	  {"metadata":{"name":"env"},"spec":{"containers":[{"env":[{"name":"HF_MODEL_ID","value":"https://huggingface.co/org/classifier"}]}]}},
+	  {"metadata":{"name":"web"},"spec":{"containers":[{"command":["nginx"]}]}}
+	]}`
+	got, err := ParsePods(strings.NewReader(pods))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Served{
+		{ID: "chat", Root: "meta-llama/Llama-3.1-8B", Source: "pod ml/vllm-0"},
+		{ID: "org/embed", Source: "pod tgi"},
+		{ID: "org/classifier", Source: "pod env"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("ParsePods = %+v", got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("ParsePods[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	bom := cdx.NewBOM()
+	bom.Metadata = &cdx.Metadata{Component: &cdx.Component{Type: cdx.ComponentTypeApplication, Name: "app"}}
+	bom.Components = &[]cdx.Component{
+		{Type: cdx.ComponentTypeMachineLearningModel, Name: "Llama-3.1-8B", PackageURL: "pkg:huggingface/meta-llama/Llama-3.1-8B@abc"},
+		{Type: cdx.ComponentTypeMachineLearningModel, Name: "gpt2", PackageURL: "pkg:huggingface/openai-community/gpt2@def"},
+		{Type: cdx.ComponentTypeMachineLearningModel, Name: "bert", Group: "google"},
+		{Type: cdx.ComponentTypeData, Name: "wiki"},
+	}
+	documented := DocumentedModels(bom, "app.json")
+	// The same model documented in a second AIBOM.
+	documented = append(documented, Documented{ID: "google/bert", File: "bert.json"})
+	if len(documented) != 4 {
+		t.Fatalf("DocumentedModels = %+v", documented)
+	}
+
+	rep := Compare([]Served{
+		{ID: "chat", Root: "meta-llama/Llama-3.1-8B"},
+		{ID: "BERT"},
+		{ID: "org/unknown"},
+	}, documented)
+
+	if len(rep.Matched) != 2 || rep.Matched[0].Documented.ID != "meta-llama/Llama-3.1-8B" || rep.Matched[1].Documented.ID != "google/bert" {
+		t.Errorf("Matched = %+v", rep.Matched)
+	}
+	if len(rep.Undocumented) != 1 || rep.Undocumented[0].ID != "org/unknown" {
+		t.Errorf("Undocumented = %+v", rep.Undocumented)
+	}
+	if len(rep.Absent) != 1 || rep.Absent[0].ID != "openai-community/gpt2" {
+		t.Errorf("Absent = %+v", rep.Absent)
+	}
+	if !rep.Drift() {
+		t.Errorf("Drift() = false")
+	}
+}
@@ -0,0 +1,52 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNext(t *testing.T) {
+	// Wednesday.
+	base := time.Date(2026, time.March, 4, 10, 30, 15, 0, time.UTC)
+	cases := []struct {
+		expr string
+		want time.Time
+	}{
+		{"0 3 * * *", time.Date(2026, time.March, 5, 3, 0, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2026, time.March, 4, 10, 45, 0, 0, time.UTC)},
+		{"31 10 * * *", time.Date(2026, time.March, 4, 10, 31, 0, 0, time.UTC)},
+		{"30 10 * * *", time.Date(2026, time.March, 5, 10, 30, 0, 0, time.UTC)},
+		{"0 9-17/4 * * mon-fri", time.Date(2026, time.March, 4, 13, 0, 0, 0, time.UTC)},
+		{"0 0 * * sun", time.Date(2026, time.March, 8, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2026, time.March, 8, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 jan *", time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2026, time.April, 1, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2026, time.March, 4, 11, 0, 0, 0, time.UTC)},
+		// Day of month OR day of week when both are restricted.
+		{"0 0 13 * fri", time.Date(2026, time.March, 6, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{"5,10 4 * * *", time.Date(2026, time.March, 5, 4, 5, 0, 0, time.UTC)},
+	}
+	for _, c := range cases {
+		s, err := Parse(c.expr)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", c.expr, err)
+		}
+		if got := s.Next(base); !got.Equal(c.want) {
+			t.Errorf("Next(%q) = %s, want %s", c.expr, got, c.want)
+		}
+	}
+
+	s, _ := Parse("0 0 30 2 *")
+	if got := s.Next(base); !got.IsZero() {
+		t.Errorf("impossible schedule: Next = %s, want zero", got)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8", "*/0 * * * *", "5-1 * * * *", "x * * * *", "@sometimes"} {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("Parse(%q): expected an error", expr)
+		}
+	}
+}
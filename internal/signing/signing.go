@@ -0,0 +1,167 @@
+// Package signing signs AIBOM files with cosign (Sigstore) and verifies the.
+// signatures before a BOM is consumed.
+//.
+// cosign is run from PATH (or Options.Cosign). Signatures are written as.
+// Sigstore bundles next to the BOM:.
+//.
+//	signature   – a detached signature over the BOM file (cosign sign-blob),.
+//	              in <bom>.sigstore.json.
+//	attestation – an in-toto statement with the BOM as predicate of type.
+//	              https://cyclonedx.org/bom and the BOM file as subject.
+//	              (cosign attest-blob), in <bom>.intoto.sigstore.json.
+//.
+// Without a key, cosign signs keyless: it obtains a short-lived certificate.
+// from Fulcio for an OIDC identity and records the signature in Rekor.
+// Encrypted keys are unlocked with the COSIGN_PASSWORD environment variable.
+package signing
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Signing modes.
+const (
+	ModeSignature   = "signature"
+	ModeAttestation = "attestation"
+)
+
+// PredicateType is the in-toto predicate type of AIBOM attestations.
+const PredicateType = "https://cyclonedx.org/bom"
+
+// Bundle file suffixes.
+const (
+	SignatureSuffix   = ".sigstore.json"
+	AttestationSuffix = ".intoto.sigstore.json"
+)
+
+// ErrNoSignature is returned by Verify when a BOM has no bundle.
+var ErrNoSignature = errors.New("no signature bundle")
+
+// Options configures signing and verification.
+type Options struct {
+	// Cosign is the cosign executable (default "cosign").
+	Cosign string
+	// Mode is ModeSignature (default) or ModeAttestation.
+	Mode string
+	// Key is the private key (signing) or public key (verification): a file.
+	// or a KMS URI. Empty signs keyless.
+	Key string
+	// IdentityToken is the OIDC token for keyless signing, e.g. in CI.
+	IdentityToken string
+	// CertificateIdentity and CertificateOIDCIssuer are the signer identity.
+	// keyless signatures must have.
+	CertificateIdentity   string
+	CertificateOIDCIssuer string
+}
+
+// ParseMode validates a signing mode.
+func ParseMode(s string) (string, error) {
+	switch m := strings.ToLower(strings.TrimSpace(s)); m {
+	case "":
+		return ModeSignature, nil
+	case ModeSignature, ModeAttestation:
+		return m, nil
+	}
+	return "", fmt.Errorf("invalid signing mode %q (expected signature|attestation)", s)
+}
+
+// BundlePath returns the path of the bundle of bomPath in mode.
+func BundlePath(bomPath, mode string) string {
+	if mode == ModeAttestation {
+		return bomPath + AttestationSuffix
+	}
+	return bomPath + SignatureSuffix
+}
+
+// IsBundle reports whether path is a signature bundle rather than a BOM.
+func IsBundle(path string) bool {
+	return strings.HasSuffix(path, SignatureSuffix)
+}
+
+// signArgs returns the cosign arguments signing bomPath.
+func signArgs(bomPath string, opts Options) []string {
+	bundle := BundlePath(bomPath, opts.Mode)
+	var args []string
+	if opts.Mode == ModeAttestation {
+		args = []string{"attest-blob", "--yes", "--bundle", bundle, "--predicate", bomPath, "--type", PredicateType}
+	} else {
+		args = []string{"sign-blob", "--yes", "--bundle", bundle}
+	}
+	if opts.Key != "" {
+		args = append(args, "--key", opts.Key)
+	}
+	if opts.IdentityToken != "" {
+		args = append(args, "--identity-token", opts.IdentityToken)
+	}
+	return append(args, bomPath)
+}
+
+// verifyArgs returns the cosign arguments verifying the bundle of bomPath.
+func verifyArgs(bomPath string, opts Options) []string {
+	bundle := BundlePath(bomPath, opts.Mode)
+	var args []string
+	if opts.Mode == ModeAttestation {
+		args = []string{"verify-blob-attestation", "--bundle", bundle, "--type", PredicateType}
+	} else {
+		args = []string{"verify-blob", "--bundle", bundle}
+	}
+	if opts.Key != "" {
+		args = append(args, "--key", opts.Key)
+	} else {
+		args = append(args, "--certificate-identity", opts.CertificateIdentity, "--certificate-oidc-issuer", opts.CertificateOIDCIssuer)
+	}
+	return append(args, bomPath)
+}
+
+// Sign signs bomPath and returns the path of the written bundle.
+func Sign(ctx context.Context, bomPath string, opts Options) (string, error) {
+	if err := run(ctx, opts, signArgs(bomPath, opts)); err != nil {
+		return "", fmt.Errorf("sign %s: %w", bomPath, err)
+	}
+	return BundlePath(bomPath, opts.Mode), nil
+}
+
+// Verify checks the bundle of bomPath: signed by Key, or keyless by the.
+// configured certificate identity and issuer.
+func Verify(ctx context.Context, bomPath string, opts Options) error {
+	if opts.Key == "" && (opts.CertificateIdentity == "" || opts.CertificateOIDCIssuer == "") {
+		return fmt.Errorf("keyless signatures need a certificate identity and OIDC issuer to verify against")
+	}
+	if _, err := os.Stat(BundlePath(bomPath, opts.Mode)); err != nil {
+		return fmt.Errorf("verify %s: %w", bomPath, ErrNoSignature)
+	}
+	if err := run(ctx, opts, verifyArgs(bomPath, opts)); err != nil {
+		return fmt.Errorf("verify %s: %w", bomPath, err)
+	}
+	return nil
+}
+
+// run executes cosign. Its error names the last line cosign printed.
+func run(ctx context.Context, opts Options, args []string) error {
+	bin := opts.Cosign
+	if bin == "" {
+		bin = "cosign"
+	}
+	path, err := exec.LookPath(bin)
+	if err != nil {
+		return fmt.Errorf("cosign not found (install it from https://docs.sigstore.dev): %w", err)
+	}
+	var out bytes.Buffer
+	cmd := exec.CommandContext(ctx, path, args...)
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	if err := cmd.Run(); err != nil {
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		if msg := strings.TrimSpace(lines[len(lines)-1]); msg != "" {
+			return fmt.Errorf("%s: %w", msg, err)
+		}
+		return err
+	}
+	return nil
+}
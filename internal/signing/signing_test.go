@@ -0,0 +1,98 @@
+package signing
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// fakeCosign writes a cosign stand-in that logs its arguments, writes the.
+// bundle on signing and fails verification of bundles containing "bad".
+func fakeCosign(t *testing.T) (string, string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script stand-in")
+	}
+	dir := t.TempDir()
+	log := filepath.Join(dir, "args.log")
+	script := `#!/bin/sh
+echo "$@" >> "` + log + `"
+cmd="$1"
+while [ $# -gt 0 ]; do
+  case "$1" in --bundle) bundle="$2"; shift ;; esac
+  shift
+done
+case "$cmd" in
+  sign-blob|attest-blob) echo '{"bundle":true}' > "$bundle" ;;
+  verify-*) if grep -q bad "$bundle"; then echo "Error: none of the signatures verified" >&2; exit 1; fi ;;
+esac
+`
+	path := filepath.Join(dir, "cosign")
+	if err := os.WriteFile(path, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return path, log
+}
+
+func TestSignAndVerify(t *testing.T) {
+	cosign, log := fakeCosign(t)
+	bom := filepath.Join(t.TempDir(), "model_aibom.json")
+	os.WriteFile(bom, []byte(`{}`), 0o644)
+
+	opts := Options{Cosign: cosign, Key: "cosign.key"}
+	bundle, err := Sign(context.Background(), bom, opts)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	if bundle != bom+SignatureSuffix {
+		t.Errorf("bundle = %q", bundle)
+	}
+	if _, err := os.Stat(bundle); err != nil {
+		t.Errorf("bundle not written: %v", err)
+	}
+	if err := Verify(context.Background(), bom, Options{Cosign: cosign, Key: "cosign.pub"}); err != nil {
+		t.Errorf("Verify: %v", err)
+	}
+
+	os.WriteFile(bundle, []byte("bad"), 0o644)
+	err = Verify(context.Background(), bom, Options{Cosign: cosign, Key: "cosign.pub"})
+	if err == nil || !strings.Contains(err.Error(), "none of the signatures verified") {
+		t.Errorf("expected the cosign error, got %v", err)
+	}
+
+	attest := Options{Cosign: cosign, Mode: ModeAttestation, CertificateIdentity: "ci@example.com", CertificateOIDCIssuer: "https://issuer"}
+	if err := Verify(context.Background(), bom, attest); !errors.Is(err, ErrNoSignature) {
+		t.Errorf("expected ErrNoSignature, got %v", err)
+	}
+
+	data, _ := os.ReadFile(log)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	want := []string{
+		"sign-blob --yes --bundle " + bundle + " --key cosign.key " + bom,
+		"verify-blob --bundle " + bundle + " --key cosign.pub " + bom,
+		"verify-blob --bundle " + bundle + " --key cosign.pub " + bom,
+	}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("cosign calls =\n%s\nwant\n%s", strings.Join(lines, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestArgs(t *testing.T) {
+	opts := Options{Mode: ModeAttestation, IdentityToken: "tok", CertificateIdentity: "ci@example.com", CertificateOIDCIssuer: "https://issuer"}
+	got := strings.Join(signArgs("b.json", opts), " ")
+	if want := "attest-blob --yes --bundle b.json.intoto.sigstore.json --predicate b.json --type " + PredicateType + " --identity-token tok b.json"; got != want {
+		t.Errorf("signArgs = %q, want %q", got, want)
+	}
+	got = strings.Join(verifyArgs("b.json", opts), " ")
+	if want := "verify-blob-attestation --bundle b.json.intoto.sigstore.json --type " + PredicateType + " --certificate-identity ci@example.com --certificate-oidc-issuer https://issuer b.json"; got != want {
+		t.Errorf("verifyArgs = %q, want %q", got, want)
+	}
+	if err := Verify(context.Background(), "b.json", Options{}); err == nil {
+		t.Error("expected keyless verification without an identity to fail")
+	}
+}
@@ -0,0 +1,423 @@
+// Package taxonomy defines the versioned namespace of the CycloneDX properties.
+// written by AIBoMGen (see docs/property-taxonomy.md).
+//.
+// Every property name starts with "aibomgen:" and is registered here together.
+// with the names earlier versions of the tool used for it. Property writers.
+// go through Set, which refuses unregistered names, and readers go through.
+// Get/Has, which also recognise the legacy names so that older BOMs keep.
+// working. BOMs record the taxonomy version they follow in the.
+// "aibomgen:taxonomyVersion" metadata property.
+package taxonomy
+
+import (
+	"fmt"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+// Version is the current version of the property taxonomy.
+const Version = "1"
+
+// Namespace is the prefix shared by all AIBoMGen properties.
+const Namespace = "aibomgen:"
+
+// Property names, version 1.
+const (
+	// TaxonomyVersion is the BOM metadata property holding Version.
+	TaxonomyVersion = "aibomgen:taxonomyVersion"
+
+	Classifier = "aibomgen:classifier"
+
+	DiscoveryType       = "aibomgen:discovery:type"
+	DiscoveryEvidence   = "aibomgen:discovery:evidence"
+	DiscoveryPath       = "aibomgen:discovery:path"
+	DiscoveryConfidence = "aibomgen:discovery:confidence"
+
+	DiscoveryIntroducedCommit = "aibomgen:discovery:introducedCommit"
+	DiscoveryIntroducedAt     = "aibomgen:discovery:introducedAt"
+	DiscoveryRemovedCommit    = "aibomgen:discovery:removedCommit"
+	DiscoveryRemovedAt        = "aibomgen:discovery:removedAt"
+
+	FileFormat = "aibomgen:file:format"
+	FileSize   = "aibomgen:file:size"
+	FileETag   = "aibomgen:file:etag"
+
+	HFLastModified     = "aibomgen:huggingface:lastModified"
+	HFCreatedAt        = "aibomgen:huggingface:createdAt"
+	HFLanguage         = "aibomgen:huggingface:language"
+	HFUsedStorage      = "aibomgen:huggingface:usedStorage"
+	HFPrivate          = "aibomgen:huggingface:private"
+	HFLibraryName      = "aibomgen:huggingface:libraryName"
+	HFDownloads        = "aibomgen:huggingface:downloads"
+	HFLikes            = "aibomgen:huggingface:likes"
+	HFBaseModel        = "aibomgen:huggingface:baseModel"
+	HFModelCardContact = "aibomgen:huggingface:modelCardContact"
+	HFDatasetContact   = "aibomgen:huggingface:datasetContact"
+
+	HFSecurityOverallStatus    = "aibomgen:huggingface:security:overallStatus"
+	HFSecurityScannedFileCount = "aibomgen:huggingface:security:scannedFileCount"
+	HFSecurityUnsafeFileCount  = "aibomgen:huggingface:security:unsafeFileCount"
+	HFSecurityCautionFileCount = "aibomgen:huggingface:security:cautionFileCount"
+
+	HFPipelineClass     = "aibomgen:huggingface:pipelineClass"
+	HFPipelineComponent = "aibomgen:huggingface:pipelineComponent"
+	HFPipelinePartLib   = "aibomgen:huggingface:pipelinePartLibrary"
+	HFPipelinePartClass = "aibomgen:huggingface:pipelinePartClass"
+
+	KaggleFramework       = "aibomgen:kaggle:framework"
+	KaggleFineTunable     = "aibomgen:kaggle:fineTunable"
+	KagglePublishTime     = "aibomgen:kaggle:publishTime"
+	KaggleLastUpdated     = "aibomgen:kaggle:lastUpdated"
+	KaggleTotalBytes      = "aibomgen:kaggle:totalBytes"
+	KaggleVotes           = "aibomgen:kaggle:votes"
+	KaggleDownloads       = "aibomgen:kaggle:downloads"
+	KaggleUsabilityRating = "aibomgen:kaggle:usabilityRating"
+
+	OllamaDigest        = "aibomgen:ollama:digest"
+	OllamaFamily        = "aibomgen:ollama:family"
+	OllamaParameterSize = "aibomgen:ollama:parameterSize"
+	OllamaQuantization  = "aibomgen:ollama:quantization"
+
+	WeightsParameterCount = "aibomgen:weights:parameterCount"
+	WeightsQuantization   = "aibomgen:weights:quantization"
+	WeightsContextLength  = "aibomgen:weights:contextLength"
+	WeightsTokenizer      = "aibomgen:weights:tokenizer"
+	WeightsVocabularySize = "aibomgen:weights:vocabularySize"
+	WeightsTensorCount    = "aibomgen:weights:tensorCount"
+	WeightsDTypes         = "aibomgen:weights:dtypes"
+
+	SafetensorsMetadata = "aibomgen:safetensors:metadata"
+
+	ONNXProducer  = "aibomgen:onnx:producer"
+	ONNXIRVersion = "aibomgen:onnx:irVersion"
+	ONNXOpsets    = "aibomgen:onnx:opsets"
+	ONNXDocString = "aibomgen:onnx:docString"
+
+	GovernanceConsent    = "aibomgen:governance:consent"
+	GovernanceOptOut     = "aibomgen:governance:optOut"
+	GovernanceDoNotTrain = "aibomgen:governance:doNotTrain"
+
+	DataSynthetic       = "aibomgen:data:synthetic"
+	DataGeneratingModel = "aibomgen:data:generatingModel"
+
+	DataTemporalCoverage   = "aibomgen:data:temporalCoverage"
+	DataGeographicCoverage = "aibomgen:data:geographicCoverage"
+	DataLanguages          = "aibomgen:data:languages"
+
+	AttributionHolders   = "aibomgen:attribution:holders"
+	AttributionStatement = "aibomgen:attribution:statement"
+
+	FairnessCriteria = "aibomgen:fairness:criteria"
+
+	AIActDeploymentContext  = "aibomgen:aiact:deploymentContext"
+	AIActHumanOversight     = "aibomgen:aiact:humanOversight"
+	AIActRiskClassification = "aibomgen:aiact:riskClassification"
+
+	LicenseFile       = "aibomgen:license:file"
+	LicenseFileSHA256 = "aibomgen:license:fileSha256"
+
+	RawHFAPI             = "aibomgen:raw:huggingface:api"
+	RawReadmeFrontMatter = "aibomgen:raw:huggingface:readmeFrontMatter"
+
+	ReviewRequested = "aibomgen:review:requested"
+	ReviewApproved  = "aibomgen:review:approved"
+
+	ServingFramework = "aibomgen:serving:framework"
+
+	HostedProvider = "aibomgen:hosted:provider"
+	HostedEvidence = "aibomgen:hosted:evidence"
+	HostedPath     = "aibomgen:hosted:path"
+)
+
+// LegacyLastModifiedTagPrefix prefixes the "lastModified:<time>" component tag.
+// that versions before the taxonomy wrote for datasets instead of the.
+// HFLastModified property.
+const LegacyLastModifiedTagPrefix = "lastModified:"
+
+// Scope tells where a property is attached.
+type Scope string
+
+const (
+	ScopeMetadata  Scope = "metadata"  // bom.metadata.properties
+	ScopeComponent Scope = "component" // components[].properties
+	ScopeLicense   Scope = "license"   // components[].licenses[].license.properties
+	ScopeService   Scope = "service"   // services[].properties
+)
+
+// Property documents one registered property name.
+type Property struct {
+	Name        string
+	Scope       Scope
+	Description string
+	// Legacy lists the names earlier versions wrote for this property.
+	Legacy []string
+}
+
+var registry = []Property{
+	{TaxonomyVersion, ScopeMetadata, "Version of the property taxonomy the BOM follows.", nil},
+
+	{Classifier, ScopeComponent, "Kind of model (llm, diffusion, embedding, ...).", nil},
+
+	{DiscoveryType, ScopeComponent, "Type of the scanner discovery the component was built from.", []string{"aibomgen.type"}},
+	{DiscoveryEvidence, ScopeComponent, "Source snippet the model reference was detected in.", []string{"aibomgen.evidence"}},
+	{DiscoveryPath, ScopeComponent, "File the model reference was detected in.", []string{"aibomgen.path"}},
+	{DiscoveryConfidence, ScopeComponent, "Detection confidence; \"low\" for references found only in comments or tests.", []string{"aibomgen.confidence"}},
+	{DiscoveryIntroducedCommit, ScopeComponent, "Git commit that first added the model reference (scan --git-history).", nil},
+	{DiscoveryIntroducedAt, ScopeComponent, "Commit time of the commit that first added the model reference.", nil},
+	{DiscoveryRemovedCommit, ScopeComponent, "Git commit that removed the last occurrence of a model reference no longer in the tree.", nil},
+	{DiscoveryRemovedAt, ScopeComponent, "Commit time of the commit that removed the model reference.", nil},
+
+	{FileFormat, ScopeComponent, "Weight file format (safetensors, gguf, onnx, ...).", []string{"aibomgen.fileFormat"}},
+	{FileSize, ScopeComponent, "Weight file size in bytes.", []string{"aibomgen.fileSize"}},
+	{FileETag, ScopeComponent, "ETag of an external weight URL.", []string{"aibomgen.etag"}},
+
+	{HFLastModified, ScopeComponent, "Time the Hugging Face repository was last updated.", []string{"huggingface:lastModified"}},
+	{HFCreatedAt, ScopeComponent, "Time the Hugging Face repository was created.", []string{"huggingface:createdAt"}},
+	{HFLanguage, ScopeComponent, "Natural languages the model supports.", []string{"huggingface:language"}},
+	{HFUsedStorage, ScopeComponent, "Storage used by the Hugging Face repository, in bytes.", []string{"huggingface:usedStorage"}},
+	{HFPrivate, ScopeComponent, "Whether the Hugging Face repository is private.", []string{"huggingface:private"}},
+	{HFLibraryName, ScopeComponent, "Library the model is meant to be loaded with.", []string{"huggingface:libraryName"}},
+	{HFDownloads, ScopeComponent, "Number of recent downloads on Hugging Face.", []string{"huggingface:downloads"}},
+	{HFLikes, ScopeComponent, "Number of likes on Hugging Face.", []string{"huggingface:likes"}},
+	{HFBaseModel, ScopeComponent, "Model this model was fine-tuned or derived from.", []string{"huggingface:baseModel"}},
+	{HFModelCardContact, ScopeComponent, "Contact for questions about the model card.", []string{"huggingface:modelCardContact", "contact"}},
+	{HFDatasetContact, ScopeComponent, "Contact for questions about the dataset card.", []string{"huggingface:datasetContact"}},
+
+	{HFSecurityOverallStatus, ScopeComponent, "Worst security scan result across the repository files.", []string{"huggingface:security:overallStatus"}},
+	{HFSecurityScannedFileCount, ScopeComponent, "Number of repository files with a security scan result.", []string{"huggingface:security:scannedFileCount"}},
+	{HFSecurityUnsafeFileCount, ScopeComponent, "Number of files flagged as unsafe.", []string{"huggingface:security:unsafeFileCount"}},
+	{HFSecurityCautionFileCount, ScopeComponent, "Number of files flagged for caution.", []string{"huggingface:security:cautionFileCount"}},
+
+	{HFPipelineClass, ScopeComponent, "Class of a diffusers pipeline.", []string{"huggingface:pipelineClass"}},
+	{HFPipelineComponent, ScopeComponent, "Subfolder of a diffusers pipeline part.", []string{"huggingface:pipelineComponent"}},
+	{HFPipelinePartLib, ScopeComponent, "Library of a diffusers pipeline part.", []string{"huggingface:library"}},
+	{HFPipelinePartClass, ScopeComponent, "Class of a diffusers pipeline part.", []string{"huggingface:class"}},
+
+	{KaggleFramework, ScopeComponent, "Framework of a Kaggle model variation (transformers, keras, pytorch, ...).", nil},
+	{KaggleFineTunable, ScopeComponent, "Whether the Kaggle model variation can be fine-tuned.", nil},
+	{KagglePublishTime, ScopeComponent, "Time the Kaggle model was published.", nil},
+	{KaggleLastUpdated, ScopeComponent, "Time the Kaggle dataset was last updated.", nil},
+	{KaggleTotalBytes, ScopeComponent, "Size of the Kaggle model variation or dataset, in bytes.", nil},
+	{KaggleVotes, ScopeComponent, "Number of upvotes on Kaggle.", nil},
+	{KaggleDownloads, ScopeComponent, "Number of downloads of the Kaggle dataset.", nil},
+	{KaggleUsabilityRating, ScopeComponent, "Kaggle usability rating of the dataset (0-1).", nil},
+	{OllamaDigest, ScopeComponent, "Digest of the manifest of an Ollama model tag.", nil},
+	{OllamaFamily, ScopeComponent, "Model family of an Ollama model (llama, qwen2, ...).", nil},
+	{OllamaParameterSize, ScopeComponent, "Parameter size the Ollama registry reports (8.0B).", nil},
+	{OllamaQuantization, ScopeComponent, "Quantization of the weights of an Ollama model (Q4_0, Q4_K_M, ...).", nil},
+
+	{WeightsParameterCount, ScopeComponent, "Number of parameters of the model (weight file tensors, Hub safetensors total or model card).", nil},
+	{WeightsQuantization, ScopeComponent, "Quantization or precision of the weight file (Q4_K_M, F16, ...).", nil},
+	{WeightsContextLength, ScopeComponent, "Context length of the model, in tokens (weight file header, config.json or model card).", nil},
+	{WeightsTokenizer, ScopeComponent, "Tokenizer model the weight file embeds (llama, gpt2, ...).", nil},
+	{WeightsVocabularySize, ScopeComponent, "Number of tokens in the vocabulary the weight file embeds.", nil},
+	{WeightsTensorCount, ScopeComponent, "Number of tensors in the weight file.", nil},
+	{WeightsDTypes, ScopeComponent, "Data types of the tensors in the weight file, most parameters first (BF16, F32).", nil},
+
+	{SafetensorsMetadata, ScopeComponent, "Free-form __metadata__ entries of a safetensors file, as key=value pairs separated by semicolons.", nil},
+
+	{ONNXProducer, ScopeComponent, "Tool and version that exported an ONNX model (pytorch 2.1.0).", nil},
+	{ONNXIRVersion, ScopeComponent, "ONNX IR version of the model file.", nil},
+	{ONNXOpsets, ScopeComponent, "Operator sets an ONNX model imports, with their versions (ai.onnx 17, com.microsoft 1).", nil},
+	{ONNXDocString, ScopeComponent, "Documentation string of an ONNX model.", nil},
+
+	{GovernanceConsent, ScopeComponent, "How the consent of the people in a dataset was obtained, as the dataset card states it.", nil},
+	{GovernanceOptOut, ScopeComponent, "Opt-out or removal mechanism the dataset card describes.", nil},
+	{GovernanceDoNotTrain, ScopeComponent, "Do-not-train signals the dataset card mentions (robots.txt, noai, ai.txt, tdm-reservation, do-not-train), comma-separated.", nil},
+
+	{DataSynthetic, ScopeComponent, "Whether a dataset was generated by a model rather than collected (true or false).", nil},
+	{DataGeneratingModel, ScopeComponent, "Model that generated a synthetic dataset, as a Hugging Face model ID; the dataset depends on its component.", nil},
+	{DataTemporalCoverage, ScopeComponent, "Time range the data of a dataset was collected in, as an ISO 8601 interval (2019/2021) or as the dataset card states it.", nil},
+	{DataGeographicCoverage, ScopeComponent, "Countries or regions the data of a dataset was collected in or describes.", nil},
+	{DataLanguages, ScopeComponent, "Languages of a dataset, as BCP 47 codes (en, nl), comma-separated.", nil},
+
+	{AttributionHolders, ScopeComponent, "Copyright holders named by the copyright notices of a model or dataset, semicolon-separated.", nil},
+	{AttributionStatement, ScopeComponent, "Attribution the authors of a model or dataset card require from its users.", nil},
+
+	{FairnessCriteria, ScopeComponent, "Fairness criteria a model card reports evaluating (demographic parity, equalized odds, ...), comma-separated.", nil},
+
+	{AIActDeploymentContext, ScopeComponent, "Setting a model is deployed in (sector, product, who operates it), as the EU AI Act asks providers to document.", nil},
+	{AIActHumanOversight, ScopeComponent, "Human oversight measures required when operating a model (EU AI Act, Article 14).", nil},
+	{AIActRiskClassification, ScopeComponent, "EU AI Act risk class of a model's intended use: prohibited, high, limited or minimal.", nil},
+
+	{LicenseFile, ScopeLicense, "Path of the license file in the repository.", []string{"huggingface:licenseFile"}},
+	{LicenseFileSHA256, ScopeLicense, "SHA-256 digest of the license file.", []string{"huggingface:licenseFileSha256"}},
+
+	{RawHFAPI, ScopeComponent, "Hugging Face API response as received (gzip, base64).", []string{"aibomgen.raw.huggingface:api"}},
+	{RawReadmeFrontMatter, ScopeComponent, "Model card YAML front matter as written (gzip, base64).", []string{"aibomgen.raw.huggingface:readmeFrontMatter"}},
+
+	{ReviewRequested, ScopeComponent, "Field key awaiting review; one property per field.", nil},
+	{ReviewApproved, ScopeComponent, "Field key whose value a reviewer approved; one property per field.", nil},
+
+	{ServingFramework, ScopeComponent, "Framework of a serving bundle application (bentoml, kserve).", nil},
+
+	{HostedProvider, ScopeService, "Provider of a hosted model API (openai, anthropic, azure-openai, bedrock).", nil},
+	{HostedEvidence, ScopeService, "Source snippet the hosted model API was detected in.", nil},
+	{HostedPath, ScopeService, "File the hosted model API was detected in.", nil},
+}
+
+// Kind tells how reports display the value of a property.
+type Kind string
+
+const (
+	KindText  Kind = ""      // shown as written
+	KindCount Kind = "count" // an integer count
+	KindBytes Kind = "bytes" // a size in bytes
+	KindTime  Kind = "time"  // an RFC 3339 time
+)
+
+// kinds lists the properties whose values are counts, sizes or times.
+var kinds = map[string]Kind{
+	DiscoveryIntroducedAt:      KindTime,
+	DiscoveryRemovedAt:         KindTime,
+	FileSize:                   KindBytes,
+	HFLastModified:             KindTime,
+	HFCreatedAt:                KindTime,
+	HFUsedStorage:              KindBytes,
+	HFDownloads:                KindCount,
+	HFLikes:                    KindCount,
+	HFSecurityScannedFileCount: KindCount,
+	HFSecurityUnsafeFileCount:  KindCount,
+	HFSecurityCautionFileCount: KindCount,
+	KagglePublishTime:          KindTime,
+	KaggleLastUpdated:          KindTime,
+	KaggleTotalBytes:           KindBytes,
+	KaggleVotes:                KindCount,
+	KaggleDownloads:            KindCount,
+	WeightsParameterCount:      KindCount,
+	WeightsContextLength:       KindCount,
+	WeightsVocabularySize:      KindCount,
+	WeightsTensorCount:         KindCount,
+}
+
+var (
+	byName   = make(map[string]Property)
+	byLegacy = make(map[string]string)
+)
+
+func init() {
+	for _, p := range registry {
+		if !strings.HasPrefix(p.Name, Namespace) {
+			panic(fmt.Sprintf("taxonomy: %q is outside the %q namespace", p.Name, Namespace))
+		}
+		byName[p.Name] = p
+		for _, l := range p.Legacy {
+			byLegacy[l] = p.Name
+		}
+	}
+}
+
+// All returns the registered properties in documentation order.
+func All() []Property {
+	return append([]Property(nil), registry...)
+}
+
+// Lookup returns the registered property called name.
+func Lookup(name string) (Property, bool) {
+	p, ok := byName[strings.TrimSpace(name)]
+	return p, ok
+}
+
+// KindOf returns the display kind of the current or legacy property name.
+func KindOf(name string) Kind {
+	if current, ok := Canonical(name); ok {
+		return kinds[current]
+	}
+	return KindText
+}
+
+// Kinds returns the display kinds of the properties that are not text,.
+// keyed by their current and legacy names.
+func Kinds() map[string]Kind {
+	out := make(map[string]Kind, len(kinds))
+	for name, k := range kinds {
+		out[name] = k
+		for _, l := range byName[name].Legacy {
+			out[l] = k
+		}
+	}
+	return out
+}
+
+// Canonical maps a current or legacy property name to its current name. It.
+// reports false for names that are not part of the taxonomy.
+func Canonical(name string) (string, bool) {
+	name = strings.TrimSpace(name)
+	if _, ok := byName[name]; ok {
+		return name, true
+	}
+	current, ok := byLegacy[name]
+	return current, ok
+}
+
+// Set appends the property name=value to props and returns the (possibly.
+// newly allocated) list. Empty values are skipped. Set panics when name is not.
+// registered: every property the tool writes must be part of the taxonomy.
+func Set(props *[]cdx.Property, name, value string) *[]cdx.Property {
+	name = strings.TrimSpace(name)
+	if _, ok := byName[name]; !ok {
+		panic(fmt.Sprintf("taxonomy: unregistered property %q", name))
+	}
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return props
+	}
+	if props == nil {
+		props = &[]cdx.Property{}
+	}
+	*props = append(*props, cdx.Property{Name: name, Value: value})
+	return props
+}
+
+// Get returns the first non-empty value of the property name in props,.
+// accepting its legacy names as well.
+func Get(props *[]cdx.Property, name string) (string, bool) {
+	if props == nil {
+		return "", false
+	}
+	for _, p := range *props {
+		if matches(p.Name, name) && strings.TrimSpace(p.Value) != "" {
+			return strings.TrimSpace(p.Value), true
+		}
+	}
+	return "", false
+}
+
+// Has reports whether props holds a non-empty value for the property name.
+// (under its current or a legacy name).
+func Has(props *[]cdx.Property, name string) bool {
+	_, ok := Get(props, name)
+	return ok
+}
+
+// Remove drops every occurrence of the property name (current and legacy.
+// names) from props. It returns nil when no properties remain.
+func Remove(props *[]cdx.Property, name string) *[]cdx.Property {
+	if props == nil {
+		return nil
+	}
+	kept := make([]cdx.Property, 0, len(*props))
+	for _, p := range *props {
+		if !matches(p.Name, name) {
+			kept = append(kept, p)
+		}
+	}
+	if len(kept) == 0 {
+		return nil
+	}
+	*props = kept
+	return props
+}
+
+// matches reports whether the stored property name refers to the registered.
+// property name.
+func matches(stored, name string) bool {
+	stored = strings.TrimSpace(stored)
+	name = strings.TrimSpace(name)
+	if stored == name {
+		return true
+	}
+	current, ok := byLegacy[stored]
+	return ok && current == name
+}
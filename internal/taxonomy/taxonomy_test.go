@@ -0,0 +1,104 @@
+package taxonomy
+
+import (
+	"strings"
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+func TestRegistryNamesAreUnique(t *testing.T) {
+	seen := map[string]bool{}
+	for _, p := range All() {
+		if !strings.HasPrefix(p.Name, Namespace) {
+			t.Errorf("%s is outside the namespace", p.Name)
+		}
+		if p.Description == "" {
+			t.Errorf("%s has no description", p.Name)
+		}
+		for _, n := range append([]string{p.Name}, p.Legacy...) {
+			if seen[n] {
+				t.Errorf("duplicate name %s", n)
+			}
+			seen[n] = true
+		}
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	cases := map[string]string{
+		HFLastModified:             HFLastModified,
+		"huggingface:lastModified": HFLastModified,
+		" aibomgen.confidence ":    DiscoveryConfidence,
+		"contact":                  HFModelCardContact,
+		"huggingface:library":      HFPipelinePartLib,
+	}
+	for in, want := range cases {
+		if got, ok := Canonical(in); !ok || got != want {
+			t.Errorf("Canonical(%q) = %q, %v; want %q", in, got, ok, want)
+		}
+	}
+	if _, ok := Canonical("vendor:other"); ok {
+		t.Errorf("expected unknown name to be rejected")
+	}
+}
+
+func TestKindOf(t *testing.T) {
+	cases := map[string]Kind{
+		HFUsedStorage:           KindBytes,
+		"huggingface:downloads": KindCount,
+		HFLastModified:          KindTime,
+		HFLibraryName:           KindText,
+		"vendor:other":          KindText,
+	}
+	for name, want := range cases {
+		if got := KindOf(name); got != want {
+			t.Errorf("KindOf(%q) = %q, want %q", name, got, want)
+		}
+	}
+	for name := range kinds {
+		if _, ok := byName[name]; !ok {
+			t.Errorf("kind registered for unknown property %q", name)
+		}
+	}
+	if Kinds()["huggingface:usedStorage"] != KindBytes {
+		t.Errorf("Kinds() misses the legacy name of %s", HFUsedStorage)
+	}
+}
+
+func TestSetGetRemove(t *testing.T) {
+	var props *[]cdx.Property
+	if props = Set(props, HFLikes, "  "); props != nil {
+		t.Fatalf("expected empty value to be skipped")
+	}
+	props = Set(props, HFLikes, " 12 ")
+	if got, ok := Get(props, HFLikes); !ok || got != "12" {
+		t.Fatalf("Get = %q, %v", got, ok)
+	}
+
+	*props = append(*props, cdx.Property{Name: "huggingface:downloads", Value: "7"}, cdx.Property{Name: "vendor:other", Value: "x"})
+	if got, _ := Get(props, HFDownloads); got != "7" {
+		t.Fatalf("expected legacy name to be read, got %q", got)
+	}
+	if Has(props, HFPrivate) {
+		t.Fatalf("unexpected property")
+	}
+
+	props = Remove(props, HFDownloads)
+	props = Remove(props, HFLikes)
+	if props == nil || len(*props) != 1 || (*props)[0].Name != "vendor:other" {
+		t.Fatalf("Remove left %+v", props)
+	}
+	if props = Remove(props, "vendor:other"); props != nil {
+		t.Fatalf("expected nil after removing the last property")
+	}
+}
+
+func TestSetPanicsOnUnregisteredName(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected panic")
+		}
+	}()
+	Set(nil, "aibomgen.type", "scan")
+}
@@ -0,0 +1,205 @@
+// Package tracing records OpenTelemetry spans for the generation pipeline, so.
+// that a slow model generation can be traced to the Hugging Face request or.
+// build step responsible.
+//.
+// Tracing is off unless an OTLP endpoint is configured (tracing.endpoint or.
+// the standard OTEL_EXPORTER_OTLP_ENDPOINT / OTEL_EXPORTER_OTLP_TRACES_ENDPOINT.
+// variables). Spans are then exported over OTLP/HTTP; the other standard.
+// OTEL_* variables (headers, service name, resource attributes) apply too.
+package tracing
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ServiceName is the default service.name of exported spans.
+const ServiceName = "aibomgen-cli"
+
+const tracerName = "github.com/idlab-discover/aibomgen-cli"
+
+// active is set once Setup installed a tracer provider.
+var active atomic.Bool
+
+// Enabled reports whether spans should be exported: endpoint is set or the.
+// standard OTLP endpoint variables are, and OTEL_SDK_DISABLED is not true.
+func Enabled(endpoint string) bool {
+	if strings.EqualFold(strings.TrimSpace(os.Getenv("OTEL_SDK_DISABLED")), "true") {
+		return false
+	}
+	return strings.TrimSpace(endpoint) != "" ||
+		os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" ||
+		os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != ""
+}
+
+// Active reports whether Setup installed a tracer provider.
+func Active() bool {
+	return active.Load()
+}
+
+// Setup installs a tracer provider that exports spans over OTLP/HTTP to.
+// endpoint (a URL such as http://localhost:4318; empty: the OTEL_* variables).
+// The returned shutdown function flushes the remaining spans.
+func Setup(ctx context.Context, endpoint, version string) (shutdown func(context.Context) error, err error) {
+	var opts []otlptracehttp.Option
+	if endpoint = strings.TrimSpace(endpoint); endpoint != "" {
+		opts = append(opts, otlptracehttp.WithEndpointURL(endpoint))
+	}
+	exporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	// Detectors listed later take precedence, so OTEL_SERVICE_NAME and.
+	// OTEL_RESOURCE_ATTRIBUTES override the defaults.
+	res, err := resource.New(ctx,
+		resource.WithTelemetrySDK(),
+		resource.WithAttributes(
+			attribute.String("service.name", ServiceName),
+			attribute.String("service.version", version),
+		),
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	tp := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res))
+	otel.SetTracerProvider(tp)
+	active.Store(true)
+	return tp.Shutdown, nil
+}
+
+// Start starts a span under ctx.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records err, if any, on span and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Scope tracks the open spans of sequential work whose callees take no.
+// context, such as the Hugging Face fetchers. Requests sent through the client.
+// returned by Client are recorded under the innermost open span.
+type Scope struct {
+	mu    sync.Mutex
+	stack []context.Context
+}
+
+// NewScope returns a Scope whose spans are children of ctx (nil: none).
+func NewScope(ctx context.Context) *Scope {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return &Scope{stack: []context.Context{ctx}}
+}
+
+// Start starts a span under the innermost open span. The returned function.
+// records the error, if any, and ends the span; spans must end in reverse.
+// order of their start. Calls after the first are no-ops.
+func (s *Scope) Start(name string, attrs ...attribute.KeyValue) func(error) {
+	ctx, span := Start(s.Context(), name, attrs...)
+	s.mu.Lock()
+	s.stack = append(s.stack, ctx)
+	s.mu.Unlock()
+	var once sync.Once
+	return func(err error) {
+		once.Do(func() {
+			s.mu.Lock()
+			if len(s.stack) > 1 {
+				s.stack = s.stack[:len(s.stack)-1]
+			}
+			s.mu.Unlock()
+			End(span, err)
+		})
+	}
+}
+
+// Context returns the context of the innermost open span.
+func (s *Scope) Context() context.Context {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stack[len(s.stack)-1]
+}
+
+// Client returns a copy of c whose requests are recorded as client spans.
+// under the innermost open span of s. Requests whose own context carries a.
+// span are recorded under that span instead. A client already returned by.
+// the Client of another scope is moved to s.
+func (s *Scope) Client(c *http.Client) *http.Client {
+	base := c.Transport
+	if t, ok := base.(*transport); ok {
+		base = t.base
+	}
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	cp := *c
+	cp.Transport = &transport{base: base, scope: s}
+	return &cp
+}
+
+// transport records every request as a client span.
+type transport struct {
+	base  http.RoundTripper
+	scope *Scope
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	parent := req.Context()
+	if !trace.SpanContextFromContext(parent).IsValid() {
+		parent = t.scope.Context()
+	}
+	// The query is left out: it may hold search terms or signed parameters.
+	u := *req.URL
+	u.RawQuery, u.User = "", nil
+	_, span := otel.Tracer(tracerName).Start(parent, "HTTP "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", req.Method),
+			attribute.String("url.full", u.String()),
+			attribute.String("server.address", req.URL.Hostname()),
+		))
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		End(span, err)
+		return nil, err
+	}
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	if resp.StatusCode >= 400 {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+	// End the span once the body is read, so that it covers the download.
+	resp.Body = &spanBody{ReadCloser: resp.Body, span: span}
+	return resp, nil
+}
+
+// spanBody ends its span when the response body is closed.
+type spanBody struct {
+	io.ReadCloser
+	span trace.Span
+	once sync.Once
+}
+
+func (b *spanBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(func() { b.span.End() })
+	return err
+}
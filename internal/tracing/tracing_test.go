@@ -0,0 +1,155 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestScope(t *testing.T) {
+	rec := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = io.WriteString(w, "ok")
+	}))
+	defer srv.Close()
+
+	scope := NewScope(context.Background())
+	client := scope.Client(srv.Client())
+	get := func(path string) {
+		resp, err := client.Get(srv.URL + path + "?q=secret")
+		if err != nil {
+			t.Fatalf("GET %s: %v", path, err)
+		}
+		_, _ = io.ReadAll(resp.Body)
+		resp.Body.Close()
+	}
+
+	endModel := scope.Start("generate model")
+	endFetch := scope.Start("fetch model API")
+	get("/api")
+	endFetch(nil)
+	endBuild := scope.Start("build model")
+	get("/missing")
+	endBuild(errors.New("boom"))
+	endBuild(nil) // no-op
+	endModel(nil)
+
+	spans := rec.Ended()
+	if len(spans) != 5 {
+		t.Fatalf("got %d spans, want 5", len(spans))
+	}
+	byName := make(map[string]sdktrace.ReadOnlySpan)
+	for _, s := range spans {
+		byName[s.Name()] = s
+	}
+	model, fetch, build := byName["generate model"], byName["fetch model API"], byName["build model"]
+	if model == nil || fetch == nil || build == nil {
+		t.Fatalf("missing spans: %v", byName)
+	}
+	if fetch.Parent().SpanID() != model.SpanContext().SpanID() || build.Parent().SpanID() != model.SpanContext().SpanID() {
+		t.Errorf("stage spans are not children of the model span")
+	}
+	if build.Status().Code != codes.Error {
+		t.Errorf("build status = %v, want error", build.Status())
+	}
+
+	// spans[0] and [2] are the HTTP spans, ended before their stage.
+	api, missing := spans[0], spans[2]
+	if api.Name() != "HTTP GET" || api.Parent().SpanID() != fetch.SpanContext().SpanID() {
+		t.Errorf("first HTTP span %q is not a child of the fetch span", api.Name())
+	}
+	if missing.Parent().SpanID() != build.SpanContext().SpanID() || missing.Status().Code != codes.Error {
+		t.Errorf("second HTTP span: parent/status mismatch (%v)", missing.Status())
+	}
+	for _, kv := range api.Attributes() {
+		if kv.Key == "url.full" && kv.Value.AsString() != srv.URL+"/api" {
+			t.Errorf("url.full = %q, want the URL without query", kv.Value.AsString())
+		}
+	}
+}
+
+func TestSetupExports(t *testing.T) {
+	var posts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && r.URL.Path == "/v1/traces" {
+			posts.Add(1)
+		}
+	}))
+	defer srv.Close()
+	prev := otel.GetTracerProvider()
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prev)
+		active.Store(false)
+	})
+
+	if Enabled("") {
+		t.Skip("OTLP endpoint set in the environment")
+	}
+	if !Enabled(srv.URL) {
+		t.Fatalf("Enabled(%q) = false", srv.URL)
+	}
+	shutdown, err := Setup(context.Background(), srv.URL, "test")
+	if err != nil {
+		t.Fatalf("Setup: %v", err)
+	}
+	if !Active() {
+		t.Errorf("Active() = false after Setup")
+	}
+	_, span := Start(context.Background(), "aibomgen-cli test")
+	span.End()
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+	if posts.Load() == 0 {
+		t.Errorf("no spans exported")
+	}
+}
+
+func TestScopeClientMovesToScope(t *testing.T) {
+	rec := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "ok")
+	}))
+	defer srv.Close()
+
+	model := NewScope(context.Background())
+	endModel := model.Start("generate model")
+	dataset := NewScope(model.Context())
+	endDataset := dataset.Start("generate dataset")
+	resp, err := dataset.Client(model.Client(srv.Client())).Get(srv.URL)
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	resp.Body.Close()
+	endDataset(nil)
+	endModel(nil)
+
+	spans := rec.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("got %d spans, want 3 (one per request)", len(spans))
+	}
+	if spans[0].Name() != "HTTP GET" || spans[0].Parent().SpanID() != spans[1].SpanContext().SpanID() {
+		t.Errorf("HTTP span is not a child of the dataset span")
+	}
+}
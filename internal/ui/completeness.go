@@ -3,6 +3,7 @@ package ui
 import (
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 
 	"github.com/idlab-discover/aibomgen-cli/internal/metadata"
@@ -53,6 +54,13 @@ func (c *CompletenessUI) PrintReport(result completeness.Result) {
 		output.WriteString("\n")
 	}
 
+	// Glossary Section (--explain-fields).
+	if glossary := renderGlossary(output.String()); glossary != "" {
+		output.WriteString("\n")
+		output.WriteString(glossary)
+		output.WriteString("\n")
+	}
+
 	// Wrap in box.
 	boxed := SuccessBox.Render(output.String())
 	fmt.Fprintln(c.writer, boxed)
@@ -70,14 +78,43 @@ func (c *CompletenessUI) renderModelScore(result completeness.Result) string {
 		sb.WriteString(FormatKeyValue("ID", Highlight.Render(result.ModelID)))
 		sb.WriteString("\n")
 	}
+	if result.Profile != "" {
+		sb.WriteString(FormatKeyValue("Profile", result.Profile))
+		sb.WriteString("\n")
+	}
 
 	sb.WriteString(FormatKeyValue("Score", c.renderProgressBar(result.Score, 40)+" "+c.renderScorePercentage(result.Score)))
 	sb.WriteString("\n")
 	sb.WriteString(Dim.Render(fmt.Sprintf("(%d/%d fields present)", result.Passed, result.Total)))
 
+	for _, name := range sortedCategories(result.Categories) {
+		cat := result.Categories[name]
+		sb.WriteString("\n")
+		sb.WriteString(FormatKeyValue(categoryLabel(name), c.renderProgressBar(cat.Score, 20)+" "+c.renderScorePercentage(cat.Score)+" "+Dim.Render(fmt.Sprintf("(%d/%d)", cat.Passed, cat.Total))))
+	}
+
 	return sb.String()
 }
 
+// sortedCategories returns the category names of a completeness result in.
+// order.
+func sortedCategories(categories map[string]completeness.CategoryResult) []string {
+	names := make([]string, 0, len(categories))
+	for name := range categories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// categoryLabel capitalizes a category name for display.
+func categoryLabel(name string) string {
+	if name == "" {
+		return name
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
 // renderMissingFields creates the missing fields section with expandable groups.
 func (c *CompletenessUI) renderMissingFields(result completeness.Result) string {
 	var sb strings.Builder
@@ -91,6 +128,7 @@ func (c *CompletenessUI) renderMissingFields(result completeness.Result) string
 			sb.WriteString(GetCrossMark())
 			sb.WriteString(" ")
 			sb.WriteString(field.String())
+			sb.WriteString(fieldHint(field.String()))
 			sb.WriteString("\n")
 		}
 	}
@@ -107,6 +145,7 @@ func (c *CompletenessUI) renderMissingFields(result completeness.Result) string
 			sb.WriteString(GetWarnMark())
 			sb.WriteString(" ")
 			sb.WriteString(Dim.Render(field.String()))
+			sb.WriteString(fieldHint(field.String()))
 			sb.WriteString("\n")
 		}
 	}
@@ -142,6 +181,7 @@ func (c *CompletenessUI) renderDatasetScores(datasets map[string]completeness.Da
 				sb.WriteString(GetCrossMark())
 				sb.WriteString(" ")
 				sb.WriteString(field.String())
+				sb.WriteString(fieldHint(field.String()))
 				sb.WriteString("\n")
 			}
 		}
@@ -158,6 +198,7 @@ func (c *CompletenessUI) renderDatasetScores(datasets map[string]completeness.Da
 				sb.WriteString(GetWarnMark())
 				sb.WriteString(" ")
 				sb.WriteString(Dim.Render(field.String()))
+				sb.WriteString(fieldHint(field.String()))
 				sb.WriteString("\n")
 			}
 		}
@@ -185,7 +226,7 @@ func (c *CompletenessUI) renderProgressBar(score float64, width int) string {
 		style = lipgloss.NewStyle().Foreground(ColorError)
 	}
 
-	return style.Render(bar)
+	return StyledText(bar, style)
 }
 
 // renderScorePercentage formats the score as a percentage.
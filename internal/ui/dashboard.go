@@ -0,0 +1,394 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/idlab-discover/aibomgen-cli/internal/apperr"
+	"github.com/idlab-discover/aibomgen-cli/internal/metadata"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/completeness"
+)
+
+// DashboardBOM is an AIBOM listed by the review dashboard.
+type DashboardBOM struct {
+	Path   string
+	Result completeness.Result
+	Err    error // set when the file could not be read as an AIBOM
+}
+
+// DashboardSelection names a component of an AIBOM: its model, or one of its.
+// datasets.
+type DashboardSelection struct {
+	Path    string
+	Dataset string // dataset component name; "" selects the model
+}
+
+// Dashboard levels: the AIBOMs, the components of one AIBOM, and the missing.
+// fields of one component.
+const (
+	dashboardBOMs = iota
+	dashboardComponents
+	dashboardFields
+)
+
+// dashboardComponent is the model or a dataset of an AIBOM with its score.
+type dashboardComponent struct {
+	name     string
+	dataset  bool
+	score    float64
+	passed   int
+	total    int
+	required []string
+	optional []string
+}
+
+// components lists the model of b and then its datasets by name.
+func (b DashboardBOM) components() []dashboardComponent {
+	if b.Err != nil {
+		return nil
+	}
+	r := b.Result
+	model := dashboardComponent{name: r.ModelID, score: r.Score, passed: r.Passed, total: r.Total}
+	if model.name == "" {
+		model.name = "(model)"
+	}
+	for _, k := range r.MissingRequired {
+		model.required = append(model.required, string(k))
+	}
+	for _, k := range r.MissingOptional {
+		model.optional = append(model.optional, string(k))
+	}
+	out := []dashboardComponent{model}
+
+	names := make([]string, 0, len(r.DatasetResults))
+	for name := range r.DatasetResults {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		ds := r.DatasetResults[name]
+		c := dashboardComponent{name: name, dataset: true, score: ds.Score, passed: ds.Passed, total: ds.Total}
+		for _, k := range ds.MissingRequired {
+			c.required = append(c.required, string(k))
+		}
+		for _, k := range ds.MissingOptional {
+			c.optional = append(c.optional, string(k))
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+// dashboardModel is the Bubble Tea model of the review dashboard.
+type dashboardModel struct {
+	boms   []DashboardBOM
+	level  int
+	cursor [3]int
+
+	selection *DashboardSelection
+	width     int
+	height    int
+}
+
+// newDashboard opens the dashboard on the components of start when it names.
+// one of the AIBOMs, else on the AIBOM list.
+func newDashboard(boms []DashboardBOM, start DashboardSelection) *dashboardModel {
+	m := &dashboardModel{boms: boms, width: 80, height: 24}
+	for i, b := range boms {
+		if b.Path != start.Path || start.Path == "" {
+			continue
+		}
+		m.cursor[dashboardBOMs] = i
+		m.level = dashboardComponents
+		for j, c := range b.components() {
+			if c.dataset == (start.Dataset != "") && (!c.dataset || c.name == start.Dataset) {
+				m.cursor[dashboardComponents] = j
+				break
+			}
+		}
+	}
+	return m
+}
+
+// Init initializes the model.
+func (m *dashboardModel) Init() tea.Cmd {
+	return nil
+}
+
+// rows returns the number of entries of the current level.
+func (m *dashboardModel) rows() int {
+	switch m.level {
+	case dashboardComponents:
+		return len(m.currentBOM().components())
+	case dashboardFields:
+		c := m.currentComponent()
+		return len(c.required) + len(c.optional)
+	}
+	return len(m.boms)
+}
+
+func (m *dashboardModel) currentBOM() DashboardBOM {
+	return m.boms[m.cursor[dashboardBOMs]]
+}
+
+func (m *dashboardModel) currentComponent() dashboardComponent {
+	return m.currentBOM().components()[m.cursor[dashboardComponents]]
+}
+
+// Update handles messages.
+func (m *dashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		cur := &m.cursor[m.level]
+		switch msg.String() {
+		case "ctrl+c", "q":
+			return m, tea.Quit
+		case "up", "k":
+			if *cur > 0 {
+				*cur--
+			}
+		case "down", "j":
+			if *cur < m.rows()-1 {
+				*cur++
+			}
+		case "home", "g":
+			*cur = 0
+		case "end", "G":
+			*cur = max(m.rows()-1, 0)
+		case "enter", "right", "l":
+			if m.level < dashboardFields && m.rows() > 0 {
+				if m.level == dashboardBOMs && m.currentBOM().Err != nil {
+					return m, nil
+				}
+				m.level++
+				m.cursor[m.level] = 0
+			}
+		case "esc", "left", "h", "backspace":
+			if m.level > dashboardBOMs {
+				m.level--
+			}
+		case "e":
+			// Enrich the selected component; on the AIBOM list, its model.
+			if m.rows() == 0 || m.currentBOM().Err != nil {
+				return m, nil
+			}
+			sel := DashboardSelection{Path: m.currentBOM().Path}
+			if m.level > dashboardBOMs {
+				if c := m.currentComponent(); c.dataset {
+					sel.Dataset = c.name
+				}
+			}
+			m.selection = &sel
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+// View renders the model.
+func (m *dashboardModel) View() tea.View {
+	var b strings.Builder
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ColorPrimary).
+		Padding(1, 0, 0, 0)
+	b.WriteString(titleStyle.Render("AIBOM Review Dashboard"))
+	b.WriteString("\n")
+	b.WriteString(Dim.Render(m.breadcrumb()))
+	b.WriteString("\n\n")
+
+	var lines []string
+	var detail string
+	switch m.level {
+	case dashboardBOMs:
+		lines = m.bomLines()
+	case dashboardComponents:
+		lines = m.componentLines()
+	case dashboardFields:
+		lines, detail = m.fieldLines()
+	}
+	b.WriteString(m.window(lines))
+
+	if detail != "" {
+		b.WriteString("\n\n")
+		b.WriteString(detail)
+	}
+
+	b.WriteString("\n\n")
+	helpStyle := lipgloss.NewStyle().Foreground(ColorTextDim)
+	switch m.level {
+	case dashboardBOMs:
+		b.WriteString(helpStyle.Render("↑/↓: navigate · enter: components · e: enrich model · q: quit"))
+	case dashboardComponents:
+		b.WriteString(helpStyle.Render("↑/↓: navigate · enter: missing fields · e: enrich component · esc: back · q: quit"))
+	default:
+		b.WriteString(helpStyle.Render("↑/↓: navigate · e: enrich component · esc: back · q: quit"))
+	}
+
+	v := tea.NewView(b.String())
+	v.AltScreen = true
+	return v
+}
+
+// breadcrumb names the AIBOM and component being looked at.
+func (m *dashboardModel) breadcrumb() string {
+	parts := []string{fmt.Sprintf("%d AIBOM(s)", len(m.boms))}
+	if m.level >= dashboardComponents {
+		parts = append(parts, filepath.Base(m.currentBOM().Path))
+	}
+	if m.level >= dashboardFields {
+		parts = append(parts, m.currentComponent().name)
+	}
+	return strings.Join(parts, " › ")
+}
+
+// window cuts lines to the rows that fit the terminal, keeping the cursor.
+// in view.
+func (m *dashboardModel) window(lines []string) string {
+	visible := max(m.height-12, 5)
+	if len(lines) <= visible {
+		return strings.Join(lines, "\n")
+	}
+	start := 0
+	if cur := m.cursor[m.level]; cur >= visible {
+		start = cur - visible + 1
+	}
+	end := min(start+visible, len(lines))
+	out := strings.Join(lines[start:end], "\n")
+	return out + "\n" + Dim.Render(fmt.Sprintf("(%d-%d of %d)", start+1, end, len(lines)))
+}
+
+// row renders an entry, highlighted when it is under the cursor.
+func (m *dashboardModel) row(i int, text string) string {
+	if i == m.cursor[m.level] {
+		return Highlight.Render("› ") + text
+	}
+	return "  " + text
+}
+
+func (m *dashboardModel) bomLines() []string {
+	lines := make([]string, 0, len(m.boms))
+	for i, b := range m.boms {
+		name := filepath.Base(b.Path)
+		if b.Err != nil {
+			lines = append(lines, m.row(i, Dim.Render(name)+" "+Error.Render(b.Err.Error())))
+			continue
+		}
+		r := b.Result
+		missing := len(r.MissingRequired) + len(r.MissingOptional)
+		text := fmt.Sprintf("%s %s %s %s",
+			dashboardScore(r.Score),
+			name,
+			Dim.Render(fmt.Sprintf("%s · %d dataset(s) ·", r.ModelID, len(r.DatasetResults))),
+			missingLabel(len(r.MissingRequired), missing))
+		lines = append(lines, m.row(i, text))
+	}
+	return lines
+}
+
+func (m *dashboardModel) componentLines() []string {
+	comps := m.currentBOM().components()
+	lines := make([]string, 0, len(comps))
+	for i, c := range comps {
+		kind := "model"
+		if c.dataset {
+			kind = "dataset"
+		}
+		text := fmt.Sprintf("%s %s %s %s",
+			dashboardScore(c.score),
+			c.name,
+			Dim.Render(fmt.Sprintf("%s · %d/%d fields ·", kind, c.passed, c.total)),
+			missingLabel(len(c.required), len(c.required)+len(c.optional)))
+		lines = append(lines, m.row(i, text))
+	}
+	return lines
+}
+
+// fieldLines lists the missing fields of the current component, required.
+// ones first, and explains the field under the cursor.
+func (m *dashboardModel) fieldLines() ([]string, string) {
+	c := m.currentComponent()
+	if len(c.required)+len(c.optional) == 0 {
+		return []string{Success.Render("All fields present")}, ""
+	}
+	var lines []string
+	var detail string
+	for i, key := range append(append([]string{}, c.required...), c.optional...) {
+		mark := GetWarnMark()
+		if i < len(c.required) {
+			mark = GetCrossMark()
+		}
+		lines = append(lines, m.row(i, mark+" "+key))
+		if i == m.cursor[dashboardFields] {
+			detail = fieldDetail(key)
+		}
+	}
+	return lines, detail
+}
+
+// fieldDetail explains a missing field: where it lives, what it holds, an.
+// example value and its CycloneDX documentation.
+func fieldDetail(key string) string {
+	var parts []string
+	if e, ok := metadata.ExplainPath(key); ok {
+		parts = append(parts, Highlight.Render(e.Location), e.Description)
+	}
+	if help, ok := metadata.LookupHelp(key); ok {
+		if help.Example != "" {
+			parts = append(parts, Dim.Render("Example: ")+help.Example)
+		}
+		if url := help.DocsURL(); url != "" {
+			parts = append(parts, Muted.Render(url))
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// dashboardScore renders a completeness score, colored like the reports.
+func dashboardScore(score float64) string {
+	s := fmt.Sprintf("%5.1f%%", score*100)
+	switch {
+	case score >= 0.8:
+		return Success.Render(s)
+	case score >= 0.5:
+		return Warning.Render(s)
+	}
+	return Error.Render(s)
+}
+
+// missingLabel summarizes the missing fields of a component.
+func missingLabel(required, missing int) string {
+	switch {
+	case missing == 0:
+		return Success.Render("complete")
+	case required > 0:
+		return Error.Render(fmt.Sprintf("%d missing (%d required)", missing, required))
+	}
+	return Warning.Render(fmt.Sprintf("%d missing", missing))
+}
+
+// RunDashboard shows boms until the user quits, which returns.
+// apperr.ErrCancelled, or picks a component to enrich, which is returned. The.
+// dashboard opens on the components of start when it names one of boms.
+func RunDashboard(boms []DashboardBOM, start DashboardSelection) (DashboardSelection, error) {
+	p := tea.NewProgram(newDashboard(boms, start))
+	m, err := p.Run()
+	if err != nil {
+		return DashboardSelection{}, err
+	}
+	model := m.(*dashboardModel)
+	if model.selection == nil {
+		return DashboardSelection{}, apperr.ErrCancelled
+	}
+	return *model.selection, nil
+}
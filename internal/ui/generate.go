@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"charm.land/lipgloss/v2"
+
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/scanner"
 )
 
 // GenerateUI provides a rich UI for the generate command.
@@ -201,6 +203,20 @@ func (g *GenerateUI) PrintSummary(filesWritten int, outputDir, format string) {
 	fmt.Fprintln(g.writer, SuccessBox.Render(summary.String()))
 }
 
+// PrintParameterized lists model references built from templates (e.g.
+// f"org/{name}") that could not be resolved to a model and have no BOM.
+func (g *GenerateUI) PrintParameterized(refs []scanner.Discovery) {
+	if g.quiet || len(refs) == 0 {
+		return
+	}
+
+	fmt.Fprintln(g.writer)
+	fmt.Fprintf(g.writer, "%s %s\n", GetWarnMark(), Warning.Render(fmt.Sprintf("%d parameterized model reference(s) not resolved:", len(refs))))
+	for _, r := range refs {
+		fmt.Fprintf(g.writer, "    %s %s\n", Highlight.Render(r.ID), Dim.Render(fmt.Sprintf("%s (static prefix %q)", r.Path, r.Name)))
+	}
+}
+
 // PrintNoBOMsWritten prints a message when no BOMs were written.
 func (g *GenerateUI) PrintNoBOMsWritten() {
 	if g.quiet {
@@ -261,6 +277,6 @@ func PrintBanner(w io.Writer) {
 | $$  | $$ /$$$$$$| $$$$$$$/|  $$$$$$/| $$ \/  | $$|  $$$$$$/|  $$$$$$$| $$  | $$        |  $$$$$$$| $$| $$
 |__/  |__/|______/|_______/  \______/ |__/     |__/ \______/  \_______/|__/  |__/         \_______/|__/|__/
 `
-	styled := lipgloss.NewStyle().Foreground(ColorSuccess).Render(banner)
+	styled := StyledText(banner, lipgloss.NewStyle().Foreground(ColorSuccess))
 	fmt.Fprintln(w, styled)
 }
@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"regexp"
+	"strings"
+	"sync/atomic"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/idlab-discover/aibomgen-cli/internal/metadata"
+)
+
+// explainFields makes the reports expand CycloneDX field paths into.
+// plain-language descriptions and end with a glossary of the abbreviations.
+// they use. It is set once per run by SetExplainFields (--explain-fields).
+var explainFields atomic.Bool
+
+// fieldPathRe matches the CycloneDX field paths of report messages: registry.
+// keys (BOM.metadata.component.name) and schema paths (metadata.component.type,.
+// components[0].type).
+var fieldPathRe = regexp.MustCompile(`\b(?:BOM\.|metadata\.|components\[)[\w:\[\]\-]*(?:\.[\w:\[\]\-]+)*`)
+
+// SetExplainFields turns the field explanations of the reports on or off.
+func SetExplainFields(v bool) {
+	explainFields.Store(v)
+}
+
+// ExplainFields reports whether the reports explain field paths.
+func ExplainFields() bool {
+	return explainFields.Load()
+}
+
+// fieldHint explains the field a report line names, indented below it, or.
+// returns "" when explanations are off or the path is not in the glossary.
+func fieldHint(path string) string {
+	if !ExplainFields() {
+		return ""
+	}
+	e, ok := metadata.ExplainPath(path)
+	if !ok {
+		return ""
+	}
+	return "\n      " + Muted.Render(e.String())
+}
+
+// messageHints explains the field paths a report message mentions.
+func messageHints(msg string) string {
+	if !ExplainFields() {
+		return ""
+	}
+	var sb strings.Builder
+	seen := map[string]bool{}
+	for _, path := range fieldPathRe.FindAllString(msg, -1) {
+		path = strings.TrimRight(path, ":")
+		if seen[path] {
+			continue
+		}
+		seen[path] = true
+		sb.WriteString(fieldHint(path))
+	}
+	return sb.String()
+}
+
+// renderGlossary lists the abbreviations a report uses, or returns "" when.
+// explanations are off or it uses none.
+func renderGlossary(report string) string {
+	if !ExplainFields() {
+		return ""
+	}
+	terms := metadata.AbbreviationsIn(ansi.Strip(report))
+	if len(terms) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString(SectionHeader.Render("Glossary"))
+	for _, t := range terms {
+		sb.WriteString("\n")
+		line := t.Label
+		if t.Label != t.Term {
+			line = t.Term + " (" + t.Label + ")"
+		}
+		sb.WriteString(FormatKeyValue(line, Dim.Render(t.Description)))
+	}
+	return sb.String()
+}
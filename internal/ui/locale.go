@@ -0,0 +1,22 @@
+package ui
+
+import (
+	"sync/atomic"
+
+	"github.com/idlab-discover/aibomgen-cli/internal/locale"
+)
+
+// reportLocale formats the numbers and dates of reports. It is set once per.
+// run by SetLocale (--locale); the zero Locale prints raw values.
+var reportLocale atomic.Value
+
+// SetLocale sets the locale reports format numbers and dates for.
+func SetLocale(l locale.Locale) {
+	reportLocale.Store(l)
+}
+
+// Locale returns the locale reports format numbers and dates for.
+func Locale() locale.Locale {
+	l, _ := reportLocale.Load().(locale.Locale)
+	return l
+}
@@ -0,0 +1,48 @@
+package ui
+
+import (
+	"os"
+	"sync/atomic"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/charmbracelet/x/term"
+)
+
+// plain disables colors and animations for the whole process. It is set once.
+// per run by Configure and read by every renderer in this package.
+var plain atomic.Bool
+
+// Configure selects the rendering mode for the current run. Plain mode is used.
+// when forced (--no-ui) or when DetectPlain reports that out cannot show a.
+// styled, animated UI.
+func Configure(forcePlain bool, out *os.File) {
+	plain.Store(forcePlain || DetectPlain(out))
+}
+
+// SetPlain forces plain rendering on or off.
+func SetPlain(v bool) {
+	plain.Store(v)
+}
+
+// Plain reports whether output is rendered without colors and animations.
+func Plain() bool {
+	return plain.Load()
+}
+
+// DetectPlain reports whether plain output should be used for out: when.
+// NO_COLOR is set (https://no-color.org), TERM is "dumb", or out is not a.
+// terminal (CI logs, pipes, redirects).
+func DetectPlain(out *os.File) bool {
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return true
+	}
+	return out == nil || !term.IsTerminal(out.Fd())
+}
+
+// plainText strips ANSI escape sequences from s when plain mode is active.
+func plainText(s string) string {
+	if !Plain() {
+		return s
+	}
+	return ansi.Strip(s)
+}
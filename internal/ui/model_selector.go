@@ -365,8 +365,12 @@ func RunModelSelector(config ModelSelectorConfig) ([]string, error) {
 	return model.GetSelectedModels(), nil
 }
 
-// formatNumber formats a number with commas for thousands.
+// formatNumber formats a number with the digit grouping of the report.
+// locale, or with commas for thousands when none is set.
 func formatNumber(n int) string {
+	if l := Locale(); !l.IsRaw() {
+		return l.Int(int64(n))
+	}
 	if n < 1000 {
 		return fmt.Sprintf("%d", n)
 	}
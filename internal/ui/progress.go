@@ -230,6 +230,8 @@ type ProgressTracker struct {
 	mu         sync.Mutex
 	running    bool
 	useSpinner bool
+	// plain logs steps to stdout instead of running a Bubble Tea program.
+	plain bool
 }
 
 // NewProgressTracker creates a new progress tracker.
@@ -249,6 +251,12 @@ func (pt *ProgressTracker) Start() {
 		return
 	}
 
+	if Plain() {
+		pt.plain = true
+		pt.running = true
+		return
+	}
+
 	opts := []ProgressOption{
 		WithTitle(""),
 	}
@@ -273,6 +281,10 @@ func (pt *ProgressTracker) UpdateStep(index int, status StepStatus, message stri
 	pt.mu.Lock()
 	defer pt.mu.Unlock()
 
+	if pt.plain && pt.running {
+		pt.logStep(index, status, message)
+		return
+	}
 	if pt.program == nil || !pt.running {
 		return
 	}
@@ -289,6 +301,10 @@ func (pt *ProgressTracker) SetMessage(message string) {
 	pt.mu.Lock()
 	defer pt.mu.Unlock()
 
+	if pt.plain && pt.running {
+		fmt.Println(message)
+		return
+	}
 	if pt.program == nil || !pt.running {
 		return
 	}
@@ -304,6 +320,13 @@ func (pt *ProgressTracker) Complete(err error) {
 	pt.mu.Lock()
 	defer pt.mu.Unlock()
 
+	if pt.plain && pt.running {
+		pt.running = false
+		if err != nil {
+			fmt.Println(GetCrossMark() + " " + err.Error())
+		}
+		return
+	}
 	if pt.program == nil || !pt.running {
 		return
 	}
@@ -320,6 +343,10 @@ func (pt *ProgressTracker) Stop() {
 	pt.mu.Lock()
 	defer pt.mu.Unlock()
 
+	if pt.plain {
+		pt.running = false
+		return
+	}
 	if pt.program == nil || !pt.running {
 		return
 	}
@@ -328,3 +355,29 @@ func (pt *ProgressTracker) Stop() {
 	pt.running = false
 	time.Sleep(50 * time.Millisecond)
 }
+
+// logStep prints a single line for a step update in plain mode.
+func (pt *ProgressTracker) logStep(index int, status StepStatus, message string) {
+	name := ""
+	if index >= 0 && index < len(pt.steps) {
+		name = pt.steps[index]
+	}
+	var icon string
+	switch status {
+	case StatusRunning:
+		icon = "▸"
+	case StatusComplete:
+		icon = GetCheckMark()
+	case StatusFailed:
+		icon = GetCrossMark()
+	case StatusSkipped:
+		icon = "⊘"
+	default:
+		icon = "○"
+	}
+	line := icon + " " + name
+	if message != "" {
+		line += " " + message
+	}
+	fmt.Println(line)
+}
@@ -29,8 +29,12 @@ type styleWrapper struct {
 	style lipgloss.Style
 }
 
-// Render renders the string with the style.
+// Render renders the string with the style. In plain mode the string is.
+// returned unstyled.
 func (s styleWrapper) Render(str string) string {
+	if Plain() {
+		return str
+	}
 	return s.style.Render(str)
 }
 
@@ -92,7 +96,7 @@ type boxWrapper struct {
 }
 
 func (b boxWrapper) Render(str string) string {
-	return b.style.Render(str)
+	return plainText(b.style.Render(str))
 }
 
 var (
@@ -172,6 +176,9 @@ var (
 
 // StyledText applies a lipgloss style to a string.
 func StyledText(s string, style lipgloss.Style) string {
+	if Plain() {
+		return s
+	}
 	return style.Render(s)
 }
 
@@ -5,6 +5,7 @@ import (
 	"io"
 	"strings"
 
+	"github.com/idlab-discover/aibomgen-cli/internal/metadata"
 	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/validator"
 )
 
@@ -59,6 +60,12 @@ func (v *ValidationUI) PrintReport(report validator.ValidationResult) {
 		output.WriteString(v.renderDatasetValidation(report.DatasetResults))
 	}
 
+	// Glossary Section (--explain-fields).
+	if glossary := renderGlossary(output.String()); glossary != "" {
+		output.WriteString("\n\n")
+		output.WriteString(glossary)
+	}
+
 	// Wrap in appropriate box based on validation status.
 	var boxed string
 	if report.Valid {
@@ -110,6 +117,7 @@ func (v *ValidationUI) renderErrors(errors []string) string {
 		sb.WriteString(GetCrossMark())
 		sb.WriteString(" ")
 		sb.WriteString(err)
+		sb.WriteString(issueHint(err))
 		sb.WriteString("\n")
 	}
 
@@ -127,12 +135,39 @@ func (v *ValidationUI) renderWarnings(warnings []string) string {
 		sb.WriteString(GetWarnMark())
 		sb.WriteString(" ")
 		sb.WriteString(Dim.Render(warn))
+		sb.WriteString(issueHint(warn))
 		sb.WriteString("\n")
 	}
 
 	return strings.TrimRight(sb.String(), "\n")
 }
 
+// issueHint explains the field a "... field missing: <key>" message refers to,.
+// with a link to its CycloneDX documentation. With --explain-fields the hint.
+// also says where the field lives, and other messages get an explanation of.
+// the field paths they mention.
+func issueHint(msg string) string {
+	i := strings.LastIndex(msg, ": ")
+	if i < 0 || !strings.Contains(msg[:i], "field missing") {
+		return messageHints(msg)
+	}
+	help, ok := metadata.LookupHelp(msg[i+2:])
+	if !ok {
+		return messageHints(msg)
+	}
+	hint := help.Description
+	if e, ok := metadata.ExplainPath(msg[i+2:]); ok && ExplainFields() {
+		hint = e.String()
+	}
+	if help.Example != "" {
+		hint += " Example: " + help.Example
+	}
+	if url := help.DocsURL(); url != "" {
+		hint += " (" + url + ")"
+	}
+	return "\n      " + Muted.Render(hint)
+}
+
 // renderDatasetValidation creates the dataset validation section.
 func (v *ValidationUI) renderDatasetValidation(datasets map[string]validator.DatasetValidationResult) string {
 	var sb strings.Builder
@@ -170,6 +205,7 @@ func (v *ValidationUI) renderDatasetValidation(datasets map[string]validator.Dat
 				sb.WriteString(GetCrossMark())
 				sb.WriteString(" ")
 				sb.WriteString(err)
+				sb.WriteString(issueHint(err))
 				sb.WriteString("\n")
 			}
 		}
@@ -188,6 +224,7 @@ func (v *ValidationUI) renderDatasetValidation(datasets map[string]validator.Dat
 				sb.WriteString(GetWarnMark())
 				sb.WriteString(" ")
 				sb.WriteString(Dim.Render(warn))
+				sb.WriteString(issueHint(warn))
 				sb.WriteString("\n")
 			}
 		}
@@ -43,6 +43,9 @@ type Workflow struct {
 	lastRender  string
 	startTime   time.Time
 	currentTask int
+	// plain logs one line per task state change instead of redrawing an.
+	// animated task list (non-TTY, NO_COLOR or --no-ui).
+	plain bool
 }
 
 // NewWorkflow creates a new workflow tracker.
@@ -52,7 +55,8 @@ func NewWorkflow(w io.Writer, title string) *Workflow {
 		title:       title,
 		tasks:       make([]*Task, 0),
 		stopChan:    make(chan struct{}),
-		showSpinner: true,
+		showSpinner: !Plain(),
+		plain:       Plain(),
 	}
 }
 
@@ -78,6 +82,7 @@ func (wf *Workflow) StartTask(idx int, message string) {
 		wf.tasks[idx].Status = TaskRunning
 		wf.tasks[idx].Message = message
 		wf.currentTask = idx
+		wf.logTask(wf.tasks[idx])
 	}
 }
 
@@ -89,6 +94,7 @@ func (wf *Workflow) CompleteTask(idx int, details string) {
 	if idx >= 0 && idx < len(wf.tasks) {
 		wf.tasks[idx].Status = TaskDone
 		wf.tasks[idx].Details = details
+		wf.logTask(wf.tasks[idx])
 	}
 }
 
@@ -100,6 +106,7 @@ func (wf *Workflow) FailTask(idx int, errMsg string) {
 	if idx >= 0 && idx < len(wf.tasks) {
 		wf.tasks[idx].Status = TaskFailed
 		wf.tasks[idx].Message = errMsg
+		wf.logTask(wf.tasks[idx])
 	}
 }
 
@@ -111,6 +118,7 @@ func (wf *Workflow) SkipTask(idx int, reason string) {
 	if idx >= 0 && idx < len(wf.tasks) {
 		wf.tasks[idx].Status = TaskSkipped
 		wf.tasks[idx].Message = reason
+		wf.logTask(wf.tasks[idx])
 	}
 }
 
@@ -135,6 +143,11 @@ func (wf *Workflow) Start() {
 	wf.startTime = time.Now()
 	wf.mu.Unlock()
 
+	// Plain mode logs task changes as they happen; there is nothing to animate.
+	if wf.plain {
+		return
+	}
+
 	// Start spinner animation.
 	go func() {
 		ticker := time.NewTicker(80 * time.Millisecond)
@@ -165,7 +178,26 @@ func (wf *Workflow) Stop() {
 	wf.mu.Unlock()
 
 	close(wf.stopChan)
-	wf.renderFinal()
+	if !wf.plain {
+		wf.renderFinal()
+	}
+}
+
+// logTask writes a single line for a task state change in plain mode. The.
+// caller must hold wf.mu.
+func (wf *Workflow) logTask(task *Task) {
+	if !wf.plain || !wf.running {
+		return
+	}
+	if task.Status == TaskRunning {
+		line := "▸ " + task.Name
+		if task.Message != "" {
+			line += " " + task.Message
+		}
+		fmt.Fprintln(wf.writer, line)
+		return
+	}
+	fmt.Fprintln(wf.writer, wf.renderTaskFinal(task))
 }
 
 // render displays the current state (during animation).
@@ -322,6 +354,12 @@ func (s *SimpleSpinner) Start() {
 	s.running = true
 	s.mu.Unlock()
 
+	if Plain() {
+		fmt.Fprintln(s.writer, "▸ "+s.message)
+		close(s.doneChan)
+		return
+	}
+
 	go func() {
 		ticker := time.NewTicker(80 * time.Millisecond)
 		defer ticker.Stop()
@@ -360,7 +398,9 @@ func (s *SimpleSpinner) Stop(success bool, finalMessage string) {
 	<-s.doneChan // Wait for goroutine to finish
 
 	// Clear the spinner line and print final result.
-	fmt.Fprint(s.writer, "\r\033[K")
+	if !Plain() {
+		fmt.Fprint(s.writer, "\r\033[K")
+	}
 	if success {
 		fmt.Fprintf(s.writer, "%s %s\n", GetCheckMark(), finalMessage)
 	} else {
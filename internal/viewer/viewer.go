@@ -0,0 +1,159 @@
+// Package viewer renders one or more AIBOMs as a self-contained HTML page, so.
+// stakeholders without the CLI can inspect a BOM by opening a single file.
+//.
+// The page embeds the BOMs as JSON together with their completeness results.
+// and the script and styles that render them: an overview of the main.
+// component, the completeness scores, the model cards and a searchable table.
+// of all components. It loads nothing from the network. BOM content is only.
+// ever inserted into the page as text, never as markup.
+package viewer
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/internal/locale"
+	"github.com/idlab-discover/aibomgen-cli/internal/taxonomy"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/completeness"
+)
+
+var (
+	//go:embed viewer.html
+	pageHTML string
+	//go:embed viewer.css
+	pageCSS string
+	//go:embed viewer.js
+	pageJS string
+
+	page = template.Must(template.New("viewer").Parse(pageHTML))
+)
+
+// Input is a BOM to show, with the name it is listed under (e.g. its file.
+// name).
+type Input struct {
+	Name string
+	BOM  *cdx.BOM
+}
+
+// Options configures Render.
+type Options struct {
+	// Title is the page title (default: "AIBOM viewer").
+	Title string
+	// Profile is the weights profile the completeness scores are computed.
+	// with (nil: the registry defaults).
+	Profile *completeness.Profile
+	// Locale formats the scores, timestamps and numeric properties of the.
+	// page (zero: values as written in the BOM).
+	Locale locale.Locale
+}
+
+// Document is the JSON embedded in the page.
+type Document struct {
+	Title string    `json:"title"`
+	BOMs  []BOMData `json:"boms"`
+	// Locale is the BCP 47 tag the page formats numbers and dates for, and.
+	// PropertyKinds tells which properties hold counts, sizes or times. Both.
+	// are empty without a locale.
+	Locale        string                   `json:"locale,omitempty"`
+	PropertyKinds map[string]taxonomy.Kind `json:"propertyKinds,omitempty"`
+}
+
+// BOMData is one BOM of the page.
+type BOMData struct {
+	Name         string           `json:"name"`
+	BOM          *cdx.BOM         `json:"bom"`
+	Completeness CompletenessData `json:"completeness"`
+}
+
+// CompletenessData is the completeness result of a BOM.
+type CompletenessData struct {
+	Score           float64       `json:"score"`
+	Passed          int           `json:"passed"`
+	Total           int           `json:"total"`
+	MissingRequired []string      `json:"missingRequired"`
+	MissingOptional []string      `json:"missingOptional"`
+	Profile         string        `json:"profile,omitempty"`
+	Datasets        []DatasetData `json:"datasets"`
+}
+
+// DatasetData is the completeness result of a dataset component.
+type DatasetData struct {
+	Name            string   `json:"name"`
+	Score           float64  `json:"score"`
+	Passed          int      `json:"passed"`
+	Total           int      `json:"total"`
+	MissingRequired []string `json:"missingRequired"`
+	MissingOptional []string `json:"missingOptional"`
+}
+
+// NewDocument scores the BOMs of inputs and returns the page data.
+func NewDocument(inputs []Input, opts Options) Document {
+	doc := Document{Title: opts.Title, BOMs: make([]BOMData, 0, len(inputs))}
+	if doc.Title == "" {
+		doc.Title = "AIBOM viewer"
+	}
+	if !opts.Locale.IsRaw() {
+		doc.Locale = opts.Locale.Tag()
+		doc.PropertyKinds = taxonomy.Kinds()
+	}
+	for _, in := range inputs {
+		if in.BOM == nil {
+			continue
+		}
+		res := completeness.CheckWithProfile(in.BOM, opts.Profile)
+		data := CompletenessData{
+			Score:           res.Score,
+			Passed:          res.Passed,
+			Total:           res.Total,
+			MissingRequired: keyStrings(res.MissingRequired),
+			MissingOptional: keyStrings(res.MissingOptional),
+			Profile:         res.Profile,
+			Datasets:        []DatasetData{},
+		}
+		for name, ds := range res.DatasetResults {
+			data.Datasets = append(data.Datasets, DatasetData{
+				Name:            name,
+				Score:           ds.Score,
+				Passed:          ds.Passed,
+				Total:           ds.Total,
+				MissingRequired: keyStrings(ds.MissingRequired),
+				MissingOptional: keyStrings(ds.MissingOptional),
+			})
+		}
+		sort.Slice(data.Datasets, func(i, j int) bool { return data.Datasets[i].Name < data.Datasets[j].Name })
+		doc.BOMs = append(doc.BOMs, BOMData{Name: in.Name, BOM: in.BOM, Completeness: data})
+	}
+	return doc
+}
+
+// Render writes the viewer page for inputs to w.
+func Render(w io.Writer, inputs []Input, opts Options) error {
+	doc := NewDocument(inputs, opts)
+	// json.Marshal escapes <, > and &, so the data cannot close the script.
+	// element it is embedded in.
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("encode viewer data: %w", err)
+	}
+	return page.Execute(w, struct {
+		Title string
+		Data  template.JS
+		CSS   template.CSS
+		JS    template.JS
+	}{doc.Title, template.JS(data), template.CSS(pageCSS), template.JS(pageJS)})
+}
+
+// keyStrings converts registry keys to strings, never returning nil so the.
+// JSON holds a list.
+func keyStrings[K ~string](keys []K) []string {
+	out := make([]string, 0, len(keys))
+	for _, k := range keys {
+		out = append(out, string(k))
+	}
+	return out
+}
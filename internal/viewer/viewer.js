@@ -0,0 +1,533 @@
+// AIBOM viewer. Renders the AIBOMs embedded in the aibom-data element. BOM
+// content is only ever inserted as text nodes, never as markup.
+(function () {
+  "use strict";
+
+  var doc = JSON.parse(document.getElementById("aibom-data").textContent);
+  var app = document.getElementById("app");
+
+  // Pages exported with --locale format scores, timestamps and numeric
+  // properties for that locale; otherwise values are shown as written.
+  var kinds = doc.propertyKinds || {};
+  var fmt = doc.locale ? {
+    number: new Intl.NumberFormat(doc.locale),
+    size: new Intl.NumberFormat(doc.locale, { minimumFractionDigits: 1, maximumFractionDigits: 1 }),
+    percent: new Intl.NumberFormat(doc.locale, { style: "percent", minimumFractionDigits: 1, maximumFractionDigits: 1 }),
+    time: new Intl.DateTimeFormat(doc.locale, { dateStyle: "medium", timeStyle: "short" })
+  } : null;
+
+  // el creates an element with attributes and children (nodes or strings).
+  function el(tag, attrs, children) {
+    var node = document.createElement(tag);
+    Object.keys(attrs || {}).forEach(function (k) {
+      if (k === "class") {
+        node.className = attrs[k];
+      } else {
+        node.setAttribute(k, attrs[k]);
+      }
+    });
+    (children || []).forEach(function (c) {
+      if (c === null || c === undefined || c === "") {
+        return;
+      }
+      node.appendChild(typeof c === "string" || typeof c === "number" ? document.createTextNode(String(c)) : c);
+    });
+    return node;
+  }
+
+  function svg(tag, attrs) {
+    var node = document.createElementNS("http://www.w3.org/2000/svg", tag);
+    Object.keys(attrs).forEach(function (k) {
+      node.setAttribute(k, attrs[k]);
+    });
+    return node;
+  }
+
+  // link returns an anchor for http(s) URLs and plain text otherwise.
+  function link(url, text) {
+    if (/^https?:\/\//i.test(url || "")) {
+      return el("a", { href: url, target: "_blank", rel: "noopener noreferrer" }, [text || url]);
+    }
+    return el("span", { class: "mono" }, [text || url || ""]);
+  }
+
+  function list(items) {
+    return (items || []).filter(function (x) {
+      return x !== null && x !== undefined && x !== "";
+    });
+  }
+
+  function pct(score) {
+    if (fmt) {
+      return fmt.percent.format(score || 0);
+    }
+    return Math.round((score || 0) * 1000) / 10 + "%";
+  }
+
+  function formatTime(v) {
+    var d = fmt && v ? new Date(v) : null;
+    return d && !isNaN(d.getTime()) ? fmt.time.format(d) : v;
+  }
+
+  // formatBytes renders a size in binary units (1.5 GiB).
+  function formatBytes(n) {
+    var units = ["B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"];
+    var i = 0;
+    while (n >= 1024 && i < units.length - 1) {
+      n /= 1024;
+      i++;
+    }
+    return (i === 0 ? fmt.number.format(n) : fmt.size.format(n)) + " " + units[i];
+  }
+
+  // property formats the value of a count, size or time property.
+  function property(name, value) {
+    var kind = kinds[name];
+    if (!fmt || !kind) {
+      return value;
+    }
+    if (kind === "time") {
+      return formatTime(value);
+    }
+    if (!/^\d+$/.test(value || "")) {
+      return value;
+    }
+    return kind === "bytes" ? formatBytes(Number(value)) : fmt.number.format(Number(value));
+  }
+
+  function grade(score) {
+    if (score >= 0.8) {
+      return "good";
+    }
+    return score >= 0.5 ? "fair" : "poor";
+  }
+
+  function licenses(c) {
+    return list((c.licenses || []).map(function (l) {
+      if (l.expression) {
+        return l.expression;
+      }
+      return l.license ? l.license.id || l.license.name || (l.license.url || "") : "";
+    }));
+  }
+
+  function fullName(c) {
+    if (!c) {
+      return "";
+    }
+    var name = c.name || c["bom-ref"] || "";
+    if (c.group && c.group !== name && name.indexOf(c.group + "/") !== 0) {
+      name = c.group + "/" + name;
+    }
+    return name;
+  }
+
+  function chips(items, cls) {
+    var wrap = el("div", { class: "chips" });
+    list(items).forEach(function (x) {
+      wrap.appendChild(el("span", { class: "chip" + (cls ? " " + cls : "") }, [x]));
+    });
+    return wrap;
+  }
+
+  // facts renders label/value pairs, skipping empty values.
+  function facts(pairs) {
+    var dl = el("dl", { class: "facts" });
+    pairs.forEach(function (p) {
+      var v = p[1];
+      if (v === null || v === undefined || v === "" || (Array.isArray(v) && v.length === 0)) {
+        return;
+      }
+      if (Array.isArray(v)) {
+        v = v.join(", ");
+      }
+      dl.appendChild(el("dt", {}, [p[0]]));
+      dl.appendChild(el("dd", {}, [typeof v === "object" ? v : String(v)]));
+    });
+    return dl;
+  }
+
+  function card(title, children) {
+    return el("section", { class: "card" }, [el("h2", {}, [title])].concat(children));
+  }
+
+  // ── Overview ──────────────────────────────────────────────────────────────
+
+  function overview(bom) {
+    var meta = bom.metadata || {};
+    var c = meta.component || {};
+    var tools = [];
+    var t = meta.tools || {};
+    (t.components || []).forEach(function (x) {
+      tools.push(fullName(x) + (x.version ? " " + x.version : ""));
+    });
+    (Array.isArray(t) ? t : []).forEach(function (x) {
+      tools.push(list([x.vendor, x.name, x.version]).join(" "));
+    });
+    var lifecycles = (meta.lifecycles || []).map(function (l) {
+      return l.phase || l.name;
+    });
+    var body = [facts([
+      ["Name", fullName(c)],
+      ["Type", c.type],
+      ["Version", c.version],
+      ["Package URL", c.purl ? el("span", { class: "mono" }, [c.purl]) : ""],
+      ["Manufacturer", c.manufacturer && c.manufacturer.name],
+      ["Supplier", c.supplier && c.supplier.name],
+      ["Licenses", licenses(c)],
+      ["Serial number", bom.serialNumber ? el("span", { class: "mono" }, [bom.serialNumber]) : ""],
+      ["BOM version", bom.version],
+      ["Spec version", bom.specVersion],
+      ["Timestamp", formatTime(meta.timestamp)],
+      ["Lifecycle", list(lifecycles)],
+      ["Tools", tools]
+    ])];
+    if (c.description) {
+      body.push(el("p", {}, [c.description]));
+    }
+    if (c.tags && c.tags.length) {
+      body.push(chips(c.tags));
+    }
+    return card("Overview", body);
+  }
+
+  // ── Completeness ──────────────────────────────────────────────────────────
+
+  function gauge(score, label) {
+    var r = 42;
+    var circ = 2 * Math.PI * r;
+    var s = svg("svg", { width: 110, height: 110, viewBox: "0 0 110 110", role: "img", "aria-label": label + " " + pct(score) });
+    s.appendChild(svg("circle", { cx: 55, cy: 55, r: r, fill: "none", stroke: "currentColor", "stroke-opacity": 0.12, "stroke-width": 10 }));
+    s.appendChild(svg("circle", {
+      cx: 55, cy: 55, r: r, fill: "none", "stroke-width": 10, "stroke-linecap": "round",
+      class: grade(score), "stroke-dasharray": (circ * (score || 0)) + " " + circ, transform: "rotate(-90 55 55)"
+    }));
+    return el("div", { class: "gauge" }, [s, el("div", { class: "value" }, [pct(score)]), el("div", { class: "muted" }, [label])]);
+  }
+
+  function bar(label, score, note) {
+    var fill = el("div", { class: "fill " + grade(score) });
+    fill.style.width = Math.max(0, Math.min(1, score || 0)) * 100 + "%";
+    return el("div", { class: "bar", title: label + ": " + pct(score) + (note ? " (" + note + ")" : "") }, [
+      el("span", { class: "label" }, [label]),
+      el("div", { class: "track" }, [fill]),
+      el("span", {}, [pct(score)])
+    ]);
+  }
+
+  function missing(prefix, required, optional) {
+    var out = [];
+    if (required && required.length) {
+      out.push(el("h3", {}, [prefix + "missing required fields"]), chips(required, "required"));
+    }
+    if (optional && optional.length) {
+      out.push(el("h3", {}, [prefix + "missing optional fields"]), chips(optional));
+    }
+    return out;
+  }
+
+  function completeness(res) {
+    var bars = el("div", { class: "bars" }, [
+      el("h3", {}, ["Model"]),
+      bar("Model fields", res.score, res.passed + "/" + res.total)
+    ]);
+    if (res.datasets.length) {
+      bars.appendChild(el("h3", {}, ["Datasets"]));
+      res.datasets.forEach(function (d) {
+        bars.appendChild(bar(d.name, d.score, d.passed + "/" + d.total));
+      });
+    }
+    var body = [
+      el("div", { class: "scores" }, [gauge(res.score, res.passed + " of " + res.total + " fields"), bars])
+    ];
+    if (res.profile) {
+      body.push(el("p", { class: "muted" }, ["Weights profile: " + res.profile]));
+    }
+    body = body.concat(missing("Model: ", res.missingRequired, res.missingOptional));
+    res.datasets.forEach(function (d) {
+      body = body.concat(missing("Dataset " + d.name + ": ", d.missingRequired, d.missingOptional));
+    });
+    return card("Completeness", body);
+  }
+
+  // ── Model cards ───────────────────────────────────────────────────────────
+
+  function bullets(items) {
+    var ul = el("ul");
+    list(items).forEach(function (x) {
+      ul.appendChild(el("li", {}, [x]));
+    });
+    return ul;
+  }
+
+  function modelCard(c, refs) {
+    var mc = c.modelCard || {};
+    var mp = mc.modelParameters || {};
+    var qa = mc.quantitativeAnalysis || {};
+    var cons = mc.considerations || {};
+    var body = [facts([
+      ["Task", mp.task],
+      ["Architecture family", mp.architectureFamily],
+      ["Architecture", mp.modelArchitecture],
+      ["Approach", mp.approach && mp.approach.type],
+      ["Datasets", (mp.datasets || []).map(function (d) {
+        return d.ref ? (refs[d.ref] ? fullName(refs[d.ref]) : d.ref) : d.name || "";
+      })],
+      ["Inputs", (mp.inputs || []).map(function (x) { return x.format; })],
+      ["Outputs", (mp.outputs || []).map(function (x) { return x.format; })]
+    ])];
+
+    var metrics = qa.performanceMetrics || [];
+    if (metrics.length) {
+      var tbody = el("tbody");
+      metrics.forEach(function (m) {
+        tbody.appendChild(el("tr", {}, [
+          el("td", {}, [m.type || ""]), el("td", {}, [m.value || ""]), el("td", {}, [m.slice || ""])
+        ]));
+      });
+      body.push(el("h3", {}, ["Performance metrics"]), el("table", {}, [
+        el("thead", {}, [el("tr", {}, [el("th", {}, ["Metric"]), el("th", {}, ["Value"]), el("th", {}, ["Slice"])])]), tbody
+      ]));
+    }
+
+    var sections = [
+      ["Users", cons.users],
+      ["Use cases", cons.useCases],
+      ["Technical limitations", cons.technicalLimitations],
+      ["Performance trade-offs", cons.performanceTradeoffs],
+      ["Ethical considerations", (cons.ethicalConsiderations || []).map(function (e) {
+        return e.name + (e.mitigationStrategy ? " Mitigation: " + e.mitigationStrategy : "");
+      })],
+      ["Fairness assessments", (cons.fairnessAssessments || []).map(function (f) {
+        return list([f.groupAtRisk, f.benefits, f.harms, f.mitigationStrategy]).join(" – ");
+      })]
+    ];
+    sections.forEach(function (s) {
+      if (s[1] && s[1].length) {
+        body.push(el("h3", {}, [s[0]]), bullets(s[1]));
+      }
+    });
+    var env = (cons.environmentalConsiderations || {}).properties || [];
+    if (env.length) {
+      body.push(el("h3", {}, ["Environmental considerations"]), facts(env.map(function (p) {
+        return [p.name, p.value];
+      })));
+    }
+    var s = card("Model card: " + fullName(c), body);
+    s.classList.add("modelcard");
+    return s;
+  }
+
+  // ── Components ────────────────────────────────────────────────────────────
+
+  // components flattens the metadata component and all (nested) components.
+  function components(bom) {
+    var out = [];
+    function walk(c, root) {
+      out.push({ c: c, root: root });
+      (c.components || []).forEach(function (x) {
+        walk(x, false);
+      });
+    }
+    if (bom.metadata && bom.metadata.component) {
+      walk(bom.metadata.component, true);
+    }
+    (bom.components || []).forEach(function (x) {
+      walk(x, false);
+    });
+    return out;
+  }
+
+  function details(c, deps, refs) {
+    var parts = [];
+    if (c.description) {
+      parts.push(el("p", {}, [c.description]));
+    }
+    parts.push(facts([
+      ["bom-ref", c["bom-ref"] ? el("span", { class: "mono" }, [c["bom-ref"]]) : ""],
+      ["Manufacturer", c.manufacturer && c.manufacturer.name],
+      ["Supplier", c.supplier && c.supplier.name],
+      ["Authors", (c.authors || []).map(function (a) { return a.name || a.email; })],
+      ["Copyright", c.copyright],
+      ["Hashes", (c.hashes || []).map(function (h) { return h.alg + ":" + h.content; })],
+      ["Depends on", (deps[c["bom-ref"]] || []).map(function (r) { return refs[r] ? fullName(refs[r]) : r; })],
+      ["Base models", ((c.pedigree || {}).ancestors || []).map(fullName)]
+    ]));
+    (c.data || []).forEach(function (d) {
+      parts.push(el("h3", {}, ["Data: " + (d.name || d.type || "")]), facts([
+        ["Type", d.type],
+        ["Classification", d.classification],
+        ["Contents", d.contents && d.contents.url ? link(d.contents.url) : ""],
+        ["Description", d.description],
+        ["Sensitive data", d.sensitiveData],
+        ["Custodians", ((d.governance || {}).custodians || []).map(function (x) { return (x.organization || x.contact || {}).name; })]
+      ]));
+    });
+    if (c.externalReferences && c.externalReferences.length) {
+      var ul = el("ul");
+      c.externalReferences.forEach(function (r) {
+        ul.appendChild(el("li", {}, [r.type + ": ", link(r.url)]));
+      });
+      parts.push(el("h3", {}, ["External references"]), ul);
+    }
+    if (c.properties && c.properties.length) {
+      parts.push(el("h3", {}, ["Properties"]), facts(c.properties.map(function (p) {
+        return [p.name, property(p.name, p.value)];
+      })));
+    }
+    return parts;
+  }
+
+  function componentTable(bom, refs) {
+    var rows = components(bom);
+    var deps = {};
+    (bom.dependencies || []).forEach(function (d) {
+      deps[d.ref] = d.dependsOn || [];
+    });
+
+    var types = {};
+    rows.forEach(function (r) {
+      types[r.c.type || ""] = true;
+    });
+    var search = el("input", { type: "search", placeholder: "Search name, version, license, purl, property…", "aria-label": "Search components" });
+    var typeSel = el("select", { "aria-label": "Component type" }, [el("option", { value: "" }, ["All types"])]);
+    Object.keys(types).sort().forEach(function (t) {
+      typeSel.appendChild(el("option", { value: t }, [t || "(none)"]));
+    });
+    var count = el("span", { class: "muted" });
+
+    var columns = [
+      ["Name", function (c) { return fullName(c); }],
+      ["Type", function (c) { return c.type || ""; }],
+      ["Version", function (c) { return c.version || ""; }],
+      ["Licenses", function (c) { return licenses(c).join(", "); }],
+      ["Package URL", function (c) { return c.purl || ""; }]
+    ];
+    var sortCol = -1;
+    var sortDir = 1;
+    var headRow = el("tr");
+    var tbody = el("tbody");
+    columns.forEach(function (col, i) {
+      var th = el("th", { scope: "col" }, [col[0]]);
+      th.addEventListener("click", function () {
+        sortDir = sortCol === i ? -sortDir : 1;
+        sortCol = i;
+        Array.prototype.forEach.call(headRow.children, function (h, j) {
+          if (j === i) {
+            h.setAttribute("aria-sort", sortDir > 0 ? "ascending" : "descending");
+          } else {
+            h.removeAttribute("aria-sort");
+          }
+        });
+        draw();
+      });
+      headRow.appendChild(th);
+    });
+
+    // r.text is the lower-case text a search matches against.
+    rows.forEach(function (r) {
+      var c = r.c;
+      r.text = list([fullName(c), c.type, c.version, c.purl, c.description, c["bom-ref"]]
+        .concat(licenses(c))
+        .concat((c.tags || []))
+        .concat((c.properties || []).map(function (p) { return p.name + " " + p.value; }))).join("\n").toLowerCase();
+    });
+
+    function draw() {
+      var q = search.value.trim().toLowerCase();
+      var t = typeSel.value;
+      var shown = rows.filter(function (r) {
+        return (!t || (r.c.type || "") === t) && (!q || r.text.indexOf(q) >= 0);
+      });
+      if (sortCol >= 0) {
+        var key = columns[sortCol][1];
+        shown = shown.slice().sort(function (a, b) {
+          return key(a.c).localeCompare(key(b.c)) * sortDir;
+        });
+      }
+      tbody.textContent = "";
+      shown.forEach(function (r) {
+        var tr = el("tr", { class: "row", tabindex: 0, "aria-expanded": "false" }, columns.map(function (col, i) {
+          var v = col[1](r.c);
+          if (i === 0 && r.root) {
+            return el("td", {}, [v + " ", el("span", { class: "chip" }, ["main"])]);
+          }
+          return el("td", { class: i === 4 ? "mono" : "" }, [v]);
+        }));
+        var open = null;
+        function toggle() {
+          if (open) {
+            open.remove();
+            open = null;
+            tr.setAttribute("aria-expanded", "false");
+            return;
+          }
+          open = el("tr", { class: "details" }, [el("td", { colspan: columns.length }, details(r.c, deps, refs))]);
+          tr.after(open);
+          tr.setAttribute("aria-expanded", "true");
+        }
+        tr.addEventListener("click", toggle);
+        tr.addEventListener("keydown", function (e) {
+          if (e.key === "Enter" || e.key === " ") {
+            e.preventDefault();
+            toggle();
+          }
+        });
+        tbody.appendChild(tr);
+      });
+      count.textContent = shown.length + " of " + rows.length + " component(s)";
+      if (!shown.length) {
+        tbody.appendChild(el("tr", {}, [el("td", { colspan: columns.length, class: "empty" }, ["No component matches."])]));
+      }
+    }
+    search.addEventListener("input", draw);
+    typeSel.addEventListener("change", draw);
+    draw();
+
+    return card("Components", [
+      el("div", { class: "toolbar" }, [search, typeSel, count]),
+      el("table", {}, [el("thead", {}, [headRow]), tbody])
+    ]);
+  }
+
+  // ── Page ──────────────────────────────────────────────────────────────────
+
+  function show(i) {
+    var entry = doc.boms[i];
+    var bom = entry.bom || {};
+    var refs = {};
+    components(bom).forEach(function (r) {
+      if (r.c["bom-ref"]) {
+        refs[r.c["bom-ref"]] = r.c;
+      }
+    });
+    app.textContent = "";
+    app.appendChild(el("p", { class: "muted" }, [entry.name]));
+    app.appendChild(overview(bom));
+    app.appendChild(completeness(entry.completeness));
+    components(bom).forEach(function (r) {
+      if (r.c.modelCard) {
+        app.appendChild(modelCard(r.c, refs));
+      }
+    });
+    app.appendChild(componentTable(bom, refs));
+  }
+
+  if (!doc.boms.length) {
+    app.appendChild(el("p", { class: "empty" }, ["No AIBOM to show."]));
+    return;
+  }
+  if (doc.boms.length > 1) {
+    var picker = document.getElementById("bom-picker");
+    var sel = document.getElementById("bom-select");
+    doc.boms.forEach(function (b, i) {
+      var c = (b.bom && b.bom.metadata && b.bom.metadata.component) || {};
+      sel.appendChild(el("option", { value: i }, [b.name + (c.name ? " – " + fullName(c) : "")]));
+    });
+    sel.addEventListener("change", function () {
+      show(Number(sel.value));
+    });
+    picker.hidden = false;
+  }
+  show(0);
+})();
@@ -0,0 +1,89 @@
+package viewer
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/internal/locale"
+	"github.com/idlab-discover/aibomgen-cli/internal/taxonomy"
+)
+
+func modelBOM(name string) *cdx.BOM {
+	bom := cdx.NewBOM()
+	bom.Metadata = &cdx.Metadata{Component: &cdx.Component{
+		BOMRef: "pkg:huggingface/" + name,
+		Type:   cdx.ComponentTypeMachineLearningModel,
+		Name:   name,
+		ModelCard: &cdx.MLModelCard{ModelParameters: &cdx.MLModelParameters{
+			Datasets: &[]cdx.MLDatasetChoice{{Ref: "dataset:imdb"}},
+		}},
+	}}
+	bom.Components = &[]cdx.Component{{BOMRef: "dataset:imdb", Type: cdx.ComponentTypeData, Name: "imdb"}}
+	bom.Dependencies = &[]cdx.Dependency{{Ref: "pkg:huggingface/" + name, Dependencies: &[]string{"dataset:imdb"}}}
+	return bom
+}
+
+func TestNewDocument(t *testing.T) {
+	doc := NewDocument([]Input{{Name: "a.json", BOM: modelBOM("org/a")}, {Name: "nil.json"}}, Options{})
+	if doc.Title != "AIBOM viewer" || len(doc.BOMs) != 1 {
+		t.Fatalf("document = %+v", doc)
+	}
+	res := doc.BOMs[0].Completeness
+	if res.Total == 0 || len(res.MissingRequired)+len(res.MissingOptional) == 0 {
+		t.Errorf("completeness = %+v", res)
+	}
+	if len(res.Datasets) != 1 || res.Datasets[0].Name != "imdb" || res.Datasets[0].Total == 0 {
+		t.Errorf("dataset results = %+v", res.Datasets)
+	}
+}
+
+func TestNewDocumentLocale(t *testing.T) {
+	inputs := []Input{{Name: "a.json", BOM: modelBOM("org/a")}}
+	if doc := NewDocument(inputs, Options{}); doc.Locale != "" || doc.PropertyKinds != nil {
+		t.Errorf("document without locale = %q, %v", doc.Locale, doc.PropertyKinds)
+	}
+	nl, err := locale.Parse("nl_BE.UTF-8")
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := NewDocument(inputs, Options{Locale: nl})
+	if doc.Locale != "nl-BE" || doc.PropertyKinds[taxonomy.HFDownloads] != taxonomy.KindCount {
+		t.Errorf("document locale = %q, kinds = %v", doc.Locale, doc.PropertyKinds)
+	}
+}
+
+func TestRenderEmbedsDataSafely(t *testing.T) {
+	bom := modelBOM("org/model")
+	bom.Metadata.Component.Description = `</script><script>alert(1)</script>`
+
+	var buf bytes.Buffer
+	if err := Render(&buf, []Input{{Name: "model.json", BOM: bom}}, Options{Title: "Release <1.0>"}); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	page := buf.String()
+	if strings.Contains(page, "alert(1)</script>") {
+		t.Fatalf("BOM content escaped the data element")
+	}
+	if !strings.Contains(page, "<title>Release &lt;1.0&gt;</title>") {
+		t.Errorf("title not escaped")
+	}
+	if strings.Contains(page, "src=") || strings.Contains(page, "<link") {
+		t.Errorf("page must not load external resources")
+	}
+
+	start := strings.Index(page, `id="aibom-data">`) + len(`id="aibom-data">`)
+	end := strings.Index(page[start:], "</script>")
+	var doc Document
+	if err := json.Unmarshal([]byte(page[start:start+end]), &doc); err != nil {
+		t.Fatalf("embedded data: %v", err)
+	}
+	if len(doc.BOMs) != 1 || doc.BOMs[0].BOM.Metadata.Component.Description != bom.Metadata.Component.Description {
+		t.Errorf("embedded document = %+v", doc)
+	}
+	if !strings.Contains(page, "function componentTable") || !strings.Contains(page, ".card {") {
+		t.Errorf("script or styles not inlined")
+	}
+}
@@ -0,0 +1,221 @@
+package vulnaudit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+// DefaultOSVURL is the base URL of the public OSV API.
+const DefaultOSVURL = "https://api.osv.dev"
+
+// maxOSVResponse caps the number of bytes read from one OSV response.
+const maxOSVResponse = 16 << 20
+
+// osvEcosystems are the package URL types OSV indexes. Components with other.
+// types (Hugging Face models and datasets, generic files) are not queried.
+var osvEcosystems = map[string]bool{
+	"pypi":     true,
+	"npm":      true,
+	"golang":   true,
+	"maven":    true,
+	"cargo":    true,
+	"gem":      true,
+	"nuget":    true,
+	"composer": true,
+	"hex":      true,
+	"pub":      true,
+	"conan":    true,
+	"cran":     true,
+	"swift":    true,
+	"deb":      true,
+	"apk":      true,
+	"rpm":      true,
+}
+
+// osvVuln is the subset of the OSV schema the audit uses.
+type osvVuln struct {
+	ID               string        `json:"id"`
+	Summary          string        `json:"summary"`
+	Details          string        `json:"details"`
+	Aliases          []string      `json:"aliases"`
+	Published        string        `json:"published"`
+	Modified         string        `json:"modified"`
+	Severity         []osvSeverity `json:"severity"`
+	DatabaseSpecific struct {
+		Severity string `json:"severity"`
+	} `json:"database_specific"`
+	References []struct {
+		Type string `json:"type"`
+		URL  string `json:"url"`
+	} `json:"references"`
+}
+
+type osvSeverity struct {
+	Type  string `json:"type"`
+	Score string `json:"score"`
+}
+
+// osvClient queries the OSV API for the vulnerabilities of a package version.
+type osvClient struct {
+	Client  *http.Client
+	BaseURL string
+}
+
+// Query returns the OSV vulnerabilities affecting the package version that.
+// purl names.
+func (c *osvClient) Query(ctx context.Context, purl string) ([]osvVuln, error) {
+	body, err := json.Marshal(map[string]any{"package": map[string]string{"purl": purl}})
+	if err != nil {
+		return nil, err
+	}
+	base := strings.TrimSuffix(strings.TrimSpace(c.BaseURL), "/")
+	if base == "" {
+		base = DefaultOSVURL
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, base+"/v1/query", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("query OSV: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("query OSV: status %d", resp.StatusCode)
+	}
+
+	var out struct {
+		Vulns []osvVuln `json:"vulns"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxOSVResponse)).Decode(&out); err != nil {
+		return nil, fmt.Errorf("decode OSV response: %w", err)
+	}
+	return out.Vulns, nil
+}
+
+// osvQueryable reports whether OSV indexes the package URL purl: its type is.
+// an OSV ecosystem and it names a version. Without a version OSV would.
+// return every vulnerability the package ever had.
+func osvQueryable(purl string) bool {
+	rest, ok := strings.CutPrefix(strings.TrimSpace(purl), "pkg:")
+	if !ok {
+		return false
+	}
+	typ, rest, _ := strings.Cut(rest, "/")
+	if !osvEcosystems[strings.ToLower(typ)] {
+		return false
+	}
+	if i := strings.IndexAny(rest, "?#"); i >= 0 {
+		rest = rest[:i]
+	}
+	_, version, ok := strings.Cut(rest, "@")
+	return ok && version != ""
+}
+
+// osvVulnerability converts an OSV entry into a CycloneDX vulnerability.
+// affecting the component with the given bom-ref.
+func osvVulnerability(v osvVuln, ref string) cdx.Vulnerability {
+	vuln := cdx.Vulnerability{
+		BOMRef:      "osv-" + v.ID,
+		ID:          v.ID,
+		Source:      &cdx.Source{Name: "OSV", URL: "https://osv.dev/vulnerability/" + v.ID},
+		Description: v.Summary,
+		Detail:      v.Details,
+		Published:   v.Published,
+		Updated:     v.Modified,
+		Affects:     &[]cdx.Affects{{Ref: ref}},
+	}
+
+	var refs []cdx.VulnerabilityReference
+	for _, alias := range v.Aliases {
+		refs = append(refs, cdx.VulnerabilityReference{ID: alias, Source: aliasSource(alias)})
+	}
+	if len(refs) > 0 {
+		vuln.References = &refs
+	}
+
+	var advisories []cdx.Advisory
+	for _, r := range v.References {
+		if r.Type == "ADVISORY" && r.URL != "" {
+			advisories = append(advisories, cdx.Advisory{URL: r.URL})
+		}
+	}
+	if len(advisories) > 0 {
+		vuln.Advisories = &advisories
+	}
+
+	severity := osvSeverityLevel(v.DatabaseSpecific.Severity)
+	var ratings []cdx.VulnerabilityRating
+	for _, s := range v.Severity {
+		method := cvssMethod(s.Type, s.Score)
+		if method == "" {
+			continue
+		}
+		ratings = append(ratings, cdx.VulnerabilityRating{Severity: severity, Method: method, Vector: s.Score})
+	}
+	if len(ratings) == 0 && severity != cdx.SeverityUnknown {
+		ratings = append(ratings, cdx.VulnerabilityRating{Severity: severity, Method: cdx.ScoringMethodOther})
+	}
+	if len(ratings) > 0 {
+		vuln.Ratings = &ratings
+	}
+	return vuln
+}
+
+// osvSeverityLevel maps the database-specific severity of GitHub and PyPA.
+// advisories to a CycloneDX severity.
+func osvSeverityLevel(s string) cdx.Severity {
+	switch strings.ToUpper(strings.TrimSpace(s)) {
+	case "CRITICAL":
+		return cdx.SeverityCritical
+	case "HIGH":
+		return cdx.SeverityHigh
+	case "MODERATE", "MEDIUM":
+		return cdx.SeverityMedium
+	case "LOW":
+		return cdx.SeverityLow
+	default:
+		return cdx.SeverityUnknown
+	}
+}
+
+// cvssMethod returns the CycloneDX scoring method of an OSV severity entry,.
+// or "" when it is not a CVSS vector.
+func cvssMethod(typ, vector string) cdx.ScoringMethod {
+	switch typ {
+	case "CVSS_V2":
+		return cdx.ScoringMethodCVSSv2
+	case "CVSS_V3":
+		if strings.HasPrefix(vector, "CVSS:3.1/") {
+			return cdx.ScoringMethodCVSSv31
+		}
+		return cdx.ScoringMethodCVSSv3
+	case "CVSS_V4":
+		return cdx.ScoringMethodCVSSv4
+	default:
+		return ""
+	}
+}
+
+// aliasSource names the database of a vulnerability alias.
+func aliasSource(alias string) *cdx.Source {
+	switch {
+	case strings.HasPrefix(alias, "CVE-"):
+		return &cdx.Source{Name: "NVD", URL: "https://nvd.nist.gov/vuln/detail/" + alias}
+	case strings.HasPrefix(alias, "GHSA-"):
+		return &cdx.Source{Name: "GitHub", URL: "https://github.com/advisories/" + alias}
+	default:
+		return &cdx.Source{Name: "OSV", URL: "https://osv.dev/vulnerability/" + alias}
+	}
+}
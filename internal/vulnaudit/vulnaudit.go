@@ -0,0 +1,350 @@
+// Package vulnaudit looks up known vulnerabilities of the components of an.
+// AIBOM and turns them into CycloneDX vulnerabilities.
+//.
+// Three sources are consulted:.
+//.
+//	hf    – the Hugging Face security scanners, for model and dataset components.
+//	        (see the vulnscan package).
+//	osv   – the OSV database, for library components with a versioned package.
+//	        URL in an ecosystem OSV indexes (pkg:pypi, pkg:npm, pkg:golang, …).
+//	feed  – a signed advisories feed of model CVEs, takedowns and weight.
+//	        poisoning reports (see the advisories package).
+//.
+// A lookup that fails is recorded in the report and does not stop the others.
+package vulnaudit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/internal/advisories"
+	"github.com/idlab-discover/aibomgen-cli/internal/vulnscan"
+)
+
+// Vulnerability sources.
+const (
+	SourceHF   = "hf"
+	SourceOSV  = "osv"
+	SourceFeed = "feed"
+)
+
+// Sources lists the vulnerability sources in the order they are queried.
+var Sources = []string{SourceHF, SourceOSV, SourceFeed}
+
+// Options configures an audit.
+type Options struct {
+	// Sources selects the sources to query; empty means hf and osv, plus feed.
+	// when Feed is set.
+	Sources []string
+	// Feed is the verified advisories feed; FeedURL where it was loaded from.
+	Feed    *advisories.Feed
+	FeedURL string
+
+	HFToken   string
+	HFBaseURL string
+	// OSVURL overrides the OSV API base URL (empty = DefaultOSVURL).
+	OSVURL  string
+	Timeout time.Duration
+	Context context.Context
+}
+
+// Finding is one vulnerability affecting one component.
+type Finding struct {
+	Source       string
+	ComponentRef string
+	// ComponentID names the component: its Hugging Face ID or package URL.
+	ComponentID   string
+	Vulnerability cdx.Vulnerability
+}
+
+// Report is the result of an audit.
+type Report struct {
+	Findings []Finding
+	// Queried counts the component lookups per source.
+	Queried map[string]int
+	// Errors are the failed lookups.
+	Errors []error
+}
+
+// Run queries the selected sources for the vulnerabilities of the components.
+// of bom.
+func Run(bom *cdx.BOM, opts Options) Report {
+	if opts.Timeout <= 0 {
+		opts.Timeout = 15 * time.Second
+	}
+	if opts.Context == nil {
+		opts.Context = context.Background()
+	}
+	sources := opts.Sources
+	if len(sources) == 0 {
+		sources = []string{SourceHF, SourceOSV}
+		if opts.Feed != nil {
+			sources = append(sources, SourceFeed)
+		}
+	}
+
+	report := Report{Queried: map[string]int{}}
+	for _, source := range sources {
+		switch source {
+		case SourceHF:
+			auditHF(bom, opts, &report)
+		case SourceOSV:
+			auditOSV(bom, opts, &report)
+		case SourceFeed:
+			auditFeed(bom, opts, &report)
+		}
+	}
+	return report
+}
+
+func auditHF(bom *cdx.BOM, opts Options, report *Report) {
+	results := vulnscan.ScanBOM(bom, vulnscan.Options{
+		HFToken: opts.HFToken,
+		Timeout: opts.Timeout,
+		BaseURL: opts.HFBaseURL,
+	})
+	for _, r := range results {
+		report.Queried[SourceHF]++
+		if r.Err != nil {
+			report.Errors = append(report.Errors, r.Err)
+			continue
+		}
+		for _, v := range r.Vulnerabilities {
+			report.Findings = append(report.Findings, Finding{Source: SourceHF, ComponentRef: r.ComponentRef, ComponentID: r.ModelID, Vulnerability: v})
+		}
+	}
+}
+
+func auditOSV(bom *cdx.BOM, opts Options, report *Report) {
+	client := &osvClient{Client: &http.Client{Timeout: opts.Timeout}, BaseURL: opts.OSVURL}
+	// A package can be listed by several components; query it once.
+	seen := map[string][]osvVuln{}
+	walkComponents(bom, func(c *cdx.Component) {
+		purl := strings.TrimSpace(c.PackageURL)
+		if !osvQueryable(purl) {
+			return
+		}
+		vulns, ok := seen[purl]
+		if !ok {
+			report.Queried[SourceOSV]++
+			var err error
+			vulns, err = client.Query(opts.Context, purl)
+			if err != nil {
+				report.Errors = append(report.Errors, fmt.Errorf("OSV lookup for %q failed: %w", purl, err))
+			}
+			seen[purl] = vulns
+		}
+		for _, v := range vulns {
+			report.Findings = append(report.Findings, Finding{Source: SourceOSV, ComponentRef: c.BOMRef, ComponentID: purl, Vulnerability: osvVulnerability(v, c.BOMRef)})
+		}
+	})
+}
+
+func auditFeed(bom *cdx.BOM, opts Options, report *Report) {
+	if opts.Feed == nil {
+		return
+	}
+	report.Queried[SourceFeed]++
+	for _, m := range advisories.CheckBOM(bom, opts.Feed) {
+		report.Findings = append(report.Findings, Finding{Source: SourceFeed, ComponentRef: m.ComponentRef, ComponentID: m.ComponentID, Vulnerability: feedVulnerability(m, opts.FeedURL)})
+	}
+}
+
+// feedVulnerability converts an advisory match into a CycloneDX.
+// vulnerability.
+func feedVulnerability(m advisories.Match, feedURL string) cdx.Vulnerability {
+	adv := m.Advisory
+	vuln := cdx.Vulnerability{
+		BOMRef:      "advisory-" + adv.ID,
+		ID:          adv.ID,
+		Source:      &cdx.Source{Name: "aibomgen advisories feed", URL: feedURL},
+		Description: adv.Summary,
+		Published:   adv.Published,
+		Affects:     &[]cdx.Affects{{Ref: m.ComponentRef}},
+	}
+	if adv.Type != "" {
+		vuln.Detail = "Advisory type: " + adv.Type
+	}
+	if sev := osvSeverityLevel(adv.Severity); sev != cdx.SeverityUnknown {
+		vuln.Ratings = &[]cdx.VulnerabilityRating{{Severity: sev, Method: cdx.ScoringMethodOther}}
+	}
+	var refs []cdx.Advisory
+	for _, ref := range adv.References {
+		refs = append(refs, cdx.Advisory{URL: ref})
+	}
+	if len(refs) > 0 {
+		vuln.Advisories = &refs
+	}
+	return vuln
+}
+
+// walkComponents calls fn for the metadata component and every component of.
+// bom, nested ones included.
+func walkComponents(bom *cdx.BOM, fn func(*cdx.Component)) {
+	var walk func(comps *[]cdx.Component)
+	walk = func(comps *[]cdx.Component) {
+		if comps == nil {
+			return
+		}
+		for i := range *comps {
+			c := &(*comps)[i]
+			fn(c)
+			walk(c.Components)
+		}
+	}
+	if bom.Metadata != nil && bom.Metadata.Component != nil {
+		fn(bom.Metadata.Component)
+		walk(bom.Metadata.Component.Components)
+	}
+	walk(bom.Components)
+}
+
+// Vulnerabilities returns the vulnerabilities of the findings, one per.
+// bom-ref: a vulnerability found for several components affects all of them.
+func (r Report) Vulnerabilities() []cdx.Vulnerability {
+	var out []cdx.Vulnerability
+	index := map[string]int{}
+	for _, f := range r.Findings {
+		v := f.Vulnerability
+		i, ok := index[v.BOMRef]
+		if !ok {
+			index[v.BOMRef] = len(out)
+			out = append(out, v)
+			continue
+		}
+		if v.Affects == nil {
+			continue
+		}
+		affects := []cdx.Affects{}
+		if out[i].Affects != nil {
+			affects = append(affects, *out[i].Affects...)
+		}
+		for _, a := range *v.Affects {
+			if !hasAffect(affects, a.Ref) {
+				affects = append(affects, a)
+			}
+		}
+		out[i].Affects = &affects
+	}
+	return out
+}
+
+func hasAffect(affects []cdx.Affects, ref string) bool {
+	for _, a := range affects {
+		if a.Ref == ref {
+			return true
+		}
+	}
+	return false
+}
+
+// Apply adds the vulnerabilities of the report to bom. Existing entries with.
+// the same bom-ref are replaced, so auditing a BOM twice does not duplicate.
+// them. It reports the number of vulnerabilities written.
+func Apply(bom *cdx.BOM, r Report) int {
+	incoming := r.Vulnerabilities()
+	if len(incoming) == 0 {
+		return 0
+	}
+	replaced := map[string]bool{}
+	for _, v := range incoming {
+		replaced[v.BOMRef] = true
+	}
+	var kept []cdx.Vulnerability
+	if bom.Vulnerabilities != nil {
+		for _, v := range *bom.Vulnerabilities {
+			if !replaced[v.BOMRef] {
+				kept = append(kept, v)
+			}
+		}
+	}
+	kept = append(kept, incoming...)
+	bom.Vulnerabilities = &kept
+	return len(incoming)
+}
+
+// severityRank orders the CycloneDX severities; unknown and none rank 0.
+var severityRank = map[cdx.Severity]int{
+	cdx.SeverityInfo:     1,
+	cdx.SeverityLow:      2,
+	cdx.SeverityMedium:   3,
+	cdx.SeverityHigh:     4,
+	cdx.SeverityCritical: 5,
+}
+
+// Severity returns the highest severity among the ratings of v, or.
+// cdx.SeverityUnknown when it has none.
+func Severity(v cdx.Vulnerability) cdx.Severity {
+	best := cdx.SeverityUnknown
+	if v.Ratings != nil {
+		for _, r := range *v.Ratings {
+			if severityRank[r.Severity] > severityRank[best] {
+				best = r.Severity
+			}
+		}
+	}
+	return best
+}
+
+// Fail-on policies besides the severities.
+const (
+	FailOnNone = "none"
+	FailOnAny  = "any"
+)
+
+// ParseFailOn validates a --fail-on policy: none, any, or the lowest.
+// severity (low, medium, high, critical) that fails the audit.
+func ParseFailOn(s string) (string, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	switch s {
+	case "":
+		return FailOnNone, nil
+	case FailOnNone, FailOnAny, string(cdx.SeverityLow), string(cdx.SeverityMedium), string(cdx.SeverityHigh), string(cdx.SeverityCritical):
+		return s, nil
+	}
+	return "", fmt.Errorf("invalid fail-on policy %q (expected none|any|low|medium|high|critical)", s)
+}
+
+// Violations returns the findings that fail the policy failOn. Findings.
+// without a rating only fail the "any" policy.
+func (r Report) Violations(failOn string) []Finding {
+	if failOn == FailOnNone || failOn == "" {
+		return nil
+	}
+	var out []Finding
+	for _, f := range r.Findings {
+		if failOn == FailOnAny || severityRank[Severity(f.Vulnerability)] >= severityRank[cdx.Severity(failOn)] {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// Counts returns the number of findings per severity.
+func (r Report) Counts() map[cdx.Severity]int {
+	counts := map[cdx.Severity]int{}
+	for _, f := range r.Findings {
+		counts[Severity(f.Vulnerability)]++
+	}
+	return counts
+}
+
+// SortFindings orders findings by descending severity, then component and.
+// vulnerability ID.
+func SortFindings(findings []Finding) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		si, sj := severityRank[Severity(findings[i].Vulnerability)], severityRank[Severity(findings[j].Vulnerability)]
+		if si != sj {
+			return si > sj
+		}
+		if findings[i].ComponentID != findings[j].ComponentID {
+			return findings[i].ComponentID < findings[j].ComponentID
+		}
+		return findings[i].Vulnerability.BOMRef < findings[j].Vulnerability.BOMRef
+	})
+}
@@ -0,0 +1,133 @@
+package vulnaudit
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/internal/advisories"
+)
+
+func testBOM() *cdx.BOM {
+	bom := cdx.NewBOM()
+	bom.Metadata = &cdx.Metadata{Component: &cdx.Component{
+		BOMRef:     "pkg:huggingface/org/model@abc",
+		Type:       cdx.ComponentTypeMachineLearningModel,
+		Name:       "org/model",
+		PackageURL: "pkg:huggingface/org/model@abc",
+	}}
+	bom.Components = &[]cdx.Component{
+		{BOMRef: "lib-a", Type: cdx.ComponentTypeLibrary, Name: "transformers", PackageURL: "pkg:pypi/transformers@4.30.0"},
+		{BOMRef: "lib-b", Type: cdx.ComponentTypeLibrary, Name: "transformers", PackageURL: "pkg:pypi/transformers@4.30.0"},
+		{BOMRef: "lib-c", Type: cdx.ComponentTypeLibrary, Name: "torch", PackageURL: "pkg:pypi/torch"},
+	}
+	bom.Vulnerabilities = &[]cdx.Vulnerability{{BOMRef: "osv-GHSA-1111", Description: "stale"}, {BOMRef: "other"}}
+	return bom
+}
+
+func TestRunOSVAndFeed(t *testing.T) {
+	queries := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/query" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var q struct {
+			Package struct {
+				PURL string `json:"purl"`
+			} `json:"package"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&q); err != nil || q.Package.PURL != "pkg:pypi/transformers@4.30.0" {
+			t.Errorf("unexpected query %+v, %v", q, err)
+		}
+		queries++
+		w.Write([]byte(`{"vulns":[{"id":"GHSA-1111","summary":"Deserialization of untrusted data","aliases":["CVE-2023-0001"],
+			"severity":[{"type":"CVSS_V3","score":"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"}],
+			"database_specific":{"severity":"CRITICAL"},"references":[{"type":"ADVISORY","url":"https://example.com/adv"},{"type":"WEB","url":"https://example.com"}]},
+			{"id":"PYSEC-2","summary":"unrated"}]}`))
+	}))
+	defer srv.Close()
+
+	feed := &advisories.Feed{Advisories: []advisories.Advisory{{ID: "AIB-1", Type: advisories.TypeWeightPoisoning, Severity: "high", Models: []string{"org/*"}}}}
+	report := Run(testBOM(), Options{Sources: []string{SourceOSV, SourceFeed}, OSVURL: srv.URL, Feed: feed, FeedURL: "https://example.org/feed.json"})
+
+	if len(report.Errors) != 0 {
+		t.Fatalf("unexpected errors: %v", report.Errors)
+	}
+	if queries != 1 || report.Queried[SourceOSV] != 1 {
+		t.Errorf("expected one OSV query per versioned package, got %d (%v)", queries, report.Queried)
+	}
+	if len(report.Findings) != 5 {
+		t.Fatalf("expected 5 findings, got %+v", report.Findings)
+	}
+
+	vulns := report.Vulnerabilities()
+	if len(vulns) != 3 {
+		t.Fatalf("expected 3 vulnerabilities, got %+v", vulns)
+	}
+	ghsa := vulns[0]
+	if ghsa.ID != "GHSA-1111" || Severity(ghsa) != cdx.SeverityCritical || len(*ghsa.Affects) != 2 {
+		t.Errorf("GHSA vulnerability = %+v", ghsa)
+	}
+	if r := (*ghsa.Ratings)[0]; r.Method != cdx.ScoringMethodCVSSv31 || r.Vector == "" {
+		t.Errorf("rating = %+v", r)
+	}
+	if refs := *ghsa.References; refs[0].ID != "CVE-2023-0001" || refs[0].Source.Name != "NVD" {
+		t.Errorf("references = %+v", refs)
+	}
+	if adv := *ghsa.Advisories; len(adv) != 1 || adv[0].URL != "https://example.com/adv" {
+		t.Errorf("advisories = %+v", adv)
+	}
+	if v := vulns[2]; v.ID != "AIB-1" || Severity(v) != cdx.SeverityHigh || (*v.Affects)[0].Ref != "pkg:huggingface/org/model@abc" {
+		t.Errorf("feed vulnerability = %+v", v)
+	}
+
+	for policy, want := range map[string]int{FailOnNone: 0, FailOnAny: 5, "critical": 2, "high": 3, "low": 3} {
+		if got := len(report.Violations(policy)); got != want {
+			t.Errorf("Violations(%q) = %d, want %d", policy, got, want)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	bom := testBOM()
+	report := Report{Findings: []Finding{
+		{ComponentRef: "lib-a", Vulnerability: osvVulnerability(osvVuln{ID: "GHSA-1111"}, "lib-a")},
+		{ComponentRef: "lib-b", Vulnerability: osvVulnerability(osvVuln{ID: "GHSA-1111"}, "lib-b")},
+	}}
+	if n := Apply(bom, report); n != 1 {
+		t.Errorf("Apply wrote %d vulnerabilities, want 1", n)
+	}
+	got := *bom.Vulnerabilities
+	if len(got) != 2 || got[0].BOMRef != "other" || got[1].Description == "stale" || len(*got[1].Affects) != 2 {
+		t.Errorf("vulnerabilities = %+v", got)
+	}
+}
+
+func TestOSVQueryable(t *testing.T) {
+	tests := map[string]bool{
+		"pkg:pypi/transformers@4.30.0":          true,
+		"pkg:npm/%40huggingface/hub@0.1.0?x=y":  true,
+		"pkg:pypi/transformers":                 false,
+		"pkg:huggingface/org/model@abc":         false,
+		"pkg:generic/model.gguf@1":              false,
+		"https://example.com/model.safetensors": false,
+	}
+	for purl, want := range tests {
+		if got := osvQueryable(purl); got != want {
+			t.Errorf("osvQueryable(%q) = %v, want %v", purl, got, want)
+		}
+	}
+}
+
+func TestParseFailOn(t *testing.T) {
+	for _, s := range []string{"", "none", "any", "LOW", "critical"} {
+		if _, err := ParseFailOn(s); err != nil {
+			t.Errorf("ParseFailOn(%q): %v", s, err)
+		}
+	}
+	if _, err := ParseFailOn("severe"); err == nil {
+		t.Error("expected an error for an unknown policy")
+	}
+}
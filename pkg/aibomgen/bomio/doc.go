@@ -4,6 +4,9 @@
 // "auto", the format is inferred from the file extension (.json → JSON,.
 // .xml → XML). [WriteBOM] accepts an optional CycloneDX spec version string.
 // (e.g. "1.5") to downgrade the output; omitting it encodes with the version.
-// already set on the BOM. [WriteOutputFiles] writes one file per.
-// [generator.DiscoveredBOM], deriving filenames from the model component name.
+// already set on the BOM. The "spdx-json" format (and the .spdx.json.
+// extension) maps the BOM to and from an SPDX 3.0 document with the AI and.
+// Dataset profiles; see [EncodeSPDX] and [DecodeSPDX]. [WriteOutputFiles].
+// writes one file per [generator.DiscoveredBOM], deriving filenames from the.
+// model component name.
 package bomio
@@ -2,24 +2,53 @@ package bomio
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
 
 	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/internal/builder"
+	"github.com/idlab-discover/aibomgen-cli/internal/fsutil"
 	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/generator"
 )
 
-// ReadBOM reads a BOM from a file (JSON or XML).
-// The format parameter can be "json", "xml", or "auto" (default).
-// If "auto", the format is determined from the file extension.
+// ReadBOM reads a BOM from a file (JSON, XML or SPDX 3.0 JSON-LD).
+// The format parameter can be "json", "xml", "spdx-json", or "auto" (default).
+// If "auto", the format is determined from the file extension (.spdx.json.
+// selects SPDX).
 func ReadBOM(path string, format string) (*cdx.BOM, error) {
-	f, err := os.Open(path)
+	f, err := os.Open(fsutil.LongPath(path))
 	if err != nil {
 		return nil, err
 	}
 	defer f.Close()
 
+	actual, err := InputFormat(path, format)
+	if err != nil {
+		return nil, err
+	}
+	if actual == FormatSPDXJSON {
+		return DecodeSPDX(f)
+	}
+
+	fileFmt := cdx.BOMFileFormatJSON
+	if actual == "xml" {
+		fileFmt = cdx.BOMFileFormatXML
+	}
+
+	bom := new(cdx.BOM)
+	dec := cdx.NewBOMDecoder(f, fileFmt)
+	if err := dec.Decode(bom); err != nil {
+		return nil, err
+	}
+
+	return bom, nil
+}
+
+// InputFormat resolves the format ReadBOM reads path in: json, xml or.
+// spdx-json. "auto" (or "") selects it from the file extension.
+func InputFormat(path, format string) (string, error) {
 	actual := strings.ToLower(strings.TrimSpace(format))
 	switch actual {
 	case "", "auto":
@@ -28,46 +57,41 @@ func ReadBOM(path string, format string) (*cdx.BOM, error) {
 			actual = "xml"
 		case ".json":
 			actual = "json"
+			if isSPDXPath(path) {
+				actual = FormatSPDXJSON
+			}
 		default:
 			// keep existing behavior: default to JSON when not .xml.
 			actual = "json"
 		}
-	case "json", "xml":
+	case "json", "xml", FormatSPDXJSON:
 		// ok.
 	default:
-		return nil, fmt.Errorf("unsupported BOM format: %q", format)
-	}
-
-	fileFmt := cdx.BOMFileFormatJSON
-	if actual == "xml" {
-		fileFmt = cdx.BOMFileFormatXML
+		return "", fmt.Errorf("unsupported BOM format: %q", format)
 	}
-
-	bom := new(cdx.BOM)
-	dec := cdx.NewBOMDecoder(f, fileFmt)
-	if err := dec.Decode(bom); err != nil {
-		return nil, err
-	}
-
-	return bom, nil
+	return actual, nil
 }
 
 // WriteBOM writes a BOM to a file in the specified format.
-// The format parameter can be "json", "xml", or "auto" (default).
-// If "auto", the format is determined from the file extension.
-// If spec is provided, it encodes with that specific CycloneDX version.
+// The format parameter can be "json", "xml", "spdx-json", or "auto" (default).
+// If "auto", the format is determined from the file extension (.spdx.json.
+// selects SPDX). If spec is provided, it encodes with that specific CycloneDX.
+// version; it does not apply to SPDX.
 func WriteBOM(bom *cdx.BOM, outputPath string, format string, spec string) error {
 	ext := filepath.Ext(outputPath)
 
 	actual := strings.ToLower(strings.TrimSpace(format))
 	switch actual {
 	case "", "auto":
-		if strings.EqualFold(ext, ".xml") {
+		switch {
+		case strings.EqualFold(ext, ".xml"):
 			actual = "xml"
-		} else {
+		case isSPDXPath(outputPath):
+			actual = FormatSPDXJSON
+		default:
 			actual = "json"
 		}
-	case "json", "xml":
+	case "json", "xml", FormatSPDXJSON:
 		// ok.
 	default:
 		return fmt.Errorf("unsupported BOM format: %q", format)
@@ -79,7 +103,7 @@ func WriteBOM(bom *cdx.BOM, outputPath string, format string, spec string) error
 		if ext != ".xml" {
 			return fmt.Errorf("output path extension %q does not match format %q", ext, actual)
 		}
-	case "json":
+	case "json", FormatSPDXJSON:
 		if ext != ".json" {
 			return fmt.Errorf("output path extension %q does not match format %q", ext, actual)
 		}
@@ -90,13 +114,22 @@ func WriteBOM(bom *cdx.BOM, outputPath string, format string, spec string) error
 		fileFmt = cdx.BOMFileFormatXML
 	}
 
-	f, err := os.Create(outputPath)
+	f, err := os.Create(fsutil.LongPath(outputPath))
 	if err != nil {
 		return err
 	}
 	defer f.Close()
 
-	encoder := cdx.NewBOMEncoder(f, fileFmt)
+	if actual == FormatSPDXJSON {
+		return EncodeSPDX(f, bom)
+	}
+	return EncodeBOM(f, bom, fileFmt, spec)
+}
+
+// EncodeBOM writes bom to w in the given file format. If spec is provided, it.
+// encodes with that specific CycloneDX version.
+func EncodeBOM(w io.Writer, bom *cdx.BOM, fileFmt cdx.BOMFileFormat, spec string) error {
+	encoder := cdx.NewBOMEncoder(w, fileFmt)
 	encoder.SetPretty(true)
 
 	if spec == "" {
@@ -183,9 +216,27 @@ func ParseSpecVersion(s string) (cdx.SpecVersion, bool) {
 	}
 }
 
+// maxFileNameStem caps the sanitized component name used in output file names.
+const maxFileNameStem = 200
+
+// ChainOptions controls how WriteOutputFiles continues the revision history of.
+// BOMs written before (see builder.ChainBOMVersion).
+type ChainOptions struct {
+	// Disabled writes every BOM as a new document with a fresh serial number.
+	Disabled bool
+	// PreviousDir holds the previous revisions, under the same file names.
+	// Empty means the output directory, i.e. the files being replaced.
+	PreviousDir string
+	// Compare, when set, is called with each BOM and its previous revision.
+	// before they are chained, e.g. to report completeness regressions.
+	Compare func(bom, prev *cdx.BOM)
+}
+
 // WriteOutputFiles writes BOM files to disk and returns the list of written paths.
-// Each BOM is written to a separate file named after the component.
-func WriteOutputFiles(discoveredBOMs []generator.DiscoveredBOM, outputDir, fileExt, format, specVersion string) ([]string, error) {
+// Each BOM is written to a separate file named after the component. Unless.
+// chaining is disabled, a BOM that replaces an earlier revision keeps its.
+// serial number and gets the next version.
+func WriteOutputFiles(discoveredBOMs []generator.DiscoveredBOM, outputDir, fileExt, format, specVersion string, chain ChainOptions) ([]string, error) {
 	written := make([]string, 0, len(discoveredBOMs))
 	for _, d := range discoveredBOMs {
 		// Extract component name from BOM metadata.
@@ -219,13 +270,22 @@ func WriteOutputFiles(discoveredBOMs []generator.DiscoveredBOM, outputDir, fileE
 			}
 		}
 		sanitized := b.String()
+		// Keep the file name well within the 255-byte component limit shared.
+		// by common filesystems.
+		if len(sanitized) > maxFileNameStem {
+			sanitized = sanitized[:maxFileNameStem]
+		}
 		if sanitized == "" {
 			sanitized = "model"
 		}
 
 		fileName := fmt.Sprintf("%s_aibom%s", sanitized, fileExt)
-		dest := filepath.Join(outputDir, fileName)
+		// Accept forward slashes in --output on every platform.
+		dest := filepath.Join(filepath.FromSlash(outputDir), fileName)
 
+		if !chain.Disabled {
+			chainPrevious(d.BOM, fileName, dest, format, chain)
+		}
 		if err := WriteBOM(d.BOM, dest, format, specVersion); err != nil {
 			return written, err
 		}
@@ -233,3 +293,21 @@ func WriteOutputFiles(discoveredBOMs []generator.DiscoveredBOM, outputDir, fileE
 	}
 	return written, nil
 }
+
+// chainPrevious chains bom to the previous revision stored as fileName in.
+// chain.PreviousDir, or at dest when it is empty. A missing or unreadable.
+// previous revision simply starts a new history.
+func chainPrevious(bom *cdx.BOM, fileName, dest, format string, chain ChainOptions) {
+	path := dest
+	if strings.TrimSpace(chain.PreviousDir) != "" {
+		path = filepath.Join(filepath.FromSlash(chain.PreviousDir), fileName)
+	}
+	prev, err := ReadBOM(path, format)
+	if err != nil {
+		return
+	}
+	if chain.Compare != nil {
+		chain.Compare(bom, prev)
+	}
+	builder.ChainBOMVersion(bom, prev)
+}
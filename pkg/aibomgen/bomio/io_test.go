@@ -3,9 +3,11 @@ package bomio
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/generator"
 )
 
 func minimalBOM() *cdx.BOM {
@@ -48,6 +50,27 @@ func TestParseSpecVersion_AllCases(t *testing.T) {
 	}
 }
 
+func TestInputFormat(t *testing.T) {
+	tcs := []struct {
+		path, format, want string
+	}{
+		{"bom.json", "auto", "json"},
+		{"bom.XML", "", "xml"},
+		{"bom.spdx.json", "auto", FormatSPDXJSON},
+		{"bom", "auto", "json"},
+		{"bom.json", " XML ", "xml"},
+	}
+	for _, tc := range tcs {
+		got, err := InputFormat(tc.path, tc.format)
+		if err != nil || got != tc.want {
+			t.Errorf("InputFormat(%q, %q) = (%q, %v), want %q", tc.path, tc.format, got, err, tc.want)
+		}
+	}
+	if _, err := InputFormat("bom.json", "yaml"); err == nil {
+		t.Error("InputFormat(yaml) succeeded, want an error")
+	}
+}
+
 func TestReadBOM_OpenError(t *testing.T) {
 	_, err := ReadBOM(filepath.Join(t.TempDir(), "missing.json"), "auto")
 	if err == nil {
@@ -257,3 +280,70 @@ func TestWriteBOM_Auto_UppercaseXMLExtension_HitsEqualFoldThenValidationMismatch
 		t.Fatalf("expected error for uppercase .XML extension validation mismatch")
 	}
 }
+
+func TestWriteOutputFiles_SlashOutputDirAndLongNames(t *testing.T) {
+	dir := t.TempDir()
+	sub := filepath.Join(dir, "nested", "out")
+	if err := os.MkdirAll(sub, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	bom := minimalBOM()
+	bom.Metadata = &cdx.Metadata{Component: &cdx.Component{Name: strings.Repeat("a", 300)}}
+	written, err := WriteOutputFiles(
+		[]generator.DiscoveredBOM{{BOM: bom}},
+		filepath.ToSlash(dir)+"/nested/out", ".json", "json", "", ChainOptions{},
+	)
+	if err != nil {
+		t.Fatalf("WriteOutputFiles: %v", err)
+	}
+	if len(written) != 1 {
+		t.Fatalf("expected 1 file, got %d", len(written))
+	}
+	if got := filepath.Dir(written[0]); got != sub {
+		t.Fatalf("output dir = %q, want %q", got, sub)
+	}
+	if base := filepath.Base(written[0]); len(base) > 255 {
+		t.Fatalf("file name too long: %d bytes", len(base))
+	}
+}
+
+func TestWriteOutputFiles_ChainsPreviousRevision(t *testing.T) {
+	dir := t.TempDir()
+	newBOM := func(desc string) *cdx.BOM {
+		bom := minimalBOM()
+		bom.SerialNumber = "urn:uuid:" + desc
+		bom.Metadata = &cdx.Metadata{Component: &cdx.Component{Type: cdx.ComponentTypeMachineLearningModel, Name: "org/model", Description: desc}}
+		return bom
+	}
+	write := func(bom *cdx.BOM, chain ChainOptions) *cdx.BOM {
+		t.Helper()
+		written, err := WriteOutputFiles([]generator.DiscoveredBOM{{BOM: bom}}, dir, ".json", "json", "", chain)
+		if err != nil {
+			t.Fatalf("WriteOutputFiles: %v", err)
+		}
+		got, err := ReadBOM(written[0], "json")
+		if err != nil {
+			t.Fatalf("ReadBOM: %v", err)
+		}
+		return got
+	}
+
+	first := write(newBOM("first"), ChainOptions{})
+	if first.Version != 1 || first.SerialNumber != "urn:uuid:first" {
+		t.Fatalf("first revision: serial %q version %d", first.SerialNumber, first.Version)
+	}
+	var compared string
+	compare := func(bom, prev *cdx.BOM) { compared = prev.Metadata.Component.Description }
+	second := write(newBOM("second"), ChainOptions{Compare: compare})
+	if second.Version != 2 || second.SerialNumber != first.SerialNumber {
+		t.Fatalf("second revision: serial %q version %d", second.SerialNumber, second.Version)
+	}
+	if compared != "first" {
+		t.Errorf("Compare was not called with the previous revision (got %q)", compared)
+	}
+	fresh := write(newBOM("third"), ChainOptions{Disabled: true})
+	if fresh.Version != 1 || fresh.SerialNumber != "urn:uuid:third" {
+		t.Fatalf("unchained BOM: serial %q version %d", fresh.SerialNumber, fresh.Version)
+	}
+}
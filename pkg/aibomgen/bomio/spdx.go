@@ -0,0 +1,1022 @@
+package bomio
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/google/uuid"
+	"github.com/idlab-discover/aibomgen-cli/internal/taxonomy"
+)
+
+// FormatSPDXJSON selects the SPDX 3.0 JSON-LD serialisation in [ReadBOM] and.
+// [WriteBOM]. The mapping between the CycloneDX model and SPDX 3.0 is.
+// documented in docs/spdx-mapping.md; fields without an SPDX counterpart are.
+// dropped.
+const FormatSPDXJSON = "spdx-json"
+
+// spdxExt is the file name suffix that selects SPDX when the format is "auto".
+const spdxExt = ".spdx.json"
+
+const (
+	spdxContext      = "https://spdx.org/rdf/3.0.1/spdx-context.jsonld"
+	spdxSpecVersion  = "3.0.1"
+	spdxCreationInfo = "_:creationinfo"
+)
+
+// isSPDXPath reports whether path carries the .spdx.json suffix.
+func isSPDXPath(path string) bool {
+	return strings.HasSuffix(strings.ToLower(path), spdxExt)
+}
+
+// ── Mapping tables ───────────────────────────────────────────────────────────.
+
+// spdxHashAlgorithms maps CycloneDX hash algorithms to SPDX HashAlgorithm.
+// values.
+var spdxHashAlgorithms = map[cdx.HashAlgorithm]string{
+	cdx.HashAlgoMD5:         "md5",
+	cdx.HashAlgoSHA1:        "sha1",
+	cdx.HashAlgoSHA256:      "sha256",
+	cdx.HashAlgoSHA384:      "sha384",
+	cdx.HashAlgoSHA512:      "sha512",
+	cdx.HashAlgoSHA3_256:    "sha3_256",
+	cdx.HashAlgoSHA3_384:    "sha3_384",
+	cdx.HashAlgoSHA3_512:    "sha3_512",
+	cdx.HashAlgoBlake2b_256: "blake2b256",
+	cdx.HashAlgoBlake2b_384: "blake2b384",
+	cdx.HashAlgoBlake2b_512: "blake2b512",
+	cdx.HashAlgoBlake3:      "blake3",
+}
+
+// spdxExternalRefTypes maps CycloneDX external reference types to SPDX.
+// ExternalRefType values. The first website and distribution references.
+// become software_homePage and software_downloadLocation instead.
+var spdxExternalRefTypes = map[cdx.ExternalReferenceType]string{
+	cdx.ERTypeAdvisories:              "securityAdvisory",
+	cdx.ERTypeBuildMeta:               "buildMeta",
+	cdx.ERTypeBuildSystem:             "buildSystem",
+	cdx.ERTypeCertificationReport:     "certificationReport",
+	cdx.ERTypeChat:                    "chat",
+	cdx.ERTypeComponentAnalysisReport: "componentAnalysisReport",
+	cdx.ERTypeDistribution:            "altDownloadLocation",
+	cdx.ERTypeDocumentation:           "documentation",
+	cdx.ERTypeDynamicAnalysisReport:   "dynamicAnalysisReport",
+	cdx.ERTypeIssueTracker:            "issueTracker",
+	cdx.ERTypeLicense:                 "license",
+	cdx.ERTypeMailingList:             "mailingList",
+	cdx.ERTypeOther:                   "other",
+	cdx.ERTypePentestReport:           "securityPenTestReport",
+	cdx.ERTypeQualityMetrics:          "qualityAssessmentReport",
+	cdx.ERTypeReleaseNotes:            "releaseNotes",
+	cdx.ERTypeRiskAssessment:          "riskAssessment",
+	cdx.ERTypeRuntimeAnalysisReport:   "runtimeAnalysisReport",
+	cdx.ERTypeSecurityContact:         "securityOther",
+	cdx.ERTypeSocial:                  "socialMedia",
+	cdx.ERTypeSourceDistribution:      "sourceArtifact",
+	cdx.ERTypeStaticAnalysisReport:    "staticAnalysisReport",
+	cdx.ERTypeSupport:                 "support",
+	cdx.ERTypeThreatModel:             "securityThreatModel",
+	cdx.ERTypeVCS:                     "vcs",
+	cdx.ERTypeWebsite:                 "altWebPage",
+}
+
+// spdxSbomTypes maps CycloneDX lifecycle phases to SPDX software_SbomType.
+// values.
+var spdxSbomTypes = map[cdx.LifecyclePhase]string{
+	cdx.LifecyclePhaseDesign:     "design",
+	cdx.LifecyclePhasePreBuild:   "source",
+	cdx.LifecyclePhaseBuild:      "build",
+	cdx.LifecyclePhasePostBuild:  "analyzed",
+	cdx.LifecyclePhaseOperations: "deployed",
+	cdx.LifecyclePhaseDiscovery:  "runtime",
+}
+
+// spdxEnergyActivities maps CycloneDX energy consumption activities to the.
+// ai_EnergyConsumption property that holds them. Other activities are dropped.
+var spdxEnergyActivities = map[cdx.MLModelEnergyConsumptionActivity]string{
+	cdx.MLModelEnergyConsumptionActivityTraining:   "training",
+	cdx.MLModelEnergyConsumptionActivityFineTuning: "finetuning",
+	cdx.MLModelEnergyConsumptionActivityInference:  "inference",
+}
+
+// Reverse tables, built from the ones above.
+var (
+	cdxHashAlgorithms     = invert(spdxHashAlgorithms)
+	cdxExternalRefTypes   = invert(spdxExternalRefTypes)
+	cdxLifecyclePhases    = invert(spdxSbomTypes)
+	cdxEnergyActivities   = invert(spdxEnergyActivities)
+	spdxLicenseIDPattern  = regexp.MustCompile(`^[A-Za-z0-9.\-+]+$`)
+	spdxLicenseRefInvalid = regexp.MustCompile(`[^A-Za-z0-9.\-]+`)
+)
+
+func invert[K, V comparable](m map[K]V) map[V]K {
+	out := make(map[V]K, len(m))
+	for k, v := range m {
+		out[v] = k
+	}
+	return out
+}
+
+// spdxDatasetTypes derives dataset_datasetType from keywords of the CycloneDX.
+// data classification, in order.
+var spdxDatasetTypes = []struct{ keyword, datasetType string }{
+	{"image", "image"},
+	{"video", "video"},
+	{"audio", "audio"},
+	{"speech", "audio"},
+	{"tabular", "structured"},
+	{"time-series", "timeseries"},
+	{"graph", "graph"},
+	{"text", "text"},
+	{"translation", "text"},
+	{"question", "text"},
+	{"summarization", "text"},
+}
+
+// ── SPDX 3.0 JSON-LD model ───────────────────────────────────────────────────.
+
+type spdxDocument struct {
+	Context string        `json:"@context"`
+	Graph   []spdxElement `json:"@graph"`
+}
+
+// spdxElement holds the union of the element properties aibomgen-cli maps.
+// The type property says which of them apply.
+type spdxElement struct {
+	Type         string  `json:"type"`
+	ID           string  `json:"@id,omitempty"`
+	SpdxID       string  `json:"spdxId,omitempty"`
+	CreationInfo spdxRef `json:"creationInfo,omitempty"`
+	Name         string  `json:"name,omitempty"`
+	Description  string  `json:"description,omitempty"`
+
+	// CreationInfo.
+	SpecVersion  string   `json:"specVersion,omitempty"`
+	Created      string   `json:"created,omitempty"`
+	CreatedBy    []string `json:"createdBy,omitempty"`
+	CreatedUsing []string `json:"createdUsing,omitempty"`
+
+	// SpdxDocument and software_Sbom.
+	RootElement        []string `json:"rootElement,omitempty"`
+	Element            []string `json:"element,omitempty"`
+	ProfileConformance []string `json:"profileConformance,omitempty"`
+	SbomType           []string `json:"software_sbomType,omitempty"`
+
+	// Artifact and software_Package.
+	SuppliedBy       string            `json:"suppliedBy,omitempty"`
+	OriginatedBy     []string          `json:"originatedBy,omitempty"`
+	ReleaseTime      string            `json:"releaseTime,omitempty"`
+	VerifiedUsing    []spdxHash        `json:"verifiedUsing,omitempty"`
+	ExternalRef      []spdxExternalRef `json:"externalRef,omitempty"`
+	PackageVersion   string            `json:"software_packageVersion,omitempty"`
+	PackageURL       string            `json:"software_packageUrl,omitempty"`
+	DownloadLocation string            `json:"software_downloadLocation,omitempty"`
+	HomePage         string            `json:"software_homePage,omitempty"`
+	PrimaryPurpose   string            `json:"software_primaryPurpose,omitempty"`
+
+	// ai_AIPackage.
+	Domain                   []string               `json:"ai_domain,omitempty"`
+	TypeOfModel              []string               `json:"ai_typeOfModel,omitempty"`
+	Metric                   []spdxDictionaryEntry  `json:"ai_metric,omitempty"`
+	InformationAboutApp      string                 `json:"ai_informationAboutApplication,omitempty"`
+	InformationAboutTraining string                 `json:"ai_informationAboutTraining,omitempty"`
+	Limitation               string                 `json:"ai_limitation,omitempty"`
+	EnergyConsumption        *spdxEnergyConsumption `json:"ai_energyConsumption,omitempty"`
+
+	// dataset_DatasetPackage.
+	DatasetType                     []string `json:"dataset_datasetType,omitempty"`
+	IntendedUse                     string   `json:"dataset_intendedUse,omitempty"`
+	HasSensitivePersonalInformation string   `json:"dataset_hasSensitivePersonalInformation,omitempty"`
+	DatasetSize                     int64    `json:"dataset_datasetSize,omitempty"`
+
+	// Relationship.
+	From             string   `json:"from,omitempty"`
+	RelationshipType string   `json:"relationshipType,omitempty"`
+	To               []string `json:"to,omitempty"`
+
+	// simplelicensing_LicenseExpression.
+	LicenseExpression string `json:"simplelicensing_licenseExpression,omitempty"`
+}
+
+// spdxRef is a reference to another node. Inline nodes are accepted when.
+// reading and resolve to their @id, if any.
+type spdxRef string
+
+func (r *spdxRef) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*r = spdxRef(s)
+		return nil
+	}
+	var node struct {
+		ID string `json:"@id"`
+	}
+	if err := json.Unmarshal(b, &node); err != nil {
+		return err
+	}
+	*r = spdxRef(node.ID)
+	return nil
+}
+
+type spdxHash struct {
+	Type      string `json:"type"`
+	Algorithm string `json:"algorithm"`
+	HashValue string `json:"hashValue"`
+}
+
+type spdxExternalRef struct {
+	Type            string   `json:"type"`
+	ExternalRefType string   `json:"externalRefType"`
+	Locator         []string `json:"locator"`
+}
+
+type spdxDictionaryEntry struct {
+	Type  string `json:"type"`
+	Key   string `json:"key"`
+	Value string `json:"value,omitempty"`
+}
+
+type spdxEnergyConsumption struct {
+	Type       string                  `json:"type"`
+	Training   []spdxEnergyDescription `json:"ai_trainingEnergyConsumption,omitempty"`
+	Finetuning []spdxEnergyDescription `json:"ai_finetuningEnergyConsumption,omitempty"`
+	Inference  []spdxEnergyDescription `json:"ai_inferenceEnergyConsumption,omitempty"`
+}
+
+type spdxEnergyDescription struct {
+	Type     string  `json:"type"`
+	Quantity float64 `json:"ai_energyQuantity"`
+	Unit     string  `json:"ai_energyUnit"`
+}
+
+// activity returns the description list for the given SPDX activity name.
+func (e *spdxEnergyConsumption) activity(name string) *[]spdxEnergyDescription {
+	switch name {
+	case "training":
+		return &e.Training
+	case "finetuning":
+		return &e.Finetuning
+	default:
+		return &e.Inference
+	}
+}
+
+// ── CycloneDX → SPDX ─────────────────────────────────────────────────────────.
+
+// EncodeSPDX writes bom to w as an SPDX 3.0 JSON-LD document. Model.
+// components become ai_AIPackage elements, data components.
+// dataset_DatasetPackage elements and all other components software_Package.
+// elements. Element IDs are derived from the BOM serial number.
+func EncodeSPDX(w io.Writer, bom *cdx.BOM) error {
+	if bom == nil {
+		return fmt.Errorf("nil BOM")
+	}
+	doc := newSPDXWriter(bom).document()
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(doc)
+}
+
+type spdxWriter struct {
+	bom   *cdx.BOM
+	ns    string
+	graph []spdxElement
+	ids   map[string]string // bom-ref → spdxId
+	types map[string]cdx.ComponentType
+	seq   map[string]int
+}
+
+func newSPDXWriter(bom *cdx.BOM) *spdxWriter {
+	ns := strings.TrimSpace(bom.SerialNumber)
+	if ns == "" {
+		ns = "urn:uuid:" + uuid.NewString()
+	}
+	return &spdxWriter{
+		bom:   bom,
+		ns:    ns,
+		ids:   make(map[string]string),
+		types: make(map[string]cdx.ComponentType),
+		seq:   make(map[string]int),
+	}
+}
+
+// id returns the next element ID of the given kind.
+func (w *spdxWriter) id(kind string) string {
+	w.seq[kind]++
+	return fmt.Sprintf("%s#SPDXRef-%s-%d", w.ns, kind, w.seq[kind])
+}
+
+func (w *spdxWriter) add(e spdxElement) string {
+	if e.Type != "CreationInfo" {
+		e.CreationInfo = spdxCreationInfo
+	}
+	w.graph = append(w.graph, e)
+	return e.SpdxID
+}
+
+func (w *spdxWriter) document() spdxDocument {
+	bom := w.bom
+	info := spdxElement{Type: "CreationInfo", ID: spdxCreationInfo, SpecVersion: spdxSpecVersion}
+	var root *cdx.Component
+	if bom.Metadata != nil {
+		root = bom.Metadata.Component
+		info.Created = bom.Metadata.Timestamp
+		info.CreatedBy = w.creators(bom.Metadata)
+		if bom.Metadata.Tools != nil && bom.Metadata.Tools.Components != nil {
+			for _, t := range *bom.Metadata.Tools.Components {
+				info.CreatedUsing = append(info.CreatedUsing, w.add(spdxElement{Type: "Tool", SpdxID: w.id("Tool"), Name: t.Name}))
+			}
+		}
+	}
+	if info.Created == "" {
+		info.Created = time.Now().UTC().Format(time.RFC3339)
+	}
+	if len(info.CreatedBy) == 0 {
+		info.CreatedBy = []string{w.add(spdxElement{Type: "Organization", SpdxID: w.id("Organization"), Name: "aibomgen-cli"})}
+	}
+
+	var rootID string
+	if root != nil {
+		rootID = w.component(root)
+	}
+	if bom.Components != nil {
+		for i := range *bom.Components {
+			w.component(&(*bom.Components)[i])
+		}
+	}
+	w.relationships(root)
+
+	var elements []string
+	for _, e := range w.graph {
+		elements = append(elements, e.SpdxID)
+	}
+	sbom := spdxElement{Type: "software_Sbom", SpdxID: w.ns + "#SPDXRef-Sbom", Element: elements}
+	if rootID != "" {
+		sbom.RootElement = []string{rootID}
+	}
+	if bom.Metadata != nil && bom.Metadata.Lifecycles != nil {
+		for _, lc := range *bom.Metadata.Lifecycles {
+			if t, ok := spdxSbomTypes[lc.Phase]; ok {
+				sbom.SbomType = append(sbom.SbomType, t)
+			}
+		}
+	}
+	w.add(sbom)
+
+	name := "AIBOM"
+	if root != nil && root.Name != "" {
+		name = root.Name + " AIBOM"
+	}
+	w.add(spdxElement{
+		Type:               "SpdxDocument",
+		SpdxID:             w.ns + "#SPDXRef-Document",
+		Name:               name,
+		RootElement:        []string{sbom.SpdxID},
+		Element:            append(elements, sbom.SpdxID),
+		ProfileConformance: []string{"core", "software", "simpleLicensing", "ai", "dataset"},
+	})
+
+	return spdxDocument{Context: spdxContext, Graph: append([]spdxElement{info}, w.graph...)}
+}
+
+// creators maps the BOM authors, or else its manufacturer, supplier or the.
+// manufacturer of a tool, to agents.
+func (w *spdxWriter) creators(md *cdx.Metadata) []string {
+	var out []string
+	if md.Authors != nil {
+		for _, a := range *md.Authors {
+			if a.Name != "" {
+				out = append(out, w.add(spdxElement{Type: "Person", SpdxID: w.id("Person"), Name: a.Name}))
+			}
+		}
+	}
+	if len(out) > 0 {
+		return out
+	}
+	orgs := []*cdx.OrganizationalEntity{md.Manufacturer, md.Supplier}
+	if md.Tools != nil && md.Tools.Components != nil {
+		for _, t := range *md.Tools.Components {
+			orgs = append(orgs, t.Manufacturer)
+		}
+	}
+	for _, org := range orgs {
+		if org != nil && org.Name != "" {
+			return []string{w.add(spdxElement{Type: "Organization", SpdxID: w.id("Organization"), Name: org.Name})}
+		}
+	}
+	return nil
+}
+
+// component adds the package element for comp and returns its ID.
+func (w *spdxWriter) component(comp *cdx.Component) string {
+	e := spdxElement{
+		Name:           comp.Name,
+		Description:    comp.Description,
+		PackageVersion: comp.Version,
+		PackageURL:     comp.PackageURL,
+	}
+	switch comp.Type {
+	case cdx.ComponentTypeMachineLearningModel:
+		e.Type, e.SpdxID, e.PrimaryPurpose = "ai_AIPackage", w.id("AIPackage"), "model"
+		w.modelCard(&e, comp.ModelCard)
+	case cdx.ComponentTypeData:
+		e.Type, e.SpdxID, e.PrimaryPurpose = "dataset_DatasetPackage", w.id("DatasetPackage"), "data"
+		w.datasetFields(&e, comp)
+	default:
+		e.Type, e.SpdxID = "software_Package", w.id("Package")
+		if comp.Type == cdx.ComponentTypeApplication || comp.Type == cdx.ComponentTypeLibrary {
+			e.PrimaryPurpose = string(comp.Type)
+		}
+	}
+
+	if comp.Manufacturer != nil && comp.Manufacturer.Name != "" {
+		e.SuppliedBy = w.add(spdxElement{Type: "Organization", SpdxID: w.id("Organization"), Name: comp.Manufacturer.Name})
+	}
+	if comp.Authors != nil {
+		for _, a := range *comp.Authors {
+			if a.Name != "" {
+				e.OriginatedBy = append(e.OriginatedBy, w.add(spdxElement{Type: "Person", SpdxID: w.id("Person"), Name: a.Name}))
+			}
+		}
+	}
+	if v, ok := taxonomy.Get(comp.Properties, taxonomy.HFCreatedAt); ok {
+		e.ReleaseTime = spdxTime(v)
+	}
+	if comp.Hashes != nil {
+		for _, h := range *comp.Hashes {
+			if alg, ok := spdxHashAlgorithms[h.Algorithm]; ok && h.Value != "" {
+				e.VerifiedUsing = append(e.VerifiedUsing, spdxHash{Type: "Hash", Algorithm: alg, HashValue: h.Value})
+			}
+		}
+	}
+	if comp.ExternalReferences != nil {
+		for _, ref := range *comp.ExternalReferences {
+			switch {
+			case ref.URL == "":
+				continue
+			case ref.Type == cdx.ERTypeWebsite && e.HomePage == "":
+				e.HomePage = ref.URL
+			case ref.Type == cdx.ERTypeDistribution && e.DownloadLocation == "":
+				e.DownloadLocation = ref.URL
+			default:
+				t, ok := spdxExternalRefTypes[ref.Type]
+				if !ok {
+					t = "other"
+				}
+				e.ExternalRef = append(e.ExternalRef, spdxExternalRef{Type: "ExternalRef", ExternalRefType: t, Locator: []string{ref.URL}})
+			}
+		}
+	}
+
+	if comp.BOMRef != "" {
+		w.ids[comp.BOMRef] = e.SpdxID
+		w.types[comp.BOMRef] = comp.Type
+	}
+	id := w.add(e)
+	w.licenses(id, comp.Licenses)
+	return id
+}
+
+// modelCard maps the CycloneDX model card onto the ai_AIPackage properties.
+func (w *spdxWriter) modelCard(e *spdxElement, mc *cdx.MLModelCard) {
+	if mc == nil {
+		return
+	}
+	if mp := mc.ModelParameters; mp != nil {
+		if mp.Task != "" {
+			e.Domain = []string{mp.Task}
+		}
+		for _, v := range []string{mp.ArchitectureFamily, mp.ModelArchitecture} {
+			if v != "" {
+				e.TypeOfModel = append(e.TypeOfModel, v)
+			}
+		}
+		if mp.Approach != nil && mp.Approach.Type != "" {
+			e.InformationAboutTraining = string(mp.Approach.Type)
+		}
+	}
+	if qa := mc.QuantitativeAnalysis; qa != nil && qa.PerformanceMetrics != nil {
+		for _, m := range *qa.PerformanceMetrics {
+			if m.Type != "" {
+				e.Metric = append(e.Metric, spdxDictionaryEntry{Type: "DictionaryEntry", Key: m.Type, Value: m.Value})
+			}
+		}
+	}
+	c := mc.Considerations
+	if c == nil {
+		return
+	}
+	if c.UseCases != nil {
+		e.InformationAboutApp = strings.Join(*c.UseCases, "\n")
+	}
+	if c.TechnicalLimitations != nil {
+		e.Limitation = strings.Join(*c.TechnicalLimitations, "\n")
+	}
+	if env := c.EnvironmentalConsiderations; env != nil && env.EnergyConsumptions != nil {
+		ec := &spdxEnergyConsumption{Type: "ai_EnergyConsumption"}
+		n := 0
+		for _, c := range *env.EnergyConsumptions {
+			activity, ok := spdxEnergyActivities[c.Activity]
+			if !ok || c.ActivityEnergyCost.Unit != cdx.MLModelEnergyUnitKWH {
+				continue
+			}
+			list := ec.activity(activity)
+			*list = append(*list, spdxEnergyDescription{Type: "ai_EnergyConsumptionDescription", Quantity: float64(c.ActivityEnergyCost.Value), Unit: "kilowattHour"})
+			n++
+		}
+		if n > 0 {
+			e.EnergyConsumption = ec
+		}
+	}
+}
+
+// datasetFields maps the first data entry of comp onto the.
+// dataset_DatasetPackage properties.
+func (w *spdxWriter) datasetFields(e *spdxElement, comp *cdx.Component) {
+	if v, ok := taxonomy.Get(comp.Properties, taxonomy.HFUsedStorage); ok {
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil && n > 0 {
+			e.DatasetSize = n
+		}
+	}
+	var data *cdx.ComponentData
+	if comp.Data != nil && len(*comp.Data) > 0 {
+		data = &(*comp.Data)[0]
+	}
+	if data != nil {
+		if e.Description == "" {
+			e.Description = data.Description
+		}
+		e.IntendedUse = data.Classification
+		if data.SensitiveData != nil && len(*data.SensitiveData) > 0 {
+			e.HasSensitivePersonalInformation = "yes"
+		}
+		if data.Contents != nil && data.Contents.URL != "" {
+			e.DownloadLocation = data.Contents.URL
+		}
+	}
+	// dataset_datasetType is mandatory; derive it from the classification.
+	e.DatasetType = []string{"noAssertion"}
+	if data != nil {
+		class := strings.ToLower(data.Classification)
+		for _, t := range spdxDatasetTypes {
+			if strings.Contains(class, t.keyword) {
+				e.DatasetType = []string{t.datasetType}
+				break
+			}
+		}
+	}
+}
+
+// licenses links the package to its declared license expression.
+func (w *spdxWriter) licenses(pkgID string, lc *cdx.Licenses) {
+	if lc == nil {
+		return
+	}
+	var terms []string
+	for _, l := range *lc {
+		switch {
+		case l.Expression != "":
+			terms = append(terms, l.Expression)
+		case l.License != nil && l.License.ID != "":
+			terms = append(terms, l.License.ID)
+		case l.License != nil && l.License.Name != "":
+			terms = append(terms, spdxLicenseTerm(l.License.Name))
+		}
+	}
+	if len(terms) == 0 {
+		return
+	}
+	if len(terms) > 1 {
+		for i, t := range terms {
+			if strings.Contains(t, " ") {
+				terms[i] = "(" + t + ")"
+			}
+		}
+	}
+	expr := strings.Join(terms, " AND ")
+	licID := w.add(spdxElement{Type: "simplelicensing_LicenseExpression", SpdxID: w.id("License"), LicenseExpression: expr})
+	w.add(spdxElement{Type: "Relationship", SpdxID: w.id("Relationship"), From: pkgID, RelationshipType: "hasDeclaredLicense", To: []string{licID}})
+}
+
+// spdxLicenseTerm turns a free-form license name into a valid expression term.
+func spdxLicenseTerm(name string) string {
+	name = strings.TrimSpace(name)
+	if spdxLicenseIDPattern.MatchString(name) {
+		return name
+	}
+	return "LicenseRef-" + strings.Trim(spdxLicenseRefInvalid.ReplaceAllString(name, "-"), "-")
+}
+
+// relationships maps the dependency graph and the model's dataset references.
+// A model depending on a data component becomes trainedOn, every other edge.
+// dependsOn.
+func (w *spdxWriter) relationships(root *cdx.Component) {
+	type edge struct{ from, kind string }
+	targets := make(map[edge][]string)
+	var order []edge
+	link := func(fromRef, toRef string) {
+		from, ok1 := w.ids[fromRef]
+		to, ok2 := w.ids[toRef]
+		if !ok1 || !ok2 {
+			return
+		}
+		kind := "dependsOn"
+		if w.types[fromRef] == cdx.ComponentTypeMachineLearningModel && w.types[toRef] == cdx.ComponentTypeData {
+			kind = "trainedOn"
+		}
+		k := edge{from, kind}
+		for _, t := range targets[k] {
+			if t == to {
+				return
+			}
+		}
+		if _, seen := targets[k]; !seen {
+			order = append(order, k)
+		}
+		targets[k] = append(targets[k], to)
+	}
+
+	if w.bom.Dependencies != nil {
+		for _, d := range *w.bom.Dependencies {
+			if d.Dependencies != nil {
+				for _, to := range *d.Dependencies {
+					link(d.Ref, to)
+				}
+			}
+		}
+	}
+	if root != nil && root.ModelCard != nil && root.ModelCard.ModelParameters != nil && root.ModelCard.ModelParameters.Datasets != nil {
+		for _, ds := range *root.ModelCard.ModelParameters.Datasets {
+			link(root.BOMRef, ds.Ref)
+		}
+	}
+	for _, k := range order {
+		w.add(spdxElement{Type: "Relationship", SpdxID: w.id("Relationship"), From: k.from, RelationshipType: k.kind, To: targets[k]})
+	}
+}
+
+// spdxTime normalises a timestamp to the SPDX DateTime form (UTC, seconds).
+func spdxTime(v string) string {
+	t, err := time.Parse(time.RFC3339Nano, v)
+	if err != nil {
+		return v
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// ── SPDX → CycloneDX ─────────────────────────────────────────────────────────.
+
+// DecodeSPDX reads an SPDX 3.0 JSON-LD document into a CycloneDX BOM, using.
+// the reverse of the mapping EncodeSPDX applies.
+func DecodeSPDX(r io.Reader) (*cdx.BOM, error) {
+	var doc spdxDocument
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, err
+	}
+	if !strings.Contains(doc.Context, "spdx.org/rdf/3.") {
+		return nil, fmt.Errorf("not an SPDX 3 JSON-LD document (@context %q)", doc.Context)
+	}
+
+	byID := make(map[string]*spdxElement, len(doc.Graph))
+	var info, document, sbom *spdxElement
+	for i := range doc.Graph {
+		e := &doc.Graph[i]
+		if e.SpdxID != "" {
+			byID[e.SpdxID] = e
+		}
+		switch e.Type {
+		case "CreationInfo":
+			if info == nil {
+				info = e
+			}
+		case "SpdxDocument":
+			document = e
+		case "software_Sbom":
+			if sbom == nil {
+				sbom = e
+			}
+		}
+	}
+
+	bom := cdx.NewBOM()
+	bom.SpecVersion = cdx.SpecVersion1_6
+	bom.Metadata = &cdx.Metadata{}
+	if document != nil {
+		if ns, _, ok := strings.Cut(document.SpdxID, "#"); ok && strings.HasPrefix(ns, "urn:uuid:") {
+			bom.SerialNumber = ns
+		}
+	}
+	if info != nil {
+		bom.Metadata.Timestamp = info.Created
+		var tools []cdx.Component
+		for _, id := range info.CreatedUsing {
+			if t := byID[id]; t != nil && t.Name != "" {
+				tools = append(tools, cdx.Component{Type: cdx.ComponentTypeApplication, Name: t.Name})
+			}
+		}
+		if len(tools) > 0 {
+			bom.Metadata.Tools = &cdx.ToolsChoice{Components: &tools}
+		}
+	}
+	var rootID string
+	if sbom != nil {
+		if len(sbom.RootElement) > 0 {
+			rootID = sbom.RootElement[0]
+		}
+		var lcs []cdx.Lifecycle
+		for _, t := range sbom.SbomType {
+			if phase, ok := cdxLifecyclePhases[t]; ok {
+				lcs = append(lcs, cdx.Lifecycle{Phase: phase})
+			}
+		}
+		if len(lcs) > 0 {
+			bom.Metadata.Lifecycles = &lcs
+		}
+	}
+
+	// Map the packages, keeping graph order.
+	comps := make(map[string]*cdx.Component)
+	refs := make(map[string]string) // spdxId → bom-ref
+	var ordered []string
+	for i := range doc.Graph {
+		e := &doc.Graph[i]
+		comp := spdxComponent(e, byID)
+		if comp == nil {
+			continue
+		}
+		comps[e.SpdxID] = comp
+		refs[e.SpdxID] = comp.BOMRef
+		ordered = append(ordered, e.SpdxID)
+	}
+	if rootID == "" {
+		// Fall back to the first model, then the first package.
+		for _, id := range ordered {
+			if comps[id].Type == cdx.ComponentTypeMachineLearningModel {
+				rootID = id
+				break
+			}
+		}
+		if rootID == "" && len(ordered) > 0 {
+			rootID = ordered[0]
+		}
+	}
+
+	// Relationships.
+	deps := make(map[string][]string)
+	var depOrder []string
+	for i := range doc.Graph {
+		e := &doc.Graph[i]
+		if e.Type != "Relationship" {
+			continue
+		}
+		from := comps[e.From]
+		if from == nil {
+			continue
+		}
+		switch e.RelationshipType {
+		case "hasDeclaredLicense":
+			for _, to := range e.To {
+				if l := byID[to]; l != nil && l.LicenseExpression != "" {
+					appendLicense(from, l.LicenseExpression)
+				}
+			}
+		case "trainedOn", "dependsOn":
+			for _, to := range e.To {
+				ref, ok := refs[to]
+				if !ok {
+					continue
+				}
+				if _, seen := deps[from.BOMRef]; !seen {
+					depOrder = append(depOrder, from.BOMRef)
+				}
+				deps[from.BOMRef] = append(deps[from.BOMRef], ref)
+				if e.RelationshipType == "trainedOn" && from.Type == cdx.ComponentTypeMachineLearningModel {
+					addDatasetRef(from, ref)
+				}
+			}
+		}
+	}
+
+	if root := comps[rootID]; root != nil {
+		bom.Metadata.Component = root
+	}
+	var others []cdx.Component
+	for _, id := range ordered {
+		if id != rootID {
+			others = append(others, *comps[id])
+		}
+	}
+	if len(others) > 0 {
+		bom.Components = &others
+	}
+	if len(depOrder) > 0 {
+		dl := make([]cdx.Dependency, 0, len(depOrder))
+		for _, ref := range depOrder {
+			to := deps[ref]
+			dl = append(dl, cdx.Dependency{Ref: ref, Dependencies: &to})
+		}
+		bom.Dependencies = &dl
+	}
+	return bom, nil
+}
+
+// spdxComponent maps a package element to a component; nil for other elements.
+func spdxComponent(e *spdxElement, byID map[string]*spdxElement) *cdx.Component {
+	comp := &cdx.Component{
+		Name:        e.Name,
+		Version:     e.PackageVersion,
+		PackageURL:  e.PackageURL,
+		Description: e.Description,
+	}
+	download := e.DownloadLocation
+	switch e.Type {
+	case "ai_AIPackage":
+		comp.Type = cdx.ComponentTypeMachineLearningModel
+		comp.ModelCard = spdxModelCard(e)
+	case "dataset_DatasetPackage":
+		comp.Type = cdx.ComponentTypeData
+		comp.Description = ""
+		data := cdx.ComponentData{Type: cdx.ComponentDataTypeDataset, Description: e.Description, Classification: e.IntendedUse}
+		if download != "" {
+			data.Contents = &cdx.ComponentDataContents{URL: download}
+			download = ""
+		}
+		comp.Data = &[]cdx.ComponentData{data}
+		if e.DatasetSize > 0 {
+			comp.Properties = taxonomy.Set(comp.Properties, taxonomy.HFUsedStorage, strconv.FormatInt(e.DatasetSize, 10))
+		}
+	case "software_Package":
+		comp.Type = cdx.ComponentTypeLibrary
+		if e.PrimaryPurpose == "application" {
+			comp.Type = cdx.ComponentTypeApplication
+		}
+	default:
+		return nil
+	}
+
+	comp.BOMRef = comp.PackageURL
+	if comp.BOMRef == "" {
+		_, frag, _ := strings.Cut(e.SpdxID, "#")
+		comp.BOMRef = frag
+		if frag == "" {
+			comp.BOMRef = e.SpdxID
+		}
+	}
+	if s := byID[e.SuppliedBy]; s != nil && s.Name != "" {
+		comp.Manufacturer = &cdx.OrganizationalEntity{Name: s.Name}
+	}
+	var authors []cdx.OrganizationalContact
+	for _, id := range e.OriginatedBy {
+		if a := byID[id]; a != nil && a.Name != "" {
+			authors = append(authors, cdx.OrganizationalContact{Name: a.Name})
+		}
+	}
+	if len(authors) > 0 {
+		comp.Authors = &authors
+	}
+	if e.ReleaseTime != "" {
+		comp.Properties = taxonomy.Set(comp.Properties, taxonomy.HFCreatedAt, e.ReleaseTime)
+	}
+
+	var hashes []cdx.Hash
+	for _, h := range e.VerifiedUsing {
+		if alg, ok := cdxHashAlgorithms[h.Algorithm]; ok {
+			hashes = append(hashes, cdx.Hash{Algorithm: alg, Value: h.HashValue})
+		}
+	}
+	if len(hashes) > 0 {
+		comp.Hashes = &hashes
+	}
+
+	var refs []cdx.ExternalReference
+	if e.HomePage != "" {
+		refs = append(refs, cdx.ExternalReference{Type: cdx.ERTypeWebsite, URL: e.HomePage})
+	}
+	if download != "" {
+		refs = append(refs, cdx.ExternalReference{Type: cdx.ERTypeDistribution, URL: download})
+	}
+	for _, r := range e.ExternalRef {
+		t, ok := cdxExternalRefTypes[r.ExternalRefType]
+		if !ok {
+			t = cdx.ERTypeOther
+		}
+		for _, loc := range r.Locator {
+			refs = append(refs, cdx.ExternalReference{Type: t, URL: loc})
+		}
+	}
+	if len(refs) > 0 {
+		comp.ExternalReferences = &refs
+	}
+	return comp
+}
+
+// spdxModelCard maps the ai_AIPackage properties back to a model card.
+func spdxModelCard(e *spdxElement) *cdx.MLModelCard {
+	mc := &cdx.MLModelCard{}
+	mp := &cdx.MLModelParameters{}
+	if len(e.Domain) > 0 {
+		mp.Task = e.Domain[0]
+	}
+	if len(e.TypeOfModel) > 0 {
+		mp.ArchitectureFamily = e.TypeOfModel[0]
+	}
+	if len(e.TypeOfModel) > 1 {
+		mp.ModelArchitecture = e.TypeOfModel[1]
+	}
+	if e.InformationAboutTraining != "" {
+		mp.Approach = &cdx.MLModelParametersApproach{Type: cdx.MLModelParametersApproachType(e.InformationAboutTraining)}
+	}
+	if *mp != (cdx.MLModelParameters{}) {
+		mc.ModelParameters = mp
+	}
+
+	if len(e.Metric) > 0 {
+		metrics := make([]cdx.MLPerformanceMetric, 0, len(e.Metric))
+		for _, m := range e.Metric {
+			metrics = append(metrics, cdx.MLPerformanceMetric{Type: m.Key, Value: m.Value})
+		}
+		mc.QuantitativeAnalysis = &cdx.MLQuantitativeAnalysis{PerformanceMetrics: &metrics}
+	}
+
+	c := &cdx.MLModelCardConsiderations{}
+	if e.InformationAboutApp != "" {
+		uc := strings.Split(e.InformationAboutApp, "\n")
+		c.UseCases = &uc
+	}
+	if e.Limitation != "" {
+		tl := strings.Split(e.Limitation, "\n")
+		c.TechnicalLimitations = &tl
+	}
+	if ec := e.EnergyConsumption; ec != nil {
+		var list []cdx.MLModelEnergyConsumption
+		for _, name := range []string{"training", "finetuning", "inference"} {
+			for _, d := range *ec.activity(name) {
+				if d.Unit != "kilowattHour" {
+					continue
+				}
+				list = append(list, cdx.MLModelEnergyConsumption{
+					Activity:           cdxEnergyActivities[name],
+					ActivityEnergyCost: cdx.MLModelEnergyMeasure{Value: float32(d.Quantity), Unit: cdx.MLModelEnergyUnitKWH},
+				})
+			}
+		}
+		if len(list) > 0 {
+			c.EnvironmentalConsiderations = &cdx.MLModelCardEnvironmentalConsiderations{EnergyConsumptions: &list}
+		}
+	}
+	if c.UseCases != nil || c.TechnicalLimitations != nil || c.EnvironmentalConsiderations != nil {
+		mc.Considerations = c
+	}
+
+	if mc.ModelParameters == nil && mc.QuantitativeAnalysis == nil && mc.Considerations == nil {
+		return nil
+	}
+	return mc
+}
+
+// appendLicense adds an SPDX license expression to comp. A single license.
+// ID becomes a license ID, a LicenseRef a license name and anything else an.
+// expression.
+func appendLicense(comp *cdx.Component, expr string) {
+	var lc cdx.Licenses
+	if comp.Licenses != nil {
+		lc = *comp.Licenses
+	}
+	switch {
+	case strings.HasPrefix(expr, "LicenseRef-") && !strings.Contains(expr, " "):
+		lc = append(lc, cdx.LicenseChoice{License: &cdx.License{Name: strings.TrimPrefix(expr, "LicenseRef-")}})
+	case spdxLicenseIDPattern.MatchString(expr):
+		lc = append(lc, cdx.LicenseChoice{License: &cdx.License{ID: expr}})
+	default:
+		lc = append(lc, cdx.LicenseChoice{Expression: expr})
+	}
+	comp.Licenses = &lc
+}
+
+// addDatasetRef lists ref among the model card datasets of comp.
+func addDatasetRef(comp *cdx.Component, ref string) {
+	if comp.ModelCard == nil {
+		comp.ModelCard = &cdx.MLModelCard{}
+	}
+	if comp.ModelCard.ModelParameters == nil {
+		comp.ModelCard.ModelParameters = &cdx.MLModelParameters{}
+	}
+	mp := comp.ModelCard.ModelParameters
+	var list []cdx.MLDatasetChoice
+	if mp.Datasets != nil {
+		list = *mp.Datasets
+	}
+	for _, ds := range list {
+		if ds.Ref == ref {
+			return
+		}
+	}
+	list = append(list, cdx.MLDatasetChoice{Ref: ref})
+	mp.Datasets = &list
+}
@@ -0,0 +1,220 @@
+package bomio
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/internal/taxonomy"
+)
+
+func aiBOM() *cdx.BOM {
+	bom := cdx.NewBOM()
+	bom.SerialNumber = "urn:uuid:11111111-2222-3333-4444-555555555555"
+	bom.Metadata = &cdx.Metadata{
+		Timestamp:  "2026-01-02T03:04:05Z",
+		Lifecycles: &[]cdx.Lifecycle{{Phase: cdx.LifecyclePhaseBuild}},
+		Tools:      &cdx.ToolsChoice{Components: &[]cdx.Component{{Type: cdx.ComponentTypeApplication, Name: "aibomgen-cli"}}},
+		Component: &cdx.Component{
+			BOMRef:       "pkg:huggingface/org/model@abc",
+			Type:         cdx.ComponentTypeMachineLearningModel,
+			Name:         "org/model",
+			PackageURL:   "pkg:huggingface/org/model@abc",
+			Manufacturer: &cdx.OrganizationalEntity{Name: "org"},
+			Hashes:       &[]cdx.Hash{{Algorithm: cdx.HashAlgoSHA1, Value: "abc"}},
+			Licenses:     &cdx.Licenses{{License: &cdx.License{ID: "Apache-2.0"}}},
+			ExternalReferences: &[]cdx.ExternalReference{
+				{Type: cdx.ERTypeWebsite, URL: "https://huggingface.co/org/model"},
+				{Type: cdx.ERTypeDocumentation, URL: "https://arxiv.org/abs/1"},
+			},
+			Properties: &[]cdx.Property{{Name: taxonomy.HFCreatedAt, Value: "2023-06-01T08:00:00Z"}},
+			ModelCard: &cdx.MLModelCard{
+				ModelParameters: &cdx.MLModelParameters{
+					Task:               "text-generation",
+					ArchitectureFamily: "gpt2",
+					ModelArchitecture:  "GPT2LMHeadModel",
+					Datasets:           &[]cdx.MLDatasetChoice{{Ref: "pkg:huggingface/datasets/wiki@def"}},
+				},
+				QuantitativeAnalysis: &cdx.MLQuantitativeAnalysis{
+					PerformanceMetrics: &[]cdx.MLPerformanceMetric{{Type: "accuracy", Value: "0.85"}},
+				},
+				Considerations: &cdx.MLModelCardConsiderations{
+					UseCases:             &[]string{"chat", "completion"},
+					TechnicalLimitations: &[]string{"English only"},
+					EnvironmentalConsiderations: &cdx.MLModelCardEnvironmentalConsiderations{
+						EnergyConsumptions: &[]cdx.MLModelEnergyConsumption{{
+							Activity:           cdx.MLModelEnergyConsumptionActivityTraining,
+							ActivityEnergyCost: cdx.MLModelEnergyMeasure{Value: 12.5, Unit: cdx.MLModelEnergyUnitKWH},
+						}},
+					},
+				},
+			},
+		},
+	}
+	bom.Components = &[]cdx.Component{{
+		BOMRef:     "pkg:huggingface/datasets/wiki@def",
+		Type:       cdx.ComponentTypeData,
+		Name:       "wiki",
+		PackageURL: "pkg:huggingface/datasets/wiki@def",
+		Licenses:   &cdx.Licenses{{License: &cdx.License{Name: "Custom wiki license"}}},
+		Properties: &[]cdx.Property{{Name: taxonomy.HFUsedStorage, Value: "1024"}},
+		Data: &[]cdx.ComponentData{{
+			Type:           cdx.ComponentDataTypeDataset,
+			Description:    "Wikipedia dump",
+			Classification: "text-classification",
+			SensitiveData:  &[]string{"names"},
+		}},
+	}}
+	bom.Dependencies = &[]cdx.Dependency{{
+		Ref:          "pkg:huggingface/org/model@abc",
+		Dependencies: &[]string{"pkg:huggingface/datasets/wiki@def"},
+	}}
+	return bom
+}
+
+func TestEncodeSPDX_Structure(t *testing.T) {
+	var buf bytes.Buffer
+	if err := EncodeSPDX(&buf, aiBOM()); err != nil {
+		t.Fatalf("EncodeSPDX: %v", err)
+	}
+	var doc spdxDocument
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("output is not JSON: %v", err)
+	}
+	if doc.Context != spdxContext {
+		t.Errorf("@context = %q", doc.Context)
+	}
+
+	byType := make(map[string][]spdxElement)
+	for _, e := range doc.Graph {
+		byType[e.Type] = append(byType[e.Type], e)
+		if e.Type != "CreationInfo" && !strings.HasPrefix(e.SpdxID, "urn:uuid:11111111-2222-3333-4444-555555555555#SPDXRef-") {
+			t.Errorf("spdxId %q is not in the document namespace", e.SpdxID)
+		}
+	}
+	for typ, n := range map[string]int{"CreationInfo": 1, "SpdxDocument": 1, "software_Sbom": 1, "ai_AIPackage": 1, "dataset_DatasetPackage": 1, "Tool": 1} {
+		if len(byType[typ]) != n {
+			t.Errorf("%d %s elements, want %d", len(byType[typ]), typ, n)
+		}
+	}
+
+	model := byType["ai_AIPackage"][0]
+	if model.PrimaryPurpose != "model" || model.HomePage != "https://huggingface.co/org/model" || model.ReleaseTime != "2023-06-01T08:00:00Z" {
+		t.Errorf("model = %+v", model)
+	}
+	if strings.Join(model.TypeOfModel, ",") != "gpt2,GPT2LMHeadModel" || strings.Join(model.Domain, ",") != "text-generation" {
+		t.Errorf("model card mapping = %v / %v", model.TypeOfModel, model.Domain)
+	}
+	if len(model.VerifiedUsing) != 1 || model.VerifiedUsing[0].Algorithm != "sha1" {
+		t.Errorf("verifiedUsing = %+v", model.VerifiedUsing)
+	}
+	if ec := model.EnergyConsumption; ec == nil || len(ec.Training) != 1 || ec.Training[0].Quantity != 12.5 {
+		t.Errorf("energy = %+v", ec)
+	}
+
+	ds := byType["dataset_DatasetPackage"][0]
+	if ds.DatasetType[0] != "text" || ds.HasSensitivePersonalInformation != "yes" || ds.DatasetSize != 1024 || ds.Description != "Wikipedia dump" {
+		t.Errorf("dataset = %+v", ds)
+	}
+
+	rels := make(map[string]spdxElement)
+	for _, r := range byType["Relationship"] {
+		rels[r.From+" "+r.RelationshipType] = r
+	}
+	trained, ok := rels[model.SpdxID+" trainedOn"]
+	if !ok || len(trained.To) != 1 || trained.To[0] != ds.SpdxID {
+		t.Errorf("trainedOn relationship = %+v", trained)
+	}
+	var exprs []string
+	for _, l := range byType["simplelicensing_LicenseExpression"] {
+		exprs = append(exprs, l.LicenseExpression)
+	}
+	if strings.Join(exprs, ";") != "Apache-2.0;LicenseRef-Custom-wiki-license" {
+		t.Errorf("license expressions = %v", exprs)
+	}
+	if sbom := byType["software_Sbom"][0]; sbom.RootElement[0] != model.SpdxID || sbom.SbomType[0] != "build" {
+		t.Errorf("sbom = %+v", sbom)
+	}
+}
+
+func TestSPDX_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "model_aibom.spdx.json")
+	if err := WriteBOM(aiBOM(), path, "auto", ""); err != nil {
+		t.Fatalf("WriteBOM: %v", err)
+	}
+	got, err := ReadBOM(path, "auto")
+	if err != nil {
+		t.Fatalf("ReadBOM: %v", err)
+	}
+
+	if got.SerialNumber != "urn:uuid:11111111-2222-3333-4444-555555555555" || got.Metadata.Timestamp != "2026-01-02T03:04:05Z" {
+		t.Errorf("document = %s / %s", got.SerialNumber, got.Metadata.Timestamp)
+	}
+	if lc := got.Metadata.Lifecycles; lc == nil || (*lc)[0].Phase != cdx.LifecyclePhaseBuild {
+		t.Errorf("lifecycles = %+v", lc)
+	}
+
+	m := got.Metadata.Component
+	if m == nil || m.Type != cdx.ComponentTypeMachineLearningModel || m.BOMRef != "pkg:huggingface/org/model@abc" || m.Manufacturer.Name != "org" {
+		t.Fatalf("model = %+v", m)
+	}
+	if (*m.Licenses)[0].License.ID != "Apache-2.0" || (*m.Hashes)[0].Algorithm != cdx.HashAlgoSHA1 {
+		t.Errorf("licenses/hashes = %+v / %+v", *m.Licenses, *m.Hashes)
+	}
+	if v, _ := taxonomy.Get(m.Properties, taxonomy.HFCreatedAt); v != "2023-06-01T08:00:00Z" {
+		t.Errorf("createdAt = %q", v)
+	}
+	mp := m.ModelCard.ModelParameters
+	if mp.Task != "text-generation" || mp.ArchitectureFamily != "gpt2" || mp.ModelArchitecture != "GPT2LMHeadModel" {
+		t.Errorf("model parameters = %+v", mp)
+	}
+	if mp.Datasets == nil || (*mp.Datasets)[0].Ref != "pkg:huggingface/datasets/wiki@def" {
+		t.Errorf("dataset refs = %+v", mp.Datasets)
+	}
+	c := m.ModelCard.Considerations
+	if strings.Join(*c.UseCases, "|") != "chat|completion" || (*c.TechnicalLimitations)[0] != "English only" {
+		t.Errorf("considerations = %+v", c)
+	}
+	if ec := (*c.EnvironmentalConsiderations.EnergyConsumptions)[0]; ec.Activity != cdx.MLModelEnergyConsumptionActivityTraining || ec.ActivityEnergyCost.Value != 12.5 {
+		t.Errorf("energy = %+v", ec)
+	}
+	if pm := (*m.ModelCard.QuantitativeAnalysis.PerformanceMetrics)[0]; pm.Type != "accuracy" || pm.Value != "0.85" {
+		t.Errorf("metric = %+v", pm)
+	}
+
+	if got.Components == nil || len(*got.Components) != 1 {
+		t.Fatalf("components = %+v", got.Components)
+	}
+	ds := (*got.Components)[0]
+	if ds.Type != cdx.ComponentTypeData || (*ds.Data)[0].Description != "Wikipedia dump" || (*ds.Data)[0].Classification != "text-classification" {
+		t.Errorf("dataset = %+v", ds)
+	}
+	if (*ds.Licenses)[0].License.Name != "Custom-wiki-license" {
+		t.Errorf("dataset license = %+v", (*ds.Licenses)[0].License)
+	}
+	if v, _ := taxonomy.Get(ds.Properties, taxonomy.HFUsedStorage); v != "1024" {
+		t.Errorf("usedStorage = %q", v)
+	}
+	if got.Dependencies == nil || (*(*got.Dependencies)[0].Dependencies)[0] != ds.BOMRef {
+		t.Errorf("dependencies = %+v", got.Dependencies)
+	}
+}
+
+func TestWriteBOM_SPDX_ExtensionMismatch(t *testing.T) {
+	if err := WriteBOM(aiBOM(), filepath.Join(t.TempDir(), "bom.xml"), FormatSPDXJSON, ""); err == nil {
+		t.Fatalf("expected error for extension/format mismatch")
+	}
+}
+
+func TestDecodeSPDX_RejectsCycloneDX(t *testing.T) {
+	var buf bytes.Buffer
+	if err := EncodeBOM(&buf, minimalBOM(), cdx.BOMFileFormatJSON, ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DecodeSPDX(&buf); err == nil {
+		t.Fatalf("expected error for a CycloneDX document")
+	}
+}
@@ -2,6 +2,7 @@ package completeness
 
 import (
 	"github.com/idlab-discover/aibomgen-cli/internal/metadata"
+	"github.com/idlab-discover/aibomgen-cli/internal/review"
 
 	cdx "github.com/CycloneDX/cyclonedx-go"
 )
@@ -18,8 +19,43 @@ type Result struct {
 	MissingRequired []metadata.Key
 	MissingOptional []metadata.Key
 
+	// VerifiedScore counts only present fields a reviewer approved (see the.
+	// review command); Verified is their number.
+	VerifiedScore float64
+	Verified      int
+
+	// Categories scores the fields of each registry category (see.
+	// metadata.CategoryResponsibleAI) on their own; key is the category.
+	Categories map[string]CategoryResult
+
 	// Dataset-specific tracking.
 	DatasetResults map[string]DatasetResult // key is dataset name/ref
+
+	// Profile is the name of the weights profile used (empty: the registry.
+	// defaults).
+	Profile string
+}
+
+// CategoryResult holds the completeness score for the fields of one category.
+type CategoryResult struct {
+	Score  float64 // 0..1
+	Passed int
+	Total  int
+
+	earned, max float64
+}
+
+// add counts a field of weight w towards the category.
+func (c *CategoryResult) add(w float64, ok bool) {
+	c.Total++
+	c.max += w
+	if ok {
+		c.Passed++
+		c.earned += w
+	}
+	if c.max > 0 {
+		c.Score = c.earned / c.max
+	}
 }
 
 // DatasetResult holds the completeness score for a single dataset component.
@@ -32,6 +68,9 @@ type DatasetResult struct {
 
 	MissingRequired []metadata.DatasetKey
 	MissingOptional []metadata.DatasetKey
+
+	VerifiedScore float64
+	Verified      int
 }
 
 // Check checks the completeness of a BOM using the default metadata registry.
@@ -47,10 +86,19 @@ func checkWithRegistry(bom *cdx.BOM, modelRegistry []metadata.FieldSpec, dataset
 		total       int
 		missingReq  []metadata.Key
 		missingOpt  []metadata.Key
+
+		verifiedEarned float64
+		verified       int
+
+		categories = make(map[string]CategoryResult)
 	)
 
 	// Check if datasets are referenced in model.
 	datasetsReferenced := hasDatasetsReferenced(bom)
+	var approved map[string]bool
+	if bom != nil && bom.Metadata != nil {
+		approved = review.Approved(bom.Metadata.Component)
+	}
 
 	for _, spec := range modelRegistry {
 		if spec.Weight <= 0 {
@@ -77,10 +125,19 @@ func checkWithRegistry(bom *cdx.BOM, modelRegistry []metadata.FieldSpec, dataset
 		if spec.Present != nil {
 			ok = spec.Present(bom)
 		}
+		if spec.Category != "" {
+			c := categories[spec.Category]
+			c.add(spec.Weight, ok)
+			categories[spec.Category] = c
+		}
 
 		if ok {
 			passed++
 			earned += spec.Weight
+			if approved[string(spec.Key)] {
+				verified++
+				verifiedEarned += spec.Weight
+			}
 			continue
 		}
 
@@ -91,9 +148,10 @@ func checkWithRegistry(bom *cdx.BOM, modelRegistry []metadata.FieldSpec, dataset
 		}
 	}
 
-	score := 0.0
+	score, verifiedScore := 0.0, 0.0
 	if max > 0 {
 		score = earned / max
+		verifiedScore = verifiedEarned / max
 	}
 
 	// Extract model ID from BOM.
@@ -109,6 +167,9 @@ func checkWithRegistry(bom *cdx.BOM, modelRegistry []metadata.FieldSpec, dataset
 		Total:           total,
 		MissingRequired: missingReq,
 		MissingOptional: missingOpt,
+		VerifiedScore:   verifiedScore,
+		Verified:        verified,
+		Categories:      categories,
 		DatasetResults:  make(map[string]DatasetResult),
 	}
 
@@ -160,7 +221,11 @@ func checkDatasetWithRegistry(comp *cdx.Component, datasetRegistry []metadata.Da
 		total       int
 		missingReq  []metadata.DatasetKey
 		missingOpt  []metadata.DatasetKey
+
+		verifiedEarned float64
+		verified       int
 	)
+	approved := review.Approved(comp)
 
 	for _, spec := range datasetRegistry {
 		if spec.Weight <= 0 {
@@ -177,6 +242,10 @@ func checkDatasetWithRegistry(comp *cdx.Component, datasetRegistry []metadata.Da
 		if ok {
 			passed++
 			earned += spec.Weight
+			if approved[string(spec.Key)] {
+				verified++
+				verifiedEarned += spec.Weight
+			}
 			continue
 		}
 
@@ -187,9 +256,10 @@ func checkDatasetWithRegistry(comp *cdx.Component, datasetRegistry []metadata.Da
 		}
 	}
 
-	score := 0.0
+	score, verifiedScore := 0.0, 0.0
 	if max > 0 {
 		score = earned / max
+		verifiedScore = verifiedEarned / max
 	}
 
 	return DatasetResult{
@@ -199,5 +269,7 @@ func checkDatasetWithRegistry(comp *cdx.Component, datasetRegistry []metadata.Da
 		Total:           total,
 		MissingRequired: missingReq,
 		MissingOptional: missingOpt,
+		VerifiedScore:   verifiedScore,
+		Verified:        verified,
 	}
 }
@@ -11,15 +11,15 @@ import (
 )
 
 // Test Strategy:.
-// - Uses calculated score values (e.g., 1.0 / 12.15) instead of hardcoded floats to avoid precision issues.
+// - Uses calculated score values (e.g., 1.0 / 15.9) instead of hardcoded floats to avoid precision issues.
 // - Implements tolerance-based comparison (1e-9) for floating point scores.
 // - Helper functions resultsEqual() and datasetResultsEqual() compare results with proper float handling.
 // - Best practice: never hardcode floating point literals in test expectations.
 
-// Constants from metadata registry (total weight: 12.15 for model, 9.4 for dataset).
+// Constants from metadata registry (total weight: 15.9 for model, 10.9 for dataset).
 const (
-	totalModelFields   = 30
-	totalDatasetFields = 17
+	totalModelFields   = 39
+	totalDatasetFields = 20
 	floatTolerance     = 1e-9 // Tolerance for floating point comparison
 )
 
@@ -98,6 +98,7 @@ func TestCheck(t *testing.T) {
 					metadata.ComponentHashes,
 					metadata.ComponentManufacturer,
 					metadata.ComponentGroup,
+					metadata.ComponentPropertiesModelClassifier,
 					metadata.ComponentPropertiesHuggingFaceLastModified,
 					metadata.ComponentPropertiesHuggingFaceCreatedAt,
 					metadata.ComponentPropertiesHuggingFaceLanguage,
@@ -117,6 +118,14 @@ func TestCheck(t *testing.T) {
 					metadata.ModelCardConsiderationsEthicalConsiderations,
 					metadata.ModelCardQuantitativeAnalysisPerformanceMetrics,
 					metadata.ModelCardConsiderationsEnvironmentalConsiderationsProperties,
+					metadata.ModelCardModelParametersNumberOfParameters,
+					metadata.ComponentPropertiesWeightsContextLength,
+					metadata.ModelCardQuantitativeAnalysisFairnessMetrics,
+					metadata.ComponentPropertiesFairnessCriteria,
+					metadata.ModelCardConsiderationsUsers,
+					metadata.ComponentPropertiesAIActDeploymentContext,
+					metadata.ComponentPropertiesAIActHumanOversight,
+					metadata.ComponentPropertiesAIActRiskClassification,
 					metadata.ComponentPropertiesSecurityOverallStatus,
 					metadata.ComponentPropertiesSecurityScannedFiles,
 					metadata.ComponentPropertiesSecurityUnsafeFiles,
@@ -138,7 +147,7 @@ func TestCheck(t *testing.T) {
 			},
 			want: Result{
 				ModelID:         "test-model",
-				Score:           1.0 / 12.15, // ComponentName weight (1.0) / total weight (12.15)
+				Score:           1.0 / 15.9, // ComponentName weight (1.0) / total weight (15.9)
 				Passed:          1,
 				Total:           totalModelFields,
 				MissingRequired: nil, // ComponentName is satisfied
@@ -149,6 +158,7 @@ func TestCheck(t *testing.T) {
 					metadata.ComponentHashes,
 					metadata.ComponentManufacturer,
 					metadata.ComponentGroup,
+					metadata.ComponentPropertiesModelClassifier,
 					metadata.ComponentPropertiesHuggingFaceLastModified,
 					metadata.ComponentPropertiesHuggingFaceCreatedAt,
 					metadata.ComponentPropertiesHuggingFaceLanguage,
@@ -168,6 +178,14 @@ func TestCheck(t *testing.T) {
 					metadata.ModelCardConsiderationsEthicalConsiderations,
 					metadata.ModelCardQuantitativeAnalysisPerformanceMetrics,
 					metadata.ModelCardConsiderationsEnvironmentalConsiderationsProperties,
+					metadata.ModelCardModelParametersNumberOfParameters,
+					metadata.ComponentPropertiesWeightsContextLength,
+					metadata.ModelCardQuantitativeAnalysisFairnessMetrics,
+					metadata.ComponentPropertiesFairnessCriteria,
+					metadata.ModelCardConsiderationsUsers,
+					metadata.ComponentPropertiesAIActDeploymentContext,
+					metadata.ComponentPropertiesAIActHumanOversight,
+					metadata.ComponentPropertiesAIActRiskClassification,
 					metadata.ComponentPropertiesSecurityOverallStatus,
 					metadata.ComponentPropertiesSecurityScannedFiles,
 					metadata.ComponentPropertiesSecurityUnsafeFiles,
@@ -196,7 +214,7 @@ func TestCheck(t *testing.T) {
 			},
 			want: Result{
 				ModelID:         "test-model",
-				Score:           1.5 / 12.15, // ComponentName (1.0) + Datasets (0.5) / total (12.15)
+				Score:           1.5 / 15.9, // ComponentName (1.0) + Datasets (0.5) / total (15.9)
 				Passed:          2,
 				Total:           totalModelFields,
 				MissingRequired: nil,
@@ -207,6 +225,7 @@ func TestCheck(t *testing.T) {
 					metadata.ComponentHashes,
 					metadata.ComponentManufacturer,
 					metadata.ComponentGroup,
+					metadata.ComponentPropertiesModelClassifier,
 					metadata.ComponentPropertiesHuggingFaceLastModified,
 					metadata.ComponentPropertiesHuggingFaceCreatedAt,
 					metadata.ComponentPropertiesHuggingFaceLanguage,
@@ -226,6 +245,14 @@ func TestCheck(t *testing.T) {
 					metadata.ModelCardConsiderationsEthicalConsiderations,
 					metadata.ModelCardQuantitativeAnalysisPerformanceMetrics,
 					metadata.ModelCardConsiderationsEnvironmentalConsiderationsProperties,
+					metadata.ModelCardModelParametersNumberOfParameters,
+					metadata.ComponentPropertiesWeightsContextLength,
+					metadata.ModelCardQuantitativeAnalysisFairnessMetrics,
+					metadata.ComponentPropertiesFairnessCriteria,
+					metadata.ModelCardConsiderationsUsers,
+					metadata.ComponentPropertiesAIActDeploymentContext,
+					metadata.ComponentPropertiesAIActHumanOversight,
+					metadata.ComponentPropertiesAIActRiskClassification,
 					metadata.ComponentPropertiesSecurityOverallStatus,
 					metadata.ComponentPropertiesSecurityScannedFiles,
 					metadata.ComponentPropertiesSecurityUnsafeFiles,
@@ -260,7 +287,7 @@ func TestCheck(t *testing.T) {
 			},
 			want: Result{
 				ModelID:         "test-model",
-				Score:           1.5 / 12.15,
+				Score:           1.5 / 15.9,
 				Passed:          2,
 				Total:           totalModelFields,
 				MissingRequired: nil,
@@ -271,6 +298,7 @@ func TestCheck(t *testing.T) {
 					metadata.ComponentHashes,
 					metadata.ComponentManufacturer,
 					metadata.ComponentGroup,
+					metadata.ComponentPropertiesModelClassifier,
 					metadata.ComponentPropertiesHuggingFaceLastModified,
 					metadata.ComponentPropertiesHuggingFaceCreatedAt,
 					metadata.ComponentPropertiesHuggingFaceLanguage,
@@ -289,6 +317,14 @@ func TestCheck(t *testing.T) {
 					metadata.ModelCardConsiderationsEthicalConsiderations,
 					metadata.ModelCardQuantitativeAnalysisPerformanceMetrics,
 					metadata.ModelCardConsiderationsEnvironmentalConsiderationsProperties,
+					metadata.ModelCardModelParametersNumberOfParameters,
+					metadata.ComponentPropertiesWeightsContextLength,
+					metadata.ModelCardQuantitativeAnalysisFairnessMetrics,
+					metadata.ComponentPropertiesFairnessCriteria,
+					metadata.ModelCardConsiderationsUsers,
+					metadata.ComponentPropertiesAIActDeploymentContext,
+					metadata.ComponentPropertiesAIActHumanOversight,
+					metadata.ComponentPropertiesAIActRiskClassification,
 					metadata.ComponentPropertiesSecurityOverallStatus,
 					metadata.ComponentPropertiesSecurityScannedFiles,
 					metadata.ComponentPropertiesSecurityUnsafeFiles,
@@ -297,7 +333,7 @@ func TestCheck(t *testing.T) {
 				DatasetResults: map[string]DatasetResult{
 					"dataset-1": {
 						DatasetRef:      "dataset-1",
-						Score:           1.0 / 9.4, // DatasetName weight (1.0) / total dataset weight (9.4)
+						Score:           1.0 / 10.9, // DatasetName weight (1.0) / total dataset weight (10.9)
 						Passed:          1,
 						Total:           totalDatasetFields,
 						MissingRequired: nil, // DatasetName is satisfied
@@ -318,6 +354,9 @@ func TestCheck(t *testing.T) {
 							metadata.DatasetUsedStorage,
 							metadata.DatasetLastModified,
 							metadata.DatasetContact,
+							metadata.DatasetTemporalCoverage,
+							metadata.DatasetGeographicCoverage,
+							metadata.DatasetLanguages,
 						},
 					},
 				},
@@ -341,7 +380,7 @@ func TestCheck(t *testing.T) {
 			},
 			want: Result{
 				ModelID:         "test-model",
-				Score:           1.0 / 12.15, // Only ComponentName is present
+				Score:           1.0 / 15.9, // Only ComponentName is present
 				Passed:          1,
 				Total:           totalModelFields,
 				MissingRequired: nil,
@@ -352,6 +391,7 @@ func TestCheck(t *testing.T) {
 					metadata.ComponentHashes,
 					metadata.ComponentManufacturer,
 					metadata.ComponentGroup,
+					metadata.ComponentPropertiesModelClassifier,
 					metadata.ComponentPropertiesHuggingFaceLastModified,
 					metadata.ComponentPropertiesHuggingFaceCreatedAt,
 					metadata.ComponentPropertiesHuggingFaceLanguage,
@@ -371,6 +411,14 @@ func TestCheck(t *testing.T) {
 					metadata.ModelCardConsiderationsEthicalConsiderations,
 					metadata.ModelCardQuantitativeAnalysisPerformanceMetrics,
 					metadata.ModelCardConsiderationsEnvironmentalConsiderationsProperties,
+					metadata.ModelCardModelParametersNumberOfParameters,
+					metadata.ComponentPropertiesWeightsContextLength,
+					metadata.ModelCardQuantitativeAnalysisFairnessMetrics,
+					metadata.ComponentPropertiesFairnessCriteria,
+					metadata.ModelCardConsiderationsUsers,
+					metadata.ComponentPropertiesAIActDeploymentContext,
+					metadata.ComponentPropertiesAIActHumanOversight,
+					metadata.ComponentPropertiesAIActRiskClassification,
 					metadata.ComponentPropertiesSecurityOverallStatus,
 					metadata.ComponentPropertiesSecurityScannedFiles,
 					metadata.ComponentPropertiesSecurityUnsafeFiles,
@@ -399,7 +447,7 @@ func TestCheck(t *testing.T) {
 			},
 			want: Result{
 				ModelID:         "test-model",
-				Score:           1.0 / 12.15,
+				Score:           1.0 / 15.9,
 				Passed:          1,
 				Total:           totalModelFields,
 				MissingRequired: nil,
@@ -410,6 +458,7 @@ func TestCheck(t *testing.T) {
 					metadata.ComponentHashes,
 					metadata.ComponentManufacturer,
 					metadata.ComponentGroup,
+					metadata.ComponentPropertiesModelClassifier,
 					metadata.ComponentPropertiesHuggingFaceLastModified,
 					metadata.ComponentPropertiesHuggingFaceCreatedAt,
 					metadata.ComponentPropertiesHuggingFaceLanguage,
@@ -429,6 +478,14 @@ func TestCheck(t *testing.T) {
 					metadata.ModelCardConsiderationsEthicalConsiderations,
 					metadata.ModelCardQuantitativeAnalysisPerformanceMetrics,
 					metadata.ModelCardConsiderationsEnvironmentalConsiderationsProperties,
+					metadata.ModelCardModelParametersNumberOfParameters,
+					metadata.ComponentPropertiesWeightsContextLength,
+					metadata.ModelCardQuantitativeAnalysisFairnessMetrics,
+					metadata.ComponentPropertiesFairnessCriteria,
+					metadata.ModelCardConsiderationsUsers,
+					metadata.ComponentPropertiesAIActDeploymentContext,
+					metadata.ComponentPropertiesAIActHumanOversight,
+					metadata.ComponentPropertiesAIActRiskClassification,
 					metadata.ComponentPropertiesSecurityOverallStatus,
 					metadata.ComponentPropertiesSecurityScannedFiles,
 					metadata.ComponentPropertiesSecurityUnsafeFiles,
@@ -475,7 +532,7 @@ func TestCheck(t *testing.T) {
 			},
 			want: Result{
 				ModelID:         "test-model",
-				Score:           1.5 / 12.15,
+				Score:           1.5 / 15.9,
 				Passed:          2,
 				Total:           totalModelFields,
 				MissingRequired: nil,
@@ -486,6 +543,7 @@ func TestCheck(t *testing.T) {
 					metadata.ComponentHashes,
 					metadata.ComponentManufacturer,
 					metadata.ComponentGroup,
+					metadata.ComponentPropertiesModelClassifier,
 					metadata.ComponentPropertiesHuggingFaceLastModified,
 					metadata.ComponentPropertiesHuggingFaceCreatedAt,
 					metadata.ComponentPropertiesHuggingFaceLanguage,
@@ -504,6 +562,14 @@ func TestCheck(t *testing.T) {
 					metadata.ModelCardConsiderationsEthicalConsiderations,
 					metadata.ModelCardQuantitativeAnalysisPerformanceMetrics,
 					metadata.ModelCardConsiderationsEnvironmentalConsiderationsProperties,
+					metadata.ModelCardModelParametersNumberOfParameters,
+					metadata.ComponentPropertiesWeightsContextLength,
+					metadata.ModelCardQuantitativeAnalysisFairnessMetrics,
+					metadata.ComponentPropertiesFairnessCriteria,
+					metadata.ModelCardConsiderationsUsers,
+					metadata.ComponentPropertiesAIActDeploymentContext,
+					metadata.ComponentPropertiesAIActHumanOversight,
+					metadata.ComponentPropertiesAIActRiskClassification,
 					metadata.ComponentPropertiesSecurityOverallStatus,
 					metadata.ComponentPropertiesSecurityScannedFiles,
 					metadata.ComponentPropertiesSecurityUnsafeFiles,
@@ -512,7 +578,7 @@ func TestCheck(t *testing.T) {
 				DatasetResults: map[string]DatasetResult{
 					"dataset-1": {
 						DatasetRef:      "dataset-1",
-						Score:           1.0 / 9.4,
+						Score:           1.0 / 10.9,
 						Passed:          1,
 						Total:           totalDatasetFields,
 						MissingRequired: nil,
@@ -533,11 +599,14 @@ func TestCheck(t *testing.T) {
 							metadata.DatasetUsedStorage,
 							metadata.DatasetLastModified,
 							metadata.DatasetContact,
+							metadata.DatasetTemporalCoverage,
+							metadata.DatasetGeographicCoverage,
+							metadata.DatasetLanguages,
 						},
 					},
 					"dataset-2": {
 						DatasetRef:      "dataset-2",
-						Score:           1.7 / 9.4, // DatasetName (1.0) + DatasetDescription (0.7)
+						Score:           1.7 / 10.9, // DatasetName (1.0) + DatasetDescription (0.7)
 						Passed:          2,
 						Total:           totalDatasetFields,
 						MissingRequired: nil,
@@ -558,6 +627,9 @@ func TestCheck(t *testing.T) {
 							metadata.DatasetUsedStorage,
 							metadata.DatasetLastModified,
 							metadata.DatasetContact,
+							metadata.DatasetTemporalCoverage,
+							metadata.DatasetGeographicCoverage,
+							metadata.DatasetLanguages,
 						},
 					},
 				},
@@ -777,6 +849,9 @@ func TestCheckDataset(t *testing.T) {
 					metadata.DatasetUsedStorage,
 					metadata.DatasetLastModified,
 					metadata.DatasetContact,
+					metadata.DatasetTemporalCoverage,
+					metadata.DatasetGeographicCoverage,
+					metadata.DatasetLanguages,
 				},
 			},
 		},
@@ -789,7 +864,7 @@ func TestCheckDataset(t *testing.T) {
 			},
 			want: DatasetResult{
 				DatasetRef:      "test-dataset",
-				Score:           1.0 / 9.4, // DatasetName weight (1.0) / total weight (9.4)
+				Score:           1.0 / 10.9, // DatasetName weight (1.0) / total weight (10.9)
 				Passed:          1,
 				Total:           totalDatasetFields,
 				MissingRequired: nil, // DatasetName is satisfied
@@ -810,6 +885,9 @@ func TestCheckDataset(t *testing.T) {
 					metadata.DatasetUsedStorage,
 					metadata.DatasetLastModified,
 					metadata.DatasetContact,
+					metadata.DatasetTemporalCoverage,
+					metadata.DatasetGeographicCoverage,
+					metadata.DatasetLanguages,
 				},
 			},
 		},
@@ -825,7 +903,7 @@ func TestCheckDataset(t *testing.T) {
 			},
 			want: DatasetResult{
 				DatasetRef:      "test-dataset",
-				Score:           1.7 / 9.4, // DatasetName (1.0) + DatasetDescription (0.7) / total (9.4)
+				Score:           1.7 / 10.9, // DatasetName (1.0) + DatasetDescription (0.7) / total (10.9)
 				Passed:          2,
 				Total:           totalDatasetFields,
 				MissingRequired: nil,
@@ -846,6 +924,9 @@ func TestCheckDataset(t *testing.T) {
 					metadata.DatasetUsedStorage,
 					metadata.DatasetLastModified,
 					metadata.DatasetContact,
+					metadata.DatasetTemporalCoverage,
+					metadata.DatasetGeographicCoverage,
+					metadata.DatasetLanguages,
 				},
 			},
 		},
@@ -1005,3 +1086,92 @@ func Test_checkWithRegistry_ZeroWeight(t *testing.T) {
 		t.Errorf("Score = %v, want 1.0", got.Score)
 	}
 }
+
+func Test_checkWithRegistry_VerifiedScore(t *testing.T) {
+	customRegistry := []metadata.FieldSpec{
+		{
+			Key:    metadata.ComponentName,
+			Weight: 1.0,
+			Present: func(bom *cdx.BOM) bool {
+				return bom.Metadata.Component.Name != ""
+			},
+		},
+		{
+			Key:    metadata.ComponentGroup,
+			Weight: 3.0,
+			Present: func(bom *cdx.BOM) bool {
+				return bom.Metadata.Component.Group != ""
+			},
+		},
+	}
+
+	// Approving the missing group field does not count: only present fields.
+	// a reviewer approved make up the verified score.
+	bom := &cdx.BOM{
+		Metadata: &cdx.Metadata{
+			Component: &cdx.Component{
+				Name: "test-model",
+				Properties: &[]cdx.Property{
+					{Name: "aibomgen:review:approved", Value: string(metadata.ComponentName)},
+					{Name: "aibomgen:review:approved", Value: string(metadata.ComponentGroup)},
+				},
+			},
+		},
+	}
+
+	got := checkWithRegistry(bom, customRegistry, []metadata.DatasetFieldSpec{})
+	if got.Verified != 1 {
+		t.Errorf("Verified = %v, want 1", got.Verified)
+	}
+	if math.Abs(got.VerifiedScore-0.25) > floatTolerance || math.Abs(got.Score-0.25) > floatTolerance {
+		t.Errorf("VerifiedScore/Score = %v/%v, want 0.25/0.25", got.VerifiedScore, got.Score)
+	}
+
+	(*bom.Metadata.Component.Properties)[0].Name = "aibomgen:review:requested"
+	if got := checkWithRegistry(bom, customRegistry, []metadata.DatasetFieldSpec{}); got.Verified != 0 || got.VerifiedScore != 0 {
+		t.Errorf("requested field counted as verified: %v/%v", got.Verified, got.VerifiedScore)
+	}
+}
+
+func Test_checkWithRegistry_Categories(t *testing.T) {
+	customRegistry := []metadata.FieldSpec{
+		{
+			Key:    metadata.ComponentName,
+			Weight: 1.0,
+			Present: func(bom *cdx.BOM) bool {
+				return bom.Metadata.Component.Name != ""
+			},
+		},
+		{
+			Key:      metadata.ModelCardConsiderationsEthicalConsiderations,
+			Weight:   1.0,
+			Category: metadata.CategoryResponsibleAI,
+			Present:  func(*cdx.BOM) bool { return true },
+		},
+		{
+			Key:      metadata.ModelCardQuantitativeAnalysisFairnessMetrics,
+			Weight:   3.0,
+			Category: metadata.CategoryResponsibleAI,
+			Present:  func(*cdx.BOM) bool { return false },
+		},
+		{
+			Key:      metadata.ComponentPropertiesFairnessCriteria,
+			Weight:   0,
+			Category: metadata.CategoryResponsibleAI,
+			Present:  func(*cdx.BOM) bool { return true },
+		},
+	}
+	bom := &cdx.BOM{Metadata: &cdx.Metadata{Component: &cdx.Component{Name: "test-model"}}}
+
+	got := checkWithRegistry(bom, customRegistry, []metadata.DatasetFieldSpec{})
+	if len(got.Categories) != 1 {
+		t.Fatalf("Categories = %+v, want one category", got.Categories)
+	}
+	cat := got.Categories[metadata.CategoryResponsibleAI]
+	if cat.Passed != 1 || cat.Total != 2 || math.Abs(cat.Score-0.25) > floatTolerance {
+		t.Errorf("responsible AI = %d/%d %v, want 1/2 0.25", cat.Passed, cat.Total, cat.Score)
+	}
+	if math.Abs(got.Score-0.4) > floatTolerance {
+		t.Errorf("Score = %v, want 0.4 (categories also count overall)", got.Score)
+	}
+}
@@ -5,5 +5,12 @@
 // [Check] scores the model component of a BOM and all linked dataset.
 // components, returning a [Result] that includes the weighted score (0–1),.
 // counts of present/total fields, and lists of missing required and optional.
-// fields. [CheckDataset] scores a single dataset component in isolation.
+// fields. Fields of a registry category, such as the responsible AI fields.
+// (ethical considerations, fairness metrics and criteria), are also scored.
+// per category in [Result.Categories]. [CheckDataset] scores a single.
+// dataset component in isolation.
+//.
+// A weights [Profile], loaded with [LoadProfile] from a YAML or JSON file,.
+// overrides the weight and required flag of individual fields so teams can.
+// express their own scoring scheme; [CheckWithProfile] scores a BOM with it.
 package completeness
@@ -0,0 +1,142 @@
+package completeness
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/idlab-discover/aibomgen-cli/internal/metadata"
+	yaml "go.yaml.in/yaml/v3"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+// FieldWeight overrides the weight and/or required flag of one field. Unset.
+// values keep the registry default; a weight of 0 leaves the field out of the.
+// score.
+type FieldWeight struct {
+	Weight   *float64 `json:"weight,omitempty" yaml:"weight,omitempty"`
+	Required *bool    `json:"required,omitempty" yaml:"required,omitempty"`
+}
+
+// Profile is a weights profile: a named scoring scheme that overrides the.
+// registry weights and required flags per model Key and DatasetKey, e.g. to.
+// score AIBOMs against a team's regulatory requirements. Fields it does not.
+// name keep their registry defaults.
+type Profile struct {
+	Name        string                              `json:"name,omitempty" yaml:"name,omitempty"`
+	Description string                              `json:"description,omitempty" yaml:"description,omitempty"`
+	Model       map[metadata.Key]FieldWeight        `json:"model,omitempty" yaml:"model,omitempty"`
+	Dataset     map[metadata.DatasetKey]FieldWeight `json:"dataset,omitempty" yaml:"dataset,omitempty"`
+}
+
+// LoadProfile reads a weights profile from a YAML or JSON file (chosen by.
+// the .json extension) and validates it against the registry.
+func LoadProfile(path string) (*Profile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read profile: %w", err)
+	}
+	var p Profile
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&p)
+	} else {
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		err = dec.Decode(&p)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse profile %s: %w", path, err)
+	}
+	if p.Name == "" {
+		p.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	if err := p.Validate(); err != nil {
+		return nil, fmt.Errorf("profile %s: %w", path, err)
+	}
+	return &p, nil
+}
+
+// Validate reports keys the registry does not know and negative weights.
+func (p *Profile) Validate() error {
+	known := make(map[metadata.Key]bool)
+	for _, spec := range metadata.Registry() {
+		known[spec.Key] = true
+	}
+	for k, w := range p.Model {
+		if !known[k] {
+			return fmt.Errorf("unknown model field %q", k)
+		}
+		if w.Weight != nil && *w.Weight < 0 {
+			return fmt.Errorf("model field %q: negative weight %v", k, *w.Weight)
+		}
+	}
+	knownDS := make(map[metadata.DatasetKey]bool)
+	for _, spec := range metadata.DatasetRegistry() {
+		knownDS[spec.Key] = true
+	}
+	for k, w := range p.Dataset {
+		if !knownDS[k] {
+			return fmt.Errorf("unknown dataset field %q", k)
+		}
+		if w.Weight != nil && *w.Weight < 0 {
+			return fmt.Errorf("dataset field %q: negative weight %v", k, *w.Weight)
+		}
+	}
+	return nil
+}
+
+// Registry returns the model registry with the overrides of p applied. A nil.
+// profile returns the registry unchanged.
+func (p *Profile) Registry() []metadata.FieldSpec {
+	specs := metadata.Registry()
+	if p == nil {
+		return specs
+	}
+	for i := range specs {
+		if w, ok := p.Model[specs[i].Key]; ok {
+			if w.Weight != nil {
+				specs[i].Weight = *w.Weight
+			}
+			if w.Required != nil {
+				specs[i].Required = *w.Required
+			}
+		}
+	}
+	return specs
+}
+
+// DatasetRegistry returns the dataset registry with the overrides of p.
+// applied. A nil profile returns the registry unchanged.
+func (p *Profile) DatasetRegistry() []metadata.DatasetFieldSpec {
+	specs := metadata.DatasetRegistry()
+	if p == nil {
+		return specs
+	}
+	for i := range specs {
+		if w, ok := p.Dataset[specs[i].Key]; ok {
+			if w.Weight != nil {
+				specs[i].Weight = *w.Weight
+			}
+			if w.Required != nil {
+				specs[i].Required = *w.Required
+			}
+		}
+	}
+	return specs
+}
+
+// CheckWithProfile checks the completeness of a BOM with the weights of p.
+// (the registry defaults when p is nil).
+func CheckWithProfile(bom *cdx.BOM, p *Profile) Result {
+	res := checkWithRegistry(bom, p.Registry(), p.DatasetRegistry())
+	if p != nil {
+		res.Profile = p.Name
+	}
+	return res
+}
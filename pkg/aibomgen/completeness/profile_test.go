@@ -0,0 +1,108 @@
+package completeness
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/idlab-discover/aibomgen-cli/internal/metadata"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+func writeProfile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadProfileAndCheck(t *testing.T) {
+	path := writeProfile(t, "eu-ai-act.yaml", `description: Licensing first
+model:
+  BOM.metadata.component.licenses:
+    weight: 4
+    required: true
+  BOM.metadata.component.licenses.text:
+    weight: 1
+  BOM.metadata.component.group:
+    weight: 0
+dataset:
+  BOM.components[DATA].licenses:
+    required: true
+`)
+	p, err := LoadProfile(path)
+	if err != nil {
+		t.Fatalf("LoadProfile: %v", err)
+	}
+	if p.Name != "eu-ai-act" {
+		t.Errorf("Name = %q, want the file name", p.Name)
+	}
+
+	bom := &cdx.BOM{Metadata: &cdx.Metadata{Component: &cdx.Component{Name: "org/model"}}}
+	def, got := Check(bom), CheckWithProfile(bom, p)
+
+	var defMax, gotMax float64
+	for _, spec := range metadata.Registry() {
+		if spec.Weight > 0 {
+			defMax += spec.Weight
+		}
+	}
+	for _, spec := range p.Registry() {
+		if spec.Weight > 0 {
+			gotMax += spec.Weight
+		}
+	}
+	// Licenses 1.0 → 4, license text 0 → 1, group 0.25 → 0.
+	if math.Abs(gotMax-(defMax+3+1-0.25)) > floatTolerance {
+		t.Errorf("profile total weight = %v, default %v", gotMax, defMax)
+	}
+	if want := 1.0 / gotMax; math.Abs(got.Score-want) > floatTolerance {
+		t.Errorf("Score = %v, want %v", got.Score, want)
+	}
+	if got.Total != def.Total {
+		t.Errorf("Total = %d, want %d (one field added, one removed)", got.Total, def.Total)
+	}
+	required := map[metadata.Key]bool{}
+	for _, k := range got.MissingRequired {
+		required[k] = true
+	}
+	if !required[metadata.ComponentLicenses] {
+		t.Errorf("MissingRequired = %v, want licenses", got.MissingRequired)
+	}
+	for _, spec := range p.DatasetRegistry() {
+		if spec.Key == metadata.DatasetLicenses && (!spec.Required || spec.Weight != 0.8) {
+			t.Errorf("dataset licenses = %v/%v, want required with the default weight", spec.Weight, spec.Required)
+		}
+	}
+	if r := (*Profile)(nil).Registry(); len(r) != len(metadata.Registry()) {
+		t.Errorf("nil profile changed the registry")
+	}
+}
+
+func TestLoadProfileJSONAndErrors(t *testing.T) {
+	path := writeProfile(t, "p.json", `{"name": "strict", "model": {"BOM.metadata.component.hashes": {"required": true}}}`)
+	p, err := LoadProfile(path)
+	if err != nil {
+		t.Fatalf("LoadProfile: %v", err)
+	}
+	if p.Name != "strict" || p.Model[metadata.ComponentHashes].Required == nil {
+		t.Errorf("profile = %+v", p)
+	}
+
+	for name, content := range map[string]string{
+		"unknown.yaml":  "model:\n  BOM.metadata.component.nope:\n    weight: 1\n",
+		"negative.yaml": "dataset:\n  BOM.components[DATA].name:\n    weight: -1\n",
+		"typo.yaml":     "modle:\n  BOM.metadata.component.name:\n    weight: 1\n",
+	} {
+		if _, err := LoadProfile(writeProfile(t, name, content)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		} else if !strings.Contains(err.Error(), name) {
+			t.Errorf("%s: error %q does not name the file", name, err)
+		}
+	}
+}
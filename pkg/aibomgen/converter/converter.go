@@ -0,0 +1,131 @@
+package converter
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/bomio"
+)
+
+// Prefix is the executable name prefix of converter plugins.
+const Prefix = "aibomgen-convert-"
+
+// ProtocolVersion is the plugin protocol version passed in.
+// AIBOMGEN_CONVERT_PROTOCOL.
+const ProtocolVersion = "1"
+
+// Plugin is a converter plugin found on the PATH.
+type Plugin struct {
+	// Name is the format name, i.e. the executable name without Prefix.
+	// (and without the extension on Windows).
+	Name string
+	Path string
+}
+
+// Discover returns the converter plugins on the PATH, sorted by name. When.
+// several directories hold a plugin of the same name, the first one wins, as.
+// it would for the shell.
+func Discover() []Plugin {
+	seen := make(map[string]bool)
+	var plugins []Plugin
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if dir == "" {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			name, ok := pluginName(e.Name())
+			if !ok || seen[name] || e.IsDir() {
+				continue
+			}
+			path := filepath.Join(dir, e.Name())
+			if !isExecutable(path) {
+				continue
+			}
+			seen[name] = true
+			plugins = append(plugins, Plugin{Name: name, Path: path})
+		}
+	}
+	sort.Slice(plugins, func(i, j int) bool { return plugins[i].Name < plugins[j].Name })
+	return plugins
+}
+
+// Lookup finds the converter plugin for the format name on the PATH.
+func Lookup(name string) (Plugin, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || strings.ContainsAny(name, `/\`) {
+		return Plugin{}, fmt.Errorf("invalid converter name %q", name)
+	}
+	path, err := exec.LookPath(Prefix + name)
+	if err != nil {
+		return Plugin{}, fmt.Errorf("no converter plugin %q: %s%s not found on PATH", name, Prefix, name)
+	}
+	return Plugin{Name: name, Path: path}, nil
+}
+
+// Convert encodes bom as CycloneDX JSON (in spec version spec, or the BOM's.
+// own version when empty), pipes it to the plugin and copies the plugin's.
+// output to w. args are passed to the plugin as command-line arguments.
+func Convert(ctx context.Context, p Plugin, bom *cdx.BOM, spec string, args []string, w io.Writer) error {
+	var in bytes.Buffer
+	if err := bomio.EncodeBOM(&in, bom, cdx.BOMFileFormatJSON, spec); err != nil {
+		return fmt.Errorf("encode BOM: %w", err)
+	}
+
+	cmd := exec.CommandContext(ctx, p.Path, args...)
+	cmd.Stdin = &in
+	cmd.Stdout = w
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	cmd.Env = append(os.Environ(), "AIBOMGEN_CONVERT_PROTOCOL="+ProtocolVersion)
+
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && msg != "" {
+			return fmt.Errorf("converter %q failed (%s): %s", p.Name, exitErr, msg)
+		}
+		return fmt.Errorf("converter %q failed: %w", p.Name, err)
+	}
+	return nil
+}
+
+// pluginName returns the format name of a plugin executable file name.
+func pluginName(file string) (string, bool) {
+	if !strings.HasPrefix(file, Prefix) {
+		return "", false
+	}
+	name := strings.TrimPrefix(file, Prefix)
+	if runtime.GOOS == "windows" {
+		ext := strings.ToLower(filepath.Ext(name))
+		if ext != ".exe" && ext != ".bat" && ext != ".cmd" {
+			return "", false
+		}
+		name = strings.TrimSuffix(name, filepath.Ext(name))
+	}
+	return name, name != ""
+}
+
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		return true
+	}
+	return info.Mode()&0o111 != 0
+}
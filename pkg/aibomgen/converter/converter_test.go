@@ -0,0 +1,105 @@
+package converter
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+func writePlugin(t *testing.T, dir, name, script string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, Prefix+name), []byte("#!/bin/sh\n"+script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func pluginDirs(t *testing.T) (string, string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script plugins")
+	}
+	first, second := t.TempDir(), t.TempDir()
+	sep := string(os.PathListSeparator)
+	t.Setenv("PATH", first+sep+second+sep+os.Getenv("PATH"))
+	return first, second
+}
+
+func TestDiscoverAndLookup(t *testing.T) {
+	first, second := pluginDirs(t)
+	writePlugin(t, first, "registry", "cat\n")
+	writePlugin(t, second, "registry", "exit 1\n")
+	writePlugin(t, second, "csv", "cat\n")
+	// Not executable: ignored.
+	if err := os.WriteFile(filepath.Join(second, Prefix+"notes"), []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var plugins []Plugin
+	for _, p := range Discover() {
+		if filepath.Dir(p.Path) == first || filepath.Dir(p.Path) == second {
+			plugins = append(plugins, p)
+		}
+	}
+	if len(plugins) != 2 || plugins[0].Name != "csv" || plugins[1].Name != "registry" {
+		t.Fatalf("Discover = %+v", plugins)
+	}
+	if plugins[1].Path != filepath.Join(first, Prefix+"registry") {
+		t.Errorf("expected the first PATH entry to win, got %s", plugins[1].Path)
+	}
+
+	if _, err := Lookup("registry"); err != nil {
+		t.Errorf("Lookup(registry): %v", err)
+	}
+	for _, name := range []string{"missing", "", "../csv"} {
+		if _, err := Lookup(name); err == nil {
+			t.Errorf("Lookup(%q): expected an error", name)
+		}
+	}
+}
+
+func TestConvert(t *testing.T) {
+	dir, _ := pluginDirs(t)
+	writePlugin(t, dir, "echo", `echo "protocol=$AIBOMGEN_CONVERT_PROTOCOL args=$*"; cat`+"\n")
+	writePlugin(t, dir, "fail", "echo 'unknown registry' >&2; exit 3\n")
+
+	bom := cdx.NewBOM()
+	bom.Metadata = &cdx.Metadata{Component: &cdx.Component{
+		Type: cdx.ComponentTypeMachineLearningModel,
+		Name: "org/model",
+		Tags: &[]string{"nlp"},
+	}}
+
+	p, err := Lookup("echo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	if err := Convert(context.Background(), p, bom, "1.5", []string{"--team", "ml"}, &out); err != nil {
+		t.Fatalf("Convert: %v", err)
+	}
+	got := out.String()
+	if !strings.HasPrefix(got, "protocol=1 args=--team ml\n") {
+		t.Errorf("unexpected plugin output header: %q", got)
+	}
+	if !strings.Contains(got, `"specVersion": "1.5"`) || !strings.Contains(got, `"name": "org/model"`) {
+		t.Errorf("plugin did not receive the BOM as CycloneDX 1.5 JSON: %s", got)
+	}
+	if strings.Contains(got, `"tags"`) {
+		t.Errorf("tags must be stripped for spec 1.5")
+	}
+
+	p, err = Lookup("fail")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = Convert(context.Background(), p, bom, "", nil, &out)
+	if err == nil || !strings.Contains(err.Error(), "unknown registry") {
+		t.Errorf("expected the plugin's stderr in the error, got %v", err)
+	}
+}
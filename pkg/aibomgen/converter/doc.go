@@ -0,0 +1,14 @@
+// Package converter runs output format converter plugins.
+//.
+// A converter plugin is an executable named "aibomgen-convert-<name>" on the.
+// PATH. It receives a CycloneDX JSON BOM on stdin and writes the converted.
+// document to stdout; anything it writes to stderr is reported when it exits.
+// with a non-zero status. The AIBOMGEN_CONVERT_PROTOCOL environment variable.
+// tells the plugin which version of this protocol is used (currently "1"),.
+// and extra command-line arguments are passed through unchanged. This lets.
+// formats for niche consumers (e.g. internal model registries) live outside.
+// this repository.
+//.
+// [Discover] lists the plugins found on the PATH, [Lookup] finds one by name.
+// and [Convert] runs it on a BOM.
+package converter
@@ -0,0 +1,519 @@
+package generator
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/idlab-discover/aibomgen-cli/internal/builder"
+	"github.com/idlab-discover/aibomgen-cli/internal/taxonomy"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/scanner"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	toml "github.com/pelletier/go-toml/v2"
+)
+
+// ApplicationInfo identifies the application a set of discoveries was.
+// scanned from. It becomes the metadata component of an application BOM.
+type ApplicationInfo struct {
+	Name    string
+	Version string
+	// VCSURL is the repository URL, added as a "vcs" external reference.
+	VCSURL string
+	// Path is the scanned directory.
+	Path string
+}
+
+// projectManifest is the identity declared by one kind of project file.
+type projectManifest struct {
+	Name    string
+	Version string
+	VCSURL  string
+}
+
+// InferApplicationInfo derives the application identity for the directory.
+// root from its project files and git metadata. Each field is taken from the.
+// first source that declares it, in this order: go.mod (module path),
+// pyproject.toml ([project] or [tool.poetry]), package.json, then git (the.
+// "origin" remote for name and VCS URL, the nearest tag for the version).
+// The name falls back to the directory name.
+func InferApplicationInfo(root string) ApplicationInfo {
+	info := ApplicationInfo{Path: root}
+
+	sources := []projectManifest{
+		{Name: goModuleName(filepath.Join(root, "go.mod"))},
+		pyprojectManifest(filepath.Join(root, "pyproject.toml")),
+		packageJSONManifest(filepath.Join(root, "package.json")),
+		gitManifest(root),
+	}
+	for _, m := range sources {
+		if info.Name == "" {
+			info.Name = m.Name
+		}
+		if info.Version == "" {
+			info.Version = m.Version
+		}
+		if info.VCSURL == "" {
+			info.VCSURL = m.VCSURL
+		}
+	}
+
+	if info.Name == "" {
+		info.Name = filepath.Base(filepath.Clean(root))
+		if info.Name == "." || info.Name == string(filepath.Separator) {
+			info.Name = "application"
+		}
+	}
+	return info
+}
+
+// goModuleName returns the module path declared in a go.mod file.
+func goModuleName(path string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if rest, ok := strings.CutPrefix(line, "module"); ok && rest != "" && (rest[0] == ' ' || rest[0] == '\t') {
+			return strings.Trim(strings.TrimSpace(rest), `"`)
+		}
+	}
+	return ""
+}
+
+// pyprojectManifest reads the project identity from a pyproject.toml file,.
+// preferring PEP 621 [project] over [tool.poetry].
+func pyprojectManifest(path string) projectManifest {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return projectManifest{}
+	}
+
+	type project struct {
+		Name    string            `toml:"name"`
+		Version string            `toml:"version"`
+		URLs    map[string]string `toml:"urls"`
+		// Poetry keeps the repository URL at the top level of its table.
+		Repository string `toml:"repository"`
+	}
+	var doc struct {
+		Project project `toml:"project"`
+		Tool    struct {
+			Poetry project `toml:"poetry"`
+		} `toml:"tool"`
+	}
+	if err := toml.Unmarshal(data, &doc); err != nil {
+		return projectManifest{}
+	}
+
+	p := doc.Project
+	if strings.TrimSpace(p.Name) == "" {
+		p = doc.Tool.Poetry
+	}
+	vcs := p.Repository
+	for _, key := range []string{"Repository", "repository", "Source", "source", "Source Code"} {
+		if vcs == "" {
+			vcs = p.URLs[key]
+		}
+	}
+	return projectManifest{
+		Name:    strings.TrimSpace(p.Name),
+		Version: strings.TrimSpace(p.Version),
+		VCSURL:  cleanVCSURL(vcs),
+	}
+}
+
+// packageJSONManifest reads the project identity from a package.json file.
+func packageJSONManifest(path string) projectManifest {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return projectManifest{}
+	}
+
+	var doc struct {
+		Name       string          `json:"name"`
+		Version    string          `json:"version"`
+		Repository json.RawMessage `json:"repository"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return projectManifest{}
+	}
+
+	// repository is either a string ("github:user/repo", "user/repo" or a.
+	// URL) or an object with a url field.
+	var repo string
+	if err := json.Unmarshal(doc.Repository, &repo); err != nil {
+		var obj struct {
+			URL string `json:"url"`
+		}
+		if json.Unmarshal(doc.Repository, &obj) == nil {
+			repo = obj.URL
+		}
+	}
+	repo = strings.TrimSpace(repo)
+	if rest, ok := strings.CutPrefix(repo, "github:"); ok {
+		repo = "https://github.com/" + rest
+	} else if repo != "" && !strings.Contains(repo, ":") && strings.Count(repo, "/") == 1 {
+		repo = "https://github.com/" + repo
+	}
+
+	return projectManifest{
+		Name:    strings.TrimSpace(doc.Name),
+		Version: strings.TrimSpace(doc.Version),
+		VCSURL:  cleanVCSURL(repo),
+	}
+}
+
+// gitManifest derives the project identity from the git repository.
+// containing dir: the name and VCS URL from the "origin" remote and the.
+// version from the nearest tag.
+func gitManifest(dir string) projectManifest {
+	remote := gitOriginURL(dir)
+	var m projectManifest
+	if remote != "" {
+		m.VCSURL = cleanVCSURL(remote)
+		name := strings.TrimSuffix(strings.TrimRight(remote, "/"), ".git")
+		if i := strings.LastIndexAny(name, "/:"); i >= 0 {
+			name = name[i+1:]
+		}
+		m.Name = name
+	}
+	m.Version = gitLatestTag(dir)
+	return m
+}
+
+// gitOriginURL returns the URL of the "origin" remote of the git repository.
+// containing dir.
+func gitOriginURL(dir string) string {
+	gitDir := findGitDir(dir)
+	if gitDir == "" {
+		return ""
+	}
+	f, err := os.Open(filepath.Join(gitDir, "config"))
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	inOrigin := false
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if strings.HasPrefix(line, "[") {
+			inOrigin = line == `[remote "origin"]`
+			continue
+		}
+		if !inOrigin {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if ok && strings.TrimSpace(key) == "url" {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+// gitLatestTag returns the nearest tag reachable from HEAD, or "" when dir.
+// is not in a git repository, has no tags or git is not installed.
+func gitLatestTag(dir string) string {
+	if findGitDir(dir) == "" {
+		return ""
+	}
+	cmd := exec.CommandContext(context.Background(), "git", "-C", dir, "describe", "--tags", "--abbrev=0")
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// findGitDir walks up from dir to the nearest .git directory.
+func findGitDir(dir string) string {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	for {
+		candidate := filepath.Join(dir, ".git")
+		if fi, err := os.Stat(candidate); err == nil && fi.IsDir() {
+			return candidate
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// cleanVCSURL normalizes a repository URL for the BOM: the "git+" prefix of.
+// npm URLs and the ".git" suffix are dropped, scp-like remotes.
+// (git@host:org/repo) become https URLs and credentials are removed.
+func cleanVCSURL(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return ""
+	}
+	raw = strings.TrimPrefix(raw, "git+")
+	raw = strings.TrimSuffix(strings.TrimRight(raw, "/"), ".git")
+
+	if !strings.Contains(raw, "://") {
+		if at := strings.Index(raw, "@"); at >= 0 {
+			if host, path, ok := strings.Cut(raw[at+1:], ":"); ok {
+				return "https://" + host + "/" + strings.TrimPrefix(path, "/")
+			}
+		}
+		return raw
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return raw
+	}
+	u.User = nil
+	if u.Scheme == "ssh" || u.Scheme == "git" {
+		u.Scheme = "https"
+		u.Host = u.Hostname()
+	}
+	return u.String()
+}
+
+// BuildApplicationBOM combines per-model BOMs into a single BOM whose.
+// metadata component is the application. Every model becomes a component.
+// alongside its datasets; the application depends on each model and each.
+// model keeps its dependencies on its datasets. Components shared between.
+// models (by BOM-ref) are listed once.
+func BuildApplicationBOM(app ApplicationInfo, results []DiscoveredBOM) (DiscoveredBOM, error) {
+	name := strings.TrimSpace(app.Name)
+	if name == "" {
+		name = "application"
+	}
+	appComp := &cdx.Component{
+		Type:    cdx.ComponentTypeApplication,
+		Name:    name,
+		Version: strings.TrimSpace(app.Version),
+	}
+	if vcs := strings.TrimSpace(app.VCSURL); vcs != "" {
+		appComp.ExternalReferences = &[]cdx.ExternalReference{{Type: cdx.ERTypeVCS, URL: vcs}}
+	}
+	builder.AddComponentBOMRef(appComp)
+
+	bom := cdx.NewBOM()
+	bom.Metadata = &cdx.Metadata{Component: appComp}
+	if err := builder.AddMetaSerialNumber(bom); err != nil {
+		return DiscoveredBOM{}, err
+	}
+	if err := builder.AddMetaTimestamp(bom); err != nil {
+		return DiscoveredBOM{}, err
+	}
+	if err := builder.AddMetaTools(bom, "", builder.GetAIBoMGenVersion()); err != nil {
+		return DiscoveredBOM{}, err
+	}
+	if err := builder.AddMetaTaxonomyVersion(bom); err != nil {
+		return DiscoveredBOM{}, err
+	}
+
+	var components []cdx.Component
+	var deps []cdx.Dependency
+	var vulns []cdx.Vulnerability
+	seenComp := make(map[string]bool)
+	depIndex := make(map[string]int)
+
+	addComponent := func(c cdx.Component) {
+		if c.BOMRef != "" {
+			if seenComp[c.BOMRef] {
+				return
+			}
+			seenComp[c.BOMRef] = true
+		}
+		components = append(components, c)
+	}
+
+	for _, r := range results {
+		if r.BOM == nil || r.BOM.Metadata == nil || r.BOM.Metadata.Component == nil {
+			continue
+		}
+		addComponent(*r.BOM.Metadata.Component)
+		if r.BOM.Components != nil {
+			for _, c := range *r.BOM.Components {
+				addComponent(c)
+			}
+		}
+		if r.BOM.Dependencies != nil {
+			for _, d := range *r.BOM.Dependencies {
+				i, ok := depIndex[d.Ref]
+				if !ok {
+					depIndex[d.Ref] = len(deps)
+					deps = append(deps, cdx.Dependency{Ref: d.Ref})
+					i = len(deps) - 1
+				}
+				if d.Dependencies != nil {
+					deps[i].Dependencies = appendUniqueRefs(deps[i].Dependencies, *d.Dependencies)
+				}
+			}
+		}
+		if r.BOM.Vulnerabilities != nil {
+			vulns = append(vulns, *r.BOM.Vulnerabilities...)
+		}
+	}
+
+	if len(components) > 0 {
+		bom.Components = &components
+	}
+	if len(deps) > 0 {
+		bom.Dependencies = &deps
+	}
+	if len(vulns) > 0 {
+		bom.Vulnerabilities = &vulns
+	}
+	builder.AddApplicationDependencies(bom)
+
+	members := make([]scanner.Discovery, 0, len(results))
+	for _, r := range results {
+		if r.BOM != nil {
+			members = append(members, r.Discovery)
+		}
+	}
+	builder.SetMetaLifecycle(bom, lifecyclePhaseOf(members...))
+
+	return DiscoveredBOM{
+		Discovery: scanner.Discovery{Name: name, Path: app.Path},
+		BOM:       bom,
+	}, nil
+}
+
+// appendUniqueRefs appends refs to list, skipping refs already present.
+func appendUniqueRefs(list *[]string, refs []string) *[]string {
+	var out []string
+	if list != nil {
+		out = *list
+	}
+	for _, ref := range refs {
+		dup := false
+		for _, existing := range out {
+			if existing == ref {
+				dup = true
+				break
+			}
+		}
+		if !dup {
+			out = append(out, ref)
+		}
+	}
+	return &out
+}
+
+// BuildPerProject generates one application BOM per project of a monorepo.
+// scan (see [scanner.ScanProjects]). Each model is fetched and built once,.
+// even when several projects use it, and then listed in the BOM of every.
+// project that references it. The hosted model APIs of a project become.
+// services of its BOM. Projects without generated models or hosted models.
+// are left out. Each application is identified with [InferApplicationInfo].
+// on the project root.
+func BuildPerProject(projects []scanner.Project, opts GenerateOptions) ([]DiscoveredBOM, error) {
+	models, err := BuildPerDiscovery(scanner.ProjectDiscoveries(projects), opts)
+	if err != nil {
+		return nil, err
+	}
+	byKey := make(map[string]*cdx.BOM, len(models))
+	for _, m := range models {
+		byKey[m.Discovery.Type+"::"+m.Discovery.ID] = m.BOM
+	}
+
+	var results []DiscoveredBOM
+	for _, p := range projects {
+		var group []DiscoveredBOM
+		hosted := 0
+		for _, d := range p.Discoveries {
+			if bom, ok := byKey[d.Type+"::"+d.ID]; ok {
+				group = append(group, DiscoveredBOM{Discovery: d, BOM: bom})
+			}
+			if d.Type == scanner.DiscoveryTypeHostedModel {
+				hosted++
+			}
+		}
+		if len(group) == 0 && hosted == 0 {
+			continue
+		}
+		app, err := BuildApplicationBOM(InferApplicationInfo(filepath.FromSlash(p.Root)), group)
+		if err != nil {
+			return nil, err
+		}
+		AddHostedServices(app.BOM, p.Discoveries)
+		results = append(results, app)
+	}
+	return results, nil
+}
+
+// AddHostedServices records the hosted model APIs among discoveries (OpenAI,.
+// Anthropic, Azure OpenAI and Amazon Bedrock models) as services of bom, on.
+// which its metadata component depends. It returns the number of services.
+// added.
+func AddHostedServices(bom *cdx.BOM, discoveries []scanner.Discovery) int {
+	return builder.AddHostedServices(bom, discoveries)
+}
+
+// BuildHostedServicesBOM builds the BOM of the application app that uses the.
+// hosted model APIs among discoveries, each as a service. ok is false when.
+// discoveries name no hosted model.
+func BuildHostedServicesBOM(app ApplicationInfo, discoveries []scanner.Discovery) (result DiscoveredBOM, ok bool, err error) {
+	var hosted []scanner.Discovery
+	for _, d := range discoveries {
+		if d.Type == scanner.DiscoveryTypeHostedModel {
+			hosted = append(hosted, d)
+		}
+	}
+	if len(hosted) == 0 {
+		return DiscoveredBOM{}, false, nil
+	}
+	result, err = BuildApplicationBOM(app, nil)
+	if err != nil {
+		return DiscoveredBOM{}, false, err
+	}
+	AddHostedServices(result.BOM, hosted)
+	builder.SetMetaLifecycle(result.BOM, lifecyclePhaseOf(hosted...))
+	return result, true, nil
+}
+
+// BuildServingBundleBOMs builds an application BOM for every serving bundle.
+// (BentoML service, KServe InferenceService) the discoveries of results.
+// belong to. The bundle is the metadata component, marked with its framework,.
+// and depends on the models it packages. Results outside any bundle are left.
+// out.
+func BuildServingBundleBOMs(results []DiscoveredBOM) ([]DiscoveredBOM, error) {
+	var bundles []scanner.ServingBundle
+	groups := make(map[scanner.ServingBundle][]DiscoveredBOM)
+	for _, r := range results {
+		if r.BOM == nil {
+			continue
+		}
+		for _, b := range r.Discovery.Bundles {
+			if _, ok := groups[b]; !ok {
+				bundles = append(bundles, b)
+			}
+			groups[b] = append(groups[b], r)
+		}
+	}
+
+	out := make([]DiscoveredBOM, 0, len(bundles))
+	for _, b := range bundles {
+		app, err := BuildApplicationBOM(ApplicationInfo{Name: b.Name, Path: b.Path}, groups[b])
+		if err != nil {
+			return nil, err
+		}
+		comp := app.BOM.Metadata.Component
+		comp.Properties = taxonomy.Set(comp.Properties, taxonomy.ServingFramework, b.Kind)
+		out = append(out, app)
+	}
+	return out, nil
+}
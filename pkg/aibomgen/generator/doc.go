@@ -3,11 +3,30 @@
 //.
 // For each [scanner.Discovery], the generator fetches model metadata from the.
 // Hugging Face Hub (API response, model card / README, optionally the security.
-// scan tree), builds linked dataset components, and produces a CycloneDX BOM.
-// via the internal builder.
+// scan tree, the root license file), builds linked dataset components, and.
+// produces a CycloneDX BOM via the internal builder. The parts of diffusers pipelines are nested under.
+// the pipeline component. Adapter discoveries produce a BOM for the adapter.
+// that contains its base model as a pedigree ancestor and dependency.
 //.
 // The primary entry point is [BuildPerDiscovery]. Progress during generation is.
 // reported through the [ProgressCallback] supplied in [GenerateOptions].
+// [BuildFromModelIDs] generates up to GenerateOptions.Concurrency models at.
+// the same time; the callback is still called from one goroutine at a time.
+// With GenerateOptions.MaxRetries, rate-limited and transiently failing Hub.
+// requests are retried with backoff; each retry is reported as [EventRetry].
+// When the Hub cannot be reached at all, [EventHubUnreachable] is reported.
+// once and the remaining models are built from the scan alone.
 // [BuildDummyBOM] produces a fully-populated fixture BOM without any network.
-// calls, intended for offline testing and demos.
+// calls, intended for offline testing and demos. [BuildFromLocalPath] builds.
+// the BOM of a model repo downloaded or cloned to disk, reading its files.
+// instead of the Hub API, for air-gapped environments.
+//.
+// [BuildApplicationBOM] combines the per-model BOMs of a scan into a single.
+// BOM whose metadata component is the scanned application, identified with.
+// [InferApplicationInfo].
+// [BuildServingBundleBOMs] does the same for every BentoML service and KServe.
+// InferenceService the scan found the models of.
+//.
+// [ApplyLifecycle] records the CycloneDX lifecycle phase of the generated BOMs:.
+// operations for models found only in deployment manifests, design otherwise.
 package generator
@@ -1,16 +1,24 @@
 package generator
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/idlab-discover/aibomgen-cli/internal/bomcache"
 	"github.com/idlab-discover/aibomgen-cli/internal/builder"
 	"github.com/idlab-discover/aibomgen-cli/internal/fetcher"
+	"github.com/idlab-discover/aibomgen-cli/internal/tracing"
 	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/scanner"
 
 	cdx "github.com/CycloneDX/cyclonedx-go"
+	"golang.org/x/sync/errgroup"
 )
 
 // DiscoveredBOM pairs a scanner discovery with the CycloneDX BOM generated.
@@ -46,20 +54,73 @@ type fetcherSet struct {
 	modelTree interface {
 		Fetch(string) ([]fetcher.SecurityFileEntry, error)
 	}
+	modelIndex interface {
+		Fetch(string) (*fetcher.ModelIndex, error)
+	}
+	modelConfig interface {
+		Fetch(string) (*fetcher.ModelConfig, error)
+	}
+	licenseFile interface {
+		Fetch(string, string) (*fetcher.LicenseFile, error)
+	}
+
+	// scope and untraced are set by traceFetchers: the scope the fetchers.
+	// record their spans in and the fetchers before tracing.
+	scope    *tracing.Scope
+	untraced *fetcherSet
 }
 
-var newFetcherSet = func(httpClient *http.Client) fetcherSet {
+var newFetcherSet = func(clients httpClients) fetcherSet {
 	return fetcherSet{
-		modelAPI:      &fetcher.ModelAPIFetcher{Client: httpClient},
-		modelReadme:   &fetcher.ModelReadmeFetcher{Client: httpClient},
-		datasetAPI:    &fetcher.DatasetAPIFetcher{Client: httpClient},
-		datasetReadme: &fetcher.DatasetReadmeFetcher{Client: httpClient},
-		modelTree:     &fetcher.ModelTreeFetcher{Client: httpClient},
+		modelAPI:      &fetcher.ModelAPIFetcher{Client: clients.api},
+		modelReadme:   &fetcher.ModelReadmeFetcher{Client: clients.readme},
+		datasetAPI:    &fetcher.DatasetAPIFetcher{Client: clients.dataset},
+		datasetReadme: &fetcher.DatasetReadmeFetcher{Client: clients.dataset},
+		modelTree:     &fetcher.ModelTreeFetcher{Client: clients.api},
+		modelIndex:    &fetcher.ModelIndexFetcher{Client: clients.api},
+		modelConfig:   &fetcher.ModelConfigFetcher{Client: clients.api},
+		licenseFile:   &fetcher.ModelLicenseFetcher{Client: clients.api},
 	}
 }
 
-func newHTTPClient(opts GenerateOptions) *http.Client {
-	return fetcher.NewHFClient(opts.Timeout, opts.HFToken)
+func newHTTPClient(opts GenerateOptions, timeout time.Duration, onRetry func(fetcher.RetryAttempt)) *http.Client {
+	return fetcher.NewHFClientWithRetry(timeout, opts.HFToken, fetcher.RetryPolicy{
+		MaxRetries: opts.MaxRetries,
+		MaxBackoff: opts.MaxBackoff,
+		OnRetry:    onRetry,
+	})
+}
+
+// retryReporter returns a fetcher.RetryPolicy.OnRetry callback that reports.
+// retries as EventRetry events of the model last passed to setModel.
+func retryReporter(progress ProgressCallback) (onRetry func(fetcher.RetryAttempt), setModel func(string)) {
+	var current atomic.Pointer[string]
+	onRetry = func(a fetcher.RetryAttempt) {
+		var modelID string
+		if p := current.Load(); p != nil {
+			modelID = *p
+		}
+		progress(ProgressEvent{
+			Type:    EventRetry,
+			ModelID: modelID,
+			Error:   a.Err,
+			Message: fmt.Sprintf("%s from %s, retry %d/%d in %s", a.Reason(), a.URL, a.Attempt, a.MaxRetries, a.Wait.Round(100*time.Millisecond)),
+		})
+	}
+	setModel = func(modelID string) { current.Store(&modelID) }
+	return onRetry, setModel
+}
+
+// Local fetcher factory for BuildFromLocalPath. A local repo has no Hub.
+// security scan results, and referenced datasets are not available offline.
+var newLocalFetcherSet = func(dir string) fetcherSet {
+	return fetcherSet{
+		modelAPI:    &fetcher.LocalModelAPIFetcher{Dir: dir},
+		modelReadme: &fetcher.LocalModelReadmeFetcher{Dir: dir},
+		modelIndex:  &fetcher.LocalModelIndexFetcher{Dir: dir},
+		modelConfig: &fetcher.LocalModelConfigFetcher{Dir: dir},
+		licenseFile: &fetcher.LocalModelLicenseFetcher{Dir: dir},
+	}
 }
 
 // Dummy fetcher factory for BuildDummyBOM testing.
@@ -70,6 +131,9 @@ var newDummyFetcherSet = func() fetcherSet {
 		datasetAPI:    &fetcher.DummyDatasetAPIFetcher{},
 		datasetReadme: &fetcher.DummyDatasetReadmeFetcher{},
 		modelTree:     &fetcher.DummyModelTreeFetcher{},
+		modelIndex:    &fetcher.DummyModelIndexFetcher{},
+		modelConfig:   &fetcher.DummyModelConfigFetcher{},
+		licenseFile:   &fetcher.DummyModelLicenseFetcher{},
 	}
 }
 
@@ -104,6 +168,13 @@ const (
 	EventDatasetError // dataset fetch/build failed (non-fatal; model processing continues)
 	EventModelComplete
 	EventError
+	EventCacheHit // BOM reused from the local BOM cache; no further fetches for this model
+	EventRetry    // a Hugging Face request is retried (rate limit or transient failure); Message says why and when
+	// EventHubUnreachable is sent once per run, when a fetch first fails.
+	// because the Hugging Face Hub is unreachable (fetcher.ErrHubUnreachable).
+	// Further Hub requests fail at once, and the models are built from the.
+	// scan alone; Error is the first such error.
+	EventHubUnreachable
 )
 
 // GenerateOptions configures the generation process.
@@ -112,6 +183,49 @@ type GenerateOptions struct {
 	Timeout          time.Duration
 	OnProgress       ProgressCallback
 	SkipSecurityScan bool // when true, the HF tree security scan is not fetched
+	// Cache, when non-nil, reuses previously generated BOMs for model revisions.
+	// that did not change. BOMs are only stored when generation had no errors.
+	Cache *bomcache.Cache
+	// ProbeWeightURLs sends a HEAD request for every external weight URL to.
+	// record its size and ETag.
+	ProbeWeightURLs bool
+	// IncludeRawMetadata stores the compressed Hugging Face API response and.
+	// model card front matter on the model component.
+	IncludeRawMetadata bool
+	// Context is the parent of the trace spans of the run (nil: none). See.
+	// the tracing package. Cancelling it also ends weight URL probes.
+	Context context.Context
+	// Concurrency is the number of models BuildFromModelIDs fetches and.
+	// builds at the same time; values below 2 build them one after another.
+	// The results keep the order of the model IDs, the events of one model.
+	// keep their order, and OnProgress is never called concurrently.
+	Concurrency int
+	// MaxRetries is the number of times a rate-limited (429) or transiently.
+	// failing (5xx, connection error) Hugging Face request is retried, with.
+	// exponential backoff or the wait the Hub asks for (0: no retries). Every.
+	// retry is reported as an EventRetry event; Timeout then applies to each.
+	// attempt.
+	MaxRetries int
+	// MaxBackoff caps the wait before a retry (0: fetcher.DefaultMaxBackoff).
+	MaxBackoff time.Duration
+	// APITimeout, ReadmeTimeout and DatasetTimeout replace Timeout for the.
+	// requests of one fetch stage (0: Timeout). APITimeout covers the model.
+	// API and the repository files (tree, config.json, LICENSE,.
+	// model_index.json), ReadmeTimeout the model cards, which can be large,.
+	// and DatasetTimeout the dataset API and dataset cards.
+	APITimeout     time.Duration
+	ReadmeTimeout  time.Duration
+	DatasetTimeout time.Duration
+	// ModelDeadline bounds all requests made for one model, its datasets,.
+	// base models and retries included (0: none). Requests still running at.
+	// the deadline fail with an error wrapping ErrModelDeadline, and the BOM.
+	// is built from what was fetched until then.
+	ModelDeadline time.Duration
+	// BaseModelDepth is the number of levels of the base_model chain of a.
+	// model card that are followed (0: none). Every ancestor is fetched and.
+	// added as a component, linked through pedigree and the dependency graph.
+	// Adapters always get their base model, and its ancestors up to this depth.
+	BaseModelDepth int
 }
 
 // BuildDummyBOM builds a single comprehensive dummy BOM with all fields populated.
@@ -144,6 +258,9 @@ func BuildDummyBOM() ([]DiscoveredBOM, error) {
 		securityTree, _ = fetchers.modelTree.Fetch("dummy-org/dummy-model")
 	}
 
+	licenseFile := fetchLicenseFile(fetchers, apiResp, "dummy-org/dummy-model", func(ProgressEvent) {})
+	modelConfig := fetchModelConfig(fetchers, apiResp, "dummy-org/dummy-model", func(ProgressEvent) {})
+
 	// Build the BOM with all dummy data.
 	bctx := builder.BuildContext{
 		ModelID:      "dummy-org/dummy-model",
@@ -151,6 +268,8 @@ func BuildDummyBOM() ([]DiscoveredBOM, error) {
 		HF:           apiResp,
 		Readme:       readme,
 		SecurityTree: securityTree,
+		LicenseFile:  licenseFile,
+		Config:       modelConfig,
 	}
 
 	bomBuilder := newBOMBuilder()
@@ -161,10 +280,11 @@ func BuildDummyBOM() ([]DiscoveredBOM, error) {
 
 	// Build dataset components for any datasets referenced in the model's training metadata.
 	noProgress := func(ProgressEvent) {}
-	buildDatasetComponents(fetchers, bom, extractDatasetsFromModel(apiResp, readme), "dummy-org/dummy-model", noProgress)
+	buildDatasetComponents(fetchers, bomBuilder, bom, extractDatasetsFromModel(apiResp, readme), "dummy-org/dummy-model", noProgress)
 
 	// Add dependencies from model to datasets.
 	builder.AddDependencies(bom)
+	builder.AddGeneratingModels(bom)
 
 	return []DiscoveredBOM{
 		{
@@ -188,22 +308,115 @@ func BuildPerDiscovery(discoveries []scanner.Discovery, opts GenerateOptions) ([
 		progress = func(ProgressEvent) {}
 	}
 
+	progress, failed := trackFailures(reportHubOutage(progress))
+	cliVersion := cacheVersion(opts)
+
 	results := make([]DiscoveredBOM, 0, len(discoveries))
 
-	fetchers := newFetcherSet(newHTTPClient(opts))
-	bomBuilder := newBOMBuilder()
+	scope := tracing.NewScope(opts.Context)
+	onRetry, setModel := retryReporter(progress)
+	deadline := &modelDeadline{budget: opts.ModelDeadline}
+	client := func(c *http.Client) *http.Client { return scope.Client(deadline.client(c)) }
+	fetchers := traceFetchers(newFetcherSet(newHTTPClients(opts, onRetry, client)), scope)
+	bomBuilder := tracedBuilder{newBOMBuilder(), scope}
+	var kaggle kaggleFetcher
+	var ollama ollamaFetcher
+
+	// The span of a model ends where the next model starts.
+	endModel := func(error) {}
+	defer func() { endModel(nil) }()
 
 	for i, d := range discoveries {
+		endModel(nil)
 		modelID := strings.TrimSpace(d.ID)
 		if modelID == "" {
 			modelID = strings.TrimSpace(d.Name)
 		}
 
+		// Templated references have no concrete repository to fetch, and.
+		// hosted models are services of the project (see AddHostedServices).
+		if d.Type == scanner.DiscoveryTypeParameterized || d.Type == scanner.DiscoveryTypeHostedModel {
+			continue
+		}
+		endModel = startModel(scope, modelID, d.Type)
+		setModel(modelID)
+		deadline.start()
+
+		// External weight URLs are built like local weight files, optionally.
+		// with the size and ETag reported by the server.
+		if d.Type == scanner.DiscoveryTypeWeightURL && opts.ProbeWeightURLs {
+			if err := probeWeightURL(scope.Context(), client(newProbeClient(opts.Timeout)), &d); err != nil {
+				progress(ProgressEvent{Type: EventError, ModelID: d.Name, Error: err, Message: "weight URL probe failed"})
+			}
+		}
+
+		// Ollama models are described by the Ollama registry.
+		if _, _, _, ok := scanner.OllamaRef(d); ok {
+			if ollama == nil {
+				ollama = tracedOllamaFetch{newOllamaFetcher(client(newOllamaClient(opts, onRetry))), scope}
+			}
+			if r, ok := buildOllama(ollama, bomBuilder, d, i, len(discoveries), progress); ok {
+				results = append(results, r)
+			}
+			continue
+		}
+
+		// Local weight files and models of other providers are built from the.
+		// scan alone: there is no Hub repository to fetch metadata or security.
+		// results for.
+		if d.Type == scanner.DiscoveryTypeModelFile || d.Type == scanner.DiscoveryTypeWeightURL || d.Type == scanner.DiscoveryTypeProviderModel {
+			if r, ok := buildLocalModelFile(bomBuilder, d, i, len(discoveries), progress); ok {
+				results = append(results, r)
+			}
+			continue
+		}
+
+		// Artifacts registered with DVC or MLflow are built like the files.
+		// they track, under their registered name.
+		if d.Type == scanner.DiscoveryTypeTrackedArtifact {
+			build := buildLocalModelFile
+			if d.ArtifactType == scanner.ArtifactTypeDataset {
+				build = buildLocalDataFile
+			}
+			if r, ok := build(bomBuilder, d, i, len(discoveries), progress); ok {
+				results = append(results, r)
+			}
+			continue
+		}
+
+		// Dataset files tracked by DVC or Git LFS get a BOM of their own.
+		if d.Type == scanner.DiscoveryTypeDataFile {
+			if r, ok := buildLocalDataFile(bomBuilder, d, i, len(discoveries), progress); ok {
+				results = append(results, r)
+			}
+			continue
+		}
+
+		// Kaggle models and datasets are described by the Kaggle API, with.
+		// its own credentials.
+		if d.Type == scanner.DiscoveryTypeKaggle {
+			if kaggle == nil {
+				kaggle = tracedKaggleFetch{newKaggleFetcher(client(newKaggleClient(opts, onRetry))), scope}
+			}
+			if r, ok := buildKaggle(kaggle, bomBuilder, d, i, len(discoveries), progress); ok {
+				results = append(results, r)
+			}
+			continue
+		}
+
+		// Adapters are built from the scan and linked to their base model.
+		if d.Type == scanner.DiscoveryTypeAdapter {
+			if r, ok := buildAdapter(fetchers, bomBuilder, d, opts.BaseModelDepth, i, len(discoveries), progress); ok {
+				results = append(results, r)
+			}
+			continue
+		}
+
 		progress(ProgressEvent{Type: EventFetchStart, ModelID: modelID, Index: i, Total: len(discoveries)})
 
 		var resp *fetcher.ModelAPIResponse
 		var readme *fetcher.ModelReadmeCard
-		var apiNotFound bool
+		var apiNotFound, hubDown bool
 
 		if modelID != "" {
 			if r, err := fetchers.modelAPI.Fetch(modelID); err == nil {
@@ -213,6 +426,7 @@ func BuildPerDiscovery(discoveries []scanner.Discovery, opts GenerateOptions) ([
 				if fetcher.IsNotFound(err) || fetcher.IsUnauthorized(err) {
 					apiNotFound = true
 				}
+				hubDown = errors.Is(err, fetcher.ErrHubUnreachable)
 				progress(ProgressEvent{Type: EventError, ModelID: modelID, Error: err, Message: fetchErrMessage("API", err)})
 			}
 
@@ -222,16 +436,25 @@ func BuildPerDiscovery(discoveries []scanner.Discovery, opts GenerateOptions) ([
 				continue
 			}
 
-			if c, err := fetchers.modelReadme.Fetch(modelID); err == nil {
-				readme = c
-				progress(ProgressEvent{Type: EventFetchReadmeComplete, ModelID: modelID})
-			} else {
-				progress(ProgressEvent{Type: EventError, ModelID: modelID, Error: err, Message: fetchErrMessage("README", err)})
+			if bom, ok := opts.Cache.Get(cacheKey(modelID, d, resp, cliVersion, opts)); ok {
+				results = append(results, cachedResult(d, bom, modelID, progress))
+				continue
+			}
+
+			// Without the Hub the model is built from the scan alone.
+			if !hubDown {
+				if c, err := fetchers.modelReadme.Fetch(modelID); err == nil {
+					readme = c
+					progress(ProgressEvent{Type: EventFetchReadmeComplete, ModelID: modelID})
+				} else {
+					hubDown = errors.Is(err, fetcher.ErrHubUnreachable)
+					progress(ProgressEvent{Type: EventError, ModelID: modelID, Error: err, Message: fetchErrMessage("README", err)})
+				}
 			}
 		}
 
 		var securityTree []fetcher.SecurityFileEntry
-		if modelID != "" && !opts.SkipSecurityScan && fetchers.modelTree != nil {
+		if modelID != "" && !hubDown && !opts.SkipSecurityScan && fetchers.modelTree != nil {
 			if tree, err := fetchers.modelTree.Fetch(modelID); err == nil {
 				securityTree = tree
 				progress(ProgressEvent{Type: EventFetchSecurityScanComplete, ModelID: modelID})
@@ -241,6 +464,9 @@ func BuildPerDiscovery(discoveries []scanner.Discovery, opts GenerateOptions) ([
 			}
 		}
 
+		licenseFile := fetchLicenseFile(fetchers, resp, modelID, progress)
+		modelConfig := fetchModelConfig(fetchers, resp, modelID, progress)
+
 		progress(ProgressEvent{Type: EventBuildStart, ModelID: modelID})
 
 		bctx := builder.BuildContext{
@@ -249,6 +475,8 @@ func BuildPerDiscovery(discoveries []scanner.Discovery, opts GenerateOptions) ([
 			HF:           resp,
 			Readme:       readme,
 			SecurityTree: securityTree,
+			LicenseFile:  licenseFile,
+			Config:       modelConfig,
 		}
 
 		bom, err := bomBuilder.Build(bctx)
@@ -259,13 +487,29 @@ func BuildPerDiscovery(discoveries []scanner.Discovery, opts GenerateOptions) ([
 
 		progress(ProgressEvent{Type: EventBuildComplete, ModelID: modelID})
 
-		datasetCount := buildDatasetComponents(fetchers, bom, extractDatasetsFromModel(resp, readme), modelID, progress)
+		if opts.IncludeRawMetadata {
+			if err := builder.AddRawMetadata(bom, resp, readme); err != nil {
+				progress(ProgressEvent{Type: EventError, ModelID: modelID, Error: err, Message: "raw metadata not stored"})
+			}
+		}
+
+		buildPipelineComponents(fetchers, bom, resp, modelID, progress)
+		datasetCount := buildDatasetComponents(fetchers, bomBuilder, bom, extractDatasetsFromModel(resp, readme), modelID, progress)
 
 		// Add dependencies from model to datasets.
 		builder.AddDependencies(bom)
+		if opts.BaseModelDepth > 0 {
+			builder.AddBaseModelChain(bom, buildBaseModelChain(fetchers, bomBuilder, d, modelID, baseModelID(readme), opts.BaseModelDepth, progress))
+		}
+		builder.AddGeneratingModels(bom)
 
 		progress(ProgressEvent{Type: EventModelComplete, ModelID: modelID, Datasets: datasetCount})
 
+		if !failed(modelID) {
+			// Best effort: a cache write failure must not fail generation.
+			_ = opts.Cache.Put(cacheKey(modelID, d, resp, cliVersion, opts), bom)
+		}
+
 		results = append(results, DiscoveredBOM{
 			Discovery: d,
 			BOM:       bom,
@@ -275,6 +519,377 @@ func BuildPerDiscovery(discoveries []scanner.Discovery, opts GenerateOptions) ([
 	return results, nil
 }
 
+// trackFailures wraps progress so that every model ID that reported an error.
+// (including non-fatal fetch and dataset errors) is recorded; failed reports.
+// them. Such partial BOMs are never written to the BOM cache. The returned.
+// callback may be called from several goroutines: the calls are serialized.
+func trackFailures(progress ProgressCallback) (wrapped ProgressCallback, failed func(modelID string) bool) {
+	var mu sync.Mutex
+	errs := make(map[string]bool)
+	wrapped = func(evt ProgressEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		if evt.Type == EventError || evt.Type == EventDatasetError {
+			errs[evt.ModelID] = true
+		}
+		progress(evt)
+	}
+	failed = func(modelID string) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return errs[modelID]
+	}
+	return wrapped, failed
+}
+
+// reportHubOutage wraps progress so that the first error event of a run.
+// that wraps fetcher.ErrHubUnreachable is preceded by an EventHubUnreachable.
+// event. The returned callback may be called from several goroutines.
+func reportHubOutage(progress ProgressCallback) ProgressCallback {
+	var once sync.Once
+	return func(evt ProgressEvent) {
+		if (evt.Type == EventError || evt.Type == EventDatasetError) && errors.Is(evt.Error, fetcher.ErrHubUnreachable) {
+			once.Do(func() {
+				progress(ProgressEvent{
+					Type:    EventHubUnreachable,
+					ModelID: evt.ModelID,
+					Error:   evt.Error,
+					Message: "Hugging Face Hub unreachable; building the remaining models without Hub metadata",
+				})
+			})
+		}
+		progress(evt)
+	}
+}
+
+// cacheVersion returns the CLI version used in BOM cache keys. It is resolved.
+// once per run since it may shell out to git in development builds.
+func cacheVersion(opts GenerateOptions) string {
+	if opts.Cache == nil {
+		return ""
+	}
+	return builder.GetAIBoMGenVersion()
+}
+
+// cacheKey derives the BOM cache key for a model whose API metadata was fetched.
+// The discovery is part of the key: scan and generate build BOMs of the same.
+// model from different discoveries, which the BOM records. Only the model's.
+// revision is: dataset and base model revisions would take their own fetches.
+func cacheKey(modelID string, d scanner.Discovery, resp *fetcher.ModelAPIResponse, cliVersion string, opts GenerateOptions) bomcache.Key {
+	k := bomcache.Key{ModelID: modelID, CLIVersion: cliVersion}
+	if data, err := json.Marshal(d); err == nil {
+		k.Discovery = string(data)
+	}
+	if resp != nil {
+		k.Revision = resp.SHA
+	}
+	var profile []string
+	if opts.SkipSecurityScan {
+		profile = append(profile, "no-security-scan")
+	}
+	if opts.IncludeRawMetadata {
+		profile = append(profile, "raw-metadata")
+	}
+	if opts.BaseModelDepth > 0 {
+		profile = append(profile, fmt.Sprintf("base-model-depth=%d", opts.BaseModelDepth))
+	}
+	k.Profile = strings.Join(profile, ",")
+	return k
+}
+
+// cachedResult reports a BOM cache hit and wraps the cached BOM as a result.
+// The BOM gets a new serial number and timestamp, as a rebuilt one would.
+func cachedResult(d scanner.Discovery, bom *cdx.BOM, modelID string, progress ProgressCallback) DiscoveredBOM {
+	bom.SerialNumber = ""
+	_ = builder.AddMetaSerialNumber(bom)
+	if bom.Metadata != nil {
+		bom.Metadata.Timestamp = ""
+		_ = builder.AddMetaTimestamp(bom)
+	}
+	datasets := 0
+	if bom.Components != nil {
+		for _, c := range *bom.Components {
+			if c.Type == cdx.ComponentTypeData {
+				datasets++
+			}
+		}
+	}
+	progress(ProgressEvent{Type: EventCacheHit, ModelID: modelID})
+	progress(ProgressEvent{Type: EventModelComplete, ModelID: modelID, Datasets: datasets, Message: "reused from BOM cache"})
+	return DiscoveredBOM{Discovery: d, BOM: bom}
+}
+
+// buildLocalModelFile builds a BOM for a "model-file", "weight-url" or model.
+// "tracked-artifact" discovery without any Hugging Face lookups. The header of a local GGUF,.
+// ONNX or safetensors file describes the model.
+func buildLocalModelFile(bomBuilder bomBuilder, d scanner.Discovery, index, total int, progress ProgressCallback) (DiscoveredBOM, bool) {
+	name := strings.TrimSpace(d.Name)
+
+	progress(ProgressEvent{Type: EventFetchStart, ModelID: name, Index: index, Total: total})
+	progress(ProgressEvent{Type: EventBuildStart, ModelID: name})
+
+	bom, err := bomBuilder.Build(builder.BuildContext{Scan: d, GGUF: localGGUF(d), ONNX: localONNX(d), Safetensors: localSafetensors(d)})
+	if err != nil {
+		progress(ProgressEvent{Type: EventError, ModelID: name, Error: err, Message: "BOM build failed"})
+		return DiscoveredBOM{}, false
+	}
+
+	progress(ProgressEvent{Type: EventBuildComplete, ModelID: name})
+	progress(ProgressEvent{Type: EventModelComplete, ModelID: name})
+
+	return DiscoveredBOM{Discovery: d, BOM: bom}, true
+}
+
+// localGGUF reads the header of the GGUF file of a "model-file" discovery. It.
+// returns nil for other discoveries and for files that cannot be read, such.
+// as weights referenced by a script but not present in the scanned tree.
+func localGGUF(d scanner.Discovery) *fetcher.GGUFHeader {
+	if !isLocalWeights(d) || !strings.EqualFold(d.Format, "gguf") {
+		return nil
+	}
+	h, err := fetcher.ReadGGUF(d.Path)
+	if err != nil {
+		return nil
+	}
+	return h
+}
+
+// isLocalWeights reports whether the Path of d is a weights file: that of a.
+// "model-file" or of a model "tracked-artifact".
+func isLocalWeights(d scanner.Discovery) bool {
+	return d.Type == scanner.DiscoveryTypeModelFile || d.Type == scanner.DiscoveryTypeTrackedArtifact && d.ArtifactType == scanner.ArtifactTypeModel
+}
+
+// localONNX reads the model metadata of the ONNX file of a "model-file".
+// discovery, like localGGUF.
+func localONNX(d scanner.Discovery) *fetcher.ONNXModel {
+	if !isLocalWeights(d) || !strings.EqualFold(d.Format, "onnx") {
+		return nil
+	}
+	m, err := fetcher.ReadONNX(d.Path)
+	if err != nil {
+		return nil
+	}
+	return m
+}
+
+// localSafetensors reads the JSON header of the safetensors file of a.
+// "model-file" discovery, like localGGUF.
+func localSafetensors(d scanner.Discovery) *fetcher.SafetensorsHeader {
+	if !isLocalWeights(d) || !strings.EqualFold(d.Format, "safetensors") {
+		return nil
+	}
+	h, err := fetcher.ReadSafetensors(d.Path)
+	if err != nil {
+		return nil
+	}
+	return h
+}
+
+// buildLocalDataFile builds a BOM for a "data-file" or dataset.
+// "tracked-artifact" discovery whose metadata component is the dataset file,.
+// with the digest its pointer or tracker records.
+func buildLocalDataFile(bomBuilder bomBuilder, d scanner.Discovery, index, total int, progress ProgressCallback) (DiscoveredBOM, bool) {
+	name := strings.TrimSpace(d.Name)
+
+	progress(ProgressEvent{Type: EventFetchStart, ModelID: name, Index: index, Total: total})
+	progress(ProgressEvent{Type: EventBuildStart, ModelID: name})
+
+	bom, err := datasetBOM(bomBuilder, builder.DatasetBuildContext{DatasetID: d.Name, Scan: d})
+	if err != nil {
+		progress(ProgressEvent{Type: EventError, ModelID: name, Error: err, Message: "BOM build failed"})
+		return DiscoveredBOM{}, false
+	}
+
+	progress(ProgressEvent{Type: EventBuildComplete, ModelID: name})
+	progress(ProgressEvent{Type: EventModelComplete, ModelID: name})
+
+	return DiscoveredBOM{Discovery: d, BOM: bom}, true
+}
+
+// datasetBOM builds a BOM whose metadata component is the dataset of ctx.
+func datasetBOM(bomBuilder bomBuilder, ctx builder.DatasetBuildContext) (*cdx.BOM, error) {
+	comp, err := bomBuilder.BuildDataset(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bom := cdx.NewBOM()
+	bom.Metadata = &cdx.Metadata{Component: comp}
+	if err := builder.AddMetaSerialNumber(bom); err != nil {
+		return nil, err
+	}
+	if err := builder.AddMetaTimestamp(bom); err != nil {
+		return nil, err
+	}
+	if err := builder.AddMetaTools(bom, "", builder.GetAIBoMGenVersion()); err != nil {
+		return nil, err
+	}
+	if err := builder.AddMetaTaxonomyVersion(bom); err != nil {
+		return nil, err
+	}
+	return bom, nil
+}
+
+// buildAdapter builds a BOM for an "adapter" discovery. The adapter is the.
+// metadata component; when the adapter names a base model, that model is.
+// fetched from Hugging Face, added as a component and linked through the.
+// adapter's pedigree and the dependency graph, followed by its own ancestors.
+// when depth is above 1. A base model that cannot be fetched is still added.
+// with the metadata the scan provides.
+func buildAdapter(fetchers fetcherSet, bomBuilder bomBuilder, d scanner.Discovery, depth, index, total int, progress ProgressCallback) (DiscoveredBOM, bool) {
+	name := strings.TrimSpace(d.Name)
+
+	progress(ProgressEvent{Type: EventFetchStart, ModelID: name, Index: index, Total: total})
+	progress(ProgressEvent{Type: EventBuildStart, ModelID: name})
+
+	bom, err := bomBuilder.Build(builder.BuildContext{Scan: d, GGUF: localGGUF(d)})
+	if err != nil {
+		progress(ProgressEvent{Type: EventError, ModelID: name, Error: err, Message: "BOM build failed"})
+		return DiscoveredBOM{}, false
+	}
+
+	builder.AddDependencies(bom)
+	builder.AddBaseModelChain(bom, buildBaseModelChain(fetchers, bomBuilder, d, "adapter "+d.ID, strings.TrimSpace(d.BaseModel), max(depth, 1), progress))
+
+	progress(ProgressEvent{Type: EventBuildComplete, ModelID: name})
+	progress(ProgressEvent{Type: EventModelComplete, ModelID: name})
+
+	return DiscoveredBOM{Discovery: d, BOM: bom}, true
+}
+
+// buildBaseModelChain follows the base-model chain that starts at baseID,.
+// the base model of child, up to depth levels and builds a component for.
+// every ancestor, nearest first. The chain ends early at a model without a.
+// (single) base model, at a model already in the chain and at an ancestor.
+// that cannot be built.
+func buildBaseModelChain(fetchers fetcherSet, bomBuilder bomBuilder, d scanner.Discovery, child, baseID string, depth int, progress ProgressCallback) []*cdx.Component {
+	seen := map[string]bool{strings.ToLower(child): true, strings.ToLower(d.ID): true}
+	var chain []*cdx.Component
+	for len(chain) < depth && baseID != "" && !seen[strings.ToLower(baseID)] {
+		seen[strings.ToLower(baseID)] = true
+		base, next, ok := buildBaseModelComponent(fetchers, bomBuilder, d, child, baseID, progress)
+		if !ok {
+			break
+		}
+		chain = append(chain, base)
+		child, baseID = baseID, next
+	}
+	return chain
+}
+
+// baseModelID returns the base model named on a model card, or "" when there.
+// is none or the card lists several (e.g. a merge).
+func baseModelID(readme *fetcher.ModelReadmeCard) string {
+	if readme == nil {
+		return ""
+	}
+	id := strings.TrimSpace(strings.Trim(strings.TrimSpace(readme.BaseModel), "[]"))
+	if strings.ContainsAny(id, " \t,") {
+		return ""
+	}
+	return id
+}
+
+// buildBaseModelComponent fetches the Hugging Face metadata of the base model.
+// of child and builds its model component; it also returns the base model.
+// named on the fetched model card. Fetch errors are reported but not fatal.
+func buildBaseModelComponent(fetchers fetcherSet, bomBuilder bomBuilder, d scanner.Discovery, child, baseID string, progress ProgressCallback) (*cdx.Component, string, bool) {
+	bctx := builder.BuildContext{
+		ModelID: baseID,
+		Scan: scanner.Discovery{
+			ID:       baseID,
+			Name:     baseID,
+			Type:     "model",
+			Path:     d.Path,
+			Evidence: "base model of " + child,
+			Method:   d.Method,
+		},
+	}
+	if r, err := fetchers.modelAPI.Fetch(baseID); err == nil {
+		bctx.HF = r
+		progress(ProgressEvent{Type: EventFetchAPIComplete, ModelID: baseID})
+	} else {
+		progress(ProgressEvent{Type: EventError, ModelID: baseID, Error: err, Message: "base model " + fetchErrMessage("API", err)})
+	}
+	if c, err := fetchers.modelReadme.Fetch(baseID); err == nil {
+		bctx.Readme = c
+		progress(ProgressEvent{Type: EventFetchReadmeComplete, ModelID: baseID})
+	} else {
+		progress(ProgressEvent{Type: EventError, ModelID: baseID, Error: err, Message: "base model " + fetchErrMessage("README", err)})
+	}
+
+	baseBOM, err := bomBuilder.Build(bctx)
+	if err != nil || baseBOM.Metadata == nil || baseBOM.Metadata.Component == nil {
+		progress(ProgressEvent{Type: EventError, ModelID: baseID, Error: err, Message: "base model build failed"})
+		return nil, "", false
+	}
+	return baseBOM.Metadata.Component, baseModelID(bctx.Readme), true
+}
+
+// buildPipelineComponents nests the parts of a diffusers pipeline under the.
+// model component. Only repos tagged with the diffusers library are checked;.
+// a repo without model_index.json is a single diffusers model, not an error.
+func buildPipelineComponents(fetchers fetcherSet, bom *cdx.BOM, resp *fetcher.ModelAPIResponse, modelID string, progress ProgressCallback) {
+	if fetchers.modelIndex == nil || !isDiffusersRepo(resp) {
+		return
+	}
+	index, err := fetchers.modelIndex.Fetch(modelID)
+	if err != nil {
+		if !fetcher.IsNotFound(err) {
+			progress(ProgressEvent{Type: EventError, ModelID: modelID, Error: err, Message: fetchErrMessage("pipeline index", err)})
+		}
+		return
+	}
+	builder.AddPipelineComponents(bom, index)
+}
+
+// fetchModelConfig downloads the config.json of the repo of modelID, if it.
+// has one, for the context length of the model. Fetch errors are reported.
+// but not fatal.
+func fetchModelConfig(fetchers fetcherSet, resp *fetcher.ModelAPIResponse, modelID string, progress ProgressCallback) *fetcher.ModelConfig {
+	if fetchers.modelConfig == nil || modelID == "" || !fetcher.HasModelConfig(resp) {
+		return nil
+	}
+	cfg, err := fetchers.modelConfig.Fetch(modelID)
+	if err != nil {
+		progress(ProgressEvent{Type: EventError, ModelID: modelID, Error: err, Message: fetchErrMessage("config.json", err)})
+		return nil
+	}
+	return cfg
+}
+
+// fetchLicenseFile downloads the license file listed in the repo of modelID,.
+// if any, so that its exact terms are attached to the model license. Fetch.
+// errors are reported but not fatal.
+func fetchLicenseFile(fetchers fetcherSet, resp *fetcher.ModelAPIResponse, modelID string, progress ProgressCallback) *fetcher.LicenseFile {
+	path := fetcher.FindLicenseFile(resp)
+	if fetchers.licenseFile == nil || modelID == "" || path == "" {
+		return nil
+	}
+	lf, err := fetchers.licenseFile.Fetch(modelID, path)
+	if err != nil {
+		progress(ProgressEvent{Type: EventError, ModelID: modelID, Error: err, Message: fetchErrMessage("license file", err)})
+		return nil
+	}
+	return lf
+}
+
+// isDiffusersRepo reports whether the API metadata marks a diffusers repo.
+func isDiffusersRepo(resp *fetcher.ModelAPIResponse) bool {
+	if resp == nil {
+		return false
+	}
+	if resp.LibraryName == "diffusers" {
+		return true
+	}
+	for _, tag := range resp.Tags {
+		if tag == "diffusers" {
+			return true
+		}
+	}
+	return false
+}
+
 // fetchErrMessage returns a user-facing message for a Hugging Face fetch error,.
 // distinguishing "not found" (404) from other failures.
 func fetchErrMessage(kind string, err error) string {
@@ -332,40 +947,82 @@ func extractDatasetsFromModel(modelResp *fetcher.ModelAPIResponse, readme *fetch
 	return nil
 }
 
+// datasetConcurrency is the number of datasets of one model that are fetched.
+// and built at the same time.
+const datasetConcurrency = 4
+
 // buildDatasetComponents fetches and builds dataset components for a model BOM.
 // It appends each successfully built dataset component to bom.Components and returns.
 // the number of datasets that were successfully added.
 // Dataset references that fail to fetch (e.g. not on HuggingFace) are silently skipped;.
-// the references are still preserved in the model's modelCard metadata.
-func buildDatasetComponents(fetchers fetcherSet, bom *cdx.BOM, datasets []string, modelID string, progress ProgressCallback) int {
-	count := 0
-	for _, dsID := range datasets {
+// the references are still preserved in the model's modelCard metadata. Without a.
+// dataset fetcher (offline generation) no dataset components are built.
+//.
+// Up to datasetConcurrency datasets are fetched at the same time. Their.
+// components, and their completion and error events, follow the order of.
+// datasets regardless of which fetch finishes first. All dataset events are.
+// emitted from the calling goroutine: the start event of a dataset when a.
+// worker is free to fetch it, the others once every fetch is done. Each.
+// dataset is traced as a span of its own under the model's span, which its.
+// fetches and build are recorded under.
+func buildDatasetComponents(fetchers fetcherSet, bomBuilder bomBuilder, bom *cdx.BOM, datasets []string, modelID string, progress ProgressCallback) int {
+	if fetchers.datasetAPI == nil {
+		return 0
+	}
+
+	type datasetResult struct {
+		comp *cdx.Component
+		err  error // fetch error, reported as EventDatasetError
+	}
+	results := make([]datasetResult, len(datasets))
+
+	var g errgroup.Group
+	g.SetLimit(datasetConcurrency)
+	for i, dsID := range datasets {
+		// g.Go blocks until a worker is free, so the event marks the start.
+		// of the fetch.
 		progress(ProgressEvent{Type: EventDatasetStart, ModelID: modelID, Message: dsID})
+		g.Go(func() error {
+			fetchers, bomBuilder, end := datasetScope(fetchers, bomBuilder, dsID)
+			dsResp, err := fetchers.datasetAPI.Fetch(dsID)
+			if err != nil {
+				end(err)
+				results[i].err = err
+				return nil
+			}
 
-		dsResp, err := fetchers.datasetAPI.Fetch(dsID)
-		if err != nil {
-			progress(ProgressEvent{Type: EventDatasetError, ModelID: modelID, Message: dsID, Error: err})
-			continue
-		}
+			dsReadme, _ := fetchers.datasetReadme.Fetch(dsID)
 
-		dsReadme, _ := fetchers.datasetReadme.Fetch(dsID)
+			dsCtx := builder.DatasetBuildContext{
+				DatasetID: dsID,
+				Scan:      scanner.Discovery{ID: dsID, Name: dsID, Type: "dataset"},
+				HF:        dsResp,
+				Readme:    dsReadme,
+			}
 
-		dsCtx := builder.DatasetBuildContext{
-			DatasetID: dsID,
-			Scan:      scanner.Discovery{ID: dsID, Name: dsID, Type: "dataset"},
-			HF:        dsResp,
-			Readme:    dsReadme,
-		}
+			// A dataset that fails to build is skipped without an event.
+			results[i].comp, err = bomBuilder.BuildDataset(dsCtx)
+			end(err)
+			return nil
+		})
+	}
+	_ = g.Wait() // the workers report failures through results, never as errors
 
-		dsComp, err := newBOMBuilder().BuildDataset(dsCtx)
-		if err != nil {
+	count := 0
+	for i, r := range results {
+		dsID := datasets[i]
+		if r.err != nil {
+			progress(ProgressEvent{Type: EventDatasetError, ModelID: modelID, Message: dsID, Error: r.err})
+			continue
+		}
+		if r.comp == nil {
 			continue
 		}
 
 		if bom.Components == nil {
 			bom.Components = &[]cdx.Component{}
 		}
-		*bom.Components = append(*bom.Components, *dsComp)
+		*bom.Components = append(*bom.Components, *r.comp)
 		count++
 
 		progress(ProgressEvent{Type: EventDatasetComplete, ModelID: modelID, Message: dsID})
@@ -380,102 +1037,220 @@ func BuildFromModelIDs(modelIDs []string, opts GenerateOptions) ([]DiscoveredBOM
 		opts.Timeout = 10 * time.Second
 	}
 
+	newFetchers := func(scope *tracing.Scope, onRetry func(fetcher.RetryAttempt), deadline *modelDeadline) fetcherSet {
+		client := func(c *http.Client) *http.Client { return scope.Client(deadline.client(c)) }
+		return traceFetchers(newFetcherSet(newHTTPClients(opts, onRetry, client)), scope)
+	}
+
+	return buildModels(modelIDs, opts, newFetchers, func(modelID string) scanner.Discovery {
+		return scanner.Discovery{
+			ID:       modelID,
+			Name:     modelID,
+			Type:     "huggingface",
+			Path:     "",
+			Evidence: fmt.Sprintf("from model-id: %s", modelID),
+		}
+	})
+}
+
+// BuildFromLocalPath generates an AIBOM for the model repo downloaded or.
+// cloned to dir, reading config.json, the README front matter and the file.
+// listing instead of calling the Hugging Face API. modelID names the model;.
+// when empty it is derived from the directory (see fetcher.LocalModelID).
+// Referenced datasets and base models are kept on the model card but not.
+// fetched, and the BOM cache is not used since local files can change without.
+// a new revision.
+func BuildFromLocalPath(dir, modelID string, opts GenerateOptions) ([]DiscoveredBOM, error) {
+	modelID = strings.TrimSpace(modelID)
+	if modelID == "" {
+		modelID = fetcher.LocalModelID(dir)
+	}
+	opts.Cache = nil
+	opts.BaseModelDepth = 0
+
+	newFetchers := func(scope *tracing.Scope, _ func(fetcher.RetryAttempt), _ *modelDeadline) fetcherSet {
+		return traceFetchers(newLocalFetcherSet(dir), scope)
+	}
+
+	return buildModels([]string{modelID}, opts, newFetchers, func(modelID string) scanner.Discovery {
+		return scanner.Discovery{
+			ID:       modelID,
+			Name:     modelID,
+			Type:     "huggingface",
+			Path:     dir,
+			Evidence: fmt.Sprintf("from local snapshot: %s", dir),
+		}
+	})
+}
+
+// buildModels generates an AIBOM for each model ID, with opts.Concurrency.
+// workers. newFetchers returns the fetchers of a worker, which report their.
+// retries to onRetry and end their requests at deadline; discovery describes.
+// where a model ID came from.
+func buildModels(modelIDs []string, opts GenerateOptions, newFetchers func(scope *tracing.Scope, onRetry func(fetcher.RetryAttempt), deadline *modelDeadline) fetcherSet, discovery func(string) scanner.Discovery) ([]DiscoveredBOM, error) {
 	progress := opts.OnProgress
 	if progress == nil {
 		progress = func(ProgressEvent) {} // no-op
 	}
+	progress, failed := trackFailures(reportHubOutage(progress))
+	cliVersion := cacheVersion(opts)
 
-	results := make([]DiscoveredBOM, 0, len(modelIDs))
+	workers := opts.Concurrency
+	if workers > len(modelIDs) {
+		workers = len(modelIDs)
+	}
+	if workers < 1 {
+		workers = 1
+	}
 
-	fetchers := newFetcherSet(newHTTPClient(opts))
+	// Each model is built by a single worker, so its events stay in order.
+	built := make([]*DiscoveredBOM, len(modelIDs))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// A scope tracks sequential spans, so every worker has its own.
+			scope := tracing.NewScope(opts.Context)
+			onRetry, setModel := retryReporter(progress)
+			deadline := &modelDeadline{budget: opts.ModelDeadline}
+			fetchers := newFetchers(scope, onRetry, deadline)
+			for i := range indexes {
+				setModel(strings.TrimSpace(modelIDs[i]))
+				deadline.start()
+				if r, ok := buildModel(i, modelIDs, opts, fetchers, scope, discovery, cliVersion, progress, failed); ok {
+					built[i] = &r
+				}
+			}
+		}()
+	}
+	for i := range modelIDs {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
 
-	for i, modelID := range modelIDs {
-		modelID = strings.TrimSpace(modelID)
-		if modelID == "" {
-			continue
+	results := make([]DiscoveredBOM, 0, len(modelIDs))
+	for _, r := range built {
+		if r != nil {
+			results = append(results, *r)
 		}
+	}
+	return results, nil
+}
 
-		progress(ProgressEvent{Type: EventFetchStart, ModelID: modelID, Index: i, Total: len(modelIDs)})
+// buildModel generates the AIBOM of modelIDs[i]. It reports false when the.
+// model was skipped or its BOM could not be built.
+func buildModel(i int, modelIDs []string, opts GenerateOptions, fetchers fetcherSet, scope *tracing.Scope, discovery func(string) scanner.Discovery, cliVersion string, progress ProgressCallback, failed func(string) bool) (DiscoveredBOM, bool) {
+	modelID := strings.TrimSpace(modelIDs[i])
+	if modelID == "" {
+		return DiscoveredBOM{}, false
+	}
+	endModel := startModel(scope, modelID, "huggingface")
+	defer endModel(nil)
 
-		// Fetch API metadata.
-		resp, err := fetchers.modelAPI.Fetch(modelID)
-		var apiNotFound bool
-		if err != nil {
-			if fetcher.IsNotFound(err) || fetcher.IsUnauthorized(err) {
-				apiNotFound = true
-			}
-			progress(ProgressEvent{Type: EventError, ModelID: modelID, Error: err, Message: "API fetch failed"})
-			resp = nil
-		} else {
-			progress(ProgressEvent{Type: EventFetchAPIComplete, ModelID: modelID})
-		}
+	progress(ProgressEvent{Type: EventFetchStart, ModelID: modelID, Index: i, Total: len(modelIDs)})
 
-		// Skip BOM generation if API fetch returned not found or unauthorized (model not accessible on HF)
-		if apiNotFound {
-			progress(ProgressEvent{Type: EventModelComplete, ModelID: modelID, Message: "model skipped: API not found or unauthorized"})
-			continue
+	// Fetch API metadata.
+	resp, err := fetchers.modelAPI.Fetch(modelID)
+	var apiNotFound bool
+	hubDown := errors.Is(err, fetcher.ErrHubUnreachable)
+	if err != nil {
+		if fetcher.IsNotFound(err) || fetcher.IsUnauthorized(err) {
+			apiNotFound = true
 		}
+		progress(ProgressEvent{Type: EventError, ModelID: modelID, Error: err, Message: "API fetch failed"})
+		resp = nil
+	} else {
+		progress(ProgressEvent{Type: EventFetchAPIComplete, ModelID: modelID})
+	}
 
-		bomBuilder := newBOMBuilder()
+	// Skip BOM generation if API fetch returned not found or unauthorized (model not accessible on HF)
+	if apiNotFound {
+		progress(ProgressEvent{Type: EventModelComplete, ModelID: modelID, Message: "model skipped: API not found or unauthorized"})
+		return DiscoveredBOM{}, false
+	}
 
-		// Fetch README.
-		readme, err := fetchers.modelReadme.Fetch(modelID)
+	d := discovery(modelID)
+
+	if bom, ok := opts.Cache.Get(cacheKey(modelID, d, resp, cliVersion, opts)); ok {
+		return cachedResult(d, bom, modelID, progress), true
+	}
+
+	bomBuilder := tracedBuilder{newBOMBuilder(), scope}
+
+	// Fetch README. Without the Hub the model is built from the scan alone.
+	var readme *fetcher.ModelReadmeCard
+	if !hubDown {
+		readme, err = fetchers.modelReadme.Fetch(modelID)
 		if err != nil {
+			hubDown = errors.Is(err, fetcher.ErrHubUnreachable)
 			progress(ProgressEvent{Type: EventError, ModelID: modelID, Error: err, Message: "README fetch failed"})
 			readme = nil
 		} else {
 			progress(ProgressEvent{Type: EventFetchReadmeComplete, ModelID: modelID})
 		}
+	}
 
-		// Fetch security scan tree (non-fatal).
-		var securityTree []fetcher.SecurityFileEntry
-		if !opts.SkipSecurityScan && fetchers.modelTree != nil {
-			if tree, err := fetchers.modelTree.Fetch(modelID); err == nil {
-				securityTree = tree
-				progress(ProgressEvent{Type: EventFetchSecurityScanComplete, ModelID: modelID})
-			} else {
-				progress(ProgressEvent{Type: EventError, ModelID: modelID, Error: err, Message: fetchErrMessage("security scan", err)})
-			}
+	// Fetch security scan tree (non-fatal).
+	var securityTree []fetcher.SecurityFileEntry
+	if !hubDown && !opts.SkipSecurityScan && fetchers.modelTree != nil {
+		if tree, err := fetchers.modelTree.Fetch(modelID); err == nil {
+			securityTree = tree
+			progress(ProgressEvent{Type: EventFetchSecurityScanComplete, ModelID: modelID})
+		} else {
+			progress(ProgressEvent{Type: EventError, ModelID: modelID, Error: err, Message: fetchErrMessage("security scan", err)})
 		}
+	}
 
-		progress(ProgressEvent{Type: EventBuildStart, ModelID: modelID})
+	licenseFile := fetchLicenseFile(fetchers, resp, modelID, progress)
+	modelConfig := fetchModelConfig(fetchers, resp, modelID, progress)
 
-		discovery := scanner.Discovery{
-			ID:       modelID,
-			Name:     modelID,
-			Type:     "huggingface",
-			Path:     "",
-			Evidence: fmt.Sprintf("from model-id: %s", modelID),
-		}
+	progress(ProgressEvent{Type: EventBuildStart, ModelID: modelID})
 
-		bctx := builder.BuildContext{
-			ModelID:      modelID,
-			Scan:         discovery,
-			HF:           resp,
-			Readme:       readme,
-			SecurityTree: securityTree,
-		}
+	bctx := builder.BuildContext{
+		ModelID:      modelID,
+		Scan:         d,
+		HF:           resp,
+		Readme:       readme,
+		SecurityTree: securityTree,
+		LicenseFile:  licenseFile,
+		Config:       modelConfig,
+	}
 
-		bom, err := bomBuilder.Build(bctx)
-		if err != nil {
-			progress(ProgressEvent{Type: EventError, ModelID: modelID, Error: err, Message: "BOM build failed"})
-			continue
-		}
+	bom, err := bomBuilder.Build(bctx)
+	if err != nil {
+		progress(ProgressEvent{Type: EventError, ModelID: modelID, Error: err, Message: "BOM build failed"})
+		return DiscoveredBOM{}, false
+	}
 
-		progress(ProgressEvent{Type: EventBuildComplete, ModelID: modelID})
+	progress(ProgressEvent{Type: EventBuildComplete, ModelID: modelID})
 
-		datasetCount := buildDatasetComponents(fetchers, bom, extractDatasetsFromModel(resp, readme), modelID, progress)
+	if opts.IncludeRawMetadata {
+		if err := builder.AddRawMetadata(bom, resp, readme); err != nil {
+			progress(ProgressEvent{Type: EventError, ModelID: modelID, Error: err, Message: "raw metadata not stored"})
+		}
+	}
 
-		// Add dependencies from model to datasets.
-		builder.AddDependencies(bom)
+	buildPipelineComponents(fetchers, bom, resp, modelID, progress)
+	datasetCount := buildDatasetComponents(fetchers, bomBuilder, bom, extractDatasetsFromModel(resp, readme), modelID, progress)
 
-		progress(ProgressEvent{Type: EventModelComplete, ModelID: modelID, Datasets: datasetCount})
+	// Add dependencies from model to datasets.
+	builder.AddDependencies(bom)
+	if opts.BaseModelDepth > 0 {
+		builder.AddBaseModelChain(bom, buildBaseModelChain(fetchers, bomBuilder, d, modelID, baseModelID(readme), opts.BaseModelDepth, progress))
+	}
+	builder.AddGeneratingModels(bom)
 
-		results = append(results, DiscoveredBOM{
-			Discovery: discovery,
-			BOM:       bom,
-		})
+	progress(ProgressEvent{Type: EventModelComplete, ModelID: modelID, Datasets: datasetCount})
+
+	if !failed(modelID) {
+		_ = opts.Cache.Put(cacheKey(modelID, d, resp, cliVersion, opts), bom)
 	}
 
-	return results, nil
+	return DiscoveredBOM{
+		Discovery: d,
+		BOM:       bom,
+	}, true
 }
@@ -1,16 +1,32 @@
 package generator
 
 import (
+	"bytes"
 	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"reflect"
+	"slices"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/internal/bomcache"
 	"github.com/idlab-discover/aibomgen-cli/internal/builder"
 	"github.com/idlab-discover/aibomgen-cli/internal/fetcher"
+	"github.com/idlab-discover/aibomgen-cli/internal/taxonomy"
+	"github.com/idlab-discover/aibomgen-cli/internal/tracing"
 	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/scanner"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Mock BOM Builder for testing.
@@ -131,6 +147,10 @@ func TestBuildDummyBOM(t *testing.T) {
 				if got[0].Discovery.ID != "dummy-org/dummy-model" {
 					t.Errorf("Expected discovery ID 'dummy-org/dummy-model', got %q", got[0].Discovery.ID)
 				}
+				// The context length comes from the dummy config.json.
+				if v, _ := taxonomy.Get(got[0].BOM.Metadata.Component.Properties, taxonomy.WeightsContextLength); v != "1024" {
+					t.Errorf("context length = %q, want 1024", v)
+				}
 				// Should have datasets from dummy data.
 				if got[0].BOM.Components != nil && len(*got[0].BOM.Components) > 0 {
 					t.Logf("BOM has %d dataset components", len(*got[0].BOM.Components))
@@ -297,7 +317,7 @@ func TestBuildPerDiscovery(t *testing.T) {
 						},
 					}
 				}
-				newFetcherSet = func(httpClient *http.Client) fetcherSet {
+				newFetcherSet = func(clients httpClients) fetcherSet {
 					return successFetcherSet()
 				}
 			},
@@ -340,7 +360,7 @@ func TestBuildPerDiscovery(t *testing.T) {
 						},
 					}
 				}
-				newFetcherSet = func(httpClient *http.Client) fetcherSet {
+				newFetcherSet = func(clients httpClients) fetcherSet {
 					return successFetcherSet()
 				}
 			},
@@ -367,7 +387,7 @@ func TestBuildPerDiscovery(t *testing.T) {
 						},
 					}
 				}
-				newFetcherSet = func(httpClient *http.Client) fetcherSet {
+				newFetcherSet = func(clients httpClients) fetcherSet {
 					return successFetcherSet()
 				}
 			},
@@ -397,7 +417,7 @@ func TestBuildPerDiscovery(t *testing.T) {
 						},
 					}
 				}
-				newFetcherSet = func(httpClient *http.Client) fetcherSet {
+				newFetcherSet = func(clients httpClients) fetcherSet {
 					return successFetcherSet()
 				}
 			},
@@ -454,7 +474,7 @@ func TestBuildPerDiscovery(t *testing.T) {
 						},
 					}
 				}
-				newFetcherSet = func(httpClient *http.Client) fetcherSet {
+				newFetcherSet = func(clients httpClients) fetcherSet {
 					return fetcherSet{
 						modelAPI: &mockModelAPIFetcher{
 							fetchFunc: func(id string) (*fetcher.ModelAPIResponse, error) {
@@ -515,7 +535,7 @@ func TestBuildPerDiscovery(t *testing.T) {
 						},
 					}
 				}
-				newFetcherSet = func(httpClient *http.Client) fetcherSet {
+				newFetcherSet = func(clients httpClients) fetcherSet {
 					return fetcherSet{
 						modelAPI: &mockModelAPIFetcher{
 							fetchFunc: func(id string) (*fetcher.ModelAPIResponse, error) {
@@ -581,7 +601,7 @@ func TestBuildPerDiscovery(t *testing.T) {
 						},
 					}
 				}
-				newFetcherSet = func(httpClient *http.Client) fetcherSet {
+				newFetcherSet = func(clients httpClients) fetcherSet {
 					return fetcherSet{
 						modelAPI: &mockModelAPIFetcher{
 							fetchFunc: func(id string) (*fetcher.ModelAPIResponse, error) {
@@ -627,7 +647,7 @@ func TestBuildPerDiscovery(t *testing.T) {
 						},
 					}
 				}
-				newFetcherSet = func(httpClient *http.Client) fetcherSet {
+				newFetcherSet = func(clients httpClients) fetcherSet {
 					return fetcherSet{
 						modelAPI: &mockModelAPIFetcher{
 							fetchFunc: func(id string) (*fetcher.ModelAPIResponse, error) {
@@ -667,7 +687,7 @@ func TestBuildPerDiscovery(t *testing.T) {
 						},
 					}
 				}
-				newFetcherSet = func(httpClient *http.Client) fetcherSet {
+				newFetcherSet = func(clients httpClients) fetcherSet {
 					return fetcherSet{
 						modelAPI: &mockModelAPIFetcher{
 							fetchFunc: func(id string) (*fetcher.ModelAPIResponse, error) {
@@ -705,6 +725,118 @@ func TestBuildPerDiscovery(t *testing.T) {
 	}
 }
 
+func Test_buildDatasetComponentsKeepsOrder(t *testing.T) {
+	datasets := []string{"org/ds-0", "org/ds-1", "org/missing", "org/ds-3", "org/ds-4", "org/ds-5"}
+	var inFlight, maxInFlight atomic.Int32
+	fetchers := fetcherSet{
+		datasetAPI: &mockDatasetAPIFetcher{
+			fetchFunc: func(id string) (*fetcher.DatasetAPIResponse, error) {
+				n := inFlight.Add(1)
+				defer inFlight.Add(-1)
+				for {
+					m := maxInFlight.Load()
+					if n <= m || maxInFlight.CompareAndSwap(m, n) {
+						break
+					}
+				}
+				if id == "org/missing" {
+					return nil, &fetcher.HFError{StatusCode: http.StatusNotFound}
+				}
+				// Earlier datasets finish last.
+				time.Sleep(time.Duration(len(datasets)-slices.Index(datasets, id)) * time.Millisecond)
+				return &fetcher.DatasetAPIResponse{ID: id}, nil
+			},
+		},
+		datasetReadme: &mockDatasetReadmeFetcher{},
+	}
+
+	var events []ProgressEvent
+	progress, _ := trackFailures(func(evt ProgressEvent) {
+		if evt.Type != EventDatasetStart {
+			events = append(events, evt)
+		}
+	})
+	bom := cdx.NewBOM()
+	if n := buildDatasetComponents(fetchers, newBOMBuilder(), bom, datasets, "org/model", progress); n != 5 {
+		t.Fatalf("built %d datasets, want 5", n)
+	}
+
+	var names []string
+	for _, c := range *bom.Components {
+		names = append(names, c.Name)
+	}
+	if want := []string{"org/ds-0", "org/ds-1", "org/ds-3", "org/ds-4", "org/ds-5"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("components = %v, want %v", names, want)
+	}
+	var reported []string
+	for _, evt := range events {
+		reported = append(reported, evt.Message)
+	}
+	if !reflect.DeepEqual(reported, datasets) || events[2].Type != EventDatasetError {
+		t.Errorf("events = %v, want one per dataset in order with org/missing as error", reported)
+	}
+	if m := maxInFlight.Load(); m < 2 || m > datasetConcurrency {
+		t.Errorf("max datasets in flight = %d, want 2..%d", m, datasetConcurrency)
+	}
+}
+
+func Test_buildDatasetComponentsTracesEachDataset(t *testing.T) {
+	rec := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	scope := tracing.NewScope(context.Background())
+	fetchers := traceFetchers(fetcherSet{
+		datasetAPI: &mockDatasetAPIFetcher{
+			fetchFunc: func(id string) (*fetcher.DatasetAPIResponse, error) {
+				time.Sleep(time.Millisecond)
+				return &fetcher.DatasetAPIResponse{ID: id}, nil
+			},
+		},
+		datasetReadme: &mockDatasetReadmeFetcher{},
+	}, scope)
+	endModel := startModel(scope, "org/model", "huggingface")
+	datasets := []string{"org/ds-0", "org/ds-1", "org/ds-2", "org/ds-3"}
+	n := buildDatasetComponents(fetchers, tracedBuilder{newBOMBuilder(), scope}, cdx.NewBOM(), datasets, "org/model", func(ProgressEvent) {})
+	endModel(nil)
+	if n != len(datasets) {
+		t.Fatalf("built %d datasets, want %d", n, len(datasets))
+	}
+
+	byID := make(map[trace.SpanID]sdktrace.ReadOnlySpan)
+	for _, s := range rec.Ended() {
+		byID[s.SpanContext().SpanID()] = s
+	}
+	repo := func(s sdktrace.ReadOnlySpan) string {
+		for _, kv := range s.Attributes() {
+			if kv.Key == attrRepoID {
+				return kv.Value.AsString()
+			}
+		}
+		return ""
+	}
+	var traced []string
+	for _, s := range byID {
+		switch s.Name() {
+		case "generate dataset":
+			if parent := byID[s.Parent().SpanID()]; parent == nil || parent.Name() != "generate model" {
+				t.Errorf("dataset span %s is not a child of the model span", repo(s))
+			}
+			traced = append(traced, repo(s))
+		case "fetch dataset API", "fetch dataset README", "build dataset":
+			parent := byID[s.Parent().SpanID()]
+			if parent == nil || parent.Name() != "generate dataset" || repo(parent) != repo(s) {
+				t.Errorf("%s span of %s is not a child of its dataset span", s.Name(), repo(s))
+			}
+		}
+	}
+	slices.Sort(traced)
+	if !reflect.DeepEqual(traced, datasets) {
+		t.Errorf("dataset spans = %v, want %v", traced, datasets)
+	}
+}
+
 func Test_extractDatasetsFromModel(t *testing.T) {
 	type args struct {
 		modelResp *fetcher.ModelAPIResponse
@@ -840,7 +972,7 @@ func TestBuildFromModelIDs(t *testing.T) {
 						},
 					}
 				}
-				newFetcherSet = func(httpClient *http.Client) fetcherSet {
+				newFetcherSet = func(clients httpClients) fetcherSet {
 					return successFetcherSet()
 				}
 			},
@@ -869,7 +1001,7 @@ func TestBuildFromModelIDs(t *testing.T) {
 						},
 					}
 				}
-				newFetcherSet = func(httpClient *http.Client) fetcherSet {
+				newFetcherSet = func(clients httpClients) fetcherSet {
 					return successFetcherSet()
 				}
 			},
@@ -913,7 +1045,7 @@ func TestBuildFromModelIDs(t *testing.T) {
 						},
 					}
 				}
-				newFetcherSet = func(httpClient *http.Client) fetcherSet {
+				newFetcherSet = func(clients httpClients) fetcherSet {
 					return successFetcherSet()
 				}
 			},
@@ -943,7 +1075,7 @@ func TestBuildFromModelIDs(t *testing.T) {
 						},
 					}
 				}
-				newFetcherSet = func(httpClient *http.Client) fetcherSet {
+				newFetcherSet = func(clients httpClients) fetcherSet {
 					return successFetcherSet()
 				}
 			},
@@ -975,7 +1107,7 @@ func TestBuildFromModelIDs(t *testing.T) {
 						},
 					}
 				}
-				newFetcherSet = func(httpClient *http.Client) fetcherSet {
+				newFetcherSet = func(clients httpClients) fetcherSet {
 					return fetcherSet{
 						modelAPI: &mockModelAPIFetcher{
 							fetchFunc: func(id string) (*fetcher.ModelAPIResponse, error) {
@@ -1034,7 +1166,7 @@ func TestBuildFromModelIDs(t *testing.T) {
 						},
 					}
 				}
-				newFetcherSet = func(httpClient *http.Client) fetcherSet {
+				newFetcherSet = func(clients httpClients) fetcherSet {
 					return fetcherSet{
 						modelAPI: &mockModelAPIFetcher{
 							fetchFunc: func(id string) (*fetcher.ModelAPIResponse, error) {
@@ -1082,7 +1214,7 @@ func TestBuildFromModelIDs(t *testing.T) {
 						},
 					}
 				}
-				newFetcherSet = func(httpClient *http.Client) fetcherSet {
+				newFetcherSet = func(clients httpClients) fetcherSet {
 					return fetcherSet{
 						modelAPI: &mockModelAPIFetcher{
 							fetchFunc: func(id string) (*fetcher.ModelAPIResponse, error) {
@@ -1116,7 +1248,7 @@ func TestBuildFromModelIDs(t *testing.T) {
 						},
 					}
 				}
-				newFetcherSet = func(httpClient *http.Client) fetcherSet {
+				newFetcherSet = func(clients httpClients) fetcherSet {
 					return fetcherSet{
 						modelAPI: &mockModelAPIFetcher{
 							fetchFunc: func(id string) (*fetcher.ModelAPIResponse, error) {
@@ -1153,3 +1285,963 @@ func TestBuildFromModelIDs(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildFromModelIDs_BOMCache(t *testing.T) {
+	originalBuilder := newBOMBuilder
+	originalFetcherSet := newFetcherSet
+	defer func() {
+		newBOMBuilder = originalBuilder
+		newFetcherSet = originalFetcherSet
+	}()
+
+	builds := 0
+	newBOMBuilder = func() bomBuilder {
+		return &mockBOMBuilder{
+			buildFunc: func(bctx builder.BuildContext) (*cdx.BOM, error) {
+				builds++
+				bom := cdx.NewBOM()
+				bom.Metadata = &cdx.Metadata{Component: &cdx.Component{Name: bctx.ModelID}}
+				return bom, nil
+			},
+		}
+	}
+	sha := "abc123"
+	newFetcherSet = func(clients httpClients) fetcherSet {
+		fs := successFetcherSet()
+		fs.modelAPI = &mockModelAPIFetcher{
+			fetchFunc: func(id string) (*fetcher.ModelAPIResponse, error) {
+				return &fetcher.ModelAPIResponse{ID: id, SHA: sha}, nil
+			},
+		}
+		return fs
+	}
+
+	cache, err := bomcache.New(t.TempDir())
+	if err != nil {
+		t.Fatalf("bomcache.New: %v", err)
+	}
+	var hits int
+	opts := GenerateOptions{
+		Timeout: time.Second,
+		Cache:   cache,
+		OnProgress: func(evt ProgressEvent) {
+			if evt.Type == EventCacheHit {
+				hits++
+			}
+		},
+	}
+
+	for run := 0; run < 2; run++ {
+		got, err := BuildFromModelIDs([]string{"org/model"}, opts)
+		if err != nil || len(got) != 1 {
+			t.Fatalf("run %d: got %d BOMs, err %v", run, len(got), err)
+		}
+	}
+	if builds != 1 || hits != 1 {
+		t.Fatalf("expected 1 build and 1 cache hit, got builds=%d hits=%d", builds, hits)
+	}
+
+	// A new revision invalidates the entry.
+	sha = "def456"
+	if _, err := BuildFromModelIDs([]string{"org/model"}, opts); err != nil {
+		t.Fatalf("BuildFromModelIDs: %v", err)
+	}
+	if builds != 2 {
+		t.Fatalf("expected rebuild after revision change, got builds=%d", builds)
+	}
+}
+
+func TestBOMCacheScanThenGenerate(t *testing.T) {
+	originalBuilder := newBOMBuilder
+	originalFetcherSet := newFetcherSet
+	defer func() {
+		newBOMBuilder = originalBuilder
+		newFetcherSet = originalFetcherSet
+	}()
+
+	builds := 0
+	newBOMBuilder = func() bomBuilder {
+		return &mockBOMBuilder{
+			buildFunc: func(bctx builder.BuildContext) (*cdx.BOM, error) {
+				builds++
+				bom := cdx.NewBOM()
+				bom.SerialNumber = fmt.Sprintf("urn:uuid:build-%d", builds)
+				bom.Metadata = &cdx.Metadata{Component: &cdx.Component{
+					Name:       bctx.ModelID,
+					Properties: &[]cdx.Property{{Name: "path", Value: bctx.Scan.Path}},
+				}}
+				return bom, nil
+			},
+		}
+	}
+	newFetcherSet = func(clients httpClients) fetcherSet {
+		fs := successFetcherSet()
+		fs.modelAPI = &mockModelAPIFetcher{
+			fetchFunc: func(id string) (*fetcher.ModelAPIResponse, error) {
+				return &fetcher.ModelAPIResponse{ID: id, SHA: "abc123"}, nil
+			},
+		}
+		return fs
+	}
+
+	cache, err := bomcache.New(t.TempDir())
+	if err != nil {
+		t.Fatalf("bomcache.New: %v", err)
+	}
+	opts := GenerateOptions{Timeout: time.Second, Cache: cache}
+	path := func(r DiscoveredBOM) string {
+		return (*r.BOM.Metadata.Component.Properties)[0].Value
+	}
+
+	scan := []scanner.Discovery{{ID: "org/model", Name: "org/model", Type: "huggingface", Path: "src/app.py", Evidence: `from_pretrained("org/model")`}}
+	scanned, err := BuildPerDiscovery(scan, opts)
+	if err != nil || len(scanned) != 1 {
+		t.Fatalf("scan: got %d BOMs, err %v", len(scanned), err)
+	}
+
+	// generate of the same model must not get the BOM of the scan discovery.
+	generated, err := BuildFromModelIDs([]string{"org/model"}, opts)
+	if err != nil || len(generated) != 1 {
+		t.Fatalf("generate: got %d BOMs, err %v", len(generated), err)
+	}
+	if builds != 2 || path(generated[0]) == "src/app.py" {
+		t.Fatalf("generate reused the scan BOM: builds=%d path=%q", builds, path(generated[0]))
+	}
+
+	// Scanning again reuses the entry, under a new serial number.
+	rescanned, err := BuildPerDiscovery(scan, opts)
+	if err != nil || len(rescanned) != 1 {
+		t.Fatalf("rescan: got %d BOMs, err %v", len(rescanned), err)
+	}
+	if builds != 2 || path(rescanned[0]) != "src/app.py" {
+		t.Fatalf("rescan: builds=%d path=%q, want the cached scan BOM", builds, path(rescanned[0]))
+	}
+	if rescanned[0].BOM.SerialNumber == scanned[0].BOM.SerialNumber || rescanned[0].BOM.Metadata.Timestamp == "" {
+		t.Errorf("cached BOM kept serial number %s, timestamp %q", rescanned[0].BOM.SerialNumber, rescanned[0].BOM.Metadata.Timestamp)
+	}
+}
+
+func TestBuildPerDiscovery_Adapter(t *testing.T) {
+	originalFetcherSet := newFetcherSet
+	t.Cleanup(func() { newFetcherSet = originalFetcherSet })
+	newFetcherSet = func(httpClients) fetcherSet { return successFetcherSet() }
+
+	adapter := scanner.Discovery{
+		ID:        "repo/adapters/sql-lora",
+		Name:      "sql-lora",
+		Type:      scanner.DiscoveryTypeAdapter,
+		Path:      "repo/adapters/sql-lora/adapter_config.json",
+		Evidence:  "adapter_config.json: peft_type LORA, base model meta-llama/Llama-3.1-8B",
+		Method:    "peft_adapter_config",
+		BaseModel: "meta-llama/Llama-3.1-8B",
+	}
+
+	results, err := BuildPerDiscovery([]scanner.Discovery{adapter}, GenerateOptions{})
+	if err != nil {
+		t.Fatalf("BuildPerDiscovery: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 BOM, got %d", len(results))
+	}
+	bom := results[0].BOM
+	comp := bom.Metadata.Component
+	if comp.Name != "sql-lora" || comp.PackageURL != "" {
+		t.Errorf("adapter component = %q (purl %q), want sql-lora without purl", comp.Name, comp.PackageURL)
+	}
+
+	if bom.Components == nil || len(*bom.Components) != 1 {
+		t.Fatalf("expected the base model as the only component, got %+v", bom.Components)
+	}
+	base := (*bom.Components)[0]
+	if base.Type != cdx.ComponentTypeMachineLearningModel || base.Name != "meta-llama/Llama-3.1-8B" || base.PackageURL == "" {
+		t.Errorf("unexpected base model component: %+v", base)
+	}
+
+	if comp.Pedigree == nil || comp.Pedigree.Ancestors == nil || len(*comp.Pedigree.Ancestors) != 1 {
+		t.Fatalf("expected one pedigree ancestor, got %+v", comp.Pedigree)
+	}
+	if anc := (*comp.Pedigree.Ancestors)[0]; anc.PackageURL != base.PackageURL || anc.BOMRef != "" {
+		t.Errorf("ancestor should reference the base model by purl only: %+v", anc)
+	}
+
+	if bom.Dependencies == nil {
+		t.Fatal("expected dependencies")
+	}
+	dep := (*bom.Dependencies)[0]
+	if dep.Ref != comp.BOMRef || dep.Dependencies == nil || !reflect.DeepEqual(*dep.Dependencies, []string{base.BOMRef}) {
+		t.Errorf("adapter should depend on the base model, got %+v", dep)
+	}
+}
+
+func TestBuildFromModelIDs_BaseModelDepth(t *testing.T) {
+	originalFetcherSet := newFetcherSet
+	t.Cleanup(func() { newFetcherSet = originalFetcherSet })
+
+	// A LoRA fine-tune of an instruct model of a base model whose card.
+	// (wrongly) points back at the fine-tune.
+	bases := map[string]string{
+		"org/sql-lora":                     "meta-llama/Llama-3.1-8B-Instruct",
+		"meta-llama/Llama-3.1-8B-Instruct": "meta-llama/Llama-3.1-8B",
+		"meta-llama/Llama-3.1-8B":          "org/sql-lora",
+	}
+	newFetcherSet = func(httpClients) fetcherSet {
+		fs := successFetcherSet()
+		fs.modelReadme = &mockModelReadmeFetcher{fetchFunc: func(id string) (*fetcher.ModelReadmeCard, error) {
+			return &fetcher.ModelReadmeCard{BaseModel: bases[id]}, nil
+		}}
+		return fs
+	}
+
+	chainOf := func(depth int) []string {
+		t.Helper()
+		results, err := BuildFromModelIDs([]string{"org/sql-lora"}, GenerateOptions{BaseModelDepth: depth})
+		if err != nil || len(results) != 1 {
+			t.Fatalf("BuildFromModelIDs: %v (%d results)", err, len(results))
+		}
+		bom := results[0].BOM
+		var names []string
+		if bom.Components != nil {
+			for _, c := range *bom.Components {
+				names = append(names, c.Name)
+			}
+		}
+		if depth > 0 {
+			dep := (*bom.Dependencies)[0]
+			if dep.Dependencies == nil || len(*dep.Dependencies) != 1 || (*dep.Dependencies)[0] != (*bom.Components)[0].BOMRef {
+				t.Errorf("depth %d: model should only depend on its direct base model, got %+v", depth, dep)
+			}
+		}
+		return names
+	}
+
+	if got := chainOf(0); got != nil {
+		t.Errorf("depth 0: unexpected components %v", got)
+	}
+	if got, want := chainOf(1), []string{"meta-llama/Llama-3.1-8B-Instruct"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("depth 1: components = %v, want %v", got, want)
+	}
+	if got, want := chainOf(5), []string{"meta-llama/Llama-3.1-8B-Instruct", "meta-llama/Llama-3.1-8B"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("depth 5: components = %v, want %v (the cycle must end the chain)", got, want)
+	}
+}
+
+func TestBaseModelID(t *testing.T) {
+	for in, want := range map[string]string{
+		"":              "",
+		" org/base ":    "org/base",
+		"[org/base]":    "org/base",
+		"[org/a org/b]": "",
+		"org/a, org/b":  "",
+	} {
+		if got := baseModelID(&fetcher.ModelReadmeCard{BaseModel: in}); got != want {
+			t.Errorf("baseModelID(%q) = %q, want %q", in, got, want)
+		}
+	}
+	if baseModelID(nil) != "" {
+		t.Errorf("nil card must have no base model")
+	}
+}
+
+func TestBuildFromModelIDs_DiffusersPipeline(t *testing.T) {
+	originalFetcherSet := newFetcherSet
+	t.Cleanup(func() { newFetcherSet = originalFetcherSet })
+
+	var indexFetches []string
+	newFetcherSet = func(httpClients) fetcherSet {
+		fs := successFetcherSet()
+		fs.modelAPI = &mockModelAPIFetcher{fetchFunc: func(id string) (*fetcher.ModelAPIResponse, error) {
+			if id == "org/sd" {
+				return &fetcher.ModelAPIResponse{ID: id, LibraryName: "diffusers"}, nil
+			}
+			return &fetcher.ModelAPIResponse{ID: id, LibraryName: "transformers"}, nil
+		}}
+		fs.modelIndex = indexFetcherFunc(func(id string) (*fetcher.ModelIndex, error) {
+			indexFetches = append(indexFetches, id)
+			return (&fetcher.DummyModelIndexFetcher{}).Fetch(id)
+		})
+		return fs
+	}
+
+	results, err := BuildFromModelIDs([]string{"org/sd", "org/bert"}, GenerateOptions{})
+	if err != nil {
+		t.Fatalf("BuildFromModelIDs: %v", err)
+	}
+	if !reflect.DeepEqual(indexFetches, []string{"org/sd"}) {
+		t.Errorf("model_index.json should only be fetched for diffusers repos, fetched %v", indexFetches)
+	}
+
+	sd := results[0].BOM.Metadata.Component
+	if sd.Components == nil || len(*sd.Components) != 6 {
+		t.Fatalf("expected 6 pipeline parts, got %+v", sd.Components)
+	}
+	if unet := (*sd.Components)[4]; unet.Name != "org/sd/unet" || unet.BOMRef != sd.PackageURL+"#unet" {
+		t.Errorf("unexpected unet part: %+v", unet)
+	}
+	if results[1].BOM.Metadata.Component.Components != nil {
+		t.Errorf("non-diffusers model must not get pipeline parts")
+	}
+}
+
+func TestBuildFromModelIDs_Concurrency(t *testing.T) {
+	originalFetcherSet := newFetcherSet
+	t.Cleanup(func() { newFetcherSet = originalFetcherSet })
+
+	ids := []string{"org/m0", "org/m1", "org/m2", "org/m3", "org/m4", "org/m5"}
+	var inFlight, maxInFlight atomic.Int32
+	newFetcherSet = func(httpClients) fetcherSet {
+		fs := successFetcherSet()
+		fs.modelAPI = &mockModelAPIFetcher{fetchFunc: func(id string) (*fetcher.ModelAPIResponse, error) {
+			n := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				m := maxInFlight.Load()
+				if n <= m || maxInFlight.CompareAndSwap(m, n) {
+					break
+				}
+			}
+			// Earlier models take longer, so they finish last.
+			time.Sleep(time.Duration(len(ids)-int(id[len(id)-1]-'0')) * 5 * time.Millisecond)
+			return &fetcher.ModelAPIResponse{ID: id}, nil
+		}}
+		return fs
+	}
+
+	var callbacks atomic.Int32
+	events := make(map[string][]ProgressEventType)
+	opts := GenerateOptions{
+		Concurrency: 3,
+		OnProgress: func(evt ProgressEvent) {
+			if callbacks.Add(1) != 1 {
+				t.Error("OnProgress called concurrently")
+			}
+			events[evt.ModelID] = append(events[evt.ModelID], evt.Type)
+			callbacks.Add(-1)
+		},
+	}
+
+	results, err := BuildFromModelIDs(ids, opts)
+	if err != nil {
+		t.Fatalf("BuildFromModelIDs: %v", err)
+	}
+	if len(results) != len(ids) {
+		t.Fatalf("expected %d BOMs, got %d", len(ids), len(results))
+	}
+	for i, r := range results {
+		if r.Discovery.ID != ids[i] {
+			t.Errorf("result %d is %s, want %s (results must keep the input order)", i, r.Discovery.ID, ids[i])
+		}
+	}
+	if got := maxInFlight.Load(); got < 2 || got > 3 {
+		t.Errorf("expected 2-3 concurrent fetches, got %d", got)
+	}
+	for _, id := range ids {
+		evts := events[id]
+		if len(evts) == 0 || evts[0] != EventFetchStart || evts[len(evts)-1] != EventModelComplete {
+			t.Errorf("%s: events out of order: %v", id, evts)
+		}
+	}
+}
+
+type indexFetcherFunc func(string) (*fetcher.ModelIndex, error)
+
+func (f indexFetcherFunc) Fetch(id string) (*fetcher.ModelIndex, error) { return f(id) }
+
+func TestBuildFromModelIDs_LicenseFile(t *testing.T) {
+	originalFetcherSet := newFetcherSet
+	t.Cleanup(func() { newFetcherSet = originalFetcherSet })
+
+	var licenseFetches []string
+	newFetcherSet = func(httpClients) fetcherSet {
+		fs := successFetcherSet()
+		fs.modelAPI = &mockModelAPIFetcher{fetchFunc: func(id string) (*fetcher.ModelAPIResponse, error) {
+			resp := &fetcher.ModelAPIResponse{ID: id, Tags: []string{"license:mit"}}
+			if id == "org/licensed" {
+				resp.Siblings = []fetcher.ModelSibling{{RFilename: "README.md"}, {RFilename: "LICENSE.md"}}
+			}
+			return resp, nil
+		}}
+		fs.licenseFile = licenseFetcherFunc(func(id, path string) (*fetcher.LicenseFile, error) {
+			licenseFetches = append(licenseFetches, id+"/"+path)
+			return fetcher.NewLicenseFile(path, []byte("MIT License")), nil
+		})
+		return fs
+	}
+
+	results, err := BuildFromModelIDs([]string{"org/licensed", "org/plain"}, GenerateOptions{})
+	if err != nil {
+		t.Fatalf("BuildFromModelIDs: %v", err)
+	}
+	if !reflect.DeepEqual(licenseFetches, []string{"org/licensed/LICENSE.md"}) {
+		t.Errorf("license file should only be fetched when the repo lists one, fetched %v", licenseFetches)
+	}
+
+	lic := (*results[0].BOM.Metadata.Component.Licenses)[0].License
+	if lic.Name != "mit" || lic.Text == nil || lic.Text.Content != "MIT License" || lic.Text.ContentType != "text/markdown" {
+		t.Errorf("expected license text attached to the declared license, got %+v", lic)
+	}
+	if lic := (*results[1].BOM.Metadata.Component.Licenses)[0].License; lic.Text != nil {
+		t.Errorf("model without license file must not get license text, got %+v", lic.Text)
+	}
+}
+
+func TestBuildFromModelIDs_IncludeRawMetadata(t *testing.T) {
+	originalFetcherSet := newFetcherSet
+	t.Cleanup(func() { newFetcherSet = originalFetcherSet })
+
+	newFetcherSet = func(httpClients) fetcherSet {
+		fs := successFetcherSet()
+		fs.modelAPI = &mockModelAPIFetcher{fetchFunc: func(id string) (*fetcher.ModelAPIResponse, error) {
+			return &fetcher.ModelAPIResponse{ID: id, SHA: "abc", Raw: []byte(`{"id":"` + id + `"}`)}, nil
+		}}
+		return fs
+	}
+
+	for _, include := range []bool{false, true} {
+		results, err := BuildFromModelIDs([]string{"org/model"}, GenerateOptions{IncludeRawMetadata: include})
+		if err != nil {
+			t.Fatalf("BuildFromModelIDs: %v", err)
+		}
+		var raw string
+		if props := results[0].BOM.Metadata.Component.Properties; props != nil {
+			for _, p := range *props {
+				if p.Name == taxonomy.RawHFAPI {
+					raw = p.Value
+				}
+			}
+		}
+		if !include {
+			if raw != "" {
+				t.Errorf("raw metadata stored without IncludeRawMetadata")
+			}
+			continue
+		}
+		got, err := builder.DecodeRawMetadata(raw)
+		if err != nil || string(got) != `{"id":"org/model"}` {
+			t.Errorf("raw API metadata = %q, %v", got, err)
+		}
+	}
+
+	with := cacheKey("org/model", scanner.Discovery{}, nil, "v1", GenerateOptions{IncludeRawMetadata: true, SkipSecurityScan: true})
+	without := cacheKey("org/model", scanner.Discovery{}, nil, "v1", GenerateOptions{SkipSecurityScan: true})
+	if with.Profile == without.Profile {
+		t.Errorf("raw metadata must be part of the BOM cache profile, got %q", with.Profile)
+	}
+}
+
+type licenseFetcherFunc func(string, string) (*fetcher.LicenseFile, error)
+
+func (f licenseFetcherFunc) Fetch(id, path string) (*fetcher.LicenseFile, error) { return f(id, path) }
+
+func TestBuildPerDiscovery_WeightURL(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead || r.Header.Get("Authorization") != "" {
+			t.Errorf("unexpected request: %s auth=%q", r.Method, r.Header.Get("Authorization"))
+		}
+		w.Header().Set("Content-Length", "4096")
+		w.Header().Set("ETag", `"abc123"`)
+	}))
+	defer srv.Close()
+
+	d := scanner.Discovery{
+		ID:     srv.URL + "/models/clf.onnx",
+		Name:   "clf.onnx",
+		Type:   scanner.DiscoveryTypeWeightURL,
+		Path:   "repo/download.py",
+		Method: "weight_url_https",
+		Format: "onnx",
+	}
+
+	results, err := BuildPerDiscovery([]scanner.Discovery{d}, GenerateOptions{HFToken: "hf_secret", ProbeWeightURLs: true})
+	if err != nil {
+		t.Fatalf("BuildPerDiscovery: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 BOM, got %d", len(results))
+	}
+	if got := results[0].Discovery; got.Size != 4096 || got.ETag != "abc123" {
+		t.Errorf("probe results not recorded: size=%d etag=%q", got.Size, got.ETag)
+	}
+
+	comp := results[0].BOM.Metadata.Component
+	if comp.PackageURL != "" {
+		t.Errorf("weight URL component must not get a Hugging Face purl: %q", comp.PackageURL)
+	}
+	want := []cdx.ExternalReference{{Type: cdx.ERTypeDistribution, URL: d.ID}}
+	if comp.ExternalReferences == nil || !reflect.DeepEqual(*comp.ExternalReferences, want) {
+		t.Errorf("externalReferences = %+v, want %+v", comp.ExternalReferences, want)
+	}
+}
+
+func TestBuildPerDiscovery_WeightURLCancelled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("probe sent after the run was cancelled: %s %s", r.Method, r.URL)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	d := scanner.Discovery{ID: srv.URL + "/models/clf.onnx", Name: "clf.onnx", Type: scanner.DiscoveryTypeWeightURL}
+
+	var probeErr error
+	progress := func(e ProgressEvent) {
+		if e.Type == EventError && e.Message == "weight URL probe failed" {
+			probeErr = e.Error
+		}
+	}
+	results, err := BuildPerDiscovery([]scanner.Discovery{d}, GenerateOptions{ProbeWeightURLs: true, Context: ctx, OnProgress: progress})
+	if err != nil {
+		t.Fatalf("BuildPerDiscovery: %v", err)
+	}
+	if !errors.Is(probeErr, context.Canceled) {
+		t.Errorf("probe error = %v, want context.Canceled", probeErr)
+	}
+	if len(results) != 1 || results[0].Discovery.Size != 0 {
+		t.Errorf("expected 1 BOM without probe results, got %+v", results)
+	}
+}
+
+func TestBuildPerDiscovery_ProviderModel(t *testing.T) {
+	d := scanner.Discovery{
+		ID:      "ngc:nvidia/tao/peoplenet:pruned_v2.6",
+		Name:    "nvidia/tao/peoplenet",
+		Type:    scanner.DiscoveryTypeProviderModel,
+		Path:    "repo/fetch.sh",
+		Method:  "ngc_cli",
+		Version: "pruned_v2.6",
+	}
+
+	results, err := BuildPerDiscovery([]scanner.Discovery{d}, GenerateOptions{})
+	if err != nil {
+		t.Fatalf("BuildPerDiscovery: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 BOM, got %d", len(results))
+	}
+
+	comp := results[0].BOM.Metadata.Component
+	if comp.PackageURL != "" {
+		t.Errorf("provider model component must not get a Hugging Face purl: %q", comp.PackageURL)
+	}
+	if comp.Name != d.Name || comp.Version != d.Version {
+		t.Errorf("component name/version = %q/%q, want %q/%q", comp.Name, comp.Version, d.Name, d.Version)
+	}
+	want := []cdx.ExternalReference{{Type: cdx.ERTypeWebsite, URL: "https://catalog.ngc.nvidia.com/orgs/nvidia/teams/tao/models/peoplenet"}}
+	if comp.ExternalReferences == nil || !reflect.DeepEqual(*comp.ExternalReferences, want) {
+		t.Errorf("externalReferences = %+v, want %+v", comp.ExternalReferences, want)
+	}
+}
+
+func TestBuildPerDiscovery_GGUF(t *testing.T) {
+	var b bytes.Buffer
+	le := func(v any) { binary.Write(&b, binary.LittleEndian, v) }
+	str := func(s string) { le(uint64(len(s))); b.WriteString(s) }
+	b.WriteString("GGUF")
+	le(uint32(3))
+	le(uint64(0)) // tensors
+	le(uint64(3)) // key-values
+	str("general.architecture")
+	le(uint32(8))
+	str("qwen2")
+	str("general.file_type")
+	le(uint32(4))
+	le(uint32(7))
+	str("qwen2.context_length")
+	le(uint32(4))
+	le(uint32(32768))
+	path := filepath.Join(t.TempDir(), "qwen2.Q8_0.gguf")
+	if err := os.WriteFile(path, b.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	d := scanner.Discovery{ID: path, Name: "qwen2.Q8_0.gguf", Type: scanner.DiscoveryTypeModelFile, Path: path, Format: "gguf"}
+	results, err := BuildPerDiscovery([]scanner.Discovery{d}, GenerateOptions{})
+	if err != nil || len(results) != 1 {
+		t.Fatalf("BuildPerDiscovery: %v (%d results)", err, len(results))
+	}
+	comp := results[0].BOM.Metadata.Component
+	if mp := comp.ModelCard.ModelParameters; mp == nil || mp.ArchitectureFamily != "qwen2" {
+		t.Errorf("model parameters = %+v", comp.ModelCard.ModelParameters)
+	}
+	if v, _ := taxonomy.Get(comp.Properties, taxonomy.WeightsQuantization); v != "Q8_0" {
+		t.Errorf("quantization = %q", v)
+	}
+	if v, _ := taxonomy.Get(comp.Properties, taxonomy.WeightsContextLength); v != "32768" {
+		t.Errorf("context length = %q", v)
+	}
+}
+
+func TestBuildPerDiscovery_ONNX(t *testing.T) {
+	field := func(num int, v []byte) []byte {
+		b := binary.AppendUvarint(nil, uint64(num)<<3|2)
+		b = binary.AppendUvarint(b, uint64(len(v)))
+		return append(b, v...)
+	}
+	// ModelProto{ir_version: 8, producer_name: "skl2onnx", graph: {name: "LinearRegression"}}.
+	model := append([]byte{0x08, 0x08}, field(2, []byte("skl2onnx"))...)
+	model = append(model, field(7, field(2, []byte("LinearRegression")))...)
+	path := filepath.Join(t.TempDir(), "model.onnx")
+	if err := os.WriteFile(path, model, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	d := scanner.Discovery{ID: path, Name: "model.onnx", Type: scanner.DiscoveryTypeModelFile, Path: path, Format: "onnx"}
+	results, err := BuildPerDiscovery([]scanner.Discovery{d}, GenerateOptions{})
+	if err != nil || len(results) != 1 {
+		t.Fatalf("BuildPerDiscovery: %v (%d results)", err, len(results))
+	}
+	comp := results[0].BOM.Metadata.Component
+	if mp := comp.ModelCard.ModelParameters; mp == nil || mp.ModelArchitecture != "LinearRegression" {
+		t.Errorf("model parameters = %+v", comp.ModelCard.ModelParameters)
+	}
+	if v, _ := taxonomy.Get(comp.Properties, taxonomy.ONNXProducer); v != "skl2onnx" {
+		t.Errorf("producer = %q", v)
+	}
+}
+
+func TestBuildPerDiscovery_Safetensors(t *testing.T) {
+	header := `{"__metadata__":{"format":"pt"},"w":{"dtype":"F16","shape":[4,8],"data_offsets":[0,64]}}`
+	data := append(binary.LittleEndian.AppendUint64(nil, uint64(len(header))), header...)
+	data = append(data, make([]byte, 64)...)
+	path := filepath.Join(t.TempDir(), "model.safetensors")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	d := scanner.Discovery{ID: path, Name: "model.safetensors", Type: scanner.DiscoveryTypeModelFile, Path: path, Format: "safetensors"}
+	results, err := BuildPerDiscovery([]scanner.Discovery{d}, GenerateOptions{})
+	if err != nil || len(results) != 1 {
+		t.Fatalf("BuildPerDiscovery: %v (%d results)", err, len(results))
+	}
+	comp := results[0].BOM.Metadata.Component
+	for name, want := range map[string]string{
+		taxonomy.WeightsParameterCount: "32",
+		taxonomy.WeightsDTypes:         "F16",
+		taxonomy.SafetensorsMetadata:   "format=pt",
+	} {
+		if v, _ := taxonomy.Get(comp.Properties, name); v != want {
+			t.Errorf("%s = %q, want %q", name, v, want)
+		}
+	}
+}
+
+func TestBuildPerDiscovery_TrackedArtifacts(t *testing.T) {
+	discoveries := []scanner.Discovery{
+		{ID: "models/clf.pkl", Name: "churn-classifier", Type: scanner.DiscoveryTypeTrackedArtifact, ArtifactType: scanner.ArtifactTypeModel,
+			Path: "models/clf.pkl", Format: "pickle", Hash: "11112222333344445555666677778888", HashAlgorithm: scanner.HashAlgorithmMD5,
+			StorageURI: "s3://ml-bucket/dvcstore/files/md5/11/112222333344445555666677778888", Method: "dvc_artifact"},
+		{ID: "data/train.parquet", Name: "churn-data", Type: scanner.DiscoveryTypeTrackedArtifact, ArtifactType: scanner.ArtifactTypeDataset,
+			Path: "data/train.parquet", Format: "parquet", StorageURI: "s3://ml-bucket/dvcstore/files/md5/aa/aabbbb", Method: "dvc_artifact"},
+	}
+	results, err := BuildPerDiscovery(discoveries, GenerateOptions{})
+	if err != nil || len(results) != 2 {
+		t.Fatalf("BuildPerDiscovery: %v (%d results)", err, len(results))
+	}
+	for i, wantType := range []cdx.ComponentType{cdx.ComponentTypeMachineLearningModel, cdx.ComponentTypeData} {
+		comp := results[i].BOM.Metadata.Component
+		if comp.Type != wantType || comp.Name != discoveries[i].Name {
+			t.Errorf("component %d = %s %q, want %s %q", i, comp.Type, comp.Name, wantType, discoveries[i].Name)
+		}
+		if comp.ExternalReferences == nil || len(*comp.ExternalReferences) != 1 ||
+			(*comp.ExternalReferences)[0].Type != cdx.ERTypeDistribution || (*comp.ExternalReferences)[0].URL != discoveries[i].StorageURI {
+			t.Errorf("%s external references = %+v, want the storage URI", comp.Name, comp.ExternalReferences)
+		}
+	}
+	if h := results[0].BOM.Metadata.Component.Hashes; h == nil || (*h)[0].Algorithm != cdx.HashAlgoMD5 {
+		t.Errorf("model hashes = %+v, want the MD5 tracked by DVC", h)
+	}
+}
+
+// mockKaggleFetcher serves Kaggle metadata from fixed values.
+type mockKaggleFetcher struct {
+	model   *fetcher.KaggleModel
+	dataset *fetcher.KaggleDataset
+}
+
+func (m mockKaggleFetcher) FetchModel(string) (*fetcher.KaggleModel, error) {
+	if m.model == nil {
+		return nil, &fetcher.KaggleError{StatusCode: http.StatusNotFound}
+	}
+	return m.model, nil
+}
+
+func (m mockKaggleFetcher) FetchDataset(string) (*fetcher.KaggleDataset, error) {
+	if m.dataset == nil {
+		return nil, &fetcher.KaggleError{StatusCode: http.StatusNotFound}
+	}
+	return m.dataset, nil
+}
+
+func TestBuildPerDiscovery_Kaggle(t *testing.T) {
+	original := newKaggleFetcher
+	t.Cleanup(func() { newKaggleFetcher = original })
+	newKaggleFetcher = func(*http.Client) kaggleFetcher {
+		return mockKaggleFetcher{
+			model: &fetcher.KaggleModel{
+				Author:   "Google",
+				Instance: &fetcher.KaggleModelInstance{Framework: "keras", LicenseName: "Gemma", VersionNumber: 3},
+			},
+		}
+	}
+
+	discoveries := []scanner.Discovery{
+		{ID: "kaggle:models/keras/gemma/keras/gemma_2b_en", Name: "keras/gemma/keras/gemma_2b_en", Type: scanner.DiscoveryTypeKaggle, Path: "repo/train.py", Method: "kaggle_uri"},
+		{ID: "kaggle:datasets/uciml/iris", Name: "uciml/iris", Type: scanner.DiscoveryTypeKaggle, Path: "repo/train.py", Method: "kagglehub_dataset"},
+	}
+	var errors int
+	results, err := BuildPerDiscovery(discoveries, GenerateOptions{OnProgress: func(e ProgressEvent) {
+		if e.Type == EventError {
+			errors++
+		}
+	}})
+	if err != nil {
+		t.Fatalf("BuildPerDiscovery: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 BOMs, got %d", len(results))
+	}
+
+	model := results[0].BOM.Metadata.Component
+	if model.PackageURL != "" {
+		t.Errorf("Kaggle model must not get a Hugging Face purl: %q", model.PackageURL)
+	}
+	if model.Version != "3" || model.Manufacturer == nil || model.Manufacturer.Name != "Google" {
+		t.Errorf("model version/manufacturer = %q/%+v", model.Version, model.Manufacturer)
+	}
+	want := []cdx.ExternalReference{{Type: cdx.ERTypeWebsite, URL: "https://www.kaggle.com/models/keras/gemma/keras/gemma_2b_en/3"}}
+	if model.ExternalReferences == nil || !reflect.DeepEqual(*model.ExternalReferences, want) {
+		t.Errorf("externalReferences = %+v, want %+v", model.ExternalReferences, want)
+	}
+
+	// The dataset cannot be fetched: it is still built from the scan.
+	dataset := results[1].BOM.Metadata.Component
+	if dataset.Type != cdx.ComponentTypeData || dataset.Name != "uciml/iris" {
+		t.Errorf("dataset component = %s %q", dataset.Type, dataset.Name)
+	}
+	if errors != 1 {
+		t.Errorf("expected 1 error event for the dataset, got %d", errors)
+	}
+}
+
+// mockOllamaFetcher serves a fixed manifest for any model.
+type mockOllamaFetcher struct {
+	model *fetcher.OllamaModel
+}
+
+func (m mockOllamaFetcher) Fetch(namespace, model, tag string) (*fetcher.OllamaModel, error) {
+	if namespace != "library" || model != "llama3" || tag != "8b" {
+		return nil, &fetcher.OllamaError{StatusCode: http.StatusNotFound}
+	}
+	return m.model, nil
+}
+
+func TestBuildPerDiscovery_Ollama(t *testing.T) {
+	original := newOllamaFetcher
+	t.Cleanup(func() { newOllamaFetcher = original })
+	newOllamaFetcher = func(*http.Client) ollamaFetcher {
+		return mockOllamaFetcher{model: &fetcher.OllamaModel{
+			Digest: "sha256:manifest",
+			Manifest: fetcher.OllamaManifest{Layers: []fetcher.OllamaLayer{
+				{MediaType: fetcher.OllamaLayerModel, Digest: "sha256:weights", Size: 4000},
+				{MediaType: fetcher.OllamaLayerLicense, Digest: "sha256:lic", Size: 20},
+			}},
+			Config:  fetcher.OllamaConfig{ModelFormat: "gguf", FileType: "Q4_0"},
+			License: "META LLAMA 3 COMMUNITY LICENSE AGREEMENT",
+		}}
+	}
+
+	d := scanner.Discovery{ID: "ollama:llama3:8b", Name: "llama3", Type: scanner.DiscoveryTypeProviderModel, Path: "repo/Modelfile", Method: "ollama_modelfile", Version: "8b"}
+	results, err := BuildPerDiscovery([]scanner.Discovery{d}, GenerateOptions{HFToken: "hf_secret"})
+	if err != nil {
+		t.Fatalf("BuildPerDiscovery: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 BOM, got %d", len(results))
+	}
+	if got := results[0].Discovery; got.Format != "gguf" || got.Size != 4020 {
+		t.Errorf("discovery format/size = %q/%d", got.Format, got.Size)
+	}
+
+	comp := results[0].BOM.Metadata.Component
+	if comp.PackageURL != "" || comp.Version != "8b" {
+		t.Errorf("component purl/version = %q/%q", comp.PackageURL, comp.Version)
+	}
+	if comp.Hashes == nil || (*comp.Hashes)[0].Value != "weights" {
+		t.Errorf("hashes = %+v", comp.Hashes)
+	}
+	if v, _ := taxonomy.Get(comp.Properties, taxonomy.OllamaQuantization); v != "Q4_0" {
+		t.Errorf("quantization = %q", v)
+	}
+	if v, _ := taxonomy.Get(comp.Properties, taxonomy.FileSize); v != "4020" {
+		t.Errorf("file size = %q", v)
+	}
+}
+
+func TestWeightURLEndpoint(t *testing.T) {
+	tests := map[string]string{
+		"s3://bucket/dir/model.safetensors": "https://bucket.s3.amazonaws.com/dir/model.safetensors",
+		"gs://bucket/model.ckpt":            "https://storage.googleapis.com/bucket/model.ckpt",
+		"https://example.com/m.gguf":        "https://example.com/m.gguf",
+		"az://container/model.onnx":         "",
+	}
+	for in, want := range tests {
+		got, ok := weightURLEndpoint(in)
+		if got != want || ok != (want != "") {
+			t.Errorf("weightURLEndpoint(%q) = %q, %v; want %q", in, got, ok, want)
+		}
+	}
+}
+
+func TestBuildFromLocalPath(t *testing.T) {
+	originalFetcherSet := newFetcherSet
+	t.Cleanup(func() { newFetcherSet = originalFetcherSet })
+	newFetcherSet = func(httpClients) fetcherSet {
+		t.Fatal("local generation must not use the Hub fetchers")
+		return fetcherSet{}
+	}
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"README.md":   "---\nlicense: mit\npipeline_tag: text-classification\ndatasets: [imdb]\n---\n# model\n",
+		"config.json": `{"_name_or_path":"org/local-model","model_type":"bert","architectures":["BertForSequenceClassification"]}`,
+		"LICENSE":     "MIT License",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var events []ProgressEventType
+	results, err := BuildFromLocalPath(dir, "", GenerateOptions{OnProgress: func(e ProgressEvent) {
+		events = append(events, e.Type)
+		if e.Type == EventError {
+			t.Errorf("unexpected error event: %s: %v", e.Message, e.Error)
+		}
+	}})
+	if err != nil {
+		t.Fatalf("BuildFromLocalPath: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+	if results[0].Discovery.ID != "org/local-model" || results[0].Discovery.Path != dir {
+		t.Errorf("unexpected discovery %+v", results[0].Discovery)
+	}
+
+	comp := results[0].BOM.Metadata.Component
+	if comp.ModelCard == nil || comp.ModelCard.ModelParameters == nil || comp.ModelCard.ModelParameters.Task != "text-classification" {
+		t.Errorf("expected task from the README front matter, got %+v", comp.ModelCard)
+	}
+	lic := (*comp.Licenses)[0].License
+	if lic.Name != "mit" || lic.Text == nil || lic.Text.Content != "MIT License" {
+		t.Errorf("expected the local license file on the license, got %+v", lic)
+	}
+	if results[0].BOM.Components != nil {
+		for _, c := range *results[0].BOM.Components {
+			if c.Type == cdx.ComponentTypeData {
+				t.Errorf("datasets must not be fetched offline, got %s", c.Name)
+			}
+		}
+	}
+	for _, e := range events {
+		if e == EventDatasetStart {
+			t.Error("datasets must not be fetched offline")
+		}
+	}
+}
+
+func TestBuildFromModelIDs_RetriesRateLimitedRequests(t *testing.T) {
+	originalFetcherSet := newFetcherSet
+	t.Cleanup(func() { newFetcherSet = originalFetcherSet })
+
+	var attempts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/models/org/limited" && attempts.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"id":"` + r.URL.Path[len("/api/models/"):] + `"}`))
+	}))
+	defer srv.Close()
+
+	newFetcherSet = func(clients httpClients) fetcherSet {
+		fs := successFetcherSet()
+		fs.modelAPI = &fetcher.ModelAPIFetcher{Client: clients.api, BaseURL: srv.URL}
+		return fs
+	}
+
+	var retries []ProgressEvent
+	results, err := BuildFromModelIDs([]string{"org/ok", "org/limited"}, GenerateOptions{
+		MaxRetries:  2,
+		Concurrency: 2,
+		OnProgress: func(evt ProgressEvent) {
+			if evt.Type == EventRetry {
+				retries = append(retries, evt)
+			}
+			if evt.Type == EventError {
+				t.Errorf("unexpected error event: %+v", evt)
+			}
+		},
+	})
+	if err != nil {
+		t.Fatalf("BuildFromModelIDs: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 BOMs, got %d", len(results))
+	}
+	if len(retries) != 1 || retries[0].ModelID != "org/limited" {
+		t.Fatalf("retry events = %+v", retries)
+	}
+	if want := "HTTP 429 from " + srv.URL + "/api/models/org/limited, retry 1/2 in 0s"; retries[0].Message != want {
+		t.Errorf("retry message = %q, want %q", retries[0].Message, want)
+	}
+}
+
+func TestBuildFromModelIDs_HubUnreachable(t *testing.T) {
+	originalFetcherSet := newFetcherSet
+	t.Cleanup(func() { newFetcherSet = originalFetcherSet })
+
+	var readmeCalls atomic.Int32
+	newFetcherSet = func(httpClients) fetcherSet {
+		fs := successFetcherSet()
+		fs.modelAPI = &mockModelAPIFetcher{
+			fetchFunc: func(string) (*fetcher.ModelAPIResponse, error) {
+				return nil, fmt.Errorf("%w: 5 requests to huggingface.co failed in a row", fetcher.ErrHubUnreachable)
+			},
+		}
+		fs.modelReadme = &mockModelReadmeFetcher{
+			fetchFunc: func(string) (*fetcher.ModelReadmeCard, error) {
+				readmeCalls.Add(1)
+				return &fetcher.ModelReadmeCard{}, nil
+			},
+		}
+		return fs
+	}
+
+	var outages, errs int
+	results, err := BuildFromModelIDs([]string{"org/a", "org/b", "org/c"}, GenerateOptions{
+		Concurrency: 2,
+		OnProgress: func(evt ProgressEvent) {
+			switch evt.Type {
+			case EventHubUnreachable:
+				outages++
+			case EventError:
+				errs++
+			}
+		},
+	})
+	if err != nil {
+		t.Fatalf("BuildFromModelIDs: %v", err)
+	}
+	if outages != 1 {
+		t.Fatalf("got %d EventHubUnreachable events, want 1", outages)
+	}
+	if errs != 3 || readmeCalls.Load() != 0 {
+		t.Fatalf("got %d errors and %d README fetches, want one error per model and no further fetches", errs, readmeCalls.Load())
+	}
+	// The models are still built, from the scan alone.
+	if len(results) != 3 {
+		t.Fatalf("got %d results, want 3", len(results))
+	}
+}
@@ -0,0 +1,93 @@
+package generator
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/idlab-discover/aibomgen-cli/internal/builder"
+	"github.com/idlab-discover/aibomgen-cli/internal/fetcher"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/scanner"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+// kaggleFetcher fetches the Kaggle API metadata of "kaggle" discoveries.
+type kaggleFetcher interface {
+	FetchModel(string) (*fetcher.KaggleModel, error)
+	FetchDataset(string) (*fetcher.KaggleDataset, error)
+}
+
+var newKaggleFetcher = func(httpClient *http.Client) kaggleFetcher {
+	return &fetcher.KaggleFetcher{Client: httpClient}
+}
+
+// newKaggleClient returns the HTTP client for the Kaggle API. It sends the.
+// Kaggle credentials, never the Hugging Face token.
+func newKaggleClient(opts GenerateOptions, onRetry func(fetcher.RetryAttempt)) *http.Client {
+	return fetcher.NewKaggleClient(opts.Timeout, fetcher.LoadKaggleCredentials(), fetcher.RetryPolicy{
+		MaxRetries: opts.MaxRetries,
+		MaxBackoff: opts.MaxBackoff,
+		OnRetry:    onRetry,
+	})
+}
+
+// buildKaggle builds a BOM for a "kaggle" discovery: a model variation.
+// becomes the metadata component like a Hugging Face model, a dataset like a.
+// "data-file" discovery. A reference without a version records the version.
+// the API reports as current. When the metadata cannot be fetched the BOM is.
+// built from the scan alone.
+func buildKaggle(kf kaggleFetcher, bomBuilder bomBuilder, d scanner.Discovery, index, total int, progress ProgressCallback) (DiscoveredBOM, bool) {
+	name := strings.TrimSpace(d.Name)
+	kind, handle, _ := scanner.KaggleRef(d)
+
+	progress(ProgressEvent{Type: EventFetchStart, ModelID: name, Index: index, Total: total})
+
+	var bom *cdx.BOM
+	var err error
+	if kind == scanner.KaggleDatasets {
+		ds, ferr := kf.FetchDataset(handle)
+		if ferr != nil {
+			progress(ProgressEvent{Type: EventError, ModelID: name, Error: ferr, Message: kaggleErrMessage(ferr)})
+		} else {
+			progress(ProgressEvent{Type: EventFetchAPIComplete, ModelID: name})
+			if d.Version == "" && ds.CurrentVersionNumber > 0 {
+				d.Version = strconv.Itoa(ds.CurrentVersionNumber)
+			}
+		}
+		progress(ProgressEvent{Type: EventBuildStart, ModelID: name})
+		bom, err = datasetBOM(bomBuilder, builder.DatasetBuildContext{Scan: d, Kaggle: ds})
+	} else {
+		m, ferr := kf.FetchModel(handle)
+		if ferr != nil {
+			progress(ProgressEvent{Type: EventError, ModelID: name, Error: ferr, Message: kaggleErrMessage(ferr)})
+		} else {
+			progress(ProgressEvent{Type: EventFetchAPIComplete, ModelID: name})
+			if d.Version == "" && m.Instance != nil && m.Instance.VersionNumber > 0 {
+				d.Version = strconv.Itoa(m.Instance.VersionNumber)
+			}
+		}
+		progress(ProgressEvent{Type: EventBuildStart, ModelID: name})
+		bom, err = bomBuilder.Build(builder.BuildContext{Scan: d, Kaggle: m})
+	}
+	if err != nil {
+		progress(ProgressEvent{Type: EventError, ModelID: name, Error: err, Message: "BOM build failed"})
+		return DiscoveredBOM{}, false
+	}
+
+	progress(ProgressEvent{Type: EventBuildComplete, ModelID: name})
+	progress(ProgressEvent{Type: EventModelComplete, ModelID: name})
+
+	return DiscoveredBOM{Discovery: d, BOM: bom}, true
+}
+
+// kaggleErrMessage is fetchErrMessage for the Kaggle API.
+func kaggleErrMessage(err error) string {
+	switch {
+	case fetcher.IsNotFound(err):
+		return "Kaggle API: not found on Kaggle"
+	case fetcher.IsUnauthorized(err):
+		return "Kaggle API: unauthorized (set KAGGLE_USERNAME and KAGGLE_KEY or kaggle.json)"
+	}
+	return "Kaggle API fetch failed: " + err.Error()
+}
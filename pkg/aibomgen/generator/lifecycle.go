@@ -0,0 +1,73 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/internal/builder"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/scanner"
+)
+
+// lifecyclePhases are the CycloneDX lifecycle phases accepted by.
+// ParseLifecyclePhase.
+var lifecyclePhases = []cdx.LifecyclePhase{
+	cdx.LifecyclePhaseDesign,
+	cdx.LifecyclePhasePreBuild,
+	cdx.LifecyclePhaseBuild,
+	cdx.LifecyclePhasePostBuild,
+	cdx.LifecyclePhaseOperations,
+	cdx.LifecyclePhaseDiscovery,
+	cdx.LifecyclePhaseDecommission,
+}
+
+// ParseLifecyclePhase validates a CycloneDX lifecycle phase name. An empty.
+// name returns an empty phase, which selects the phase from the context.
+func ParseLifecyclePhase(s string) (cdx.LifecyclePhase, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return "", nil
+	}
+	names := make([]string, 0, len(lifecyclePhases))
+	for _, p := range lifecyclePhases {
+		if string(p) == s {
+			return p, nil
+		}
+		names = append(names, string(p))
+	}
+	return "", fmt.Errorf("invalid lifecycle phase %q (expected %s)", s, strings.Join(names, "|"))
+}
+
+// lifecyclePhaseOf returns the phase of a BOM built from discoveries:.
+// operations when every discovery was found in a deployment manifest.
+// (Dockerfiles, compose files, Kubernetes or Helm YAML), design otherwise.
+// BOMs generated from model IDs alone describe models chosen for use and are.
+// design BOMs as well.
+func lifecyclePhaseOf(discoveries ...scanner.Discovery) cdx.LifecyclePhase {
+	if len(discoveries) == 0 {
+		return cdx.LifecyclePhaseDesign
+	}
+	for _, d := range discoveries {
+		if !scanner.IsDeploymentManifest(d.Path) {
+			return cdx.LifecyclePhaseDesign
+		}
+	}
+	return cdx.LifecyclePhaseOperations
+}
+
+// ApplyLifecycle records the lifecycle phase in the metadata of every result.
+// A non-empty phase overrides the phase derived from the command context;.
+// otherwise BOMs that have no phase yet get the phase of their discovery.
+func ApplyLifecycle(results []DiscoveredBOM, phase cdx.LifecyclePhase) {
+	for _, r := range results {
+		if r.BOM == nil {
+			continue
+		}
+		switch {
+		case phase != "":
+			builder.SetMetaLifecycle(r.BOM, phase)
+		case r.BOM.Metadata == nil || r.BOM.Metadata.Lifecycles == nil:
+			builder.SetMetaLifecycle(r.BOM, lifecyclePhaseOf(r.Discovery))
+		}
+	}
+}
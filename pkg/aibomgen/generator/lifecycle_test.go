@@ -0,0 +1,80 @@
+package generator
+
+import (
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/scanner"
+)
+
+func phaseOf(bom *cdx.BOM) cdx.LifecyclePhase {
+	if bom.Metadata == nil || bom.Metadata.Lifecycles == nil || len(*bom.Metadata.Lifecycles) != 1 {
+		return ""
+	}
+	return (*bom.Metadata.Lifecycles)[0].Phase
+}
+
+func TestParseLifecyclePhase(t *testing.T) {
+	if p, err := ParseLifecyclePhase(" Operations "); err != nil || p != cdx.LifecyclePhaseOperations {
+		t.Errorf("ParseLifecyclePhase(operations) = %q, %v", p, err)
+	}
+	if p, err := ParseLifecyclePhase(""); err != nil || p != "" {
+		t.Errorf("ParseLifecyclePhase(\"\") = %q, %v", p, err)
+	}
+	if _, err := ParseLifecyclePhase("runtime"); err == nil {
+		t.Errorf("expected error for unknown phase")
+	}
+}
+
+func TestApplyLifecycle(t *testing.T) {
+	results := []DiscoveredBOM{
+		{Discovery: scanner.Discovery{Path: "src/app.py"}, BOM: cdx.NewBOM()},
+		{Discovery: scanner.Discovery{Path: "deploy/k8s/serving.yaml"}, BOM: cdx.NewBOM()},
+		{Discovery: scanner.Discovery{}, BOM: cdx.NewBOM()},
+		{BOM: nil},
+	}
+	ApplyLifecycle(results, "")
+	want := []cdx.LifecyclePhase{cdx.LifecyclePhaseDesign, cdx.LifecyclePhaseOperations, cdx.LifecyclePhaseDesign}
+	for i, w := range want {
+		if got := phaseOf(results[i].BOM); got != w {
+			t.Errorf("result %d phase = %q, want %q", i, got, w)
+		}
+	}
+
+	ApplyLifecycle(results, cdx.LifecyclePhasePreBuild)
+	for i := range want {
+		if got := phaseOf(results[i].BOM); got != cdx.LifecyclePhasePreBuild {
+			t.Errorf("result %d phase = %q after override", i, got)
+		}
+	}
+}
+
+func TestBuildApplicationBOM_Lifecycle(t *testing.T) {
+	manifests := []DiscoveredBOM{
+		{Discovery: scanner.Discovery{Path: "Dockerfile"}, BOM: cdx.NewBOM()},
+		{Discovery: scanner.Discovery{Path: "docker-compose.yml"}, BOM: cdx.NewBOM()},
+	}
+	got, err := BuildApplicationBOM(ApplicationInfo{Name: "svc", Path: "/src/svc"}, manifests)
+	if err != nil {
+		t.Fatalf("BuildApplicationBOM() error = %v", err)
+	}
+	if p := phaseOf(got.BOM); p != cdx.LifecyclePhaseOperations {
+		t.Errorf("phase = %q, want operations", p)
+	}
+
+	// The application discovery points at the project root; the phase derived.
+	// from its members must survive ApplyLifecycle.
+	ApplyLifecycle([]DiscoveredBOM{got}, "")
+	if p := phaseOf(got.BOM); p != cdx.LifecyclePhaseOperations {
+		t.Errorf("phase after ApplyLifecycle = %q, want operations", p)
+	}
+
+	mixed := append(manifests, DiscoveredBOM{Discovery: scanner.Discovery{Path: "train.py"}, BOM: cdx.NewBOM()})
+	got, err = BuildApplicationBOM(ApplicationInfo{Name: "svc"}, mixed)
+	if err != nil {
+		t.Fatalf("BuildApplicationBOM() error = %v", err)
+	}
+	if p := phaseOf(got.BOM); p != cdx.LifecyclePhaseDesign {
+		t.Errorf("phase = %q, want design", p)
+	}
+}
@@ -0,0 +1,73 @@
+package generator
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/idlab-discover/aibomgen-cli/internal/builder"
+	"github.com/idlab-discover/aibomgen-cli/internal/fetcher"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/scanner"
+)
+
+// ollamaFetcher fetches the registry metadata of Ollama models.
+type ollamaFetcher interface {
+	Fetch(namespace, model, tag string) (*fetcher.OllamaModel, error)
+}
+
+var newOllamaFetcher = func(httpClient *http.Client) ollamaFetcher {
+	return &fetcher.OllamaFetcher{Client: httpClient}
+}
+
+// newOllamaClient returns the HTTP client for the Ollama registry. It never.
+// sends the Hugging Face token.
+func newOllamaClient(opts GenerateOptions, onRetry func(fetcher.RetryAttempt)) *http.Client {
+	return fetcher.NewOllamaClient(opts.Timeout, fetcher.RetryPolicy{
+		MaxRetries: opts.MaxRetries,
+		MaxBackoff: opts.MaxBackoff,
+		OnRetry:    onRetry,
+	})
+}
+
+// buildOllama builds a BOM for an Ollama "provider-model" discovery from the.
+// manifest of its tag in the Ollama registry. The weights format and size.
+// are recorded on the discovery like those of a probed weight URL. When the.
+// manifest cannot be fetched the BOM is built from the scan alone.
+func buildOllama(of ollamaFetcher, bomBuilder bomBuilder, d scanner.Discovery, index, total int, progress ProgressCallback) (DiscoveredBOM, bool) {
+	name := strings.TrimSpace(d.Name)
+	namespace, model, tag, _ := scanner.OllamaRef(d)
+
+	progress(ProgressEvent{Type: EventFetchStart, ModelID: name, Index: index, Total: total})
+
+	m, err := of.Fetch(namespace, model, tag)
+	if err != nil {
+		progress(ProgressEvent{Type: EventError, ModelID: name, Error: err, Message: ollamaErrMessage(err)})
+	} else {
+		progress(ProgressEvent{Type: EventFetchAPIComplete, ModelID: name})
+		if d.Format == "" {
+			d.Format = strings.TrimSpace(m.Config.ModelFormat)
+		}
+		if d.Size == 0 {
+			d.Size = m.Size()
+		}
+	}
+
+	progress(ProgressEvent{Type: EventBuildStart, ModelID: name})
+	bom, err := bomBuilder.Build(builder.BuildContext{Scan: d, Ollama: m})
+	if err != nil {
+		progress(ProgressEvent{Type: EventError, ModelID: name, Error: err, Message: "BOM build failed"})
+		return DiscoveredBOM{}, false
+	}
+
+	progress(ProgressEvent{Type: EventBuildComplete, ModelID: name})
+	progress(ProgressEvent{Type: EventModelComplete, ModelID: name})
+
+	return DiscoveredBOM{Discovery: d, BOM: bom}, true
+}
+
+// ollamaErrMessage is fetchErrMessage for the Ollama registry.
+func ollamaErrMessage(err error) string {
+	if fetcher.IsNotFound(err) {
+		return "Ollama registry: not found in the Ollama registry"
+	}
+	return "Ollama registry fetch failed: " + err.Error()
+}
@@ -0,0 +1,85 @@
+package generator
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// progressEventNames are the names of the event types in JSON Lines progress.
+// streams. They are part of the stream format: rename none.
+var progressEventNames = map[ProgressEventType]string{
+	EventScanStart:                 "scan_start",
+	EventScanComplete:              "scan_complete",
+	EventFetchStart:                "fetch_start",
+	EventFetchAPIComplete:          "fetch_api_complete",
+	EventFetchReadmeComplete:       "fetch_readme_complete",
+	EventFetchSecurityScanComplete: "fetch_security_scan_complete",
+	EventBuildStart:                "build_start",
+	EventBuildComplete:             "build_complete",
+	EventDatasetStart:              "dataset_start",
+	EventDatasetComplete:           "dataset_complete",
+	EventDatasetError:              "dataset_error",
+	EventModelComplete:             "model_complete",
+	EventError:                     "error",
+	EventCacheHit:                  "cache_hit",
+	EventRetry:                     "retry",
+	EventHubUnreachable:            "hub_unreachable",
+}
+
+// String returns the snake_case name of the event type.
+func (t ProgressEventType) String() string {
+	if name, ok := progressEventNames[t]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// ProgressRecord is the JSON form of a ProgressEvent, one per line of a.
+// JSON Lines progress stream.
+type ProgressRecord struct {
+	Type      string `json:"type"`
+	ModelID   string `json:"modelID,omitempty"`
+	Dataset   string `json:"dataset,omitempty"`
+	Message   string `json:"message,omitempty"`
+	Index     *int   `json:"index,omitempty"`
+	Total     int    `json:"total,omitempty"`
+	Datasets  int    `json:"datasets,omitempty"`
+	Error     string `json:"error,omitempty"`
+	Timestamp string `json:"timestamp"`
+}
+
+// Record converts the event to its JSON form, stamped with at. The dataset.
+// events carry the dataset ID in Dataset rather than Message, and Index is.
+// only set for the events that count models (Total > 0).
+func (e ProgressEvent) Record(at time.Time) ProgressRecord {
+	r := ProgressRecord{
+		Type:      e.Type.String(),
+		ModelID:   e.ModelID,
+		Message:   e.Message,
+		Total:     e.Total,
+		Datasets:  e.Datasets,
+		Timestamp: at.UTC().Format(time.RFC3339Nano),
+	}
+	switch e.Type {
+	case EventDatasetStart, EventDatasetComplete, EventDatasetError:
+		r.Dataset, r.Message = e.Message, ""
+	}
+	if e.Total > 0 {
+		r.Index = &e.Index
+	}
+	if e.Error != nil {
+		r.Error = e.Error.Error()
+	}
+	return r
+}
+
+// JSONLProgress returns a ProgressCallback that writes every event to w as.
+// one JSON object per line, for CI wrappers that track generation. Write.
+// errors are ignored: progress reporting never fails a run.
+func JSONLProgress(w io.Writer) ProgressCallback {
+	enc := json.NewEncoder(w)
+	return func(evt ProgressEvent) {
+		_ = enc.Encode(evt.Record(time.Now()))
+	}
+}
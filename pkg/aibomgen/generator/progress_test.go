@@ -0,0 +1,53 @@
+package generator
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestJSONLProgress(t *testing.T) {
+	var buf bytes.Buffer
+	progress := JSONLProgress(&buf)
+	progress(ProgressEvent{Type: EventFetchStart, ModelID: "org/model", Index: 0, Total: 2})
+	progress(ProgressEvent{Type: EventDatasetError, ModelID: "org/model", Message: "org/data", Error: errors.New("not found")})
+
+	var records []ProgressRecord
+	sc := bufio.NewScanner(&buf)
+	for sc.Scan() {
+		var r ProgressRecord
+		if err := json.Unmarshal(sc.Bytes(), &r); err != nil {
+			t.Fatalf("line %q: %v", sc.Text(), err)
+		}
+		if _, err := time.Parse(time.RFC3339Nano, r.Timestamp); err != nil {
+			t.Errorf("timestamp %q: %v", r.Timestamp, err)
+		}
+		r.Timestamp = ""
+		records = append(records, r)
+	}
+	index := 0
+	want := []ProgressRecord{
+		{Type: "fetch_start", ModelID: "org/model", Index: &index, Total: 2},
+		{Type: "dataset_error", ModelID: "org/model", Dataset: "org/data", Error: "not found"},
+	}
+	if len(records) != len(want) {
+		t.Fatalf("got %d records, want %d", len(records), len(want))
+	}
+	for i := range want {
+		if !reflect.DeepEqual(records[i], want[i]) {
+			t.Errorf("record %d = %+v, want %+v", i, records[i], want[i])
+		}
+	}
+}
+
+func TestProgressEventTypeNames(t *testing.T) {
+	for typ := EventScanStart; typ <= EventRetry; typ++ {
+		if _, ok := progressEventNames[typ]; !ok {
+			t.Errorf("event type %d has no name", typ)
+		}
+	}
+}
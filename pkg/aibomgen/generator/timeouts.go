@@ -0,0 +1,111 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/idlab-discover/aibomgen-cli/internal/fetcher"
+)
+
+// ErrModelDeadline is wrapped by the errors of requests cut short by.
+// GenerateOptions.ModelDeadline.
+var ErrModelDeadline = errors.New("model deadline exceeded")
+
+// httpClients are the Hugging Face clients of the fetch stages of a model,.
+// each with the timeout of its stage.
+type httpClients struct {
+	api     *http.Client // model API, repository tree, config.json, LICENSE, model_index.json
+	readme  *http.Client // model cards
+	dataset *http.Client // dataset API and dataset cards
+}
+
+// newHTTPClients returns the clients of the fetch stages. A stage without a.
+// timeout of its own uses opts.Timeout. wrap, when non-nil, wraps every.
+// client (tracing, deadline).
+func newHTTPClients(opts GenerateOptions, onRetry func(fetcher.RetryAttempt), wrap func(*http.Client) *http.Client) httpClients {
+	client := func(timeout time.Duration) *http.Client {
+		if timeout <= 0 {
+			timeout = opts.Timeout
+		}
+		c := newHTTPClient(opts, timeout, onRetry)
+		if wrap != nil {
+			c = wrap(c)
+		}
+		return c
+	}
+	return httpClients{
+		api:     client(opts.APITimeout),
+		readme:  client(opts.ReadmeTimeout),
+		dataset: client(opts.DatasetTimeout),
+	}
+}
+
+// modelDeadline bounds all requests made for the model a worker is building.
+// Every worker has its own: the deadline changes with every model.
+type modelDeadline struct {
+	budget time.Duration // 0: no deadline
+	at     atomic.Int64  // deadline of the current model in Unix nanoseconds
+}
+
+// start begins the deadline of the next model.
+func (m *modelDeadline) start() {
+	if m.budget > 0 {
+		m.at.Store(time.Now().Add(m.budget).UnixNano())
+	}
+}
+
+// client returns a copy of c whose requests end at the deadline of the.
+// current model, retries and their waits included. Without a budget c is.
+// returned as is.
+func (m *modelDeadline) client(c *http.Client) *http.Client {
+	if m.budget <= 0 {
+		return c
+	}
+	base := c.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	cp := *c
+	cp.Transport = &deadlineTransport{base: base, deadline: m}
+	return &cp
+}
+
+// deadlineTransport applies the deadline of a modelDeadline to requests.
+type deadlineTransport struct {
+	base     http.RoundTripper
+	deadline *modelDeadline
+}
+
+func (t *deadlineTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithDeadline(req.Context(), time.Unix(0, t.deadline.at.Load()))
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) && req.Context().Err() == nil {
+			err = fmt.Errorf("%w (%s): %w", ErrModelDeadline, t.deadline.budget, err)
+		}
+		return nil, err
+	}
+	// The deadline also covers reading the body; release it once closed.
+	resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelBody cancels the context of its request when closed.
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+	once   sync.Once
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.cancel)
+	return err
+}
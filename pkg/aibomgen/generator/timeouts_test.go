@@ -0,0 +1,76 @@
+package generator
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/idlab-discover/aibomgen-cli/internal/fetcher"
+)
+
+func TestNewHTTPClients_StageTimeouts(t *testing.T) {
+	clients := newHTTPClients(GenerateOptions{Timeout: 10 * time.Second, ReadmeTimeout: 45 * time.Second}, nil, nil)
+	for name, tc := range map[string]struct {
+		client *http.Client
+		want   time.Duration
+	}{
+		"api":     {clients.api, 10 * time.Second},
+		"readme":  {clients.readme, 45 * time.Second},
+		"dataset": {clients.dataset, 10 * time.Second},
+	} {
+		if tc.client.Timeout != tc.want {
+			t.Errorf("%s timeout = %s, want %s", name, tc.client.Timeout, tc.want)
+		}
+	}
+}
+
+func TestBuildFromModelIDs_ModelDeadline(t *testing.T) {
+	originalFetcherSet := newFetcherSet
+	t.Cleanup(func() { newFetcherSet = originalFetcherSet })
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/models/org/slow" {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(5 * time.Second):
+			}
+			return
+		}
+		w.Write([]byte(`{"id":"` + r.URL.Path[len("/api/models/"):] + `"}`))
+	}))
+	defer srv.Close()
+
+	newFetcherSet = func(clients httpClients) fetcherSet {
+		fs := successFetcherSet()
+		fs.modelAPI = &fetcher.ModelAPIFetcher{Client: clients.api, BaseURL: srv.URL}
+		return fs
+	}
+
+	var deadlineErrs []ProgressEvent
+	start := time.Now()
+	results, err := BuildFromModelIDs([]string{"org/slow", "org/fast"}, GenerateOptions{
+		Timeout:       10 * time.Second,
+		ModelDeadline: 100 * time.Millisecond,
+		OnProgress: func(evt ProgressEvent) {
+			if evt.Type == EventError && errors.Is(evt.Error, ErrModelDeadline) {
+				deadlineErrs = append(deadlineErrs, evt)
+			}
+		},
+	})
+	if err != nil {
+		t.Fatalf("BuildFromModelIDs: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Fatalf("took %s, want the slow model cut at its deadline", elapsed)
+	}
+	if len(deadlineErrs) != 1 || deadlineErrs[0].ModelID != "org/slow" {
+		t.Fatalf("deadline errors = %+v, want one for org/slow", deadlineErrs)
+	}
+	// The slow model is built from what was fetched; the next model gets a.
+	// deadline of its own.
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2", len(results))
+	}
+}
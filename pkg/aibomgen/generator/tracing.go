@@ -0,0 +1,156 @@
+package generator
+
+import (
+	"github.com/idlab-discover/aibomgen-cli/internal/builder"
+	"github.com/idlab-discover/aibomgen-cli/internal/fetcher"
+	"github.com/idlab-discover/aibomgen-cli/internal/tracing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Span attribute keys.
+const (
+	attrModelID = attribute.Key("aibomgen.model.id")
+	attrRepoID  = attribute.Key("aibomgen.repo.id")
+)
+
+// tracedFetch records every fetch as a span of the run's scope.
+type tracedFetch[T any] struct {
+	scope *tracing.Scope
+	name  string
+	fetch func(string) (T, error)
+}
+
+func (f tracedFetch[T]) Fetch(id string) (T, error) {
+	end := f.scope.Start(f.name, attrRepoID.String(id))
+	v, err := f.fetch(id)
+	end(err)
+	return v, err
+}
+
+// tracedLicenseFetch is tracedFetch for the two-argument license fetcher.
+type tracedLicenseFetch struct {
+	scope *tracing.Scope
+	fetch func(string, string) (*fetcher.LicenseFile, error)
+}
+
+func (f tracedLicenseFetch) Fetch(id, path string) (*fetcher.LicenseFile, error) {
+	end := f.scope.Start("fetch license file", attrRepoID.String(id), attribute.String("aibomgen.file.path", path))
+	v, err := f.fetch(id, path)
+	end(err)
+	return v, err
+}
+
+// traceFetchers wraps every fetcher of fs in a span of scope. Unset fetchers.
+// stay unset.
+func traceFetchers(fs fetcherSet, scope *tracing.Scope) fetcherSet {
+	out := fs
+	out.scope, out.untraced = scope, &fs
+	if fs.modelAPI != nil {
+		out.modelAPI = tracedFetch[*fetcher.ModelAPIResponse]{scope, "fetch model API", fs.modelAPI.Fetch}
+	}
+	if fs.modelReadme != nil {
+		out.modelReadme = tracedFetch[*fetcher.ModelReadmeCard]{scope, "fetch model README", fs.modelReadme.Fetch}
+	}
+	if fs.datasetAPI != nil {
+		out.datasetAPI = tracedFetch[*fetcher.DatasetAPIResponse]{scope, "fetch dataset API", fs.datasetAPI.Fetch}
+	}
+	if fs.datasetReadme != nil {
+		out.datasetReadme = tracedFetch[*fetcher.DatasetReadmeCard]{scope, "fetch dataset README", fs.datasetReadme.Fetch}
+	}
+	if fs.modelTree != nil {
+		out.modelTree = tracedFetch[[]fetcher.SecurityFileEntry]{scope, "fetch security tree", fs.modelTree.Fetch}
+	}
+	if fs.modelIndex != nil {
+		out.modelIndex = tracedFetch[*fetcher.ModelIndex]{scope, "fetch pipeline index", fs.modelIndex.Fetch}
+	}
+	if fs.modelConfig != nil {
+		out.modelConfig = tracedFetch[*fetcher.ModelConfig]{scope, "fetch model config", fs.modelConfig.Fetch}
+	}
+	if fs.licenseFile != nil {
+		out.licenseFile = tracedLicenseFetch{scope, fs.licenseFile.Fetch}
+	}
+	return out
+}
+
+// tracedKaggleFetch is tracedFetch for the Kaggle fetcher.
+type tracedKaggleFetch struct {
+	kaggleFetcher
+	scope *tracing.Scope
+}
+
+func (f tracedKaggleFetch) FetchModel(handle string) (*fetcher.KaggleModel, error) {
+	return tracedFetch[*fetcher.KaggleModel]{f.scope, "fetch Kaggle model", f.kaggleFetcher.FetchModel}.Fetch(handle)
+}
+
+func (f tracedKaggleFetch) FetchDataset(handle string) (*fetcher.KaggleDataset, error) {
+	return tracedFetch[*fetcher.KaggleDataset]{f.scope, "fetch Kaggle dataset", f.kaggleFetcher.FetchDataset}.Fetch(handle)
+}
+
+// tracedOllamaFetch is tracedFetch for the Ollama fetcher.
+type tracedOllamaFetch struct {
+	ollamaFetcher
+	scope *tracing.Scope
+}
+
+func (f tracedOllamaFetch) Fetch(namespace, model, tag string) (*fetcher.OllamaModel, error) {
+	end := f.scope.Start("fetch Ollama manifest", attrRepoID.String(namespace+"/"+model+":"+tag))
+	m, err := f.ollamaFetcher.Fetch(namespace, model, tag)
+	end(err)
+	return m, err
+}
+
+// tracedBuilder records every model build as a span of the run's scope.
+type tracedBuilder struct {
+	bomBuilder
+	scope *tracing.Scope
+}
+
+func (b tracedBuilder) Build(ctx builder.BuildContext) (*cdx.BOM, error) {
+	end := b.scope.Start("build model", attrModelID.String(ctx.ModelID), attribute.String("aibomgen.discovery.type", ctx.Scan.Type))
+	bom, err := b.bomBuilder.Build(ctx)
+	end(err)
+	return bom, err
+}
+
+func (b tracedBuilder) BuildDataset(ctx builder.DatasetBuildContext) (*cdx.Component, error) {
+	end := b.scope.Start("build dataset", attrRepoID.String(ctx.DatasetID))
+	comp, err := b.bomBuilder.BuildDataset(ctx)
+	end(err)
+	return comp, err
+}
+
+// datasetScope starts the span of one dataset of a model and returns fs and.
+// b recording their spans, and the requests of the dataset fetchers, under.
+// it, with the function that ends it. The datasets of a model are fetched.
+// side by side, so each gets a scope of its own rather than the model's.
+// Untraced fetchers and builders are returned as they are.
+func datasetScope(fs fetcherSet, b bomBuilder, datasetID string) (fetcherSet, bomBuilder, func(error)) {
+	if fs.scope == nil {
+		return fs, b, func(error) {}
+	}
+	scope := tracing.NewScope(fs.scope.Context())
+	end := scope.Start("generate dataset", attrRepoID.String(datasetID))
+	raw := *fs.untraced
+	if f, ok := raw.datasetAPI.(*fetcher.DatasetAPIFetcher); ok && f.Client != nil {
+		cp := *f
+		cp.Client = scope.Client(f.Client)
+		raw.datasetAPI = &cp
+	}
+	if f, ok := raw.datasetReadme.(*fetcher.DatasetReadmeFetcher); ok && f.Client != nil {
+		cp := *f
+		cp.Client = scope.Client(f.Client)
+		raw.datasetReadme = &cp
+	}
+	if tb, ok := b.(tracedBuilder); ok {
+		b = tracedBuilder{tb.bomBuilder, scope}
+	}
+	return traceFetchers(raw, scope), b, end
+}
+
+// startModel starts the span of one model of a run; the returned function.
+// ends it.
+func startModel(scope *tracing.Scope, modelID, discoveryType string) func(error) {
+	return scope.Start("generate model", attrModelID.String(modelID), attribute.String("aibomgen.discovery.type", discoveryType))
+}
@@ -0,0 +1,70 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/idlab-discover/aibomgen-cli/internal/fetcher"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/scanner"
+)
+
+// newProbeClient returns the HTTP client for HEAD requests to weight URLs.
+// It deliberately carries no Hugging Face token: weight URLs point at.
+// arbitrary hosts. It reuses the connection pool of the fetchers.
+var newProbeClient = func(timeout time.Duration) *http.Client {
+	return &http.Client{Timeout: timeout, Transport: fetcher.SharedTransport()}
+}
+
+// probeWeightURL sends a HEAD request for a "weight-url" discovery and.
+// records the Content-Length as Size and the ETag. s3:// and gs:// URLs are.
+// probed through their public HTTPS endpoints; other object storage schemes.
+// cannot be probed anonymously and are left unchanged. The request ends.
+// when ctx is done.
+func probeWeightURL(ctx context.Context, client *http.Client, d *scanner.Discovery) error {
+	url, ok := weightURLEndpoint(d.ID)
+	if !ok {
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("HEAD %s: status %d", url, resp.StatusCode)
+	}
+	if n, err := strconv.ParseInt(resp.Header.Get("Content-Length"), 10, 64); err == nil && n > 0 {
+		d.Size = n
+	}
+	d.ETag = strings.Trim(strings.TrimPrefix(resp.Header.Get("ETag"), "W/"), `"`)
+	return nil
+}
+
+// weightURLEndpoint maps a weight URL to the HTTPS URL a HEAD request is.
+// sent to.
+func weightURLEndpoint(url string) (string, bool) {
+	scheme, rest, ok := strings.Cut(url, "://")
+	if !ok {
+		return "", false
+	}
+	switch scheme {
+	case "http", "https":
+		return url, true
+	case "s3":
+		bucket, key, _ := strings.Cut(rest, "/")
+		return "https://" + bucket + ".s3.amazonaws.com/" + key, true
+	case "gs":
+		return "https://storage.googleapis.com/" + rest, true
+	}
+	return "", false
+}
@@ -0,0 +1,14 @@
+// Package migrator upgrades AIBOMs written by earlier versions of AIBoMGen to.
+// the current conventions, so that validate and completeness scores of old.
+// and new BOMs can be compared.
+//.
+// A migration renames legacy property names to their current name in the.
+// property taxonomy (see internal/taxonomy), moves the "lastModified:" tags.
+// older versions wrote for datasets into the lastModified property, adds.
+// missing bom-refs and records the current taxonomy version. Unknown.
+// properties and all other fields are left untouched, and migrating a BOM.
+// that already follows the current conventions changes nothing.
+//.
+// [Migrate] is the primary entry point. It updates the BOM in place and.
+// returns a [Result] describing the changes.
+package migrator
@@ -0,0 +1,136 @@
+package migrator
+
+import (
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/internal/builder"
+	"github.com/idlab-discover/aibomgen-cli/internal/taxonomy"
+)
+
+// Result describes the changes made by Migrate.
+type Result struct {
+	// FromVersion is the taxonomy version the BOM recorded before the.
+	// migration, or "" when it predates the taxonomy.
+	FromVersion string
+	// ToVersion is the taxonomy version the BOM follows after the migration.
+	ToVersion string
+	// RenamedProperties is the number of legacy property names replaced.
+	RenamedProperties int
+	// DuplicatesRemoved is the number of properties dropped because the.
+	// same name and value was already present after renaming.
+	DuplicatesRemoved int
+	// MovedTags is the number of "lastModified:" tags turned into properties.
+	MovedTags int
+	// AddedBOMRefs is the number of components that received a bom-ref.
+	AddedBOMRefs int
+}
+
+// Changed reports whether the migration modified the BOM.
+func (r Result) Changed() bool {
+	return r.FromVersion != r.ToVersion || r.RenamedProperties > 0 || r.DuplicatesRemoved > 0 || r.MovedTags > 0 || r.AddedBOMRefs > 0
+}
+
+// Migrate upgrades bom in place to the current AIBoMGen conventions.
+func Migrate(bom *cdx.BOM) Result {
+	res := Result{ToVersion: taxonomy.Version}
+	if bom == nil {
+		return res
+	}
+	if bom.Metadata == nil {
+		bom.Metadata = &cdx.Metadata{}
+	}
+
+	res.FromVersion, _ = taxonomy.Get(bom.Metadata.Properties, taxonomy.TaxonomyVersion)
+	bom.Metadata.Properties = migrateProperties(bom.Metadata.Properties, &res)
+
+	if bom.Metadata.Component != nil {
+		migrateComponent(bom.Metadata.Component, &res)
+	}
+	if bom.Components != nil {
+		for i := range *bom.Components {
+			migrateComponent(&(*bom.Components)[i], &res)
+		}
+	}
+
+	props := taxonomy.Remove(bom.Metadata.Properties, taxonomy.TaxonomyVersion)
+	bom.Metadata.Properties = taxonomy.Set(props, taxonomy.TaxonomyVersion, taxonomy.Version)
+	return res
+}
+
+// migrateComponent migrates c and its nested components.
+func migrateComponent(c *cdx.Component, res *Result) {
+	if c.BOMRef == "" {
+		builder.AddComponentBOMRef(c)
+		res.AddedBOMRefs++
+	}
+
+	c.Properties = migrateProperties(c.Properties, res)
+	moveLastModifiedTags(c, res)
+
+	if c.Licenses != nil {
+		for i := range *c.Licenses {
+			if lic := (*c.Licenses)[i].License; lic != nil {
+				lic.Properties = migrateProperties(lic.Properties, res)
+			}
+		}
+	}
+
+	if c.Components != nil {
+		for i := range *c.Components {
+			migrateComponent(&(*c.Components)[i], res)
+		}
+	}
+}
+
+// migrateProperties renames legacy property names and drops the exact.
+// duplicates this creates. It returns nil when no properties remain.
+func migrateProperties(props *[]cdx.Property, res *Result) *[]cdx.Property {
+	if props == nil {
+		return nil
+	}
+	seen := make(map[cdx.Property]bool, len(*props))
+	kept := make([]cdx.Property, 0, len(*props))
+	for _, p := range *props {
+		if current, ok := taxonomy.Canonical(p.Name); ok && current != p.Name {
+			p.Name = current
+			res.RenamedProperties++
+		}
+		if seen[p] {
+			res.DuplicatesRemoved++
+			continue
+		}
+		seen[p] = true
+		kept = append(kept, p)
+	}
+	if len(kept) == 0 {
+		return nil
+	}
+	*props = kept
+	return props
+}
+
+// moveLastModifiedTags turns "lastModified:<time>" tags into the.
+// lastModified property. An existing property wins over the tag.
+func moveLastModifiedTags(c *cdx.Component, res *Result) {
+	if c.Tags == nil {
+		return
+	}
+	var tags []string
+	for _, tag := range *c.Tags {
+		value, ok := strings.CutPrefix(tag, taxonomy.LegacyLastModifiedTagPrefix)
+		if !ok {
+			tags = append(tags, tag)
+			continue
+		}
+		if !taxonomy.Has(c.Properties, taxonomy.HFLastModified) {
+			c.Properties = taxonomy.Set(c.Properties, taxonomy.HFLastModified, value)
+		}
+		res.MovedTags++
+	}
+	if len(tags) == 0 {
+		c.Tags = nil
+	} else {
+		c.Tags = &tags
+	}
+}
@@ -0,0 +1,112 @@
+package migrator
+
+import (
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/internal/taxonomy"
+)
+
+func legacyBOM() *cdx.BOM {
+	tags := []string{"nlp", "lastModified:2024-01-01"}
+	return &cdx.BOM{
+		Metadata: &cdx.Metadata{
+			Component: &cdx.Component{
+				Type:       cdx.ComponentTypeMachineLearningModel,
+				Name:       "org/model",
+				PackageURL: "pkg:huggingface/org/model@abc",
+				Properties: &[]cdx.Property{
+					{Name: "aibomgen.type", Value: "huggingface"},
+					{Name: "huggingface:lastModified", Value: "2024-02-02"},
+					{Name: "aibomgen:huggingface:lastModified", Value: "2024-02-02"},
+					{Name: "vendor:custom", Value: "kept"},
+				},
+				Licenses: &cdx.Licenses{{License: &cdx.License{
+					ID:         "MIT",
+					Properties: &[]cdx.Property{{Name: "huggingface:licenseFile", Value: "LICENSE"}},
+				}}},
+			},
+		},
+		Components: &[]cdx.Component{{
+			Type:       cdx.ComponentTypeData,
+			Name:       "org/dataset",
+			Tags:       &tags,
+			Properties: &[]cdx.Property{{Name: "huggingface:createdAt", Value: "2023-01-01"}},
+		}},
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	bom := legacyBOM()
+	res := Migrate(bom)
+
+	if res.FromVersion != "" || res.ToVersion != taxonomy.Version {
+		t.Errorf("versions = %q -> %q", res.FromVersion, res.ToVersion)
+	}
+	if res.RenamedProperties != 4 || res.DuplicatesRemoved != 1 || res.MovedTags != 1 || res.AddedBOMRefs != 2 {
+		t.Errorf("unexpected result: %+v", res)
+	}
+	if got, _ := taxonomy.Get(bom.Metadata.Properties, taxonomy.TaxonomyVersion); got != taxonomy.Version {
+		t.Errorf("taxonomy version = %q", got)
+	}
+
+	model := bom.Metadata.Component
+	want := []cdx.Property{
+		{Name: taxonomy.DiscoveryType, Value: "huggingface"},
+		{Name: taxonomy.HFLastModified, Value: "2024-02-02"},
+		{Name: "vendor:custom", Value: "kept"},
+	}
+	if len(*model.Properties) != len(want) {
+		t.Fatalf("model properties = %+v", *model.Properties)
+	}
+	for i, p := range want {
+		if (*model.Properties)[i] != p {
+			t.Errorf("property %d = %+v, want %+v", i, (*model.Properties)[i], p)
+		}
+	}
+	if model.BOMRef != model.PackageURL {
+		t.Errorf("model bom-ref = %q", model.BOMRef)
+	}
+	if lic := (*model.Licenses)[0].License; (*lic.Properties)[0].Name != taxonomy.LicenseFile {
+		t.Errorf("license property = %+v", *lic.Properties)
+	}
+
+	ds := (*bom.Components)[0]
+	if ds.BOMRef == "" {
+		t.Errorf("dataset bom-ref missing")
+	}
+	if ds.Tags == nil || len(*ds.Tags) != 1 || (*ds.Tags)[0] != "nlp" {
+		t.Errorf("dataset tags = %+v", ds.Tags)
+	}
+	if got, _ := taxonomy.Get(ds.Properties, taxonomy.HFLastModified); got != "2024-01-01" {
+		t.Errorf("dataset lastModified = %q", got)
+	}
+	if (*ds.Properties)[0].Name != taxonomy.HFCreatedAt {
+		t.Errorf("dataset properties = %+v", *ds.Properties)
+	}
+}
+
+func TestMigrateIsIdempotent(t *testing.T) {
+	bom := legacyBOM()
+	Migrate(bom)
+	res := Migrate(bom)
+	if res.Changed() || res.FromVersion != taxonomy.Version {
+		t.Errorf("second migration changed the BOM: %+v", res)
+	}
+	if n := len(*bom.Metadata.Properties); n != 1 {
+		t.Errorf("metadata properties = %+v", *bom.Metadata.Properties)
+	}
+}
+
+func TestMigrateEmpty(t *testing.T) {
+	Migrate(nil)
+
+	bom := &cdx.BOM{}
+	res := Migrate(bom)
+	if res.FromVersion != "" || !res.Changed() {
+		t.Errorf("unexpected result for empty BOM: %+v", res)
+	}
+	if got, _ := taxonomy.Get(bom.Metadata.Properties, taxonomy.TaxonomyVersion); got != taxonomy.Version {
+		t.Errorf("taxonomy version = %q", got)
+	}
+}
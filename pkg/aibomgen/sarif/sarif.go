@@ -0,0 +1,360 @@
+// Package sarif writes the discoveries of a scan as a SARIF 2.1.0 log, the.
+// format security tooling ingests, so model references show up as.
+// code-scanning annotations in GitHub and GitLab.
+//.
+// Every discovery becomes one result of the rule for its type (e.g.
+// "aibomgen/model" for a Hugging Face reference). Its location is the file.
+// it was found in, relative to [Options] BaseDir, and the line named in its.
+// evidence ("from_pretrained at line 12: ..."); results without a line, such.
+// as weight files, point at the whole file. Results are notes: they list.
+// the AI inventory rather than vulnerabilities. Templated references, which.
+// cannot be resolved to a model, are warnings.
+package sarif
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/idlab-discover/aibomgen-cli/internal/fsutil"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/scanner"
+)
+
+// Version is the SARIF version of the logs written by this package.
+const Version = "2.1.0"
+
+// SchemaURI is the JSON schema of SARIF 2.1.0.
+const SchemaURI = "https://json.schemastore.org/sarif-2.1.0.json"
+
+// SrcRoot is the uriBaseId result locations are relative to.
+const SrcRoot = "%SRCROOT%"
+
+// Result levels.
+const (
+	LevelNote    = "note"
+	LevelWarning = "warning"
+)
+
+// Log is a SARIF log.
+type Log struct {
+	Schema  string `json:"$schema"`
+	Version string `json:"version"`
+	Runs    []Run  `json:"runs"`
+}
+
+// Run is the output of one run of a tool.
+type Run struct {
+	Tool               Tool                        `json:"tool"`
+	OriginalURIBaseIDs map[string]ArtifactLocation `json:"originalUriBaseIds,omitempty"`
+	Results            []Result                    `json:"results"`
+}
+
+// Tool describes the analysis tool.
+type Tool struct {
+	Driver Driver `json:"driver"`
+}
+
+// Driver is the tool component that produced the results.
+type Driver struct {
+	Name           string `json:"name"`
+	Version        string `json:"version,omitempty"`
+	InformationURI string `json:"informationUri,omitempty"`
+	Rules          []Rule `json:"rules"`
+}
+
+// Rule describes a kind of result.
+type Rule struct {
+	ID                   string         `json:"id"`
+	Name                 string         `json:"name"`
+	ShortDescription     Message        `json:"shortDescription"`
+	FullDescription      Message        `json:"fullDescription"`
+	DefaultConfiguration Configuration  `json:"defaultConfiguration"`
+	Properties           map[string]any `json:"properties,omitempty"`
+}
+
+// Configuration is the default configuration of a rule.
+type Configuration struct {
+	Level string `json:"level"`
+}
+
+// Message is a plain-text message.
+type Message struct {
+	Text string `json:"text"`
+}
+
+// Result is one discovery.
+type Result struct {
+	RuleID              string            `json:"ruleId"`
+	RuleIndex           int               `json:"ruleIndex"`
+	Level               string            `json:"level"`
+	Message             Message           `json:"message"`
+	Locations           []Location        `json:"locations,omitempty"`
+	PartialFingerprints map[string]string `json:"partialFingerprints,omitempty"`
+	Properties          map[string]any    `json:"properties,omitempty"`
+}
+
+// Location is where a result was found.
+type Location struct {
+	PhysicalLocation PhysicalLocation `json:"physicalLocation"`
+}
+
+// PhysicalLocation is a file and, when known, a region of it.
+type PhysicalLocation struct {
+	ArtifactLocation ArtifactLocation `json:"artifactLocation"`
+	Region           *Region          `json:"region,omitempty"`
+}
+
+// ArtifactLocation is the URI of a file, relative to URIBaseID when set.
+type ArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+// Region is a line of a file.
+type Region struct {
+	StartLine int `json:"startLine"`
+}
+
+// Options configures Build.
+type Options struct {
+	// ToolVersion is the version of AIBoMGen recorded as the driver version.
+	ToolVersion string
+	// BaseDir is the directory file locations are made relative to, usually.
+	// the root of the repository. Files outside it are given as absolute.
+	// file URIs.
+	BaseDir string
+}
+
+// rule describes the rule of a discovery type.
+type rule struct {
+	name, short, full string
+	level             string
+}
+
+// rules lists the rule of each discovery type. Build only includes the rules.
+// of the types it reports.
+var rules = []struct {
+	typ string
+	rule
+}{
+	{"model", rule{"ModelReference", "Model or dataset reference", "A Hugging Face model or dataset is referenced in code or configuration. It belongs in the AIBOM of the project.", LevelNote}},
+	{scanner.DiscoveryTypeModelFile, rule{"ModelFile", "Model weight file", "A model weight file (or a DVC / Git LFS pointer to one, or a model of a serving bundle) is part of the project.", LevelNote}},
+	{scanner.DiscoveryTypeDataFile, rule{"DataFile", "Dataset file", "A DVC or Git LFS pointer tracks a dataset artifact.", LevelNote}},
+	{scanner.DiscoveryTypeTrackedArtifact, rule{"TrackedArtifact", "Tracked model or dataset artifact", "A model or dataset is registered with an ML experiment tracker (a DVC artifact in dvc.yaml or an MLflow model).", LevelNote}},
+	{scanner.DiscoveryTypeWeightURL, rule{"WeightURL", "Model weight URL", "Model weights are downloaded from an external URL (S3, GCS, Azure or HTTP(S)).", LevelNote}},
+	{scanner.DiscoveryTypeProviderModel, rule{"ProviderModel", "Provider-hosted model", "A model hosted by a provider other than Hugging Face (NVIDIA NGC, Meta, Ollama) is referenced.", LevelNote}},
+	{scanner.DiscoveryTypeHostedModel, rule{"HostedModel", "Hosted model API", "A model is used through a hosted inference API (OpenAI, Anthropic, Azure OpenAI, Amazon Bedrock). It is recorded as a third-party service of the project.", LevelNote}},
+	{scanner.DiscoveryTypeKaggle, rule{"KaggleResource", "Kaggle model or dataset", "A Kaggle Models variation or Kaggle dataset is referenced.", LevelNote}},
+	{scanner.DiscoveryTypeAdapter, rule{"Adapter", "PEFT adapter", "A PEFT adapter configuration names the base model it was trained on.", LevelNote}},
+	{scanner.DiscoveryTypeParameterized, rule{"ParameterizedReference", "Templated model reference", "A model ID is built at runtime (f-string or template literal) and cannot be resolved to a model, so it is missing from the AIBOM.", LevelWarning}},
+}
+
+// otherRule is used for discovery types without a rule of their own.
+var otherRule = rule{"OtherReference", "AI artifact reference", "An AI model or dataset artifact is referenced.", LevelNote}
+
+// lineRe extracts the line number from discovery evidence. The evidence of a.
+// reference matched several times starts with the first match.
+var lineRe = regexp.MustCompile(` at line (\d+)`)
+
+// Build returns the SARIF log of discoveries.
+func Build(discoveries []scanner.Discovery, opts Options) Log {
+	driver := Driver{
+		Name:           "AIBoMGen",
+		Version:        opts.ToolVersion,
+		InformationURI: "https://github.com/idlab-discover/aibomgen-cli",
+		Rules:          []Rule{},
+	}
+	index := make(map[string]int)
+	ruleFor := func(typ string) (string, int, string) {
+		r := otherRule
+		id := "aibomgen/other"
+		for _, known := range rules {
+			if known.typ == typ {
+				r, id = known.rule, "aibomgen/"+typ
+				break
+			}
+		}
+		if i, ok := index[id]; ok {
+			return id, i, r.level
+		}
+		index[id] = len(driver.Rules)
+		driver.Rules = append(driver.Rules, Rule{
+			ID:                   id,
+			Name:                 r.name,
+			ShortDescription:     Message{Text: r.short},
+			FullDescription:      Message{Text: r.full},
+			DefaultConfiguration: Configuration{Level: r.level},
+			Properties:           map[string]any{"tags": []string{"ai-inventory", "aibom"}},
+		})
+		return id, index[id], r.level
+	}
+
+	base := ""
+	if opts.BaseDir != "" {
+		if abs, err := filepath.Abs(opts.BaseDir); err == nil {
+			base = fsutil.Normalize(abs)
+		}
+	}
+
+	results := make([]Result, 0, len(discoveries))
+	for _, d := range discoveries {
+		id, idx, level := ruleFor(d.Type)
+		res := Result{
+			RuleID:    id,
+			RuleIndex: idx,
+			Level:     level,
+			Message:   Message{Text: message(d)},
+		}
+		loc, rel := location(d, base)
+		if loc != nil {
+			res.Locations = []Location{*loc}
+		}
+		sum := sha256.Sum256([]byte(d.Type + "\x00" + d.ID + "\x00" + rel))
+		res.PartialFingerprints = map[string]string{"aibomgenDiscovery/v1": hex.EncodeToString(sum[:])}
+		res.Properties = properties(d)
+		results = append(results, res)
+	}
+
+	run := Run{Tool: Tool{Driver: driver}, Results: results}
+	if base != "" {
+		run.OriginalURIBaseIDs = map[string]ArtifactLocation{SrcRoot: {URI: fileURI(base + "/")}}
+	}
+	return Log{Schema: SchemaURI, Version: Version, Runs: []Run{run}}
+}
+
+// Write encodes l as indented JSON.
+func (l Log) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(l); err != nil {
+		return fmt.Errorf("encode SARIF log: %w", err)
+	}
+	return nil
+}
+
+func message(d scanner.Discovery) string {
+	var msg string
+	switch d.Type {
+	case "model":
+		msg = "Model reference " + d.ID
+	case scanner.DiscoveryTypeModelFile:
+		msg = "Model file " + d.ID
+	case scanner.DiscoveryTypeDataFile:
+		msg = "Dataset file " + d.ID
+	case scanner.DiscoveryTypeTrackedArtifact:
+		msg = "Tracked " + d.ArtifactType + " artifact " + d.Name + " at " + d.ID
+	case scanner.DiscoveryTypeWeightURL:
+		msg = "Model weights downloaded from " + d.ID
+	case scanner.DiscoveryTypeProviderModel:
+		msg = "Provider-hosted model " + d.ID
+	case scanner.DiscoveryTypeHostedModel:
+		msg = "Hosted model API " + d.ID
+	case scanner.DiscoveryTypeKaggle:
+		msg = "Kaggle resource " + d.ID
+	case scanner.DiscoveryTypeAdapter:
+		msg = "PEFT adapter " + d.ID
+		if d.BaseModel != "" {
+			msg += " of base model " + d.BaseModel
+		}
+	case scanner.DiscoveryTypeParameterized:
+		msg = "Templated model reference " + d.ID + " cannot be resolved to a model"
+	default:
+		msg = "Reference " + d.ID
+	}
+	if d.Method != "" {
+		msg += " (" + d.Method + ")"
+	}
+	if d.Confidence == scanner.ConfidenceLow {
+		msg += ", found only in a comment or test"
+	}
+	if h := d.History; h != nil && h.RemovedCommit != "" {
+		msg += fmt.Sprintf(", removed in commit %s", shortSHA(h.RemovedCommit))
+	}
+	return msg + "."
+}
+
+// location returns the location of d and its path relative to base (the.
+// absolute path when it is outside base). d has no location without a path.
+func location(d scanner.Discovery, base string) (*Location, string) {
+	if d.Path == "" {
+		return nil, ""
+	}
+	p := fsutil.Normalize(d.Path)
+	art := ArtifactLocation{URI: fileURI(p)}
+	rel := p
+	if base != "" {
+		if r, ok := strings.CutPrefix(p, base+"/"); ok {
+			rel = r
+			art = ArtifactLocation{URI: relURI(r), URIBaseID: SrcRoot}
+		}
+	}
+	loc := &Location{PhysicalLocation: PhysicalLocation{ArtifactLocation: art}}
+	if m := lineRe.FindStringSubmatch(d.Evidence); m != nil {
+		if n, err := strconv.Atoi(m[1]); err == nil && n > 0 {
+			loc.PhysicalLocation.Region = &Region{StartLine: n}
+		}
+	}
+	return loc, rel
+}
+
+// properties returns the discovery fields not covered by the result.
+func properties(d scanner.Discovery) map[string]any {
+	props := map[string]any{"id": d.ID, "type": d.Type}
+	set := func(k, v string) {
+		if v != "" {
+			props[k] = v
+		}
+	}
+	set("method", d.Method)
+	set("confidence", d.Confidence)
+	set("format", d.Format)
+	set("hash", d.Hash)
+	set("baseModel", d.BaseModel)
+	set("version", d.Version)
+	if d.Size > 0 {
+		props["size"] = d.Size
+	}
+	if h := d.History; h != nil {
+		set("introducedCommit", h.IntroducedCommit)
+		set("introducedAt", h.IntroducedAt.UTC().Format(time.RFC3339))
+		if h.RemovedCommit != "" {
+			set("removedCommit", h.RemovedCommit)
+			set("removedAt", h.RemovedAt.UTC().Format(time.RFC3339))
+		}
+	}
+	return props
+}
+
+// fileURI returns the file URI of an absolute slash-separated path.
+func fileURI(p string) string {
+	if !strings.HasPrefix(p, "/") {
+		// Windows drive paths (C:/...).
+		p = "/" + p
+	}
+	return (&url.URL{Scheme: "file", Path: p}).String()
+}
+
+// relURI escapes a relative slash-separated path as a URI reference.
+func relURI(p string) string {
+	parts := strings.Split(path.Clean(p), "/")
+	for i, part := range parts {
+		parts[i] = url.PathEscape(part)
+	}
+	return strings.Join(parts, "/")
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 12 {
+		return sha[:12]
+	}
+	return sha
+}
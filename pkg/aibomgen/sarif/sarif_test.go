@@ -0,0 +1,86 @@
+package sarif
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/scanner"
+)
+
+func TestBuild(t *testing.T) {
+	removedAt := time.Date(2024, 5, 2, 8, 0, 0, 0, time.UTC)
+	discoveries := []scanner.Discovery{
+		{ID: "google-bert/bert-base-uncased", Type: "model", Method: "from_pretrained", Path: "/repo/app/train.py", Evidence: "from_pretrained at line 12: AutoModel.from_pretrained(\"google-bert/bert-base-uncased\"). pipeline at line 30: pipeline(model=\"google-bert/bert-base-uncased\")"},
+		{ID: "org/{size}", Type: scanner.DiscoveryTypeParameterized, Method: "from_pretrained", Path: "/repo/app/my model.py", Evidence: "from_pretrained at line 3: x"},
+		{ID: "model.safetensors", Type: scanner.DiscoveryTypeModelFile, Path: "/repo/weights/model.safetensors", Format: "safetensors", Size: 42},
+		{ID: "gpt2", Type: "model", Path: "/elsewhere/venv/x.py", Evidence: "from_pretrained at line 1: x", Confidence: scanner.ConfidenceLow,
+			History: &scanner.GitHistory{IntroducedCommit: "aaaa", IntroducedAt: removedAt.Add(-time.Hour), RemovedCommit: "0123456789abcdef", RemovedAt: removedAt}},
+	}
+
+	log := Build(discoveries, Options{ToolVersion: "v1.0.0", BaseDir: "/repo"})
+	if log.Version != Version || len(log.Runs) != 1 {
+		t.Fatalf("log = %+v", log)
+	}
+	run := log.Runs[0]
+	if run.OriginalURIBaseIDs[SrcRoot].URI != "file:///repo/" {
+		t.Errorf("base = %+v", run.OriginalURIBaseIDs)
+	}
+	var ruleIDs []string
+	for _, r := range run.Tool.Driver.Rules {
+		ruleIDs = append(ruleIDs, r.ID)
+	}
+	if strings.Join(ruleIDs, ",") != "aibomgen/model,aibomgen/parameterized,aibomgen/model-file" {
+		t.Errorf("rules = %v", ruleIDs)
+	}
+	if len(run.Results) != 4 {
+		t.Fatalf("results = %d", len(run.Results))
+	}
+
+	bert := run.Results[0]
+	loc := bert.Locations[0].PhysicalLocation
+	if bert.RuleIndex != 0 || bert.Level != LevelNote || loc.ArtifactLocation.URI != "app/train.py" || loc.ArtifactLocation.URIBaseID != SrcRoot || loc.Region == nil || loc.Region.StartLine != 12 {
+		t.Errorf("bert = %+v, location = %+v", bert, loc)
+	}
+	templated := run.Results[1]
+	if templated.Level != LevelWarning || templated.RuleIndex != 1 || templated.Locations[0].PhysicalLocation.ArtifactLocation.URI != "app/my%20model.py" {
+		t.Errorf("templated = %+v", templated)
+	}
+	file := run.Results[2]
+	if file.Locations[0].PhysicalLocation.Region != nil || file.Properties["size"] != int64(42) || file.Properties["format"] != "safetensors" {
+		t.Errorf("model file = %+v", file)
+	}
+	gone := run.Results[3]
+	if gone.Locations[0].PhysicalLocation.ArtifactLocation.URI != "file:///elsewhere/venv/x.py" || gone.Locations[0].PhysicalLocation.ArtifactLocation.URIBaseID != "" {
+		t.Errorf("outside location = %+v", gone.Locations[0])
+	}
+	if !strings.Contains(gone.Message.Text, "removed in commit 0123456789ab") || !strings.Contains(gone.Message.Text, "comment or test") || gone.Properties["removedAt"] != "2024-05-02T08:00:00Z" {
+		t.Errorf("removed = %+v", gone)
+	}
+	if bert.PartialFingerprints["aibomgenDiscovery/v1"] == "" || bert.PartialFingerprints["aibomgenDiscovery/v1"] == gone.PartialFingerprints["aibomgenDiscovery/v1"] {
+		t.Errorf("fingerprints = %v, %v", bert.PartialFingerprints, gone.PartialFingerprints)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Build(nil, Options{}).Write(&buf); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if doc["$schema"] != SchemaURI || doc["version"] != "2.1.0" {
+		t.Errorf("header = %v", doc)
+	}
+	run := doc["runs"].([]any)[0].(map[string]any)
+	if results, ok := run["results"].([]any); !ok || len(results) != 0 {
+		t.Errorf("results must be an empty list, got %v", run["results"])
+	}
+	if _, ok := run["originalUriBaseIds"]; ok {
+		t.Errorf("no base without BaseDir")
+	}
+}
@@ -0,0 +1,73 @@
+package scanner
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DiscoveryTypeAdapter is the Discovery.Type of PEFT adapters (LoRA, IA3,.
+// prefix tuning, ...) found through their adapter_config.json. ID is the.
+// adapter directory, Name its base name and BaseModel the model the adapter.
+// was trained on, when the config names a Hugging Face repository.
+const DiscoveryTypeAdapter = "adapter"
+
+// adapterConfigName is the file PEFT writes next to the adapter weights.
+const adapterConfigName = "adapter_config.json"
+
+// adapterConfig holds the adapter_config.json fields used for discovery.
+type adapterConfig struct {
+	BaseModelNameOrPath string `json:"base_model_name_or_path"`
+	BaseModel           string `json:"base_model"`
+	PeftType            string `json:"peft_type"`
+	TaskType            string `json:"task_type"`
+}
+
+// scanAdapterConfig scans an adapter_config.json with the JSON rules, which.
+// report the base model as a model of its own, and adds an adapter.
+// discovery linking the adapter directory to that base model.
+func scanAdapterConfig(path string) []Discovery {
+	results := scanLines(path, jsonRules, false)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return results
+	}
+	var cfg adapterConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return results
+	}
+
+	base := strings.TrimSpace(cfg.BaseModelNameOrPath)
+	if base == "" {
+		base = strings.TrimSpace(cfg.BaseModel)
+	}
+
+	evidence := adapterConfigName
+	if cfg.PeftType != "" {
+		evidence += ": peft_type " + cfg.PeftType
+	}
+	if cfg.TaskType != "" {
+		evidence += ", task_type " + cfg.TaskType
+	}
+	if base != "" {
+		evidence += ", base model " + base
+	}
+	// Local base model paths cannot be looked up on the Hub; they stay in.
+	// the evidence only.
+	if !isPlausibleModelID(base) || filepath.IsAbs(base) {
+		base = ""
+	}
+
+	dir := filepath.Dir(path)
+	return append(results, Discovery{
+		ID:        dir,
+		Name:      filepath.Base(dir),
+		Type:      DiscoveryTypeAdapter,
+		Path:      path,
+		Evidence:  evidence,
+		Method:    "peft_adapter_config",
+		BaseModel: base,
+	})
+}
@@ -0,0 +1,438 @@
+package scanner
+
+import (
+	"bytes"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	yaml "go.yaml.in/yaml/v3"
+)
+
+// ServingBundle is a model serving package a discovery belongs to: a.
+// BentoML service or a KServe InferenceService.
+type ServingBundle struct {
+	// Kind is BundleKindBentoML or BundleKindKServe.
+	Kind string `json:"kind"`
+	// Name is the bento or InferenceService name.
+	Name string `json:"name"`
+	// Path is the bento directory or the manifest declaring the.
+	// InferenceService.
+	Path string `json:"path"`
+}
+
+// Serving bundle kinds.
+const (
+	BundleKindBentoML = "bentoml"
+	BundleKindKServe  = "kserve"
+)
+
+// bentoModelFormat is the Discovery.Format of models in the BentoML model.
+// store. Such "model-file" discoveries have the model tag (name:version) as.
+// ID and no file of their own; Path is the file referencing them.
+const bentoModelFormat = "bentoml"
+
+// bentoModelMethod is the detection method of BentoML model store references.
+// in Python code.
+const bentoModelMethod = "bentoml_model"
+
+// bentoTagRe matches a BentoML model tag: a name with an optional version.
+var bentoTagRe = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*(?::[A-Za-z0-9_.-]+)?$`)
+
+// hfIDSlashRe matches a complete org/model Hugging Face ID.
+var hfIDSlashRe = regexp.MustCompile(`^` + hfIDSlashPat + `$`)
+
+var (
+	// bentoServiceRe matches the decorator of a BentoML service class, with.
+	// an optional name argument: @bentoml.service(name="summarizer").
+	bentoServiceRe = regexp.MustCompile(`^\s*@(?:bentoml\.)?service\b(?:.*\bname\s*=\s*` + q + `([^"']+)` + q + `)?`)
+
+	// bentoLegacyServiceRe matches a BentoML 1.0 service: bentoml.Service("name").
+	bentoLegacyServiceRe = regexp.MustCompile(`\bbentoml\.Service\(\s*(?:name\s*=\s*)?` + q + `([^"']+)` + q)
+
+	pyClassRe = regexp.MustCompile(`^\s*class\s+([A-Za-z_]\w*)`)
+)
+
+func init() {
+	// BentoML: bentoml.models.HuggingFaceModel("org/model").
+	codeRules = append(codeRules, detectionRule{
+		method:   "bentoml_huggingface_model",
+		pattern:  regexp.MustCompile(`\bHuggingFaceModel\(\s*` + q + `(` + hfIDPat + `)` + q),
+		groupIdx: 1,
+	})
+
+	// BentoML model store: bentoml.models.get("iris_clf:latest"),.
+	// BentoModel("iris_clf"), bentoml.sklearn.load_model("iris_clf:v1").
+	// Matches are turned into "model-file" discoveries by bentoStoreModels.
+	codeRules = append(codeRules, detectionRule{
+		method:   bentoModelMethod,
+		pattern:  regexp.MustCompile(`\b(?:BentoModel|bentoml\.models\.get|bentoml\.[a-z_]+\.(?:get|load_model))\(\s*` + q + `([A-Za-z0-9][A-Za-z0-9_.-]*(?::[A-Za-z0-9_.-]+)?)` + q),
+		groupIdx: 1,
+	})
+}
+
+// isBentofile reports whether name (lower case) is a BentoML build file.
+func isBentofile(name string) bool {
+	return name == "bentofile.yaml" || name == "bentofile.yml"
+}
+
+// bentofile holds the bentofile.yaml fields that name the bento.
+type bentofile struct {
+	Name    string `yaml:"name"`
+	Service string `yaml:"service"`
+}
+
+// scanBentofile scans a bentofile.yaml with the YAML rules and reports the.
+// models it packages. Every discovery belongs to the bento of the directory.
+func scanBentofile(path string) []Discovery {
+	results := scanServingConfig(path, yamlRules)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return results
+	}
+	var doc yaml.Node
+	if yaml.Unmarshal(data, &doc) != nil || len(doc.Content) == 0 {
+		return results
+	}
+	root := doc.Content[0]
+	var bf bentofile
+	if root.Decode(&bf) != nil {
+		return results
+	}
+
+	lines := strings.Split(string(data), "\n")
+	if models := mappingValue(root, "models"); models != nil && models.Kind == yaml.SequenceNode {
+		for _, item := range models.Content {
+			// An entry is a tag or a mapping with a tag and an alias.
+			tag := item
+			if item.Kind == yaml.MappingNode {
+				tag = mappingValue(item, "tag")
+			}
+			if tag == nil || tag.Kind != yaml.ScalarNode {
+				continue
+			}
+			if d, ok := bentoModelRef(strings.TrimSpace(tag.Value), "bentofile_model", lineEvidence(lines, tag.Line), path); ok {
+				results = append(results, d)
+			}
+		}
+	}
+
+	dir := filepath.Dir(path)
+	return withBundle(results, ServingBundle{Kind: BundleKindBentoML, Name: bentoName(bf, dir), Path: dir})
+}
+
+// bentoModelRef turns a model listed in a bentofile into a discovery: a.
+// Hugging Face ID (hf://org/model or org/model) or a model store tag.
+func bentoModelRef(ref, method, evidence, path string) (Discovery, bool) {
+	if id, ok := strings.CutPrefix(ref, "hf://"); ok {
+		id, _, _ = strings.Cut(id, "@")
+		ref, _, _ = strings.Cut(id, ":")
+	}
+	evidence = method + evidence
+	if hfIDSlashRe.MatchString(ref) {
+		if !isPlausibleModelID(ref) {
+			return Discovery{}, false
+		}
+		return Discovery{ID: ref, Name: ref, Type: "model", Path: path, Evidence: evidence, Method: method}, true
+	}
+	if !bentoTagRe.MatchString(ref) {
+		return Discovery{}, false
+	}
+	d := Discovery{ID: ref, Path: path, Evidence: evidence, Method: method}
+	toBentoStoreModel(&d)
+	return d, true
+}
+
+// bentoStoreModels turns the BentoML model store references found by the.
+// code rules into "model-file" discoveries.
+func bentoStoreModels(ds []Discovery) []Discovery {
+	for i := range ds {
+		if ds[i].Method == bentoModelMethod && ds[i].Type == "model" {
+			toBentoStoreModel(&ds[i])
+		}
+	}
+	return ds
+}
+
+// toBentoStoreModel makes d the discovery of the store model its ID tags.
+// A tag without a version means the latest one.
+func toBentoStoreModel(d *Discovery) {
+	name, version, _ := strings.Cut(d.ID, ":")
+	if version == "" {
+		version = "latest"
+	}
+	d.ID, d.Name, d.Type, d.Format = name+":"+version, name, DiscoveryTypeModelFile, bentoModelFormat
+	if version != "latest" {
+		d.Version = version
+	}
+}
+
+// bentoServiceBundle returns the bento a Python file belongs to when it.
+// defines a BentoML service. The bento is named by the bentofile next to it,.
+// or else after the service.
+func bentoServiceBundle(lines []string, path string) (ServingBundle, bool) {
+	name := bentoServiceName(lines)
+	if name == "" {
+		return ServingBundle{}, false
+	}
+	dir := filepath.Dir(path)
+	for _, file := range []string{"bentofile.yaml", "bentofile.yml"} {
+		data, err := os.ReadFile(filepath.Join(dir, file))
+		if err != nil {
+			continue
+		}
+		var bf bentofile
+		if yaml.Unmarshal(data, &bf) == nil {
+			name = bentoName(bf, dir)
+		}
+		break
+	}
+	return ServingBundle{Kind: BundleKindBentoML, Name: name, Path: dir}, true
+}
+
+// bentoServiceName returns the name of the last BentoML service defined in.
+// the Python source lines, or "" when there is none. A service composed of.
+// others is defined after them.
+func bentoServiceName(lines []string) string {
+	if !strings.Contains(strings.Join(lines, "\n"), "bentoml") {
+		return ""
+	}
+	var name string
+	decorated := false
+	for _, line := range lines {
+		if m := bentoLegacyServiceRe.FindStringSubmatch(line); m != nil {
+			name = m[1]
+			continue
+		}
+		if m := bentoServiceRe.FindStringSubmatch(line); m != nil {
+			decorated = true
+			if m[1] != "" {
+				name, decorated = m[1], false
+			}
+			continue
+		}
+		if m := pyClassRe.FindStringSubmatch(line); m != nil && decorated {
+			name, decorated = m[1], false
+		}
+	}
+	return name
+}
+
+// bentoName returns the name of the bento built from bf in dir: its name.
+// field, else the name of the service it points to, else the directory name.
+func bentoName(bf bentofile, dir string) string {
+	if name := strings.TrimSpace(bf.Name); name != "" {
+		return name
+	}
+	module, attr, _ := strings.Cut(strings.TrimSpace(bf.Service), ":")
+	if module != "" {
+		module = strings.ReplaceAll(strings.TrimSuffix(module, ".py"), ".", "/")
+		if lines, err := readLines(filepath.Join(dir, filepath.FromSlash(module)+".py")); err == nil {
+			if name := bentoServiceName(lines); name != "" {
+				return name
+			}
+		}
+	}
+	if attr != "" {
+		return attr
+	}
+	return filepath.Base(dir)
+}
+
+// scanKServe adds the models of the KServe InferenceServices declared in the.
+// YAML file at path to results, the discoveries of the YAML rules. Models.
+// named by storageUri or a --model_id argument belong to their.
+// InferenceService; when the file declares a single one, so do results.
+func scanKServe(path string, results []Discovery) []Discovery {
+	data, err := os.ReadFile(path)
+	if err != nil || !bytes.Contains(data, []byte("InferenceService")) {
+		return results
+	}
+	lines := strings.Split(string(data), "\n")
+
+	var services []ServingBundle
+	var models []Discovery
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var doc yaml.Node
+		if dec.Decode(&doc) != nil {
+			break
+		}
+		if len(doc.Content) == 0 {
+			continue
+		}
+		root := doc.Content[0]
+		if scalarValue(root, "kind") != "InferenceService" || !strings.HasPrefix(scalarValue(root, "apiVersion"), "serving.kserve.io/") {
+			continue
+		}
+		name := scalarValue(mappingValue(root, "metadata"), "name")
+		if name == "" {
+			name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		}
+		b := ServingBundle{Kind: BundleKindKServe, Name: name, Path: path}
+		services = append(services, b)
+		models = append(models, withBundle(kserveModels(mappingValue(root, "spec"), lines, path), b)...)
+	}
+
+	if len(services) == 1 {
+		results = withBundle(results, services[0])
+	}
+	return append(results, models...)
+}
+
+// kserveModels reports the models served by the predictor, transformer and.
+// explainer of an InferenceService spec. Each of them holds a model (or a.
+// framework key such as sklearn in older manifests) with a storageUri and.
+// container args.
+func kserveModels(spec *yaml.Node, lines []string, path string) []Discovery {
+	var results []Discovery
+	for _, component := range []string{"predictor", "transformer", "explainer"} {
+		c := mappingValue(spec, component)
+		if c == nil || c.Kind != yaml.MappingNode {
+			continue
+		}
+		for i := 0; i+1 < len(c.Content); i += 2 {
+			impl := c.Content[i+1]
+			if impl.Kind != yaml.MappingNode {
+				continue
+			}
+			format := scalarValue(mappingValue(impl, "modelFormat"), "name")
+			if format == "" && c.Content[i].Value != "model" {
+				format = c.Content[i].Value
+			}
+			if uri := mappingValue(impl, "storageUri"); uri != nil && uri.Kind == yaml.ScalarNode {
+				if d, ok := kserveStorageURI(strings.TrimSpace(uri.Value), strings.ToLower(format), lineEvidence(lines, uri.Line), path); ok {
+					results = append(results, d)
+				}
+			}
+			results = append(results, kserveModelIDArgs(mappingValue(impl, "args"), lines, path)...)
+		}
+	}
+	return results
+}
+
+// kserveStorageURI turns a storageUri into a discovery: hf:// URIs name a.
+// Hugging Face model and object storage, HTTP(S), PVC and OCI URIs are.
+// reported as "weight-url" discoveries. Local paths are ignored.
+func kserveStorageURI(uri, format, evidence, filePath string) (Discovery, bool) {
+	const method = "kserve_storage_uri"
+	if id, ok := strings.CutPrefix(uri, "hf://"); ok {
+		id, _, _ = strings.Cut(id, ":")
+		if !hfIDSlashRe.MatchString(id) || !isPlausibleModelID(id) {
+			return Discovery{}, false
+		}
+		return Discovery{ID: id, Name: id, Type: "model", Path: filePath, Evidence: method + evidence, Method: method}, true
+	}
+	if !strings.Contains(uri, "://") {
+		return Discovery{}, false
+	}
+
+	// Query strings may hold credentials (signed URLs).
+	id, query, _ := strings.Cut(uri, "?")
+	if query != "" {
+		evidence = strings.Replace(evidence, "?"+query, "?REDACTED", 1)
+	}
+	id = strings.TrimRight(id, "/")
+	if format == "" {
+		format = weightURLExts[strings.ToLower(path.Ext(id))]
+	}
+	return Discovery{
+		ID:       id,
+		Name:     path.Base(id),
+		Type:     DiscoveryTypeWeightURL,
+		Path:     filePath,
+		Evidence: method + evidence,
+		Method:   method,
+		Format:   format,
+	}, true
+}
+
+// kserveModelIDArgs reports the Hugging Face models passed to the KServe.
+// Hugging Face runtime with --model_id.
+func kserveModelIDArgs(args *yaml.Node, lines []string, path string) []Discovery {
+	const method = "kserve_model_id_arg"
+	if args == nil || args.Kind != yaml.SequenceNode {
+		return nil
+	}
+	var results []Discovery
+	for i, arg := range args.Content {
+		value, ok := strings.CutPrefix(arg.Value, "--model_id=")
+		if !ok && arg.Value == "--model_id" && i+1 < len(args.Content) {
+			arg = args.Content[i+1]
+			value, ok = arg.Value, true
+		}
+		value = strings.TrimSpace(value)
+		if !ok || !hfIDSlashRe.MatchString(value) || !isPlausibleModelID(value) {
+			continue
+		}
+		results = append(results, Discovery{
+			ID:       value,
+			Name:     value,
+			Type:     "model",
+			Path:     path,
+			Evidence: method + lineEvidence(lines, arg.Line),
+			Method:   method,
+		})
+	}
+	return results
+}
+
+// withBundle adds b to the bundles of every discovery in ds.
+func withBundle(ds []Discovery, b ServingBundle) []Discovery {
+	for i := range ds {
+		ds[i].Bundles = append(ds[i].Bundles, b)
+	}
+	return ds
+}
+
+// mergeBundles returns the bundles of a followed by those of b it lacks.
+func mergeBundles(a, b []ServingBundle) []ServingBundle {
+	for _, x := range b {
+		dup := false
+		for _, y := range a {
+			if x == y {
+				dup = true
+				break
+			}
+		}
+		if !dup {
+			a = append(a[:len(a):len(a)], x)
+		}
+	}
+	return a
+}
+
+// lineEvidence returns the evidence suffix " at line N: <text>" for line N.
+// (1-based) of lines.
+func lineEvidence(lines []string, n int) string {
+	text := ""
+	if n >= 1 && n <= len(lines) {
+		text = strings.TrimSpace(lines[n-1])
+	}
+	return " at line " + strconv.Itoa(n) + ": " + text
+}
+
+// mappingValue returns the value of key in the YAML mapping n, or nil.
+func mappingValue(n *yaml.Node, key string) *yaml.Node {
+	if n == nil || n.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// scalarValue returns the scalar value of key in the YAML mapping n, or "".
+func scalarValue(n *yaml.Node, key string) string {
+	v := mappingValue(n, key)
+	if v == nil || v.Kind != yaml.ScalarNode {
+		return ""
+	}
+	return strings.TrimSpace(v.Value)
+}
@@ -0,0 +1,143 @@
+package scanner
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	// pyConstRe matches a Python assignment of a plain string literal:.
+	//   MODEL_ID = "org/model"  |  MODEL_ID: str = 'org/model'.
+	pyConstRe = regexp.MustCompile(`^\s*([A-Za-z_][A-Za-z0-9_]*)\s*(?::\s*[A-Za-z_][\w\[\]., ]*)?=\s*[rRuU]?["']([^"'\\]+)["']\s*$`)
+
+	// jsConstRe matches a JavaScript / TypeScript declaration of a plain.
+	// string literal:.
+	//   const MODEL_ID = "org/model";  |  export let model: string = 'org/model'.
+	jsConstRe = regexp.MustCompile("^\\s*(?:export\\s+)?(?:const|let|var)\\s+([A-Za-z_][A-Za-z0-9_]*)\\s*(?::\\s*string\\s*)?=\\s*[\"'`]([^\"'`\\\\$]+)[\"'`]\\s*;?\\s*$")
+)
+
+// stringConstant is a name bound to a string literal in a single file.
+type stringConstant struct {
+	name  string
+	value string
+	line  int
+	re    *regexp.Regexp
+}
+
+// resolveConstants replaces references to simple string constants with the.
+// quoted constant value, so detection rules written for literals also match.
+// from_pretrained(MODEL_ID). Constants are collected from the whole file with.
+// assign; a name bound to different values is ambiguous and left alone, as.
+// are values that cannot be model IDs. notes[i] describes the constants.
+// substituted on line i, for the evidence string.
+func resolveConstants(lines []string, assign *regexp.Regexp) (resolved, notes []string) {
+	consts := collectConstants(lines, assign)
+	if len(consts) == 0 {
+		return lines, nil
+	}
+
+	resolved = make([]string, len(lines))
+	notes = make([]string, len(lines))
+	for i, line := range lines {
+		resolved[i] = line
+		if assign.MatchString(line) {
+			continue
+		}
+		var used []string
+		for _, c := range consts {
+			out, ok := substituteIdent(resolved[i], c)
+			if ok {
+				resolved[i] = out
+				used = append(used, c.name+" from line "+strconv.Itoa(c.line))
+			}
+		}
+		if len(used) > 0 {
+			notes[i] = "resolved " + strings.Join(used, ", ")
+		}
+	}
+	return resolved, notes
+}
+
+// collectConstants returns the unambiguous string constants in lines, in.
+// name order.
+func collectConstants(lines []string, assign *regexp.Regexp) []stringConstant {
+	found := make(map[string]*stringConstant)
+	ambiguous := make(map[string]bool)
+	for i, line := range lines {
+		m := assign.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		name, value := m[1], strings.TrimSpace(m[2])
+		if c, ok := found[name]; ok {
+			if c.value != value {
+				ambiguous[name] = true
+			}
+			continue
+		}
+		found[name] = &stringConstant{name: name, value: value, line: i + 1}
+	}
+
+	var out []stringConstant
+	for name, c := range found {
+		if ambiguous[name] || strings.ContainsAny(c.value, " \t") || !isPlausibleModelID(c.value) {
+			continue
+		}
+		c.re = regexp.MustCompile(`\b` + regexp.QuoteMeta(name) + `\b`)
+		out = append(out, *c)
+	}
+	sort.Slice(out, func(a, b int) bool { return out[a].name < out[b].name })
+	return out
+}
+
+// substituteIdent replaces standalone uses of c.name in line with the quoted.
+// value. Attribute accesses (cfg.MODEL_ID), keyword names (MODEL_ID=...) and.
+// occurrences inside string literals are kept.
+func substituteIdent(line string, c stringConstant) (string, bool) {
+	locs := c.re.FindAllStringIndex(line, -1)
+	if len(locs) == 0 {
+		return line, false
+	}
+
+	var b strings.Builder
+	last := 0
+	changed := false
+	for _, loc := range locs {
+		start, end := loc[0], loc[1]
+		if start > 0 && line[start-1] == '.' {
+			continue
+		}
+		if rest := strings.TrimLeft(line[end:], " \t"); strings.HasPrefix(rest, "=") && !strings.HasPrefix(rest, "==") {
+			continue
+		}
+		if insideString(line[:start]) {
+			continue
+		}
+		b.WriteString(line[last:start])
+		b.WriteString(strconv.Quote(c.value))
+		last = end
+		changed = true
+	}
+	b.WriteString(line[last:])
+	return b.String(), changed
+}
+
+// insideString reports whether the text before a position leaves a single.
+// or double quoted string open.
+func insideString(prefix string) bool {
+	var quote byte
+	for i := 0; i < len(prefix); i++ {
+		ch := prefix[i]
+		switch {
+		case quote != 0 && ch == '\\':
+			i++
+		case quote != 0 && ch == quote:
+			quote = 0
+		case quote == 0 && (ch == '"' || ch == '\'' || ch == '`'):
+			quote = ch
+		}
+	}
+	return quote != 0
+}
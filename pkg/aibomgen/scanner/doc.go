@@ -8,11 +8,75 @@
 //   - YAML (.yaml, .yml): model_name_or_path, base_model, _name_or_path,.
 //     pretrained_model_name_or_path.
 //   - JSON (.json): adapter configs, _name_or_path, base_model.
+//     A PEFT adapter_config.json also yields an "adapter" discovery whose.
+//     BaseModel is the base_model_name_or_path it was trained on.
 //   - Markdown: base_model field in YAML front-matter.
 //   - Shell scripts and Dockerfiles: huggingface-cli download, hf download.
+//   - Serving configs (shell, Dockerfile, YAML): vllm serve and --model (vLLM),.
+//     --model-id and MODEL_ID (text-generation-inference), -hf (llama.cpp).
+//     GGUF paths passed to llama.cpp with -m are reported as "model-file".
+//     discoveries.
 //   - JavaScript / TypeScript (.js, .ts, .mjs, .cjs): pipeline and from_pretrained.
 //     calls via the @huggingface/transformers library.
+//   - Model weight files (any extension): GGUF magic, safetensors header,.
+//     TorchScript / PyTorch zip archives, legacy PyTorch pickle checkpoints.
+//     and ONNX (.onnx) protobuf headers are reported as "model-file".
+//     discoveries with their size and SHA-256 digest.
+//   - DVC (.dvc files and dvc.lock stage outputs) and Git LFS pointer files.
+//     that track model or dataset artifacts, judged by path pattern and size,.
+//     are reported as "model-file" or [DiscoveryTypeDataFile] discoveries.
+//     with the tracked digest (MD5 for DVC, SHA-256 for Git LFS).
+//     DVC outputs carry the URL of their copy in the default (or their own).
+//     remote of .dvc/config as StorageURI.
+//   - Tracked artifacts: the artifacts section of a dvc.yaml (the DVC model.
+//     registry) and MLflow models (MLmodel files) are reported as.
+//     [DiscoveryTypeTrackedArtifact] discoveries with their registered name,.
+//     artifact type, digest and storage URI (the DVC remote URL, or the.
+//     runs:/ URI of the MLflow model).
+//   - Weight URLs in code and config files (s3://, gs://, Azure and HTTP(S).
+//     URLs ending in a weight file extension) are reported as "weight-url".
+//     discoveries.
+//   - Models hosted by NVIDIA NGC (ngc registry model, api.ngc.nvidia.com and.
+//     catalog URLs, nvcr.io/nim images) and Meta's gated Llama downloads (llama.
+//     model download --source meta, *.llamameta.net URLs) are reported as.
+//     [DiscoveryTypeProviderModel] discoveries with a provider-qualified ID.
+//   - Ollama models: the FROM of a Modelfile, the ollama CLI (pull, run),.
+//     the Ollama clients and integrations (ollama.chat, ChatOllama), the.
+//     command of ollama/ollama Compose services and OLLAMA_MODEL are reported.
+//     as [DiscoveryTypeProviderModel] discoveries ("ollama:llama3:8b").
+//   - Hosted model APIs: OpenAI, Anthropic, Azure OpenAI and Amazon Bedrock.
+//     clients and the models they are called with (quoted model names,.
+//     Azure deployments, Bedrock model IDs and ARNs) are reported as.
+//     [DiscoveryTypeHostedModel] discoveries ("openai:gpt-4o").
+//   - Kaggle Models and Kaggle Datasets (kagglehub calls, kaggle:// presets,.
+//     the kaggle CLI and kaggle.com URLs) are reported as.
+//     [DiscoveryTypeKaggle] discoveries.
+//   - Serving bundles: models listed in a BentoML bentofile.yaml or loaded by a.
+//     BentoML service (HuggingFaceModel, the BentoML model store), and the.
+//     storageUri and --model_id of KServe InferenceServices. Their discoveries.
+//     list the [ServingBundle] that packages them; BentoML store models are.
+//     "model-file" discoveries with the model tag as ID.
+//.
+// In Python and JavaScript / TypeScript, references through simple string.
+// constants defined in the same file (MODEL_ID = "org/model") are resolved.
+// before the detection rules run. IDs built from Python f-strings or.
+// JavaScript template literals (f"org/{name}") are reported as.
+// [DiscoveryTypeParameterized] discoveries carrying their static prefix.
+//.
+// Python comments and docstrings are skipped unless [Options] IncludeComments.
+// is set. Matches found only there or in test files carry [ConfidenceLow].
 //.
 // The primary entry point is [Scan], which returns a slice of [Discovery] values.
-// describing each detected model or dataset reference.
+// describing each detected model or dataset reference. Discovery paths are.
+// always slash-separated; on Windows the walk uses extended-length (\\?\).
+// paths so deep trees are not limited by MAX_PATH.
+//.
+// Virtual environments and site-packages are skipped by the walk.
+// [ScanPythonEnv] reports the model weights installed in a Python.
+// environment instead: spaCy pipeline packages, Hugging Face cache snapshots,.
+// sentence-transformers and torch hub caches, and weight files shipped by.
+// installed packages, with the package version or snapshot revision.
+//.
+// [DetectModelFile] and [HashFile] expose the weight detection and hashing.
+// on their own, e.g. to check deployed files against a BOM.
 package scanner
@@ -0,0 +1,318 @@
+package scanner
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/idlab-discover/aibomgen-cli/internal/fsutil"
+)
+
+// maxHistoryBlobSize is the largest file version read from the git history;.
+// larger blobs are data or weights, not source referencing models.
+const maxHistoryBlobSize = 1 << 20
+
+// ErrNotGitRepository is returned by [ScanGitHistory] when the scanned.
+// directory is not inside a git repository.
+var ErrNotGitRepository = errors.New("not a git repository")
+
+// GitHistory records when a reference entered and left the git history.
+type GitHistory struct {
+	// IntroducedCommit is the commit that first added the reference, and.
+	// IntroducedAt its commit time.
+	IntroducedCommit string    `json:"introduced_commit"`
+	IntroducedAt     time.Time `json:"introduced_at"`
+	// RemovedCommit is the commit that removed the last occurrence of the.
+	// reference, and RemovedAt its commit time. Both are unset while the.
+	// reference is still in the tree.
+	RemovedCommit string    `json:"removed_commit,omitempty"`
+	RemovedAt     time.Time `json:"removed_at,omitzero"`
+}
+
+// ScanGitHistory walks the first-parent history of HEAD of the git.
+// repository containing root, oldest commit first, and applies the text.
+// detection rules to every version of every file below root. It returns every.
+// model reference that was ever committed, with the commits that introduced.
+// it and, when it is no longer in HEAD, removed it. Paths are those of the.
+// files in the work tree, including deleted ones.
+//.
+// maxCommits limits the walk to the latest commits (0: all); references.
+// already present in the oldest commit walked are attributed to it. Model.
+// weights, DVC and LFS pointers, tracked artifacts and adapter configs are.
+// not looked up in the history, and neither are templated references.
+func ScanGitHistory(root string, maxCommits int, opts Options) ([]Discovery, error) {
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+	repo, err := git.PlainOpenWithOptions(absRoot, &git.PlainOpenOptions{DetectDotGit: true})
+	if errors.Is(err, git.ErrRepositoryNotExists) {
+		return nil, fmt.Errorf("%s: %w", root, ErrNotGitRepository)
+	}
+	if err != nil {
+		return nil, err
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		return nil, err
+	}
+	repoRoot := wt.Filesystem.Root()
+	// Resolve symlinks on both sides so the scan root can be located in the.
+	// repository (e.g. /tmp on macOS).
+	if r, err := filepath.EvalSymlinks(repoRoot); err == nil {
+		repoRoot = r
+	}
+	if r, err := filepath.EvalSymlinks(absRoot); err == nil {
+		absRoot = r
+	}
+	prefix, err := filepath.Rel(repoRoot, absRoot)
+	if err != nil || prefix == ".." || strings.HasPrefix(prefix, ".."+string(filepath.Separator)) {
+		return nil, fmt.Errorf("%s is outside the repository at %s", root, repoRoot)
+	}
+	prefix = filepath.ToSlash(prefix)
+
+	head, err := repo.Head()
+	if err != nil {
+		// A repository without commits has no history.
+		return nil, nil
+	}
+	commit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		return nil, err
+	}
+	var commits []*object.Commit
+	for commit != nil && (maxCommits <= 0 || len(commits) < maxCommits) {
+		commits = append(commits, commit)
+		if commit.NumParents() == 0 {
+			break
+		}
+		if commit, err = commit.Parent(0); err != nil {
+			return nil, err
+		}
+	}
+
+	tmp, err := os.MkdirTemp("", "aibomgen-history-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+
+	h := &historyWalk{
+		repoRoot: repoRoot,
+		prefix:   prefix,
+		absRoot:  absRoot,
+		tmp:      tmp,
+		opts:     opts,
+		files:    make(map[string]map[string]Discovery),
+		refs:     make(map[string]*historyRef),
+	}
+	var prevTree *object.Tree
+	for i := len(commits) - 1; i >= 0; i-- {
+		c := commits[i]
+		tree, err := c.Tree()
+		if err != nil {
+			return nil, err
+		}
+		changes, err := object.DiffTree(prevTree, tree)
+		if err != nil {
+			return nil, err
+		}
+		if err := h.apply(repo, c, changes); err != nil {
+			return nil, err
+		}
+		prevTree = tree
+	}
+
+	out := make([]Discovery, 0, len(h.order))
+	for _, key := range h.order {
+		ref := h.refs[key]
+		d := ref.last
+		hist := ref.history
+		d.History = &hist
+		out = append(out, d)
+	}
+	return out, nil
+}
+
+// historyRef is a reference seen in the history.
+type historyRef struct {
+	// count is the number of files that contain the reference.
+	count   int
+	last    Discovery
+	history GitHistory
+}
+
+type historyWalk struct {
+	repoRoot, prefix, absRoot, tmp string
+	opts                           Options
+	// files maps the repository path of every scanned file to its.
+	// references, keyed like dedupe.
+	files map[string]map[string]Discovery
+	refs  map[string]*historyRef
+	// order lists the reference keys in the order they were introduced.
+	order []string
+}
+
+// apply updates the references with the files changed by c. All additions.
+// of a commit are counted before its removals, so a reference moved from one.
+// file to another is not removed.
+func (h *historyWalk) apply(repo *git.Repository, c *object.Commit, changes object.Changes) error {
+	updated := make(map[string]map[string]Discovery)
+	for _, ch := range changes {
+		if name := ch.From.Name; name != "" && h.files[name] != nil {
+			updated[name] = nil
+		}
+		name := ch.To.Name
+		if name == "" || !h.scannable(name) {
+			continue
+		}
+		blob, err := repo.BlobObject(ch.To.TreeEntry.Hash)
+		if err != nil {
+			return err
+		}
+		found, err := h.scanBlob(name, blob)
+		if err != nil {
+			return err
+		}
+		updated[name] = found
+	}
+
+	for name, found := range updated {
+		for key, d := range found {
+			ref := h.refs[key]
+			if ref == nil {
+				ref = &historyRef{history: GitHistory{IntroducedCommit: c.Hash.String(), IntroducedAt: c.Committer.When}}
+				h.refs[key] = ref
+				h.order = append(h.order, key)
+			}
+			if _, ok := h.files[name][key]; !ok {
+				ref.count++
+			}
+			// A reintroduced reference is no longer removed.
+			ref.history.RemovedCommit, ref.history.RemovedAt = "", time.Time{}
+			ref.last = d
+		}
+	}
+	for name, found := range updated {
+		for key := range h.files[name] {
+			if _, ok := found[key]; ok {
+				continue
+			}
+			ref := h.refs[key]
+			if ref.count--; ref.count == 0 {
+				ref.history.RemovedCommit, ref.history.RemovedAt = c.Hash.String(), c.Committer.When
+			}
+		}
+		if len(found) == 0 {
+			delete(h.files, name)
+		} else {
+			h.files[name] = found
+		}
+	}
+	return nil
+}
+
+// scannable reports whether the file at the repository path name is below.
+// the scan root, not skipped or ignored, and holds text the rules apply to.
+func (h *historyWalk) scannable(name string) bool {
+	if h.prefix != "." && !strings.HasPrefix(name, h.prefix+"/") {
+		return false
+	}
+	dir := path.Dir(name)
+	for _, part := range strings.Split(dir, "/") {
+		if shouldSkipDir(part) {
+			return false
+		}
+	}
+	abs := filepath.Join(h.repoRoot, filepath.FromSlash(name))
+	for d := filepath.Dir(abs); len(d) > len(h.absRoot); d = filepath.Dir(d) {
+		if ignored(h.opts.Ignore, h.absRoot, d, true) {
+			return false
+		}
+	}
+	if ignored(h.opts.Ignore, h.absRoot, abs, false) {
+		return false
+	}
+	base := strings.ToLower(path.Base(name))
+	return classifyFile(strings.ToLower(path.Ext(base)), base) != fileClassUnknown && base != adapterConfigName
+}
+
+// scanBlob runs the detection rules on one version of a file, by writing it.
+// to a temporary file with the same name.
+func (h *historyWalk) scanBlob(name string, blob *object.Blob) (map[string]Discovery, error) {
+	if blob.Size > maxHistoryBlobSize {
+		return nil, nil
+	}
+	r, err := blob.Reader()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	tmpFile := filepath.Join(h.tmp, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(tmpFile), 0o755); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(tmpFile, data, 0o644); err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmpFile)
+
+	hits := scanFile(tmpFile, h.opts)
+	if isTestPath(strings.TrimPrefix(name, h.prefix+"/")) {
+		hits = markLowConfidence(hits)
+	}
+	found := make(map[string]Discovery)
+	workPath := fsutil.Normalize(filepath.Join(h.repoRoot, filepath.FromSlash(name)))
+	for _, d := range dedupe(hits) {
+		switch d.Type {
+		case DiscoveryTypeParameterized, DiscoveryTypeModelFile, DiscoveryTypeDataFile, DiscoveryTypeTrackedArtifact, DiscoveryTypeAdapter:
+			continue
+		}
+		d.Path = workPath
+		d.Bundles = nil
+		found[d.Type+"::"+d.ID] = d
+	}
+	return found, nil
+}
+
+// MergeHistory annotates current discoveries with the history of their.
+// references and appends the references of history that are no longer in.
+// the tree. The work tree wins over HEAD: a reference removed in HEAD but.
+// still present in the scanned files is current.
+func MergeHistory(current, history []Discovery) []Discovery {
+	byKey := make(map[string]GitHistory, len(history))
+	for _, d := range history {
+		if d.History != nil {
+			byKey[d.Type+"::"+d.ID] = *d.History
+		}
+	}
+	out := make([]Discovery, 0, len(current)+len(history))
+	inTree := make(map[string]bool, len(current))
+	for _, d := range current {
+		key := d.Type + "::" + d.ID
+		inTree[key] = true
+		if h, ok := byKey[key]; ok {
+			h.RemovedCommit, h.RemovedAt = "", time.Time{}
+			d.History = &h
+		}
+		out = append(out, d)
+	}
+	for _, d := range history {
+		if d.History != nil && d.History.RemovedCommit != "" && !inTree[d.Type+"::"+d.ID] {
+			out = append(out, d)
+		}
+	}
+	return out
+}
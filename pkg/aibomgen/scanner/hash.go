@@ -0,0 +1,267 @@
+package scanner
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/idlab-discover/aibomgen-cli/internal/fsutil"
+	"lukechampine.com/blake3"
+)
+
+// DefaultHashChunkSize is the chunk size used when HashOptions.ChunkSize is.
+// not set. Files smaller than two chunks are hashed with a single stream.
+const DefaultHashChunkSize int64 = 64 << 20
+
+// DefaultHashWorkers is the number of chunk readers per file used when.
+// HashOptions.Workers is not set. Scans hash several files at once, so the.
+// per-file count stays small.
+const DefaultHashWorkers = 4
+
+// chunkSlots bounds the chunks held in memory by all files being hashed at.
+// once, so that hashing NumCPU files with many workers each needs NumCPU.
+// chunk buffers rather than NumCPU times as many.
+var chunkSlots = make(chan struct{}, max(runtime.NumCPU(), DefaultHashWorkers))
+
+// chunkBuffers recycles chunk buffers across chunks and files.
+var chunkBuffers sync.Pool
+
+// HashOptions controls how model weight files are hashed.
+type HashOptions struct {
+	// Workers is the number of concurrent chunk readers per file.
+	// Zero uses DefaultHashWorkers.
+	Workers int
+	// ChunkSize is the read size per worker in bytes. Zero uses.
+	// DefaultHashChunkSize.
+	ChunkSize int64
+	// UseSidecar reuses the digest from a "<file>.sha256" sidecar when present.
+	// instead of reading the file.
+	UseSidecar bool
+	// BLAKE3 also computes the BLAKE3-256 digest, in the same pass over the.
+	// file. Sidecars only hold the SHA-256 digest, so with BLAKE3 the file is.
+	// always read.
+	BLAKE3 bool
+	// OnProgress, when set, is called after every hashed chunk. It may be.
+	// called concurrently for different files.
+	OnProgress func(HashProgress)
+}
+
+// HashProgress reports hashing progress for a single file.
+type HashProgress struct {
+	Path  string
+	Done  int64
+	Total int64
+}
+
+// HashResult is the outcome of HashFile.
+type HashResult struct {
+	SHA256 string
+	// BLAKE3 is the BLAKE3-256 digest, set when HashOptions.BLAKE3 is.
+	BLAKE3 string
+	// FromSidecar is true when the digest was read from a ".sha256" sidecar.
+	FromSidecar bool
+}
+
+// HashFile computes the SHA-256 digest of path, and its BLAKE3 digest when.
+// opts.BLAKE3 is set.
+//.
+// Large files are read in fixed-size chunks by a pool of workers using.
+// positional reads, so disk I/O for upcoming chunks overlaps with hashing of.
+// the current one. Chunks are folded into the digest strictly in order, so the.
+// result is identical to `sha256sum` and stays verifiable by consumers.
+func HashFile(path string, opts HashOptions) (HashResult, error) {
+	if opts.UseSidecar && !opts.BLAKE3 {
+		if digest, ok := readSidecar(path); ok {
+			return HashResult{SHA256: digest, FromSidecar: true}, nil
+		}
+	}
+
+	f, err := os.Open(fsutil.LongPath(path))
+	if err != nil {
+		return HashResult{}, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return HashResult{}, err
+	}
+
+	chunkSize := opts.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = DefaultHashChunkSize
+	}
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = DefaultHashWorkers
+	}
+
+	h := sha256.New()
+	var w io.Writer = h
+	var b3 *blake3.Hasher
+	if opts.BLAKE3 {
+		b3 = blake3.New(32, nil)
+		w = io.MultiWriter(h, b3)
+	}
+	size := info.Size()
+	if size < 2*chunkSize || workers == 1 {
+		err = hashStream(w, f, path, size, chunkSize, opts.OnProgress)
+	} else {
+		err = hashChunked(w, f, path, size, chunkSize, workers, opts.OnProgress)
+	}
+	if err != nil {
+		return HashResult{}, err
+	}
+	res := HashResult{SHA256: hex.EncodeToString(h.Sum(nil))}
+	if b3 != nil {
+		res.BLAKE3 = hex.EncodeToString(b3.Sum(nil))
+	}
+	return res, nil
+}
+
+// hashStream hashes r sequentially, reporting progress once per chunk.
+func hashStream(h io.Writer, r io.Reader, path string, size, chunkSize int64, progress func(HashProgress)) error {
+	buf := make([]byte, 1<<20)
+	var done, reported int64
+	br := bufio.NewReaderSize(r, len(buf))
+	for {
+		n, err := br.Read(buf)
+		if n > 0 {
+			h.Write(buf[:n])
+			done += int64(n)
+			if progress != nil && done-reported >= chunkSize {
+				reported = done
+				progress(HashProgress{Path: path, Done: done, Total: size})
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if progress != nil {
+		progress(HashProgress{Path: path, Done: done, Total: size})
+	}
+	return nil
+}
+
+// hashChunked reads chunks of r concurrently and folds them into h in order.
+// At most workers chunks of this file, and len(chunkSlots) chunks of all files,.
+// are buffered at any time.
+func hashChunked(h io.Writer, r io.ReaderAt, path string, size, chunkSize int64, workers int, progress func(HashProgress)) error {
+	numChunks := int((size + chunkSize - 1) / chunkSize)
+
+	type chunk struct {
+		buf *[]byte
+		n   int64
+		err error
+	}
+	// One buffered result channel per chunk keeps ordering trivial and never.
+	// blocks a reader; the semaphores bound memory to the chunks in flight.
+	// Chunks take their slots in order, so the next chunk to fold always has.
+	// one.
+	results := make([]chan chunk, numChunks)
+	for i := range results {
+		results[i] = make(chan chunk, 1)
+	}
+	sem := make(chan struct{}, workers)
+	stop := make(chan struct{})
+	launched := make(chan int, 1)
+
+	go func() {
+		i := 0
+		defer func() { launched <- i }()
+		for ; i < numChunks; i++ {
+			select {
+			case sem <- struct{}{}:
+			case <-stop:
+				return
+			}
+			select {
+			case chunkSlots <- struct{}{}:
+			case <-stop:
+				return
+			}
+			go func(i int) {
+				off := int64(i) * chunkSize
+				n := chunkSize
+				if off+n > size {
+					n = size - off
+				}
+				buf := chunkBuffer(n)
+				_, err := r.ReadAt((*buf)[:n], off)
+				if err == io.EOF {
+					err = nil
+				}
+				results[i] <- chunk{buf: buf, n: n, err: err}
+			}(i)
+		}
+	}()
+
+	var done int64
+	for i := 0; i < numChunks; i++ {
+		c := <-results[i]
+		<-sem
+		if c.err != nil {
+			releaseChunk(c.buf)
+			// Free the slots of the chunks already read ahead.
+			close(stop)
+			n := <-launched
+			for j := i + 1; j < n; j++ {
+				releaseChunk((<-results[j]).buf)
+			}
+			return fmt.Errorf("read chunk %d: %w", i, c.err)
+		}
+		h.Write((*c.buf)[:c.n])
+		releaseChunk(c.buf)
+		done += c.n
+		if progress != nil {
+			progress(HashProgress{Path: path, Done: done, Total: size})
+		}
+	}
+	return nil
+}
+
+// chunkBuffer returns a pooled buffer of at least n bytes.
+func chunkBuffer(n int64) *[]byte {
+	if buf, ok := chunkBuffers.Get().(*[]byte); ok && int64(cap(*buf)) >= n {
+		return buf
+	}
+	buf := make([]byte, n)
+	return &buf
+}
+
+// releaseChunk returns buf to the pool and frees its chunk slot.
+func releaseChunk(buf *[]byte) {
+	chunkBuffers.Put(buf)
+	<-chunkSlots
+}
+
+// readSidecar returns the digest from "<path>.sha256" when it exists and.
+// holds a well-formed SHA-256 hex digest ("<hex>" or "<hex>  <filename>").
+func readSidecar(path string) (string, bool) {
+	data, err := os.ReadFile(fsutil.LongPath(path + ".sha256"))
+	if err != nil {
+		return "", false
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return "", false
+	}
+	digest := strings.ToLower(fields[0])
+	if len(digest) != sha256.Size*2 {
+		return "", false
+	}
+	if _, err := hex.DecodeString(digest); err != nil {
+		return "", false
+	}
+	return digest, true
+}
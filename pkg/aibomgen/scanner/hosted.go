@@ -0,0 +1,178 @@
+package scanner
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DiscoveryTypeHostedModel is the Discovery.Type of models used through a.
+// hosted inference API (OpenAI, Anthropic, Azure OpenAI, Amazon Bedrock).
+// rather than run from weights. ID is the provider-qualified model.
+// ("openai:gpt-4o", "anthropic:claude-3-5-sonnet-20241022",.
+// "bedrock:anthropic.claude-3-haiku-20240307-v1:0", "azure-openai:my-deployment").
+// and Name the model as the API names it. A client of a provider without a.
+// model named in the project has the provider as ID and Name ("openai").
+//.
+// Hosted models have no weights to describe: they are recorded as CycloneDX.
+// services of the project instead of model components.
+const DiscoveryTypeHostedModel = "hosted-model"
+
+// Providers of hosted model APIs, the prefix of a hosted model ID.
+const (
+	HostedOpenAI      = "openai"
+	HostedAnthropic   = "anthropic"
+	HostedAzureOpenAI = "azure-openai"
+	HostedBedrock     = "bedrock"
+)
+
+var (
+	// openaiClientRe matches an OpenAI client or a call of the OpenAI API:.
+	//   OpenAI()  |  new OpenAI({...})  |  openai.ChatCompletion.create(...)  |  ChatOpenAI(...).
+	openaiClientRe = regexp.MustCompile(`\b(?:Async)?OpenAI\(|\bopenai\.(?:ChatCompletion|Completion|Embedding|Image|Moderation)\.a?create\(|\bopenai\.(?:chat\.completions|completions|embeddings|responses|images)\.create\(|\b(?:ChatOpenAI|OpenAIEmbeddings)\(`)
+
+	// openaiModelRe matches a quoted OpenAI model name:.
+	//   "gpt-4o"  |  'gpt-4-turbo-2024-04-09'  |  "text-embedding-3-small".
+	openaiModelRe = regexp.MustCompile(`["'](gpt-(?:3\.5|4(?:\.\d)?|4o|5)(?:-[a-z0-9]+)*|chatgpt-4o-latest|text-embedding-(?:3-small|3-large|ada-002)|dall-e-[23]|whisper-1|tts-1(?:-hd)?|gpt-image-1|omni-moderation-latest)["']`)
+
+	// openaiReasoningRe matches an OpenAI reasoning model passed as the model.
+	// argument; the bare names are too short to trust anywhere else:.
+	//   model="o1-mini"  |  "model": "o3".
+	openaiReasoningRe = regexp.MustCompile(`\bmodel["']?\s*[=:]\s*["'](o[1345](?:-mini|-preview|-pro)?(?:-\d{4}-\d{2}-\d{2})?)["']`)
+
+	// anthropicClientRe matches an Anthropic client:.
+	//   anthropic.Anthropic()  |  AsyncAnthropic()  |  new Anthropic()  |  ChatAnthropic(...).
+	anthropicClientRe = regexp.MustCompile(`\b(?:Async)?Anthropic\(|\bChatAnthropic\(`)
+
+	// anthropicModelRe matches a quoted Claude model name:.
+	//   "claude-3-5-sonnet-20241022"  |  "claude-sonnet-4-20250514"  |  "claude-3-opus-latest".
+	anthropicModelRe = regexp.MustCompile(`["'](claude-(?:instant-1(?:\.\d)?|[1-9](?:[.-]\d)?-(?:opus|sonnet|haiku)|(?:opus|sonnet|haiku)-[1-9](?:[.-]\d)?|[1-9](?:\.\d)?)(?:-\d{8}|-latest)?)["']`)
+
+	// azureClientRe matches an Azure OpenAI client or endpoint:.
+	//   AzureOpenAI(...)  |  AzureChatOpenAI(...)  |  https://my-resource.openai.azure.com/.
+	azureClientRe = regexp.MustCompile(`\b(?:Async)?Azure(?:Chat)?OpenAI(?:Embeddings)?\(|\b[a-z0-9][a-z0-9-]*\.openai\.azure\.com\b`)
+
+	// azureDeploymentRe matches the deployment an Azure OpenAI client calls:.
+	//   azure_deployment="gpt4o-prod"  |  deploymentName: "gpt4o-prod".
+	azureDeploymentRe = regexp.MustCompile(`\b(?:azure_deployment|deployment_name|deployment_id|deploymentName)\s*[=:]\s*["']([A-Za-z0-9][A-Za-z0-9_.-]*)["']`)
+
+	// bedrockClientRe matches an Amazon Bedrock runtime client:.
+	//   boto3.client("bedrock-runtime")  |  new BedrockRuntimeClient({...})  |  ChatBedrock(...)  |  AnthropicBedrock().
+	bedrockClientRe = regexp.MustCompile(`\bclient\(\s*(?:service_name\s*=\s*)?["']bedrock-runtime["']|\bBedrockRuntimeClient\b|\b(?:ChatBedrock(?:Converse)?|BedrockEmbeddings|(?:Async)?AnthropicBedrock)\(`)
+
+	// bedrockARNRe matches the ARN of a Bedrock model:.
+	//   arn:aws:bedrock:us-east-1::foundation-model/anthropic.claude-v2.
+	bedrockARNRe = regexp.MustCompile(`\barn:aws[a-z-]*:bedrock:[a-z0-9-]*:\d*:(?:foundation-model|inference-profile|provisioned-model|custom-model)/([A-Za-z0-9][A-Za-z0-9._:/-]*[A-Za-z0-9])`)
+
+	// bedrockModelIDRe matches a quoted Bedrock model ID, optionally with the.
+	// prefix of a cross-region inference profile:.
+	//   "anthropic.claude-3-haiku-20240307-v1:0"  |  "us.amazon.nova-pro-v1:0".
+	bedrockModelIDRe = regexp.MustCompile(`["']((?:(?:us|eu|apac|global)\.)?(?:anthropic|amazon|meta|cohere|ai21|mistral|stability|deepseek|writer)\.[a-z0-9][a-z0-9.-]*-v\d+(?::\d+)?)["']`)
+)
+
+// hostedModels returns a "hosted-model" discovery for every hosted model API.
+// client and every hosted model named in line.
+func hostedModels(line string, lineNum int, path, note string) []Discovery {
+	if !containsHostedHint(strings.ToLower(line)) {
+		return nil
+	}
+
+	var results []Discovery
+	add := func(method, provider, model string) {
+		id, name := provider, provider
+		if model != "" {
+			id, name = provider+":"+model, model
+		}
+		evidence := method + " at line " + strconv.Itoa(lineNum) + ": " + strings.TrimSpace(line)
+		if note != "" {
+			evidence += " (" + note + ")"
+		}
+		results = append(results, Discovery{
+			ID:       id,
+			Name:     name,
+			Type:     DiscoveryTypeHostedModel,
+			Path:     path,
+			Evidence: evidence,
+			Method:   method,
+		})
+	}
+
+	if openaiClientRe.MatchString(line) {
+		add("openai_client", HostedOpenAI, "")
+	}
+	for _, m := range openaiModelRe.FindAllStringSubmatch(line, -1) {
+		add("openai_model", HostedOpenAI, m[1])
+	}
+	for _, m := range openaiReasoningRe.FindAllStringSubmatch(line, -1) {
+		add("openai_model", HostedOpenAI, m[1])
+	}
+	if anthropicClientRe.MatchString(line) {
+		add("anthropic_client", HostedAnthropic, "")
+	}
+	for _, m := range anthropicModelRe.FindAllStringSubmatch(line, -1) {
+		add("anthropic_model", HostedAnthropic, m[1])
+	}
+	if azureClientRe.MatchString(line) {
+		add("azure_openai_client", HostedAzureOpenAI, "")
+	}
+	for _, m := range azureDeploymentRe.FindAllStringSubmatch(line, -1) {
+		add("azure_openai_deployment", HostedAzureOpenAI, m[1])
+	}
+	if bedrockClientRe.MatchString(line) {
+		add("bedrock_client", HostedBedrock, "")
+	}
+	for _, m := range bedrockARNRe.FindAllStringSubmatch(line, -1) {
+		add("bedrock_arn", HostedBedrock, m[1])
+	}
+	for _, m := range bedrockModelIDRe.FindAllStringSubmatch(line, -1) {
+		add("bedrock_model_id", HostedBedrock, m[1])
+	}
+	return results
+}
+
+// hostedHints are substrings of every line hostedModels can match; "-v".
+// starts the version of Bedrock model IDs.
+var hostedHints = []string{"openai", "anthropic", "bedrock", "claude", "gpt-", "model", "deployment", "text-embedding", "dall-e", "whisper-1", "tts-1", "-v"}
+
+func containsHostedHint(lower string) bool {
+	for _, h := range hostedHints {
+		if strings.Contains(lower, h) {
+			return true
+		}
+	}
+	return false
+}
+
+// HostedRef splits the ID of a "hosted-model" discovery into the provider.
+// and the model, which is "" for a client without a model. ok is false for.
+// other discoveries.
+func HostedRef(d Discovery) (provider, model string, ok bool) {
+	if d.Type != DiscoveryTypeHostedModel {
+		return "", "", false
+	}
+	provider, model, _ = strings.Cut(d.ID, ":")
+	return provider, model, provider != ""
+}
+
+// dropHostedClients leaves out the clients of hosted model providers that.
+// also have a model among discoveries: the model says more about what the.
+// project depends on.
+func dropHostedClients(discoveries []Discovery) []Discovery {
+	withModel := make(map[string]bool)
+	for _, d := range discoveries {
+		if provider, model, ok := HostedRef(d); ok && model != "" {
+			withModel[provider] = true
+		}
+	}
+	if len(withModel) == 0 {
+		return discoveries
+	}
+	out := discoveries[:0]
+	for _, d := range discoveries {
+		if provider, model, ok := HostedRef(d); ok && model == "" && withModel[provider] {
+			continue
+		}
+		out = append(out, d)
+	}
+	return out
+}
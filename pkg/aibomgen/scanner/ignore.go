@@ -0,0 +1,43 @@
+package scanner
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/idlab-discover/aibomgen-cli/internal/fsutil"
+)
+
+// ignored reports whether the file or directory p below root matches one of.
+// the ignore patterns. Patterns are globs (see path.Match): a pattern without.
+// a slash matches the name of a file or directory at any depth, a pattern.
+// with a slash matches the slash-separated path relative to root, and a.
+// trailing slash restricts a pattern to directories. An ignored directory is.
+// skipped with everything below it.
+func ignored(patterns []string, root, p string, isDir bool) bool {
+	if len(patterns) == 0 {
+		return false
+	}
+	rel, err := filepath.Rel(fsutil.LongPath(root), p)
+	if err != nil || rel == "." {
+		return false
+	}
+	rel = filepath.ToSlash(rel)
+	name := path.Base(rel)
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		dirOnly := strings.HasSuffix(pattern, "/")
+		pattern = strings.Trim(pattern, "/")
+		if pattern == "" || (dirOnly && !isDir) {
+			continue
+		}
+		target := name
+		if strings.Contains(pattern, "/") {
+			target = rel
+		}
+		if ok, _ := path.Match(pattern, target); ok {
+			return true
+		}
+	}
+	return false
+}
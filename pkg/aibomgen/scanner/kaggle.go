@@ -0,0 +1,188 @@
+package scanner
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DiscoveryTypeKaggle is the Discovery.Type of Kaggle Models and Kaggle.
+// Datasets. ID is "kaggle:" followed by the kind and the Kaggle handle.
+// ("kaggle:models/google/gemma/transformers/2b",.
+// "kaggle:datasets/uciml/iris"), Name the handle and Version the version.
+// number, when the reference pins one.
+const DiscoveryTypeKaggle = "kaggle"
+
+// Kinds of Kaggle resources, the first segment of a Kaggle discovery ID.
+const (
+	KaggleModels   = "models"
+	KaggleDatasets = "datasets"
+)
+
+// kagglePart matches one segment of a Kaggle handle (owner, slug, framework.
+// or variation).
+const kagglePart = `[A-Za-z0-9][A-Za-z0-9_.-]*`
+
+// kaggleModelHandle matches a model variation handle with an optional.
+// version: owner/model/framework/variation[/version].
+const kaggleModelHandle = `(` + kagglePart + `(?:/` + kagglePart + `){3})(?:/(\d+))?`
+
+var (
+	// kagglehubModelRe matches kagglehub downloading a model variation:.
+	//   kagglehub.model_download("google/gemma/transformers/2b").
+	kagglehubModelRe = regexp.MustCompile(`\bkagglehub\.(?:model_download|load_model)\(\s*(?:handle\s*=\s*)?["']` + kaggleModelHandle + `["']`)
+
+	// kagglehubDatasetRe matches kagglehub downloading or loading a dataset:.
+	//   kagglehub.dataset_download("uciml/iris")  |  kagglehub.load_dataset(KaggleDatasetAdapter.PANDAS, "uciml/iris", "Iris.csv").
+	kagglehubDatasetRe = regexp.MustCompile(`\bkagglehub\.(?:dataset_download\(|load_dataset\([^,()]*,)\s*(?:handle\s*=\s*)?["'](` + kagglePart + `/` + kagglePart + `)(?:/versions/(\d+))?["']`)
+
+	// kaggleURIRe matches the kaggle:// presets of KerasHub and KerasNLP:.
+	//   keras_hub.models.GemmaCausalLM.from_preset("kaggle://keras/gemma/keras/gemma_2b_en/2").
+	kaggleURIRe = regexp.MustCompile(`\bkaggle://` + kaggleModelHandle)
+
+	// kaggleCLIModelRe matches the Kaggle CLI downloading or describing a.
+	// model variation:.
+	//   kaggle models instances versions download google/gemma/pytorch/7b/1.
+	kaggleCLIModelRe = regexp.MustCompile(`\bkaggle\s+models\s+instances\s+(?:versions\s+)?(?:download|get|files)\s+(?:-[\w-]+\s+\S+\s+)*["']?` + kaggleModelHandle)
+
+	// kaggleCLIDatasetRe matches the Kaggle CLI downloading or describing a.
+	// dataset, up to the end of the command:.
+	//   kaggle datasets download -d uciml/iris -p data  |  kaggle datasets files uciml/iris.
+	kaggleCLIDatasetRe = regexp.MustCompile(`\bkaggle\s+datasets\s+(?:download|files|metadata|status)\s+([^;&|]*)`)
+
+	// kaggleDatasetHandleRe matches a complete dataset handle.
+	kaggleDatasetHandleRe = regexp.MustCompile(`^` + kagglePart + `/` + kagglePart + `$`)
+
+	// kaggleModelURLRe matches a Kaggle Models page of a model variation:.
+	//   https://www.kaggle.com/models/google/gemma/transformers/2b/3.
+	kaggleModelURLRe = regexp.MustCompile(`\bkaggle\.com/models/` + kaggleModelHandle)
+
+	// kaggleDatasetURLRe matches a Kaggle Datasets page or API URL:.
+	//   https://www.kaggle.com/datasets/uciml/iris  |  https://www.kaggle.com/api/v1/datasets/download/uciml/iris.
+	kaggleDatasetURLRe = regexp.MustCompile(`\bkaggle\.com/(?:api/v1/)?datasets/(?:download/|view/)?(` + kagglePart + `/` + kagglePart + `)(?:/versions/(\d+))?`)
+)
+
+// kaggleResources returns a "kaggle" discovery for every Kaggle model or.
+// dataset reference in line.
+func kaggleResources(line string, lineNum int, path, note string) []Discovery {
+	if !strings.Contains(line, "kaggle") {
+		return nil
+	}
+
+	var results []Discovery
+	add := func(method, kind, handle, version string) {
+		evidence := method + " at line " + strconv.Itoa(lineNum) + ": " + strings.TrimSpace(line)
+		if note != "" {
+			evidence += " (" + note + ")"
+		}
+		results = append(results, Discovery{
+			ID:       "kaggle:" + kind + "/" + handle,
+			Name:     handle,
+			Type:     DiscoveryTypeKaggle,
+			Path:     path,
+			Evidence: evidence,
+			Method:   method,
+			Version:  version,
+		})
+	}
+
+	for _, m := range kagglehubModelRe.FindAllStringSubmatch(line, -1) {
+		add("kagglehub_model", KaggleModels, m[1], m[2])
+	}
+	for _, m := range kaggleURIRe.FindAllStringSubmatch(line, -1) {
+		add("kaggle_uri", KaggleModels, m[1], m[2])
+	}
+	for _, m := range kaggleCLIModelRe.FindAllStringSubmatch(line, -1) {
+		add("kaggle_cli", KaggleModels, m[1], m[2])
+	}
+	for _, m := range kaggleModelURLRe.FindAllStringSubmatch(line, -1) {
+		add("kaggle_url", KaggleModels, m[1], m[2])
+	}
+	for _, m := range kagglehubDatasetRe.FindAllStringSubmatch(line, -1) {
+		add("kagglehub_dataset", KaggleDatasets, m[1], m[2])
+	}
+	for _, m := range kaggleCLIDatasetRe.FindAllStringSubmatch(line, -1) {
+		if handle := kaggleCLIDataset(m[1]); handle != "" {
+			add("kaggle_cli", KaggleDatasets, handle, "")
+		}
+	}
+	for _, m := range kaggleDatasetURLRe.FindAllStringSubmatch(line, -1) {
+		add("kaggle_url", KaggleDatasets, m[1], m[2])
+	}
+	return results
+}
+
+// kaggleCLIDataset returns the dataset handle among the arguments of a.
+// "kaggle datasets" command: the value of -d/--dataset, else the first.
+// positional argument. Other flags take a value (-p data, -f file.csv).
+func kaggleCLIDataset(args string) string {
+	fields := strings.Fields(args)
+	var positional string
+	for i := 0; i < len(fields); i++ {
+		f := fields[i]
+		if f == "-d" || f == "--dataset" {
+			if i+1 < len(fields) {
+				return kaggleHandle(fields[i+1])
+			}
+			return ""
+		}
+		if v, ok := strings.CutPrefix(f, "--dataset="); ok {
+			return kaggleHandle(v)
+		}
+		if strings.HasPrefix(f, "-") {
+			// Boolean flags such as --unzip or --force take no value.
+			if !strings.HasPrefix(f, "--") || f == "--path" || f == "--file" {
+				i++
+			}
+			continue
+		}
+		if positional == "" {
+			positional = f
+		}
+	}
+	return kaggleHandle(positional)
+}
+
+// kaggleHandle returns s without quotes when it is a dataset handle, else "".
+func kaggleHandle(s string) string {
+	s = strings.Trim(s, `"'`)
+	if !kaggleDatasetHandleRe.MatchString(s) {
+		return ""
+	}
+	return s
+}
+
+// KaggleRef splits the ID of a "kaggle" discovery into the kind of resource.
+// (KaggleModels or KaggleDatasets) and its handle. ok is false for other.
+// discoveries.
+func KaggleRef(d Discovery) (kind, handle string, ok bool) {
+	if d.Type != DiscoveryTypeKaggle {
+		return "", "", false
+	}
+	rest, found := strings.CutPrefix(d.ID, "kaggle:")
+	if !found {
+		return "", "", false
+	}
+	kind, handle, found = strings.Cut(rest, "/")
+	if !found || (kind != KaggleModels && kind != KaggleDatasets) || handle == "" {
+		return "", "", false
+	}
+	return kind, handle, true
+}
+
+// KaggleURL returns the Kaggle page of a "kaggle" discovery, or "" for other.
+// discoveries.
+func KaggleURL(d Discovery) string {
+	kind, handle, ok := KaggleRef(d)
+	if !ok {
+		return ""
+	}
+	url := "https://www.kaggle.com/" + kind + "/" + handle
+	if d.Version != "" {
+		if kind == KaggleDatasets {
+			url += "/versions"
+		}
+		url += "/" + d.Version
+	}
+	return url
+}
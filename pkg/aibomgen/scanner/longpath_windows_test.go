@@ -0,0 +1,55 @@
+//go:build windows
+
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/idlab-discover/aibomgen-cli/internal/fsutil"
+)
+
+// TestScanDeepRelativeRoot scans a relative root whose files lie deeper than.
+// MAX_PATH and expects the paths to be reported relative, as given.
+func TestScanDeepRelativeRoot(t *testing.T) {
+	work := t.TempDir()
+	t.Chdir(work)
+
+	root := filepath.Join("targets", strings.Repeat("nested-directory-", 10), strings.Repeat("d", 100))
+	file := filepath.Join(root, strings.Repeat("sub-", 20), "use_model.py")
+	if len(file) <= 260 {
+		t.Fatalf("test path is only %d characters long", len(file))
+	}
+	if err := os.MkdirAll(fsutil.LongPath(filepath.Dir(file)), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	src := "from transformers import AutoModel\nAutoModel.from_pretrained(\"bert-base-uncased\")\n"
+	if err := os.WriteFile(fsutil.LongPath(file), []byte(src), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	comps, err := Scan(root)
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	d, ok := findByID(comps, "bert-base-uncased")
+	if !ok {
+		t.Fatalf("model not found in %+v", comps)
+	}
+	if want := filepath.ToSlash(file); d.Path != want {
+		t.Errorf("Path = %q, want %q", d.Path, want)
+	}
+	if strings.Contains(d.Evidence, filepath.ToSlash(work)) {
+		t.Errorf("Evidence %q names the absolute path", d.Evidence)
+	}
+
+	projects, err := ScanProjects(root, Options{})
+	if err != nil {
+		t.Fatalf("ScanProjects failed: %v", err)
+	}
+	if projects[0].Root != filepath.ToSlash(root) || len(projects[0].Discoveries) != 1 || projects[0].Discoveries[0].Path != filepath.ToSlash(file) {
+		t.Errorf("ScanProjects = %+v, want the relative root and path", projects)
+	}
+}
@@ -0,0 +1,175 @@
+package scanner
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/idlab-discover/aibomgen-cli/internal/fsutil"
+)
+
+// DiscoveryTypeModelFile is the Discovery.Type of locally vendored model.
+// weights detected by file content rather than by a Hugging Face reference.
+const DiscoveryTypeModelFile = "model-file"
+
+// minModelFileSize is the smallest file considered for content sniffing.
+// Anything smaller cannot hold meaningful weights and is skipped to keep the.
+// walk cheap on source-heavy repositories.
+const minModelFileSize = 64
+
+// maxSafetensorsHeader bounds the declared JSON header length of a.
+// safetensors file (the reference implementation caps it at 100 MB).
+const maxSafetensorsHeader = 100 << 20
+
+// legacyTorchMagic is how torch.save writes its magic number.
+// 0x1950a86a20f9469cfc6c at the start of a legacy (non-zip) checkpoint: a.
+// protocol 2 pickle opcode followed by the number as a 10-byte LONG1.
+var legacyTorchMagic = []byte{0x80, 0x02, 0x8a, 0x0a, 0x6c, 0xfc, 0x9c, 0x46, 0xf9, 0x20, 0x6a, 0xa8, 0x50, 0x19}
+
+// sniffModelFile inspects the leading bytes of path and reports whether it is.
+// a known model weight format. On a match it returns a Discovery of type.
+// "model-file" carrying the file size and SHA-256 digest.
+func sniffModelFile(path string, hashOpts HashOptions) []Discovery {
+	format, detail, info := sniffModelFormat(path)
+	if format == "" {
+		return nil
+	}
+
+	sum, err := HashFile(path, hashOpts)
+	if err != nil {
+		return nil
+	}
+	if sum.FromSidecar {
+		detail += ", sha256 from sidecar"
+	}
+
+	name := filepath.Base(path)
+	return []Discovery{{
+		ID:       fsutil.Normalize(path),
+		Name:     name,
+		Type:     DiscoveryTypeModelFile,
+		Path:     path,
+		Evidence: detail + " (" + strconv.FormatInt(info.Size(), 10) + " bytes)",
+		Method:   "magic_" + format,
+		Format:   format,
+		Size:     info.Size(),
+		Hash:     sum.SHA256,
+		BLAKE3:   sum.BLAKE3,
+	}}
+}
+
+// DetectModelFile reports the weight format of the file at path (gguf,.
+// safetensors, pytorch, torchscript or onnx), or "" when its content is not a.
+// known model weight format. Only the leading bytes are read.
+func DetectModelFile(path string) string {
+	format, _, _ := sniffModelFormat(path)
+	return format
+}
+
+// sniffModelFormat opens path and detects its weight format from the leading.
+// bytes. It returns the format, the evidence string and the file info, or "".
+// for files that are not regular, too small or not model weights.
+func sniffModelFormat(path string) (string, string, os.FileInfo) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", "", nil
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil || !info.Mode().IsRegular() || info.Size() < minModelFileSize {
+		return "", "", nil
+	}
+
+	header := make([]byte, 16)
+	if _, err := io.ReadFull(f, header); err != nil {
+		return "", "", nil
+	}
+
+	ext := strings.ToLower(filepath.Ext(path))
+	format, detail := detectModelFormat(f, header, info.Size(), ext)
+	return format, detail, info
+}
+
+// detectModelFormat returns the detected format name and a short evidence.
+// string, or "" when the content does not look like model weights.
+func detectModelFormat(r io.ReaderAt, header []byte, size int64, ext string) (string, string) {
+	switch {
+	case bytes.HasPrefix(header, []byte("GGUF")):
+		version := binary.LittleEndian.Uint32(header[4:8])
+		return "gguf", "GGUF magic header v" + strconv.FormatUint(uint64(version), 10)
+
+	case isSafetensorsHeader(header, size):
+		return "safetensors", "safetensors JSON header"
+
+	case bytes.HasPrefix(header, []byte("PK\x03\x04")):
+		if kind := torchArchiveKind(r, size); kind != "" {
+			return kind, kind + " zip archive"
+		}
+
+	case bytes.HasPrefix(header, legacyTorchMagic):
+		return "pytorch", "legacy PyTorch pickle checkpoint"
+
+	case ext == ".onnx" && isONNXHeader(header):
+		return "onnx", "ONNX ModelProto header"
+	}
+	return "", ""
+}
+
+// isSafetensorsHeader checks for the safetensors layout: a little-endian.
+// uint64 header length followed by a JSON object.
+func isSafetensorsHeader(header []byte, size int64) bool {
+	n := binary.LittleEndian.Uint64(header[:8])
+	if n < 2 || n > maxSafetensorsHeader || int64(n)+8 > size {
+		return false
+	}
+	return header[8] == '{'
+}
+
+// isONNXHeader checks for the start of a protobuf-encoded ONNX ModelProto:.
+// field 1 (ir_version, varint) followed by another length-delimited or varint.
+// field such as producer_name, opset_import or graph.
+func isONNXHeader(header []byte) bool {
+	if header[0] != 0x08 || header[1] == 0 || header[1] > 20 {
+		return false
+	}
+	switch header[2] {
+	case 0x12, 0x1a, 0x22, 0x28, 0x32, 0x3a, 0x42:
+		return true
+	}
+	return false
+}
+
+// torchArchiveKind reports "torchscript" for TorchScript archives,.
+// "pytorch" for zip-based torch.save checkpoints and "" otherwise.
+func torchArchiveKind(r io.ReaderAt, size int64) string {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return ""
+	}
+	hasData, hasConstants := false, false
+	for _, zf := range zr.File {
+		base := zf.Name
+		if i := strings.Index(base, "/"); i >= 0 {
+			base = base[i+1:]
+		}
+		switch base {
+		case "data.pkl":
+			hasData = true
+		case "constants.pkl":
+			hasConstants = true
+		}
+	}
+	switch {
+	case hasData && hasConstants:
+		return "torchscript"
+	case hasData:
+		return "pytorch"
+	}
+	return ""
+}
@@ -0,0 +1,266 @@
+package scanner
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/idlab-discover/aibomgen-cli/internal/fsutil"
+	yaml "go.yaml.in/yaml/v3"
+)
+
+// ollamaRegistryHost is the host of the Ollama registry, the default of.
+// model references that name no host.
+const ollamaRegistryHost = "registry.ollama.ai"
+
+// ollamaRefPat matches an Ollama model reference:.
+// [host/][namespace/]model[:tag].
+const ollamaRefPat = `[A-Za-z0-9][A-Za-z0-9_.-]*(?:/[A-Za-z0-9][A-Za-z0-9_.-]*){0,2}(?::[A-Za-z0-9_][A-Za-z0-9_.-]*)?`
+
+var (
+	// ollamaCLIRe matches the Ollama CLI pulling or running a model:.
+	//   ollama pull llama3:8b  |  ollama run mistral "prompt".
+	ollamaCLIRe = regexp.MustCompile(`\bollama\s+(?:pull|run|show)\s+(?:--?[\w-]+(?:=\S+)?\s+)*["']?(` + ollamaRefPat + `)`)
+
+	// ollamaCallRe matches the Ollama Python and JavaScript clients and the.
+	// LangChain and LlamaIndex integrations naming a model:.
+	//   ollama.chat(model="llama3", ...)  |  ollama.pull('llama3')  |  ChatOllama(model="llama3:8b").
+	ollamaCallRe = regexp.MustCompile(`\b(?:ollama\.(?:chat|generate|pull|embed|embeddings|show)|(?:Chat)?Ollama(?:LLM|Embeddings|Embedding)?)\(\s*\{?\s*(?:[^()]*?\bmodel(?:_name)?\s*[=:]\s*)?["'](` + ollamaRefPat + `)["']`)
+
+	// ollamaEnvRe matches the model of an Ollama service set through the.
+	// environment: OLLAMA_MODEL=llama3  |  OLLAMA_MODEL: "llama3:8b".
+	ollamaEnvRe = regexp.MustCompile(`\bOLLAMA_MODEL["']?\s*[:=]\s*["']?(` + ollamaRefPat + `)`)
+
+	// modelfileFromRe matches the FROM instruction of an Ollama Modelfile.
+	modelfileFromRe = regexp.MustCompile(`(?i)^\s*FROM\s+(\S+)`)
+)
+
+// isModelfile reports whether name (lower-cased) is an Ollama Modelfile.
+func isModelfile(name string) bool {
+	return name == "modelfile" || strings.HasPrefix(name, "modelfile.") || strings.HasSuffix(name, ".modelfile")
+}
+
+// ollamaModels returns a discovery for every Ollama model reference in line:.
+// a "provider-model" discovery for models of the Ollama registry and a.
+// Hugging Face model for hf.co/org/model references.
+func ollamaModels(line string, lineNum int, path, note string) []Discovery {
+	if !strings.Contains(line, "ollama") && !strings.Contains(line, "Ollama") && !strings.Contains(line, "OLLAMA") {
+		return nil
+	}
+
+	var results []Discovery
+	for _, re := range []struct {
+		method string
+		re     *regexp.Regexp
+	}{
+		{"ollama_cli", ollamaCLIRe},
+		{"ollama_client", ollamaCallRe},
+		{"ollama_model_env", ollamaEnvRe},
+	} {
+		for _, m := range re.re.FindAllStringSubmatch(line, -1) {
+			evidence := re.method + " at line " + strconv.Itoa(lineNum) + ": " + strings.TrimSpace(line)
+			if note != "" {
+				evidence += " (" + note + ")"
+			}
+			if d, ok := ollamaDiscovery(m[1], re.method, evidence, path); ok {
+				results = append(results, d)
+			}
+		}
+	}
+	return results
+}
+
+// scanModelfile reports the model an Ollama Modelfile builds on. FROM names.
+// a model of the Ollama registry, a Hugging Face GGUF repository (hf.co/...).
+// or a local weight file, which is reported like the GGUF files passed to.
+// llama.cpp.
+func scanModelfile(path string) []Discovery {
+	const method = "ollama_modelfile"
+	lines, err := readLines(path)
+	if err != nil {
+		return nil
+	}
+	var results []Discovery
+	for i, line := range lines {
+		m := modelfileFromRe.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		ref := strings.Trim(m[1], `"'`)
+		evidence := method + " at line " + strconv.Itoa(i+1) + ": " + strings.TrimSpace(line)
+		if strings.HasPrefix(ref, ".") || strings.HasPrefix(ref, "/") || strings.HasPrefix(ref, "~") || hasWeightFileExt(ref) {
+			weights := ref
+			if !filepath.IsAbs(weights) && !strings.HasPrefix(weights, "/") {
+				weights = filepath.Join(filepath.Dir(path), filepath.FromSlash(ref))
+			}
+			format := ""
+			if strings.EqualFold(filepath.Ext(ref), ".gguf") {
+				format = "gguf"
+			}
+			results = append(results, Discovery{
+				ID:       weights,
+				Name:     filepath.Base(filepath.FromSlash(ref)),
+				Type:     DiscoveryTypeModelFile,
+				Path:     weights,
+				Evidence: method + " at line " + strconv.Itoa(i+1) + " of " + fsutil.Normalize(path) + ": " + strings.TrimSpace(line),
+				Method:   method,
+				Format:   format,
+			})
+			continue
+		}
+		if d, ok := ollamaDiscovery(ref, method, evidence, path); ok {
+			results = append(results, d)
+		}
+	}
+	return results
+}
+
+// scanOllamaCompose adds the models of the Ollama services declared in the.
+// Compose file at path to results. The ollama/ollama image runs the ollama.
+// CLI, so a service command such as "pull llama3" or ["run", "llama3"] names.
+// a model without mentioning ollama.
+func scanOllamaCompose(path string, results []Discovery) []Discovery {
+	data, err := os.ReadFile(path)
+	if err != nil || !bytes.Contains(data, []byte("ollama/ollama")) {
+		return results
+	}
+	var doc yaml.Node
+	if yaml.Unmarshal(data, &doc) != nil || len(doc.Content) == 0 {
+		return results
+	}
+	lines := strings.Split(string(data), "\n")
+
+	const method = "ollama_compose_command"
+	services := mappingValue(doc.Content[0], "services")
+	if services == nil || services.Kind != yaml.MappingNode {
+		return results
+	}
+	for i := 0; i+1 < len(services.Content); i += 2 {
+		svc := services.Content[i+1]
+		image := scalarValue(svc, "image")
+		if !strings.HasPrefix(image, "ollama/ollama") && !strings.Contains(image, "/ollama/ollama") {
+			continue
+		}
+		cmd := mappingValue(svc, "command")
+		if cmd == nil {
+			continue
+		}
+		var args []string
+		switch cmd.Kind {
+		case yaml.ScalarNode:
+			args = strings.Fields(cmd.Value)
+		case yaml.SequenceNode:
+			for _, a := range cmd.Content {
+				args = append(args, a.Value)
+			}
+		}
+		if len(args) < 2 || (args[0] != "pull" && args[0] != "run") {
+			continue
+		}
+		if d, ok := ollamaDiscovery(args[1], method, method+lineEvidence(lines, cmd.Line), path); ok {
+			results = append(results, d)
+		}
+	}
+	return results
+}
+
+// ollamaDiscovery turns an Ollama model reference into a discovery. Models.
+// of the Ollama registry become "ollama:name:tag" provider models; Ollama.
+// pulls GGUF repositories of the Hugging Face Hub with hf.co/org/model[:quant].
+// references, which become Hugging Face models.
+func ollamaDiscovery(ref, method, evidence, path string) (Discovery, bool) {
+	ref = strings.Trim(strings.TrimSpace(ref), `"'`)
+	for _, host := range []string{"hf.co/", "huggingface.co/"} {
+		if rest, ok := strings.CutPrefix(ref, host); ok {
+			id, _, _ := strings.Cut(rest, ":")
+			if !hfIDSlashRe.MatchString(id) || !isPlausibleModelID(id) {
+				return Discovery{}, false
+			}
+			return Discovery{ID: id, Name: id, Type: "model", Path: path, Evidence: evidence, Method: method}, true
+		}
+	}
+
+	name, tag, ok := parseOllamaRef(ref)
+	if !ok {
+		return Discovery{}, false
+	}
+	return Discovery{
+		ID:       ProviderOllama + ":" + name + ":" + tag,
+		Name:     name,
+		Type:     DiscoveryTypeProviderModel,
+		Path:     path,
+		Evidence: evidence,
+		Method:   method,
+		Version:  tag,
+	}, true
+}
+
+// parseOllamaRef splits a reference to a model of the Ollama registry into.
+// the name Ollama shows (llama3, or namespace/model outside the library) and.
+// the tag, "latest" when the reference names none. ok is false for models of.
+// other registries and for references that are not model names.
+func parseOllamaRef(ref string) (name, tag string, ok bool) {
+	if ref == "" || hasWeightFileExt(ref) || strings.Contains(ref, "{") || strings.Contains(ref, "$") {
+		return "", "", false
+	}
+	name, tag = ref, "latest"
+	if i := strings.LastIndex(ref, ":"); i > strings.LastIndex(ref, "/") {
+		name, tag = ref[:i], ref[i+1:]
+	}
+
+	parts := strings.Split(name, "/")
+	if len(parts) == 3 {
+		if parts[0] != ollamaRegistryHost {
+			return "", "", false
+		}
+		parts = parts[1:]
+	}
+	if len(parts) == 2 && parts[0] == "library" {
+		parts = parts[1:]
+	}
+	// A first segment with a dot is the host of another registry.
+	if len(parts) == 2 && strings.Contains(parts[0], ".") {
+		return "", "", false
+	}
+	name = strings.ToLower(strings.Join(parts, "/"))
+	if !isPlausibleOllamaName(name) {
+		return "", "", false
+	}
+	return name, tag, true
+}
+
+// isPlausibleOllamaName rejects names that are commands, flags or words.
+// rather than models (ollama run --help, ollama pull the model).
+func isPlausibleOllamaName(name string) bool {
+	switch name {
+	case "", "the", "a", "model", "models", "your", "my", "it", "this", "serve", "help":
+		return false
+	}
+	return !strings.HasPrefix(name, "-")
+}
+
+// OllamaRef splits the ID of an Ollama "provider-model" discovery into the.
+// namespace of the model in the Ollama registry ("library" for official.
+// models), the model and the tag. ok is false for other discoveries.
+func OllamaRef(d Discovery) (namespace, model, tag string, ok bool) {
+	if d.Type != DiscoveryTypeProviderModel {
+		return "", "", "", false
+	}
+	rest, found := strings.CutPrefix(d.ID, ProviderOllama+":")
+	if !found {
+		return "", "", "", false
+	}
+	i := strings.LastIndex(rest, ":")
+	if i <= 0 {
+		return "", "", "", false
+	}
+	name, tag := rest[:i], rest[i+1:]
+	namespace, model, found = strings.Cut(name, "/")
+	if !found {
+		namespace, model = "library", name
+	}
+	return namespace, model, tag, true
+}
@@ -0,0 +1,229 @@
+package scanner
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	yaml "go.yaml.in/yaml/v3"
+)
+
+// DiscoveryTypeDataFile is the Discovery.Type of a dataset artifact tracked.
+// by DVC or Git LFS. Like a "model-file" discovery it carries the format,.
+// size and digest of the artifact; ID and Path are the artifact's path.
+const DiscoveryTypeDataFile = "data-file"
+
+// HashAlgorithmMD5 is the Discovery.HashAlgorithm of DVC-tracked outputs.
+const HashAlgorithmMD5 = "MD5"
+
+// lfsPointerVersion is the first line of a Git LFS pointer file.
+const lfsPointerVersion = "version https://git-lfs.github.com/spec/v1"
+
+// maxLFSPointerSize is the size limit of a Git LFS pointer file.
+const maxLFSPointerSize = 1024
+
+// minTrackedArtifactSize is the size from which a tracked file without a.
+// model or data extension counts as an artifact when it sits in a models/.
+// or data/ directory.
+const minTrackedArtifactSize = 1 << 20
+
+// trackedModelExts maps the extensions of tracked model artifacts to a.
+// format name, in addition to weightURLExts.
+var trackedModelExts = map[string]string{
+	".pkl":     "pickle",
+	".joblib":  "joblib",
+	".keras":   "keras",
+	".msgpack": "flax",
+	".pb":      "tensorflow",
+	".mlmodel": "coreml",
+	".pte":     "executorch",
+}
+
+// trackedDataExts lists the extensions of tracked dataset artifacts.
+var trackedDataExts = map[string]bool{
+	".parquet": true, ".arrow": true, ".feather": true, ".avro": true,
+	".csv": true, ".tsv": true, ".jsonl": true, ".tfrecord": true,
+	".npy": true, ".npz": true,
+}
+
+// dvcOut is an output of a .dvc file or a dvc.lock stage.
+type dvcOut struct {
+	Path   string `yaml:"path"`
+	MD5    string `yaml:"md5"`
+	Size   int64  `yaml:"size"`
+	NFiles int    `yaml:"nfiles"`
+	Hash   string `yaml:"hash"`   // "md5" for outputs of DVC 3
+	Remote string `yaml:"remote"` // remote of the output; "" for the default
+}
+
+// scanDVCFile reports the model and dataset outputs tracked by a .dvc file.
+func scanDVCFile(path string) []Discovery {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var file struct {
+		Outs []dvcOut `yaml:"outs"`
+	}
+	if yaml.Unmarshal(data, &file) != nil {
+		return nil
+	}
+	return dvcDiscoveries(path, file.Outs, "")
+}
+
+// scanDVCLock reports the model and dataset outputs of the stages in a.
+// dvc.lock file.
+func scanDVCLock(path string) []Discovery {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var lock struct {
+		Stages map[string]struct {
+			Outs []dvcOut `yaml:"outs"`
+		} `yaml:"stages"`
+	}
+	if yaml.Unmarshal(data, &lock) != nil {
+		return nil
+	}
+	var results []Discovery
+	for name, stage := range lock.Stages {
+		results = append(results, dvcDiscoveries(path, stage.Outs, name)...)
+	}
+	return results
+}
+
+// dvcDiscoveries turns the outputs listed in the DVC file at path into.
+// discoveries. Output paths are relative to the directory of the file.
+func dvcDiscoveries(path string, outs []dvcOut, stage string) []Discovery {
+	var results []Discovery
+	for _, out := range outs {
+		if out.Path == "" || out.MD5 == "" {
+			continue
+		}
+		digest, isDir := strings.CutSuffix(out.MD5, ".dir")
+		artifact := filepath.Join(filepath.Dir(path), filepath.FromSlash(out.Path))
+		typ, format, ok := classifyTrackedArtifact(artifact, out.Size, isDir)
+		if !ok {
+			continue
+		}
+
+		evidence := "DVC-tracked output in " + filepath.Base(path)
+		if stage != "" {
+			evidence += " (stage " + stage + ")"
+		}
+		evidence += ": md5 " + digest
+		if isDir {
+			evidence += " of the listing of " + strconv.Itoa(out.NFiles) + " files"
+		}
+		if out.Size > 0 {
+			evidence += " (" + strconv.FormatInt(out.Size, 10) + " bytes)"
+		}
+		results = append(results, Discovery{
+			ID:            artifact,
+			Name:          filepath.Base(artifact),
+			Type:          typ,
+			Path:          artifact,
+			Evidence:      evidence,
+			Method:        "dvc_pointer",
+			Format:        format,
+			Size:          out.Size,
+			Hash:          digest,
+			HashAlgorithm: HashAlgorithmMD5,
+			StorageURI:    dvcStorageURI(path, out),
+		})
+	}
+	return results
+}
+
+// scanLFSPointer reports the artifact a Git LFS pointer file stands in for,.
+// or nil when path is not a pointer to a model or dataset artifact.
+func scanLFSPointer(path string) []Discovery {
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() || info.Size() > maxLFSPointerSize {
+		return nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	if !sc.Scan() || sc.Text() != lfsPointerVersion {
+		return nil
+	}
+	var oid string
+	var size int64 = -1
+	for sc.Scan() {
+		key, value, _ := strings.Cut(sc.Text(), " ")
+		switch key {
+		case "oid":
+			oid, _ = strings.CutPrefix(value, "sha256:")
+		case "size":
+			size, _ = strconv.ParseInt(value, 10, 64)
+		}
+	}
+	if len(oid) != 64 || size < 0 {
+		return nil
+	}
+
+	typ, format, ok := classifyTrackedArtifact(path, size, false)
+	if !ok {
+		return nil
+	}
+	return []Discovery{{
+		ID:       path,
+		Name:     filepath.Base(path),
+		Type:     typ,
+		Path:     path,
+		Evidence: "Git LFS pointer: sha256 " + oid + " (" + strconv.FormatInt(size, 10) + " bytes, not checked out)",
+		Method:   "git_lfs_pointer",
+		Format:   format,
+		Size:     size,
+		Hash:     oid,
+	}}
+}
+
+// classifyTrackedArtifact decides from its path and size whether a file or.
+// directory tracked by DVC or Git LFS is a model ("model-file") or a dataset.
+// ("data-file") artifact, and returns its format. Model and data extensions.
+// decide on their own; other files count from minTrackedArtifactSize when.
+// their directory or its parent is named models, checkpoints, weights, data.
+// or datasets, and directories when they are so named themselves.
+func classifyTrackedArtifact(path string, size int64, isDir bool) (typ, format string, ok bool) {
+	ext := strings.ToLower(filepath.Ext(path))
+	if !isDir {
+		if f, ok := weightURLExts[ext]; ok {
+			return DiscoveryTypeModelFile, f, true
+		}
+		if f, ok := trackedModelExts[ext]; ok {
+			return DiscoveryTypeModelFile, f, true
+		}
+		if trackedDataExts[ext] {
+			return DiscoveryTypeDataFile, strings.TrimPrefix(ext, "."), true
+		}
+		if size < minTrackedArtifactSize {
+			return "", "", false
+		}
+	}
+
+	format = strings.TrimPrefix(ext, ".")
+	names := []string{filepath.Base(filepath.Dir(path)), filepath.Base(filepath.Dir(filepath.Dir(path)))}
+	if isDir {
+		// A directory output is named after what it holds (data.dvc).
+		format = "directory"
+		names = append([]string{filepath.Base(path)}, names...)
+	}
+	for _, name := range names {
+		switch strings.ToLower(name) {
+		case "model", "models", "checkpoints", "weights":
+			return DiscoveryTypeModelFile, format, true
+		case "data", "dataset", "datasets":
+			return DiscoveryTypeDataFile, format, true
+		}
+	}
+	return "", "", false
+}
@@ -0,0 +1,115 @@
+package scanner
+
+import (
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/idlab-discover/aibomgen-cli/internal/fsutil"
+)
+
+// ProjectMarkers are the file names that mark the root directory of a.
+// project inside a scanned tree.
+var ProjectMarkers = []string{"pyproject.toml", "package.json", "go.mod"}
+
+// Project is a directory containing one of [ProjectMarkers] together with.
+// the discoveries made in the files it owns. Root is slash-separated like.
+// [Discovery.Path].
+type Project struct {
+	Root        string
+	Discoveries []Discovery
+}
+
+// ScanProjects is like [ScanWithOptions] but partitions the tree into.
+// projects first. Every file belongs to the deepest project directory that.
+// contains it; files outside any project belong to root, which is always.
+// the first returned project. Discoveries are deduplicated per project, so.
+// a model used by two projects is reported for both. Projects without.
+// discoveries are included.
+func ScanProjects(root string, opts Options) ([]Project, error) {
+	longRoot := fsutil.LongPath(filepath.Clean(root))
+	roots := []string{longRoot}
+	seen := map[string]bool{longRoot: true}
+	var paths []string
+	err := filepath.WalkDir(longRoot, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if shouldSkipDir(d.Name()) || ignored(opts.Ignore, root, path, true) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if ignored(opts.Ignore, root, path, false) {
+			return nil
+		}
+		if dir := filepath.Dir(path); isProjectMarker(d.Name()) && !seen[dir] {
+			seen[dir] = true
+			roots = append(roots, dir)
+		}
+		paths = append(paths, path)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Match the deepest project first.
+	byDepth := make([]int, len(roots))
+	for i := range byDepth {
+		byDepth[i] = i
+	}
+	sort.SliceStable(byDepth, func(a, b int) bool {
+		return len(roots[byDepth[a]]) > len(roots[byDepth[b]])
+	})
+
+	owned := make([][]string, len(roots))
+	for _, p := range paths {
+		for _, i := range byDepth {
+			if i == 0 || isWithin(p, roots[i]) {
+				owned[i] = append(owned[i], p)
+				break
+			}
+		}
+	}
+
+	relocate := fsutil.Relocator(root)
+	projects := make([]Project, len(roots))
+	for i, r := range roots {
+		projectRoot := relocate(fsutil.Normalize(r))
+		projects[i] = Project{
+			Root:        projectRoot,
+			Discoveries: scanPaths(filepath.FromSlash(projectRoot), owned[i], opts),
+		}
+	}
+	return projects, nil
+}
+
+// ProjectDiscoveries returns the discoveries of all projects, deduplicated.
+// across projects.
+func ProjectDiscoveries(projects []Project) []Discovery {
+	var all []Discovery
+	for _, p := range projects {
+		all = append(all, p.Discoveries...)
+	}
+	if len(all) == 0 {
+		return nil
+	}
+	return dedupe(all)
+}
+
+func isProjectMarker(name string) bool {
+	for _, m := range ProjectMarkers {
+		if name == m {
+			return true
+		}
+	}
+	return false
+}
+
+// isWithin reports whether path lies inside the directory dir.
+func isWithin(path, dir string) bool {
+	return strings.HasPrefix(path, dir+string(filepath.Separator))
+}
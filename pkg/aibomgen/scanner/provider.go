@@ -0,0 +1,234 @@
+package scanner
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DiscoveryTypeProviderModel is the Discovery.Type of models hosted by a.
+// provider other than the Hugging Face Hub: NVIDIA NGC models and NIM.
+// containers, Meta's gated Llama downloads and models of the Ollama registry.
+// ID is the provider-qualified identity ("ngc:nvidia/tao/peoplenet:v2.6",.
+// "meta:Llama3.1-8B-Instruct", "ollama:llama3:8b"),.
+// Name the model without provider and version and Version the version, when.
+// the reference names one.
+const DiscoveryTypeProviderModel = "provider-model"
+
+// Model providers, the prefix of a provider model ID.
+const (
+	ProviderNGC    = "ngc"
+	ProviderMeta   = "meta"
+	ProviderOllama = "ollama"
+)
+
+// ngcPart matches one segment of an NGC path (org, team, model or version).
+const ngcPart = `[A-Za-z0-9][A-Za-z0-9_.-]*`
+
+var (
+	// ngcCLIRe matches the NGC CLI addressing a model, optionally with a.
+	// version:.
+	//   ngc registry model download-version nvidia/tao/peoplenet:pruned_v2.6.
+	ngcCLIRe = regexp.MustCompile(`\bngc\s+registry\s+model\s+(?:download-version|info|list-versions)\s+(?:--[\w-]+=\S+\s+)*["']?(` + ngcPart + `(?:/` + ngcPart + `){1,2})(?::(` + ngcPart + `))?`)
+
+	// ngcAPIRe matches an NGC API model URL:.
+	//   https://api.ngc.nvidia.com/v2/models/nvidia/tao/peoplenet/versions/v2.6/zip.
+	ngcAPIRe = regexp.MustCompile(`\bapi\.ngc\.nvidia\.com/v2/models/(` + ngcPart + `(?:/` + ngcPart + `){1,2})/versions/(` + ngcPart + `)`)
+
+	// ngcAPIOrgRe matches the org/team form of an NGC API model URL:.
+	//   https://api.ngc.nvidia.com/v2/models/org/nvidia/team/nemo/parakeet/1.0/files.
+	ngcAPIOrgRe = regexp.MustCompile(`\bapi\.ngc\.nvidia\.com/v2/models/org/(` + ngcPart + `)/(?:team/(` + ngcPart + `)/)?(` + ngcPart + `)/(` + ngcPart + `)/files`)
+
+	// ngcCatalogRe matches an NGC catalog page of a model:.
+	//   https://catalog.ngc.nvidia.com/orgs/nvidia/teams/tao/models/peoplenet.
+	ngcCatalogRe = regexp.MustCompile(`\bcatalog\.ngc\.nvidia\.com/orgs/(` + ngcPart + `)/(?:teams/(` + ngcPart + `)/)?models/(` + ngcPart + `)`)
+
+	// nimImageRe matches an NVIDIA NIM container image, which packages a.
+	// model: nvcr.io/nim/meta/llama-3.1-8b-instruct:1.2.2.
+	nimImageRe = regexp.MustCompile(`\bnvcr\.io/nim/(` + ngcPart + `)/(` + ngcPart + `)(?::(` + ngcPart + `))?`)
+
+	// llamaCLIRe matches Meta's llama CLI downloading from Meta:.
+	//   llama model download --source meta --model-id Llama3.1-8B-Instruct.
+	llamaCLIRe = regexp.MustCompile(`\bllama\s+(?:model\s+)?download\b`)
+
+	// llamaModelIDRe matches the --model-id argument of the llama CLI, a.
+	// comma-separated list of model descriptors.
+	llamaModelIDRe = regexp.MustCompile(`--model-id(?:\s*=\s*|\s+)["']?([A-Za-z0-9][A-Za-z0-9_.,-]*)`)
+
+	// llamaSourceRe matches the --source argument of the llama CLI.
+	llamaSourceRe = regexp.MustCompile(`--source(?:\s*=\s*|\s+)["']?(\w+)`)
+
+	// llamaURLRe matches Meta's signed Llama download URLs:.
+	//   https://llama3-1.llamameta.net/*?Policy=...  |  https://download.llamameta.net/Llama-2-7b/consolidated.00.pth?Policy=....
+	llamaURLRe = regexp.MustCompile(`\bhttps?://([a-z0-9-]+)\.llamameta\.net/([^\s"'?]*)(\?[^\s"']*)?`)
+)
+
+// providerModels returns a "provider-model" discovery for every NGC or Meta.
+// Llama model reference in line.
+func providerModels(line string, lineNum int, path, note string) []Discovery {
+	if !strings.Contains(line, "ngc") && !strings.Contains(line, "nvcr.io") && !strings.Contains(line, "llama") {
+		return nil
+	}
+
+	var results []Discovery
+	add := func(method, provider, name, version, evidence string) {
+		id := provider + ":" + name
+		if version != "" {
+			id += ":" + version
+		}
+		evidence = method + " at line " + strconv.Itoa(lineNum) + ": " + strings.TrimSpace(evidence)
+		if note != "" {
+			evidence += " (" + note + ")"
+		}
+		results = append(results, Discovery{
+			ID:       id,
+			Name:     name,
+			Type:     DiscoveryTypeProviderModel,
+			Path:     path,
+			Evidence: evidence,
+			Method:   method,
+			Version:  version,
+		})
+	}
+
+	for _, m := range ngcCLIRe.FindAllStringSubmatch(line, -1) {
+		add("ngc_cli", ProviderNGC, m[1], m[2], line)
+	}
+	for _, m := range ngcAPIRe.FindAllStringSubmatch(line, -1) {
+		add("ngc_api_url", ProviderNGC, m[1], m[2], line)
+	}
+	for _, m := range ngcAPIOrgRe.FindAllStringSubmatch(line, -1) {
+		add("ngc_api_url", ProviderNGC, joinNonEmpty(m[1], m[2], m[3]), m[4], line)
+	}
+	for _, m := range ngcCatalogRe.FindAllStringSubmatch(line, -1) {
+		add("ngc_catalog_url", ProviderNGC, joinNonEmpty(m[1], m[2], m[3]), "", line)
+	}
+	for _, m := range nimImageRe.FindAllStringSubmatch(line, -1) {
+		d := len(results)
+		add("ngc_nim_image", ProviderNGC, "nim/"+m[1]+"/"+m[2], m[3], line)
+		results[d].Format = "nim"
+	}
+
+	if llamaCLIRe.MatchString(line) {
+		source := "meta"
+		if m := llamaSourceRe.FindStringSubmatch(line); m != nil {
+			source = strings.ToLower(m[1])
+		}
+		// With --source huggingface the weights come from the meta-llama.
+		// repositories on the Hub instead.
+		if m := llamaModelIDRe.FindStringSubmatch(line); m != nil && source == "meta" {
+			for _, id := range strings.Split(m[1], ",") {
+				if id = normalizeLlamaModelID(id); id != "" {
+					add("meta_llama_cli", ProviderMeta, id, "", line)
+				}
+			}
+		}
+	}
+
+	if matches := llamaURLRe.FindAllStringSubmatchIndex(line, -1); matches != nil {
+		// The signed query grants access to the weights; keep it out of the.
+		// evidence.
+		evidence := line
+		for i := len(matches) - 1; i >= 0; i-- {
+			if qs, qe := matches[i][6], matches[i][7]; qs >= 0 && qe > qs+1 {
+				evidence = evidence[:qs] + "?REDACTED" + evidence[qe:]
+			}
+		}
+		for _, m := range matches {
+			add("meta_llama_download_url", ProviderMeta, llamaURLModel(line[m[2]:m[3]], line[m[4]:m[5]]), "", evidence)
+		}
+	}
+	return results
+}
+
+// llamaURLModel names the model of a Meta download URL: the first path.
+// segment when it names a model folder, else the model family served by the.
+// host (llama3-1.llamameta.net serves Llama 3.1). The download script.
+// replaces a "*" path with the files of the selected models.
+func llamaURLModel(host, urlPath string) string {
+	folder, _, _ := strings.Cut(urlPath, "/")
+	if id := normalizeLlamaModelID(folder); id != "" {
+		return id
+	}
+	if strings.HasPrefix(host, "download") {
+		return "Llama2"
+	}
+	// llama3-2-lightweight serves the lightweight Llama 3.2 models.
+	parts := strings.Split(strings.TrimPrefix(host, "llama"), "-")
+	family, rest := parts[0], parts[1:]
+	if len(rest) > 0 && isDigits(rest[0]) {
+		family += "." + rest[0]
+		rest = rest[1:]
+	}
+	return strings.Join(append([]string{"Llama" + family}, rest...), "-")
+}
+
+// normalizeLlamaModelID returns a Meta model descriptor in the form the llama.
+// CLI uses (Llama3.1-8B-Instruct) for the folder names of Meta's downloads.
+// (Meta-Llama-3.1-8B-Instruct, Llama-3.1-8B-Instruct), or "" when id does.
+// not name a Llama model.
+func normalizeLlamaModelID(id string) string {
+	id = strings.Trim(strings.TrimSpace(id), `"'`)
+	id = strings.TrimPrefix(id, "Meta-")
+	if rest, ok := strings.CutPrefix(id, "Llama-"); ok && rest != "" && rest[0] >= '0' && rest[0] <= '9' {
+		id = "Llama" + rest
+	}
+	if !strings.HasPrefix(strings.ToLower(id), "llama") && !strings.HasPrefix(strings.ToLower(id), "prompt-guard") {
+		return ""
+	}
+	return id
+}
+
+// ProviderModelURL returns the catalog page of a "provider-model" discovery,.
+// or "" for other discoveries.
+func ProviderModelURL(d Discovery) string {
+	if d.Type != DiscoveryTypeProviderModel {
+		return ""
+	}
+	provider, _, _ := strings.Cut(d.ID, ":")
+	switch provider {
+	case ProviderNGC:
+		if rest, ok := strings.CutPrefix(d.Name, "nim/"); ok {
+			team, model, _ := strings.Cut(rest, "/")
+			return "https://catalog.ngc.nvidia.com/orgs/nim/teams/" + team + "/containers/" + model
+		}
+		parts := strings.Split(d.Name, "/")
+		if len(parts) == 3 {
+			return "https://catalog.ngc.nvidia.com/orgs/" + parts[0] + "/teams/" + parts[1] + "/models/" + parts[2]
+		}
+		if len(parts) == 2 {
+			return "https://catalog.ngc.nvidia.com/orgs/" + parts[0] + "/models/" + parts[1]
+		}
+	case ProviderMeta:
+		return "https://www.llama.com/llama-downloads/"
+	case ProviderOllama:
+		if strings.Contains(d.Name, "/") {
+			return "https://ollama.com/" + d.Name + ":" + d.Version
+		}
+		return "https://ollama.com/library/" + d.Name + ":" + d.Version
+	}
+	return ""
+}
+
+// joinNonEmpty joins the non-empty parts with "/".
+func joinNonEmpty(parts ...string) string {
+	var out []string
+	for _, p := range parts {
+		if p != "" {
+			out = append(out, p)
+		}
+	}
+	return strings.Join(out, "/")
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
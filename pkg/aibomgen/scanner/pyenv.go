@@ -0,0 +1,337 @@
+package scanner
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/idlab-discover/aibomgen-cli/internal/fsutil"
+)
+
+// pyPackage is a distribution installed in a Python environment.
+type pyPackage struct {
+	name    string
+	version string
+	site    string // the site-packages directory it is installed in
+}
+
+// spacyMeta is the part of a spaCy pipeline's meta.json that is reported.
+type spacyMeta struct {
+	Lang         string `json:"lang"`
+	Name         string `json:"name"`
+	Version      string `json:"version"`
+	SpacyVersion string `json:"spacy_version"`
+}
+
+// ScanPythonEnv reports the model weights installed in a Python environment:.
+// a virtualenv, a conda environment, a site-packages directory or a model.
+// cache directory. Every discovery is a "model-file" with the size and.
+// SHA-256 digest of the weights; Version is set when it is known.
+//.
+//   - spaCy pipeline packages (en_core_web_sm, ...) yield one discovery per.
+//     package with the package version. Their weights are spread over many.
+//     files, so Hash is the SHA-256 of the sha256sum listing of the pipeline.
+//     directory (see [DirectoryDigest]).
+//   - Weight files in a Hugging Face cache snapshot (as used by.
+//     sentence-transformers >= 2.3) are named after the repo, with the.
+//     snapshot revision as Version.
+//   - Weight files in a sentence-transformers model directory (one with a.
+//     modules.json) are named after the directory.
+//   - Torch hub checkpoints (hub/checkpoints/*.pth) keep their file name.
+//   - Other weight files owned by an installed package (per its RECORD) are.
+//     named after their path in site-packages, with the package version.
+//.
+// Options.Ignore and Options.Hash apply as for [ScanWithOptions].
+func ScanPythonEnv(root string, opts Options) ([]Discovery, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", root)
+	}
+
+	var files, distInfos []string
+	err = filepath.WalkDir(fsutil.LongPath(root), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			switch {
+			case ignored(opts.Ignore, root, path, true), d.Name() == "__pycache__":
+				return filepath.SkipDir
+			case strings.HasSuffix(d.Name(), ".dist-info"):
+				distInfos = append(distInfos, path)
+				return filepath.SkipDir
+			case d.Name() == "blobs" && isHubCacheRepo(filepath.Base(filepath.Dir(path))):
+				// The snapshot files link here and are reported by name.
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !ignored(opts.Ignore, root, path, false) {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var results []Discovery
+	owners := make(map[string]pyPackage)
+	var spacyDirs []string
+	for _, di := range distInfos {
+		pkg, ok := readDistInfo(di)
+		if !ok {
+			continue
+		}
+		for _, f := range readRecord(di) {
+			owners[filepath.Join(pkg.site, filepath.FromSlash(f))] = pkg
+		}
+		if d, dir, ok := spacyPipeline(pkg, opts.Hash); ok {
+			results = append(results, d)
+			spacyDirs = append(spacyDirs, dir)
+		}
+	}
+
+	for _, path := range files {
+		if underAny(path, spacyDirs) {
+			continue
+		}
+		hits := sniffModelFile(path, opts.Hash)
+		if len(hits) == 0 {
+			continue
+		}
+		d := hits[0]
+		attributeEnvFile(&d, fsutil.LongPath(root), path, owners)
+		results = append(results, d)
+	}
+
+	for i := range results {
+		results[i].Path = fsutil.Normalize(results[i].Path)
+		results[i].ID = results[i].Path
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].Path < results[j].Path })
+	return results, nil
+}
+
+// attributeEnvFile names the weight file d at path below root after the.
+// model cache or package it belongs to.
+func attributeEnvFile(d *Discovery, root, path string, owners map[string]pyPackage) {
+	dirs := ancestors(filepath.Dir(path), root)
+
+	// <cache>/models--org--name/snapshots/<revision>/<file>.
+	for _, p := range dirs {
+		snapshots := filepath.Dir(p)
+		repo := filepath.Base(filepath.Dir(snapshots))
+		if filepath.Base(snapshots) != "snapshots" || !isHubCacheRepo(repo) {
+			continue
+		}
+		id := strings.ReplaceAll(strings.SplitN(repo, "--", 2)[1], "--", "/")
+		rel, _ := filepath.Rel(p, path)
+		d.Name = id + "/" + filepath.ToSlash(rel)
+		d.Version = filepath.Base(p)
+		d.Method = "python_env_hf_cache"
+		d.Evidence = "Hugging Face cache snapshot " + d.Version + " of " + id + ": " + d.Evidence
+		return
+	}
+
+	// A sentence-transformers model directory has a modules.json.
+	for _, p := range dirs {
+		if _, err := os.Stat(filepath.Join(p, "modules.json")); err != nil {
+			continue
+		}
+		rel, _ := filepath.Rel(p, path)
+		d.Name = filepath.Base(p) + "/" + filepath.ToSlash(rel)
+		d.Method = "python_env_sentence_transformers"
+		d.Evidence = "sentence-transformers model " + filepath.Base(p) + ": " + d.Evidence
+		return
+	}
+
+	if dir := filepath.Dir(path); filepath.Base(dir) == "checkpoints" && filepath.Base(filepath.Dir(dir)) == "hub" {
+		d.Method = "python_env_torch_hub"
+		d.Evidence = "torch hub checkpoint: " + d.Evidence
+		return
+	}
+
+	if pkg, ok := owners[path]; ok {
+		rel, _ := filepath.Rel(pkg.site, path)
+		d.Name = filepath.ToSlash(rel)
+		d.Version = pkg.version
+		d.Method = "python_env_package"
+		d.Evidence = "installed with " + pkg.name + " " + pkg.version + ": " + d.Evidence
+	}
+}
+
+// ancestors returns dir and its parents up to and including root.
+func ancestors(dir, root string) []string {
+	var out []string
+	for p := dir; ; p = filepath.Dir(p) {
+		out = append(out, p)
+		if p == root || filepath.Dir(p) == p {
+			return out
+		}
+	}
+}
+
+// isHubCacheRepo reports whether name is a repo directory of the Hugging.
+// Face cache (models--org--name, datasets--org--name, ...).
+func isHubCacheRepo(name string) bool {
+	for _, prefix := range []string{"models--", "datasets--", "spaces--"} {
+		if rest, ok := strings.CutPrefix(name, prefix); ok && rest != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// readDistInfo reads the name and version of an installed distribution from.
+// the METADATA file of its .dist-info directory.
+func readDistInfo(dir string) (pyPackage, bool) {
+	f, err := os.Open(filepath.Join(dir, "METADATA"))
+	if err != nil {
+		return pyPackage{}, false
+	}
+	defer f.Close()
+
+	pkg := pyPackage{site: filepath.Dir(dir)}
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := sc.Text()
+		if line == "" {
+			break // end of the headers
+		}
+		if v, ok := strings.CutPrefix(line, "Name:"); ok {
+			pkg.name = strings.TrimSpace(v)
+		} else if v, ok := strings.CutPrefix(line, "Version:"); ok {
+			pkg.version = strings.TrimSpace(v)
+		}
+	}
+	return pkg, pkg.name != ""
+}
+
+// readRecord returns the files listed in the RECORD of a .dist-info.
+// directory, relative to site-packages.
+func readRecord(dir string) []string {
+	f, err := os.Open(filepath.Join(dir, "RECORD"))
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = -1
+	var out []string
+	for {
+		rec, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return out
+		}
+		if len(rec) > 0 && rec[0] != "" {
+			out = append(out, rec[0])
+		}
+	}
+	return out
+}
+
+// spacyPipeline returns the discovery of pkg when it is a spaCy pipeline.
+// package, with the pipeline directory it covers.
+func spacyPipeline(pkg pyPackage, hashOpts HashOptions) (Discovery, string, bool) {
+	dir := filepath.Join(pkg.site, strings.ReplaceAll(strings.ToLower(pkg.name), "-", "_"))
+	data, err := os.ReadFile(filepath.Join(dir, "meta.json"))
+	if err != nil {
+		return Discovery{}, "", false
+	}
+	var meta spacyMeta
+	if json.Unmarshal(data, &meta) != nil || meta.SpacyVersion == "" {
+		return Discovery{}, "", false
+	}
+
+	digest, size, count, err := DirectoryDigest(dir, hashOpts)
+	if err != nil {
+		return Discovery{}, "", false
+	}
+	version := pkg.version
+	if version == "" {
+		version = meta.Version
+	}
+	return Discovery{
+		Name:     pkg.name,
+		Type:     DiscoveryTypeModelFile,
+		Path:     dir,
+		Evidence: "spaCy " + meta.Lang + " pipeline " + pkg.name + " " + version + " for spacy" + meta.SpacyVersion + " (" + strconv.Itoa(count) + " files, " + strconv.FormatInt(size, 10) + " bytes)",
+		Method:   "python_env_spacy",
+		Format:   "spacy",
+		Size:     size,
+		Hash:     digest,
+		Version:  version,
+	}, dir, true
+}
+
+// DirectoryDigest computes the SHA-256 of the sha256sum listing of the files.
+// below dir: one "<sha256>  <path>\n" line per file, with slash-separated.
+// paths relative to dir, sorted by path. The result equals.
+//
+//	find . -type f | sed 's|^\./||' | LC_ALL=C sort | xargs sha256sum | sha256sum
+//
+// run in dir. It also returns the total size and number of files.
+func DirectoryDigest(dir string, opts HashOptions) (string, int64, int, error) {
+	var rels []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type().IsRegular() {
+			rel, _ := filepath.Rel(dir, path)
+			rels = append(rels, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	if err != nil {
+		return "", 0, 0, err
+	}
+	sort.Strings(rels)
+
+	h := sha256.New()
+	var size int64
+	for _, rel := range rels {
+		path := filepath.Join(dir, filepath.FromSlash(rel))
+		sum, err := HashFile(path, HashOptions{Workers: opts.Workers, ChunkSize: opts.ChunkSize, OnProgress: opts.OnProgress})
+		if err != nil {
+			return "", 0, 0, err
+		}
+		if fi, err := os.Stat(path); err == nil {
+			size += fi.Size()
+		}
+		fmt.Fprintf(h, "%s  %s\n", sum.SHA256, rel)
+	}
+	return hex.EncodeToString(h.Sum(nil)), size, len(rels), nil
+}
+
+// underAny reports whether path is inside one of dirs.
+func underAny(path string, dirs []string) bool {
+	for _, dir := range dirs {
+		if strings.HasPrefix(path, dir+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,149 @@
+package scanner
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// ConfidenceLow marks discoveries that are likely not real model usage:.
+// references in Python comments and docstrings (only reported with.
+// Options.IncludeComments) and references in test files and fixtures.
+const ConfidenceLow = "low"
+
+// splitPythonSource separates Python source lines into code and prose. code.
+// holds each line with comments and docstrings blanked out; prose holds the.
+// comment and docstring text that was removed. Both have one entry per input.
+// line so line numbers stay aligned.
+//.
+// This is a lightweight tokenizer, not a parser: it tracks string literals.
+// (single, double and triple quoted, with backslash escapes), comments and.
+// bracket depth. A triple-quoted string counts as a docstring when it is the.
+// first token of a logical line; triple-quoted strings used as values (e.g.
+// prompt = """...""") stay code.
+func splitPythonSource(lines []string) (code, prose []string) {
+	code = make([]string, len(lines))
+	prose = make([]string, len(lines))
+
+	var (
+		quote     string // open string delimiter: ", ', """ or '''
+		docstring bool   // the open string is a docstring
+		depth     int    // bracket depth
+		continued bool   // previous line ended with a backslash
+	)
+
+	for i, line := range lines {
+		var c, p strings.Builder
+		// A logical line starts unless we are inside brackets, a string or a.
+		// backslash continuation.
+		statementStart := quote == "" && depth == 0 && !continued
+		continued = false
+
+		for j := 0; j < len(line); {
+			ch := line[j]
+
+			if quote != "" {
+				out := &c
+				if docstring {
+					out = &p
+				}
+				if ch == '\\' && j+1 < len(line) {
+					out.WriteString(line[j : j+2])
+					j += 2
+					continue
+				}
+				if strings.HasPrefix(line[j:], quote) {
+					out.WriteString(quote)
+					j += len(quote)
+					quote, docstring = "", false
+					continue
+				}
+				out.WriteByte(ch)
+				j++
+				continue
+			}
+
+			switch {
+			case ch == '#':
+				p.WriteString(line[j:])
+				j = len(line)
+				continue
+			case ch == '"' || ch == '\'':
+				delim := string(ch)
+				if strings.HasPrefix(line[j:], strings.Repeat(delim, 3)) {
+					delim = strings.Repeat(delim, 3)
+					docstring = statementStart && isStringPrefix(c.String())
+				}
+				quote = delim
+				if docstring {
+					p.WriteString(delim)
+				} else {
+					c.WriteString(delim)
+				}
+				j += len(delim)
+				continue
+			case ch == '(' || ch == '[' || ch == '{':
+				depth++
+			case ch == ')' || ch == ']' || ch == '}':
+				if depth > 0 {
+					depth--
+				}
+			case ch == '\\' && j == len(line)-1:
+				continued = true
+			}
+			c.WriteByte(ch)
+			j++
+		}
+
+		// Single-quoted strings cannot span lines without a continuation.
+		if len(quote) == 1 && !continued {
+			quote = ""
+		}
+
+		code[i] = c.String()
+		prose[i] = p.String()
+	}
+	return code, prose
+}
+
+// isStringPrefix reports whether s is only indentation followed by an.
+// optional string prefix such as r, u, b or f.
+func isStringPrefix(s string) bool {
+	s = strings.TrimLeft(s, " \t")
+	if len(s) > 2 {
+		return false
+	}
+	for _, r := range strings.ToLower(s) {
+		if r != 'r' && r != 'u' && r != 'b' && r != 'f' {
+			return false
+		}
+	}
+	return true
+}
+
+// isTestPath reports whether path looks like a test file or fixture: a.
+// test_*.py, *_test.py or conftest.py file, or any file below a tests, test,
+// testdata or fixtures directory.
+func isTestPath(path string) bool {
+	slashed := filepath.ToSlash(path)
+	name := strings.ToLower(slashed[strings.LastIndex(slashed, "/")+1:])
+	if name == "conftest.py" ||
+		(strings.HasPrefix(name, "test_") && strings.HasSuffix(name, ".py")) ||
+		strings.HasSuffix(name, "_test.py") {
+		return true
+	}
+	for _, seg := range strings.Split(strings.ToLower(slashed), "/") {
+		switch seg {
+		case "tests", "test", "testdata", "fixtures":
+			return true
+		}
+	}
+	return false
+}
+
+// markLowConfidence sets ConfidenceLow on every discovery in ds.
+func markLowConfidence(ds []Discovery) []Discovery {
+	for i := range ds {
+		ds[i].Confidence = ConfidenceLow
+	}
+	return ds
+}
@@ -5,12 +5,15 @@ import (
 	"encoding/json"
 	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
 	"runtime"
 	"strconv"
 	"strings"
 	"sync"
+
+	"github.com/idlab-discover/aibomgen-cli/internal/fsutil"
 )
 
 // Discovery represents a Hugging Face model or dataset reference detected in a.
@@ -24,6 +27,57 @@ type Discovery struct {
 	Path     string `json:"path"`
 	Evidence string `json:"evidence"`
 	Method   string `json:"method"`
+
+	// Format, Size and Hash are only set for "model-file" discoveries: the.
+	// detected weight format, the file size in bytes and its SHA-256 digest.
+	// "weight-url" discoveries carry the Format implied by the URL.
+	Format string `json:"format,omitempty"`
+	Size   int64  `json:"size,omitempty"`
+	Hash   string `json:"hash,omitempty"`
+
+	// HashAlgorithm names the algorithm of Hash when it is not SHA-256:.
+	// [HashAlgorithmMD5] for outputs tracked by DVC.
+	HashAlgorithm string `json:"hash_algorithm,omitempty"`
+
+	// BLAKE3 is the BLAKE3-256 digest of the file, recorded next to its.
+	// SHA-256 Hash when HashOptions.BLAKE3 is set.
+	BLAKE3 string `json:"blake3,omitempty"`
+
+	// Confidence is ConfidenceLow for references found only in comments,.
+	// docstrings or test files, and empty otherwise.
+	Confidence string `json:"confidence,omitempty"`
+
+	// ETag is only set for "weight-url" discoveries whose URL was probed with.
+	// a HEAD request; Size then holds the Content-Length.
+	ETag string `json:"etag,omitempty"`
+
+	// BaseModel is only set for "adapter" discoveries: the Hugging Face ID of.
+	// the model the adapter was trained on.
+	BaseModel string `json:"base_model,omitempty"`
+
+	// Version is only set for model weights found by [ScanPythonEnv]: the.
+	// version of the package that installed them or the revision of the.
+	// Hugging Face cache snapshot they belong to. BentoML model store.
+	// references carry the version of their tag.
+	Version string `json:"version,omitempty"`
+
+	// Bundles lists the serving bundles (BentoML services, KServe.
+	// InferenceServices) that package the model.
+	Bundles []ServingBundle `json:"bundles,omitempty"`
+
+	// StorageURI is where the tracked copy of a DVC output or a.
+	// "tracked-artifact" is stored: its URL in the DVC remote.
+	// ("s3://bucket/files/md5/a3/04afb9...") or the MLflow model URI.
+	// ("runs:/<run_id>/model").
+	StorageURI string `json:"storage_uri,omitempty"`
+
+	// ArtifactType is only set for "tracked-artifact" discoveries:.
+	// [ArtifactTypeModel] or [ArtifactTypeDataset].
+	ArtifactType string `json:"artifact_type,omitempty"`
+
+	// History is only set when the git history was scanned (see.
+	// [ScanGitHistory] and [MergeHistory]).
+	History *GitHistory `json:"history,omitempty"`
 }
 
 // detectionRule pairs a named detection method with a compiled pattern.
@@ -64,6 +118,11 @@ var (
 
 	// jsRules apply to JavaScript / TypeScript (.js, .ts, .mjs, .cjs).
 	jsRules []detectionRule
+
+	// servingRules detect inference server command lines (vLLM,.
+	// text-generation-inference, llama.cpp). They are part of both.
+	// shellRules and yamlRules.
+	servingRules []detectionRule
 )
 
 func init() {
@@ -301,6 +360,53 @@ func init() {
 		groupIdx: 1,
 	})
 
+	// ── Serving stack rules ───────────────────────────────────────────────────.
+	// Inference server command lines in shell scripts, Dockerfiles, compose.
+	// files and Kubernetes manifests. argSep matches the separator between a.
+	// flag and its value in shell (--model org/model, --model=org/model) and.
+	// exec-form lists (["--model", "org/model"]).
+	argSep := `(?:\s*=\s*|\s+|["']\s*,\s*["'])["']?`
+
+	// vLLM: vllm serve org/model.
+	servingRules = append(servingRules, detectionRule{
+		method:   "vllm_serve",
+		pattern:  regexp.MustCompile(`\bvllm\s+serve\s+["']?(` + hfIDSlashPat + `)["']?`),
+		groupIdx: 1,
+	})
+
+	// vLLM OpenAI server and other launchers: --model org/model.
+	servingRules = append(servingRules, detectionRule{
+		method:   "vllm_model_arg",
+		pattern:  regexp.MustCompile(`(?:^|[\s"'\[,])--model` + argSep + `(` + hfIDSlashPat + `)`),
+		groupIdx: 1,
+	})
+
+	// text-generation-inference launcher: --model-id org/model.
+	servingRules = append(servingRules, detectionRule{
+		method:   "tgi_model_id_arg",
+		pattern:  regexp.MustCompile(`(?:^|[\s"'\[,])--model-id` + argSep + `(` + hfIDSlashPat + `)`),
+		groupIdx: 1,
+	})
+
+	// llama.cpp server / CLI downloading from the Hub:.
+	//   llama-server -hf org/model-GGUF:Q4_K_M  |  --hf-repo org/model-GGUF.
+	servingRules = append(servingRules, detectionRule{
+		method:   "llama_cpp_hf_repo",
+		pattern:  regexp.MustCompile(`(?:^|[\s"'\[,])(?:--hf-repo|-hfr|-hf)` + argSep + `(` + hfIDSlashPat + `)`),
+		groupIdx: 1,
+	})
+
+	shellRules = append(shellRules, servingRules...)
+	yamlRules = append(yamlRules, servingRules...)
+
+	// text-generation-inference MODEL_ID in compose / manifest environments:.
+	//   MODEL_ID: org/model  |  - MODEL_ID=org/model.
+	yamlRules = append(yamlRules, detectionRule{
+		method:   "tgi_model_id_env",
+		pattern:  regexp.MustCompile(`^\s*(?:-\s*)?["']?(?:MODEL_ID|HF_MODEL_ID)["']?\s*[:=]\s*["']?(` + hfIDSlashPat + `)["']?\s*(?:#.*)?$`),
+		groupIdx: 1,
+	})
+
 	// ── JavaScript / TypeScript rules ─────────────────────────────────────────.
 	// @xenova/transformers or @huggingface/transformers pipeline:.
 	//   await pipeline("task", "org/model").
@@ -334,29 +440,57 @@ func init() {
 // (ID, Path). Hidden directories, virtual environments, and common build.
 // output directories are skipped automatically.
 func Scan(root string) ([]Discovery, error) {
+	return ScanWithOptions(root, Options{})
+}
+
+// Options tunes a scan. The zero value matches [Scan].
+type Options struct {
+	// Hash controls hashing of detected model weight files.
+	Hash HashOptions
+	// IncludeComments also reports model IDs found in Python comments and.
+	// docstrings, with ConfidenceLow. By default they are ignored.
+	IncludeComments bool
+	// Ignore lists glob patterns of files and directories to leave out of.
+	// the scan, e.g. "examples/" or "*.ipynb".
+	Ignore []string
+}
+
+// ScanWithOptions is like [Scan] but accepts tuning options.
+func ScanWithOptions(root string, opts Options) ([]Discovery, error) {
 	// Collect file paths first (fast, serial walk).
+	// Walking the extended-length form of root lets deep trees on Windows.
+	// exceed MAX_PATH; every collected path inherits the prefix.
 	var paths []string
-	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+	err := filepath.WalkDir(fsutil.LongPath(root), func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
 		if d.IsDir() {
-			if shouldSkipDir(d.Name()) {
+			if shouldSkipDir(d.Name()) || ignored(opts.Ignore, root, path, true) {
 				return filepath.SkipDir
 			}
 			return nil
 		}
-		if classifyFile(strings.ToLower(filepath.Ext(d.Name())), strings.ToLower(d.Name())) != fileClassUnknown {
-			paths = append(paths, path)
+		if ignored(opts.Ignore, root, path, false) {
+			return nil
 		}
+		// Unclassified files are still collected: scanFile sniffs their.
+		// content for model weight formats.
+		paths = append(paths, path)
 		return nil
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	return scanPaths(root, paths, opts), nil
+}
+
+// scanPaths scans the given files below root concurrently and returns the.
+// deduplicated discoveries.
+func scanPaths(root string, paths []string, opts Options) []Discovery {
 	if len(paths) == 0 {
-		return nil, nil
+		return nil
 	}
 
 	// Fan-out over a bounded goroutine pool.
@@ -380,7 +514,10 @@ func Scan(root string) ([]Discovery, error) {
 		go func() {
 			defer wg.Done()
 			for p := range pathCh {
-				hits := scanFile(p)
+				hits := scanFile(p, opts)
+				if rel, err := filepath.Rel(fsutil.LongPath(root), p); err == nil && isTestPath(rel) {
+					hits = markLowConfidence(hits)
+				}
 				if len(hits) > 0 {
 					mu.Lock()
 					results = append(results, hits...)
@@ -391,7 +528,24 @@ func Scan(root string) ([]Discovery, error) {
 	}
 	wg.Wait()
 
-	return dedupe(results), nil
+	// Report slash-separated paths without the long-path prefix so evidence is.
+	// identical across platforms, below root as given (relative when it is).
+	relocate := fsutil.Relocator(root)
+	for i := range results {
+		results[i].Path = relocate(fsutil.Normalize(results[i].Path))
+		results[i].Evidence = relocate(results[i].Evidence)
+		switch results[i].Type {
+		case DiscoveryTypeModelFile, DiscoveryTypeDataFile, DiscoveryTypeTrackedArtifact:
+			results[i].ID = relocate(fsutil.Normalize(results[i].ID))
+		case DiscoveryTypeAdapter:
+			results[i].ID = path.Dir(results[i].Path)
+		}
+		for j := range results[i].Bundles {
+			results[i].Bundles[j].Path = relocate(fsutil.Normalize(results[i].Bundles[j].Path))
+		}
+	}
+
+	return dedupe(results)
 }
 
 // fileClass categorises a file so we know which rule-set to apply.
@@ -446,6 +600,7 @@ func classifyFile(ext, name string) fileClass {
 func shouldSkipDir(name string) bool {
 	switch name {
 	case ".git", ".hg", ".svn",
+		".dvc", // DVC cache and config; tracked outputs come from the .dvc files
 		"node_modules",
 		"__pycache__",
 		".venv", "venv", "env", ".env",
@@ -459,28 +614,51 @@ func shouldSkipDir(name string) bool {
 }
 
 // scanFile dispatches a single file to the appropriate scanner.
-func scanFile(path string) []Discovery {
+func scanFile(path string, opts Options) []Discovery {
 	name := strings.ToLower(filepath.Base(path))
 	ext := strings.ToLower(filepath.Ext(name))
 	class := classifyFile(ext, name)
 
+	// DVC files stand in for the models and datasets they track.
+	switch {
+	case ext == ".dvc":
+		return scanDVCFile(path)
+	case name == "dvc.lock":
+		return scanDVCLock(path)
+	case name == "dvc.yaml":
+		return scanDVCYAML(path)
+	case name == mlmodelName:
+		return scanMLmodel(path, opts.Hash)
+	case isModelfile(name):
+		return scanModelfile(path)
+	}
+
 	switch class {
 	case fileClassPython:
-		return scanLines(path, codeRules, true)
+		return scanPython(path, opts)
 	case fileClassNotebook:
-		return scanNotebook(path)
+		return scanNotebook(path, opts)
 	case fileClassYAML:
-		return scanLines(path, yamlRules, false)
+		if isBentofile(name) {
+			return scanBentofile(path)
+		}
+		return scanOllamaCompose(path, scanKServe(path, scanServingConfig(path, yamlRules)))
 	case fileClassJSON:
+		if name == adapterConfigName {
+			return scanAdapterConfig(path)
+		}
 		return scanLines(path, jsonRules, false)
 	case fileClassMarkdown:
 		return scanMarkdown(path)
 	case fileClassShell:
-		return scanLines(path, shellRules, false)
+		return scanServingConfig(path, shellRules)
 	case fileClassJS:
-		return scanLines(path, jsRules, false)
+		return scanJS(path)
 	}
-	return nil
+	if hits := scanLFSPointer(path); hits != nil {
+		return hits
+	}
+	return sniffModelFile(path, opts.Hash)
 }
 
 // scanLines reads a file line by line and applies the given rules.
@@ -493,27 +671,99 @@ func scanFile(path string) []Discovery {
 //	    model="org/model",.
 //	).
 func scanLines(path string, rules []detectionRule, multiLine bool) []Discovery {
-	f, err := os.Open(path)
+	lines, err := readLines(path)
 	if err != nil {
 		return nil
 	}
+	return scanTextLines(lines, nil, rules, multiLine, path)
+}
+
+// readLines reads the lines of a file. Reading stops at the first line that.
+// exceeds the scanner buffer; only failing to open the file is an error.
+func readLines(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
 	defer f.Close()
 
-	var results []Discovery
+	var lines []string
 	sc := bufio.NewScanner(f)
-	lineNum := 0
+	for sc.Scan() {
+		lines = append(lines, sc.Text())
+	}
+	return lines, nil
+}
+
+// scanPython scans a Python file. Comments and docstrings are skipped unless.
+// opts.IncludeComments is set, in which case their matches are reported with.
+// ConfidenceLow. The models of a file defining a BentoML service belong to.
+// its bento.
+func scanPython(path string, opts Options) []Discovery {
+	lines, err := readLines(path)
+	if err != nil {
+		return nil
+	}
+	results := scanPythonLines(lines, path, opts)
+	if b, ok := bentoServiceBundle(lines, path); ok {
+		results = withBundle(results, b)
+	}
+	return results
+}
+
+// scanPythonLines applies the code rules to Python source lines. f-strings.
+// are reported as parameterized discoveries and references to string.
+// constants defined in the same source are resolved first.
+func scanPythonLines(lines []string, path string, opts Options) []Discovery {
+	code, prose := splitPythonSource(lines)
+	code, templates := replaceTemplates(code, true)
+	resolved, notes := resolveConstants(code, pyConstRe)
+	results := restoreTemplates(scanTextLines(resolved, notes, codeRules, true, path), templates)
+	if opts.IncludeComments {
+		results = append(results, markLowConfidence(scanTextLines(prose, nil, codeRules, false, path))...)
+	}
+	return bentoStoreModels(results)
+}
+
+// scanJS scans a JavaScript / TypeScript file. Template literals are.
+// reported as parameterized discoveries and references to string constants.
+// declared in the same file are resolved.
+func scanJS(path string) []Discovery {
+	lines, err := readLines(path)
+	if err != nil {
+		return nil
+	}
+	lines, templates := replaceTemplates(lines, false)
+	resolved, notes := resolveConstants(lines, jsConstRe)
+	return restoreTemplates(scanTextLines(resolved, notes, jsRules, false, path), templates)
+}
+
+// scanTextLines applies rules to each line. When multiLine is true, lines.
+// belonging to the same open-paren call are also scanned joined together.
+// (see scanLines). notes, when non-nil, holds a remark per line that is.
+// appended to the evidence of matches on that line.
+func scanTextLines(lines, notes []string, rules []detectionRule, multiLine bool, path string) []Discovery {
+	var results []Discovery
 
 	// Multi-line accumulation state (only used when multiLine=true).
-	var callBuf []string
+	var callBuf, callNotes []string
 	callStartLine := 0
 	depth := 0
 
-	for sc.Scan() {
-		lineNum++
-		line := sc.Text()
+	for i, line := range lines {
+		lineNum := i + 1
+		note := ""
+		if notes != nil {
+			note = notes[i]
+		}
 
 		// Always scan each individual line.
-		results = applyRules(results, rules, line, lineNum, path)
+		results = applyRules(results, rules, line, lineNum, path, note)
+		results = append(results, weightURLs(line, lineNum, path, note)...)
+		results = append(results, providerModels(line, lineNum, path, note)...)
+		results = append(results, kaggleResources(line, lineNum, path, note)...)
+		results = append(results, ollamaModels(line, lineNum, path, note)...)
+		results = append(results, hostedModels(line, lineNum, path, note)...)
 
 		if !multiLine {
 			continue
@@ -539,20 +789,24 @@ func scanLines(path string, rules []detectionRule, multiLine bool) []Discovery {
 				callStartLine = lineNum
 			}
 			callBuf = append(callBuf, strings.TrimSpace(line))
+			if note != "" {
+				callNotes = append(callNotes, note)
+			}
 		}
 
 		// Flush once parens are balanced.
 		if depth == 0 && len(callBuf) > 0 {
 			combined := strings.Join(callBuf, " ")
-			results = applyRules(results, rules, combined, callStartLine, path)
-			callBuf = nil
+			results = applyRules(results, rules, combined, callStartLine, path, strings.Join(callNotes, "; "))
+			callBuf, callNotes = nil, nil
 		}
 	}
 	return results
 }
 
 // applyRules tests a single text string against all rules and appends any hits.
-func applyRules(results []Discovery, rules []detectionRule, text string, lineNum int, path string) []Discovery {
+// A non-empty note is added to the evidence in parentheses.
+func applyRules(results []Discovery, rules []detectionRule, text string, lineNum int, path, note string) []Discovery {
 	for _, rule := range rules {
 		matches := rule.pattern.FindAllStringSubmatch(text, -1)
 		for _, m := range matches {
@@ -564,6 +818,9 @@ func applyRules(results []Discovery, rules []detectionRule, text string, lineNum
 				continue
 			}
 			evidence := rule.method + " at line " + strconv.Itoa(lineNum) + ": " + strings.TrimSpace(text)
+			if note != "" {
+				evidence += " (" + note + ")"
+			}
 			results = append(results, Discovery{
 				ID:       modelID,
 				Name:     modelID,
@@ -587,7 +844,7 @@ type notebookFormat struct {
 
 // scanNotebook parses a Jupyter notebook and scans each code cell's source.
 // as Python using the standard code rules.
-func scanNotebook(path string) []Discovery {
+func scanNotebook(path string, opts Options) []Discovery {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil
@@ -596,7 +853,7 @@ func scanNotebook(path string) []Discovery {
 	var nb notebookFormat
 	if err := json.Unmarshal(data, &nb); err != nil {
 		// Fall back to a raw line scan with code rules if parse fails.
-		return scanLines(path, codeRules, true)
+		return scanPython(path, opts)
 	}
 
 	var results []Discovery
@@ -604,56 +861,18 @@ func scanNotebook(path string) []Discovery {
 		if cell.CellType != "code" && cell.CellType != "markdown" {
 			continue
 		}
-		rules := codeRules
-		multiLine := cell.CellType == "code"
-		if cell.CellType == "markdown" {
-			rules = mdFrontmatterRules
-		}
 
 		// Source is either a JSON string or a JSON array of strings.
-		lines := unmarshalSource(cell.Source)
-		lineNum := 0
-
-		// Multi-line accumulation state.
-		var callBuf []string
-		callStartLine := 0
-		depth := 0
-
-		for _, line := range lines {
-			for _, subline := range strings.Split(line, "\n") {
-				lineNum++
-				results = applyRules(results, rules, subline, lineNum, path)
-
-				if !multiLine {
-					continue
-				}
-
-				for _, ch := range subline {
-					switch ch {
-					case '(':
-						depth++
-					case ')':
-						depth--
-					}
-				}
-				if depth < 0 {
-					depth = 0
-				}
-
-				if depth > 0 || len(callBuf) > 0 {
-					if len(callBuf) == 0 {
-						callStartLine = lineNum
-					}
-					callBuf = append(callBuf, strings.TrimSpace(subline))
-				}
+		var lines []string
+		for _, line := range unmarshalSource(cell.Source) {
+			lines = append(lines, strings.Split(strings.TrimSuffix(line, "\n"), "\n")...)
+		}
 
-				if depth == 0 && len(callBuf) > 0 {
-					combined := strings.Join(callBuf, " ")
-					results = applyRules(results, rules, combined, callStartLine, path)
-					callBuf = nil
-				}
-			}
+		if cell.CellType == "markdown" {
+			results = append(results, scanTextLines(lines, nil, mdFrontmatterRules, false, path)...)
+			continue
 		}
+		results = append(results, scanPythonLines(lines, path, opts)...)
 	}
 	return results
 }
@@ -718,7 +937,7 @@ func scanMarkdown(path string) []Discovery {
 				frontmatterClosed = true
 				continue
 			}
-			results = applyRules(results, mdFrontmatterRules, line, lineNum, path)
+			results = applyRules(results, mdFrontmatterRules, line, lineNum, path, "")
 			continue
 		}
 
@@ -761,6 +980,10 @@ func isPlausibleModelID(id string) bool {
 	if strings.HasPrefix(id, "./") || strings.HasPrefix(id, "../") || strings.HasPrefix(id, "/") {
 		return false
 	}
+	// Reject paths to weight files such as models/llama.gguf.
+	if hasWeightFileExt(id) {
+		return false
+	}
 	return true
 }
 
@@ -775,6 +998,16 @@ func dedupe(components []Discovery) []Discovery {
 			if !strings.Contains(existing.Evidence, c.Evidence) {
 				existing.Evidence += ". " + c.Evidence
 			}
+			// One regular reference is enough to trust the discovery.
+			if c.Confidence != ConfidenceLow {
+				existing.Confidence = c.Confidence
+			}
+			// A referenced weight file takes size and digest from its sniffed copy,.
+			// and a DVC-tracked file the SHA-256 of its checked-out copy.
+			if c.Hash != "" && (existing.Hash == "" || existing.HashAlgorithm != "" && c.HashAlgorithm == "") {
+				existing.Format, existing.Size, existing.Hash, existing.HashAlgorithm = c.Format, c.Size, c.Hash, c.HashAlgorithm
+			}
+			existing.Bundles = mergeBundles(existing.Bundles, c.Bundles)
 			// Keep the first seen Method; additional methods are visible via Evidence.
 			index[key] = existing
 		} else {
@@ -785,7 +1018,7 @@ func dedupe(components []Discovery) []Discovery {
 	for _, v := range index {
 		out = append(out, v)
 	}
-	return out
+	return dropTrackedPointers(dropHostedClients(out))
 }
 
 // shouldScanForModelID is retained for backward compatibility with tests.
@@ -1,11 +1,24 @@
 package scanner
 
 import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
 )
 
 // ── helpers ──────────────────────────────────────────────────────────────────.
@@ -372,6 +385,39 @@ func TestJSONBaseModel(t *testing.T) {
 	}
 }
 
+func TestAdapterConfigLinksBaseModel(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "adapters/sql-lora/adapter_config.json", `{
+  "base_model_name_or_path": "meta-llama/Llama-3.1-8B",
+  "peft_type": "LORA",
+  "task_type": "CAUSAL_LM",
+  "r": 16
+}`)
+	writeFile(t, dir, "adapters/local-lora/adapter_config.json", `{"base_model_name_or_path": "/models/llama", "peft_type": "LORA"}`)
+
+	comps, err := Scan(dir)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	adapterDir := filepath.ToSlash(filepath.Join(dir, "adapters", "sql-lora"))
+	a, ok := findByID(comps, adapterDir)
+	if !ok {
+		t.Fatalf("expected adapter discovery %s, got %+v", adapterDir, comps)
+	}
+	if a.Type != DiscoveryTypeAdapter || a.Name != "sql-lora" || a.BaseModel != "meta-llama/Llama-3.1-8B" {
+		t.Errorf("unexpected adapter discovery: %+v", a)
+	}
+	if !strings.Contains(a.Evidence, "peft_type LORA") {
+		t.Errorf("evidence %q should mention the PEFT type", a.Evidence)
+	}
+
+	local, ok := findByID(comps, filepath.ToSlash(filepath.Join(dir, "adapters", "local-lora")))
+	if !ok || local.BaseModel != "" || !strings.Contains(local.Evidence, "/models/llama") {
+		t.Errorf("local base model path should only appear in evidence: %+v", local)
+	}
+}
+
 // ── Markdown front-matter tests ───────────────────────────────────────────────.
 
 func TestMarkdownFrontmatterModel(t *testing.T) {
@@ -436,6 +482,387 @@ func TestDockerfileModelEnv(t *testing.T) {
 	}
 }
 
+func TestServingConfigsDetected(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "serve.sh", `#!/bin/sh
+vllm serve mistralai/Mistral-7B-Instruct-v0.3 --port 8000
+python -m vllm.entrypoints.openai.api_server --model=Qwen/Qwen2.5-7B-Instruct
+text-generation-launcher --model-id bigscience/bloom-560m
+llama-server -hf bartowski/Llama-3.2-3B-Instruct-GGUF:Q4_K_M
+`)
+	writeFile(t, dir, "deploy/docker-compose.yml", `services:
+  tgi:
+    image: ghcr.io/huggingface/text-generation-inference
+    environment:
+      MODEL_ID: HuggingFaceH4/zephyr-7b-beta
+  vllm:
+    image: vllm/vllm-openai
+    command: ["--model", "meta-llama/Llama-3.1-8B-Instruct", "--dtype", "auto"]
+  tei:
+    environment:
+      - MODEL_ID=BAAI/bge-small-en-v1.5
+`)
+
+	comps, err := Scan(dir)
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	want := map[string]string{
+		"mistralai/Mistral-7B-Instruct-v0.3":   "vllm_serve",
+		"Qwen/Qwen2.5-7B-Instruct":             "vllm_model_arg",
+		"bigscience/bloom-560m":                "tgi_model_id_arg",
+		"bartowski/Llama-3.2-3B-Instruct-GGUF": "llama_cpp_hf_repo",
+		"HuggingFaceH4/zephyr-7b-beta":         "tgi_model_id_env",
+		"meta-llama/Llama-3.1-8B-Instruct":     "vllm_model_arg",
+		"BAAI/bge-small-en-v1.5":               "tgi_model_id_env",
+	}
+	for id, method := range want {
+		c, ok := findByID(comps, id)
+		if !ok {
+			t.Errorf("expected %s, got %+v", id, comps)
+			continue
+		}
+		if c.Method != method {
+			t.Errorf("%s: method = %q, want %q", id, c.Method, method)
+		}
+	}
+	if len(comps) != len(want) {
+		t.Errorf("expected %d discoveries, got %d: %+v", len(want), len(comps), comps)
+	}
+}
+
+func TestLlamaCppGGUFReference(t *testing.T) {
+	dir := t.TempDir()
+	gguf := append([]byte("GGUF\x03\x00\x00\x00"), make([]byte, 120)...)
+	writeFile(t, dir, "models/tiny.Q4_K_M.gguf", string(gguf))
+	writeFile(t, dir, "scripts/run.sh", `llama-server -m ../models/tiny.Q4_K_M.gguf --port 8080
+llama-cli --model /opt/models/llama-3-8b.Q8_0.gguf -p "hi"
+`)
+
+	comps, err := Scan(dir)
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+
+	local, ok := findByID(comps, filepath.ToSlash(filepath.Join(dir, "models", "tiny.Q4_K_M.gguf")))
+	if !ok {
+		t.Fatalf("local GGUF not reported: %+v", comps)
+	}
+	if local.Type != DiscoveryTypeModelFile || len(local.Hash) != 64 || local.Size == 0 {
+		t.Errorf("referenced GGUF should keep its size and digest: %+v", local)
+	}
+	if !strings.Contains(local.Evidence, "llama_cpp_model at line 1") || !strings.Contains(local.Evidence, "GGUF magic header") {
+		t.Errorf("evidence should combine the reference and the sniffed header: %q", local.Evidence)
+	}
+
+	remote, ok := findByID(comps, "/opt/models/llama-3-8b.Q8_0.gguf")
+	if !ok {
+		t.Fatalf("container GGUF path not reported: %+v", comps)
+	}
+	if remote.Format != "gguf" || remote.Name != "llama-3-8b.Q8_0.gguf" || remote.Hash != "" {
+		t.Errorf("unexpected discovery for unresolved GGUF path: %+v", remote)
+	}
+	if len(comps) != 2 {
+		t.Errorf("GGUF paths must not also be reported as model IDs: %+v", comps)
+	}
+}
+
+func TestWeightURLsDetected(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "download.py", `import boto3
+WEIGHTS = "s3://ml-artifacts/llama/model-00001.safetensors"
+gcs = "gs://my-bucket/ckpt/epoch_10.ckpt"
+url = "https://acct.blob.core.windows.net/models/clf.onnx?sv=2024-01-01&sig=SECRET"
+urllib.request.urlretrieve("https://example.com/files/tiny.Q4_K_M.gguf", "tiny.gguf")
+docs = "https://example.com/docs/model.html"
+archive = "https://example.com/files/weights.bin.tar.gz"
+`)
+
+	comps, err := Scan(dir)
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+
+	want := map[string][2]string{
+		"s3://ml-artifacts/llama/model-00001.safetensors":    {"weight_url_s3", "safetensors"},
+		"gs://my-bucket/ckpt/epoch_10.ckpt":                  {"weight_url_gcs", "checkpoint"},
+		"https://acct.blob.core.windows.net/models/clf.onnx": {"weight_url_azure", "onnx"},
+		"https://example.com/files/tiny.Q4_K_M.gguf":         {"weight_url_https", "gguf"},
+	}
+	for id, w := range want {
+		c, ok := findByID(comps, id)
+		if !ok {
+			t.Errorf("expected weight URL %s, got %+v", id, comps)
+			continue
+		}
+		if c.Type != DiscoveryTypeWeightURL || c.Method != w[0] || c.Format != w[1] {
+			t.Errorf("%s: type=%q method=%q format=%q, want %v", id, c.Type, c.Method, c.Format, w)
+		}
+	}
+	if len(comps) != len(want) {
+		t.Errorf("expected %d discoveries, got %+v", len(want), comps)
+	}
+	for _, c := range comps {
+		if strings.Contains(c.Evidence, "SECRET") || strings.Contains(c.ID, "?") {
+			t.Errorf("query string leaked into discovery: %+v", c)
+		}
+	}
+}
+
+func TestProviderModelsDetected(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "fetch.sh", `#!/bin/sh
+ngc registry model download-version nvidia/tao/peoplenet:pruned_quantized_v2.3.3
+curl -LO https://api.ngc.nvidia.com/v2/models/nvidia/nemo/stt_en_conformer/versions/1.10.0/zip
+wget https://api.ngc.nvidia.com/v2/models/org/nvidia/team/riva/parakeet-ctc/2.0/files
+docker run nvcr.io/nim/meta/llama-3.1-8b-instruct:1.2.2
+llama model download --source meta --model-id Llama3.1-8B-Instruct
+llama model download --source huggingface --model-id Llama3.2-1B
+wget "https://download.llamameta.net/Meta-Llama-3.1-70B/consolidated.00.pth?Policy=SECRET&Signature=SECRET"
+`)
+
+	comps, err := Scan(dir)
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+
+	want := map[string][2]string{
+		"ngc:nvidia/tao/peoplenet:pruned_quantized_v2.3.3": {"ngc_cli", "pruned_quantized_v2.3.3"},
+		"ngc:nvidia/nemo/stt_en_conformer:1.10.0":          {"ngc_api_url", "1.10.0"},
+		"ngc:nvidia/riva/parakeet-ctc:2.0":                 {"ngc_api_url", "2.0"},
+		"ngc:nim/meta/llama-3.1-8b-instruct:1.2.2":         {"ngc_nim_image", "1.2.2"},
+		"meta:Llama3.1-8B-Instruct":                        {"meta_llama_cli", ""},
+		"meta:Llama3.1-70B":                                {"meta_llama_download_url", ""},
+	}
+	for id, w := range want {
+		c, ok := findByID(comps, id)
+		if !ok {
+			t.Errorf("expected provider model %s, got %+v", id, comps)
+			continue
+		}
+		if c.Type != DiscoveryTypeProviderModel || c.Method != w[0] || c.Version != w[1] {
+			t.Errorf("%s: type=%q method=%q version=%q, want %v", id, c.Type, c.Method, c.Version, w)
+		}
+	}
+	if len(comps) != len(want) {
+		t.Errorf("expected %d discoveries, got %+v", len(want), comps)
+	}
+	if c, _ := findByID(comps, "ngc:nim/meta/llama-3.1-8b-instruct:1.2.2"); c.Format != "nim" {
+		t.Errorf("NIM image format = %q, want nim", c.Format)
+	}
+	for _, c := range comps {
+		if strings.Contains(c.Evidence, "SECRET") {
+			t.Errorf("signed query leaked into discovery: %+v", c)
+		}
+	}
+
+	if got := ProviderModelURL(Discovery{ID: "ngc:nvidia/tao/peoplenet", Name: "nvidia/tao/peoplenet", Type: DiscoveryTypeProviderModel}); got != "https://catalog.ngc.nvidia.com/orgs/nvidia/teams/tao/models/peoplenet" {
+		t.Errorf("ProviderModelURL = %q", got)
+	}
+}
+
+func TestKaggleResourcesDetected(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "train.py", `import kagglehub
+import keras_hub
+
+path = kagglehub.model_download("google/gemma/transformers/2b")
+iris = kagglehub.dataset_download("uciml/iris")
+df = kagglehub.load_dataset(KaggleDatasetAdapter.PANDAS, "zynicide/wine-reviews", "winemag.csv")
+lm = keras_hub.models.GemmaCausalLM.from_preset("kaggle://keras/gemma/keras/gemma_2b_en/2")
+`)
+	writeFile(t, dir, "fetch.sh", `#!/bin/sh
+kaggle datasets download -p data/raw --unzip -d titanic-org/titanic && echo done
+kaggle models instances versions download google/gemma/pytorch/7b/1
+`)
+	writeFile(t, dir, "data.yaml", "source: https://www.kaggle.com/datasets/uciml/mushroom-classification/versions/1\n")
+
+	comps, err := Scan(dir)
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+
+	want := map[string][2]string{
+		"kaggle:models/google/gemma/transformers/2b":    {"kagglehub_model", ""},
+		"kaggle:datasets/uciml/iris":                    {"kagglehub_dataset", ""},
+		"kaggle:datasets/zynicide/wine-reviews":         {"kagglehub_dataset", ""},
+		"kaggle:models/keras/gemma/keras/gemma_2b_en":   {"kaggle_uri", "2"},
+		"kaggle:datasets/titanic-org/titanic":           {"kaggle_cli", ""},
+		"kaggle:models/google/gemma/pytorch/7b":         {"kaggle_cli", "1"},
+		"kaggle:datasets/uciml/mushroom-classification": {"kaggle_url", "1"},
+	}
+	for id, w := range want {
+		c, ok := findByID(comps, id)
+		if !ok {
+			t.Errorf("expected Kaggle resource %s, got %+v", id, comps)
+			continue
+		}
+		if c.Type != DiscoveryTypeKaggle || c.Method != w[0] || c.Version != w[1] {
+			t.Errorf("%s: type=%q method=%q version=%q, want %v", id, c.Type, c.Method, c.Version, w)
+		}
+	}
+	if len(comps) != len(want) {
+		t.Errorf("expected %d discoveries, got %+v", len(want), comps)
+	}
+
+	d, _ := findByID(comps, "kaggle:datasets/uciml/mushroom-classification")
+	if kind, handle, ok := KaggleRef(d); !ok || kind != KaggleDatasets || handle != "uciml/mushroom-classification" {
+		t.Errorf("KaggleRef = %q, %q, %v", kind, handle, ok)
+	}
+	if got := KaggleURL(d); got != "https://www.kaggle.com/datasets/uciml/mushroom-classification/versions/1" {
+		t.Errorf("KaggleURL = %q", got)
+	}
+}
+
+func TestOllamaModelsDetected(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "Modelfile", `FROM llama3:8b
+PARAMETER temperature 0.2
+SYSTEM "You are a helpful assistant."
+`)
+	writeFile(t, dir, "local/Modelfile", "FROM ./weights/mistral-7b.Q4_K_M.gguf\n")
+	writeFile(t, dir, "docker-compose.yml", `services:
+  ollama:
+    image: ollama/ollama:0.3.12
+    command: ["run", "phi3:mini"]
+  init:
+    image: curlimages/curl
+    entrypoint: ["/bin/sh", "-c", "ollama pull nomic-embed-text && ollama pull hf.co/bartowski/Llama-3.2-1B-Instruct-GGUF:Q4_K_M"]
+  app:
+    environment:
+      OLLAMA_MODEL: "jmorganca/codellama:7b"
+`)
+	writeFile(t, dir, "chat.py", `import ollama
+from langchain_ollama import ChatOllama
+
+resp = ollama.chat(model="registry.ollama.ai/library/mistral", messages=[])
+llm = ChatOllama(model="qwen2.5:7b", temperature=0)
+`)
+
+	comps, err := Scan(dir)
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+
+	want := map[string][2]string{
+		"ollama:llama3:8b":               {"ollama_modelfile", "8b"},
+		"ollama:phi3:mini":               {"ollama_compose_command", "mini"},
+		"ollama:nomic-embed-text:latest": {"ollama_cli", "latest"},
+		"ollama:jmorganca/codellama:7b":  {"ollama_model_env", "7b"},
+		"ollama:mistral:latest":          {"ollama_client", "latest"},
+		"ollama:qwen2.5:7b":              {"ollama_client", "7b"},
+	}
+	for id, w := range want {
+		c, ok := findByID(comps, id)
+		if !ok {
+			t.Errorf("expected Ollama model %s, got %+v", id, comps)
+			continue
+		}
+		if c.Type != DiscoveryTypeProviderModel || c.Method != w[0] || c.Version != w[1] {
+			t.Errorf("%s: type=%q method=%q version=%q, want %v", id, c.Type, c.Method, c.Version, w)
+		}
+	}
+	if c, ok := findByID(comps, "bartowski/Llama-3.2-1B-Instruct-GGUF"); !ok || c.Type != "model" {
+		t.Errorf("expected hf.co reference as Hugging Face model, got %+v", comps)
+	}
+	if c, ok := findByID(comps, filepath.Join(dir, "local", "weights", "mistral-7b.Q4_K_M.gguf")); !ok || c.Type != DiscoveryTypeModelFile || c.Format != "gguf" {
+		t.Errorf("expected local Modelfile weights as model-file, got %+v", comps)
+	}
+
+	d, _ := findByID(comps, "ollama:jmorganca/codellama:7b")
+	if ns, model, tag, ok := OllamaRef(d); !ok || ns != "jmorganca" || model != "codellama" || tag != "7b" {
+		t.Errorf("OllamaRef = %q, %q, %q, %v", ns, model, tag, ok)
+	}
+	if got := ProviderModelURL(d); got != "https://ollama.com/jmorganca/codellama:7b" {
+		t.Errorf("ProviderModelURL = %q", got)
+	}
+	d, _ = findByID(comps, "ollama:llama3:8b")
+	if ns, model, _, _ := OllamaRef(d); ns != "library" || model != "llama3" {
+		t.Errorf("OllamaRef(llama3) = %q, %q", ns, model)
+	}
+	if got := ProviderModelURL(d); got != "https://ollama.com/library/llama3:8b" {
+		t.Errorf("ProviderModelURL = %q", got)
+	}
+}
+
+func TestHostedModelsDetected(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "chat.py", `import anthropic, boto3, openai
+from openai import AzureOpenAI
+
+client = openai.OpenAI()
+resp = client.chat.completions.create(model="gpt-4o-mini", messages=msgs)
+legacy = openai.ChatCompletion.create(model="gpt-3.5-turbo", messages=msgs)
+plan = client.responses.create(model="o3-mini", input=prompt)
+claude = anthropic.Anthropic()
+msg = claude.messages.create(model="claude-3-5-sonnet-20241022", max_tokens=1024, messages=msgs)
+azure = AzureOpenAI(azure_endpoint="https://contoso.openai.azure.com/", azure_deployment="gpt4o-prod")
+bedrock = boto3.client("bedrock-runtime", region_name="us-east-1")
+bedrock.invoke_model(modelId="anthropic.claude-3-haiku-20240307-v1:0", body=body)
+ARN = "arn:aws:bedrock:us-east-1::foundation-model/amazon.titan-embed-text-v2:0"
+tokenizer = AutoTokenizer.from_pretrained("openai-community/gpt2")
+`)
+	writeFile(t, dir, "web/app.ts", `import Anthropic from "@anthropic-ai/sdk";
+const client = new Anthropic();
+`)
+
+	comps, err := Scan(dir)
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+
+	want := map[string]string{
+		"openai:gpt-4o-mini":                             "openai_model",
+		"openai:gpt-3.5-turbo":                           "openai_model",
+		"openai:o3-mini":                                 "openai_model",
+		"anthropic:claude-3-5-sonnet-20241022":           "anthropic_model",
+		"azure-openai:gpt4o-prod":                        "azure_openai_deployment",
+		"bedrock:anthropic.claude-3-haiku-20240307-v1:0": "bedrock_model_id",
+		"bedrock:amazon.titan-embed-text-v2:0":           "bedrock_arn",
+	}
+	for id, method := range want {
+		c, ok := findByID(comps, id)
+		if !ok {
+			t.Errorf("expected hosted model %s, got %+v", id, comps)
+			continue
+		}
+		if c.Type != DiscoveryTypeHostedModel || c.Method != method {
+			t.Errorf("%s: type=%q method=%q, want %s", id, c.Type, c.Method, method)
+		}
+	}
+	// Clients of providers with a named model are left out.
+	for _, id := range []string{"openai", "anthropic", "azure-openai", "bedrock"} {
+		if _, ok := findByID(comps, id); ok {
+			t.Errorf("expected client %s to be dropped, got %+v", id, comps)
+		}
+	}
+	if c, ok := findByID(comps, "openai-community/gpt2"); !ok || c.Type != "model" {
+		t.Errorf("expected Hugging Face model next to hosted models, got %+v", comps)
+	}
+
+	d, _ := findByID(comps, "bedrock:anthropic.claude-3-haiku-20240307-v1:0")
+	if provider, model, ok := HostedRef(d); !ok || provider != HostedBedrock || model != "anthropic.claude-3-haiku-20240307-v1:0" {
+		t.Errorf("HostedRef = %q, %q, %v", provider, model, ok)
+	}
+}
+
+func TestHostedClientWithoutModel(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "app.js", `import OpenAI from "openai";
+const openai = new OpenAI({ apiKey: process.env.OPENAI_API_KEY });
+`)
+	comps, err := Scan(dir)
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	if len(comps) != 1 || comps[0].ID != HostedOpenAI || comps[0].Type != DiscoveryTypeHostedModel {
+		t.Fatalf("expected only the OpenAI client, got %+v", comps)
+	}
+	if _, model, _ := HostedRef(comps[0]); model != "" {
+		t.Errorf("HostedRef model = %q, want empty", model)
+	}
+}
+
 // ── Jupyter Notebook tests ────────────────────────────────────────────────────.
 
 func TestNotebookCodeCell(t *testing.T) {
@@ -617,7 +1044,7 @@ func TestDedupeMergesEvidence(t *testing.T) {
 //     Markdown, Shell, and Dockerfile sources are found.
 //   - Local paths and variable-indirected IDs are NOT false-positives.
 //
-// .
+//.
 // Run with -v to see the full detection report.
 func TestScanRepoDifficult(t *testing.T) {
 	// Resolve the path from the package dir (internal/scanner → repo root → targets).
@@ -791,3 +1218,931 @@ func TestIsPlausibleModelID(t *testing.T) {
 		}
 	}
 }
+
+// ── model file magic detection ────────────────────────────────────────────────.
+
+func writeBytes(t *testing.T, dir, name string, data []byte) string {
+	t.Helper()
+	p := filepath.Join(dir, name)
+	if err := os.WriteFile(p, data, 0o644); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+	return p
+}
+
+func TestScanDetectsModelFilesByContent(t *testing.T) {
+	dir := t.TempDir()
+
+	gguf := append([]byte("GGUF\x03\x00\x00\x00"), make([]byte, 120)...)
+	writeBytes(t, dir, "weights.bin", gguf)
+
+	header := []byte(`{"w":{"dtype":"F32","shape":[1],"data_offsets":[0,4]}}`)
+	st := make([]byte, 8)
+	binary.LittleEndian.PutUint64(st, uint64(len(header)))
+	st = append(append(st, header...), 0, 0, 128, 63)
+	writeBytes(t, dir, "model.safetensors", st)
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, name := range []string{"model/data.pkl", "model/constants.pkl", "model/code/__torch__.py"} {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("zip create: %v", err)
+		}
+		_, _ = w.Write([]byte("payload"))
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("zip close: %v", err)
+	}
+	writeBytes(t, dir, "traced.pt", buf.Bytes())
+
+	// A checkpoint written by torch.save with the legacy (pre-1.6) format.
+	legacy := append([]byte{0x80, 0x02, 0x8a, 0x0a, 0x6c, 0xfc, 0x9c, 0x46, 0xf9, 0x20, 0x6a, 0xa8, 0x50, 0x19, 0x2e}, make([]byte, 100)...)
+	writeBytes(t, dir, "pytorch_model.bin", legacy)
+
+	onnx := append([]byte{0x08, 0x07, 0x12, 0x07}, []byte("pytorch")...)
+	onnx = append(onnx, make([]byte, 100)...)
+	writeBytes(t, dir, "model.onnx", onnx)
+
+	// Noise that must not be reported.
+	writeBytes(t, dir, "notes.bin", make([]byte, 256))
+	writeBytes(t, dir, "tiny.gguf", []byte("GGUF"))
+
+	comps, err := Scan(dir)
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	want := map[string]string{
+		"weights.bin":       "gguf",
+		"model.safetensors": "safetensors",
+		"traced.pt":         "torchscript",
+		"pytorch_model.bin": "pytorch",
+		"model.onnx":        "onnx",
+	}
+	if len(comps) != len(want) {
+		t.Fatalf("expected %d model files, got %d: %+v", len(want), len(comps), comps)
+	}
+	for _, c := range comps {
+		if c.Type != DiscoveryTypeModelFile {
+			t.Fatalf("unexpected type %q for %s", c.Type, c.Name)
+		}
+		if want[c.Name] != c.Format {
+			t.Fatalf("%s: format = %q, want %q", c.Name, c.Format, want[c.Name])
+		}
+		if c.Size <= 0 || len(c.Hash) != 64 {
+			t.Fatalf("%s: expected size and sha256, got size=%d hash=%q", c.Name, c.Size, c.Hash)
+		}
+	}
+
+	for name, format := range want {
+		if got := DetectModelFile(filepath.Join(dir, name)); got != format {
+			t.Errorf("DetectModelFile(%s) = %q, want %q", name, got, format)
+		}
+	}
+	for _, name := range []string{"notes.bin", "tiny.gguf", "missing.gguf"} {
+		if got := DetectModelFile(filepath.Join(dir, name)); got != "" {
+			t.Errorf("DetectModelFile(%s) = %q, want none", name, got)
+		}
+	}
+}
+
+func TestHashFileChunkedMatchesStream(t *testing.T) {
+	dir := t.TempDir()
+	data := make([]byte, 1<<20+123)
+	for i := range data {
+		data[i] = byte(i * 31)
+	}
+	p := writeBytes(t, dir, "weights.bin", data)
+	want := sha256.Sum256(data)
+
+	var calls int
+	res, err := HashFile(p, HashOptions{Workers: 4, ChunkSize: 64 << 10, OnProgress: func(HashProgress) { calls++ }})
+	if err != nil {
+		t.Fatalf("HashFile: %v", err)
+	}
+	if res.SHA256 != hex.EncodeToString(want[:]) {
+		t.Fatalf("chunked digest mismatch: %s", res.SHA256)
+	}
+	if calls < 2 {
+		t.Fatalf("expected progress callbacks per chunk, got %d", calls)
+	}
+
+	res, err = HashFile(p, HashOptions{Workers: 1})
+	if err != nil || res.SHA256 != hex.EncodeToString(want[:]) {
+		t.Fatalf("stream digest mismatch: %v %s", err, res.SHA256)
+	}
+}
+
+// failingReaderAt fails the reads at or after offset fail.
+type failingReaderAt struct {
+	data []byte
+	fail int64
+}
+
+func (r failingReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	if off >= r.fail {
+		return 0, errors.New("disk error")
+	}
+	return copy(p, r.data[off:]), nil
+}
+
+func TestHashChunkedSharesChunkSlots(t *testing.T) {
+	data := make([]byte, 1<<20)
+	want := sha256.Sum256(data)
+
+	// Many files hashed at once with many workers each hold at most.
+	// len(chunkSlots) chunks, and every slot is freed afterwards.
+	var wg sync.WaitGroup
+	for f := 0; f < 8; f++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			h := sha256.New()
+			if err := hashChunked(h, bytes.NewReader(data), "w.bin", int64(len(data)), 16<<10, 64, nil); err != nil {
+				t.Errorf("hashChunked: %v", err)
+			} else if !bytes.Equal(h.Sum(nil), want[:]) {
+				t.Errorf("chunked digest mismatch")
+			}
+		}()
+	}
+	wg.Wait()
+
+	err := hashChunked(sha256.New(), failingReaderAt{data: data, fail: 256 << 10}, "w.bin", int64(len(data)), 16<<10, 8, nil)
+	if err == nil {
+		t.Fatal("expected the read error")
+	}
+	if n := len(chunkSlots); n != 0 {
+		t.Fatalf("%d chunk slots still held", n)
+	}
+}
+
+func TestHashFileSidecar(t *testing.T) {
+	dir := t.TempDir()
+	p := writeBytes(t, dir, "model.gguf", []byte("weights"))
+	sidecar := strings.Repeat("ab", 32)
+	writeBytes(t, dir, "model.gguf.sha256", []byte(sidecar+"  model.gguf\n"))
+
+	res, err := HashFile(p, HashOptions{UseSidecar: true})
+	if err != nil {
+		t.Fatalf("HashFile: %v", err)
+	}
+	if !res.FromSidecar || res.SHA256 != sidecar {
+		t.Fatalf("expected sidecar digest, got %+v", res)
+	}
+
+	res, err = HashFile(p, HashOptions{})
+	if err != nil || res.FromSidecar {
+		t.Fatalf("sidecar must be ignored unless enabled: %+v %v", res, err)
+	}
+}
+
+func TestHashFileBLAKE3(t *testing.T) {
+	dir := t.TempDir()
+	abc := writeBytes(t, dir, "abc.bin", []byte("abc"))
+	writeBytes(t, dir, "abc.bin.sha256", []byte(strings.Repeat("ab", 32)+"\n"))
+	res, err := HashFile(abc, HashOptions{BLAKE3: true, UseSidecar: true})
+	if err != nil {
+		t.Fatalf("HashFile: %v", err)
+	}
+	// BLAKE3 test vector; the sidecar cannot provide it, so the file is read.
+	if res.BLAKE3 != "6437b3ac38465133ffb63b75273a8db548c558465d79db03fd359c6cd5bd9d85" || res.FromSidecar {
+		t.Fatalf("unexpected result %+v", res)
+	}
+	want := sha256.Sum256([]byte("abc"))
+	if res.SHA256 != hex.EncodeToString(want[:]) {
+		t.Fatalf("SHA-256 = %s", res.SHA256)
+	}
+
+	data := make([]byte, 1<<20+123)
+	for i := range data {
+		data[i] = byte(i * 31)
+	}
+	p := writeBytes(t, dir, "weights.bin", data)
+	chunked, err := HashFile(p, HashOptions{Workers: 4, ChunkSize: 64 << 10, BLAKE3: true})
+	if err != nil {
+		t.Fatalf("HashFile: %v", err)
+	}
+	stream, err := HashFile(p, HashOptions{Workers: 1, BLAKE3: true})
+	if err != nil || chunked.BLAKE3 == "" || chunked.BLAKE3 != stream.BLAKE3 {
+		t.Fatalf("chunked BLAKE3 %q != stream %q (%v)", chunked.BLAKE3, stream.BLAKE3, err)
+	}
+	if plain, _ := HashFile(p, HashOptions{}); plain.BLAKE3 != "" {
+		t.Fatalf("BLAKE3 computed without being asked for")
+	}
+}
+
+// ── project partitioning ─────────────────────────────────────────────────────.
+
+func TestScanProjectsPartitionsMonorepo(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "tools/eval.py", `AutoModel.from_pretrained("org/root-model")`)
+	writeFile(t, dir, "services/chat/pyproject.toml", "[project]\nname = \"chat\"\n")
+	writeFile(t, dir, "services/chat/app.py", `AutoModel.from_pretrained("org/shared-model")`)
+	writeFile(t, dir, "services/chat/worker/job.py", `AutoModel.from_pretrained("org/chat-model")`)
+	writeFile(t, dir, "services/web/package.json", `{"name": "web"}`)
+	writeFile(t, dir, "services/web/go.mod", "module example.com/web\n")
+	writeFile(t, dir, "services/web/index.js", `const p = await pipeline("sentiment-analysis", "org/shared-model");`)
+	writeFile(t, dir, "services/web/node_modules/dep/package.json", `{"name": "dep"}`)
+	writeFile(t, dir, "services/empty/go.mod", "module example.com/empty\n")
+
+	projects, err := ScanProjects(dir, Options{})
+	if err != nil {
+		t.Fatalf("ScanProjects: %v", err)
+	}
+
+	byRoot := make(map[string][]string)
+	var order []string
+	for _, p := range projects {
+		rel, err := filepath.Rel(dir, filepath.FromSlash(p.Root))
+		if err != nil {
+			t.Fatalf("rel: %v", err)
+		}
+		rel = filepath.ToSlash(rel)
+		order = append(order, rel)
+		var ids []string
+		for _, d := range p.Discoveries {
+			ids = append(ids, d.ID)
+		}
+		byRoot[rel] = ids
+	}
+
+	if len(projects) != 4 || order[0] != "." {
+		t.Fatalf("projects = %v, want root first and 3 nested projects", order)
+	}
+	want := map[string][]string{
+		".":              {"org/root-model"},
+		"services/chat":  {"org/chat-model", "org/shared-model"},
+		"services/web":   {"org/shared-model"},
+		"services/empty": nil,
+	}
+	for root, ids := range want {
+		got, ok := byRoot[root]
+		if !ok {
+			t.Fatalf("project %q not found in %v", root, order)
+		}
+		sort.Strings(got)
+		if strings.Join(got, ",") != strings.Join(ids, ",") {
+			t.Errorf("project %q discoveries = %v, want %v", root, got, ids)
+		}
+	}
+
+	all := ProjectDiscoveries(projects)
+	if len(all) != 3 {
+		t.Errorf("ProjectDiscoveries returned %d discoveries, want 3 unique", len(all))
+	}
+}
+
+// ── comments, docstrings and test files ─────────────────────────────────────.
+
+func TestPythonCommentsAndDocstringsIgnored(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "app.py", `"""Module docstring.
+
+Example: AutoModel.from_pretrained("org/docstring-model")
+"""
+# model = AutoModel.from_pretrained("org/comment-model")
+
+def load():
+    '''Loads pipeline("text-classification", model="org/func-doc-model").'''
+    prompt = """Use AutoModel.from_pretrained("org/prompt-model")"""
+    return AutoModel.from_pretrained("org/real-model")  # not "org/trailing-model"
+`)
+
+	comps, err := Scan(dir)
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	var ids []string
+	for _, c := range comps {
+		ids = append(ids, c.ID)
+		if c.Confidence != "" {
+			t.Errorf("%s: Confidence = %q, want empty", c.ID, c.Confidence)
+		}
+	}
+	sort.Strings(ids)
+	if got := strings.Join(ids, ","); got != "org/prompt-model,org/real-model" {
+		t.Fatalf("discoveries = %s, want org/prompt-model,org/real-model", got)
+	}
+
+	comps, err = ScanWithOptions(dir, Options{IncludeComments: true})
+	if err != nil {
+		t.Fatalf("ScanWithOptions: %v", err)
+	}
+	if len(comps) != 5 {
+		t.Fatalf("expected 5 discoveries with comments included, got %d: %+v", len(comps), comps)
+	}
+	for _, id := range []string{"org/docstring-model", "org/comment-model", "org/func-doc-model"} {
+		c, ok := findByID(comps, id)
+		if !ok {
+			t.Fatalf("%s not found with comments included", id)
+		}
+		if c.Confidence != ConfidenceLow {
+			t.Errorf("%s: Confidence = %q, want %q", id, c.Confidence, ConfidenceLow)
+		}
+	}
+	// Trailing comments are reported as a separate line of prose.
+	if _, ok := findByID(comps, "org/trailing-model"); ok {
+		t.Errorf("org/trailing-model has no call and must not be detected")
+	}
+}
+
+func TestSplitPythonSourceKeepsLineAlignment(t *testing.T) {
+	lines := []string{
+		`x = call(`,
+		`    """not a docstring""",  # note`,
+		`)`,
+		`s = 'a # b'`,
+		`path = "c:\\dir\\" + \`,
+		`    """still code"""`,
+	}
+	code, prose := splitPythonSource(lines)
+	if len(code) != len(lines) || len(prose) != len(lines) {
+		t.Fatalf("expected %d lines, got %d/%d", len(lines), len(code), len(prose))
+	}
+	if code[1] != `    """not a docstring""",  ` || prose[1] != "# note" {
+		t.Errorf("line 2 = %q / %q", code[1], prose[1])
+	}
+	if code[3] != `s = 'a # b'` || prose[3] != "" {
+		t.Errorf("hash inside a string must stay code: %q / %q", code[3], prose[3])
+	}
+	if code[5] != `    """still code"""` {
+		t.Errorf("string after a backslash continuation must stay code: %q", code[5])
+	}
+}
+
+func TestScanMarksTestFilesLowConfidence(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "tests/test_loading.py", `AutoModel.from_pretrained("org/fixture-model")`)
+	writeFile(t, dir, "src/conftest.py", `AutoModel.from_pretrained("org/shared-model")`)
+	writeFile(t, dir, "src/app.py", `AutoModel.from_pretrained("org/shared-model")`)
+
+	comps, err := Scan(dir)
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	fixture, ok := findByID(comps, "org/fixture-model")
+	if !ok || fixture.Confidence != ConfidenceLow {
+		t.Errorf("fixture model = %+v, want low confidence", fixture)
+	}
+	shared, ok := findByID(comps, "org/shared-model")
+	if !ok || shared.Confidence != "" {
+		t.Errorf("model also used outside tests = %+v, want regular confidence", shared)
+	}
+}
+
+func TestIsTestPath(t *testing.T) {
+	tests := map[string]bool{
+		"test_model.py":           true,
+		"pkg/model_test.py":       true,
+		"conftest.py":             true,
+		"tests/data/config.yaml":  true,
+		"src/fixtures/model.json": true,
+		"src/testing_utils.py":    false,
+		"src/app.py":              false,
+		"contest.py":              false,
+		"latest/model.py":         false,
+	}
+	for path, want := range tests {
+		if got := isTestPath(path); got != want {
+			t.Errorf("isTestPath(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+// ── constant resolution ──────────────────────────────────────────────────────.
+
+func TestPythonResolvesStringConstants(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "app.py", `from transformers import AutoModel, pipeline
+
+MODEL_ID = "org/const-model"
+TASK = "text-classification"
+EMBEDDER: str = 'org/typed-model'
+SWAPPED = "org/first"
+SWAPPED = "org/second"
+
+model = AutoModel.from_pretrained(MODEL_ID)
+clf = pipeline(
+    TASK,
+    model=EMBEDDER,
+)
+other = AutoModel.from_pretrained(SWAPPED)
+cfg = AutoModel.from_pretrained(settings.MODEL_ID)
+`)
+
+	comps, err := Scan(dir)
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	var ids []string
+	for _, c := range comps {
+		ids = append(ids, c.ID)
+	}
+	sort.Strings(ids)
+	if got := strings.Join(ids, ","); got != "org/const-model,org/typed-model" {
+		t.Fatalf("discoveries = %s, want org/const-model,org/typed-model", got)
+	}
+
+	c, _ := findByID(comps, "org/const-model")
+	if c.Method != "from_pretrained" || !strings.Contains(c.Evidence, "at line 9") || !strings.Contains(c.Evidence, "resolved MODEL_ID from line 3") {
+		t.Errorf("unexpected discovery: %+v", c)
+	}
+	c, _ = findByID(comps, "org/typed-model")
+	if !strings.Contains(c.Evidence, "pipeline_model_kwarg at line 10") || !strings.Contains(c.Evidence, "resolved EMBEDDER from line 5") {
+		t.Errorf("unexpected discovery: %+v", c)
+	}
+}
+
+func TestJSResolvesStringConstants(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "index.ts", `import { pipeline } from "@huggingface/transformers";
+export const MODEL: string = "org/js-model";
+const label = "MODEL";
+const classifier = await pipeline("sentiment-analysis", MODEL);
+`)
+
+	comps, err := Scan(dir)
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	if len(comps) != 1 || comps[0].ID != "org/js-model" || comps[0].Method != "js_pipeline_positional" {
+		t.Fatalf("unexpected discoveries: %+v", comps)
+	}
+	if !strings.Contains(comps[0].Evidence, "resolved MODEL from line 2") {
+		t.Errorf("evidence %q does not mention the resolved constant", comps[0].Evidence)
+	}
+}
+
+func TestSubstituteIdentSkipsStringsAndKeywords(t *testing.T) {
+	c := stringConstant{name: "MODEL", value: "org/m", re: regexp.MustCompile(`\bMODEL\b`)}
+	tests := map[string]string{
+		`load(MODEL)`:            `load("org/m")`,
+		`load(cfg.MODEL)`:        `load(cfg.MODEL)`,
+		`f(MODEL="x")`:           `f(MODEL="x")`,
+		`print("MODEL", MODEL)`:  `print("MODEL", "org/m")`,
+		`if MODEL == other: x()`: `if "org/m" == other: x()`,
+	}
+	for in, want := range tests {
+		if got, _ := substituteIdent(in, c); got != want {
+			t.Errorf("substituteIdent(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+// ── templated IDs ────────────────────────────────────────────────────────────.
+
+func TestPythonFStringIsParameterized(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "app.py", `size = "base"
+model = AutoModel.from_pretrained(f"google-bert/bert-{size}-uncased")
+REPO = f"{org}/whisper"
+asr = pipeline("automatic-speech-recognition", model=REPO)
+plain = AutoModel.from_pretrained(f"org/static-model")
+`)
+
+	comps, err := Scan(dir)
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+
+	bert, ok := findByID(comps, "google-bert/bert-{size}-uncased")
+	if !ok {
+		t.Fatalf("templated from_pretrained not reported: %+v", comps)
+	}
+	if bert.Type != DiscoveryTypeParameterized || bert.Name != "google-bert/bert-" {
+		t.Errorf("unexpected discovery: %+v", bert)
+	}
+	if !strings.Contains(bert.Evidence, `from_pretrained(f"google-bert/bert-{size}-uncased")`) ||
+		!strings.Contains(bert.Evidence, `static prefix "google-bert/bert-"`) {
+		t.Errorf("evidence %q does not show the template and its prefix", bert.Evidence)
+	}
+
+	whisper, ok := findByID(comps, "{org}/whisper")
+	if !ok || whisper.Type != DiscoveryTypeParameterized || whisper.Name != "" {
+		t.Errorf("templated constant not reported as parameterized: %+v", comps)
+	}
+
+	// An f-string without placeholders is a plain literal.
+	if _, ok := findByID(comps, "org/static-model"); ok {
+		t.Errorf("f-string without fields should not be treated as a template")
+	}
+	for _, c := range comps {
+		if strings.Contains(c.ID, templatePlaceholder) || strings.Contains(c.Evidence, templatePlaceholder) {
+			t.Errorf("placeholder leaked into discovery: %+v", c)
+		}
+	}
+}
+
+func TestJSTemplateLiteralIsParameterized(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "index.js", "const p = await pipeline(\"feature-extraction\", `Xenova/${name}`);\n"+
+		"const q = await pipeline(\"feature-extraction\", `Xenova/all-MiniLM-L6-v2`);\n")
+
+	comps, err := Scan(dir)
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	tpl, ok := findByID(comps, "Xenova/${name}")
+	if !ok || tpl.Type != DiscoveryTypeParameterized || tpl.Name != "Xenova/" {
+		t.Fatalf("template literal not reported as parameterized: %+v", comps)
+	}
+	if len(comps) != 1 {
+		t.Errorf("expected only the templated reference, got %+v", comps)
+	}
+}
+
+func TestIsDeploymentManifest(t *testing.T) {
+	cases := map[string]bool{
+		"Dockerfile":                true,
+		"svc/Dockerfile.gpu":        true,
+		"docker-compose.yml":        true,
+		"ollama/Modelfile":          true,
+		"deploy/k8s/inference.yaml": true,
+		`charts\model\values.yaml`:  true,
+		"config/training.yaml":      false,
+		"src/app.py":                false,
+		"kubernetes/README.md":      false,
+		"":                          false,
+	}
+	for path, want := range cases {
+		if got := IsDeploymentManifest(path); got != want {
+			t.Errorf("IsDeploymentManifest(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestScanIgnorePatterns(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "app.py", `AutoModel.from_pretrained("org/kept")`)
+	writeFile(t, dir, "examples/demo.py", `AutoModel.from_pretrained("org/example")`)
+	writeFile(t, dir, "src/legacy/old.py", `AutoModel.from_pretrained("org/legacy")`)
+	writeFile(t, dir, "notebooks/explore.py", `AutoModel.from_pretrained("org/notebook")`)
+
+	opts := Options{Ignore: []string{"examples/", "src/legacy", "explore.*"}}
+	comps, err := ScanWithOptions(dir, opts)
+	if err != nil {
+		t.Fatalf("ScanWithOptions failed: %v", err)
+	}
+	if len(comps) != 1 || comps[0].ID != "org/kept" {
+		t.Fatalf("expected only org/kept, got %+v", comps)
+	}
+
+	projects, err := ScanProjects(dir, opts)
+	if err != nil {
+		t.Fatalf("ScanProjects failed: %v", err)
+	}
+	if got := ProjectDiscoveries(projects); len(got) != 1 || got[0].ID != "org/kept" {
+		t.Fatalf("expected only org/kept in projects, got %+v", got)
+	}
+}
+
+func TestScanPythonEnv(t *testing.T) {
+	env := t.TempDir()
+	site := filepath.Join("lib", "python3.11", "site-packages")
+	gguf := string(append([]byte("GGUF\x03\x00\x00\x00"), make([]byte, 120)...))
+
+	// A spaCy pipeline package.
+	writeFile(t, env, filepath.Join(site, "en_core_web_sm-3.7.1.dist-info", "METADATA"), "Metadata-Version: 2.1\nName: en_core_web_sm\nVersion: 3.7.1\n\nbody\n")
+	writeFile(t, env, filepath.Join(site, "en_core_web_sm", "meta.json"), `{"lang":"en","name":"core_web_sm","version":"3.7.1","spacy_version":">=3.7.2,<3.8.0"}`)
+	writeFile(t, env, filepath.Join(site, "en_core_web_sm", "en_core_web_sm-3.7.1", "ner", "model"), "weights")
+
+	// A package that ships a weight file.
+	writeFile(t, env, filepath.Join(site, "rembg-2.0.50.dist-info", "METADATA"), "Name: rembg\nVersion: 2.0.50\n")
+	writeFile(t, env, filepath.Join(site, "rembg-2.0.50.dist-info", "RECORD"), "rembg/__init__.py,sha256=x,1\nrembg/models/u2net.gguf,sha256=y,128\n")
+	writeFile(t, env, filepath.Join(site, "rembg", "models", "u2net.gguf"), gguf)
+
+	// Model caches inside the environment.
+	writeFile(t, env, filepath.Join("cache", "hub", "models--sentence-transformers--all-MiniLM-L6-v2", "snapshots", "c9745ed1d9f207416be6d2e6f8de32d1f16199bf", "model.gguf"), gguf)
+	writeFile(t, env, filepath.Join("cache", "hub", "models--sentence-transformers--all-MiniLM-L6-v2", "blobs", "abc"), gguf)
+	writeFile(t, env, filepath.Join("cache", "sentence_transformers", "org_model", "modules.json"), "[]")
+	writeFile(t, env, filepath.Join("cache", "sentence_transformers", "org_model", "0_Transformer", "model.gguf"), gguf)
+	writeFile(t, env, filepath.Join("torch", "hub", "checkpoints", "resnet50-0676ba61.gguf"), gguf)
+
+	results, err := ScanPythonEnv(env, Options{})
+	if err != nil {
+		t.Fatalf("ScanPythonEnv: %v", err)
+	}
+
+	type want struct{ name, version, method string }
+	wants := []want{
+		{"sentence-transformers/all-MiniLM-L6-v2/model.gguf", "c9745ed1d9f207416be6d2e6f8de32d1f16199bf", "python_env_hf_cache"},
+		{"org_model/0_Transformer/model.gguf", "", "python_env_sentence_transformers"},
+		{"en_core_web_sm", "3.7.1", "python_env_spacy"},
+		{"rembg/models/u2net.gguf", "2.0.50", "python_env_package"},
+		{"resnet50-0676ba61.gguf", "", "python_env_torch_hub"},
+	}
+	if len(results) != len(wants) {
+		t.Fatalf("expected %d discoveries, got %d: %+v", len(wants), len(results), results)
+	}
+	byName := make(map[string]Discovery)
+	for _, d := range results {
+		byName[d.Name] = d
+		if d.Type != DiscoveryTypeModelFile || d.Hash == "" || d.Size == 0 {
+			t.Errorf("%s: expected a hashed model-file discovery, got %+v", d.Name, d)
+		}
+	}
+	for _, w := range wants {
+		d, ok := byName[w.name]
+		if !ok {
+			t.Errorf("missing discovery %s in %+v", w.name, results)
+			continue
+		}
+		if d.Version != w.version || d.Method != w.method {
+			t.Errorf("%s: version %q method %q, want %q %q", w.name, d.Version, d.Method, w.version, w.method)
+		}
+	}
+
+	// The spaCy digest is the sha256sum listing of the package directory.
+	spacyDir := filepath.Join(env, site, "en_core_web_sm")
+	digest, _, count, err := DirectoryDigest(spacyDir, HashOptions{})
+	if err != nil || count != 2 || byName["en_core_web_sm"].Hash != digest {
+		t.Errorf("spaCy digest = %q, DirectoryDigest = %q (%d files, %v)", byName["en_core_web_sm"].Hash, digest, count, err)
+	}
+}
+
+func TestScanDVCAndLFSPointers(t *testing.T) {
+	root := t.TempDir()
+	oid := strings.Repeat("ab", 32)
+	writeFile(t, root, filepath.Join("models", "model.pt.dvc"), "outs:\n- md5: a304afb96060aad90176268345e10355\n  size: 14445097\n  hash: md5\n  path: model.pt\n")
+	writeFile(t, root, "data.dvc", "outs:\n- md5: 0b4e4d3f2a8c1e9d7f6a5b4c3d2e1f00.dir\n  size: 3000000\n  nfiles: 3\n  path: data\n")
+	writeFile(t, root, "dvc.lock", "schema: '2.0'\nstages:\n  train:\n    cmd: python train.py\n    outs:\n    - path: out/clf.pkl\n      md5: 11112222333344445555666677778888\n      size: 2048\n    - path: metrics.json\n      md5: 99990000aaaabbbbccccddddeeeeffff\n      size: 100\n")
+	writeFile(t, root, filepath.Join("corpus", "train.parquet"), "version https://git-lfs.github.com/spec/v1\noid sha256:"+oid+"\nsize 123456\n")
+	writeFile(t, root, filepath.Join("assets", "logo.png"), "version https://git-lfs.github.com/spec/v1\noid sha256:"+oid+"\nsize 99\n")
+	// The DVC cache duplicates the tracked outputs and is skipped.
+	writeFile(t, root, filepath.Join(".dvc", "cache", "files", "md5", "a3", "04afb9"), string(append([]byte("GGUF\x03\x00\x00\x00"), make([]byte, 120)...)))
+
+	results, err := Scan(root)
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	type want struct{ typ, format, hash, alg, method string }
+	wants := map[string]want{
+		"model.pt":      {DiscoveryTypeModelFile, "pytorch", "a304afb96060aad90176268345e10355", HashAlgorithmMD5, "dvc_pointer"},
+		"data":          {DiscoveryTypeDataFile, "directory", "0b4e4d3f2a8c1e9d7f6a5b4c3d2e1f00", HashAlgorithmMD5, "dvc_pointer"},
+		"clf.pkl":       {DiscoveryTypeModelFile, "pickle", "11112222333344445555666677778888", HashAlgorithmMD5, "dvc_pointer"},
+		"train.parquet": {DiscoveryTypeDataFile, "parquet", oid, "", "git_lfs_pointer"},
+	}
+	if len(results) != len(wants) {
+		t.Fatalf("got %d discoveries, want %d: %+v", len(results), len(wants), results)
+	}
+	for _, d := range results {
+		w, ok := wants[d.Name]
+		if !ok {
+			t.Errorf("unexpected discovery %+v", d)
+			continue
+		}
+		got := want{d.Type, d.Format, d.Hash, d.HashAlgorithm, d.Method}
+		if got != w {
+			t.Errorf("%s: got %+v, want %+v", d.Name, got, w)
+		}
+		if d.ID != d.Path || !strings.HasSuffix(d.Path, "/"+d.Name) {
+			t.Errorf("%s: ID %q, Path %q", d.Name, d.ID, d.Path)
+		}
+	}
+}
+
+func TestScanTrackedArtifacts(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, filepath.Join(".dvc", "config"), "[core]\n    remote = storage\n['remote \"storage\"']\n    url = s3://ml-bucket/dvcstore\n")
+	writeFile(t, root, "dvc.yaml", "stages:\n  train:\n    cmd: python train.py\n    outs:\n    - models/clf.pkl\n"+
+		"artifacts:\n  churn-classifier:\n    path: models/clf.pkl\n    type: model\n    desc: Churn model\n  churn-data:\n    path: data/train.parquet\n    type: dataset\n")
+	writeFile(t, root, "dvc.lock", "schema: '2.0'\nstages:\n  train:\n    cmd: python train.py\n    outs:\n    - path: models/clf.pkl\n      hash: md5\n      md5: 11112222333344445555666677778888\n      size: 2048\n")
+	writeFile(t, root, filepath.Join("models", "old.pt.dvc"), "outs:\n- md5: a304afb96060aad90176268345e10355\n  size: 1445\n  remote: legacy\n  path: old.pt\n")
+	writeFile(t, root, filepath.Join("mlruns", "1", "abc", "artifacts", "model", "MLmodel"),
+		"artifact_path: model\nflavors:\n  python_function:\n    loader_module: mlflow.sklearn\n    model_path: model.pkl\n  sklearn:\n    pickled_model: model.pkl\n    sklearn_version: 1.3.0\nmlflow_version: 2.8.0\nrun_id: abc123\n")
+	writeFile(t, root, filepath.Join("mlruns", "1", "abc", "artifacts", "model", "model.pkl"), "pickle")
+
+	results, err := Scan(root)
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	type want struct{ typ, artifactType, format, hash, storage, method string }
+	wants := map[string]want{
+		// The dvc.lock output of the artifact is merged into it.
+		"churn-classifier": {DiscoveryTypeTrackedArtifact, ArtifactTypeModel, "pickle", "11112222333344445555666677778888",
+			"s3://ml-bucket/dvcstore/files/md5/11/112222333344445555666677778888", "dvc_artifact"},
+		"churn-data": {DiscoveryTypeTrackedArtifact, ArtifactTypeDataset, "parquet", "", "", "dvc_artifact"},
+		// The remote of an output without a configured URL leaves no URI.
+		"old.pt": {DiscoveryTypeModelFile, "", "pytorch", "a304afb96060aad90176268345e10355", "", "dvc_pointer"},
+		"model": {DiscoveryTypeTrackedArtifact, ArtifactTypeModel, "sklearn",
+			"6d08a4e630e4aa0d5cd873e65aea0a23df42de61073ecb49ef17158fe6a9dcea", "runs:/abc123/model", "mlflow_model"},
+	}
+	if len(results) != len(wants) {
+		t.Fatalf("got %d discoveries, want %d: %+v", len(results), len(wants), results)
+	}
+	for _, d := range results {
+		w, ok := wants[d.Name]
+		if !ok {
+			t.Errorf("unexpected discovery %+v", d)
+			continue
+		}
+		got := want{d.Type, d.ArtifactType, d.Format, d.Hash, d.StorageURI, d.Method}
+		if got != w {
+			t.Errorf("%s: got %+v, want %+v", d.Name, got, w)
+		}
+	}
+}
+
+func TestScanBentoMLAndKServeBundles(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, filepath.Join("bento", "bentofile.yaml"),
+		"service: \"service:Summarization\"\n"+
+			"models:\n"+
+			"  - \"summarization-model:latest\"\n"+
+			"  - tag: \"iris_clf:v2\"\n"+
+			"    alias: iris\n"+
+			"  - hf://google-bert/bert-base-uncased\n")
+	writeFile(t, root, filepath.Join("bento", "service.py"),
+		"import bentoml\n"+
+			"from bentoml.models import HuggingFaceModel\n\n"+
+			"@bentoml.service(resources={\"gpu\": 1})\n"+
+			"class Summarization:\n"+
+			"    model_path = HuggingFaceModel(\"sshleifer/distilbart-cnn-12-6\")\n"+
+			"    clf = bentoml.models.get(\"iris_clf:v2\")\n"+
+			"    def __init__(self):\n"+
+			"        self.m = bentoml.sklearn.load_model(\"summarization-model\")\n")
+	writeFile(t, root, filepath.Join("k8s", "isvc.yaml"),
+		"apiVersion: serving.kserve.io/v1beta1\n"+
+			"kind: InferenceService\n"+
+			"metadata:\n  name: llama3\n"+
+			"spec:\n  predictor:\n    model:\n      modelFormat:\n        name: huggingface\n"+
+			"      args:\n        - --model_name=llama3\n        - --model_id=meta-llama/Meta-Llama-3-8B-Instruct\n"+
+			"---\n"+
+			"apiVersion: serving.kserve.io/v1beta1\n"+
+			"kind: InferenceService\n"+
+			"metadata:\n  name: sklearn-iris\n"+
+			"spec:\n  predictor:\n    sklearn:\n      storageUri: \"gs://kfserving-examples/models/sklearn/1.0/model?sig=secret\"\n"+
+			"  transformer:\n    model:\n      storageUri: pvc://claim/tokenizer\n")
+
+	results, err := Scan(root)
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	bento := ServingBundle{Kind: BundleKindBentoML, Name: "Summarization", Path: filepath.ToSlash(filepath.Join(root, "bento"))}
+	isvcPath := filepath.ToSlash(filepath.Join(root, "k8s", "isvc.yaml"))
+	llama := ServingBundle{Kind: BundleKindKServe, Name: "llama3", Path: isvcPath}
+	iris := ServingBundle{Kind: BundleKindKServe, Name: "sklearn-iris", Path: isvcPath}
+
+	type want struct {
+		typ, format, version string
+		bundle               ServingBundle
+	}
+	wants := map[string]want{
+		"summarization-model:latest":                       {DiscoveryTypeModelFile, "bentoml", "", bento},
+		"iris_clf:v2":                                      {DiscoveryTypeModelFile, "bentoml", "v2", bento},
+		"google-bert/bert-base-uncased":                    {"model", "", "", bento},
+		"sshleifer/distilbart-cnn-12-6":                    {"model", "", "", bento},
+		"meta-llama/Meta-Llama-3-8B-Instruct":              {"model", "", "", llama},
+		"gs://kfserving-examples/models/sklearn/1.0/model": {DiscoveryTypeWeightURL, "sklearn", "", iris},
+		"pvc://claim/tokenizer":                            {DiscoveryTypeWeightURL, "", "", iris},
+	}
+	if len(results) != len(wants) {
+		t.Fatalf("got %d discoveries, want %d: %+v", len(results), len(wants), results)
+	}
+	for _, d := range results {
+		w, ok := wants[d.ID]
+		if !ok {
+			t.Errorf("unexpected discovery %+v", d)
+			continue
+		}
+		if d.Type != w.typ || d.Format != w.format || d.Version != w.version {
+			t.Errorf("%s: type %q, format %q, version %q; want %+v", d.ID, d.Type, d.Format, d.Version, w)
+		}
+		if len(d.Bundles) != 1 || d.Bundles[0] != w.bundle {
+			t.Errorf("%s: bundles %+v, want [%+v]", d.ID, d.Bundles, w.bundle)
+		}
+		if strings.Contains(d.Evidence, "secret") {
+			t.Errorf("%s: evidence leaks the signed query: %q", d.ID, d.Evidence)
+		}
+	}
+	// The store model referenced by the bentofile and the service merges.
+	if d, _ := findByID(results, "iris_clf:v2"); !strings.Contains(d.Evidence, "bentofile_model at line 4") || !strings.Contains(d.Evidence, "bentoml_model at line 7") {
+		t.Errorf("iris_clf evidence = %q", d.Evidence)
+	}
+}
+
+func TestClassifyTrackedArtifact(t *testing.T) {
+	tests := []struct {
+		path       string
+		size       int64
+		dir        bool
+		typ, fmt   string
+		isArtifact bool
+	}{
+		{"repo/model.safetensors", 10, false, DiscoveryTypeModelFile, "safetensors", true},
+		{"repo/train.csv", 10, false, DiscoveryTypeDataFile, "csv", true},
+		{"repo/models/bert/blob", 2 << 20, false, DiscoveryTypeModelFile, "", true},
+		{"repo/models/bert/blob", 100, false, "", "", false},
+		{"repo/datasets/raw.zip", 2 << 20, false, DiscoveryTypeDataFile, "zip", true},
+		{"repo/images/big.zip", 2 << 20, false, "", "", false},
+		{"repo/data", 0, true, DiscoveryTypeDataFile, "directory", true},
+		{"repo/src", 0, true, "", "", false},
+	}
+	for _, tt := range tests {
+		typ, format, ok := classifyTrackedArtifact(filepath.FromSlash(tt.path), tt.size, tt.dir)
+		if typ != tt.typ || format != tt.fmt || ok != tt.isArtifact {
+			t.Errorf("classifyTrackedArtifact(%q, %d, %v) = %q, %q, %v", tt.path, tt.size, tt.dir, typ, format, ok)
+		}
+	}
+}
+
+// commitAll stages the work tree of repo and commits it at the given time.
+func commitAll(t *testing.T, repo *git.Repository, msg string, when time.Time) string {
+	t.Helper()
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := wt.AddWithOptions(&git.AddOptions{All: true}); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	sig := &object.Signature{Name: "dev", Email: "dev@example.com", When: when}
+	h, err := wt.Commit(msg, &git.CommitOptions{Author: sig, Committer: sig, AllowEmptyCommits: true})
+	if err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+	return h.String()
+}
+
+func TestScanGitHistory(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 12, 0, 0, 0, time.UTC) }
+
+	writeFile(t, dir, "app/train.py", `model = AutoModel.from_pretrained("google-bert/bert-base-uncased")`+"\n")
+	writeFile(t, dir, "app/serve.py", `pipe = pipeline("text-generation", model="openai-community/gpt2")`+"\n")
+	first := commitAll(t, repo, "initial", day(1))
+
+	// gpt2 moves to another file; bert is replaced by roberta.
+	if err := os.Remove(filepath.Join(dir, "app/serve.py")); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, dir, "app/api.py", `pipe = pipeline("text-generation", model="openai-community/gpt2")`+"\n")
+	writeFile(t, dir, "app/train.py", `model = AutoModel.from_pretrained("FacebookAI/roberta-base")`+"\n")
+	second := commitAll(t, repo, "switch to roberta", day(2))
+
+	writeFile(t, dir, "app/notes.py", `# nothing here`+"\n")
+	commitAll(t, repo, "unrelated", day(3))
+
+	hist, err := ScanGitHistory(filepath.Join(dir, "app"), 0, Options{})
+	if err != nil {
+		t.Fatalf("ScanGitHistory: %v", err)
+	}
+	byID := map[string]Discovery{}
+	var order []string
+	for _, d := range hist {
+		byID[d.ID] = d
+		order = append(order, d.ID)
+	}
+	if len(hist) != 3 {
+		t.Fatalf("expected 3 references, got %v", order)
+	}
+
+	bert := byID["google-bert/bert-base-uncased"]
+	if h := bert.History; h == nil || h.IntroducedCommit != first || !h.IntroducedAt.Equal(day(1)) || h.RemovedCommit != second || !h.RemovedAt.Equal(day(2)) {
+		t.Errorf("bert history = %+v", bert.History)
+	}
+	if !strings.HasSuffix(bert.Path, "/app/train.py") {
+		t.Errorf("bert path = %q", bert.Path)
+	}
+	if h := byID["openai-community/gpt2"].History; h == nil || h.IntroducedCommit != first || h.RemovedCommit != "" {
+		t.Errorf("a reference moved to another file must not be removed: %+v", h)
+	}
+	if h := byID["FacebookAI/roberta-base"].History; h == nil || h.IntroducedCommit != second {
+		t.Errorf("roberta history = %+v", h)
+	}
+
+	// The last commit only: everything in it is attributed to it.
+	recent, err := ScanGitHistory(dir, 1, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recent) != 2 {
+		t.Errorf("with one commit expected the 2 current references, got %+v", recent)
+	}
+
+	current, err := Scan(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	merged := MergeHistory(current, hist)
+	if len(merged) != 3 {
+		t.Fatalf("merged = %+v", merged)
+	}
+	for _, d := range merged {
+		if d.History == nil {
+			t.Errorf("%s has no history", d.ID)
+		}
+		if (d.History.RemovedCommit != "") != (d.ID == "google-bert/bert-base-uncased") {
+			t.Errorf("%s: removed = %q", d.ID, d.History.RemovedCommit)
+		}
+	}
+
+	if _, err := ScanGitHistory(t.TempDir(), 0, Options{}); !errors.Is(err, ErrNotGitRepository) {
+		t.Errorf("expected ErrNotGitRepository, got %v", err)
+	}
+}
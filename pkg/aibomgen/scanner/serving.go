@@ -0,0 +1,103 @@
+package scanner
+
+import (
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/idlab-discover/aibomgen-cli/internal/fsutil"
+)
+
+// ggufArgRe matches a llama.cpp model path argument:.
+//
+//	llama-server -m models/llama-3-8b.Q4_K_M.gguf  |  ["--model", "/models/x.gguf"].
+var ggufArgRe = regexp.MustCompile(`(?:^|[\s"'\[,])(?:-m|--model)(?:\s*=\s*|\s+|["']\s*,\s*["'])["']?([^\s"',\]]+\.gguf)\b`)
+
+// weightFileExts are file extensions that mark a path to local weights rather.
+// than a Hugging Face repository ID.
+var weightFileExts = []string{".gguf", ".safetensors", ".bin", ".pt", ".pth", ".onnx"}
+
+// scanServingConfig scans a shell script, Dockerfile or YAML file with rules.
+// and additionally reports GGUF files passed to llama.cpp with -m / --model.
+func scanServingConfig(path string, rules []detectionRule) []Discovery {
+	lines, err := readLines(path)
+	if err != nil {
+		return nil
+	}
+	results := scanTextLines(lines, nil, rules, false, path)
+	return append(results, ggufReferences(lines, path)...)
+}
+
+// ggufReferences returns a "model-file" discovery for every GGUF path passed.
+// to llama.cpp in lines. Relative paths are resolved against the directory of.
+// the referencing file. Path and ID hold the weight file, so a reference to a.
+// file that is also in the scanned tree merges with its content-sniffed.
+// discovery; Size and Hash are only known from that discovery.
+func ggufReferences(lines []string, path string) []Discovery {
+	var results []Discovery
+	for i, line := range lines {
+		for _, m := range ggufArgRe.FindAllStringSubmatch(line, -1) {
+			ref := m[1]
+			weights := ref
+			if !filepath.IsAbs(weights) && !strings.HasPrefix(weights, "/") {
+				weights = filepath.Join(filepath.Dir(path), filepath.FromSlash(ref))
+			}
+			results = append(results, Discovery{
+				ID:       weights,
+				Name:     filepath.Base(filepath.FromSlash(ref)),
+				Type:     DiscoveryTypeModelFile,
+				Path:     weights,
+				Evidence: "llama_cpp_model at line " + strconv.Itoa(i+1) + " of " + fsutil.Normalize(path) + ": " + strings.TrimSpace(line),
+				Method:   "llama_cpp_model",
+				Format:   "gguf",
+			})
+		}
+	}
+	return results
+}
+
+// hasWeightFileExt reports whether id ends in a model weight file extension.
+func hasWeightFileExt(id string) bool {
+	lower := strings.ToLower(id)
+	for _, ext := range weightFileExts {
+		if strings.HasSuffix(lower, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// deploymentDirs are directory names whose YAML files describe deployments.
+var deploymentDirs = map[string]bool{
+	"k8s": true, "kubernetes": true, "helm": true, "charts": true, "kustomize": true,
+	"deploy": true, "deployment": true, "deployments": true, "manifests": true,
+}
+
+// IsDeploymentManifest reports whether path describes how a model is.
+// deployed rather than developed: Dockerfiles, Containerfiles, compose files,.
+// Ollama Modelfiles and YAML files below a Kubernetes, Helm or deployment.
+// directory.
+func IsDeploymentManifest(path string) bool {
+	path = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(path), `\`, "/"))
+	if path == "" {
+		return false
+	}
+	parts := strings.Split(path, "/")
+	name := parts[len(parts)-1]
+	switch {
+	case name == "dockerfile", strings.HasPrefix(name, "dockerfile."), strings.HasSuffix(name, ".dockerfile"),
+		name == "containerfile", strings.HasPrefix(name, "docker-compose"), strings.HasPrefix(name, "compose."),
+		isModelfile(name):
+		return true
+	}
+	if ext := filepath.Ext(name); ext != ".yaml" && ext != ".yml" {
+		return false
+	}
+	for _, dir := range parts[:len(parts)-1] {
+		if deploymentDirs[dir] {
+			return true
+		}
+	}
+	return false
+}
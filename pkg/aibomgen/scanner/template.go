@@ -0,0 +1,168 @@
+package scanner
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DiscoveryTypeParameterized is the Discovery.Type of model references built.
+// at runtime from a template, such as from_pretrained(f"org/{name}") in.
+// Python or pipeline("task", `org/${name}`) in JavaScript. ID holds the.
+// template (org/{name}) and Name its static prefix (org/). They cannot be.
+// resolved to a Hugging Face repository and are reported separately.
+const DiscoveryTypeParameterized = "parameterized"
+
+// templatePlaceholder replaces templated string literals before the.
+// detection rules run. It is a valid model ID so every rule can capture it.
+const templatePlaceholder = "aibomgen-template/"
+
+// templatePlaceholderRe matches a quoted placeholder in evidence text.
+var templatePlaceholderRe = regexp.MustCompile(`"` + regexp.QuoteMeta(templatePlaceholder) + `(\d+)"`)
+
+// stringTemplate is a templated string literal found in a source line.
+type stringTemplate struct {
+	literal string // source text including prefix and quotes
+	body    string // text between the quotes
+}
+
+// replaceTemplates rewrites Python f-strings (python=true) or JavaScript.
+// template literals with placeholders. It returns the rewritten lines and.
+// the templates, indexed by placeholder number.
+func replaceTemplates(lines []string, python bool) ([]string, []stringTemplate) {
+	var templates []stringTemplate
+	out := make([]string, len(lines))
+	for i, line := range lines {
+		var b strings.Builder
+		for j := 0; j < len(line); {
+			start, bodyStart, quote, ok := templateStart(line, j, python)
+			if !ok {
+				b.WriteByte(line[j])
+				j++
+				continue
+			}
+			end := closingQuote(line, bodyStart, quote)
+			if end < 0 {
+				b.WriteString(line[j:])
+				break
+			}
+			body := line[bodyStart:end]
+			if !isTemplated(body, python) {
+				b.WriteString(line[j : end+1])
+				j = end + 1
+				continue
+			}
+			b.WriteString(line[j:start])
+			b.WriteString(strconv.Quote(templatePlaceholder + strconv.Itoa(len(templates))))
+			templates = append(templates, stringTemplate{literal: line[start : end+1], body: body})
+			j = end + 1
+		}
+		out[i] = b.String()
+	}
+	return out, templates
+}
+
+// templateStart reports whether a templated literal starts at line[j]. For.
+// Python that is an f-string prefix (f, rf, fr in any case) followed by a.
+// quote; for JavaScript a backtick. It returns the literal start, the body.
+// start and the quote character.
+func templateStart(line string, j int, python bool) (start, bodyStart int, quote byte, ok bool) {
+	if !python {
+		if line[j] == '`' {
+			return j, j + 1, '`', true
+		}
+		return 0, 0, 0, false
+	}
+	if j > 0 && isIdentByte(line[j-1]) {
+		return 0, 0, 0, false
+	}
+	// The prefix is at most two letters: f, rf or fr.
+	k := j
+	hasF := false
+	for k < len(line) && k-j < 2 && strings.IndexByte("fFrR", line[k]) >= 0 {
+		hasF = hasF || line[k] == 'f' || line[k] == 'F'
+		k++
+	}
+	if !hasF || k >= len(line) || (line[k] != '"' && line[k] != '\'') {
+		return 0, 0, 0, false
+	}
+	return j, k + 1, line[k], true
+}
+
+// closingQuote returns the index of the quote closing a literal whose body.
+// starts at i, or -1 when it does not close on this line.
+func closingQuote(line string, i int, quote byte) int {
+	for ; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			i++
+		case quote:
+			return i
+		}
+	}
+	return -1
+}
+
+// isTemplated reports whether a literal body interpolates a value.
+func isTemplated(body string, python bool) bool {
+	if python {
+		return strings.Contains(strings.ReplaceAll(body, "{{", ""), "{")
+	}
+	return strings.Contains(body, "${")
+}
+
+func isIdentByte(ch byte) bool {
+	return ch == '_' || ch >= '0' && ch <= '9' || ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z'
+}
+
+// restoreTemplates undoes replaceTemplates in the discoveries: evidence.
+// shows the original literals again and discoveries whose ID is a.
+// placeholder become DiscoveryTypeParameterized discoveries.
+func restoreTemplates(results []Discovery, templates []stringTemplate) []Discovery {
+	if len(templates) == 0 {
+		return results
+	}
+	lookup := func(n string) (stringTemplate, bool) {
+		i, err := strconv.Atoi(n)
+		if err != nil || i >= len(templates) {
+			return stringTemplate{}, false
+		}
+		return templates[i], true
+	}
+
+	for i := range results {
+		d := &results[i]
+		d.Evidence = templatePlaceholderRe.ReplaceAllStringFunc(d.Evidence, func(p string) string {
+			if t, ok := lookup(templatePlaceholderRe.FindStringSubmatch(p)[1]); ok {
+				return t.literal
+			}
+			return p
+		})
+
+		n, ok := strings.CutPrefix(d.ID, templatePlaceholder)
+		if !ok {
+			continue
+		}
+		t, ok := lookup(n)
+		if !ok {
+			continue
+		}
+		d.Type = DiscoveryTypeParameterized
+		d.ID = t.body
+		d.Name = staticPrefix(t.body)
+		d.Evidence += " (static prefix " + strconv.Quote(d.Name) + ")"
+	}
+	return results
+}
+
+// staticPrefix returns the part of a template body before the first.
+// interpolation.
+func staticPrefix(body string) string {
+	if i := strings.Index(body, "${"); i >= 0 {
+		body = body[:i]
+	}
+	if i := strings.Index(body, "{"); i >= 0 {
+		body = body[:i]
+	}
+	return body
+}
@@ -0,0 +1,328 @@
+package scanner
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	yaml "go.yaml.in/yaml/v3"
+)
+
+// DiscoveryTypeTrackedArtifact is the Discovery.Type of a model or dataset.
+// registered with an ML experiment tracker: an artifact declared in the.
+// artifacts section of a dvc.yaml (the DVC model registry) or a model logged.
+// with MLflow (a directory holding an MLmodel file). ArtifactType tells.
+// models from datasets; Hash, HashAlgorithm and StorageURI locate the.
+// tracked copy when the tracker records them.
+//.
+// ID is the path of the artifact (of the model directory for MLflow) and.
+// Name the name it is registered under.
+const DiscoveryTypeTrackedArtifact = "tracked-artifact"
+
+// Discovery.ArtifactType values of "tracked-artifact" discoveries.
+const (
+	ArtifactTypeModel   = "model"
+	ArtifactTypeDataset = "dataset"
+)
+
+// mlmodelName is the name of the file describing an MLflow model.
+const mlmodelName = "mlmodel"
+
+// dvcArtifact is an entry of the artifacts section of a dvc.yaml.
+type dvcArtifact struct {
+	Path   string   `yaml:"path"`
+	Type   string   `yaml:"type"`
+	Desc   string   `yaml:"desc"`
+	Labels []string `yaml:"labels"`
+}
+
+// scanDVCYAML reports the artifacts declared in a dvc.yaml. Their digest and.
+// size come from the dvc.lock or .dvc file that tracks the same path (see.
+// dropTrackedPointers); stage outputs are reported from dvc.lock alone.
+func scanDVCYAML(path string) []Discovery {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var file struct {
+		Artifacts map[string]dvcArtifact `yaml:"artifacts"`
+	}
+	if yaml.Unmarshal(data, &file) != nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(file.Artifacts))
+	for name := range file.Artifacts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var results []Discovery
+	for _, name := range names {
+		a := file.Artifacts[name]
+		if strings.TrimSpace(a.Path) == "" {
+			continue
+		}
+		artifact := filepath.Join(filepath.Dir(path), filepath.FromSlash(a.Path))
+		typ, format, _ := classifyTrackedArtifact(artifact, 0, false)
+		artifactType := ArtifactTypeModel
+		switch strings.ToLower(strings.TrimSpace(a.Type)) {
+		case "dataset", "data":
+			artifactType = ArtifactTypeDataset
+		case "model":
+		default:
+			if typ == DiscoveryTypeDataFile {
+				artifactType = ArtifactTypeDataset
+			}
+		}
+		if format == "" {
+			format = strings.TrimPrefix(strings.ToLower(filepath.Ext(artifact)), ".")
+		}
+
+		evidence := "DVC artifact " + name + " in " + filepath.Base(path) + " (type " + artifactType + ")"
+		if desc := strings.TrimSpace(a.Desc); desc != "" {
+			evidence += ": " + desc
+		}
+		if len(a.Labels) > 0 {
+			evidence += " [" + strings.Join(a.Labels, ", ") + "]"
+		}
+		results = append(results, Discovery{
+			ID:           artifact,
+			Name:         name,
+			Type:         DiscoveryTypeTrackedArtifact,
+			Path:         artifact,
+			Evidence:     evidence,
+			Method:       "dvc_artifact",
+			Format:       format,
+			ArtifactType: artifactType,
+		})
+	}
+	return results
+}
+
+// mlmodel is the part of an MLflow MLmodel file the scanner reads.
+type mlmodel struct {
+	ArtifactPath   string                    `yaml:"artifact_path"`
+	Flavors        map[string]map[string]any `yaml:"flavors"`
+	MLflowVersion  string                    `yaml:"mlflow_version"`
+	ModelUUID      string                    `yaml:"model_uuid"`
+	ModelID        string                    `yaml:"model_id"`
+	RunID          string                    `yaml:"run_id"`
+	ModelSizeBytes int64                     `yaml:"model_size_bytes"`
+}
+
+// mlflowModelFileKeys are the flavor keys that name the file of the model.
+// inside its directory.
+var mlflowModelFileKeys = []string{"pickled_model", "model_path", "data", "model_data", "model_file"}
+
+// scanMLmodel reports the MLflow model described by the MLmodel file at path.
+// The model is named after its directory and its format is its flavor; the.
+// SHA-256 digest is that of the model file the flavor names, when present.
+func scanMLmodel(path string, hashOpts HashOptions) []Discovery {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var m mlmodel
+	if yaml.Unmarshal(data, &m) != nil || len(m.Flavors) == 0 {
+		return nil
+	}
+
+	dir := filepath.Dir(path)
+	flavor := mlflowFlavor(m.Flavors)
+	d := Discovery{
+		ID:           dir,
+		Name:         filepath.Base(dir),
+		Type:         DiscoveryTypeTrackedArtifact,
+		Path:         path,
+		Method:       "mlflow_model",
+		Format:       flavor,
+		Size:         m.ModelSizeBytes,
+		ArtifactType: ArtifactTypeModel,
+	}
+	if m.RunID != "" && m.ArtifactPath != "" {
+		d.StorageURI = "runs:/" + m.RunID + "/" + m.ArtifactPath
+	} else if m.ModelID != "" {
+		d.StorageURI = "models:/" + m.ModelID
+	}
+
+	evidence := "MLflow model (flavor " + flavor
+	if m.MLflowVersion != "" {
+		evidence += ", mlflow " + m.MLflowVersion
+	}
+	evidence += ")"
+	if m.RunID != "" {
+		evidence += " logged by run " + m.RunID
+	}
+	if m.ModelUUID != "" {
+		evidence += ", model_uuid " + m.ModelUUID
+	}
+	if file := mlflowModelFile(dir, m.Flavors); file != "" {
+		if sum, err := HashFile(file, hashOpts); err == nil {
+			d.Hash, d.BLAKE3 = sum.SHA256, sum.BLAKE3
+			evidence += ": sha256 of " + filepath.Base(file)
+			if d.Size == 0 {
+				if info, err := os.Stat(file); err == nil {
+					d.Size = info.Size()
+				}
+			}
+		}
+	}
+	if d.Size > 0 {
+		evidence += " (" + strconv.FormatInt(d.Size, 10) + " bytes)"
+	}
+	d.Evidence = evidence
+	return []Discovery{d}
+}
+
+// mlflowFlavor returns the flavor of an MLflow model: the framework flavor.
+// (sklearn, pytorch, transformers, ...) rather than the generic.
+// python_function one every model also has.
+func mlflowFlavor(flavors map[string]map[string]any) string {
+	names := make([]string, 0, len(flavors))
+	for name := range flavors {
+		if name != "python_function" {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return "python_function"
+	}
+	sort.Strings(names)
+	return names[0]
+}
+
+// mlflowModelFile returns the regular file of the model in the MLflow model.
+// directory dir, as named by its flavors, or "" when none is found.
+func mlflowModelFile(dir string, flavors map[string]map[string]any) string {
+	names := make([]string, 0, len(flavors))
+	for name := range flavors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, key := range mlflowModelFileKeys {
+		for _, name := range names {
+			rel, _ := flavors[name][key].(string)
+			if rel == "" || filepath.IsAbs(rel) || strings.HasPrefix(filepath.Clean(rel), "..") {
+				continue
+			}
+			file := filepath.Join(dir, filepath.FromSlash(rel))
+			if info, err := os.Stat(file); err == nil && info.Mode().IsRegular() {
+				return file
+			}
+		}
+	}
+	return ""
+}
+
+// dvcStorageURI returns the URI of the copy of an output in the DVC remote.
+// storage, or "" when no remote is configured for the DVC file at path.
+// Outputs of DVC 3 (hash: md5) are stored under files/md5/, older ones at.
+// the root of the remote; a directory is stored as its .dir listing.
+func dvcStorageURI(path string, out dvcOut) string {
+	remote := dvcRemoteURL(filepath.Dir(path), out.Remote)
+	if remote == "" || len(out.MD5) < 3 {
+		return ""
+	}
+	prefix := strings.TrimRight(remote, "/") + "/"
+	if out.Hash == "md5" {
+		prefix += "files/md5/"
+	}
+	return prefix + out.MD5[:2] + "/" + out.MD5[2:]
+}
+
+// dvcRemoteURL returns the url of the DVC remote called name, or of the.
+// default remote when name is "", from the .dvc/config of the DVC project.
+// that contains dir.
+func dvcRemoteURL(dir, name string) string {
+	for {
+		if f, err := os.Open(filepath.Join(dir, ".dvc", "config")); err == nil {
+			url := dvcConfigRemote(bufio.NewScanner(f), name)
+			f.Close()
+			return url
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// dvcConfigRemote reads a DVC config file (INI syntax) and returns the url.
+// of the remote called name, or of the core.remote default when name is "".
+func dvcConfigRemote(sc *bufio.Scanner, name string) string {
+	urls := make(map[string]string)
+	var section, defaultRemote string
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.Trim(line, "[]'\" ")
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		switch {
+		case section == "core" && key == "remote":
+			defaultRemote = value
+		case strings.HasPrefix(section, "remote ") && key == "url":
+			remote := strings.Trim(strings.TrimPrefix(section, "remote "), `"' `)
+			urls[remote] = value
+		}
+	}
+	if name == "" {
+		name = defaultRemote
+	}
+	return urls[name]
+}
+
+// dropTrackedPointers merges the DVC pointer discoveries ("model-file" and.
+// "data-file" from dvc.lock and .dvc files) of the artifacts declared in a.
+// dvc.yaml into the "tracked-artifact" discoveries of those artifacts: the.
+// artifact keeps its registered name and takes the digest, size and storage.
+// URI of the pointer.
+func dropTrackedPointers(discoveries []Discovery) []Discovery {
+	tracked := make(map[string]bool)
+	for _, d := range discoveries {
+		if d.Type == DiscoveryTypeTrackedArtifact && d.Method == "dvc_artifact" {
+			tracked[d.ID] = true
+		}
+	}
+	if len(tracked) == 0 {
+		return discoveries
+	}
+	pointers := make(map[string][]Discovery)
+	out := make([]Discovery, 0, len(discoveries))
+	for _, d := range discoveries {
+		if tracked[d.ID] && d.Method == "dvc_pointer" && (d.Type == DiscoveryTypeModelFile || d.Type == DiscoveryTypeDataFile) {
+			pointers[d.ID] = append(pointers[d.ID], d)
+			continue
+		}
+		out = append(out, d)
+	}
+	for i := range out {
+		a := &out[i]
+		if a.Type != DiscoveryTypeTrackedArtifact || a.Method != "dvc_artifact" {
+			continue
+		}
+		for _, p := range pointers[a.ID] {
+			if a.Hash == "" {
+				a.Hash, a.HashAlgorithm, a.Size, a.StorageURI = p.Hash, p.HashAlgorithm, p.Size, p.StorageURI
+				if p.Format != "" {
+					a.Format = p.Format
+				}
+			}
+			a.Evidence += ". " + p.Evidence
+		}
+	}
+	return out
+}
@@ -0,0 +1,104 @@
+package scanner
+
+import (
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DiscoveryTypeWeightURL is the Discovery.Type of model weights downloaded.
+// directly from object storage or a web server instead of the Hugging Face.
+// Hub. ID is the URL without its query string, Name the file name and Format.
+// the weight format implied by the extension.
+const DiscoveryTypeWeightURL = "weight-url"
+
+// weightURLExts maps the file extensions of weight URLs to a format name.
+var weightURLExts = map[string]string{
+	".safetensors": "safetensors",
+	".gguf":        "gguf",
+	".onnx":        "onnx",
+	".bin":         "pytorch",
+	".pt":          "pytorch",
+	".pth":         "pytorch",
+	".ckpt":        "checkpoint",
+	".h5":          "keras",
+	".tflite":      "tflite",
+}
+
+// weightURLRe matches object storage and HTTP(S) URLs ending in a weight.
+// file extension, optionally followed by a query string (signed URLs).
+var weightURLRe = regexp.MustCompile(`\b(s3|gs|az|abfss|wasbs|https?)://[^\s"'` + "`" + `<>()\[\]{}?#]+?(\.(?:safetensors|gguf|onnx|bin|pth?|ckpt|h5|tflite))(\?[^\s"'` + "`" + `<>()\[\]{}#]*)?(?:[\s"'` + "`" + `<>()\[\]{},;#]|$)`)
+
+// weightURLs returns a "weight-url" discovery for every weight URL in line.
+// Query strings may hold credentials (S3 presigned URLs, Azure SAS tokens),.
+// so they are dropped from the ID and redacted in the evidence.
+func weightURLs(line string, lineNum int, filePath, note string) []Discovery {
+	matches := weightURLRe.FindAllStringSubmatchIndex(line, -1)
+	if matches == nil {
+		return nil
+	}
+
+	evidence := line
+	for i := len(matches) - 1; i >= 0; i-- {
+		if qs, qe := matches[i][6], matches[i][7]; qs >= 0 && qe > qs+1 {
+			evidence = evidence[:qs] + "?REDACTED" + evidence[qe:]
+		}
+	}
+	evidence = "weight_url at line " + strconv.Itoa(lineNum) + ": " + strings.TrimSpace(evidence)
+	if note != "" {
+		evidence += " (" + note + ")"
+	}
+
+	var results []Discovery
+	for _, m := range matches {
+		scheme := line[m[2]:m[3]]
+		url := line[m[0]:m[5]]
+		// Meta's signed Llama downloads are reported as provider models.
+		if isLlamaDownloadURL(url) {
+			continue
+		}
+		results = append(results, Discovery{
+			ID:       url,
+			Name:     path.Base(url),
+			Type:     DiscoveryTypeWeightURL,
+			Path:     filePath,
+			Evidence: evidence,
+			Method:   "weight_url_" + weightURLProvider(scheme, url),
+			Format:   weightURLExts[strings.ToLower(line[m[4]:m[5]])],
+		})
+	}
+	return results
+}
+
+// weightURLProvider names the storage service a weight URL points at.
+func weightURLProvider(scheme, url string) string {
+	switch scheme {
+	case "s3":
+		return "s3"
+	case "gs":
+		return "gcs"
+	case "az", "abfss", "wasbs":
+		return "azure"
+	}
+	host := strings.TrimPrefix(strings.TrimPrefix(url, "https://"), "http://")
+	if i := strings.IndexByte(host, '/'); i >= 0 {
+		host = host[:i]
+	}
+	switch {
+	case strings.HasSuffix(host, ".amazonaws.com"):
+		return "s3"
+	case host == "storage.googleapis.com" || strings.HasSuffix(host, ".storage.googleapis.com"):
+		return "gcs"
+	case strings.HasSuffix(host, ".blob.core.windows.net"):
+		return "azure"
+	}
+	return "https"
+}
+
+// isLlamaDownloadURL reports whether url points at Meta's Llama downloads.
+func isLlamaDownloadURL(url string) bool {
+	host := strings.TrimPrefix(strings.TrimPrefix(url, "https://"), "http://")
+	host, _, _ = strings.Cut(host, "/")
+	return strings.HasSuffix(host, ".llamameta.net")
+}
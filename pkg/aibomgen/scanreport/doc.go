@@ -0,0 +1,13 @@
+// Package scanreport describes the outcome of a scan as a versioned JSON.
+// document for programmatic consumers (CI jobs, dashboards, policy engines).
+//.
+// The human output of the scan command changes as the UI evolves; the.
+// [Report] does not. Its keys are snake_case and always present (empty lists.
+// are written as [] and unset strings as ""), and [FormatVersion] names the.
+// schema: within a major version keys are only ever added, so consumers.
+// should ignore keys they do not know and check the major version before.
+// reading a report.
+//.
+// [Build] assembles a report from the discoveries of a scan and the BOMs.
+// written for them; [Report.Write] encodes it.
+package scanreport
@@ -0,0 +1,212 @@
+package scanreport
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/completeness"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/generator"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/scanner"
+)
+
+// FormatVersion is the schema version of the reports written by this.
+// package. The major version changes when a key is removed or changes.
+// meaning; the minor version when keys are added.
+const FormatVersion = "1.1"
+
+// Modes of a scan.
+const (
+	ModeModels      = "models"
+	ModeApplication = "application"
+	ModePerProject  = "per-project"
+)
+
+// Report is the machine-readable outcome of a scan.
+type Report struct {
+	FormatVersion string `json:"format_version"`
+	ToolVersion   string `json:"tool_version"`
+	// Input is the scanned directory.
+	Input string `json:"input"`
+	// Mode is ModeModels (one BOM per model), ModeApplication or.
+	// ModePerProject.
+	Mode string `json:"mode"`
+	// Discoveries are the model and dataset references found.
+	Discoveries []Discovery `json:"discoveries"`
+	// Parameterized are templated references that could not be resolved to.
+	// a model.
+	Parameterized []Discovery `json:"parameterized"`
+	// BOMs are the written BOMs, in the order they were written.
+	BOMs []BOM `json:"boms"`
+	// Failures are the model IDs whose BOM could not be built.
+	Failures []string `json:"failures"`
+}
+
+// Discovery is a reference found by the scanner.
+type Discovery struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	Path       string `json:"path"`
+	Evidence   string `json:"evidence"`
+	Method     string `json:"method"`
+	Confidence string `json:"confidence"`
+	Format     string `json:"format"`
+	Size       int64  `json:"size"`
+	Hash       string `json:"hash"`
+	BaseModel  string `json:"base_model"`
+	Version    string `json:"version"`
+	// Git history of the reference (scan --git-history), RFC 3339 times;.
+	// empty without history, the removal fields also while the reference is.
+	// still in the tree. Added in 1.1.
+	IntroducedCommit string `json:"introduced_commit"`
+	IntroducedAt     string `json:"introduced_at"`
+	RemovedCommit    string `json:"removed_commit"`
+	RemovedAt        string `json:"removed_at"`
+}
+
+// BOM is a written BOM.
+type BOM struct {
+	File         string `json:"file"`
+	Format       string `json:"format"`
+	SerialNumber string `json:"serial_number"`
+	Version      int    `json:"version"`
+	// Component is the metadata component of the BOM.
+	Component Component `json:"component"`
+	// DiscoveryID and DiscoveryType name the discovery the BOM was built.
+	// from (empty for application BOMs).
+	DiscoveryID   string `json:"discovery_id"`
+	DiscoveryType string `json:"discovery_type"`
+	// Models and Datasets count the model and dataset components.
+	Models   int `json:"models"`
+	Datasets int `json:"datasets"`
+	// Completeness is the completeness score of the BOM (0–1).
+	Completeness float64 `json:"completeness"`
+}
+
+// Component identifies the metadata component of a BOM.
+type Component struct {
+	BOMRef  string `json:"bom_ref"`
+	Type    string `json:"type"`
+	Group   string `json:"group"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	PURL    string `json:"purl"`
+}
+
+// Input is what a scan produced.
+type Input struct {
+	Path        string
+	Mode        string
+	ToolVersion string
+	// Discoveries are all references found, parameterized ones included.
+	Discoveries []scanner.Discovery
+	// BOMs are the generated BOMs; Files[i] is the file BOMs[i] was written.
+	// to. BOMs without a file are left out.
+	BOMs   []generator.DiscoveredBOM
+	Files  []string
+	Format string
+	// Failures are the model IDs whose BOM could not be built.
+	Failures []string
+}
+
+// Build assembles the report of a scan.
+func Build(in Input) Report {
+	r := Report{
+		FormatVersion: FormatVersion,
+		ToolVersion:   in.ToolVersion,
+		Input:         in.Path,
+		Mode:          in.Mode,
+		Discoveries:   []Discovery{},
+		Parameterized: []Discovery{},
+		BOMs:          []BOM{},
+		Failures:      []string{},
+	}
+	for _, d := range in.Discoveries {
+		if d.Type == scanner.DiscoveryTypeParameterized {
+			r.Parameterized = append(r.Parameterized, discovery(d))
+		} else {
+			r.Discoveries = append(r.Discoveries, discovery(d))
+		}
+	}
+	for i, file := range in.Files {
+		if i >= len(in.BOMs) {
+			break
+		}
+		r.BOMs = append(r.BOMs, bomEntry(in.BOMs[i], file, in.Format))
+	}
+	r.Failures = append(r.Failures, in.Failures...)
+	return r
+}
+
+// Write encodes r as indented JSON.
+func (r Report) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+func discovery(d scanner.Discovery) Discovery {
+	out := Discovery{
+		ID:         d.ID,
+		Name:       d.Name,
+		Type:       d.Type,
+		Path:       d.Path,
+		Evidence:   d.Evidence,
+		Method:     d.Method,
+		Confidence: d.Confidence,
+		Format:     d.Format,
+		Size:       d.Size,
+		Hash:       d.Hash,
+		BaseModel:  d.BaseModel,
+		Version:    d.Version,
+	}
+	if h := d.History; h != nil {
+		out.IntroducedCommit = h.IntroducedCommit
+		out.IntroducedAt = h.IntroducedAt.UTC().Format(time.RFC3339)
+		if h.RemovedCommit != "" {
+			out.RemovedCommit = h.RemovedCommit
+			out.RemovedAt = h.RemovedAt.UTC().Format(time.RFC3339)
+		}
+	}
+	return out
+}
+
+func bomEntry(d generator.DiscoveredBOM, file, format string) BOM {
+	b := BOM{
+		File:          file,
+		Format:        format,
+		DiscoveryID:   d.Discovery.ID,
+		DiscoveryType: d.Discovery.Type,
+	}
+	bom := d.BOM
+	if bom == nil {
+		return b
+	}
+	b.SerialNumber = bom.SerialNumber
+	b.Version = bom.Version
+	if bom.Metadata != nil && bom.Metadata.Component != nil {
+		c := bom.Metadata.Component
+		b.Component = Component{
+			BOMRef:  c.BOMRef,
+			Type:    string(c.Type),
+			Group:   c.Group,
+			Name:    c.Name,
+			Version: c.Version,
+			PURL:    c.PackageURL,
+		}
+	}
+	if bom.Components != nil {
+		for _, c := range *bom.Components {
+			switch c.Type {
+			case cdx.ComponentTypeMachineLearningModel:
+				b.Models++
+			case cdx.ComponentTypeData:
+				b.Datasets++
+			}
+		}
+	}
+	b.Completeness = completeness.Check(bom).Score
+	return b
+}
@@ -0,0 +1,126 @@
+package scanreport
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/generator"
+	"github.com/idlab-discover/aibomgen-cli/pkg/aibomgen/scanner"
+)
+
+func TestBuild(t *testing.T) {
+	bert := scanner.Discovery{ID: "google-bert/bert-base-uncased", Name: "google-bert/bert-base-uncased", Type: "huggingface", Path: "app/train.py", Evidence: "from_pretrained", Method: "python_from_pretrained"}
+	templated := scanner.Discovery{ID: "org/{size}", Name: "org/{size}", Type: scanner.DiscoveryTypeParameterized, Path: "app/serve.py"}
+	bom := &cdx.BOM{
+		SerialNumber: "urn:uuid:1",
+		Version:      2,
+		Metadata: &cdx.Metadata{Component: &cdx.Component{
+			BOMRef:     "pkg:huggingface/google-bert/bert-base-uncased",
+			Type:       cdx.ComponentTypeMachineLearningModel,
+			Name:       "bert-base-uncased",
+			Group:      "google-bert",
+			PackageURL: "pkg:huggingface/google-bert/bert-base-uncased",
+		}},
+		Components: &[]cdx.Component{{Type: cdx.ComponentTypeData, Name: "wikipedia"}, {Type: cdx.ComponentTypeData, Name: "bookcorpus"}},
+	}
+
+	r := Build(Input{
+		Path:        "/src/app",
+		Mode:        ModeModels,
+		ToolVersion: "v1.2.3",
+		Discoveries: []scanner.Discovery{bert, templated},
+		BOMs:        []generator.DiscoveredBOM{{Discovery: bert, BOM: bom}, {Discovery: bert, BOM: bom}},
+		Files:       []string{"dist/google-bert_bert-base-uncased_aibom.json"},
+		Format:      "json",
+		Failures:    []string{"org/gone"},
+	})
+
+	if r.FormatVersion != FormatVersion || len(r.Discoveries) != 1 || len(r.Parameterized) != 1 || r.Parameterized[0].ID != templated.ID {
+		t.Fatalf("report = %+v", r)
+	}
+	if len(r.BOMs) != 1 {
+		t.Fatalf("only written BOMs are reported, got %d", len(r.BOMs))
+	}
+	b := r.BOMs[0]
+	if b.SerialNumber != "urn:uuid:1" || b.Version != 2 || b.Component.Group != "google-bert" || b.DiscoveryID != bert.ID || b.Datasets != 2 || b.Models != 0 || b.Completeness <= 0 {
+		t.Errorf("bom entry = %+v", b)
+	}
+	if !reflect.DeepEqual(r.Failures, []string{"org/gone"}) {
+		t.Errorf("failures = %v", r.Failures)
+	}
+}
+
+func TestBuildHistory(t *testing.T) {
+	at := time.Date(2024, 3, 1, 12, 0, 0, 0, time.FixedZone("CET", 3600))
+	gone := scanner.Discovery{ID: "gpt2", Name: "gpt2", Type: "huggingface", History: &scanner.GitHistory{IntroducedCommit: "a1", IntroducedAt: at, RemovedCommit: "b2", RemovedAt: at.Add(time.Hour)}}
+	kept := scanner.Discovery{ID: "t5-small", Name: "t5-small", Type: "huggingface", History: &scanner.GitHistory{IntroducedCommit: "a1", IntroducedAt: at}}
+
+	r := Build(Input{Path: "/src", Mode: ModeModels, Discoveries: []scanner.Discovery{gone, kept}})
+	if d := r.Discoveries[0]; d.IntroducedCommit != "a1" || d.IntroducedAt != "2024-03-01T11:00:00Z" || d.RemovedCommit != "b2" || d.RemovedAt != "2024-03-01T12:00:00Z" {
+		t.Errorf("removed discovery = %+v", d)
+	}
+	if d := r.Discoveries[1]; d.IntroducedCommit != "a1" || d.RemovedCommit != "" || d.RemovedAt != "" {
+		t.Errorf("current discovery = %+v", d)
+	}
+}
+
+// TestWriteKeysAreStable guards the schema: renaming or dropping a key must.
+// come with a new major FormatVersion.
+func TestWriteKeysAreStable(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Build(Input{Path: "/src", Mode: ModeApplication}).Write(&buf); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if !strings.HasPrefix(FormatVersion, "1.") {
+		t.Fatalf("FormatVersion %s: update the key lists below", FormatVersion)
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	want := []string{"boms", "discoveries", "failures", "format_version", "input", "mode", "parameterized", "tool_version"}
+	if got := keys(doc); !reflect.DeepEqual(got, want) {
+		t.Errorf("report keys = %v, want %v", got, want)
+	}
+	for _, k := range []string{"boms", "discoveries", "failures", "parameterized"} {
+		if list, ok := doc[k].([]any); !ok || len(list) != 0 {
+			t.Errorf("%s = %v, want []", k, doc[k])
+		}
+	}
+
+	entry, _ := json.Marshal(BOM{})
+	var bomDoc map[string]any
+	_ = json.Unmarshal(entry, &bomDoc)
+	want = []string{"completeness", "component", "datasets", "discovery_id", "discovery_type", "file", "format", "models", "serial_number", "version"}
+	if got := keys(bomDoc); !reflect.DeepEqual(got, want) {
+		t.Errorf("bom keys = %v, want %v", got, want)
+	}
+	want = []string{"bom_ref", "group", "name", "purl", "type", "version"}
+	if got := keys(bomDoc["component"].(map[string]any)); !reflect.DeepEqual(got, want) {
+		t.Errorf("component keys = %v, want %v", got, want)
+	}
+
+	entry, _ = json.Marshal(Discovery{})
+	var discDoc map[string]any
+	_ = json.Unmarshal(entry, &discDoc)
+	want = []string{"base_model", "confidence", "evidence", "format", "hash", "id", "introduced_at", "introduced_commit", "method", "name", "path", "removed_at", "removed_commit", "size", "type", "version"}
+	if got := keys(discDoc); !reflect.DeepEqual(got, want) {
+		t.Errorf("discovery keys = %v, want %v", got, want)
+	}
+}
+
+func keys(m map[string]any) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
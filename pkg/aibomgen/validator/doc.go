@@ -4,4 +4,18 @@
 // spec version), delegates completeness scoring to the [completeness] package,.
 // and enforces optional thresholds via [ValidationOptions]. Results are.
 // returned as a [ValidationResult] that includes per-dataset breakdowns.
+//.
+// Opt-in lint checks add warnings: CheckModelCard for a missing model card,.
+// CheckDataConsent for training datasets without a consent or licensing basis.
+// and CheckAttribution for attribution-required licenses (CC-BY) without a.
+// recorded copyright notice or attribution statement. CheckSecurityContact.
+// adds an error for every production model (lifecycle phase operations).
+// without a security contact, and CheckMLConstraints for ML-BOM rules the.
+// schema cannot express (modelCard only on machine-learning-model components,.
+// dataset references that resolve).
+//.
+// [ValidateSchema] checks a document against the embedded official CycloneDX.
+// JSON schemas (1.2 to 1.6) and returns path-level [SchemaViolation] values;.
+// [ValidationResult.AddSchemaViolations] records them as errors. XML.
+// documents are refused with [ErrSchemaFormat].
 package validator
@@ -0,0 +1,122 @@
+package validator
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// schemaFS holds the official CycloneDX JSON schemas (1.2 to 1.6) and the.
+// SPDX license and JSF signature schemas they reference.
+//.
+//
+//go:embed schema/*.json
+var schemaFS embed.FS
+
+// ErrSchemaFormat is returned by [ValidateSchema] for documents that are not.
+// CycloneDX JSON.
+var ErrSchemaFormat = errors.New("only CycloneDX JSON documents can be checked against the schema")
+
+// SchemaViolation is a place where a document does not conform to the.
+// CycloneDX schema.
+type SchemaViolation struct {
+	Path    string // path of the offending value, e.g. components[0].type
+	Message string
+}
+
+func (v SchemaViolation) String() string {
+	return v.Path + ": " + v.Message
+}
+
+var (
+	schemaMu    sync.Mutex
+	schemaCache = map[cdx.SpecVersion]*gojsonschema.Schema{}
+
+	// indexSegmentRe matches the array indexes of a gojsonschema field path.
+	indexSegmentRe = regexp.MustCompile(`\.(\d+)\b`)
+)
+
+// loadSchema compiles the JSON schema of spec once.
+func loadSchema(spec cdx.SpecVersion) (*gojsonschema.Schema, error) {
+	schemaMu.Lock()
+	defer schemaMu.Unlock()
+	if s, ok := schemaCache[spec]; ok {
+		return s, nil
+	}
+	if spec < cdx.SpecVersion1_2 || spec > cdx.SpecVersion1_6 {
+		return nil, fmt.Errorf("no CycloneDX JSON schema for spec version %s (supported: 1.2 to 1.6)", spec)
+	}
+	main, err := schemaFS.ReadFile(fmt.Sprintf("schema/bom-%s.schema.json", spec))
+	if err != nil {
+		return nil, err
+	}
+	loader := gojsonschema.NewSchemaLoader()
+	loader.Draft = gojsonschema.Draft7
+	for _, name := range []string{"spdx.schema.json", "jsf-0.82.schema.json"} {
+		b, err := schemaFS.ReadFile("schema/" + name)
+		if err != nil {
+			return nil, err
+		}
+		if err := loader.AddSchemas(gojsonschema.NewBytesLoader(b)); err != nil {
+			return nil, fmt.Errorf("load %s: %w", name, err)
+		}
+	}
+	s, err := loader.Compile(gojsonschema.NewBytesLoader(main))
+	if err != nil {
+		return nil, fmt.Errorf("compile CycloneDX %s schema: %w", spec, err)
+	}
+	schemaCache[spec] = s
+	return s, nil
+}
+
+// ValidateSchema checks doc, a CycloneDX document in format, against the.
+// official JSON schema of spec and returns the violations found. Only JSON.
+// documents can be checked: decoding XML would drop what the schema is meant.
+// to catch, such as unknown elements, so XML is refused rather than reported.
+// valid. The error is only set when doc cannot be read, is XML, or spec has.
+// no JSON schema (versions before 1.2).
+func ValidateSchema(doc []byte, format cdx.BOMFileFormat, spec cdx.SpecVersion) ([]SchemaViolation, error) {
+	if format != cdx.BOMFileFormatJSON {
+		return nil, ErrSchemaFormat
+	}
+	schema, err := loadSchema(spec)
+	if err != nil {
+		return nil, err
+	}
+	res, err := schema.Validate(gojsonschema.NewBytesLoader(doc))
+	if err != nil {
+		return nil, fmt.Errorf("read BOM: %w", err)
+	}
+	var violations []SchemaViolation
+	for _, e := range res.Errors() {
+		path := schemaPath(e.Field())
+		violations = append(violations, SchemaViolation{Path: path, Message: strings.ReplaceAll(e.Description(), e.Field(), path)})
+	}
+	return violations, nil
+}
+
+// schemaPath turns a gojsonschema field path (components.0.type) into the.
+// path notation of the reports (components[0].type).
+func schemaPath(field string) string {
+	if field == "" || field == "(root)" {
+		return "(root)"
+	}
+	field = indexSegmentRe.ReplaceAllString("."+field, "[$1]")
+	return strings.TrimPrefix(field, ".")
+}
+
+// AddSchemaViolations records the schema violations of the document in r and.
+// marks it invalid when there are any.
+func (r *ValidationResult) AddSchemaViolations(spec cdx.SpecVersion, violations []SchemaViolation) {
+	r.SchemaViolations = append(r.SchemaViolations, violations...)
+	for _, v := range violations {
+		r.Valid = false
+		r.Errors = append(r.Errors, fmt.Sprintf("schema %s: %s", spec, v))
+	}
+}
@@ -0,0 +1,76 @@
+package validator
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+func encodeBOM(t *testing.T, bom *cdx.BOM, format cdx.BOMFileFormat, spec cdx.SpecVersion) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := cdx.NewBOMEncoder(&buf, format).EncodeVersion(bom, spec); err != nil {
+		t.Fatalf("encode BOM: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestValidateSchema(t *testing.T) {
+	bom := cdx.NewBOM()
+	bom.Metadata = &cdx.Metadata{Component: &cdx.Component{
+		BOMRef: "pkg:huggingface/org/model",
+		Type:   cdx.ComponentTypeMachineLearningModel,
+		Name:   "org/model",
+	}}
+	bom.Components = &[]cdx.Component{{BOMRef: "pkg:huggingface/datasets/org/data", Type: cdx.ComponentTypeData, Name: "org/data"}}
+
+	violations, err := ValidateSchema(encodeBOM(t, bom, cdx.BOMFileFormatJSON, cdx.SpecVersion1_6), cdx.BOMFileFormatJSON, cdx.SpecVersion1_6)
+	if err != nil {
+		t.Fatalf("ValidateSchema: %v", err)
+	}
+	if len(violations) != 0 {
+		t.Errorf("ValidateSchema = %v, want none", violations)
+	}
+
+	// XML is refused rather than reported valid.
+	xml := encodeBOM(t, bom, cdx.BOMFileFormatXML, cdx.SpecVersion1_6)
+	if _, err := ValidateSchema(xml, cdx.BOMFileFormatXML, cdx.SpecVersion1_6); !errors.Is(err, ErrSchemaFormat) {
+		t.Errorf("ValidateSchema(XML) err = %v, want ErrSchemaFormat", err)
+	}
+
+	// 1.4 has no machine-learning-model or data component types.
+	doc := encodeBOM(t, bom, cdx.BOMFileFormatJSON, cdx.SpecVersion1_6)
+	violations, err = ValidateSchema(doc, cdx.BOMFileFormatJSON, cdx.SpecVersion1_4)
+	if err != nil {
+		t.Fatalf("ValidateSchema(1.4): %v", err)
+	}
+	found := false
+	for _, v := range violations {
+		if v.Path == "components[0].type" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("ValidateSchema(1.4) = %v, want a violation at components[0].type", violations)
+	}
+
+	if _, err := ValidateSchema(doc, cdx.BOMFileFormatJSON, cdx.SpecVersion1_1); err == nil {
+		t.Error("ValidateSchema(1.1) succeeded, want an error")
+	}
+	if _, err := ValidateSchema([]byte("{"), cdx.BOMFileFormatJSON, cdx.SpecVersion1_6); err == nil {
+		t.Error("ValidateSchema(malformed JSON) succeeded, want an error")
+	}
+}
+
+func TestAddSchemaViolations(t *testing.T) {
+	res := ValidationResult{Valid: true}
+	res.AddSchemaViolations(cdx.SpecVersion1_6, []SchemaViolation{{Path: "components[0].type", Message: "invalid"}})
+	if res.Valid || len(res.Errors) != 1 || res.Errors[0] != "schema 1.6: components[0].type: invalid" {
+		t.Errorf("result = %+v", res)
+	}
+	if len(res.SchemaViolations) != 1 {
+		t.Errorf("SchemaViolations = %v", res.SchemaViolations)
+	}
+}
@@ -23,6 +23,10 @@ type ValidationResult struct {
 
 	// Dataset-specific results.
 	DatasetResults map[string]DatasetValidationResult // key is dataset name
+
+	// SchemaViolations are the CycloneDX schema violations added by.
+	// [ValidationResult.AddSchemaViolations].
+	SchemaViolations []SchemaViolation
 }
 
 // DatasetValidationResult holds validation results for a single dataset.
@@ -41,6 +45,14 @@ type ValidationOptions struct {
 	StrictMode           bool    // Fail if required fields missing
 	MinCompletenessScore float64 // Minimum acceptable score (0.0-1.0)
 	CheckModelCard       bool    // Validate model card fields
+	CheckDataConsent     bool    // Flag training datasets without a consent or licensing basis
+	CheckAttribution     bool    // Flag attribution-required licenses without recorded attribution text
+	CheckSecurityContact bool    // Fail production models without a security contact
+	CheckMLConstraints   bool    // Fail model cards outside ML components and dataset refs that do not resolve
+
+	// Profile overrides the completeness weights and required fields (nil:.
+	// the registry defaults).
+	Profile *completeness.Profile
 }
 
 // Validate checks the structural and completeness properties of bom.
@@ -72,7 +84,7 @@ func Validate(bom *cdx.BOM, opts ValidationOptions) ValidationResult {
 	validateSpecVersion(bom, &result)
 
 	// 4. Run completeness check (leverages existing package).
-	completenessResult := completeness.Check(bom)
+	completenessResult := completeness.CheckWithProfile(bom, opts.Profile)
 	result.ModelID = completenessResult.ModelID
 	result.CompletenessScore = completenessResult.Score
 	result.MissingRequired = completenessResult.MissingRequired
@@ -135,6 +147,26 @@ func Validate(bom *cdx.BOM, opts ValidationOptions) ValidationResult {
 		result.DatasetResults[dsName] = dsResult
 	}
 
+	// 9. Consent lint for the training datasets.
+	if opts.CheckDataConsent {
+		validateDataConsent(bom, &result)
+	}
+
+	// 10. Attribution lint for the model and its datasets.
+	if opts.CheckAttribution {
+		validateAttribution(bom, &result)
+	}
+
+	// 11. Security contact lint for production models.
+	if opts.CheckSecurityContact {
+		validateSecurityContact(bom, &result)
+	}
+
+	// 12. ML-BOM constraints the JSON schema does not express.
+	if opts.CheckMLConstraints {
+		validateMLConstraints(bom, &result)
+	}
+
 	return result
 }
 
@@ -181,3 +213,177 @@ func validateModelCard(bom *cdx.BOM, result *ValidationResult) {
 		result.Warnings = append(result.Warnings, "model parameters not present")
 	}
 }
+
+// validateDataConsent flags the training datasets that record no licensing or.
+// consent basis: no license, consent statement or opt-out mechanism. The.
+// training datasets are the data components the model card references, or.
+// every data component when it references none.
+func validateDataConsent(bom *cdx.BOM, result *ValidationResult) {
+	if bom.Components == nil {
+		return
+	}
+	refs := map[string]bool{}
+	if md := bom.Metadata; md != nil && md.Component != nil && md.Component.ModelCard != nil {
+		if mp := md.Component.ModelCard.ModelParameters; mp != nil && mp.Datasets != nil {
+			for _, d := range *mp.Datasets {
+				if d.Ref != "" {
+					refs[d.Ref] = true
+				}
+			}
+		}
+	}
+	for i := range *bom.Components {
+		ds := &(*bom.Components)[i]
+		if ds.Type != cdx.ComponentTypeData || (len(refs) > 0 && !refs[ds.BOMRef]) {
+			continue
+		}
+		if metadata.HasConsentBasis(ds) {
+			continue
+		}
+		name := ds.Name
+		if name == "" {
+			name = ds.BOMRef
+		}
+		msg := "training dataset has no consent or licensing basis (no license, consent statement or opt-out mechanism)"
+		result.Warnings = append(result.Warnings, fmt.Sprintf("dataset %s: %s", name, msg))
+		if dsResult, ok := result.DatasetResults[name]; ok {
+			dsResult.Warnings = append(dsResult.Warnings, msg)
+			result.DatasetResults[name] = dsResult
+		}
+	}
+}
+
+// validateAttribution flags the model and dataset components whose license.
+// requires attribution (CC-BY and its variants, ODC-By) but that record no.
+// copyright notice or attribution statement to give it with.
+func validateAttribution(bom *cdx.BOM, result *ValidationResult) {
+	const msg = "license %s requires attribution but no copyright notice or attribution statement is recorded"
+	if md := bom.Metadata; md != nil && md.Component != nil {
+		if lic := metadata.AttributionLicense(md.Component); lic != "" && !metadata.HasAttribution(md.Component) {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("model %s: "+msg, md.Component.Name, lic))
+		}
+	}
+	if bom.Components == nil {
+		return
+	}
+	for i := range *bom.Components {
+		ds := &(*bom.Components)[i]
+		if ds.Type != cdx.ComponentTypeData {
+			continue
+		}
+		lic := metadata.AttributionLicense(ds)
+		if lic == "" || metadata.HasAttribution(ds) {
+			continue
+		}
+		name := ds.Name
+		if name == "" {
+			name = ds.BOMRef
+		}
+		w := fmt.Sprintf(msg, lic)
+		result.Warnings = append(result.Warnings, fmt.Sprintf("dataset %s: %s", name, w))
+		if dsResult, ok := result.DatasetResults[name]; ok {
+			dsResult.Warnings = append(dsResult.Warnings, w)
+			result.DatasetResults[name] = dsResult
+		}
+	}
+}
+
+// validateSecurityContact fails the production models that name no security.
+// contact to report vulnerabilities or misuse to. A BOM describes production.
+// models when its lifecycle includes the operations phase; its models are the.
+// metadata component, or the model components of an application BOM.
+func validateSecurityContact(bom *cdx.BOM, result *ValidationResult) {
+	md := bom.Metadata
+	if md == nil || md.Component == nil || !inOperations(md) {
+		return
+	}
+	models := []*cdx.Component{md.Component}
+	if md.Component.Type != cdx.ComponentTypeMachineLearningModel {
+		models = nil
+		if bom.Components != nil {
+			for i := range *bom.Components {
+				if c := &(*bom.Components)[i]; c.Type == cdx.ComponentTypeMachineLearningModel {
+					models = append(models, c)
+				}
+			}
+		}
+	}
+	for _, c := range models {
+		if metadata.HasSecurityContact(c) {
+			continue
+		}
+		result.Valid = false
+		result.Errors = append(result.Errors, fmt.Sprintf("model %s: production model has no security contact (external reference of type security-contact)", c.Name))
+	}
+}
+
+// inOperations reports whether the lifecycle of a BOM includes the.
+// operations phase.
+func inOperations(md *cdx.Metadata) bool {
+	if md.Lifecycles == nil {
+		return false
+	}
+	for _, l := range *md.Lifecycles {
+		if l.Phase == cdx.LifecyclePhaseOperations {
+			return true
+		}
+	}
+	return false
+}
+
+// validateMLConstraints fails the ML-BOM rules the CycloneDX schema cannot.
+// express: only machine-learning-model components have a model card, and.
+// every dataset a model card references is a component of the BOM. A.
+// reference resolves by bom-ref, or as dataset:<name> (the form generate.
+// writes) by the name of a data component.
+func validateMLConstraints(bom *cdx.BOM, result *ValidationResult) {
+	refs := map[string]bool{}
+	var comps []*cdx.Component
+	var walk func(c *cdx.Component)
+	walk = func(c *cdx.Component) {
+		comps = append(comps, c)
+		if c.BOMRef != "" {
+			refs[c.BOMRef] = true
+		}
+		if c.Type == cdx.ComponentTypeData && c.Name != "" {
+			refs["dataset:"+c.Name] = true
+		}
+		if c.Components != nil {
+			for i := range *c.Components {
+				walk(&(*c.Components)[i])
+			}
+		}
+	}
+	if bom.Metadata != nil && bom.Metadata.Component != nil {
+		walk(bom.Metadata.Component)
+	}
+	if bom.Components != nil {
+		for i := range *bom.Components {
+			walk(&(*bom.Components)[i])
+		}
+	}
+
+	for _, c := range comps {
+		if c.ModelCard == nil {
+			continue
+		}
+		name := c.Name
+		if name == "" {
+			name = c.BOMRef
+		}
+		if c.Type != cdx.ComponentTypeMachineLearningModel {
+			result.Valid = false
+			result.Errors = append(result.Errors, fmt.Sprintf("component %s: modelCard on a %s component (only machine-learning-model components have a model card)", name, c.Type))
+		}
+		mp := c.ModelCard.ModelParameters
+		if mp == nil || mp.Datasets == nil {
+			continue
+		}
+		for _, d := range *mp.Datasets {
+			if d.Ref != "" && !refs[d.Ref] {
+				result.Valid = false
+				result.Errors = append(result.Errors, fmt.Sprintf("model %s: dataset reference %q does not resolve to a component", name, d.Ref))
+			}
+		}
+	}
+}
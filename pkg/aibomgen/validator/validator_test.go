@@ -70,7 +70,7 @@ func TestValidate(t *testing.T) {
 			},
 			wantValid:        true,
 			wantModelID:      "test-model",
-			wantScore:        1.0 / 12.15,
+			wantScore:        1.0 / 15.9,
 			wantErrorCount:   0,
 			wantDatasetCount: 0,
 		},
@@ -88,7 +88,7 @@ func TestValidate(t *testing.T) {
 			},
 			wantValid:        false,
 			wantModelID:      "test-model",
-			wantScore:        1.0 / 12.15,
+			wantScore:        1.0 / 15.9,
 			wantErrorCount:   1,
 			wantErrorContain: "BOM missing spec version",
 			wantDatasetCount: 0,
@@ -108,7 +108,7 @@ func TestValidate(t *testing.T) {
 			},
 			wantValid:        true,
 			wantModelID:      "test-model",
-			wantScore:        1.0 / 12.15,
+			wantScore:        1.0 / 15.9,
 			wantErrorCount:   0,
 			wantDatasetCount: 0,
 		},
@@ -150,9 +150,9 @@ func TestValidate(t *testing.T) {
 			},
 			wantValid:        false,
 			wantModelID:      "test-model",
-			wantScore:        1.0 / 12.15,
+			wantScore:        1.0 / 15.9,
 			wantErrorCount:   1,
-			wantErrorContain: "completeness score 0.08 below minimum 0.50",
+			wantErrorContain: "completeness score 0.06 below minimum 0.50",
 			wantDatasetCount: 0,
 		},
 		{
@@ -172,7 +172,7 @@ func TestValidate(t *testing.T) {
 			},
 			wantValid:        true,
 			wantModelID:      "test-model",
-			wantScore:        1.0 / 12.15,
+			wantScore:        1.0 / 15.9,
 			wantErrorCount:   0,
 			wantDatasetCount: 0,
 		},
@@ -194,7 +194,7 @@ func TestValidate(t *testing.T) {
 			},
 			wantValid:        true,
 			wantModelID:      "test-model",
-			wantScore:        1.0 / 12.15,
+			wantScore:        1.0 / 15.9,
 			wantErrorCount:   0,
 			wantDatasetCount: 0,
 		},
@@ -226,7 +226,7 @@ func TestValidate(t *testing.T) {
 			},
 			wantValid:        true,
 			wantModelID:      "test-model",
-			wantScore:        1.5 / 12.15,
+			wantScore:        1.5 / 15.9,
 			wantErrorCount:   0,
 			wantDatasetCount: 1,
 		},
@@ -260,7 +260,7 @@ func TestValidate(t *testing.T) {
 			},
 			wantValid:        true,
 			wantModelID:      "test-model",
-			wantScore:        1.5 / 12.15,
+			wantScore:        1.5 / 15.9,
 			wantErrorCount:   0,
 			wantDatasetCount: 1,
 		},
@@ -507,3 +507,139 @@ func Test_validateModelCard(t *testing.T) {
 		})
 	}
 }
+
+func Test_validateDataConsent(t *testing.T) {
+	bom := cdx.NewBOM()
+	bom.Metadata = &cdx.Metadata{Component: &cdx.Component{
+		Type: cdx.ComponentTypeMachineLearningModel,
+		Name: "org/model",
+		ModelCard: &cdx.MLModelCard{ModelParameters: &cdx.MLModelParameters{
+			Datasets: &[]cdx.MLDatasetChoice{{Ref: "dataset:licensed"}, {Ref: "dataset:consented"}, {Ref: "dataset:bare"}},
+		}},
+	}}
+	bom.Components = &[]cdx.Component{
+		{BOMRef: "dataset:licensed", Type: cdx.ComponentTypeData, Name: "licensed", Licenses: &cdx.Licenses{{License: &cdx.License{ID: "MIT"}}}},
+		{BOMRef: "dataset:consented", Type: cdx.ComponentTypeData, Name: "consented", Properties: &[]cdx.Property{{Name: "aibomgen:governance:consent", Value: "Participants consented."}}},
+		{BOMRef: "dataset:bare", Type: cdx.ComponentTypeData, Name: "bare"},
+		{BOMRef: "dataset:eval", Type: cdx.ComponentTypeData, Name: "eval"},
+	}
+
+	without := Validate(bom, ValidationOptions{})
+	with := Validate(bom, ValidationOptions{CheckDataConsent: true})
+	if got := len(with.Warnings) - len(without.Warnings); got != 1 {
+		t.Fatalf("consent warnings = %d, want 1 (warnings: %v)", got, with.Warnings)
+	}
+	last := with.Warnings[len(with.Warnings)-1]
+	if last != "dataset bare: training dataset has no consent or licensing basis (no license, consent statement or opt-out mechanism)" {
+		t.Errorf("warning = %q", last)
+	}
+	if !with.Valid {
+		t.Errorf("consent warnings made the BOM invalid: %v", with.Errors)
+	}
+}
+
+func Test_validateAttribution(t *testing.T) {
+	bom := cdx.NewBOM()
+	bom.Metadata = &cdx.Metadata{Component: &cdx.Component{
+		Type:     cdx.ComponentTypeMachineLearningModel,
+		Name:     "org/model",
+		Licenses: &cdx.Licenses{{License: &cdx.License{Name: "cc-by-4.0"}}},
+	}}
+	bom.Components = &[]cdx.Component{
+		{BOMRef: "dataset:credited", Type: cdx.ComponentTypeData, Name: "credited", Licenses: &cdx.Licenses{{License: &cdx.License{ID: "CC-BY-SA-4.0"}}}, Copyright: "Copyright 2020 Example Lab"},
+		{BOMRef: "dataset:uncredited", Type: cdx.ComponentTypeData, Name: "uncredited", Licenses: &cdx.Licenses{{License: &cdx.License{ID: "CC-BY-NC-4.0"}}}},
+		{BOMRef: "dataset:mit", Type: cdx.ComponentTypeData, Name: "mit", Licenses: &cdx.Licenses{{License: &cdx.License{ID: "MIT"}}}},
+	}
+
+	without := Validate(bom, ValidationOptions{})
+	with := Validate(bom, ValidationOptions{CheckAttribution: true})
+	added := with.Warnings[len(without.Warnings):]
+	want := []string{
+		"model org/model: license cc-by-4.0 requires attribution but no copyright notice or attribution statement is recorded",
+		"dataset uncredited: license CC-BY-NC-4.0 requires attribution but no copyright notice or attribution statement is recorded",
+	}
+	if len(added) != len(want) {
+		t.Fatalf("attribution warnings = %v, want %v", added, want)
+	}
+	for i := range want {
+		if added[i] != want[i] {
+			t.Errorf("warning %d = %q, want %q", i, added[i], want[i])
+		}
+	}
+	if !with.Valid {
+		t.Errorf("attribution warnings made the BOM invalid: %v", with.Errors)
+	}
+}
+
+func Test_validateSecurityContact(t *testing.T) {
+	bom := cdx.NewBOM()
+	bom.Metadata = &cdx.Metadata{Component: &cdx.Component{
+		Type: cdx.ComponentTypeMachineLearningModel,
+		Name: "org/model",
+	}}
+	opts := ValidationOptions{CheckSecurityContact: true}
+
+	// Outside the operations phase the rule does not apply.
+	if res := Validate(bom, opts); !res.Valid {
+		t.Fatalf("design BOM failed: %v", res.Errors)
+	}
+
+	bom.Metadata.Lifecycles = &[]cdx.Lifecycle{{Phase: cdx.LifecyclePhaseOperations}}
+	res := Validate(bom, opts)
+	if res.Valid || len(res.Errors) != 1 || res.Errors[0] != "model org/model: production model has no security contact (external reference of type security-contact)" {
+		t.Fatalf("production BOM without security contact: valid = %v, errors = %v", res.Valid, res.Errors)
+	}
+
+	bom.Metadata.Component.ExternalReferences = &[]cdx.ExternalReference{{Type: cdx.ERTypeSecurityContact, URL: "mailto:security@example.org"}}
+	if res := Validate(bom, opts); !res.Valid {
+		t.Errorf("production BOM with security contact failed: %v", res.Errors)
+	}
+
+	// An application BOM checks its model components.
+	bom.Metadata.Component = &cdx.Component{Type: cdx.ComponentTypeApplication, Name: "app"}
+	bom.Components = &[]cdx.Component{
+		{Type: cdx.ComponentTypeMachineLearningModel, Name: "org/a", ExternalReferences: &[]cdx.ExternalReference{{Type: cdx.ERTypeSecurityContact, URL: "https://example.org/security"}}},
+		{Type: cdx.ComponentTypeMachineLearningModel, Name: "org/b"},
+	}
+	res = Validate(bom, opts)
+	if len(res.Errors) != 1 || res.Errors[0] != "model org/b: production model has no security contact (external reference of type security-contact)" {
+		t.Errorf("application BOM errors = %v", res.Errors)
+	}
+}
+
+func Test_validateMLConstraints(t *testing.T) {
+	bom := cdx.NewBOM()
+	bom.Metadata = &cdx.Metadata{Component: &cdx.Component{
+		Type: cdx.ComponentTypeMachineLearningModel,
+		Name: "org/model",
+		ModelCard: &cdx.MLModelCard{ModelParameters: &cdx.MLModelParameters{Datasets: &[]cdx.MLDatasetChoice{
+			{Ref: "pkg:huggingface/datasets/org/data@abc"},
+			{Ref: "dataset:wikipedia"},
+		}}},
+	}}
+	bom.Components = &[]cdx.Component{
+		{Type: cdx.ComponentTypeData, BOMRef: "pkg:huggingface/datasets/org/data@abc", Name: "org/data"},
+		{Type: cdx.ComponentTypeData, BOMRef: "pkg:huggingface/datasets/wikipedia@abc", Name: "wikipedia"},
+	}
+	opts := ValidationOptions{CheckMLConstraints: true}
+
+	if res := Validate(bom, opts); !res.Valid {
+		t.Fatalf("valid ML-BOM failed: %v", res.Errors)
+	}
+
+	(*bom.Components)[1].Name = "bookcorpus"
+	(*bom.Components)[0].ModelCard = &cdx.MLModelCard{}
+	res := Validate(bom, opts)
+	want := []string{
+		`model org/model: dataset reference "dataset:wikipedia" does not resolve to a component`,
+		"component org/data: modelCard on a data component (only machine-learning-model components have a model card)",
+	}
+	if res.Valid || len(res.Errors) != len(want) {
+		t.Fatalf("valid = %v, errors = %v, want %v", res.Valid, res.Errors, want)
+	}
+	for i := range want {
+		if res.Errors[i] != want[i] {
+			t.Errorf("error %d = %q, want %q", i, res.Errors[i], want[i])
+		}
+	}
+}